@@ -0,0 +1,447 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/registry"
+
+	// Import server to auto-register all integrations, components, and triggers via init()
+	_ "github.com/superplanehq/superplane/pkg/server"
+)
+
+/*
+ * Exports a JSON Schema and a Markdown page for every integration,
+ * component and trigger in the registry:
+ *
+ *   go run ./cmd/schema-export -out schemas
+ *
+ * With -check, nothing is written, and the command fails
+ * if the files in the output directory are not up to date.
+ */
+func main() {
+	out := flag.String("out", "schemas", "output directory")
+	check := flag.Bool("check", false, "fail if the output directory is not up to date, instead of writing it")
+	flag.Parse()
+
+	reg, err := registry.NewRegistry(crypto.NewNoOpEncryptor(), registry.HTTPOptions{})
+	if err != nil {
+		exitWithError(err)
+	}
+
+	files, err := export(reg)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if *check {
+		drift, err := findDrift(*out, files)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if len(drift) > 0 {
+			fmt.Fprintf(os.Stderr, "schemas in %s are not up to date, run go run ./cmd/schema-export -out %s:\n", *out, *out)
+			for _, path := range drift {
+				fmt.Fprintf(os.Stderr, "  %s\n", path)
+			}
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if err := write(*out, files); err != nil {
+		exitWithError(err)
+	}
+}
+
+type indexEntry struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Integration string `json:"integration,omitempty"`
+	Schema      string `json:"schema"`
+	Docs        string `json:"docs"`
+}
+
+type outputChannel struct {
+	Name        string `json:"name"`
+	Label       string `json:"label,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type action struct {
+	Name           string         `json:"name"`
+	Description    string         `json:"description,omitempty"`
+	UserAccessible bool           `json:"userAccessible"`
+	Parameters     map[string]any `json:"parameters"`
+}
+
+/*
+ * Returns the content of every exported file, by path relative to the output directory.
+ */
+func export(reg *registry.Registry) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	index := []indexEntry{}
+
+	add := func(kind, name, label, integration string, schema map[string]any, docs string) error {
+		entry := indexEntry{
+			Kind:        kind,
+			Name:        name,
+			Label:       label,
+			Integration: integration,
+			Schema:      filepath.ToSlash(filepath.Join(kind+"s", name+".schema.json")),
+			Docs:        filepath.ToSlash(filepath.Join(kind+"s", name+".md")),
+		}
+
+		data, err := encodeJSON(schema)
+		if err != nil {
+			return fmt.Errorf("error encoding schema for %s: %v", name, err)
+		}
+
+		files[entry.Schema] = data
+		files[entry.Docs] = []byte(docs)
+		index = append(index, entry)
+		return nil
+	}
+
+	for _, component := range reg.ListComponents() {
+		if err := add("component", component.Name(), component.Label(), "", componentSchema(component), componentDocs(component)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, trigger := range reg.ListTriggers() {
+		if err := add("trigger", trigger.Name(), trigger.Label(), "", triggerSchema(trigger), triggerDocs(trigger)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, integration := range reg.ListIntegrations() {
+		if err := add("integration", integration.Name(), integration.Label(), "", integrationSchema(integration), integrationDocs(integration)); err != nil {
+			return nil, err
+		}
+
+		for _, component := range integration.Components() {
+			if err := add("component", component.Name(), component.Label(), integration.Name(), componentSchema(component), componentDocs(component)); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, trigger := range integration.Triggers() {
+			if err := add("trigger", trigger.Name(), trigger.Label(), integration.Name(), triggerSchema(trigger), triggerDocs(trigger)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(index, func(i, j int) bool {
+		if index[i].Kind != index[j].Kind {
+			return index[i].Kind < index[j].Kind
+		}
+
+		return index[i].Name < index[j].Name
+	})
+
+	data, err := encodeJSON(index)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding index: %v", err)
+	}
+
+	files["index.json"] = data
+	return files, nil
+}
+
+func baseSchema(title, description string, fields []configuration.Field, extensions map[string]any) map[string]any {
+	schema := configuration.JSONSchema(fields)
+	schema["$schema"] = configuration.JSONSchemaDraft
+	schema["title"] = title
+	if description != "" {
+		schema["description"] = description
+	}
+
+	schema["x-superplane"] = extensions
+	return schema
+}
+
+func componentSchema(component core.Component) map[string]any {
+	channels := []outputChannel{}
+	for _, channel := range component.OutputChannels(nil) {
+		channels = append(channels, outputChannel(channel))
+	}
+
+	return baseSchema(component.Label(), component.Description(), component.Configuration(), map[string]any{
+		"kind":           "component",
+		"name":           component.Name(),
+		"icon":           component.Icon(),
+		"color":          component.Color(),
+		"outputChannels": channels,
+		"actions":        actions(component.Actions()),
+		"exampleOutput":  component.ExampleOutput(),
+	})
+}
+
+func triggerSchema(trigger core.Trigger) map[string]any {
+	return baseSchema(trigger.Label(), trigger.Description(), trigger.Configuration(), map[string]any{
+		"kind":        "trigger",
+		"name":        trigger.Name(),
+		"icon":        trigger.Icon(),
+		"color":       trigger.Color(),
+		"actions":     actions(trigger.Actions()),
+		"exampleData": trigger.ExampleData(),
+	})
+}
+
+func integrationSchema(integration core.Integration) map[string]any {
+	components := []string{}
+	for _, component := range integration.Components() {
+		components = append(components, component.Name())
+	}
+
+	triggers := []string{}
+	for _, trigger := range integration.Triggers() {
+		triggers = append(triggers, trigger.Name())
+	}
+
+	sort.Strings(components)
+	sort.Strings(triggers)
+
+	return baseSchema(integration.Label(), integration.Description(), integration.Configuration(), map[string]any{
+		"kind":       "integration",
+		"name":       integration.Name(),
+		"icon":       integration.Icon(),
+		"components": components,
+		"triggers":   triggers,
+		"actions":    actions(integration.Actions()),
+	})
+}
+
+func actions(in []core.Action) []action {
+	out := []action{}
+	for _, a := range in {
+		out = append(out, action{
+			Name:           a.Name,
+			Description:    a.Description,
+			UserAccessible: a.UserAccessible,
+			Parameters:     configuration.JSONSchema(a.Parameters),
+		})
+	}
+
+	return out
+}
+
+func componentDocs(component core.Component) string {
+	var buf bytes.Buffer
+	writeHeader(&buf, component.Label(), component.Name(), component.Description())
+	writeFields(&buf, "Configuration", component.Configuration())
+
+	channels := component.OutputChannels(nil)
+	if len(channels) > 0 {
+		buf.WriteString("## Output channels\n\n")
+		for _, channel := range channels {
+			buf.WriteString(fmt.Sprintf("- `%s`", channel.Name))
+			if channel.Description != "" {
+				buf.WriteString(": " + channel.Description)
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	writeActions(&buf, component.Actions())
+	writeExample(&buf, "Example output", component.ExampleOutput())
+	return buf.String()
+}
+
+func triggerDocs(trigger core.Trigger) string {
+	var buf bytes.Buffer
+	writeHeader(&buf, trigger.Label(), trigger.Name(), trigger.Description())
+	writeFields(&buf, "Configuration", trigger.Configuration())
+	writeActions(&buf, trigger.Actions())
+	writeExample(&buf, "Example data", trigger.ExampleData())
+	return buf.String()
+}
+
+func integrationDocs(integration core.Integration) string {
+	var buf bytes.Buffer
+	writeHeader(&buf, integration.Label(), integration.Name(), integration.Description())
+	writeFields(&buf, "Configuration", integration.Configuration())
+	writeActions(&buf, integration.Actions())
+	return buf.String()
+}
+
+func writeHeader(buf *bytes.Buffer, label, name, description string) {
+	buf.WriteString(fmt.Sprintf("# %s\n\n", label))
+	buf.WriteString(fmt.Sprintf("`%s`\n\n", name))
+	if description != "" {
+		buf.WriteString(description + "\n\n")
+	}
+}
+
+func writeFields(buf *bytes.Buffer, title string, fields []configuration.Field) {
+	if len(fields) == 0 {
+		return
+	}
+
+	buf.WriteString(fmt.Sprintf("## %s\n\n", title))
+	buf.WriteString("| Name | Type | Required | Expressions | Default | Description |\n")
+	buf.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, field := range fields {
+		buf.WriteString(fmt.Sprintf(
+			"| `%s` | %s | %s | %s | %s | %s |\n",
+			field.Name,
+			field.Type,
+			yesNo(field.Required),
+			yesNo(!field.DisallowExpression),
+			tableCell(defaultValue(field.Default)),
+			tableCell(field.Description),
+		))
+	}
+	buf.WriteString("\n")
+}
+
+func writeActions(buf *bytes.Buffer, actions []core.Action) {
+	if len(actions) == 0 {
+		return
+	}
+
+	buf.WriteString("## Actions\n\n")
+	for _, action := range actions {
+		buf.WriteString(fmt.Sprintf("- `%s`", action.Name))
+		if action.Description != "" {
+			buf.WriteString(": " + action.Description)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+}
+
+func writeExample(buf *bytes.Buffer, title string, example map[string]any) {
+	if len(example) == 0 {
+		return
+	}
+
+	data, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		return
+	}
+
+	buf.WriteString(fmt.Sprintf("## %s\n\n", title))
+	buf.WriteString("```json\n")
+	buf.Write(data)
+	buf.WriteString("\n```\n")
+}
+
+func defaultValue(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+
+	return "`" + string(data) + "`"
+}
+
+func tableCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func encodeJSON(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func write(out string, files map[string][]byte) error {
+	if err := os.RemoveAll(out); err != nil {
+		return err
+	}
+
+	for path, data := range files {
+		fullPath := filepath.Join(out, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(fullPath, data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Returns the paths that are missing, different or no longer exported.
+ */
+func findDrift(out string, files map[string][]byte) ([]string, error) {
+	drift := []string{}
+	for path, data := range files {
+		existing, err := os.ReadFile(filepath.Join(out, path))
+		if err != nil || !bytes.Equal(existing, data) {
+			drift = append(drift, path)
+		}
+	}
+
+	err := filepath.WalkDir(out, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(out, path)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := files[filepath.ToSlash(relative)]; !ok {
+			drift = append(drift, filepath.ToSlash(relative))
+		}
+
+		return nil
+	})
+
+	sort.Strings(drift)
+	return drift, err
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS canvas_key_values (
+  canvas_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+  key TEXT NOT NULL,
+  value JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (canvas_id, key)
+);
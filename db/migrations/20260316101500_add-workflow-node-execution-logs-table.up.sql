@@ -0,0 +1,16 @@
+--
+-- Logs are written outside of the transaction running the execution,
+-- while the execution row is locked, so there is no foreign key to workflow_node_executions.
+-- They are deleted together with the other node resources by the canvas cleanup worker.
+--
+CREATE TABLE IF NOT EXISTS workflow_node_execution_logs (
+  id BIGSERIAL PRIMARY KEY,
+  workflow_id UUID NOT NULL,
+  node_id CHARACTER VARYING(128) NOT NULL,
+  execution_id UUID NOT NULL,
+  message TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_node_execution_logs_execution ON workflow_node_execution_logs (execution_id, id);
+CREATE INDEX IF NOT EXISTS idx_workflow_node_execution_logs_workflow_node ON workflow_node_execution_logs (workflow_id, node_id);
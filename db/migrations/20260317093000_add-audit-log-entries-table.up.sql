@@ -0,0 +1,19 @@
+--
+-- Entries are kept when the canvas, node or execution they refer to is deleted,
+-- so there are no foreign keys, and they are not removed by the cleanup workers.
+--
+CREATE TABLE IF NOT EXISTS audit_log_entries (
+  id BIGSERIAL PRIMARY KEY,
+  organization_id UUID NOT NULL,
+  user_id UUID,
+  workflow_id UUID,
+  node_id CHARACTER VARYING(128),
+  execution_id UUID,
+  action CHARACTER VARYING(64) NOT NULL,
+  details JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entries_organization ON audit_log_entries (organization_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entries_workflow ON audit_log_entries (workflow_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entries_execution ON audit_log_entries (execution_id);
@@ -0,0 +1 @@
+ALTER TABLE app_installations ADD COLUMN health_checked_at TIMESTAMP;
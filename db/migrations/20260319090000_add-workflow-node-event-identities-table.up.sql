@@ -0,0 +1,15 @@
+--
+-- Identities of the events emitted by trigger nodes, e.g. the id of an EventBridge event,
+-- used to discard redeliveries of the same event within the node deduplication window.
+--
+CREATE TABLE IF NOT EXISTS workflow_node_event_identities (
+  workflow_id UUID NOT NULL,
+  node_id CHARACTER VARYING(128) NOT NULL,
+  identity CHARACTER VARYING(256) NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (workflow_id, node_id, identity),
+  FOREIGN KEY (workflow_id, node_id) REFERENCES workflow_nodes(workflow_id, node_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_node_event_identities_expires_at ON workflow_node_event_identities (expires_at);
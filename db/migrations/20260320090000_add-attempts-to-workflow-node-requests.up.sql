@@ -0,0 +1,6 @@
+--
+-- Failed scheduled actions are retried with a backoff,
+-- keeping the number of failed attempts and the last error for debugging.
+--
+ALTER TABLE workflow_node_requests ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workflow_node_requests ADD COLUMN last_error TEXT;
@@ -0,0 +1,21 @@
+--
+-- Raw inbound webhook and push requests received for integrations,
+-- kept for a retention window to inspect and replay them.
+--
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  organization_id UUID NOT NULL,
+  app_installation_id UUID NOT NULL REFERENCES app_installations(id) ON DELETE CASCADE,
+  webhook_id UUID,
+  replay_of UUID,
+  method CHARACTER VARYING(16) NOT NULL,
+  url TEXT NOT NULL,
+  headers JSONB NOT NULL DEFAULT '{}'::jsonb,
+  body BYTEA,
+  status_code INTEGER NOT NULL,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_app_installation ON webhook_deliveries (app_installation_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
@@ -0,0 +1,17 @@
+--
+-- Cost hints reported by components for their executions,
+-- e.g. the monthly estimate of a VM created by an execution.
+--
+CREATE TABLE IF NOT EXISTS workflow_node_execution_costs (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  workflow_id UUID NOT NULL,
+  node_id CHARACTER VARYING(128) NOT NULL,
+  execution_id UUID NOT NULL,
+  amount DOUBLE PRECISION NOT NULL,
+  unit CHARACTER VARYING(32) NOT NULL,
+  description TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_node_execution_costs_execution ON workflow_node_execution_costs (execution_id);
+CREATE INDEX IF NOT EXISTS idx_workflow_node_execution_costs_workflow ON workflow_node_execution_costs (workflow_id, created_at);
@@ -0,0 +1,5 @@
+--
+-- Canvases in test mode run integration components without
+-- changing external systems, emitting example outputs instead.
+--
+ALTER TABLE workflows ADD COLUMN test_mode BOOLEAN NOT NULL DEFAULT false;
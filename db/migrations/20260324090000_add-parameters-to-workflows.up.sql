@@ -0,0 +1,7 @@
+--
+-- Canvas parameters can be referenced from node configurations
+-- with {{ params.<name> }}. The environment of the canvas selects
+-- which of the per-environment overrides of the parameters is used.
+--
+ALTER TABLE workflows ADD COLUMN parameters jsonb NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE workflows ADD COLUMN environment character varying(64) NOT NULL DEFAULT '';
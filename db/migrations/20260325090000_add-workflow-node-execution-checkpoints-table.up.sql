@@ -0,0 +1,18 @@
+--
+-- Resumable steps of executions, e.g. the cloud operation
+-- an execution is waiting on. Written with their own connection,
+-- so they survive a restart that rolls back the execution transaction.
+--
+-- No foreign key to workflow_node_executions: the execution row is locked
+-- by the transaction running it while the checkpoint is written.
+--
+CREATE TABLE IF NOT EXISTS workflow_node_execution_checkpoints (
+  execution_id UUID NOT NULL PRIMARY KEY,
+  workflow_id UUID NOT NULL,
+  node_id CHARACTER VARYING(128) NOT NULL,
+  step CHARACTER VARYING(128) NOT NULL,
+  operation TEXT NOT NULL,
+  target JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
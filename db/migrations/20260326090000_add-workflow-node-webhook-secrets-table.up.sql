@@ -0,0 +1,18 @@
+--
+-- Webhook secrets of each node. Webhooks can be shared by several nodes,
+-- so the secret of the webhook is known to all of them. These are not:
+-- a request authenticated with one only reaches its node.
+--
+-- During a rotation, the previous secret is still accepted until it expires.
+--
+CREATE TABLE IF NOT EXISTS workflow_node_webhook_secrets (
+  workflow_id UUID NOT NULL,
+  node_id CHARACTER VARYING(128) NOT NULL,
+  secret BYTEA NOT NULL,
+  previous_secret BYTEA,
+  previous_secret_expires_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (workflow_id, node_id),
+  FOREIGN KEY (workflow_id, node_id) REFERENCES workflow_nodes(workflow_id, node_id) ON DELETE CASCADE
+);
@@ -0,0 +1,27 @@
+--
+-- Backfills replay the historical events of a trigger,
+-- through the whole canvas, or through a single node.
+-- Events are replayed in batches, at most rate_per_minute per minute,
+-- and last_event_at/last_event_id is the position of the last one replayed.
+--
+CREATE TABLE IF NOT EXISTS workflow_backfills (
+  id UUID NOT NULL PRIMARY KEY DEFAULT uuid_generate_v4(),
+  workflow_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+  trigger_node_id CHARACTER VARYING(128) NOT NULL,
+  target_node_id CHARACTER VARYING(128),
+  since TIMESTAMP NOT NULL,
+  until TIMESTAMP NOT NULL,
+  rate_per_minute INTEGER NOT NULL,
+  state CHARACTER VARYING(32) NOT NULL,
+  last_event_at TIMESTAMP,
+  last_event_id UUID,
+  replayed_count INTEGER NOT NULL DEFAULT 0,
+  skipped_count INTEGER NOT NULL DEFAULT 0,
+  next_run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  created_by UUID,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_backfills_workflow ON workflow_backfills(workflow_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_workflow_backfills_state ON workflow_backfills(state, next_run_at);
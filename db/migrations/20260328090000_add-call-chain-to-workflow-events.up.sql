@@ -0,0 +1,6 @@
+--
+-- Canvases that called the canvas of a run, outermost first,
+-- set on the root event of runs started by the Call Canvas component,
+-- so calls that would loop back to a canvas in the chain are rejected.
+--
+ALTER TABLE workflow_events ADD COLUMN IF NOT EXISTS call_chain JSONB NOT NULL DEFAULT '[]'::jsonb;
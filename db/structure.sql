@@ -146,10 +146,47 @@ CREATE TABLE public.app_installations (
     browser_action jsonb,
     created_at timestamp without time zone NOT NULL,
     updated_at timestamp without time zone NOT NULL,
-    deleted_at timestamp with time zone
+    deleted_at timestamp with time zone,
+    health_checked_at timestamp without time zone
 );
 
 
+--
+-- Name: audit_log_entries; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.audit_log_entries (
+    id bigint NOT NULL,
+    organization_id uuid NOT NULL,
+    user_id uuid,
+    workflow_id uuid,
+    node_id character varying(128),
+    execution_id uuid,
+    action character varying(64) NOT NULL,
+    details jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: audit_log_entries_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.audit_log_entries_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: audit_log_entries_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.audit_log_entries_id_seq OWNED BY public.audit_log_entries.id;
+
+
 --
 -- Name: blueprints; Type: TABLE; Schema: public; Owner: -
 --
@@ -171,6 +208,19 @@ CREATE TABLE public.blueprints (
 );
 
 
+--
+-- Name: canvas_key_values; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.canvas_key_values (
+    canvas_id uuid NOT NULL,
+    key text NOT NULL,
+    value jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
 --
 -- Name: canvas_memories; Type: TABLE; Schema: public; Owner: -
 --
@@ -409,6 +459,26 @@ CREATE TABLE public.users (
 );
 
 
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.webhook_deliveries (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    organization_id uuid NOT NULL,
+    app_installation_id uuid NOT NULL,
+    webhook_id uuid,
+    replay_of uuid,
+    method character varying(16) NOT NULL,
+    url text NOT NULL,
+    headers jsonb DEFAULT '{}'::jsonb NOT NULL,
+    body bytea,
+    status_code integer NOT NULL,
+    error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
 --
 -- Name: webhooks; Type: TABLE; Schema: public; Owner: -
 --
@@ -428,6 +498,30 @@ CREATE TABLE public.webhooks (
 );
 
 
+--
+-- Name: workflow_backfills; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.workflow_backfills (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    workflow_id uuid NOT NULL,
+    trigger_node_id character varying(128) NOT NULL,
+    target_node_id character varying(128),
+    since timestamp without time zone NOT NULL,
+    until timestamp without time zone NOT NULL,
+    rate_per_minute integer NOT NULL,
+    state character varying(32) NOT NULL,
+    last_event_at timestamp without time zone,
+    last_event_id uuid,
+    replayed_count integer DEFAULT 0 NOT NULL,
+    skipped_count integer DEFAULT 0 NOT NULL,
+    next_run_at timestamp without time zone DEFAULT now() NOT NULL,
+    created_by uuid,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+
 --
 -- Name: workflow_change_request_approvals; Type: TABLE; Schema: public; Owner: -
 --
@@ -482,7 +576,53 @@ CREATE TABLE public.workflow_events (
     state character varying(32) NOT NULL,
     execution_id uuid,
     created_at timestamp without time zone NOT NULL,
-    custom_name text
+    custom_name text,
+    call_chain jsonb DEFAULT '[]'::jsonb NOT NULL
+);
+
+
+--
+-- Name: workflow_node_event_identities; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.workflow_node_event_identities (
+    workflow_id uuid NOT NULL,
+    node_id character varying(128) NOT NULL,
+    identity character varying(256) NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: workflow_node_execution_checkpoints; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.workflow_node_execution_checkpoints (
+    execution_id uuid NOT NULL,
+    workflow_id uuid NOT NULL,
+    node_id character varying(128) NOT NULL,
+    step character varying(128) NOT NULL,
+    operation text NOT NULL,
+    target jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: workflow_node_execution_costs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.workflow_node_execution_costs (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    workflow_id uuid NOT NULL,
+    node_id character varying(128) NOT NULL,
+    execution_id uuid NOT NULL,
+    amount double precision NOT NULL,
+    unit character varying(32) NOT NULL,
+    description text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
 );
 
 
@@ -501,6 +641,39 @@ CREATE TABLE public.workflow_node_execution_kvs (
 );
 
 
+--
+-- Name: workflow_node_execution_logs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.workflow_node_execution_logs (
+    id bigint NOT NULL,
+    workflow_id uuid NOT NULL,
+    node_id character varying(128) NOT NULL,
+    execution_id uuid NOT NULL,
+    message text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: workflow_node_execution_logs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.workflow_node_execution_logs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: workflow_node_execution_logs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.workflow_node_execution_logs_id_seq OWNED BY public.workflow_node_execution_logs.id;
+
+
 --
 -- Name: workflow_node_executions; Type: TABLE; Schema: public; Owner: -
 --
@@ -553,7 +726,24 @@ CREATE TABLE public.workflow_node_requests (
     run_at timestamp without time zone NOT NULL,
     created_at timestamp without time zone NOT NULL,
     updated_at timestamp without time zone NOT NULL,
-    node_id character varying(128) NOT NULL
+    node_id character varying(128) NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_error text
+);
+
+
+--
+-- Name: workflow_node_webhook_secrets; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.workflow_node_webhook_secrets (
+    workflow_id uuid NOT NULL,
+    node_id character varying(128) NOT NULL,
+    secret bytea NOT NULL,
+    previous_secret bytea,
+    previous_secret_expires_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
 );
 
 
@@ -628,10 +818,20 @@ CREATE TABLE public.workflows (
     is_template boolean DEFAULT false NOT NULL,
     live_version_id uuid NOT NULL,
     canvas_versioning_enabled boolean DEFAULT false NOT NULL,
-    change_request_approvers jsonb DEFAULT '[{"type": "anyone"}]'::jsonb NOT NULL
+    change_request_approvers jsonb DEFAULT '[{"type": "anyone"}]'::jsonb NOT NULL,
+    test_mode boolean DEFAULT false NOT NULL,
+    parameters jsonb DEFAULT '[]'::jsonb NOT NULL,
+    environment character varying(64) DEFAULT ''::character varying NOT NULL
 );
 
 
+--
+-- Name: audit_log_entries id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_log_entries ALTER COLUMN id SET DEFAULT nextval('public.audit_log_entries_id_seq'::regclass);
+
+
 --
 -- Name: casbin_rule id; Type: DEFAULT; Schema: public; Owner: -
 --
@@ -639,6 +839,13 @@ CREATE TABLE public.workflows (
 ALTER TABLE ONLY public.casbin_rule ALTER COLUMN id SET DEFAULT nextval('public.casbin_rule_id_seq'::regclass);
 
 
+--
+-- Name: workflow_node_execution_logs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_execution_logs ALTER COLUMN id SET DEFAULT nextval('public.workflow_node_execution_logs_id_seq'::regclass);
+
+
 --
 -- Name: account_password_auth account_password_auth_account_id_key; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -735,6 +942,14 @@ ALTER TABLE ONLY public.app_installations
     ADD CONSTRAINT app_installations_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: audit_log_entries audit_log_entries_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_log_entries
+    ADD CONSTRAINT audit_log_entries_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: blueprints blueprints_organization_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -751,6 +966,14 @@ ALTER TABLE ONLY public.blueprints
     ADD CONSTRAINT blueprints_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: canvas_key_values canvas_key_values_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_key_values
+    ADD CONSTRAINT canvas_key_values_pkey PRIMARY KEY (canvas_id, key);
+
+
 --
 -- Name: canvas_memories canvas_memories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -927,6 +1150,14 @@ ALTER TABLE ONLY public.users
     ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: webhooks webhooks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -935,6 +1166,14 @@ ALTER TABLE ONLY public.webhooks
     ADD CONSTRAINT webhooks_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: workflow_backfills workflow_backfills_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_backfills
+    ADD CONSTRAINT workflow_backfills_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: workflow_change_request_approvals workflow_change_request_approvals_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -967,6 +1206,30 @@ ALTER TABLE ONLY public.workflow_events
     ADD CONSTRAINT workflow_events_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: workflow_node_event_identities workflow_node_event_identities_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_event_identities
+    ADD CONSTRAINT workflow_node_event_identities_pkey PRIMARY KEY (workflow_id, node_id, identity);
+
+
+--
+-- Name: workflow_node_execution_checkpoints workflow_node_execution_checkpoints_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_execution_checkpoints
+    ADD CONSTRAINT workflow_node_execution_checkpoints_pkey PRIMARY KEY (execution_id);
+
+
+--
+-- Name: workflow_node_execution_costs workflow_node_execution_costs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_execution_costs
+    ADD CONSTRAINT workflow_node_execution_costs_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: workflow_node_execution_kvs workflow_node_execution_kvs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -975,6 +1238,14 @@ ALTER TABLE ONLY public.workflow_node_execution_kvs
     ADD CONSTRAINT workflow_node_execution_kvs_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: workflow_node_execution_logs workflow_node_execution_logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_execution_logs
+    ADD CONSTRAINT workflow_node_execution_logs_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: workflow_node_requests workflow_node_execution_requests_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -999,6 +1270,14 @@ ALTER TABLE ONLY public.workflow_node_queue_items
     ADD CONSTRAINT workflow_node_queue_items_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: workflow_node_webhook_secrets workflow_node_webhook_secrets_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_webhook_secrets
+    ADD CONSTRAINT workflow_node_webhook_secrets_pkey PRIMARY KEY (workflow_id, node_id);
+
+
 --
 -- Name: workflow_nodes workflow_nodes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1138,6 +1417,27 @@ CREATE UNIQUE INDEX idx_app_installations_org_name_unique ON public.app_installa
 CREATE INDEX idx_app_installations_organization_id ON public.app_installations USING btree (organization_id);
 
 
+--
+-- Name: idx_audit_log_entries_execution; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_audit_log_entries_execution ON public.audit_log_entries USING btree (execution_id);
+
+
+--
+-- Name: idx_audit_log_entries_organization; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_audit_log_entries_organization ON public.audit_log_entries USING btree (organization_id, id);
+
+
+--
+-- Name: idx_audit_log_entries_workflow; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_audit_log_entries_workflow ON public.audit_log_entries USING btree (workflow_id, id);
+
+
 --
 -- Name: idx_blueprints_organization_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -1215,6 +1515,20 @@ CREATE INDEX idx_organizations_deleted_at ON public.organizations USING btree (d
 CREATE INDEX idx_role_metadata_lookup ON public.role_metadata USING btree (role_name, domain_type, domain_id);
 
 
+--
+-- Name: idx_webhook_deliveries_app_installation; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_webhook_deliveries_app_installation ON public.webhook_deliveries USING btree (app_installation_id, created_at);
+
+
+--
+-- Name: idx_webhook_deliveries_created_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_webhook_deliveries_created_at ON public.webhook_deliveries USING btree (created_at);
+
+
 --
 -- Name: idx_webhooks_app_installation_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -1229,6 +1543,20 @@ CREATE INDEX idx_webhooks_app_installation_id ON public.webhooks USING btree (ap
 CREATE INDEX idx_webhooks_deleted_at ON public.webhooks USING btree (deleted_at);
 
 
+--
+-- Name: idx_workflow_backfills_state; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_workflow_backfills_state ON public.workflow_backfills USING btree (state, next_run_at);
+
+
+--
+-- Name: idx_workflow_backfills_workflow; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_workflow_backfills_workflow ON public.workflow_backfills USING btree (workflow_id, created_at);
+
+
 --
 -- Name: idx_workflow_change_request_approvals_active; Type: INDEX; Schema: public; Owner: -
 --
@@ -1292,6 +1620,27 @@ CREATE INDEX idx_workflow_events_state ON public.workflow_events USING btree (st
 CREATE INDEX idx_workflow_events_workflow_node_id ON public.workflow_events USING btree (workflow_id, node_id);
 
 
+--
+-- Name: idx_workflow_node_event_identities_expires_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_workflow_node_event_identities_expires_at ON public.workflow_node_event_identities USING btree (expires_at);
+
+
+--
+-- Name: idx_workflow_node_execution_costs_execution; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_workflow_node_execution_costs_execution ON public.workflow_node_execution_costs USING btree (execution_id);
+
+
+--
+-- Name: idx_workflow_node_execution_costs_workflow; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_workflow_node_execution_costs_workflow ON public.workflow_node_execution_costs USING btree (workflow_id, created_at);
+
+
 --
 -- Name: idx_workflow_node_execution_kvs_ekv; Type: INDEX; Schema: public; Owner: -
 --
@@ -1306,6 +1655,20 @@ CREATE INDEX idx_workflow_node_execution_kvs_ekv ON public.workflow_node_executi
 CREATE INDEX idx_workflow_node_execution_kvs_workflow_node_key_value ON public.workflow_node_execution_kvs USING btree (workflow_id, node_id, key, value);
 
 
+--
+-- Name: idx_workflow_node_execution_logs_execution; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_workflow_node_execution_logs_execution ON public.workflow_node_execution_logs USING btree (execution_id, id);
+
+
+--
+-- Name: idx_workflow_node_execution_logs_workflow_node; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_workflow_node_execution_logs_workflow_node ON public.workflow_node_execution_logs USING btree (workflow_id, node_id);
+
+
 --
 -- Name: idx_workflow_node_executions_event_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -1539,6 +1902,14 @@ ALTER TABLE ONLY public.app_installations
     ADD CONSTRAINT app_installations_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
 
 
+--
+-- Name: canvas_key_values canvas_key_values_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_key_values
+    ADD CONSTRAINT canvas_key_values_canvas_id_fkey FOREIGN KEY (canvas_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
+
+
 --
 -- Name: canvas_memories canvas_memories_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1659,6 +2030,14 @@ ALTER TABLE ONLY public.users
     ADD CONSTRAINT users_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id);
 
 
+--
+-- Name: webhook_deliveries webhook_deliveries_app_installation_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_app_installation_id_fkey FOREIGN KEY (app_installation_id) REFERENCES public.app_installations(id) ON DELETE CASCADE;
+
+
 --
 -- Name: webhooks webhooks_app_installation_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1667,6 +2046,14 @@ ALTER TABLE ONLY public.webhooks
     ADD CONSTRAINT webhooks_app_installation_id_fkey FOREIGN KEY (app_installation_id) REFERENCES public.app_installations(id);
 
 
+--
+-- Name: workflow_backfills workflow_backfills_workflow_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_backfills
+    ADD CONSTRAINT workflow_backfills_workflow_id_fkey FOREIGN KEY (workflow_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
+
+
 --
 -- Name: workflow_change_request_approvals workflow_change_request_approvals_actor_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1747,6 +2134,14 @@ ALTER TABLE ONLY public.workflow_events
     ADD CONSTRAINT workflow_events_workflow_id_fkey FOREIGN KEY (workflow_id) REFERENCES public.workflows(id);
 
 
+--
+-- Name: workflow_node_event_identities workflow_node_event_identities_workflow_id_node_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_event_identities
+    ADD CONSTRAINT workflow_node_event_identities_workflow_id_node_id_fkey FOREIGN KEY (workflow_id, node_id) REFERENCES public.workflow_nodes(workflow_id, node_id) ON DELETE CASCADE;
+
+
 --
 -- Name: workflow_node_execution_kvs workflow_node_execution_kvs_execution_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1835,6 +2230,14 @@ ALTER TABLE ONLY public.workflow_node_requests
     ADD CONSTRAINT workflow_node_requests_workflow_id_fkey FOREIGN KEY (workflow_id) REFERENCES public.workflows(id);
 
 
+--
+-- Name: workflow_node_webhook_secrets workflow_node_webhook_secrets_workflow_id_node_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.workflow_node_webhook_secrets
+    ADD CONSTRAINT workflow_node_webhook_secrets_workflow_id_node_id_fkey FOREIGN KEY (workflow_id, node_id) REFERENCES public.workflow_nodes(workflow_id, node_id) ON DELETE CASCADE;
+
+
 --
 -- Name: workflow_nodes workflow_nodes_app_installation_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1939,7 +2342,7 @@ SET row_security = off;
 --
 
 COPY public.schema_migrations (version, dirty) FROM stdin;
-20260310154909	f
+20260328090000	f
 \.
 
 
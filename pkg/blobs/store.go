@@ -0,0 +1,134 @@
+package blobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+/*
+ * Store keeps files uploaded through configuration fields.
+ * Keys are slash-separated paths, e.g. "<organization-id>/<file-id>".
+ */
+type Store interface {
+	Put(ctx context.Context, key string, data io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	defaultStore Store
+	defaultOnce  sync.Once
+)
+
+/*
+ * Default returns the store configured through BLOB_STORAGE_PATH.
+ * The directory must be shared by all the instances
+ * running the API and the workers, e.g. a volume mounted on all of them.
+ * The server requires it outside of development,
+ * where a temporary directory is used when it is not set.
+ */
+func Default() Store {
+	defaultOnce.Do(func() {
+		path := os.Getenv("BLOB_STORAGE_PATH")
+		if path == "" {
+			path = filepath.Join(os.TempDir(), "superplane-blobs")
+		}
+
+		defaultStore = NewFilesystemStore(path)
+	})
+
+	return defaultStore
+}
+
+/*
+ * FilesystemStore stores blobs as files under a root directory.
+ */
+type FilesystemStore struct {
+	root string
+}
+
+func NewFilesystemStore(root string) *FilesystemStore {
+	return &FilesystemStore{root: root}
+}
+
+func (s *FilesystemStore) Put(ctx context.Context, key string, data io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	//
+	// Write to a temporary file first,
+	// so readers never see partial blobs.
+	//
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FilesystemStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *FilesystemStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *FilesystemStore) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if key == "" || cleaned == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,47 @@
+package blobs
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	store := NewFilesystemStore(t.TempDir())
+	ctx := context.Background()
+
+	t.Run("put and get", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "org-1/file-1", strings.NewReader("hello")))
+
+		reader, err := store.Get(ctx, "org-1/file-1")
+		require.NoError(t, err)
+		defer reader.Close()
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+
+	t.Run("get missing key -> not found", func(t *testing.T) {
+		_, err := store.Get(ctx, "org-1/missing")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "org-1/file-2", strings.NewReader("hello")))
+		require.NoError(t, store.Delete(ctx, "org-1/file-2"))
+		require.NoError(t, store.Delete(ctx, "org-1/file-2"))
+
+		_, err := store.Get(ctx, "org-1/file-2")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("keys escaping the root -> error", func(t *testing.T) {
+		require.Error(t, store.Put(ctx, "../outside", strings.NewReader("hello")))
+		require.Error(t, store.Put(ctx, "", strings.NewReader("hello")))
+	})
+}
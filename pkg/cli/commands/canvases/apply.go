@@ -0,0 +1,222 @@
+package canvases
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/superplanehq/superplane/pkg/cli/commands/canvases/models"
+	"github.com/superplanehq/superplane/pkg/cli/core"
+	"github.com/superplanehq/superplane/pkg/openapi_client"
+)
+
+/*
+ * NewApplyCommand returns the "apply" command,
+ * which creates or updates the canvas described in a file.
+ * Applying a file that matches the canvas does nothing,
+ * so it can be run on every change to the file, e.g. from CI.
+ */
+func NewApplyCommand(options core.BindOptions) *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update a canvas from a file",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "canvas file to apply")
+	_ = cmd.MarkFlagRequired("file")
+	core.Bind(cmd, &applyCommand{file: &file}, options)
+	return cmd
+}
+
+type applyCommand struct {
+	file *string
+}
+
+func (c *applyCommand) Execute(ctx core.CommandContext) error {
+	// #nosec
+	data, err := os.ReadFile(*c.file)
+	if err != nil {
+		return fmt.Errorf("failed to read resource file: %w", err)
+	}
+
+	_, kind, err := core.ParseYamlResourceHeaders(data)
+	if err != nil {
+		return err
+	}
+
+	if kind != models.CanvasKind {
+		return fmt.Errorf("unsupported resource kind %q for apply", kind)
+	}
+
+	resource, err := models.ParseCanvas(data)
+	if err != nil {
+		return err
+	}
+
+	if resource.Spec == nil {
+		resource.Spec = models.EmptyCanvasSpec()
+	}
+
+	integrationIDs, err := findIntegrationIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := models.ResolveIntegrationRefs(resource.Spec, integrationIDs); err != nil {
+		return err
+	}
+
+	canvasID, err := findCanvasToApply(ctx, resource.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if canvasID == "" {
+		return c.create(ctx, *resource)
+	}
+
+	return c.update(ctx, canvasID, *resource)
+}
+
+func (c *applyCommand) create(ctx core.CommandContext, resource models.Canvas) error {
+	request := openapi_client.CanvasesCreateCanvasRequest{}
+	request.SetCanvas(models.CanvasFromCanvas(resource))
+
+	response, _, err := ctx.API.CanvasAPI.CanvasesCreateCanvas(ctx.Context).Body(request).Execute()
+	if err != nil {
+		return err
+	}
+
+	id := ""
+	if response.Canvas != nil && response.Canvas.Metadata != nil {
+		id = response.Canvas.Metadata.GetId()
+	}
+
+	return printApplyResult(ctx, resource.Metadata.GetName(), id, "created")
+}
+
+/*
+ * If canvas versioning is enabled, changes are applied
+ * to the draft of the current user, like "canvases update --draft".
+ */
+func (c *applyCommand) update(ctx core.CommandContext, canvasID string, resource models.Canvas) error {
+	versioningContext, err := resolveCanvasVersioningContext(ctx, canvasID)
+	if err != nil {
+		return err
+	}
+
+	body := openapi_client.CanvasesUpdateCanvasVersionBody{}
+	var current openapi_client.CanvasesCanvas
+	if versioningContext.versioningEnabled {
+		versionID, err := ensureCurrentUserDraftVersionID(ctx, canvasID)
+		if err != nil {
+			return err
+		}
+
+		version, err := describeCanvasVersionByID(ctx, canvasID, versionID)
+		if err != nil {
+			return err
+		}
+
+		current = canvasFromVersion(version)
+		body.SetVersionId(versionID)
+	} else {
+		current, err = describeCanvasByID(ctx, canvasID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if models.SpecsEqual(current.Spec, resource.Spec) && sameDescription(current.Metadata, resource.Metadata) {
+		return printApplyResult(ctx, resource.Metadata.GetName(), canvasID, "unchanged")
+	}
+
+	canvas := models.CanvasFromCanvas(resource)
+	canvas.Metadata.SetId(canvasID)
+	body.SetCanvas(canvas)
+	body.SetAutoLayout(buildDefaultAutoLayout(current, canvas))
+
+	_, _, err = ctx.API.CanvasVersionAPI.
+		CanvasesUpdateCanvasVersion2(ctx.Context, canvasID).
+		Body(body).
+		Execute()
+	if err != nil {
+		return err
+	}
+
+	return printApplyResult(ctx, resource.Metadata.GetName(), canvasID, "updated")
+}
+
+/*
+ * The canvas is found by ID, if the file has one,
+ * or by name, since exported canvases do not include IDs.
+ */
+func findCanvasToApply(ctx core.CommandContext, metadata *openapi_client.CanvasesCanvasMetadata) (string, error) {
+	if metadata.GetId() != "" {
+		return metadata.GetId(), nil
+	}
+
+	response, _, err := ctx.API.CanvasAPI.CanvasesListCanvases(ctx.Context).Execute()
+	if err != nil {
+		return "", err
+	}
+
+	ids := []string{}
+	for _, canvas := range response.GetCanvases() {
+		if canvas.Metadata != nil && canvas.Metadata.GetName() == metadata.GetName() {
+			ids = append(ids, canvas.Metadata.GetId())
+		}
+	}
+
+	if len(ids) > 1 {
+		return "", fmt.Errorf("multiple canvases named %q found, set metadata.id in the file", metadata.GetName())
+	}
+
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	return ids[0], nil
+}
+
+func findIntegrationIDs(ctx core.CommandContext) (map[string]string, error) {
+	me, _, err := ctx.API.MeAPI.MeMe(ctx.Context).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	if !me.HasOrganizationId() {
+		return nil, fmt.Errorf("organization id not found for authenticated user")
+	}
+
+	response, _, err := ctx.API.OrganizationAPI.OrganizationsListIntegrations(ctx.Context, me.GetOrganizationId()).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]string{}
+	for _, integration := range response.GetIntegrations() {
+		metadata := integration.GetMetadata()
+		ids[metadata.GetName()] = metadata.GetId()
+	}
+
+	return ids, nil
+}
+
+func sameDescription(current, next *openapi_client.CanvasesCanvasMetadata) bool {
+	return current.GetDescription() == next.GetDescription()
+}
+
+func printApplyResult(ctx core.CommandContext, name, id, result string) error {
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(map[string]string{"name": name, "id": id, "result": result})
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		_, err := fmt.Fprintf(stdout, "canvas %q %s\n", name, result)
+		return err
+	})
+}
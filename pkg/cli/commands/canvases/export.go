@@ -0,0 +1,39 @@
+package canvases
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/superplanehq/superplane/pkg/cli/commands/canvases/models"
+	"github.com/superplanehq/superplane/pkg/cli/core"
+)
+
+type exportCommand struct{}
+
+func (c *exportCommand) Execute(ctx core.CommandContext) error {
+	canvasID, err := findCanvasID(ctx, ctx.API, ctx.Args[0])
+	if err != nil {
+		return err
+	}
+
+	canvas, err := describeCanvasByID(ctx, canvasID)
+	if err != nil {
+		return err
+	}
+
+	resource := models.PortableCanvas(canvas)
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(resource)
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		data, err := yaml.Marshal(resource)
+		if err != nil {
+			return fmt.Errorf("failed to encode canvas: %w", err)
+		}
+
+		_, err = stdout.Write(data)
+		return err
+	})
+}
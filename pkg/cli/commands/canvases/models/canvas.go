@@ -76,3 +76,130 @@ func EmptyCanvasSpec() *openapi_client.CanvasesCanvasSpec {
 		Edges: []openapi_client.ComponentsEdge{},
 	}
 }
+
+/*
+ * PortableCanvas returns the canvas without the fields set by the server,
+ * so it can be kept in git and applied to any organization:
+ * ids and timestamps are removed from the metadata,
+ * validation messages are removed from the nodes,
+ * and integrations are referenced by name only.
+ */
+func PortableCanvas(canvas openapi_client.CanvasesCanvas) Canvas {
+	metadata := &openapi_client.CanvasesCanvasMetadata{}
+	if canvas.Metadata != nil {
+		metadata.Name = canvas.Metadata.Name
+		metadata.Description = canvas.Metadata.Description
+	}
+
+	spec := EmptyCanvasSpec()
+	if canvas.Spec != nil {
+		for _, node := range canvas.Spec.GetNodes() {
+			spec.Nodes = append(spec.Nodes, portableNode(node))
+		}
+
+		spec.Edges = append(spec.Edges, canvas.Spec.GetEdges()...)
+	}
+
+	return Canvas{
+		APIVersion: "v1",
+		Kind:       CanvasKind,
+		Metadata:   metadata,
+		Spec:       spec,
+	}
+}
+
+func portableNode(node openapi_client.ComponentsNode) openapi_client.ComponentsNode {
+	node.ErrorMessage = nil
+	node.WarningMessage = nil
+	if node.Integration != nil && node.Integration.GetName() != "" {
+		node.Integration = &openapi_client.ComponentsIntegrationRef{Name: node.Integration.Name}
+	}
+
+	return node
+}
+
+/*
+ * ResolveIntegrationRefs sets the ID of integrations referenced only by name,
+ * using the IDs of the integrations connected in the organization.
+ */
+func ResolveIntegrationRefs(spec *openapi_client.CanvasesCanvasSpec, integrationIDs map[string]string) error {
+	if spec == nil {
+		return nil
+	}
+
+	for i := range spec.Nodes {
+		ref := spec.Nodes[i].Integration
+		if ref == nil || ref.GetId() != "" {
+			continue
+		}
+
+		id, ok := integrationIDs[ref.GetName()]
+		if !ok {
+			return fmt.Errorf("node %s: integration %q not found", spec.Nodes[i].GetId(), ref.GetName())
+		}
+
+		ref.SetId(id)
+	}
+
+	return nil
+}
+
+/*
+ * SpecsEqual returns true if applying next over current changes nothing.
+ * Validation messages are ignored, and integrations are compared by ID.
+ */
+func SpecsEqual(current, next *openapi_client.CanvasesCanvasSpec) bool {
+	if current == nil || next == nil {
+		return current == next
+	}
+
+	if len(current.Nodes) != len(next.Nodes) || len(current.Edges) != len(next.Edges) {
+		return false
+	}
+
+	for i := range current.Nodes {
+		if !nodesEqual(current.Nodes[i], next.Nodes[i]) {
+			return false
+		}
+	}
+
+	for i := range current.Edges {
+		a, _ := json.Marshal(current.Edges[i])
+		b, _ := json.Marshal(next.Edges[i])
+		if string(a) != string(b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func nodesEqual(current, next openapi_client.ComponentsNode) bool {
+	current.ErrorMessage = nil
+	current.WarningMessage = nil
+	next.ErrorMessage = nil
+	next.WarningMessage = nil
+
+	if current.Integration != nil && next.Integration != nil {
+		current.Integration = &openapi_client.ComponentsIntegrationRef{Id: current.Integration.Id}
+		next.Integration = &openapi_client.ComponentsIntegrationRef{Id: next.Integration.Id}
+	}
+
+	//
+	// Compare the JSON encoding, so numbers decoded
+	// from YAML and from the API are compared the same way.
+	//
+	a, _ := json.Marshal(current)
+	b, _ := json.Marshal(next)
+	return normalizeJSON(a) == normalizeJSON(b)
+}
+
+func normalizeJSON(data []byte) string {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return string(data)
+	}
+
+	normalized, _ := json.Marshal(value)
+	return string(normalized)
+}
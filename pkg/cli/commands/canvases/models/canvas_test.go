@@ -1,6 +1,11 @@
 package models
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/superplanehq/superplane/pkg/openapi_client"
+)
 
 func TestParseCanvasPreservesPositionYFromUnquotedKey(t *testing.T) {
 	raw := []byte(`
@@ -61,3 +66,81 @@ spec:
 		t.Fatalf("expected targetId=manual-plan-start, got %q", edges[0].GetTargetId())
 	}
 }
+
+func TestPortableCanvasRoundTrip(t *testing.T) {
+	id := "4e9ae08d-0363-40d2-ba2c-5f6389a418d8"
+	integrationID := "0b7d3f0e-7a43-4c2a-9a0e-2f3b0c1d9e11"
+	integrationName := "production-gcp"
+	errorMessage := "invalid configuration"
+	canvas := openapi_client.CanvasesCanvas{
+		Metadata: &openapi_client.CanvasesCanvasMetadata{
+			Id:   &id,
+			Name: openapi_client.PtrString("deploy"),
+		},
+		Spec: &openapi_client.CanvasesCanvasSpec{
+			Nodes: []openapi_client.ComponentsNode{
+				{
+					Id:            openapi_client.PtrString("create-vm"),
+					Name:          openapi_client.PtrString("create_vm"),
+					Configuration: map[string]interface{}{"bootDiskSizeGb": float64(20)},
+					Integration:   &openapi_client.ComponentsIntegrationRef{Id: &integrationID, Name: &integrationName},
+					ErrorMessage:  &errorMessage,
+				},
+			},
+			Edges: []openapi_client.ComponentsEdge{},
+		},
+	}
+
+	resource := PortableCanvas(canvas)
+	if resource.Metadata.Id != nil {
+		t.Fatalf("expected metadata.id to be removed")
+	}
+
+	node := resource.Spec.Nodes[0]
+	if node.ErrorMessage != nil || node.Integration.Id != nil || node.Integration.GetName() != integrationName {
+		t.Fatalf("unexpected portable node: %+v", node)
+	}
+
+	data, err := yaml.Marshal(resource)
+	if err != nil {
+		t.Fatalf("failed to encode canvas: %v", err)
+	}
+
+	parsed, err := ParseCanvas(data)
+	if err != nil {
+		t.Fatalf("ParseCanvas returned error: %v", err)
+	}
+
+	if SpecsEqual(canvas.Spec, parsed.Spec) {
+		t.Fatalf("expected specs to differ before integrations are resolved")
+	}
+
+	if err := ResolveIntegrationRefs(parsed.Spec, map[string]string{integrationName: integrationID}); err != nil {
+		t.Fatalf("ResolveIntegrationRefs returned error: %v", err)
+	}
+
+	if !SpecsEqual(canvas.Spec, parsed.Spec) {
+		t.Fatalf("expected exported canvas to be equal to the original one")
+	}
+
+	parsed.Spec.Nodes[0].Configuration["bootDiskSizeGb"] = 30
+	if SpecsEqual(canvas.Spec, parsed.Spec) {
+		t.Fatalf("expected configuration change to be detected")
+	}
+}
+
+func TestResolveIntegrationRefsUnknownIntegration(t *testing.T) {
+	spec := &openapi_client.CanvasesCanvasSpec{
+		Nodes: []openapi_client.ComponentsNode{
+			{
+				Id:          openapi_client.PtrString("create-vm"),
+				Integration: &openapi_client.ComponentsIntegrationRef{Name: openapi_client.PtrString("staging-gcp")},
+			},
+		},
+	}
+
+	err := ResolveIntegrationRefs(spec, map[string]string{})
+	if err == nil || err.Error() != `node create-vm: integration "staging-gcp" not found` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
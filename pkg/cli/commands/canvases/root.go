@@ -27,6 +27,13 @@ func NewCommand(options core.BindOptions) *cobra.Command {
 	}
 	core.Bind(getCmd, &getCommand{}, options)
 
+	exportCmd := &cobra.Command{
+		Use:   "export <name-or-id>",
+		Short: "Export a canvas as YAML that can be applied to any organization",
+		Args:  cobra.ExactArgs(1),
+	}
+	core.Bind(exportCmd, &exportCommand{}, options)
+
 	activeCmd := &cobra.Command{
 		Use:   "active [canvas-id]",
 		Short: "Set the active canvas",
@@ -198,6 +205,7 @@ func NewCommand(options core.BindOptions) *cobra.Command {
 
 	root.AddCommand(listCmd)
 	root.AddCommand(getCmd)
+	root.AddCommand(exportCmd)
 	root.AddCommand(activeCmd)
 	root.AddCommand(createCmd)
 	root.AddCommand(updateCmd)
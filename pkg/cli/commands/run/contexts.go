@@ -0,0 +1,301 @@
+package run
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * Contexts used to run a component outside of the server.
+ * State is kept in memory, and printed once the run finishes.
+ */
+
+type metadataContext struct {
+	metadata any
+}
+
+func (m *metadataContext) Get() any {
+	return m.metadata
+}
+
+func (m *metadataContext) Set(metadata any) error {
+	m.metadata = metadata
+	return nil
+}
+
+type executionStateContext struct {
+	finished       bool
+	passed         bool
+	failureReason  string
+	failureMessage string
+	outputs        map[string][]any
+	kvs            map[string]string
+}
+
+func newExecutionStateContext() *executionStateContext {
+	return &executionStateContext{outputs: map[string][]any{}, kvs: map[string]string{}}
+}
+
+func (s *executionStateContext) IsFinished() bool {
+	return s.finished
+}
+
+func (s *executionStateContext) SetKV(key, value string) error {
+	s.kvs[key] = value
+	return nil
+}
+
+func (s *executionStateContext) Pass() error {
+	s.finished = true
+	s.passed = true
+	return nil
+}
+
+func (s *executionStateContext) Emit(channel, payloadType string, payloads []any) error {
+	return s.EmitOutputs([]core.ChannelOutput{{Channel: channel, PayloadType: payloadType, Payloads: payloads}})
+}
+
+func (s *executionStateContext) EmitOutputs(outputs []core.ChannelOutput) error {
+	for _, output := range outputs {
+		for _, payload := range output.Payloads {
+			s.outputs[output.Channel] = append(s.outputs[output.Channel], map[string]any{
+				"type":      output.PayloadType,
+				"timestamp": time.Now(),
+				"data":      payload,
+			})
+		}
+	}
+
+	s.finished = true
+	s.passed = true
+	return nil
+}
+
+func (s *executionStateContext) Fail(reason, message string) error {
+	s.finished = true
+	s.passed = false
+	s.failureReason = reason
+	s.failureMessage = message
+	return nil
+}
+
+type scheduledCall struct {
+	Action     string         `json:"action"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+	Interval   string         `json:"interval"`
+
+	interval time.Duration
+}
+
+type requestContext struct {
+	calls []scheduledCall
+}
+
+func (r *requestContext) ScheduleActionCall(actionName string, parameters map[string]any, interval time.Duration) error {
+	r.calls = append(r.calls, scheduledCall{
+		Action:     actionName,
+		Parameters: parameters,
+		Interval:   interval.String(),
+		interval:   interval,
+	})
+
+	return nil
+}
+
+/*
+ * next removes and returns the first scheduled call, if any.
+ */
+func (r *requestContext) next() *scheduledCall {
+	if len(r.calls) == 0 {
+		return nil
+	}
+
+	call := r.calls[0]
+	r.calls = r.calls[1:]
+	return &call
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+/*
+ * dryRunHTTPContext does not send requests.
+ * Every request is recorded and gets an empty JSON object back.
+ */
+type dryRunHTTPContext struct {
+	requests []recordedRequest
+}
+
+func (c *dryRunHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	recorded := recordedRequest{Method: request.Method, URL: request.URL.String()}
+	if request.Body != nil {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %v", err)
+		}
+
+		recorded.Body = string(body)
+	}
+
+	c.requests = append(c.requests, recorded)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString("{}")),
+		Request:    request,
+	}, nil
+}
+
+/*
+ * integrationContext holds the configuration, metadata and secrets
+ * of the integration, as read from the integration file.
+ */
+type integrationContext struct {
+	id            uuid.UUID
+	configuration map[string]any
+	metadata      any
+	secrets       map[string][]byte
+	state         string
+	stateMessage  string
+}
+
+func (c *integrationContext) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *integrationContext) GetMetadata() any {
+	return c.metadata
+}
+
+func (c *integrationContext) SetMetadata(metadata any) {
+	c.metadata = metadata
+}
+
+func (c *integrationContext) GetConfig(name string) ([]byte, error) {
+	value, ok := c.configuration[name]
+	if !ok {
+		return nil, fmt.Errorf("config %s not found", name)
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("config %s is not a string", name)
+	}
+
+	return []byte(s), nil
+}
+
+func (c *integrationContext) Ready() {
+	c.state = "ready"
+	c.stateMessage = ""
+}
+
+func (c *integrationContext) Error(message string) {
+	c.state = "error"
+	c.stateMessage = message
+}
+
+func (c *integrationContext) NewBrowserAction(action core.BrowserAction) {}
+
+func (c *integrationContext) RemoveBrowserAction() {}
+
+func (c *integrationContext) SetSecret(name string, value []byte) error {
+	c.secrets[name] = value
+	return nil
+}
+
+func (c *integrationContext) GetSecrets() ([]core.IntegrationSecret, error) {
+	secrets := make([]core.IntegrationSecret, 0, len(c.secrets))
+	for name, value := range c.secrets {
+		secrets = append(secrets, core.IntegrationSecret{Name: name, Value: value})
+	}
+
+	return secrets, nil
+}
+
+func (c *integrationContext) RequestWebhook(configuration any) error {
+	return fmt.Errorf("webhooks are not available when running components locally")
+}
+
+func (c *integrationContext) Subscribe(any) (*uuid.UUID, error) {
+	return nil, fmt.Errorf("subscriptions are not available when running components locally")
+}
+
+func (c *integrationContext) ScheduleResync(interval time.Duration) error {
+	return nil
+}
+
+func (c *integrationContext) ScheduleActionCall(actionName string, parameters any, interval time.Duration) error {
+	return nil
+}
+
+func (c *integrationContext) ListSubscriptions() ([]core.IntegrationSubscriptionContext, error) {
+	return []core.IntegrationSubscriptionContext{}, nil
+}
+
+func (c *integrationContext) FindSubscription(predicate func(core.IntegrationSubscriptionContext) bool) (core.IntegrationSubscriptionContext, error) {
+	return nil, nil
+}
+
+/*
+ * secretsContext reads keys from the secrets file,
+ * where values are nested by secret name and key name.
+ */
+type secretsContext struct {
+	secrets map[string]map[string]string
+}
+
+func (c *secretsContext) GetKey(secretName, keyName string) ([]byte, error) {
+	secret, ok := c.secrets[secretName]
+	if !ok {
+		return nil, fmt.Errorf("secret %s not found", secretName)
+	}
+
+	value, ok := secret[keyName]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s", keyName, secretName)
+	}
+
+	return []byte(value), nil
+}
+
+type notificationContext struct {
+	stderr io.Writer
+}
+
+func (c *notificationContext) Send(title, body, url, urlLabel string, receivers core.NotificationReceivers) error {
+	_, err := fmt.Fprintf(c.stderr, "notification not sent when running locally: %s (%s)\n", title, strings.Join(receivers.Emails, ", "))
+	return err
+}
+
+/*
+ * Execution logs are printed to stderr as they are written,
+ * so they do not mix with the result printed to stdout.
+ */
+type logsContext struct {
+	stderr io.Writer
+}
+
+func (c *logsContext) Write(p []byte) (int, error) {
+	return c.stderr.Write(p)
+}
+
+func (c *logsContext) Printf(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+
+	_, _ = io.WriteString(c.stderr, line)
+}
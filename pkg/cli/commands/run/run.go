@@ -0,0 +1,359 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/superplanehq/superplane/pkg/cli/core"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	corepkg "github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/registry"
+
+	// Import server to register all integrations, components, and triggers via init()
+	_ "github.com/superplanehq/superplane/pkg/server"
+)
+
+/*
+ * Scheduled action calls are followed at most this many times,
+ * so components that poll forever do not keep the command running.
+ */
+const maxFollowedActions = 100
+
+func NewCommand(options core.BindOptions) *cobra.Command {
+	var configFile string
+	var integrationFile string
+	var secretsFile string
+	var dataFile string
+	var dryRun bool
+	var follow bool
+
+	cmd := &cobra.Command{
+		Use:   "run <component>",
+		Short: "Run a component locally",
+		Long: `Runs a single component on this machine and prints the payloads it emits.
+
+The integration file holds the configuration of the integration used by the component,
+e.g. {"configuration": {...}, "metadata": {...}, "secrets": {"name": "value"}}.
+If it has no metadata, the integration is synced first, like when it is connected.
+
+With --dry-run, HTTP requests are not sent: they are printed,
+and an empty JSON object is returned for each of them.`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVar(&configFile, "config", "", "JSON file with the configuration of the component")
+	cmd.Flags().StringVar(&integrationFile, "integration", "", "JSON file with the configuration, metadata and secrets of the integration")
+	cmd.Flags().StringVar(&secretsFile, "secrets", "", "JSON file with organization secrets, as {\"secret\": {\"key\": \"value\"}}")
+	cmd.Flags().StringVar(&dataFile, "data", "", "JSON file with the input event data")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print HTTP requests instead of sending them")
+	cmd.Flags().BoolVar(&follow, "follow", false, "wait for and run the actions scheduled by the component, until it finishes")
+
+	core.Bind(cmd, &runCommand{
+		configFile:      &configFile,
+		integrationFile: &integrationFile,
+		secretsFile:     &secretsFile,
+		dataFile:        &dataFile,
+		dryRun:          &dryRun,
+		follow:          &follow,
+	}, options)
+
+	return cmd
+}
+
+type runCommand struct {
+	configFile      *string
+	integrationFile *string
+	secretsFile     *string
+	dataFile        *string
+	dryRun          *bool
+	follow          *bool
+}
+
+type integrationFile struct {
+	Configuration map[string]any    `json:"configuration"`
+	Metadata      any               `json:"metadata"`
+	Secrets       map[string]string `json:"secrets"`
+}
+
+type runResult struct {
+	Component        string            `json:"component"`
+	Status           string            `json:"status"`
+	FailureReason    string            `json:"failureReason,omitempty"`
+	FailureMessage   string            `json:"failureMessage,omitempty"`
+	Outputs          map[string][]any  `json:"outputs"`
+	Metadata         any               `json:"metadata,omitempty"`
+	ScheduledActions []scheduledCall   `json:"scheduledActions,omitempty"`
+	Requests         []recordedRequest `json:"requests,omitempty"`
+}
+
+func (c *runCommand) Execute(ctx core.CommandContext) error {
+	reg, err := registry.NewRegistry(crypto.NewNoOpEncryptor(), registry.HTTPOptions{})
+	if err != nil {
+		return err
+	}
+
+	name := ctx.Args[0]
+	component, err := reg.GetComponent(name)
+	if err != nil {
+		return err
+	}
+
+	var http corepkg.HTTPContext
+	dryRunHTTP := &dryRunHTTPContext{}
+	if *c.dryRun {
+		http = dryRunHTTP
+	} else {
+		http, err = registry.NewHTTPContext(registry.HTTPOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	stderr := ctx.Cmd.ErrOrStderr()
+	integration, err := c.loadIntegration(ctx, reg, name, http)
+	if err != nil {
+		return err
+	}
+
+	config := map[string]any{}
+	if err := readJSONFile(*c.configFile, &config); err != nil {
+		return err
+	}
+
+	if err := configuration.ValidateConfiguration(component.Configuration(), config); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	config = configuration.NormalizeConfiguration(component.Configuration(), config)
+
+	var data any
+	if err := readJSONFile(*c.dataFile, &data); err != nil {
+		return err
+	}
+
+	secrets := map[string]map[string]string{}
+	if err := readJSONFile(*c.secretsFile, &secrets); err != nil {
+		return err
+	}
+
+	metadata := &metadataContext{}
+	state := newExecutionStateContext()
+	requests := &requestContext{}
+	executionCtx := corepkg.ExecutionContext{
+		ID:             uuid.New(),
+		WorkflowID:     uuid.NewString(),
+		OrganizationID: uuid.NewString(),
+		NodeID:         "local",
+		BaseURL:        "http://localhost:8000",
+		Data:           data,
+		Configuration:  config,
+		Logger:         ctx.Logger,
+		HTTP:           http,
+		Metadata:       metadata,
+		NodeMetadata:   &metadataContext{},
+		ExecutionState: state,
+		Requests:       requests,
+		Notifications:  &notificationContext{stderr: stderr},
+		Secrets:        &secretsContext{secrets: secrets},
+		Logs:           &logsContext{stderr: stderr},
+		Context:        ctx.Context,
+	}
+
+	if integration != nil {
+		executionCtx.Integration = integration
+	}
+
+	if err := component.Execute(executionCtx); err != nil {
+		return fmt.Errorf("error executing %s: %w", name, err)
+	}
+
+	if *c.follow {
+		if err := c.followActions(executionCtx, component, requests, stderr); err != nil {
+			return err
+		}
+	}
+
+	result := runResult{
+		Component:        name,
+		Status:           status(state),
+		FailureReason:    state.failureReason,
+		FailureMessage:   state.failureMessage,
+		Outputs:          state.outputs,
+		Metadata:         metadata.metadata,
+		ScheduledActions: requests.calls,
+		Requests:         dryRunHTTP.requests,
+	}
+
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(result)
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		return renderText(stdout, result)
+	})
+}
+
+/*
+ * Runs the actions scheduled by the component, after waiting for their interval,
+ * until the execution finishes or no more actions are scheduled.
+ */
+func (c *runCommand) followActions(executionCtx corepkg.ExecutionContext, component corepkg.Component, requests *requestContext, stderr io.Writer) error {
+	for i := 0; i < maxFollowedActions && !executionCtx.ExecutionState.IsFinished(); i++ {
+		call := requests.next()
+		if call == nil {
+			return nil
+		}
+
+		fmt.Fprintf(stderr, "running action %s in %s\n", call.Action, call.Interval)
+		if !*c.dryRun {
+			time.Sleep(call.interval)
+		}
+
+		err := component.HandleAction(corepkg.ActionContext{
+			Name:           call.Action,
+			Configuration:  executionCtx.Configuration,
+			Parameters:     call.Parameters,
+			Logger:         executionCtx.Logger,
+			HTTP:           executionCtx.HTTP,
+			Metadata:       executionCtx.Metadata,
+			ExecutionState: executionCtx.ExecutionState,
+			Requests:       executionCtx.Requests,
+			Integration:    executionCtx.Integration,
+			Notifications:  executionCtx.Notifications,
+			Secrets:        executionCtx.Secrets,
+			Logs:           executionCtx.Logs,
+			Context:        executionCtx.Context,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error running action %s: %w", call.Action, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *runCommand) loadIntegration(ctx core.CommandContext, reg *registry.Registry, componentName string, http corepkg.HTTPContext) (*integrationContext, error) {
+	integrationName, _, isIntegrationComponent := core.ParseIntegrationScopedName(componentName)
+	if !isIntegrationComponent {
+		return nil, nil
+	}
+
+	if *c.integrationFile == "" {
+		return nil, fmt.Errorf("--integration is required for %s components", integrationName)
+	}
+
+	file := integrationFile{}
+	if err := readJSONFile(*c.integrationFile, &file); err != nil {
+		return nil, err
+	}
+
+	integration := &integrationContext{
+		id:            uuid.New(),
+		configuration: file.Configuration,
+		metadata:      file.Metadata,
+		secrets:       map[string][]byte{},
+	}
+
+	for name, value := range file.Secrets {
+		integration.secrets[name] = []byte(value)
+	}
+
+	if integration.configuration == nil {
+		integration.configuration = map[string]any{}
+	}
+
+	if file.Metadata != nil {
+		return integration, nil
+	}
+
+	impl, err := reg.GetIntegration(integrationName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = impl.Sync(corepkg.SyncContext{
+		Logger:         ctx.Logger,
+		Configuration:  integration.configuration,
+		BaseURL:        "http://localhost:8000",
+		OrganizationID: uuid.NewString(),
+		HTTP:           http,
+		Integration:    integration,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error syncing %s integration: %w", integrationName, err)
+	}
+
+	if integration.state == "error" {
+		return nil, fmt.Errorf("error syncing %s integration: %s", integrationName, integration.stateMessage)
+	}
+
+	return integration, nil
+}
+
+func status(state *executionStateContext) string {
+	switch {
+	case !state.finished:
+		return "running"
+	case state.passed:
+		return "passed"
+	default:
+		return "failed"
+	}
+}
+
+func renderText(stdout io.Writer, result runResult) error {
+	_, _ = fmt.Fprintf(stdout, "Component: %s\n", result.Component)
+	_, _ = fmt.Fprintf(stdout, "Status: %s\n", result.Status)
+	if result.FailureReason != "" {
+		_, _ = fmt.Fprintf(stdout, "Failure: %s: %s\n", result.FailureReason, result.FailureMessage)
+	}
+
+	for _, request := range result.Requests {
+		_, _ = fmt.Fprintf(stdout, "\nRequest (not sent): %s %s\n", request.Method, request.URL)
+		if request.Body != "" {
+			_, _ = fmt.Fprintln(stdout, strings.TrimSpace(request.Body))
+		}
+	}
+
+	for _, call := range result.ScheduledActions {
+		_, _ = fmt.Fprintf(stdout, "\nScheduled action: %s in %s\n", call.Action, call.Interval)
+	}
+
+	for channel, payloads := range result.Outputs {
+		data, err := json.MarshalIndent(payloads, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(stdout, "\nOutput channel %s:\n%s\n", channel, data)
+	}
+
+	return nil
+}
+
+func readJSONFile(path string, value any) error {
+	if path == "" {
+		return nil
+	}
+
+	// #nosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return nil
+}
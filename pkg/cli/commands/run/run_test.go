@@ -0,0 +1,77 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/cli/core"
+)
+
+func TestRunCommandExecuteNoop(t *testing.T) {
+	ctx, stdout := newRunCommandContextForTest(t, "json", "noop")
+	command := newRunCommandForTest("", true)
+
+	err := command.Execute(ctx)
+	require.NoError(t, err)
+	require.Contains(t, stdout.String(), `"status": "passed"`)
+	require.Contains(t, stdout.String(), `"type": "noop.finished"`)
+}
+
+func TestRunCommandExecuteDryRunRecordsRequests(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"method": "POST", "url": "https://example.com/hooks"}`), 0o600))
+
+	ctx, stdout := newRunCommandContextForTest(t, "text", "http")
+	command := newRunCommandForTest(configFile, true)
+
+	err := command.Execute(ctx)
+	require.NoError(t, err)
+	require.Contains(t, stdout.String(), "Request (not sent): POST https://example.com/hooks")
+}
+
+func TestRunCommandExecuteRequiresIntegration(t *testing.T) {
+	ctx, _ := newRunCommandContextForTest(t, "text", "gcp.createVM")
+	command := newRunCommandForTest("", true)
+
+	err := command.Execute(ctx)
+	require.ErrorContains(t, err, "--integration is required")
+}
+
+func newRunCommandForTest(configFile string, dryRun bool) *runCommand {
+	empty := ""
+	follow := false
+	return &runCommand{
+		configFile:      &configFile,
+		integrationFile: &empty,
+		secretsFile:     &empty,
+		dataFile:        &empty,
+		dryRun:          &dryRun,
+		follow:          &follow,
+	}
+}
+
+func newRunCommandContextForTest(t *testing.T, outputFormat string, component string) (core.CommandContext, *bytes.Buffer) {
+	t.Helper()
+
+	stdout := bytes.NewBuffer(nil)
+	renderer, err := core.NewRenderer(outputFormat, stdout)
+	require.NoError(t, err)
+
+	cmd := &cobra.Command{}
+	cmd.SetErr(bytes.NewBuffer(nil))
+
+	return core.CommandContext{
+		Context:  context.Background(),
+		Cmd:      cmd,
+		Args:     []string{component},
+		Logger:   log.NewEntry(log.New()),
+		Renderer: renderer,
+	}, stdout
+}
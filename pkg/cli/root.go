@@ -16,6 +16,7 @@ import (
 	index "github.com/superplanehq/superplane/pkg/cli/commands/index"
 	integrations "github.com/superplanehq/superplane/pkg/cli/commands/integrations"
 	queue "github.com/superplanehq/superplane/pkg/cli/commands/queue"
+	run "github.com/superplanehq/superplane/pkg/cli/commands/run"
 	secrets "github.com/superplanehq/superplane/pkg/cli/commands/secrets"
 	"github.com/superplanehq/superplane/pkg/cli/core"
 )
@@ -52,11 +53,13 @@ func init() {
 
 	options := defaultBindOptions()
 	RootCmd.AddCommand(canvases.NewCommand(options))
+	RootCmd.AddCommand(canvases.NewApplyCommand(options))
 	RootCmd.AddCommand(executions.NewCommand(options))
 	RootCmd.AddCommand(events.NewCommand(options))
 	RootCmd.AddCommand(index.NewCommand(options))
 	RootCmd.AddCommand(integrations.NewCommand(options))
 	RootCmd.AddCommand(queue.NewCommand(options))
+	RootCmd.AddCommand(run.NewCommand(options))
 	RootCmd.AddCommand(secrets.NewCommand(options))
 }
 
@@ -88,3 +88,7 @@ func TestAddMemoryExecute(t *testing.T) {
 	})
 
 }
+
+func TestAddMemory_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&AddMemory{}))
+}
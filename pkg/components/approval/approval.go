@@ -27,6 +27,10 @@ const (
 
 	ChannelApproved = "approved"
 	ChannelRejected = "rejected"
+
+	UnitMinutes = "minutes"
+	UnitHours   = "hours"
+	UnitDays    = "days"
 )
 
 func init() {
@@ -38,7 +42,32 @@ func init() {
  * Filled when the component is added to a blueprint/workflow.
  */
 type Config struct {
-	Items []Item `json:"items" mapstructure:"items"`
+	Items      []Item      `json:"items" mapstructure:"items"`
+	Message    string      `json:"message" mapstructure:"message"`
+	Expiration *Expiration `json:"expiration,omitempty" mapstructure:"expiration"`
+}
+
+type Expiration struct {
+	Duration int    `json:"duration" mapstructure:"duration"`
+	Unit     string `json:"unit" mapstructure:"unit"`
+	Outcome  string `json:"outcome" mapstructure:"outcome"`
+}
+
+func (e *Expiration) Interval() (time.Duration, error) {
+	if e.Duration <= 0 {
+		return 0, fmt.Errorf("expiration duration must be positive, got: %d", e.Duration)
+	}
+
+	switch e.Unit {
+	case UnitMinutes:
+		return time.Duration(e.Duration) * time.Minute, nil
+	case UnitHours:
+		return time.Duration(e.Duration) * time.Hour, nil
+	case UnitDays:
+		return time.Duration(e.Duration) * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid expiration unit: %s", e.Unit)
+	}
 }
 
 type Item struct {
@@ -52,8 +81,11 @@ type Item struct {
  * Metadata for the component.
  */
 type Metadata struct {
-	Result  string   `mapstructure:"result" json:"result"`
-	Records []Record `mapstructure:"records" json:"records"`
+	Result    string   `mapstructure:"result" json:"result"`
+	Records   []Record `mapstructure:"records" json:"records"`
+	Message   string   `mapstructure:"message" json:"message,omitempty"`
+	ExpiresAt string   `mapstructure:"expiresAt" json:"expiresAt,omitempty"`
+	Expired   bool     `mapstructure:"expired" json:"expired,omitempty"`
 }
 
 type Record struct {
@@ -111,6 +143,12 @@ func (m *Metadata) UpdateResult() {
 	m.Result = StateApproved
 }
 
+func (m *Metadata) hasRejectedAnyRecord() bool {
+	return slices.ContainsFunc(m.Records, func(record Record) bool {
+		return record.State == StateRejected
+	})
+}
+
 func (m *Metadata) hasApprovedAnyRecord(userID string) bool {
 	if userID == "" {
 		return false
@@ -324,10 +362,13 @@ func (a *Approval) Documentation() string {
   - **Group**: Any member of the specified group can approve
   - **Role**: Any user with the specified role can approve
 
+- **Message**: Context shown to approvers, e.g. what is being deployed and where. Supports expressions, so details from the incoming payload can be included
+- **Expiration**: Optionally finish the approval after a period of time, going to the approved or rejected channel
+
 ## Output Channels
 
-- **Approved**: Emitted when all required approvers have approved
-- **Rejected**: Emitted when at least one approver rejects (after all have responded)
+- **Approved**: Emitted when all required approvers have approved, or when the approval expires with an approved outcome and nobody has rejected
+- **Rejected**: Emitted when at least one approver rejects (after all have responded), or when the approval expires with a rejected outcome or after a rejection
 
 ## Actions
 
@@ -420,10 +461,91 @@ func (a *Approval) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeText,
+			Description: "Context shown to approvers. Supports expressions, e.g. {{$.version}}",
+		},
+		{
+			Name:        "expiration",
+			Label:       "Expiration",
+			Type:        configuration.FieldTypeObject,
+			Togglable:   true,
+			Description: "Finish the approval automatically if nobody responds in time",
+			TypeOptions: &configuration.TypeOptions{
+				Object: &configuration.ObjectTypeOptions{
+					Schema: []configuration.Field{
+						{
+							Name:     "duration",
+							Label:    "Expires after",
+							Type:     configuration.FieldTypeNumber,
+							Required: true,
+							Default:  24,
+							TypeOptions: &configuration.TypeOptions{
+								Number: &configuration.NumberTypeOptions{
+									Min: intPtr(1),
+								},
+							},
+						},
+						{
+							Name:     "unit",
+							Label:    "Unit",
+							Type:     configuration.FieldTypeSelect,
+							Required: true,
+							Default:  UnitHours,
+							TypeOptions: &configuration.TypeOptions{
+								Select: &configuration.SelectTypeOptions{
+									Options: []configuration.FieldOption{
+										{Label: "Minutes", Value: UnitMinutes},
+										{Label: "Hours", Value: UnitHours},
+										{Label: "Days", Value: UnitDays},
+									},
+								},
+							},
+						},
+						{
+							Name:        "outcome",
+							Label:       "Outcome",
+							Type:        configuration.FieldTypeSelect,
+							Required:    true,
+							Default:     StateRejected,
+							Description: "Result of the approval when it expires",
+							TypeOptions: &configuration.TypeOptions{
+								Select: &configuration.SelectTypeOptions{
+									Options: []configuration.FieldOption{
+										{Label: "Reject", Value: StateRejected},
+										{Label: "Approve", Value: StateApproved},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
 func (a *Approval) Setup(ctx core.SetupContext) error {
+	config := Config{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Expiration == nil {
+		return nil
+	}
+
+	if _, err := config.Expiration.Interval(); err != nil {
+		return err
+	}
+
+	if config.Expiration.Outcome != StateApproved && config.Expiration.Outcome != StateRejected {
+		return fmt.Errorf("invalid expiration outcome: %s", config.Expiration.Outcome)
+	}
+
 	return nil
 }
 
@@ -443,7 +565,19 @@ func (a *Approval) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	metadata.Message = config.Message
 	metadata.UpdateResult()
+
+	var expiresIn time.Duration
+	if config.Expiration != nil && !metadata.Completed() {
+		expiresIn, err = config.Expiration.Interval()
+		if err != nil {
+			return err
+		}
+
+		metadata.ExpiresAt = time.Now().Add(expiresIn).Format(time.RFC3339)
+	}
+
 	err = ctx.Metadata.Set(metadata)
 	if err != nil {
 		return fmt.Errorf("error setting metadata: %v", err)
@@ -468,6 +602,10 @@ func (a *Approval) Execute(ctx core.ExecutionContext) error {
 		}
 	}
 
+	if expiresIn > 0 {
+		return ctx.Requests.ScheduleActionCall("expire", map[string]any{}, expiresIn)
+	}
+
 	return nil
 }
 
@@ -515,6 +653,10 @@ func (a *Approval) Actions() []core.Action {
 				},
 			},
 		},
+		{
+			Name:           "expire",
+			UserAccessible: false,
+		},
 	}
 }
 
@@ -526,6 +668,8 @@ func (a *Approval) HandleAction(ctx core.ActionContext) error {
 		metadata, err = a.handleApprove(ctx)
 	case "reject":
 		metadata, err = a.handleReject(ctx)
+	case "expire":
+		return a.handleExpire(ctx)
 	default:
 		return fmt.Errorf("unknown action: %s", ctx.Name)
 	}
@@ -724,10 +868,59 @@ func (a *Approval) handleReject(ctx core.ActionContext) (*Metadata, error) {
 	return &metadata, nil
 }
 
+func (a *Approval) handleExpire(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	config := Config{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	var metadata Metadata
+	err = mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	//
+	// The expiration outcome only decides for the approvers that did not respond,
+	// so a rejection that was already given is never turned into an approval.
+	//
+	outcome := StateRejected
+	if config.Expiration != nil && config.Expiration.Outcome == StateApproved && !metadata.hasRejectedAnyRecord() {
+		outcome = StateApproved
+	}
+
+	metadata.Result = outcome
+	metadata.Expired = true
+	err = ctx.Metadata.Set(metadata)
+	if err != nil {
+		return err
+	}
+
+	outputChannel := ChannelRejected
+	if outcome == StateApproved {
+		outputChannel = ChannelApproved
+	}
+
+	return ctx.ExecutionState.Emit(
+		outputChannel,
+		"approval.finished",
+		[]any{metadata},
+	)
+}
+
 func (a *Approval) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (a *Approval) HandlesTestMode() bool {
+	return true
+}
+
 func (a *Approval) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
@@ -746,6 +939,9 @@ func (a *Approval) notifyApprovers(ctx core.ExecutionContext, metadata *Metadata
 
 	title := "Approval required"
 	body := "A canvas run item is waiting for your approval. Please visit the URL below to handle it."
+	if metadata.Message != "" {
+		body = metadata.Message + "\n\n" + body
+	}
 
 	receivers := core.NotificationReceivers{}
 	emailSet := map[string]struct{}{}
@@ -798,3 +994,7 @@ func mapKeys(input map[string]struct{}) []string {
 func (a *Approval) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
+
+func intPtr(v int) *int {
+	return &v
+}
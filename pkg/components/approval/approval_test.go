@@ -2,6 +2,7 @@ package approval
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -666,3 +667,126 @@ func TestApproval_Configuration_Validation(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestApproval_Expiration(t *testing.T) {
+	approval := &Approval{}
+
+	configWithOutcome := func(outcome string) map[string]any {
+		return map[string]any{
+			"items":   []any{map[string]any{"type": "anyone"}},
+			"message": "Deploy v1.2.3 to production",
+			"expiration": map[string]any{
+				"duration": float64(2),
+				"unit":     UnitHours,
+				"outcome":  outcome,
+			},
+		}
+	}
+
+	t.Run("setup rejects invalid expiration", func(t *testing.T) {
+		config := configWithOutcome("maybe")
+		err := approval.Setup(core.SetupContext{Configuration: config})
+		require.ErrorContains(t, err, "invalid expiration outcome")
+
+		config = configWithOutcome(StateApproved)
+		config["expiration"].(map[string]any)["unit"] = "weeks"
+		err = approval.Setup(core.SetupContext{Configuration: config})
+		require.ErrorContains(t, err, "invalid expiration unit")
+	})
+
+	t.Run("execute schedules expiration and stores message", func(t *testing.T) {
+		metadataCtx := &contexts.MetadataContext{}
+		requestCtx := &contexts.RequestContext{}
+
+		err := approval.Execute(core.ExecutionContext{
+			Configuration:  configWithOutcome(StateRejected),
+			Metadata:       metadataCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Auth:           &contexts.AuthContext{},
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "expire", requestCtx.Action)
+		assert.Equal(t, 2*time.Hour, requestCtx.Duration)
+
+		stored := metadataCtx.Metadata.(*Metadata)
+		assert.Equal(t, "Deploy v1.2.3 to production", stored.Message)
+		assert.NotEmpty(t, stored.ExpiresAt)
+	})
+
+	for _, tt := range []struct {
+		outcome string
+		channel string
+	}{
+		{outcome: StateRejected, channel: ChannelRejected},
+		{outcome: StateApproved, channel: ChannelApproved},
+	} {
+		t.Run("expire emits on "+tt.channel+" channel", func(t *testing.T) {
+			stateCtx := &contexts.ExecutionStateContext{}
+			metadataCtx := &contexts.MetadataContext{
+				Metadata: &Metadata{
+					Result:  StatePending,
+					Records: []Record{{Index: 0, Type: ItemTypeAnyone, State: StatePending}},
+				},
+			}
+
+			err := approval.HandleAction(core.ActionContext{
+				Name:           "expire",
+				Configuration:  configWithOutcome(tt.outcome),
+				Metadata:       metadataCtx,
+				ExecutionState: stateCtx,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, stateCtx.Finished)
+			assert.Equal(t, tt.channel, stateCtx.Channel)
+
+			stored := metadataCtx.Metadata.(Metadata)
+			assert.True(t, stored.Expired)
+			assert.Equal(t, tt.outcome, stored.Result)
+		})
+	}
+
+	t.Run("expire with approved outcome keeps a rejection", func(t *testing.T) {
+		stateCtx := &contexts.ExecutionStateContext{}
+		metadataCtx := &contexts.MetadataContext{
+			Metadata: &Metadata{
+				Result: StatePending,
+				Records: []Record{
+					{Index: 0, Type: ItemTypeAnyone, State: StateRejected},
+					{Index: 1, Type: ItemTypeAnyone, State: StatePending},
+				},
+			},
+		}
+
+		err := approval.HandleAction(core.ActionContext{
+			Name:           "expire",
+			Configuration:  configWithOutcome(StateApproved),
+			Metadata:       metadataCtx,
+			ExecutionState: stateCtx,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, stateCtx.Finished)
+		assert.Equal(t, ChannelRejected, stateCtx.Channel)
+
+		stored := metadataCtx.Metadata.(Metadata)
+		assert.True(t, stored.Expired)
+		assert.Equal(t, StateRejected, stored.Result)
+	})
+
+	t.Run("expire is ignored when already finished", func(t *testing.T) {
+		stateCtx := &contexts.ExecutionStateContext{Finished: true, Channel: ChannelApproved}
+
+		err := approval.HandleAction(core.ActionContext{
+			Name:           "expire",
+			Configuration:  configWithOutcome(StateRejected),
+			Metadata:       &contexts.MetadataContext{},
+			ExecutionState: stateCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelApproved, stateCtx.Channel)
+	})
+}
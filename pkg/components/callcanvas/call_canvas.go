@@ -0,0 +1,303 @@
+package callcanvas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	PayloadType = "canvas.run.finished"
+
+	ChannelPassed = "passed"
+	ChannelFailed = "failed"
+
+	DefaultPollIntervalSeconds = 10
+	MinPollIntervalSeconds     = 5
+	MaxPollIntervalSeconds     = 3600
+)
+
+func init() {
+	registry.RegisterComponent("callCanvas", &CallCanvas{})
+}
+
+type CallCanvas struct{}
+
+type Spec struct {
+	Canvas              string         `json:"canvas" mapstructure:"canvas"`
+	StartNode           string         `json:"startNode" mapstructure:"startNode"`
+	Input               map[string]any `json:"input" mapstructure:"input"`
+	PollIntervalSeconds int            `json:"pollIntervalSeconds" mapstructure:"pollIntervalSeconds"`
+}
+
+type ExecutionMetadata struct {
+	CanvasID            string `json:"canvasId" mapstructure:"canvasId"`
+	RunID               string `json:"runId" mapstructure:"runId"`
+	State               string `json:"state" mapstructure:"state"`
+	PollIntervalSeconds int    `json:"pollIntervalSeconds" mapstructure:"pollIntervalSeconds"`
+}
+
+func (c *CallCanvas) Name() string {
+	return "callCanvas"
+}
+
+func (c *CallCanvas) Label() string {
+	return "Call Canvas"
+}
+
+func (c *CallCanvas) Description() string {
+	return "Run another canvas and wait for it to finish"
+}
+
+func (c *CallCanvas) Documentation() string {
+	return `The Call Canvas component runs another canvas with an input payload, and waits until that run finishes.
+
+## Use Cases
+
+- **Reusable building blocks**: Keep common steps, e.g. provisioning a VM, bootstrapping it and registering it in the inventory, in one canvas called from many others
+- **Composition**: Split large workflows into smaller canvases that can be tested on their own
+
+## Configuration
+
+- **Canvas**: ID of the canvas to call. It must be in the same organization
+- **Start Node**: ID of the Manual Run trigger node to start from. Only required if the called canvas has more than one
+- **Input**: Payload of the trigger event of the called canvas. Supports expressions
+- **Poll Interval (seconds)**: How often the state of the run is checked. Defaults to 10 seconds
+
+## Behavior
+
+- The called canvas starts from its Manual Run trigger, with the input as the event payload
+- The run is finished once all of its executions finished and no events are left to process
+- Emits on **Passed** if every execution of the run passed, and on **Failed** otherwise
+- Use the execution timeout of the node to stop waiting for runs that take too long
+- A canvas cannot call itself, or a canvas that is already calling it, directly or through other canvases
+- Canvas calls can go at most 10 levels deep
+
+## Output
+
+The component emits a payload with:
+- **canvasId** and **runId**: The called canvas, and the ID of the run, which is its root event ID
+- **state**: passed, failed or cancelled
+- **message**: Why the run did not pass, if it did not
+- **outputs**: The terminal outputs of the run, i.e. the payloads emitted by its last nodes, with their node ID and channel`
+}
+
+func (c *CallCanvas) Icon() string {
+	return "workflow"
+}
+
+func (c *CallCanvas) Color() string {
+	return "purple"
+}
+
+func (c *CallCanvas) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: ChannelPassed, Label: "Passed", Description: "Every execution of the called canvas passed"},
+		{Name: ChannelFailed, Label: "Failed", Description: "An execution of the called canvas failed or was cancelled"},
+	}
+}
+
+func (c *CallCanvas) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "canvas",
+			Label:       "Canvas",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "ID of the canvas to call",
+		},
+		{
+			Name:        "startNode",
+			Label:       "Start Node",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Description: "ID of the Manual Run trigger node to start from, if the canvas has more than one",
+		},
+		{
+			Name:        "input",
+			Label:       "Input",
+			Type:        configuration.FieldTypeObject,
+			Required:    false,
+			Description: "Payload of the trigger event of the called canvas",
+		},
+		{
+			Name:        "pollIntervalSeconds",
+			Label:       "Poll Interval (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultPollIntervalSeconds,
+			Description: "How often the state of the run is checked",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := MinPollIntervalSeconds; return &min }(),
+					Max: func() *int { max := MaxPollIntervalSeconds; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func decodeSpec(raw any) (Spec, error) {
+	spec := Spec{}
+	if err := mapstructure.Decode(raw, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Canvas = strings.TrimSpace(spec.Canvas)
+	spec.StartNode = strings.TrimSpace(spec.StartNode)
+	if spec.Canvas == "" {
+		return Spec{}, errors.New("canvas is required")
+	}
+
+	if !strings.Contains(spec.Canvas, "{{") {
+		if _, err := uuid.Parse(spec.Canvas); err != nil {
+			return Spec{}, fmt.Errorf("invalid canvas ID %q", spec.Canvas)
+		}
+	}
+
+	if spec.PollIntervalSeconds == 0 {
+		spec.PollIntervalSeconds = DefaultPollIntervalSeconds
+	}
+
+	if spec.PollIntervalSeconds < MinPollIntervalSeconds || spec.PollIntervalSeconds > MaxPollIntervalSeconds {
+		return Spec{}, fmt.Errorf("poll interval must be between %d and %d seconds", MinPollIntervalSeconds, MaxPollIntervalSeconds)
+	}
+
+	return spec, nil
+}
+
+func (c *CallCanvas) Setup(ctx core.SetupContext) error {
+	_, err := decodeSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CallCanvas) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CallCanvas) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if ctx.CanvasRuns == nil {
+		return errors.New("calling canvases is not available")
+	}
+
+	runID, err := ctx.CanvasRuns.Start(spec.Canvas, spec.StartNode, spec.Input)
+	if err != nil {
+		return fmt.Errorf("failed to start canvas %s: %w", spec.Canvas, err)
+	}
+
+	metadata := ExecutionMetadata{
+		CanvasID:            spec.Canvas,
+		RunID:               runID,
+		State:               core.CanvasRunStateRunning,
+		PollIntervalSeconds: spec.PollIntervalSeconds,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, time.Duration(spec.PollIntervalSeconds)*time.Second)
+}
+
+func (c *CallCanvas) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        "poll",
+			Description: "Check the state of the called canvas run",
+		},
+	}
+}
+
+func (c *CallCanvas) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case "poll":
+		return c.poll(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *CallCanvas) poll(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := ExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if ctx.CanvasRuns == nil {
+		return errors.New("calling canvases is not available")
+	}
+
+	run, err := ctx.CanvasRuns.Get(metadata.CanvasID, metadata.RunID)
+	if err != nil {
+		return ctx.ExecutionState.Fail(models.CanvasNodeExecutionResultReasonError, err.Error())
+	}
+
+	if !run.IsFinished() {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, time.Duration(metadata.PollIntervalSeconds)*time.Second)
+	}
+
+	metadata.State = run.State
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	channel := ChannelPassed
+	if run.State != core.CanvasRunStatePassed {
+		channel = ChannelFailed
+	}
+
+	return ctx.ExecutionState.Emit(channel, PayloadType, []any{
+		map[string]any{
+			"canvasId": metadata.CanvasID,
+			"runId":    run.ID,
+			"state":    run.State,
+			"message":  run.Message,
+			"outputs":  runOutputs(run),
+		},
+	})
+}
+
+func runOutputs(run *core.CanvasRun) []any {
+	outputs := make([]any, 0, len(run.Outputs))
+	for _, output := range run.Outputs {
+		outputs = append(outputs, map[string]any{
+			"nodeId":  output.NodeID,
+			"channel": output.Channel,
+			"data":    output.Data,
+		})
+	}
+
+	return outputs
+}
+
+func (c *CallCanvas) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CallCanvas) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CallCanvas) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
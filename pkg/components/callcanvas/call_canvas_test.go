@@ -0,0 +1,167 @@
+package callcanvas
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const canvasID = "7f0c5a8e-3b2d-4c61-9a8e-2d6f1b4c9e10"
+
+func Test__CallCanvas__Setup(t *testing.T) {
+	c := &CallCanvas{}
+
+	t.Run("canvas is required", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{Configuration: map[string]any{}})
+		require.ErrorContains(t, err, "canvas is required")
+	})
+
+	t.Run("invalid canvas ID -> error", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{Configuration: map[string]any{"canvas": "provision-vm"}})
+		require.ErrorContains(t, err, `invalid canvas ID "provision-vm"`)
+	})
+
+	t.Run("poll interval out of range -> error", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{Configuration: map[string]any{"canvas": canvasID, "pollIntervalSeconds": 1}})
+		require.ErrorContains(t, err, "poll interval must be between 5 and 3600 seconds")
+	})
+
+	t.Run("canvas from expression is accepted", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{Configuration: map[string]any{"canvas": "{{ params.provisionCanvas }}"}})
+		require.NoError(t, err)
+	})
+}
+
+func Test__CallCanvas__Execute(t *testing.T) {
+	c := &CallCanvas{}
+
+	t.Run("starts the run and schedules a poll", func(t *testing.T) {
+		canvasRuns := &contexts.CanvasRunsContext{RunID: "run-1"}
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+
+		err := c.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"canvas":    canvasID,
+				"startNode": "start-provision-a1b2c3",
+				"input":     map[string]any{"machineType": "e2-medium"},
+			},
+			CanvasRuns: canvasRuns,
+			Metadata:   metadata,
+			Requests:   requests,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, canvasID, canvasRuns.StartedCanvasID)
+		assert.Equal(t, "start-provision-a1b2c3", canvasRuns.StartedNodeID)
+		assert.Equal(t, map[string]any{"machineType": "e2-medium"}, canvasRuns.StartedPayload)
+		assert.Equal(t, ExecutionMetadata{
+			CanvasID:            canvasID,
+			RunID:               "run-1",
+			State:               core.CanvasRunStateRunning,
+			PollIntervalSeconds: DefaultPollIntervalSeconds,
+		}, metadata.Metadata)
+		assert.Equal(t, "poll", requests.Action)
+		assert.Equal(t, 10*time.Second, requests.Duration)
+	})
+
+	t.Run("run cannot be started -> error", func(t *testing.T) {
+		err := c.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"canvas": canvasID},
+			CanvasRuns:    &contexts.CanvasRunsContext{Err: errors.New("a canvas cannot call itself")},
+			Metadata:      &contexts.MetadataContext{},
+			Requests:      &contexts.RequestContext{},
+		})
+
+		require.ErrorContains(t, err, "a canvas cannot call itself")
+	})
+}
+
+func Test__CallCanvas__Poll(t *testing.T) {
+	c := &CallCanvas{}
+	metadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{Metadata: map[string]any{
+			"canvasId":            canvasID,
+			"runId":               "run-1",
+			"state":               core.CanvasRunStateRunning,
+			"pollIntervalSeconds": 30,
+		}}
+	}
+
+	t.Run("run still running -> schedules next poll", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		requests := &contexts.RequestContext{}
+
+		err := c.HandleAction(core.ActionContext{
+			Name:           "poll",
+			CanvasRuns:     &contexts.CanvasRunsContext{Run: &core.CanvasRun{ID: "run-1", State: core.CanvasRunStateRunning}},
+			Metadata:       metadata(),
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, "poll", requests.Action)
+		assert.Equal(t, 30*time.Second, requests.Duration)
+	})
+
+	t.Run("run passed -> emits terminal outputs on passed", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := c.HandleAction(core.ActionContext{
+			Name: "poll",
+			CanvasRuns: &contexts.CanvasRunsContext{Run: &core.CanvasRun{
+				ID:    "run-1",
+				State: core.CanvasRunStatePassed,
+				Outputs: []core.CanvasRunOutput{
+					{NodeID: "register", Channel: "default", Data: map[string]any{"ip": "10.0.0.42"}},
+				},
+			}},
+			Metadata:       metadata(),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelPassed, executionState.Channel)
+		assert.Equal(t, PayloadType, executionState.Type)
+		require.Len(t, executionState.Payloads, 1)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "run-1", data["runId"])
+		assert.Equal(t, []any{
+			map[string]any{"nodeId": "register", "channel": "default", "data": map[string]any{"ip": "10.0.0.42"}},
+		}, data["outputs"])
+	})
+
+	t.Run("run failed -> emits on failed", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := c.HandleAction(core.ActionContext{
+			Name: "poll",
+			CanvasRuns: &contexts.CanvasRunsContext{Run: &core.CanvasRun{
+				ID:      "run-1",
+				State:   core.CanvasRunStateFailed,
+				Message: "node bootstrap failed: exit status 1",
+			}},
+			Metadata:       metadata(),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelFailed, executionState.Channel)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "node bootstrap failed: exit status 1", data["message"])
+	})
+}
+
+func TestCallCanvas_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&CallCanvas{}))
+}
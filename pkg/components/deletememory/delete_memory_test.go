@@ -163,3 +163,7 @@ func TestDeleteMemorySetup(t *testing.T) {
 	})
 
 }
+
+func TestDeleteMemory_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&DeleteMemory{}))
+}
@@ -0,0 +1,365 @@
+package foreach
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/expressions"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ComponentName = "forEach"
+
+const (
+	ChannelNameItem       = "item"
+	ChannelNameDispatched = "dispatched"
+
+	ItemPayloadType       = "forEach.item"
+	DispatchedPayloadType = "forEach.dispatched"
+
+	// Upper bound on the number of events a single execution can fan out to.
+	MaxItems = 1000
+)
+
+func init() {
+	registry.RegisterComponent(ComponentName, &ForEach{})
+}
+
+type ForEach struct{}
+
+type Spec struct {
+	Items string `json:"items" mapstructure:"items"`
+}
+
+func (f *ForEach) Name() string {
+	return ComponentName
+}
+
+func (f *ForEach) Label() string {
+	return "For Each"
+}
+
+func (f *ForEach) Description() string {
+	return "Emit one event per element of a list"
+}
+
+func (f *ForEach) Documentation() string {
+	return `The For Each component takes a list from the incoming event data and emits one event per element, so everything connected to the Item channel runs once for each element.
+
+## Use Cases
+
+- **Fan-out**: Create one VM per entry of a machine list
+- **Batch operations**: Notify every owner in a list of services
+- **Multi-region rollouts**: Run the same deployment steps for every region
+
+## How It Works
+
+1. The **Items** expression is evaluated against the incoming event data and must return a list
+2. One event is emitted on the **Item** channel for every element of the list
+3. A single event with a summary of the list is emitted on the **Dispatched** channel, at the same time as the item events
+
+The **Dispatched** event does not wait for the elements to be processed. Use a Merge component with a correlation key and an event count to wait for the processing of all elements to finish.
+
+## Concurrency
+
+Item events are queued on every node connected to the **Item** channel, and processed according to the queue mode of that node:
+- **Serial** (default): one element at a time
+- **Parallel**: up to **Max executions in flight** elements at the same time
+
+## Output Channels
+
+- **Item**: One event per element, with ` + "`item`" + `, ` + "`index`" + ` and ` + "`total`" + `
+- **Dispatched**: One event with ` + "`total`" + ` and the full list under ` + "`items`" + `, once all item events were emitted. Also emitted for empty lists
+
+## Expression Environment
+
+The expression has access to:
+- **$**: The run context data
+- **root()**: Access to the root event data
+- **previous()**: Access to previous node outputs (optionally with depth parameter)
+
+## Examples
+
+- ` + "`$[\"Node Name\"].machines`" + `: Emit one event per machine
+- ` + "`filter($[\"Node Name\"].instances, {#.status == \"RUNNING\"})`" + `: Emit one event per running instance
+
+## Limits
+
+A list can have at most 1000 elements.`
+}
+
+func (f *ForEach) Icon() string {
+	return "repeat"
+}
+
+func (f *ForEach) Color() string {
+	return "blue"
+}
+
+func (f *ForEach) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: ChannelNameItem, Label: "Item", Description: "One event per element"},
+		{Name: ChannelNameDispatched, Label: "Dispatched", Description: "Summary of all elements, once their events were emitted"},
+	}
+}
+
+func (f *ForEach) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "items",
+			Label:       "Items",
+			Type:        configuration.FieldTypeExpression,
+			Description: "Expression returning the list to iterate over",
+			Placeholder: "e.g. $[\"Node Name\"].machines",
+			Required:    true,
+		},
+	}
+}
+
+func (f *ForEach) Setup(ctx core.SetupContext) error {
+	spec := Spec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if spec.Items == "" {
+		return fmt.Errorf("items is required")
+	}
+
+	return nil
+}
+
+func (f *ForEach) Execute(ctx core.ExecutionContext) error {
+	spec := Spec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	items, err := evaluateItems(ctx, spec.Items)
+	if err != nil {
+		return err
+	}
+
+	if len(items) > MaxItems {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("list has %d elements, the maximum is %d", len(items), MaxItems))
+	}
+
+	err = ctx.Metadata.Set(map[string]any{"total": len(items)})
+	if err != nil {
+		return fmt.Errorf("error setting metadata: %w", err)
+	}
+
+	payloads := make([]any, 0, len(items))
+	for i, item := range items {
+		payloads = append(payloads, map[string]any{
+			"item":  item,
+			"index": i,
+			"total": len(items),
+		})
+	}
+
+	outputs := []core.ChannelOutput{}
+	if len(payloads) > 0 {
+		outputs = append(outputs, core.ChannelOutput{
+			Channel:     ChannelNameItem,
+			PayloadType: ItemPayloadType,
+			Payloads:    payloads,
+		})
+	}
+
+	outputs = append(outputs, core.ChannelOutput{
+		Channel:     ChannelNameDispatched,
+		PayloadType: DispatchedPayloadType,
+		Payloads: []any{
+			map[string]any{
+				"total": len(items),
+				"items": items,
+			},
+		},
+	})
+
+	return ctx.ExecutionState.EmitOutputs(outputs)
+}
+
+func evaluateItems(ctx core.ExecutionContext, expression string) ([]any, error) {
+	env, err := expressionEnv(ctx, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := expr.Compile(expression, expressionOptions(env)...)
+	if err != nil {
+		return nil, fmt.Errorf("expression compilation failed: %w", err)
+	}
+
+	output, err := expr.Run(vm, env)
+	if err != nil {
+		return nil, fmt.Errorf("expression evaluation failed: %w", err)
+	}
+
+	if output == nil {
+		return []any{}, nil
+	}
+
+	value := reflect.ValueOf(output)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("items must evaluate to a list, got %T", output)
+	}
+
+	items := make([]any, value.Len())
+	for i := range items {
+		items[i] = value.Index(i).Interface()
+	}
+
+	return items, nil
+}
+
+func expressionEnv(ctx core.ExecutionContext, expression string) (map[string]any, error) {
+	if ctx.ExpressionEnv != nil {
+		return ctx.ExpressionEnv(expression)
+	}
+
+	return buildExpressionEnv(ctx.Data, ctx.SourceNodeID), nil
+}
+
+func buildExpressionEnv(input any, sourceNodeID string) map[string]any {
+	if sourceNodeID == "" {
+		return map[string]any{"$": input}
+	}
+
+	if inputMap, ok := input.(map[string]any); ok {
+		envData := make(map[string]any, len(inputMap)+1)
+		for key, value := range inputMap {
+			envData[key] = value
+		}
+		if _, exists := envData[sourceNodeID]; !exists {
+			envData[sourceNodeID] = input
+		}
+		return map[string]any{"$": envData}
+	}
+
+	if inputMap, ok := input.(map[string]string); ok {
+		envData := make(map[string]any, len(inputMap)+1)
+		for key, value := range inputMap {
+			envData[key] = value
+		}
+		if _, exists := envData[sourceNodeID]; !exists {
+			envData[sourceNodeID] = input
+		}
+		return map[string]any{"$": envData}
+	}
+
+	return map[string]any{"$": map[string]any{sourceNodeID: input}}
+}
+
+func expressionOptions(env map[string]any) []expr.Option {
+	options := []expr.Option{
+		expr.Env(env),
+		expr.AsAny(),
+		expr.WithContext("ctx"),
+		expr.Timezone(time.UTC.String()),
+		expr.Function("root", func(params ...any) (any, error) {
+			if len(params) != 0 {
+				return nil, fmt.Errorf("root() takes no arguments")
+			}
+
+			rootPayload, ok := env["__root"]
+			if !ok {
+				return nil, fmt.Errorf("no root event found")
+			}
+			return rootPayload, nil
+		}),
+		expr.Function("previous", func(params ...any) (any, error) {
+			depth := 1
+			if len(params) > 1 {
+				return nil, fmt.Errorf("previous() accepts zero or one argument")
+			}
+			if len(params) == 1 {
+				parsedDepth, err := parseDepthValue(params[0])
+				if err != nil {
+					return nil, err
+				}
+				depth = parsedDepth
+			}
+
+			previousByDepth, ok := env["__previousByDepth"]
+			if !ok {
+				return nil, nil
+			}
+			if values, ok := previousByDepth.(map[string]any); ok {
+				return values[strconv.Itoa(depth)], nil
+			}
+			if values, ok := previousByDepth.(map[int]any); ok {
+				return values[depth], nil
+			}
+
+			return nil, nil
+		}),
+	}
+
+	return append(options, expressions.Functions()...)
+}
+
+func parseDepthValue(param any) (int, error) {
+	switch value := param.(type) {
+	case int:
+		if value < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return value, nil
+	case int64:
+		if value < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return int(value), nil
+	case float64:
+		parsed := int(value)
+		if value != float64(parsed) {
+			return 0, fmt.Errorf("depth must be an integer")
+		}
+		if parsed < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("depth must be an integer")
+	}
+}
+
+func (f *ForEach) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (f *ForEach) HandleAction(ctx core.ActionContext) error {
+	return fmt.Errorf("forEach does not support actions")
+}
+
+func (f *ForEach) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (f *ForEach) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (f *ForEach) HandlesTestMode() bool {
+	return true
+}
+
+func (f *ForEach) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (f *ForEach) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,91 @@
+package foreach
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func TestForEach_Setup(t *testing.T) {
+	f := &ForEach{}
+
+	err := f.Setup(core.SetupContext{Configuration: map[string]any{}})
+	require.ErrorContains(t, err, "items is required")
+
+	err = f.Setup(core.SetupContext{Configuration: map[string]any{"items": "$.machines"}})
+	require.NoError(t, err)
+}
+
+func TestForEach_Execute(t *testing.T) {
+	execute := func(data map[string]any, items string) (*contexts.ExecutionStateContext, error) {
+		stateCtx := &contexts.ExecutionStateContext{}
+		err := (&ForEach{}).Execute(core.ExecutionContext{
+			Data:           data,
+			Configuration:  map[string]any{"items": items},
+			ExecutionState: stateCtx,
+			Metadata:       &contexts.MetadataContext{},
+		})
+
+		return stateCtx, err
+	}
+
+	t.Run("emits one event per element and a summary", func(t *testing.T) {
+		stateCtx, err := execute(map[string]any{"machines": []any{"web-1", "web-2", "web-3"}}, "$.machines")
+		require.NoError(t, err)
+		assert.True(t, stateCtx.Passed)
+
+		items := stateCtx.Outputs[ChannelNameItem]
+		require.Len(t, items, 3)
+		for i, item := range items {
+			event := item.(map[string]any)
+			assert.Equal(t, ItemPayloadType, event["type"])
+			assert.Equal(t, map[string]any{
+				"item":  []any{"web-1", "web-2", "web-3"}[i],
+				"index": i,
+				"total": 3,
+			}, event["data"])
+		}
+
+		dispatched := stateCtx.Outputs[ChannelNameDispatched]
+		require.Len(t, dispatched, 1)
+		event := dispatched[0].(map[string]any)
+		assert.Equal(t, DispatchedPayloadType, event["type"])
+		assert.Equal(t, 3, event["data"].(map[string]any)["total"])
+	})
+
+	t.Run("expression can filter the list", func(t *testing.T) {
+		stateCtx, err := execute(map[string]any{
+			"instances": []any{
+				map[string]any{"name": "a", "status": "RUNNING"},
+				map[string]any{"name": "b", "status": "STOPPED"},
+			},
+		}, `filter($.instances, {#.status == "RUNNING"})`)
+
+		require.NoError(t, err)
+		require.Len(t, stateCtx.Outputs[ChannelNameItem], 1)
+	})
+
+	t.Run("empty list only emits dispatched", func(t *testing.T) {
+		stateCtx, err := execute(map[string]any{"machines": []any{}}, "$.machines")
+		require.NoError(t, err)
+		assert.True(t, stateCtx.Passed)
+		assert.Empty(t, stateCtx.Outputs[ChannelNameItem])
+		require.Len(t, stateCtx.Outputs[ChannelNameDispatched], 1)
+	})
+
+	t.Run("non-list result returns error", func(t *testing.T) {
+		_, err := execute(map[string]any{"machines": "web-1"}, "$.machines")
+		require.ErrorContains(t, err, "must evaluate to a list")
+	})
+
+	t.Run("too many elements fails the execution", func(t *testing.T) {
+		stateCtx, err := execute(map[string]any{}, "1..1001")
+		require.NoError(t, err)
+		assert.True(t, stateCtx.Finished)
+		assert.False(t, stateCtx.Passed)
+	})
+}
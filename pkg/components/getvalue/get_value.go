@@ -0,0 +1,189 @@
+package getvalue
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ComponentName = "getValue"
+const PayloadType = "kv.read"
+const ChannelNameFound = "found"
+const ChannelNameNotFound = "notFound"
+
+func init() {
+	registry.RegisterComponent(ComponentName, &GetValue{})
+}
+
+type GetValue struct{}
+
+type Spec struct {
+	Key string `json:"key"`
+}
+
+type canvasKeyValueReadContext interface {
+	GetValue(key string) (any, bool, error)
+}
+
+func (c *GetValue) Name() string {
+	return ComponentName
+}
+
+func (c *GetValue) Label() string {
+	return "Get Value"
+}
+
+func (c *GetValue) Description() string {
+	return "Read a value from the canvas key-value store"
+}
+
+func (c *GetValue) Documentation() string {
+	return `The Get Value component reads a single value from the canvas key-value store.
+
+Values are shared by all runs of the canvas and are kept until they are overwritten, so they can be used for counters, locks and last-run markers.
+
+## Use Cases
+
+- Read the version of the last successful deployment
+- Check whether a lock was already taken by another run
+- Read a counter maintained by the Increment Counter component
+
+## How It Works
+
+1. Reads ` + "`key`" + ` from configuration
+2. Looks up the value stored under the key
+3. Emits ` + "`kv.read`" + ` to the ` + "`found`" + ` or ` + "`notFound`" + ` channel
+
+## Output Channels
+
+- **Found**: A value is stored under the key
+- **Not Found**: No value is stored under the key`
+}
+
+func (c *GetValue) Icon() string {
+	return "key-round"
+}
+
+func (c *GetValue) Color() string {
+	return "purple"
+}
+
+func (c *GetValue) ExampleOutput() map[string]any {
+	return exampleOutput()
+}
+
+func (c *GetValue) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: ChannelNameFound, Label: "Found"},
+		{Name: ChannelNameNotFound, Label: "Not Found"},
+	}
+}
+
+func (c *GetValue) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "key",
+			Label:       "Key",
+			Type:        configuration.FieldTypeString,
+			Description: "Key to read",
+			Placeholder: "e.g. last-deployment",
+			Required:    true,
+		},
+	}
+}
+
+func (c *GetValue) Setup(ctx core.SetupContext) error {
+	_, err := decodeSpec(ctx.Configuration)
+	return err
+}
+
+func (c *GetValue) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	readCtx, ok := ctx.CanvasMemory.(canvasKeyValueReadContext)
+	if !ok {
+		return fmt.Errorf("canvas key-value operations are not supported")
+	}
+
+	value, found, err := readCtx.GetValue(spec.Key)
+	if err != nil {
+		return fmt.Errorf("failed to read value: %w", err)
+	}
+
+	metadata := map[string]any{
+		"key":   spec.Key,
+		"found": found,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	channel := ChannelNameNotFound
+	if found {
+		channel = ChannelNameFound
+	}
+
+	return ctx.ExecutionState.Emit(
+		channel,
+		PayloadType,
+		[]any{
+			map[string]any{
+				"key":   spec.Key,
+				"value": value,
+				"found": found,
+			},
+		},
+	)
+}
+
+func decodeSpec(raw any) (Spec, error) {
+	var spec Spec
+	if err := mapstructure.Decode(raw, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Key = strings.TrimSpace(spec.Key)
+	if spec.Key == "" {
+		return Spec{}, fmt.Errorf("key is required")
+	}
+
+	return spec, nil
+}
+
+func (c *GetValue) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetValue) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *GetValue) HandleAction(ctx core.ActionContext) error {
+	return fmt.Errorf("getValue does not support actions")
+}
+
+func (c *GetValue) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetValue) HandlesTestMode() bool {
+	return true
+}
+
+func (c *GetValue) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *GetValue) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package getvalue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type canvasMemoryContext struct {
+	values map[string]any
+	err    error
+}
+
+func (c *canvasMemoryContext) Add(namespace string, values any) error {
+	return nil
+}
+
+func (c *canvasMemoryContext) Find(namespace string, matches map[string]any) ([]any, error) {
+	return []any{}, nil
+}
+
+func (c *canvasMemoryContext) FindFirst(namespace string, matches map[string]any) (any, error) {
+	return nil, nil
+}
+
+func (c *canvasMemoryContext) GetValue(key string) (any, bool, error) {
+	if c.err != nil {
+		return nil, false, c.err
+	}
+
+	value, ok := c.values[key]
+	return value, ok, nil
+}
+
+func TestGetValueExecute(t *testing.T) {
+	execute := func(memory core.CanvasMemoryContext, key string) (*contexts.ExecutionStateContext, error) {
+		execState := &contexts.ExecutionStateContext{}
+		err := (&GetValue{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"key": key},
+			Metadata:       &contexts.MetadataContext{},
+			CanvasMemory:   memory,
+			ExecutionState: execState,
+		})
+
+		return execState, err
+	}
+
+	t.Run("emits found with the stored value", func(t *testing.T) {
+		memory := &canvasMemoryContext{values: map[string]any{"last-deployment": "v1.4.2"}}
+		execState, err := execute(memory, " last-deployment ")
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelNameFound, execState.Channel)
+		assert.Equal(t, PayloadType, execState.Type)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, map[string]any{
+			"key":   "last-deployment",
+			"value": "v1.4.2",
+			"found": true,
+		}, execState.Payloads[0].(map[string]any)["data"])
+	})
+
+	t.Run("emits notFound for a missing key", func(t *testing.T) {
+		execState, err := execute(&canvasMemoryContext{values: map[string]any{}}, "missing")
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelNameNotFound, execState.Channel)
+	})
+
+	t.Run("returns store errors", func(t *testing.T) {
+		_, err := execute(&canvasMemoryContext{err: errors.New("boom")}, "key")
+		require.ErrorContains(t, err, "failed to read value: boom")
+	})
+
+	t.Run("requires a key", func(t *testing.T) {
+		_, err := execute(&canvasMemoryContext{}, "  ")
+		require.ErrorContains(t, err, "key is required")
+	})
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,6 +18,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/expressions"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/pkg/registry"
 )
@@ -35,20 +37,42 @@ type KeyValue struct {
 	Value string `json:"value"`
 }
 
+type Extraction struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+const (
+	AuthTypeBearer = "bearer"
+	AuthTypeBasic  = "basic"
+	AuthTypeAPIKey = "apiKey"
+)
+
+type Authentication struct {
+	Type       string                     `json:"type" mapstructure:"type"`
+	Token      configuration.SecretKeyRef `json:"token" mapstructure:"token"`
+	Username   string                     `json:"username" mapstructure:"username"`
+	Password   configuration.SecretKeyRef `json:"password" mapstructure:"password"`
+	HeaderName string                     `json:"headerName" mapstructure:"headerName"`
+	APIKey     configuration.SecretKeyRef `json:"apiKey" mapstructure:"apiKey"`
+}
+
 type Spec struct {
-	Method          string      `json:"method"`
-	URL             string      `json:"url"`
-	QueryParams     *[]KeyValue `json:"queryParams,omitempty"`
-	Headers         *[]Header   `json:"headers,omitempty"`
-	ContentType     *string     `json:"contentType,omitempty"`
-	JSON            *any        `json:"json,omitempty"`
-	XML             *string     `json:"xml,omitempty"`
-	Text            *string     `json:"text,omitempty"`
-	FormData        *[]KeyValue `json:"formData,omitempty"`
-	SuccessCodes    *string     `json:"successCodes,omitempty"`
-	TimeoutStrategy *string     `json:"timeoutStrategy,omitempty"`
-	TimeoutSeconds  *int        `json:"timeoutSeconds,omitempty"`
-	Retries         *int        `json:"retries,omitempty"`
+	Method          string          `json:"method"`
+	URL             string          `json:"url"`
+	QueryParams     *[]KeyValue     `json:"queryParams,omitempty"`
+	Headers         *[]Header       `json:"headers,omitempty"`
+	Authentication  *Authentication `json:"authentication,omitempty"`
+	ContentType     *string         `json:"contentType,omitempty"`
+	JSON            *any            `json:"json,omitempty"`
+	XML             *string         `json:"xml,omitempty"`
+	Text            *string         `json:"text,omitempty"`
+	FormData        *[]KeyValue     `json:"formData,omitempty"`
+	SuccessCodes    *string         `json:"successCodes,omitempty"`
+	Extract         *[]Extraction   `json:"extract,omitempty"`
+	TimeoutStrategy *string         `json:"timeoutStrategy,omitempty"`
+	TimeoutSeconds  *int            `json:"timeoutSeconds,omitempty"`
+	Retries         *int            `json:"retries,omitempty"`
 }
 
 type RetryMetadata struct {
@@ -96,6 +120,10 @@ func (e *HTTP) Documentation() string {
 - **Method**: HTTP method to use
 - **Query Parameters**: Optional URL query parameters
 - **Headers**: Custom HTTP headers (header names cannot use expressions)
+- **Authentication**: Optional credentials read from organization secrets:
+  - **Bearer token**: Sent as ` + "`Authorization: Bearer <token>`" + `
+  - **Basic auth**: Username and a password secret
+  - **API key**: Sent in a custom header, e.g. ` + "`X-API-Key`" + `
 - **Body**: Request body in various formats:
   - **JSON**: Structured JSON payload
   - **Form Data**: URL-encoded form data
@@ -108,6 +136,12 @@ The component emits the response with:
 - **status**: HTTP status code
 - **headers**: Response headers
 - **body**: Parsed response body (JSON if possible, otherwise string)
+- **extracted**: Values extracted from a JSON body, when **Extract** is configured
+
+### Extracting values
+
+Each extraction has a name and a JSONPath, e.g. ` + "`$.data.id`" + `, ` + "`$.items[0].name`" + ` or ` + "`$.items[*].id`" + `.
+Paths with ` + "`*`" + ` return a list of all matches, and paths that do not match anything return null.
 
 ## Error Handling & Retries
 
@@ -146,6 +180,14 @@ func (e *HTTP) Setup(ctx core.SetupContext) error {
 		return fmt.Errorf("method is required")
 	}
 
+	if err := validateAuthentication(spec.Authentication); err != nil {
+		return err
+	}
+
+	if err := validateExtractions(spec.Extract); err != nil {
+		return err
+	}
+
 	if spec.ContentType == nil {
 		return nil
 	}
@@ -273,6 +315,76 @@ func (e *HTTP) Configuration() []configuration.Field {
 			},
 			Default: "[{\"name\": \"X-Foo\", \"value\": \"Bar\"}]",
 		},
+		{
+			Name:        "authentication",
+			Label:       "Authentication",
+			Type:        configuration.FieldTypeObject,
+			Required:    false,
+			Togglable:   true,
+			Description: "Credentials sent with the request, read from organization secrets",
+			TypeOptions: &configuration.TypeOptions{
+				Object: &configuration.ObjectTypeOptions{
+					Schema: []configuration.Field{
+						{
+							Name:     "type",
+							Label:    "Type",
+							Type:     configuration.FieldTypeSelect,
+							Required: true,
+							Default:  AuthTypeBearer,
+							TypeOptions: &configuration.TypeOptions{
+								Select: &configuration.SelectTypeOptions{
+									Options: []configuration.FieldOption{
+										{Label: "Bearer token", Value: AuthTypeBearer},
+										{Label: "Basic auth", Value: AuthTypeBasic},
+										{Label: "API key header", Value: AuthTypeAPIKey},
+									},
+								},
+							},
+						},
+						{
+							Name:                 "token",
+							Label:                "Token",
+							Type:                 configuration.FieldTypeSecretKey,
+							Description:          "Secret key that holds the bearer token",
+							RequiredConditions:   []configuration.RequiredCondition{{Field: "type", Values: []string{AuthTypeBearer}}},
+							VisibilityConditions: []configuration.VisibilityCondition{{Field: "type", Values: []string{AuthTypeBearer}}},
+						},
+						{
+							Name:                 "username",
+							Label:                "Username",
+							Type:                 configuration.FieldTypeString,
+							RequiredConditions:   []configuration.RequiredCondition{{Field: "type", Values: []string{AuthTypeBasic}}},
+							VisibilityConditions: []configuration.VisibilityCondition{{Field: "type", Values: []string{AuthTypeBasic}}},
+						},
+						{
+							Name:                 "password",
+							Label:                "Password",
+							Type:                 configuration.FieldTypeSecretKey,
+							Description:          "Secret key that holds the password",
+							RequiredConditions:   []configuration.RequiredCondition{{Field: "type", Values: []string{AuthTypeBasic}}},
+							VisibilityConditions: []configuration.VisibilityCondition{{Field: "type", Values: []string{AuthTypeBasic}}},
+						},
+						{
+							Name:                 "headerName",
+							Label:                "Header Name",
+							Type:                 configuration.FieldTypeString,
+							Default:              "X-API-Key",
+							DisallowExpression:   true,
+							RequiredConditions:   []configuration.RequiredCondition{{Field: "type", Values: []string{AuthTypeAPIKey}}},
+							VisibilityConditions: []configuration.VisibilityCondition{{Field: "type", Values: []string{AuthTypeAPIKey}}},
+						},
+						{
+							Name:                 "apiKey",
+							Label:                "API Key",
+							Type:                 configuration.FieldTypeSecretKey,
+							Description:          "Secret key that holds the API key",
+							RequiredConditions:   []configuration.RequiredCondition{{Field: "type", Values: []string{AuthTypeAPIKey}}},
+							VisibilityConditions: []configuration.VisibilityCondition{{Field: "type", Values: []string{AuthTypeAPIKey}}},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:        "contentType",
 			Label:       "Body",
@@ -377,6 +489,40 @@ func (e *HTTP) Configuration() []configuration.Field {
 			Description: "Comma-separated list of success status codes (e.g., 200, 201, 2xx). Leave empty for default 2xx behavior",
 			Default:     "2xx",
 		},
+		{
+			Name:        "extract",
+			Label:       "Extract",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Values to extract from a JSON response body into the output",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Value",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:               "name",
+								Type:               configuration.FieldTypeString,
+								Label:              "Name",
+								Required:           true,
+								Placeholder:        "userId",
+								DisallowExpression: true,
+							},
+							{
+								Name:               "path",
+								Type:               configuration.FieldTypeString,
+								Label:              "JSONPath",
+								Required:           true,
+								Placeholder:        "$.data.id",
+								DisallowExpression: true,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:        "timeoutStrategy",
 			Type:        configuration.FieldTypeSelect,
@@ -488,13 +634,39 @@ func (e *HTTP) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	//
+	// In test mode, only requests that do not change anything are sent.
+	//
+	if ctx.TestMode && !isReadOnlyMethod(spec.Method) {
+		if ctx.Logs != nil {
+			ctx.Logs.Printf("Test mode: %s request to %s was not sent", spec.Method, spec.URL)
+		}
+
+		return core.EmitExampleOutput(ctx, e)
+	}
+
 	return e.executeHTTPRequest(ctx, spec, retryMetadata)
 }
 
+func isReadOnlyMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
 func (e *HTTP) executeHTTPRequest(ctx core.ExecutionContext, spec Spec, retryMetadata RetryMetadata) error {
 	currentTimeout := e.calculateTimeoutForAttempt(retryMetadata.TimeoutStrategy, retryMetadata.TimeoutSeconds, retryMetadata.Attempt)
 
-	resp, err := e.executeRequest(ctx.HTTP, spec, currentTimeout)
+	// Missing or unreadable secrets will not fix themselves, so they are not retried.
+	authHeaders, err := e.authenticationHeaders(ctx.Secrets, spec.Authentication)
+	if err != nil {
+		return e.handleRequestError(ctx, err, retryMetadata.Attempt+1)
+	}
+
+	resp, err := e.executeRequest(ctx.HTTP, spec, authHeaders, currentTimeout)
 	if err != nil {
 		if retryMetadata.Attempt < retryMetadata.MaxRetries {
 			return e.scheduleRetry(ctx, err.Error(), retryMetadata)
@@ -557,6 +729,7 @@ func (e *HTTP) handleRetryRequest(ctx core.ActionContext) error {
 		Requests:       ctx.Requests,
 		Auth:           ctx.Auth,
 		HTTP:           ctx.HTTP,
+		Secrets:        ctx.Secrets,
 	}
 
 	return e.executeHTTPRequest(execCtx, spec, retryMetadata)
@@ -578,7 +751,7 @@ func (e *HTTP) calculateTimeoutForAttempt(strategy string, timeoutSeconds int, a
 	return baseTimeout
 }
 
-func (e *HTTP) executeRequest(httpCtx core.HTTPContext, spec Spec, timeout time.Duration) (*http.Response, error) {
+func (e *HTTP) executeRequest(httpCtx core.HTTPContext, spec Spec, authHeaders http.Header, timeout time.Duration) (*http.Response, error) {
 	var body io.Reader
 	var contentType string
 	var err error
@@ -617,6 +790,11 @@ func (e *HTTP) executeRequest(httpCtx core.HTTPContext, spec Spec, timeout time.
 		req.Header.Set("Content-Type", contentType)
 	}
 
+	// Custom headers are applied afterwards, so they can override authentication headers.
+	for name, values := range authHeaders {
+		req.Header[name] = values
+	}
+
 	if spec.Headers != nil {
 		for _, header := range *spec.Headers {
 			req.Header.Set(header.Name, header.Value)
@@ -729,6 +907,10 @@ func (e *HTTP) processResponse(ctx core.ExecutionContext, resp *http.Response, s
 		"body":    bodyData,
 	}
 
+	if spec.Extract != nil && len(*spec.Extract) > 0 {
+		response["extracted"] = e.extractValues(bodyData, *spec.Extract)
+	}
+
 	var isSuccess bool
 	if spec.SuccessCodes != nil && *spec.SuccessCodes != "" {
 		isSuccess = e.matchesSuccessCode(resp.StatusCode, *spec.SuccessCodes)
@@ -846,10 +1028,135 @@ func (e *HTTP) serializePayload(spec Spec) (io.Reader, string, error) {
 	}
 }
 
+func validateAuthentication(auth *Authentication) error {
+	if auth == nil || auth.Type == "" {
+		return nil
+	}
+
+	switch auth.Type {
+	case AuthTypeBearer:
+		if !auth.Token.IsSet() {
+			return fmt.Errorf("authentication token is required")
+		}
+
+	case AuthTypeBasic:
+		if auth.Username == "" {
+			return fmt.Errorf("authentication username is required")
+		}
+
+		if !auth.Password.IsSet() {
+			return fmt.Errorf("authentication password is required")
+		}
+
+	case AuthTypeAPIKey:
+		if strings.TrimSpace(auth.HeaderName) == "" {
+			return fmt.Errorf("authentication header name is required")
+		}
+
+		if !auth.APIKey.IsSet() {
+			return fmt.Errorf("authentication API key is required")
+		}
+
+	default:
+		return fmt.Errorf("unsupported authentication type: %s", auth.Type)
+	}
+
+	return nil
+}
+
+func validateExtractions(extractions *[]Extraction) error {
+	if extractions == nil {
+		return nil
+	}
+
+	names := map[string]bool{}
+	for _, extraction := range *extractions {
+		if extraction.Name == "" {
+			return fmt.Errorf("extraction name is required")
+		}
+
+		if names[extraction.Name] {
+			return fmt.Errorf("duplicate extraction name: %s", extraction.Name)
+		}
+
+		names[extraction.Name] = true
+		if err := expressions.ValidateJSONPath(extraction.Path); err != nil {
+			return fmt.Errorf("invalid path for %s: %w", extraction.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *HTTP) authenticationHeaders(secrets core.SecretsContext, auth *Authentication) (http.Header, error) {
+	headers := http.Header{}
+	if auth == nil || auth.Type == "" {
+		return headers, nil
+	}
+
+	if secrets == nil {
+		return nil, fmt.Errorf("secrets are not available")
+	}
+
+	switch auth.Type {
+	case AuthTypeBearer:
+		token, err := secrets.GetKey(auth.Token.Secret, auth.Token.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get authentication token: %w", err)
+		}
+
+		headers.Set("Authorization", "Bearer "+string(token))
+
+	case AuthTypeBasic:
+		password, err := secrets.GetKey(auth.Password.Secret, auth.Password.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get authentication password: %w", err)
+		}
+
+		credentials := base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + string(password)))
+		headers.Set("Authorization", "Basic "+credentials)
+
+	case AuthTypeAPIKey:
+		apiKey, err := secrets.GetKey(auth.APIKey.Secret, auth.APIKey.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get authentication API key: %w", err)
+		}
+
+		headers.Set(auth.HeaderName, string(apiKey))
+
+	default:
+		return nil, fmt.Errorf("unsupported authentication type: %s", auth.Type)
+	}
+
+	return headers, nil
+}
+
+func (e *HTTP) extractValues(body any, extractions []Extraction) map[string]any {
+	extracted := map[string]any{}
+	for _, extraction := range extractions {
+		value, found, err := expressions.EvaluateJSONPath(body, extraction.Path)
+		if err != nil || !found {
+			extracted[extraction.Name] = nil
+			continue
+		}
+
+		extracted[extraction.Name] = value
+	}
+
+	return extracted
+}
+
 func (e *HTTP) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+/*
+ * In test mode, HTTP only sends requests that do not change anything.
+ */
+func (e *HTTP) HandlesTestMode() bool {
+	return true
+}
+
 func (e *HTTP) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
@@ -1249,3 +1249,255 @@ func TestHTTP__RetryMetadata_Progression(t *testing.T) {
 
 	assert.Equal(t, int32(3), atomic.LoadInt32(&requestCount))
 }
+
+func TestHTTP__Setup__AuthenticationAndExtraction(t *testing.T) {
+	h := &HTTP{}
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		expectedErr string
+	}{
+		{
+			name: "bearer token",
+			config: map[string]any{
+				"method": "GET",
+				"url":    "https://api.example.com",
+				"authentication": map[string]any{
+					"type":  "bearer",
+					"token": map[string]any{"secret": "api", "key": "token"},
+				},
+			},
+		},
+		{
+			name: "bearer without token",
+			config: map[string]any{
+				"method":         "GET",
+				"url":            "https://api.example.com",
+				"authentication": map[string]any{"type": "bearer"},
+			},
+			expectedErr: "authentication token is required",
+		},
+		{
+			name: "basic without username",
+			config: map[string]any{
+				"method": "GET",
+				"url":    "https://api.example.com",
+				"authentication": map[string]any{
+					"type":     "basic",
+					"password": map[string]any{"secret": "api", "key": "password"},
+				},
+			},
+			expectedErr: "authentication username is required",
+		},
+		{
+			name: "unknown authentication type",
+			config: map[string]any{
+				"method":         "GET",
+				"url":            "https://api.example.com",
+				"authentication": map[string]any{"type": "digest"},
+			},
+			expectedErr: "unsupported authentication type",
+		},
+		{
+			name: "invalid extraction path",
+			config: map[string]any{
+				"method":  "GET",
+				"url":     "https://api.example.com",
+				"extract": []map[string]any{{"name": "id", "path": "data.id"}},
+			},
+			expectedErr: "invalid path for id",
+		},
+		{
+			name: "duplicate extraction name",
+			config: map[string]any{
+				"method": "GET",
+				"url":    "https://api.example.com",
+				"extract": []map[string]any{
+					{"name": "id", "path": "$.id"},
+					{"name": "id", "path": "$.data.id"},
+				},
+			},
+			expectedErr: "duplicate extraction name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := h.Setup(core.SetupContext{Configuration: tt.config})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestHTTP__Execute__Authentication(t *testing.T) {
+	secrets := &contexts.SecretsContext{
+		Values: map[string][]byte{
+			"api/token":    []byte("token123"),
+			"api/password": []byte("s3cret"),
+			"api/key":      []byte("key123"),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		auth   map[string]any
+		header string
+		value  string
+	}{
+		{
+			name:   "bearer token",
+			auth:   map[string]any{"type": "bearer", "token": map[string]any{"secret": "api", "key": "token"}},
+			header: "Authorization",
+			value:  "Bearer token123",
+		},
+		{
+			name: "basic auth",
+			auth: map[string]any{
+				"type":     "basic",
+				"username": "superplane",
+				"password": map[string]any{"secret": "api", "key": "password"},
+			},
+			header: "Authorization",
+			value:  "Basic c3VwZXJwbGFuZTpzM2NyZXQ=",
+		},
+		{
+			name: "API key header",
+			auth: map[string]any{
+				"type":       "apiKey",
+				"headerName": "X-API-Key",
+				"apiKey":     map[string]any{"secret": "api", "key": "key"},
+			},
+			header: "X-API-Key",
+			value:  "key123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.value, r.Header.Get(tt.header))
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			h := &HTTP{}
+			ctx, stateCtx, _ := createExecutionContext(map[string]any{
+				"method":         "GET",
+				"url":            server.URL,
+				"authentication": tt.auth,
+			})
+
+			ctx.Secrets = secrets
+			err := h.Execute(ctx)
+			assert.NoError(t, err)
+			assert.True(t, stateCtx.Passed)
+		})
+	}
+
+	t.Run("missing secret -> fails without sending the request", func(t *testing.T) {
+		var requestCount int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		h := &HTTP{}
+		ctx, stateCtx, _ := createExecutionContext(map[string]any{
+			"method": "GET",
+			"url":    server.URL,
+			"authentication": map[string]any{
+				"type":  "bearer",
+				"token": map[string]any{"secret": "missing", "key": "token"},
+			},
+			"timeoutStrategy": "fixed",
+			"timeoutSeconds":  1,
+			"retries":         3,
+		})
+
+		ctx.Secrets = secrets
+		ctx.Requests = &contexts.RequestContext{}
+		err := h.Execute(ctx)
+		assert.NoError(t, err)
+		assert.True(t, stateCtx.Finished)
+		assert.False(t, stateCtx.Passed)
+		assert.Contains(t, stateCtx.FailureMessage, "cannot get authentication token")
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requestCount))
+	})
+}
+
+func TestHTTP__Execute__ExtractsValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":{"id":"user-1","roles":[{"name":"admin"},{"name":"viewer"}]}}`))
+	}))
+	defer server.Close()
+
+	h := &HTTP{}
+	ctx, stateCtx, _ := createExecutionContext(map[string]any{
+		"method": "GET",
+		"url":    server.URL,
+		"extract": []map[string]any{
+			{"name": "id", "path": "$.data.id"},
+			{"name": "firstRole", "path": "$.data.roles[0].name"},
+			{"name": "roles", "path": "$.data.roles[*].name"},
+			{"name": "missing", "path": "$.data.email"},
+		},
+	})
+
+	err := h.Execute(ctx)
+	require.NoError(t, err)
+	require.True(t, stateCtx.Passed)
+
+	response := stateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"id":        "user-1",
+		"firstRole": "admin",
+		"roles":     []any{"admin", "viewer"},
+		"missing":   nil,
+	}, response["extracted"])
+}
+
+func TestHTTP__Execute__TestMode(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	h := &HTTP{}
+
+	t.Run("write request -> not sent, example output emitted", func(t *testing.T) {
+		ctx, stateCtx, _ := createExecutionContext(map[string]any{
+			"method": "POST",
+			"url":    server.URL,
+		})
+
+		ctx.TestMode = true
+		require.NoError(t, h.Execute(ctx))
+		assert.Zero(t, requests)
+		assert.True(t, stateCtx.Passed)
+		assert.Equal(t, "http.request.finished", stateCtx.Type)
+		assert.Equal(t, h.ExampleOutput()["data"], stateCtx.Payloads[0].(map[string]any)["data"])
+	})
+
+	t.Run("read request -> sent", func(t *testing.T) {
+		ctx, stateCtx, _ := createExecutionContext(map[string]any{
+			"method": "GET",
+			"url":    server.URL,
+		})
+
+		ctx.TestMode = true
+		require.NoError(t, h.Execute(ctx))
+		assert.Equal(t, 1, requests)
+		assert.True(t, stateCtx.Passed)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/expressions"
 	"github.com/superplanehq/superplane/pkg/registry"
 )
 
@@ -193,7 +194,7 @@ func buildExpressionEnv(input any, sourceNodeID string) map[string]any {
 }
 
 func expressionOptions(env map[string]any) []expr.Option {
-	return []expr.Option{
+	options := []expr.Option{
 		expr.Env(env),
 		expr.AsBool(),
 		expr.WithContext("ctx"),
@@ -236,6 +237,8 @@ func expressionOptions(env map[string]any) []expr.Option {
 			return nil, nil
 		}),
 	}
+
+	return append(options, expressions.Functions()...)
 }
 
 func parseDepthValue(param any) (int, error) {
@@ -284,6 +287,10 @@ func (f *If) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (f *If) HandlesTestMode() bool {
+	return true
+}
+
 func (f *If) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
@@ -40,6 +40,12 @@ func TestIf_Execute_EmitsEmptyEvents(t *testing.T) {
 			inputData:       map[string]any{"test": "value"},
 			expectedChannel: "false",
 		},
+		{
+			name:            "if with expression functions emits empty event",
+			configuration:   map[string]any{"expression": "toInt(jsonpath($, '$.items[0].count')) > 1"},
+			inputData:       map[string]any{"items": []any{map[string]any{"count": "2"}}},
+			expectedChannel: "true",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,97 @@
+package incrementcounter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type canvasMemoryContext struct {
+	values map[string]any
+}
+
+func (c *canvasMemoryContext) Add(namespace string, values any) error {
+	return nil
+}
+
+func (c *canvasMemoryContext) Find(namespace string, matches map[string]any) ([]any, error) {
+	return []any{}, nil
+}
+
+func (c *canvasMemoryContext) FindFirst(namespace string, matches map[string]any) (any, error) {
+	return nil, nil
+}
+
+func (c *canvasMemoryContext) IncrementValue(key string, amount float64) (float64, error) {
+	current, ok := c.values[key]
+	if !ok {
+		current = float64(0)
+	}
+
+	n, ok := current.(float64)
+	if !ok {
+		return 0, models.ErrCanvasKeyValueNotNumber
+	}
+
+	c.values[key] = n + amount
+	return n + amount, nil
+}
+
+func TestIncrementCounterExecute(t *testing.T) {
+	execute := func(memory *canvasMemoryContext, configuration map[string]any) (*contexts.ExecutionStateContext, error) {
+		execState := &contexts.ExecutionStateContext{}
+		err := (&IncrementCounter{}).Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			Metadata:       &contexts.MetadataContext{},
+			CanvasMemory:   memory,
+			ExecutionState: execState,
+		})
+
+		return execState, err
+	}
+
+	t.Run("starts from zero and uses the default amount", func(t *testing.T) {
+		memory := &canvasMemoryContext{values: map[string]any{}}
+		execState, err := execute(memory, map[string]any{"key": "deployments"})
+
+		require.NoError(t, err)
+		assert.True(t, execState.Passed)
+		assert.Equal(t, core.DefaultOutputChannel.Name, execState.Channel)
+		assert.Equal(t, map[string]any{
+			"key":    "deployments",
+			"amount": float64(1),
+			"value":  float64(1),
+		}, execState.Payloads[0].(map[string]any)["data"])
+	})
+
+	t.Run("amount from string configuration", func(t *testing.T) {
+		memory := &canvasMemoryContext{values: map[string]any{"deployments": float64(10)}}
+		_, err := execute(memory, map[string]any{"key": "deployments", "amount": "-3"})
+
+		require.NoError(t, err)
+		assert.Equal(t, float64(7), memory.values["deployments"])
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		_, err := execute(&canvasMemoryContext{}, map[string]any{"key": "deployments", "amount": "many"})
+		require.ErrorContains(t, err, "amount must be a number")
+	})
+
+	t.Run("non-numeric value fails the execution", func(t *testing.T) {
+		memory := &canvasMemoryContext{values: map[string]any{"deployments": "v1"}}
+		execState, err := execute(memory, map[string]any{"key": "deployments"})
+
+		require.NoError(t, err)
+		assert.True(t, execState.Finished)
+		assert.False(t, execState.Passed)
+		assert.Contains(t, execState.FailureMessage, "not a number")
+	})
+}
+
+func TestIncrementCounter_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&IncrementCounter{}))
+}
@@ -2,8 +2,10 @@ package merge
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/expr-lang/expr"
@@ -11,6 +13,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/expressions"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/pkg/registry"
 )
@@ -26,6 +29,11 @@ const (
 	ChannelNameFail    = "fail"
 )
 
+const (
+	WaitForSources = "sources"
+	WaitForCount   = "count"
+)
+
 func init() {
 	registry.RegisterComponent("merge", &Merge{})
 }
@@ -53,6 +61,8 @@ func (m *Merge) Documentation() string {
 
 ## Configuration Options
 
+- **Wait for**: Wait for one event from each upstream node (default), or for a fixed number of events
+- **Enable Correlation Key**: Group events by an expression instead of by run, so events from separate runs can be merged, e.g. one deploy event per region for the same release
 - **Enable Timeout**: Cancel merge after a specified time if not all inputs are received
 - **Enable Conditional Stop**: Stop waiting early when a condition is met (e.g., if one branch fails)
 
@@ -65,7 +75,8 @@ func (m *Merge) Documentation() string {
 ## Behavior
 
 - Tracks distinct source nodes (ignoring multiple channels from the same source)
-- Combines all received event data into the output
+- Combines all received event data into the output, under ` + "`inputs`" + `
+- With a correlation key, a new group starts for the key once the previous one finished
 - Supports timeout to prevent indefinite waiting
 - Supports conditional early stop based on expression evaluation`
 }
@@ -81,6 +92,16 @@ func (m *Merge) OutputChannels(configuration any) []core.OutputChannel {
 }
 
 type Spec struct {
+	// WaitFor controls when the merge completes: once every distinct
+	// upstream source sent an event, or once Count events were received.
+	WaitFor string `json:"waitFor" mapstructure:"waitFor"`
+	Count   int    `json:"count" mapstructure:"count"`
+
+	// EnableCorrelationKey toggles grouping by CorrelationKey
+	// instead of by root event.
+	EnableCorrelationKey bool   `json:"enableCorrelationKey" mapstructure:"enableCorrelationKey"`
+	CorrelationKey       string `json:"correlationKey" mapstructure:"correlationKey"`
+
 	// EnableTimeout toggles the execution timeout feature
 	EnableTimeout bool `json:"enableTimeout" mapstructure:"enableTimeout"`
 
@@ -99,8 +120,97 @@ type Spec struct {
 	StopIfExpression string `json:"stopIfExpression" mapstructure:"stopIfExpression"`
 }
 
+// Version 2 added the toggles that show the timeout and stop condition fields.
+// Configurations from before have the fields set without their toggles,
+// so the fields would be hidden, and dropped the next time the node is saved.
+func (m *Merge) ConfigurationVersion() int {
+	return 2
+}
+
+func (m *Merge) UpgradeConfiguration(version int, config map[string]any) (map[string]any, error) {
+	if version != 1 {
+		return nil, fmt.Errorf("unknown configuration version %d", version)
+	}
+
+	upgraded := maps.Clone(config)
+	if _, ok := config["enableTimeout"]; !ok {
+		spec := Spec{}
+		if err := mapstructure.Decode(config, &spec); err == nil {
+			upgraded["enableTimeout"] = spec.ExecutionTimeout.Value > 0
+		}
+	}
+
+	if _, ok := config["enableStopIf"]; !ok {
+		expression, _ := config["stopIfExpression"].(string)
+		upgraded["enableStopIf"] = strings.TrimSpace(expression) != ""
+	}
+
+	return upgraded, nil
+}
+
 func (m *Merge) Configuration() []configuration.Field {
 	return []configuration.Field{
+		{
+			Name:     "waitFor",
+			Label:    "Wait for",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  WaitForSources,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "All upstream nodes", Value: WaitForSources},
+						{Label: "Number of events", Value: WaitForCount},
+					},
+				},
+			},
+		},
+		{
+			Name:        "count",
+			Label:       "Number of events",
+			Type:        configuration.FieldTypeNumber,
+			Description: "Number of events to collect before emitting",
+			Default:     2,
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "waitFor", Values: []string{WaitForCount}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "waitFor", Values: []string{WaitForCount}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: intPtr(1),
+				},
+			},
+		},
+		{
+			Name:        "enableCorrelationKey",
+			Label:       "Enable Correlation Key",
+			Type:        configuration.FieldTypeBool,
+			Description: "Group events by a key instead of by run.",
+			Required:    false,
+			Default:     false,
+		},
+		{
+			Name:        "correlationKey",
+			Label:       "Correlation Key",
+			Type:        configuration.FieldTypeExpression,
+			Description: "Events with the same key are merged together.",
+			Placeholder: "e.g. $[\"Deploy\"].version",
+			Required:    false,
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "enableCorrelationKey",
+					Values: []string{"true"},
+				},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{
+					Field:  "enableCorrelationKey",
+					Values: []string{"true"},
+				},
+			},
+		},
 		{
 			Name:        "enableTimeout",
 			Label:       "Enable Timeout",
@@ -181,6 +291,20 @@ func (m *Merge) Actions() []core.Action {
 }
 
 func (m *Merge) Setup(ctx core.SetupContext) error {
+	spec := &Spec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if spec.WaitFor == WaitForCount && spec.Count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+
+	if spec.EnableCorrelationKey && spec.CorrelationKey == "" {
+		return fmt.Errorf("correlation key is required")
+	}
+
 	return nil
 }
 
@@ -191,9 +315,22 @@ func (m *Merge) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, erro
 		return nil, fmt.Errorf("error decoding configuration: %v", err)
 	}
 
-	executionCtx, err := m.findOrCreateExecution(ctx, ctx.RootEventID)
-	if err != nil {
-		return nil, fmt.Errorf("error finding or creating execution: %v", err)
+	var executionCtx *core.ExecutionContext
+	if spec.EnableCorrelationKey && spec.CorrelationKey != "" {
+		key, err := evaluateCorrelationKey(ctx, spec.CorrelationKey)
+		if err != nil {
+			return nil, err
+		}
+
+		executionCtx, err = m.findOrCreateCorrelatedExecution(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("error finding or creating execution: %v", err)
+		}
+	} else {
+		executionCtx, err = m.findOrCreateExecution(ctx, ctx.RootEventID)
+		if err != nil {
+			return nil, fmt.Errorf("error finding or creating execution: %v", err)
+		}
 	}
 
 	// If the execution is already finished (e.g., timed out or stopped early),
@@ -272,7 +409,7 @@ func (m *Merge) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, erro
 		}
 	}
 
-	if len(md.Sources) >= incoming {
+	if spec.completed(md, incoming) {
 		return &executionCtx.ID, executionCtx.ExecutionState.Emit(
 			ChannelNameSuccess,
 			"merge.finished",
@@ -283,6 +420,42 @@ func (m *Merge) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, erro
 	return nil, nil
 }
 
+func (s *Spec) completed(md *ExecutionMetadata, incoming int) bool {
+	if s.WaitFor == WaitForCount {
+		return len(md.EventIDs) >= s.Count
+	}
+
+	return len(md.Sources) >= incoming
+}
+
+func evaluateCorrelationKey(ctx core.ProcessQueueContext, expression string) (string, error) {
+	env, err := expressionEnv(ctx, expression)
+	if err != nil {
+		return "", err
+	}
+
+	vm, err := expr.Compile(expression, append(commonExpressionOptions(env), expr.AsAny())...)
+	if err != nil {
+		return "", fmt.Errorf("correlationKey compilation failed: %w", err)
+	}
+
+	out, err := expr.Run(vm, env)
+	if err != nil {
+		return "", fmt.Errorf("correlationKey evaluation failed: %w", err)
+	}
+
+	if out == nil {
+		return "", fmt.Errorf("correlationKey evaluated to nil")
+	}
+
+	key := fmt.Sprintf("%v", out)
+	if key == "" {
+		return "", fmt.Errorf("correlationKey evaluated to an empty string")
+	}
+
+	return key, nil
+}
+
 func expressionEnv(ctx core.ProcessQueueContext, expression string) (map[string]any, error) {
 	if ctx.ExpressionEnv != nil {
 		return ctx.ExpressionEnv(expression)
@@ -322,9 +495,12 @@ func buildExpressionEnv(input any, sourceNodeID string) map[string]any {
 }
 
 func expressionOptions(env map[string]any) []expr.Option {
-	return []expr.Option{
+	return append(commonExpressionOptions(env), expr.AsBool())
+}
+
+func commonExpressionOptions(env map[string]any) []expr.Option {
+	options := []expr.Option{
 		expr.Env(env),
-		expr.AsBool(),
 		expr.WithContext("ctx"),
 		expr.Timezone(time.UTC.String()),
 		expr.Function("root", func(params ...any) (any, error) {
@@ -365,6 +541,8 @@ func expressionOptions(env map[string]any) []expr.Option {
 			return nil, nil
 		}),
 	}
+
+	return append(options, expressions.Functions()...)
 }
 
 func parseDepthValue(param any) (int, error) {
@@ -433,6 +611,35 @@ func (m *Merge) findOrCreateExecution(ctx core.ProcessQueueContext, mergeGroup s
 	return executionCtx, nil
 }
 
+// findOrCreateCorrelatedExecution finds the open execution for a correlation key.
+// Since keys can be reused once a group finishes, each finished group moves the
+// lookup to the next generation of the key, until an open or new one is found.
+func (m *Merge) findOrCreateCorrelatedExecution(ctx core.ProcessQueueContext, key string) (*core.ExecutionContext, error) {
+	for generation := 0; ; generation++ {
+		group := correlationGroup(key, generation)
+		executionCtx, err := ctx.FindExecutionByKV("merge_group", group)
+		if err != nil {
+			return nil, err
+		}
+
+		if executionCtx == nil {
+			return m.findOrCreateExecution(ctx, group)
+		}
+
+		if !executionCtx.ExecutionState.IsFinished() {
+			return executionCtx, nil
+		}
+	}
+}
+
+func correlationGroup(key string, generation int) string {
+	if generation == 0 {
+		return "key:" + key
+	}
+
+	return fmt.Sprintf("key:%s#%d", key, generation)
+}
+
 func (m *Merge) addEventToMetadata(ctx core.ProcessQueueContext, executionCtx *core.ExecutionContext) (*ExecutionMetadata, error) {
 	md := &ExecutionMetadata{}
 	err := mapstructure.Decode(executionCtx.Metadata.Get(), md)
@@ -441,6 +648,11 @@ func (m *Merge) addEventToMetadata(ctx core.ProcessQueueContext, executionCtx *c
 	}
 
 	md.EventIDs = append(md.EventIDs, ctx.EventID)
+	md.Inputs = append(md.Inputs, Input{
+		EventID: ctx.EventID,
+		Source:  ctx.SourceNodeID,
+		Data:    ctx.Input,
+	})
 
 	//
 	// Track distinct source nodes that reached this merge
@@ -522,6 +734,10 @@ func (m *Merge) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (m *Merge) HandlesTestMode() bool {
+	return true
+}
+
 func (m *Merge) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
@@ -542,3 +758,7 @@ func durationFrom(value int, unit string) time.Duration {
 func (m *Merge) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
+
+func intPtr(v int) *int {
+	return &v
+}
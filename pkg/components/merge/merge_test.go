@@ -14,6 +14,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/pkg/workers/contexts"
+	supportcontexts "github.com/superplanehq/superplane/test/support/contexts"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -177,6 +178,118 @@ func TestMerge_ExpressionOptions_RootAndPrevious(t *testing.T) {
 	assert.True(t, out.(bool))
 }
 
+// fakeQueue keeps merge executions in memory, keyed
+// by their KVs, to exercise ProcessQueueItem without a database.
+type fakeQueue struct {
+	executions []*core.ExecutionContext
+	incoming   int
+}
+
+func (q *fakeQueue) context(configuration map[string]any, rootEventID, eventID, source string, input any) core.ProcessQueueContext {
+	return core.ProcessQueueContext{
+		RootEventID:   rootEventID,
+		EventID:       eventID,
+		SourceNodeID:  source,
+		Configuration: configuration,
+		Input:         input,
+		DequeueItem:   func() error { return nil },
+		UpdateNodeState: func(state string) error {
+			return nil
+		},
+		CountDistinctIncomingSources: func() (int, error) {
+			return q.incoming, nil
+		},
+		CreateExecution: func() (*core.ExecutionContext, error) {
+			executionCtx := &core.ExecutionContext{
+				ID:             uuid.New(),
+				Metadata:       &supportcontexts.MetadataContext{},
+				ExecutionState: &supportcontexts.ExecutionStateContext{KVs: map[string]string{}},
+			}
+
+			q.executions = append(q.executions, executionCtx)
+			return executionCtx, nil
+		},
+		FindExecutionByKV: func(key, value string) (*core.ExecutionContext, error) {
+			for _, executionCtx := range q.executions {
+				state := executionCtx.ExecutionState.(*supportcontexts.ExecutionStateContext)
+				if state.KVs[key] == value {
+					return executionCtx, nil
+				}
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+func (q *fakeQueue) state(i int) *supportcontexts.ExecutionStateContext {
+	return q.executions[i].ExecutionState.(*supportcontexts.ExecutionStateContext)
+}
+
+func TestMerge_WaitForCount(t *testing.T) {
+	q := &fakeQueue{incoming: 1}
+	m := &Merge{}
+	config := map[string]any{"waitFor": WaitForCount, "count": 3}
+
+	for i := 1; i <= 3; i++ {
+		_, err := m.ProcessQueueItem(q.context(config, "root-1", fmt.Sprintf("event-%d", i), "deploy", map[string]any{"n": i}))
+		require.NoError(t, err)
+		assert.Equal(t, i == 3, q.state(0).Finished)
+	}
+
+	require.Len(t, q.executions, 1)
+	assert.Equal(t, ChannelNameSuccess, q.state(0).Channel)
+
+	md := q.executions[0].Metadata.Get().(*ExecutionMetadata)
+	require.Len(t, md.Inputs, 3)
+	assert.Equal(t, map[string]any{"n": 2}, md.Inputs[1].Data)
+	assert.Equal(t, "deploy", md.Inputs[1].Source)
+}
+
+func TestMerge_CorrelationKey(t *testing.T) {
+	q := &fakeQueue{incoming: 1}
+	m := &Merge{}
+	config := map[string]any{
+		"waitFor":              WaitForCount,
+		"count":                2,
+		"enableCorrelationKey": true,
+		"correlationKey":       `$.version`,
+	}
+
+	process := func(root, event, version string) {
+		_, err := m.ProcessQueueItem(q.context(config, root, event, "", map[string]any{"version": version}))
+		require.NoError(t, err)
+	}
+
+	// Events from different runs are grouped by key.
+	process("root-1", "event-1", "v1")
+	process("root-2", "event-2", "v2")
+	process("root-3", "event-3", "v1")
+
+	require.Len(t, q.executions, 2)
+	assert.True(t, q.state(0).Finished)
+	assert.Equal(t, "key:v1", q.state(0).KVs["merge_group"])
+	assert.False(t, q.state(1).Finished)
+
+	// Once the group for a key finished, the next event for it starts a new group.
+	process("root-4", "event-4", "v1")
+	require.Len(t, q.executions, 3)
+	assert.Equal(t, "key:v1#1", q.state(2).KVs["merge_group"])
+	assert.False(t, q.state(2).Finished)
+}
+
+func TestMerge_Setup(t *testing.T) {
+	m := &Merge{}
+
+	err := m.Setup(core.SetupContext{Configuration: map[string]any{"waitFor": WaitForCount, "count": 0}})
+	require.ErrorContains(t, err, "count must be at least 1")
+
+	err = m.Setup(core.SetupContext{Configuration: map[string]any{"enableCorrelationKey": true}})
+	require.ErrorContains(t, err, "correlation key is required")
+
+	require.NoError(t, m.Setup(core.SetupContext{Configuration: map[string]any{}}))
+}
+
 type MergeTestSteps struct {
 	t  *testing.T
 	Tx *gorm.DB
@@ -602,3 +715,37 @@ func (s *MergeTestSteps) AssertNodeIsAllowedToProcessNextQueueItem() {
 	require.NoError(s.t, s.Tx.Where("node_id = ?", s.MergeNode.NodeID).First(&node).Error)
 	assert.Equal(s.t, models.CanvasNodeStateReady, node.State)
 }
+
+func TestMerge_UpgradeConfiguration(t *testing.T) {
+	m := &Merge{}
+
+	t.Run("enables the toggles of fields that were set", func(t *testing.T) {
+		config, version, err := core.UpgradeConfiguration(m, 0, map[string]any{
+			"executionTimeout": map[string]any{"value": 10, "unit": "minutes"},
+			"stopIfExpression": "$.result == 'failed'",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+		assert.Equal(t, true, config["enableTimeout"])
+		assert.Equal(t, true, config["enableStopIf"])
+	})
+
+	t.Run("disables the toggles of fields that were not set", func(t *testing.T) {
+		config, _, err := core.UpgradeConfiguration(m, 1, map[string]any{"waitFor": WaitForSources})
+		require.NoError(t, err)
+		assert.Equal(t, false, config["enableTimeout"])
+		assert.Equal(t, false, config["enableStopIf"])
+		assert.Equal(t, WaitForSources, config["waitFor"])
+	})
+
+	t.Run("keeps toggles that are already set", func(t *testing.T) {
+		config, _, err := core.UpgradeConfiguration(m, 1, map[string]any{
+			"enableTimeout":    false,
+			"executionTimeout": map[string]any{"value": 10, "unit": "minutes"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, false, config["enableTimeout"])
+	})
+}
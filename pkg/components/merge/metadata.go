@@ -15,6 +15,15 @@ type ExecutionMetadata struct {
 	// Sources collects distinct upstream source node ids that reached this merge
 	Sources []string `json:"sources,omitempty" mapstructure:"sources"`
 
+	// Inputs collects the data of every event that reached this merge
+	Inputs []Input `json:"inputs,omitempty" mapstructure:"inputs"`
+
 	// StopEarly indicates the merge was short-circuited based on a stop condition
 	StopEarly bool `json:"stopEarly,omitempty" mapstructure:"stopEarly"`
 }
+
+type Input struct {
+	EventID string `json:"eventID" mapstructure:"eventID"`
+	Source  string `json:"source,omitempty" mapstructure:"source"`
+	Data    any    `json:"data" mapstructure:"data"`
+}
@@ -89,6 +89,10 @@ func (c *NoOp) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (c *NoOp) HandlesTestMode() bool {
+	return true
+}
+
 func (c *NoOp) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
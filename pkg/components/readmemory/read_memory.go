@@ -360,6 +360,10 @@ func (c *ReadMemory) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (c *ReadMemory) HandlesTestMode() bool {
+	return true
+}
+
 func (c *ReadMemory) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
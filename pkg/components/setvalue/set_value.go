@@ -0,0 +1,229 @@
+package setvalue
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ComponentName = "setValue"
+const PayloadType = "kv.set"
+const ChannelNameSet = "set"
+const ChannelNameAlreadySet = "alreadySet"
+
+func init() {
+	registry.RegisterComponent(ComponentName, &SetValue{})
+}
+
+type SetValue struct{}
+
+type Spec struct {
+	Key           string `json:"key"`
+	Value         any    `json:"value"`
+	OnlyIfMissing bool   `json:"onlyIfMissing"`
+}
+
+type canvasKeyValueWriteContext interface {
+	GetValue(key string) (any, bool, error)
+	SetValue(key string, value any) error
+	SetValueIfMissing(key string, value any) (bool, error)
+}
+
+func (c *SetValue) Name() string {
+	return ComponentName
+}
+
+func (c *SetValue) Label() string {
+	return "Set Value"
+}
+
+func (c *SetValue) Description() string {
+	return "Store a value in the canvas key-value store"
+}
+
+func (c *SetValue) Documentation() string {
+	return `The Set Value component stores a single value under a key in the canvas key-value store.
+
+Values are shared by all runs of the canvas and are kept until they are overwritten.
+
+## Use Cases
+
+- Record the version of the last successful deployment
+- Store a last-run marker for a scheduled workflow
+- Take a lock, so only one run proceeds past this point
+
+## How It Works
+
+1. Reads ` + "`key`" + ` and ` + "`value`" + ` from configuration
+2. Stores the value under the key, replacing any previous value
+3. Emits ` + "`kv.set`" + ` to the ` + "`set`" + ` channel
+
+## Locks
+
+With **Only If Not Set** enabled, the value is only stored if the key does not exist yet.
+If another run already stored a value, nothing is changed and the event is emitted to the ` + "`alreadySet`" + ` channel,
+with the current value in ` + "`data.value`" + `. This makes the component usable as a lock that is taken by a single run.
+
+## Output Channels
+
+- **Set**: The value was stored
+- **Already Set**: Only If Not Set is enabled and the key already had a value`
+}
+
+func (c *SetValue) Icon() string {
+	return "key-round"
+}
+
+func (c *SetValue) Color() string {
+	return "blue"
+}
+
+func (c *SetValue) ExampleOutput() map[string]any {
+	return exampleOutput()
+}
+
+func (c *SetValue) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: ChannelNameSet, Label: "Set"},
+		{Name: ChannelNameAlreadySet, Label: "Already Set"},
+	}
+}
+
+func (c *SetValue) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "key",
+			Label:       "Key",
+			Type:        configuration.FieldTypeString,
+			Description: "Key to store the value under",
+			Placeholder: "e.g. last-deployment",
+			Required:    true,
+		},
+		{
+			Name:        "value",
+			Label:       "Value",
+			Type:        configuration.FieldTypeExpression,
+			Description: "Value to store (can be expression)",
+			Required:    true,
+		},
+		{
+			Name:        "onlyIfMissing",
+			Label:       "Only If Not Set",
+			Type:        configuration.FieldTypeBool,
+			Description: "Only store the value if the key does not have a value yet",
+			Default:     false,
+		},
+	}
+}
+
+func (c *SetValue) Setup(ctx core.SetupContext) error {
+	_, err := decodeSpec(ctx.Configuration)
+	return err
+}
+
+func (c *SetValue) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	writeCtx, ok := ctx.CanvasMemory.(canvasKeyValueWriteContext)
+	if !ok {
+		return fmt.Errorf("canvas key-value operations are not supported")
+	}
+
+	stored := true
+	value := spec.Value
+	if spec.OnlyIfMissing {
+		stored, err = writeCtx.SetValueIfMissing(spec.Key, spec.Value)
+		if err != nil {
+			return fmt.Errorf("failed to set value: %w", err)
+		}
+
+		if !stored {
+			value, _, err = writeCtx.GetValue(spec.Key)
+			if err != nil {
+				return fmt.Errorf("failed to read value: %w", err)
+			}
+		}
+	} else {
+		err = writeCtx.SetValue(spec.Key, spec.Value)
+		if err != nil {
+			return fmt.Errorf("failed to set value: %w", err)
+		}
+	}
+
+	metadata := map[string]any{
+		"key":    spec.Key,
+		"stored": stored,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	channel := ChannelNameSet
+	if !stored {
+		channel = ChannelNameAlreadySet
+	}
+
+	return ctx.ExecutionState.Emit(
+		channel,
+		PayloadType,
+		[]any{
+			map[string]any{
+				"key":    spec.Key,
+				"value":  value,
+				"stored": stored,
+			},
+		},
+	)
+}
+
+func decodeSpec(raw any) (Spec, error) {
+	var spec Spec
+	if err := mapstructure.Decode(raw, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Key = strings.TrimSpace(spec.Key)
+	if spec.Key == "" {
+		return Spec{}, fmt.Errorf("key is required")
+	}
+
+	if spec.Value == nil {
+		return Spec{}, fmt.Errorf("value is required")
+	}
+
+	return spec, nil
+}
+
+func (c *SetValue) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *SetValue) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *SetValue) HandleAction(ctx core.ActionContext) error {
+	return fmt.Errorf("setValue does not support actions")
+}
+
+func (c *SetValue) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SetValue) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *SetValue) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
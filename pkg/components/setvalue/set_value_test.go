@@ -0,0 +1,98 @@
+package setvalue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type canvasMemoryContext struct {
+	values map[string]any
+}
+
+func (c *canvasMemoryContext) Add(namespace string, values any) error {
+	return nil
+}
+
+func (c *canvasMemoryContext) Find(namespace string, matches map[string]any) ([]any, error) {
+	return []any{}, nil
+}
+
+func (c *canvasMemoryContext) FindFirst(namespace string, matches map[string]any) (any, error) {
+	return nil, nil
+}
+
+func (c *canvasMemoryContext) GetValue(key string) (any, bool, error) {
+	value, ok := c.values[key]
+	return value, ok, nil
+}
+
+func (c *canvasMemoryContext) SetValue(key string, value any) error {
+	c.values[key] = value
+	return nil
+}
+
+func (c *canvasMemoryContext) SetValueIfMissing(key string, value any) (bool, error) {
+	if _, ok := c.values[key]; ok {
+		return false, nil
+	}
+
+	c.values[key] = value
+	return true, nil
+}
+
+func TestSetValueExecute(t *testing.T) {
+	execute := func(memory *canvasMemoryContext, configuration map[string]any) (*contexts.ExecutionStateContext, error) {
+		execState := &contexts.ExecutionStateContext{}
+		err := (&SetValue{}).Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			Metadata:       &contexts.MetadataContext{},
+			CanvasMemory:   memory,
+			ExecutionState: execState,
+		})
+
+		return execState, err
+	}
+
+	t.Run("overwrites existing value", func(t *testing.T) {
+		memory := &canvasMemoryContext{values: map[string]any{"last-deployment": "v1.4.1"}}
+		execState, err := execute(memory, map[string]any{"key": "last-deployment", "value": "v1.4.2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "v1.4.2", memory.values["last-deployment"])
+		assert.Equal(t, ChannelNameSet, execState.Channel)
+		assert.Equal(t, PayloadType, execState.Type)
+	})
+
+	t.Run("only if missing stores the value once", func(t *testing.T) {
+		memory := &canvasMemoryContext{values: map[string]any{}}
+		configuration := map[string]any{"key": "lock", "value": "run-1", "onlyIfMissing": true}
+
+		execState, err := execute(memory, configuration)
+		require.NoError(t, err)
+		assert.Equal(t, ChannelNameSet, execState.Channel)
+
+		configuration["value"] = "run-2"
+		execState, err = execute(memory, configuration)
+		require.NoError(t, err)
+		assert.Equal(t, ChannelNameAlreadySet, execState.Channel)
+		assert.Equal(t, "run-1", memory.values["lock"])
+		assert.Equal(t, map[string]any{
+			"key":    "lock",
+			"value":  "run-1",
+			"stored": false,
+		}, execState.Payloads[0].(map[string]any)["data"])
+	})
+
+	t.Run("requires a value", func(t *testing.T) {
+		_, err := execute(&canvasMemoryContext{}, map[string]any{"key": "lock"})
+		require.ErrorContains(t, err, "value is required")
+	})
+}
+
+func TestSetValue_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&SetValue{}))
+}
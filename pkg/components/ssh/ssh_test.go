@@ -207,3 +207,7 @@ func TestSSHCommand_BuildRemoteCommand(t *testing.T) {
 		)
 	})
 }
+
+func TestSSHCommand_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&SSHCommand{}))
+}
@@ -0,0 +1,393 @@
+package switchp
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/expressions"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ComponentName = "switch"
+const ChannelNameDefault = "default"
+const PayloadType = "switch.executed"
+
+var caseNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+func init() {
+	registry.RegisterComponent(ComponentName, &Switch{})
+}
+
+type Switch struct{}
+
+type Spec struct {
+	Cases []Case `json:"cases" mapstructure:"cases"`
+}
+
+type Case struct {
+	Name       string `json:"name" mapstructure:"name"`
+	Expression string `json:"expression" mapstructure:"expression"`
+}
+
+func (s *Switch) Name() string {
+	return ComponentName
+}
+
+func (s *Switch) Label() string {
+	return "Switch"
+}
+
+func (s *Switch) Description() string {
+	return "Route events to named channels based on expressions"
+}
+
+func (s *Switch) Documentation() string {
+	return `The Switch component evaluates a list of boolean expressions and routes the event to the output channel of the first case that matches.
+
+## Use Cases
+
+- **Multi-way branching**: Route events down one of several paths, e.g. by environment or severity
+- **Event classification**: Send different event types to dedicated processing paths
+- **Fallback handling**: Catch everything that doesn't match a known case in the default channel
+
+## How It Works
+
+1. Cases are evaluated in order against the incoming event data
+2. The event is emitted to the channel named after the first case whose expression evaluates to ` + "`true`" + `
+3. If no case matches, the event is emitted to the "Default" channel
+
+## Output Channels
+
+- One channel per configured case, named after the case
+- **Default**: Events that don't match any case
+
+## Expression Environment
+
+The expressions have access to:
+- **$**: The run context data
+- **root()**: Access to the root event data
+- **previous()**: Access to previous node outputs (optionally with depth parameter)
+
+## Examples
+
+- **production**: ` + "`$[\"Node Name\"].environment == \"production\"`" + `
+- **staging**: ` + "`$[\"Node Name\"].environment == \"staging\"`" + `
+- **critical**: ` + "`$[\"Node Name\"].severity in [\"P1\", \"P2\"]`" + ``
+}
+
+func (s *Switch) Icon() string {
+	return "split"
+}
+
+func (s *Switch) Color() string {
+	return "red"
+}
+
+func (s *Switch) OutputChannels(config any) []core.OutputChannel {
+	channels := []core.OutputChannel{}
+
+	spec := Spec{}
+	if config != nil && mapstructure.Decode(config, &spec) == nil {
+		for _, c := range spec.Cases {
+			if c.Name == "" || c.Name == ChannelNameDefault {
+				continue
+			}
+
+			channels = append(channels, core.OutputChannel{Name: c.Name, Label: c.Name})
+		}
+	}
+
+	return append(channels, core.OutputChannel{Name: ChannelNameDefault, Label: "Default"})
+}
+
+func (s *Switch) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "cases",
+			Label:       "Cases",
+			Type:        configuration.FieldTypeList,
+			Required:    true,
+			Description: "Cases evaluated in order. The event goes to the channel of the first matching case",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Case",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:               "name",
+								Label:              "Channel",
+								Type:               configuration.FieldTypeString,
+								Required:           true,
+								Placeholder:        "production",
+								Description:        "Name of the output channel for this case",
+								DisallowExpression: true,
+							},
+							{
+								Name:        "expression",
+								Label:       "Expression",
+								Type:        configuration.FieldTypeExpression,
+								Required:    true,
+								Description: "Boolean expression to evaluate",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *Switch) Setup(ctx core.SetupContext) error {
+	spec := Spec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return spec.Validate()
+}
+
+func (s Spec) Validate() error {
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("at least one case is required")
+	}
+
+	names := map[string]bool{}
+	for i, c := range s.Cases {
+		if !caseNameRegex.MatchString(c.Name) {
+			return fmt.Errorf("case %d: name must only contain letters, numbers, '-' and '_'", i+1)
+		}
+
+		if c.Name == ChannelNameDefault {
+			return fmt.Errorf("case %d: %s is reserved for events that match no case", i+1, ChannelNameDefault)
+		}
+
+		if c.Name == core.ErrorOutputChannel.Name {
+			return fmt.Errorf("case %d: %s is reserved for failed executions", i+1, core.ErrorOutputChannel.Name)
+		}
+
+		if names[c.Name] {
+			return fmt.Errorf("case %d: duplicate name %s", i+1, c.Name)
+		}
+
+		if c.Expression == "" {
+			return fmt.Errorf("case %s: expression is required", c.Name)
+		}
+
+		names[c.Name] = true
+	}
+
+	return nil
+}
+
+func (s *Switch) Execute(ctx core.ExecutionContext) error {
+	spec := Spec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	err = spec.Validate()
+	if err != nil {
+		return err
+	}
+
+	channel := ChannelNameDefault
+	for _, c := range spec.Cases {
+		matches, err := evaluate(ctx, c.Expression)
+		if err != nil {
+			return fmt.Errorf("case %s: %w", c.Name, err)
+		}
+
+		if matches {
+			channel = c.Name
+			break
+		}
+	}
+
+	// Store the matched case in metadata so it can be retrieved later
+	// even if the node configuration changes
+	err = ctx.Metadata.Set(map[string]any{"channel": channel})
+	if err != nil {
+		return fmt.Errorf("error setting metadata: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		channel,
+		PayloadType,
+		[]any{map[string]any{"case": channel}},
+	)
+}
+
+func evaluate(ctx core.ExecutionContext, expression string) (bool, error) {
+	env, err := expressionEnv(ctx, expression)
+	if err != nil {
+		return false, err
+	}
+
+	vm, err := expr.Compile(expression, expressionOptions(env)...)
+	if err != nil {
+		return false, fmt.Errorf("expression compilation failed: %w", err)
+	}
+
+	output, err := expr.Run(vm, env)
+	if err != nil {
+		return false, fmt.Errorf("expression evaluation failed: %w", err)
+	}
+
+	matches, ok := output.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to boolean, got %T", output)
+	}
+
+	return matches, nil
+}
+
+func expressionEnv(ctx core.ExecutionContext, expression string) (map[string]any, error) {
+	if ctx.ExpressionEnv != nil {
+		return ctx.ExpressionEnv(expression)
+	}
+
+	return buildExpressionEnv(ctx.Data, ctx.SourceNodeID), nil
+}
+
+func buildExpressionEnv(input any, sourceNodeID string) map[string]any {
+	if sourceNodeID == "" {
+		return map[string]any{"$": input}
+	}
+
+	if inputMap, ok := input.(map[string]any); ok {
+		envData := make(map[string]any, len(inputMap)+1)
+		for key, value := range inputMap {
+			envData[key] = value
+		}
+		if _, exists := envData[sourceNodeID]; !exists {
+			envData[sourceNodeID] = input
+		}
+		return map[string]any{"$": envData}
+	}
+
+	if inputMap, ok := input.(map[string]string); ok {
+		envData := make(map[string]any, len(inputMap)+1)
+		for key, value := range inputMap {
+			envData[key] = value
+		}
+		if _, exists := envData[sourceNodeID]; !exists {
+			envData[sourceNodeID] = input
+		}
+		return map[string]any{"$": envData}
+	}
+
+	return map[string]any{"$": map[string]any{sourceNodeID: input}}
+}
+
+func expressionOptions(env map[string]any) []expr.Option {
+	options := []expr.Option{
+		expr.Env(env),
+		expr.AsBool(),
+		expr.WithContext("ctx"),
+		expr.Timezone(time.UTC.String()),
+		expr.Function("root", func(params ...any) (any, error) {
+			if len(params) != 0 {
+				return nil, fmt.Errorf("root() takes no arguments")
+			}
+
+			rootPayload, ok := env["__root"]
+			if !ok {
+				return nil, fmt.Errorf("no root event found")
+			}
+			return rootPayload, nil
+		}),
+		expr.Function("previous", func(params ...any) (any, error) {
+			depth := 1
+			if len(params) > 1 {
+				return nil, fmt.Errorf("previous() accepts zero or one argument")
+			}
+			if len(params) == 1 {
+				parsedDepth, err := parseDepthValue(params[0])
+				if err != nil {
+					return nil, err
+				}
+				depth = parsedDepth
+			}
+
+			previousByDepth, ok := env["__previousByDepth"]
+			if !ok {
+				return nil, nil
+			}
+			if values, ok := previousByDepth.(map[string]any); ok {
+				return values[strconv.Itoa(depth)], nil
+			}
+			if values, ok := previousByDepth.(map[int]any); ok {
+				return values[depth], nil
+			}
+
+			return nil, nil
+		}),
+	}
+
+	return append(options, expressions.Functions()...)
+}
+
+func parseDepthValue(param any) (int, error) {
+	switch value := param.(type) {
+	case int:
+		if value < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return value, nil
+	case int64:
+		if value < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return int(value), nil
+	case float64:
+		parsed := int(value)
+		if value != float64(parsed) {
+			return 0, fmt.Errorf("depth must be an integer")
+		}
+		if parsed < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("depth must be an integer")
+	}
+}
+
+func (s *Switch) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (s *Switch) HandleAction(ctx core.ActionContext) error {
+	return fmt.Errorf("switch does not support actions")
+}
+
+func (s *Switch) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (s *Switch) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *Switch) HandlesTestMode() bool {
+	return true
+}
+
+func (s *Switch) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (s *Switch) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
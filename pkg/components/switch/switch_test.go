@@ -0,0 +1,164 @@
+package switchp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func casesConfig(cases ...map[string]any) map[string]any {
+	items := []any{}
+	for _, c := range cases {
+		items = append(items, c)
+	}
+
+	return map[string]any{"cases": items}
+}
+
+func TestSwitch_OutputChannels(t *testing.T) {
+	s := &Switch{}
+
+	t.Run("without configuration only default channel", func(t *testing.T) {
+		channels := s.OutputChannels(nil)
+		require.Len(t, channels, 1)
+		assert.Equal(t, ChannelNameDefault, channels[0].Name)
+	})
+
+	t.Run("one channel per case followed by default", func(t *testing.T) {
+		channels := s.OutputChannels(casesConfig(
+			map[string]any{"name": "production", "expression": "true"},
+			map[string]any{"name": "staging", "expression": "true"},
+		))
+
+		require.Len(t, channels, 3)
+		assert.Equal(t, "production", channels[0].Name)
+		assert.Equal(t, "staging", channels[1].Name)
+		assert.Equal(t, ChannelNameDefault, channels[2].Name)
+	})
+}
+
+func TestSwitch_Setup(t *testing.T) {
+	s := &Switch{}
+
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		expectedError string
+	}{
+		{
+			name:          "no cases",
+			configuration: casesConfig(),
+			expectedError: "at least one case is required",
+		},
+		{
+			name:          "invalid name",
+			configuration: casesConfig(map[string]any{"name": "prod env", "expression": "true"}),
+			expectedError: "name must only contain",
+		},
+		{
+			name:          "reserved name",
+			configuration: casesConfig(map[string]any{"name": "default", "expression": "true"}),
+			expectedError: "reserved",
+		},
+		{
+			name:          "error channel name",
+			configuration: casesConfig(map[string]any{"name": "error", "expression": "true"}),
+			expectedError: "reserved for failed executions",
+		},
+		{
+			name: "duplicate name",
+			configuration: casesConfig(
+				map[string]any{"name": "prod", "expression": "true"},
+				map[string]any{"name": "prod", "expression": "false"},
+			),
+			expectedError: "duplicate name prod",
+		},
+		{
+			name:          "missing expression",
+			configuration: casesConfig(map[string]any{"name": "prod"}),
+			expectedError: "expression is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Setup(core.SetupContext{Configuration: tt.configuration})
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+
+	t.Run("valid cases", func(t *testing.T) {
+		err := s.Setup(core.SetupContext{Configuration: casesConfig(
+			map[string]any{"name": "prod", "expression": "true"},
+		)})
+		require.NoError(t, err)
+	})
+}
+
+func TestSwitch_Execute(t *testing.T) {
+	configuration := casesConfig(
+		map[string]any{"name": "production", "expression": `$.environment == "production"`},
+		map[string]any{"name": "nonprod", "expression": `$.environment in ["staging", "production"]`},
+	)
+
+	tests := []struct {
+		name            string
+		inputData       map[string]any
+		expectedChannel string
+	}{
+		{
+			name:            "first matching case wins",
+			inputData:       map[string]any{"environment": "production"},
+			expectedChannel: "production",
+		},
+		{
+			name:            "later case matches",
+			inputData:       map[string]any{"environment": "staging"},
+			expectedChannel: "nonprod",
+		},
+		{
+			name:            "no case matches",
+			inputData:       map[string]any{"environment": "dev"},
+			expectedChannel: ChannelNameDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateCtx := &contexts.ExecutionStateContext{}
+			metadataCtx := &contexts.MetadataContext{}
+
+			err := (&Switch{}).Execute(core.ExecutionContext{
+				Data:           tt.inputData,
+				Configuration:  configuration,
+				ExecutionState: stateCtx,
+				Metadata:       metadataCtx,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, stateCtx.Passed)
+			assert.Equal(t, tt.expectedChannel, stateCtx.Channel)
+			assert.Equal(t, PayloadType, stateCtx.Type)
+			assert.Equal(t, map[string]any{"channel": tt.expectedChannel}, metadataCtx.Metadata)
+
+			require.Len(t, stateCtx.Payloads, 1)
+			payload := stateCtx.Payloads[0].(map[string]any)
+			assert.Equal(t, map[string]any{"case": tt.expectedChannel}, payload["data"])
+		})
+	}
+
+	t.Run("non-boolean expression returns error", func(t *testing.T) {
+		err := (&Switch{}).Execute(core.ExecutionContext{
+			Data:           map[string]any{},
+			Configuration:  casesConfig(map[string]any{"name": "prod", "expression": `"yes"`}),
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Metadata:       &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "case prod")
+	})
+}
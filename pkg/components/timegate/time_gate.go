@@ -481,6 +481,10 @@ func (tg *TimeGate) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (tg *TimeGate) HandlesTestMode() bool {
+	return true
+}
+
 func (tg *TimeGate) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
 	return http.StatusOK, nil, nil
 }
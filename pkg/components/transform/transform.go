@@ -0,0 +1,576 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/expressions"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ComponentName = "transform"
+const PayloadType = "transform.executed"
+
+const (
+	ModeFields     = "fields"
+	ModeExpression = "expression"
+
+	TypeAuto    = "auto"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeBoolean = "boolean"
+	TypeJSON    = "json"
+)
+
+func init() {
+	registry.RegisterComponent(ComponentName, &Transform{})
+}
+
+type Transform struct{}
+
+type Spec struct {
+	Mode       string  `json:"mode" mapstructure:"mode"`
+	Fields     []Field `json:"fields" mapstructure:"fields"`
+	Expression string  `json:"expression" mapstructure:"expression"`
+}
+
+type Field struct {
+	Name       string `json:"name" mapstructure:"name"`
+	Expression string `json:"expression" mapstructure:"expression"`
+	Type       string `json:"type" mapstructure:"type"`
+}
+
+func (t *Transform) Name() string {
+	return ComponentName
+}
+
+func (t *Transform) Label() string {
+	return "Transform"
+}
+
+func (t *Transform) Description() string {
+	return "Reshape event data with expressions"
+}
+
+func (t *Transform) Documentation() string {
+	return `The Transform component builds a new payload from the incoming event data using expressions, so the output of one component can be adapted to the input another one expects.
+
+## Use Cases
+
+- **Field mapping**: Rename and pick fields from a previous component's output
+- **Type coercion**: Turn strings into numbers or booleans, or parse JSON strings
+- **Array processing**: Map and filter lists, e.g. turn a list of instances into an inventory
+- **Defaults**: Fill in values that may be missing from the input
+
+## Modes
+
+- **Fields**: Define the output field by field. Each field has a name, an expression and an optional type.
+  Names can use dots to create nested objects, e.g. ` + "`instance.ip`" + `
+- **Expression**: A single expression that returns the whole output. If it doesn't return an object, the value is emitted under ` + "`result`" + `
+
+## Expression Environment
+
+Expressions have access to:
+- **$**: The run context data
+- **root()**: Access to the root event data
+- **previous()**: Access to previous node outputs (optionally with depth parameter)
+
+## Examples
+
+- **Default value**: ` + "`$[\"Node Name\"].region ?? \"us-east-1\"`" + `
+- **Map an array**: ` + "`map($[\"Node Name\"].instances, {#.networkIP})`" + `
+- **Filter an array**: ` + "`filter($[\"Node Name\"].instances, {#.status == \"RUNNING\"})`" + `
+- **Build an object**: ` + "`{\"hosts\": map($[\"Node Name\"].instances, {#.name})}`" + `
+
+## Output
+
+The built payload is emitted on the default channel.`
+}
+
+func (t *Transform) Icon() string {
+	return "shuffle"
+}
+
+func (t *Transform) Color() string {
+	return "blue"
+}
+
+func (t *Transform) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (t *Transform) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "mode",
+			Label:    "Mode",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  ModeFields,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Fields", Value: ModeFields},
+						{Label: "Expression", Value: ModeExpression},
+					},
+				},
+			},
+		},
+		{
+			Name:        "fields",
+			Label:       "Fields",
+			Type:        configuration.FieldTypeList,
+			Description: "Fields of the output payload",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "mode", Values: []string{ModeFields}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "mode", Values: []string{ModeFields}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Field",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:               "name",
+								Label:              "Name",
+								Type:               configuration.FieldTypeString,
+								Required:           true,
+								Placeholder:        "instance.ip",
+								DisallowExpression: true,
+							},
+							{
+								Name:     "expression",
+								Label:    "Expression",
+								Type:     configuration.FieldTypeExpression,
+								Required: true,
+							},
+							{
+								Name:    "type",
+								Label:   "Type",
+								Type:    configuration.FieldTypeSelect,
+								Default: TypeAuto,
+								TypeOptions: &configuration.TypeOptions{
+									Select: &configuration.SelectTypeOptions{
+										Options: []configuration.FieldOption{
+											{Label: "Auto", Value: TypeAuto},
+											{Label: "String", Value: TypeString},
+											{Label: "Number", Value: TypeNumber},
+											{Label: "Integer", Value: TypeInteger},
+											{Label: "Boolean", Value: TypeBoolean},
+											{Label: "Parse JSON", Value: TypeJSON},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "expression",
+			Label:       "Expression",
+			Type:        configuration.FieldTypeExpression,
+			Description: "Expression returning the output payload",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "mode", Values: []string{ModeExpression}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "mode", Values: []string{ModeExpression}},
+			},
+		},
+	}
+}
+
+func (t *Transform) Setup(ctx core.SetupContext) error {
+	spec := Spec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return spec.Validate()
+}
+
+func (s Spec) Validate() error {
+	switch s.Mode {
+	case ModeExpression:
+		if s.Expression == "" {
+			return fmt.Errorf("expression is required")
+		}
+
+		return nil
+
+	case ModeFields:
+		if len(s.Fields) == 0 {
+			return fmt.Errorf("at least one field is required")
+		}
+
+		for i, field := range s.Fields {
+			if field.Name == "" || strings.HasPrefix(field.Name, ".") || strings.HasSuffix(field.Name, ".") || strings.Contains(field.Name, "..") {
+				return fmt.Errorf("field %d: invalid name %q", i+1, field.Name)
+			}
+
+			if field.Expression == "" {
+				return fmt.Errorf("field %s: expression is required", field.Name)
+			}
+
+			switch field.Type {
+			case "", TypeAuto, TypeString, TypeNumber, TypeInteger, TypeBoolean, TypeJSON:
+			default:
+				return fmt.Errorf("field %s: invalid type %s", field.Name, field.Type)
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("invalid mode: %s", s.Mode)
+	}
+}
+
+func (t *Transform) Execute(ctx core.ExecutionContext) error {
+	spec := Spec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	err = spec.Validate()
+	if err != nil {
+		return err
+	}
+
+	output, err := build(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		PayloadType,
+		[]any{output},
+	)
+}
+
+func build(ctx core.ExecutionContext, spec Spec) (map[string]any, error) {
+	if spec.Mode == ModeExpression {
+		value, err := evaluate(ctx, spec.Expression)
+		if err != nil {
+			return nil, err
+		}
+
+		if output, ok := value.(map[string]any); ok {
+			return output, nil
+		}
+
+		return map[string]any{"result": value}, nil
+	}
+
+	output := map[string]any{}
+	for _, field := range spec.Fields {
+		value, err := evaluate(ctx, field.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		value, err = coerce(value, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		err = setPath(output, strings.Split(field.Name, "."), value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return output, nil
+}
+
+func setPath(target map[string]any, path []string, value any) error {
+	for _, key := range path[:len(path)-1] {
+		next, exists := target[key]
+		if !exists {
+			child := map[string]any{}
+			target[key] = child
+			target = child
+			continue
+		}
+
+		child, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s is already set to a non-object value", key)
+		}
+
+		target = child
+	}
+
+	target[path[len(path)-1]] = value
+	return nil
+}
+
+func coerce(value any, valueType string) (any, error) {
+	switch valueType {
+	case "", TypeAuto:
+		return value, nil
+
+	case TypeString:
+		switch v := value.(type) {
+		case nil:
+			return "", nil
+		case string:
+			return v, nil
+		case map[string]any, []any:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert to string: %w", err)
+			}
+			return string(data), nil
+		default:
+			return fmt.Sprintf("%v", v), nil
+		}
+
+	case TypeNumber, TypeInteger:
+		number, err := toNumber(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if valueType == TypeInteger {
+			return int64(math.Trunc(number)), nil
+		}
+
+		return number, nil
+
+	case TypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert %q to boolean", v)
+			}
+			return parsed, nil
+		default:
+			number, err := toNumber(value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert %T to boolean", value)
+			}
+			return number != 0, nil
+		}
+
+	case TypeJSON:
+		v, ok := value.(string)
+		if !ok {
+			return value, nil
+		}
+
+		var parsed any
+		if err := json.Unmarshal([]byte(v), &parsed); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		return parsed, nil
+	}
+
+	return nil, fmt.Errorf("invalid type %s", valueType)
+}
+
+func toNumber(value any) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert %q to number", v)
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to number", value)
+	}
+}
+
+func evaluate(ctx core.ExecutionContext, expression string) (any, error) {
+	env, err := expressionEnv(ctx, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := expr.Compile(expression, expressionOptions(env)...)
+	if err != nil {
+		return nil, fmt.Errorf("expression compilation failed: %w", err)
+	}
+
+	output, err := expr.Run(vm, env)
+	if err != nil {
+		return nil, fmt.Errorf("expression evaluation failed: %w", err)
+	}
+
+	return output, nil
+}
+
+func expressionEnv(ctx core.ExecutionContext, expression string) (map[string]any, error) {
+	if ctx.ExpressionEnv != nil {
+		return ctx.ExpressionEnv(expression)
+	}
+
+	return buildExpressionEnv(ctx.Data, ctx.SourceNodeID), nil
+}
+
+func buildExpressionEnv(input any, sourceNodeID string) map[string]any {
+	if sourceNodeID == "" {
+		return map[string]any{"$": input}
+	}
+
+	if inputMap, ok := input.(map[string]any); ok {
+		envData := make(map[string]any, len(inputMap)+1)
+		for key, value := range inputMap {
+			envData[key] = value
+		}
+		if _, exists := envData[sourceNodeID]; !exists {
+			envData[sourceNodeID] = input
+		}
+		return map[string]any{"$": envData}
+	}
+
+	if inputMap, ok := input.(map[string]string); ok {
+		envData := make(map[string]any, len(inputMap)+1)
+		for key, value := range inputMap {
+			envData[key] = value
+		}
+		if _, exists := envData[sourceNodeID]; !exists {
+			envData[sourceNodeID] = input
+		}
+		return map[string]any{"$": envData}
+	}
+
+	return map[string]any{"$": map[string]any{sourceNodeID: input}}
+}
+
+func expressionOptions(env map[string]any) []expr.Option {
+	options := []expr.Option{
+		expr.Env(env),
+		expr.AsAny(),
+		expr.WithContext("ctx"),
+		expr.Timezone(time.UTC.String()),
+		expr.Function("root", func(params ...any) (any, error) {
+			if len(params) != 0 {
+				return nil, fmt.Errorf("root() takes no arguments")
+			}
+
+			rootPayload, ok := env["__root"]
+			if !ok {
+				return nil, fmt.Errorf("no root event found")
+			}
+			return rootPayload, nil
+		}),
+		expr.Function("previous", func(params ...any) (any, error) {
+			depth := 1
+			if len(params) > 1 {
+				return nil, fmt.Errorf("previous() accepts zero or one argument")
+			}
+			if len(params) == 1 {
+				parsedDepth, err := parseDepthValue(params[0])
+				if err != nil {
+					return nil, err
+				}
+				depth = parsedDepth
+			}
+
+			previousByDepth, ok := env["__previousByDepth"]
+			if !ok {
+				return nil, nil
+			}
+			if values, ok := previousByDepth.(map[string]any); ok {
+				return values[strconv.Itoa(depth)], nil
+			}
+			if values, ok := previousByDepth.(map[int]any); ok {
+				return values[depth], nil
+			}
+
+			return nil, nil
+		}),
+	}
+
+	return append(options, expressions.Functions()...)
+}
+
+func parseDepthValue(param any) (int, error) {
+	switch value := param.(type) {
+	case int:
+		if value < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return value, nil
+	case int64:
+		if value < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return int(value), nil
+	case float64:
+		parsed := int(value)
+		if value != float64(parsed) {
+			return 0, fmt.Errorf("depth must be an integer")
+		}
+		if parsed < 1 {
+			return 0, fmt.Errorf("depth must be >= 1")
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("depth must be an integer")
+	}
+}
+
+func (t *Transform) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *Transform) HandleAction(ctx core.ActionContext) error {
+	return fmt.Errorf("transform does not support actions")
+}
+
+func (t *Transform) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (t *Transform) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (t *Transform) HandlesTestMode() bool {
+	return true
+}
+
+func (t *Transform) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (t *Transform) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,175 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func execute(t *testing.T, configuration map[string]any, data any) (*contexts.ExecutionStateContext, error) {
+	t.Helper()
+
+	stateCtx := &contexts.ExecutionStateContext{}
+	err := (&Transform{}).Execute(core.ExecutionContext{
+		Data:           data,
+		Configuration:  configuration,
+		ExecutionState: stateCtx,
+		Metadata:       &contexts.MetadataContext{},
+	})
+
+	return stateCtx, err
+}
+
+func emitted(t *testing.T, stateCtx *contexts.ExecutionStateContext) any {
+	t.Helper()
+
+	require.True(t, stateCtx.Passed)
+	assert.Equal(t, core.DefaultOutputChannel.Name, stateCtx.Channel)
+	assert.Equal(t, PayloadType, stateCtx.Type)
+	require.Len(t, stateCtx.Payloads, 1)
+	return stateCtx.Payloads[0].(map[string]any)["data"]
+}
+
+func TestTransform_Setup(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		expectedError string
+	}{
+		{
+			name:          "invalid mode",
+			configuration: map[string]any{"mode": "template"},
+			expectedError: "invalid mode",
+		},
+		{
+			name:          "expression mode without expression",
+			configuration: map[string]any{"mode": ModeExpression},
+			expectedError: "expression is required",
+		},
+		{
+			name:          "fields mode without fields",
+			configuration: map[string]any{"mode": ModeFields, "fields": []any{}},
+			expectedError: "at least one field is required",
+		},
+		{
+			name: "invalid field name",
+			configuration: map[string]any{
+				"mode":   ModeFields,
+				"fields": []any{map[string]any{"name": "a..b", "expression": "1"}},
+			},
+			expectedError: "invalid name",
+		},
+		{
+			name: "invalid field type",
+			configuration: map[string]any{
+				"mode":   ModeFields,
+				"fields": []any{map[string]any{"name": "a", "expression": "1", "type": "date"}},
+			},
+			expectedError: "invalid type date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Transform{}).Setup(core.SetupContext{Configuration: tt.configuration})
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestTransform_Execute_Fields(t *testing.T) {
+	data := map[string]any{
+		"instance": map[string]any{
+			"name":   "web-1",
+			"ip":     "10.0.0.12",
+			"port":   "8080",
+			"public": "true",
+			"labels": `{"team":"platform"}`,
+		},
+		"instances": []any{
+			map[string]any{"name": "web-1", "status": "RUNNING"},
+			map[string]any{"name": "web-2", "status": "STOPPED"},
+		},
+	}
+
+	stateCtx, err := execute(t, map[string]any{
+		"mode": ModeFields,
+		"fields": []any{
+			map[string]any{"name": "host.name", "expression": "$.instance.name"},
+			map[string]any{"name": "host.ip", "expression": "$.instance.ip"},
+			map[string]any{"name": "host.port", "expression": "$.instance.port", "type": TypeInteger},
+			map[string]any{"name": "public", "expression": "$.instance.public", "type": TypeBoolean},
+			map[string]any{"name": "labels", "expression": "$.instance.labels", "type": TypeJSON},
+			map[string]any{"name": "region", "expression": `$.instance.region ?? "us-east-1"`},
+			map[string]any{"name": "running", "expression": `map(filter($.instances, {#.status == "RUNNING"}), {#.name})`},
+			map[string]any{"name": "count", "expression": "len($.instances)", "type": TypeString},
+		},
+	}, data)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"host": map[string]any{
+			"name": "web-1",
+			"ip":   "10.0.0.12",
+			"port": int64(8080),
+		},
+		"public":  true,
+		"labels":  map[string]any{"team": "platform"},
+		"region":  "us-east-1",
+		"running": []any{"web-1"},
+		"count":   "2",
+	}, emitted(t, stateCtx))
+}
+
+func TestTransform_Execute_Expression(t *testing.T) {
+	data := map[string]any{"instances": []any{"web-1", "web-2"}}
+
+	t.Run("object result is emitted as is", func(t *testing.T) {
+		stateCtx, err := execute(t, map[string]any{
+			"mode":       ModeExpression,
+			"expression": `{"all": {"hosts": $.instances}}`,
+		}, data)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"all": map[string]any{"hosts": []any{"web-1", "web-2"}},
+		}, emitted(t, stateCtx))
+	})
+
+	t.Run("non-object result is wrapped", func(t *testing.T) {
+		stateCtx, err := execute(t, map[string]any{
+			"mode":       ModeExpression,
+			"expression": `len($.instances)`,
+		}, data)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"result": 2}, emitted(t, stateCtx))
+	})
+}
+
+func TestTransform_Execute_Errors(t *testing.T) {
+	t.Run("coercion failure", func(t *testing.T) {
+		_, err := execute(t, map[string]any{
+			"mode":   ModeFields,
+			"fields": []any{map[string]any{"name": "port", "expression": `"http"`, "type": TypeNumber}},
+		}, map[string]any{})
+
+		require.ErrorContains(t, err, "field port")
+	})
+
+	t.Run("conflicting nested names", func(t *testing.T) {
+		_, err := execute(t, map[string]any{
+			"mode": ModeFields,
+			"fields": []any{
+				map[string]any{"name": "host", "expression": `"web-1"`},
+				map[string]any{"name": "host.ip", "expression": `"10.0.0.1"`},
+			},
+		}, map[string]any{})
+
+		require.ErrorContains(t, err, "non-object value")
+	})
+}
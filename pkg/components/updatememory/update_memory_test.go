@@ -202,3 +202,7 @@ func TestUpdateMemorySetup(t *testing.T) {
 		assert.Contains(t, err.Error(), "at least one memory value update is required")
 	})
 }
+
+func TestUpdateMemory_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&UpdateMemory{}))
+}
@@ -285,3 +285,7 @@ func TestUpsertMemorySetup(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestUpsertMemory_IsNotExecutedInTestMode(t *testing.T) {
+	assert.False(t, core.HandlesTestMode(&UpsertMemory{}))
+}
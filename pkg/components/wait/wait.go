@@ -76,7 +76,8 @@ func (w *Wait) Documentation() string {
 ## Behavior
 
 - Execution pauses until the wait period completes
-- Can be manually pushed through using the "Push Through" action
+- The wait is implemented as a scheduled action, so no worker is blocked while waiting
+- Can be skipped at any time using the "Push Through" action
 - Automatically resumes when the wait time expires
 - Emits metadata including start time, finish time, and result
 
@@ -434,6 +435,10 @@ func (w *Wait) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (w *Wait) HandlesTestMode() bool {
+	return true
+}
+
 func (w *Wait) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
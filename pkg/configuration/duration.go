@@ -0,0 +1,114 @@
+package configuration
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ * ParseDuration accepts the values used for duration fields:
+ * a number of seconds, or a string like "90", "30s", "1h30m" or "2d".
+ * Values are stored as a number of seconds once the node is saved,
+ * but components should still accept strings from older configurations.
+ */
+func ParseDuration(value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case int:
+		return durationFromSeconds(float64(v))
+	case int64:
+		return durationFromSeconds(float64(v))
+	case float64:
+		return durationFromSeconds(v)
+	case string:
+		return parseDurationString(v)
+	}
+
+	return 0, fmt.Errorf("must be a duration")
+}
+
+func durationFromSeconds(seconds float64) (time.Duration, error) {
+	if seconds < 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, fmt.Errorf("duration cannot be negative")
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func parseDurationString(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return durationFromSeconds(seconds)
+	}
+
+	//
+	// time.ParseDuration() does not support days,
+	// so they are handled separately, e.g. "2d12h".
+	//
+	var days time.Duration
+	if before, after, found := strings.Cut(value, "d"); found {
+		n, err := strconv.Atoi(before)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+
+		days = time.Duration(n) * 24 * time.Hour
+		value = after
+	}
+
+	var rest time.Duration
+	if value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: use a number of seconds, or a value like 30s, 5m, 1h30m or 2d", value)
+		}
+
+		rest = d
+	}
+
+	if days+rest < 0 {
+		return 0, fmt.Errorf("duration cannot be negative")
+	}
+
+	return days + rest, nil
+}
+
+func validateDuration(field Field, value any) error {
+	if s, ok := value.(string); ok && expressionPlaceholderRegex.MatchString(s) {
+		return nil
+	}
+
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	if duration%time.Second != 0 {
+		return fmt.Errorf("duration must be a whole number of seconds")
+	}
+
+	if field.TypeOptions == nil || field.TypeOptions.Duration == nil {
+		return nil
+	}
+
+	options := field.TypeOptions.Duration
+	if options.Min != nil && duration < time.Duration(*options.Min)*time.Second {
+		return fmt.Errorf("must be at least %s", formatSeconds(*options.Min))
+	}
+
+	if options.Max != nil && duration > time.Duration(*options.Max)*time.Second {
+		return fmt.Errorf("must be at most %s", formatSeconds(*options.Max))
+	}
+
+	return nil
+}
+
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
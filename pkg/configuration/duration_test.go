@@ -0,0 +1,72 @@
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	valid := map[string]struct {
+		value    any
+		expected time.Duration
+	}{
+		"seconds as number":         {value: float64(90), expected: 90 * time.Second},
+		"seconds as int":            {value: 30, expected: 30 * time.Second},
+		"seconds as string":         {value: " 90 ", expected: 90 * time.Second},
+		"go duration":               {value: "1h30m", expected: 90 * time.Minute},
+		"days":                      {value: "2d", expected: 48 * time.Hour},
+		"days with hours":           {value: "1d12h", expected: 36 * time.Hour},
+		"zero":                      {value: "0", expected: 0},
+		"milliseconds are accepted": {value: "1500ms", expected: 1500 * time.Millisecond},
+	}
+
+	for name, tc := range valid {
+		t.Run(name, func(t *testing.T) {
+			duration, err := ParseDuration(tc.value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, duration)
+		})
+	}
+
+	invalid := map[string]any{
+		"empty":            "",
+		"negative":         float64(-1),
+		"negative string":  "-5m",
+		"unknown unit":     "5 weeks",
+		"invalid days":     "xd",
+		"unsupported type": true,
+	}
+
+	for name, value := range invalid {
+		t.Run(name+" -> error", func(t *testing.T) {
+			_, err := ParseDuration(value)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestValidateConfiguration_Duration(t *testing.T) {
+	min := 60
+	max := 3600
+	fields := []Field{
+		{
+			Name:  "timeout",
+			Label: "Timeout",
+			Type:  FieldTypeDuration,
+			TypeOptions: &TypeOptions{
+				Duration: &DurationTypeOptions{Min: &min, Max: &max},
+			},
+		},
+	}
+
+	require.NoError(t, ValidateConfiguration(fields, map[string]any{"timeout": "5m"}))
+	require.NoError(t, ValidateConfiguration(fields, map[string]any{"timeout": float64(600)}))
+	require.NoError(t, ValidateConfiguration(fields, map[string]any{"timeout": "{{ $['trigger'].data.timeout }}"}))
+	require.ErrorContains(t, ValidateConfiguration(fields, map[string]any{"timeout": "30s"}), "must be at least 1m0s")
+	require.ErrorContains(t, ValidateConfiguration(fields, map[string]any{"timeout": "2h"}), "must be at most 1h0m0s")
+	require.ErrorContains(t, ValidateConfiguration(fields, map[string]any{"timeout": "90500ms"}), "whole number of seconds")
+	require.ErrorContains(t, ValidateConfiguration(fields, map[string]any{"timeout": "soon"}), "invalid duration")
+}
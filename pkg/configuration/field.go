@@ -20,6 +20,7 @@ const (
 	FieldTypeTimezone    = "timezone"
 	FieldTypeDaysOfWeek  = "days-of-week"
 	FieldTypeTimeRange   = "time-range"
+	FieldTypeDuration    = "duration"
 
 	/*
 	 * Special field types
@@ -33,6 +34,19 @@ const (
 	FieldTypeAnyPredicateList    = "any-predicate-list"
 	FieldTypeGitRef              = "git-ref"
 	FieldTypeSecretKey           = "secret-key"
+
+	/*
+	 * Write-only value, stored encrypted with the node.
+	 * Components only see the plain text value in Execute().
+	 */
+	FieldTypeSecret = "secret"
+
+	/*
+	 * File uploaded with the node configuration.
+	 * The content is kept in blob storage, and components
+	 * read it through core.ExecutionContext.Files.
+	 */
+	FieldTypeFile = "file"
 )
 
 type Field struct {
@@ -54,18 +68,33 @@ type Field struct {
 	/*
 	 * Type of the field. Supported types are defined by FieldType* constants above.
 	 */
-	Type               string `json:"type"`
-	Description        string `json:"description"`
-	Required           bool   `json:"required"`
-	Default            any    `json:"default"`
-	Togglable          bool   `json:"togglable"`
-	DisallowExpression bool   `json:"disallow_expression"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Default     any    `json:"default"`
+	Togglable   bool   `json:"togglable"`
+
+	/*
+	 * Fields are expression-capable unless this is set: values can embed
+	 * expressions like "{{ jsonpath($.data, '$.items[0].id') }}", resolved
+	 * before the component runs, with the functions from expressions.Functions().
+	 * Fields of type expression are evaluated by the component itself,
+	 * with the same functions available.
+	 */
+	DisallowExpression bool `json:"disallow_expression"`
 
 	/*
 	 * Whether the field is sensitive (e.g., password, API token)
 	 */
 	Sensitive bool `json:"sensitive"`
 
+	/*
+	 * Name of the section the field is displayed in, e.g. "Networking".
+	 * Fields without a group are displayed before all sections,
+	 * and sections are displayed in the order they first appear.
+	 */
+	Group string `json:"group,omitempty"`
+
 	/*
 	 * Type-specific options for fields.
 	 * The structure depends on the field type.
@@ -111,6 +140,9 @@ type TypeOptions struct {
 	DayInYear        *DayInYearTypeOptions        `json:"day_in_year,omitempty"`
 	Cron             *CronTypeOptions             `json:"cron,omitempty"`
 	Timezone         *TimezoneTypeOptions         `json:"timezone,omitempty"`
+	DynamicSchema    *DynamicSchemaTypeOptions    `json:"dynamic_schema,omitempty"`
+	File             *FileTypeOptions             `json:"file,omitempty"`
+	Duration         *DurationTypeOptions         `json:"duration,omitempty"`
 }
 
 /*
@@ -240,6 +272,45 @@ type ObjectTypeOptions struct {
 	Schema []Field `json:"schema"`
 }
 
+/*
+ * DurationTypeOptions specifies constraints for duration fields, in seconds
+ */
+type DurationTypeOptions struct {
+	Min *int `json:"min,omitempty"`
+	Max *int `json:"max,omitempty"`
+}
+
+/*
+ * FileTypeOptions specifies constraints for file fields
+ */
+type FileTypeOptions struct {
+	//
+	// Maximum size of the file, in bytes.
+	// DefaultMaxFileSize is used if not set.
+	//
+	MaxSize int64 `json:"max_size,omitempty"`
+
+	//
+	// Accepted file types, using the format of the HTML accept attribute,
+	// e.g. [".yaml", ".json", "application/json"].
+	//
+	Accept []string `json:"accept,omitempty"`
+}
+
+/*
+ * DynamicSchemaTypeOptions is used by object fields whose schema
+ * is only known once other fields are set, e.g. the variables
+ * declared on the selected pipeline. The schema is provided
+ * by the component, through core.DynamicSchemaProvider.
+ */
+type DynamicSchemaTypeOptions struct {
+	//
+	// Fields whose values are used to build the schema.
+	// The schema is only loaded once all of them are set.
+	//
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
 /*
  * FieldOption represents a selectable option for select / multi_select field types
  */
@@ -273,10 +344,21 @@ const (
 	ValidationRuleNotEqual    = "not_equal"
 	ValidationRuleMaxLength   = "max_length"
 	ValidationRuleMinLength   = "min_length"
+
+	/*
+	 * Rules that do not compare the field with another field.
+	 * pattern: the value must match the regular expression in Value.
+	 * min, max: the value must be within the inclusive bound in Value.
+	 * mutually_exclusive: the field and CompareWith cannot be both set.
+	 */
+	ValidationRulePattern           = "pattern"
+	ValidationRuleMin               = "min"
+	ValidationRuleMax               = "max"
+	ValidationRuleMutuallyExclusive = "mutually_exclusive"
 )
 
 type ValidationRule struct {
-	Type        string `json:"type"`         // less_than, greater_than, equal, not_equal, max_length, min_length
+	Type        string `json:"type"`         // less_than, greater_than, equal, not_equal, max_length, min_length, pattern, min, max, mutually_exclusive
 	CompareWith string `json:"compare_with"` // field name to compare with (for field comparisons)
 	Value       any    `json:"value"`        // static value to compare with (for direct validation)
 	Message     string `json:"message"`      // custom error message
@@ -0,0 +1,238 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"mime"
+	"path"
+	"strings"
+)
+
+const DefaultMaxFileSize = 1024 * 1024
+
+/*
+ * Files are sent by the UI as data URLs,
+ * e.g. "data:application/json;name=template.json;base64,eyJ9".
+ * Before the node is saved, the content is moved to blob storage,
+ * and the value is replaced by a FileReference.
+ */
+const fileDataURLPrefix = "data:"
+
+type FileUpload struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+/*
+ * FileReference is the value stored in the node configuration
+ * for file fields, after the content is moved to blob storage.
+ */
+type FileReference struct {
+	Key         string `json:"key" mapstructure:"key"`
+	Name        string `json:"name" mapstructure:"name"`
+	ContentType string `json:"contentType" mapstructure:"contentType"`
+	Size        int64  `json:"size" mapstructure:"size"`
+}
+
+func (r FileReference) ToMap() map[string]any {
+	return map[string]any{
+		"key":         r.Key,
+		"name":        r.Name,
+		"contentType": r.ContentType,
+		"size":        r.Size,
+	}
+}
+
+func IsFileUpload(value any) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, fileDataURLPrefix)
+}
+
+func ParseFileUpload(value string) (*FileUpload, error) {
+	if !strings.HasPrefix(value, fileDataURLPrefix) {
+		return nil, fmt.Errorf("must be a data URL")
+	}
+
+	header, content, found := strings.Cut(strings.TrimPrefix(value, fileDataURLPrefix), ",")
+	if !found {
+		return nil, fmt.Errorf("invalid data URL")
+	}
+
+	parts := strings.Split(header, ";")
+	if len(parts) < 2 || parts[len(parts)-1] != "base64" {
+		return nil, fmt.Errorf("data URL must be base64 encoded")
+	}
+
+	upload := &FileUpload{ContentType: parts[0]}
+	for _, param := range parts[1 : len(parts)-1] {
+		key, value, _ := strings.Cut(param, "=")
+		if key == "name" {
+			upload.Name = value
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 content: %w", err)
+	}
+
+	upload.Data = data
+	return upload, nil
+}
+
+/*
+ * ParseFileReference accepts a FileReference
+ * or its map representation from the node configuration.
+ */
+func ParseFileReference(value any) (*FileReference, error) {
+	switch v := value.(type) {
+	case FileReference:
+		return &v, nil
+	case *FileReference:
+		return v, nil
+	case map[string]any:
+		key, _ := v["key"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("file key is required")
+		}
+
+		name, _ := v["name"].(string)
+		contentType, _ := v["contentType"].(string)
+		size, _ := toFloat(v["size"])
+		return &FileReference{Key: key, Name: name, ContentType: contentType, Size: int64(size)}, nil
+	}
+
+	return nil, fmt.Errorf("must be a file")
+}
+
+func MaxFileSize(field Field) int64 {
+	if field.TypeOptions != nil && field.TypeOptions.File != nil && field.TypeOptions.File.MaxSize > 0 {
+		return field.TypeOptions.File.MaxSize
+	}
+
+	return DefaultMaxFileSize
+}
+
+func validateFile(field Field, value any) error {
+	if s, ok := value.(string); ok {
+		upload, err := ParseFileUpload(s)
+		if err != nil {
+			return err
+		}
+
+		return validateFileUpload(field, upload)
+	}
+
+	_, err := ParseFileReference(value)
+	return err
+}
+
+func validateFileUpload(field Field, upload *FileUpload) error {
+	maxSize := MaxFileSize(field)
+	if int64(len(upload.Data)) > maxSize {
+		return fmt.Errorf("file must be at most %d bytes", maxSize)
+	}
+
+	if field.TypeOptions == nil || field.TypeOptions.File == nil || len(field.TypeOptions.File.Accept) == 0 {
+		return nil
+	}
+
+	for _, accepted := range field.TypeOptions.File.Accept {
+		if fileTypeMatches(accepted, upload) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("file type must be one of %s", strings.Join(field.TypeOptions.File.Accept, ", "))
+}
+
+func fileTypeMatches(accepted string, upload *FileUpload) bool {
+	accepted = strings.ToLower(strings.TrimSpace(accepted))
+	if strings.HasPrefix(accepted, ".") {
+		return strings.ToLower(path.Ext(upload.Name)) == accepted
+	}
+
+	mediaType, _, err := mime.ParseMediaType(upload.ContentType)
+	if err != nil {
+		return false
+	}
+
+	if prefix, ok := strings.CutSuffix(accepted, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+
+	return mediaType == accepted
+}
+
+/*
+ * NewFileUploadDataURL builds the data URL sent by the UI for a file.
+ */
+func NewFileUploadDataURL(name, contentType string, data []byte) string {
+	var buf bytes.Buffer
+	buf.WriteString(fileDataURLPrefix)
+	buf.WriteString(contentType)
+	if name != "" {
+		buf.WriteString(";name=")
+		buf.WriteString(name)
+	}
+
+	buf.WriteString(";base64,")
+	buf.WriteString(base64.StdEncoding.EncodeToString(data))
+	return buf.String()
+}
+
+/*
+ * StoreFileFields returns a copy of the configuration with the
+ * uploads in file fields replaced by the references returned by store.
+ * Values that are already references are kept as they are.
+ */
+func StoreFileFields(fields []Field, config map[string]any, store func(Field, *FileUpload) (*FileReference, error)) (map[string]any, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	result := maps.Clone(config)
+	for _, field := range fields {
+		switch field.Type {
+		case FieldTypeFile:
+			value, ok := config[field.Name].(string)
+			if !ok || !IsFileUpload(value) {
+				continue
+			}
+
+			upload, err := ParseFileUpload(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+
+			if err := validateFileUpload(field, upload); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+
+			reference, err := store(field, upload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to store field %s: %w", field.Name, err)
+			}
+
+			result[field.Name] = reference.ToMap()
+
+		case FieldTypeObject:
+			object, ok := config[field.Name].(map[string]any)
+			if !ok || field.TypeOptions == nil || field.TypeOptions.Object == nil {
+				continue
+			}
+
+			transformed, err := StoreFileFields(field.TypeOptions.Object.Schema, object, store)
+			if err != nil {
+				return nil, err
+			}
+
+			result[field.Name] = transformed
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFileUpload(t *testing.T) {
+	t.Run("valid data URL", func(t *testing.T) {
+		upload, err := ParseFileUpload(NewFileUploadDataURL("template.json", "application/json", []byte("{}")))
+		require.NoError(t, err)
+		assert.Equal(t, "template.json", upload.Name)
+		assert.Equal(t, "application/json", upload.ContentType)
+		assert.Equal(t, []byte("{}"), upload.Data)
+	})
+
+	t.Run("not base64 encoded -> error", func(t *testing.T) {
+		_, err := ParseFileUpload("data:text/plain,hello")
+		require.ErrorContains(t, err, "base64")
+	})
+
+	t.Run("not a data URL -> error", func(t *testing.T) {
+		_, err := ParseFileUpload("hello")
+		require.ErrorContains(t, err, "must be a data URL")
+	})
+}
+
+func TestValidateConfiguration_File(t *testing.T) {
+	fields := []Field{
+		{
+			Name:     "template",
+			Label:    "Template",
+			Type:     FieldTypeFile,
+			Required: true,
+			TypeOptions: &TypeOptions{
+				File: &FileTypeOptions{MaxSize: 10, Accept: []string{".json", "text/*"}},
+			},
+		},
+	}
+
+	t.Run("upload is accepted", func(t *testing.T) {
+		config := map[string]any{"template": NewFileUploadDataURL("template.json", "application/json", []byte("{}"))}
+		require.NoError(t, ValidateConfiguration(fields, config))
+	})
+
+	t.Run("upload matching content type wildcard is accepted", func(t *testing.T) {
+		config := map[string]any{"template": NewFileUploadDataURL("notes", "text/plain", []byte("hello"))}
+		require.NoError(t, ValidateConfiguration(fields, config))
+	})
+
+	t.Run("reference is accepted", func(t *testing.T) {
+		config := map[string]any{"template": FileReference{Key: "org/file", Name: "template.json"}.ToMap()}
+		require.NoError(t, ValidateConfiguration(fields, config))
+	})
+
+	t.Run("file too large -> error", func(t *testing.T) {
+		config := map[string]any{"template": NewFileUploadDataURL("template.json", "application/json", []byte("{\"a\": \"bcdef\"}"))}
+		require.ErrorContains(t, ValidateConfiguration(fields, config), "file must be at most 10 bytes")
+	})
+
+	t.Run("file type not accepted -> error", func(t *testing.T) {
+		config := map[string]any{"template": NewFileUploadDataURL("image.png", "image/png", []byte("png"))}
+		require.ErrorContains(t, ValidateConfiguration(fields, config), "file type must be one of .json, text/*")
+	})
+
+	t.Run("reference without key -> error", func(t *testing.T) {
+		config := map[string]any{"template": map[string]any{"name": "template.json"}}
+		require.ErrorContains(t, ValidateConfiguration(fields, config), "file key is required")
+	})
+}
+
+func TestStoreFileFields(t *testing.T) {
+	fields := []Field{
+		{Name: "name", Type: FieldTypeString},
+		{Name: "template", Type: FieldTypeFile},
+		{
+			Name: "advanced",
+			Type: FieldTypeObject,
+			TypeOptions: &TypeOptions{
+				Object: &ObjectTypeOptions{
+					Schema: []Field{{Name: "kubeconfig", Type: FieldTypeFile}},
+				},
+			},
+		},
+	}
+
+	reference := FileReference{Key: "org/existing", Name: "existing.yaml", ContentType: "text/yaml", Size: 3}
+	config := map[string]any{
+		"name":     "stack",
+		"template": NewFileUploadDataURL("template.json", "application/json", []byte("{}")),
+		"advanced": map[string]any{"kubeconfig": reference.ToMap()},
+	}
+
+	stored := []string{}
+	result, err := StoreFileFields(fields, config, func(field Field, upload *FileUpload) (*FileReference, error) {
+		stored = append(stored, field.Name)
+		return &FileReference{Key: "org/new", Name: upload.Name, ContentType: upload.ContentType, Size: int64(len(upload.Data))}, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"template"}, stored)
+	assert.Equal(t, "stack", result["name"])
+	assert.Equal(t, FileReference{Key: "org/new", Name: "template.json", ContentType: "application/json", Size: 2}.ToMap(), result["template"])
+	assert.Equal(t, map[string]any{"kubeconfig": reference.ToMap()}, result["advanced"])
+}
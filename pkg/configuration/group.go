@@ -0,0 +1,43 @@
+package configuration
+
+/*
+ * FieldGroup is a section of a configuration form.
+ * The group with an empty name holds the fields without a group.
+ */
+type FieldGroup struct {
+	Name   string
+	Fields []Field
+}
+
+/*
+ * GroupFields splits the fields into sections. The fields without
+ * a group come first, followed by the groups in the order they
+ * first appear. Fields keep their order within each group.
+ */
+func GroupFields(fields []Field) []FieldGroup {
+	groups := []FieldGroup{}
+	indexes := map[string]int{}
+
+	for _, field := range fields {
+		i, ok := indexes[field.Group]
+		if !ok {
+			i = len(groups)
+			indexes[field.Group] = i
+			groups = append(groups, FieldGroup{Name: field.Group})
+		}
+
+		groups[i].Fields = append(groups[i].Fields, field)
+	}
+
+	//
+	// Ungrouped fields are always displayed first,
+	// even if they are declared after a grouped field.
+	//
+	if i, ok := indexes[""]; ok && i > 0 {
+		ungrouped := groups[i]
+		copy(groups[1:i+1], groups[:i])
+		groups[0] = ungrouped
+	}
+
+	return groups
+}
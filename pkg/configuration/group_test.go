@@ -0,0 +1,36 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupFields(t *testing.T) {
+	fields := []Field{
+		{Name: "machineType", Group: "Machine"},
+		{Name: "instanceName"},
+		{Name: "network", Group: "Networking"},
+		{Name: "zone"},
+		{Name: "machineFamily", Group: "Machine"},
+	}
+
+	groups := GroupFields(fields)
+
+	names := func(group FieldGroup) []string {
+		result := []string{}
+		for _, field := range group.Fields {
+			result = append(result, field.Name)
+		}
+		return result
+	}
+
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "", groups[0].Name)
+	assert.Equal(t, []string{"instanceName", "zone"}, names(groups[0]))
+	assert.Equal(t, "Machine", groups[1].Name)
+	assert.Equal(t, []string{"machineType", "machineFamily"}, names(groups[1]))
+	assert.Equal(t, "Networking", groups[2].Name)
+	assert.Equal(t, []string{"network"}, names(groups[2]))
+	assert.Empty(t, GroupFields(nil))
+}
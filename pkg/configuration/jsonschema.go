@@ -0,0 +1,250 @@
+package configuration
+
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+/*
+ * JSONSchema returns a JSON Schema for a configuration
+ * with the given fields, e.g. for editor autocompletion.
+ *
+ * The SuperPlane field type is kept in "x-superplane-type",
+ * since JSON Schema can only describe the shape of most values.
+ * Values that may be expressions are not restricted to their type,
+ * so expressions like "{{ $.data.count }}" are also accepted,
+ * and expression-capable fields are marked with "x-superplane-expression".
+ */
+func JSONSchema(fields []Field) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for _, field := range fields {
+		properties[field.Name] = FieldJSONSchema(field)
+		if field.Required && len(field.RequiredConditions) == 0 {
+			required = append(required, field.Name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+func FieldJSONSchema(field Field) map[string]any {
+	schema := valueJSONSchema(field)
+	if allowsExpression(field) {
+		schema = map[string]any{"anyOf": []any{schema, expressionJSONSchema()}}
+	}
+
+	schema["x-superplane-type"] = field.Type
+	schema["x-superplane-expression"] = !field.DisallowExpression
+	if field.Label != "" {
+		schema["title"] = field.Label
+	}
+
+	if field.Description != "" {
+		schema["description"] = field.Description
+	}
+
+	if field.Default != nil {
+		schema["default"] = field.Default
+	}
+
+	if field.Sensitive || field.Type == FieldTypeSecret {
+		schema["writeOnly"] = true
+	}
+
+	return schema
+}
+
+/*
+ * Strings accept expressions as they are, so only other types need to be
+ * extended to accept expressions.
+ */
+func allowsExpression(field Field) bool {
+	if field.DisallowExpression {
+		return false
+	}
+
+	switch field.Type {
+	case FieldTypeNumber, FieldTypeBool, FieldTypeDuration:
+		return true
+	default:
+		return false
+	}
+}
+
+func expressionJSONSchema() map[string]any {
+	return map[string]any{
+		"type":    "string",
+		"pattern": `\{\{.*\}\}`,
+	}
+}
+
+func valueJSONSchema(field Field) map[string]any {
+	options := field.TypeOptions
+	if options == nil {
+		options = &TypeOptions{}
+	}
+
+	switch field.Type {
+	case FieldTypeNumber:
+		schema := map[string]any{"type": "number"}
+		if options.Number != nil && options.Number.Min != nil {
+			schema["minimum"] = *options.Number.Min
+		}
+
+		if options.Number != nil && options.Number.Max != nil {
+			schema["maximum"] = *options.Number.Max
+		}
+
+		return schema
+
+	case FieldTypeBool:
+		return map[string]any{"type": "boolean"}
+
+	case FieldTypeString:
+		return stringJSONSchema(options.String)
+
+	case FieldTypeText:
+		if options.Text == nil {
+			return stringJSONSchema(nil)
+		}
+
+		return stringJSONSchema(&StringTypeOptions{MinLength: options.Text.MinLength, MaxLength: options.Text.MaxLength})
+
+	case FieldTypeExpression:
+		if options.Expression == nil {
+			return stringJSONSchema(nil)
+		}
+
+		return stringJSONSchema(&StringTypeOptions{MinLength: options.Expression.MinLength, MaxLength: options.Expression.MaxLength})
+
+	case FieldTypeSelect:
+		if options.Select == nil {
+			return map[string]any{"type": "string"}
+		}
+
+		return enumJSONSchema(options.Select.Options)
+
+	case FieldTypeMultiSelect:
+		items := map[string]any{"type": "string"}
+		if options.MultiSelect != nil {
+			items = enumJSONSchema(options.MultiSelect.Options)
+		}
+
+		return map[string]any{"type": "array", "items": items, "uniqueItems": true}
+
+	case FieldTypeDaysOfWeek:
+		return map[string]any{
+			"type":        "array",
+			"minItems":    1,
+			"uniqueItems": true,
+			"items": map[string]any{
+				"type": "string",
+				"enum": []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"},
+			},
+		}
+
+	case FieldTypeIntegrationResource:
+		if options.Resource != nil && options.Resource.Multi {
+			return map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "uniqueItems": true}
+		}
+
+		return map[string]any{"type": "string"}
+
+	case FieldTypeList:
+		schema := map[string]any{"type": "array"}
+		if options.List == nil {
+			return schema
+		}
+
+		if options.List.MaxItems != nil {
+			schema["maxItems"] = *options.List.MaxItems
+		}
+
+		if item := options.List.ItemDefinition; item != nil {
+			if item.Type == FieldTypeObject {
+				schema["items"] = JSONSchema(item.Schema)
+			} else {
+				schema["items"] = valueJSONSchema(Field{Type: item.Type})
+			}
+		}
+
+		return schema
+
+	case FieldTypeAnyPredicateList:
+		predicateType := map[string]any{"type": "string"}
+		if options.AnyPredicateList != nil && len(options.AnyPredicateList.Operators) > 0 {
+			predicateType = enumJSONSchema(options.AnyPredicateList.Operators)
+		}
+
+		return map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type":     "object",
+				"required": []string{"type", "value"},
+				"properties": map[string]any{
+					"type":  predicateType,
+					"value": map[string]any{"type": "string"},
+				},
+			},
+		}
+
+	case FieldTypeObject:
+		if options.DynamicSchema != nil || options.Object == nil {
+			return map[string]any{"type": "object"}
+		}
+
+		return JSONSchema(options.Object.Schema)
+
+	case FieldTypeDuration:
+		schema := map[string]any{"type": []string{"integer", "string"}}
+		if options.Duration != nil && options.Duration.Min != nil {
+			schema["minimum"] = *options.Duration.Min
+		}
+
+		if options.Duration != nil && options.Duration.Max != nil {
+			schema["maximum"] = *options.Duration.Max
+		}
+
+		return schema
+
+	case FieldTypeFile:
+		return map[string]any{"type": []string{"string", "object"}}
+
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func stringJSONSchema(options *StringTypeOptions) map[string]any {
+	schema := map[string]any{"type": "string"}
+	if options == nil {
+		return schema
+	}
+
+	if options.MinLength != nil {
+		schema["minLength"] = *options.MinLength
+	}
+
+	if options.MaxLength != nil {
+		schema["maxLength"] = *options.MaxLength
+	}
+
+	return schema
+}
+
+func enumJSONSchema(options []FieldOption) map[string]any {
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		values = append(values, option.Value)
+	}
+
+	return map[string]any{"type": "string", "enum": values}
+}
@@ -0,0 +1,53 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONSchema(t *testing.T) {
+	min := 1
+	max := 10
+	schema := JSONSchema([]Field{
+		{Name: "url", Label: "URL", Type: FieldTypeString, Required: true, Description: "Where to send the request"},
+		{Name: "retries", Label: "Retries", Type: FieldTypeNumber, Default: 3, TypeOptions: &TypeOptions{Number: &NumberTypeOptions{Min: &min, Max: &max}}},
+		{Name: "method", Label: "Method", Type: FieldTypeSelect, DisallowExpression: true, TypeOptions: &TypeOptions{
+			Select: &SelectTypeOptions{Options: []FieldOption{{Label: "GET", Value: "GET"}, {Label: "POST", Value: "POST"}}},
+		}},
+		{Name: "token", Label: "Token", Type: FieldTypeSecret, Required: true, RequiredConditions: []RequiredCondition{{Field: "method", Values: []string{"POST"}}}},
+		{Name: "headers", Label: "Headers", Type: FieldTypeList, TypeOptions: &TypeOptions{List: &ListTypeOptions{
+			ItemDefinition: &ListItemDefinition{Type: FieldTypeObject, Schema: []Field{{Name: "name", Type: FieldTypeString, Required: true}}},
+		}}},
+	})
+
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []string{"url"}, schema["required"], "conditionally required fields are not always required")
+
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"type":                    "string",
+		"title":                   "URL",
+		"description":             "Where to send the request",
+		"x-superplane-type":       FieldTypeString,
+		"x-superplane-expression": true,
+	}, properties["url"])
+
+	assert.Equal(t, map[string]any{
+		"anyOf": []any{
+			map[string]any{"type": "number", "minimum": 1, "maximum": 10},
+			map[string]any{"type": "string", "pattern": `\{\{.*\}\}`},
+		},
+		"title":                   "Retries",
+		"default":                 3,
+		"x-superplane-type":       FieldTypeNumber,
+		"x-superplane-expression": true,
+	}, properties["retries"], "numbers also accept expressions")
+
+	assert.Equal(t, []string{"GET", "POST"}, properties["method"].(map[string]any)["enum"])
+	assert.Equal(t, false, properties["method"].(map[string]any)["x-superplane-expression"])
+	assert.Equal(t, true, properties["token"].(map[string]any)["writeOnly"])
+
+	items := properties["headers"].(map[string]any)["items"].(map[string]any)
+	assert.Equal(t, []string{"name"}, items["required"])
+}
@@ -0,0 +1,92 @@
+package configuration
+
+import (
+	"maps"
+	"strings"
+	"time"
+)
+
+/*
+ * NormalizeConfiguration returns a copy of the configuration with
+ * values stored in their canonical form: durations as a number of seconds,
+ * and cron expressions with single spaces and descriptors expanded.
+ * Values that are not valid, or that contain expressions, are kept as they are.
+ */
+func NormalizeConfiguration(fields []Field, config map[string]any) map[string]any {
+	if config == nil {
+		return nil
+	}
+
+	result := maps.Clone(config)
+	for _, field := range fields {
+		value, ok := config[field.Name]
+		if !ok || value == nil {
+			continue
+		}
+
+		switch field.Type {
+		case FieldTypeDuration:
+			if s, ok := value.(string); ok && expressionPlaceholderRegex.MatchString(s) {
+				continue
+			}
+
+			duration, err := ParseDuration(value)
+			if err != nil || duration%time.Second != 0 {
+				continue
+			}
+
+			result[field.Name] = int64(duration / time.Second)
+
+		case FieldTypeCron:
+			s, ok := value.(string)
+			if !ok || expressionPlaceholderRegex.MatchString(s) {
+				continue
+			}
+
+			normalized, err := NormalizeCron(s)
+			if err != nil {
+				continue
+			}
+
+			result[field.Name] = normalized
+
+		case FieldTypeObject:
+			object, ok := value.(map[string]any)
+			if !ok || field.TypeOptions == nil || field.TypeOptions.Object == nil {
+				continue
+			}
+
+			result[field.Name] = NormalizeConfiguration(field.TypeOptions.Object.Schema, object)
+		}
+	}
+
+	return result
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+/*
+ * NormalizeCron validates the cron expression, and returns it
+ * in its canonical form: descriptors like @daily are expanded
+ * to 5 fields, and fields are separated by single spaces.
+ */
+func NormalizeCron(value string) (string, error) {
+	value = strings.Join(strings.Fields(value), " ")
+	if expanded, ok := cronDescriptors[strings.ToLower(value)]; ok {
+		value = expanded
+	}
+
+	if err := validateCron(Field{}, value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
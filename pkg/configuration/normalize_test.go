@@ -0,0 +1,59 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeConfiguration(t *testing.T) {
+	fields := []Field{
+		{Name: "name", Type: FieldTypeString},
+		{Name: "timeout", Type: FieldTypeDuration},
+		{Name: "interval", Type: FieldTypeDuration},
+		{Name: "schedule", Type: FieldTypeCron},
+		{
+			Name: "retry",
+			Type: FieldTypeObject,
+			TypeOptions: &TypeOptions{
+				Object: &ObjectTypeOptions{
+					Schema: []Field{{Name: "delay", Type: FieldTypeDuration}},
+				},
+			},
+		},
+	}
+
+	config := map[string]any{
+		"name":     "1h",
+		"timeout":  "1h30m",
+		"interval": "{{ $['trigger'].data.interval }}",
+		"schedule": "  @daily ",
+		"retry":    map[string]any{"delay": "30"},
+	}
+
+	normalized := NormalizeConfiguration(fields, config)
+
+	assert.Equal(t, map[string]any{
+		"name":     "1h",
+		"timeout":  int64(5400),
+		"interval": "{{ $['trigger'].data.interval }}",
+		"schedule": "0 0 * * *",
+		"retry":    map[string]any{"delay": int64(30)},
+	}, normalized)
+
+	assert.Equal(t, "1h30m", config["timeout"], "input configuration is not modified")
+}
+
+func TestNormalizeCron(t *testing.T) {
+	normalized, err := NormalizeCron(" 0   9 * *   MON-FRI ")
+	require.NoError(t, err)
+	assert.Equal(t, "0 9 * * MON-FRI", normalized)
+
+	normalized, err = NormalizeCron("@hourly")
+	require.NoError(t, err)
+	assert.Equal(t, "0 * * * *", normalized)
+
+	_, err = NormalizeCron("0 9 * *")
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package configuration
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+/*
+ * Values of secret fields are never stored in plain text.
+ * Plain text values are encrypted when the node is saved, and
+ * stored as "sealed:<base64 ciphertext>". API responses only
+ * include the sealed value, so sending it back keeps the secret.
+ */
+const SealedSecretPrefix = "sealed:"
+
+/*
+ * SecretEncryptor is the subset of crypto.Encryptor
+ * used for sealing secret fields.
+ */
+type SecretEncryptor interface {
+	Encrypt(context.Context, []byte, []byte) ([]byte, error)
+	Decrypt(context.Context, []byte, []byte) ([]byte, error)
+}
+
+func IsSealedSecret(value string) bool {
+	return strings.HasPrefix(value, SealedSecretPrefix)
+}
+
+/*
+ * SealSecretFields returns a copy of the configuration
+ * with the plain text values of secret fields encrypted.
+ */
+func SealSecretFields(ctx context.Context, encryptor SecretEncryptor, fields []Field, config map[string]any, associatedData []byte) (map[string]any, error) {
+	return transformSecretFields(fields, config, func(field Field, value string) (string, error) {
+		if IsSealedSecret(value) {
+			return value, nil
+		}
+
+		encrypted, err := encryptor.Encrypt(ctx, []byte(value), associatedData)
+		if err != nil {
+			return "", fmt.Errorf("failed to encrypt field %s: %w", field.Name, err)
+		}
+
+		return SealedSecretPrefix + base64.StdEncoding.EncodeToString(encrypted), nil
+	})
+}
+
+/*
+ * UnsealSecretFields returns a copy of the configuration
+ * with the sealed values of secret fields decrypted.
+ */
+func UnsealSecretFields(ctx context.Context, encryptor SecretEncryptor, fields []Field, config map[string]any, associatedData []byte) (map[string]any, error) {
+	return transformSecretFields(fields, config, func(field Field, value string) (string, error) {
+		if !IsSealedSecret(value) {
+			return value, nil
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, SealedSecretPrefix))
+		if err != nil {
+			return "", fmt.Errorf("failed to decode field %s: %w", field.Name, err)
+		}
+
+		decrypted, err := encryptor.Decrypt(ctx, decoded, associatedData)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt field %s: %w", field.Name, err)
+		}
+
+		return string(decrypted), nil
+	})
+}
+
+/*
+ * transformSecretFields applies fn to the non-empty values of secret fields,
+ * including the ones in the schema of object fields.
+ */
+func transformSecretFields(fields []Field, config map[string]any, fn func(Field, string) (string, error)) (map[string]any, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	result := maps.Clone(config)
+	for _, field := range fields {
+		switch field.Type {
+		case FieldTypeSecret:
+			value, ok := config[field.Name].(string)
+			if !ok || value == "" {
+				continue
+			}
+
+			transformed, err := fn(field, value)
+			if err != nil {
+				return nil, err
+			}
+
+			result[field.Name] = transformed
+
+		case FieldTypeObject:
+			object, ok := config[field.Name].(map[string]any)
+			if !ok || field.TypeOptions == nil || field.TypeOptions.Object == nil {
+				continue
+			}
+
+			transformed, err := transformSecretFields(field.TypeOptions.Object.Schema, object, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			result[field.Name] = transformed
+		}
+	}
+
+	return result, nil
+}
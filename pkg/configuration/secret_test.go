@@ -0,0 +1,72 @@
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/crypto"
+)
+
+func TestSealSecretFields(t *testing.T) {
+	encryptor := crypto.NewAESGCMEncryptor([]byte("1234567890abcdefghijklmnopqrstuv"))
+	ad := []byte("org-1")
+	fields := []Field{
+		{Name: "url", Type: FieldTypeString},
+		{Name: "token", Type: FieldTypeSecret},
+		{
+			Name: "authentication",
+			Type: FieldTypeObject,
+			TypeOptions: &TypeOptions{
+				Object: &ObjectTypeOptions{
+					Schema: []Field{
+						{Name: "username", Type: FieldTypeString},
+						{Name: "password", Type: FieldTypeSecret},
+					},
+				},
+			},
+		},
+	}
+
+	config := map[string]any{
+		"url":   "https://example.com",
+		"token": "my-token",
+		"authentication": map[string]any{
+			"username": "admin",
+			"password": "my-password",
+		},
+	}
+
+	sealed, err := SealSecretFields(context.Background(), encryptor, fields, config, ad)
+	require.NoError(t, err)
+
+	t.Run("plain text values are encrypted", func(t *testing.T) {
+		assert.Equal(t, "https://example.com", sealed["url"])
+		assert.True(t, IsSealedSecret(sealed["token"].(string)))
+		assert.NotContains(t, sealed["token"], "my-token")
+
+		authentication := sealed["authentication"].(map[string]any)
+		assert.Equal(t, "admin", authentication["username"])
+		assert.True(t, IsSealedSecret(authentication["password"].(string)))
+
+		assert.Equal(t, "my-token", config["token"], "input configuration is not modified")
+	})
+
+	t.Run("sealed values are kept when sealing again", func(t *testing.T) {
+		resealed, err := SealSecretFields(context.Background(), encryptor, fields, sealed, ad)
+		require.NoError(t, err)
+		assert.Equal(t, sealed, resealed)
+	})
+
+	t.Run("unsealing returns plain text values", func(t *testing.T) {
+		unsealed, err := UnsealSecretFields(context.Background(), encryptor, fields, sealed, ad)
+		require.NoError(t, err)
+		assert.Equal(t, config, unsealed)
+	})
+
+	t.Run("unsealing with different associated data fails", func(t *testing.T) {
+		_, err := UnsealSecretFields(context.Background(), encryptor, fields, sealed, []byte("org-2"))
+		require.ErrorContains(t, err, "failed to decrypt field token")
+	})
+}
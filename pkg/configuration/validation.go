@@ -544,6 +544,10 @@ func validateCron(_ Field, value any) error {
 		return fmt.Errorf("cron expression cannot be empty")
 	}
 
+	if expanded, ok := cronDescriptors[strings.ToLower(strings.TrimSpace(cronStr))]; ok {
+		cronStr = expanded
+	}
+
 	// Validate allowed wildcards: * , - /
 	validChars := "0123456789*,-/ abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	for _, char := range cronStr {
@@ -578,6 +582,62 @@ func validateCron(_ Field, value any) error {
 	return nil
 }
 
+func validateIntegrationResource(field Field, value any) error {
+	if field.TypeOptions == nil || field.TypeOptions.Resource == nil || !field.TypeOptions.Resource.Multi {
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("must be a string")
+		}
+
+		return nil
+	}
+
+	// Multi-select resources are a list of IDs (or names, with UseNameAsValue)
+	selectedValues, err := ResourceValues(value)
+	if err != nil {
+		return err
+	}
+
+	if field.Required && len(selectedValues) == 0 {
+		return fmt.Errorf("at least one value must be selected")
+	}
+
+	seen := make(map[string]bool, len(selectedValues))
+	for _, selectedValue := range selectedValues {
+		if seen[selectedValue] {
+			return fmt.Errorf("value %s is selected more than once", selectedValue)
+		}
+
+		seen[selectedValue] = true
+	}
+
+	return nil
+}
+
+/*
+ * ResourceValues returns the values selected
+ * in a multi-select integration resource field.
+ */
+func ResourceValues(value any) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("all items must be strings")
+			}
+
+			values = append(values, s)
+		}
+
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("must be a list of values")
+}
+
 func validateFieldValue(field Field, value any) error {
 	switch field.Type {
 	case FieldTypeString:
@@ -607,25 +667,19 @@ func validateFieldValue(field Field, value any) error {
 		return validateDaysOfWeek(field, value)
 
 	case FieldTypeIntegrationResource:
-		// If Multi is true, validate as array of strings
-		// Otherwise, validate as a single string
-		if field.TypeOptions != nil && field.TypeOptions.Resource != nil && field.TypeOptions.Resource.Multi {
-			selectedValues, ok := value.([]any)
-			if !ok {
-				return fmt.Errorf("must be a list of values")
-			}
-			// Validate all items are strings
-			for _, selectedValue := range selectedValues {
-				if _, ok := selectedValue.(string); !ok {
-					return fmt.Errorf("all items must be strings")
-				}
-			}
-			return nil
-		}
+		return validateIntegrationResource(field, value)
+
+	case FieldTypeSecret:
 		if _, ok := value.(string); !ok {
 			return fmt.Errorf("must be a string")
 		}
 
+	case FieldTypeFile:
+		return validateFile(field, value)
+
+	case FieldTypeDuration:
+		return validateDuration(field, value)
+
 	case FieldTypeGitRef:
 		// Git reference is represented as a string (e.g., refs/heads/main, refs/tags/v1.0.0)
 		if _, ok := value.(string); !ok {
@@ -699,15 +753,10 @@ func isRequiredByCondition(field Field, config map[string]any) bool {
 	return false
 }
 
-// validateFieldRules validates comparison rules between fields
+// validateFieldRules validates the field rules, including comparison rules between fields
 func validateFieldRules(field Field, value any, config map[string]any) error {
 	for _, rule := range field.ValidationRules {
-		compareValue, exists := config[rule.CompareWith]
-		if !exists || compareValue == nil {
-			continue // Skip validation if comparison field doesn't exist
-		}
-
-		err := validateComparisonRule(field, value, compareValue, rule)
+		err := validateFieldRule(field, value, config, rule)
 		if err != nil {
 			if rule.Message != "" {
 				return fmt.Errorf("%s", rule.Message)
@@ -718,6 +767,126 @@ func validateFieldRules(field Field, value any, config map[string]any) error {
 	return nil
 }
 
+func validateFieldRule(field Field, value any, config map[string]any, rule ValidationRule) error {
+	switch rule.Type {
+	case ValidationRulePattern:
+		return validatePatternRule(value, rule)
+	case ValidationRuleMin, ValidationRuleMax:
+		return validateBoundRule(value, rule)
+	case ValidationRuleMutuallyExclusive:
+		return validateMutuallyExclusiveRule(value, config, rule)
+	}
+
+	compareValue, exists := config[rule.CompareWith]
+	if !exists || compareValue == nil {
+		return nil // Skip validation if comparison field doesn't exist
+	}
+
+	return validateComparisonRule(field, value, compareValue, rule)
+}
+
+// validatePatternRule validates that a string value matches the rule pattern.
+// Values with expressions are only known at execution time, so they are skipped.
+func validatePatternRule(value any, rule ValidationRule) error {
+	pattern, ok := rule.Value.(string)
+	if !ok {
+		return fmt.Errorf("pattern rule requires a string pattern")
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("must be a string")
+	}
+
+	if expressionPlaceholderRegex.MatchString(text) {
+		return nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	if !re.MatchString(text) {
+		return fmt.Errorf("must match pattern %s", pattern)
+	}
+
+	return nil
+}
+
+// validateBoundRule validates that a number is within the inclusive bound of the rule.
+func validateBoundRule(value any, rule ValidationRule) error {
+	bound, ok := toFloat(rule.Value)
+	if !ok {
+		return fmt.Errorf("%s rule requires a number", rule.Type)
+	}
+
+	if text, ok := value.(string); ok && expressionPlaceholderRegex.MatchString(text) {
+		return nil
+	}
+
+	num, ok := toFloat(value)
+	if !ok {
+		return fmt.Errorf("must be a number")
+	}
+
+	if rule.Type == ValidationRuleMin && num < bound {
+		return fmt.Errorf("must be at least %v", bound)
+	}
+
+	if rule.Type == ValidationRuleMax && num > bound {
+		return fmt.Errorf("must be at most %v", bound)
+	}
+
+	return nil
+}
+
+// validateMutuallyExclusiveRule validates that the field and the CompareWith field are not both set.
+func validateMutuallyExclusiveRule(value any, config map[string]any, rule ValidationRule) error {
+	if isEmptyValue(value) {
+		return nil
+	}
+
+	other, exists := config[rule.CompareWith]
+	if !exists || isEmptyValue(other) {
+		return nil
+	}
+
+	return fmt.Errorf("cannot be set together with '%s'", rule.CompareWith)
+}
+
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
 // validateComparisonRule validates a single comparison rule
 func validateComparisonRule(field Field, value any, compareValue any, rule ValidationRule) error {
 	switch field.Type {
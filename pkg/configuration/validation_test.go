@@ -207,6 +207,102 @@ func TestValidateConfiguration_ValidationRules(t *testing.T) {
 	}
 }
 
+func TestValidateConfiguration_StandaloneValidationRules(t *testing.T) {
+	fields := []Field{
+		{
+			Name: "name",
+			Type: FieldTypeString,
+			ValidationRules: []ValidationRule{
+				{Type: ValidationRulePattern, Value: `^[a-z][-a-z0-9]*$`},
+			},
+		},
+		{
+			Name: "diskSize",
+			Type: FieldTypeNumber,
+			ValidationRules: []ValidationRule{
+				{Type: ValidationRuleMin, Value: 10},
+				{Type: ValidationRuleMax, Value: 65536},
+			},
+		},
+		{
+			Name: "image",
+			Type: FieldTypeString,
+			ValidationRules: []ValidationRule{
+				{
+					Type:        ValidationRuleMutuallyExclusive,
+					CompareWith: "snapshot",
+					Message:     "use either an image or a snapshot",
+				},
+			},
+		},
+		{
+			Name: "snapshot",
+			Type: FieldTypeString,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "valid configuration",
+			config:      map[string]any{"name": "my-vm", "diskSize": 10.0, "image": "debian-12"},
+			expectError: false,
+		},
+		{
+			name:        "name does not match pattern",
+			config:      map[string]any{"name": "My_VM"},
+			expectError: true,
+			errorMsg:    "field 'name': must match pattern",
+		},
+		{
+			name:        "name with expression is not checked",
+			config:      map[string]any{"name": "{{ $['trigger'].data.name }}"},
+			expectError: false,
+		},
+		{
+			name:        "disk size below min",
+			config:      map[string]any{"diskSize": 9.0},
+			expectError: true,
+			errorMsg:    "field 'diskSize': must be at least 10",
+		},
+		{
+			name:        "disk size above max",
+			config:      map[string]any{"diskSize": 70000},
+			expectError: true,
+			errorMsg:    "field 'diskSize': must be at most 65536",
+		},
+		{
+			name:        "mutually exclusive fields both set",
+			config:      map[string]any{"image": "debian-12", "snapshot": "snap-1"},
+			expectError: true,
+			errorMsg:    "use either an image or a snapshot",
+		},
+		{
+			name:        "mutually exclusive field set to empty value",
+			config:      map[string]any{"image": "", "snapshot": "snap-1"},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfiguration(fields, tt.config)
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.errorMsg != "" {
+					assert.Contains(t, err.Error(), tt.errorMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateConfiguration_DaysOfWeek(t *testing.T) {
 	fields := []Field{
 		{
@@ -256,6 +352,45 @@ func TestValidateConfiguration_DaysOfWeek(t *testing.T) {
 	}
 }
 
+func TestValidateConfiguration_MultiIntegrationResource(t *testing.T) {
+	resourceField := func(required bool) Field {
+		return Field{
+			Name:     "zones",
+			Type:     FieldTypeIntegrationResource,
+			Required: required,
+			TypeOptions: &TypeOptions{
+				Resource: &ResourceTypeOptions{Type: "zone", Multi: true},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		required    bool
+		value       any
+		expectError string
+	}{
+		{name: "list of IDs", required: true, value: []any{"us-central1-a", "us-central1-b"}},
+		{name: "list of IDs as strings", required: true, value: []string{"us-central1-a"}},
+		{name: "empty list for optional field", required: false, value: []any{}},
+		{name: "empty list for required field", required: true, value: []any{}, expectError: "at least one value must be selected"},
+		{name: "duplicate IDs", required: true, value: []any{"us-central1-a", "us-central1-a"}, expectError: "selected more than once"},
+		{name: "non-string item", required: true, value: []any{"us-central1-a", 1.0}, expectError: "all items must be strings"},
+		{name: "single value", required: true, value: "us-central1-a", expectError: "must be a list of values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfiguration([]Field{resourceField(tt.required)}, map[string]any{"zones": tt.value})
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateConfiguration_TimeRange(t *testing.T) {
 	fields := []Field{
 		{
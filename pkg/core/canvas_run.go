@@ -0,0 +1,56 @@
+package core
+
+const (
+	CanvasRunStateRunning   = "running"
+	CanvasRunStatePassed    = "passed"
+	CanvasRunStateFailed    = "failed"
+	CanvasRunStateCancelled = "cancelled"
+)
+
+/*
+ * CanvasRunsContext allows components to start runs of other canvases
+ * in the same organization, and to follow them until they finish.
+ *
+ * A run starts from a Manual Run trigger node of the called canvas,
+ * with the given payload as the trigger event.
+ */
+type CanvasRunsContext interface {
+
+	/*
+	 * Start creates the trigger event for nodeID in the canvas,
+	 * and returns the ID of the run. If nodeID is empty,
+	 * the canvas must have exactly one Manual Run trigger node.
+	 */
+	Start(canvasID, nodeID string, payload map[string]any) (string, error)
+
+	/*
+	 * Get returns the current state of a run started with Start.
+	 */
+	Get(canvasID, runID string) (*CanvasRun, error)
+}
+
+/*
+ * CanvasRun is a run of a canvas, i.e. all the executions
+ * started from the same trigger event.
+ *
+ * The run is finished once none of its executions are pending or started,
+ * and no event emitted by them is waiting to be routed or processed.
+ * Outputs are the payloads emitted by the executions of the run
+ * that did not lead to further executions, i.e. its terminal outputs.
+ */
+type CanvasRun struct {
+	ID      string
+	State   string
+	Message string
+	Outputs []CanvasRunOutput
+}
+
+type CanvasRunOutput struct {
+	NodeID  string `json:"nodeId"`
+	Channel string `json:"channel"`
+	Data    any    `json:"data"`
+}
+
+func (r *CanvasRun) IsFinished() bool {
+	return r.State != CanvasRunStateRunning
+}
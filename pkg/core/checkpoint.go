@@ -0,0 +1,62 @@
+package core
+
+/*
+ * Checkpoint is a step of Execute() that can be resumed,
+ * e.g. waiting on a cloud operation that was already started.
+ *
+ * Execute() runs in a transaction that is rolled back if the process stops,
+ * so the execution is processed again after a restart. Without a checkpoint,
+ * Execute() would start the same work again. With one, the executor calls
+ * Resume() instead, which picks up from the operation that was recorded.
+ */
+type Checkpoint struct {
+
+	//
+	// Name of the step, e.g. "waitForInsert".
+	//
+	Step string `json:"step"`
+
+	//
+	// What to poll to know if the step is done, e.g. the name of a cloud operation.
+	//
+	Operation string `json:"operation"`
+
+	//
+	// Anything else needed to poll the operation and finish the execution,
+	// e.g. the project, zone and name of the instance being created.
+	//
+	Target map[string]any `json:"target,omitempty"`
+}
+
+/*
+ * CheckpointContext records the checkpoint of an execution.
+ * Checkpoints are kept even if the execution transaction is rolled back.
+ * Saving a checkpoint replaces the previous one.
+ */
+type CheckpointContext interface {
+	Save(checkpoint Checkpoint) error
+}
+
+/*
+ * ResumableComponent is implemented by components that save checkpoints.
+ * Resume() is called instead of Execute() when a pending execution has one,
+ * and must finish the execution like Execute() would.
+ */
+type ResumableComponent interface {
+	Resume(ctx ExecutionContext, checkpoint Checkpoint) error
+}
+
+/*
+ * SaveCheckpoint records a resumable step of the execution.
+ * Failing to save a checkpoint does not fail the execution,
+ * it only means the step cannot be resumed after a restart.
+ */
+func (c ExecutionContext) SaveCheckpoint(checkpoint Checkpoint) {
+	if c.Checkpoints == nil {
+		return
+	}
+
+	if err := c.Checkpoints.Save(checkpoint); err != nil && c.Logger != nil {
+		c.Logger.Warnf("error saving checkpoint %s: %v", checkpoint.Step, err)
+	}
+}
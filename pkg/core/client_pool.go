@@ -0,0 +1,112 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * ClientPool keeps the clients of integration installations,
+ * shared by every call to the installation, e.g. the resource lists
+ * for all the fields of a canvas, instead of creating one on every call.
+ *
+ * An installation can have more than one client, e.g. one per set of scopes,
+ * so clients are stored by installation and by a key chosen by the caller.
+ *
+ * Clients are created from the credentials of the installation,
+ * so each one is stored with a fingerprint of what it was created from.
+ * When the fingerprint changes, e.g. because the credentials were rotated,
+ * a new client replaces the previous one.
+ */
+type ClientPool[T any] struct {
+	mu      sync.Mutex
+	clients map[uuid.UUID]map[string]pooledClient[T]
+}
+
+type pooledClient[T any] struct {
+	fingerprint string
+	client      T
+}
+
+func NewClientPool[T any]() *ClientPool[T] {
+	return &ClientPool[T]{
+		clients: map[uuid.UUID]map[string]pooledClient[T]{},
+	}
+}
+
+/*
+ * Get returns the client of the installation stored under the key,
+ * if it was created from the given fingerprint, creating it if there is none.
+ * Clients that fail to be created are not stored.
+ */
+func (p *ClientPool[T]) Get(integrationID uuid.UUID, key string, fingerprint string, create func() (T, error)) (T, error) {
+	if client, ok := p.find(integrationID, key, fingerprint); ok {
+		return client, nil
+	}
+
+	client, err := create()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	//
+	// Another call may have created the client while this one was,
+	// so the first one stored is kept, and shared by both.
+	//
+	clients, ok := p.clients[integrationID]
+	if !ok {
+		clients = map[string]pooledClient[T]{}
+		p.clients[integrationID] = clients
+	}
+
+	if pooled, ok := clients[key]; ok && pooled.fingerprint == fingerprint {
+		return pooled.client, nil
+	}
+
+	clients[key] = pooledClient[T]{fingerprint: fingerprint, client: client}
+	return client, nil
+}
+
+func (p *ClientPool[T]) find(integrationID uuid.UUID, key string, fingerprint string) (T, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pooled, ok := p.clients[integrationID][key]
+	if !ok || pooled.fingerprint != fingerprint {
+		var zero T
+		return zero, false
+	}
+
+	return pooled.client, true
+}
+
+/*
+ * Remove drops all the clients of an installation,
+ * e.g. when it is deleted, or its credentials change.
+ */
+func (p *ClientPool[T]) Remove(integrationID uuid.UUID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, integrationID)
+}
+
+/*
+ * ClientFingerprint hashes what a client is created from,
+ * so secrets are not kept in the pool in clear text.
+ */
+func ClientFingerprint(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,83 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func TestClientPool(t *testing.T) {
+	pool := core.NewClientPool[*int]()
+	integrationID := uuid.New()
+	created := 0
+	create := func() (*int, error) {
+		created++
+		client := created
+		return &client, nil
+	}
+
+	t.Run("installation shares the same client", func(t *testing.T) {
+		first, err := pool.Get(integrationID, "scope", core.ClientFingerprint([]byte("key")), create)
+		require.NoError(t, err)
+		second, err := pool.Get(integrationID, "scope", core.ClientFingerprint([]byte("key")), create)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("other installations have their own client", func(t *testing.T) {
+		client, err := pool.Get(uuid.New(), "scope", core.ClientFingerprint([]byte("key")), create)
+		require.NoError(t, err)
+		assert.Equal(t, 2, *client)
+	})
+
+	t.Run("new fingerprint replaces the client", func(t *testing.T) {
+		client, err := pool.Get(integrationID, "scope", core.ClientFingerprint([]byte("rotated")), create)
+		require.NoError(t, err)
+		assert.Equal(t, 3, *client)
+
+		again, err := pool.Get(integrationID, "scope", core.ClientFingerprint([]byte("rotated")), create)
+		require.NoError(t, err)
+		assert.Same(t, client, again)
+	})
+
+	t.Run("clients that fail to be created are not stored", func(t *testing.T) {
+		id := uuid.New()
+		_, err := pool.Get(id, "scope", "", func() (*int, error) { return nil, errors.New("invalid key") })
+		require.ErrorContains(t, err, "invalid key")
+
+		client, err := pool.Get(id, "scope", "", create)
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("other keys of the installation have their own client", func(t *testing.T) {
+		client, err := pool.Get(integrationID, "other-scope", core.ClientFingerprint([]byte("rotated")), create)
+		require.NoError(t, err)
+		assert.Equal(t, 5, *client)
+
+		again, err := pool.Get(integrationID, "scope", core.ClientFingerprint([]byte("rotated")), create)
+		require.NoError(t, err)
+		assert.Equal(t, 3, *again)
+	})
+
+	t.Run("removed installation gets new clients for all keys", func(t *testing.T) {
+		pool.Remove(integrationID)
+		client, err := pool.Get(integrationID, "scope", core.ClientFingerprint([]byte("rotated")), create)
+		require.NoError(t, err)
+		assert.Equal(t, 6, *client)
+
+		other, err := pool.Get(integrationID, "other-scope", core.ClientFingerprint([]byte("rotated")), create)
+		require.NoError(t, err)
+		assert.Equal(t, 7, *other)
+	})
+}
+
+func TestClientFingerprint(t *testing.T) {
+	assert.Equal(t, core.ClientFingerprint([]byte("a"), []byte("b")), core.ClientFingerprint([]byte("a"), []byte("b")))
+	assert.NotEqual(t, core.ClientFingerprint([]byte("ab")), core.ClientFingerprint([]byte("a"), []byte("b")))
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net/http"
 	"time"
 
@@ -12,6 +14,131 @@ import (
 
 var DefaultOutputChannel = OutputChannel{Name: "default", Label: "Default"}
 
+/*
+ * ErrorOutputChannel is available on every component node
+ * that enables the RouteErrorsField configuration field.
+ *
+ * When the execution of such a node fails, the execution is still
+ * marked as failed, but an event describing the failure is also emitted
+ * on this channel, so remediation nodes can handle it.
+ */
+var ErrorOutputChannel = OutputChannel{
+	Name:        "error",
+	Label:       "Error",
+	Description: "Emitted when the execution fails",
+}
+
+const RouteErrorsField = "routeErrors"
+const ErrorPayloadType = "execution.failed"
+
+const TimeoutPayloadType = "execution.timedOut"
+
+/*
+ * ExecutionTimeoutField is prefixed, since components like merge
+ * already have an executionTimeout field of their own.
+ */
+const ExecutionTimeoutField = "frameworkExecutionTimeout"
+
+/*
+ * FrameworkConfigurationFields are the configuration fields
+ * handled by the framework, and added to every component.
+ */
+func FrameworkConfigurationFields() []configuration.Field {
+	fields := []configuration.Field{
+		{
+			Name:        RouteErrorsField,
+			Label:       "Route errors to channel",
+			Type:        configuration.FieldTypeBool,
+			Description: "Emit failures on the error channel instead of only failing the execution",
+			Default:     false,
+		},
+		{
+			Name:        ExecutionTimeoutField,
+			Label:       "Execution timeout",
+			Type:        configuration.FieldTypeDuration,
+			Description: "Cancel and fail the execution if it does not finish in time",
+			Placeholder: "e.g. 30m",
+			Togglable:   true,
+			TypeOptions: &configuration.TypeOptions{
+				Duration: &configuration.DurationTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+				},
+			},
+		},
+	}
+
+	return append(fields, QueueConfigurationFields()...)
+}
+
+/*
+ * RouteErrorsEnabled checks if the node configuration
+ * has opted in to routing failures to the error channel.
+ */
+func RouteErrorsEnabled(config any) bool {
+	values, ok := config.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	switch v := values[RouteErrorsField].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+/*
+ * ExecutionTimeout returns the execution timeout configured for the node,
+ * or zero if the node has no execution timeout.
+ */
+func ExecutionTimeout(config any) time.Duration {
+	values, ok := config.(map[string]any)
+	if !ok {
+		return 0
+	}
+
+	value, ok := values[ExecutionTimeoutField]
+	if !ok || value == nil {
+		return 0
+	}
+
+	timeout, err := configuration.ParseDuration(value)
+	if err != nil {
+		return 0
+	}
+
+	return timeout
+}
+
+/*
+ * DefaultExecutionTimeoutProvider is implemented by components
+ * whose executions should never run without a deadline,
+ * e.g. the ones waiting for long-running cloud operations.
+ */
+type DefaultExecutionTimeoutProvider interface {
+	DefaultExecutionTimeout() time.Duration
+}
+
+/*
+ * ExecutionTimeoutFor returns the execution timeout configured for the node,
+ * or the default execution timeout of the component, if it has one.
+ */
+func ExecutionTimeoutFor(component Component, config any) time.Duration {
+	if timeout := ExecutionTimeout(config); timeout > 0 {
+		return timeout
+	}
+
+	provider, ok := component.(DefaultExecutionTimeoutProvider)
+	if !ok {
+		return 0
+	}
+
+	return provider.DefaultExecutionTimeout()
+}
+
 var ErrSecretKeyNotFound = errors.New("secret or key not found")
 
 type Component interface {
@@ -129,6 +256,16 @@ type OutputChannel struct {
 	Description string
 }
 
+/*
+ * Payloads emitted to a single output channel,
+ * used when an execution emits to multiple channels.
+ */
+type ChannelOutput struct {
+	Channel     string
+	PayloadType string
+	Payloads    []any
+}
+
 /*
  * ExecutionContext allows the component
  * to control the state and metadata of each execution of it.
@@ -153,8 +290,58 @@ type ExecutionContext struct {
 	Integration    IntegrationContext
 	Notifications  NotificationContext
 	Secrets        SecretsContext
+	Files          FilesContext
 	CanvasMemory   CanvasMemoryContext
+	CanvasRuns     CanvasRunsContext
 	Webhook        NodeWebhookContext
+	Logs           LogsContext
+	Costs          CostContext
+	Checkpoints    CheckpointContext
+
+	//
+	// Set when the canvas runs in test mode: components must not
+	// change external systems. See TestModeComponent.
+	//
+	TestMode bool
+
+	//
+	// Carries the deadline of the execution, if the node has an execution timeout.
+	// Use GoContext(), since it is not set everywhere.
+	//
+	Context context.Context
+}
+
+func (c ExecutionContext) GoContext() context.Context {
+	if c.Context == nil {
+		return context.Background()
+	}
+
+	return c.Context
+}
+
+/*
+ * ReportCost records a cost hint for the execution.
+ * Costs are only hints, so failing to record them does not fail the execution,
+ * and nothing is recorded where costs are not tracked.
+ */
+func (c ExecutionContext) ReportCost(cost Cost) {
+	if c.Costs == nil || cost.Amount <= 0 {
+		return
+	}
+
+	if err := c.Costs.Report(cost); err != nil && c.Logger != nil {
+		c.Logger.Warnf("error reporting cost: %v", err)
+	}
+}
+
+/*
+ * IdempotencyKey returns a key that is stable for the execution,
+ * so components can pass it to external APIs (request IDs, client tokens)
+ * and retried executions do not create the same resources twice.
+ * The key is the execution ID, a UUID, which most APIs accept as an idempotency token.
+ */
+func (c ExecutionContext) IdempotencyKey() string {
+	return c.ID.String()
 }
 
 /*
@@ -181,6 +368,24 @@ type SetupContext struct {
 	Auth          AuthContext
 	Integration   IntegrationContext
 	Webhook       NodeWebhookContext
+	WebhookSecret NodeWebhookSecretContext
+}
+
+/*
+ * DynamicSchemaProvider is implemented by components with
+ * fields using configuration.DynamicSchemaTypeOptions.
+ * DynamicSchema() returns the schema for the value of the field,
+ * based on the rest of the node configuration.
+ */
+type DynamicSchemaProvider interface {
+	DynamicSchema(field string, ctx DynamicSchemaContext) ([]configuration.Field, error)
+}
+
+type DynamicSchemaContext struct {
+	Logger        *log.Entry
+	Configuration any
+	HTTP          HTTPContext
+	Integration   IntegrationContext
 }
 
 /*
@@ -198,6 +403,49 @@ type CanvasMemoryContext interface {
 	FindFirst(namespace string, matches map[string]any) (any, error)
 }
 
+/*
+ * LogsContext streams the output of an execution,
+ * e.g. the output of a command running on a remote machine.
+ * Lines are stored as they are written, so they can be followed
+ * while the execution is running, separate from the payloads it emits.
+ *
+ * Partial lines are buffered until a newline is written,
+ * so Write() can be used with io.Copy() on any stream.
+ */
+type LogsContext interface {
+	io.Writer
+	Printf(format string, args ...any)
+}
+
+/*
+ * Units for cost hints. Costs with different units are
+ * aggregated separately, since they cannot be summed.
+ */
+const (
+	CostUnitUSD         = "USD"
+	CostUnitUSDPerMonth = "USD/month"
+	CostUnitGBSeconds   = "GB-seconds"
+)
+
+/*
+ * Cost is a hint of what an execution costs, reported by components
+ * that already know it, e.g. the monthly estimate of a VM they create,
+ * or the GB-seconds billed for a function they invoke.
+ */
+type Cost struct {
+	Amount      float64
+	Unit        string
+	Description string
+}
+
+/*
+ * CostContext records the cost hints of an execution,
+ * which are aggregated per execution and canvas for chargeback.
+ */
+type CostContext interface {
+	Report(cost Cost) error
+}
+
 /*
  * ExecutionStateContext allows components to control execution lifecycle.
  */
@@ -210,6 +458,11 @@ type ExecutionStateContext interface {
 	 */
 	Emit(channel, payloadType string, payloads []any) error
 
+	/*
+	 * Pass the execution, emitting payloads to multiple channels at once.
+	 */
+	EmitOutputs(outputs []ChannelOutput) error
+
 	/*
 	 * Pass the execution, without emitting any payloads from it.
 	 */
@@ -261,6 +514,22 @@ type ActionContext struct {
 	Integration    IntegrationContext
 	Notifications  NotificationContext
 	Secrets        SecretsContext
+	CanvasRuns     CanvasRunsContext
+	Logs           LogsContext
+
+	//
+	// Carries the deadline of the execution, if the node has an execution timeout.
+	// Use GoContext(), since it is not set everywhere.
+	//
+	Context context.Context
+}
+
+func (c ActionContext) GoContext() context.Context {
+	if c.Context == nil {
+		return context.Background()
+	}
+
+	return c.Context
 }
 
 /*
@@ -334,6 +603,26 @@ type SecretsContext interface {
 	GetKey(secretName, keyName string) ([]byte, error)
 }
 
+/*
+ * FilesContext gives access to the content of file fields.
+ * The value passed to Open() is the value of the field
+ * in the configuration received by the component.
+ */
+type FilesContext interface {
+	Open(value any) (*File, error)
+}
+
+/*
+ * File is the content of a file field.
+ * The caller must close the reader.
+ */
+type File struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Reader      io.ReadCloser
+}
+
 type User struct {
 	ID    string `mapstructure:"id" json:"id"`
 	Name  string `mapstructure:"name" json:"name"`
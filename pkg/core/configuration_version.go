@@ -0,0 +1,75 @@
+package core
+
+import "fmt"
+
+/*
+ * Configurations without a version were created before
+ * the component declared one, so they are on the first version.
+ */
+const InitialConfigurationVersion = 1
+
+/*
+ * VersionedConfiguration is implemented by components
+ * whose configuration fields were reorganized.
+ *
+ * ConfigurationVersion() is the version of the current fields,
+ * starting at InitialConfigurationVersion, and increased every time
+ * fields are renamed, moved or change meaning.
+ *
+ * UpgradeConfiguration() receives a configuration on the given version,
+ * and returns it on the next one. Configurations stored on older versions
+ * are upgraded one version at a time, so each upgrade only needs to
+ * know about the version right before it.
+ *
+ * Nodes created outside of the API are not stamped with a version,
+ * so an upgrade can receive a configuration already on the next version,
+ * and must leave it as it is.
+ */
+type VersionedConfiguration interface {
+	ConfigurationVersion() int
+	UpgradeConfiguration(version int, configuration map[string]any) (map[string]any, error)
+}
+
+/*
+ * ConfigurationVersion returns the version of the current configuration fields of a component.
+ */
+func ConfigurationVersion(component Component) int {
+	versioned, ok := component.(VersionedConfiguration)
+	if !ok || versioned.ConfigurationVersion() < InitialConfigurationVersion {
+		return InitialConfigurationVersion
+	}
+
+	return versioned.ConfigurationVersion()
+}
+
+/*
+ * UpgradeConfiguration upgrades a configuration from its version to the current one.
+ * A version of 0 means the configuration was never versioned.
+ * Returns the configuration and the version it is on.
+ */
+func UpgradeConfiguration(component Component, version int, configuration map[string]any) (map[string]any, int, error) {
+	if version < InitialConfigurationVersion {
+		version = InitialConfigurationVersion
+	}
+
+	current := ConfigurationVersion(component)
+	if version >= current {
+		return configuration, version, nil
+	}
+
+	versioned := component.(VersionedConfiguration)
+	for ; version < current; version++ {
+		if configuration == nil {
+			configuration = map[string]any{}
+		}
+
+		upgraded, err := versioned.UpgradeConfiguration(version, configuration)
+		if err != nil {
+			return nil, version, fmt.Errorf("error upgrading %s configuration from version %d: %w", component.Name(), version, err)
+		}
+
+		configuration = upgraded
+	}
+
+	return configuration, current, nil
+}
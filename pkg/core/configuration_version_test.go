@@ -0,0 +1,88 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type versionedComponent struct {
+	core.Component
+	version  int
+	upgrades []int
+}
+
+func (c *versionedComponent) Name() string {
+	return "versioned"
+}
+
+func (c *versionedComponent) ConfigurationVersion() int {
+	return c.version
+}
+
+func (c *versionedComponent) UpgradeConfiguration(version int, config map[string]any) (map[string]any, error) {
+	c.upgrades = append(c.upgrades, version)
+	switch version {
+	case 1:
+		return map[string]any{"name": config["title"]}, nil
+	case 2:
+		return map[string]any{"name": config["name"], "enabled": true}, nil
+	default:
+		return nil, fmt.Errorf("unknown version %d", version)
+	}
+}
+
+func TestConfigurationVersion(t *testing.T) {
+	t.Run("unversioned component is on the initial version", func(t *testing.T) {
+		assert.Equal(t, core.InitialConfigurationVersion, core.ConfigurationVersion(&exampleComponent{}))
+	})
+
+	t.Run("versioned component", func(t *testing.T) {
+		assert.Equal(t, 3, core.ConfigurationVersion(&versionedComponent{version: 3}))
+	})
+}
+
+func TestUpgradeConfiguration(t *testing.T) {
+	t.Run("upgrades one version at a time", func(t *testing.T) {
+		component := &versionedComponent{version: 3}
+		config, version, err := core.UpgradeConfiguration(component, 1, map[string]any{"title": "hello"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, version)
+		assert.Equal(t, []int{1, 2}, component.upgrades)
+		assert.Equal(t, map[string]any{"name": "hello", "enabled": true}, config)
+	})
+
+	t.Run("unversioned configuration is on the initial version", func(t *testing.T) {
+		component := &versionedComponent{version: 2}
+		config, version, err := core.UpgradeConfiguration(component, 0, map[string]any{"title": "hello"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+		assert.Equal(t, []int{1}, component.upgrades)
+		assert.Equal(t, map[string]any{"name": "hello"}, config)
+	})
+
+	t.Run("current configuration is not upgraded", func(t *testing.T) {
+		component := &versionedComponent{version: 2}
+		config, version, err := core.UpgradeConfiguration(component, 2, map[string]any{"name": "hello"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+		assert.Empty(t, component.upgrades)
+		assert.Equal(t, map[string]any{"name": "hello"}, config)
+	})
+
+	t.Run("unversioned component is not upgraded", func(t *testing.T) {
+		config, version, err := core.UpgradeConfiguration(&exampleComponent{}, 0, map[string]any{"a": "b"})
+		require.NoError(t, err)
+		assert.Equal(t, core.InitialConfigurationVersion, version)
+		assert.Equal(t, map[string]any{"a": "b"}, config)
+	})
+
+	t.Run("upgrade error", func(t *testing.T) {
+		component := &versionedComponent{version: 5}
+		_, _, err := core.UpgradeConfiguration(component, 3, map[string]any{})
+		require.ErrorContains(t, err, "error upgrading versioned configuration from version 3")
+	})
+}
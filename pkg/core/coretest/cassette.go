@@ -0,0 +1,319 @@
+package coretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * RecordCassettesEnv is the environment variable used to send
+ * requests to the real APIs and record them into cassettes, e.g.:
+ *
+ *   RECORD_CASSETTES=yes go test ./pkg/integrations/gcp/compute/...
+ *
+ * Without it, cassettes are replayed, and requests not in them fail the test.
+ */
+const RecordCassettesEnv = "RECORD_CASSETTES"
+
+/*
+ * Response headers never written to cassettes.
+ */
+var redactedHeaders = []string{"Set-Cookie", "Authorization", "X-Amz-Security-Token"}
+
+/*
+ * Interaction is a request and the response received for it.
+ * Request headers are not recorded, since they carry credentials.
+ */
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body"`
+}
+
+/*
+ * Cassette implements core.HTTPContext, replaying the requests
+ * recorded in testdata/cassettes/<test name>.json.
+ * In record mode, requests are sent with the real HTTP context,
+ * and the cassette is written when the test finishes.
+ */
+type Cassette struct {
+	t         testing.TB
+	path      string
+	recording bool
+	real      core.HTTPContext
+	matchBody bool
+	redact    []func(*Interaction)
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+type CassetteOption func(*Cassette)
+
+/*
+ * WithRealHTTP sets the HTTP context used in record mode.
+ * By default, http.DefaultClient is used.
+ */
+func WithRealHTTP(real core.HTTPContext) CassetteOption {
+	return func(c *Cassette) {
+		c.real = real
+	}
+}
+
+/*
+ * MatchBody also compares request bodies when replaying.
+ * By default, only the method and URL are compared,
+ * since bodies often include timestamps or signatures.
+ */
+func MatchBody() CassetteOption {
+	return func(c *Cassette) {
+		c.matchBody = true
+	}
+}
+
+/*
+ * WithRedaction runs redact on every interaction before it is written,
+ * e.g. to remove account IDs or tokens from response bodies.
+ */
+func WithRedaction(redact func(*Interaction)) CassetteOption {
+	return func(c *Cassette) {
+		c.redact = append(c.redact, redact)
+	}
+}
+
+/*
+ * WithCassetteName sets the name of the cassette file,
+ * e.g. to share a cassette between tests.
+ */
+func WithCassetteName(name string) CassetteOption {
+	return func(c *Cassette) {
+		c.path = filepath.Join("testdata", "cassettes", cassetteName(name)+".json")
+	}
+}
+
+func NewCassette(t testing.TB, options ...CassetteOption) *Cassette {
+	t.Helper()
+
+	c := &Cassette{
+		t:         t,
+		path:      filepath.Join("testdata", "cassettes", cassetteName(t.Name())+".json"),
+		recording: os.Getenv(RecordCassettesEnv) != "",
+		real:      defaultHTTP{},
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.recording {
+		t.Cleanup(c.save)
+		return c
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		t.Fatalf("error reading cassette %s, run the test with %s=yes to record it: %v", c.path, RecordCassettesEnv, err)
+	}
+
+	if err := json.Unmarshal(data, &c.interactions); err != nil {
+		t.Fatalf("error decoding cassette %s: %v", c.path, err)
+	}
+
+	c.used = make([]bool, len(c.interactions))
+	return c
+}
+
+func (c *Cassette) Do(request *http.Request) (*http.Response, error) {
+	body, err := readBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.recording {
+		return c.record(request, body)
+	}
+
+	return c.replay(request, body)
+}
+
+func (c *Cassette) record(request *http.Request, body []byte) (*http.Response, error) {
+	response, err := c.real.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+
+	interaction := Interaction{
+		Request: CassetteRequest{
+			Method: request.Method,
+			URL:    request.URL.String(),
+			Body:   string(body),
+		},
+		Response: CassetteResponse{
+			Status: response.StatusCode,
+			Header: recordedHeaders(response.Header),
+			Body:   string(responseBody),
+		},
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	return response, nil
+}
+
+/*
+ * Requests are matched with the first unused interaction
+ * with the same method and URL, so concurrent requests
+ * do not need to be sent in the order they were recorded.
+ */
+func (c *Cassette) replay(request *http.Request, body []byte) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	URL := request.URL.String()
+	for i, interaction := range c.interactions {
+		if c.used[i] || interaction.Request.Method != request.Method || interaction.Request.URL != URL {
+			continue
+		}
+
+		if c.matchBody && interaction.Request.Body != string(body) {
+			continue
+		}
+
+		c.used[i] = true
+		header := http.Header{}
+		for name, value := range interaction.Response.Header {
+			header.Set(name, value)
+		}
+
+		return &http.Response{
+			StatusCode: interaction.Response.Status,
+			Status:     fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			Request:    request,
+		}, nil
+	}
+
+	c.t.Errorf("request %s %s not found in cassette %s", request.Method, URL, c.path)
+	return nil, fmt.Errorf("request %s %s not found in cassette %s", request.Method, URL, c.path)
+}
+
+func (c *Cassette) save() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.interactions {
+		for _, redact := range c.redact {
+			redact(&c.interactions[i])
+		}
+	}
+
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		c.t.Errorf("error encoding cassette %s: %v", c.path, err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		c.t.Errorf("error creating cassette directory: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(c.path, append(data, '\n'), 0644); err != nil {
+		c.t.Errorf("error writing cassette %s: %v", c.path, err)
+	}
+}
+
+/*
+ * Subtests are written to a directory named after the parent test,
+ * e.g. TestCreateVM/creates_instance -> TestCreateVM/creates_instance.json.
+ */
+func cassetteName(testName string) string {
+	parts := strings.Split(testName, "/")
+	for i, part := range parts {
+		parts[i] = strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+				return r
+			default:
+				return '_'
+			}
+		}, part)
+	}
+
+	return filepath.Join(parts...)
+}
+
+func recordedHeaders(header http.Header) map[string]string {
+	recorded := map[string]string{}
+	for name := range header {
+		if isRedactedHeader(name) {
+			continue
+		}
+
+		recorded[name] = header.Get(name)
+	}
+
+	return recorded
+}
+
+func isRedactedHeader(name string) bool {
+	for _, redacted := range redactedHeaders {
+		if strings.EqualFold(name, redacted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %v", err)
+	}
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+type defaultHTTP struct{}
+
+func (defaultHTTP) Do(request *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(request)
+}
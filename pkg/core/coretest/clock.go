@@ -0,0 +1,111 @@
+package coretest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+ * DefaultTime is the time used by clocks created without an explicit time,
+ * so timestamps in emitted payloads are the same on every run.
+ */
+var DefaultTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+/*
+ * Clock is a fake clock, only moved forward with Advance().
+ */
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+/*
+ * ScheduledCall is an action call scheduled
+ * through core.RequestContext.ScheduleActionCall().
+ */
+type ScheduledCall struct {
+	Action     string
+	Parameters map[string]any
+	Interval   time.Duration
+	At         time.Time
+}
+
+/*
+ * Requests implements core.RequestContext.
+ * Scheduled calls are due once the clock is advanced past their interval.
+ */
+type Requests struct {
+	clock   *Clock
+	Calls   []ScheduledCall
+	pending []ScheduledCall
+}
+
+func NewRequests(clock *Clock) *Requests {
+	return &Requests{clock: clock}
+}
+
+func (r *Requests) ScheduleActionCall(actionName string, parameters map[string]any, interval time.Duration) error {
+	call := ScheduledCall{
+		Action:     actionName,
+		Parameters: parameters,
+		Interval:   interval,
+		At:         r.clock.Now().Add(interval),
+	}
+
+	r.Calls = append(r.Calls, call)
+	r.pending = append(r.pending, call)
+	return nil
+}
+
+/*
+ * Last returns the last scheduled call, or nil if no calls were scheduled.
+ */
+func (r *Requests) Last() *ScheduledCall {
+	if len(r.Calls) == 0 {
+		return nil
+	}
+
+	return &r.Calls[len(r.Calls)-1]
+}
+
+/*
+ * Due returns the calls that are due at the current time of the clock,
+ * in the order they are due. Returned calls are not returned again.
+ */
+func (r *Requests) Due() []ScheduledCall {
+	now := r.clock.Now()
+	due := []ScheduledCall{}
+	pending := []ScheduledCall{}
+	for _, call := range r.pending {
+		if call.At.After(now) {
+			pending = append(pending, call)
+			continue
+		}
+
+		due = append(due, call)
+	}
+
+	r.pending = pending
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].At.Before(due[j].At)
+	})
+
+	return due
+}
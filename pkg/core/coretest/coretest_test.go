@@ -0,0 +1,236 @@
+package coretest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type recordingT struct {
+	testing.TB
+	name     string
+	errors   []string
+	cleanups []func()
+}
+
+func (t *recordingT) Name() string {
+	return t.name
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *recordingT) finish() {
+	for _, f := range t.cleanups {
+		f()
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	t.Run("scripted requests return their responses", func(t *testing.T) {
+		h := NewHTTP(t)
+		h.Expect(http.MethodPost, "https://api.example.com/items?b=2&a=1").
+			WithHeader("Authorization", "Bearer token").
+			WithJSONBody(map[string]any{"name": "item"}).
+			RespondJSON(http.StatusCreated, map[string]any{"id": "item-1"})
+
+		request, err := http.NewRequest(http.MethodPost, "https://api.example.com/items?a=1&b=2", bytes.NewBufferString(`{"name": "item"}`))
+		require.NoError(t, err)
+		request.Header.Set("Authorization", "Bearer token")
+
+		response, err := h.Do(request)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "item-1"}`, string(body))
+		require.Len(t, h.Requests, 1)
+		assert.Equal(t, `{"name": "item"}`, string(h.Requests[0].Body))
+	})
+
+	t.Run("request not matching the script -> test error", func(t *testing.T) {
+		rt := &recordingT{}
+		h := NewHTTP(rt)
+		h.Expect(http.MethodGet, "https://api.example.com/items").WithHeader("Accept", "application/json")
+
+		request, err := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+		require.NoError(t, err)
+
+		_, err = h.Do(request)
+		require.ErrorContains(t, err, `expected header Accept to be "application/json"`)
+		assert.Len(t, rt.errors, 1)
+	})
+
+	t.Run("unexpected and missing requests -> test error", func(t *testing.T) {
+		rt := &recordingT{}
+		h := NewHTTP(rt)
+		h.Expect(http.MethodGet, "https://api.example.com/items")
+		h.Expect(http.MethodDelete, "https://api.example.com/items/1")
+
+		request, err := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+		require.NoError(t, err)
+		_, err = h.Do(request)
+		require.NoError(t, err)
+
+		rt.finish()
+		assert.Equal(t, []string{"expected request DELETE https://api.example.com/items/1 was not received"}, rt.errors)
+	})
+}
+
+func TestRequests(t *testing.T) {
+	clock := NewClock(DefaultTime)
+	requests := NewRequests(clock)
+
+	require.NoError(t, requests.ScheduleActionCall("poll", map[string]any{"attempt": 2}, 2*time.Minute))
+	require.NoError(t, requests.ScheduleActionCall("poll", map[string]any{"attempt": 1}, time.Minute))
+	assert.Equal(t, map[string]any{"attempt": 1}, requests.Last().Parameters)
+	assert.Empty(t, requests.Due())
+
+	clock.Advance(time.Minute)
+	due := requests.Due()
+	require.Len(t, due, 1)
+	assert.Equal(t, map[string]any{"attempt": 1}, due[0].Parameters)
+	assert.Empty(t, requests.Due())
+
+	clock.Advance(time.Hour)
+	due = requests.Due()
+	require.Len(t, due, 1)
+	assert.Equal(t, DefaultTime.Add(2*time.Minute), due[0].At)
+}
+
+func TestExecutionBuilder(t *testing.T) {
+	execution := NewExecution(t).
+		WithConfiguration(map[string]any{"url": "https://api.example.com"}).
+		WithMetadata(map[string]any{"status": "pending"}).
+		Build()
+
+	ctx := execution.Context
+	assert.Equal(t, map[string]any{"status": "pending"}, ctx.Metadata.Get())
+	require.NoError(t, ctx.Requests.ScheduleActionCall("poll", nil, time.Minute))
+
+	execution.Clock.Advance(time.Minute)
+	due := execution.Requests.Due()
+	require.Len(t, due, 1)
+
+	action := execution.Action(due[0].Action, due[0].Parameters)
+	require.NoError(t, action.ExecutionState.Emit(core.DefaultOutputChannel.Name, "example.done", []any{
+		map[string]any{"id": "item-1"},
+	}))
+
+	assert.True(t, execution.ExecutionState.Passed)
+	assert.Equal(t, []any{map[string]any{"id": "item-1"}}, execution.ExecutionState.Payloads(core.DefaultOutputChannel.Name))
+	AssertGoldenOutputs(t, "emitted", execution.ExecutionState)
+}
+
+func TestLogs(t *testing.T) {
+	execution := NewExecution(t).Build()
+
+	ctx := execution.Context
+	ctx.Logs.Printf("starting %s", "build")
+	_, err := io.Copy(ctx.Logs, bytes.NewBufferString("step 1\nstep 2\nstep"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"starting build", "step 1", "step 2"}, execution.Logs.Lines)
+
+	_, err = ctx.Logs.Write([]byte(" 3\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "step 3", execution.Logs.Lines[3])
+}
+
+func TestCosts(t *testing.T) {
+	execution := NewExecution(t).Build()
+
+	execution.Context.ReportCost(core.Cost{Amount: 12.5, Unit: core.CostUnitUSDPerMonth})
+	execution.Context.ReportCost(core.Cost{Amount: 0, Unit: core.CostUnitGBSeconds})
+
+	assert.Equal(t, []core.Cost{{Amount: 12.5, Unit: core.CostUnitUSDPerMonth}}, execution.Costs.Reported, "empty costs are not reported")
+
+	ctx := execution.Context
+	ctx.Costs = nil
+	ctx.ReportCost(core.Cost{Amount: 1, Unit: core.CostUnitUSD})
+}
+
+func TestCheckpoints(t *testing.T) {
+	execution := NewExecution(t).Build()
+	assert.Nil(t, execution.Checkpoints.Last())
+
+	execution.Context.SaveCheckpoint(core.Checkpoint{Step: "insert"})
+	execution.Context.SaveCheckpoint(core.Checkpoint{Step: "waitForInsert", Operation: "operation-1"})
+
+	require.Len(t, execution.Checkpoints.Saved, 2)
+	assert.Equal(t, "waitForInsert", execution.Checkpoints.Last().Step)
+
+	ctx := execution.Context
+	ctx.Checkpoints = nil
+	ctx.SaveCheckpoint(core.Checkpoint{Step: "ignored"})
+}
+
+func TestCassette(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		fmt.Fprintf(w, `{"path": %q}`, r.URL.Path)
+	}))
+
+	get := func(h core.HTTPContext, path string) (*http.Response, error) {
+		request, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		return h.Do(request)
+	}
+
+	t.Run("requests are recorded", func(t *testing.T) {
+		t.Setenv(RecordCassettesEnv, "yes")
+		cassette := NewCassette(t, WithCassetteName("TestCassette/shared"))
+
+		response, err := get(cassette, "/items/1")
+		require.NoError(t, err)
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"path": "/items/1"}`, string(body))
+	})
+
+	server.Close()
+
+	t.Run("recorded requests are replayed", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join("testdata", "cassettes", "TestCassette", "shared.json"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "session=secret")
+
+		cassette := NewCassette(t, WithCassetteName("TestCassette/shared"))
+		response, err := get(cassette, "/items/1")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"path": "/items/1"}`, string(body))
+	})
+
+	t.Run("requests not in the cassette -> test error", func(t *testing.T) {
+		rt := &recordingT{name: "TestCassette/shared"}
+		cassette := NewCassette(rt)
+
+		_, err := get(cassette, "/items/2")
+		require.ErrorContains(t, err, "not found in cassette")
+		assert.Len(t, rt.errors, 1)
+	})
+}
@@ -0,0 +1,337 @@
+package coretest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * Metadata implements core.MetadataContext.
+ */
+type Metadata struct {
+	Metadata any
+}
+
+func (m *Metadata) Get() any {
+	return m.Metadata
+}
+
+func (m *Metadata) Set(metadata any) error {
+	m.Metadata = metadata
+	return nil
+}
+
+/*
+ * Logs implements core.LogsContext, keeping the complete lines written.
+ */
+type Logs struct {
+	partial string
+	Lines   []string
+}
+
+func (l *Logs) Write(p []byte) (int, error) {
+	lines := strings.Split(l.partial+string(p), "\n")
+	l.partial = lines[len(lines)-1]
+	l.Lines = append(l.Lines, lines[:len(lines)-1]...)
+	return len(p), nil
+}
+
+func (l *Logs) Printf(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+
+	_, _ = l.Write([]byte(line))
+}
+
+/*
+ * Costs implements core.CostContext, keeping the costs reported.
+ */
+type Costs struct {
+	Reported []core.Cost
+}
+
+func (c *Costs) Report(cost core.Cost) error {
+	c.Reported = append(c.Reported, cost)
+	return nil
+}
+
+/*
+ * Checkpoints implements core.CheckpointContext, keeping the checkpoints saved.
+ */
+type Checkpoints struct {
+	Saved []core.Checkpoint
+}
+
+func (c *Checkpoints) Save(checkpoint core.Checkpoint) error {
+	c.Saved = append(c.Saved, checkpoint)
+	return nil
+}
+
+/*
+ * Last returns the last checkpoint saved, if any.
+ */
+func (c *Checkpoints) Last() *core.Checkpoint {
+	if len(c.Saved) == 0 {
+		return nil
+	}
+
+	return &c.Saved[len(c.Saved)-1]
+}
+
+/*
+ * ExecutionState implements core.ExecutionStateContext.
+ * Emitted payloads are wrapped like the real execution state does,
+ * using the time of the clock, so they can be compared with golden files.
+ */
+type ExecutionState struct {
+	clock *Clock
+
+	Finished       bool
+	Passed         bool
+	FailureReason  string
+	FailureMessage string
+	Outputs        map[string][]any
+	KVs            map[string]string
+}
+
+func NewExecutionState(clock *Clock) *ExecutionState {
+	return &ExecutionState{
+		clock:   clock,
+		Outputs: map[string][]any{},
+		KVs:     map[string]string{},
+	}
+}
+
+func (s *ExecutionState) IsFinished() bool {
+	return s.Finished
+}
+
+func (s *ExecutionState) SetKV(key, value string) error {
+	s.KVs[key] = value
+	return nil
+}
+
+func (s *ExecutionState) Pass() error {
+	s.Finished = true
+	s.Passed = true
+	return nil
+}
+
+func (s *ExecutionState) Emit(channel, payloadType string, payloads []any) error {
+	return s.EmitOutputs([]core.ChannelOutput{
+		{Channel: channel, PayloadType: payloadType, Payloads: payloads},
+	})
+}
+
+func (s *ExecutionState) EmitOutputs(outputs []core.ChannelOutput) error {
+	for _, output := range outputs {
+		for _, payload := range output.Payloads {
+			s.Outputs[output.Channel] = append(s.Outputs[output.Channel], map[string]any{
+				"type":      output.PayloadType,
+				"timestamp": s.clock.Now(),
+				"data":      payload,
+			})
+		}
+	}
+
+	s.Finished = true
+	s.Passed = true
+	return nil
+}
+
+func (s *ExecutionState) Fail(reason, message string) error {
+	s.Finished = true
+	s.Passed = false
+	s.FailureReason = reason
+	s.FailureMessage = message
+	return nil
+}
+
+/*
+ * Payloads returns the data of the payloads emitted on a channel,
+ * without the type and timestamp wrapping.
+ */
+func (s *ExecutionState) Payloads(channel string) []any {
+	data := []any{}
+	for _, payload := range s.Outputs[channel] {
+		data = append(data, payload.(map[string]any)["data"])
+	}
+
+	return data
+}
+
+/*
+ * Execution holds a core.ExecutionContext built by ExecutionBuilder,
+ * and the fakes used by it, so tests can inspect what the component did.
+ */
+type Execution struct {
+	Context        core.ExecutionContext
+	Clock          *Clock
+	HTTP           *HTTP
+	Metadata       *Metadata
+	NodeMetadata   *Metadata
+	ExecutionState *ExecutionState
+	Requests       *Requests
+	Logs           *Logs
+	Costs          *Costs
+	Checkpoints    *Checkpoints
+}
+
+/*
+ * Action returns a core.ActionContext for the same execution,
+ * e.g. to run the calls returned by Requests.Due().
+ */
+func (e *Execution) Action(name string, parameters map[string]any) core.ActionContext {
+	return core.ActionContext{
+		Name:           name,
+		Configuration:  e.Context.Configuration,
+		Parameters:     parameters,
+		Logger:         e.Context.Logger,
+		HTTP:           e.Context.HTTP,
+		Metadata:       e.Context.Metadata,
+		ExecutionState: e.Context.ExecutionState,
+		Auth:           e.Context.Auth,
+		Requests:       e.Context.Requests,
+		Integration:    e.Context.Integration,
+		Notifications:  e.Context.Notifications,
+		Secrets:        e.Context.Secrets,
+		CanvasRuns:     e.Context.CanvasRuns,
+		Logs:           e.Context.Logs,
+		Context:        e.Context.Context,
+	}
+}
+
+/*
+ * ExecutionBuilder builds a core.ExecutionContext for tests.
+ * Contexts not set explicitly are replaced by the fakes in this package.
+ */
+type ExecutionBuilder struct {
+	t            testing.TB
+	ctx          core.ExecutionContext
+	clock        *Clock
+	http         *HTTP
+	metadata     *Metadata
+	nodeMetadata *Metadata
+}
+
+func NewExecution(t testing.TB) *ExecutionBuilder {
+	return &ExecutionBuilder{
+		t: t,
+		ctx: core.ExecutionContext{
+			ID:             uuid.New(),
+			WorkflowID:     uuid.NewString(),
+			OrganizationID: uuid.NewString(),
+			NodeID:         "node-1",
+			BaseURL:        "http://localhost:8000",
+			Context:        context.Background(),
+		},
+		metadata:     &Metadata{},
+		nodeMetadata: &Metadata{},
+	}
+}
+
+func (b *ExecutionBuilder) WithConfiguration(configuration any) *ExecutionBuilder {
+	b.ctx.Configuration = configuration
+	return b
+}
+
+func (b *ExecutionBuilder) WithData(data any) *ExecutionBuilder {
+	b.ctx.Data = data
+	return b
+}
+
+func (b *ExecutionBuilder) WithMetadata(metadata any) *ExecutionBuilder {
+	b.metadata.Metadata = metadata
+	return b
+}
+
+func (b *ExecutionBuilder) WithNodeMetadata(metadata any) *ExecutionBuilder {
+	b.nodeMetadata.Metadata = metadata
+	return b
+}
+
+func (b *ExecutionBuilder) WithHTTP(http *HTTP) *ExecutionBuilder {
+	b.http = http
+	return b
+}
+
+func (b *ExecutionBuilder) WithClock(clock *Clock) *ExecutionBuilder {
+	b.clock = clock
+	return b
+}
+
+func (b *ExecutionBuilder) WithIntegration(integration core.IntegrationContext) *ExecutionBuilder {
+	b.ctx.Integration = integration
+	return b
+}
+
+func (b *ExecutionBuilder) WithSecrets(secrets core.SecretsContext) *ExecutionBuilder {
+	b.ctx.Secrets = secrets
+	return b
+}
+
+func (b *ExecutionBuilder) WithAuth(auth core.AuthContext) *ExecutionBuilder {
+	b.ctx.Auth = auth
+	return b
+}
+
+func (b *ExecutionBuilder) WithFiles(files core.FilesContext) *ExecutionBuilder {
+	b.ctx.Files = files
+	return b
+}
+
+func (b *ExecutionBuilder) WithCanvasRuns(canvasRuns core.CanvasRunsContext) *ExecutionBuilder {
+	b.ctx.CanvasRuns = canvasRuns
+	return b
+}
+
+func (b *ExecutionBuilder) WithContext(ctx context.Context) *ExecutionBuilder {
+	b.ctx.Context = ctx
+	return b
+}
+
+func (b *ExecutionBuilder) Build() *Execution {
+	clock := b.clock
+	if clock == nil {
+		clock = NewClock(DefaultTime)
+	}
+
+	http := b.http
+	if http == nil {
+		http = NewHTTP(b.t)
+	}
+
+	execution := &Execution{
+		Clock:          clock,
+		HTTP:           http,
+		Metadata:       b.metadata,
+		NodeMetadata:   b.nodeMetadata,
+		ExecutionState: NewExecutionState(clock),
+		Requests:       NewRequests(clock),
+		Logs:           &Logs{},
+		Costs:          &Costs{},
+		Checkpoints:    &Checkpoints{},
+	}
+
+	ctx := b.ctx
+	ctx.Logger = log.NewEntry(log.New())
+	ctx.HTTP = http
+	ctx.Metadata = execution.Metadata
+	ctx.NodeMetadata = execution.NodeMetadata
+	ctx.ExecutionState = execution.ExecutionState
+	ctx.Requests = execution.Requests
+	ctx.Logs = execution.Logs
+	ctx.Costs = execution.Costs
+	ctx.Checkpoints = execution.Checkpoints
+	execution.Context = ctx
+
+	return execution
+}
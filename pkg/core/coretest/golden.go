@@ -0,0 +1,55 @@
+package coretest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+ * UpdateGoldenEnv is the environment variable used
+ * to write golden files instead of comparing with them, e.g.:
+ *
+ *   UPDATE_GOLDEN=yes go test ./pkg/integrations/gcp/...
+ */
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+/*
+ * AssertGolden compares value, encoded as JSON,
+ * with the golden file testdata/<name>.golden.json.
+ */
+func AssertGolden(t testing.TB, name string, value any) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	require.NoError(t, err, "error encoding value for golden file %s", name)
+	data = append(data, '\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, data, 0644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %s not found, run the test with %s=yes to create it", path, UpdateGoldenEnv)
+	}
+
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(data), "value does not match golden file %s", path)
+}
+
+/*
+ * AssertGoldenOutputs compares the payloads emitted by an execution
+ * with the golden file testdata/<name>.golden.json.
+ */
+func AssertGoldenOutputs(t testing.TB, name string, state *ExecutionState) {
+	t.Helper()
+	AssertGolden(t, name, state.Outputs)
+}
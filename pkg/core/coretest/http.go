@@ -0,0 +1,271 @@
+package coretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/*
+ * HTTP implements core.HTTPContext with a script of expected requests.
+ * Requests must arrive in the order they were expected,
+ * and any expected request not received fails the test on cleanup.
+ */
+type HTTP struct {
+	t        testing.TB
+	mu       sync.Mutex
+	steps    []*Step
+	next     int
+	Requests []*RecordedRequest
+}
+
+/*
+ * RecordedRequest is a request received by HTTP,
+ * with its body already read.
+ */
+type RecordedRequest struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+/*
+ * Step is an expected request, and the response returned for it.
+ */
+type Step struct {
+	method   string
+	url      string
+	headers  map[string]string
+	jsonBody any
+	contains []string
+
+	status          int
+	body            []byte
+	responseHeaders http.Header
+	err             error
+}
+
+func NewHTTP(t testing.TB) *HTTP {
+	h := &HTTP{t: t}
+	t.Cleanup(h.AssertDone)
+	return h
+}
+
+/*
+ * Expect adds a request to the script.
+ * The query string is only compared if rawURL has one,
+ * and the order of its parameters does not matter.
+ */
+func (h *HTTP) Expect(method, rawURL string) *Step {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	step := &Step{
+		method:          method,
+		url:             rawURL,
+		headers:         map[string]string{},
+		status:          http.StatusOK,
+		responseHeaders: http.Header{},
+	}
+
+	h.steps = append(h.steps, step)
+	return step
+}
+
+func (s *Step) WithHeader(name, value string) *Step {
+	s.headers[name] = value
+	return s
+}
+
+/*
+ * WithJSONBody asserts the request body is equal to body, once both are encoded as JSON.
+ */
+func (s *Step) WithJSONBody(body any) *Step {
+	s.jsonBody = body
+	return s
+}
+
+func (s *Step) WithBodyContaining(text string) *Step {
+	s.contains = append(s.contains, text)
+	return s
+}
+
+func (s *Step) Respond(status int, body string) *Step {
+	s.status = status
+	s.body = []byte(body)
+	return s
+}
+
+func (s *Step) RespondJSON(status int, body any) *Step {
+	data, err := json.Marshal(body)
+	if err != nil {
+		panic(fmt.Sprintf("coretest: error encoding response body: %v", err))
+	}
+
+	s.status = status
+	s.body = data
+	s.responseHeaders.Set("Content-Type", "application/json")
+	return s
+}
+
+func (s *Step) ResponseHeader(name, value string) *Step {
+	s.responseHeaders.Add(name, value)
+	return s
+}
+
+/*
+ * Fail makes the request return err, as if the connection failed.
+ */
+func (s *Step) Fail(err error) *Step {
+	s.err = err
+	return s
+}
+
+func (h *HTTP) Do(request *http.Request) (*http.Response, error) {
+	h.t.Helper()
+
+	recorded, err := recordRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	h.Requests = append(h.Requests, recorded)
+	if h.next >= len(h.steps) {
+		h.mu.Unlock()
+		h.t.Errorf("unexpected request %s %s", recorded.Method, recorded.URL)
+		return nil, fmt.Errorf("unexpected request %s %s", recorded.Method, recorded.URL)
+	}
+
+	step := h.steps[h.next]
+	h.next++
+	h.mu.Unlock()
+
+	if err := step.match(recorded); err != nil {
+		h.t.Errorf("request %d: %v", len(h.Requests), err)
+		return nil, err
+	}
+
+	if step.err != nil {
+		return nil, step.err
+	}
+
+	return &http.Response{
+		StatusCode: step.status,
+		Status:     fmt.Sprintf("%d %s", step.status, http.StatusText(step.status)),
+		Header:     step.responseHeaders.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(step.body)),
+		Request:    request,
+	}, nil
+}
+
+/*
+ * AssertDone fails the test if an expected request was not received.
+ * It is called automatically when the test finishes.
+ */
+func (h *HTTP) AssertDone() {
+	h.t.Helper()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, step := range h.steps[h.next:] {
+		h.t.Errorf("expected request %s %s was not received", step.method, step.url)
+	}
+}
+
+func (s *Step) match(request *RecordedRequest) error {
+	if request.Method != s.method || !urlMatches(s.url, request.URL) {
+		return fmt.Errorf("expected %s %s, got %s %s", s.method, s.url, request.Method, request.URL)
+	}
+
+	for name, value := range s.headers {
+		if got := request.Header.Get(name); got != value {
+			return fmt.Errorf("%s %s: expected header %s to be %q, got %q", s.method, s.url, name, value, got)
+		}
+	}
+
+	for _, text := range s.contains {
+		if !strings.Contains(string(request.Body), text) {
+			return fmt.Errorf("%s %s: expected body to contain %q, got %s", s.method, s.url, text, request.Body)
+		}
+	}
+
+	if s.jsonBody == nil {
+		return nil
+	}
+
+	equal, err := jsonEqual(s.jsonBody, request.Body)
+	if err != nil {
+		return fmt.Errorf("%s %s: %v", s.method, s.url, err)
+	}
+
+	if !equal {
+		expected, _ := json.Marshal(s.jsonBody)
+		return fmt.Errorf("%s %s: expected body %s, got %s", s.method, s.url, expected, request.Body)
+	}
+
+	return nil
+}
+
+func recordRequest(request *http.Request) (*RecordedRequest, error) {
+	body, err := readBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordedRequest{
+		Method: request.Method,
+		URL:    request.URL.String(),
+		Header: request.Header.Clone(),
+		Body:   body,
+	}, nil
+}
+
+func urlMatches(expected, actual string) bool {
+	e, err := url.Parse(expected)
+	if err != nil {
+		return expected == actual
+	}
+
+	a, err := url.Parse(actual)
+	if err != nil {
+		return false
+	}
+
+	if e.Scheme != a.Scheme || e.Host != a.Host || e.Path != a.Path {
+		return false
+	}
+
+	if e.RawQuery == "" {
+		return true
+	}
+
+	return reflect.DeepEqual(e.Query(), a.Query())
+}
+
+func jsonEqual(expected any, actual []byte) (bool, error) {
+	data, err := json.Marshal(expected)
+	if err != nil {
+		return false, fmt.Errorf("error encoding expected body: %v", err)
+	}
+
+	var e, a any
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false, fmt.Errorf("error decoding expected body: %v", err)
+	}
+
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return false, fmt.Errorf("request body is not JSON: %s", actual)
+	}
+
+	return reflect.DeepEqual(e, a), nil
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	DeduplicationWindowField   = "deduplicationWindow"
+	DefaultDeduplicationWindow = time.Hour
+)
+
+/*
+ * IdentifiedEventEmitter is implemented by event contexts
+ * that can deduplicate events through an identity given by the source,
+ * e.g. the id of an EventBridge event, or the insertId of an audit log entry.
+ *
+ * Events with an identity already emitted by the same node
+ * within its deduplication window are discarded.
+ */
+type IdentifiedEventEmitter interface {
+	EmitIdentified(identity string, payloadType string, payload any) (bool, error)
+}
+
+/*
+ * EmitDeduplicated emits the event only if no other event with
+ * the same identity was emitted by the node within its deduplication window.
+ * Returns whether the event was emitted.
+ *
+ * Event contexts without deduplication support,
+ * and events without an identity, are always emitted.
+ */
+func EmitDeduplicated(events EventContext, identity string, payloadType string, payload any) (bool, error) {
+	emitter, ok := events.(IdentifiedEventEmitter)
+	if !ok || identity == "" {
+		if err := events.Emit(payloadType, payload); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return emitter.EmitIdentified(identity, payloadType, payload)
+}
+
+/*
+ * DeduplicationWindowConfigurationField is added to the configuration
+ * of triggers whose sources deliver events at least once.
+ */
+func DeduplicationWindowConfigurationField() configuration.Field {
+	return configuration.Field{
+		Name:        DeduplicationWindowField,
+		Label:       "Deduplication window",
+		Type:        configuration.FieldTypeDuration,
+		Description: "Events redelivered within this window are ignored",
+		Placeholder: "e.g. 1h",
+		Default:     "1h",
+		TypeOptions: &configuration.TypeOptions{
+			Duration: &configuration.DurationTypeOptions{
+				Min: func() *int { min := 1; return &min }(),
+			},
+		},
+	}
+}
+
+/*
+ * DeduplicationWindow returns the deduplication window of the node.
+ * Nodes without one, or with an invalid one, use DefaultDeduplicationWindow.
+ */
+func DeduplicationWindow(config any) time.Duration {
+	values, ok := config.(map[string]any)
+	if !ok {
+		return DefaultDeduplicationWindow
+	}
+
+	v, ok := values[DeduplicationWindowField]
+	if !ok || v == nil {
+		return DefaultDeduplicationWindow
+	}
+
+	window, err := configuration.ParseDuration(v)
+	if err != nil || window <= 0 {
+		return DefaultDeduplicationWindow
+	}
+
+	return window
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEventContext struct {
+	emitted []string
+}
+
+func (c *testEventContext) Emit(payloadType string, payload any) error {
+	c.emitted = append(c.emitted, payloadType)
+	return nil
+}
+
+type testIdentifiedEventContext struct {
+	testEventContext
+	identities map[string]bool
+}
+
+func (c *testIdentifiedEventContext) EmitIdentified(identity string, payloadType string, payload any) (bool, error) {
+	if c.identities[identity] {
+		return false, nil
+	}
+
+	c.identities[identity] = true
+	return true, c.Emit(payloadType, payload)
+}
+
+func Test__EmitDeduplicated(t *testing.T) {
+	t.Run("context without deduplication support -> always emitted", func(t *testing.T) {
+		events := &testEventContext{}
+
+		emitted, err := EmitDeduplicated(events, "event-1", "test", nil)
+		require.NoError(t, err)
+		assert.True(t, emitted)
+
+		emitted, err = EmitDeduplicated(events, "event-1", "test", nil)
+		require.NoError(t, err)
+		assert.True(t, emitted)
+		assert.Len(t, events.emitted, 2)
+	})
+
+	t.Run("same identity -> emitted once", func(t *testing.T) {
+		events := &testIdentifiedEventContext{identities: map[string]bool{}}
+
+		emitted, err := EmitDeduplicated(events, "event-1", "test", nil)
+		require.NoError(t, err)
+		assert.True(t, emitted)
+
+		emitted, err = EmitDeduplicated(events, "event-1", "test", nil)
+		require.NoError(t, err)
+		assert.False(t, emitted)
+
+		emitted, err = EmitDeduplicated(events, "event-2", "test", nil)
+		require.NoError(t, err)
+		assert.True(t, emitted)
+		assert.Len(t, events.emitted, 2)
+	})
+
+	t.Run("no identity -> always emitted", func(t *testing.T) {
+		events := &testIdentifiedEventContext{identities: map[string]bool{}}
+
+		for range 2 {
+			emitted, err := EmitDeduplicated(events, "", "test", nil)
+			require.NoError(t, err)
+			assert.True(t, emitted)
+		}
+
+		assert.Len(t, events.emitted, 2)
+	})
+}
+
+func Test__DeduplicationWindow(t *testing.T) {
+	assert.Equal(t, DefaultDeduplicationWindow, DeduplicationWindow(nil))
+	assert.Equal(t, DefaultDeduplicationWindow, DeduplicationWindow(map[string]any{}))
+	assert.Equal(t, DefaultDeduplicationWindow, DeduplicationWindow(map[string]any{DeduplicationWindowField: "nope"}))
+	assert.Equal(t, 10*time.Minute, DeduplicationWindow(map[string]any{DeduplicationWindowField: "10m"}))
+}
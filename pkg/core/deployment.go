@@ -0,0 +1,221 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	DeploymentStatusStarted    = "started"
+	DeploymentStatusSucceeded  = "succeeded"
+	DeploymentStatusFailed     = "failed"
+	DeploymentStatusRolledBack = "rolled_back"
+)
+
+var DeploymentStatuses = []string{
+	DeploymentStatusStarted,
+	DeploymentStatusSucceeded,
+	DeploymentStatusFailed,
+	DeploymentStatusRolledBack,
+}
+
+/*
+ * Deployment is the normalized deployment payload accepted by
+ * the components that annotate deployments in other tools,
+ * e.g. Datadog events, Grafana annotations and Slack messages.
+ *
+ * These components use the same configuration fields for it,
+ * so a canvas maps its deployment fields the same way for all of them.
+ */
+type Deployment struct {
+	Service     string `json:"service" mapstructure:"service"`
+	Version     string `json:"version" mapstructure:"version"`
+	Environment string `json:"environment" mapstructure:"environment"`
+	Status      string `json:"status" mapstructure:"status"`
+	URL         string `json:"url,omitempty" mapstructure:"url"`
+	Description string `json:"description,omitempty" mapstructure:"description"`
+}
+
+/*
+ * DeploymentConfigurationFields are added to the configuration
+ * of the components that annotate deployments.
+ */
+func DeploymentConfigurationFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "service",
+			Label:       "Service",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Service that was deployed",
+			Placeholder: "e.g. checkout",
+		},
+		{
+			Name:        "version",
+			Label:       "Version",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Version, tag or commit that was deployed",
+			Placeholder: "e.g. v1.4.2",
+		},
+		{
+			Name:        "environment",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Environment the service was deployed to",
+			Placeholder: "e.g. production",
+		},
+		{
+			Name:     "status",
+			Label:    "Status",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  DeploymentStatusSucceeded,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Started", Value: DeploymentStatusStarted},
+						{Label: "Succeeded", Value: DeploymentStatusSucceeded},
+						{Label: "Failed", Value: DeploymentStatusFailed},
+						{Label: "Rolled back", Value: DeploymentStatusRolledBack},
+					},
+				},
+			},
+		},
+		{
+			Name:        "url",
+			Label:       "URL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Description: "Link to the deployment, e.g. the pipeline or release page",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Togglable:   true,
+			Description: "Additional details about the deployment",
+		},
+	}
+}
+
+/*
+ * DecodeDeployment reads the deployment fields from the configuration of a node.
+ * The other fields of the configuration are ignored.
+ */
+func DecodeDeployment(config any) (Deployment, error) {
+	deployment := Deployment{}
+	if err := mapstructure.Decode(config, &deployment); err != nil {
+		return Deployment{}, fmt.Errorf("failed to decode deployment: %w", err)
+	}
+
+	deployment.Service = strings.TrimSpace(deployment.Service)
+	deployment.Version = strings.TrimSpace(deployment.Version)
+	deployment.Environment = strings.TrimSpace(deployment.Environment)
+	deployment.Status = strings.TrimSpace(deployment.Status)
+	deployment.URL = strings.TrimSpace(deployment.URL)
+	deployment.Description = strings.TrimSpace(deployment.Description)
+	if deployment.Status == "" {
+		deployment.Status = DeploymentStatusSucceeded
+	}
+
+	return deployment, deployment.Validate()
+}
+
+/*
+ * Validate checks the deployment fields.
+ * The status is only checked once it is not an expression anymore,
+ * since the fields are also validated when the node is set up.
+ */
+func (d Deployment) Validate() error {
+	if d.Service == "" {
+		return fmt.Errorf("service is required")
+	}
+
+	if d.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+
+	if d.Environment == "" {
+		return fmt.Errorf("environment is required")
+	}
+
+	if !slices.Contains(DeploymentStatuses, d.Status) && !strings.Contains(d.Status, "{{") {
+		return fmt.Errorf("invalid deployment status %q: must be one of %s", d.Status, strings.Join(DeploymentStatuses, ", "))
+	}
+
+	return nil
+}
+
+/*
+ * Title describes the deployment in one line,
+ * e.g. "Deployed checkout v1.4.2 to production".
+ */
+func (d Deployment) Title() string {
+	switch d.Status {
+	case DeploymentStatusStarted:
+		return fmt.Sprintf("Deploying %s %s to %s", d.Service, d.Version, d.Environment)
+	case DeploymentStatusFailed:
+		return fmt.Sprintf("Failed to deploy %s %s to %s", d.Service, d.Version, d.Environment)
+	case DeploymentStatusRolledBack:
+		return fmt.Sprintf("Rolled back %s %s in %s", d.Service, d.Version, d.Environment)
+	default:
+		return fmt.Sprintf("Deployed %s %s to %s", d.Service, d.Version, d.Environment)
+	}
+}
+
+/*
+ * Text is the title followed by the description and the URL of the deployment,
+ * separated by blank lines, for tools that show a body under the title.
+ */
+func (d Deployment) Text() string {
+	lines := []string{d.Title()}
+	if d.Description != "" {
+		lines = append(lines, d.Description)
+	}
+
+	if d.URL != "" {
+		lines = append(lines, d.URL)
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+/*
+ * Tags are key:value tags identifying the deployment,
+ * using the reserved tag names of Datadog for service, version and environment.
+ */
+func (d Deployment) Tags() []string {
+	return []string{
+		"service:" + d.Service,
+		"version:" + d.Version,
+		"env:" + d.Environment,
+		"deployment_status:" + d.Status,
+	}
+}
+
+func (d Deployment) ToMap() map[string]any {
+	data := map[string]any{
+		"service":     d.Service,
+		"version":     d.Version,
+		"environment": d.Environment,
+		"status":      d.Status,
+	}
+
+	if d.URL != "" {
+		data["url"] = d.URL
+	}
+
+	if d.Description != "" {
+		data["description"] = d.Description
+	}
+
+	return data
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__DecodeDeployment(t *testing.T) {
+	t.Run("fields are trimmed and status defaults to succeeded", func(t *testing.T) {
+		deployment, err := DecodeDeployment(map[string]any{
+			"service":     " checkout ",
+			"version":     "v1.4.2",
+			"environment": "production",
+			"channel":     "C123",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, Deployment{
+			Service:     "checkout",
+			Version:     "v1.4.2",
+			Environment: "production",
+			Status:      DeploymentStatusSucceeded,
+		}, deployment)
+	})
+
+	t.Run("missing fields -> error", func(t *testing.T) {
+		_, err := DecodeDeployment(map[string]any{"service": "checkout", "environment": "production"})
+		require.ErrorContains(t, err, "version is required")
+
+		_, err = DecodeDeployment(map[string]any{"service": "checkout", "version": "v1"})
+		require.ErrorContains(t, err, "environment is required")
+	})
+
+	t.Run("invalid status -> error", func(t *testing.T) {
+		_, err := DecodeDeployment(map[string]any{
+			"service":     "checkout",
+			"version":     "v1.4.2",
+			"environment": "production",
+			"status":      "done",
+		})
+
+		require.ErrorContains(t, err, `invalid deployment status "done"`)
+	})
+}
+
+func Test__Deployment(t *testing.T) {
+	deployment := Deployment{
+		Service:     "checkout",
+		Version:     "v1.4.2",
+		Environment: "production",
+		Status:      DeploymentStatusFailed,
+		URL:         "https://ci.example.com/runs/1",
+	}
+
+	assert.Equal(t, "Failed to deploy checkout v1.4.2 to production", deployment.Title())
+	assert.Equal(t, "Failed to deploy checkout v1.4.2 to production\n\nhttps://ci.example.com/runs/1", deployment.Text())
+	assert.Equal(t, []string{"service:checkout", "version:v1.4.2", "env:production", "deployment_status:failed"}, deployment.Tags())
+	assert.Equal(t, map[string]any{
+		"service":     "checkout",
+		"version":     "v1.4.2",
+		"environment": "production",
+		"status":      DeploymentStatusFailed,
+		"url":         "https://ci.example.com/runs/1",
+	}, deployment.ToMap())
+
+	deployment.Status = DeploymentStatusRolledBack
+	assert.Equal(t, "Rolled back checkout v1.4.2 in production", deployment.Title())
+}
@@ -0,0 +1,42 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type componentWithDefaultTimeout struct {
+	core.Component
+}
+
+func (c *componentWithDefaultTimeout) DefaultExecutionTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
+func TestExecutionTimeoutFor(t *testing.T) {
+	t.Run("no timeout -> zero", func(t *testing.T) {
+		assert.Zero(t, core.ExecutionTimeoutFor(&exampleComponent{}, map[string]any{}))
+	})
+
+	t.Run("configured timeout", func(t *testing.T) {
+		config := map[string]any{core.ExecutionTimeoutField: "30s"}
+		assert.Equal(t, 30*time.Second, core.ExecutionTimeoutFor(&exampleComponent{}, config))
+		assert.Equal(t, 30*time.Second, core.ExecutionTimeoutFor(&componentWithDefaultTimeout{}, config))
+	})
+
+	t.Run("component default is used when no timeout is configured", func(t *testing.T) {
+		assert.Equal(t, 10*time.Minute, core.ExecutionTimeoutFor(&componentWithDefaultTimeout{}, map[string]any{}))
+	})
+
+	t.Run("executionTimeout fields of components are not used", func(t *testing.T) {
+		config := map[string]any{
+			"executionTimeout":         map[string]any{"value": 2, "unit": "minutes"},
+			core.ExecutionTimeoutField: 60,
+		}
+
+		assert.Equal(t, time.Minute, core.ExecutionTimeoutFor(&exampleComponent{}, config))
+	})
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+/*
+ * HealthChecker is implemented by integrations that can verify,
+ * with a cheap request to the external system, that they still work,
+ * e.g. that their credentials were not revoked or expired.
+ *
+ * Health checks are run periodically for ready and degraded integrations.
+ * Integrations failing them are marked as degraded, and executions
+ * of nodes using them are not scheduled until a health check passes again.
+ *
+ * Health checks must not change the integration: changes to its metadata,
+ * secrets or state through ctx.Integration are not saved.
+ */
+type HealthChecker interface {
+	HealthCheck(ctx HealthCheckContext) error
+}
+
+type HealthCheckContext struct {
+	Context       context.Context
+	Logger        *logrus.Entry
+	Configuration any
+	HTTP          HTTPContext
+	Integration   IntegrationContext
+}
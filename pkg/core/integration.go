@@ -153,6 +153,34 @@ type IntegrationResource struct {
 	Type string
 	Name string
 	ID   string
+
+	//
+	// Optional details used by the UI to render the option,
+	// so listers do not need to encode them into Name,
+	// e.g. "2 vCPU, 4 GB memory" for a machine type.
+	//
+	Description string
+
+	//
+	// Optional group the resource is displayed under, e.g. the machine family.
+	//
+	Group string
+
+	//
+	// Optional icon displayed next to the resource.
+	//
+	Icon string
+
+	//
+	// Disabled resources are listed but cannot be selected,
+	// e.g. machine types not available in the selected zone.
+	//
+	Disabled bool
+
+	//
+	// Optional structured data about the resource, e.g. CPU count or price.
+	//
+	Metadata map[string]any
 }
 
 type ListResourcesContext struct {
@@ -160,6 +188,16 @@ type ListResourcesContext struct {
 	HTTP        HTTPContext
 	Integration IntegrationContext
 	Parameters  map[string]string
+
+	//
+	// Optional search query and page requested by the caller.
+	// A zero PageSize means all the resources should be returned.
+	// Integrations that do not implement ResourcePageLister can ignore them,
+	// since the resources returned by ListResources() are filtered and paginated for them.
+	//
+	Query     string
+	PageToken string
+	PageSize  int
 }
 
 type WebhookOptions struct {
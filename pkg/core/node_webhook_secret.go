@@ -0,0 +1,153 @@
+package core
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/crypto"
+)
+
+/*
+ * NodeWebhookSecretContext controls the webhook secret of a single node.
+ *
+ * Webhooks are shared by the nodes receiving the same events,
+ * e.g. all the nodes using an integration with the same configuration,
+ * so the secret from NodeWebhookContext is known to all of them.
+ * The node secret is not: configuring it in the external system
+ * for one node, and validating requests with ValidateWebhookRequest(),
+ * means a leaked URL and secret only give access to that node.
+ *
+ * Rotating the secret keeps the previous one valid for a grace period,
+ * so the external system can be updated without rejecting requests.
+ */
+type NodeWebhookSecretContext interface {
+
+	//
+	// Returns the current secret of the node, creating it if it has none.
+	//
+	GetSecret() ([]byte, error)
+
+	//
+	// Returns the secrets requests can be authenticated with:
+	// the current one, and the previous one during its grace period.
+	//
+	AcceptedSecrets() ([][]byte, error)
+
+	//
+	// Replaces the secret with a new one, and returns it.
+	// The previous secret is still accepted until the grace period ends.
+	//
+	RotateSecret(gracePeriod time.Duration) ([]byte, error)
+}
+
+const (
+	WebhookAuthenticationSignature = "signature"
+	WebhookAuthenticationBearer    = "bearer"
+)
+
+/*
+ * WebhookAuthentication describes how a request carries the node secret.
+ *
+ * With WebhookAuthenticationSignature, Header has the hex-encoded
+ * HMAC-SHA256 of the body, optionally prefixed with "sha256=".
+ * With WebhookAuthenticationBearer, the Authorization header has the secret
+ * as a bearer token. If Header is set, it has the token instead, without a prefix.
+ */
+type WebhookAuthentication struct {
+	Method string
+	Header string
+}
+
+/*
+ * ValidateWebhookRequest authenticates a request to HandleWebhook()
+ * with the secrets accepted for its node. If the request is not authenticated,
+ * it returns an error, and the status code to respond with.
+ */
+func ValidateWebhookRequest(ctx WebhookRequestContext, auth WebhookAuthentication) (int, error) {
+	if ctx.WebhookSecret == nil {
+		return http.StatusInternalServerError, fmt.Errorf("node webhook secret not available")
+	}
+
+	secrets, err := ctx.WebhookSecret.AcceptedSecrets()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error authenticating request")
+	}
+
+	switch auth.Method {
+	case WebhookAuthenticationSignature:
+		if auth.Header == "" {
+			return http.StatusInternalServerError, fmt.Errorf("signature header is required")
+		}
+
+		signature := ctx.Headers.Get(auth.Header)
+		if signature == "" {
+			return http.StatusForbidden, fmt.Errorf("missing %s header", auth.Header)
+		}
+
+		if err := VerifyWebhookSignature(secrets, ctx.Body, signature); err != nil {
+			return http.StatusForbidden, err
+		}
+
+		return http.StatusOK, nil
+
+	case WebhookAuthenticationBearer:
+		token := ""
+		if auth.Header != "" {
+			token = ctx.Headers.Get(auth.Header)
+		} else {
+			authorization := ctx.Headers.Get("Authorization")
+			if len(authorization) > len("Bearer ") && strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+				token = authorization[len("Bearer "):]
+			}
+		}
+
+		if token == "" {
+			return http.StatusUnauthorized, fmt.Errorf("missing token")
+		}
+
+		if err := VerifyWebhookToken(secrets, token); err != nil {
+			return http.StatusUnauthorized, err
+		}
+
+		return http.StatusOK, nil
+
+	default:
+		return http.StatusInternalServerError, fmt.Errorf("unknown webhook authentication %q", auth.Method)
+	}
+}
+
+/*
+ * VerifyWebhookSignature checks the hex-encoded HMAC-SHA256 of the body
+ * against each of the secrets, e.g. the ones accepted for a node.
+ */
+func VerifyWebhookSignature(secrets [][]byte, body []byte, signature string) error {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	if signature == "" {
+		return fmt.Errorf("invalid signature format")
+	}
+
+	for _, secret := range secrets {
+		if crypto.VerifySignature(secret, body, signature) == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid signature")
+}
+
+/*
+ * VerifyWebhookToken checks a token against each of the secrets,
+ * in constant time, so the secrets cannot be guessed from response times.
+ */
+func VerifyWebhookToken(secrets [][]byte, token string) error {
+	for _, secret := range secrets {
+		if subtle.ConstantTimeCompare([]byte(token), secret) == 1 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid token")
+}
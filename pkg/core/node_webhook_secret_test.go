@@ -0,0 +1,83 @@
+package core_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func TestValidateWebhookRequest(t *testing.T) {
+	body := []byte(`{"event":"deployed"}`)
+	secrets := &contexts.NodeWebhookSecretContext{Secret: "current", PreviousSecret: "previous"}
+
+	request := func(headers map[string]string) core.WebhookRequestContext {
+		h := http.Header{}
+		for name, value := range headers {
+			h.Set(name, value)
+		}
+
+		return core.WebhookRequestContext{Body: body, Headers: h, WebhookSecret: secrets}
+	}
+
+	signature := core.WebhookAuthentication{Method: core.WebhookAuthenticationSignature, Header: "X-Signature-256"}
+	bearer := core.WebhookAuthentication{Method: core.WebhookAuthenticationBearer}
+
+	t.Run("signature with the current secret", func(t *testing.T) {
+		code, err := core.ValidateWebhookRequest(request(map[string]string{
+			"X-Signature-256": "sha256=" + crypto.Sign([]byte("current"), body),
+		}), signature)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("signature with the previous secret", func(t *testing.T) {
+		code, err := core.ValidateWebhookRequest(request(map[string]string{
+			"X-Signature-256": crypto.Sign([]byte("previous"), body),
+		}), signature)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("signature with another secret", func(t *testing.T) {
+		code, err := core.ValidateWebhookRequest(request(map[string]string{
+			"X-Signature-256": crypto.Sign([]byte("other-node"), body),
+		}), signature)
+
+		require.ErrorContains(t, err, "invalid signature")
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		code, err := core.ValidateWebhookRequest(request(nil), signature)
+		require.ErrorContains(t, err, "missing X-Signature-256 header")
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("bearer token", func(t *testing.T) {
+		code, err := core.ValidateWebhookRequest(request(map[string]string{"Authorization": "Bearer previous"}), bearer)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+
+		code, err = core.ValidateWebhookRequest(request(map[string]string{"Authorization": "Bearer other-node"}), bearer)
+		require.ErrorContains(t, err, "invalid token")
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		code, err = core.ValidateWebhookRequest(request(nil), bearer)
+		require.ErrorContains(t, err, "missing token")
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("token in a custom header", func(t *testing.T) {
+		auth := core.WebhookAuthentication{Method: core.WebhookAuthenticationBearer, Header: "X-Webhook-Token"}
+		code, err := core.ValidateWebhookRequest(request(map[string]string{"X-Webhook-Token": "current"}), auth)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
@@ -0,0 +1,263 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/*
+ * OutputSchemaProvider is implemented by components that declare
+ * the JSON schema of the payloads emitted on their output channels.
+ *
+ * OutputSchemas() returns the schemas by output channel name.
+ * Channels without a schema are not validated.
+ *
+ * Only a subset of JSON schema is supported, the one needed
+ * to describe the shape of a payload: type, properties, required,
+ * additionalProperties, items and enum.
+ */
+type OutputSchemaProvider interface {
+	OutputSchemas() map[string]map[string]any
+}
+
+func OutputSchemas(component Component) map[string]map[string]any {
+	provider, ok := component.(OutputSchemaProvider)
+	if !ok {
+		return nil
+	}
+
+	return provider.OutputSchemas()
+}
+
+/*
+ * OutputSchemaValidationEnabled returns true in development and test,
+ * where payloads that do not match the schema of their channel fail the execution.
+ * In production, payloads are never validated.
+ */
+func OutputSchemaValidationEnabled() bool {
+	appEnv := os.Getenv("APP_ENV")
+	return appEnv == "development" || appEnv == "test"
+}
+
+/*
+ * ValidateOutputs wraps the execution state of a component,
+ * validating the payloads emitted against the schemas of their channels.
+ * If the component does not declare schemas, the execution state is returned as is.
+ */
+func ValidateOutputs(component Component, state ExecutionStateContext) ExecutionStateContext {
+	schemas := OutputSchemas(component)
+	if len(schemas) == 0 || state == nil {
+		return state
+	}
+
+	return &validatingExecutionState{
+		ExecutionStateContext: state,
+		component:             component.Name(),
+		schemas:               schemas,
+	}
+}
+
+type validatingExecutionState struct {
+	ExecutionStateContext
+	component string
+	schemas   map[string]map[string]any
+}
+
+func (s *validatingExecutionState) Emit(channel, payloadType string, payloads []any) error {
+	if err := s.validate(channel, payloads); err != nil {
+		return err
+	}
+
+	return s.ExecutionStateContext.Emit(channel, payloadType, payloads)
+}
+
+func (s *validatingExecutionState) EmitOutputs(outputs []ChannelOutput) error {
+	for _, output := range outputs {
+		if err := s.validate(output.Channel, output.Payloads); err != nil {
+			return err
+		}
+	}
+
+	return s.ExecutionStateContext.EmitOutputs(outputs)
+}
+
+func (s *validatingExecutionState) validate(channel string, payloads []any) error {
+	schema, ok := s.schemas[channel]
+	if !ok {
+		return nil
+	}
+
+	for i, payload := range payloads {
+		if err := ValidateOutputSchema(schema, payload); err != nil {
+			return fmt.Errorf("%s emitted a payload on %s that does not match its schema: payload %d: %w", s.component, channel, i, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+ * ValidateOutputSchema validates a payload against a schema.
+ * The payload is validated as it will be stored, i.e. as JSON,
+ * so nil slices and maps are null, and structs use their JSON names.
+ */
+func ValidateOutputSchema(schema map[string]any, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("payload is not valid JSON: %w", err)
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("payload is not valid JSON: %w", err)
+	}
+
+	return validateSchemaValue(schema, value, "$")
+}
+
+func validateSchemaValue(schema map[string]any, value any, path string) error {
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		actual := jsonType(value)
+		if !typeAllowed(types, actual) {
+			return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(types, " or "), actual)
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !enumContains(enum, value) {
+		return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return validateSchemaObject(schema, v, path)
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		for i, item := range v {
+			if err := validateSchemaValue(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateSchemaObject(schema map[string]any, value map[string]any, path string) error {
+	for _, name := range schemaStrings(schema["required"]) {
+		if _, ok := value[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", path, name)
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	additional := schema["additionalProperties"]
+
+	//
+	// Sorted, so the same payload always reports the same error.
+	//
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + "." + name
+		if property, ok := properties[name].(map[string]any); ok {
+			if err := validateSchemaValue(property, value[name], propertyPath); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		switch a := additional.(type) {
+		case bool:
+			if !a {
+				return fmt.Errorf("%s: unexpected property", propertyPath)
+			}
+		case map[string]any:
+			if err := validateSchemaValue(a, value[name], propertyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func schemaTypes(t any) []string {
+	switch v := t.(type) {
+	case string:
+		return []string{v}
+	default:
+		return schemaStrings(v)
+	}
+}
+
+func schemaStrings(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	default:
+		return nil
+	}
+}
+
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return reflect.TypeOf(value).String()
+	}
+}
+
+func typeAllowed(types []string, actual string) bool {
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, item := range enum {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,123 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/core/coretest"
+)
+
+var pipelineSchema = map[string]any{
+	"type":                 "object",
+	"required":             []any{"pipeline"},
+	"additionalProperties": false,
+	"properties": map[string]any{
+		"pipeline": map[string]any{
+			"type":     "object",
+			"required": []any{"name"},
+			"properties": map[string]any{
+				"name":   map[string]any{"type": "string"},
+				"state":  map[string]any{"type": "string", "enum": []any{"SUCCEEDED", "FAILED"}},
+				"stages": map[string]any{"type": []any{"array", "null"}, "items": map[string]any{"type": "string"}},
+				"count":  map[string]any{"type": "integer"},
+			},
+		},
+	},
+}
+
+func TestValidateOutputSchema(t *testing.T) {
+	t.Run("matching payload", func(t *testing.T) {
+		err := core.ValidateOutputSchema(pipelineSchema, map[string]any{
+			"pipeline": map[string]any{"name": "deploy", "state": "SUCCEEDED", "stages": []string{"build"}, "count": 2},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("nil slice -> validated as null", func(t *testing.T) {
+		var stages []string
+		err := core.ValidateOutputSchema(pipelineSchema, map[string]any{
+			"pipeline": map[string]any{"name": "deploy", "stages": stages},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("payload wrapped in data -> error", func(t *testing.T) {
+		err := core.ValidateOutputSchema(pipelineSchema, map[string]any{
+			"data": map[string]any{"pipeline": map[string]any{"name": "deploy"}},
+		})
+
+		require.ErrorContains(t, err, `$: missing required property "pipeline"`)
+	})
+
+	t.Run("unexpected property -> error", func(t *testing.T) {
+		err := core.ValidateOutputSchema(pipelineSchema, map[string]any{
+			"pipeline": map[string]any{"name": "deploy"},
+			"extra":    true,
+		})
+
+		require.ErrorContains(t, err, "$.extra: unexpected property")
+	})
+
+	t.Run("wrong types -> error", func(t *testing.T) {
+		err := core.ValidateOutputSchema(pipelineSchema, map[string]any{"pipeline": map[string]any{"name": 1}})
+		require.ErrorContains(t, err, "$.pipeline.name: expected string, got integer")
+
+		err = core.ValidateOutputSchema(pipelineSchema, map[string]any{"pipeline": map[string]any{"name": "deploy", "count": 1.5}})
+		require.ErrorContains(t, err, "$.pipeline.count: expected integer, got number")
+
+		err = core.ValidateOutputSchema(pipelineSchema, map[string]any{"pipeline": map[string]any{"name": "deploy", "stages": []any{"build", 2}}})
+		require.ErrorContains(t, err, "$.pipeline.stages[1]: expected string, got integer")
+	})
+
+	t.Run("value not in enum -> error", func(t *testing.T) {
+		err := core.ValidateOutputSchema(pipelineSchema, map[string]any{"pipeline": map[string]any{"name": "deploy", "state": "RUNNING"}})
+		require.ErrorContains(t, err, "$.pipeline.state: RUNNING is not one of")
+	})
+}
+
+type schemaComponent struct {
+	exampleComponent
+}
+
+func (c *schemaComponent) OutputSchemas() map[string]map[string]any {
+	return map[string]map[string]any{"passed": pipelineSchema}
+}
+
+func TestValidateOutputs(t *testing.T) {
+	t.Run("component without schemas -> execution state is not wrapped", func(t *testing.T) {
+		execution := coretest.NewExecution(t).Build()
+		state := core.ValidateOutputs(&exampleComponent{}, execution.Context.ExecutionState)
+		assert.Same(t, execution.ExecutionState, state)
+	})
+
+	t.Run("payload matching the schema -> emitted", func(t *testing.T) {
+		execution := coretest.NewExecution(t).Build()
+		state := core.ValidateOutputs(&schemaComponent{}, execution.Context.ExecutionState)
+
+		require.NoError(t, state.Emit("passed", "pipeline.finished", []any{map[string]any{"pipeline": map[string]any{"name": "deploy"}}}))
+		assert.Len(t, execution.ExecutionState.Payloads("passed"), 1)
+	})
+
+	t.Run("payload not matching the schema -> not emitted", func(t *testing.T) {
+		execution := coretest.NewExecution(t).Build()
+		state := core.ValidateOutputs(&schemaComponent{}, execution.Context.ExecutionState)
+
+		err := state.Emit("passed", "pipeline.finished", []any{map[string]any{"data": map[string]any{}}})
+		require.ErrorContains(t, err, "example emitted a payload on passed that does not match its schema")
+		assert.False(t, execution.ExecutionState.Finished)
+
+		err = state.EmitOutputs([]core.ChannelOutput{{Channel: "passed", PayloadType: "pipeline.finished", Payloads: []any{"deploy"}}})
+		require.ErrorContains(t, err, "expected object, got string")
+	})
+
+	t.Run("channel without a schema -> not validated", func(t *testing.T) {
+		execution := coretest.NewExecution(t).Build()
+		state := core.ValidateOutputs(&schemaComponent{}, execution.Context.ExecutionState)
+
+		require.NoError(t, state.Emit("failed", "pipeline.finished", []any{"anything"}))
+	})
+}
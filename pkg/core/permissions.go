@@ -0,0 +1,24 @@
+package core
+
+/*
+ * Permissions required in the external system,
+ * e.g. OAuth scopes or cloud IAM roles.
+ */
+type Permissions struct {
+	Scopes []string `json:"scopes,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+func (p Permissions) IsEmpty() bool {
+	return len(p.Scopes) == 0 && len(p.Roles) == 0
+}
+
+/*
+ * PermissionsProvider is implemented by integrations, components and triggers
+ * that need specific permissions from the credentials used by the integration.
+ * Permissions of an integration are needed by all its components and triggers,
+ * so components and triggers only declare what they need on top of those.
+ */
+type PermissionsProvider interface {
+	RequiredPermissions() Permissions
+}
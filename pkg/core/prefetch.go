@@ -0,0 +1,31 @@
+package core
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+/*
+ * Prefetcher is implemented by integrations that cache resources
+ * which are slow to list, e.g. the regions, zones and machine types
+ * shown in configuration forms, so the forms do not wait on the first list.
+ *
+ * Prefetch() is called in the background, after the integration is synced,
+ * and periodically after that. Resources are cached in memory, so it is called
+ * in every process serving the API. Errors are only logged.
+ *
+ * Like health checks, Prefetch() must not change the integration:
+ * changes to its metadata, secrets or state through ctx.Integration are not saved.
+ */
+type Prefetcher interface {
+	Prefetch(ctx PrefetchContext) error
+}
+
+type PrefetchContext struct {
+	Context       context.Context
+	Logger        *logrus.Entry
+	Configuration any
+	HTTP          HTTPContext
+	Integration   IntegrationContext
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	QueueModeField      = "queueMode"
+	MaxInFlightField    = "maxInFlight"
+	DebounceWindowField = "debounceWindow"
+
+	//
+	// One execution at a time, queued events are processed in order.
+	//
+	QueueModeSerial = "serial"
+
+	//
+	// Up to MaxInFlight executions at the same time.
+	//
+	QueueModeParallel = "parallel"
+
+	//
+	// One execution at a time, but events queued while the node is busy
+	// are replaced by newer ones, so only the latest one is processed.
+	//
+	QueueModeDropOldest = "dropOldest"
+
+	//
+	// Waits until no new events were queued for DebounceWindow,
+	// and then processes only the latest one.
+	//
+	QueueModeDebounce = "debounce"
+
+	DefaultMaxInFlight    = 5
+	DefaultDebounceWindow = 30 * time.Second
+)
+
+/*
+ * QueueSettings controls how the queue of a node is processed.
+ */
+type QueueSettings struct {
+	Mode           string
+	MaxInFlight    int
+	DebounceWindow time.Duration
+}
+
+/*
+ * MaxExecutions returns how many executions of the node can be in flight at the same time.
+ */
+func (s QueueSettings) MaxExecutions() int {
+	if s.Mode == QueueModeParallel {
+		return s.MaxInFlight
+	}
+
+	return 1
+}
+
+func QueueConfigurationFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        QueueModeField,
+			Label:       "Queue mode",
+			Type:        configuration.FieldTypeSelect,
+			Description: "How events queued for this node are processed",
+			Default:     QueueModeSerial,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Serial", Value: QueueModeSerial},
+						{Label: "Parallel", Value: QueueModeParallel},
+						{Label: "Drop oldest", Value: QueueModeDropOldest},
+						{Label: "Debounce", Value: QueueModeDebounce},
+					},
+				},
+			},
+		},
+		{
+			Name:        MaxInFlightField,
+			Label:       "Max executions in flight",
+			Type:        configuration.FieldTypeNumber,
+			Description: "Events are queued while this many executions are running",
+			Default:     DefaultMaxInFlight,
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: QueueModeField, Values: []string{QueueModeParallel}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: QueueModeField, Values: []string{QueueModeParallel}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+				},
+			},
+		},
+		{
+			Name:        DebounceWindowField,
+			Label:       "Debounce window",
+			Type:        configuration.FieldTypeDuration,
+			Description: "Only the latest event is processed, once no new events arrived for this long",
+			Placeholder: "e.g. 30s",
+			Default:     "30s",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: QueueModeField, Values: []string{QueueModeDebounce}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: QueueModeField, Values: []string{QueueModeDebounce}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Duration: &configuration.DurationTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+				},
+			},
+		},
+	}
+}
+
+/*
+ * QueueSettingsFromConfiguration returns the queue settings of the node.
+ * Nodes without queue settings, or with invalid ones, are processed serially.
+ */
+func QueueSettingsFromConfiguration(config any) QueueSettings {
+	settings := QueueSettings{
+		Mode:           QueueModeSerial,
+		MaxInFlight:    DefaultMaxInFlight,
+		DebounceWindow: DefaultDebounceWindow,
+	}
+
+	values, ok := config.(map[string]any)
+	if !ok {
+		return settings
+	}
+
+	switch mode, _ := values[QueueModeField].(string); mode {
+	case QueueModeParallel, QueueModeDropOldest, QueueModeDebounce:
+		settings.Mode = mode
+	}
+
+	if n := intValue(values[MaxInFlightField]); n > 0 {
+		settings.MaxInFlight = n
+	}
+
+	if v, ok := values[DebounceWindowField]; ok && v != nil {
+		window, err := configuration.ParseDuration(v)
+		if err == nil && window > 0 {
+			settings.DebounceWindow = window
+		}
+	}
+
+	return settings
+}
+
+func intValue(value any) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+
+	return 0
+}
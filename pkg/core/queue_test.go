@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__QueueSettingsFromConfiguration(t *testing.T) {
+	t.Run("no configuration -> serial", func(t *testing.T) {
+		settings := QueueSettingsFromConfiguration(nil)
+		assert.Equal(t, QueueModeSerial, settings.Mode)
+		assert.Equal(t, 1, settings.MaxExecutions())
+	})
+
+	t.Run("unknown mode -> serial", func(t *testing.T) {
+		settings := QueueSettingsFromConfiguration(map[string]any{QueueModeField: "whatever", MaxInFlightField: 10})
+		assert.Equal(t, QueueModeSerial, settings.Mode)
+		assert.Equal(t, 1, settings.MaxExecutions())
+	})
+
+	t.Run("parallel -> max in flight", func(t *testing.T) {
+		settings := QueueSettingsFromConfiguration(map[string]any{QueueModeField: QueueModeParallel, MaxInFlightField: float64(3)})
+		assert.Equal(t, QueueModeParallel, settings.Mode)
+		assert.Equal(t, 3, settings.MaxExecutions())
+	})
+
+	t.Run("parallel with invalid max in flight -> default", func(t *testing.T) {
+		settings := QueueSettingsFromConfiguration(map[string]any{QueueModeField: QueueModeParallel, MaxInFlightField: "0"})
+		assert.Equal(t, DefaultMaxInFlight, settings.MaxExecutions())
+	})
+
+	t.Run("drop oldest runs one at a time", func(t *testing.T) {
+		settings := QueueSettingsFromConfiguration(map[string]any{QueueModeField: QueueModeDropOldest})
+		assert.Equal(t, QueueModeDropOldest, settings.Mode)
+		assert.Equal(t, 1, settings.MaxExecutions())
+	})
+
+	t.Run("debounce window", func(t *testing.T) {
+		settings := QueueSettingsFromConfiguration(map[string]any{QueueModeField: QueueModeDebounce, DebounceWindowField: "2m"})
+		assert.Equal(t, QueueModeDebounce, settings.Mode)
+		assert.Equal(t, 2*time.Minute, settings.DebounceWindow)
+
+		settings = QueueSettingsFromConfiguration(map[string]any{QueueModeField: QueueModeDebounce})
+		assert.Equal(t, DefaultDebounceWindow, settings.DebounceWindow)
+	})
+}
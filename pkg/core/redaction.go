@@ -0,0 +1,97 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+const RedactedValue = "[REDACTED]"
+
+/*
+ * SensitivePayload is implemented by components and triggers
+ * whose payloads include values that should not be displayed,
+ * e.g. service account emails, internal IPs, or secret values
+ * included in the responses of the external API.
+ *
+ * Payloads are stored as they are emitted, so downstream nodes
+ * still receive the values, but they are masked in the payloads
+ * returned by the API, and should be masked with RedactPayload() before logging.
+ *
+ * Paths are relative to the emitted payload, with keys separated by dots,
+ * e.g. "data.serviceAccount.email". A "*" segment matches every item of a list,
+ * or every value of an object, e.g. "data.networkInterfaces.*.networkIP".
+ */
+type SensitivePayload interface {
+	SensitivePaths() []string
+}
+
+/*
+ * RedactPayload returns a copy of the payload, with the values
+ * at the given paths replaced by RedactedValue.
+ * The payload itself is not changed, and paths not found in it are ignored.
+ */
+func RedactPayload(payload any, paths []string) any {
+	for _, path := range paths {
+		segments := strings.Split(path, ".")
+		payload = redactPath(payload, segments)
+	}
+
+	return payload
+}
+
+func redactPath(value any, segments []string) any {
+	if len(segments) == 0 {
+		if value == nil {
+			return nil
+		}
+
+		return RedactedValue
+	}
+
+	segment := segments[0]
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			if segment == "*" || key == segment {
+				copied[key] = redactPath(item, segments[1:])
+				continue
+			}
+
+			copied[key] = item
+		}
+
+		return copied
+
+	case []any:
+		if segment != "*" {
+			return v
+		}
+
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = redactPath(item, segments[1:])
+		}
+
+		return copied
+
+	default:
+		return value
+	}
+}
+
+/*
+ * ValidateSensitivePaths checks that the paths are well-formed,
+ * e.g. in tests of components implementing SensitivePayload.
+ */
+func ValidateSensitivePaths(paths []string) error {
+	for _, path := range paths {
+		for _, segment := range strings.Split(path, ".") {
+			if segment == "" {
+				return fmt.Errorf("invalid sensitive path %q", path)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func TestRedactPayload(t *testing.T) {
+	payload := map[string]any{
+		"type": "gcp.vm",
+		"data": map[string]any{
+			"name":           "vm-1",
+			"serviceAccount": "sa@project.iam.gserviceaccount.com",
+			"networkInterfaces": []any{
+				map[string]any{"name": "nic0", "internalIP": "10.0.0.2"},
+				map[string]any{"name": "nic1", "internalIP": "10.0.0.3"},
+			},
+			"labels": map[string]any{"team": "infra", "owner": "alice"},
+		},
+	}
+
+	redacted := core.RedactPayload(payload, []string{
+		"data.serviceAccount",
+		"data.networkInterfaces.*.internalIP",
+		"data.labels.*",
+		"data.missing.path",
+	})
+
+	assert.Equal(t, map[string]any{
+		"type": "gcp.vm",
+		"data": map[string]any{
+			"name":           "vm-1",
+			"serviceAccount": core.RedactedValue,
+			"networkInterfaces": []any{
+				map[string]any{"name": "nic0", "internalIP": core.RedactedValue},
+				map[string]any{"name": "nic1", "internalIP": core.RedactedValue},
+			},
+			"labels": map[string]any{"team": core.RedactedValue, "owner": core.RedactedValue},
+		},
+	}, redacted)
+
+	t.Run("payload is not changed", func(t *testing.T) {
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "sa@project.iam.gserviceaccount.com", data["serviceAccount"])
+		assert.Equal(t, "10.0.0.2", data["networkInterfaces"].([]any)[0].(map[string]any)["internalIP"])
+	})
+
+	t.Run("null values are kept", func(t *testing.T) {
+		redacted := core.RedactPayload(map[string]any{"secret": nil}, []string{"secret"})
+		assert.Equal(t, map[string]any{"secret": nil}, redacted)
+	})
+
+	t.Run("key on a list is ignored", func(t *testing.T) {
+		redacted := core.RedactPayload(map[string]any{"items": []any{"a"}}, []string{"items.name"})
+		assert.Equal(t, map[string]any{"items": []any{"a"}}, redacted)
+	})
+}
+
+func TestValidateSensitivePaths(t *testing.T) {
+	require.NoError(t, core.ValidateSensitivePaths([]string{"data.serviceAccount", "data.items.*.ip"}))
+	require.ErrorContains(t, core.ValidateSensitivePaths([]string{"data..ip"}), "invalid sensitive path")
+	require.ErrorContains(t, core.ValidateSensitivePaths([]string{""}), "invalid sensitive path")
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+ * Parameters used by the resources endpoint for search and pagination.
+ * They are removed from ListResourcesContext.Parameters, and set
+ * on ListResourcesContext.Query, PageToken and PageSize instead.
+ */
+const (
+	ResourceQueryParameter     = "query"
+	ResourcePageTokenParameter = "pageToken"
+	ResourcePageSizeParameter  = "pageSize"
+)
+
+const MaxResourcePageSize = 500
+
+/*
+ * ResourcePage is a page of resources.
+ * NextPageToken is empty on the last page.
+ */
+type ResourcePage struct {
+	Resources     []IntegrationResource
+	NextPageToken string
+}
+
+/*
+ * ResourcePageLister is implemented by integrations that can search
+ * and paginate resources through the external API, for resource types
+ * with too many resources to be listed in a single response.
+ *
+ * Page tokens are opaque to the caller, so integrations can use the
+ * tokens returned by the external API. Resource types that are not
+ * paginated natively can fall back to PageResources().
+ */
+type ResourcePageLister interface {
+	ListResourcePage(resourceType string, ctx ListResourcesContext) (*ResourcePage, error)
+}
+
+/*
+ * NewListResourcesContext moves the search and pagination
+ * parameters out of the parameters for the integration.
+ */
+func NewListResourcesContext(ctx ListResourcesContext) (ListResourcesContext, error) {
+	parameters := make(map[string]string, len(ctx.Parameters))
+	for name, value := range ctx.Parameters {
+		switch name {
+		case ResourceQueryParameter:
+			ctx.Query = strings.TrimSpace(value)
+		case ResourcePageTokenParameter:
+			ctx.PageToken = value
+		case ResourcePageSizeParameter:
+			if value == "" {
+				continue
+			}
+
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 0 {
+				return ctx, fmt.Errorf("invalid page size %q", value)
+			}
+
+			ctx.PageSize = min(size, MaxResourcePageSize)
+		default:
+			parameters[name] = value
+		}
+	}
+
+	ctx.Parameters = parameters
+	return ctx, nil
+}
+
+/*
+ * FilterResources returns the resources whose name, ID, description or group
+ * contain all the words in the query, ignoring case.
+ */
+func FilterResources(resources []IntegrationResource, query string) []IntegrationResource {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return resources
+	}
+
+	filtered := []IntegrationResource{}
+	for _, resource := range resources {
+		text := strings.ToLower(strings.Join([]string{resource.Name, resource.ID, resource.Description, resource.Group}, " "))
+		matches := true
+		for _, word := range words {
+			if !strings.Contains(text, word) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			filtered = append(filtered, resource)
+		}
+	}
+
+	return filtered
+}
+
+/*
+ * PageResources filters already listed resources with ctx.Query,
+ * and returns the page requested with ctx.PageToken and ctx.PageSize.
+ * The page token is the offset of the first resource in the page.
+ */
+func PageResources(resources []IntegrationResource, ctx ListResourcesContext) (*ResourcePage, error) {
+	resources = FilterResources(resources, ctx.Query)
+
+	offset := 0
+	if ctx.PageToken != "" {
+		n, err := strconv.Atoi(ctx.PageToken)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid page token %q", ctx.PageToken)
+		}
+
+		offset = min(n, len(resources))
+	}
+
+	if ctx.PageSize <= 0 {
+		return &ResourcePage{Resources: resources[offset:]}, nil
+	}
+
+	end := min(offset+ctx.PageSize, len(resources))
+	page := &ResourcePage{Resources: resources[offset:end]}
+	if end < len(resources) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewListResourcesContext(t *testing.T) {
+	t.Run("search and page parameters are moved out of the parameters", func(t *testing.T) {
+		ctx, err := NewListResourcesContext(ListResourcesContext{
+			Parameters: map[string]string{
+				"type":      "ec2.image",
+				"region":    "us-east-1",
+				"query":     " web ",
+				"pageToken": "token",
+				"pageSize":  "1000",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"type": "ec2.image", "region": "us-east-1"}, ctx.Parameters)
+		assert.Equal(t, "web", ctx.Query)
+		assert.Equal(t, "token", ctx.PageToken)
+		assert.Equal(t, MaxResourcePageSize, ctx.PageSize)
+	})
+
+	t.Run("invalid page size -> error", func(t *testing.T) {
+		_, err := NewListResourcesContext(ListResourcesContext{Parameters: map[string]string{"pageSize": "-1"}})
+		require.ErrorContains(t, err, "invalid page size")
+	})
+}
+
+func TestPageResources(t *testing.T) {
+	resources := []IntegrationResource{
+		{Name: "Web Server", ID: "ami-1"},
+		{Name: "web-worker", ID: "ami-2"},
+		{Name: "database", ID: "ami-3"},
+	}
+
+	t.Run("no query or page size -> all resources", func(t *testing.T) {
+		page, err := PageResources(resources, ListResourcesContext{})
+		require.NoError(t, err)
+		assert.Equal(t, resources, page.Resources)
+		assert.Empty(t, page.NextPageToken)
+	})
+
+	t.Run("query matches name or ID, ignoring case", func(t *testing.T) {
+		page, err := PageResources(resources, ListResourcesContext{Query: "WEB"})
+		require.NoError(t, err)
+		assert.Equal(t, resources[:2], page.Resources)
+
+		page, err = PageResources(resources, ListResourcesContext{Query: "ami-3"})
+		require.NoError(t, err)
+		assert.Equal(t, resources[2:], page.Resources)
+
+		page, err = PageResources(resources, ListResourcesContext{Query: "web server"})
+		require.NoError(t, err)
+		assert.Equal(t, resources[:1], page.Resources)
+	})
+
+	t.Run("query matches description or group", func(t *testing.T) {
+		annotated := []IntegrationResource{
+			{Name: "e2-medium", ID: "e2-medium", Description: "2 vCPU, 4 GB memory", Group: "E2"},
+			{Name: "n2-standard-4", ID: "n2-standard-4", Description: "4 vCPU, 16 GB memory", Group: "N2"},
+		}
+
+		page, err := PageResources(annotated, ListResourcesContext{Query: "16 gb"})
+		require.NoError(t, err)
+		assert.Equal(t, annotated[1:], page.Resources)
+
+		page, err = PageResources(annotated, ListResourcesContext{Query: "e2"})
+		require.NoError(t, err)
+		assert.Equal(t, annotated[:1], page.Resources)
+	})
+
+	t.Run("pages are returned with a next page token", func(t *testing.T) {
+		page, err := PageResources(resources, ListResourcesContext{PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, resources[:2], page.Resources)
+		assert.Equal(t, "2", page.NextPageToken)
+
+		page, err = PageResources(resources, ListResourcesContext{PageSize: 2, PageToken: page.NextPageToken})
+		require.NoError(t, err)
+		assert.Equal(t, resources[2:], page.Resources)
+		assert.Empty(t, page.NextPageToken)
+	})
+
+	t.Run("invalid page token -> error", func(t *testing.T) {
+		_, err := PageResources(resources, ListResourcesContext{PageToken: "abc"})
+		require.ErrorContains(t, err, "invalid page token")
+	})
+}
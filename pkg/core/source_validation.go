@@ -0,0 +1,204 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+/*
+ * Integrations that add SourceValidationField() to their configuration
+ * have the requests to their HandleRequest() and HandleWebhook() endpoints
+ * validated by the framework, before they are handled, e.g. to only accept
+ * Pub/Sub push requests from Google, or EventBridge requests from AWS.
+ */
+const SourceValidationConfigurationKey = "sourceValidation"
+
+type SourceValidation struct {
+	AllowedCIDRs             []string         `json:"allowedCIDRs" mapstructure:"allowedCIDRs"`
+	RequiredHeaders          []RequiredHeader `json:"requiredHeaders" mapstructure:"requiredHeaders"`
+	RequireClientCertificate bool             `json:"requireClientCertificate" mapstructure:"requireClientCertificate"`
+}
+
+// RequiredHeader is a header requests must have.
+// Without a value, any value is accepted.
+type RequiredHeader struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+/*
+ * RequestSource is where a request comes from, as seen by the framework:
+ * the client IP, after the trusted proxies in front of SuperPlane,
+ * and whether the client presented a verified TLS certificate,
+ * to SuperPlane itself or to the proxy terminating TLS.
+ */
+type RequestSource struct {
+	IP                        net.IP
+	ClientCertificateVerified bool
+}
+
+func SourceValidationField() configuration.Field {
+	return configuration.Field{
+		Name:        SourceValidationConfigurationKey,
+		Label:       "Source validation",
+		Type:        configuration.FieldTypeObject,
+		Required:    false,
+		Togglable:   true,
+		Description: "Only accept events from the sources matching all the rules below",
+		TypeOptions: &configuration.TypeOptions{
+			Object: &configuration.ObjectTypeOptions{
+				Schema: []configuration.Field{
+					{
+						Name:               "allowedCIDRs",
+						Label:              "Allowed IP ranges",
+						Type:               configuration.FieldTypeList,
+						Required:           false,
+						DisallowExpression: true,
+						Description:        "IP ranges in CIDR notation, e.g. 10.0.0.0/8. Leave empty to accept any IP.",
+						TypeOptions: &configuration.TypeOptions{
+							List: &configuration.ListTypeOptions{
+								ItemLabel: "IP range",
+								ItemDefinition: &configuration.ListItemDefinition{
+									Type: configuration.FieldTypeString,
+								},
+							},
+						},
+					},
+					{
+						Name:        "requiredHeaders",
+						Label:       "Required headers",
+						Type:        configuration.FieldTypeList,
+						Required:    false,
+						Description: "Headers requests must have. Leave the value empty to accept any value.",
+						TypeOptions: &configuration.TypeOptions{
+							List: &configuration.ListTypeOptions{
+								ItemLabel: "Header",
+								ItemDefinition: &configuration.ListItemDefinition{
+									Type: configuration.FieldTypeObject,
+									Schema: []configuration.Field{
+										{
+											Name:               "name",
+											Label:              "Name",
+											Type:               configuration.FieldTypeString,
+											Required:           true,
+											DisallowExpression: true,
+										},
+										{
+											Name:     "value",
+											Label:    "Value",
+											Type:     configuration.FieldTypeString,
+											Required: false,
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:        "requireClientCertificate",
+						Label:       "Require TLS client certificate",
+						Type:        configuration.FieldTypeBool,
+						Required:    false,
+						Default:     false,
+						Description: "Only accept requests made with a verified TLS client certificate",
+					},
+				},
+			},
+		},
+	}
+}
+
+/*
+ * SourceValidationFromConfiguration returns the source validation
+ * in the configuration of an integration, or nil if it has none.
+ */
+func SourceValidationFromConfiguration(config any) (*SourceValidation, error) {
+	values, ok := config.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	value, ok := values[SourceValidationConfigurationKey]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	validation := SourceValidation{}
+	if err := mapstructure.Decode(value, &validation); err != nil {
+		return nil, fmt.Errorf("invalid source validation: %w", err)
+	}
+
+	if err := validation.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &validation, nil
+}
+
+func (v *SourceValidation) Validate() error {
+	for _, cidr := range v.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("invalid IP range %q: must be in CIDR notation, e.g. 10.0.0.0/8", cidr)
+		}
+	}
+
+	for _, header := range v.RequiredHeaders {
+		if strings.TrimSpace(header.Name) == "" {
+			return fmt.Errorf("required header name is empty")
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Check validates a request against the rules. If the request is not accepted,
+ * it returns an error, and the status code to respond with.
+ * Header values are compared exactly, header names are not case sensitive.
+ */
+func (v *SourceValidation) Check(headers http.Header, source RequestSource) (int, error) {
+	if len(v.AllowedCIDRs) > 0 && !v.allowsIP(source.IP) {
+		return http.StatusForbidden, fmt.Errorf("source IP not allowed")
+	}
+
+	for _, header := range v.RequiredHeaders {
+		value := headers.Get(strings.TrimSpace(header.Name))
+		if value == "" {
+			return http.StatusForbidden, fmt.Errorf("missing %s header", header.Name)
+		}
+
+		if header.Value != "" && value != header.Value {
+			return http.StatusForbidden, fmt.Errorf("invalid %s header", header.Name)
+		}
+	}
+
+	if v.RequireClientCertificate && !source.ClientCertificateVerified {
+		return http.StatusForbidden, fmt.Errorf("client certificate required")
+	}
+
+	return http.StatusOK, nil
+}
+
+func (v *SourceValidation) allowsIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, cidr := range v.AllowedCIDRs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			continue
+		}
+
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
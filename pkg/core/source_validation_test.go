@@ -0,0 +1,99 @@
+package core_test
+
+import (
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func TestSourceValidationFromConfiguration(t *testing.T) {
+	t.Run("no source validation", func(t *testing.T) {
+		validation, err := core.SourceValidationFromConfiguration(map[string]any{"region": "us-east-1"})
+		require.NoError(t, err)
+		assert.Nil(t, validation)
+	})
+
+	t.Run("source validation is decoded", func(t *testing.T) {
+		validation, err := core.SourceValidationFromConfiguration(map[string]any{
+			"sourceValidation": map[string]any{
+				"allowedCIDRs":             []any{"10.0.0.0/8"},
+				"requiredHeaders":          []any{map[string]any{"name": "X-Source", "value": "pubsub"}},
+				"requireClientCertificate": true,
+			},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, validation)
+		assert.Equal(t, []string{"10.0.0.0/8"}, validation.AllowedCIDRs)
+		assert.Equal(t, []core.RequiredHeader{{Name: "X-Source", Value: "pubsub"}}, validation.RequiredHeaders)
+		assert.True(t, validation.RequireClientCertificate)
+	})
+
+	t.Run("invalid IP range", func(t *testing.T) {
+		_, err := core.SourceValidationFromConfiguration(map[string]any{
+			"sourceValidation": map[string]any{"allowedCIDRs": []any{"10.0.0.1"}},
+		})
+
+		require.ErrorContains(t, err, "invalid IP range")
+	})
+}
+
+func TestSourceValidation_Check(t *testing.T) {
+	validation := core.SourceValidation{
+		AllowedCIDRs:    []string{"10.0.0.0/8", "2001:db8::/32"},
+		RequiredHeaders: []core.RequiredHeader{{Name: "X-Source", Value: "pubsub"}, {Name: "X-Token"}},
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Source", "pubsub")
+	headers.Set("X-Token", "anything")
+
+	t.Run("accepted", func(t *testing.T) {
+		for _, ip := range []string{"10.1.2.3", "2001:db8::1"} {
+			code, err := validation.Check(headers, core.RequestSource{IP: net.ParseIP(ip)})
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, code)
+		}
+	})
+
+	t.Run("IP not allowed", func(t *testing.T) {
+		code, err := validation.Check(headers, core.RequestSource{IP: net.ParseIP("192.168.1.1")})
+		require.ErrorContains(t, err, "source IP not allowed")
+		assert.Equal(t, http.StatusForbidden, code)
+
+		_, err = validation.Check(headers, core.RequestSource{})
+		require.ErrorContains(t, err, "source IP not allowed")
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		h := headers.Clone()
+		h.Del("X-Token")
+
+		code, err := validation.Check(h, core.RequestSource{IP: net.ParseIP("10.1.2.3")})
+		require.ErrorContains(t, err, "missing X-Token header")
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("wrong header value", func(t *testing.T) {
+		h := headers.Clone()
+		h.Set("X-Source", "other")
+
+		_, err := validation.Check(h, core.RequestSource{IP: net.ParseIP("10.1.2.3")})
+		require.ErrorContains(t, err, "invalid X-Source header")
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		validation := core.SourceValidation{RequireClientCertificate: true}
+
+		_, err := validation.Check(http.Header{}, core.RequestSource{IP: net.ParseIP("10.1.2.3")})
+		require.ErrorContains(t, err, "client certificate required")
+
+		code, err := validation.Check(http.Header{}, core.RequestSource{ClientCertificateVerified: true})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
@@ -0,0 +1,49 @@
+package core
+
+import "fmt"
+
+/*
+ * TestModeComponent is implemented by components that can be executed in test mode,
+ * because they do not change external systems, e.g. components that only read,
+ * or that check ExecutionContext.TestMode and skip their writes, like HTTP.
+ *
+ * In test mode, components that do not implement it are not executed,
+ * and emit their example output instead, so a canvas can be exercised end to end
+ * without creating VMs, incidents or running SSH commands. This is an explicit opt-in,
+ * so new components are not executed in test mode until they declare it.
+ * Components that write canvas values or memory, or that run other canvases,
+ * change state outside of the run, so they do not implement it either.
+ */
+type TestModeComponent interface {
+	HandlesTestMode() bool
+}
+
+func HandlesTestMode(component Component) bool {
+	c, ok := component.(TestModeComponent)
+	return ok && c.HandlesTestMode()
+}
+
+/*
+ * EmitExampleOutput finishes the execution with the example output of the component,
+ * emitted on its first output channel, in place of executing it.
+ */
+func EmitExampleOutput(ctx ExecutionContext, component Component) error {
+	example := component.ExampleOutput()
+
+	payloadType, _ := example["type"].(string)
+	if payloadType == "" {
+		payloadType = fmt.Sprintf("%s.example", component.Name())
+	}
+
+	data, ok := example["data"]
+	if !ok {
+		data = map[string]any{}
+	}
+
+	channel := DefaultOutputChannel.Name
+	if channels := component.OutputChannels(ctx.Configuration); len(channels) > 0 {
+		channel = channels[0].Name
+	}
+
+	return ctx.ExecutionState.Emit(channel, payloadType, []any{data})
+}
@@ -0,0 +1,59 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/core/coretest"
+)
+
+type exampleComponent struct {
+	core.Component
+	channels []core.OutputChannel
+	readOnly bool
+}
+
+func (c *exampleComponent) Name() string {
+	return "example"
+}
+
+func (c *exampleComponent) ExampleOutput() map[string]any {
+	return map[string]any{
+		"type": "example.finished",
+		"data": map[string]any{"id": "123"},
+	}
+}
+
+func (c *exampleComponent) OutputChannels(configuration any) []core.OutputChannel {
+	return c.channels
+}
+
+func (c *exampleComponent) HandlesTestMode() bool {
+	return c.readOnly
+}
+
+func TestEmitExampleOutput(t *testing.T) {
+	t.Run("no output channels -> emitted on the default channel", func(t *testing.T) {
+		execution := coretest.NewExecution(t).Build()
+		require.NoError(t, core.EmitExampleOutput(execution.Context, &exampleComponent{}))
+
+		assert.True(t, execution.ExecutionState.Passed)
+		assert.Equal(t, []any{map[string]any{"id": "123"}}, execution.ExecutionState.Payloads(core.DefaultOutputChannel.Name))
+	})
+
+	t.Run("output channels -> emitted on the first channel", func(t *testing.T) {
+		execution := coretest.NewExecution(t).Build()
+		component := &exampleComponent{channels: []core.OutputChannel{{Name: "passed"}, {Name: "failed"}}}
+		require.NoError(t, core.EmitExampleOutput(execution.Context, component))
+
+		assert.Equal(t, []any{map[string]any{"id": "123"}}, execution.ExecutionState.Payloads("passed"))
+		assert.Empty(t, execution.ExecutionState.Payloads("failed"))
+	})
+}
+
+func TestHandlesTestMode(t *testing.T) {
+	assert.True(t, core.HandlesTestMode(&exampleComponent{readOnly: true}))
+	assert.False(t, core.HandlesTestMode(&exampleComponent{readOnly: false}))
+}
@@ -89,6 +89,7 @@ type TriggerContext struct {
 	Requests      RequestContext
 	Events        EventContext
 	Webhook       NodeWebhookContext
+	WebhookSecret NodeWebhookSecretContext
 	Integration   IntegrationContext
 }
 
@@ -106,6 +107,7 @@ type TriggerActionContext struct {
 	Requests      RequestContext
 	Events        EventContext
 	Webhook       NodeWebhookContext
+	WebhookSecret NodeWebhookSecretContext
 	Integration   IntegrationContext
 }
 
@@ -118,6 +120,7 @@ type WebhookRequestContext struct {
 	Metadata      MetadataContext
 	Logger        *log.Entry
 	Webhook       NodeWebhookContext
+	WebhookSecret NodeWebhookSecretContext
 	Events        EventContext
 	Integration   IntegrationContext
 
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+ * Severities for configuration validation results.
+ * Errors prevent the node from running, warnings are only displayed.
+ */
+const (
+	ValidationSeverityError   = "error"
+	ValidationSeverityWarning = "warning"
+)
+
+/*
+ * ValidationResult describes a problem with a configuration field.
+ * Field is the path to the field, e.g. "zone" or "labels[0].key",
+ * and is empty for problems that are not tied to a single field.
+ */
+type ValidationResult struct {
+	Field    string
+	Message  string
+	Severity string
+}
+
+func (r ValidationResult) String() string {
+	if r.Field == "" {
+		return r.Message
+	}
+
+	return fmt.Sprintf("field '%s': %s", r.Field, r.Message)
+}
+
+/*
+ * ConfigurationValidator is implemented by components that validate
+ * their configuration beyond what the configuration fields describe.
+ *
+ * Validate() is called when the canvas is saved, before Setup(),
+ * so values can still contain expressions that are not resolved yet.
+ */
+type ConfigurationValidator interface {
+	Validate(configuration any) []ValidationResult
+}
+
+func FieldError(field, message string) ValidationResult {
+	return ValidationResult{Field: field, Message: message, Severity: ValidationSeverityError}
+}
+
+func FieldWarning(field, message string) ValidationResult {
+	return ValidationResult{Field: field, Message: message, Severity: ValidationSeverityWarning}
+}
+
+/*
+ * ValidationError returns an error with all the error results,
+ * or nil if there are none. Warnings are ignored.
+ */
+func ValidationError(results []ValidationResult) error {
+	messages := []string{}
+	for _, result := range results {
+		if result.Severity == ValidationSeverityWarning {
+			continue
+		}
+
+		messages = append(messages, result.String())
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
+
+/*
+ * ValidationWarnings returns the warning results as a single message,
+ * or an empty string if there are none.
+ */
+func ValidationWarnings(results []ValidationResult) string {
+	messages := []string{}
+	for _, result := range results {
+		if result.Severity != ValidationSeverityWarning {
+			continue
+		}
+
+		messages = append(messages, result.String())
+	}
+
+	return strings.Join(messages, "; ")
+}
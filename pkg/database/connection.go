@@ -102,6 +102,7 @@ func TruncateTables() error {
 			app_installation_secrets,
 			app_installation_requests,
 			app_installation_subscriptions,
+			audit_log_entries,
 			casbin_rule,
 			role_metadata,
 			group_metadata,
@@ -109,10 +110,15 @@ func TruncateTables() error {
 			workflows,
 			workflow_nodes,
 			workflow_events,
+			workflow_node_event_identities,
+			workflow_node_execution_checkpoints,
+			workflow_node_execution_costs,
 			workflow_node_execution_kvs,
+			workflow_node_execution_logs,
 			workflow_node_executions,
 			workflow_node_queue_items,
 			workflow_node_requests,
+			webhook_deliveries,
 			webhooks
 		restart identity cascade;
 	`).Error
@@ -0,0 +1,248 @@
+package expressions
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/google/uuid"
+)
+
+var namedTimeLayouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC822":      time.RFC822,
+	"DateOnly":    time.DateOnly,
+	"DateTime":    time.DateTime,
+	"TimeOnly":    time.TimeOnly,
+}
+
+/*
+ * Functions returns the function library available in every expression,
+ * on top of the expr built-in functions, like now(), toBase64() or fromJSON().
+ *
+ *   jsonpath(value, path)                     value at a JSONPath, e.g. "$.items[0].name", or nil
+ *   base64Encode(value)                       standard base64 encoding
+ *   base64Decode(value)                       accepts standard and URL-safe encodings, with or without padding
+ *   regexReplace(value, pattern, replacement) replacement can use $1 for submatches
+ *   formatTime(time, layout)                  time is a time, an RFC3339 string or unix seconds,
+ *                                             layout is a Go layout, a name like "RFC3339", or "unix"
+ *   uuid()                                    random UUID
+ *   coalesce(values...)                       first value that is not nil or an empty string
+ *   toInt(value)                              integer from a number or a numeric string
+ */
+func Functions() []expr.Option {
+	return []expr.Option{
+		expr.Function("jsonpath", jsonPath),
+		expr.Function("base64Encode", base64Encode),
+		expr.Function("base64Decode", base64Decode),
+		expr.Function("regexReplace", regexReplace),
+		expr.Function("formatTime", formatTime),
+		expr.Function("uuid", newUUID),
+		expr.Function("coalesce", coalesce),
+		expr.Function("toInt", toInt),
+	}
+}
+
+func jsonPath(params ...any) (any, error) {
+	if len(params) != 2 {
+		return nil, fmt.Errorf("jsonpath() takes a value and a path")
+	}
+
+	path, ok := params[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("jsonpath() path must be a string")
+	}
+
+	value, _, err := EvaluateJSONPath(params[0], path)
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath(): %w", err)
+	}
+
+	return value, nil
+}
+
+func base64Encode(params ...any) (any, error) {
+	if len(params) != 1 {
+		return nil, fmt.Errorf("base64Encode() takes one argument")
+	}
+
+	value, ok := params[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("base64Encode() argument must be a string")
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(value)), nil
+}
+
+func base64Decode(params ...any) (any, error) {
+	if len(params) != 1 {
+		return nil, fmt.Errorf("base64Decode() takes one argument")
+	}
+
+	value, ok := params[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("base64Decode() argument must be a string")
+	}
+
+	value = strings.TrimRight(strings.TrimSpace(value), "=")
+	encoding := base64.RawStdEncoding
+	if strings.ContainsAny(value, "-_") {
+		encoding = base64.RawURLEncoding
+	}
+
+	decoded, err := encoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("base64Decode(): %w", err)
+	}
+
+	return string(decoded), nil
+}
+
+func regexReplace(params ...any) (any, error) {
+	if len(params) != 3 {
+		return nil, fmt.Errorf("regexReplace() takes a value, a pattern and a replacement")
+	}
+
+	value, ok := params[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("regexReplace() value must be a string")
+	}
+
+	pattern, ok := params[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("regexReplace() pattern must be a string")
+	}
+
+	replacement, ok := params[2].(string)
+	if !ok {
+		return nil, fmt.Errorf("regexReplace() replacement must be a string")
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("regexReplace(): invalid pattern: %w", err)
+	}
+
+	return re.ReplaceAllString(value, replacement), nil
+}
+
+func formatTime(params ...any) (any, error) {
+	if len(params) != 2 {
+		return nil, fmt.Errorf("formatTime() takes a time and a layout")
+	}
+
+	t, err := parseTime(params[0])
+	if err != nil {
+		return nil, fmt.Errorf("formatTime(): %w", err)
+	}
+
+	layout, ok := params[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("formatTime() layout must be a string")
+	}
+
+	if layout == "unix" {
+		return int(t.Unix()), nil
+	}
+
+	if named, ok := namedTimeLayouts[layout]; ok {
+		layout = named
+	}
+
+	return t.Format(layout), nil
+}
+
+func parseTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(v))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q is not an RFC3339 time", v)
+		}
+
+		return t, nil
+	case int, int32, int64, float32, float64:
+		seconds, err := toInt(v)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return time.Unix(int64(seconds.(int)), 0).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported time value %v", value)
+	}
+}
+
+func newUUID(params ...any) (any, error) {
+	if len(params) != 0 {
+		return nil, fmt.Errorf("uuid() takes no arguments")
+	}
+
+	return uuid.NewString(), nil
+}
+
+func coalesce(params ...any) (any, error) {
+	for _, param := range params {
+		if param == nil {
+			continue
+		}
+
+		if s, ok := param.(string); ok && s == "" {
+			continue
+		}
+
+		return param, nil
+	}
+
+	return nil, nil
+}
+
+func toInt(params ...any) (any, error) {
+	if len(params) != 1 {
+		return nil, fmt.Errorf("toInt() takes one argument")
+	}
+
+	switch v := params[0].(type) {
+	case int:
+		return v, nil
+	case int32:
+		return int(v), nil
+	case int64:
+		return int(v), nil
+	case float32:
+		return floatToInt(float64(v))
+	case float64:
+		return floatToInt(v)
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.Atoi(s); err == nil {
+			return i, nil
+		}
+
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("toInt(): %q is not a number", v)
+		}
+
+		return floatToInt(f)
+	default:
+		return nil, fmt.Errorf("toInt(): unsupported value %v", params[0])
+	}
+}
+
+func floatToInt(f float64) (any, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f > math.MaxInt64 || f < math.MinInt64 {
+		return nil, fmt.Errorf("toInt(): %v is out of range", f)
+	}
+
+	return int(math.Trunc(f)), nil
+}
@@ -0,0 +1,94 @@
+package expressions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(t *testing.T, expression string, env map[string]any) (any, error) {
+	t.Helper()
+
+	options := append([]expr.Option{expr.Env(env), expr.AsAny()}, Functions()...)
+	vm, err := expr.Compile(expression, options...)
+	require.NoError(t, err)
+
+	return expr.Run(vm, env)
+}
+
+func TestFunctions(t *testing.T) {
+	env := map[string]any{
+		"data": map[string]any{
+			"items":     []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}},
+			"count":     "42",
+			"createdAt": "2026-03-01T10:30:00Z",
+			"empty":     "",
+		},
+	}
+
+	tests := []struct {
+		expression string
+		expected   any
+	}{
+		{expression: `jsonpath(data, "$.items[-1].name")`, expected: "b"},
+		{expression: `jsonpath(data, "$.items[*].name")`, expected: []any{"a", "b"}},
+		{expression: `jsonpath(data, "$.missing")`, expected: nil},
+		{expression: `base64Encode("hello?")`, expected: "aGVsbG8/"},
+		{expression: `base64Decode("aGVsbG8/")`, expected: "hello?"},
+		{expression: `base64Decode("aGVsbG8_")`, expected: "hello?"},
+		{expression: `base64Decode("aGk=")`, expected: "hi"},
+		{expression: `regexReplace("release-1.2.3", "^release-(\\d+)\\..*$", "v$1")`, expected: "v1"},
+		{expression: `formatTime(data.createdAt, "DateOnly")`, expected: "2026-03-01"},
+		{expression: `formatTime(data.createdAt, "15:04")`, expected: "10:30"},
+		{expression: `formatTime(data.createdAt, "unix")`, expected: 1772361000},
+		{expression: `formatTime(1772361000, "RFC3339")`, expected: "2026-03-01T10:30:00Z"},
+		{expression: `coalesce(data.missing, data.empty, "fallback")`, expected: "fallback"},
+		{expression: `coalesce(data.missing)`, expected: nil},
+		{expression: `toInt(data.count) + 1`, expected: 43},
+		{expression: `toInt("3.9")`, expected: 3},
+		{expression: `toInt(7.0)`, expected: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			output, err := run(t, tt.expression, env)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestFunctions__UUID(t *testing.T) {
+	output, err := run(t, `uuid()`, map[string]any{})
+	require.NoError(t, err)
+
+	_, err = uuid.Parse(output.(string))
+	assert.NoError(t, err)
+}
+
+func TestFunctions__FormatTimeWithNow(t *testing.T) {
+	output, err := run(t, `formatTime(now(), "DateOnly")`, map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().Format(time.DateOnly), output)
+}
+
+func TestFunctions__Errors(t *testing.T) {
+	for _, expression := range []string{
+		`jsonpath({}, "items")`,
+		`base64Decode("not base64!")`,
+		`regexReplace("a", "(", "b")`,
+		`formatTime("yesterday", "RFC3339")`,
+		`toInt("abc")`,
+		`toInt(true)`,
+		`uuid(1)`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := run(t, expression, map[string]any{})
+			assert.Error(t, err)
+		})
+	}
+}
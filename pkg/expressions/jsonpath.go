@@ -0,0 +1,172 @@
+package expressions
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegment is a single step of a JSONPath expression.
+// Wildcard segments match all fields of an object or all items of an array.
+type jsonPathSegment struct {
+	key      string
+	index    *int
+	wildcard bool
+}
+
+// parseJSONPath parses the supported subset of JSONPath:
+// $.field, $['field'], $.items[0], $.items[-1], $.items[*].name and $.*.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("path must start with $")
+	}
+
+	segments := []jsonPathSegment{}
+	rest := path[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("empty field name in %q", path)
+			}
+
+			if key == "*" {
+				segments = append(segments, jsonPathSegment{wildcard: true})
+			} else {
+				segments = append(segments, jsonPathSegment{key: key})
+			}
+
+			rest = rest[end:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated bracket in %q", path)
+			}
+
+			segment, err := parseBracketSegment(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid bracket in %q: %v", path, err)
+			}
+
+			segments = append(segments, segment)
+			rest = rest[end+1:]
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q in %q", rest[0], path)
+		}
+	}
+
+	return segments, nil
+}
+
+func parseBracketSegment(value string) (jsonPathSegment, error) {
+	value = strings.TrimSpace(value)
+	if value == "*" {
+		return jsonPathSegment{wildcard: true}, nil
+	}
+
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return jsonPathSegment{key: value[1 : len(value)-1]}, nil
+	}
+
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return jsonPathSegment{}, fmt.Errorf("%q is not a quoted field name or an array index", value)
+	}
+
+	return jsonPathSegment{index: &index}, nil
+}
+
+// ValidateJSONPath checks that the path is in the supported subset of JSONPath.
+func ValidateJSONPath(path string) error {
+	_, err := parseJSONPath(path)
+	return err
+}
+
+// EvaluateJSONPath returns the value at the given path.
+// Paths with wildcards return the list of all matches.
+// found is false when a path without wildcards does not match anything.
+func EvaluateJSONPath(data any, path string) (value any, found bool, err error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	matches := []any{data}
+	hasWildcard := false
+	for _, segment := range segments {
+		if segment.wildcard {
+			hasWildcard = true
+		}
+
+		next := []any{}
+		for _, match := range matches {
+			next = append(next, applyJSONPathSegment(match, segment)...)
+		}
+
+		matches = next
+	}
+
+	if hasWildcard {
+		return matches, true, nil
+	}
+
+	if len(matches) == 0 {
+		return nil, false, nil
+	}
+
+	return matches[0], true, nil
+}
+
+func applyJSONPathSegment(value any, segment jsonPathSegment) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		if segment.wildcard {
+			values := make([]any, 0, len(v))
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				values = append(values, v[key])
+			}
+
+			return values
+		}
+
+		if segment.index != nil {
+			return nil
+		}
+
+		if field, ok := v[segment.key]; ok {
+			return []any{field}
+		}
+
+	case []any:
+		if segment.wildcard {
+			return v
+		}
+
+		if segment.index == nil {
+			return nil
+		}
+
+		index := *segment.index
+		if index < 0 {
+			index += len(v)
+		}
+
+		if index >= 0 && index < len(v) {
+			return []any{v[index]}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package expressions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath__Evaluate(t *testing.T) {
+	data := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": 7,
+		"items": [{"name": "a", "tags": ["x"]}, {"name": "b", "tags": []}],
+		"odd key": {"value": true},
+		"labels": {"team": "platform", "env": "prod"}
+	}`), &data))
+
+	tests := []struct {
+		path     string
+		expected any
+		found    bool
+	}{
+		{path: "$", expected: data, found: true},
+		{path: "$.id", expected: float64(7), found: true},
+		{path: "$.items[1].name", expected: "b", found: true},
+		{path: "$.items[-1].name", expected: "b", found: true},
+		{path: "$['odd key'].value", expected: true, found: true},
+		{path: `$["labels"]["team"]`, expected: "platform", found: true},
+		{path: "$.items[*].name", expected: []any{"a", "b"}, found: true},
+		{path: "$.labels.*", expected: []any{"prod", "platform"}, found: true},
+		{path: "$.items[*].tags[0]", expected: []any{"x"}, found: true},
+		{path: "$.items[5]", found: false},
+		{path: "$.id.value", found: false},
+		{path: "$.missing", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			value, found, err := EvaluateJSONPath(data, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestJSONPath__InvalidPaths(t *testing.T) {
+	for _, path := range []string{"", "id", "$.", "$.items[", "$.items[abc]", "$x"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := parseJSONPath(path)
+			assert.Error(t, err)
+		})
+	}
+}
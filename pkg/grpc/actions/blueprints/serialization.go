@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/blobs"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/grpc/actions"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -107,6 +108,17 @@ func ParseBlueprint(registry *registry.Registry, organizationID string, blueprin
 		}
 	}
 
+	actions.NormalizeNodeConfigurations(registry, nodes)
+	actions.StampNodeConfigurationVersions(registry, nodes)
+
+	if err := actions.SealNodeSecrets(registry, organizationID, nodes); err != nil {
+		return nil, nil, err
+	}
+
+	if err := actions.StoreNodeFiles(registry, blobs.Default(), organizationID, nodes); err != nil {
+		return nil, nil, err
+	}
+
 	return nodes, actions.ProtoToEdges(blueprint.Edges), nil
 }
 
@@ -0,0 +1,168 @@
+package canvases
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+/*
+ * Records the nodes created, deleted, or with configuration changes,
+ * when the live nodes of a canvas are replaced by the user in the context.
+ * Nodes expanded from blueprints are not recorded, since they change with their parent node.
+ */
+func auditNodeChanges(
+	ctx context.Context,
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	canvasID uuid.UUID,
+	existingNodes []models.CanvasNode,
+	newNodes []models.Node,
+) error {
+	var userID *uuid.UUID
+	if id, ok := authentication.GetUserIdFromMetadata(ctx); ok {
+		if parsed, err := uuid.Parse(id); err == nil {
+			userID = &parsed
+		}
+	}
+
+	record := func(nodeID, action string, details map[string]any) error {
+		return models.CreateAuditLogEntryInTransaction(tx, &models.AuditLogEntry{
+			OrganizationID: organizationID,
+			UserID:         userID,
+			WorkflowID:     &canvasID,
+			NodeID:         &nodeID,
+			Action:         action,
+			Details:        datatypes.NewJSONType(details),
+		})
+	}
+
+	for _, node := range newNodes {
+		if node.Type == models.NodeTypeWidget || strings.Contains(node.ID, ":") {
+			continue
+		}
+
+		existingNode := findNode(existingNodes, node.ID)
+		if existingNode == nil {
+			if err := record(node.ID, models.AuditActionNodeCreated, map[string]any{"name": node.Name}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		fields := changedConfigurationFields(existingNode.Configuration.Data(), node.Configuration)
+		if len(fields) == 0 {
+			continue
+		}
+
+		err := record(node.ID, models.AuditActionNodeConfigurationUpdated, map[string]any{
+			"name":   node.Name,
+			"fields": fields,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, existingNode := range existingNodes {
+		if existingNode.ParentNodeID != nil {
+			continue
+		}
+
+		if slices.ContainsFunc(newNodes, func(n models.Node) bool { return n.ID == existingNode.NodeID }) {
+			continue
+		}
+
+		if err := record(existingNode.NodeID, models.AuditActionNodeDeleted, map[string]any{"name": existingNode.Name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Records a manual action invoked by a user on an execution or trigger node.
+ * The action already ran when this is called, so failing to record it is only logged.
+ */
+func auditActionInvoked(
+	auditAction string,
+	organizationID uuid.UUID,
+	userID uuid.UUID,
+	canvasID uuid.UUID,
+	nodeID string,
+	executionID *uuid.UUID,
+	actionName string,
+	actionErr error,
+) {
+	details := map[string]any{"action": actionName}
+	if actionErr != nil {
+		details["error"] = actionErr.Error()
+	}
+
+	err := models.CreateAuditLogEntry(&models.AuditLogEntry{
+		OrganizationID: organizationID,
+		UserID:         &userID,
+		WorkflowID:     &canvasID,
+		NodeID:         &nodeID,
+		ExecutionID:    executionID,
+		Action:         auditAction,
+		Details:        datatypes.NewJSONType(details),
+	})
+
+	if err != nil {
+		log.Errorf("error recording %s for node %s in canvas %s: %v", auditAction, nodeID, canvasID, err)
+	}
+}
+
+/*
+ * Returns the sorted names of the top-level configuration fields that differ.
+ * Values are compared after a JSON round trip, since the stored configuration
+ * is decoded from JSON, and the new one may still hold Go types, e.g. int instead of float64.
+ */
+func changedConfigurationFields(before, after map[string]any) []string {
+	before = normalizeConfiguration(before)
+	after = normalizeConfiguration(after)
+
+	fields := []string{}
+	for key, value := range after {
+		if !reflect.DeepEqual(before[key], value) {
+			fields = append(fields, key)
+		}
+	}
+
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			fields = append(fields, key)
+		}
+	}
+
+	slices.Sort(fields)
+	return fields
+}
+
+func normalizeConfiguration(configuration map[string]any) map[string]any {
+	normalized := map[string]any{}
+
+	data, err := json.Marshal(configuration)
+	if err != nil {
+		return configuration
+	}
+
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return configuration
+	}
+
+	return normalized
+}
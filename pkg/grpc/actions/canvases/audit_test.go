@@ -0,0 +1,27 @@
+package canvases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__ChangedConfigurationFields(t *testing.T) {
+	t.Run("same values with different Go types -> no changes", func(t *testing.T) {
+		before := map[string]any{"count": float64(3), "tags": []any{"a"}}
+		after := map[string]any{"count": 3, "tags": []string{"a"}}
+		assert.Empty(t, changedConfigurationFields(before, after))
+	})
+
+	t.Run("changed, added and removed fields are sorted", func(t *testing.T) {
+		before := map[string]any{"url": "https://a.example.com", "method": "GET", "timeout": 10}
+		after := map[string]any{"url": "https://b.example.com", "method": "GET", "headers": map[string]any{"x": "y"}}
+		assert.Equal(t, []string{"headers", "timeout", "url"}, changedConfigurationFields(before, after))
+	})
+
+	t.Run("nested changes are reported on the top-level field", func(t *testing.T) {
+		before := map[string]any{"headers": map[string]any{"x": "y"}}
+		after := map[string]any{"headers": map[string]any{"x": "z"}}
+		assert.Equal(t, []string{"headers"}, changedConfigurationFields(before, after))
+	})
+}
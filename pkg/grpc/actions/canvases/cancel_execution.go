@@ -84,6 +84,7 @@ func cancelExecutionInTransaction(tx *gorm.DB, authService authorization.Authori
 
 			logger := logging.ForExecution(execution, nil)
 			orgUUID := uuid.MustParse(organizationID)
+			logs := contexts.NewExecutionLogsContext(execution)
 			ctx := core.ExecutionContext{
 				ID:             execution.ID,
 				WorkflowID:     execution.WorkflowID.String(),
@@ -95,6 +96,7 @@ func cancelExecutionInTransaction(tx *gorm.DB, authService authorization.Authori
 				Auth:           contexts.NewAuthContext(tx, orgUUID, authService, user),
 				Notifications:  contexts.NewNotificationContext(tx, orgUUID, execution.WorkflowID),
 				CanvasMemory:   contexts.NewCanvasMemoryContext(tx, execution.WorkflowID),
+				Logs:           logs,
 			}
 
 			if node.AppInstallationID != nil {
@@ -112,6 +114,10 @@ func cancelExecutionInTransaction(tx *gorm.DB, authService authorization.Authori
 			if err := component.Cancel(ctx); err != nil {
 				log.Errorf("failed to cancel component execution %s: %v", execution.ID.String(), err)
 			}
+
+			if err := logs.Flush(); err != nil {
+				log.Errorf("failed to flush logs for execution %s: %v", execution.ID.String(), err)
+			}
 		}
 	}
 
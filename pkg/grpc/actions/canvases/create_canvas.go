@@ -128,7 +128,7 @@ func CreateCanvas(ctx context.Context, registry *registry.Registry, organization
 		return nil, err
 	}
 
-	proto, err := SerializeCanvas(&canvas, false)
+	proto, err := SerializeCanvas(registry, &canvas, false)
 	if err != nil {
 		return nil, err
 	}
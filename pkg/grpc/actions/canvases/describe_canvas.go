@@ -33,7 +33,7 @@ func DescribeCanvas(ctx context.Context, registry *registry.Registry, organizati
 		}
 	}
 
-	proto, err := SerializeCanvas(canvas, true)
+	proto, err := SerializeCanvas(registry, canvas, true)
 	if err != nil {
 		log.Errorf("failed to serialize canvas %s: %v", canvas.ID.String(), err)
 		return nil, status.Error(codes.Internal, "failed to serialize workflow")
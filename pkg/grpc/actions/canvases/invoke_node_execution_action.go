@@ -86,6 +86,7 @@ func InvokeNodeExecutionAction(
 
 	tx := database.Conn()
 	logger := logging.ForExecution(execution, nil)
+	logs := contexts.NewExecutionLogsContext(execution)
 	actionCtx := core.ActionContext{
 		Name:           actionName,
 		Parameters:     parameters,
@@ -96,6 +97,7 @@ func InvokeNodeExecutionAction(
 		Auth:           contexts.NewAuthContext(tx, orgID, authService, user),
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 		Notifications:  contexts.NewNotificationContext(tx, orgID, canvas.ID),
+		Logs:           logs,
 	}
 
 	if node.AppInstallationID != nil {
@@ -107,10 +109,26 @@ func InvokeNodeExecutionAction(
 
 		logger = logging.WithIntegration(logger, *integration)
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, integration, encryptor, registry, onNewEvents)
+		actionCtx.HTTP = registry.HTTPContext().ForIntegration(integration.ID.String())
 	}
 
 	actionCtx.Logger = logger
 	err = component.HandleAction(actionCtx)
+	if flushErr := logs.Flush(); flushErr != nil {
+		logger.Errorf("error flushing execution logs: %v", flushErr)
+	}
+
+	auditActionInvoked(
+		models.AuditActionExecutionActionInvoked,
+		orgID,
+		user.ID,
+		canvas.ID,
+		execution.NodeID,
+		&execution.ID,
+		actionName,
+		err,
+	)
+
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "action execution failed: %v", err)
 	}
@@ -72,7 +72,7 @@ func InvokeNodeTriggerAction(
 		return nil, status.Errorf(codes.InvalidArgument, "action parameter validation failed: %v", err)
 	}
 
-	_, err = models.FindActiveUserByID(orgID.String(), userID)
+	user, err := models.FindActiveUserByID(orgID.String(), userID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
 	}
@@ -88,6 +88,7 @@ func InvokeNodeTriggerAction(
 		Metadata:      contexts.NewNodeMetadataContext(tx, node),
 		Requests:      contexts.NewNodeRequestContext(tx, node),
 		Webhook:       contexts.NewNodeWebhookContext(ctx, tx, encryptor, node, webhookBaseURL),
+		WebhookSecret: contexts.NewNodeWebhookSecretContext(ctx, tx, encryptor, node),
 	}
 
 	newEvents := []models.CanvasEvent{}
@@ -108,6 +109,7 @@ func InvokeNodeTriggerAction(
 
 	actionCtx.Logger = logger
 	result, err := trigger.HandleAction(actionCtx)
+	auditActionInvoked(models.AuditActionTriggerActionInvoked, orgID, user.ID, canvas.ID, node.NodeID, nil, actionName, err)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "action execution failed: %v", err)
 	}
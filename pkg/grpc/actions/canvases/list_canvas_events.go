@@ -30,7 +30,7 @@ func ListCanvasEvents(ctx context.Context, registry *registry.Registry, canvasID
 		return nil, err
 	}
 
-	serialized, err := SerializeCanvasEventsWithExecutions(events, executionsByEventID, childExecutionsByEventID)
+	serialized, err := SerializeCanvasEventsWithExecutions(registry, events, executionsByEventID, childExecutionsByEventID)
 	if err != nil {
 		return nil, err
 	}
@@ -43,11 +43,16 @@ func ListCanvasEvents(ctx context.Context, registry *registry.Registry, canvasID
 	}, nil
 }
 
-func SerializeCanvasEvents(events []models.CanvasEvent) ([]*pb.CanvasEvent, error) {
+func SerializeCanvasEvents(registry *registry.Registry, events []models.CanvasEvent) ([]*pb.CanvasEvent, error) {
+	events, err := redactEvents(registry, events)
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]*pb.CanvasEvent, 0, len(events))
 
 	for _, event := range events {
-		serializedEvent, err := SerializeCanvasEvent(event)
+		serializedEvent, err := serializeCanvasEvent(event)
 		if err != nil {
 			return nil, err
 		}
@@ -57,11 +62,16 @@ func SerializeCanvasEvents(events []models.CanvasEvent) ([]*pb.CanvasEvent, erro
 	return result, nil
 }
 
-func SerializeCanvasEventsWithExecutions(events []models.CanvasEvent, executionsByEventID map[string][]models.CanvasNodeExecution, childExecutionsByEventID map[string][]models.CanvasNodeExecution) ([]*pb.CanvasEventWithExecutions, error) {
+func SerializeCanvasEventsWithExecutions(registry *registry.Registry, events []models.CanvasEvent, executionsByEventID map[string][]models.CanvasNodeExecution, childExecutionsByEventID map[string][]models.CanvasNodeExecution) ([]*pb.CanvasEventWithExecutions, error) {
+	events, err := redactEvents(registry, events)
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]*pb.CanvasEventWithExecutions, 0, len(events))
 
 	for _, event := range events {
-		serializedEvent, err := SerializeCanvasEventWithExecutions(event, executionsByEventID[event.ID.String()], childExecutionsByEventID[event.ID.String()])
+		serializedEvent, err := serializeCanvasEventWithExecutions(registry, event, executionsByEventID[event.ID.String()], childExecutionsByEventID[event.ID.String()])
 		if err != nil {
 			return nil, err
 		}
@@ -71,7 +81,16 @@ func SerializeCanvasEventsWithExecutions(events []models.CanvasEvent, executions
 	return result, nil
 }
 
-func SerializeCanvasEvent(event models.CanvasEvent) (*pb.CanvasEvent, error) {
+func SerializeCanvasEvent(registry *registry.Registry, event models.CanvasEvent) (*pb.CanvasEvent, error) {
+	event, err := redactEvent(registry, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeCanvasEvent(event)
+}
+
+func serializeCanvasEvent(event models.CanvasEvent) (*pb.CanvasEvent, error) {
 	data, ok := event.Data.Data().(map[string]any)
 	if !ok {
 		return nil, fmt.Errorf("event data is not a map[string]any")
@@ -93,7 +112,7 @@ func SerializeCanvasEvent(event models.CanvasEvent) (*pb.CanvasEvent, error) {
 	}, nil
 }
 
-func SerializeCanvasEventWithExecutions(event models.CanvasEvent, executions []models.CanvasNodeExecution, childExecutions []models.CanvasNodeExecution) (*pb.CanvasEventWithExecutions, error) {
+func serializeCanvasEventWithExecutions(registry *registry.Registry, event models.CanvasEvent, executions []models.CanvasNodeExecution, childExecutions []models.CanvasNodeExecution) (*pb.CanvasEventWithExecutions, error) {
 	data, ok := event.Data.Data().(map[string]any)
 	if !ok {
 		return nil, fmt.Errorf("event data is not a map[string]any")
@@ -104,7 +123,7 @@ func SerializeCanvasEventWithExecutions(event models.CanvasEvent, executions []m
 		return nil, err
 	}
 
-	serializedExecutions, err := SerializeNodeExecutions(executions, childExecutions)
+	serializedExecutions, err := SerializeNodeExecutions(registry, executions, childExecutions)
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +20,7 @@ func ListCanvases(ctx context.Context, registry *registry.Registry, organization
 
 	protoCanvases := make([]*pb.Canvas, len(canvases))
 	for i, canvas := range canvases {
-		protoCanvas, err := SerializeCanvas(&canvas, false)
+		protoCanvas, err := SerializeCanvas(registry, &canvas, false)
 		if err != nil {
 			return nil, err
 		}
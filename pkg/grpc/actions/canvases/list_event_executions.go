@@ -38,7 +38,7 @@ func ListEventExecutions(ctx context.Context, registry *registry.Registry, workf
 		return nil, err
 	}
 
-	serialized, err := SerializeNodeExecutions(executions, childExecutions)
+	serialized, err := SerializeNodeExecutions(registry, executions, childExecutions)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +27,7 @@ func ListNodeEvents(ctx context.Context, registry *registry.Registry, workflowID
 		return nil, err
 	}
 
-	serialized, err := SerializeCanvasEvents(events)
+	serialized, err := SerializeCanvasEvents(registry, events)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +61,7 @@ func ListNodeExecutions(ctx context.Context, registry *registry.Registry, workfl
 		return nil, err
 	}
 
-	serialized, err := SerializeNodeExecutionsForSingleNode(workflowNode, executions)
+	serialized, err := SerializeNodeExecutionsForSingleNode(registry, workflowNode, executions)
 	if err != nil {
 		return nil, err
 	}
@@ -74,9 +74,9 @@ func ListNodeExecutions(ctx context.Context, registry *registry.Registry, workfl
 	}, nil
 }
 
-func SerializeNodeExecutionsForSingleNode(node *models.CanvasNode, executions []models.CanvasNodeExecution) ([]*pb.CanvasNodeExecution, error) {
+func SerializeNodeExecutionsForSingleNode(registry *registry.Registry, node *models.CanvasNode, executions []models.CanvasNodeExecution) ([]*pb.CanvasNodeExecution, error) {
 	if node.Type != models.NodeTypeBlueprint {
-		return SerializeNodeExecutions(executions, []models.CanvasNodeExecution{})
+		return SerializeNodeExecutions(registry, executions, []models.CanvasNodeExecution{})
 	}
 
 	childExecutions, err := models.FindChildExecutionsForMultiple(executionIDs(executions))
@@ -84,10 +84,10 @@ func SerializeNodeExecutionsForSingleNode(node *models.CanvasNode, executions []
 		return nil, err
 	}
 
-	return SerializeNodeExecutions(executions, childExecutions)
+	return SerializeNodeExecutions(registry, executions, childExecutions)
 }
 
-func SerializeNodeExecutions(executions []models.CanvasNodeExecution, childExecutions []models.CanvasNodeExecution) ([]*pb.CanvasNodeExecution, error) {
+func SerializeNodeExecutions(registry *registry.Registry, executions []models.CanvasNodeExecution, childExecutions []models.CanvasNodeExecution) ([]*pb.CanvasNodeExecution, error) {
 	var rootEvents, inputEvents, outputEvents []models.CanvasEvent
 	var rootEventsErr, inputEventsErr, outputEventsErr error
 	var cancelledByUsers []models.User
@@ -146,6 +146,19 @@ func SerializeNodeExecutions(executions []models.CanvasNodeExecution, childExecu
 		return nil, fmt.Errorf("error finding cancelled-by users: %v", cancelledByUsersErr)
 	}
 
+	rootEvents, err := redactEvents(registry, rootEvents)
+	if err != nil {
+		return nil, fmt.Errorf("error redacting root events: %v", err)
+	}
+	inputEvents, err = redactEvents(registry, inputEvents)
+	if err != nil {
+		return nil, fmt.Errorf("error redacting input events: %v", err)
+	}
+	outputEvents, err = redactEvents(registry, outputEvents)
+	if err != nil {
+		return nil, fmt.Errorf("error redacting output events: %v", err)
+	}
+
 	cancelledByUsersByID := make(map[uuid.UUID]models.User, len(cancelledByUsers))
 	for _, user := range cancelledByUsers {
 		cancelledByUsersByID[user.ID] = user
@@ -208,7 +221,7 @@ func SerializeNodeExecutions(executions []models.CanvasNodeExecution, childExecu
 		}
 
 		children := filterChildrenForParent(execution.ID, childExecutions)
-		childExecutions, err := SerializeNodeExecutions(children, []models.CanvasNodeExecution{})
+		childExecutions, err := SerializeNodeExecutions(registry, children, []models.CanvasNodeExecution{})
 		if err != nil {
 			return nil, err
 		}
@@ -319,7 +332,7 @@ func NodeExecutionResultReasonToProto(reason string) pb.CanvasNodeExecution_Resu
 	switch reason {
 	case models.CanvasNodeExecutionResultReasonOk:
 		return pb.CanvasNodeExecution_RESULT_REASON_OK
-	case models.CanvasNodeExecutionResultReasonError:
+	case models.CanvasNodeExecutionResultReasonError, models.CanvasNodeExecutionResultReasonTimeout:
 		return pb.CanvasNodeExecution_RESULT_REASON_ERROR
 	case models.CanvasNodeExecutionResultReasonErrorResolved:
 		return pb.CanvasNodeExecution_RESULT_REASON_ERROR_RESOLVED
@@ -37,7 +37,7 @@ func ListNodeQueueItems(ctx context.Context, registry *registry.Registry, workfl
 		return nil, err
 	}
 
-	serialized, err := SerializeNodeQueueItems(queueItems)
+	serialized, err := SerializeNodeQueueItems(registry, queueItems)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +50,7 @@ func ListNodeQueueItems(ctx context.Context, registry *registry.Registry, workfl
 	}, nil
 }
 
-func SerializeNodeQueueItems(queueItems []models.CanvasNodeQueueItem) ([]*pb.CanvasNodeQueueItem, error) {
+func SerializeNodeQueueItems(registry *registry.Registry, queueItems []models.CanvasNodeQueueItem) ([]*pb.CanvasNodeQueueItem, error) {
 	//
 	// Fetch all input records
 	//
@@ -59,6 +59,11 @@ func SerializeNodeQueueItems(queueItems []models.CanvasNodeQueueItem) ([]*pb.Can
 		return nil, fmt.Errorf("error find input events: %v", err)
 	}
 
+	inputEvents, err = redactEvents(registry, inputEvents)
+	if err != nil {
+		return nil, fmt.Errorf("error redacting input events: %v", err)
+	}
+
 	//
 	// Combine everything into the response
 	//
@@ -78,7 +83,7 @@ func SerializeNodeQueueItems(queueItems []models.CanvasNodeQueueItem) ([]*pb.Can
 		}
 
 		if queueItem.RootEvent != nil {
-			serializedQueueItem.RootEvent, err = SerializeCanvasEvent(*queueItem.RootEvent)
+			serializedQueueItem.RootEvent, err = SerializeCanvasEvent(registry, *queueItem.RootEvent)
 			if err != nil {
 				log.Errorf("Failed to serialize workflow event: %v", err)
 				return nil, status.Error(codes.Internal, "failed to list node queue items")
@@ -0,0 +1,116 @@
+package canvases
+
+import (
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"gorm.io/datatypes"
+)
+
+/*
+ * redactEvents returns copies of the events, with the sensitive paths
+ * declared by the components and triggers of their nodes masked,
+ * see core.SensitivePayload. Events are stored as they are emitted,
+ * so only the payloads returned by the API are masked.
+ */
+func redactEvents(registry *registry.Registry, events []models.CanvasEvent) ([]models.CanvasEvent, error) {
+	if registry == nil || len(events) == 0 {
+		return events, nil
+	}
+
+	paths, err := sensitivePathsForEvents(registry, events)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := make([]models.CanvasEvent, len(events))
+	for i, event := range events {
+		redacted[i] = event
+
+		nodePaths := paths[event.WorkflowID][event.NodeID]
+		if len(nodePaths) == 0 {
+			continue
+		}
+
+		redacted[i].Data = datatypes.NewJSONType(core.RedactPayload(event.Data.Data(), nodePaths))
+	}
+
+	return redacted, nil
+}
+
+func redactEvent(registry *registry.Registry, event models.CanvasEvent) (models.CanvasEvent, error) {
+	redacted, err := redactEvents(registry, []models.CanvasEvent{event})
+	if err != nil {
+		return event, err
+	}
+
+	return redacted[0], nil
+}
+
+/*
+ * sensitivePathsForEvents finds the sensitive paths of the nodes that emitted the events.
+ * Nodes deleted since are included, since their events are still displayed.
+ */
+func sensitivePathsForEvents(registry *registry.Registry, events []models.CanvasEvent) (map[uuid.UUID]map[string][]string, error) {
+	nodeIDs := map[uuid.UUID][]string{}
+	seen := map[uuid.UUID]map[string]bool{}
+	for _, event := range events {
+		if seen[event.WorkflowID] == nil {
+			seen[event.WorkflowID] = map[string]bool{}
+		}
+
+		if seen[event.WorkflowID][event.NodeID] {
+			continue
+		}
+
+		seen[event.WorkflowID][event.NodeID] = true
+		nodeIDs[event.WorkflowID] = append(nodeIDs[event.WorkflowID], event.NodeID)
+	}
+
+	paths := map[uuid.UUID]map[string][]string{}
+	for canvasID, ids := range nodeIDs {
+		nodes, err := models.FindCanvasNodesByIDs(database.Conn().Unscoped(), canvasID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		paths[canvasID] = map[string][]string{}
+		for _, node := range nodes {
+			paths[canvasID][node.NodeID] = sensitivePathsForNode(registry, node)
+		}
+	}
+
+	return paths, nil
+}
+
+func sensitivePathsForNode(registry *registry.Registry, node models.CanvasNode) []string {
+	ref := node.Ref.Data()
+
+	var implementation any
+	switch {
+	case ref.Component != nil:
+		component, err := registry.GetComponent(ref.Component.Name)
+		if err != nil {
+			return nil
+		}
+
+		implementation = component
+
+	case ref.Trigger != nil:
+		trigger, err := registry.GetTrigger(ref.Trigger.Name)
+		if err != nil {
+			return nil
+		}
+
+		implementation = trigger
+	}
+
+	sensitive, ok := implementation.(core.SensitivePayload)
+	if !ok {
+		return nil
+	}
+
+	return sensitive.SensitivePaths()
+}
@@ -155,6 +155,10 @@ func PublishCanvasChangeRequest(
 			return findErr
 		}
 
+		if auditErr := auditNodeChanges(ctx, tx, organizationUUID, canvasUUID, existingNodes, expandedNodes); auditErr != nil {
+			return auditErr
+		}
+
 		for _, node := range expandedNodes {
 			if node.Type == models.NodeTypeWidget {
 				continue
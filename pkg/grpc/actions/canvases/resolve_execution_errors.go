@@ -56,6 +56,7 @@ func invalidErrorResolutionIDs(executions []models.CanvasNodeExecution) []string
 	invalidIDs := make([]string, 0)
 	for _, execution := range executions {
 		if execution.ResultReason == models.CanvasNodeExecutionResultReasonError ||
+			execution.ResultReason == models.CanvasNodeExecutionResultReasonTimeout ||
 			execution.ResultReason == models.CanvasNodeExecutionResultReasonErrorResolved {
 			continue
 		}
@@ -184,6 +184,7 @@ func setupTrigger(ctx context.Context, tx *gorm.DB, encryptor crypto.Encryptor,
 		Requests:      contexts.NewNodeRequestContext(tx, node),
 		Events:        contexts.NewEventContext(tx, node, nil),
 		Webhook:       contexts.NewNodeWebhookContext(ctx, tx, encryptor, node, webhookBaseURL),
+		WebhookSecret: contexts.NewNodeWebhookSecretContext(ctx, tx, encryptor, node),
 	}
 
 	if node.AppInstallationID != nil {
@@ -225,6 +226,7 @@ func setupComponent(ctx context.Context, tx *gorm.DB, encryptor crypto.Encryptor
 		Metadata:      contexts.NewNodeMetadataContext(tx, node),
 		Requests:      contexts.NewNodeRequestContext(tx, node),
 		Webhook:       contexts.NewNodeWebhookContext(ctx, tx, encryptor, node, webhookBaseURL),
+		WebhookSecret: contexts.NewNodeWebhookSecretContext(ctx, tx, encryptor, node),
 	}
 
 	if node.AppInstallationID != nil {
@@ -687,6 +687,12 @@ func buildRequestedCanvasContextData(
 				continue
 			}
 
+			events, eventsErr = redactEvents(registry, events)
+			if eventsErr != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("node_recent_outputs(%s): %s", nodeID, eventsErr.Error()))
+				continue
+			}
+
 			samples := make([]map[string]any, 0, len(events))
 			for _, event := range events {
 				row := map[string]any{
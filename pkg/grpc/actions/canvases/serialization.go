@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/blobs"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/database"
@@ -18,7 +19,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func SerializeCanvas(canvas *models.Canvas, includeStatus bool) (*pb.Canvas, error) {
+func SerializeCanvas(registry *registry.Registry, canvas *models.Canvas, includeStatus bool) (*pb.Canvas, error) {
 	liveVersion, err := models.FindLiveCanvasVersionByCanvasInTransaction(database.Conn(), canvas)
 	if err != nil {
 		return nil, err
@@ -84,7 +85,7 @@ func SerializeCanvas(canvas *models.Canvas, includeStatus bool) (*pb.Canvas, err
 		return nil, err
 	}
 
-	serializedExecutions, err := SerializeNodeExecutions(lastExecutions, childExecutions)
+	serializedExecutions, err := SerializeNodeExecutions(registry, lastExecutions, childExecutions)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +96,7 @@ func SerializeCanvas(canvas *models.Canvas, includeStatus bool) (*pb.Canvas, err
 		return nil, err
 	}
 
-	serializedQueueItems, err := SerializeNodeQueueItems(nextQueueItems)
+	serializedQueueItems, err := SerializeNodeQueueItems(registry, nextQueueItems)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +107,7 @@ func SerializeCanvas(canvas *models.Canvas, includeStatus bool) (*pb.Canvas, err
 		return nil, err
 	}
 
-	serializedEvents, err := SerializeCanvasEvents(lastEvents)
+	serializedEvents, err := SerializeCanvasEvents(registry, lastEvents)
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +241,24 @@ func ParseCanvas(registry *registry.Registry, orgID string, canvas *pb.Canvas) (
 	// Find shadowed names within connected components
 	nodeWarnings := actions.FindShadowedNameWarnings(canvas.Spec.Nodes, canvas.Spec.Edges)
 
+	// Add configuration warnings reported by components
+	for _, node := range canvas.Spec.Nodes {
+		if _, hasError := nodeValidationErrors[node.Id]; hasError {
+			continue
+		}
+
+		warning := findConfigurationWarning(registry, node)
+		if warning == "" {
+			continue
+		}
+
+		if existing, ok := nodeWarnings[node.Id]; ok {
+			nodeWarnings[node.Id] = existing + "; " + warning
+		} else {
+			nodeWarnings[node.Id] = warning
+		}
+	}
+
 	for i, edge := range canvas.Spec.Edges {
 		if edge.SourceId == "" || edge.TargetId == "" {
 			return nil, nil, status.Errorf(codes.InvalidArgument, "edge %d: source_id and target_id are required", i)
@@ -282,6 +301,17 @@ func ParseCanvas(registry *registry.Registry, orgID string, canvas *pb.Canvas) (
 		}
 	}
 
+	actions.NormalizeNodeConfigurations(registry, nodes)
+	actions.StampNodeConfigurationVersions(registry, nodes)
+
+	if err := actions.SealNodeSecrets(registry, orgID, nodes); err != nil {
+		return nil, nil, err
+	}
+
+	if err := actions.StoreNodeFiles(registry, blobs.Default(), orgID, nodes); err != nil {
+		return nil, nil, err
+	}
+
 	return nodes, actions.ProtoToEdges(canvas.Spec.Edges), nil
 }
 
@@ -301,7 +331,12 @@ func validateNodeRef(registry *registry.Registry, organizationID string, node *c
 			return err
 		}
 
-		return configuration.ValidateConfiguration(component.Configuration(), node.Configuration.AsMap())
+		err = configuration.ValidateConfiguration(component.Configuration(), node.Configuration.AsMap())
+		if err != nil {
+			return err
+		}
+
+		return core.ValidationError(validateComponentConfiguration(component, node))
 
 	case compb.Node_TYPE_BLUEPRINT:
 		if node.Blueprint == nil {
@@ -356,6 +391,28 @@ func validateNodeRef(registry *registry.Registry, organizationID string, node *c
 	}
 }
 
+func validateComponentConfiguration(component core.Component, node *compb.Node) []core.ValidationResult {
+	validator, ok := component.(core.ConfigurationValidator)
+	if !ok {
+		return nil
+	}
+
+	return validator.Validate(node.Configuration.AsMap())
+}
+
+func findConfigurationWarning(registry *registry.Registry, node *compb.Node) string {
+	if node.Type != compb.Node_TYPE_COMPONENT || node.Component == nil {
+		return ""
+	}
+
+	component, err := registry.GetComponent(node.Component.Name)
+	if err != nil {
+		return ""
+	}
+
+	return core.ValidationWarnings(validateComponentConfiguration(component, node))
+}
+
 func findAndValidateTrigger(registry *registry.Registry, organizationID string, node *compb.Node) (core.Trigger, error) {
 	parts := strings.SplitN(node.Trigger.Name, ".", 2)
 	if len(parts) > 2 {
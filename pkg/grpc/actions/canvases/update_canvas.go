@@ -112,7 +112,7 @@ func UpdateCanvas(
 		log.Errorf("failed to publish canvas updated RabbitMQ message: %v", publishErr)
 	}
 
-	serializedCanvas, serializeErr := SerializeCanvas(canvas, false)
+	serializedCanvas, serializeErr := SerializeCanvas(nil, canvas, false)
 	if serializeErr != nil {
 		log.Errorf("failed to serialize canvas %s after update: %v", canvas.ID.String(), serializeErr)
 		return nil, status.Error(codes.Internal, "failed to serialize canvas")
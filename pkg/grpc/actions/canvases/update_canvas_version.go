@@ -198,6 +198,10 @@ func updateLiveCanvasWithoutVersioning(
 			return expandErr
 		}
 
+		if auditErr := auditNodeChanges(ctx, tx, organizationUUID, canvasID, existingNodes, expandedNodes); auditErr != nil {
+			return auditErr
+		}
+
 		for _, node := range expandedNodes {
 			if node.Type == models.NodeTypeWidget {
 				continue
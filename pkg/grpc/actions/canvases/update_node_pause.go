@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/grpc/actions"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -50,7 +51,12 @@ func UpdateNodePause(ctx context.Context, registry *registry.Registry, canvasID,
 				}
 			}
 		} else if lockedNode.State == models.CanvasNodeStatePaused {
-			nextState, err := models.ResumeStateForNodeInTransaction(tx, lockedNode.WorkflowID, lockedNode.NodeID)
+			nextState, err := models.ResumeStateForNodeInTransaction(
+				tx,
+				lockedNode.WorkflowID,
+				lockedNode.NodeID,
+				core.QueueSettingsFromConfiguration(lockedNode.Configuration.Data()).MaxExecutions(),
+			)
 			if err != nil {
 				return err
 			}
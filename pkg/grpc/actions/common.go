@@ -1,10 +1,14 @@
 package actions
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"slices"
 
 	uuid "github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/blobs"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -13,6 +17,7 @@ import (
 	configpb "github.com/superplanehq/superplane/pkg/protos/configuration"
 	triggerpb "github.com/superplanehq/superplane/pkg/protos/triggers"
 	widgetpb "github.com/superplanehq/superplane/pkg/protos/widgets"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -684,6 +689,146 @@ func ProtoToConfigurationField(pbField *configpb.Field) configuration.Field {
 	return field
 }
 
+/*
+ * SealNodeSecrets encrypts the plain text values of secret
+ * configuration fields of component nodes, before they are stored.
+ */
+func SealNodeSecrets(registry *registry.Registry, organizationID string, nodes []models.Node) error {
+	for i, node := range nodes {
+		if node.Type != models.NodeTypeComponent || node.Ref.Component == nil {
+			continue
+		}
+
+		component, err := registry.GetComponent(node.Ref.Component.Name)
+		if err != nil {
+			continue
+		}
+
+		config, err := configuration.SealSecretFields(
+			context.Background(),
+			registry.Encryptor,
+			component.Configuration(),
+			node.Configuration,
+			[]byte(organizationID),
+		)
+
+		if err != nil {
+			return status.Errorf(codes.Internal, "node %s: %v", node.ID, err)
+		}
+
+		nodes[i].Configuration = config
+	}
+
+	return nil
+}
+
+/*
+ * StampNodeConfigurationVersions records the configuration version of component nodes.
+ * Configurations received through the API are built from the current fields,
+ * so they are on the current version. Stored nodes on older versions are
+ * upgraded by the configuration upgrader, see core.VersionedConfiguration.
+ */
+func StampNodeConfigurationVersions(registry *registry.Registry, nodes []models.Node) {
+	for i, node := range nodes {
+		if node.Type != models.NodeTypeComponent || node.Ref.Component == nil {
+			continue
+		}
+
+		component, err := registry.GetComponent(node.Ref.Component.Name)
+		if err != nil {
+			continue
+		}
+
+		//
+		// Components that never changed their fields are not stamped,
+		// so their nodes stay the same as before versions existed.
+		//
+		version := core.ConfigurationVersion(component)
+		if version == core.InitialConfigurationVersion {
+			continue
+		}
+
+		ref := *node.Ref.Component
+		ref.ConfigurationVersion = version
+		nodes[i].Ref.Component = &ref
+	}
+}
+
+/*
+ * NormalizeNodeConfigurations stores durations and cron expressions
+ * in component and trigger configurations in their canonical form.
+ */
+func NormalizeNodeConfigurations(registry *registry.Registry, nodes []models.Node) {
+	for i, node := range nodes {
+		var fields []configuration.Field
+		switch {
+		case node.Type == models.NodeTypeComponent && node.Ref.Component != nil:
+			component, err := registry.GetComponent(node.Ref.Component.Name)
+			if err != nil {
+				continue
+			}
+
+			fields = component.Configuration()
+
+		case node.Type == models.NodeTypeTrigger && node.Ref.Trigger != nil:
+			trigger, err := registry.GetTrigger(node.Ref.Trigger.Name)
+			if err != nil {
+				continue
+			}
+
+			fields = trigger.Configuration()
+
+		default:
+			continue
+		}
+
+		nodes[i].Configuration = configuration.NormalizeConfiguration(fields, node.Configuration)
+	}
+}
+
+/*
+ * StoreNodeFiles moves the files uploaded in file fields
+ * to blob storage, and replaces them with references.
+ */
+func StoreNodeFiles(registry *registry.Registry, store blobs.Store, organizationID string, nodes []models.Node) error {
+	for i, node := range nodes {
+		if node.Type != models.NodeTypeComponent || node.Ref.Component == nil {
+			continue
+		}
+
+		component, err := registry.GetComponent(node.Ref.Component.Name)
+		if err != nil {
+			continue
+		}
+
+		config, err := configuration.StoreFileFields(
+			component.Configuration(),
+			node.Configuration,
+			func(field configuration.Field, upload *configuration.FileUpload) (*configuration.FileReference, error) {
+				key := fmt.Sprintf("%s/%s", organizationID, uuid.NewString())
+				if err := store.Put(context.Background(), key, bytes.NewReader(upload.Data)); err != nil {
+					return nil, err
+				}
+
+				return &configuration.FileReference{
+					Key:         key,
+					Name:        upload.Name,
+					ContentType: upload.ContentType,
+					Size:        int64(len(upload.Data)),
+				}, nil
+			},
+		)
+
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "node %s: %v", node.ID, err)
+		}
+
+		nodes[i].Configuration = config
+	}
+
+	return nil
+}
+
 func ProtoToNodes(nodes []*componentpb.Node) []models.Node {
 	result := make([]models.Node, len(nodes))
 	for i, node := range nodes {
@@ -2,6 +2,9 @@ package organizations
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"strconv"
 
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -13,9 +16,10 @@ import (
 	"github.com/superplanehq/superplane/pkg/workers/contexts"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
-func ListIntegrationResources(ctx context.Context, registry *registry.Registry, orgID string, integrationID string, parameters map[string]string) (*pb.ListIntegrationResourcesResponse, error) {
+func ListIntegrationResources(ctx context.Context, registry *registry.Registry, orgID string, integrationID string, parameters map[string]string, pageToken string, pageSize int32) (*pb.ListIntegrationResourcesResponse, error) {
 	org, err := uuid.Parse(orgID)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
@@ -50,36 +54,100 @@ func ListIntegrationResources(ctx context.Context, registry *registry.Registry,
 		nil,
 	)
 
-	listCtx := core.ListResourcesContext{
+	listCtx, err := core.NewListResourcesContext(core.ListResourcesContext{
 		Logger: log.WithFields(log.Fields{
 			"integration_id":   instance.ID.String(),
 			"integration_name": instance.AppName,
 			"resource_type":    resourceType,
 		}),
-		HTTP:        registry.HTTPContext(),
+		HTTP:        registry.HTTPContext().ForIntegration(instance.ID.String()),
 		Integration: integrationCtx,
-		Parameters:  parameters,
+		Parameters:  withPageParameters(parameters, pageToken, pageSize),
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	resources, err := integration.ListResources(resourceType, listCtx)
+	page, err := listResourcePage(integration, resourceType, listCtx)
 	if err != nil {
 		log.WithError(err).Errorf("failed to list resources for integration %s", instance.ID)
 		return nil, status.Error(codes.Internal, "failed to list integration resources")
 	}
 
+	resources, err := serializeIntegrationResources(page.Resources)
+	if err != nil {
+		log.WithError(err).Errorf("failed to serialize resources for integration %s", instance.ID)
+		return nil, status.Error(codes.Internal, "failed to list integration resources")
+	}
+
 	return &pb.ListIntegrationResourcesResponse{
-		Resources: serializeIntegrationResources(resources),
+		Resources:     resources,
+		NextPageToken: page.NextPageToken,
 	}, nil
 }
 
-func serializeIntegrationResources(resources []core.IntegrationResource) []*pb.IntegrationResourceRef {
+/*
+ * The page token and size can be sent as request fields,
+ * or as query parameters, which is what the UI does.
+ * The request fields take precedence.
+ */
+func withPageParameters(parameters map[string]string, pageToken string, pageSize int32) map[string]string {
+	if pageToken == "" && pageSize == 0 {
+		return parameters
+	}
+
+	merged := make(map[string]string, len(parameters)+2)
+	maps.Copy(merged, parameters)
+
+	if pageToken != "" {
+		merged[core.ResourcePageTokenParameter] = pageToken
+	}
+
+	if pageSize != 0 {
+		merged[core.ResourcePageSizeParameter] = strconv.Itoa(int(pageSize))
+	}
+
+	return merged
+}
+
+func listResourcePage(integration core.Integration, resourceType string, ctx core.ListResourcesContext) (*core.ResourcePage, error) {
+	if lister, ok := integration.(core.ResourcePageLister); ok {
+		return lister.ListResourcePage(resourceType, ctx)
+	}
+
+	resources, err := integration.ListResources(resourceType, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.PageResources(resources, ctx)
+}
+
+func serializeIntegrationResources(resources []core.IntegrationResource) ([]*pb.IntegrationResourceRef, error) {
 	out := make([]*pb.IntegrationResourceRef, 0, len(resources))
 	for _, resource := range resources {
-		out = append(out, &pb.IntegrationResourceRef{
-			Type: resource.Type,
-			Name: resource.Name,
-			Id:   resource.ID,
-		})
+		ref := &pb.IntegrationResourceRef{
+			Type:        resource.Type,
+			Name:        resource.Name,
+			Id:          resource.ID,
+			Description: resource.Description,
+			Group:       resource.Group,
+			Icon:        resource.Icon,
+			Disabled:    resource.Disabled,
+		}
+
+		if len(resource.Metadata) > 0 {
+			metadata, err := structpb.NewStruct(resource.Metadata)
+			if err != nil {
+				return nil, fmt.Errorf("invalid metadata for resource %s: %w", resource.ID, err)
+			}
+
+			ref.Metadata = metadata
+		}
+
+		out = append(out, ref)
 	}
-	return out
+
+	return out, nil
 }
@@ -0,0 +1,57 @@
+package organizations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__ListIntegrationResources__PageParameters(t *testing.T) {
+	t.Run("no page fields -> parameters are unchanged", func(t *testing.T) {
+		parameters := map[string]string{"type": "repository", core.ResourcePageTokenParameter: "abc"}
+		assert.Equal(t, parameters, withPageParameters(parameters, "", 0))
+	})
+
+	t.Run("page fields take precedence over parameters", func(t *testing.T) {
+		parameters := map[string]string{"type": "repository", core.ResourcePageTokenParameter: "abc"}
+		merged := withPageParameters(parameters, "def", 50)
+
+		assert.Equal(t, map[string]string{
+			"type":                          "repository",
+			core.ResourcePageTokenParameter: "def",
+			core.ResourcePageSizeParameter:  "50",
+		}, merged)
+
+		assert.Equal(t, "abc", parameters[core.ResourcePageTokenParameter])
+	})
+}
+
+func Test__ListIntegrationResources__SerializeResources(t *testing.T) {
+	resources, err := serializeIntegrationResources([]core.IntegrationResource{
+		{
+			Type:        "machineType",
+			Name:        "e2-medium",
+			ID:          "e2-medium",
+			Description: "2 vCPU, 4 GB memory",
+			Group:       "e2",
+			Icon:        "cpu",
+			Metadata:    map[string]any{"guestCpus": 2, "sharedCpu": true},
+		},
+		{Type: "machineType", Name: "c3-standard-4", ID: "c3-standard-4", Disabled: true},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	assert.Equal(t, "e2-medium", resources[0].Name)
+	assert.Equal(t, "2 vCPU, 4 GB memory", resources[0].Description)
+	assert.Equal(t, "e2", resources[0].Group)
+	assert.Equal(t, "cpu", resources[0].Icon)
+	assert.False(t, resources[0].Disabled)
+	assert.Equal(t, map[string]any{"guestCpus": float64(2), "sharedCpu": true}, resources[0].Metadata.AsMap())
+
+	assert.True(t, resources[1].Disabled)
+	assert.Nil(t, resources[1].Metadata)
+}
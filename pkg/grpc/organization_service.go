@@ -157,7 +157,7 @@ func (s *OrganizationService) DescribeIntegration(ctx context.Context, req *pb.D
 
 func (s *OrganizationService) ListIntegrationResources(ctx context.Context, req *pb.ListIntegrationResourcesRequest) (*pb.ListIntegrationResourcesResponse, error) {
 	orgID := ctx.Value(authorization.DomainIdContextKey).(string)
-	return organizations.ListIntegrationResources(ctx, s.registry, orgID, req.IntegrationId, req.Parameters)
+	return organizations.ListIntegrationResources(ctx, s.registry, orgID, req.IntegrationId, req.Parameters, req.PageToken, req.PageSize)
 }
 
 func (s *OrganizationService) CreateIntegration(ctx context.Context, req *pb.CreateIntegrationRequest) (*pb.CreateIntegrationResponse, error) {
@@ -23,10 +23,14 @@ import (
 	"github.com/superplanehq/superplane/pkg/integrations/aws/ec2"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/ecr"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/ecs"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/eks"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/elb"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/eventbridge"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/iam"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/lambda"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/rds"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/route53"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/secretsmanager"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/sns"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/sqs"
 	"github.com/superplanehq/superplane/pkg/registry"
@@ -132,6 +136,7 @@ func (a *AWS) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.SourceValidationField(),
 	}
 }
 
@@ -144,6 +149,7 @@ func (a *AWS) Components() []core.Component {
 		&codeartifact.DisposePackageVersions{},
 		&codeartifact.GetPackageVersion{},
 		&codeartifact.UpdatePackageVersionsStatus{},
+		&codepipeline.ApprovalGate{},
 		&codepipeline.GetPipeline{},
 		&codepipeline.GetPipelineExecution{},
 		&codepipeline.RetryStageExecution{},
@@ -155,13 +161,20 @@ func (a *AWS) Components() []core.Component {
 		&ecs.StopTask{},
 		&ecs.UpdateService{},
 		&ec2.CopyImage{},
+		&ec2.CopySnapshot{},
 		&ec2.CreateImage{},
+		&ec2.CreateSnapshot{},
 		&ec2.DeregisterImage{},
 		&ec2.DisableImage{},
 		&ec2.DisableImageDeprecation{},
 		&ec2.EnableImage{},
 		&ec2.EnableImageDeprecation{},
 		&ec2.GetImage{},
+		&ec2.GetSnapshot{},
+		&ec2.ShareImage{},
+		&ec2.WaitForImage{},
+		&elb.DeregisterTargets{},
+		&elb.RegisterTargets{},
 		&sns.GetTopic{},
 		&sns.GetSubscription{},
 		&sns.CreateTopic{},
@@ -170,6 +183,14 @@ func (a *AWS) Components() []core.Component {
 		&ecr.GetImage{},
 		&ecr.GetImageScanFindings{},
 		&ecr.ScanImage{},
+		&ecr.TagImage{},
+		&ecr.WaitForScanFindings{},
+		&eks.GetClusterCredentials{},
+		&rds.CreateDBSnapshot{},
+		&rds.ModifyDBInstance{},
+		&rds.RestoreDBInstanceFromSnapshot{},
+		&secretsmanager.GetSecretValue{},
+		&secretsmanager.PutSecretValue{},
 		&lambda.RunFunction{},
 		&sqs.SendMessage{},
 		&sqs.GetQueue{},
@@ -200,6 +221,10 @@ func (a *AWS) Sync(ctx core.SyncContext) error {
 		return fmt.Errorf("failed to decode configuration: %v", err)
 	}
 
+	if _, err := core.SourceValidationFromConfiguration(ctx.Configuration); err != nil {
+		return err
+	}
+
 	metadata := common.IntegrationMetadata{}
 	if err := mapstructure.Decode(ctx.Integration.GetMetadata(), &metadata); err != nil {
 		return fmt.Errorf("failed to decode metadata: %v", err)
@@ -237,6 +262,33 @@ func (a *AWS) Sync(ctx core.SyncContext) error {
 	return nil
 }
 
+/*
+ * Calls STS GetCallerIdentity with the session credentials,
+ * to verify they are still accepted by AWS.
+ */
+func (a *AWS) HealthCheck(ctx core.HealthCheckContext) error {
+	metadata := common.IntegrationMetadata{}
+	if err := mapstructure.Decode(ctx.Integration.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	if metadata.Session == nil {
+		return fmt.Errorf("integration has no AWS session")
+	}
+
+	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	_, err = getCallerIdentity(ctx.Context, ctx.HTTP, metadata.Session.Region, credentials)
+	if err != nil {
+		return fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	return nil
+}
+
 func (a *AWS) Cleanup(ctx core.IntegrationCleanupContext) error {
 	metadata := common.IntegrationMetadata{}
 	if err := mapstructure.Decode(ctx.Integration.GetMetadata(), &metadata); err != nil {
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -211,6 +212,84 @@ func Test__AWS__Sync(t *testing.T) {
 	})
 }
 
+func Test__AWS__HealthCheck(t *testing.T) {
+	a := &AWS{}
+	secrets := map[string]core.IntegrationSecret{
+		"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+		"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+		"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+	}
+
+	metadata := common.IntegrationMetadata{
+		Session: &common.SessionMetadata{
+			RoleArn:   "arn:aws:iam::123456789012:role/test-role",
+			AccountID: "123456789012",
+			Region:    "us-east-1",
+		},
+	}
+
+	t.Run("no session -> error", func(t *testing.T) {
+		err := a.HealthCheck(core.HealthCheckContext{
+			Context:     context.Background(),
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        &contexts.HTTPContext{},
+			Integration: &contexts.IntegrationContext{Metadata: common.IntegrationMetadata{}, Secrets: secrets},
+		})
+
+		require.ErrorContains(t, err, "no AWS session")
+	})
+
+	t.Run("credentials accepted -> healthy", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						<GetCallerIdentityResponse>
+							<GetCallerIdentityResult>
+								<Arn>arn:aws:sts::123456789012:assumed-role/test-role/SuperPlane</Arn>
+								<Account>123456789012</Account>
+							</GetCallerIdentityResult>
+						</GetCallerIdentityResponse>
+					`)),
+				},
+			},
+		}
+
+		err := a.HealthCheck(core.HealthCheckContext{
+			Context:     context.Background(),
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: &contexts.IntegrationContext{Metadata: metadata, Secrets: secrets},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://sts.us-east-1.amazonaws.com", httpContext.Requests[0].URL.String())
+		assert.Contains(t, httpContext.Requests[0].Header.Get("Authorization"), "/us-east-1/sts/aws4_request")
+	})
+
+	t.Run("credentials rejected -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`<ErrorResponse><Error><Code>ExpiredToken</Code></Error></ErrorResponse>`)),
+				},
+			},
+		}
+
+		err := a.HealthCheck(core.HealthCheckContext{
+			Context:     context.Background(),
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: &contexts.IntegrationContext{Metadata: metadata, Secrets: secrets},
+		})
+
+		require.ErrorContains(t, err, "ExpiredToken")
+	})
+}
+
 func Test__AWS__ListResources(t *testing.T) {
 	a := &AWS{}
 
@@ -341,6 +420,175 @@ func Test__AWS__ListResources(t *testing.T) {
 		assert.Equal(t, "https://api.ecr.us-east-1.amazonaws.com/", httpContext.Requests[0].URL.String())
 	})
 
+	t.Run("ecr.image returns images by tag or digest", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						{
+							"imageIds": [
+								{"imageDigest": "sha256:abc", "imageTag": "latest"},
+								{"imageDigest": "sha256:def"}
+							]
+						}
+					`)),
+				},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{},
+			Secrets: map[string]core.IntegrationSecret{
+				"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+				"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+				"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+			},
+		}
+
+		resources, err := a.ListResources("ecr.image", core.ListResourcesContext{
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Parameters: map[string]string{
+				"region":     "us-east-1",
+				"repository": "arn:aws:ecr:us-east-1:123456789012:repository/backend",
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "ecr.image", resources[0].Type)
+		assert.Equal(t, "latest", resources[0].Name)
+		assert.Equal(t, "sha256:abc", resources[0].ID)
+		assert.Equal(t, "sha256:def", resources[1].Name)
+
+		require.Len(t, httpContext.Requests, 1)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"repositoryName": "backend", "maxResults": 1000}`, string(body))
+	})
+
+	t.Run("eks.cluster returns clusters", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"clusters": ["production", "staging"]}`)),
+				},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{},
+			Secrets: map[string]core.IntegrationSecret{
+				"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+				"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+				"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+			},
+		}
+
+		resources, err := a.ListResources("eks.cluster", core.ListResourcesContext{
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Parameters:  map[string]string{"region": "us-east-1"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "eks.cluster", resources[0].Type)
+		assert.Equal(t, "production", resources[0].Name)
+		assert.Equal(t, "staging", resources[1].ID)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://eks.us-east-1.amazonaws.com/clusters?maxResults=100", httpContext.Requests[0].URL.String())
+	})
+
+	t.Run("rds.dbSnapshot returns available snapshots", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						<DescribeDBSnapshotsResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+							<DescribeDBSnapshotsResult>
+								<DBSnapshots>
+									<DBSnapshot><DBSnapshotIdentifier>production-1</DBSnapshotIdentifier><Status>available</Status></DBSnapshot>
+									<DBSnapshot><DBSnapshotIdentifier>production-2</DBSnapshotIdentifier><Status>creating</Status></DBSnapshot>
+								</DBSnapshots>
+							</DescribeDBSnapshotsResult>
+						</DescribeDBSnapshotsResponse>
+					`)),
+				},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{},
+			Secrets: map[string]core.IntegrationSecret{
+				"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+				"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+				"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+			},
+		}
+
+		resources, err := a.ListResources("rds.dbSnapshot", core.ListResourcesContext{
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Parameters:  map[string]string{"region": "us-east-1"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "rds.dbSnapshot", resources[0].Type)
+		assert.Equal(t, "production-1", resources[0].Name)
+		assert.Equal(t, "production-1", resources[0].ID)
+	})
+
+	t.Run("secretsmanager.secret returns secrets", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						{
+							"SecretList": [
+								{
+									"ARN": "arn:aws:secretsmanager:us-east-1:123456789012:secret:production/database-AbCdEf",
+									"Name": "production/database"
+								}
+							]
+						}
+					`)),
+				},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{},
+			Secrets: map[string]core.IntegrationSecret{
+				"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+				"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+				"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+			},
+		}
+
+		resources, err := a.ListResources("secretsmanager.secret", core.ListResourcesContext{
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Parameters:  map[string]string{"region": "us-east-1"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "secretsmanager.secret", resources[0].Type)
+		assert.Equal(t, "production/database", resources[0].Name)
+		assert.Equal(t, "arn:aws:secretsmanager:us-east-1:123456789012:secret:production/database-AbCdEf", resources[0].ID)
+	})
+
 	t.Run("ecs.cluster returns clusters", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{
 			Responses: []*http.Response{
@@ -123,6 +123,7 @@ func (p *OnAlarm) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -290,7 +291,8 @@ func (p *OnAlarm) OnIntegrationMessage(ctx core.IntegrationMessageContext) error
 		}
 	}
 
-	return ctx.Events.Emit("aws.cloudwatch.alarm", ctx.Message)
+	_, err := core.EmitDeduplicated(ctx.Events, event.ID, "aws.cloudwatch.alarm", ctx.Message)
+	return err
 }
 
 func (p *OnAlarm) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
@@ -87,7 +87,7 @@ type ListPackageVersionAssetsInput struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      region,
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -106,10 +106,7 @@ type Domain struct {
 
 func (c *Client) ListDomains() ([]Domain, error) {
 	endpoint := fmt.Sprintf("https://codeartifact.%s.amazonaws.com/v1/domains", c.region)
-	domains := []Domain{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(nextToken string) ([]Domain, string, error) {
 		payload := map[string]any{
 			"maxResults": 100,
 		}
@@ -119,33 +116,33 @@ func (c *Client) ListDomains() ([]Domain, error) {
 
 		bodyBytes, err := json.Marshal(payload)
 		if err != nil {
-			return nil, fmt.Errorf("failed to encode list domains request: %w", err)
+			return nil, "", fmt.Errorf("failed to encode list domains request: %w", err)
 		}
 
 		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
 		if err != nil {
-			return nil, fmt.Errorf("failed to build list domains request: %w", err)
+			return nil, "", fmt.Errorf("failed to build list domains request: %w", err)
 		}
 
 		req.Header.Set("Content-Type", "application/json")
 
 		if err := c.signRequest(req, bodyBytes); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		res, err := c.http.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("list domains request failed: %w", err)
+			return nil, "", fmt.Errorf("list domains request failed: %w", err)
 		}
 
 		body, err := io.ReadAll(res.Body)
 		res.Body.Close()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read list domains response: %w", err)
+			return nil, "", fmt.Errorf("failed to read list domains response: %w", err)
 		}
 
 		if res.StatusCode < 200 || res.StatusCode >= 300 {
-			return nil, fmt.Errorf("list domains failed with %d: %s", res.StatusCode, string(body))
+			return nil, "", fmt.Errorf("list domains failed with %d: %s", res.StatusCode, string(body))
 		}
 
 		var response struct {
@@ -153,17 +150,11 @@ func (c *Client) ListDomains() ([]Domain, error) {
 			NextToken string   `json:"nextToken"`
 		}
 		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to decode list domains response: %w", err)
-		}
-
-		domains = append(domains, response.Domains...)
-		if strings.TrimSpace(response.NextToken) == "" {
-			break
+			return nil, "", fmt.Errorf("failed to decode list domains response: %w", err)
 		}
-		nextToken = response.NextToken
-	}
 
-	return domains, nil
+		return response.Domains, response.NextToken, nil
+	})
 }
 
 type ListRepositoriesResponse struct {
@@ -201,10 +192,7 @@ type UpstreamRepositoryInfo struct {
 
 func (c *Client) ListRepositories(domain string) ([]Repository, error) {
 	endpoint := fmt.Sprintf("https://codeartifact.%s.amazonaws.com/v1/repositories", c.region)
-	repositories := []Repository{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(nextToken string) ([]Repository, string, error) {
 		payload := map[string]any{
 			"maxResults": 100,
 		}
@@ -219,33 +207,33 @@ func (c *Client) ListRepositories(domain string) ([]Repository, error) {
 
 		bodyBytes, err := json.Marshal(payload)
 		if err != nil {
-			return nil, fmt.Errorf("failed to encode list repositories request: %w", err)
+			return nil, "", fmt.Errorf("failed to encode list repositories request: %w", err)
 		}
 
 		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
 		if err != nil {
-			return nil, fmt.Errorf("failed to build list repositories request: %w", err)
+			return nil, "", fmt.Errorf("failed to build list repositories request: %w", err)
 		}
 
 		req.Header.Set("Content-Type", "application/json")
 
 		if err := c.signRequest(req, bodyBytes); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		res, err := c.http.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("list repositories request failed: %w", err)
+			return nil, "", fmt.Errorf("list repositories request failed: %w", err)
 		}
 
 		body, err := io.ReadAll(res.Body)
 		res.Body.Close()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read list repositories response: %w", err)
+			return nil, "", fmt.Errorf("failed to read list repositories response: %w", err)
 		}
 
 		if res.StatusCode < 200 || res.StatusCode >= 300 {
-			return nil, fmt.Errorf("list repositories failed with %d: %s", res.StatusCode, string(body))
+			return nil, "", fmt.Errorf("list repositories failed with %d: %s", res.StatusCode, string(body))
 		}
 
 		var response struct {
@@ -253,17 +241,11 @@ func (c *Client) ListRepositories(domain string) ([]Repository, error) {
 			NextToken    string       `json:"nextToken"`
 		}
 		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to decode list repositories response: %w", err)
-		}
-
-		repositories = append(repositories, response.Repositories...)
-		if strings.TrimSpace(response.NextToken) == "" {
-			break
+			return nil, "", fmt.Errorf("failed to decode list repositories response: %w", err)
 		}
-		nextToken = response.NextToken
-	}
 
-	return repositories, nil
+		return response.Repositories, response.NextToken, nil
+	})
 }
 
 // CreateRepositoryInput is the input for CreateRepository.
@@ -766,13 +748,10 @@ type ListPackageVersionAssetsResponse struct {
 
 func (c *Client) ListPackageVersionAssets(input ListPackageVersionAssetsInput) ([]PackageVersionAsset, error) {
 	endpoint := fmt.Sprintf("https://codeartifact.%s.amazonaws.com/v1/package/version/assets", c.region)
-	assets := []PackageVersionAsset{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(nextToken string) ([]PackageVersionAsset, string, error) {
 		req, err := http.NewRequest(http.MethodPost, endpoint, nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build list package version assets request: %w", err)
+			return nil, "", fmt.Errorf("failed to build list package version assets request: %w", err)
 		}
 
 		query := url.Values{}
@@ -793,40 +772,34 @@ func (c *Client) ListPackageVersionAssets(input ListPackageVersionAssetsInput) (
 		req.URL.RawQuery = query.Encode()
 
 		if err := c.signRequest(req, []byte{}); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		res, err := c.http.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("list package version assets request failed: %w", err)
+			return nil, "", fmt.Errorf("list package version assets request failed: %w", err)
 		}
 
 		body, err := io.ReadAll(res.Body)
 		res.Body.Close()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read list package version assets response: %w", err)
+			return nil, "", fmt.Errorf("failed to read list package version assets response: %w", err)
 		}
 
 		if res.StatusCode < 200 || res.StatusCode >= 300 {
 			if awsErr := common.ParseError(body); awsErr != nil {
-				return nil, awsErr
+				return nil, "", awsErr
 			}
-			return nil, fmt.Errorf("list package version assets failed with %d: %s", res.StatusCode, string(body))
+			return nil, "", fmt.Errorf("list package version assets failed with %d: %s", res.StatusCode, string(body))
 		}
 
 		response := ListPackageVersionAssetsResponse{}
 		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to decode list package version assets response: %w", err)
+			return nil, "", fmt.Errorf("failed to decode list package version assets response: %w", err)
 		}
 
-		assets = append(assets, response.Assets...)
-		if strings.TrimSpace(response.NextToken) == "" {
-			break
-		}
-		nextToken = response.NextToken
-	}
-
-	return assets, nil
+		return response.Assets, response.NextToken, nil
+	})
 }
 
 func (c *Client) signRequest(req *http.Request, payload []byte) error {
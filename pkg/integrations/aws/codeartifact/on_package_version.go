@@ -157,6 +157,7 @@ func (p *OnPackageVersion) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -320,7 +321,8 @@ func (p *OnPackageVersion) OnIntegrationMessage(ctx core.IntegrationMessageConte
 		return nil
 	}
 
-	return ctx.Events.Emit("aws.codeartifact.package.version", ctx.Message)
+	_, err = core.EmitDeduplicated(ctx.Events, event.ID, "aws.codeartifact.package.version", ctx.Message)
+	return err
 }
 
 func (p *OnPackageVersion) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
@@ -0,0 +1,551 @@
+package codepipeline
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+const (
+	ApprovalPayloadType = "aws.codepipeline.approval"
+
+	ApprovedOutputChannel = "approved"
+	RejectedOutputChannel = "rejected"
+
+	ApprovalStatusApproved = "Approved"
+	ApprovalStatusRejected = "Rejected"
+
+	ApprovalGateStateWaiting  = "waiting"
+	ApprovalGateStatePending  = "pending"
+	ApprovalGateStateResolved = "resolved"
+
+	ApprovalPollInterval = time.Minute
+)
+
+type ApprovalGate struct{}
+
+type ApprovalGateSpec struct {
+	Region   string `json:"region" mapstructure:"region"`
+	Pipeline string `json:"pipeline" mapstructure:"pipeline"`
+	Stage    string `json:"stage" mapstructure:"stage"`
+	Action   string `json:"action" mapstructure:"action"`
+}
+
+type ApprovalGateExecutionMetadata struct {
+	Pipeline *PipelineMetadata `json:"pipeline" mapstructure:"pipeline"`
+	State    string            `json:"state" mapstructure:"state"`
+	Approval *PendingApproval  `json:"approval,omitempty" mapstructure:"approval,omitempty"`
+}
+
+// PendingApproval identifies a manual approval action
+// waiting for a result in a pipeline execution.
+type PendingApproval struct {
+	ExecutionID string `json:"executionId" mapstructure:"executionId"`
+	Stage       string `json:"stage" mapstructure:"stage"`
+	Action      string `json:"action" mapstructure:"action"`
+	Token       string `json:"token" mapstructure:"token"`
+}
+
+func normalizeApprovalGateSpec(spec *ApprovalGateSpec) {
+	spec.Region = strings.TrimSpace(spec.Region)
+	spec.Pipeline = strings.TrimSpace(spec.Pipeline)
+	spec.Stage = strings.TrimSpace(spec.Stage)
+	spec.Action = strings.TrimSpace(spec.Action)
+}
+
+// findPendingApproval looks for an in-progress action holding an approval token.
+// Only manual approval actions receive tokens, so no pipeline definition lookup is needed.
+func findPendingApproval(state *GetPipelineStateResponse, stage, action string) *PendingApproval {
+	for _, stageState := range state.StageStates {
+		if stage != "" && stageState.StageName != stage {
+			continue
+		}
+
+		for _, actionState := range stageState.ActionStates {
+			if action != "" && actionState.ActionName != action {
+				continue
+			}
+
+			execution := actionState.LatestExecution
+			if execution == nil || execution.Status != PipelineStatusInProgress || execution.Token == "" {
+				continue
+			}
+
+			approval := &PendingApproval{
+				Stage:  stageState.StageName,
+				Action: actionState.ActionName,
+				Token:  execution.Token,
+			}
+
+			if stageState.LatestExecution != nil {
+				approval.ExecutionID = stageState.LatestExecution.PipelineExecutionID
+			}
+
+			return approval
+		}
+	}
+
+	return nil
+}
+
+func findActionState(state *GetPipelineStateResponse, stage, action string) *ActionExecutionState {
+	for _, stageState := range state.StageStates {
+		if stageState.StageName != stage {
+			continue
+		}
+
+		for _, actionState := range stageState.ActionStates {
+			if actionState.ActionName == action {
+				return actionState.LatestExecution
+			}
+		}
+	}
+
+	return nil
+}
+
+func approvalOutputPayload(pipelineName string, approval *PendingApproval, status, summary string, user *core.User) map[string]any {
+	payload := map[string]any{
+		"pipeline": map[string]any{
+			"name":        pipelineName,
+			"executionId": approval.ExecutionID,
+			"stage":       approval.Stage,
+			"action":      approval.Action,
+		},
+		"result": map[string]any{
+			"status":  status,
+			"summary": summary,
+		},
+	}
+
+	if user != nil {
+		payload["result"].(map[string]any)["user"] = map[string]any{
+			"id":    user.ID,
+			"name":  user.Name,
+			"email": user.Email,
+		}
+	}
+
+	return payload
+}
+
+func (c *ApprovalGate) Name() string {
+	return "aws.codepipeline.approvalGate"
+}
+
+func (c *ApprovalGate) Label() string {
+	return "CodePipeline • Approval Gate"
+}
+
+func (c *ApprovalGate) Description() string {
+	return "Wait for a CodePipeline manual approval and approve or reject it from SuperPlane"
+}
+
+func (c *ApprovalGate) Documentation() string {
+	return `The Approval Gate component waits for a pending manual approval action in an AWS CodePipeline pipeline and lets users approve or reject it from SuperPlane.
+
+## Use Cases
+
+- **Deployment gates**: Use SuperPlane as the approval UI for CodePipeline manual approval actions
+- **Centralized approvals**: Review pipeline approvals next to the rest of the workflow context
+- **Automated follow-ups**: Route approved and rejected results to different downstream steps
+
+## How It Works
+
+1. Looks for an in-progress manual approval action in the pipeline, polling until one shows up
+2. Waits for a user to run the **Approve** or **Reject** action on the execution
+3. Sends the result to CodePipeline and routes the execution accordingly
+4. If the approval is resolved directly in AWS, the result is picked up on the next poll
+
+## Configuration
+
+- **Region**: AWS region where the pipeline exists
+- **Pipeline**: Pipeline containing the manual approval action
+- **Stage**: Optional stage to restrict the search to
+- **Approval Action**: Optional name of the manual approval action. If empty, the first pending approval is used
+
+## Output Channels
+
+- **Approved**: The approval was accepted
+- **Rejected**: The approval was rejected
+
+## Notes
+
+- Approval summaries are limited to 512 characters by CodePipeline`
+}
+
+func (c *ApprovalGate) Icon() string {
+	return "aws"
+}
+
+func (c *ApprovalGate) Color() string {
+	return "orange"
+}
+
+func (c *ApprovalGate) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{
+			Name:        ApprovedOutputChannel,
+			Label:       "Approved",
+			Description: "The manual approval was approved",
+		},
+		{
+			Name:        RejectedOutputChannel,
+			Label:       "Rejected",
+			Description: "The manual approval was rejected",
+		},
+	}
+}
+
+func (c *ApprovalGate) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "pipeline",
+			Label:       "Pipeline",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "CodePipeline pipeline with the manual approval action",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "codepipeline.pipeline",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+		},
+		{
+			Name:        "stage",
+			Label:       "Stage",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Only consider approvals in this stage",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "codepipeline.stage",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+						{
+							Name: "pipeline",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "pipeline",
+							},
+						},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "pipeline",
+					Values: []string{"*"},
+				},
+			},
+		},
+		{
+			Name:        "action",
+			Label:       "Approval Action",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Name of the manual approval action. Leave empty to use the first pending approval",
+		},
+	}
+}
+
+func (c *ApprovalGate) Setup(ctx core.SetupContext) error {
+	spec := ApprovalGateSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	normalizeApprovalGateSpec(&spec)
+
+	if spec.Region == "" {
+		return fmt.Errorf("region is required")
+	}
+
+	if spec.Pipeline == "" {
+		return fmt.Errorf("pipeline is required")
+	}
+
+	return nil
+}
+
+func (c *ApprovalGate) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ApprovalGate) Execute(ctx core.ExecutionContext) error {
+	spec := ApprovalGateSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	normalizeApprovalGateSpec(&spec)
+
+	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, credentials, spec.Region)
+	state, err := client.GetPipelineState(spec.Pipeline)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline state: %w", err)
+	}
+
+	metadata := ApprovalGateExecutionMetadata{
+		Pipeline: &PipelineMetadata{Name: spec.Pipeline},
+		State:    ApprovalGateStateWaiting,
+	}
+
+	approval := findPendingApproval(state, spec.Stage, spec.Action)
+	if approval != nil {
+		metadata.State = ApprovalGateStatePending
+		metadata.Approval = approval
+		ctx.Logger.Infof("Found pending approval %s/%s in pipeline %s", approval.Stage, approval.Action, spec.Pipeline)
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, ApprovalPollInterval)
+}
+
+func (c *ApprovalGate) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:           "poll",
+			UserAccessible: false,
+			Description:    "Check for pending approvals and their results",
+		},
+		{
+			Name:           "approve",
+			UserAccessible: true,
+			Description:    "Approve the pending manual approval",
+			Parameters: []configuration.Field{
+				{
+					Name:        "summary",
+					Label:       "Summary",
+					Type:        configuration.FieldTypeText,
+					Required:    false,
+					Description: "Optional summary sent to CodePipeline",
+				},
+			},
+		},
+		{
+			Name:           "reject",
+			UserAccessible: true,
+			Description:    "Reject the pending manual approval",
+			Parameters: []configuration.Field{
+				{
+					Name:        "summary",
+					Label:       "Summary",
+					Type:        configuration.FieldTypeText,
+					Required:    false,
+					Description: "Optional summary sent to CodePipeline",
+				},
+			},
+		},
+	}
+}
+
+func (c *ApprovalGate) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case "poll":
+		return c.poll(ctx)
+	case "approve":
+		return c.putResult(ctx, ApprovalStatusApproved)
+	case "reject":
+		return c.putResult(ctx, ApprovalStatusRejected)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *ApprovalGate) poll(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	spec := ApprovalGateSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	normalizeApprovalGateSpec(&spec)
+
+	metadata := ApprovalGateExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.State == ApprovalGateStateResolved {
+		return nil
+	}
+
+	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, credentials, spec.Region)
+	state, err := client.GetPipelineState(spec.Pipeline)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline state: %w", err)
+	}
+
+	if metadata.Approval == nil {
+		approval := findPendingApproval(state, spec.Stage, spec.Action)
+		if approval == nil {
+			return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, ApprovalPollInterval)
+		}
+
+		metadata.State = ApprovalGateStatePending
+		metadata.Approval = approval
+		if err := ctx.Metadata.Set(metadata); err != nil {
+			return fmt.Errorf("failed to set metadata: %w", err)
+		}
+
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, ApprovalPollInterval)
+	}
+
+	//
+	// The approval might have been resolved directly in AWS.
+	// If the action is no longer waiting on our token, we use its latest status.
+	//
+	actionState := findActionState(state, metadata.Approval.Stage, metadata.Approval.Action)
+	if actionState != nil && actionState.Status == PipelineStatusInProgress && actionState.Token == metadata.Approval.Token {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, ApprovalPollInterval)
+	}
+
+	status := ApprovalStatusRejected
+	channel := RejectedOutputChannel
+	summary := ""
+	if actionState != nil {
+		summary = actionState.Summary
+		if actionState.Status == PipelineStatusSucceeded {
+			status = ApprovalStatusApproved
+			channel = ApprovedOutputChannel
+		}
+	}
+
+	metadata.State = ApprovalGateStateResolved
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	payload := approvalOutputPayload(spec.Pipeline, metadata.Approval, status, summary, nil)
+	return ctx.ExecutionState.Emit(channel, ApprovalPayloadType, []any{payload})
+}
+
+func (c *ApprovalGate) putResult(ctx core.ActionContext, status string) error {
+	if ctx.ExecutionState.IsFinished() {
+		return fmt.Errorf("execution already finished")
+	}
+
+	spec := ApprovalGateSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	normalizeApprovalGateSpec(&spec)
+
+	metadata := ApprovalGateExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Approval == nil {
+		return fmt.Errorf("no pending approval found in pipeline %s yet", spec.Pipeline)
+	}
+
+	summary, _ := ctx.Parameters["summary"].(string)
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		summary = fmt.Sprintf("%s from SuperPlane", status)
+	}
+
+	if len(summary) > 512 {
+		return fmt.Errorf("summary must be at most 512 characters")
+	}
+
+	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, credentials, spec.Region)
+	_, err = client.PutApprovalResult(
+		spec.Pipeline,
+		metadata.Approval.Stage,
+		metadata.Approval.Action,
+		metadata.Approval.Token,
+		status,
+		summary,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to put approval result: %w", err)
+	}
+
+	metadata.State = ApprovalGateStateResolved
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	var user *core.User
+	if ctx.Auth != nil {
+		user = ctx.Auth.AuthenticatedUser()
+	}
+
+	payload := approvalOutputPayload(spec.Pipeline, metadata.Approval, status, summary, user)
+	if status == ApprovalStatusApproved {
+		return ctx.ExecutionState.Emit(ApprovedOutputChannel, ApprovalPayloadType, []any{payload})
+	}
+
+	return ctx.ExecutionState.Emit(RejectedOutputChannel, ApprovalPayloadType, []any{payload})
+}
+
+func (c *ApprovalGate) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ApprovalGate) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ApprovalGate) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,276 @@
+package codepipeline
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const pendingApprovalState = `{
+	"pipelineName": "my-pipeline",
+	"stageStates": [
+		{
+			"stageName": "Build",
+			"latestExecution": {"pipelineExecutionId": "exec-1", "status": "Succeeded"},
+			"actionStates": [{"actionName": "Compile", "latestExecution": {"status": "Succeeded"}}]
+		},
+		{
+			"stageName": "Approve",
+			"latestExecution": {"pipelineExecutionId": "exec-1", "status": "InProgress"},
+			"actionStates": [{"actionName": "ManualApproval", "latestExecution": {"status": "InProgress", "token": "token-123"}}]
+		}
+	]
+}`
+
+func Test__ApprovalGate__Setup(t *testing.T) {
+	component := &ApprovalGate{}
+
+	t.Run("missing region -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"pipeline": "my-pipeline"},
+		})
+
+		require.ErrorContains(t, err, "region is required")
+	})
+
+	t.Run("missing pipeline -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1"},
+		})
+
+		require.ErrorContains(t, err, "pipeline is required")
+	})
+
+	t.Run("valid configuration -> no error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__ApprovalGate__Execute(t *testing.T) {
+	component := &ApprovalGate{}
+
+	t.Run("pending approval -> stores token and schedules poll", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(pendingApprovalState))},
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{}
+		requestCtx := &contexts.RequestContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			Metadata:       metadataCtx,
+			Requests:       requestCtx,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Logger:         logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		metadata, ok := metadataCtx.Metadata.(ApprovalGateExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, ApprovalGateStatePending, metadata.State)
+		require.NotNil(t, metadata.Approval)
+		assert.Equal(t, "Approve", metadata.Approval.Stage)
+		assert.Equal(t, "ManualApproval", metadata.Approval.Action)
+		assert.Equal(t, "token-123", metadata.Approval.Token)
+		assert.Equal(t, "exec-1", metadata.Approval.ExecutionID)
+		assert.Equal(t, "poll", requestCtx.Action)
+		assert.Equal(t, ApprovalPollInterval, requestCtx.Duration)
+	})
+
+	t.Run("no pending approval -> waits and schedules poll", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(pendingApprovalState))},
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{}
+		requestCtx := &contexts.RequestContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":   "us-east-1",
+				"pipeline": "my-pipeline",
+				"stage":    "Build",
+			},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			Metadata:       metadataCtx,
+			Requests:       requestCtx,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Logger:         logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		metadata, ok := metadataCtx.Metadata.(ApprovalGateExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, ApprovalGateStateWaiting, metadata.State)
+		assert.Nil(t, metadata.Approval)
+		assert.Equal(t, "poll", requestCtx.Action)
+	})
+}
+
+func Test__ApprovalGate__HandleAction(t *testing.T) {
+	component := &ApprovalGate{}
+
+	pendingMetadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: ApprovalGateExecutionMetadata{
+				Pipeline: &PipelineMetadata{Name: "my-pipeline"},
+				State:    ApprovalGateStatePending,
+				Approval: &PendingApproval{
+					ExecutionID: "exec-1",
+					Stage:       "Approve",
+					Action:      "ManualApproval",
+					Token:       "token-123",
+				},
+			},
+		}
+	}
+
+	t.Run("approve -> puts approval result and emits approved", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"approvedAt": 1770734122.518}`))},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "approve",
+			Configuration:  map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			Parameters:     map[string]any{"summary": "Ship it"},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			Metadata:       pendingMetadata(),
+			ExecutionState: execState,
+			Auth:           &contexts.AuthContext{User: &core.User{ID: "user-1", Name: "Jane", Email: "jane@example.com"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, TargetPrefix+"PutApprovalResult", httpCtx.Requests[0].Header.Get("X-Amz-Target"))
+
+		body, err := io.ReadAll(httpCtx.Requests[0].Body)
+		require.NoError(t, err)
+		payload := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "token-123", payload["token"])
+		assert.Equal(t, "Approve", payload["stageName"])
+		assert.Equal(t, "ManualApproval", payload["actionName"])
+		assert.Equal(t, map[string]any{"status": "Approved", "summary": "Ship it"}, payload["result"])
+
+		assert.Equal(t, ApprovedOutputChannel, execState.Channel)
+		assert.Equal(t, ApprovalPayloadType, execState.Type)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		result := data["result"].(map[string]any)
+		assert.Equal(t, "Approved", result["status"])
+		assert.Equal(t, "jane@example.com", result["user"].(map[string]any)["email"])
+	})
+
+	t.Run("reject -> emits rejected", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "reject",
+			Configuration:  map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			Parameters:     map[string]any{},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			Metadata:       pendingMetadata(),
+			ExecutionState: execState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, RejectedOutputChannel, execState.Channel)
+	})
+
+	t.Run("approve without pending approval -> error", func(t *testing.T) {
+		err := component.HandleAction(core.ActionContext{
+			Name:          "approve",
+			Configuration: map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			Metadata: &contexts.MetadataContext{
+				Metadata: ApprovalGateExecutionMetadata{State: ApprovalGateStateWaiting},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "no pending approval found")
+	})
+
+	t.Run("poll with approval resolved in AWS -> emits result", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"stageStates": [{
+							"stageName": "Approve",
+							"actionStates": [{"actionName": "ManualApproval", "latestExecution": {"status": "Succeeded", "summary": "approved in console"}}]
+						}]
+					}`)),
+				},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "poll",
+			Configuration:  map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			Metadata:       pendingMetadata(),
+			ExecutionState: execState,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ApprovedOutputChannel, execState.Channel)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "approved in console", data["result"].(map[string]any)["summary"])
+	})
+
+	t.Run("poll with approval still pending -> schedules next poll", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(pendingApprovalState))},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requestCtx := &contexts.RequestContext{}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "poll",
+			Configuration:  map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			Metadata:       pendingMetadata(),
+			ExecutionState: execState,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Finished)
+		assert.Equal(t, "poll", requestCtx.Action)
+	})
+}
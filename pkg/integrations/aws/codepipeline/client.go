@@ -28,7 +28,7 @@ type Client struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      region,
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -64,11 +64,34 @@ type StartPipelineExecutionResponse struct {
 	PipelineExecutionID string `json:"pipelineExecutionId"`
 }
 
-func (c *Client) StartPipelineExecution(pipelineName string) (*StartPipelineExecutionResponse, error) {
+type PipelineVariable struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type SourceRevision struct {
+	ActionName    string `json:"actionName" mapstructure:"actionName"`
+	RevisionType  string `json:"revisionType" mapstructure:"revisionType"`
+	RevisionValue string `json:"revisionValue" mapstructure:"revisionValue"`
+}
+
+func (c *Client) StartPipelineExecution(pipelineName, clientRequestToken string, variables []PipelineVariable, sourceRevisions []SourceRevision) (*StartPipelineExecutionResponse, error) {
 	payload := map[string]any{
 		"name": pipelineName,
 	}
 
+	if clientRequestToken != "" {
+		payload["clientRequestToken"] = clientRequestToken
+	}
+
+	if len(variables) > 0 {
+		payload["variables"] = variables
+	}
+
+	if len(sourceRevisions) > 0 {
+		payload["sourceRevisions"] = sourceRevisions
+	}
+
 	var response StartPipelineExecutionResponse
 	if err := c.postJSON("StartPipelineExecution", payload, &response); err != nil {
 		return nil, err
@@ -150,6 +173,77 @@ func (c *Client) StopPipelineExecution(pipelineName, executionID, reason string,
 	return c.postJSON("StopPipelineExecution", payload, nil)
 }
 
+type StageExecutionState struct {
+	PipelineExecutionID string `json:"pipelineExecutionId"`
+	Status              string `json:"status"`
+}
+
+type ActionExecutionState struct {
+	Status        string              `json:"status"`
+	Summary       string              `json:"summary"`
+	Token         string              `json:"token,omitempty"`
+	LastUpdatedBy string              `json:"lastUpdatedBy,omitempty"`
+	ErrorDetails  *ActionErrorDetails `json:"errorDetails,omitempty"`
+}
+
+type ActionErrorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ActionState struct {
+	ActionName      string                `json:"actionName"`
+	LatestExecution *ActionExecutionState `json:"latestExecution,omitempty"`
+}
+
+type StageState struct {
+	StageName       string               `json:"stageName"`
+	LatestExecution *StageExecutionState `json:"latestExecution,omitempty"`
+	ActionStates    []ActionState        `json:"actionStates"`
+}
+
+type GetPipelineStateResponse struct {
+	PipelineName string       `json:"pipelineName"`
+	StageStates  []StageState `json:"stageStates"`
+}
+
+func (c *Client) GetPipelineState(pipelineName string) (*GetPipelineStateResponse, error) {
+	payload := map[string]any{
+		"name": pipelineName,
+	}
+
+	var response GetPipelineStateResponse
+	if err := c.postJSON("GetPipelineState", payload, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+type PutApprovalResultResponse struct {
+	ApprovedAt common.FloatTime `json:"approvedAt"`
+}
+
+func (c *Client) PutApprovalResult(pipelineName, stageName, actionName, token, status, summary string) (*PutApprovalResultResponse, error) {
+	payload := map[string]any{
+		"pipelineName": pipelineName,
+		"stageName":    stageName,
+		"actionName":   actionName,
+		"token":        token,
+		"result": map[string]any{
+			"status":  status,
+			"summary": summary,
+		},
+	}
+
+	var response PutApprovalResultResponse
+	if err := c.postJSON("PutApprovalResult", payload, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
 // PipelineSummary uses Name as the identifier because AWS ListPipelines
 // does not return ARN in the response.
 type PipelineSummary struct {
@@ -162,10 +256,7 @@ type ListPipelinesResponse struct {
 }
 
 func (c *Client) ListPipelines() ([]PipelineSummary, error) {
-	pipelines := []PipelineSummary{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(nextToken string) ([]PipelineSummary, string, error) {
 		payload := map[string]any{}
 		if nextToken != "" {
 			payload["nextToken"] = nextToken
@@ -173,17 +264,11 @@ func (c *Client) ListPipelines() ([]PipelineSummary, error) {
 
 		var response ListPipelinesResponse
 		if err := c.postJSON("ListPipelines", payload, &response); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
-		pipelines = append(pipelines, response.Pipelines...)
-		if response.NextToken == "" {
-			break
-		}
-		nextToken = response.NextToken
-	}
-
-	return pipelines, nil
+		return response.Pipelines, response.NextToken, nil
+	})
 }
 
 type PipelineExecutionSummary struct {
@@ -197,10 +282,7 @@ type ListPipelineExecutionsResponse struct {
 }
 
 func (c *Client) ListPipelineExecutionSummaries(pipelineName string) ([]PipelineExecutionSummary, error) {
-	summaries := []PipelineExecutionSummary{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(nextToken string) ([]PipelineExecutionSummary, string, error) {
 		payload := map[string]any{
 			"pipelineName": pipelineName,
 		}
@@ -210,17 +292,11 @@ func (c *Client) ListPipelineExecutionSummaries(pipelineName string) ([]Pipeline
 
 		var response ListPipelineExecutionsResponse
 		if err := c.postJSON("ListPipelineExecutions", payload, &response); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
-		summaries = append(summaries, response.PipelineExecutionSummaries...)
-		if response.NextToken == "" {
-			break
-		}
-		nextToken = response.NextToken
-	}
-
-	return summaries, nil
+		return response.PipelineExecutionSummaries, response.NextToken, nil
+	})
 }
 
 func (c *Client) postJSON(action string, payload any, out any) error {
@@ -76,3 +76,36 @@ func (c *RetryStageExecution) ExampleOutput() map[string]any {
 		&exampleOutputRetryStageExecution,
 	)
 }
+
+//go:embed example_output_approval_gate.json
+var exampleOutputApprovalGateBytes []byte
+
+var exampleOutputApprovalGateOnce sync.Once
+var exampleOutputApprovalGate map[string]any
+
+func (c *ApprovalGate) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputApprovalGateOnce,
+		exampleOutputApprovalGateBytes,
+		&exampleOutputApprovalGate,
+	)
+}
+
+//go:embed output_schema_run_pipeline.json
+var outputSchemaRunPipelineBytes []byte
+
+var outputSchemaRunPipelineOnce sync.Once
+var outputSchemaRunPipeline map[string]any
+
+func (r *RunPipeline) OutputSchemas() map[string]map[string]any {
+	schema := utils.UnmarshalEmbeddedJSON(
+		&outputSchemaRunPipelineOnce,
+		outputSchemaRunPipelineBytes,
+		&outputSchemaRunPipeline,
+	)
+
+	return map[string]map[string]any{
+		PassedOutputChannel: schema,
+		FailedOutputChannel: schema,
+	}
+}
@@ -16,6 +16,7 @@ import (
 const (
 	Source                                 = "aws.codepipeline"
 	DetailTypePipelineExecutionStateChange = "CodePipeline Pipeline Execution State Change"
+	DetailTypeStageExecutionStateChange    = "CodePipeline Stage Execution State Change"
 )
 
 var AllPipelineExecutionStates = []configuration.FieldOption{
@@ -121,6 +122,7 @@ func (p *OnPipeline) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -289,7 +291,8 @@ func (p *OnPipeline) OnIntegrationMessage(ctx core.IntegrationMessageContext) er
 		}
 	}
 
-	return ctx.Events.Emit("aws.codepipeline.pipeline", ctx.Message)
+	_, err := core.EmitDeduplicated(ctx.Events, event.ID, "aws.codepipeline.pipeline", ctx.Message)
+	return err
 }
 
 func (p *OnPipeline) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
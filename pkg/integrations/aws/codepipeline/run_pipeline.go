@@ -3,12 +3,15 @@ package codepipeline
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
@@ -26,21 +29,139 @@ const (
 	PipelineStatusStopped    = "Stopped"
 	PipelineStatusStopping   = "Stopping"
 
-	PollInterval = 5 * time.Minute
+	PollInterval           = 5 * time.Minute
+	MinPollIntervalSeconds = 30
+	MaxPollIntervalSeconds = 3600
+	MaxTimeoutMinutes      = 7 * 24 * 60
+
+	FailureReasonTimeout = "timeout"
+
+	PipelineVariablesField = "pipelineVariables"
+
+	RevisionTypeCommitID          = "COMMIT_ID"
+	RevisionTypeImageDigest       = "IMAGE_DIGEST"
+	RevisionTypeS3ObjectVersionID = "S3_OBJECT_VERSION_ID"
+	RevisionTypeS3ObjectKey       = "S3_OBJECT_KEY"
 )
 
+var AllRevisionTypes = []configuration.FieldOption{
+	{Label: "Commit ID", Value: RevisionTypeCommitID},
+	{Label: "Image Digest", Value: RevisionTypeImageDigest},
+	{Label: "S3 Object Version ID", Value: RevisionTypeS3ObjectVersionID},
+	{Label: "S3 Object Key", Value: RevisionTypeS3ObjectKey},
+}
+
 type RunPipeline struct{}
 
 type RunPipelineSpec struct {
-	Region   string `json:"region" mapstructure:"region"`
-	Pipeline string `json:"pipeline" mapstructure:"pipeline"`
+	Region        string `json:"region" mapstructure:"region"`
+	Pipeline      string `json:"pipeline" mapstructure:"pipeline"`
+	StageProgress bool   `json:"stageProgress" mapstructure:"stageProgress"`
+	WaitForRetry  bool   `json:"waitForRetry" mapstructure:"waitForRetry"`
+
+	PollIntervalSeconds int `json:"pollIntervalSeconds,omitempty" mapstructure:"pollIntervalSeconds,omitempty"`
+	TimeoutMinutes      int `json:"timeoutMinutes,omitempty" mapstructure:"timeoutMinutes,omitempty"`
+
+	Variables         []PipelineVariable `json:"variables,omitempty" mapstructure:"variables,omitempty"`
+	PipelineVariables map[string]any     `json:"pipelineVariables,omitempty" mapstructure:"pipelineVariables,omitempty"`
+	SourceRevisions   []SourceRevision   `json:"sourceRevisions,omitempty" mapstructure:"sourceRevisions,omitempty"`
+}
+
+// pollInterval returns the configured poll interval,
+// falling back to PollInterval when it is not set.
+func (s *RunPipelineSpec) pollInterval() time.Duration {
+	if s.PollIntervalSeconds <= 0 {
+		return PollInterval
+	}
+
+	return time.Duration(s.PollIntervalSeconds) * time.Second
+}
+
+func validateTimings(spec RunPipelineSpec) error {
+	if spec.PollIntervalSeconds != 0 && (spec.PollIntervalSeconds < MinPollIntervalSeconds || spec.PollIntervalSeconds > MaxPollIntervalSeconds) {
+		return fmt.Errorf("poll interval must be between %d and %d seconds", MinPollIntervalSeconds, MaxPollIntervalSeconds)
+	}
+
+	if spec.TimeoutMinutes < 0 || spec.TimeoutMinutes > MaxTimeoutMinutes {
+		return fmt.Errorf("timeout must be between 0 and %d minutes", MaxTimeoutMinutes)
+	}
+
+	return nil
+}
+
+func normalizeRunPipelineSpec(spec *RunPipelineSpec) {
+	variables := make([]PipelineVariable, 0, len(spec.Variables))
+	for _, variable := range spec.Variables {
+		name := strings.TrimSpace(variable.Name)
+		if name == "" {
+			continue
+		}
+
+		variables = append(variables, PipelineVariable{Name: name, Value: variable.Value})
+	}
+
+	//
+	// Values for the variables declared on the pipeline,
+	// unless they are also set in the variables list.
+	//
+	names := slices.Sorted(maps.Keys(spec.PipelineVariables))
+	for _, name := range names {
+		value := spec.PipelineVariables[name]
+		if value == nil || slices.ContainsFunc(variables, func(v PipelineVariable) bool { return v.Name == name }) {
+			continue
+		}
+
+		variables = append(variables, PipelineVariable{Name: name, Value: fmt.Sprintf("%v", value)})
+	}
+
+	sourceRevisions := make([]SourceRevision, 0, len(spec.SourceRevisions))
+	for _, revision := range spec.SourceRevisions {
+		sourceRevisions = append(sourceRevisions, SourceRevision{
+			ActionName:    strings.TrimSpace(revision.ActionName),
+			RevisionType:  strings.TrimSpace(revision.RevisionType),
+			RevisionValue: strings.TrimSpace(revision.RevisionValue),
+		})
+	}
+
+	spec.Variables = variables
+	spec.SourceRevisions = sourceRevisions
+}
+
+func validateSourceRevisions(revisions []SourceRevision) error {
+	seen := map[string]bool{}
+	for i, revision := range revisions {
+		if revision.ActionName == "" {
+			return fmt.Errorf("source revision %d: action name is required", i+1)
+		}
+
+		if revision.RevisionValue == "" {
+			return fmt.Errorf("source revision %d: revision value is required", i+1)
+		}
+
+		valid := slices.ContainsFunc(AllRevisionTypes, func(option configuration.FieldOption) bool {
+			return option.Value == revision.RevisionType
+		})
+
+		if !valid {
+			return fmt.Errorf("source revision %d: invalid revision type %q", i+1, revision.RevisionType)
+		}
+
+		if seen[revision.ActionName] {
+			return fmt.Errorf("source revision %d: duplicate override for action %s", i+1, revision.ActionName)
+		}
+
+		seen[revision.ActionName] = true
+	}
+
+	return nil
 }
 
 // RunPipelineNodeMetadata is cached during Setup() to avoid repeated API calls.
 type RunPipelineNodeMetadata struct {
-	Region         string            `json:"region,omitempty" mapstructure:"region,omitempty"`
-	Pipeline       *PipelineMetadata `json:"pipeline" mapstructure:"pipeline"`
-	SubscriptionID string            `json:"subscriptionId,omitempty" mapstructure:"subscriptionId,omitempty"`
+	Region              string            `json:"region,omitempty" mapstructure:"region,omitempty"`
+	Pipeline            *PipelineMetadata `json:"pipeline" mapstructure:"pipeline"`
+	SubscriptionID      string            `json:"subscriptionId,omitempty" mapstructure:"subscriptionId,omitempty"`
+	StageSubscriptionID string            `json:"stageSubscriptionId,omitempty" mapstructure:"stageSubscriptionId,omitempty"`
 }
 
 type PipelineMetadata struct {
@@ -51,12 +172,24 @@ type PipelineMetadata struct {
 type RunPipelineExecutionMetadata struct {
 	Pipeline  *PipelineMetadata  `json:"pipeline" mapstructure:"pipeline"`
 	Execution *ExecutionMetadata `json:"execution" mapstructure:"execution"`
+	Stages    []StageProgress    `json:"stages,omitempty" mapstructure:"stages,omitempty"`
 	Extra     map[string]any     `json:"extra,omitempty" mapstructure:"extra,omitempty"`
 }
 
 type ExecutionMetadata struct {
-	ID     string `json:"id"`
-	Status string `json:"status"`
+	ID            string   `json:"id"`
+	Status        string   `json:"status"`
+	FailedStage   string   `json:"failedStage,omitempty"`
+	FailedActions []string `json:"failedActions,omitempty"`
+	DeadlineAt    string   `json:"deadlineAt,omitempty"`
+}
+
+// StageProgress records the latest known state of a pipeline stage,
+// as reported by "CodePipeline Stage Execution State Change" events.
+type StageProgress struct {
+	Name      string `json:"name" mapstructure:"name"`
+	State     string `json:"state" mapstructure:"state"`
+	UpdatedAt string `json:"updatedAt,omitempty" mapstructure:"updatedAt,omitempty"`
 }
 
 // terminalStatusFromEventBridgeState maps an EventBridge pipeline execution
@@ -100,6 +233,84 @@ func pipelineExecutionOutputPayload(pipelineName, executionID, status, state str
 	}
 }
 
+// addFailureDetails includes the failed stage and action names
+// in the pipeline section of a failed output payload.
+func addFailureDetails(payload map[string]any, failedStage string, failedActions []string) {
+	if failedStage == "" {
+		return
+	}
+
+	pipeline, ok := payload["pipeline"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	pipeline["failedStage"] = failedStage
+	pipeline["failedActions"] = failedActions
+}
+
+// failureDetailsFromState finds the stage that failed for the given
+// pipeline execution, along with the names of its failed actions.
+func failureDetailsFromState(state *GetPipelineStateResponse, executionID string) (string, []string) {
+	if state == nil {
+		return "", nil
+	}
+
+	for _, stage := range state.StageStates {
+		if stage.LatestExecution == nil {
+			continue
+		}
+
+		if stage.LatestExecution.PipelineExecutionID != executionID || stage.LatestExecution.Status != PipelineStatusFailed {
+			continue
+		}
+
+		failedActions := []string{}
+		for _, action := range stage.ActionStates {
+			if action.LatestExecution != nil && action.LatestExecution.Status == PipelineStatusFailed {
+				failedActions = append(failedActions, action.ActionName)
+			}
+		}
+
+		return stage.StageName, failedActions
+	}
+
+	return "", nil
+}
+
+// failedStageFromProgress returns the last stage reported
+// as failed through stage execution state change events.
+func failedStageFromProgress(stages []StageProgress) string {
+	for i := len(stages) - 1; i >= 0; i-- {
+		if stages[i].State == "FAILED" {
+			return stages[i].Name
+		}
+	}
+
+	return ""
+}
+
+// resolveFailureDetails looks up the failed stage and actions through the
+// CodePipeline API, falling back to the stage progress tracked so far.
+func resolveFailureDetails(logger *logrus.Entry, client *Client, metadata *RunPipelineExecutionMetadata) (string, []string) {
+	if metadata.Pipeline == nil || metadata.Execution == nil {
+		return failedStageFromProgress(metadata.Stages), nil
+	}
+
+	state, err := client.GetPipelineState(metadata.Pipeline.Name)
+	if err != nil {
+		logger.Warnf("Failed to get pipeline state for %s: %v", metadata.Pipeline.Name, err)
+		return failedStageFromProgress(metadata.Stages), nil
+	}
+
+	failedStage, failedActions := failureDetailsFromState(state, metadata.Execution.ID)
+	if failedStage == "" {
+		return failedStageFromProgress(metadata.Stages), nil
+	}
+
+	return failedStage, failedActions
+}
+
 func (r *RunPipeline) Name() string {
 	return "aws.codepipeline.runPipeline"
 }
@@ -134,11 +345,17 @@ func (r *RunPipeline) Documentation() string {
 
 - **Region**: AWS region where the pipeline exists
 - **Pipeline**: Pipeline name or ARN to execute
+- **Variables**: Pipeline-level variables passed to the execution, overriding their default values
+- **Source Revisions**: Override the revision used by source actions (commit ID, image digest, S3 object version or key), so the pipeline runs against a specific artifact
+- **Track Stage Progress**: Subscribe to stage execution state change events and record the progress of each stage on the execution
+- **Wait for Stage Retry**: When the pipeline fails, keep the execution waiting so the failed stage can be retried with the **Retry Stage** action, instead of re-running the whole pipeline. Use the **Accept Failure** action to route it to the failed channel
+- **Poll Interval (seconds)**: How often the execution status is checked when no EventBridge event arrives. Defaults to 5 minutes
+- **Timeout (minutes)**: Maximum time to wait for the pipeline to complete. When exceeded, the execution is routed to the failed channel with a ` + "`timeout`" + ` reason. The pipeline execution itself is not stopped
 
 ## Output Channels
 
 - **Passed**: Emitted when pipeline completes successfully
-- **Failed**: Emitted when pipeline fails or is cancelled
+- **Failed**: Emitted when pipeline fails, is cancelled, or the timeout is reached. Includes the failed stage and action names when available
 
 ## Notes
 
@@ -208,7 +425,201 @@ func (r *RunPipeline) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "variables",
+			Label:       "Variables",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Pipeline variables to set for this execution",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:               "name",
+								Label:              "Name",
+								Type:               configuration.FieldTypeString,
+								Required:           true,
+								DisallowExpression: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        PipelineVariablesField,
+			Label:       "Pipeline Variables",
+			Type:        configuration.FieldTypeObject,
+			Required:    false,
+			Description: "Values for the variables declared on the selected pipeline",
+			TypeOptions: &configuration.TypeOptions{
+				DynamicSchema: &configuration.DynamicSchemaTypeOptions{
+					DependsOn: []string{"region", "pipeline"},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "pipeline",
+					Values: []string{"*"},
+				},
+			},
+		},
+		{
+			Name:        "sourceRevisions",
+			Label:       "Source Revisions",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Override the revision used by source actions, e.g. a commit SHA or image digest",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Source Revision",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "actionName",
+								Label:       "Source Action",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "Name of the source action in the pipeline",
+							},
+							{
+								Name:     "revisionType",
+								Label:    "Revision Type",
+								Type:     configuration.FieldTypeSelect,
+								Required: true,
+								Default:  RevisionTypeCommitID,
+								TypeOptions: &configuration.TypeOptions{
+									Select: &configuration.SelectTypeOptions{
+										Options: AllRevisionTypes,
+									},
+								},
+							},
+							{
+								Name:     "revisionValue",
+								Label:    "Revision",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "stageProgress",
+			Label:       "Track Stage Progress",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Record stage-level progress on the execution while the pipeline runs",
+		},
+		{
+			Name:        "waitForRetry",
+			Label:       "Wait for Stage Retry",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "On failure, wait for the failed stage to be retried or the failure to be accepted",
+		},
+		{
+			Name:        "pollIntervalSeconds",
+			Label:       "Poll Interval (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     "300",
+			Togglable:   true,
+			Description: "How often to check the pipeline execution status",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := MinPollIntervalSeconds; return &min }(),
+					Max: func() *int { max := MaxPollIntervalSeconds; return &max }(),
+				},
+			},
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     "0",
+			Togglable:   true,
+			Description: "Fail the execution if the pipeline does not complete in time. 0 means no timeout",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+					Max: func() *int { max := MaxTimeoutMinutes; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+/*
+ * The pipeline variables field is built from
+ * the variables declared on the selected pipeline.
+ */
+func (r *RunPipeline) DynamicSchema(field string, ctx core.DynamicSchemaContext) ([]configuration.Field, error) {
+	if field != PipelineVariablesField {
+		return nil, fmt.Errorf("field %s does not have a dynamic schema", field)
+	}
+
+	spec := RunPipelineSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, credentials, spec.Region)
+	response, err := client.GetPipeline(spec.Pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	return pipelineVariableFields(response.Pipeline), nil
+}
+
+func pipelineVariableFields(pipeline map[string]any) []configuration.Field {
+	declared, _ := pipeline["variables"].([]any)
+	fields := make([]configuration.Field, 0, len(declared))
+	for _, item := range declared {
+		variable, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		name, _ := variable["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		description, _ := variable["description"].(string)
+		defaultValue, hasDefault := variable["defaultValue"]
+		fields = append(fields, configuration.Field{
+			Name:        name,
+			Label:       name,
+			Type:        configuration.FieldTypeString,
+			Description: description,
+			Required:    !hasDefault,
+			Default:     defaultValue,
+		})
 	}
+
+	return fields
 }
 
 func (r *RunPipeline) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
@@ -229,13 +640,28 @@ func (r *RunPipeline) Setup(ctx core.SetupContext) error {
 		return fmt.Errorf("pipeline is required")
 	}
 
+	normalizeRunPipelineSpec(&spec)
+	err = validateSourceRevisions(spec.SourceRevisions)
+	if err != nil {
+		return err
+	}
+
+	err = validateTimings(spec)
+	if err != nil {
+		return err
+	}
+
 	metadata := RunPipelineNodeMetadata{}
 	err = mapstructure.Decode(ctx.Metadata.Get(), &metadata)
 	if err != nil {
 		metadata = RunPipelineNodeMetadata{}
 	}
 
-	if metadata.SubscriptionID != "" && metadata.Pipeline != nil && spec.Pipeline == metadata.Pipeline.Name && spec.Region == metadata.Region {
+	if metadata.SubscriptionID != "" &&
+		metadata.Pipeline != nil &&
+		spec.Pipeline == metadata.Pipeline.Name &&
+		spec.Region == metadata.Region &&
+		(!spec.StageProgress || metadata.StageSubscriptionID != "") {
 		return nil
 	}
 
@@ -265,11 +691,39 @@ func (r *RunPipeline) Setup(ctx core.SetupContext) error {
 		return fmt.Errorf("pipeline not found: %s", spec.Pipeline)
 	}
 
-	// Provision EventBridge rule if not already present for CodePipeline events.
-	source := "aws.codepipeline"
-	detailType := "CodePipeline Pipeline Execution State Change"
+	nodeMetadata := RunPipelineNodeMetadata{
+		Region:   spec.Region,
+		Pipeline: foundPipeline,
+	}
+
+	subscriptionID, err := r.subscribe(ctx, spec.Region, DetailTypePipelineExecutionStateChange)
+	if err != nil {
+		ctx.Logger.Warnf("Failed to subscribe to CodePipeline events: %v", err)
+	} else {
+		nodeMetadata.SubscriptionID = subscriptionID.String()
+	}
+
+	if spec.StageProgress {
+		stageSubscriptionID, err := r.subscribe(ctx, spec.Region, DetailTypeStageExecutionStateChange)
+		if err != nil {
+			ctx.Logger.Warnf("Failed to subscribe to CodePipeline stage events: %v", err)
+		} else {
+			nodeMetadata.StageSubscriptionID = stageSubscriptionID.String()
+		}
+	}
+
+	err = ctx.Metadata.Set(nodeMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	return nil
+}
 
-	hasRule, err := common.HasEventBridgeRule(ctx.Logger, ctx.Integration, source, spec.Region, detailType)
+// subscribe provisions the EventBridge rule for the detail type,
+// if not already present, and subscribes the node to its events.
+func (r *RunPipeline) subscribe(ctx core.SetupContext, region, detailType string) (*uuid.UUID, error) {
+	hasRule, err := common.HasEventBridgeRule(ctx.Logger, ctx.Integration, Source, region, detailType)
 	if err != nil {
 		ctx.Logger.Warnf("Failed to check EventBridge rule availability: %v", err)
 	}
@@ -278,8 +732,8 @@ func (r *RunPipeline) Setup(ctx core.SetupContext) error {
 		err = ctx.Integration.ScheduleActionCall(
 			"provisionRule",
 			common.ProvisionRuleParameters{
-				Region:     spec.Region,
-				Source:     source,
+				Region:     region,
+				Source:     Source,
 				DetailType: detailType,
 			},
 			time.Second,
@@ -289,29 +743,11 @@ func (r *RunPipeline) Setup(ctx core.SetupContext) error {
 		}
 	}
 
-	subscriptionID, err := ctx.Integration.Subscribe(&common.EventBridgeEvent{
-		Region:     spec.Region,
-		DetailType: "CodePipeline Pipeline Execution State Change",
-		Source:     "aws.codepipeline",
+	return ctx.Integration.Subscribe(&common.EventBridgeEvent{
+		Region:     region,
+		DetailType: detailType,
+		Source:     Source,
 	})
-
-	nodeMetadata := RunPipelineNodeMetadata{
-		Region:   spec.Region,
-		Pipeline: foundPipeline,
-	}
-
-	if err != nil {
-		ctx.Logger.Warnf("Failed to subscribe to CodePipeline events: %v", err)
-	} else {
-		nodeMetadata.SubscriptionID = subscriptionID.String()
-	}
-
-	err = ctx.Metadata.Set(nodeMetadata)
-	if err != nil {
-		return fmt.Errorf("failed to set metadata: %w", err)
-	}
-
-	return nil
 }
 
 func (r *RunPipeline) Execute(ctx core.ExecutionContext) error {
@@ -338,7 +774,18 @@ func (r *RunPipeline) Execute(ctx core.ExecutionContext) error {
 
 	client := NewClient(ctx.HTTP, credentials, spec.Region)
 
-	response, err := client.StartPipelineExecution(nodeMetadata.Pipeline.Name)
+	normalizeRunPipelineSpec(&spec)
+	err = validateSourceRevisions(spec.SourceRevisions)
+	if err != nil {
+		return err
+	}
+
+	err = validateTimings(spec)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.StartPipelineExecution(nodeMetadata.Pipeline.Name, ctx.IdempotencyKey(), spec.Variables, spec.SourceRevisions)
 	if err != nil {
 		return fmt.Errorf("failed to start pipeline execution: %w", err)
 	}
@@ -346,12 +793,18 @@ func (r *RunPipeline) Execute(ctx core.ExecutionContext) error {
 	executionID := response.PipelineExecutionID
 	ctx.Logger.Infof("Started pipeline execution - pipeline=%s, execution=%s", nodeMetadata.Pipeline.Name, executionID)
 
+	execution := &ExecutionMetadata{
+		ID:     executionID,
+		Status: PipelineStatusInProgress,
+	}
+
+	if spec.TimeoutMinutes > 0 {
+		execution.DeadlineAt = time.Now().Add(time.Duration(spec.TimeoutMinutes) * time.Minute).Format(time.RFC3339)
+	}
+
 	err = ctx.Metadata.Set(RunPipelineExecutionMetadata{
-		Pipeline: nodeMetadata.Pipeline,
-		Execution: &ExecutionMetadata{
-			ID:     executionID,
-			Status: PipelineStatusInProgress,
-		},
+		Pipeline:  nodeMetadata.Pipeline,
+		Execution: execution,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to set execution metadata: %w", err)
@@ -363,7 +816,45 @@ func (r *RunPipeline) Execute(ctx core.ExecutionContext) error {
 		return fmt.Errorf("failed to set execution ID: %w", err)
 	}
 
-	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, PollInterval)
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, nextPollInterval(spec, execution, time.Now()))
+}
+
+// nextPollInterval returns the configured poll interval,
+// shortened so the next poll does not happen after the deadline.
+func nextPollInterval(spec RunPipelineSpec, execution *ExecutionMetadata, now time.Time) time.Duration {
+	interval := spec.pollInterval()
+	if execution == nil || execution.DeadlineAt == "" {
+		return interval
+	}
+
+	deadline, err := time.Parse(time.RFC3339, execution.DeadlineAt)
+	if err != nil {
+		return interval
+	}
+
+	remaining := deadline.Sub(now)
+	if remaining < time.Second {
+		return time.Second
+	}
+
+	if remaining < interval {
+		return remaining
+	}
+
+	return interval
+}
+
+func deadlineExceeded(execution *ExecutionMetadata, now time.Time) bool {
+	if execution.DeadlineAt == "" {
+		return false
+	}
+
+	deadline, err := time.Parse(time.RFC3339, execution.DeadlineAt)
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(deadline)
 }
 
 func (r *RunPipeline) Cancel(ctx core.ExecutionContext) error {
@@ -480,7 +971,10 @@ func (r *RunPipeline) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.
 	if status == PipelineStatusSucceeded {
 		err = executionCtx.ExecutionState.Emit(PassedOutputChannel, PayloadType, []any{outputPayload})
 	} else {
-		err = executionCtx.ExecutionState.Emit(FailedOutputChannel, PayloadType, []any{outputPayload})
+		spec := RunPipelineSpec{}
+		_ = mapstructure.Decode(ctx.Configuration, &spec)
+		addFailureDetails(outputPayload, failedStageFromProgress(metadata.Stages), nil)
+		err = r.emitFailed(spec.WaitForRetry, executionCtx.Metadata, executionCtx.ExecutionState, &metadata, outputPayload)
 	}
 
 	if err != nil {
@@ -497,6 +991,40 @@ func (r *RunPipeline) Actions() []core.Action {
 			UserAccessible: false,
 			Description:    "Check pipeline execution status",
 		},
+		{
+			Name:           "retryStage",
+			UserAccessible: true,
+			Description:    "Retry the failed stage and resume waiting for the pipeline",
+			Parameters: []configuration.Field{
+				{
+					Name:        "stage",
+					Label:       "Stage",
+					Type:        configuration.FieldTypeString,
+					Required:    false,
+					Description: "Stage to retry. Defaults to the stage that failed",
+				},
+				{
+					Name:     "retryMode",
+					Label:    "Retry Mode",
+					Type:     configuration.FieldTypeSelect,
+					Required: false,
+					Default:  RetryModeFailedActions,
+					TypeOptions: &configuration.TypeOptions{
+						Select: &configuration.SelectTypeOptions{
+							Options: []configuration.FieldOption{
+								{Label: "Failed Actions", Value: RetryModeFailedActions},
+								{Label: "All Actions", Value: RetryModeAllActions},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:           "acceptFailure",
+			UserAccessible: true,
+			Description:    "Stop waiting for a retry and route the execution to the failed channel",
+		},
 		{
 			Name:           "finish",
 			UserAccessible: true,
@@ -519,6 +1047,10 @@ func (r *RunPipeline) HandleAction(ctx core.ActionContext) error {
 		return r.poll(ctx)
 	case "finish":
 		return r.finish(ctx)
+	case "retryStage":
+		return r.retryStage(ctx)
+	case "acceptFailure":
+		return r.acceptFailure(ctx)
 	default:
 		return fmt.Errorf("unknown action: %s", ctx.Name)
 	}
@@ -553,6 +1085,10 @@ func (r *RunPipeline) poll(ctx core.ActionContext) error {
 		return nil
 	}
 
+	if deadlineExceeded(metadata.Execution, time.Now()) {
+		return r.timeout(ctx, metadata)
+	}
+
 	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
 	if err != nil {
 		return fmt.Errorf("failed to get AWS credentials: %w", err)
@@ -566,7 +1102,7 @@ func (r *RunPipeline) poll(ctx core.ActionContext) error {
 	}
 
 	if execution.Status == PipelineStatusInProgress || execution.Status == PipelineStatusStopping {
-		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, PollInterval)
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, nextPollInterval(spec, metadata.Execution, time.Now()))
 	}
 
 	metadata.Execution.Status = execution.Status
@@ -594,6 +1130,150 @@ func (r *RunPipeline) poll(ctx core.ActionContext) error {
 		return ctx.ExecutionState.Emit(PassedOutputChannel, PayloadType, []any{outputPayload})
 	}
 
+	if execution.Status == PipelineStatusFailed {
+		failedStage, failedActions := resolveFailureDetails(ctx.Logger, client, &metadata)
+		addFailureDetails(outputPayload, failedStage, failedActions)
+	}
+
+	return r.emitFailed(spec.WaitForRetry, ctx.Metadata, ctx.ExecutionState, &metadata, outputPayload)
+}
+
+// timeout routes the execution to the failed channel when the pipeline
+// did not complete before the configured deadline.
+// The pipeline execution itself is left running.
+func (r *RunPipeline) timeout(ctx core.ActionContext, metadata RunPipelineExecutionMetadata) error {
+	ctx.Logger.Infof(
+		"Pipeline %s execution %s did not complete before %s",
+		metadata.Pipeline.Name,
+		metadata.Execution.ID,
+		metadata.Execution.DeadlineAt,
+	)
+
+	state := eventBridgeStateFromPipelineStatus(metadata.Execution.Status)
+	outputPayload := pipelineExecutionOutputPayload(
+		metadata.Pipeline.Name,
+		metadata.Execution.ID,
+		metadata.Execution.Status,
+		state,
+		map[string]any{
+			"pipeline":     metadata.Pipeline.Name,
+			"execution-id": metadata.Execution.ID,
+			"state":        state,
+		},
+	)
+
+	outputPayload["reason"] = FailureReasonTimeout
+	return ctx.ExecutionState.Emit(FailedOutputChannel, PayloadType, []any{outputPayload})
+}
+
+func (r *RunPipeline) retryStage(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return fmt.Errorf("execution already finished")
+	}
+
+	spec := RunPipelineSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	metadata := RunPipelineExecutionMetadata{}
+	err = mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Pipeline == nil || metadata.Execution == nil {
+		return fmt.Errorf("execution metadata not found - component may not have started properly")
+	}
+
+	if metadata.Execution.Status != PipelineStatusFailed {
+		return fmt.Errorf("pipeline execution has not failed")
+	}
+
+	stage, _ := ctx.Parameters["stage"].(string)
+	stage = strings.TrimSpace(stage)
+	if stage == "" {
+		stage = metadata.Execution.FailedStage
+	}
+
+	if stage == "" {
+		return fmt.Errorf("failed stage is unknown - specify the stage to retry")
+	}
+
+	retryMode, _ := ctx.Parameters["retryMode"].(string)
+	if retryMode == "" {
+		retryMode = RetryModeFailedActions
+	}
+
+	if retryMode != RetryModeFailedActions && retryMode != RetryModeAllActions {
+		return fmt.Errorf("retry mode must be one of %s, %s", RetryModeFailedActions, RetryModeAllActions)
+	}
+
+	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, credentials, spec.Region)
+	response, err := client.RetryStageExecution(metadata.Pipeline.Name, stage, metadata.Execution.ID, retryMode)
+	if err != nil {
+		return fmt.Errorf("failed to retry stage execution: %w", err)
+	}
+
+	if response.PipelineExecutionID != "" && response.PipelineExecutionID != metadata.Execution.ID {
+		metadata.Execution.ID = response.PipelineExecutionID
+		err = ctx.ExecutionState.SetKV("pipeline_execution_id", response.PipelineExecutionID)
+		if err != nil {
+			return fmt.Errorf("failed to set execution ID: %w", err)
+		}
+	}
+
+	metadata.Execution.Status = PipelineStatusInProgress
+	metadata.Execution.FailedStage = ""
+	metadata.Execution.FailedActions = nil
+	err = ctx.Metadata.Set(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	ctx.Logger.Infof("Retrying stage %s of pipeline %s - execution=%s", stage, metadata.Pipeline.Name, metadata.Execution.ID)
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, nextPollInterval(spec, metadata.Execution, time.Now()))
+}
+
+func (r *RunPipeline) acceptFailure(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := RunPipelineExecutionMetadata{}
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Pipeline == nil || metadata.Execution == nil {
+		return fmt.Errorf("execution metadata not found - component may not have started properly")
+	}
+
+	if metadata.Execution.Status != PipelineStatusFailed {
+		return fmt.Errorf("pipeline execution has not failed")
+	}
+
+	state := eventBridgeStateFromPipelineStatus(PipelineStatusFailed)
+	outputPayload := pipelineExecutionOutputPayload(
+		metadata.Pipeline.Name,
+		metadata.Execution.ID,
+		PipelineStatusFailed,
+		state,
+		map[string]any{
+			"pipeline":     metadata.Pipeline.Name,
+			"execution-id": metadata.Execution.ID,
+			"state":        state,
+		},
+	)
+
+	addFailureDetails(outputPayload, metadata.Execution.FailedStage, metadata.Execution.FailedActions)
 	return ctx.ExecutionState.Emit(FailedOutputChannel, PayloadType, []any{outputPayload})
 }
 
@@ -668,6 +1348,10 @@ func (r *RunPipeline) OnIntegrationMessage(ctx core.IntegrationMessageContext) e
 		return fmt.Errorf("failed to decode EventBridge event: %w", err)
 	}
 
+	if event.DetailType == DetailTypeStageExecutionStateChange {
+		return r.onStageEvent(ctx, event)
+	}
+
 	pipelineName, ok := event.Detail["pipeline"]
 	if !ok {
 		return fmt.Errorf("missing pipeline name in event detail")
@@ -760,7 +1444,112 @@ func (r *RunPipeline) OnIntegrationMessage(ctx core.IntegrationMessageContext) e
 		return executionCtx.ExecutionState.Emit(PassedOutputChannel, PayloadType, []any{outputPayload})
 	}
 
-	return executionCtx.ExecutionState.Emit(FailedOutputChannel, PayloadType, []any{outputPayload})
+	if status == PipelineStatusFailed {
+		addFailureDetails(outputPayload, failedStageFromProgress(execMetadata.Stages), nil)
+
+		credentials, err := common.CredentialsFromInstallation(ctx.Integration)
+		if err != nil {
+			ctx.Logger.Warnf("Failed to get AWS credentials for failure details: %v", err)
+		} else {
+			client := NewClient(ctx.HTTP, credentials, metadata.Region)
+			failedStage, failedActions := resolveFailureDetails(ctx.Logger, client, &execMetadata)
+			addFailureDetails(outputPayload, failedStage, failedActions)
+		}
+	}
+
+	spec := RunPipelineSpec{}
+	_ = mapstructure.Decode(ctx.Configuration, &spec)
+	return r.emitFailed(spec.WaitForRetry, executionCtx.Metadata, executionCtx.ExecutionState, &execMetadata, outputPayload)
+}
+
+// emitFailed routes the execution to the failed channel. If the pipeline failed
+// and the node waits for retries, the failure details are recorded instead,
+// and the execution is kept around for the retryStage / acceptFailure actions.
+func (r *RunPipeline) emitFailed(
+	waitForRetry bool,
+	metadataCtx core.MetadataContext,
+	executionState core.ExecutionStateContext,
+	metadata *RunPipelineExecutionMetadata,
+	outputPayload map[string]any,
+) error {
+	if !waitForRetry || metadata.Execution.Status != PipelineStatusFailed {
+		return executionState.Emit(FailedOutputChannel, PayloadType, []any{outputPayload})
+	}
+
+	pipeline, _ := outputPayload["pipeline"].(map[string]any)
+	metadata.Execution.FailedStage, _ = pipeline["failedStage"].(string)
+	metadata.Execution.FailedActions, _ = pipeline["failedActions"].([]string)
+	return metadataCtx.Set(*metadata)
+}
+
+// onStageEvent records the progress of a pipeline stage on the execution
+// waiting for the pipeline, without finishing it. Only nodes configured
+// to track stage progress subscribe to these events.
+func (r *RunPipeline) onStageEvent(ctx core.IntegrationMessageContext, event common.EventBridgeEvent) error {
+	metadata := RunPipelineNodeMetadata{}
+	err := mapstructure.Decode(ctx.NodeMetadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode node metadata: %w", err)
+	}
+
+	if metadata.Pipeline == nil {
+		return nil
+	}
+
+	pipelineName, _ := event.Detail["pipeline"].(string)
+	if pipelineName != metadata.Pipeline.Name {
+		return nil
+	}
+
+	stage, _ := event.Detail["stage"].(string)
+	state, _ := event.Detail["state"].(string)
+	executionID, _ := event.Detail["execution-id"].(string)
+	if stage == "" || state == "" || executionID == "" {
+		return fmt.Errorf("missing stage, state or execution-id in stage event detail")
+	}
+
+	if ctx.FindExecutionByKV == nil {
+		return nil
+	}
+
+	executionCtx, err := ctx.FindExecutionByKV("pipeline_execution_id", executionID)
+	if err != nil || executionCtx == nil {
+		return nil
+	}
+
+	if executionCtx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	execMetadata := RunPipelineExecutionMetadata{}
+	err = mapstructure.Decode(executionCtx.Metadata.Get(), &execMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode execution metadata: %w", err)
+	}
+
+	progress := StageProgress{
+		Name:      stage,
+		State:     state,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	index := slices.IndexFunc(execMetadata.Stages, func(s StageProgress) bool {
+		return s.Name == stage
+	})
+
+	if index >= 0 {
+		execMetadata.Stages[index] = progress
+	} else {
+		execMetadata.Stages = append(execMetadata.Stages, progress)
+	}
+
+	err = executionCtx.Metadata.Set(execMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to update execution metadata: %w", err)
+	}
+
+	ctx.Logger.Infof("Pipeline %s execution %s: stage %s is %s", pipelineName, executionID, stage, state)
+	return nil
 }
 
 func (r *RunPipeline) Cleanup(ctx core.SetupContext) error {
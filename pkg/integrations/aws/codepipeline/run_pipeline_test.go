@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,6 +56,21 @@ func Test__RunPipeline__Setup(t *testing.T) {
 		require.ErrorContains(t, err, "pipeline is required")
 	})
 
+	t.Run("duplicate source revision action -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":   "us-east-1",
+				"pipeline": "my-pipeline",
+				"sourceRevisions": []any{
+					map[string]any{"actionName": "Source", "revisionType": "COMMIT_ID", "revisionValue": "abc"},
+					map[string]any{"actionName": "Source", "revisionType": "COMMIT_ID", "revisionValue": "def"},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "duplicate override for action Source")
+	})
+
 	t.Run("already cached metadata matches -> no API call", func(t *testing.T) {
 		err := component.Setup(core.SetupContext{
 			Configuration: map[string]any{
@@ -218,6 +235,182 @@ func Test__RunPipeline__Execute(t *testing.T) {
 		require.Len(t, httpCtx.Requests, 1)
 		assert.Contains(t, httpCtx.Requests[0].URL.String(), "codepipeline.us-east-1.amazonaws.com")
 	})
+
+	t.Run("variables and source revisions -> passed to StartPipelineExecution", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"pipelineExecutionId": "exec-123"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":   "us-east-1",
+				"pipeline": "my-pipeline",
+				"variables": []any{
+					map[string]any{"name": "ENVIRONMENT", "value": "staging"},
+					map[string]any{"name": " ", "value": "ignored"},
+				},
+				"sourceRevisions": []any{
+					map[string]any{"actionName": "Source", "revisionType": "COMMIT_ID", "revisionValue": " abc123 "},
+				},
+			},
+			NodeMetadata: &contexts.MetadataContext{
+				Metadata: RunPipelineNodeMetadata{Pipeline: &PipelineMetadata{Name: "my-pipeline"}},
+			},
+			Metadata:       &contexts.MetadataContext{Metadata: map[string]any{}},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Requests:       &contexts.RequestContext{},
+			Logger:         logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+
+		body, err := io.ReadAll(httpCtx.Requests[0].Body)
+		require.NoError(t, err)
+
+		payload := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "my-pipeline", payload["name"])
+		assert.Equal(t, []any{
+			map[string]any{"name": "ENVIRONMENT", "value": "staging"},
+		}, payload["variables"])
+		assert.Equal(t, []any{
+			map[string]any{"actionName": "Source", "revisionType": "COMMIT_ID", "revisionValue": "abc123"},
+		}, payload["sourceRevisions"])
+	})
+
+	t.Run("retried execution -> same client request token", func(t *testing.T) {
+		executionID := uuid.New()
+		tokens := []any{}
+		for range 2 {
+			httpCtx := &contexts.HTTPContext{
+				Responses: []*http.Response{
+					{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"pipelineExecutionId": "exec-123"}`)),
+					},
+				},
+			}
+
+			err := component.Execute(core.ExecutionContext{
+				ID:            executionID,
+				NodeID:        "run-pipeline",
+				Configuration: map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+				NodeMetadata: &contexts.MetadataContext{
+					Metadata: RunPipelineNodeMetadata{Pipeline: &PipelineMetadata{Name: "my-pipeline"}},
+				},
+				Metadata:       &contexts.MetadataContext{Metadata: map[string]any{}},
+				HTTP:           httpCtx,
+				Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+				ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+				Requests:       &contexts.RequestContext{},
+				Logger:         logrus.NewEntry(logrus.New()),
+			})
+
+			require.NoError(t, err)
+			require.Len(t, httpCtx.Requests, 1)
+
+			body, err := io.ReadAll(httpCtx.Requests[0].Body)
+			require.NoError(t, err)
+
+			payload := map[string]any{}
+			require.NoError(t, json.Unmarshal(body, &payload))
+			require.NotEmpty(t, payload["clientRequestToken"])
+			tokens = append(tokens, payload["clientRequestToken"])
+		}
+
+		assert.Equal(t, tokens[0], tokens[1])
+	})
+
+	t.Run("invalid source revision type -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":   "us-east-1",
+				"pipeline": "my-pipeline",
+				"sourceRevisions": []any{
+					map[string]any{"actionName": "Source", "revisionType": "BRANCH", "revisionValue": "main"},
+				},
+			},
+			NodeMetadata: &contexts.MetadataContext{
+				Metadata: RunPipelineNodeMetadata{Pipeline: &PipelineMetadata{Name: "my-pipeline"}},
+			},
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "invalid revision type")
+	})
+}
+
+func Test__RunPipeline__DynamicSchema(t *testing.T) {
+	component := &RunPipeline{}
+
+	t.Run("declared variables -> one field per variable", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"pipeline": {
+							"name": "my-pipeline",
+							"variables": [
+								{"name": "ENVIRONMENT", "description": "Target environment"},
+								{"name": "VERSION", "defaultValue": "latest"}
+							]
+						}
+					}`)),
+				},
+			},
+		}
+
+		fields, err := component.DynamicSchema(PipelineVariablesField, core.DynamicSchemaContext{
+			Configuration: map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			HTTP:          httpCtx,
+			Integration:   &contexts.IntegrationContext{Secrets: validSecrets()},
+			Logger:        logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, fields, 2)
+		assert.Equal(t, "ENVIRONMENT", fields[0].Name)
+		assert.Equal(t, "Target environment", fields[0].Description)
+		assert.True(t, fields[0].Required)
+		assert.Equal(t, "VERSION", fields[1].Name)
+		assert.False(t, fields[1].Required)
+		assert.Equal(t, "latest", fields[1].Default)
+	})
+
+	t.Run("unknown field -> error", func(t *testing.T) {
+		_, err := component.DynamicSchema("variables", core.DynamicSchemaContext{})
+		require.ErrorContains(t, err, "does not have a dynamic schema")
+	})
+}
+
+func Test__RunPipeline__PipelineVariables(t *testing.T) {
+	spec := RunPipelineSpec{
+		Variables: []PipelineVariable{{Name: "ENVIRONMENT", Value: "production"}},
+		PipelineVariables: map[string]any{
+			"VERSION":     "1.2.3",
+			"ENVIRONMENT": "staging",
+			"REPLICAS":    3,
+			"UNSET":       nil,
+		},
+	}
+
+	normalizeRunPipelineSpec(&spec)
+
+	assert.Equal(t, []PipelineVariable{
+		{Name: "ENVIRONMENT", Value: "production"},
+		{Name: "REPLICAS", Value: "3"},
+		{Name: "VERSION", Value: "1.2.3"},
+	}, spec.Variables)
 }
 
 func Test__RunPipeline__HandleWebhook(t *testing.T) {
@@ -557,12 +750,34 @@ func Test__RunPipeline__Poll(t *testing.T) {
 						}
 					}`)),
 				},
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"pipelineName": "my-pipeline",
+						"stageStates": [
+							{
+								"stageName": "Source",
+								"latestExecution": {"pipelineExecutionId": "exec-123", "status": "Succeeded"},
+								"actionStates": [{"actionName": "Checkout", "latestExecution": {"status": "Succeeded"}}]
+							},
+							{
+								"stageName": "Deploy",
+								"latestExecution": {"pipelineExecutionId": "exec-123", "status": "Failed"},
+								"actionStates": [
+									{"actionName": "DeployApp", "latestExecution": {"status": "Failed", "errorDetails": {"code": "JobFailed", "message": "boom"}}},
+									{"actionName": "Notify", "latestExecution": {"status": "Succeeded"}}
+								]
+							}
+						]
+					}`)),
+				},
 			},
 		}
 
 		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
 		err := component.HandleAction(core.ActionContext{
-			Name: "poll",
+			Name:   "poll",
+			Logger: logrus.NewEntry(logrus.New()),
 			Configuration: map[string]any{
 				"region":   "us-east-1",
 				"pipeline": "my-pipeline",
@@ -584,6 +799,180 @@ func Test__RunPipeline__Poll(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, execState.Finished)
 		assert.Equal(t, FailedOutputChannel, execState.Channel)
+		require.Len(t, execState.Payloads, 1)
+		wrapped, ok := execState.Payloads[0].(map[string]any)
+		require.True(t, ok)
+		data, ok := wrapped["data"].(map[string]any)
+		require.True(t, ok)
+		pipeline, ok := data["pipeline"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "Deploy", pipeline["failedStage"])
+		assert.Equal(t, []string{"DeployApp"}, pipeline["failedActions"])
+	})
+
+	t.Run("pipeline failed and state lookup fails -> uses tracked stage progress", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"pipelineExecution": {
+							"pipelineExecutionId": "exec-123",
+							"status": "Failed",
+							"pipelineName": "my-pipeline"
+						}
+					}`)),
+				},
+				{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader(`{"__type": "ValidationException", "message": "bad"}`)),
+				},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:   "poll",
+			Logger: logrus.NewEntry(logrus.New()),
+			Configuration: map[string]any{
+				"region":   "us-east-1",
+				"pipeline": "my-pipeline",
+			},
+			Metadata: &contexts.MetadataContext{
+				Metadata: RunPipelineExecutionMetadata{
+					Pipeline:  &PipelineMetadata{Name: "my-pipeline"},
+					Execution: &ExecutionMetadata{ID: "exec-123", Status: PipelineStatusInProgress},
+					Stages: []StageProgress{
+						{Name: "Source", State: "SUCCEEDED"},
+						{Name: "Build", State: "FAILED"},
+					},
+				},
+			},
+			HTTP: httpCtx,
+			Integration: &contexts.IntegrationContext{
+				Secrets: validSecrets(),
+			},
+			ExecutionState: execState,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, execState.Channel)
+		wrapped := execState.Payloads[0].(map[string]any)
+		pipeline := wrapped["data"].(map[string]any)["pipeline"].(map[string]any)
+		assert.Equal(t, "Build", pipeline["failedStage"])
+	})
+}
+
+func Test__RunPipeline__Timeout(t *testing.T) {
+	component := &RunPipeline{}
+
+	t.Run("poll interval out of bounds -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":              "us-east-1",
+				"pipeline":            "my-pipeline",
+				"pollIntervalSeconds": 5,
+			},
+		})
+
+		require.ErrorContains(t, err, "poll interval must be between 30 and 3600 seconds")
+	})
+
+	t.Run("timeout out of bounds -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":         "us-east-1",
+				"pipeline":       "my-pipeline",
+				"timeoutMinutes": MaxTimeoutMinutes + 1,
+			},
+		})
+
+		require.ErrorContains(t, err, "timeout must be between 0")
+	})
+
+	t.Run("deadline exceeded -> emits to failed channel with timeout reason", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:   "poll",
+			Logger: logrus.NewEntry(logrus.New()),
+			Configuration: map[string]any{
+				"region":         "us-east-1",
+				"pipeline":       "my-pipeline",
+				"timeoutMinutes": 10,
+			},
+			Metadata: &contexts.MetadataContext{
+				Metadata: RunPipelineExecutionMetadata{
+					Pipeline: &PipelineMetadata{Name: "my-pipeline"},
+					Execution: &ExecutionMetadata{
+						ID:         "exec-123",
+						Status:     PipelineStatusInProgress,
+						DeadlineAt: time.Now().Add(-time.Minute).Format(time.RFC3339),
+					},
+				},
+			},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			ExecutionState: execState,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpCtx.Requests)
+		assert.True(t, execState.Finished)
+		assert.Equal(t, FailedOutputChannel, execState.Channel)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, FailureReasonTimeout, data["reason"])
+	})
+
+	t.Run("pipeline still running -> uses configured poll interval", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"pipelineExecution": {"pipelineExecutionId": "exec-123", "status": "InProgress", "pipelineName": "my-pipeline"}
+					}`)),
+				},
+			},
+		}
+
+		requestCtx := &contexts.RequestContext{}
+		err := component.HandleAction(core.ActionContext{
+			Name: "poll",
+			Configuration: map[string]any{
+				"region":              "us-east-1",
+				"pipeline":            "my-pipeline",
+				"pollIntervalSeconds": 60,
+			},
+			Metadata: &contexts.MetadataContext{
+				Metadata: RunPipelineExecutionMetadata{
+					Pipeline:  &PipelineMetadata{Name: "my-pipeline"},
+					Execution: &ExecutionMetadata{ID: "exec-123", Status: PipelineStatusInProgress},
+				},
+			},
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "poll", requestCtx.Action)
+		assert.Equal(t, time.Minute, requestCtx.Duration)
+	})
+
+	t.Run("deadline before next poll -> shortens poll interval", func(t *testing.T) {
+		now := time.Now()
+		interval := nextPollInterval(
+			RunPipelineSpec{PollIntervalSeconds: 600},
+			&ExecutionMetadata{DeadlineAt: now.Add(2 * time.Minute).Format(time.RFC3339)},
+			now,
+		)
+
+		assert.LessOrEqual(t, interval, 2*time.Minute)
+		assert.Greater(t, interval, time.Minute)
 	})
 }
 
@@ -667,6 +1056,153 @@ func Test__RunPipeline__Finish(t *testing.T) {
 	})
 }
 
+func Test__RunPipeline__RetryStage(t *testing.T) {
+	component := &RunPipeline{}
+
+	failedMetadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: RunPipelineExecutionMetadata{
+				Pipeline: &PipelineMetadata{Name: "my-pipeline"},
+				Execution: &ExecutionMetadata{
+					ID:            "exec-123",
+					Status:        PipelineStatusFailed,
+					FailedStage:   "Deploy",
+					FailedActions: []string{"DeployApp"},
+				},
+			},
+		}
+	}
+
+	t.Run("pipeline failed with waitForRetry -> holds execution", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"pipelineExecution": {"pipelineExecutionId": "exec-123", "status": "Failed", "pipelineName": "my-pipeline"}
+					}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"stageStates": [{
+							"stageName": "Deploy",
+							"latestExecution": {"pipelineExecutionId": "exec-123", "status": "Failed"},
+							"actionStates": [{"actionName": "DeployApp", "latestExecution": {"status": "Failed"}}]
+						}]
+					}`)),
+				},
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{
+			Metadata: RunPipelineExecutionMetadata{
+				Pipeline:  &PipelineMetadata{Name: "my-pipeline"},
+				Execution: &ExecutionMetadata{ID: "exec-123", Status: PipelineStatusInProgress},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:   "poll",
+			Logger: logrus.NewEntry(logrus.New()),
+			Configuration: map[string]any{
+				"region":       "us-east-1",
+				"pipeline":     "my-pipeline",
+				"waitForRetry": true,
+			},
+			Metadata:       metadataCtx,
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			ExecutionState: execState,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Finished)
+		metadata, ok := metadataCtx.Metadata.(RunPipelineExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, PipelineStatusFailed, metadata.Execution.Status)
+		assert.Equal(t, "Deploy", metadata.Execution.FailedStage)
+		assert.Equal(t, []string{"DeployApp"}, metadata.Execution.FailedActions)
+	})
+
+	t.Run("retryStage -> retries failed stage and schedules poll", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"pipelineExecutionId": "exec-123"}`))},
+			},
+		}
+
+		metadataCtx := failedMetadata()
+		requestCtx := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "retryStage",
+			Logger:         logrus.NewEntry(logrus.New()),
+			Configuration:  map[string]any{"region": "us-east-1", "pipeline": "my-pipeline", "waitForRetry": true},
+			Parameters:     map[string]any{},
+			Metadata:       metadataCtx,
+			HTTP:           httpCtx,
+			Integration:    &contexts.IntegrationContext{Secrets: validSecrets()},
+			ExecutionState: execState,
+			Requests:       requestCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, TargetPrefix+"RetryStageExecution", httpCtx.Requests[0].Header.Get("X-Amz-Target"))
+		body, err := io.ReadAll(httpCtx.Requests[0].Body)
+		require.NoError(t, err)
+		payload := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "Deploy", payload["stageName"])
+		assert.Equal(t, RetryModeFailedActions, payload["retryMode"])
+
+		assert.False(t, execState.Finished)
+		assert.Equal(t, "poll", requestCtx.Action)
+		metadata, ok := metadataCtx.Metadata.(RunPipelineExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, PipelineStatusInProgress, metadata.Execution.Status)
+		assert.Empty(t, metadata.Execution.FailedStage)
+	})
+
+	t.Run("retryStage when pipeline has not failed -> error", func(t *testing.T) {
+		err := component.HandleAction(core.ActionContext{
+			Name:          "retryStage",
+			Configuration: map[string]any{"region": "us-east-1", "pipeline": "my-pipeline"},
+			Parameters:    map[string]any{},
+			Metadata: &contexts.MetadataContext{
+				Metadata: RunPipelineExecutionMetadata{
+					Pipeline:  &PipelineMetadata{Name: "my-pipeline"},
+					Execution: &ExecutionMetadata{ID: "exec-123", Status: PipelineStatusInProgress},
+				},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "pipeline execution has not failed")
+	})
+
+	t.Run("acceptFailure -> emits to failed channel", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "acceptFailure",
+			Configuration:  map[string]any{"region": "us-east-1", "pipeline": "my-pipeline", "waitForRetry": true},
+			Metadata:       failedMetadata(),
+			ExecutionState: execState,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execState.Finished)
+		assert.Equal(t, FailedOutputChannel, execState.Channel)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		pipeline := data["pipeline"].(map[string]any)
+		assert.Equal(t, "Deploy", pipeline["failedStage"])
+		assert.Equal(t, []string{"DeployApp"}, pipeline["failedActions"])
+	})
+}
+
 func Test__RunPipeline__OnIntegrationMessage(t *testing.T) {
 	component := &RunPipeline{}
 
@@ -881,6 +1417,126 @@ func Test__RunPipeline__OnIntegrationMessage(t *testing.T) {
 	})
 }
 
+func Test__RunPipeline__OnStageEvent(t *testing.T) {
+	component := &RunPipeline{}
+
+	stageEvent := func(stage, state string) common.EventBridgeEvent {
+		return common.EventBridgeEvent{
+			Source:     Source,
+			DetailType: DetailTypeStageExecutionStateChange,
+			Detail: map[string]any{
+				"pipeline":     "my-pipeline",
+				"execution-id": "exec-1",
+				"stage":        stage,
+				"state":        state,
+			},
+		}
+	}
+
+	t.Run("stage events -> records progress without finishing execution", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		executionMetadata := &contexts.MetadataContext{
+			Metadata: RunPipelineExecutionMetadata{
+				Pipeline:  &PipelineMetadata{Name: "my-pipeline"},
+				Execution: &ExecutionMetadata{ID: "exec-1", Status: PipelineStatusInProgress},
+			},
+		}
+
+		ctx := core.IntegrationMessageContext{
+			Logger: logrus.NewEntry(logrus.New()),
+			NodeMetadata: &contexts.MetadataContext{
+				Metadata: RunPipelineNodeMetadata{
+					Pipeline: &PipelineMetadata{Name: "my-pipeline"},
+				},
+			},
+			FindExecutionByKV: func(key, value string) (*core.ExecutionContext, error) {
+				assert.Equal(t, "pipeline_execution_id", key)
+				assert.Equal(t, "exec-1", value)
+				return &core.ExecutionContext{
+					Metadata:       executionMetadata,
+					ExecutionState: executionState,
+				}, nil
+			},
+		}
+
+		ctx.Message = stageEvent("Source", "STARTED")
+		require.NoError(t, component.OnIntegrationMessage(ctx))
+		ctx.Message = stageEvent("Source", "SUCCEEDED")
+		require.NoError(t, component.OnIntegrationMessage(ctx))
+		ctx.Message = stageEvent("Deploy", "STARTED")
+		require.NoError(t, component.OnIntegrationMessage(ctx))
+
+		assert.False(t, executionState.Finished)
+		metadata, ok := executionMetadata.Metadata.(RunPipelineExecutionMetadata)
+		require.True(t, ok)
+		require.Len(t, metadata.Stages, 2)
+		assert.Equal(t, "Source", metadata.Stages[0].Name)
+		assert.Equal(t, "SUCCEEDED", metadata.Stages[0].State)
+		assert.Equal(t, "Deploy", metadata.Stages[1].Name)
+		assert.Equal(t, "STARTED", metadata.Stages[1].State)
+	})
+
+	t.Run("stage event for other pipeline -> ignored", func(t *testing.T) {
+		event := stageEvent("Source", "STARTED")
+		event.Detail["pipeline"] = "other-pipeline"
+
+		err := component.OnIntegrationMessage(core.IntegrationMessageContext{
+			Logger: logrus.NewEntry(logrus.New()),
+			NodeMetadata: &contexts.MetadataContext{
+				Metadata: RunPipelineNodeMetadata{
+					Pipeline: &PipelineMetadata{Name: "my-pipeline"},
+				},
+			},
+			Message: event,
+			FindExecutionByKV: func(key, value string) (*core.ExecutionContext, error) {
+				t.Fatal("should not look up execution")
+				return nil, nil
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("setup with stage progress -> subscribes to stage events", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"pipelines": [{"name": "my-pipeline"}]}`)),
+				},
+			},
+		}
+
+		metadataCtx := &contexts.MetadataContext{Metadata: map[string]any{}}
+		integrationCtx := &contexts.IntegrationContext{
+			Secrets:  validSecrets(),
+			Metadata: map[string]any{},
+		}
+
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":        "us-east-1",
+				"pipeline":      "my-pipeline",
+				"stageProgress": true,
+			},
+			Metadata:    metadataCtx,
+			HTTP:        httpCtx,
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integrationCtx.Subscriptions, 2)
+		stageSubscription, ok := integrationCtx.Subscriptions[1].Configuration.(*common.EventBridgeEvent)
+		require.True(t, ok)
+		assert.Equal(t, DetailTypeStageExecutionStateChange, stageSubscription.DetailType)
+
+		storedMetadata, ok := metadataCtx.Metadata.(RunPipelineNodeMetadata)
+		require.True(t, ok)
+		assert.NotEmpty(t, storedMetadata.StageSubscriptionID)
+	})
+}
+
 func Test__RunPipeline__ListPipelines(t *testing.T) {
 	t.Run("missing region -> error", func(t *testing.T) {
 		_, err := ListPipelines(core.ListResourcesContext{
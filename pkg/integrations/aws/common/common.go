@@ -169,6 +169,7 @@ type ProvisionRuleParameters struct {
 }
 
 type EventBridgeEvent struct {
+	ID         string         `json:"id" mapstructure:"id"`
 	Region     string         `json:"region" mapstructure:"region"`
 	DetailType string         `json:"detail-type" mapstructure:"detail-type"`
 	Source     string         `json:"source" mapstructure:"source"`
@@ -0,0 +1,225 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * HTTPClientOptions configures the HTTP client used by the AWS service clients.
+ * Timeout applies to each attempt, including reading the response body.
+ */
+type HTTPClientOptions struct {
+	Timeout    time.Duration
+	MaxRetries int
+	RetryDelay time.Duration
+}
+
+var DefaultHTTPClientOptions = HTTPClientOptions{
+	Timeout:    30 * time.Second,
+	MaxRetries: 2,
+	RetryDelay: 200 * time.Millisecond,
+}
+
+/*
+ * HTTPClient sends the signed requests of the AWS service clients
+ * through the HTTP context of the component, so connections are pooled
+ * by the shared transport, and the host validations still apply.
+ *
+ * Read-only calls are retried when they fail with a transient network error.
+ * Other calls are not, since we can't know if AWS processed them.
+ */
+type HTTPClient struct {
+	http    core.HTTPContext
+	options HTTPClientOptions
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func NewHTTPClient(httpCtx core.HTTPContext) *HTTPClient {
+	return NewHTTPClientWithOptions(httpCtx, DefaultHTTPClientOptions)
+}
+
+func NewHTTPClientWithOptions(httpCtx core.HTTPContext, options HTTPClientOptions) *HTTPClient {
+	return &HTTPClient{
+		http:    httpCtx,
+		options: options,
+		sleep:   sleepContext,
+	}
+}
+
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if isIdempotentRequest(req) {
+		retries = max(c.options.MaxRetries, 0)
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := requestForAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.doAttempt(attemptReq)
+		if err == nil {
+			return res, nil
+		}
+
+		if attempt >= retries || req.Context().Err() != nil || !isTransientNetworkError(err) {
+			return nil, err
+		}
+
+		if err := c.sleep(req.Context(), c.options.RetryDelay<<attempt); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *HTTPClient) doAttempt(req *http.Request) (*http.Response, error) {
+	if c.options.Timeout <= 0 {
+		return c.http.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.options.Timeout)
+	res, err := c.http.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	//
+	// The timeout covers reading the body too,
+	// so the context is only released when the body is closed.
+	//
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+/*
+ * requestForAttempt returns the request to send on the given attempt.
+ * The body of the original request was consumed by the previous attempt,
+ * so retries get a fresh copy of it.
+ */
+func requestForAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errors.New("request body can't be sent again")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+var readOnlyActionPrefixes = []string{"Describe", "List", "Get"}
+
+/*
+ * isIdempotentRequest tells if the request can be sent again safely.
+ * Besides the idempotent HTTP methods, the query and JSON APIs send every action with POST,
+ * so the action name is used to find out if the call only reads data.
+ */
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return isReadOnlyAction(requestAction(req))
+	default:
+		return false
+	}
+}
+
+func requestAction(req *http.Request) string {
+	if target := req.Header.Get("X-Amz-Target"); target != "" {
+		return target[strings.LastIndex(target, ".")+1:]
+	}
+
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") || req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return ""
+	}
+
+	return values.Get("Action")
+}
+
+func isReadOnlyAction(action string) bool {
+	for _, prefix := range readOnlyActionPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type attempt struct {
+	response *http.Response
+	err      error
+}
+
+type fakeHTTP struct {
+	attempts []attempt
+	requests []*http.Request
+	bodies   []string
+}
+
+func (f *fakeHTTP) Do(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		f.bodies = append(f.bodies, string(body))
+	}
+
+	if len(f.attempts) == 0 {
+		return nil, fmt.Errorf("no response mocked")
+	}
+
+	next := f.attempts[0]
+	f.attempts = f.attempts[1:]
+	return next.response, next.err
+}
+
+func okResponse() *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}
+}
+
+func resetError() error {
+	return &url.Error{Op: "Post", URL: "https://aws", Err: syscall.ECONNRESET}
+}
+
+func newTestHTTPClient(httpCtx *fakeHTTP, options HTTPClientOptions) *HTTPClient {
+	client := NewHTTPClientWithOptions(httpCtx, options)
+	client.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	return client
+}
+
+func jsonRequest(t *testing.T, target string) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, "https://ecr.us-east-1.amazonaws.com/", strings.NewReader(`{"repositoryName":"app"}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Amz-Target", target)
+	return req
+}
+
+func formRequest(t *testing.T, action string) *http.Request {
+	body := url.Values{"Action": []string{action}, "Version": []string{"2014-10-31"}}.Encode()
+	req, err := http.NewRequest(http.MethodPost, "https://rds.us-east-1.amazonaws.com/", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	return req
+}
+
+func TestHTTPClient(t *testing.T) {
+	t.Run("read-only JSON action is retried with the same body", func(t *testing.T) {
+		httpCtx := &fakeHTTP{attempts: []attempt{{err: resetError()}, {response: okResponse()}}}
+		client := newTestHTTPClient(httpCtx, DefaultHTTPClientOptions)
+
+		res, err := client.Do(jsonRequest(t, "AmazonEC2ContainerRegistry_V20150921.DescribeRepositories"))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Len(t, httpCtx.requests, 2)
+		assert.Equal(t, []string{`{"repositoryName":"app"}`, `{"repositoryName":"app"}`}, httpCtx.bodies)
+	})
+
+	t.Run("read-only query action is retried", func(t *testing.T) {
+		httpCtx := &fakeHTTP{attempts: []attempt{{err: resetError()}, {err: io.ErrUnexpectedEOF}, {response: okResponse()}}}
+		client := newTestHTTPClient(httpCtx, DefaultHTTPClientOptions)
+
+		_, err := client.Do(formRequest(t, "DescribeDBInstances"))
+		require.NoError(t, err)
+		require.Len(t, httpCtx.requests, 3)
+	})
+
+	t.Run("mutating action is not retried", func(t *testing.T) {
+		httpCtx := &fakeHTTP{attempts: []attempt{{err: resetError()}, {response: okResponse()}}}
+		client := newTestHTTPClient(httpCtx, DefaultHTTPClientOptions)
+
+		_, err := client.Do(formRequest(t, "CreateDBSnapshot"))
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+		require.Len(t, httpCtx.requests, 1)
+	})
+
+	t.Run("non-transient errors are not retried", func(t *testing.T) {
+		httpCtx := &fakeHTTP{attempts: []attempt{{err: fmt.Errorf("connection blocked")}, {response: okResponse()}}}
+		client := newTestHTTPClient(httpCtx, DefaultHTTPClientOptions)
+
+		_, err := client.Do(jsonRequest(t, "secretsmanager.GetSecretValue"))
+		require.ErrorContains(t, err, "connection blocked")
+		require.Len(t, httpCtx.requests, 1)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		httpCtx := &fakeHTTP{attempts: []attempt{{err: resetError()}, {err: resetError()}, {response: okResponse()}}}
+		client := newTestHTTPClient(httpCtx, HTTPClientOptions{Timeout: time.Second, MaxRetries: 1})
+
+		_, err := client.Do(jsonRequest(t, "secretsmanager.ListSecrets"))
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+		require.Len(t, httpCtx.requests, 2)
+	})
+
+	t.Run("each attempt has a timeout, released when the body is closed", func(t *testing.T) {
+		httpCtx := &fakeHTTP{attempts: []attempt{{response: okResponse()}}}
+		client := newTestHTTPClient(httpCtx, DefaultHTTPClientOptions)
+
+		res, err := client.Do(jsonRequest(t, "secretsmanager.PutSecretValue"))
+		require.NoError(t, err)
+
+		ctx := httpCtx.requests[0].Context()
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(DefaultHTTPClientOptions.Timeout), deadline, time.Second)
+		require.NoError(t, ctx.Err())
+
+		require.NoError(t, res.Body.Close())
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("canceled requests are not retried", func(t *testing.T) {
+		httpCtx := &fakeHTTP{attempts: []attempt{{err: resetError()}, {response: okResponse()}}}
+		client := newTestHTTPClient(httpCtx, DefaultHTTPClientOptions)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.Do(jsonRequest(t, "secretsmanager.ListSecrets").WithContext(ctx))
+		require.Error(t, err)
+		require.Len(t, httpCtx.requests, 1)
+	})
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"strings"
+)
+
+/*
+ * DefaultMaxPages is the number of pages fetched by CollectPages.
+ * It bounds the memory used by a single listing,
+ * e.g. 100 pages of 100 results are 10k resources.
+ */
+const DefaultMaxPages = 100
+
+/*
+ * FetchPage fetches the page that starts at the given token,
+ * and returns its items and the token of the next page.
+ * The token is empty for the first page, and the next token is empty for the last one.
+ */
+type FetchPage[T any] func(token string) ([]T, string, error)
+
+/*
+ * Paginate fetches pages until the last one, or until maxPages pages were fetched,
+ * calling onPage with the items of each page. onPage returns false to stop early.
+ *
+ * Tokens are trimmed, since the XML APIs return them with surrounding whitespace,
+ * and pagination also stops if a page returns the token used to fetch it,
+ * so a misbehaving API can't keep us in a loop.
+ */
+func Paginate[T any](maxPages int, fetch FetchPage[T], onPage func(items []T) (bool, error)) error {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	token := ""
+	for page := 0; page < maxPages; page++ {
+		items, nextToken, err := fetch(token)
+		if err != nil {
+			return err
+		}
+
+		more, err := onPage(items)
+		if err != nil {
+			return err
+		}
+
+		nextToken = strings.TrimSpace(nextToken)
+		if !more || nextToken == "" || nextToken == token {
+			return nil
+		}
+
+		token = nextToken
+	}
+
+	return nil
+}
+
+/*
+ * CollectPages returns the items of all pages, up to DefaultMaxPages pages.
+ * Items of the pages after that are not returned.
+ */
+func CollectPages[T any](fetch FetchPage[T]) ([]T, error) {
+	all := []T{}
+	err := Paginate(DefaultMaxPages, fetch, func(items []T) (bool, error) {
+		all = append(all, items...)
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := map[string]struct {
+		items     []int
+		nextToken string
+	}{
+		"":   {items: []int{1, 2}, nextToken: "p2"},
+		"p2": {items: []int{3, 4}, nextToken: " p3\n"},
+		"p3": {items: []int{5}, nextToken: ""},
+	}
+
+	fetch := func(tokens *[]string) FetchPage[int] {
+		return func(token string) ([]int, string, error) {
+			*tokens = append(*tokens, token)
+			page, ok := pages[token]
+			if !ok {
+				return nil, "", fmt.Errorf("unexpected token %q", token)
+			}
+
+			return page.items, page.nextToken, nil
+		}
+	}
+
+	t.Run("collects the items of all pages", func(t *testing.T) {
+		tokens := []string{}
+		items, err := CollectPages(fetch(&tokens))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+		assert.Equal(t, []string{"", "p2", "p3"}, tokens)
+	})
+
+	t.Run("stops after max pages", func(t *testing.T) {
+		tokens := []string{}
+		items := []int{}
+		err := Paginate(2, fetch(&tokens), func(page []int) (bool, error) {
+			items = append(items, page...)
+			return true, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, items)
+		assert.Equal(t, []string{"", "p2"}, tokens)
+	})
+
+	t.Run("stops when the page callback returns false", func(t *testing.T) {
+		tokens := []string{}
+		err := Paginate(0, fetch(&tokens), func(page []int) (bool, error) {
+			return false, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{""}, tokens)
+	})
+
+	t.Run("stops when a page returns the same token", func(t *testing.T) {
+		calls := 0
+		items, err := CollectPages(func(token string) ([]int, string, error) {
+			calls++
+			return []int{calls}, "same", nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("default max pages bounds the listing", func(t *testing.T) {
+		calls := 0
+		items, err := CollectPages(func(token string) ([]int, string, error) {
+			calls++
+			return []int{calls}, fmt.Sprintf("p%d", calls), nil
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, items, DefaultMaxPages)
+	})
+
+	t.Run("returns fetch and callback errors", func(t *testing.T) {
+		_, err := CollectPages(func(token string) ([]int, string, error) {
+			return nil, "", fmt.Errorf("throttled")
+		})
+		require.ErrorContains(t, err, "throttled")
+
+		tokens := []string{}
+		err = Paginate(0, fetch(&tokens), func(page []int) (bool, error) {
+			return false, fmt.Errorf("callback failed")
+		})
+		require.ErrorContains(t, err, "callback failed")
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -78,6 +79,54 @@ type EnableImageDeprecationOutput struct {
 	DeprecateAt string `json:"deprecateAt" mapstructure:"deprecateAt"`
 }
 
+type CreateSnapshotInput struct {
+	VolumeID    string
+	Description string
+	Tags        []common.Tag
+}
+
+type CopySnapshotInput struct {
+	SourceSnapshotID string
+	SourceRegion     string
+	Description      string
+	Encrypted        bool
+	KmsKeyID         string
+	Tags             []common.Tag
+}
+
+type Snapshot struct {
+	SnapshotID   string       `json:"snapshotId" mapstructure:"snapshotId"`
+	VolumeID     string       `json:"volumeId" mapstructure:"volumeId"`
+	State        string       `json:"state" mapstructure:"state"`
+	StateMessage string       `json:"stateMessage" mapstructure:"stateMessage"`
+	Progress     string       `json:"progress" mapstructure:"progress"`
+	StartTime    string       `json:"startTime" mapstructure:"startTime"`
+	Description  string       `json:"description" mapstructure:"description"`
+	VolumeSize   int          `json:"volumeSize" mapstructure:"volumeSize"`
+	OwnerID      string       `json:"ownerId" mapstructure:"ownerId"`
+	Encrypted    bool         `json:"encrypted" mapstructure:"encrypted"`
+	KmsKeyID     string       `json:"kmsKeyId" mapstructure:"kmsKeyId"`
+	Tags         []common.Tag `json:"tags" mapstructure:"tags"`
+	Region       string       `json:"region" mapstructure:"region"`
+}
+
+/*
+ * LaunchPermissionChanges are the accounts and organizations
+ * that are granted or denied permission to launch an image.
+ */
+type LaunchPermissionChanges struct {
+	AddAccountIDs          []string
+	RemoveAccountIDs       []string
+	AddOrganizationARNs    []string
+	RemoveOrganizationARNs []string
+}
+
+type LaunchPermissions struct {
+	AccountIDs       []string `json:"accountIds" mapstructure:"accountIds"`
+	OrganizationARNs []string `json:"organizationArns" mapstructure:"organizationArns"`
+	Public           bool     `json:"public" mapstructure:"public"`
+}
+
 type Image struct {
 	RequestID           string                    `json:"requestId" mapstructure:"requestId"`
 	ImageID             string                    `json:"imageId" mapstructure:"imageId"`
@@ -121,7 +170,7 @@ type ImageEbsDetails struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      region,
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -242,6 +291,207 @@ func (c *Client) DisableImageDeprecation(imageID string) (string, error) {
 	return c.runImageBooleanAction("DisableImageDeprecation", imageID, nil)
 }
 
+func (c *Client) CreateSnapshot(input CreateSnapshotInput) (*Snapshot, error) {
+	params := url.Values{}
+	params.Set("VolumeId", strings.TrimSpace(input.VolumeID))
+
+	description := strings.TrimSpace(input.Description)
+	if description != "" {
+		params.Set("Description", description)
+	}
+
+	setSnapshotTagSpecification(params, input.Tags)
+
+	response := xmlSnapshot{}
+	if err := c.postForm("CreateSnapshot", params, &response); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(response.SnapshotID) == "" {
+		return nil, fmt.Errorf("response did not include snapshot ID")
+	}
+
+	snapshot := c.snapshotFromXML(response)
+	if len(snapshot.Tags) == 0 {
+		snapshot.Tags = input.Tags
+	}
+
+	return snapshot, nil
+}
+
+/*
+ * CopySnapshot copies a snapshot from the source region into the region of the client.
+ * The Query API needs a presigned CopySnapshot request for the source region
+ * to copy encrypted snapshots, so one is always included.
+ */
+func (c *Client) CopySnapshot(input CopySnapshotInput) (string, string, error) {
+	sourceSnapshotID := strings.TrimSpace(input.SourceSnapshotID)
+	sourceRegion := strings.TrimSpace(input.SourceRegion)
+
+	presignedURL, err := c.presignCopySnapshot(sourceSnapshotID, sourceRegion)
+	if err != nil {
+		return "", "", err
+	}
+
+	params := url.Values{}
+	params.Set("SourceSnapshotId", sourceSnapshotID)
+	params.Set("SourceRegion", sourceRegion)
+	params.Set("DestinationRegion", c.region)
+	params.Set("PresignedUrl", presignedURL)
+
+	description := strings.TrimSpace(input.Description)
+	if description != "" {
+		params.Set("Description", description)
+	}
+
+	if input.Encrypted {
+		params.Set("Encrypted", "true")
+	}
+
+	kmsKeyID := strings.TrimSpace(input.KmsKeyID)
+	if kmsKeyID != "" {
+		params.Set("KmsKeyId", kmsKeyID)
+	}
+
+	setSnapshotTagSpecification(params, input.Tags)
+
+	response := copySnapshotResponse{}
+	if err := c.postForm("CopySnapshot", params, &response); err != nil {
+		return "", "", err
+	}
+
+	if strings.TrimSpace(response.SnapshotID) == "" {
+		return "", "", fmt.Errorf("response did not include snapshot ID")
+	}
+
+	return strings.TrimSpace(response.SnapshotID), strings.TrimSpace(response.RequestID), nil
+}
+
+func (c *Client) presignCopySnapshot(sourceSnapshotID, sourceRegion string) (string, error) {
+	query := url.Values{}
+	query.Set("Action", "CopySnapshot")
+	query.Set("Version", apiVersion)
+	query.Set("SourceSnapshotId", sourceSnapshotID)
+	query.Set("SourceRegion", sourceRegion)
+	query.Set("DestinationRegion", c.region)
+	query.Set("X-Amz-Expires", "3600")
+
+	endpoint := fmt.Sprintf("https://ec2.%s.amazonaws.com/?%s", sourceRegion, query.Encode())
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build presigned request: %w", err)
+	}
+
+	hash := sha256.Sum256([]byte{})
+	presignedURL, _, err := c.signer.PresignHTTP(
+		context.Background(),
+		*c.credentials,
+		req,
+		hex.EncodeToString(hash[:]),
+		serviceName,
+		sourceRegion,
+		time.Now(),
+	)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to presign request: %w", err)
+	}
+
+	return presignedURL, nil
+}
+
+func (c *Client) DescribeSnapshot(snapshotID string) (*Snapshot, error) {
+	params := url.Values{}
+	params.Set("SnapshotId.1", strings.TrimSpace(snapshotID))
+
+	response := describeSnapshotsResponse{}
+	if err := c.postForm("DescribeSnapshots", params, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Snapshots) == 0 {
+		return nil, fmt.Errorf("snapshot not found: %s", snapshotID)
+	}
+
+	return c.snapshotFromXML(response.Snapshots[0]), nil
+}
+
+func setSnapshotTagSpecification(params url.Values, tags []common.Tag) {
+	if len(tags) == 0 {
+		return
+	}
+
+	params.Set("TagSpecification.1.ResourceType", "snapshot")
+	for i, tag := range tags {
+		params.Set(fmt.Sprintf("TagSpecification.1.Tag.%d.Key", i+1), tag.Key)
+		params.Set(fmt.Sprintf("TagSpecification.1.Tag.%d.Value", i+1), tag.Value)
+	}
+}
+
+func (c *Client) ModifyImageLaunchPermission(imageID string, changes LaunchPermissionChanges) (string, error) {
+	params := url.Values{}
+	setLaunchPermissionParams(params, "Add", "UserId", changes.AddAccountIDs)
+	setLaunchPermissionParams(params, "Add", "OrganizationArn", changes.AddOrganizationARNs)
+	setLaunchPermissionParams(params, "Remove", "UserId", changes.RemoveAccountIDs)
+	setLaunchPermissionParams(params, "Remove", "OrganizationArn", changes.RemoveOrganizationARNs)
+	return c.runImageBooleanAction("ModifyImageAttribute", imageID, params)
+}
+
+func setLaunchPermissionParams(params url.Values, operation, field string, values []string) {
+	for i, value := range values {
+		params.Set(fmt.Sprintf("LaunchPermission.%s.%d.%s", operation, i+1, field), strings.TrimSpace(value))
+	}
+}
+
+func (c *Client) DescribeImageLaunchPermissions(imageID string) (*LaunchPermissions, error) {
+	params := url.Values{}
+	params.Set("ImageId", strings.TrimSpace(imageID))
+	params.Set("Attribute", "launchPermission")
+
+	response := describeImageAttributeResponse{}
+	if err := c.postForm("DescribeImageAttribute", params, &response); err != nil {
+		return nil, err
+	}
+
+	permissions := &LaunchPermissions{
+		AccountIDs:       []string{},
+		OrganizationARNs: []string{},
+	}
+
+	for _, permission := range response.LaunchPermissions {
+		switch {
+		case strings.TrimSpace(permission.UserID) != "":
+			permissions.AccountIDs = append(permissions.AccountIDs, strings.TrimSpace(permission.UserID))
+		case strings.TrimSpace(permission.OrganizationARN) != "":
+			permissions.OrganizationARNs = append(permissions.OrganizationARNs, strings.TrimSpace(permission.OrganizationARN))
+		case strings.TrimSpace(permission.Group) == "all":
+			permissions.Public = true
+		}
+	}
+
+	return permissions, nil
+}
+
+func (c *Client) CreateTags(resourceID string, tags []common.Tag) (string, error) {
+	params := url.Values{}
+	params.Set("ResourceId.1", strings.TrimSpace(resourceID))
+	for i, tag := range tags {
+		params.Set(fmt.Sprintf("Tag.%d.Key", i+1), tag.Key)
+		params.Set(fmt.Sprintf("Tag.%d.Value", i+1), tag.Value)
+	}
+
+	response := imageActionResponse{}
+	if err := c.postForm("CreateTags", params, &response); err != nil {
+		return "", err
+	}
+
+	if !response.Return {
+		return "", fmt.Errorf("CreateTags returned unsuccessful response")
+	}
+
+	return strings.TrimSpace(response.RequestID), nil
+}
+
 func (c *Client) DescribeImage(imageID string) (*Image, error) {
 	params := url.Values{}
 	params.Set("ImageId.1", strings.TrimSpace(imageID))
@@ -260,25 +510,23 @@ func (c *Client) DescribeImage(imageID string) (*Image, error) {
 }
 
 func (c *Client) ListInstances() ([]Instance, error) {
-	instances := []Instance{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(token string) ([]Instance, string, error) {
 		params := url.Values{}
 		params.Set("MaxResults", "100")
 		params.Set("Filter.1.Name", "instance-state-name")
 		params.Set("Filter.1.Value.1", "running")
 		params.Set("Filter.1.Value.2", "stopped")
 
-		if nextToken != "" {
-			params.Set("NextToken", nextToken)
+		if token != "" {
+			params.Set("NextToken", token)
 		}
 
 		response := describeInstancesResponse{}
 		if err := c.postForm("DescribeInstances", params, &response); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
+		instances := []Instance{}
 		for _, reservation := range response.Reservations {
 			for _, instance := range reservation.Instances {
 				instances = append(instances, Instance{
@@ -290,48 +538,49 @@ func (c *Client) ListInstances() ([]Instance, error) {
 			}
 		}
 
-		nextToken = strings.TrimSpace(response.NextToken)
-		if nextToken == "" {
-			break
-		}
-	}
-
-	return instances, nil
+		return instances, response.NextToken, nil
+	})
 }
 
 func (c *Client) ListImages(ownerID string, includeDisabled bool) ([]Image, error) {
-	images := []Image{}
-	nextToken := ""
-	trimmedOwnerID := strings.TrimSpace(ownerID)
+	return common.CollectPages(func(token string) ([]Image, string, error) {
+		return c.ListImagesPage(ownerID, includeDisabled, "", 100, token)
+	})
+}
 
-	for {
-		params := url.Values{}
-		params.Set("MaxResults", "100")
-		params.Set("Owner.1", trimmedOwnerID)
-		if includeDisabled {
-			params.Set("IncludeDisabled", "true")
-		}
+/*
+ * ListImagesPage lists a single page of images.
+ * If nameFilter is set, only images whose name contains it are returned.
+ * EC2 filters are case-sensitive.
+ */
+func (c *Client) ListImagesPage(ownerID string, includeDisabled bool, nameFilter string, maxResults int, nextToken string) ([]Image, string, error) {
+	params := url.Values{}
+	params.Set("MaxResults", strconv.Itoa(maxResults))
+	params.Set("Owner.1", strings.TrimSpace(ownerID))
+	if includeDisabled {
+		params.Set("IncludeDisabled", "true")
+	}
 
-		if nextToken != "" {
-			params.Set("NextToken", nextToken)
-		}
+	if nameFilter = strings.TrimSpace(nameFilter); nameFilter != "" {
+		params.Set("Filter.1.Name", "name")
+		params.Set("Filter.1.Value.1", "*"+nameFilter+"*")
+	}
 
-		response := describeImagesResponse{}
-		if err := c.postForm("DescribeImages", params, &response); err != nil {
-			return nil, err
-		}
+	if nextToken != "" {
+		params.Set("NextToken", nextToken)
+	}
 
-		for _, image := range response.Images {
-			images = append(images, *imageFromXML(image))
-		}
+	response := describeImagesResponse{}
+	if err := c.postForm("DescribeImages", params, &response); err != nil {
+		return nil, "", err
+	}
 
-		nextToken = strings.TrimSpace(response.NextToken)
-		if nextToken == "" {
-			break
-		}
+	images := make([]Image, 0, len(response.Images))
+	for _, image := range response.Images {
+		images = append(images, *imageFromXML(image))
 	}
 
-	return images, nil
+	return images, strings.TrimSpace(response.NextToken), nil
 }
 
 func (c *Client) runImageBooleanAction(action, imageID string, additionalParams url.Values) (string, error) {
@@ -455,6 +704,65 @@ type imageActionResponse struct {
 	Return    bool   `xml:"return"`
 }
 
+type copySnapshotResponse struct {
+	RequestID  string `xml:"requestId"`
+	SnapshotID string `xml:"snapshotId"`
+}
+
+type describeSnapshotsResponse struct {
+	RequestID string        `xml:"requestId"`
+	Snapshots []xmlSnapshot `xml:"snapshotSet>item"`
+}
+
+type xmlSnapshot struct {
+	SnapshotID    string   `xml:"snapshotId"`
+	VolumeID      string   `xml:"volumeId"`
+	Status        string   `xml:"status"`
+	StatusMessage string   `xml:"statusMessage"`
+	Progress      string   `xml:"progress"`
+	StartTime     string   `xml:"startTime"`
+	Description   string   `xml:"description"`
+	VolumeSize    int      `xml:"volumeSize"`
+	OwnerID       string   `xml:"ownerId"`
+	Encrypted     bool     `xml:"encrypted"`
+	KmsKeyID      string   `xml:"kmsKeyId"`
+	Tags          []xmlTag `xml:"tagSet>item"`
+}
+
+func (c *Client) snapshotFromXML(snapshot xmlSnapshot) *Snapshot {
+	tags := make([]common.Tag, 0, len(snapshot.Tags))
+	for _, tag := range snapshot.Tags {
+		tags = append(tags, common.Tag{Key: tag.Key, Value: tag.Value})
+	}
+
+	return &Snapshot{
+		SnapshotID:   strings.TrimSpace(snapshot.SnapshotID),
+		VolumeID:     strings.TrimSpace(snapshot.VolumeID),
+		State:        strings.TrimSpace(snapshot.Status),
+		StateMessage: strings.TrimSpace(snapshot.StatusMessage),
+		Progress:     strings.TrimSpace(snapshot.Progress),
+		StartTime:    strings.TrimSpace(snapshot.StartTime),
+		Description:  strings.TrimSpace(snapshot.Description),
+		VolumeSize:   snapshot.VolumeSize,
+		OwnerID:      strings.TrimSpace(snapshot.OwnerID),
+		Encrypted:    snapshot.Encrypted,
+		KmsKeyID:     strings.TrimSpace(snapshot.KmsKeyID),
+		Tags:         tags,
+		Region:       c.region,
+	}
+}
+
+type describeImageAttributeResponse struct {
+	RequestID         string                `xml:"requestId"`
+	LaunchPermissions []xmlLaunchPermission `xml:"launchPermission>item"`
+}
+
+type xmlLaunchPermission struct {
+	UserID          string `xml:"userId"`
+	OrganizationARN string `xml:"organizationArn"`
+	Group           string `xml:"group"`
+}
+
 type describeInstancesResponse struct {
 	Reservations []xmlReservation `xml:"reservationSet>item"`
 	NextToken    string           `xml:"nextToken"`
@@ -0,0 +1,271 @@
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+type CopySnapshot struct{}
+
+type CopySnapshotConfiguration struct {
+	SourceRegion      string       `json:"sourceRegion" mapstructure:"sourceRegion"`
+	SourceSnapshotID  string       `json:"sourceSnapshotId" mapstructure:"sourceSnapshotId"`
+	Region            string       `json:"region" mapstructure:"region"`
+	Description       string       `json:"description" mapstructure:"description"`
+	Encrypted         bool         `json:"encrypted" mapstructure:"encrypted"`
+	KmsKeyID          string       `json:"kmsKeyId" mapstructure:"kmsKeyId"`
+	Tags              []common.Tag `json:"tags" mapstructure:"tags"`
+	WaitForCompletion *bool        `json:"waitForCompletion,omitempty" mapstructure:"waitForCompletion,omitempty"`
+}
+
+func (c *CopySnapshotConfiguration) ShouldWait() bool {
+	return c.WaitForCompletion == nil || *c.WaitForCompletion
+}
+
+func (c *CopySnapshot) Name() string {
+	return "aws.ec2.copySnapshot"
+}
+
+func (c *CopySnapshot) Label() string {
+	return "EC2 • Copy Snapshot"
+}
+
+func (c *CopySnapshot) Description() string {
+	return "Copy an EBS snapshot to another region"
+}
+
+func (c *CopySnapshot) Documentation() string {
+	return `The Copy Snapshot component copies an EBS snapshot, usually to another region.
+
+## Use Cases
+
+- **Disaster recovery**: Keep copies of backups in a secondary region
+- **Encryption**: Encrypt an unencrypted snapshot, or re-encrypt it with another KMS key
+- **Migrations**: Move volumes to another region through their snapshots
+
+## Configuration
+
+- **Source Region**: AWS region where the snapshot exists
+- **Source Snapshot ID**: Snapshot to copy. It must be completed
+- **Destination Region**: AWS region where the copy is created
+- **Description**: Optional description of the copy
+- **Encrypted**: Encrypt the copy. Copies of encrypted snapshots are always encrypted
+- **KMS Key ID**: Optional KMS key of the destination region used to encrypt the copy. Defaults to the EBS default key
+- **Tags**: Optional tags for the copy
+- **Wait for completion**: Wait until the copy is ` + "`completed`" + ` before emitting. Enabled by default
+
+## Completion behavior
+
+- When waiting, the state of the copy is checked every 30 seconds.
+- The execution fails if the state of the copy becomes ` + "`error`" + `.`
+}
+
+func (c *CopySnapshot) Icon() string {
+	return "aws"
+}
+
+func (c *CopySnapshot) Color() string {
+	return "gray"
+}
+
+func (c *CopySnapshot) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CopySnapshot) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "sourceRegion",
+			Label:    "Source Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "sourceSnapshotId",
+			Label:       "Source Snapshot ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "snap-1234567890abcdef0",
+			Description: "Snapshot in the source region",
+		},
+		{
+			Name:     "region",
+			Label:    "Destination Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-west-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "Optional snapshot description",
+		},
+		{
+			Name:        "encrypted",
+			Label:       "Encrypted",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Encrypt the copy of the snapshot",
+		},
+		{
+			Name:        "kmsKeyId",
+			Label:       "KMS Key ID",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "arn:aws:kms:us-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			Description: "KMS key of the destination region used to encrypt the copy",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "encrypted", Values: []string{"true"}},
+			},
+		},
+		tagsField("Tags to add to the copy of the snapshot"),
+		{
+			Name:        "waitForCompletion",
+			Label:       "Wait for completion",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Wait until the copy is completed before emitting",
+		},
+	}
+}
+
+func decodeCopySnapshotConfiguration(raw any) (CopySnapshotConfiguration, error) {
+	config := CopySnapshotConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CopySnapshotConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, err := requireRegion(config.Region)
+	if err != nil {
+		return CopySnapshotConfiguration{}, err
+	}
+
+	config.Region = region
+	config.SourceRegion = strings.TrimSpace(config.SourceRegion)
+	config.SourceSnapshotID = strings.TrimSpace(config.SourceSnapshotID)
+	config.KmsKeyID = strings.TrimSpace(config.KmsKeyID)
+	config.Tags = common.NormalizeTags(config.Tags)
+
+	if config.SourceRegion == "" {
+		return CopySnapshotConfiguration{}, fmt.Errorf("source region is required")
+	}
+
+	if config.SourceSnapshotID == "" {
+		return CopySnapshotConfiguration{}, fmt.Errorf("source snapshot ID is required")
+	}
+
+	if config.KmsKeyID != "" && !config.Encrypted {
+		return CopySnapshotConfiguration{}, fmt.Errorf("KMS key ID requires encrypted to be enabled")
+	}
+
+	return config, nil
+}
+
+func (c *CopySnapshot) Setup(ctx core.SetupContext) error {
+	_, err := decodeCopySnapshotConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *CopySnapshot) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CopySnapshot) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCopySnapshotConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, creds, config.Region)
+	snapshotID, requestID, err := client.CopySnapshot(CopySnapshotInput{
+		SourceSnapshotID: config.SourceSnapshotID,
+		SourceRegion:     config.SourceRegion,
+		Description:      config.Description,
+		Encrypted:        config.Encrypted,
+		KmsKeyID:         config.KmsKeyID,
+		Tags:             config.Tags,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to copy snapshot: %w", err)
+	}
+
+	metadata := SnapshotExecutionMetadata{
+		Region:           config.Region,
+		SnapshotID:       snapshotID,
+		State:            SnapshotStatePending,
+		SourceSnapshotID: config.SourceSnapshotID,
+		SourceRegion:     config.SourceRegion,
+		RequestID:        requestID,
+	}
+
+	if !config.ShouldWait() {
+		return checkSnapshot(ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.Requests, ctx.ExecutionState, metadata, false)
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	return ctx.Requests.ScheduleActionCall(snapshotPollAction, map[string]any{}, snapshotPollInterval)
+}
+
+func (c *CopySnapshot) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        snapshotPollAction,
+			Description: "Check the state of the copy of the snapshot",
+		},
+	}
+}
+
+func (c *CopySnapshot) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case snapshotPollAction:
+		return pollSnapshot(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *CopySnapshot) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CopySnapshot) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CopySnapshot) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
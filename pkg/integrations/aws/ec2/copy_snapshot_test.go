@@ -0,0 +1,136 @@
+package ec2
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CopySnapshot__Setup(t *testing.T) {
+	component := &CopySnapshot{}
+
+	t.Run("source snapshot ID is required -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-west-1", "sourceRegion": "us-east-1"},
+		})
+
+		require.ErrorContains(t, err, "source snapshot ID is required")
+	})
+
+	t.Run("KMS key without encryption -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":           "us-west-1",
+				"sourceRegion":     "us-east-1",
+				"sourceSnapshotId": "snap-123",
+				"kmsKeyId":         "alias/backups",
+			},
+		})
+
+		require.ErrorContains(t, err, "KMS key ID requires encrypted")
+	})
+}
+
+func Test__CopySnapshot__Execute(t *testing.T) {
+	component := &CopySnapshot{}
+	copyResponse := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+				<CopySnapshotResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+					<requestId>req-copy-snapshot</requestId>
+					<snapshotId>snap-123</snapshotId>
+				</CopySnapshotResponse>
+			`)),
+		}
+	}
+
+	t.Run("encrypted copy -> sends presigned request and schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{copyResponse()}}
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"sourceRegion":     "us-east-1",
+				"sourceSnapshotId": "snap-source",
+				"region":           "us-west-1",
+				"encrypted":        true,
+				"kmsKeyId":         "alias/backups",
+			},
+			HTTP:           httpContext,
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, snapshotPollAction, requests.Action)
+
+		stored, ok := metadata.Metadata.(SnapshotExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "snap-123", stored.SnapshotID)
+		assert.Equal(t, "us-west-1", stored.Region)
+		assert.Equal(t, "snap-source", stored.SourceSnapshotID)
+		assert.Equal(t, "req-copy-snapshot", stored.RequestID)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://ec2.us-west-1.amazonaws.com/", httpContext.Requests[0].URL.String())
+		params, err := url.ParseQuery(testRequestBodyString(t, httpContext.Requests[0]))
+		require.NoError(t, err)
+		assert.Equal(t, "CopySnapshot", params.Get("Action"))
+		assert.Equal(t, "snap-source", params.Get("SourceSnapshotId"))
+		assert.Equal(t, "us-east-1", params.Get("SourceRegion"))
+		assert.Equal(t, "us-west-1", params.Get("DestinationRegion"))
+		assert.Equal(t, "true", params.Get("Encrypted"))
+		assert.Equal(t, "alias/backups", params.Get("KmsKeyId"))
+
+		presigned, err := url.Parse(params.Get("PresignedUrl"))
+		require.NoError(t, err)
+		assert.Equal(t, "ec2.us-east-1.amazonaws.com", presigned.Host)
+		assert.Equal(t, "CopySnapshot", presigned.Query().Get("Action"))
+		assert.Equal(t, "us-west-1", presigned.Query().Get("DestinationRegion"))
+		assert.NotEmpty(t, presigned.Query().Get("X-Amz-Signature"))
+	})
+
+	t.Run("does not wait -> emits copy", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"sourceRegion":      "us-east-1",
+				"sourceSnapshotId":  "snap-source",
+				"region":            "us-west-1",
+				"waitForCompletion": false,
+			},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				copyResponse(),
+				testDescribeSnapshotsResponse(SnapshotStatePending),
+			}},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, requests.Action)
+		require.Len(t, execState.Payloads, 1)
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "snap-source", output["sourceSnapshotId"])
+		assert.Equal(t, "us-east-1", output["sourceRegion"])
+		assert.Equal(t, "req-copy-snapshot", output["requestId"])
+		snapshot := output["snapshot"].(*Snapshot)
+		assert.Equal(t, "us-west-1", snapshot.Region)
+	})
+}
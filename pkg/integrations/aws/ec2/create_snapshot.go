@@ -0,0 +1,218 @@
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+type CreateSnapshot struct{}
+
+type CreateSnapshotConfiguration struct {
+	Region            string       `json:"region" mapstructure:"region"`
+	VolumeID          string       `json:"volumeId" mapstructure:"volumeId"`
+	Description       string       `json:"description" mapstructure:"description"`
+	Tags              []common.Tag `json:"tags" mapstructure:"tags"`
+	WaitForCompletion *bool        `json:"waitForCompletion,omitempty" mapstructure:"waitForCompletion,omitempty"`
+}
+
+func (c *CreateSnapshotConfiguration) ShouldWait() bool {
+	return c.WaitForCompletion == nil || *c.WaitForCompletion
+}
+
+func (c *CreateSnapshot) Name() string {
+	return "aws.ec2.createSnapshot"
+}
+
+func (c *CreateSnapshot) Label() string {
+	return "EC2 • Create Snapshot"
+}
+
+func (c *CreateSnapshot) Description() string {
+	return "Create a snapshot of an EBS volume"
+}
+
+func (c *CreateSnapshot) Documentation() string {
+	return `The Create Snapshot component creates a point-in-time snapshot of an EBS volume.
+
+## Use Cases
+
+- **Backups**: Snapshot volumes on a schedule or before risky changes
+- **Pre-deployment safety**: Snapshot a database volume before a migration
+- **Disaster recovery**: Snapshot volumes and copy them to another region
+
+## Configuration
+
+- **Region**: AWS region of the volume
+- **Volume ID**: EBS volume to snapshot
+- **Description**: Optional snapshot description
+- **Tags**: Optional tags for the snapshot
+- **Wait for completion**: Wait until the snapshot is ` + "`completed`" + ` before emitting. Enabled by default
+
+## Completion behavior
+
+- When waiting, the snapshot state is checked every 30 seconds.
+- The execution fails if the snapshot state becomes ` + "`error`" + `.
+- Without waiting, the snapshot is emitted right away, usually in the ` + "`pending`" + ` state.`
+}
+
+func (c *CreateSnapshot) Icon() string {
+	return "aws"
+}
+
+func (c *CreateSnapshot) Color() string {
+	return "gray"
+}
+
+func (c *CreateSnapshot) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateSnapshot) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "volumeId",
+			Label:       "Volume ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "vol-1234567890abcdef0",
+			Description: "EBS volume to snapshot",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "Optional snapshot description",
+		},
+		tagsField("Tags to add to the snapshot"),
+		{
+			Name:        "waitForCompletion",
+			Label:       "Wait for completion",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Wait until the snapshot is completed before emitting",
+		},
+	}
+}
+
+func decodeCreateSnapshotConfiguration(raw any) (CreateSnapshotConfiguration, error) {
+	config := CreateSnapshotConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CreateSnapshotConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, err := requireRegion(config.Region)
+	if err != nil {
+		return CreateSnapshotConfiguration{}, err
+	}
+
+	config.Region = region
+	config.VolumeID = strings.TrimSpace(config.VolumeID)
+	config.Tags = common.NormalizeTags(config.Tags)
+
+	if config.VolumeID == "" {
+		return CreateSnapshotConfiguration{}, fmt.Errorf("volume ID is required")
+	}
+
+	return config, nil
+}
+
+func (c *CreateSnapshot) Setup(ctx core.SetupContext) error {
+	_, err := decodeCreateSnapshotConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *CreateSnapshot) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateSnapshot) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCreateSnapshotConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, creds, config.Region)
+	snapshot, err := client.CreateSnapshot(CreateSnapshotInput{
+		VolumeID:    config.VolumeID,
+		Description: config.Description,
+		Tags:        config.Tags,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	metadata := SnapshotExecutionMetadata{
+		Region:     config.Region,
+		SnapshotID: snapshot.SnapshotID,
+		State:      snapshot.State,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if !config.ShouldWait() {
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, snapshotPayloadType, []any{snapshotPayload(snapshot, metadata)})
+	}
+
+	return ctx.Requests.ScheduleActionCall(snapshotPollAction, map[string]any{}, snapshotPollInterval)
+}
+
+func (c *CreateSnapshot) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        snapshotPollAction,
+			Description: "Check the state of the snapshot",
+		},
+	}
+}
+
+func (c *CreateSnapshot) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case snapshotPollAction:
+		return pollSnapshot(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *CreateSnapshot) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateSnapshot) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateSnapshot) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,201 @@
+package ec2
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testDescribeSnapshotsResponse(state string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`
+			<DescribeSnapshotsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+				<requestId>req-describe-snapshots</requestId>
+				<snapshotSet>
+					<item>
+						<snapshotId>snap-123</snapshotId>
+						<volumeId>vol-123</volumeId>
+						<status>` + state + `</status>
+						<progress>100%</progress>
+						<volumeSize>8</volumeSize>
+						<encrypted>true</encrypted>
+						<tagSet>
+							<item><key>Backup</key><value>nightly</value></item>
+						</tagSet>
+					</item>
+				</snapshotSet>
+			</DescribeSnapshotsResponse>
+		`)),
+	}
+}
+
+func Test__CreateSnapshot__Setup(t *testing.T) {
+	component := &CreateSnapshot{}
+
+	t.Run("volume ID is required -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1"},
+		})
+
+		require.ErrorContains(t, err, "volume ID is required")
+	})
+}
+
+func Test__CreateSnapshot__Execute(t *testing.T) {
+	component := &CreateSnapshot{}
+	createResponse := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+				<CreateSnapshotResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+					<requestId>req-create-snapshot</requestId>
+					<snapshotId>snap-123</snapshotId>
+					<volumeId>vol-123</volumeId>
+					<status>pending</status>
+					<volumeSize>8</volumeSize>
+				</CreateSnapshotResponse>
+			`)),
+		}
+	}
+
+	t.Run("waits for completion -> schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{createResponse()}}
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":      "us-east-1",
+				"volumeId":    "vol-123",
+				"description": "Nightly backup",
+				"tags": []any{
+					map[string]any{"key": "Backup", "value": "nightly"},
+				},
+			},
+			HTTP:           httpContext,
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, snapshotPollAction, requests.Action)
+		assert.Equal(t, snapshotPollInterval, requests.Duration)
+
+		stored, ok := metadata.Metadata.(SnapshotExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "snap-123", stored.SnapshotID)
+		assert.Equal(t, SnapshotStatePending, stored.State)
+
+		require.Len(t, httpContext.Requests, 1)
+		body := testRequestBodyString(t, httpContext.Requests[0])
+		assert.Contains(t, body, "Action=CreateSnapshot")
+		assert.Contains(t, body, "VolumeId=vol-123")
+		assert.Contains(t, body, "Description=Nightly+backup")
+		assert.Contains(t, body, "TagSpecification.1.ResourceType=snapshot")
+		assert.Contains(t, body, "TagSpecification.1.Tag.1.Key=Backup")
+		assert.Contains(t, body, "TagSpecification.1.Tag.1.Value=nightly")
+	})
+
+	t.Run("does not wait -> emits pending snapshot", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":            "us-east-1",
+				"volumeId":          "vol-123",
+				"waitForCompletion": false,
+			},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{createResponse()}},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, requests.Action)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, snapshotPayloadType, execState.Type)
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		snapshot, ok := output["snapshot"].(*Snapshot)
+		require.True(t, ok)
+		assert.Equal(t, "snap-123", snapshot.SnapshotID)
+		assert.Equal(t, SnapshotStatePending, snapshot.State)
+		assert.Equal(t, "us-east-1", snapshot.Region)
+	})
+}
+
+func Test__CreateSnapshot__PollSnapshot(t *testing.T) {
+	component := &CreateSnapshot{}
+	metadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: SnapshotExecutionMetadata{Region: "us-east-1", SnapshotID: "snap-123", State: SnapshotStatePending},
+		}
+	}
+
+	t.Run("still pending -> schedules next poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           snapshotPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeSnapshotsResponse(SnapshotStatePending)}},
+			Metadata:       metadata(),
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, snapshotPollAction, requests.Action)
+	})
+
+	t.Run("completed -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           snapshotPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeSnapshotsResponse(SnapshotStateCompleted)}},
+			Metadata:       metadata(),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		snapshot := output["snapshot"].(*Snapshot)
+		assert.Equal(t, SnapshotStateCompleted, snapshot.State)
+		assert.True(t, snapshot.Encrypted)
+		assert.Equal(t, "nightly", snapshot.Tags[0].Value)
+	})
+
+	t.Run("error -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           snapshotPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeSnapshotsResponse(SnapshotStateError)}},
+			Metadata:       metadata(),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Equal(t, "snapshot snap-123 failed", execState.FailureMessage)
+	})
+}
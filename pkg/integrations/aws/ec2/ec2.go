@@ -12,3 +12,9 @@ const (
 	ImageStateDeregistered = "deregistered"
 	ImageStateDisabled     = "disabled"
 )
+
+const (
+	SnapshotStatePending   = "pending"
+	SnapshotStateCompleted = "completed"
+	SnapshotStateError     = "error"
+)
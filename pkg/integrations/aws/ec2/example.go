@@ -34,6 +34,21 @@ var exampleOutputEnableImageDeprecationBytes []byte
 //go:embed example_output_disable_image_deprecation.json
 var exampleOutputDisableImageDeprecationBytes []byte
 
+//go:embed example_output_share_image.json
+var exampleOutputShareImageBytes []byte
+
+//go:embed example_output_wait_for_image.json
+var exampleOutputWaitForImageBytes []byte
+
+//go:embed example_output_create_snapshot.json
+var exampleOutputCreateSnapshotBytes []byte
+
+//go:embed example_output_copy_snapshot.json
+var exampleOutputCopySnapshotBytes []byte
+
+//go:embed example_output_get_snapshot.json
+var exampleOutputGetSnapshotBytes []byte
+
 var exampleDataOnImageOnce sync.Once
 var exampleDataOnImage map[string]any
 
@@ -61,6 +76,21 @@ var exampleOutputEnableImageDeprecation map[string]any
 var exampleOutputDisableImageDeprecationOnce sync.Once
 var exampleOutputDisableImageDeprecation map[string]any
 
+var exampleOutputShareImageOnce sync.Once
+var exampleOutputShareImage map[string]any
+
+var exampleOutputWaitForImageOnce sync.Once
+var exampleOutputWaitForImage map[string]any
+
+var exampleOutputCreateSnapshotOnce sync.Once
+var exampleOutputCreateSnapshot map[string]any
+
+var exampleOutputCopySnapshotOnce sync.Once
+var exampleOutputCopySnapshot map[string]any
+
+var exampleOutputGetSnapshotOnce sync.Once
+var exampleOutputGetSnapshot map[string]any
+
 func (t *OnImage) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnImageOnce, exampleDataOnImageBytes, &exampleDataOnImage)
 }
@@ -108,3 +138,23 @@ func (c *DisableImageDeprecation) ExampleOutput() map[string]any {
 		&exampleOutputDisableImageDeprecation,
 	)
 }
+
+func (c *ShareImage) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputShareImageOnce, exampleOutputShareImageBytes, &exampleOutputShareImage)
+}
+
+func (c *WaitForImage) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputWaitForImageOnce, exampleOutputWaitForImageBytes, &exampleOutputWaitForImage)
+}
+
+func (c *CreateSnapshot) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateSnapshotOnce, exampleOutputCreateSnapshotBytes, &exampleOutputCreateSnapshot)
+}
+
+func (c *CopySnapshot) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCopySnapshotOnce, exampleOutputCopySnapshotBytes, &exampleOutputCopySnapshot)
+}
+
+func (c *GetSnapshot) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetSnapshotOnce, exampleOutputGetSnapshotBytes, &exampleOutputGetSnapshot)
+}
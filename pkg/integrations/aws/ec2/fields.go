@@ -0,0 +1,36 @@
+package ec2
+
+import "github.com/superplanehq/superplane/pkg/configuration"
+
+func tagsField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "tags",
+		Label:       "Tags",
+		Type:        configuration.FieldTypeList,
+		Required:    false,
+		Togglable:   true,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Tag",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeObject,
+					Schema: []configuration.Field{
+						{
+							Name:     "key",
+							Label:    "Key",
+							Type:     configuration.FieldTypeString,
+							Required: true,
+						},
+						{
+							Name:     "value",
+							Label:    "Value",
+							Type:     configuration.FieldTypeString,
+							Required: false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
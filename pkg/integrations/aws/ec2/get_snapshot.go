@@ -0,0 +1,155 @@
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+type GetSnapshot struct{}
+
+type GetSnapshotConfiguration struct {
+	Region     string `json:"region" mapstructure:"region"`
+	SnapshotID string `json:"snapshotId" mapstructure:"snapshotId"`
+}
+
+func (c *GetSnapshot) Name() string {
+	return "aws.ec2.getSnapshot"
+}
+
+func (c *GetSnapshot) Label() string {
+	return "EC2 • Get Snapshot"
+}
+
+func (c *GetSnapshot) Description() string {
+	return "Get an EBS snapshot by ID"
+}
+
+func (c *GetSnapshot) Documentation() string {
+	return `The Get Snapshot component retrieves metadata for an EBS snapshot.
+
+## Use Cases
+
+- **Backup checks**: Check the state and progress of a snapshot
+- **Compliance**: Verify that backups are encrypted and tagged
+- **Traceability**: Resolve the volume and owner of a snapshot by ID
+
+## Configuration
+
+- **Region**: AWS region of the snapshot
+- **Snapshot ID**: Snapshot ID (for example: snap-1234567890abcdef0)`
+}
+
+func (c *GetSnapshot) Icon() string {
+	return "aws"
+}
+
+func (c *GetSnapshot) Color() string {
+	return "gray"
+}
+
+func (c *GetSnapshot) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetSnapshot) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "snapshotId",
+			Label:       "Snapshot ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "snap-1234567890abcdef0",
+		},
+	}
+}
+
+func (c *GetSnapshot) Setup(ctx core.SetupContext) error {
+	config := GetSnapshotConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = strings.TrimSpace(config.Region)
+	config.SnapshotID = strings.TrimSpace(config.SnapshotID)
+
+	if config.Region == "" {
+		return fmt.Errorf("region is required")
+	}
+	if config.SnapshotID == "" {
+		return fmt.Errorf("snapshot ID is required")
+	}
+
+	return nil
+}
+
+func (c *GetSnapshot) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetSnapshot) Execute(ctx core.ExecutionContext) error {
+	config := GetSnapshotConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = strings.TrimSpace(config.Region)
+	config.SnapshotID = strings.TrimSpace(config.SnapshotID)
+
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, creds, config.Region)
+	snapshot, err := client.DescribeSnapshot(config.SnapshotID)
+	if err != nil {
+		return fmt.Errorf("failed to describe snapshot: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		snapshotPayloadType,
+		[]any{map[string]any{
+			"snapshot": snapshot,
+		}},
+	)
+}
+
+func (c *GetSnapshot) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *GetSnapshot) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *GetSnapshot) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *GetSnapshot) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetSnapshot) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,39 @@
+package ec2
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetSnapshot__Execute(t *testing.T) {
+	component := &GetSnapshot{}
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{testDescribeSnapshotsResponse(SnapshotStateCompleted)}}
+	execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+	err := component.Execute(core.ExecutionContext{
+		Configuration:  map[string]any{"region": "us-east-1", "snapshotId": " snap-123 "},
+		HTTP:           httpContext,
+		ExecutionState: execState,
+		Integration:    testIntegrationWithCredentials(),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, execState.Payloads, 1)
+	assert.Equal(t, snapshotPayloadType, execState.Type)
+
+	output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	snapshot := output["snapshot"].(*Snapshot)
+	assert.Equal(t, "snap-123", snapshot.SnapshotID)
+	assert.Equal(t, "vol-123", snapshot.VolumeID)
+	assert.Equal(t, 8, snapshot.VolumeSize)
+
+	require.Len(t, httpContext.Requests, 1)
+	body := testRequestBodyString(t, httpContext.Requests[0])
+	assert.Contains(t, body, "Action=DescribeSnapshots")
+	assert.Contains(t, body, "SnapshotId.1=snap-123")
+}
@@ -51,11 +51,12 @@ func (p *OnImage) Documentation() string {
 - **Image pipeline orchestration**: Continue workflows when a new AMI becomes available
 - **Failure handling**: Alert and remediate when AMI creation fails
 - **Compliance workflows**: Run validation and distribution after image creation
+- **Bake tracking**: Record when an image build starts, with the pending state
 
 ## Configuration
 
 - **Region**: AWS region where AMI state changes are monitored
-- **Image State**: State to trigger on (pending, available, failed)
+- **Image State**: States to trigger on (pending, available, failed, deregistered, disabled)
 
 ## Event Data
 
@@ -97,6 +98,7 @@ func (p *OnImage) Configuration() []configuration.Field {
 			TypeOptions: &configuration.TypeOptions{
 				MultiSelect: &configuration.MultiSelectTypeOptions{
 					Options: []configuration.FieldOption{
+						{Label: "Pending", Value: ImageStatePending},
 						{Label: "Available", Value: ImageStateAvailable},
 						{Label: "Failed", Value: ImageStateFailed},
 						{Label: "Deregistered", Value: ImageStateDeregistered},
@@ -105,6 +107,7 @@ func (p *OnImage) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -265,7 +268,8 @@ func (p *OnImage) OnIntegrationMessage(ctx core.IntegrationMessageContext) error
 		}
 	}
 
-	return ctx.Events.Emit("aws.ec2.image", ctx.Message)
+	_, err := core.EmitDeduplicated(ctx.Events, event.ID, "aws.ec2.image", ctx.Message)
+	return err
 }
 
 func (p *OnImage) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
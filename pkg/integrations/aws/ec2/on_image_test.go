@@ -213,4 +213,26 @@ func Test__OnImage__OnIntegrationMessage(t *testing.T) {
 		assert.Equal(t, 1, eventContext.Count())
 		assert.Equal(t, "aws.ec2.image", eventContext.Payloads[0].Type)
 	})
+
+	t.Run("pending state selected -> emits", func(t *testing.T) {
+		eventContext := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Logger: logrus.NewEntry(logrus.New()),
+			Events: eventContext,
+			NodeMetadata: &contexts.MetadataContext{
+				Metadata: OnImageMetadata{Region: "us-east-1"},
+			},
+			Configuration: OnImageConfiguration{States: []string{ImageStatePending, ImageStateAvailable}},
+			Message: common.EventBridgeEvent{
+				Region: "us-east-1",
+				Detail: map[string]any{
+					"ImageId": "ami-123",
+					"State":   "Pending",
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, eventContext.Count())
+	})
 }
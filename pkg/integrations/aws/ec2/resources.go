@@ -39,43 +39,85 @@ func ListInstances(ctx core.ListResourcesContext, resourceType string) ([]core.I
 }
 
 func ListImages(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
-	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	client, accountID, includeDisabled, err := imageListClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := client.ListImages(accountID, includeDisabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list EC2 images: %w", err)
+	}
+
+	return imageResources(images, resourceType), nil
+}
+
+/*
+ * ListImagePage searches and paginates images through DescribeImages,
+ * since accounts can have thousands of AMIs.
+ */
+func ListImagePage(ctx core.ListResourcesContext, resourceType string) (*core.ResourcePage, error) {
+	if ctx.Query == "" && ctx.PageToken == "" && ctx.PageSize <= 0 {
+		resources, err := ListImages(ctx, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		return &core.ResourcePage{Resources: resources}, nil
+	}
+
+	client, accountID, includeDisabled, err := imageListClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	//
+	// DescribeImages accepts between 5 and 1000 results per page.
+	//
+	pageSize := ctx.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	images, nextToken, err := client.ListImagesPage(accountID, includeDisabled, ctx.Query, max(pageSize, 5), ctx.PageToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list EC2 images: %w", err)
+	}
+
+	return &core.ResourcePage{Resources: imageResources(images, resourceType), NextPageToken: nextToken}, nil
+}
+
+func imageListClient(ctx core.ListResourcesContext) (*Client, string, bool, error) {
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return nil, "", false, err
+	}
+
 	region := strings.TrimSpace(ctx.Parameters["region"])
 	if region == "" {
-		return nil, fmt.Errorf("region is required")
+		return nil, "", false, fmt.Errorf("region is required")
 	}
 
-	var includeDisabled bool
-	if ctx.Parameters["includeDisabled"] == "true" {
-		includeDisabled = true
-	} else {
-		includeDisabled = false
-	}
+	includeDisabled := ctx.Parameters["includeDisabled"] == "true"
 
 	integrationMetadata := common.IntegrationMetadata{}
 	if err := mapstructure.Decode(ctx.Integration.GetMetadata(), &integrationMetadata); err != nil {
-		return nil, fmt.Errorf("failed to decode integration metadata: %w", err)
+		return nil, "", false, fmt.Errorf("failed to decode integration metadata: %w", err)
 	}
 
 	if integrationMetadata.Session == nil {
-		return nil, fmt.Errorf("integration account ID is not configured")
+		return nil, "", false, fmt.Errorf("integration account ID is not configured")
 	}
 
 	accountID := strings.TrimSpace(integrationMetadata.Session.AccountID)
 	if accountID == "" {
-		return nil, fmt.Errorf("integration account ID is not configured")
+		return nil, "", false, fmt.Errorf("integration account ID is not configured")
 	}
 
-	client := NewClient(ctx.HTTP, creds, region)
-	images, err := client.ListImages(accountID, includeDisabled)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list EC2 images: %w", err)
-	}
+	return NewClient(ctx.HTTP, creds, region), accountID, includeDisabled, nil
+}
 
+func imageResources(images []Image, resourceType string) []core.IntegrationResource {
 	resources := make([]core.IntegrationResource, 0, len(images))
 	for _, image := range images {
 		resources = append(resources, core.IntegrationResource{
@@ -85,7 +127,7 @@ func ListImages(ctx core.ListResourcesContext, resourceType string) ([]core.Inte
 		})
 	}
 
-	return resources, nil
+	return resources
 }
 
 func instanceResourceName(instance Instance) string {
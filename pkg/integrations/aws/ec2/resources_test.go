@@ -0,0 +1,67 @@
+package ec2
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ListImagePage(t *testing.T) {
+	integration := testIntegrationWithCredentials()
+	integration.Metadata = common.IntegrationMetadata{
+		Session: &common.SessionMetadata{AccountID: "123456789012"},
+	}
+
+	t.Run("query and page are sent to DescribeImages", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						<DescribeImagesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+							<imagesSet>
+								<item>
+									<imageId>ami-123</imageId>
+									<name>web-server</name>
+								</item>
+							</imagesSet>
+							<nextToken>next-token</nextToken>
+						</DescribeImagesResponse>
+					`)),
+				},
+			},
+		}
+
+		page, err := ListImagePage(core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: integration,
+			Parameters:  map[string]string{"region": "us-east-1"},
+			Query:       "web",
+			PageToken:   "token-1",
+			PageSize:    2,
+		}, "ec2.image")
+
+		require.NoError(t, err)
+		require.Len(t, page.Resources, 1)
+		assert.Equal(t, "web-server (ami-123)", page.Resources[0].Name)
+		assert.Equal(t, "ami-123", page.Resources[0].ID)
+		assert.Equal(t, "next-token", page.NextPageToken)
+
+		require.Len(t, httpContext.Requests, 1)
+		values, err := url.ParseQuery(testRequestBodyString(t, httpContext.Requests[0]))
+		require.NoError(t, err)
+		assert.Equal(t, "name", values.Get("Filter.1.Name"))
+		assert.Equal(t, "*web*", values.Get("Filter.1.Value.1"))
+		assert.Equal(t, "5", values.Get("MaxResults"), "DescribeImages requires at least 5 results per page")
+		assert.Equal(t, "token-1", values.Get("NextToken"))
+		assert.Equal(t, "123456789012", values.Get("Owner.1"))
+	})
+}
@@ -0,0 +1,316 @@
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+var (
+	accountIDPattern       = regexp.MustCompile(`^\d{12}$`)
+	organizationARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:organizations::\d{12}:organization/o-[a-z0-9]+$`)
+)
+
+type ShareImage struct{}
+
+type ShareImageConfiguration struct {
+	Region                 string       `json:"region" mapstructure:"region"`
+	ImageID                string       `json:"imageId" mapstructure:"imageId"`
+	AccountIDs             []string     `json:"accountIds" mapstructure:"accountIds"`
+	RemoveAccountIDs       []string     `json:"removeAccountIds" mapstructure:"removeAccountIds"`
+	OrganizationARNs       []string     `json:"organizationArns" mapstructure:"organizationArns"`
+	RemoveOrganizationARNs []string     `json:"removeOrganizationArns" mapstructure:"removeOrganizationArns"`
+	Tags                   []common.Tag `json:"tags" mapstructure:"tags"`
+}
+
+func (c *ShareImage) Name() string {
+	return "aws.ec2.shareImage"
+}
+
+func (c *ShareImage) Label() string {
+	return "EC2 • Share Image"
+}
+
+func (c *ShareImage) Description() string {
+	return "Share an EC2 AMI with other AWS accounts or organizations"
+}
+
+func (c *ShareImage) Documentation() string {
+	return `The Share Image component grants or revokes permission to launch an AMI for other AWS accounts and organizations.
+
+## Use Cases
+
+- **Multi-account pipelines**: Share a freshly baked AMI with workload accounts
+- **Organization-wide images**: Make golden images available to every account of an organization
+- **Access cleanup**: Revoke access to images that should no longer be used
+
+## Configuration
+
+- **Region**: AWS region where the AMI exists
+- **Image ID**: AMI ID to share
+- **Account IDs**: 12-digit AWS account IDs to grant launch permission to
+- **Remove Account IDs**: AWS account IDs to revoke launch permission from
+- **Organization ARNs**: AWS Organizations ARNs to grant launch permission to
+- **Remove Organization ARNs**: AWS Organizations ARNs to revoke launch permission from
+- **Tags**: Optional tags to add to the AMI, e.g. to record who it is shared with
+
+## Notes
+
+- Images with encrypted snapshots can only be launched by the other accounts if they can also use the KMS key of the snapshots.
+- Tags are not shared. Other accounts do not see the tags of the AMI.
+
+## Output
+
+The image ID, the request ID, the added and removed permissions, the added tags, and the launch permissions of the image after the change.`
+}
+
+func (c *ShareImage) Icon() string {
+	return "aws"
+}
+
+func (c *ShareImage) Color() string {
+	return "gray"
+}
+
+func (c *ShareImage) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ShareImage) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "imageId",
+			Label:       "Image ID",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "AMI ID to share",
+			Placeholder: "ami-1234567890abcdef0",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "ec2.image",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+		},
+		shareImageListField("accountIds", "Account IDs", "Account ID", "AWS account IDs to grant launch permission to"),
+		shareImageListField("removeAccountIds", "Remove Account IDs", "Account ID", "AWS account IDs to revoke launch permission from"),
+		shareImageListField("organizationArns", "Organization ARNs", "Organization ARN", "AWS Organizations ARNs to grant launch permission to"),
+		shareImageListField("removeOrganizationArns", "Remove Organization ARNs", "Organization ARN", "AWS Organizations ARNs to revoke launch permission from"),
+		tagsField("Tags to add to the AMI"),
+	}
+}
+
+func shareImageListField(name, label, itemLabel, description string) configuration.Field {
+	return configuration.Field{
+		Name:        name,
+		Label:       label,
+		Type:        configuration.FieldTypeList,
+		Required:    false,
+		Togglable:   true,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: itemLabel,
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeString,
+				},
+			},
+		},
+	}
+}
+
+func (c *ShareImage) Setup(ctx core.SetupContext) error {
+	_, err := decodeShareImageConfiguration(ctx.Configuration)
+	return err
+}
+
+func decodeShareImageConfiguration(raw any) (ShareImageConfiguration, error) {
+	config := ShareImageConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return ShareImageConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, err := requireRegion(config.Region)
+	if err != nil {
+		return ShareImageConfiguration{}, err
+	}
+
+	imageID, err := requireImageID(config.ImageID)
+	if err != nil {
+		return ShareImageConfiguration{}, err
+	}
+
+	config.Region = region
+	config.ImageID = imageID
+	config.AccountIDs = normalizeShareTargets(config.AccountIDs)
+	config.RemoveAccountIDs = normalizeShareTargets(config.RemoveAccountIDs)
+	config.OrganizationARNs = normalizeShareTargets(config.OrganizationARNs)
+	config.RemoveOrganizationARNs = normalizeShareTargets(config.RemoveOrganizationARNs)
+	config.Tags = common.NormalizeTags(config.Tags)
+
+	if len(config.AccountIDs)+len(config.RemoveAccountIDs)+len(config.OrganizationARNs)+len(config.RemoveOrganizationARNs) == 0 {
+		return ShareImageConfiguration{}, fmt.Errorf("at least one account ID or organization ARN to add or remove is required")
+	}
+
+	return config, nil
+}
+
+/*
+ * Account IDs and organization ARNs can come from expressions,
+ * so their format is only checked when the component executes.
+ */
+func validateShareTargets(config ShareImageConfiguration) error {
+	for _, accountID := range slices.Concat(config.AccountIDs, config.RemoveAccountIDs) {
+		if !accountIDPattern.MatchString(accountID) {
+			return fmt.Errorf("invalid account ID %q: must be 12 digits", accountID)
+		}
+	}
+
+	for _, arn := range slices.Concat(config.OrganizationARNs, config.RemoveOrganizationARNs) {
+		if !organizationARNPattern.MatchString(arn) {
+			return fmt.Errorf("invalid organization ARN %q", arn)
+		}
+	}
+
+	for _, accountID := range config.AccountIDs {
+		if slices.Contains(config.RemoveAccountIDs, accountID) {
+			return fmt.Errorf("account ID %s cannot be both added and removed", accountID)
+		}
+	}
+
+	for _, arn := range config.OrganizationARNs {
+		if slices.Contains(config.RemoveOrganizationARNs, arn) {
+			return fmt.Errorf("organization ARN %s cannot be both added and removed", arn)
+		}
+	}
+
+	return nil
+}
+
+func normalizeShareTargets(values []string) []string {
+	normalized := []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+
+	return normalized
+}
+
+func (c *ShareImage) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ShareImage) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeShareImageConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := validateShareTargets(config); err != nil {
+		return err
+	}
+
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, creds, config.Region)
+	requestID, err := client.ModifyImageLaunchPermission(config.ImageID, LaunchPermissionChanges{
+		AddAccountIDs:          config.AccountIDs,
+		RemoveAccountIDs:       config.RemoveAccountIDs,
+		AddOrganizationARNs:    config.OrganizationARNs,
+		RemoveOrganizationARNs: config.RemoveOrganizationARNs,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to modify image launch permissions: %w", err)
+	}
+
+	if len(config.Tags) > 0 {
+		if _, err := client.CreateTags(config.ImageID, config.Tags); err != nil {
+			return fmt.Errorf("failed to tag image: %w", err)
+		}
+	}
+
+	permissions, err := client.DescribeImageLaunchPermissions(config.ImageID)
+	if err != nil {
+		return fmt.Errorf("failed to describe image launch permissions: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, "aws.ec2.image.shared", []any{
+		map[string]any{
+			"requestId": requestID,
+			"region":    config.Region,
+			"image": map[string]any{
+				"imageId": config.ImageID,
+			},
+			"added": map[string]any{
+				"accountIds":       config.AccountIDs,
+				"organizationArns": config.OrganizationARNs,
+			},
+			"removed": map[string]any{
+				"accountIds":       config.RemoveAccountIDs,
+				"organizationArns": config.RemoveOrganizationARNs,
+			},
+			"tags":              config.Tags,
+			"launchPermissions": permissions,
+		},
+	})
+}
+
+func (c *ShareImage) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *ShareImage) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ShareImage) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ShareImage) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ShareImage) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,161 @@
+package ec2
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ShareImage__Setup(t *testing.T) {
+	component := &ShareImage{}
+
+	t.Run("no accounts or organizations -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"imageId":    "ami-123",
+				"accountIds": []any{" "},
+			},
+		})
+
+		require.ErrorContains(t, err, "at least one account ID or organization ARN")
+	})
+
+	t.Run("valid configuration -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"imageId":    "ami-123",
+				"accountIds": []any{"123456789012"},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__ShareImage__Execute(t *testing.T) {
+	component := &ShareImage{}
+
+	t.Run("invalid account ID -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"imageId":    "ami-123",
+				"accountIds": []any{"1234"},
+			},
+			HTTP:           &contexts.HTTPContext{},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, "invalid account ID")
+	})
+
+	t.Run("account added and removed -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":           "us-east-1",
+				"imageId":          "ami-123",
+				"accountIds":       []any{"123456789012"},
+				"removeAccountIds": []any{"123456789012"},
+			},
+			HTTP:           &contexts.HTTPContext{},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, "cannot be both added and removed")
+	})
+
+	t.Run("launch permissions modified and image tagged", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						<ModifyImageAttributeResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+							<requestId>req-share-image</requestId>
+							<return>true</return>
+						</ModifyImageAttributeResponse>
+					`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						<CreateTagsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+							<requestId>req-create-tags</requestId>
+							<return>true</return>
+						</CreateTagsResponse>
+					`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						<DescribeImageAttributeResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+							<requestId>req-describe-attribute</requestId>
+							<imageId>ami-123</imageId>
+							<launchPermission>
+								<item><userId>123456789012</userId></item>
+								<item><organizationArn>arn:aws:organizations::111122223333:organization/o-abc123def4</organizationArn></item>
+							</launchPermission>
+						</DescribeImageAttributeResponse>
+					`)),
+				},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":           "us-east-1",
+				"imageId":          "ami-123",
+				"accountIds":       []any{"123456789012"},
+				"removeAccountIds": []any{"210987654321"},
+				"organizationArns": []any{"arn:aws:organizations::111122223333:organization/o-abc123def4"},
+				"tags": []any{
+					map[string]any{"key": "SharedWith", "value": "workloads"},
+				},
+			},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, "aws.ec2.image.shared", execState.Type)
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "req-share-image", output["requestId"])
+		permissions, ok := output["launchPermissions"].(*LaunchPermissions)
+		require.True(t, ok)
+		assert.Equal(t, []string{"123456789012"}, permissions.AccountIDs)
+		assert.Equal(t, []string{"arn:aws:organizations::111122223333:organization/o-abc123def4"}, permissions.OrganizationARNs)
+		assert.False(t, permissions.Public)
+
+		require.Len(t, httpContext.Requests, 3)
+		modifyBody := testRequestBodyString(t, httpContext.Requests[0])
+		assert.Contains(t, modifyBody, "Action=ModifyImageAttribute")
+		assert.Contains(t, modifyBody, "ImageId=ami-123")
+		assert.Contains(t, modifyBody, "LaunchPermission.Add.1.UserId=123456789012")
+		assert.Contains(t, modifyBody, "LaunchPermission.Add.1.OrganizationArn=arn%3Aaws%3Aorganizations%3A%3A111122223333%3Aorganization%2Fo-abc123def4")
+		assert.Contains(t, modifyBody, "LaunchPermission.Remove.1.UserId=210987654321")
+
+		tagsBody := testRequestBodyString(t, httpContext.Requests[1])
+		assert.Contains(t, tagsBody, "Action=CreateTags")
+		assert.Contains(t, tagsBody, "ResourceId.1=ami-123")
+		assert.Contains(t, tagsBody, "Tag.1.Key=SharedWith")
+		assert.Contains(t, tagsBody, "Tag.1.Value=workloads")
+
+		describeBody := testRequestBodyString(t, httpContext.Requests[2])
+		assert.Contains(t, describeBody, "Action=DescribeImageAttribute")
+		assert.Contains(t, describeBody, "Attribute=launchPermission")
+	})
+}
@@ -0,0 +1,105 @@
+package ec2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	snapshotPayloadType  = "aws.ec2.snapshot"
+	snapshotPollAction   = "pollSnapshot"
+	snapshotPollInterval = 30 * time.Second
+)
+
+/*
+ * SnapshotExecutionMetadata tracks a snapshot being created or copied,
+ * while the execution waits for it to be completed.
+ */
+type SnapshotExecutionMetadata struct {
+	Region           string `json:"region" mapstructure:"region"`
+	SnapshotID       string `json:"snapshotId" mapstructure:"snapshotId"`
+	State            string `json:"state" mapstructure:"state"`
+	SourceSnapshotID string `json:"sourceSnapshotId,omitempty" mapstructure:"sourceSnapshotId"`
+	SourceRegion     string `json:"sourceRegion,omitempty" mapstructure:"sourceRegion"`
+	RequestID        string `json:"requestId,omitempty" mapstructure:"requestId"`
+}
+
+func snapshotPayload(snapshot *Snapshot, metadata SnapshotExecutionMetadata) map[string]any {
+	payload := map[string]any{
+		"snapshot": snapshot,
+	}
+
+	if metadata.RequestID != "" {
+		payload["requestId"] = metadata.RequestID
+	}
+
+	if metadata.SourceSnapshotID != "" {
+		payload["sourceSnapshotId"] = metadata.SourceSnapshotID
+		payload["sourceRegion"] = metadata.SourceRegion
+	}
+
+	return payload
+}
+
+func pollSnapshot(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := SnapshotExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return checkSnapshot(ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.Requests, ctx.ExecutionState, metadata, true)
+}
+
+/*
+ * Emits the snapshot if it is completed or the execution does not wait for it,
+ * fails the execution if the snapshot failed, and schedules the next poll otherwise.
+ */
+func checkSnapshot(
+	httpCtx core.HTTPContext,
+	integration core.IntegrationContext,
+	metadataCtx core.MetadataContext,
+	requests core.RequestContext,
+	executionState core.ExecutionStateContext,
+	metadata SnapshotExecutionMetadata,
+	wait bool,
+) error {
+	creds, err := common.CredentialsFromInstallation(integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(httpCtx, creds, metadata.Region)
+	snapshot, err := client.DescribeSnapshot(metadata.SnapshotID)
+	if err != nil {
+		return fmt.Errorf("failed to describe snapshot: %w", err)
+	}
+
+	metadata.State = snapshot.State
+	if err := metadataCtx.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if snapshot.State == SnapshotStateError {
+		message := fmt.Sprintf("snapshot %s failed", metadata.SnapshotID)
+		if snapshot.StateMessage != "" {
+			message = fmt.Sprintf("%s: %s", message, snapshot.StateMessage)
+		}
+
+		return executionState.Fail(models.CanvasNodeExecutionResultReasonError, message)
+	}
+
+	if wait && snapshot.State != SnapshotStateCompleted {
+		return requests.ScheduleActionCall(snapshotPollAction, map[string]any{}, snapshotPollInterval)
+	}
+
+	return executionState.Emit(core.DefaultOutputChannel.Name, snapshotPayloadType, []any{snapshotPayload(snapshot, metadata)})
+}
@@ -0,0 +1,329 @@
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	DefaultWaitForImagePollIntervalSeconds = 30
+	MinWaitForImagePollIntervalSeconds     = 10
+	MaxWaitForImagePollIntervalSeconds     = 3600
+	DefaultWaitForImageTimeoutMinutes      = 60
+	MaxWaitForImageTimeoutMinutes          = 1440
+)
+
+type WaitForImage struct{}
+
+type WaitForImageConfiguration struct {
+	Region              string `json:"region" mapstructure:"region"`
+	ImageID             string `json:"imageId" mapstructure:"imageId"`
+	PollIntervalSeconds int    `json:"pollIntervalSeconds" mapstructure:"pollIntervalSeconds"`
+	TimeoutMinutes      int    `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+type WaitForImageExecutionMetadata struct {
+	Region              string `json:"region" mapstructure:"region"`
+	ImageID             string `json:"imageId" mapstructure:"imageId"`
+	State               string `json:"state" mapstructure:"state"`
+	PollIntervalSeconds int    `json:"pollIntervalSeconds" mapstructure:"pollIntervalSeconds"`
+	Deadline            string `json:"deadline" mapstructure:"deadline"`
+}
+
+func (c *WaitForImage) Name() string {
+	return "aws.ec2.waitForImage"
+}
+
+func (c *WaitForImage) Label() string {
+	return "EC2 • Wait For Image"
+}
+
+func (c *WaitForImage) Description() string {
+	return "Wait until an EC2 AMI is available"
+}
+
+func (c *WaitForImage) Documentation() string {
+	return `The Wait For Image component polls an AMI until it is available, so later steps don't copy, share or launch an image that isn't ready.
+
+## Use Cases
+
+- **Image pipelines**: Wait for an AMI baked outside SuperPlane, e.g. by Packer, before sharing or copying it
+- **Cross-region copies**: Wait for a copied AMI in the destination region
+- **Regions without EventBridge**: Wait for images without relying on EventBridge rules
+
+## Configuration
+
+- **Region**: AWS region where the AMI exists
+- **Image ID**: AMI ID to wait for
+- **Poll Interval (seconds)**: How often the image state is checked. Defaults to 30 seconds
+- **Timeout (minutes)**: How long to wait before failing. Defaults to 60 minutes
+
+## Behavior
+
+- Emits the image once its state is ` + "`available`" + `
+- Fails if the image is ` + "`failed`" + `, ` + "`deregistered`" + ` or ` + "`disabled`" + `, or if it is not available before the timeout`
+}
+
+func (c *WaitForImage) Icon() string {
+	return "aws"
+}
+
+func (c *WaitForImage) Color() string {
+	return "gray"
+}
+
+func (c *WaitForImage) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *WaitForImage) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "imageId",
+			Label:       "Image ID",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "AMI ID to wait for",
+			Placeholder: "ami-1234567890abcdef0",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "ec2.image",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+		},
+		{
+			Name:        "pollIntervalSeconds",
+			Label:       "Poll Interval (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultWaitForImagePollIntervalSeconds,
+			Description: "How often the image state is checked",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := MinWaitForImagePollIntervalSeconds; return &min }(),
+					Max: func() *int { max := MaxWaitForImagePollIntervalSeconds; return &max }(),
+				},
+			},
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultWaitForImageTimeoutMinutes,
+			Description: "How long to wait for the image before failing",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := MaxWaitForImageTimeoutMinutes; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func decodeWaitForImageConfiguration(raw any) (WaitForImageConfiguration, error) {
+	config := WaitForImageConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return WaitForImageConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, err := requireRegion(config.Region)
+	if err != nil {
+		return WaitForImageConfiguration{}, err
+	}
+
+	imageID, err := requireImageID(config.ImageID)
+	if err != nil {
+		return WaitForImageConfiguration{}, err
+	}
+
+	config.Region = region
+	config.ImageID = imageID
+
+	if config.PollIntervalSeconds == 0 {
+		config.PollIntervalSeconds = DefaultWaitForImagePollIntervalSeconds
+	}
+
+	if config.TimeoutMinutes == 0 {
+		config.TimeoutMinutes = DefaultWaitForImageTimeoutMinutes
+	}
+
+	if config.PollIntervalSeconds < MinWaitForImagePollIntervalSeconds || config.PollIntervalSeconds > MaxWaitForImagePollIntervalSeconds {
+		return WaitForImageConfiguration{}, fmt.Errorf(
+			"poll interval must be between %d and %d seconds",
+			MinWaitForImagePollIntervalSeconds,
+			MaxWaitForImagePollIntervalSeconds,
+		)
+	}
+
+	if config.TimeoutMinutes < 1 || config.TimeoutMinutes > MaxWaitForImageTimeoutMinutes {
+		return WaitForImageConfiguration{}, fmt.Errorf("timeout must be between 1 and %d minutes", MaxWaitForImageTimeoutMinutes)
+	}
+
+	return config, nil
+}
+
+func (c *WaitForImage) Setup(ctx core.SetupContext) error {
+	_, err := decodeWaitForImageConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *WaitForImage) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *WaitForImage) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeWaitForImageConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := WaitForImageExecutionMetadata{
+		Region:              config.Region,
+		ImageID:             config.ImageID,
+		PollIntervalSeconds: config.PollIntervalSeconds,
+		Deadline:            time.Now().Add(time.Duration(config.TimeoutMinutes) * time.Minute).Format(time.RFC3339),
+	}
+
+	return c.checkImage(ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.Requests, ctx.ExecutionState, metadata)
+}
+
+func (c *WaitForImage) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        "poll",
+			Description: "Check the state of the image",
+		},
+	}
+}
+
+func (c *WaitForImage) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case "poll":
+		return c.poll(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *WaitForImage) poll(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := WaitForImageExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return c.checkImage(ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.Requests, ctx.ExecutionState, metadata)
+}
+
+/*
+ * Emits the image if it is available, fails the execution if it
+ * can no longer become available or the deadline has passed,
+ * and schedules the next poll otherwise.
+ */
+func (c *WaitForImage) checkImage(
+	httpCtx core.HTTPContext,
+	integration core.IntegrationContext,
+	metadataCtx core.MetadataContext,
+	requests core.RequestContext,
+	executionState core.ExecutionStateContext,
+	metadata WaitForImageExecutionMetadata,
+) error {
+	creds, err := common.CredentialsFromInstallation(integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(httpCtx, creds, metadata.Region)
+	image, err := client.DescribeImage(metadata.ImageID)
+	if err != nil {
+		return fmt.Errorf("failed to describe image: %w", err)
+	}
+
+	metadata.State = image.State
+	if err := metadataCtx.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	switch image.State {
+	case ImageStateAvailable:
+		return executionState.Emit(
+			core.DefaultOutputChannel.Name,
+			"aws.ec2.image",
+			[]any{map[string]any{
+				"image": image,
+			}},
+		)
+
+	case ImageStateFailed, ImageStateDeregistered, ImageStateDisabled:
+		return executionState.Fail(
+			models.CanvasNodeExecutionResultReasonError,
+			fmt.Sprintf("image %s is %s", metadata.ImageID, image.State),
+		)
+	}
+
+	deadline, err := time.Parse(time.RFC3339, metadata.Deadline)
+	if err != nil {
+		return fmt.Errorf("failed to parse deadline: %w", err)
+	}
+
+	if !time.Now().Before(deadline) {
+		return executionState.Fail(
+			models.CanvasNodeExecutionResultReasonError,
+			fmt.Sprintf("timed out waiting for image %s to be available, last state: %s", metadata.ImageID, image.State),
+		)
+	}
+
+	return requests.ScheduleActionCall("poll", map[string]any{}, time.Duration(metadata.PollIntervalSeconds)*time.Second)
+}
+
+func (c *WaitForImage) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *WaitForImage) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *WaitForImage) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,175 @@
+package ec2
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func describeImageResponse(state string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`
+			<DescribeImagesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+				<requestId>req-describe</requestId>
+				<imagesSet>
+					<item>
+						<imageId>ami-123</imageId>
+						<name>my-image</name>
+						<imageState>` + state + `</imageState>
+					</item>
+				</imagesSet>
+			</DescribeImagesResponse>
+		`)),
+	}
+}
+
+func Test__WaitForImage__Setup(t *testing.T) {
+	component := &WaitForImage{}
+
+	t.Run("image ID is required -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1"},
+		})
+
+		require.ErrorContains(t, err, "image ID is required")
+	})
+
+	t.Run("poll interval out of range -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":              "us-east-1",
+				"imageId":             "ami-123",
+				"pollIntervalSeconds": 5,
+			},
+		})
+
+		require.ErrorContains(t, err, "poll interval must be between 10 and 3600 seconds")
+	})
+}
+
+func Test__WaitForImage__Execute(t *testing.T) {
+	component := &WaitForImage{}
+
+	t.Run("image available -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-east-1", "imageId": "ami-123"},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{describeImageResponse(ImageStateAvailable)}},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, "aws.ec2.image", execState.Type)
+		assert.Empty(t, requests.Action)
+	})
+
+	t.Run("image pending -> schedules poll", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":              "us-east-1",
+				"imageId":             "ami-123",
+				"pollIntervalSeconds": 60,
+			},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{describeImageResponse(ImageStatePending)}},
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, "poll", requests.Action)
+		assert.Equal(t, time.Minute, requests.Duration)
+
+		stored, ok := metadata.Metadata.(WaitForImageExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "ami-123", stored.ImageID)
+		assert.Equal(t, ImageStatePending, stored.State)
+		assert.Equal(t, 60, stored.PollIntervalSeconds)
+	})
+
+	t.Run("image failed -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-east-1", "imageId": "ami-123"},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{describeImageResponse(ImageStateFailed)}},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Equal(t, "image ami-123 is failed", execState.FailureMessage)
+	})
+}
+
+func Test__WaitForImage__Poll(t *testing.T) {
+	component := &WaitForImage{}
+
+	t.Run("deadline passed -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+		err := component.HandleAction(core.ActionContext{
+			Name:     "poll",
+			HTTP:     &contexts.HTTPContext{Responses: []*http.Response{describeImageResponse(ImageStatePending)}},
+			Requests: requests,
+			Metadata: &contexts.MetadataContext{
+				Metadata: WaitForImageExecutionMetadata{
+					Region:              "us-east-1",
+					ImageID:             "ami-123",
+					PollIntervalSeconds: 30,
+					Deadline:            time.Now().Add(-time.Minute).Format(time.RFC3339),
+				},
+			},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Contains(t, execState.FailureMessage, "timed out waiting for image ami-123")
+		assert.Empty(t, requests.Action)
+	})
+
+	t.Run("image available -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:     "poll",
+			HTTP:     &contexts.HTTPContext{Responses: []*http.Response{describeImageResponse(ImageStateAvailable)}},
+			Requests: &contexts.RequestContext{},
+			Metadata: &contexts.MetadataContext{
+				Metadata: WaitForImageExecutionMetadata{
+					Region:              "us-east-1",
+					ImageID:             "ami-123",
+					PollIntervalSeconds: 30,
+					Deadline:            time.Now().Add(time.Hour).Format(time.RFC3339),
+				},
+			},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, "aws.ec2.image", execState.Type)
+	})
+}
@@ -30,7 +30,7 @@ type Client struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      region,
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -58,15 +58,12 @@ func (c *Client) DescribeRepository(name string) (*Repository, error) {
 }
 
 func (c *Client) ListRepositories() ([]Repository, error) {
-	repositories := []Repository{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(token string) ([]Repository, string, error) {
 		payload := map[string]any{
 			"maxResults": 100,
 		}
-		if nextToken != "" {
-			payload["nextToken"] = nextToken
+		if token != "" {
+			payload["nextToken"] = token
 		}
 
 		var response struct {
@@ -75,17 +72,11 @@ func (c *Client) ListRepositories() ([]Repository, error) {
 		}
 
 		if err := c.postJSON("DescribeRepositories", payload, &response); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
-		repositories = append(repositories, response.Repositories...)
-		if response.NextToken == "" {
-			break
-		}
-		nextToken = response.NextToken
-	}
-
-	return repositories, nil
+		return response.Repositories, response.NextToken, nil
+	})
 }
 
 type DescribeImageResponse struct {
@@ -228,6 +219,121 @@ func (c *Client) ScanImage(repositoryName string, imageDigest string, imageTag s
 	return &response, nil
 }
 
+type BatchGetImageResponse struct {
+	Images   []Image                `json:"images"`
+	Failures []BatchGetImageFailure `json:"failures"`
+}
+
+type Image struct {
+	RegistryID             string          `json:"registryId"`
+	RepositoryName         string          `json:"repositoryName"`
+	ImageID                ImageIdentifier `json:"imageId"`
+	ImageManifest          string          `json:"imageManifest"`
+	ImageManifestMediaType string          `json:"imageManifestMediaType"`
+}
+
+type BatchGetImageFailure struct {
+	ImageID       ImageIdentifier `json:"imageId"`
+	FailureCode   string          `json:"failureCode"`
+	FailureReason string          `json:"failureReason"`
+}
+
+/*
+ * BatchGetImage returns the manifest of a single image,
+ * identified by its digest, its tag, or both.
+ */
+func (c *Client) BatchGetImage(repositoryName string, imageDigest string, imageTag string) (*Image, error) {
+	imageID := imageIdentifier(imageDigest, imageTag)
+	if len(imageID) == 0 {
+		return nil, errors.New("image digest or image tag is required")
+	}
+
+	payload := map[string]any{
+		"repositoryName": repositoryName,
+		"imageIds":       []map[string]any{imageID},
+	}
+
+	response := BatchGetImageResponse{}
+	if err := c.postJSON("BatchGetImage", payload, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Failures) > 0 {
+		failure := response.Failures[0]
+		return nil, fmt.Errorf("%s: %s", failure.FailureCode, failure.FailureReason)
+	}
+
+	if len(response.Images) == 0 {
+		return nil, errors.New("image not found")
+	}
+
+	return &response.Images[0], nil
+}
+
+/*
+ * PutImage adds a tag to an existing image by uploading its manifest again.
+ */
+func (c *Client) PutImage(repositoryName string, image *Image, imageTag string) (*Image, error) {
+	payload := map[string]any{
+		"repositoryName": repositoryName,
+		"imageManifest":  image.ImageManifest,
+		"imageTag":       imageTag,
+	}
+
+	if image.ImageManifestMediaType != "" {
+		payload["imageManifestMediaType"] = image.ImageManifestMediaType
+	}
+
+	if image.ImageID.ImageDigest != "" {
+		payload["imageDigest"] = image.ImageID.ImageDigest
+	}
+
+	var response struct {
+		Image Image `json:"image"`
+	}
+
+	if err := c.postJSON("PutImage", payload, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Image, nil
+}
+
+func (c *Client) ListImages(repositoryName string) ([]ImageIdentifier, error) {
+	return common.CollectPages(func(token string) ([]ImageIdentifier, string, error) {
+		payload := map[string]any{
+			"repositoryName": repositoryName,
+			"maxResults":     1000,
+		}
+		if token != "" {
+			payload["nextToken"] = token
+		}
+
+		var response struct {
+			ImageIDs  []ImageIdentifier `json:"imageIds"`
+			NextToken string            `json:"nextToken"`
+		}
+
+		if err := c.postJSON("ListImages", payload, &response); err != nil {
+			return nil, "", err
+		}
+
+		return response.ImageIDs, response.NextToken, nil
+	})
+}
+
+func imageIdentifier(imageDigest string, imageTag string) map[string]any {
+	imageID := map[string]any{}
+	if strings.TrimSpace(imageDigest) != "" {
+		imageID["imageDigest"] = strings.TrimSpace(imageDigest)
+	}
+	if strings.TrimSpace(imageTag) != "" {
+		imageID["imageTag"] = strings.TrimSpace(imageTag)
+	}
+
+	return imageID
+}
+
 func (c *Client) postJSON(action string, payload any, out any) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
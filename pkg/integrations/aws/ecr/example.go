@@ -22,6 +22,12 @@ var exampleOutputGetImageScanFindingsBytes []byte
 //go:embed example_output_scan_image.json
 var exampleOutputScanImageBytes []byte
 
+//go:embed example_output_tag_image.json
+var exampleOutputTagImageBytes []byte
+
+//go:embed example_output_wait_for_scan_findings.json
+var exampleOutputWaitForScanFindingsBytes []byte
+
 var exampleDataOnImagePushOnce sync.Once
 var exampleDataOnImagePush map[string]any
 
@@ -37,6 +43,12 @@ var exampleOutputGetImageScanFindings map[string]any
 var exampleOutputScanImageOnce sync.Once
 var exampleOutputScanImage map[string]any
 
+var exampleOutputTagImageOnce sync.Once
+var exampleOutputTagImage map[string]any
+
+var exampleOutputWaitForScanFindingsOnce sync.Once
+var exampleOutputWaitForScanFindings map[string]any
+
 func (t *OnImagePush) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnImagePushOnce, exampleDataOnImagePushBytes, &exampleDataOnImagePush)
 }
@@ -56,3 +68,11 @@ func (c *GetImageScanFindings) ExampleOutput() map[string]any {
 func (c *ScanImage) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputScanImageOnce, exampleOutputScanImageBytes, &exampleOutputScanImage)
 }
+
+func (c *TagImage) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputTagImageOnce, exampleOutputTagImageBytes, &exampleOutputTagImage)
+}
+
+func (c *WaitForScanFindings) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputWaitForScanFindingsOnce, exampleOutputWaitForScanFindingsBytes, &exampleOutputWaitForScanFindings)
+}
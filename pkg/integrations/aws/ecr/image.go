@@ -0,0 +1,63 @@
+package ecr
+
+import (
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+/*
+ * imageField returns an image selector for the repository selected in the
+ * "repository" field. Its value is the tag of the image, or its digest for untagged images.
+ */
+func imageField(label, description string) configuration.Field {
+	return configuration.Field{
+		Name:        "image",
+		Label:       label,
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: description,
+		VisibilityConditions: []configuration.VisibilityCondition{
+			{
+				Field:  "region",
+				Values: []string{"*"},
+			},
+			{
+				Field:  "repository",
+				Values: []string{"*"},
+			},
+		},
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:           "ecr.image",
+				UseNameAsValue: true,
+				Parameters: []configuration.ParameterRef{
+					{
+						Name: "region",
+						ValueFrom: &configuration.ParameterValueFrom{
+							Field: "region",
+						},
+					},
+					{
+						Name: "repository",
+						ValueFrom: &configuration.ParameterValueFrom{
+							Field: "repository",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+/*
+ * imageReference splits an image reference into a digest or a tag.
+ */
+func imageReference(image string) (string, string) {
+	image = strings.TrimSpace(image)
+	if strings.HasPrefix(image, "sha256:") {
+		return image, ""
+	}
+
+	return "", image
+}
@@ -108,6 +108,7 @@ func (p *OnImagePush) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -282,7 +283,8 @@ func (p *OnImagePush) OnIntegrationMessage(ctx core.IntegrationMessageContext) e
 		return nil
 	}
 
-	return ctx.Events.Emit("aws.ecr.image.push", ctx.Message)
+	_, err = core.EmitDeduplicated(ctx.Events, event.ID, "aws.ecr.image.push", ctx.Message)
+	return err
 }
 
 func (p *OnImagePush) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
@@ -233,4 +233,26 @@ func Test__OnImagePush__OnIntegrationMessage(t *testing.T) {
 		assert.Equal(t, 1, eventContext.Count())
 		assert.Equal(t, "aws.ecr.image.push", eventContext.Payloads[0].Type)
 	})
+	t.Run("redelivered event -> emits event once", func(t *testing.T) {
+		eventContext := &contexts.EventContext{}
+		for range 2 {
+			err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+				Logger: logrus.NewEntry(logrus.New()),
+				Events: eventContext,
+				NodeMetadata: &contexts.MetadataContext{
+					Metadata: OnImagePushMetadata{
+						Repository: &Repository{RepositoryName: "backend"},
+					},
+				},
+				Message: common.EventBridgeEvent{
+					ID:     "7bf73129-1428-4cd3-a780-95db273d1602",
+					Detail: map[string]any{"repository-name": "backend"},
+				},
+			})
+
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, eventContext.Count())
+	})
 }
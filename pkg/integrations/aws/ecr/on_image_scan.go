@@ -113,6 +113,7 @@ func (p *OnImageScan) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -283,7 +284,8 @@ func (p *OnImageScan) OnIntegrationMessage(ctx core.IntegrationMessageContext) e
 		return nil
 	}
 
-	return ctx.Events.Emit("aws.ecr.image.scan", ctx.Message)
+	_, err = core.EmitDeduplicated(ctx.Events, event.ID, "aws.ecr.image.scan", ctx.Message)
+	return err
 }
 
 func (p *OnImageScan) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
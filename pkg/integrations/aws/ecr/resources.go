@@ -35,3 +35,50 @@ func ListRepositories(ctx core.ListResourcesContext, resourceType string) ([]cor
 
 	return resources, nil
 }
+
+/*
+ * ListImages lists the images of a repository by tag.
+ * Untagged images are listed by digest.
+ */
+func ListImages(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	region := ctx.Parameters["region"]
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+
+	repositoryName, err := repositoryNameFromRef(ctx.Parameters["repository"])
+	if err != nil {
+		return nil, err
+	}
+
+	if repositoryName == "" {
+		return nil, fmt.Errorf("repository is required")
+	}
+
+	client := NewClient(ctx.HTTP, creds, region)
+	images, err := client.ListImages(repositoryName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ECR images: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(images))
+	for _, image := range images {
+		name := image.ImageTag
+		if name == "" {
+			name = image.ImageDigest
+		}
+
+		resources = append(resources, core.IntegrationResource{
+			Type: resourceType,
+			Name: name,
+			ID:   image.ImageDigest,
+		})
+	}
+
+	return resources, nil
+}
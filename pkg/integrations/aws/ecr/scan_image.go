@@ -26,6 +26,7 @@ type ScanImageMetadata struct {
 	Region      string `json:"region" mapstructure:"region"`
 	Repository  string `json:"repository" mapstructure:"repository"`
 	ImageDigest string `json:"imageDigest" mapstructure:"imageDigest"`
+	ImageTag    string `json:"imageTag" mapstructure:"imageTag"`
 }
 
 func (c *ScanImage) Name() string {
@@ -193,12 +194,20 @@ func (c *ScanImage) Execute(ctx core.ExecutionContext) error {
 
 	//
 	// If the scan is not complete, poll for findings every 10 seconds.
+	// The digest returned by the scan is used, so images configured
+	// only by tag can still be found while polling.
 	//
 	if response.ScanStatus.Status != "COMPLETE" {
+		imageDigest := config.ImageDigest
+		if response.ImageIdentifier.ImageDigest != "" {
+			imageDigest = response.ImageIdentifier.ImageDigest
+		}
+
 		err = ctx.Metadata.Set(ScanImageMetadata{
 			Region:      config.Region,
 			Repository:  config.Repository,
-			ImageDigest: config.ImageDigest,
+			ImageDigest: imageDigest,
+			ImageTag:    config.ImageTag,
 		})
 
 		if err != nil {
@@ -256,7 +265,7 @@ func (c *ScanImage) pollFindings(ctx core.ActionContext) error {
 	}
 
 	client := NewClient(ctx.HTTP, creds, metadata.Region)
-	findings, err := client.DescribeImageScanFindings(metadata.Repository, metadata.ImageDigest, "")
+	findings, err := client.DescribeImageScanFindings(metadata.Repository, metadata.ImageDigest, metadata.ImageTag)
 	if err != nil {
 		return fmt.Errorf("failed to describe image scan findings: %w", err)
 	}
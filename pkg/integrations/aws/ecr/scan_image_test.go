@@ -130,7 +130,8 @@ func Test__ScanImage__Execute(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, "us-east-1", stored.Region)
 		assert.Equal(t, "backend", stored.Repository)
-		assert.Equal(t, "", stored.ImageDigest)
+		assert.Equal(t, "sha256:abc", stored.ImageDigest)
+		assert.Equal(t, "latest", stored.ImageTag)
 
 		assert.Equal(t, "pollFindings", requests.Action)
 		assert.Equal(t, time.Second*10, requests.Duration)
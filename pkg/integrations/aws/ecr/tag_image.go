@@ -0,0 +1,263 @@
+package ecr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+var imageTagRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+type TagImage struct{}
+
+type TagImageConfiguration struct {
+	Region     string   `json:"region" mapstructure:"region"`
+	Repository string   `json:"repository" mapstructure:"repository"`
+	Image      string   `json:"image" mapstructure:"image"`
+	Tags       []string `json:"tags" mapstructure:"tags"`
+}
+
+func (c *TagImage) Name() string {
+	return "aws.ecr.tagImage"
+}
+
+func (c *TagImage) Label() string {
+	return "ECR • Tag Image"
+}
+
+func (c *TagImage) Description() string {
+	return "Add tags to an existing ECR image"
+}
+
+func (c *TagImage) Documentation() string {
+	return `The Tag Image component adds tags to an existing ECR image, without pulling or pushing it.
+
+## Use Cases
+
+- **Image promotion**: Tag a scanned image as ` + "`staging`" + ` or ` + "`production`" + `
+- **Release tagging**: Tag the image of a commit with the release version
+- **Rollbacks**: Point a moving tag back to a previous image
+
+## Configuration
+
+- **Region**: AWS region of the ECR repository
+- **Repository**: ECR repository of the image
+- **Image**: Tag or digest of the image to tag
+- **Tags**: Tags to add to the image
+
+## Notes
+
+- Moving tags, like ` + "`latest`" + `, are moved to the image, unless the repository has immutable tags.
+- Tags that already point to the image are left as they are.`
+}
+
+func (c *TagImage) Icon() string {
+	return "aws"
+}
+
+func (c *TagImage) Color() string {
+	return "gray"
+}
+
+func (c *TagImage) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *TagImage) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "repository",
+			Label:       "Repository",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "ECR repository name or ARN",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "ecr.repository",
+					UseNameAsValue: true,
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+		},
+		imageField("Image", "Tag or digest of the image to tag"),
+		{
+			Name:        "tags",
+			Label:       "Tags",
+			Type:        configuration.FieldTypeList,
+			Required:    true,
+			Description: "Tags to add to the image",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func decodeTagImageConfiguration(raw any) (TagImageConfiguration, error) {
+	config := TagImageConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return TagImageConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = strings.TrimSpace(config.Region)
+	config.Repository = strings.TrimSpace(config.Repository)
+	config.Image = strings.TrimSpace(config.Image)
+
+	if config.Region == "" {
+		return TagImageConfiguration{}, fmt.Errorf("region is required")
+	}
+
+	if config.Repository == "" {
+		return TagImageConfiguration{}, fmt.Errorf("repository is required")
+	}
+
+	if config.Image == "" {
+		return TagImageConfiguration{}, fmt.Errorf("image is required")
+	}
+
+	tags := []string{}
+	for _, tag := range config.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) == 0 {
+		return TagImageConfiguration{}, fmt.Errorf("at least one tag is required")
+	}
+
+	config.Tags = tags
+	return config, nil
+}
+
+func (c *TagImage) Setup(ctx core.SetupContext) error {
+	_, err := decodeTagImageConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *TagImage) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *TagImage) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeTagImageConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	//
+	// Tags are only validated here, since they usually come from expressions.
+	//
+	for _, tag := range config.Tags {
+		if !imageTagRegex.MatchString(tag) {
+			return fmt.Errorf("invalid image tag: %s", tag)
+		}
+	}
+
+	repositoryName, err := repositoryNameFromRef(config.Repository)
+	if err != nil {
+		return err
+	}
+
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, creds, config.Region)
+	imageDigest, imageTag := imageReference(config.Image)
+	image, err := client.BatchGetImage(repositoryName, imageDigest, imageTag)
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+
+	for _, tag := range config.Tags {
+		_, err := client.PutImage(repositoryName, image, tag)
+		if err == nil {
+			continue
+		}
+
+		//
+		// ECR rejects tagging an image with a tag it already has.
+		//
+		var awsErr *common.Error
+		if errors.As(err, &awsErr) && awsErr.Code == "ImageAlreadyExistsException" {
+			continue
+		}
+
+		return fmt.Errorf("failed to tag image with %s: %w", tag, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"aws.ecr.image.tagged",
+		[]any{
+			map[string]any{
+				"registryId":     image.RegistryID,
+				"repositoryName": repositoryName,
+				"imageDigest":    image.ImageID.ImageDigest,
+				"sourceImage":    config.Image,
+				"tags":           config.Tags,
+			},
+		},
+	)
+}
+
+func (c *TagImage) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *TagImage) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *TagImage) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *TagImage) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *TagImage) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,227 @@
+package ecr
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__TagImage__Setup(t *testing.T) {
+	component := &TagImage{}
+
+	t.Run("missing image -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "backend",
+				"tags":       []any{"production"},
+			},
+		})
+
+		require.ErrorContains(t, err, "image is required")
+	})
+
+	t.Run("missing tags -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "backend",
+				"image":      "latest",
+				"tags":       []any{" "},
+			},
+		})
+
+		require.ErrorContains(t, err, "at least one tag is required")
+	})
+
+	t.Run("valid configuration -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "backend",
+				"image":      "latest",
+				"tags":       []any{"production"},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__TagImage__Execute(t *testing.T) {
+	component := &TagImage{}
+	integration := &contexts.IntegrationContext{
+		Secrets: map[string]core.IntegrationSecret{
+			"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+			"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+			"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+		},
+	}
+
+	batchGetImageResponse := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+				{
+					"images": [
+						{
+							"registryId": "123456789012",
+							"repositoryName": "backend",
+							"imageId": {"imageDigest": "sha256:abc", "imageTag": "1f3c2a9"},
+							"imageManifest": "{\"schemaVersion\":2}",
+							"imageManifestMediaType": "application/vnd.docker.distribution.manifest.v2+json"
+						}
+					],
+					"failures": []
+				}
+			`)),
+		}
+	}
+
+	t.Run("invalid tag -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "backend",
+				"image":      "1f3c2a9",
+				"tags":       []any{"release/1.0"},
+			},
+			Integration:    integration,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "invalid image tag: release/1.0")
+	})
+
+	t.Run("image not found -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						{
+							"images": [],
+							"failures": [
+								{
+									"imageId": {"imageTag": "missing"},
+									"failureCode": "ImageTagDoesNotMatchDigest",
+									"failureReason": "Requested image not found"
+								}
+							]
+						}
+					`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "backend",
+				"image":      "missing",
+				"tags":       []any{"production"},
+			},
+			HTTP:           httpContext,
+			Integration:    integration,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "Requested image not found")
+	})
+
+	t.Run("tags image -> emits", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				batchGetImageResponse(),
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"image": {"imageId": {"imageDigest": "sha256:abc", "imageTag": "v1.4.0"}}}`)),
+				},
+				{
+					StatusCode: http.StatusBadRequest,
+					Body: io.NopCloser(strings.NewReader(`
+						{
+							"__type": "ImageAlreadyExistsException",
+							"message": "Image with digest 'sha256:abc' and tag 'production' already exists"
+						}
+					`)),
+				},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "arn:aws:ecr:us-east-1:123456789012:repository/backend",
+				"image":      "1f3c2a9",
+				"tags":       []any{"v1.4.0", "production"},
+			},
+			HTTP:           httpContext,
+			Integration:    integration,
+			ExecutionState: execState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, "aws.ecr.image.tagged", execState.Type)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "backend", payload["repositoryName"])
+		assert.Equal(t, "sha256:abc", payload["imageDigest"])
+		assert.Equal(t, []string{"v1.4.0", "production"}, payload["tags"])
+
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, "AmazonEC2ContainerRegistry_V20150921.BatchGetImage", httpContext.Requests[0].Header.Get("X-Amz-Target"))
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"repositoryName": "backend", "imageIds": [{"imageTag": "1f3c2a9"}]}`, string(body))
+
+		assert.Equal(t, "AmazonEC2ContainerRegistry_V20150921.PutImage", httpContext.Requests[1].Header.Get("X-Amz-Target"))
+		body, err = io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"repositoryName": "backend",
+			"imageManifest": "{\"schemaVersion\":2}",
+			"imageManifestMediaType": "application/vnd.docker.distribution.manifest.v2+json",
+			"imageDigest": "sha256:abc",
+			"imageTag": "v1.4.0"
+		}`, string(body))
+	})
+
+	t.Run("immutable tag -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				batchGetImageResponse(),
+				{
+					StatusCode: http.StatusBadRequest,
+					Body: io.NopCloser(strings.NewReader(`
+						{
+							"__type": "ImageTagAlreadyExistsException",
+							"message": "The image tag 'production' already exists and cannot be overwritten"
+						}
+					`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "backend",
+				"image":      "sha256:abc",
+				"tags":       []any{"production"},
+			},
+			HTTP:           httpContext,
+			Integration:    integration,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "failed to tag image with production")
+	})
+}
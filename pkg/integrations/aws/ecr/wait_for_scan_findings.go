@@ -0,0 +1,412 @@
+package ecr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	ScanFindingsPassedOutputChannel = "passed"
+	ScanFindingsFailedOutputChannel = "failed"
+
+	SeverityThresholdNone = "NONE"
+
+	DefaultScanFindingsTimeoutMinutes = 30
+	MaxScanFindingsTimeoutMinutes     = 1440
+
+	scanFindingsPollAction   = "pollScanFindings"
+	scanFindingsPollInterval = 15 * time.Second
+)
+
+/*
+ * Severities, from the highest to the lowest.
+ */
+var findingSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL"}
+
+/*
+ * Scan statuses that end the wait. Basic scanning reports COMPLETE,
+ * and enhanced scanning reports ACTIVE once the image has findings.
+ */
+var (
+	scanCompletedStatuses = []string{"COMPLETE", "ACTIVE"}
+	scanFailedStatuses    = []string{"FAILED", "UNSUPPORTED_IMAGE", "FINDINGS_UNAVAILABLE", "SCAN_ELIGIBILITY_EXPIRED", "LIMIT_EXCEEDED"}
+)
+
+type WaitForScanFindings struct{}
+
+type WaitForScanFindingsConfiguration struct {
+	Region            string `json:"region" mapstructure:"region"`
+	Repository        string `json:"repository" mapstructure:"repository"`
+	Image             string `json:"image" mapstructure:"image"`
+	SeverityThreshold string `json:"severityThreshold" mapstructure:"severityThreshold"`
+	TimeoutMinutes    int    `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+type WaitForScanFindingsMetadata struct {
+	Region            string `json:"region" mapstructure:"region"`
+	Repository        string `json:"repository" mapstructure:"repository"`
+	ImageDigest       string `json:"imageDigest" mapstructure:"imageDigest"`
+	ImageTag          string `json:"imageTag" mapstructure:"imageTag"`
+	SeverityThreshold string `json:"severityThreshold" mapstructure:"severityThreshold"`
+	Deadline          string `json:"deadline" mapstructure:"deadline"`
+}
+
+func (c *WaitForScanFindings) Name() string {
+	return "aws.ecr.waitForScanFindings"
+}
+
+func (c *WaitForScanFindings) Label() string {
+	return "ECR • Wait For Scan Findings"
+}
+
+func (c *WaitForScanFindings) Description() string {
+	return "Wait for the scan of an ECR image and gate on its findings"
+}
+
+func (c *WaitForScanFindings) Documentation() string {
+	return `The Wait For Scan Findings component waits for the vulnerability scan of an ECR image to finish, and routes the execution depending on its findings.
+
+## Use Cases
+
+- **Image promotion gates**: Only promote images without high or critical vulnerabilities
+- **Scan on push**: Wait for the scan started when the image was pushed
+- **Enhanced scanning**: Wait for the findings of Amazon Inspector
+
+## Configuration
+
+- **Region**: AWS region of the ECR repository
+- **Repository**: ECR repository of the image
+- **Image**: Tag or digest of the image
+- **Severity Threshold**: Lowest severity that sends the execution to the failed channel. Defaults to ` + "`HIGH`" + `
+- **Timeout (minutes)**: How long to wait for the scan before failing. Defaults to 30 minutes
+
+This component does not start a scan. Use **Scan Image** to start one.
+
+## Output Channels
+
+- **Passed**: The image has no findings at or above the severity threshold
+- **Failed**: The image has findings at or above the severity threshold
+
+## Completion behavior
+
+- The scan status is checked every 15 seconds.
+- The execution fails if the scan fails, the image is not supported, or the timeout is reached.`
+}
+
+func (c *WaitForScanFindings) Icon() string {
+	return "aws"
+}
+
+func (c *WaitForScanFindings) Color() string {
+	return "gray"
+}
+
+func (c *WaitForScanFindings) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{
+			Name:  ScanFindingsPassedOutputChannel,
+			Label: "Passed",
+		},
+		{
+			Name:  ScanFindingsFailedOutputChannel,
+			Label: "Failed",
+		},
+	}
+}
+
+func (c *WaitForScanFindings) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "repository",
+			Label:       "Repository",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "ECR repository name or ARN",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "ecr.repository",
+					UseNameAsValue: true,
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+		},
+		imageField("Image", "Tag or digest of the scanned image"),
+		{
+			Name:        "severityThreshold",
+			Label:       "Severity Threshold",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     "HIGH",
+			Description: "Findings at or above this severity send the execution to the failed channel",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Critical", Value: "CRITICAL"},
+						{Label: "High", Value: "HIGH"},
+						{Label: "Medium", Value: "MEDIUM"},
+						{Label: "Low", Value: "LOW"},
+						{Label: "Informational", Value: "INFORMATIONAL"},
+						{Label: "None (always pass)", Value: SeverityThresholdNone},
+					},
+				},
+			},
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultScanFindingsTimeoutMinutes,
+			Description: "How long to wait for the scan before failing",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := MaxScanFindingsTimeoutMinutes; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func decodeWaitForScanFindingsConfiguration(raw any) (WaitForScanFindingsConfiguration, error) {
+	config := WaitForScanFindingsConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return WaitForScanFindingsConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = strings.TrimSpace(config.Region)
+	config.Repository = strings.TrimSpace(config.Repository)
+	config.Image = strings.TrimSpace(config.Image)
+	config.SeverityThreshold = strings.ToUpper(strings.TrimSpace(config.SeverityThreshold))
+
+	if config.Region == "" {
+		return WaitForScanFindingsConfiguration{}, fmt.Errorf("region is required")
+	}
+
+	if config.Repository == "" {
+		return WaitForScanFindingsConfiguration{}, fmt.Errorf("repository is required")
+	}
+
+	if config.Image == "" {
+		return WaitForScanFindingsConfiguration{}, fmt.Errorf("image is required")
+	}
+
+	if config.SeverityThreshold == "" {
+		config.SeverityThreshold = "HIGH"
+	}
+
+	if config.SeverityThreshold != SeverityThresholdNone && !slices.Contains(findingSeverities, config.SeverityThreshold) {
+		return WaitForScanFindingsConfiguration{}, fmt.Errorf("invalid severity threshold: %s", config.SeverityThreshold)
+	}
+
+	if config.TimeoutMinutes == 0 {
+		config.TimeoutMinutes = DefaultScanFindingsTimeoutMinutes
+	}
+
+	if config.TimeoutMinutes < 1 || config.TimeoutMinutes > MaxScanFindingsTimeoutMinutes {
+		return WaitForScanFindingsConfiguration{}, fmt.Errorf("timeout must be between 1 and %d minutes", MaxScanFindingsTimeoutMinutes)
+	}
+
+	return config, nil
+}
+
+func (c *WaitForScanFindings) Setup(ctx core.SetupContext) error {
+	_, err := decodeWaitForScanFindingsConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *WaitForScanFindings) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *WaitForScanFindings) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeWaitForScanFindingsConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	repositoryName, err := repositoryNameFromRef(config.Repository)
+	if err != nil {
+		return err
+	}
+
+	imageDigest, imageTag := imageReference(config.Image)
+	metadata := WaitForScanFindingsMetadata{
+		Region:            config.Region,
+		Repository:        repositoryName,
+		ImageDigest:       imageDigest,
+		ImageTag:          imageTag,
+		SeverityThreshold: config.SeverityThreshold,
+		Deadline:          time.Now().Add(time.Duration(config.TimeoutMinutes) * time.Minute).Format(time.RFC3339),
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	return c.checkFindings(ctx.HTTP, ctx.Integration, ctx.Requests, ctx.ExecutionState, metadata)
+}
+
+func (c *WaitForScanFindings) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        scanFindingsPollAction,
+			Description: "Check the scan status of the image",
+		},
+	}
+}
+
+func (c *WaitForScanFindings) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case scanFindingsPollAction:
+		return c.pollFindings(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *WaitForScanFindings) pollFindings(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := WaitForScanFindingsMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return c.checkFindings(ctx.HTTP, ctx.Integration, ctx.Requests, ctx.ExecutionState, metadata)
+}
+
+/*
+ * Routes the findings once the scan is done, fails the execution if the scan failed
+ * or the deadline has passed, and schedules the next poll otherwise.
+ */
+func (c *WaitForScanFindings) checkFindings(
+	httpCtx core.HTTPContext,
+	integration core.IntegrationContext,
+	requests core.RequestContext,
+	executionState core.ExecutionStateContext,
+	metadata WaitForScanFindingsMetadata,
+) error {
+	creds, err := common.CredentialsFromInstallation(integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(httpCtx, creds, metadata.Region)
+	findings, err := client.DescribeImageScanFindings(metadata.Repository, metadata.ImageDigest, metadata.ImageTag)
+
+	//
+	// With scan on push, the scan might not exist yet.
+	//
+	status := ""
+	var awsErr *common.Error
+	switch {
+	case err == nil:
+		status = findings.ImageScanStatus.Status
+	case errors.As(err, &awsErr) && awsErr.Code == "ScanNotFoundException":
+		status = "NOT_FOUND"
+	default:
+		return fmt.Errorf("failed to describe image scan findings: %w", err)
+	}
+
+	if slices.Contains(scanFailedStatuses, status) {
+		message := fmt.Sprintf("image scan finished with status %s", status)
+		if findings.ImageScanStatus.Description != "" {
+			message = fmt.Sprintf("%s: %s", message, findings.ImageScanStatus.Description)
+		}
+
+		return executionState.Fail(models.CanvasNodeExecutionResultReasonError, message)
+	}
+
+	if slices.Contains(scanCompletedStatuses, status) {
+		channel := ScanFindingsPassedOutputChannel
+		if exceedsSeverityThreshold(findings.ImageScanFindings.FindingSeverityCounts, metadata.SeverityThreshold) {
+			channel = ScanFindingsFailedOutputChannel
+		}
+
+		return executionState.Emit(channel, "aws.ecr.image.scanFindings", []any{findings})
+	}
+
+	deadline, err := time.Parse(time.RFC3339, metadata.Deadline)
+	if err != nil {
+		return fmt.Errorf("failed to parse deadline: %w", err)
+	}
+
+	if !time.Now().Before(deadline) {
+		return executionState.Fail(
+			models.CanvasNodeExecutionResultReasonError,
+			fmt.Sprintf("timed out waiting for image scan, last status: %s", status),
+		)
+	}
+
+	return requests.ScheduleActionCall(scanFindingsPollAction, map[string]any{}, scanFindingsPollInterval)
+}
+
+func exceedsSeverityThreshold(counts map[string]int, threshold string) bool {
+	if threshold == SeverityThresholdNone {
+		return false
+	}
+
+	for _, severity := range findingSeverities {
+		if counts[severity] > 0 {
+			return true
+		}
+
+		if severity == threshold {
+			return false
+		}
+	}
+
+	return false
+}
+
+func (c *WaitForScanFindings) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *WaitForScanFindings) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *WaitForScanFindings) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
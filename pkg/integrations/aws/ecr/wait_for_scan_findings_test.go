@@ -0,0 +1,209 @@
+package ecr
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__WaitForScanFindings__Setup(t *testing.T) {
+	component := &WaitForScanFindings{}
+
+	t.Run("missing image -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":     "us-east-1",
+				"repository": "backend",
+			},
+		})
+
+		require.ErrorContains(t, err, "image is required")
+	})
+
+	t.Run("invalid severity threshold -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":            "us-east-1",
+				"repository":        "backend",
+				"image":             "latest",
+				"severityThreshold": "SEVERE",
+			},
+		})
+
+		require.ErrorContains(t, err, "invalid severity threshold")
+	})
+
+	t.Run("timeout out of range -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":         "us-east-1",
+				"repository":     "backend",
+				"image":          "latest",
+				"timeoutMinutes": 2000,
+			},
+		})
+
+		require.ErrorContains(t, err, "timeout must be between 1 and 1440 minutes")
+	})
+}
+
+func Test__WaitForScanFindings__Execute(t *testing.T) {
+	component := &WaitForScanFindings{}
+	integration := &contexts.IntegrationContext{
+		Secrets: map[string]core.IntegrationSecret{
+			"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+			"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+			"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+		},
+	}
+
+	findingsResponse := func(status string, counts string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+				{
+					"repositoryName": "backend",
+					"imageId": {"imageDigest": "sha256:abc"},
+					"imageScanStatus": {"status": "` + status + `", "description": "Scan status"},
+					"imageScanFindings": {"findingSeverityCounts": ` + counts + `}
+				}
+			`)),
+		}
+	}
+
+	execute := func(threshold string, responses ...*http.Response) (*contexts.ExecutionStateContext, *contexts.RequestContext, *contexts.HTTPContext, error) {
+		httpContext := &contexts.HTTPContext{Responses: responses}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":            "us-east-1",
+				"repository":        "backend",
+				"image":             "sha256:abc",
+				"severityThreshold": threshold,
+			},
+			HTTP:           httpContext,
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       requests,
+			Integration:    integration,
+			ExecutionState: execState,
+		})
+
+		return execState, requests, httpContext, err
+	}
+
+	t.Run("findings below threshold -> passed", func(t *testing.T) {
+		execState, _, httpContext, err := execute("HIGH", findingsResponse("COMPLETE", `{"MEDIUM": 3, "LOW": 1}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, ScanFindingsPassedOutputChannel, execState.Channel)
+		assert.Equal(t, "aws.ecr.image.scanFindings", execState.Type)
+
+		require.Len(t, httpContext.Requests, 1)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"repositoryName": "backend", "imageId": {"imageDigest": "sha256:abc"}}`, string(body))
+	})
+
+	t.Run("findings at threshold -> failed", func(t *testing.T) {
+		execState, _, _, err := execute("HIGH", findingsResponse("COMPLETE", `{"HIGH": 1}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, ScanFindingsFailedOutputChannel, execState.Channel)
+	})
+
+	t.Run("findings above threshold -> failed", func(t *testing.T) {
+		execState, _, _, err := execute("MEDIUM", findingsResponse("ACTIVE", `{"CRITICAL": 1}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, ScanFindingsFailedOutputChannel, execState.Channel)
+	})
+
+	t.Run("no threshold -> passed", func(t *testing.T) {
+		execState, _, _, err := execute(SeverityThresholdNone, findingsResponse("COMPLETE", `{"CRITICAL": 1}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, ScanFindingsPassedOutputChannel, execState.Channel)
+	})
+
+	t.Run("scan in progress -> schedules poll", func(t *testing.T) {
+		execState, requests, _, err := execute("HIGH", findingsResponse("IN_PROGRESS", `{}`))
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, scanFindingsPollAction, requests.Action)
+		assert.Equal(t, scanFindingsPollInterval, requests.Duration)
+	})
+
+	t.Run("scan not found yet -> schedules poll", func(t *testing.T) {
+		execState, requests, _, err := execute("HIGH", &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(strings.NewReader(`{"__type": "ScanNotFoundException", "message": "Image scan does not exist"}`)),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, scanFindingsPollAction, requests.Action)
+	})
+
+	t.Run("scan failed -> fails execution", func(t *testing.T) {
+		execState, _, _, err := execute("HIGH", findingsResponse("UNSUPPORTED_IMAGE", `{}`))
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Equal(t, "image scan finished with status UNSUPPORTED_IMAGE: Scan status", execState.FailureMessage)
+	})
+}
+
+func Test__WaitForScanFindings__HandleAction(t *testing.T) {
+	component := &WaitForScanFindings{}
+
+	t.Run("unknown action -> error", func(t *testing.T) {
+		err := component.HandleAction(core.ActionContext{Name: "unknown"})
+		require.ErrorContains(t, err, "unknown action")
+	})
+
+	t.Run("deadline passed -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: scanFindingsPollAction,
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"imageScanStatus": {"status": "IN_PROGRESS"}}`)),
+					},
+				},
+			},
+			Metadata: &contexts.MetadataContext{
+				Metadata: WaitForScanFindingsMetadata{
+					Region:            "us-east-1",
+					Repository:        "backend",
+					ImageTag:          "latest",
+					SeverityThreshold: "HIGH",
+					Deadline:          time.Now().Add(-time.Minute).Format(time.RFC3339),
+				},
+			},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration: &contexts.IntegrationContext{
+				Secrets: map[string]core.IntegrationSecret{
+					"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+					"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+					"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Equal(t, "timed out waiting for image scan, last status: IN_PROGRESS", execState.FailureMessage)
+	})
+}
@@ -212,7 +212,7 @@ type StopTaskResponse struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      region,
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -553,49 +553,40 @@ func (c *Client) signRequest(req *http.Request, payload []byte) error {
 }
 
 func (c *Client) listPaginatedStrings(action string, basePayload map[string]any, responseField string) ([]string, error) {
-	results := []string{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(token string) ([]string, string, error) {
 		payload := clonePayload(basePayload)
 		payload["maxResults"] = 100
-		if nextToken != "" {
-			payload["nextToken"] = nextToken
+		if token != "" {
+			payload["nextToken"] = token
 		}
 
 		response := map[string]json.RawMessage{}
 		if err := c.postJSON(action, payload, &response); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		rawItems, ok := response[responseField]
 		if !ok {
-			return nil, fmt.Errorf("missing %s in %s response", responseField, action)
+			return nil, "", fmt.Errorf("missing %s in %s response", responseField, action)
 		}
 
 		pageItems := []string{}
 		if err := json.Unmarshal(rawItems, &pageItems); err != nil {
-			return nil, fmt.Errorf("failed to decode %s in %s response: %w", responseField, action, err)
+			return nil, "", fmt.Errorf("failed to decode %s in %s response: %w", responseField, action, err)
 		}
-		results = append(results, pageItems...)
 
 		rawNextToken, ok := response["nextToken"]
 		if !ok {
-			break
+			return pageItems, "", nil
 		}
 
-		pageNextToken := ""
-		if err := json.Unmarshal(rawNextToken, &pageNextToken); err != nil {
-			return nil, fmt.Errorf("failed to decode nextToken in %s response: %w", action, err)
-		}
-		if pageNextToken == "" {
-			break
+		nextToken := ""
+		if err := json.Unmarshal(rawNextToken, &nextToken); err != nil {
+			return nil, "", fmt.Errorf("failed to decode nextToken in %s response: %w", action, err)
 		}
 
-		nextToken = pageNextToken
-	}
-
-	return results, nil
+		return pageItems, nextToken, nil
+	})
 }
 
 func clonePayload(base map[string]any) map[string]any {
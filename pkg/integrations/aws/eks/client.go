@@ -0,0 +1,182 @@
+package eks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+const (
+	/*
+	 * EKS accepts tokens for 15 minutes after they are signed.
+	 * The expiration reported to users leaves a margin for clock skew.
+	 */
+	TokenLifetime = 14 * time.Minute
+	TokenPrefix   = "k8s-aws-v1."
+
+	clusterIDHeader = "x-k8s-aws-id"
+)
+
+type Client struct {
+	http        core.HTTPContext
+	region      string
+	credentials *aws.Credentials
+	signer      *v4.Signer
+}
+
+type Cluster struct {
+	Name                 string                `json:"name"`
+	Arn                  string                `json:"arn"`
+	Endpoint             string                `json:"endpoint"`
+	Status               string                `json:"status"`
+	Version              string                `json:"version"`
+	PlatformVersion      string                `json:"platformVersion"`
+	CreatedAt            common.FloatTime      `json:"createdAt,omitempty"`
+	CertificateAuthority *CertificateAuthority `json:"certificateAuthority,omitempty"`
+}
+
+type CertificateAuthority struct {
+	Data string `json:"data"`
+}
+
+type Token struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
+	return &Client{
+		http:        common.NewHTTPClient(httpCtx),
+		region:      region,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+	}
+}
+
+func (c *Client) ListClusters() ([]string, error) {
+	return common.CollectPages(func(token string) ([]string, string, error) {
+		query := url.Values{}
+		query.Set("maxResults", "100")
+		if token != "" {
+			query.Set("nextToken", token)
+		}
+
+		var response struct {
+			Clusters  []string `json:"clusters"`
+			NextToken string   `json:"nextToken"`
+		}
+
+		if err := c.get("/clusters?"+query.Encode(), &response); err != nil {
+			return nil, "", err
+		}
+
+		return response.Clusters, response.NextToken, nil
+	})
+}
+
+func (c *Client) DescribeCluster(name string) (*Cluster, error) {
+	var response struct {
+		Cluster Cluster `json:"cluster"`
+	}
+
+	if err := c.get("/clusters/"+url.PathEscape(name), &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Cluster, nil
+}
+
+/*
+ * GenerateToken creates a bearer token for the Kubernetes API of an EKS cluster,
+ * the same way as `aws eks get-token`: a presigned STS GetCallerIdentity request
+ * bound to the cluster name. The cluster resolves it to the IAM identity of the integration.
+ */
+func (c *Client) GenerateToken(clusterName string) (*Token, error) {
+	query := url.Values{}
+	query.Set("Action", "GetCallerIdentity")
+	query.Set("Version", "2011-06-15")
+	query.Set("X-Amz-Expires", "60")
+
+	endpoint := fmt.Sprintf("https://sts.%s.amazonaws.com/?%s", c.region, query.Encode())
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+
+	req.Header.Set(clusterIDHeader, clusterName)
+
+	now := time.Now()
+	hash := sha256.Sum256([]byte{})
+	presignedURL, _, err := c.signer.PresignHTTP(
+		context.Background(),
+		*c.credentials,
+		req,
+		hex.EncodeToString(hash[:]),
+		"sts",
+		c.region,
+		now,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign token request: %w", err)
+	}
+
+	return &Token{
+		Token:     TokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presignedURL)),
+		ExpiresAt: now.Add(TokenLifetime),
+	}, nil
+}
+
+func (c *Client) get(path string, out any) error {
+	endpoint := fmt.Sprintf("https://eks.%s.amazonaws.com%s", c.region, path)
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	if err := c.signRequest(req, []byte{}); err != nil {
+		return err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		if awsErr := common.ParseError(body); awsErr != nil {
+			return awsErr
+		}
+		return fmt.Errorf("EKS API request failed with %d: %s", res.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) signRequest(req *http.Request, payload []byte) error {
+	hash := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(hash[:])
+	return c.signer.SignHTTP(context.Background(), *c.credentials, req, payloadHash, "eks", c.region, time.Now())
+}
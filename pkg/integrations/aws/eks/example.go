@@ -0,0 +1,18 @@
+package eks
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_get_cluster_credentials.json
+var exampleOutputGetClusterCredentialsBytes []byte
+
+var exampleOutputGetClusterCredentialsOnce sync.Once
+var exampleOutputGetClusterCredentials map[string]any
+
+func (c *GetClusterCredentials) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetClusterCredentialsOnce, exampleOutputGetClusterCredentialsBytes, &exampleOutputGetClusterCredentials)
+}
@@ -0,0 +1,228 @@
+package eks
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+const ClusterStatusActive = "ACTIVE"
+
+type GetClusterCredentials struct{}
+
+type GetClusterCredentialsConfiguration struct {
+	Region    string `json:"region" mapstructure:"region"`
+	Cluster   string `json:"cluster" mapstructure:"cluster"`
+	Namespace string `json:"namespace" mapstructure:"namespace"`
+}
+
+func (c *GetClusterCredentials) Name() string {
+	return "aws.eks.getClusterCredentials"
+}
+
+func (c *GetClusterCredentials) Label() string {
+	return "EKS • Get Cluster Credentials"
+}
+
+func (c *GetClusterCredentials) Description() string {
+	return "Generate a short-lived kubeconfig for an EKS cluster"
+}
+
+func (c *GetClusterCredentials) Documentation() string {
+	return `The Get Cluster Credentials component generates a short-lived token and kubeconfig for an Amazon EKS cluster, using the credentials of the AWS integration.
+
+## Use Cases
+
+- **Kubernetes deployments on EKS**: Pass the kubeconfig to the Kubernetes components, without creating service account tokens
+- **Ephemeral clusters**: Connect to clusters created earlier in the workflow
+
+## Configuration
+
+- **Region**: AWS region of the cluster
+- **Cluster**: EKS cluster to connect to
+- **Namespace**: Optional default namespace of the kubeconfig
+
+## Using the kubeconfig
+
+Set the **Kubeconfig** field of a Kubernetes component to the ` + "`kubeconfig`" + ` of this component, e.g. ` + "`{{ $[\"EKS • Get Cluster Credentials\"].data.kubeconfig }}`" + `.
+
+The token is valid for about 15 minutes, like ` + "`aws eks get-token`" + `. Get new credentials before long-running steps.
+
+## Access
+
+The IAM role of the AWS integration needs ` + "`eks:DescribeCluster`" + `, and access to the cluster through an EKS access entry or the ` + "`aws-auth`" + ` config map.`
+}
+
+func (c *GetClusterCredentials) Icon() string {
+	return "aws"
+}
+
+func (c *GetClusterCredentials) Color() string {
+	return "gray"
+}
+
+func (c *GetClusterCredentials) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetClusterCredentials) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:     "cluster",
+			Label:    "Cluster",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "eks.cluster",
+					UseNameAsValue: true,
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "namespace",
+			Label:       "Namespace",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "default",
+			Description: "Default namespace of the kubeconfig",
+		},
+	}
+}
+
+func decodeGetClusterCredentialsConfiguration(raw any) (GetClusterCredentialsConfiguration, error) {
+	config := GetClusterCredentialsConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return GetClusterCredentialsConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = strings.TrimSpace(config.Region)
+	config.Cluster = strings.TrimSpace(config.Cluster)
+	config.Namespace = strings.TrimSpace(config.Namespace)
+
+	if config.Region == "" {
+		return GetClusterCredentialsConfiguration{}, fmt.Errorf("region is required")
+	}
+
+	if config.Cluster == "" {
+		return GetClusterCredentialsConfiguration{}, fmt.Errorf("cluster is required")
+	}
+
+	return config, nil
+}
+
+func (c *GetClusterCredentials) Setup(ctx core.SetupContext) error {
+	_, err := decodeGetClusterCredentialsConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *GetClusterCredentials) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetClusterCredentials) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeGetClusterCredentialsConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	client := NewClient(ctx.HTTP, creds, config.Region)
+	cluster, err := client.DescribeCluster(config.Cluster)
+	if err != nil {
+		return fmt.Errorf("failed to describe cluster: %w", err)
+	}
+
+	if cluster.Status != ClusterStatusActive || cluster.Endpoint == "" {
+		return fmt.Errorf("cluster %s is not active: %s", config.Cluster, cluster.Status)
+	}
+
+	token, err := client.GenerateToken(cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, err := BuildKubeconfig(cluster, token.Token, config.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"aws.eks.cluster.credentials",
+		[]any{
+			map[string]any{
+				"cluster": map[string]any{
+					"name":            cluster.Name,
+					"arn":             cluster.Arn,
+					"endpoint":        cluster.Endpoint,
+					"status":          cluster.Status,
+					"version":         cluster.Version,
+					"platformVersion": cluster.PlatformVersion,
+				},
+				"region":     config.Region,
+				"token":      token.Token,
+				"expiresAt":  token.ExpiresAt.UTC().Format(time.RFC3339),
+				"kubeconfig": kubeconfig,
+			},
+		},
+	)
+}
+
+func (c *GetClusterCredentials) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *GetClusterCredentials) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *GetClusterCredentials) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *GetClusterCredentials) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetClusterCredentials) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
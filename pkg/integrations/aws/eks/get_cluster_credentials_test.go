@@ -0,0 +1,152 @@
+package eks
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/kubernetes"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testIntegrationWithCredentials() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Secrets: map[string]core.IntegrationSecret{
+			"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+			"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+			"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+		},
+	}
+}
+
+func testDescribeClusterResponse(status string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`
+			{
+				"cluster": {
+					"name": "production",
+					"arn": "arn:aws:eks:us-east-1:123456789012:cluster/production",
+					"endpoint": "https://ABCDEF.gr7.us-east-1.eks.amazonaws.com",
+					"status": "` + status + `",
+					"version": "1.31",
+					"platformVersion": "eks.12",
+					"createdAt": 1767225600.0,
+					"certificateAuthority": {"data": "Y2VydGlmaWNhdGU="}
+				}
+			}
+		`)),
+	}
+}
+
+func Test__GetClusterCredentials__Setup(t *testing.T) {
+	component := &GetClusterCredentials{}
+
+	t.Run("missing cluster -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1"},
+		})
+
+		require.ErrorContains(t, err, "cluster is required")
+	})
+
+	t.Run("valid configuration -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "cluster": "production"},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__GetClusterCredentials__Execute(t *testing.T) {
+	component := &GetClusterCredentials{}
+
+	t.Run("active cluster -> emits kubeconfig", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{testDescribeClusterResponse(ClusterStatusActive)},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":    "us-east-1",
+				"cluster":   "production",
+				"namespace": "web",
+			},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://eks.us-east-1.amazonaws.com/clusters/production", httpContext.Requests[0].URL.String())
+
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, "aws.eks.cluster.credentials", execState.Type)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.NotEmpty(t, data["expiresAt"])
+
+		//
+		// The token is a presigned STS request, bound to the cluster name.
+		//
+		token := data["token"].(string)
+		require.True(t, strings.HasPrefix(token, TokenPrefix))
+		decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, TokenPrefix))
+		require.NoError(t, err)
+		presignedURL, err := url.Parse(string(decoded))
+		require.NoError(t, err)
+		assert.Equal(t, "sts.us-east-1.amazonaws.com", presignedURL.Host)
+		assert.Equal(t, "GetCallerIdentity", presignedURL.Query().Get("Action"))
+		assert.Contains(t, presignedURL.Query().Get("X-Amz-SignedHeaders"), "x-k8s-aws-id")
+		assert.Equal(t, "token", presignedURL.Query().Get("X-Amz-Security-Token"))
+
+		//
+		// The kubeconfig can be used by the Kubernetes components.
+		//
+		config, err := kubernetes.ParseKubeconfig(data["kubeconfig"].(string), "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://ABCDEF.gr7.us-east-1.eks.amazonaws.com", config.Server)
+		assert.Equal(t, token, config.Token)
+		assert.Equal(t, []byte("certificate"), config.CertificateAuthority)
+		assert.Equal(t, "web", config.Namespace)
+	})
+
+	t.Run("cluster not active -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"region": "us-east-1", "cluster": "production"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{testDescribeClusterResponse("CREATING")},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, "cluster production is not active: CREATING")
+	})
+
+	t.Run("cluster not found -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"region": "us-east-1", "cluster": "missing"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					{
+						StatusCode: http.StatusNotFound,
+						Body:       io.NopCloser(strings.NewReader(`{"message": "No cluster found for name: missing."}`)),
+					},
+				},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, "No cluster found for name: missing.")
+	})
+}
@@ -0,0 +1,78 @@
+package eks
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+type kubeconfig struct {
+	APIVersion     string              `yaml:"apiVersion"`
+	Kind           string              `yaml:"kind"`
+	CurrentContext string              `yaml:"current-context"`
+	Clusters       []kubeconfigCluster `yaml:"clusters"`
+	Users          []kubeconfigUser    `yaml:"users"`
+	Contexts       []kubeconfigContext `yaml:"contexts"`
+}
+
+type kubeconfigCluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		Server                   string `yaml:"server"`
+		CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
+	} `yaml:"cluster"`
+}
+
+type kubeconfigUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		Token string `yaml:"token"`
+	} `yaml:"user"`
+}
+
+type kubeconfigContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster   string `yaml:"cluster"`
+		User      string `yaml:"user"`
+		Namespace string `yaml:"namespace,omitempty"`
+	} `yaml:"context"`
+}
+
+/*
+ * BuildKubeconfig returns a self-contained kubeconfig for the cluster,
+ * with the token embedded instead of the `aws eks get-token` exec plugin,
+ * so it can be used by the Kubernetes components.
+ */
+func BuildKubeconfig(cluster *Cluster, token string, namespace string) (string, error) {
+	config := kubeconfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentContext: cluster.Arn,
+	}
+
+	clusterEntry := kubeconfigCluster{Name: cluster.Arn}
+	clusterEntry.Cluster.Server = cluster.Endpoint
+	if cluster.CertificateAuthority != nil {
+		clusterEntry.Cluster.CertificateAuthorityData = cluster.CertificateAuthority.Data
+	}
+
+	userEntry := kubeconfigUser{Name: cluster.Arn}
+	userEntry.User.Token = token
+
+	contextEntry := kubeconfigContext{Name: cluster.Arn}
+	contextEntry.Context.Cluster = cluster.Arn
+	contextEntry.Context.User = cluster.Arn
+	contextEntry.Context.Namespace = namespace
+
+	config.Clusters = []kubeconfigCluster{clusterEntry}
+	config.Users = []kubeconfigUser{userEntry}
+	config.Contexts = []kubeconfigContext{contextEntry}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to build kubeconfig: %w", err)
+	}
+
+	return string(data), nil
+}
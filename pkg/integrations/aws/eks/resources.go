@@ -0,0 +1,37 @@
+package eks
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+func ListClusters(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	region := ctx.Parameters["region"]
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+
+	client := NewClient(ctx.HTTP, creds, region)
+	clusters, err := client.ListClusters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list EKS clusters: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(clusters))
+	for _, cluster := range clusters {
+		resources = append(resources, core.IntegrationResource{
+			Type: resourceType,
+			Name: cluster,
+			ID:   cluster,
+		})
+	}
+
+	return resources, nil
+}
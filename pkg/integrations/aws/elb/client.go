@@ -0,0 +1,271 @@
+package elb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+const (
+	serviceName = "elasticloadbalancing"
+	apiVersion  = "2015-12-01"
+)
+
+type Client struct {
+	http        core.HTTPContext
+	region      string
+	credentials *aws.Credentials
+	signer      *v4.Signer
+}
+
+type TargetGroup struct {
+	TargetGroupArn  string `json:"targetGroupArn" mapstructure:"targetGroupArn"`
+	TargetGroupName string `json:"targetGroupName" mapstructure:"targetGroupName"`
+	TargetType      string `json:"targetType" mapstructure:"targetType"`
+	Protocol        string `json:"protocol" mapstructure:"protocol"`
+	Port            int    `json:"port" mapstructure:"port"`
+	VpcID           string `json:"vpcId" mapstructure:"vpcId"`
+}
+
+/*
+ * Target is an instance ID, IP address, Lambda function ARN or load balancer ARN,
+ * depending on the target type of the target group. Port and AvailabilityZone are optional.
+ */
+type Target struct {
+	ID               string `json:"id" mapstructure:"id"`
+	Port             int    `json:"port,omitempty" mapstructure:"port"`
+	AvailabilityZone string `json:"availabilityZone,omitempty" mapstructure:"availabilityZone"`
+}
+
+type TargetHealth struct {
+	ID               string `json:"id" mapstructure:"id"`
+	Port             int    `json:"port" mapstructure:"port"`
+	AvailabilityZone string `json:"availabilityZone,omitempty" mapstructure:"availabilityZone"`
+	State            string `json:"state" mapstructure:"state"`
+	Reason           string `json:"reason,omitempty" mapstructure:"reason"`
+	Description      string `json:"description,omitempty" mapstructure:"description"`
+}
+
+func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
+	return &Client{
+		http:        common.NewHTTPClient(httpCtx),
+		region:      region,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+	}
+}
+
+func (c *Client) ListTargetGroups() ([]TargetGroup, error) {
+	return common.CollectPages(func(marker string) ([]TargetGroup, string, error) {
+		params := url.Values{}
+		params.Set("PageSize", "400")
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		response := describeTargetGroupsResponse{}
+		if err := c.postForm("DescribeTargetGroups", params, &response); err != nil {
+			return nil, "", err
+		}
+
+		targetGroups := make([]TargetGroup, 0, len(response.TargetGroups))
+		for _, targetGroup := range response.TargetGroups {
+			targetGroups = append(targetGroups, TargetGroup{
+				TargetGroupArn:  strings.TrimSpace(targetGroup.TargetGroupArn),
+				TargetGroupName: strings.TrimSpace(targetGroup.TargetGroupName),
+				TargetType:      strings.TrimSpace(targetGroup.TargetType),
+				Protocol:        strings.TrimSpace(targetGroup.Protocol),
+				Port:            targetGroup.Port,
+				VpcID:           strings.TrimSpace(targetGroup.VpcID),
+			})
+		}
+
+		return targetGroups, response.NextMarker, nil
+	})
+}
+
+func (c *Client) RegisterTargets(targetGroupArn string, targets []Target) (string, error) {
+	params := targetParams(targetGroupArn, targets)
+	response := targetsActionResponse{}
+	if err := c.postForm("RegisterTargets", params, &response); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(response.RequestID), nil
+}
+
+func (c *Client) DeregisterTargets(targetGroupArn string, targets []Target) (string, error) {
+	params := targetParams(targetGroupArn, targets)
+	response := targetsActionResponse{}
+	if err := c.postForm("DeregisterTargets", params, &response); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(response.RequestID), nil
+}
+
+/*
+ * DescribeTargetHealth returns the health of the given targets,
+ * or of every target of the target group if none are given.
+ */
+func (c *Client) DescribeTargetHealth(targetGroupArn string, targets []Target) ([]TargetHealth, error) {
+	params := targetParams(targetGroupArn, targets)
+	response := describeTargetHealthResponse{}
+	if err := c.postForm("DescribeTargetHealth", params, &response); err != nil {
+		return nil, err
+	}
+
+	health := make([]TargetHealth, 0, len(response.TargetHealthDescriptions))
+	for _, description := range response.TargetHealthDescriptions {
+		health = append(health, TargetHealth{
+			ID:               strings.TrimSpace(description.Target.ID),
+			Port:             description.Target.Port,
+			AvailabilityZone: strings.TrimSpace(description.Target.AvailabilityZone),
+			State:            strings.TrimSpace(description.TargetHealth.State),
+			Reason:           strings.TrimSpace(description.TargetHealth.Reason),
+			Description:      strings.TrimSpace(description.TargetHealth.Description),
+		})
+	}
+
+	return health, nil
+}
+
+func targetParams(targetGroupArn string, targets []Target) url.Values {
+	params := url.Values{}
+	params.Set("TargetGroupArn", strings.TrimSpace(targetGroupArn))
+	for i, target := range targets {
+		prefix := fmt.Sprintf("Targets.member.%d.", i+1)
+		params.Set(prefix+"Id", strings.TrimSpace(target.ID))
+		if target.Port > 0 {
+			params.Set(prefix+"Port", strconv.Itoa(target.Port))
+		}
+
+		if availabilityZone := strings.TrimSpace(target.AvailabilityZone); availabilityZone != "" {
+			params.Set(prefix+"AvailabilityZone", availabilityZone)
+		}
+	}
+
+	return params
+}
+
+func (c *Client) postForm(action string, params url.Values, out any) error {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	params.Set("Action", action)
+	params.Set("Version", apiVersion)
+
+	body := []byte(params.Encode())
+	endpoint := fmt.Sprintf("https://elasticloadbalancing.%s.amazonaws.com/", c.region)
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if err := c.signRequest(req, body); err != nil {
+		return err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		if awsErr := parseError(responseBody); awsErr != nil {
+			return awsErr
+		}
+		return fmt.Errorf("ELB API request failed with %d: %s", res.StatusCode, string(responseBody))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := xml.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) signRequest(req *http.Request, payload []byte) error {
+	hash := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(hash[:])
+	return c.signer.SignHTTP(context.Background(), *c.credentials, req, payloadHash, serviceName, c.region, time.Now())
+}
+
+func parseError(body []byte) *common.Error {
+	var errResp struct {
+		Error struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		} `xml:"Error"`
+	}
+
+	if err := xml.Unmarshal(body, &errResp); err == nil {
+		code := strings.TrimSpace(errResp.Error.Code)
+		message := strings.TrimSpace(errResp.Error.Message)
+		if code != "" || message != "" {
+			return &common.Error{Code: code, Message: message}
+		}
+	}
+
+	return nil
+}
+
+type targetsActionResponse struct {
+	RequestID string `xml:"ResponseMetadata>RequestId"`
+}
+
+type describeTargetGroupsResponse struct {
+	TargetGroups []xmlTargetGroup `xml:"DescribeTargetGroupsResult>TargetGroups>member"`
+	NextMarker   string           `xml:"DescribeTargetGroupsResult>NextMarker"`
+}
+
+type xmlTargetGroup struct {
+	TargetGroupArn  string `xml:"TargetGroupArn"`
+	TargetGroupName string `xml:"TargetGroupName"`
+	TargetType      string `xml:"TargetType"`
+	Protocol        string `xml:"Protocol"`
+	Port            int    `xml:"Port"`
+	VpcID           string `xml:"VpcId"`
+}
+
+type describeTargetHealthResponse struct {
+	TargetHealthDescriptions []xmlTargetHealthDescription `xml:"DescribeTargetHealthResult>TargetHealthDescriptions>member"`
+}
+
+type xmlTargetHealthDescription struct {
+	Target struct {
+		ID               string `xml:"Id"`
+		Port             int    `xml:"Port"`
+		AvailabilityZone string `xml:"AvailabilityZone"`
+	} `xml:"Target"`
+	TargetHealth struct {
+		State       string `xml:"State"`
+		Reason      string `xml:"Reason"`
+		Description string `xml:"Description"`
+	} `xml:"TargetHealth"`
+}
@@ -0,0 +1,288 @@
+package elb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	TargetStateHealthy  = "healthy"
+	TargetStateDraining = "draining"
+	TargetStateUnused   = "unused"
+
+	DefaultTimeoutMinutes = 15
+	MaxTimeoutMinutes     = 120
+
+	targetsPollAction   = "pollTargetHealth"
+	targetsPollInterval = 15 * time.Second
+)
+
+type TargetsConfiguration struct {
+	Region         string   `json:"region" mapstructure:"region"`
+	TargetGroupArn string   `json:"targetGroup" mapstructure:"targetGroup"`
+	Targets        []Target `json:"targets" mapstructure:"targets"`
+	Wait           *bool    `json:"wait,omitempty" mapstructure:"wait,omitempty"`
+	TimeoutMinutes int      `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+func (c *TargetsConfiguration) ShouldWait() bool {
+	return c.Wait == nil || *c.Wait
+}
+
+/*
+ * TargetsExecutionMetadata tracks the targets that were registered or deregistered,
+ * while the execution waits for them to reach the expected state.
+ */
+type TargetsExecutionMetadata struct {
+	Region         string   `json:"region" mapstructure:"region"`
+	TargetGroupArn string   `json:"targetGroupArn" mapstructure:"targetGroupArn"`
+	Targets        []Target `json:"targets" mapstructure:"targets"`
+	RequestID      string   `json:"requestId" mapstructure:"requestId"`
+	Deadline       string   `json:"deadline" mapstructure:"deadline"`
+}
+
+func targetsConfigurationFields(waitLabel, waitDescription string) []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "us-east-1",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: common.AllRegions,
+				},
+			},
+		},
+		{
+			Name:        "targetGroup",
+			Label:       "Target Group",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Target group of an Application, Network or Gateway Load Balancer",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "elb.targetGroup",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+		},
+		{
+			Name:        "targets",
+			Label:       "Targets",
+			Type:        configuration.FieldTypeList,
+			Required:    true,
+			Description: "Instance IDs or IP addresses, depending on the target type of the target group",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Target",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "id",
+								Label:       "ID",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Placeholder: "i-1234567890abcdef0 or 10.0.1.25",
+							},
+							{
+								Name:        "port",
+								Label:       "Port",
+								Type:        configuration.FieldTypeNumber,
+								Required:    false,
+								Description: "Defaults to the port of the target group",
+								TypeOptions: &configuration.TypeOptions{
+									Number: &configuration.NumberTypeOptions{
+										Min: func() *int { min := 1; return &min }(),
+										Max: func() *int { max := 65535; return &max }(),
+									},
+								},
+							},
+							{
+								Name:        "availabilityZone",
+								Label:       "Availability Zone",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Description: "Only for IP addresses outside of the VPC of the target group, e.g. all",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "wait",
+			Label:       waitLabel,
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: waitDescription,
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultTimeoutMinutes,
+			Description: "How long to wait before failing",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := MaxTimeoutMinutes; return &max }(),
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "wait", Values: []string{"true"}},
+			},
+		},
+	}
+}
+
+func decodeTargetsConfiguration(raw any) (TargetsConfiguration, error) {
+	config := TargetsConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return TargetsConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = strings.TrimSpace(config.Region)
+	config.TargetGroupArn = strings.TrimSpace(config.TargetGroupArn)
+	if config.Region == "" {
+		return TargetsConfiguration{}, fmt.Errorf("region is required")
+	}
+
+	if config.TargetGroupArn == "" {
+		return TargetsConfiguration{}, fmt.Errorf("target group is required")
+	}
+
+	targets := []Target{}
+	for _, target := range config.Targets {
+		target.ID = strings.TrimSpace(target.ID)
+		target.AvailabilityZone = strings.TrimSpace(target.AvailabilityZone)
+		if target.ID == "" {
+			continue
+		}
+
+		if target.Port < 0 || target.Port > 65535 {
+			return TargetsConfiguration{}, fmt.Errorf("invalid port %d for target %s", target.Port, target.ID)
+		}
+
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return TargetsConfiguration{}, fmt.Errorf("at least one target is required")
+	}
+
+	config.Targets = targets
+	if config.TimeoutMinutes == 0 {
+		config.TimeoutMinutes = DefaultTimeoutMinutes
+	}
+
+	if config.TimeoutMinutes < 1 || config.TimeoutMinutes > MaxTimeoutMinutes {
+		return TargetsConfiguration{}, fmt.Errorf("timeout must be between 1 and %d minutes", MaxTimeoutMinutes)
+	}
+
+	return config, nil
+}
+
+func newClient(httpCtx core.HTTPContext, integration core.IntegrationContext, region string) (*Client, error) {
+	creds, err := common.CredentialsFromInstallation(integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	return NewClient(httpCtx, creds, region), nil
+}
+
+func targetsPayload(metadata TargetsExecutionMetadata, health []TargetHealth) map[string]any {
+	return map[string]any{
+		"requestId":      metadata.RequestID,
+		"targetGroupArn": metadata.TargetGroupArn,
+		"targets":        health,
+	}
+}
+
+/*
+ * Emits the health of the targets once all of them are done,
+ * fails the execution once the deadline has passed,
+ * and schedules the next poll otherwise.
+ */
+func checkTargets(
+	httpCtx core.HTTPContext,
+	integration core.IntegrationContext,
+	requests core.RequestContext,
+	executionState core.ExecutionStateContext,
+	metadata TargetsExecutionMetadata,
+	payloadType string,
+	done func(TargetHealth) bool,
+) error {
+	client, err := newClient(httpCtx, integration, metadata.Region)
+	if err != nil {
+		return err
+	}
+
+	health, err := client.DescribeTargetHealth(metadata.TargetGroupArn, metadata.Targets)
+	if err != nil {
+		return fmt.Errorf("failed to describe target health: %w", err)
+	}
+
+	pending := []string{}
+	for _, target := range health {
+		if !done(target) {
+			pending = append(pending, fmt.Sprintf("%s is %s", target.ID, target.State))
+		}
+	}
+
+	if len(pending) == 0 {
+		return executionState.Emit(core.DefaultOutputChannel.Name, payloadType, []any{targetsPayload(metadata, health)})
+	}
+
+	deadline, err := time.Parse(time.RFC3339, metadata.Deadline)
+	if err != nil {
+		return fmt.Errorf("failed to parse deadline: %w", err)
+	}
+
+	if !time.Now().Before(deadline) {
+		return executionState.Fail(
+			models.CanvasNodeExecutionResultReasonError,
+			fmt.Sprintf("timed out waiting for targets: %s", strings.Join(pending, ", ")),
+		)
+	}
+
+	return requests.ScheduleActionCall(targetsPollAction, map[string]any{}, targetsPollInterval)
+}
+
+func pollTargets(ctx core.ActionContext, payloadType string, done func(TargetHealth) bool) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := TargetsExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return checkTargets(ctx.HTTP, ctx.Integration, ctx.Requests, ctx.ExecutionState, metadata, payloadType, done)
+}
@@ -0,0 +1,152 @@
+package elb
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const deregisterTargetsPayloadType = "aws.elb.targets.deregistered"
+
+type DeregisterTargets struct{}
+
+func (c *DeregisterTargets) Name() string {
+	return "aws.elb.deregisterTargets"
+}
+
+func (c *DeregisterTargets) Label() string {
+	return "ELB • Deregister Targets"
+}
+
+func (c *DeregisterTargets) Description() string {
+	return "Deregister instances or IP addresses from a load balancer target group"
+}
+
+func (c *DeregisterTargets) Documentation() string {
+	return `The Deregister Targets component deregisters instances or IP addresses from an Elastic Load Balancing target group, and waits for their connections to drain.
+
+## Use Cases
+
+- **Blue/green cutovers**: Deregister the old instances once the new ones are healthy
+- **Maintenance**: Take an instance out of service before patching or stopping it
+- **Decommissioning**: Remove instances before terminating them
+
+## Configuration
+
+- **Region**: AWS region of the target group
+- **Target Group**: Target group to deregister the targets from
+- **Targets**: Instance IDs or IP addresses, with the port they were registered with
+- **Wait for draining**: Wait until the connections to every target are drained. Enabled by default
+- **Timeout (minutes)**: How long to wait before failing. Defaults to 15 minutes
+
+## Completion behavior
+
+- When waiting, the state of the targets is checked every 15 seconds.
+- Draining takes up to the deregistration delay of the target group, 300 seconds by default.
+- The execution fails if some targets are still draining after the timeout.
+
+## Output
+
+The request ID, the target group ARN, and the ID, port, state and reason of each target.`
+}
+
+func (c *DeregisterTargets) Icon() string {
+	return "aws"
+}
+
+func (c *DeregisterTargets) Color() string {
+	return "gray"
+}
+
+func (c *DeregisterTargets) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *DeregisterTargets) Configuration() []configuration.Field {
+	return targetsConfigurationFields("Wait for draining", "Wait until the connections to every target are drained before emitting")
+}
+
+func (c *DeregisterTargets) Setup(ctx core.SetupContext) error {
+	_, err := decodeTargetsConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *DeregisterTargets) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *DeregisterTargets) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeTargetsConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, config.Region)
+	if err != nil {
+		return err
+	}
+
+	requestID, err := client.DeregisterTargets(config.TargetGroupArn, config.Targets)
+	if err != nil {
+		return fmt.Errorf("failed to deregister targets: %w", err)
+	}
+
+	metadata := TargetsExecutionMetadata{
+		Region:         config.Region,
+		TargetGroupArn: config.TargetGroupArn,
+		Targets:        config.Targets,
+		RequestID:      requestID,
+		Deadline:       time.Now().Add(time.Duration(config.TimeoutMinutes) * time.Minute).Format(time.RFC3339),
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if !config.ShouldWait() {
+		return checkTargets(ctx.HTTP, ctx.Integration, ctx.Requests, ctx.ExecutionState, metadata, deregisterTargetsPayloadType, func(TargetHealth) bool {
+			return true
+		})
+	}
+
+	return ctx.Requests.ScheduleActionCall(targetsPollAction, map[string]any{}, targetsPollInterval)
+}
+
+func isTargetDeregistered(target TargetHealth) bool {
+	return target.State == TargetStateUnused
+}
+
+func (c *DeregisterTargets) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        targetsPollAction,
+			Description: "Check the state of the deregistered targets",
+		},
+	}
+}
+
+func (c *DeregisterTargets) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case targetsPollAction:
+		return pollTargets(ctx, deregisterTargetsPayloadType, isTargetDeregistered)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *DeregisterTargets) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *DeregisterTargets) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *DeregisterTargets) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package elb
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__DeregisterTargets__Execute(t *testing.T) {
+	component := &DeregisterTargets{}
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{testTargetsActionResponse("DeregisterTargets")}}
+	requests := &contexts.RequestContext{}
+
+	err := component.Execute(core.ExecutionContext{
+		Configuration:  testTargetsConfiguration(true),
+		HTTP:           httpContext,
+		Metadata:       &contexts.MetadataContext{},
+		Requests:       requests,
+		ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		Integration:    testIntegrationWithCredentials(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, targetsPollAction, requests.Action)
+
+	require.Len(t, httpContext.Requests, 1)
+	body := testRequestBodyString(t, httpContext.Requests[0])
+	assert.Contains(t, body, "Action=DeregisterTargets")
+	assert.Contains(t, body, "Targets.member.1.Id=i-123")
+}
+
+func Test__DeregisterTargets__Poll(t *testing.T) {
+	component := &DeregisterTargets{}
+	metadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: TargetsExecutionMetadata{
+				Region:         "us-east-1",
+				TargetGroupArn: testTargetGroupArn,
+				Targets:        []Target{{ID: "i-123", Port: 80}},
+				Deadline:       time.Now().Add(time.Hour).Format(time.RFC3339),
+			},
+		}
+	}
+
+	t.Run("draining -> schedules next poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           targetsPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeTargetHealthResponse(TargetStateDraining, "Target.DeregistrationInProgress")}},
+			Metadata:       metadata(),
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, targetsPollAction, requests.Action)
+	})
+
+	t.Run("unused -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           targetsPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeTargetHealthResponse(TargetStateUnused, "Target.NotRegistered")}},
+			Metadata:       metadata(),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, deregisterTargetsPayloadType, execState.Type)
+	})
+}
@@ -0,0 +1,32 @@
+package elb
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_register_targets.json
+var exampleOutputRegisterTargetsBytes []byte
+
+var exampleOutputRegisterTargetsOnce sync.Once
+var exampleOutputRegisterTargets map[string]any
+
+//go:embed example_output_deregister_targets.json
+var exampleOutputDeregisterTargetsBytes []byte
+
+var exampleOutputDeregisterTargetsOnce sync.Once
+var exampleOutputDeregisterTargets map[string]any
+
+func (c *RegisterTargets) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRegisterTargetsOnce, exampleOutputRegisterTargetsBytes, &exampleOutputRegisterTargets)
+}
+
+func (c *DeregisterTargets) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputDeregisterTargetsOnce,
+		exampleOutputDeregisterTargetsBytes,
+		&exampleOutputDeregisterTargets,
+	)
+}
@@ -0,0 +1,152 @@
+package elb
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const registerTargetsPayloadType = "aws.elb.targets.registered"
+
+type RegisterTargets struct{}
+
+func (c *RegisterTargets) Name() string {
+	return "aws.elb.registerTargets"
+}
+
+func (c *RegisterTargets) Label() string {
+	return "ELB • Register Targets"
+}
+
+func (c *RegisterTargets) Description() string {
+	return "Register instances or IP addresses in a load balancer target group"
+}
+
+func (c *RegisterTargets) Documentation() string {
+	return `The Register Targets component registers instances or IP addresses in an Elastic Load Balancing target group, and waits for them to be healthy.
+
+## Use Cases
+
+- **Blue/green cutovers**: Register the new instances after a deploy, then deregister the old ones
+- **Instance replacement**: Put a new instance behind the load balancer once it is ready
+- **Manual scaling**: Add capacity to a target group from a workflow
+
+## Configuration
+
+- **Region**: AWS region of the target group
+- **Target Group**: Target group to register the targets in
+- **Targets**: Instance IDs or IP addresses, with an optional port and availability zone
+- **Wait for healthy**: Wait until every target passes the health checks. Enabled by default
+- **Timeout (minutes)**: How long to wait before failing. Defaults to 15 minutes
+
+## Completion behavior
+
+- When waiting, the health of the targets is checked every 15 seconds.
+- Targets of target groups with health checks disabled don't need to be healthy.
+- The execution fails if some targets are not healthy before the timeout.
+
+## Output
+
+The request ID, the target group ARN, and the ID, port, state and reason of each target.`
+}
+
+func (c *RegisterTargets) Icon() string {
+	return "aws"
+}
+
+func (c *RegisterTargets) Color() string {
+	return "gray"
+}
+
+func (c *RegisterTargets) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *RegisterTargets) Configuration() []configuration.Field {
+	return targetsConfigurationFields("Wait for healthy", "Wait until every target is healthy before emitting")
+}
+
+func (c *RegisterTargets) Setup(ctx core.SetupContext) error {
+	_, err := decodeTargetsConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *RegisterTargets) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *RegisterTargets) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeTargetsConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, config.Region)
+	if err != nil {
+		return err
+	}
+
+	requestID, err := client.RegisterTargets(config.TargetGroupArn, config.Targets)
+	if err != nil {
+		return fmt.Errorf("failed to register targets: %w", err)
+	}
+
+	metadata := TargetsExecutionMetadata{
+		Region:         config.Region,
+		TargetGroupArn: config.TargetGroupArn,
+		Targets:        config.Targets,
+		RequestID:      requestID,
+		Deadline:       time.Now().Add(time.Duration(config.TimeoutMinutes) * time.Minute).Format(time.RFC3339),
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if !config.ShouldWait() {
+		return checkTargets(ctx.HTTP, ctx.Integration, ctx.Requests, ctx.ExecutionState, metadata, registerTargetsPayloadType, func(TargetHealth) bool {
+			return true
+		})
+	}
+
+	return ctx.Requests.ScheduleActionCall(targetsPollAction, map[string]any{}, targetsPollInterval)
+}
+
+func isTargetHealthy(target TargetHealth) bool {
+	return target.State == TargetStateHealthy || target.Reason == "Target.HealthCheckDisabled"
+}
+
+func (c *RegisterTargets) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        targetsPollAction,
+			Description: "Check the health of the registered targets",
+		},
+	}
+}
+
+func (c *RegisterTargets) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case targetsPollAction:
+		return pollTargets(ctx, registerTargetsPayloadType, isTargetHealthy)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *RegisterTargets) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *RegisterTargets) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *RegisterTargets) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,239 @@
+package elb
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testTargetGroupArn = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/web/73e2d6bc24d8a067"
+
+func testIntegrationWithCredentials() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Secrets: map[string]core.IntegrationSecret{
+			"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+			"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+			"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+		},
+	}
+}
+
+func testRequestBodyString(t *testing.T, request *http.Request) string {
+	t.Helper()
+	body, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func testResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testTargetsActionResponse(action string) *http.Response {
+	return testResponse(`
+		<` + action + `Response xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+			<` + action + `Result/>
+			<ResponseMetadata><RequestId>req-targets</RequestId></ResponseMetadata>
+		</` + action + `Response>
+	`)
+}
+
+func testDescribeTargetHealthResponse(state, reason string) *http.Response {
+	return testResponse(`
+		<DescribeTargetHealthResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+			<DescribeTargetHealthResult>
+				<TargetHealthDescriptions>
+					<member>
+						<Target><Id>i-123</Id><Port>80</Port></Target>
+						<TargetHealth><State>` + state + `</State><Reason>` + reason + `</Reason></TargetHealth>
+					</member>
+				</TargetHealthDescriptions>
+			</DescribeTargetHealthResult>
+			<ResponseMetadata><RequestId>req-health</RequestId></ResponseMetadata>
+		</DescribeTargetHealthResponse>
+	`)
+}
+
+func testTargetsConfiguration(wait bool) map[string]any {
+	return map[string]any{
+		"region":      "us-east-1",
+		"targetGroup": testTargetGroupArn,
+		"targets": []any{
+			map[string]any{"id": "i-123", "port": float64(80)},
+		},
+		"wait": wait,
+	}
+}
+
+func Test__RegisterTargets__Setup(t *testing.T) {
+	component := &RegisterTargets{}
+
+	t.Run("no targets -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":      "us-east-1",
+				"targetGroup": testTargetGroupArn,
+				"targets":     []any{map[string]any{"id": " "}},
+			},
+		})
+
+		require.ErrorContains(t, err, "at least one target is required")
+	})
+
+	t.Run("target group is required -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1"},
+		})
+
+		require.ErrorContains(t, err, "target group is required")
+	})
+}
+
+func Test__RegisterTargets__Execute(t *testing.T) {
+	component := &RegisterTargets{}
+
+	t.Run("waits for healthy -> schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{testTargetsActionResponse("RegisterTargets")}}
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  testTargetsConfiguration(true),
+			HTTP:           httpContext,
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, targetsPollAction, requests.Action)
+		assert.Equal(t, targetsPollInterval, requests.Duration)
+
+		stored, ok := metadata.Metadata.(TargetsExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, testTargetGroupArn, stored.TargetGroupArn)
+		assert.Equal(t, []Target{{ID: "i-123", Port: 80}}, stored.Targets)
+		assert.Equal(t, "req-targets", stored.RequestID)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://elasticloadbalancing.us-east-1.amazonaws.com/", httpContext.Requests[0].URL.String())
+		body := testRequestBodyString(t, httpContext.Requests[0])
+		assert.Contains(t, body, "Action=RegisterTargets")
+		assert.Contains(t, body, "Targets.member.1.Id=i-123")
+		assert.Contains(t, body, "Targets.member.1.Port=80")
+	})
+
+	t.Run("does not wait -> emits target health", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: testTargetsConfiguration(false),
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				testTargetsActionResponse("RegisterTargets"),
+				testDescribeTargetHealthResponse("initial", "Elb.RegistrationInProgress"),
+			}},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, registerTargetsPayloadType, execState.Type)
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		targets := output["targets"].([]TargetHealth)
+		require.Len(t, targets, 1)
+		assert.Equal(t, "initial", targets[0].State)
+	})
+}
+
+func Test__RegisterTargets__Poll(t *testing.T) {
+	component := &RegisterTargets{}
+	metadata := func(deadline time.Time) *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: TargetsExecutionMetadata{
+				Region:         "us-east-1",
+				TargetGroupArn: testTargetGroupArn,
+				Targets:        []Target{{ID: "i-123", Port: 80}},
+				Deadline:       deadline.Format(time.RFC3339),
+			},
+		}
+	}
+
+	t.Run("healthy -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           targetsPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeTargetHealthResponse(TargetStateHealthy, "")}},
+			Metadata:       metadata(time.Now().Add(time.Hour)),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+	})
+
+	t.Run("still initial -> schedules next poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           targetsPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeTargetHealthResponse("initial", "Elb.InitialHealthChecking")}},
+			Metadata:       metadata(time.Now().Add(time.Hour)),
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, targetsPollAction, requests.Action)
+	})
+
+	t.Run("unhealthy after deadline -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           targetsPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeTargetHealthResponse("unhealthy", "Target.FailedHealthChecks")}},
+			Metadata:       metadata(time.Now().Add(-time.Minute)),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Equal(t, "timed out waiting for targets: i-123 is unhealthy", execState.FailureMessage)
+	})
+
+	t.Run("health checks disabled -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           targetsPollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeTargetHealthResponse("unavailable", "Target.HealthCheckDisabled")}},
+			Metadata:       metadata(time.Now().Add(time.Hour)),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+	})
+}
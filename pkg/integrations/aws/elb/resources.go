@@ -0,0 +1,38 @@
+package elb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+func ListTargetGroups(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
+	creds, err := common.CredentialsFromInstallation(ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	region := strings.TrimSpace(ctx.Parameters["region"])
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+
+	client := NewClient(ctx.HTTP, creds, region)
+	targetGroups, err := client.ListTargetGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target groups: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(targetGroups))
+	for _, targetGroup := range targetGroups {
+		resources = append(resources, core.IntegrationResource{
+			Type: resourceType,
+			Name: targetGroup.TargetGroupName,
+			ID:   targetGroup.TargetGroupArn,
+		})
+	}
+
+	return resources, nil
+}
@@ -36,7 +36,7 @@ type Client struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      defaultRegion,
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -16,6 +16,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
 )
 
 type Client struct {
@@ -44,7 +45,7 @@ type listFunctionsResponse struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      region,
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -89,58 +90,48 @@ func (c *Client) Invoke(functionArn string, payload []byte) (*InvokeResult, erro
 }
 
 func (c *Client) ListFunctions() ([]FunctionSummary, error) {
-	var (
-		functions []FunctionSummary
-		marker    string
-	)
-
-	for {
-		endpoint := fmt.Sprintf("https://lambda.%s.amazonaws.com/2015-03-31/functions", c.region)
-		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to build list functions request: %w", err)
-		}
-
-		query := req.URL.Query()
-		query.Set("MaxItems", "50")
-		if strings.TrimSpace(marker) != "" {
-			query.Set("Marker", marker)
-		}
-		req.URL.RawQuery = query.Encode()
-
-		if err := c.signRequest(req, []byte{}); err != nil {
-			return nil, err
-		}
-
-		res, err := c.http.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("list functions request failed: %w", err)
-		}
-
-		body, err := io.ReadAll(res.Body)
-		res.Body.Close()
-		if err != nil {
-			return nil, fmt.Errorf("failed to read list functions response: %w", err)
-		}
-
-		if res.StatusCode < 200 || res.StatusCode >= 300 {
-			return nil, fmt.Errorf("list functions failed with %d: %s", res.StatusCode, string(body))
-		}
-
-		var response listFunctionsResponse
-		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to decode list functions response: %w", err)
-		}
-
-		functions = append(functions, response.Functions...)
-
-		if strings.TrimSpace(response.NextMarker) == "" {
-			break
-		}
-		marker = response.NextMarker
+	return common.CollectPages(c.listFunctionsPage)
+}
+
+func (c *Client) listFunctionsPage(marker string) ([]FunctionSummary, string, error) {
+	endpoint := fmt.Sprintf("https://lambda.%s.amazonaws.com/2015-03-31/functions", c.region)
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build list functions request: %w", err)
+	}
+
+	query := req.URL.Query()
+	query.Set("MaxItems", "50")
+	if strings.TrimSpace(marker) != "" {
+		query.Set("Marker", marker)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	if err := c.signRequest(req, []byte{}); err != nil {
+		return nil, "", err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("list functions request failed: %w", err)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read list functions response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("list functions failed with %d: %s", res.StatusCode, string(body))
+	}
+
+	var response listFunctionsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, "", fmt.Errorf("failed to decode list functions response: %w", err)
 	}
 
-	return functions, nil
+	return response.Functions, response.NextMarker, nil
 }
 
 func (c *Client) signRequest(req *http.Request, payload []byte) error {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -171,12 +172,27 @@ func (c *RunFunction) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	//
+	// Failed invocations are billed too,
+	// so the cost is reported before handling function errors.
+	//
+	report, reportErr := parseLambdaLogReport(result.LogResult)
+	if reportErr == nil {
+		if gbSeconds, ok := report.GBSeconds(); ok {
+			ctx.ReportCost(core.Cost{
+				Amount:      gbSeconds,
+				Unit:        core.CostUnitGBSeconds,
+				Description: fmt.Sprintf("Billed duration of %s with %s", report.BilledDuration, report.MemorySize),
+			})
+		}
+	}
+
 	if result.FunctionError != "" {
 		return c.handleFunctionError(result)
 	}
 
 	output := map[string]any{"requestId": result.RequestID}
-	if report, err := parseLambdaLogReport(result.LogResult); err == nil {
+	if reportErr == nil {
 		output["report"] = report
 	}
 
@@ -253,6 +269,24 @@ type LambdaLogReport struct {
 	InitDuration   string `json:"initDuration"`
 }
 
+/*
+ * GBSeconds returns the compute billed for the invocation,
+ * the billed duration in seconds times the memory size in GB.
+ */
+func (r *LambdaLogReport) GBSeconds() (float64, bool) {
+	billedMs, ok := parseLambdaReportNumber(r.BilledDuration)
+	if !ok {
+		return 0, false
+	}
+
+	memoryMB, ok := parseLambdaReportNumber(r.MemorySize)
+	if !ok {
+		return 0, false
+	}
+
+	return billedMs / 1000 * memoryMB / 1024, true
+}
+
 /*
  * The last line of the log result is a report of the function execution, that looks like this:
  *
@@ -323,6 +357,20 @@ func parseLambdaReportValue(value string) (string, bool) {
 	return trimmed, true
 }
 
+func parseLambdaReportNumber(value string) (float64, bool) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
 func (c *RunFunction) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
@@ -148,11 +148,13 @@ func Test__RunFunction__Execute(t *testing.T) {
 		}
 
 		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		costs := &contexts.CostContext{}
 		err := component.Execute(core.ExecutionContext{
 			Configuration:  map[string]any{"payload": map[string]any{"hello": "world"}},
 			NodeMetadata:   &contexts.MetadataContext{Metadata: RunFunctionMetadata{FunctionArn: "arn:aws:lambda:us-east-1:123:function:test"}},
 			ExecutionState: execState,
 			HTTP:           httpContext,
+			Costs:          costs,
 			Integration: &contexts.IntegrationContext{
 				Configuration: map[string]any{"region": "us-east-1"},
 				Secrets: map[string]core.IntegrationSecret{
@@ -176,6 +178,10 @@ func Test__RunFunction__Execute(t *testing.T) {
 		assert.Equal(t, "128 MB", report.MemorySize)
 		assert.Equal(t, "82 MB", report.MaxMemoryUsed)
 		assert.Equal(t, "160.97 ms", report.InitDuration)
+
+		require.Len(t, costs.Costs, 1)
+		assert.Equal(t, core.CostUnitGBSeconds, costs.Costs[0].Unit)
+		assert.InDelta(t, 0.0125, costs.Costs[0].Amount, 1e-9)
 	})
 
 	t.Run("function error -> returns error", func(t *testing.T) {
@@ -0,0 +1,486 @@
+package rds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+const (
+	serviceName = "rds"
+	apiVersion  = "2014-10-31"
+)
+
+type Client struct {
+	http        core.HTTPContext
+	region      string
+	credentials *aws.Credentials
+	signer      *v4.Signer
+}
+
+type Endpoint struct {
+	Address string `json:"address" mapstructure:"address"`
+	Port    int    `json:"port" mapstructure:"port"`
+}
+
+type DBInstance struct {
+	DBInstanceIdentifier  string            `json:"dbInstanceIdentifier" mapstructure:"dbInstanceIdentifier"`
+	DBInstanceArn         string            `json:"dbInstanceArn" mapstructure:"dbInstanceArn"`
+	DBInstanceClass       string            `json:"dbInstanceClass" mapstructure:"dbInstanceClass"`
+	Status                string            `json:"status" mapstructure:"status"`
+	Engine                string            `json:"engine" mapstructure:"engine"`
+	EngineVersion         string            `json:"engineVersion" mapstructure:"engineVersion"`
+	Endpoint              *Endpoint         `json:"endpoint,omitempty" mapstructure:"endpoint"`
+	AllocatedStorage      int               `json:"allocatedStorage" mapstructure:"allocatedStorage"`
+	BackupRetentionPeriod int               `json:"backupRetentionPeriod" mapstructure:"backupRetentionPeriod"`
+	MultiAZ               bool              `json:"multiAZ" mapstructure:"multiAZ"`
+	AvailabilityZone      string            `json:"availabilityZone,omitempty" mapstructure:"availabilityZone"`
+	DBSubnetGroupName     string            `json:"dbSubnetGroupName,omitempty" mapstructure:"dbSubnetGroupName"`
+	VpcSecurityGroupIDs   []string          `json:"vpcSecurityGroupIds" mapstructure:"vpcSecurityGroupIds"`
+	PendingModifiedValues map[string]string `json:"pendingModifiedValues,omitempty" mapstructure:"pendingModifiedValues"`
+	Region                string            `json:"region" mapstructure:"region"`
+}
+
+type DBSnapshot struct {
+	DBSnapshotIdentifier string       `json:"dbSnapshotIdentifier" mapstructure:"dbSnapshotIdentifier"`
+	DBSnapshotArn        string       `json:"dbSnapshotArn" mapstructure:"dbSnapshotArn"`
+	DBInstanceIdentifier string       `json:"dbInstanceIdentifier" mapstructure:"dbInstanceIdentifier"`
+	SnapshotType         string       `json:"snapshotType" mapstructure:"snapshotType"`
+	Status               string       `json:"status" mapstructure:"status"`
+	Engine               string       `json:"engine" mapstructure:"engine"`
+	EngineVersion        string       `json:"engineVersion" mapstructure:"engineVersion"`
+	AllocatedStorage     int          `json:"allocatedStorage" mapstructure:"allocatedStorage"`
+	PercentProgress      int          `json:"percentProgress" mapstructure:"percentProgress"`
+	SnapshotCreateTime   string       `json:"snapshotCreateTime,omitempty" mapstructure:"snapshotCreateTime"`
+	Encrypted            bool         `json:"encrypted" mapstructure:"encrypted"`
+	Tags                 []common.Tag `json:"tags" mapstructure:"tags"`
+	Region               string       `json:"region" mapstructure:"region"`
+}
+
+type CreateDBSnapshotInput struct {
+	DBInstanceIdentifier string
+	DBSnapshotIdentifier string
+	Tags                 []common.Tag
+}
+
+type RestoreDBInstanceInput struct {
+	DBInstanceIdentifier string
+	DBSnapshotIdentifier string
+	DBInstanceClass      string
+	DBSubnetGroupName    string
+	VpcSecurityGroupIDs  []string
+	MultiAZ              bool
+	PubliclyAccessible   bool
+	Tags                 []common.Tag
+}
+
+/*
+ * ModifyDBInstanceInput only sends the values that are set,
+ * so the other settings of the instance are left as they are.
+ */
+type ModifyDBInstanceInput struct {
+	DBInstanceIdentifier    string
+	NewDBInstanceIdentifier string
+	DBInstanceClass         string
+	AllocatedStorage        *int
+	BackupRetentionPeriod   *int
+	VpcSecurityGroupIDs     []string
+	ApplyImmediately        bool
+}
+
+func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
+	return &Client{
+		http:        common.NewHTTPClient(httpCtx),
+		region:      region,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+	}
+}
+
+func (c *Client) ListDBInstances() ([]DBInstance, error) {
+	return common.CollectPages(func(marker string) ([]DBInstance, string, error) {
+		params := url.Values{}
+		params.Set("MaxRecords", "100")
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		response := describeDBInstancesResponse{}
+		if err := c.postForm("DescribeDBInstances", params, &response); err != nil {
+			return nil, "", err
+		}
+
+		instances := make([]DBInstance, 0, len(response.DBInstances))
+		for _, instance := range response.DBInstances {
+			instances = append(instances, instance.toDBInstance(c.region))
+		}
+
+		return instances, response.Marker, nil
+	})
+}
+
+/*
+ * ListDBSnapshots returns the manual and automated snapshots of the account,
+ * or only the ones of the given instance.
+ */
+func (c *Client) ListDBSnapshots(dbInstanceIdentifier string) ([]DBSnapshot, error) {
+	return common.CollectPages(func(marker string) ([]DBSnapshot, string, error) {
+		params := url.Values{}
+		params.Set("MaxRecords", "100")
+		if dbInstanceIdentifier != "" {
+			params.Set("DBInstanceIdentifier", dbInstanceIdentifier)
+		}
+
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		response := describeDBSnapshotsResponse{}
+		if err := c.postForm("DescribeDBSnapshots", params, &response); err != nil {
+			return nil, "", err
+		}
+
+		snapshots := make([]DBSnapshot, 0, len(response.DBSnapshots))
+		for _, snapshot := range response.DBSnapshots {
+			snapshots = append(snapshots, snapshot.toDBSnapshot(c.region))
+		}
+
+		return snapshots, response.Marker, nil
+	})
+}
+
+func (c *Client) DescribeDBInstance(dbInstanceIdentifier string) (*DBInstance, error) {
+	params := url.Values{}
+	params.Set("DBInstanceIdentifier", dbInstanceIdentifier)
+
+	response := describeDBInstancesResponse{}
+	if err := c.postForm("DescribeDBInstances", params, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.DBInstances) == 0 {
+		return nil, fmt.Errorf("DB instance %s not found", dbInstanceIdentifier)
+	}
+
+	instance := response.DBInstances[0].toDBInstance(c.region)
+	return &instance, nil
+}
+
+func (c *Client) DescribeDBSnapshot(dbSnapshotIdentifier string) (*DBSnapshot, error) {
+	params := url.Values{}
+	params.Set("DBSnapshotIdentifier", dbSnapshotIdentifier)
+
+	response := describeDBSnapshotsResponse{}
+	if err := c.postForm("DescribeDBSnapshots", params, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.DBSnapshots) == 0 {
+		return nil, fmt.Errorf("DB snapshot %s not found", dbSnapshotIdentifier)
+	}
+
+	snapshot := response.DBSnapshots[0].toDBSnapshot(c.region)
+	return &snapshot, nil
+}
+
+func (c *Client) CreateDBSnapshot(input CreateDBSnapshotInput) (*DBSnapshot, error) {
+	params := url.Values{}
+	params.Set("DBInstanceIdentifier", input.DBInstanceIdentifier)
+	params.Set("DBSnapshotIdentifier", input.DBSnapshotIdentifier)
+	setTagParams(params, input.Tags)
+
+	response := createDBSnapshotResponse{}
+	if err := c.postForm("CreateDBSnapshot", params, &response); err != nil {
+		return nil, err
+	}
+
+	snapshot := response.DBSnapshot.toDBSnapshot(c.region)
+	return &snapshot, nil
+}
+
+func (c *Client) RestoreDBInstanceFromDBSnapshot(input RestoreDBInstanceInput) (*DBInstance, error) {
+	params := url.Values{}
+	params.Set("DBInstanceIdentifier", input.DBInstanceIdentifier)
+	params.Set("DBSnapshotIdentifier", input.DBSnapshotIdentifier)
+	params.Set("MultiAZ", strconv.FormatBool(input.MultiAZ))
+	params.Set("PubliclyAccessible", strconv.FormatBool(input.PubliclyAccessible))
+	if input.DBInstanceClass != "" {
+		params.Set("DBInstanceClass", input.DBInstanceClass)
+	}
+
+	if input.DBSubnetGroupName != "" {
+		params.Set("DBSubnetGroupName", input.DBSubnetGroupName)
+	}
+
+	setSecurityGroupParams(params, input.VpcSecurityGroupIDs)
+	setTagParams(params, input.Tags)
+
+	response := restoreDBInstanceFromDBSnapshotResponse{}
+	if err := c.postForm("RestoreDBInstanceFromDBSnapshot", params, &response); err != nil {
+		return nil, err
+	}
+
+	instance := response.DBInstance.toDBInstance(c.region)
+	return &instance, nil
+}
+
+func (c *Client) ModifyDBInstance(input ModifyDBInstanceInput) (*DBInstance, error) {
+	params := url.Values{}
+	params.Set("DBInstanceIdentifier", input.DBInstanceIdentifier)
+	params.Set("ApplyImmediately", strconv.FormatBool(input.ApplyImmediately))
+	if input.NewDBInstanceIdentifier != "" {
+		params.Set("NewDBInstanceIdentifier", input.NewDBInstanceIdentifier)
+	}
+
+	if input.DBInstanceClass != "" {
+		params.Set("DBInstanceClass", input.DBInstanceClass)
+	}
+
+	if input.AllocatedStorage != nil {
+		params.Set("AllocatedStorage", strconv.Itoa(*input.AllocatedStorage))
+	}
+
+	if input.BackupRetentionPeriod != nil {
+		params.Set("BackupRetentionPeriod", strconv.Itoa(*input.BackupRetentionPeriod))
+	}
+
+	setSecurityGroupParams(params, input.VpcSecurityGroupIDs)
+
+	response := modifyDBInstanceResponse{}
+	if err := c.postForm("ModifyDBInstance", params, &response); err != nil {
+		return nil, err
+	}
+
+	instance := response.DBInstance.toDBInstance(c.region)
+	return &instance, nil
+}
+
+func setSecurityGroupParams(params url.Values, securityGroupIDs []string) {
+	for i, securityGroupID := range securityGroupIDs {
+		params.Set(fmt.Sprintf("VpcSecurityGroupIds.VpcSecurityGroupId.%d", i+1), securityGroupID)
+	}
+}
+
+func setTagParams(params url.Values, tags []common.Tag) {
+	for i, tag := range tags {
+		prefix := fmt.Sprintf("Tags.Tag.%d.", i+1)
+		params.Set(prefix+"Key", tag.Key)
+		params.Set(prefix+"Value", tag.Value)
+	}
+}
+
+func (c *Client) postForm(action string, params url.Values, out any) error {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	params.Set("Action", action)
+	params.Set("Version", apiVersion)
+
+	body := []byte(params.Encode())
+	endpoint := fmt.Sprintf("https://rds.%s.amazonaws.com/", c.region)
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if err := c.signRequest(req, body); err != nil {
+		return err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		if awsErr := parseError(responseBody); awsErr != nil {
+			return awsErr
+		}
+		return fmt.Errorf("RDS API request failed with %d: %s", res.StatusCode, string(responseBody))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := xml.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) signRequest(req *http.Request, payload []byte) error {
+	hash := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(hash[:])
+	return c.signer.SignHTTP(context.Background(), *c.credentials, req, payloadHash, serviceName, c.region, time.Now())
+}
+
+func parseError(body []byte) *common.Error {
+	var errResp struct {
+		Error struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		} `xml:"Error"`
+	}
+
+	if err := xml.Unmarshal(body, &errResp); err == nil {
+		code := strings.TrimSpace(errResp.Error.Code)
+		message := strings.TrimSpace(errResp.Error.Message)
+		if code != "" || message != "" {
+			return &common.Error{Code: code, Message: message}
+		}
+	}
+
+	return nil
+}
+
+type describeDBInstancesResponse struct {
+	DBInstances []xmlDBInstance `xml:"DescribeDBInstancesResult>DBInstances>DBInstance"`
+	Marker      string          `xml:"DescribeDBInstancesResult>Marker"`
+}
+
+type describeDBSnapshotsResponse struct {
+	DBSnapshots []xmlDBSnapshot `xml:"DescribeDBSnapshotsResult>DBSnapshots>DBSnapshot"`
+	Marker      string          `xml:"DescribeDBSnapshotsResult>Marker"`
+}
+
+type createDBSnapshotResponse struct {
+	DBSnapshot xmlDBSnapshot `xml:"CreateDBSnapshotResult>DBSnapshot"`
+}
+
+type restoreDBInstanceFromDBSnapshotResponse struct {
+	DBInstance xmlDBInstance `xml:"RestoreDBInstanceFromDBSnapshotResult>DBInstance"`
+}
+
+type modifyDBInstanceResponse struct {
+	DBInstance xmlDBInstance `xml:"ModifyDBInstanceResult>DBInstance"`
+}
+
+type xmlDBInstance struct {
+	DBInstanceIdentifier string `xml:"DBInstanceIdentifier"`
+	DBInstanceArn        string `xml:"DBInstanceArn"`
+	DBInstanceClass      string `xml:"DBInstanceClass"`
+	DBInstanceStatus     string `xml:"DBInstanceStatus"`
+	Engine               string `xml:"Engine"`
+	EngineVersion        string `xml:"EngineVersion"`
+	Endpoint             *struct {
+		Address string `xml:"Address"`
+		Port    int    `xml:"Port"`
+	} `xml:"Endpoint"`
+	AllocatedStorage      int    `xml:"AllocatedStorage"`
+	BackupRetentionPeriod int    `xml:"BackupRetentionPeriod"`
+	MultiAZ               bool   `xml:"MultiAZ"`
+	AvailabilityZone      string `xml:"AvailabilityZone"`
+	DBSubnetGroup         struct {
+		DBSubnetGroupName string `xml:"DBSubnetGroupName"`
+	} `xml:"DBSubnetGroup"`
+	VpcSecurityGroups []struct {
+		VpcSecurityGroupID string `xml:"VpcSecurityGroupId"`
+	} `xml:"VpcSecurityGroups>VpcSecurityGroupMembership"`
+	PendingModifiedValues struct {
+		Values []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"PendingModifiedValues"`
+}
+
+func (i xmlDBInstance) toDBInstance(region string) DBInstance {
+	instance := DBInstance{
+		DBInstanceIdentifier:  strings.TrimSpace(i.DBInstanceIdentifier),
+		DBInstanceArn:         strings.TrimSpace(i.DBInstanceArn),
+		DBInstanceClass:       strings.TrimSpace(i.DBInstanceClass),
+		Status:                strings.TrimSpace(i.DBInstanceStatus),
+		Engine:                strings.TrimSpace(i.Engine),
+		EngineVersion:         strings.TrimSpace(i.EngineVersion),
+		AllocatedStorage:      i.AllocatedStorage,
+		BackupRetentionPeriod: i.BackupRetentionPeriod,
+		MultiAZ:               i.MultiAZ,
+		AvailabilityZone:      strings.TrimSpace(i.AvailabilityZone),
+		DBSubnetGroupName:     strings.TrimSpace(i.DBSubnetGroup.DBSubnetGroupName),
+		VpcSecurityGroupIDs:   []string{},
+		Region:                region,
+	}
+
+	if i.Endpoint != nil && i.Endpoint.Address != "" {
+		instance.Endpoint = &Endpoint{Address: strings.TrimSpace(i.Endpoint.Address), Port: i.Endpoint.Port}
+	}
+
+	for _, securityGroup := range i.VpcSecurityGroups {
+		instance.VpcSecurityGroupIDs = append(instance.VpcSecurityGroupIDs, strings.TrimSpace(securityGroup.VpcSecurityGroupID))
+	}
+
+	for _, value := range i.PendingModifiedValues.Values {
+		if instance.PendingModifiedValues == nil {
+			instance.PendingModifiedValues = map[string]string{}
+		}
+
+		instance.PendingModifiedValues[value.XMLName.Local] = strings.TrimSpace(value.Value)
+	}
+
+	return instance
+}
+
+type xmlDBSnapshot struct {
+	DBSnapshotIdentifier string `xml:"DBSnapshotIdentifier"`
+	DBSnapshotArn        string `xml:"DBSnapshotArn"`
+	DBInstanceIdentifier string `xml:"DBInstanceIdentifier"`
+	SnapshotType         string `xml:"SnapshotType"`
+	Status               string `xml:"Status"`
+	Engine               string `xml:"Engine"`
+	EngineVersion        string `xml:"EngineVersion"`
+	AllocatedStorage     int    `xml:"AllocatedStorage"`
+	PercentProgress      int    `xml:"PercentProgress"`
+	SnapshotCreateTime   string `xml:"SnapshotCreateTime"`
+	Encrypted            bool   `xml:"Encrypted"`
+	Tags                 []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"TagList>Tag"`
+}
+
+func (s xmlDBSnapshot) toDBSnapshot(region string) DBSnapshot {
+	snapshot := DBSnapshot{
+		DBSnapshotIdentifier: strings.TrimSpace(s.DBSnapshotIdentifier),
+		DBSnapshotArn:        strings.TrimSpace(s.DBSnapshotArn),
+		DBInstanceIdentifier: strings.TrimSpace(s.DBInstanceIdentifier),
+		SnapshotType:         strings.TrimSpace(s.SnapshotType),
+		Status:               strings.TrimSpace(s.Status),
+		Engine:               strings.TrimSpace(s.Engine),
+		EngineVersion:        strings.TrimSpace(s.EngineVersion),
+		AllocatedStorage:     s.AllocatedStorage,
+		PercentProgress:      s.PercentProgress,
+		SnapshotCreateTime:   strings.TrimSpace(s.SnapshotCreateTime),
+		Encrypted:            s.Encrypted,
+		Tags:                 []common.Tag{},
+		Region:               region,
+	}
+
+	for _, tag := range s.Tags {
+		snapshot.Tags = append(snapshot.Tags, common.Tag{Key: strings.TrimSpace(tag.Key), Value: strings.TrimSpace(tag.Value)})
+	}
+
+	return snapshot
+}
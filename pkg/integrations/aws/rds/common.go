@@ -0,0 +1,429 @@
+package rds
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	DBInstanceStatusAvailable = "available"
+	DBSnapshotStatusAvailable = "available"
+	DBSnapshotStatusFailed    = "failed"
+
+	DefaultTimeoutMinutes = 60
+	MaxTimeoutMinutes     = 1440
+
+	dbInstancePayloadType       = "aws.rds.dbInstance"
+	dbInstancePollAction        = "pollDBInstance"
+	dbSnapshotPayloadType       = "aws.rds.dbSnapshot"
+	dbSnapshotPollAction        = "pollDBSnapshot"
+	pollInterval                = 30 * time.Second
+	maxIdentifierLength         = 63
+	maxSnapshotIdentifierLength = 255
+)
+
+/*
+ * Statuses from which a DB instance doesn't become available without manual intervention.
+ */
+var failedDBInstanceStatuses = []string{
+	"failed",
+	"inaccessible-encryption-credentials",
+	"inaccessible-encryption-credentials-recoverable",
+	"incompatible-network",
+	"incompatible-option-group",
+	"incompatible-parameters",
+	"incompatible-restore",
+	"restore-error",
+	"storage-full",
+}
+
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*(-[a-zA-Z0-9]+)*$`)
+
+/*
+ * DBInstanceExecutionMetadata tracks a DB instance being restored or modified,
+ * while the execution waits for it to be available.
+ */
+type DBInstanceExecutionMetadata struct {
+	Region               string `json:"region" mapstructure:"region"`
+	DBInstanceIdentifier string `json:"dbInstanceIdentifier" mapstructure:"dbInstanceIdentifier"`
+	Status               string `json:"status" mapstructure:"status"`
+	Deadline             string `json:"deadline" mapstructure:"deadline"`
+}
+
+/*
+ * DBSnapshotExecutionMetadata tracks a DB snapshot being created,
+ * while the execution waits for it to be available.
+ */
+type DBSnapshotExecutionMetadata struct {
+	Region               string `json:"region" mapstructure:"region"`
+	DBSnapshotIdentifier string `json:"dbSnapshotIdentifier" mapstructure:"dbSnapshotIdentifier"`
+	Status               string `json:"status" mapstructure:"status"`
+	Deadline             string `json:"deadline" mapstructure:"deadline"`
+}
+
+func regionField() configuration.Field {
+	return configuration.Field{
+		Name:     "region",
+		Label:    "Region",
+		Type:     configuration.FieldTypeSelect,
+		Required: true,
+		Default:  "us-east-1",
+		TypeOptions: &configuration.TypeOptions{
+			Select: &configuration.SelectTypeOptions{
+				Options: common.AllRegions,
+			},
+		},
+	}
+}
+
+func dbInstanceField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "dbInstance",
+		Label:       "DB Instance",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: "rds.dbInstance",
+				Parameters: []configuration.ParameterRef{
+					{
+						Name: "region",
+						ValueFrom: &configuration.ParameterValueFrom{
+							Field: "region",
+						},
+					},
+				},
+			},
+		},
+		VisibilityConditions: []configuration.VisibilityCondition{
+			{
+				Field:  "region",
+				Values: []string{"*"},
+			},
+		},
+	}
+}
+
+func securityGroupsField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "vpcSecurityGroupIds",
+		Label:       "VPC Security Groups",
+		Type:        configuration.FieldTypeList,
+		Required:    false,
+		Togglable:   true,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Security Group ID",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeString,
+				},
+			},
+		},
+	}
+}
+
+func tagsField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "tags",
+		Label:       "Tags",
+		Type:        configuration.FieldTypeList,
+		Required:    false,
+		Togglable:   true,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Tag",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeObject,
+					Schema: []configuration.Field{
+						{
+							Name:     "key",
+							Label:    "Key",
+							Type:     configuration.FieldTypeString,
+							Required: true,
+						},
+						{
+							Name:     "value",
+							Label:    "Value",
+							Type:     configuration.FieldTypeString,
+							Required: false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+/*
+ * waitFields returns the "Wait for completion" toggle and the timeout shown when it is enabled.
+ */
+func waitFields(description string) []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "waitForCompletion",
+			Label:       "Wait for completion",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: description,
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultTimeoutMinutes,
+			Description: "How long to wait before failing",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := MaxTimeoutMinutes; return &max }(),
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "waitForCompletion", Values: []string{"true"}},
+			},
+		},
+	}
+}
+
+func requireRegion(region string) (string, error) {
+	region = strings.TrimSpace(region)
+	if region == "" {
+		return "", fmt.Errorf("region is required")
+	}
+
+	return region, nil
+}
+
+func normalizeTimeout(timeoutMinutes int) (int, error) {
+	if timeoutMinutes == 0 {
+		return DefaultTimeoutMinutes, nil
+	}
+
+	if timeoutMinutes < 1 || timeoutMinutes > MaxTimeoutMinutes {
+		return 0, fmt.Errorf("timeout must be between 1 and %d minutes", MaxTimeoutMinutes)
+	}
+
+	return timeoutMinutes, nil
+}
+
+func normalizeSecurityGroupIDs(securityGroupIDs []string) []string {
+	normalized := []string{}
+	for _, securityGroupID := range securityGroupIDs {
+		securityGroupID = strings.TrimSpace(securityGroupID)
+		if securityGroupID != "" && !slices.Contains(normalized, securityGroupID) {
+			normalized = append(normalized, securityGroupID)
+		}
+	}
+
+	return normalized
+}
+
+/*
+ * Identifiers are only validated when executing,
+ * since they are usually built with expressions.
+ */
+func validateIdentifier(kind, identifier string, maxLength int) error {
+	if len(identifier) > maxLength || !identifierRegexp.MatchString(identifier) {
+		return fmt.Errorf(
+			"invalid %s %q: it must start with a letter, contain only letters, digits and single hyphens, not end with a hyphen, and have at most %d characters",
+			kind,
+			identifier,
+			maxLength,
+		)
+	}
+
+	return nil
+}
+
+func deadlineFrom(timeoutMinutes int) string {
+	return time.Now().Add(time.Duration(timeoutMinutes) * time.Minute).Format(time.RFC3339)
+}
+
+func deadlinePassed(deadline string) (bool, error) {
+	parsed, err := time.Parse(time.RFC3339, deadline)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse deadline: %w", err)
+	}
+
+	return !time.Now().Before(parsed), nil
+}
+
+func newClient(httpCtx core.HTTPContext, integration core.IntegrationContext, region string) (*Client, error) {
+	creds, err := common.CredentialsFromInstallation(integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	return NewClient(httpCtx, creds, region), nil
+}
+
+func isDBInstanceNotFound(err error) bool {
+	var awsErr *common.Error
+	return errors.As(err, &awsErr) && awsErr.Code == "DBInstanceNotFound"
+}
+
+func pollDBInstance(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := DBInstanceExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return checkDBInstance(ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.Requests, ctx.ExecutionState, metadata)
+}
+
+/*
+ * Emits the DB instance once it is available without pending modifications,
+ * fails the execution if it reaches a failed status or the deadline passes,
+ * and schedules the next poll otherwise.
+ *
+ * A renamed instance is not found under its new identifier until the rename is done,
+ * so that is handled like any other pending state.
+ */
+func checkDBInstance(
+	httpCtx core.HTTPContext,
+	integration core.IntegrationContext,
+	metadataCtx core.MetadataContext,
+	requests core.RequestContext,
+	executionState core.ExecutionStateContext,
+	metadata DBInstanceExecutionMetadata,
+) error {
+	client, err := newClient(httpCtx, integration, metadata.Region)
+	if err != nil {
+		return err
+	}
+
+	instance, err := client.DescribeDBInstance(metadata.DBInstanceIdentifier)
+	if err != nil && !isDBInstanceNotFound(err) {
+		return fmt.Errorf("failed to describe DB instance: %w", err)
+	}
+
+	if instance != nil {
+		metadata.Status = instance.Status
+		if err := metadataCtx.Set(metadata); err != nil {
+			return fmt.Errorf("failed to set execution metadata: %w", err)
+		}
+
+		if slices.Contains(failedDBInstanceStatuses, instance.Status) {
+			return executionState.Fail(
+				models.CanvasNodeExecutionResultReasonError,
+				fmt.Sprintf("DB instance %s is %s", metadata.DBInstanceIdentifier, instance.Status),
+			)
+		}
+
+		if instance.Status == DBInstanceStatusAvailable && len(instance.PendingModifiedValues) == 0 {
+			return executionState.Emit(core.DefaultOutputChannel.Name, dbInstancePayloadType, []any{dbInstancePayload(instance)})
+		}
+	}
+
+	passed, err := deadlinePassed(metadata.Deadline)
+	if err != nil {
+		return err
+	}
+
+	if passed {
+		return executionState.Fail(
+			models.CanvasNodeExecutionResultReasonError,
+			fmt.Sprintf("timed out waiting for DB instance %s, last status: %s", metadata.DBInstanceIdentifier, metadata.Status),
+		)
+	}
+
+	return requests.ScheduleActionCall(dbInstancePollAction, map[string]any{}, pollInterval)
+}
+
+func dbInstancePayload(instance *DBInstance) map[string]any {
+	return map[string]any{
+		"dbInstance": instance,
+	}
+}
+
+func pollDBSnapshot(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := DBSnapshotExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return checkDBSnapshot(ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.Requests, ctx.ExecutionState, metadata)
+}
+
+/*
+ * Emits the DB snapshot once it is available,
+ * fails the execution if it failed or the deadline passes,
+ * and schedules the next poll otherwise.
+ */
+func checkDBSnapshot(
+	httpCtx core.HTTPContext,
+	integration core.IntegrationContext,
+	metadataCtx core.MetadataContext,
+	requests core.RequestContext,
+	executionState core.ExecutionStateContext,
+	metadata DBSnapshotExecutionMetadata,
+) error {
+	client, err := newClient(httpCtx, integration, metadata.Region)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.DescribeDBSnapshot(metadata.DBSnapshotIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to describe DB snapshot: %w", err)
+	}
+
+	metadata.Status = snapshot.Status
+	if err := metadataCtx.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if snapshot.Status == DBSnapshotStatusFailed {
+		return executionState.Fail(
+			models.CanvasNodeExecutionResultReasonError,
+			fmt.Sprintf("DB snapshot %s failed", metadata.DBSnapshotIdentifier),
+		)
+	}
+
+	if snapshot.Status == DBSnapshotStatusAvailable {
+		return executionState.Emit(core.DefaultOutputChannel.Name, dbSnapshotPayloadType, []any{dbSnapshotPayload(snapshot)})
+	}
+
+	passed, err := deadlinePassed(metadata.Deadline)
+	if err != nil {
+		return err
+	}
+
+	if passed {
+		return executionState.Fail(
+			models.CanvasNodeExecutionResultReasonError,
+			fmt.Sprintf("timed out waiting for DB snapshot %s, last status: %s", metadata.DBSnapshotIdentifier, metadata.Status),
+		)
+	}
+
+	return requests.ScheduleActionCall(dbSnapshotPollAction, map[string]any{}, pollInterval)
+}
+
+func dbSnapshotPayload(snapshot *DBSnapshot) map[string]any {
+	return map[string]any{
+		"dbSnapshot": snapshot,
+	}
+}
@@ -0,0 +1,210 @@
+package rds
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+type CreateDBSnapshot struct{}
+
+type CreateDBSnapshotConfiguration struct {
+	Region               string       `json:"region" mapstructure:"region"`
+	DBInstanceIdentifier string       `json:"dbInstance" mapstructure:"dbInstance"`
+	DBSnapshotIdentifier string       `json:"snapshotIdentifier" mapstructure:"snapshotIdentifier"`
+	Tags                 []common.Tag `json:"tags" mapstructure:"tags"`
+	WaitForCompletion    *bool        `json:"waitForCompletion,omitempty" mapstructure:"waitForCompletion,omitempty"`
+	TimeoutMinutes       int          `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+func (c *CreateDBSnapshotConfiguration) ShouldWait() bool {
+	return c.WaitForCompletion == nil || *c.WaitForCompletion
+}
+
+func (c *CreateDBSnapshot) Name() string {
+	return "aws.rds.createDBSnapshot"
+}
+
+func (c *CreateDBSnapshot) Label() string {
+	return "RDS • Create DB Snapshot"
+}
+
+func (c *CreateDBSnapshot) Description() string {
+	return "Create a manual snapshot of an RDS DB instance"
+}
+
+func (c *CreateDBSnapshot) Documentation() string {
+	return `The Create DB Snapshot component creates a manual snapshot of an RDS DB instance.
+
+## Use Cases
+
+- **Database refreshes**: Snapshot production, then restore the snapshot as a staging instance
+- **Pre-migration safety**: Snapshot a database before running schema migrations
+- **Backups**: Keep manual snapshots that outlive the automated backup retention
+
+## Configuration
+
+- **Region**: AWS region of the DB instance
+- **DB Instance**: DB instance to snapshot
+- **Snapshot Identifier**: Name of the new snapshot. It must be unique in the region, so it usually includes a date or a run ID
+- **Tags**: Optional tags for the snapshot
+- **Wait for completion**: Wait until the snapshot is ` + "`available`" + ` before emitting. Enabled by default
+- **Timeout (minutes)**: How long to wait before failing. Defaults to 60 minutes
+
+## Completion behavior
+
+- When waiting, the status of the snapshot is checked every 30 seconds.
+- The execution fails if the snapshot fails, or is not available before the timeout.
+- Without waiting, the snapshot is emitted right away, in the ` + "`creating`" + ` status.`
+}
+
+func (c *CreateDBSnapshot) Icon() string {
+	return "aws"
+}
+
+func (c *CreateDBSnapshot) Color() string {
+	return "gray"
+}
+
+func (c *CreateDBSnapshot) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateDBSnapshot) Configuration() []configuration.Field {
+	fields := []configuration.Field{
+		regionField(),
+		dbInstanceField("DB instance to snapshot"),
+		{
+			Name:        "snapshotIdentifier",
+			Label:       "Snapshot Identifier",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "production-2026-01-01",
+			Description: "Name of the new snapshot, unique in the region",
+		},
+		tagsField("Tags to add to the snapshot"),
+	}
+
+	return append(fields, waitFields("Wait until the snapshot is available before emitting")...)
+}
+
+func decodeCreateDBSnapshotConfiguration(raw any) (CreateDBSnapshotConfiguration, error) {
+	config := CreateDBSnapshotConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CreateDBSnapshotConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, err := requireRegion(config.Region)
+	if err != nil {
+		return CreateDBSnapshotConfiguration{}, err
+	}
+
+	config.Region = region
+	config.DBInstanceIdentifier = strings.TrimSpace(config.DBInstanceIdentifier)
+	config.DBSnapshotIdentifier = strings.TrimSpace(config.DBSnapshotIdentifier)
+	config.Tags = common.NormalizeTags(config.Tags)
+
+	if config.DBInstanceIdentifier == "" {
+		return CreateDBSnapshotConfiguration{}, fmt.Errorf("DB instance is required")
+	}
+
+	if config.DBSnapshotIdentifier == "" {
+		return CreateDBSnapshotConfiguration{}, fmt.Errorf("snapshot identifier is required")
+	}
+
+	config.TimeoutMinutes, err = normalizeTimeout(config.TimeoutMinutes)
+	if err != nil {
+		return CreateDBSnapshotConfiguration{}, err
+	}
+
+	return config, nil
+}
+
+func (c *CreateDBSnapshot) Setup(ctx core.SetupContext) error {
+	_, err := decodeCreateDBSnapshotConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *CreateDBSnapshot) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateDBSnapshot) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCreateDBSnapshotConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := validateIdentifier("snapshot identifier", config.DBSnapshotIdentifier, maxSnapshotIdentifierLength); err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, config.Region)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.CreateDBSnapshot(CreateDBSnapshotInput{
+		DBInstanceIdentifier: config.DBInstanceIdentifier,
+		DBSnapshotIdentifier: config.DBSnapshotIdentifier,
+		Tags:                 config.Tags,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create DB snapshot: %w", err)
+	}
+
+	metadata := DBSnapshotExecutionMetadata{
+		Region:               config.Region,
+		DBSnapshotIdentifier: snapshot.DBSnapshotIdentifier,
+		Status:               snapshot.Status,
+		Deadline:             deadlineFrom(config.TimeoutMinutes),
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if !config.ShouldWait() {
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, dbSnapshotPayloadType, []any{dbSnapshotPayload(snapshot)})
+	}
+
+	return ctx.Requests.ScheduleActionCall(dbSnapshotPollAction, map[string]any{}, pollInterval)
+}
+
+func (c *CreateDBSnapshot) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        dbSnapshotPollAction,
+			Description: "Check the status of the DB snapshot",
+		},
+	}
+}
+
+func (c *CreateDBSnapshot) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case dbSnapshotPollAction:
+		return pollDBSnapshot(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *CreateDBSnapshot) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateDBSnapshot) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateDBSnapshot) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
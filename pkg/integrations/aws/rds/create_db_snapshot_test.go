@@ -0,0 +1,290 @@
+package rds
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testIntegrationWithCredentials() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Secrets: map[string]core.IntegrationSecret{
+			"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+			"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+			"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+		},
+	}
+}
+
+func testRequestBodyString(t *testing.T, request *http.Request) string {
+	t.Helper()
+	body, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func testResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testErrorResponse(code, message string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body: io.NopCloser(strings.NewReader(`
+			<ErrorResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+				<Error><Type>Sender</Type><Code>` + code + `</Code><Message>` + message + `</Message></Error>
+				<RequestId>req-error</RequestId>
+			</ErrorResponse>
+		`)),
+	}
+}
+
+func testDBSnapshotXML(status string) string {
+	return `
+		<DBSnapshotIdentifier>production-2026-02-19</DBSnapshotIdentifier>
+		<DBSnapshotArn>arn:aws:rds:us-east-1:123456789012:snapshot:production-2026-02-19</DBSnapshotArn>
+		<DBInstanceIdentifier>production</DBInstanceIdentifier>
+		<SnapshotType>manual</SnapshotType>
+		<Status>` + status + `</Status>
+		<Engine>postgres</Engine>
+		<AllocatedStorage>100</AllocatedStorage>
+		<PercentProgress>100</PercentProgress>
+		<Encrypted>true</Encrypted>
+		<TagList><Tag><Key>Purpose</Key><Value>staging-refresh</Value></Tag></TagList>
+	`
+}
+
+func testCreateDBSnapshotResponse() *http.Response {
+	return testResponse(`
+		<CreateDBSnapshotResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+			<CreateDBSnapshotResult><DBSnapshot>` + testDBSnapshotXML("creating") + `</DBSnapshot></CreateDBSnapshotResult>
+		</CreateDBSnapshotResponse>
+	`)
+}
+
+func testDescribeDBSnapshotsResponse(status string) *http.Response {
+	return testResponse(`
+		<DescribeDBSnapshotsResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+			<DescribeDBSnapshotsResult>
+				<DBSnapshots><DBSnapshot>` + testDBSnapshotXML(status) + `</DBSnapshot></DBSnapshots>
+			</DescribeDBSnapshotsResult>
+		</DescribeDBSnapshotsResponse>
+	`)
+}
+
+func Test__CreateDBSnapshot__Setup(t *testing.T) {
+	component := &CreateDBSnapshot{}
+
+	t.Run("missing DB instance -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "snapshotIdentifier": "production-snapshot"},
+		})
+
+		require.ErrorContains(t, err, "DB instance is required")
+	})
+
+	t.Run("missing snapshot identifier -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "dbInstance": "production"},
+		})
+
+		require.ErrorContains(t, err, "snapshot identifier is required")
+	})
+
+	t.Run("timeout out of range -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":             "us-east-1",
+				"dbInstance":         "production",
+				"snapshotIdentifier": "production-snapshot",
+				"timeoutMinutes":     5000,
+			},
+		})
+
+		require.ErrorContains(t, err, "timeout must be between 1 and 1440 minutes")
+	})
+}
+
+func Test__CreateDBSnapshot__Execute(t *testing.T) {
+	component := &CreateDBSnapshot{}
+
+	t.Run("waits for completion -> schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{testCreateDBSnapshotResponse()}}
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":             "us-east-1",
+				"dbInstance":         "production",
+				"snapshotIdentifier": "production-2026-02-19",
+				"tags":               []any{map[string]any{"key": "Purpose", "value": "staging-refresh"}},
+			},
+			HTTP:           httpContext,
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, dbSnapshotPollAction, requests.Action)
+		assert.Equal(t, pollInterval, requests.Duration)
+
+		stored, ok := metadata.Metadata.(DBSnapshotExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "production-2026-02-19", stored.DBSnapshotIdentifier)
+		assert.Equal(t, "creating", stored.Status)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://rds.us-east-1.amazonaws.com/", httpContext.Requests[0].URL.String())
+		body := testRequestBodyString(t, httpContext.Requests[0])
+		assert.Contains(t, body, "Action=CreateDBSnapshot")
+		assert.Contains(t, body, "DBInstanceIdentifier=production")
+		assert.Contains(t, body, "DBSnapshotIdentifier=production-2026-02-19")
+		assert.Contains(t, body, "Tags.Tag.1.Key=Purpose")
+		assert.Contains(t, body, "Tags.Tag.1.Value=staging-refresh")
+	})
+
+	t.Run("does not wait -> emits snapshot", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":             "us-east-1",
+				"dbInstance":         "production",
+				"snapshotIdentifier": "production-2026-02-19",
+				"waitForCompletion":  false,
+			},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testCreateDBSnapshotResponse()}},
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, dbSnapshotPayloadType, execState.Type)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		snapshot := data["dbSnapshot"].(*DBSnapshot)
+		assert.Equal(t, "creating", snapshot.Status)
+		assert.Equal(t, "us-east-1", snapshot.Region)
+	})
+
+	t.Run("invalid snapshot identifier -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":             "us-east-1",
+				"dbInstance":         "production",
+				"snapshotIdentifier": "production--snapshot",
+			},
+			HTTP:           httpContext,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, `invalid snapshot identifier "production--snapshot"`)
+		assert.Empty(t, httpContext.Requests)
+	})
+}
+
+func Test__CreateDBSnapshot__HandleAction(t *testing.T) {
+	component := &CreateDBSnapshot{}
+
+	t.Run("snapshot available -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: dbSnapshotPollAction,
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{testDescribeDBSnapshotsResponse("available")}},
+			Metadata: &contexts.MetadataContext{Metadata: DBSnapshotExecutionMetadata{
+				Region:               "us-east-1",
+				DBSnapshotIdentifier: "production-2026-02-19",
+				Status:               "creating",
+				Deadline:             time.Now().Add(time.Hour).Format(time.RFC3339),
+			}},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		snapshot := data["dbSnapshot"].(*DBSnapshot)
+		assert.Equal(t, "available", snapshot.Status)
+		assert.Equal(t, "staging-refresh", snapshot.Tags[0].Value)
+	})
+
+	t.Run("snapshot creating -> schedules next poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: dbSnapshotPollAction,
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{testDescribeDBSnapshotsResponse("creating")}},
+			Metadata: &contexts.MetadataContext{Metadata: DBSnapshotExecutionMetadata{
+				Region:               "us-east-1",
+				DBSnapshotIdentifier: "production-2026-02-19",
+				Deadline:             time.Now().Add(time.Hour).Format(time.RFC3339),
+			}},
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, dbSnapshotPollAction, requests.Action)
+	})
+
+	t.Run("snapshot failed -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: dbSnapshotPollAction,
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{testDescribeDBSnapshotsResponse("failed")}},
+			Metadata: &contexts.MetadataContext{Metadata: DBSnapshotExecutionMetadata{
+				Region:               "us-east-1",
+				DBSnapshotIdentifier: "production-2026-02-19",
+				Deadline:             time.Now().Add(time.Hour).Format(time.RFC3339),
+			}},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Equal(t, "DB snapshot production-2026-02-19 failed", execState.FailureMessage)
+	})
+
+	t.Run("deadline passed -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: dbSnapshotPollAction,
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{testDescribeDBSnapshotsResponse("creating")}},
+			Metadata: &contexts.MetadataContext{Metadata: DBSnapshotExecutionMetadata{
+				Region:               "us-east-1",
+				DBSnapshotIdentifier: "production-2026-02-19",
+				Deadline:             time.Now().Add(-time.Minute).Format(time.RFC3339),
+			}},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "timed out waiting for DB snapshot production-2026-02-19, last status: creating", execState.FailureMessage)
+	})
+}
@@ -0,0 +1,42 @@
+package rds
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_create_db_snapshot.json
+var exampleOutputCreateDBSnapshotBytes []byte
+
+var exampleOutputCreateDBSnapshotOnce sync.Once
+var exampleOutputCreateDBSnapshot map[string]any
+
+//go:embed example_output_restore_db_instance_from_snapshot.json
+var exampleOutputRestoreDBInstanceFromSnapshotBytes []byte
+
+var exampleOutputRestoreDBInstanceFromSnapshotOnce sync.Once
+var exampleOutputRestoreDBInstanceFromSnapshot map[string]any
+
+//go:embed example_output_modify_db_instance.json
+var exampleOutputModifyDBInstanceBytes []byte
+
+var exampleOutputModifyDBInstanceOnce sync.Once
+var exampleOutputModifyDBInstance map[string]any
+
+func (c *CreateDBSnapshot) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateDBSnapshotOnce, exampleOutputCreateDBSnapshotBytes, &exampleOutputCreateDBSnapshot)
+}
+
+func (c *RestoreDBInstanceFromSnapshot) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputRestoreDBInstanceFromSnapshotOnce,
+		exampleOutputRestoreDBInstanceFromSnapshotBytes,
+		&exampleOutputRestoreDBInstanceFromSnapshot,
+	)
+}
+
+func (c *ModifyDBInstance) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputModifyDBInstanceOnce, exampleOutputModifyDBInstanceBytes, &exampleOutputModifyDBInstance)
+}
@@ -0,0 +1,319 @@
+package rds
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	MinAllocatedStorage      = 20
+	MaxAllocatedStorage      = 65536
+	MaxBackupRetentionPeriod = 35
+)
+
+type ModifyDBInstance struct{}
+
+type ModifyDBInstanceConfiguration struct {
+	Region                  string   `json:"region" mapstructure:"region"`
+	DBInstanceIdentifier    string   `json:"dbInstance" mapstructure:"dbInstance"`
+	NewDBInstanceIdentifier string   `json:"newDBInstanceIdentifier" mapstructure:"newDBInstanceIdentifier"`
+	DBInstanceClass         string   `json:"dbInstanceClass" mapstructure:"dbInstanceClass"`
+	AllocatedStorage        *int     `json:"allocatedStorage,omitempty" mapstructure:"allocatedStorage,omitempty"`
+	BackupRetentionPeriod   *int     `json:"backupRetentionPeriod,omitempty" mapstructure:"backupRetentionPeriod,omitempty"`
+	VpcSecurityGroupIDs     []string `json:"vpcSecurityGroupIds" mapstructure:"vpcSecurityGroupIds"`
+	ApplyImmediately        *bool    `json:"applyImmediately,omitempty" mapstructure:"applyImmediately,omitempty"`
+	WaitForCompletion       *bool    `json:"waitForCompletion,omitempty" mapstructure:"waitForCompletion,omitempty"`
+	TimeoutMinutes          int      `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+func (c *ModifyDBInstanceConfiguration) ShouldApplyImmediately() bool {
+	return c.ApplyImmediately == nil || *c.ApplyImmediately
+}
+
+/*
+ * Changes applied in the next maintenance window stay pending,
+ * so there is nothing to wait for unless they are applied immediately.
+ */
+func (c *ModifyDBInstanceConfiguration) ShouldWait() bool {
+	return c.ShouldApplyImmediately() && (c.WaitForCompletion == nil || *c.WaitForCompletion)
+}
+
+func (c *ModifyDBInstance) Name() string {
+	return "aws.rds.modifyDBInstance"
+}
+
+func (c *ModifyDBInstance) Label() string {
+	return "RDS • Modify DB Instance"
+}
+
+func (c *ModifyDBInstance) Description() string {
+	return "Change the settings of an RDS DB instance"
+}
+
+func (c *ModifyDBInstance) Documentation() string {
+	return `The Modify DB Instance component changes the settings of an RDS DB instance.
+
+## Use Cases
+
+- **Database refreshes**: Rename the current staging instance out of the way before restoring a fresh one, or resize a restored instance
+- **Scaling**: Change the instance class or grow the storage of a database
+- **Hardening**: Replace the security groups or change the backup retention of an instance
+
+## Configuration
+
+- **Region**: AWS region of the DB instance
+- **DB Instance**: DB instance to modify
+- **New DB Instance Identifier**: Optional new name of the instance. Its endpoint changes with it
+- **DB Instance Class**: Optional new instance class, e.g. ` + "`db.r6g.large`" + `
+- **Allocated Storage (GiB)**: Optional new storage size. Storage can only grow
+- **Backup Retention Period (days)**: Optional number of days to keep automated backups. ` + "`0`" + ` disables them
+- **VPC Security Groups**: Optional security groups, which replace the current ones
+- **Apply immediately**: Apply the changes now instead of in the next maintenance window. Enabled by default
+- **Wait for completion**: Wait until the instance is ` + "`available`" + ` without pending changes before emitting. Enabled by default, and only when applying immediately
+- **Timeout (minutes)**: How long to wait before failing. Defaults to 60 minutes
+
+At least one setting must be changed.
+
+## Completion behavior
+
+- When waiting, the status of the instance is checked every 30 seconds.
+- The execution fails if the instance reaches a failed status, e.g. ` + "`storage-full`" + `, or is not done before the timeout.
+- Changes like the instance class cause a short downtime, unless the instance is Multi-AZ.`
+}
+
+func (c *ModifyDBInstance) Icon() string {
+	return "aws"
+}
+
+func (c *ModifyDBInstance) Color() string {
+	return "gray"
+}
+
+func (c *ModifyDBInstance) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ModifyDBInstance) Configuration() []configuration.Field {
+	fields := []configuration.Field{
+		regionField(),
+		dbInstanceField("DB instance to modify"),
+		{
+			Name:        "newDBInstanceIdentifier",
+			Label:       "New DB Instance Identifier",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "staging-db-old",
+			Description: "Rename the instance. Its endpoint changes with it",
+		},
+		{
+			Name:        "dbInstanceClass",
+			Label:       "DB Instance Class",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "db.r6g.large",
+		},
+		{
+			Name:        "allocatedStorage",
+			Label:       "Allocated Storage (GiB)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Togglable:   true,
+			Description: "New storage size. Storage can only grow",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := MinAllocatedStorage; return &min }(),
+					Max: func() *int { max := MaxAllocatedStorage; return &max }(),
+				},
+			},
+		},
+		{
+			Name:        "backupRetentionPeriod",
+			Label:       "Backup Retention Period (days)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Togglable:   true,
+			Description: "Days to keep automated backups. 0 disables them",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+					Max: func() *int { max := MaxBackupRetentionPeriod; return &max }(),
+				},
+			},
+		},
+		securityGroupsField("Security groups that replace the current ones"),
+		{
+			Name:        "applyImmediately",
+			Label:       "Apply immediately",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Apply the changes now instead of in the next maintenance window",
+		},
+	}
+
+	for _, field := range waitFields("Wait until the instance is available without pending changes before emitting") {
+		field.VisibilityConditions = append(field.VisibilityConditions, configuration.VisibilityCondition{
+			Field:  "applyImmediately",
+			Values: []string{"true"},
+		})
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func decodeModifyDBInstanceConfiguration(raw any) (ModifyDBInstanceConfiguration, error) {
+	config := ModifyDBInstanceConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return ModifyDBInstanceConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, err := requireRegion(config.Region)
+	if err != nil {
+		return ModifyDBInstanceConfiguration{}, err
+	}
+
+	config.Region = region
+	config.DBInstanceIdentifier = strings.TrimSpace(config.DBInstanceIdentifier)
+	config.NewDBInstanceIdentifier = strings.TrimSpace(config.NewDBInstanceIdentifier)
+	config.DBInstanceClass = strings.TrimSpace(config.DBInstanceClass)
+	config.VpcSecurityGroupIDs = normalizeSecurityGroupIDs(config.VpcSecurityGroupIDs)
+
+	if config.DBInstanceIdentifier == "" {
+		return ModifyDBInstanceConfiguration{}, fmt.Errorf("DB instance is required")
+	}
+
+	if config.NewDBInstanceIdentifier == config.DBInstanceIdentifier {
+		config.NewDBInstanceIdentifier = ""
+	}
+
+	if config.AllocatedStorage != nil && (*config.AllocatedStorage < MinAllocatedStorage || *config.AllocatedStorage > MaxAllocatedStorage) {
+		return ModifyDBInstanceConfiguration{}, fmt.Errorf("allocated storage must be between %d and %d GiB", MinAllocatedStorage, MaxAllocatedStorage)
+	}
+
+	if config.BackupRetentionPeriod != nil && (*config.BackupRetentionPeriod < 0 || *config.BackupRetentionPeriod > MaxBackupRetentionPeriod) {
+		return ModifyDBInstanceConfiguration{}, fmt.Errorf("backup retention period must be between 0 and %d days", MaxBackupRetentionPeriod)
+	}
+
+	if config.NewDBInstanceIdentifier == "" &&
+		config.DBInstanceClass == "" &&
+		config.AllocatedStorage == nil &&
+		config.BackupRetentionPeriod == nil &&
+		len(config.VpcSecurityGroupIDs) == 0 {
+		return ModifyDBInstanceConfiguration{}, fmt.Errorf("at least one setting to modify is required")
+	}
+
+	config.TimeoutMinutes, err = normalizeTimeout(config.TimeoutMinutes)
+	if err != nil {
+		return ModifyDBInstanceConfiguration{}, err
+	}
+
+	return config, nil
+}
+
+func (c *ModifyDBInstance) Setup(ctx core.SetupContext) error {
+	_, err := decodeModifyDBInstanceConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *ModifyDBInstance) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ModifyDBInstance) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeModifyDBInstanceConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if config.NewDBInstanceIdentifier != "" {
+		if err := validateIdentifier("new DB instance identifier", config.NewDBInstanceIdentifier, maxIdentifierLength); err != nil {
+			return err
+		}
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, config.Region)
+	if err != nil {
+		return err
+	}
+
+	instance, err := client.ModifyDBInstance(ModifyDBInstanceInput{
+		DBInstanceIdentifier:    config.DBInstanceIdentifier,
+		NewDBInstanceIdentifier: config.NewDBInstanceIdentifier,
+		DBInstanceClass:         config.DBInstanceClass,
+		AllocatedStorage:        config.AllocatedStorage,
+		BackupRetentionPeriod:   config.BackupRetentionPeriod,
+		VpcSecurityGroupIDs:     config.VpcSecurityGroupIDs,
+		ApplyImmediately:        config.ShouldApplyImmediately(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to modify DB instance: %w", err)
+	}
+
+	//
+	// The response still has the old identifier while a rename is in progress,
+	// so the instance is polled under its new one.
+	//
+	identifier := instance.DBInstanceIdentifier
+	if config.NewDBInstanceIdentifier != "" {
+		identifier = config.NewDBInstanceIdentifier
+	}
+
+	metadata := DBInstanceExecutionMetadata{
+		Region:               config.Region,
+		DBInstanceIdentifier: identifier,
+		Status:               instance.Status,
+		Deadline:             deadlineFrom(config.TimeoutMinutes),
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if !config.ShouldWait() {
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, dbInstancePayloadType, []any{dbInstancePayload(instance)})
+	}
+
+	return ctx.Requests.ScheduleActionCall(dbInstancePollAction, map[string]any{}, pollInterval)
+}
+
+func (c *ModifyDBInstance) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        dbInstancePollAction,
+			Description: "Check the status of the modified DB instance",
+		},
+	}
+}
+
+func (c *ModifyDBInstance) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case dbInstancePollAction:
+		return pollDBInstance(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *ModifyDBInstance) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ModifyDBInstance) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ModifyDBInstance) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
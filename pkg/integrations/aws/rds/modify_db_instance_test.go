@@ -0,0 +1,219 @@
+package rds
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testModifyDBInstanceResponse(identifier, pendingModifiedValues string) *http.Response {
+	return testResponse(`
+		<ModifyDBInstanceResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+			<ModifyDBInstanceResult>
+				<DBInstance>` + testDBInstanceXML(identifier, "available", pendingModifiedValues) + `</DBInstance>
+			</ModifyDBInstanceResult>
+		</ModifyDBInstanceResponse>
+	`)
+}
+
+func Test__ModifyDBInstance__Setup(t *testing.T) {
+	component := &ModifyDBInstance{}
+
+	t.Run("nothing to modify -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "dbInstance": "staging"},
+		})
+
+		require.ErrorContains(t, err, "at least one setting to modify is required")
+	})
+
+	t.Run("same identifier is not a rename -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":                  "us-east-1",
+				"dbInstance":              "staging",
+				"newDBInstanceIdentifier": "staging",
+			},
+		})
+
+		require.ErrorContains(t, err, "at least one setting to modify is required")
+	})
+
+	t.Run("storage out of range -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "dbInstance": "staging", "allocatedStorage": float64(10)},
+		})
+
+		require.ErrorContains(t, err, "allocated storage must be between 20 and 65536 GiB")
+	})
+
+	t.Run("backup retention of 0 -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "dbInstance": "staging", "backupRetentionPeriod": float64(0)},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__ModifyDBInstance__Execute(t *testing.T) {
+	component := &ModifyDBInstance{}
+
+	t.Run("applies immediately -> schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			testModifyDBInstanceResponse("staging", "<DBInstanceClass>db.t3.large</DBInstanceClass>"),
+		}}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":                "us-east-1",
+				"dbInstance":            "staging",
+				"dbInstanceClass":       "db.t3.large",
+				"allocatedStorage":      float64(200),
+				"backupRetentionPeriod": float64(0),
+			},
+			HTTP:           httpContext,
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, dbInstancePollAction, requests.Action)
+
+		require.Len(t, httpContext.Requests, 1)
+		body := testRequestBodyString(t, httpContext.Requests[0])
+		assert.Contains(t, body, "Action=ModifyDBInstance")
+		assert.Contains(t, body, "DBInstanceIdentifier=staging")
+		assert.Contains(t, body, "DBInstanceClass=db.t3.large")
+		assert.Contains(t, body, "AllocatedStorage=200")
+		assert.Contains(t, body, "BackupRetentionPeriod=0")
+		assert.Contains(t, body, "ApplyImmediately=true")
+		assert.NotContains(t, body, "NewDBInstanceIdentifier")
+		assert.NotContains(t, body, "VpcSecurityGroupIds")
+	})
+
+	t.Run("rename -> polls the new identifier", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			testModifyDBInstanceResponse("staging", "<DBInstanceIdentifier>staging-old</DBInstanceIdentifier>"),
+		}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":                  "us-east-1",
+				"dbInstance":              "staging",
+				"newDBInstanceIdentifier": "staging-old",
+			},
+			HTTP:           httpContext,
+			Metadata:       metadata,
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		stored := metadata.Metadata.(DBInstanceExecutionMetadata)
+		assert.Equal(t, "staging-old", stored.DBInstanceIdentifier)
+		assert.Contains(t, testRequestBodyString(t, httpContext.Requests[0]), "NewDBInstanceIdentifier=staging-old")
+	})
+
+	t.Run("next maintenance window -> emits without waiting", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			testModifyDBInstanceResponse("staging", "<DBInstanceClass>db.t3.large</DBInstanceClass>"),
+		}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":           "us-east-1",
+				"dbInstance":       "staging",
+				"dbInstanceClass":  "db.t3.large",
+				"applyImmediately": false,
+			},
+			HTTP:           httpContext,
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, requests.Action)
+		require.Len(t, execState.Payloads, 1)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		instance := data["dbInstance"].(*DBInstance)
+		assert.Equal(t, map[string]string{"DBInstanceClass": "db.t3.large"}, instance.PendingModifiedValues)
+		assert.Contains(t, testRequestBodyString(t, httpContext.Requests[0]), "ApplyImmediately=false")
+	})
+}
+
+func Test__ModifyDBInstance__HandleAction(t *testing.T) {
+	component := &ModifyDBInstance{}
+
+	t.Run("pending modifications -> schedules next poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: dbInstancePollAction,
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				testDescribeDBInstancesResponse("staging", "available", "<DBInstanceClass>db.t3.large</DBInstanceClass>"),
+			}},
+			Metadata:       testDBInstanceMetadata("staging"),
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, dbInstancePollAction, requests.Action)
+	})
+
+	t.Run("renamed instance not found yet -> schedules next poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: dbInstancePollAction,
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				testErrorResponse("DBInstanceNotFound", "DBInstance staging-old not found."),
+			}},
+			Metadata:       testDBInstanceMetadata("staging-old"),
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, dbInstancePollAction, requests.Action)
+	})
+
+	t.Run("modifications applied -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           dbInstancePollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeDBInstancesResponse("staging-old", "available", "")}},
+			Metadata:       testDBInstanceMetadata("staging-old"),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "staging-old", data["dbInstance"].(*DBInstance).DBInstanceIdentifier)
+	})
+}
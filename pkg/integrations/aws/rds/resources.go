@@ -0,0 +1,72 @@
+package rds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func ListDBInstances(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
+	region := strings.TrimSpace(ctx.Parameters["region"])
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, region)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := client.ListDBInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DB instances: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(instances))
+	for _, instance := range instances {
+		resources = append(resources, core.IntegrationResource{
+			Type: resourceType,
+			Name: instance.DBInstanceIdentifier,
+			ID:   instance.DBInstanceIdentifier,
+		})
+	}
+
+	return resources, nil
+}
+
+/*
+ * ListDBSnapshots lists the available snapshots of the region,
+ * or only the ones of the DB instance given in the dbInstance parameter.
+ */
+func ListDBSnapshots(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
+	region := strings.TrimSpace(ctx.Parameters["region"])
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := client.ListDBSnapshots(strings.TrimSpace(ctx.Parameters["dbInstance"]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DB snapshots: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.Status != DBSnapshotStatusAvailable {
+			continue
+		}
+
+		resources = append(resources, core.IntegrationResource{
+			Type: resourceType,
+			Name: snapshot.DBSnapshotIdentifier,
+			ID:   snapshot.DBSnapshotIdentifier,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,288 @@
+package rds
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+type RestoreDBInstanceFromSnapshot struct{}
+
+type RestoreDBInstanceFromSnapshotConfiguration struct {
+	Region               string       `json:"region" mapstructure:"region"`
+	DBSnapshotIdentifier string       `json:"snapshot" mapstructure:"snapshot"`
+	DBInstanceIdentifier string       `json:"dbInstanceIdentifier" mapstructure:"dbInstanceIdentifier"`
+	DBInstanceClass      string       `json:"dbInstanceClass" mapstructure:"dbInstanceClass"`
+	DBSubnetGroupName    string       `json:"dbSubnetGroupName" mapstructure:"dbSubnetGroupName"`
+	VpcSecurityGroupIDs  []string     `json:"vpcSecurityGroupIds" mapstructure:"vpcSecurityGroupIds"`
+	MultiAZ              bool         `json:"multiAZ" mapstructure:"multiAZ"`
+	PubliclyAccessible   bool         `json:"publiclyAccessible" mapstructure:"publiclyAccessible"`
+	Tags                 []common.Tag `json:"tags" mapstructure:"tags"`
+	WaitForCompletion    *bool        `json:"waitForCompletion,omitempty" mapstructure:"waitForCompletion,omitempty"`
+	TimeoutMinutes       int          `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+func (c *RestoreDBInstanceFromSnapshotConfiguration) ShouldWait() bool {
+	return c.WaitForCompletion == nil || *c.WaitForCompletion
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Name() string {
+	return "aws.rds.restoreDBInstanceFromSnapshot"
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Label() string {
+	return "RDS • Restore DB Instance From Snapshot"
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Description() string {
+	return "Create a new RDS DB instance from a DB snapshot"
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Documentation() string {
+	return `The Restore DB Instance From Snapshot component creates a new RDS DB instance from a DB snapshot.
+
+## Use Cases
+
+- **Database refreshes**: Restore a production snapshot as a staging or QA instance
+- **Disaster recovery**: Bring a database back from its latest snapshot
+- **Investigations**: Restore a point-in-time copy of a database to inspect its data
+
+## Configuration
+
+- **Region**: AWS region of the snapshot
+- **Snapshot**: DB snapshot to restore
+- **DB Instance Identifier**: Name of the new DB instance. No instance with this name can exist in the region
+- **DB Instance Class**: Optional instance class, e.g. ` + "`db.t3.medium`" + `. Defaults to the class of the snapshotted instance
+- **DB Subnet Group**: Optional subnet group, which also selects the VPC. Defaults to the default VPC
+- **VPC Security Groups**: Optional security groups. Defaults to the default security group of the VPC
+- **Multi-AZ**: Create a standby in another availability zone
+- **Publicly Accessible**: Give the instance a public endpoint
+- **Tags**: Optional tags for the new instance
+- **Wait for completion**: Wait until the instance is ` + "`available`" + ` before emitting. Enabled by default
+- **Timeout (minutes)**: How long to wait before failing. Defaults to 60 minutes
+
+## Completion behavior
+
+- When waiting, the status of the instance is checked every 30 seconds.
+- The execution fails if the instance reaches a failed status, e.g. ` + "`incompatible-restore`" + `, or is not available before the timeout.
+- The new instance keeps the master password of the snapshotted instance. Use **RDS • Modify DB Instance** to change its settings afterwards.`
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Icon() string {
+	return "aws"
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Color() string {
+	return "gray"
+}
+
+func (c *RestoreDBInstanceFromSnapshot) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Configuration() []configuration.Field {
+	fields := []configuration.Field{
+		regionField(),
+		{
+			Name:        "snapshot",
+			Label:       "Snapshot",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "DB snapshot to restore",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "rds.dbSnapshot",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name: "region",
+							ValueFrom: &configuration.ParameterValueFrom{
+								Field: "region",
+							},
+						},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{
+					Field:  "region",
+					Values: []string{"*"},
+				},
+			},
+		},
+		{
+			Name:        "dbInstanceIdentifier",
+			Label:       "DB Instance Identifier",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "staging-db",
+			Description: "Name of the new DB instance",
+		},
+		{
+			Name:        "dbInstanceClass",
+			Label:       "DB Instance Class",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "db.t3.medium",
+			Description: "Defaults to the class of the snapshotted instance",
+		},
+		{
+			Name:        "dbSubnetGroupName",
+			Label:       "DB Subnet Group",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "staging-subnets",
+			Description: "Subnet group of the new instance, which also selects its VPC",
+		},
+		securityGroupsField("Security groups of the new instance"),
+		{
+			Name:        "multiAZ",
+			Label:       "Multi-AZ",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Create a standby in another availability zone",
+		},
+		{
+			Name:        "publiclyAccessible",
+			Label:       "Publicly Accessible",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Give the new instance a public endpoint",
+		},
+		tagsField("Tags to add to the new instance"),
+	}
+
+	return append(fields, waitFields("Wait until the new instance is available before emitting")...)
+}
+
+func decodeRestoreDBInstanceFromSnapshotConfiguration(raw any) (RestoreDBInstanceFromSnapshotConfiguration, error) {
+	config := RestoreDBInstanceFromSnapshotConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return RestoreDBInstanceFromSnapshotConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, err := requireRegion(config.Region)
+	if err != nil {
+		return RestoreDBInstanceFromSnapshotConfiguration{}, err
+	}
+
+	config.Region = region
+	config.DBSnapshotIdentifier = strings.TrimSpace(config.DBSnapshotIdentifier)
+	config.DBInstanceIdentifier = strings.TrimSpace(config.DBInstanceIdentifier)
+	config.DBInstanceClass = strings.TrimSpace(config.DBInstanceClass)
+	config.DBSubnetGroupName = strings.TrimSpace(config.DBSubnetGroupName)
+	config.VpcSecurityGroupIDs = normalizeSecurityGroupIDs(config.VpcSecurityGroupIDs)
+	config.Tags = common.NormalizeTags(config.Tags)
+
+	if config.DBSnapshotIdentifier == "" {
+		return RestoreDBInstanceFromSnapshotConfiguration{}, fmt.Errorf("snapshot is required")
+	}
+
+	if config.DBInstanceIdentifier == "" {
+		return RestoreDBInstanceFromSnapshotConfiguration{}, fmt.Errorf("DB instance identifier is required")
+	}
+
+	config.TimeoutMinutes, err = normalizeTimeout(config.TimeoutMinutes)
+	if err != nil {
+		return RestoreDBInstanceFromSnapshotConfiguration{}, err
+	}
+
+	return config, nil
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Setup(ctx core.SetupContext) error {
+	_, err := decodeRestoreDBInstanceFromSnapshotConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *RestoreDBInstanceFromSnapshot) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeRestoreDBInstanceFromSnapshotConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := validateIdentifier("DB instance identifier", config.DBInstanceIdentifier, maxIdentifierLength); err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, config.Region)
+	if err != nil {
+		return err
+	}
+
+	instance, err := client.RestoreDBInstanceFromDBSnapshot(RestoreDBInstanceInput{
+		DBInstanceIdentifier: config.DBInstanceIdentifier,
+		DBSnapshotIdentifier: config.DBSnapshotIdentifier,
+		DBInstanceClass:      config.DBInstanceClass,
+		DBSubnetGroupName:    config.DBSubnetGroupName,
+		VpcSecurityGroupIDs:  config.VpcSecurityGroupIDs,
+		MultiAZ:              config.MultiAZ,
+		PubliclyAccessible:   config.PubliclyAccessible,
+		Tags:                 config.Tags,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to restore DB instance: %w", err)
+	}
+
+	metadata := DBInstanceExecutionMetadata{
+		Region:               config.Region,
+		DBInstanceIdentifier: instance.DBInstanceIdentifier,
+		Status:               instance.Status,
+		Deadline:             deadlineFrom(config.TimeoutMinutes),
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	if !config.ShouldWait() {
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, dbInstancePayloadType, []any{dbInstancePayload(instance)})
+	}
+
+	return ctx.Requests.ScheduleActionCall(dbInstancePollAction, map[string]any{}, pollInterval)
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:        dbInstancePollAction,
+			Description: "Check the status of the restored DB instance",
+		},
+	}
+}
+
+func (c *RestoreDBInstanceFromSnapshot) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case dbInstancePollAction:
+		return pollDBInstance(ctx)
+
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *RestoreDBInstanceFromSnapshot) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *RestoreDBInstanceFromSnapshot) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
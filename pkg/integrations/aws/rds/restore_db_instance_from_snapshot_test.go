@@ -0,0 +1,225 @@
+package rds
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testDBInstanceXML(identifier, status, pendingModifiedValues string) string {
+	return `
+		<DBInstanceIdentifier>` + identifier + `</DBInstanceIdentifier>
+		<DBInstanceArn>arn:aws:rds:us-east-1:123456789012:db:` + identifier + `</DBInstanceArn>
+		<DBInstanceClass>db.t3.medium</DBInstanceClass>
+		<DBInstanceStatus>` + status + `</DBInstanceStatus>
+		<Engine>postgres</Engine>
+		<EngineVersion>16.4</EngineVersion>
+		<Endpoint><Address>` + identifier + `.abcdefghijkl.us-east-1.rds.amazonaws.com</Address><Port>5432</Port></Endpoint>
+		<AllocatedStorage>100</AllocatedStorage>
+		<BackupRetentionPeriod>1</BackupRetentionPeriod>
+		<MultiAZ>false</MultiAZ>
+		<DBSubnetGroup><DBSubnetGroupName>staging-subnets</DBSubnetGroupName></DBSubnetGroup>
+		<VpcSecurityGroups>
+			<VpcSecurityGroupMembership><VpcSecurityGroupId>sg-123</VpcSecurityGroupId><Status>active</Status></VpcSecurityGroupMembership>
+		</VpcSecurityGroups>
+		<PendingModifiedValues>` + pendingModifiedValues + `</PendingModifiedValues>
+	`
+}
+
+func testRestoreDBInstanceResponse() *http.Response {
+	return testResponse(`
+		<RestoreDBInstanceFromDBSnapshotResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+			<RestoreDBInstanceFromDBSnapshotResult>
+				<DBInstance>` + testDBInstanceXML("staging", "creating", "") + `</DBInstance>
+			</RestoreDBInstanceFromDBSnapshotResult>
+		</RestoreDBInstanceFromDBSnapshotResponse>
+	`)
+}
+
+func testDescribeDBInstancesResponse(identifier, status, pendingModifiedValues string) *http.Response {
+	return testResponse(`
+		<DescribeDBInstancesResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+			<DescribeDBInstancesResult>
+				<DBInstances><DBInstance>` + testDBInstanceXML(identifier, status, pendingModifiedValues) + `</DBInstance></DBInstances>
+			</DescribeDBInstancesResult>
+		</DescribeDBInstancesResponse>
+	`)
+}
+
+func testDBInstanceMetadata(identifier string) *contexts.MetadataContext {
+	return &contexts.MetadataContext{Metadata: DBInstanceExecutionMetadata{
+		Region:               "us-east-1",
+		DBInstanceIdentifier: identifier,
+		Status:               "creating",
+		Deadline:             time.Now().Add(time.Hour).Format(time.RFC3339),
+	}}
+}
+
+func Test__RestoreDBInstanceFromSnapshot__Setup(t *testing.T) {
+	component := &RestoreDBInstanceFromSnapshot{}
+
+	t.Run("missing snapshot -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "dbInstanceIdentifier": "staging"},
+		})
+
+		require.ErrorContains(t, err, "snapshot is required")
+	})
+
+	t.Run("missing DB instance identifier -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "snapshot": "production-2026-02-19"},
+		})
+
+		require.ErrorContains(t, err, "DB instance identifier is required")
+	})
+}
+
+func Test__RestoreDBInstanceFromSnapshot__Execute(t *testing.T) {
+	component := &RestoreDBInstanceFromSnapshot{}
+
+	t.Run("waits for completion -> schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{testRestoreDBInstanceResponse()}}
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":               "us-east-1",
+				"snapshot":             "production-2026-02-19",
+				"dbInstanceIdentifier": "staging",
+				"dbInstanceClass":      "db.t3.medium",
+				"dbSubnetGroupName":    "staging-subnets",
+				"vpcSecurityGroupIds":  []any{"sg-123", " ", "sg-123"},
+			},
+			HTTP:           httpContext,
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, dbInstancePollAction, requests.Action)
+
+		stored, ok := metadata.Metadata.(DBInstanceExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "staging", stored.DBInstanceIdentifier)
+		assert.Equal(t, "creating", stored.Status)
+
+		require.Len(t, httpContext.Requests, 1)
+		body := testRequestBodyString(t, httpContext.Requests[0])
+		assert.Contains(t, body, "Action=RestoreDBInstanceFromDBSnapshot")
+		assert.Contains(t, body, "DBSnapshotIdentifier=production-2026-02-19")
+		assert.Contains(t, body, "DBInstanceIdentifier=staging")
+		assert.Contains(t, body, "DBInstanceClass=db.t3.medium")
+		assert.Contains(t, body, "DBSubnetGroupName=staging-subnets")
+		assert.Contains(t, body, "VpcSecurityGroupIds.VpcSecurityGroupId.1=sg-123")
+		assert.NotContains(t, body, "VpcSecurityGroupIds.VpcSecurityGroupId.2")
+		assert.Contains(t, body, "PubliclyAccessible=false")
+		assert.Contains(t, body, "MultiAZ=false")
+	})
+
+	t.Run("invalid DB instance identifier -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":               "us-east-1",
+				"snapshot":             "production-2026-02-19",
+				"dbInstanceIdentifier": "1-staging",
+			},
+			HTTP:           &contexts.HTTPContext{},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, `invalid DB instance identifier "1-staging"`)
+	})
+
+	t.Run("instance already exists -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":               "us-east-1",
+				"snapshot":             "production-2026-02-19",
+				"dbInstanceIdentifier": "staging",
+			},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				testErrorResponse("DBInstanceAlreadyExists", "DB instance already exists"),
+			}},
+			Metadata:       &contexts.MetadataContext{},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, "DB instance already exists")
+	})
+}
+
+func Test__RestoreDBInstanceFromSnapshot__HandleAction(t *testing.T) {
+	component := &RestoreDBInstanceFromSnapshot{}
+
+	t.Run("instance available -> emits", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           dbInstancePollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeDBInstancesResponse("staging", "available", "")}},
+			Metadata:       testDBInstanceMetadata("staging"),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, dbInstancePayloadType, execState.Type)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		instance := data["dbInstance"].(*DBInstance)
+		assert.Equal(t, "available", instance.Status)
+		assert.Equal(t, &Endpoint{Address: "staging.abcdefghijkl.us-east-1.rds.amazonaws.com", Port: 5432}, instance.Endpoint)
+		assert.Equal(t, []string{"sg-123"}, instance.VpcSecurityGroupIDs)
+		assert.Equal(t, "staging-subnets", instance.DBSubnetGroupName)
+		assert.Empty(t, instance.PendingModifiedValues)
+	})
+
+	t.Run("instance creating -> schedules next poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           dbInstancePollAction,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{testDescribeDBInstancesResponse("staging", "creating", "")}},
+			Metadata:       testDBInstanceMetadata("staging"),
+			Requests:       requests,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+		assert.Equal(t, dbInstancePollAction, requests.Action)
+		assert.Equal(t, pollInterval, requests.Duration)
+	})
+
+	t.Run("incompatible restore -> fails execution", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name: dbInstancePollAction,
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				testDescribeDBInstancesResponse("staging", "incompatible-restore", ""),
+			}},
+			Metadata:       testDBInstanceMetadata("staging"),
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, execState.Passed)
+		assert.Equal(t, "DB instance staging is incompatible-restore", execState.FailureMessage)
+	})
+}
@@ -7,8 +7,12 @@ import (
 	"github.com/superplanehq/superplane/pkg/integrations/aws/ec2"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/ecr"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/ecs"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/eks"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/elb"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/lambda"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/rds"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/route53"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/secretsmanager"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/sns"
 	"github.com/superplanehq/superplane/pkg/integrations/aws/sqs"
 )
@@ -21,6 +25,12 @@ func (a *AWS) ListResources(resourceType string, ctx core.ListResourcesContext)
 	case "ecr.repository":
 		return ecr.ListRepositories(ctx, resourceType)
 
+	case "ecr.image":
+		return ecr.ListImages(ctx, resourceType)
+
+	case "eks.cluster":
+		return eks.ListClusters(ctx, resourceType)
+
 	case "ecs.cluster":
 		return ecs.ListClusters(ctx, resourceType)
 
@@ -39,6 +49,18 @@ func (a *AWS) ListResources(resourceType string, ctx core.ListResourcesContext)
 	case "ec2.image":
 		return ec2.ListImages(ctx, resourceType)
 
+	case "elb.targetGroup":
+		return elb.ListTargetGroups(ctx, resourceType)
+
+	case "rds.dbInstance":
+		return rds.ListDBInstances(ctx, resourceType)
+
+	case "rds.dbSnapshot":
+		return rds.ListDBSnapshots(ctx, resourceType)
+
+	case "secretsmanager.secret":
+		return secretsmanager.ListSecrets(ctx, resourceType)
+
 	case "codeartifact.repository":
 		return codeartifact.ListRepositories(ctx, resourceType)
 
@@ -69,3 +91,25 @@ func (a *AWS) ListResources(resourceType string, ctx core.ListResourcesContext)
 		return []core.IntegrationResource{}, nil
 	}
 }
+
+/*
+ * ListResourcePage searches and paginates EC2 images and SQS queues
+ * through the AWS APIs. Other resource types are listed in full.
+ */
+func (a *AWS) ListResourcePage(resourceType string, ctx core.ListResourcesContext) (*core.ResourcePage, error) {
+	switch resourceType {
+	case "ec2.image":
+		return ec2.ListImagePage(ctx, resourceType)
+
+	case "sqs.queue":
+		return sqs.ListQueuePage(ctx, resourceType)
+
+	default:
+		resources, err := a.ListResources(resourceType, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return core.PageResources(resources, ctx)
+	}
+}
@@ -33,7 +33,7 @@ type Client struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		credentials: credentials,
 		signer:      v4.NewSigner(),
 	}
@@ -165,10 +165,7 @@ func (c *Client) GetChange(changeID string) (*ChangeInfo, error) {
 
 // ListHostedZones returns all hosted zones in the account.
 func (c *Client) ListHostedZones() ([]HostedZoneSummary, error) {
-	var zones []HostedZoneSummary
-	marker := ""
-
-	for {
+	return common.CollectPages(func(marker string) ([]HostedZoneSummary, string, error) {
 		url := fmt.Sprintf("%s/hostedzone?maxitems=100", endpoint)
 		if strings.TrimSpace(marker) != "" {
 			url += "&marker=" + marker
@@ -176,36 +173,37 @@ func (c *Client) ListHostedZones() ([]HostedZoneSummary, error) {
 
 		req, err := http.NewRequest(http.MethodGet, url, nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build list hosted zones request: %w", err)
+			return nil, "", fmt.Errorf("failed to build list hosted zones request: %w", err)
 		}
 
 		if err := c.signRequest(req, []byte{}); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		res, err := c.http.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("list hosted zones request failed: %w", err)
+			return nil, "", fmt.Errorf("list hosted zones request failed: %w", err)
 		}
 
 		body, err := io.ReadAll(res.Body)
 		res.Body.Close()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read list hosted zones response: %w", err)
+			return nil, "", fmt.Errorf("failed to read list hosted zones response: %w", err)
 		}
 
 		if res.StatusCode < 200 || res.StatusCode >= 300 {
 			if awsErr := parseError(body); awsErr != nil {
-				return nil, awsErr
+				return nil, "", awsErr
 			}
-			return nil, fmt.Errorf("list hosted zones failed with %d: %s", res.StatusCode, string(body))
+			return nil, "", fmt.Errorf("list hosted zones failed with %d: %s", res.StatusCode, string(body))
 		}
 
 		var response listHostedZonesResponse
 		if err := xml.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to decode list hosted zones response: %w", err)
+			return nil, "", fmt.Errorf("failed to decode list hosted zones response: %w", err)
 		}
 
+		zones := make([]HostedZoneSummary, 0, len(response.HostedZones))
 		for _, hz := range response.HostedZones {
 			zones = append(zones, HostedZoneSummary{
 				ID:   hz.ID,
@@ -214,12 +212,11 @@ func (c *Client) ListHostedZones() ([]HostedZoneSummary, error) {
 		}
 
 		if !response.IsTruncated {
-			break
+			return zones, "", nil
 		}
-		marker = response.NextMarker
-	}
 
-	return zones, nil
+		return zones, response.NextMarker, nil
+	})
 }
 
 func (c *Client) signRequest(req *http.Request, payload []byte) error {
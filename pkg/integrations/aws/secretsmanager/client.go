@@ -0,0 +1,211 @@
+package secretsmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+const (
+	serviceName  = "secretsmanager"
+	targetPrefix = "secretsmanager."
+)
+
+type Client struct {
+	http        core.HTTPContext
+	region      string
+	credentials *aws.Credentials
+	signer      *v4.Signer
+}
+
+type Secret struct {
+	ARN         string `json:"ARN"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+}
+
+type SecretDescription struct {
+	ARN                string              `json:"ARN"`
+	Name               string              `json:"Name"`
+	RotationEnabled    bool                `json:"RotationEnabled"`
+	VersionIdsToStages map[string][]string `json:"VersionIdsToStages"`
+}
+
+type SecretValue struct {
+	ARN           string           `json:"ARN"`
+	Name          string           `json:"Name"`
+	VersionID     string           `json:"VersionId"`
+	VersionStages []string         `json:"VersionStages"`
+	SecretString  *string          `json:"SecretString"`
+	SecretBinary  string           `json:"SecretBinary"`
+	CreatedDate   common.FloatTime `json:"CreatedDate"`
+}
+
+type PutSecretValueInput struct {
+	SecretID           string
+	SecretString       string
+	ClientRequestToken string
+	VersionStages      []string
+}
+
+type PutSecretValueOutput struct {
+	ARN           string   `json:"ARN"`
+	Name          string   `json:"Name"`
+	VersionID     string   `json:"VersionId"`
+	VersionStages []string `json:"VersionStages"`
+}
+
+func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
+	return &Client{
+		http:        common.NewHTTPClient(httpCtx),
+		region:      region,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+	}
+}
+
+func (c *Client) ListSecrets() ([]Secret, error) {
+	return common.CollectPages(func(token string) ([]Secret, string, error) {
+		payload := map[string]any{
+			"MaxResults": 100,
+		}
+
+		if token != "" {
+			payload["NextToken"] = token
+		}
+
+		var response struct {
+			SecretList []Secret `json:"SecretList"`
+			NextToken  string   `json:"NextToken"`
+		}
+
+		if err := c.postJSON("ListSecrets", payload, &response); err != nil {
+			return nil, "", err
+		}
+
+		return response.SecretList, response.NextToken, nil
+	})
+}
+
+func (c *Client) DescribeSecret(secretID string) (*SecretDescription, error) {
+	payload := map[string]any{
+		"SecretId": secretID,
+	}
+
+	var response SecretDescription
+	if err := c.postJSON("DescribeSecret", payload, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+/*
+ * GetSecretValue returns the version of the secret with the given ID,
+ * or the one with the given stage if no ID is given.
+ */
+func (c *Client) GetSecretValue(secretID, versionID, versionStage string) (*SecretValue, error) {
+	payload := map[string]any{
+		"SecretId": secretID,
+	}
+
+	if versionID != "" {
+		payload["VersionId"] = versionID
+	} else if versionStage != "" {
+		payload["VersionStage"] = versionStage
+	}
+
+	var response SecretValue
+	if err := c.postJSON("GetSecretValue", payload, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *Client) PutSecretValue(input PutSecretValueInput) (*PutSecretValueOutput, error) {
+	payload := map[string]any{
+		"SecretId":     input.SecretID,
+		"SecretString": input.SecretString,
+	}
+
+	if input.ClientRequestToken != "" {
+		payload["ClientRequestToken"] = input.ClientRequestToken
+	}
+
+	if len(input.VersionStages) > 0 {
+		payload["VersionStages"] = input.VersionStages
+	}
+
+	var response PutSecretValueOutput
+	if err := c.postJSON("PutSecretValue", payload, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *Client) postJSON(action string, payload any, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("https://secretsmanager.%s.amazonaws.com/", c.region)
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", targetPrefix+action)
+
+	if err := c.signRequest(req, body); err != nil {
+		return err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		if awsErr := common.ParseError(responseBody); awsErr != nil {
+			return awsErr
+		}
+		return fmt.Errorf("Secrets Manager API request failed with %d: %s", res.StatusCode, string(responseBody))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) signRequest(req *http.Request, payload []byte) error {
+	hash := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(hash[:])
+	return c.signer.SignHTTP(context.Background(), *c.credentials, req, payloadHash, serviceName, c.region, time.Now())
+}
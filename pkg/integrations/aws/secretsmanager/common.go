@@ -0,0 +1,117 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
+)
+
+const (
+	VersionStageCurrent  = "AWSCURRENT"
+	VersionStagePrevious = "AWSPREVIOUS"
+	VersionStagePending  = "AWSPENDING"
+)
+
+func regionField() configuration.Field {
+	return configuration.Field{
+		Name:     "region",
+		Label:    "Region",
+		Type:     configuration.FieldTypeSelect,
+		Required: true,
+		Default:  "us-east-1",
+		TypeOptions: &configuration.TypeOptions{
+			Select: &configuration.SelectTypeOptions{
+				Options: common.AllRegions,
+			},
+		},
+	}
+}
+
+func secretField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "secret",
+		Label:       "Secret",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: "secretsmanager.secret",
+				Parameters: []configuration.ParameterRef{
+					{
+						Name: "region",
+						ValueFrom: &configuration.ParameterValueFrom{
+							Field: "region",
+						},
+					},
+				},
+			},
+		},
+		VisibilityConditions: []configuration.VisibilityCondition{
+			{
+				Field:  "region",
+				Values: []string{"*"},
+			},
+		},
+	}
+}
+
+func requireRegionAndSecret(region, secret string) (string, string, error) {
+	region = strings.TrimSpace(region)
+	secret = strings.TrimSpace(secret)
+	if region == "" {
+		return "", "", fmt.Errorf("region is required")
+	}
+
+	if secret == "" {
+		return "", "", fmt.Errorf("secret is required")
+	}
+
+	return region, secret, nil
+}
+
+func newClient(httpCtx core.HTTPContext, integration core.IntegrationContext, region string) (*Client, error) {
+	creds, err := common.CredentialsFromInstallation(integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	return NewClient(httpCtx, creds, region), nil
+}
+
+/*
+ * pendingRotationVersion returns the version of the secret that is being rotated,
+ * i.e. the version labeled AWSPENDING that is not AWSCURRENT yet.
+ * A rotation that failed leaves that label behind too, and also needs attention.
+ */
+func pendingRotationVersion(secret *SecretDescription) string {
+	if !secret.RotationEnabled {
+		return ""
+	}
+
+	for versionID, stages := range secret.VersionIdsToStages {
+		if slices.Contains(stages, VersionStagePending) && !slices.Contains(stages, VersionStageCurrent) {
+			return versionID
+		}
+	}
+
+	return ""
+}
+
+/*
+ * parseSecretJSON returns the secret string as an object,
+ * if it holds a JSON object like the key/value secrets created in the AWS console.
+ */
+func parseSecretJSON(secretString string) map[string]any {
+	value := map[string]any{}
+	if err := json.Unmarshal([]byte(secretString), &value); err != nil {
+		return nil
+	}
+
+	return value
+}
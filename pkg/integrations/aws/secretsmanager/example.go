@@ -0,0 +1,28 @@
+package secretsmanager
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_get_secret_value.json
+var exampleOutputGetSecretValueBytes []byte
+
+var exampleOutputGetSecretValueOnce sync.Once
+var exampleOutputGetSecretValue map[string]any
+
+//go:embed example_output_put_secret_value.json
+var exampleOutputPutSecretValueBytes []byte
+
+var exampleOutputPutSecretValueOnce sync.Once
+var exampleOutputPutSecretValue map[string]any
+
+func (c *GetSecretValue) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetSecretValueOnce, exampleOutputGetSecretValueBytes, &exampleOutputGetSecretValue)
+}
+
+func (c *PutSecretValue) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputPutSecretValueOnce, exampleOutputPutSecretValueBytes, &exampleOutputPutSecretValue)
+}
@@ -0,0 +1,198 @@
+package secretsmanager
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const getSecretValuePayloadType = "aws.secretsmanager.secretValue"
+
+type GetSecretValue struct{}
+
+type GetSecretValueConfiguration struct {
+	Region       string `json:"region" mapstructure:"region"`
+	Secret       string `json:"secret" mapstructure:"secret"`
+	VersionStage string `json:"versionStage" mapstructure:"versionStage"`
+	VersionID    string `json:"versionId" mapstructure:"versionId"`
+}
+
+func (c *GetSecretValue) Name() string {
+	return "aws.secretsmanager.getSecretValue"
+}
+
+func (c *GetSecretValue) Label() string {
+	return "Secrets Manager • Get Secret Value"
+}
+
+func (c *GetSecretValue) Description() string {
+	return "Read the value of a secret from AWS Secrets Manager"
+}
+
+func (c *GetSecretValue) Documentation() string {
+	return `The Get Secret Value component reads a version of a secret stored in AWS Secrets Manager.
+
+## Use Cases
+
+- **Deployments**: Read database credentials or API keys to pass them to the next steps
+- **Rotation checks**: Compare the current and previous versions of a rotated secret
+- **Validation**: Check the pending version of a secret before promoting it
+
+## Configuration
+
+- **Region**: AWS region of the secret
+- **Secret**: Secret to read
+- **Version Stage**: Version to read. Defaults to ` + "`AWSCURRENT`" + `
+- **Version ID**: Optional ID of the version to read. Overrides the version stage
+
+## Output
+
+- The ARN, name, version ID and version stages of the secret
+- ` + "`secretString`" + `: the value of the secret, for secrets stored as text
+- ` + "`secretJson`" + `: the same value as an object, for key/value secrets, e.g. ` + "`secretJson.password`" + `
+- ` + "`secretBinary`" + `: the base64-encoded value, for binary secrets
+
+The value is stored in the output of the execution, like any other output.`
+}
+
+func (c *GetSecretValue) Icon() string {
+	return "aws"
+}
+
+func (c *GetSecretValue) Color() string {
+	return "gray"
+}
+
+func (c *GetSecretValue) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetSecretValue) Configuration() []configuration.Field {
+	return []configuration.Field{
+		regionField(),
+		secretField("Secret to read"),
+		{
+			Name:     "versionStage",
+			Label:    "Version Stage",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  VersionStageCurrent,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Current", Value: VersionStageCurrent},
+						{Label: "Previous", Value: VersionStagePrevious},
+						{Label: "Pending", Value: VersionStagePending},
+					},
+				},
+			},
+		},
+		{
+			Name:        "versionId",
+			Label:       "Version ID",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Description: "Read this version instead of the one with the version stage",
+		},
+	}
+}
+
+func decodeGetSecretValueConfiguration(raw any) (GetSecretValueConfiguration, error) {
+	config := GetSecretValueConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return GetSecretValueConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, secret, err := requireRegionAndSecret(config.Region, config.Secret)
+	if err != nil {
+		return GetSecretValueConfiguration{}, err
+	}
+
+	config.Region = region
+	config.Secret = secret
+	config.VersionID = strings.TrimSpace(config.VersionID)
+	config.VersionStage = strings.TrimSpace(config.VersionStage)
+	if config.VersionStage == "" {
+		config.VersionStage = VersionStageCurrent
+	}
+
+	if !slices.Contains([]string{VersionStageCurrent, VersionStagePrevious, VersionStagePending}, config.VersionStage) {
+		return GetSecretValueConfiguration{}, fmt.Errorf("invalid version stage: %s", config.VersionStage)
+	}
+
+	return config, nil
+}
+
+func (c *GetSecretValue) Setup(ctx core.SetupContext) error {
+	_, err := decodeGetSecretValueConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *GetSecretValue) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetSecretValue) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeGetSecretValueConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, config.Region)
+	if err != nil {
+		return err
+	}
+
+	secret, err := client.GetSecretValue(config.Secret, config.VersionID, config.VersionStage)
+	if err != nil {
+		return fmt.Errorf("failed to get secret value: %w", err)
+	}
+
+	payload := map[string]any{
+		"arn":           secret.ARN,
+		"name":          secret.Name,
+		"versionId":     secret.VersionID,
+		"versionStages": secret.VersionStages,
+		"createdDate":   secret.CreatedDate,
+	}
+
+	if secret.SecretString != nil {
+		payload["secretString"] = *secret.SecretString
+		if value := parseSecretJSON(*secret.SecretString); value != nil {
+			payload["secretJson"] = value
+		}
+	}
+
+	if secret.SecretBinary != "" {
+		payload["secretBinary"] = secret.SecretBinary
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, getSecretValuePayloadType, []any{payload})
+}
+
+func (c *GetSecretValue) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *GetSecretValue) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *GetSecretValue) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *GetSecretValue) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetSecretValue) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,145 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testSecretArn = "arn:aws:secretsmanager:us-east-1:123456789012:secret:production/database-AbCdEf"
+
+func testIntegrationWithCredentials() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Secrets: map[string]core.IntegrationSecret{
+			"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+			"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+			"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+		},
+	}
+}
+
+func testResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testRequestBody(t *testing.T, request *http.Request) map[string]any {
+	t.Helper()
+	body, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+
+	payload := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	return payload
+}
+
+func Test__GetSecretValue__Setup(t *testing.T) {
+	component := &GetSecretValue{}
+
+	t.Run("missing secret -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1"},
+		})
+
+		require.ErrorContains(t, err, "secret is required")
+	})
+
+	t.Run("invalid version stage -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "secret": testSecretArn, "versionStage": "LATEST"},
+		})
+
+		require.ErrorContains(t, err, "invalid version stage: LATEST")
+	})
+}
+
+func Test__GetSecretValue__Execute(t *testing.T) {
+	component := &GetSecretValue{}
+
+	t.Run("JSON secret -> emits string and object", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				testResponse(http.StatusOK, `{
+					"ARN": "`+testSecretArn+`",
+					"Name": "production/database",
+					"VersionId": "v1",
+					"VersionStages": ["AWSPREVIOUS"],
+					"SecretString": "{\"username\":\"app\",\"password\":\"hunter2\"}",
+					"CreatedDate": 1771495200.0
+				}`),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-east-1", "secret": testSecretArn, "versionStage": "AWSPREVIOUS"},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://secretsmanager.us-east-1.amazonaws.com/", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "secretsmanager.GetSecretValue", httpContext.Requests[0].Header.Get("X-Amz-Target"))
+		assert.Equal(t, map[string]any{"SecretId": testSecretArn, "VersionStage": "AWSPREVIOUS"}, testRequestBody(t, httpContext.Requests[0]))
+
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, getSecretValuePayloadType, execState.Type)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "production/database", data["name"])
+		assert.Equal(t, "v1", data["versionId"])
+		assert.Equal(t, `{"username":"app","password":"hunter2"}`, data["secretString"])
+		assert.Equal(t, map[string]any{"username": "app", "password": "hunter2"}, data["secretJson"])
+		assert.NotContains(t, data, "secretBinary")
+	})
+
+	t.Run("version ID -> overrides version stage", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				testResponse(http.StatusOK, `{"ARN": "`+testSecretArn+`", "VersionId": "v2", "SecretString": "plain-token"}`),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-east-1", "secret": testSecretArn, "versionId": "v2"},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"SecretId": testSecretArn, "VersionId": "v2"}, testRequestBody(t, httpContext.Requests[0]))
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "plain-token", data["secretString"])
+		assert.NotContains(t, data, "secretJson")
+	})
+
+	t.Run("secret not found -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"region": "us-east-1", "secret": "missing"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					testResponse(http.StatusBadRequest, `{
+						"__type": "ResourceNotFoundException",
+						"Message": "Secrets Manager can't find the specified secret."
+					}`),
+				},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, "ResourceNotFoundException: Secrets Manager can't find the specified secret.")
+	})
+}
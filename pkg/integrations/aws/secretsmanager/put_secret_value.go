@@ -0,0 +1,274 @@
+package secretsmanager
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	putSecretValuePayloadType = "aws.secretsmanager.secretValue.stored"
+
+	ValueSourceText   = "text"
+	ValueSourceSecret = "secret"
+)
+
+type PutSecretValue struct{}
+
+type PutSecretValueConfiguration struct {
+	Region         string                     `json:"region" mapstructure:"region"`
+	Secret         string                     `json:"secret" mapstructure:"secret"`
+	ValueSource    string                     `json:"valueSource" mapstructure:"valueSource"`
+	SecretString   string                     `json:"secretString" mapstructure:"secretString"`
+	SecretKey      configuration.SecretKeyRef `json:"secretKey" mapstructure:"secretKey"`
+	VersionStage   string                     `json:"versionStage" mapstructure:"versionStage"`
+	FailIfRotating *bool                      `json:"failIfRotating,omitempty" mapstructure:"failIfRotating,omitempty"`
+}
+
+func (c *PutSecretValueConfiguration) ShouldFailIfRotating() bool {
+	return c.FailIfRotating == nil || *c.FailIfRotating
+}
+
+func (c *PutSecretValue) Name() string {
+	return "aws.secretsmanager.putSecretValue"
+}
+
+func (c *PutSecretValue) Label() string {
+	return "Secrets Manager • Put Secret Value"
+}
+
+func (c *PutSecretValue) Description() string {
+	return "Store a new version of a secret in AWS Secrets Manager"
+}
+
+func (c *PutSecretValue) Documentation() string {
+	return `The Put Secret Value component stores a new version of an existing secret in AWS Secrets Manager.
+
+## Use Cases
+
+- **Credential updates**: Store a new API key or password generated by a previous step
+- **Staged rollouts**: Store a value as ` + "`AWSPENDING`" + `, test it, then promote it
+- **Secret sync**: Copy a SuperPlane secret to Secrets Manager for the applications that read it there
+
+## Configuration
+
+- **Region**: AWS region of the secret
+- **Secret**: Secret to update. It must already exist
+- **Value Source**: Where the new value comes from
+  - **Text**: A value, usually built with an expression
+  - **SuperPlane Secret**: A key of a SuperPlane secret, so the value is never part of the configuration
+- **Version Stage**: Label of the new version
+  - **Current**: The new version becomes ` + "`AWSCURRENT`" + `, and the previous one ` + "`AWSPREVIOUS`" + `
+  - **Pending**: The new version becomes ` + "`AWSPENDING`" + `, and applications keep reading the current one
+- **Fail if rotating**: Fail instead of storing the value while a rotation of the secret is in progress. Enabled by default
+
+## Notes
+
+- Retries of the same execution don't create more versions, since the execution ID is used as the idempotency token.
+- Secrets Manager rejects a retry that stores a different value for the same execution.`
+}
+
+func (c *PutSecretValue) Icon() string {
+	return "aws"
+}
+
+func (c *PutSecretValue) Color() string {
+	return "gray"
+}
+
+func (c *PutSecretValue) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *PutSecretValue) Configuration() []configuration.Field {
+	return []configuration.Field{
+		regionField(),
+		secretField("Secret to update"),
+		{
+			Name:     "valueSource",
+			Label:    "Value Source",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  ValueSourceText,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Text", Value: ValueSourceText},
+						{Label: "SuperPlane Secret", Value: ValueSourceSecret},
+					},
+				},
+			},
+		},
+		{
+			Name:                 "secretString",
+			Label:                "Value",
+			Type:                 configuration.FieldTypeText,
+			Required:             false,
+			Placeholder:          `{"username": "app", "password": "..."}`,
+			Description:          "New value of the secret",
+			VisibilityConditions: []configuration.VisibilityCondition{{Field: "valueSource", Values: []string{ValueSourceText}}},
+			RequiredConditions:   []configuration.RequiredCondition{{Field: "valueSource", Values: []string{ValueSourceText}}},
+		},
+		{
+			Name:                 "secretKey",
+			Label:                "SuperPlane Secret",
+			Type:                 configuration.FieldTypeSecretKey,
+			Required:             false,
+			Description:          "Secret and key holding the new value",
+			VisibilityConditions: []configuration.VisibilityCondition{{Field: "valueSource", Values: []string{ValueSourceSecret}}},
+			RequiredConditions:   []configuration.RequiredCondition{{Field: "valueSource", Values: []string{ValueSourceSecret}}},
+		},
+		{
+			Name:     "versionStage",
+			Label:    "Version Stage",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  VersionStageCurrent,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Current", Value: VersionStageCurrent},
+						{Label: "Pending", Value: VersionStagePending},
+					},
+				},
+			},
+		},
+		{
+			Name:        "failIfRotating",
+			Label:       "Fail if rotating",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Fail instead of storing the value while a rotation is in progress",
+		},
+	}
+}
+
+func decodePutSecretValueConfiguration(raw any) (PutSecretValueConfiguration, error) {
+	config := PutSecretValueConfiguration{}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return PutSecretValueConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	region, secret, err := requireRegionAndSecret(config.Region, config.Secret)
+	if err != nil {
+		return PutSecretValueConfiguration{}, err
+	}
+
+	config.Region = region
+	config.Secret = secret
+	config.VersionStage = strings.TrimSpace(config.VersionStage)
+	if config.VersionStage == "" {
+		config.VersionStage = VersionStageCurrent
+	}
+
+	if !slices.Contains([]string{VersionStageCurrent, VersionStagePending}, config.VersionStage) {
+		return PutSecretValueConfiguration{}, fmt.Errorf("invalid version stage: %s", config.VersionStage)
+	}
+
+	switch config.ValueSource {
+	case "", ValueSourceText:
+		config.ValueSource = ValueSourceText
+		if config.SecretString == "" {
+			return PutSecretValueConfiguration{}, fmt.Errorf("value is required")
+		}
+
+	case ValueSourceSecret:
+		if !config.SecretKey.IsSet() {
+			return PutSecretValueConfiguration{}, fmt.Errorf("SuperPlane secret and key are required")
+		}
+
+	default:
+		return PutSecretValueConfiguration{}, fmt.Errorf("invalid value source: %s", config.ValueSource)
+	}
+
+	return config, nil
+}
+
+func (c *PutSecretValue) Setup(ctx core.SetupContext) error {
+	_, err := decodePutSecretValueConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *PutSecretValue) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *PutSecretValue) Execute(ctx core.ExecutionContext) error {
+	config, err := decodePutSecretValueConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	value := config.SecretString
+	if config.ValueSource == ValueSourceSecret {
+		secretValue, err := ctx.Secrets.GetKey(config.SecretKey.Secret, config.SecretKey.Key)
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret %s/%s: %w", config.SecretKey.Secret, config.SecretKey.Key, err)
+		}
+
+		value = string(secretValue)
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, config.Region)
+	if err != nil {
+		return err
+	}
+
+	if config.ShouldFailIfRotating() {
+		secret, err := client.DescribeSecret(config.Secret)
+		if err != nil {
+			return fmt.Errorf("failed to describe secret: %w", err)
+		}
+
+		if versionID := pendingRotationVersion(secret); versionID != "" {
+			return fmt.Errorf("secret %s has a rotation in progress: version %s is %s", secret.Name, versionID, VersionStagePending)
+		}
+	}
+
+	output, err := client.PutSecretValue(PutSecretValueInput{
+		SecretID:           config.Secret,
+		SecretString:       value,
+		ClientRequestToken: ctx.ID.String(),
+		VersionStages:      []string{config.VersionStage},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to put secret value: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, putSecretValuePayloadType, []any{
+		map[string]any{
+			"arn":           output.ARN,
+			"name":          output.Name,
+			"versionId":     output.VersionID,
+			"versionStages": output.VersionStages,
+		},
+	})
+}
+
+func (c *PutSecretValue) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *PutSecretValue) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *PutSecretValue) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *PutSecretValue) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *PutSecretValue) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,185 @@
+package secretsmanager
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testDescribeSecretResponse(rotationEnabled bool, versions string) *http.Response {
+	rotation := "false"
+	if rotationEnabled {
+		rotation = "true"
+	}
+
+	return testResponse(http.StatusOK, `{
+		"ARN": "`+testSecretArn+`",
+		"Name": "production/database",
+		"RotationEnabled": `+rotation+`,
+		"VersionIdsToStages": `+versions+`
+	}`)
+}
+
+func testPutSecretValueResponse(stage string) *http.Response {
+	return testResponse(http.StatusOK, `{
+		"ARN": "`+testSecretArn+`",
+		"Name": "production/database",
+		"VersionId": "v3",
+		"VersionStages": ["`+stage+`"]
+	}`)
+}
+
+func Test__PutSecretValue__Setup(t *testing.T) {
+	component := &PutSecretValue{}
+
+	t.Run("missing value -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"region": "us-east-1", "secret": testSecretArn, "valueSource": "text"},
+		})
+
+		require.ErrorContains(t, err, "value is required")
+	})
+
+	t.Run("missing SuperPlane secret -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":      "us-east-1",
+				"secret":      testSecretArn,
+				"valueSource": "secret",
+				"secretKey":   map[string]any{"secret": "database"},
+			},
+		})
+
+		require.ErrorContains(t, err, "SuperPlane secret and key are required")
+	})
+
+	t.Run("previous version stage -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"region":       "us-east-1",
+				"secret":       testSecretArn,
+				"secretString": "value",
+				"versionStage": "AWSPREVIOUS",
+			},
+		})
+
+		require.ErrorContains(t, err, "invalid version stage: AWSPREVIOUS")
+	})
+}
+
+func Test__PutSecretValue__Execute(t *testing.T) {
+	component := &PutSecretValue{}
+	executionID := uuid.New()
+
+	t.Run("text value -> stores current version", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				testDescribeSecretResponse(true, `{"v2": ["AWSCURRENT", "AWSPENDING"], "v1": ["AWSPREVIOUS"]}`),
+				testPutSecretValueResponse("AWSCURRENT"),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			ID:             executionID,
+			Configuration:  map[string]any{"region": "us-east-1", "secret": testSecretArn, "secretString": `{"password":"new"}`},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "secretsmanager.DescribeSecret", httpContext.Requests[0].Header.Get("X-Amz-Target"))
+		assert.Equal(t, "secretsmanager.PutSecretValue", httpContext.Requests[1].Header.Get("X-Amz-Target"))
+		assert.Equal(t, map[string]any{
+			"SecretId":           testSecretArn,
+			"SecretString":       `{"password":"new"}`,
+			"ClientRequestToken": executionID.String(),
+			"VersionStages":      []any{"AWSCURRENT"},
+		}, testRequestBody(t, httpContext.Requests[1]))
+
+		require.Len(t, execState.Payloads, 1)
+		assert.Equal(t, putSecretValuePayloadType, execState.Type)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "v3", data["versionId"])
+		assert.NotContains(t, data, "secretString")
+	})
+
+	t.Run("SuperPlane secret -> stores pending version", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				testDescribeSecretResponse(false, `{"v2": ["AWSCURRENT"]}`),
+				testPutSecretValueResponse("AWSPENDING"),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			ID: executionID,
+			Configuration: map[string]any{
+				"region":       "us-east-1",
+				"secret":       testSecretArn,
+				"valueSource":  "secret",
+				"secretKey":    map[string]any{"secret": "database", "key": "password"},
+				"versionStage": "AWSPENDING",
+			},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Secrets:        &contexts.SecretsContext{Values: map[string][]byte{"database/password": []byte("from-superplane")}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		body := testRequestBody(t, httpContext.Requests[1])
+		assert.Equal(t, "from-superplane", body["SecretString"])
+		assert.Equal(t, []any{"AWSPENDING"}, body["VersionStages"])
+	})
+
+	t.Run("rotation in progress -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				testDescribeSecretResponse(true, `{"v2": ["AWSCURRENT"], "v3": ["AWSPENDING"]}`),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             executionID,
+			Configuration:  map[string]any{"region": "us-east-1", "secret": testSecretArn, "secretString": "new"},
+			HTTP:           httpContext,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.ErrorContains(t, err, "secret production/database has a rotation in progress: version v3 is AWSPENDING")
+		assert.Len(t, httpContext.Requests, 1)
+	})
+
+	t.Run("rotation check disabled -> skips describe", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{testPutSecretValueResponse("AWSCURRENT")},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID: executionID,
+			Configuration: map[string]any{
+				"region":         "us-east-1",
+				"secret":         testSecretArn,
+				"secretString":   "new",
+				"failIfRotating": false,
+			},
+			HTTP:           httpContext,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			Integration:    testIntegrationWithCredentials(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "secretsmanager.PutSecretValue", httpContext.Requests[0].Header.Get("X-Amz-Target"))
+	})
+}
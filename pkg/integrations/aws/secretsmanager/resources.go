@@ -0,0 +1,36 @@
+package secretsmanager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func ListSecrets(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
+	region := strings.TrimSpace(ctx.Parameters["region"])
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+
+	client, err := newClient(ctx.HTTP, ctx.Integration, region)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := client.ListSecrets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(secrets))
+	for _, secret := range secrets {
+		resources = append(resources, core.IntegrationResource{
+			Type: resourceType,
+			Name: secret.Name,
+			ID:   secret.ARN,
+		})
+	}
+
+	return resources, nil
+}
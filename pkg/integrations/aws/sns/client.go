@@ -39,7 +39,7 @@ type Client struct {
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	normalizedRegion := strings.TrimSpace(region)
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      normalizedRegion,
 		endpoint:    fmt.Sprintf("https://sns.%s.amazonaws.com/", normalizedRegion),
 		credentials: credentials,
@@ -211,20 +211,18 @@ func (c *Client) Unsubscribe(subscriptionArn string) error {
 
 // ListTopics returns all topics in the configured region.
 func (c *Client) ListTopics() ([]Topic, error) {
-	topics := []Topic{}
-	nextToken := ""
-
-	for {
+	return common.CollectPages(func(token string) ([]Topic, string, error) {
 		params := map[string]string{}
-		if nextToken != "" {
-			params["NextToken"] = nextToken
+		if token != "" {
+			params["NextToken"] = token
 		}
 
 		var response listTopicsResponse
 		if err := c.postForm("ListTopics", params, &response); err != nil {
-			return nil, fmt.Errorf("failed to list topics in region %q: %w", c.region, err)
+			return nil, "", fmt.Errorf("failed to list topics in region %q: %w", c.region, err)
 		}
 
+		topics := make([]Topic, 0, len(response.Topics))
 		for _, item := range response.Topics {
 			topicArn := strings.TrimSpace(item.TopicArn)
 			if topicArn == "" {
@@ -237,34 +235,26 @@ func (c *Client) ListTopics() ([]Topic, error) {
 			})
 		}
 
-		nextToken = strings.TrimSpace(response.NextToken)
-		if nextToken == "" {
-			return topics, nil
-		}
-	}
+		return topics, response.NextToken, nil
+	})
 }
 
 func (c *Client) ListSubscriptionsByTopic(topicArn string) ([]Subscription, error) {
-	subscriptions := []Subscription{}
-	nextToken := ""
-	baseParams := map[string]string{
-		"TopicArn": topicArn,
-	}
-
-	for {
-		params := map[string]string{}
-		for key, value := range baseParams {
-			params[key] = value
+	return common.CollectPages(func(token string) ([]Subscription, string, error) {
+		params := map[string]string{
+			"TopicArn": topicArn,
 		}
-		if nextToken != "" {
-			params["NextToken"] = nextToken
+
+		if token != "" {
+			params["NextToken"] = token
 		}
 
 		var response listSubscriptionsResponse
 		if err := c.postForm("ListSubscriptionsByTopic", params, &response); err != nil {
-			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+			return nil, "", fmt.Errorf("failed to list subscriptions: %w", err)
 		}
 
+		subscriptions := make([]Subscription, 0, len(response.SubscriptionsTopic))
 		for _, item := range response.SubscriptionsTopic {
 			subscriptions = append(subscriptions, Subscription{
 				SubscriptionArn: item.SubscriptionArn,
@@ -272,10 +262,8 @@ func (c *Client) ListSubscriptionsByTopic(topicArn string) ([]Subscription, erro
 			})
 		}
 
-		if response.NextToken == "" {
-			return subscriptions, nil
-		}
-	}
+		return subscriptions, response.NextToken, nil
+	})
 }
 
 // postForm sends a signed SNS query request and decodes XML responses.
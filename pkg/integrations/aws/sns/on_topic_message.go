@@ -72,6 +72,7 @@ func (t *OnTopicMessage) Configuration() []configuration.Field {
 	return []configuration.Field{
 		regionField(),
 		topicField(),
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -271,7 +272,7 @@ func (t *OnTopicMessage) emitTopicNotification(ctx core.WebhookRequestContext, m
 		return http.StatusOK, nil, nil
 	}
 
-	if err := ctx.Events.Emit("aws.sns.topic.message", message); err != nil {
+	if _, err := core.EmitDeduplicated(ctx.Events, message.MessageID, "aws.sns.topic.message", message); err != nil {
 		return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit topic message event: %w", err)
 	}
 
@@ -10,12 +10,14 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
 )
 
 type Client struct {
@@ -27,7 +29,7 @@ type Client struct {
 
 func NewClient(httpCtx core.HTTPContext, credentials *aws.Credentials, region string) *Client {
 	return &Client{
-		http:        httpCtx,
+		http:        common.NewHTTPClient(httpCtx),
 		region:      strings.TrimSpace(region),
 		credentials: credentials,
 		signer:      v4.NewSigner(),
@@ -46,6 +48,7 @@ type listQueuesResponse struct {
 
 type listQueuesResult struct {
 	QueueURLs []string `xml:"QueueUrl"`
+	NextToken string   `xml:"NextToken"`
 }
 
 type getQueueAttributesResponse struct {
@@ -85,6 +88,15 @@ func (c *Client) endpoint() string {
 }
 
 func (c *Client) ListQueues(prefix string) ([]Queue, error) {
+	queues, _, err := c.ListQueuesPage(prefix, 0, "")
+	return queues, err
+}
+
+/*
+ * ListQueuesPage lists a single page of queues whose name starts with prefix.
+ * Without maxResults, SQS returns up to 1000 queues and no next token.
+ */
+func (c *Client) ListQueuesPage(prefix string, maxResults int, nextToken string) ([]Queue, string, error) {
 	params := url.Values{}
 	params.Set("Action", "ListQueues")
 	params.Set("Version", "2012-11-05")
@@ -92,14 +104,22 @@ func (c *Client) ListQueues(prefix string) ([]Queue, error) {
 		params.Set("QueueNamePrefix", strings.TrimSpace(prefix))
 	}
 
+	if maxResults > 0 {
+		params.Set("MaxResults", strconv.Itoa(maxResults))
+	}
+
+	if nextToken != "" {
+		params.Set("NextToken", nextToken)
+	}
+
 	body, err := c.postForm(c.endpoint(), params)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var resp listQueuesResponse
 	if err := xml.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to decode ListQueues response: %w", err)
+		return nil, "", fmt.Errorf("failed to decode ListQueues response: %w", err)
 	}
 
 	queues := make([]Queue, 0, len(resp.Result.QueueURLs))
@@ -111,7 +131,7 @@ func (c *Client) ListQueues(prefix string) ([]Queue, error) {
 		})
 	}
 
-	return queues, nil
+	return queues, strings.TrimSpace(resp.Result.NextToken), nil
 }
 
 func (c *Client) GetQueueAttributes(queueURL string) (map[string]string, error) {
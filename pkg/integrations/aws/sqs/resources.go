@@ -8,6 +8,19 @@ import (
 )
 
 func ListQueues(ctx core.ListResourcesContext, resourceType string) ([]core.IntegrationResource, error) {
+	page, err := ListQueuePage(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Resources, nil
+}
+
+/*
+ * ListQueuePage paginates queues through ListQueues.
+ * SQS only filters by name prefix, so the query is used as a prefix.
+ */
+func ListQueuePage(ctx core.ListResourcesContext, resourceType string) (*core.ResourcePage, error) {
 	credentials, err := common.CredentialsFromInstallation(ctx.Integration)
 	if err != nil {
 		return nil, err
@@ -19,7 +32,7 @@ func ListQueues(ctx core.ListResourcesContext, resourceType string) ([]core.Inte
 	}
 
 	client := NewClient(ctx.HTTP, credentials, region)
-	queues, err := client.ListQueues("")
+	queues, nextToken, err := client.ListQueuesPage(ctx.Query, min(ctx.PageSize, 1000), ctx.PageToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list SQS queues: %w", err)
 	}
@@ -33,5 +46,5 @@ func ListQueues(ctx core.ListResourcesContext, resourceType string) ([]core.Inte
 		})
 	}
 
-	return resources, nil
+	return &core.ResourcePage{Resources: resources, NextPageToken: nextToken}, nil
 }
@@ -0,0 +1,77 @@
+package sqs
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ListQueuePage(t *testing.T) {
+	integration := &contexts.IntegrationContext{
+		Secrets: map[string]core.IntegrationSecret{
+			"accessKeyId":     {Name: "accessKeyId", Value: []byte("key")},
+			"secretAccessKey": {Name: "secretAccessKey", Value: []byte("secret")},
+			"sessionToken":    {Name: "sessionToken", Value: []byte("token")},
+		},
+	}
+
+	t.Run("query and page are sent to SQS", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`
+						<ListQueuesResponse>
+							<ListQueuesResult>
+								<QueueUrl>https://sqs.us-east-1.amazonaws.com/123456789012/orders-1</QueueUrl>
+								<QueueUrl>https://sqs.us-east-1.amazonaws.com/123456789012/orders-2</QueueUrl>
+								<NextToken>next-token</NextToken>
+							</ListQueuesResult>
+						</ListQueuesResponse>
+					`)),
+				},
+			},
+		}
+
+		page, err := ListQueuePage(core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: integration,
+			Parameters:  map[string]string{"region": "us-east-1"},
+			Query:       "orders",
+			PageToken:   "token-1",
+			PageSize:    2,
+		}, "sqs.queue")
+
+		require.NoError(t, err)
+		require.Len(t, page.Resources, 2)
+		assert.Equal(t, "orders-1", page.Resources[0].Name)
+		assert.Equal(t, "https://sqs.us-east-1.amazonaws.com/123456789012/orders-1", page.Resources[0].ID)
+		assert.Equal(t, "next-token", page.NextPageToken)
+
+		require.Len(t, httpContext.Requests, 1)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		values, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+		assert.Equal(t, "orders", values.Get("QueueNamePrefix"))
+		assert.Equal(t, "2", values.Get("MaxResults"))
+		assert.Equal(t, "token-1", values.Get("NextToken"))
+	})
+
+	t.Run("missing region -> error", func(t *testing.T) {
+		_, err := ListQueuePage(core.ListResourcesContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: integration,
+			Parameters:  map[string]string{},
+		}, "sqs.queue")
+
+		require.ErrorContains(t, err, "region is required")
+	})
+}
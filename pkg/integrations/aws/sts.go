@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -10,7 +13,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/aws/common"
 )
 
 type stsCredentials struct {
@@ -56,7 +62,7 @@ func assumeRoleWithWebIdentity(httpCtx core.HTTPContext, region string, roleArn
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/xml")
 
-	res, err := httpCtx.Do(req)
+	res, err := common.NewHTTPClient(httpCtx).Do(req)
 	if err != nil {
 		return stsCredentials{}, fmt.Errorf("error executing STS request: %w", err)
 	}
@@ -95,6 +101,63 @@ func assumeRoleWithWebIdentity(httpCtx core.HTTPContext, region string, roleArn
 	return credentials, nil
 }
 
+type getCallerIdentityResponse struct {
+	Arn     string `xml:"GetCallerIdentityResult>Arn"`
+	Account string `xml:"GetCallerIdentityResult>Account"`
+}
+
+/*
+ * Returns the ARN of the identity the credentials belong to.
+ * It needs no permissions, so it only fails if the credentials are not valid.
+ */
+func getCallerIdentity(ctx context.Context, httpCtx core.HTTPContext, region string, credentials *aws.Credentials) (string, error) {
+	values := url.Values{}
+	values.Set("Action", "GetCallerIdentity")
+	values.Set("Version", "2011-06-15")
+
+	body := values.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, stsEndpoint(region), strings.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("error building STS request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	req.Header.Set("Accept", "application/xml")
+
+	signingRegion := strings.TrimSpace(region)
+	if signingRegion == "" {
+		signingRegion = "us-east-1"
+	}
+
+	hash := sha256.Sum256([]byte(body))
+	err = v4.NewSigner().SignHTTP(ctx, *credentials, req, hex.EncodeToString(hash[:]), "sts", signingRegion, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("error signing STS request: %w", err)
+	}
+
+	res, err := common.NewHTTPClient(httpCtx).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error executing STS request: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading STS response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("STS request failed with %d: %s", res.StatusCode, string(responseBody))
+	}
+
+	var response getCallerIdentityResponse
+	if err := xml.Unmarshal(responseBody, &response); err != nil {
+		return "", fmt.Errorf("error parsing STS response: %w", err)
+	}
+
+	return response.Arn, nil
+}
+
 func stsEndpoint(region string) string {
 	region = strings.TrimSpace(region)
 	if region == "" {
@@ -0,0 +1,112 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	defaultAuthorityHost = "https://login.microsoftonline.com"
+	managementScope      = "https://management.azure.com/.default"
+
+	// Audience recommended by Microsoft Entra for workload identity federation.
+	federatedTokenAudience = "api://AzureADTokenExchange"
+	clientAssertionType    = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+)
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// AccessToken is a Microsoft Entra access token for Azure Resource Manager.
+type AccessToken struct {
+	Token     string
+	ExpiresIn time.Duration
+}
+
+// requestAccessToken uses the OAuth 2.0 client credentials flow to get an
+// access token for Azure Resource Manager. The client authenticates either
+// with a client secret, or with a client assertion signed by SuperPlane,
+// for workload identity federation.
+func requestAccessToken(httpCtx core.HTTPContext, tenantID, clientID string, credential url.Values) (*AccessToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", clientID)
+	form.Set("scope", managementScope)
+	for key, values := range credential {
+		for _, value := range values {
+			form.Add(key, value)
+		}
+	}
+
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", defaultAuthorityHost, url.PathEscape(tenantID))
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpCtx.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		errResp := tokenErrorResponse{}
+		message := string(body)
+		if json.Unmarshal(body, &errResp) == nil && errResp.ErrorDescription != "" {
+			message = errResp.ErrorDescription
+		}
+
+		return nil, fmt.Errorf("token request failed (%d): %s", res.StatusCode, message)
+	}
+
+	token := tokenResponse{}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response missing access_token")
+	}
+
+	expiresIn := time.Duration(token.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = time.Hour
+	}
+
+	return &AccessToken{Token: token.AccessToken, ExpiresIn: expiresIn}, nil
+}
+
+func clientSecretCredential(secret string) url.Values {
+	return url.Values{"client_secret": []string{secret}}
+}
+
+func clientAssertionCredential(assertion string) url.Values {
+	return url.Values{
+		"client_assertion_type": []string{clientAssertionType},
+		"client_assertion":      []string{assertion},
+	}
+}
@@ -0,0 +1,431 @@
+package azure
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	ConnectionMethodServicePrincipal = "servicePrincipal"
+	ConnectionMethodWorkloadIdentity = "workloadIdentity"
+
+	ResourceTypeResourceGroup  = "resourceGroup"
+	ResourceTypeLocation       = "location"
+	ResourceTypeVMSize         = "vmSize"
+	ResourceTypeVirtualMachine = "virtualMachine"
+	ResourceTypeVirtualNetwork = "virtualNetwork"
+	ResourceTypeSubnet         = "subnet"
+)
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("azure", &Azure{}, &AzureWebhookHandler{})
+}
+
+type Azure struct{}
+
+type Configuration struct {
+	ConnectionMethod string `json:"connectionMethod" mapstructure:"connectionMethod"`
+	TenantID         string `json:"tenantId" mapstructure:"tenantId"`
+	ClientID         string `json:"clientId" mapstructure:"clientId"`
+	ClientSecret     string `json:"clientSecret" mapstructure:"clientSecret"`
+	SubscriptionID   string `json:"subscriptionId" mapstructure:"subscriptionId"`
+}
+
+type Metadata struct {
+	SubscriptionID       string `json:"subscriptionId" mapstructure:"subscriptionId"`
+	SubscriptionName     string `json:"subscriptionName" mapstructure:"subscriptionName"`
+	TenantID             string `json:"tenantId" mapstructure:"tenantId"`
+	AuthMethod           string `json:"authMethod" mapstructure:"authMethod"`
+	AccessTokenExpiresAt string `json:"accessTokenExpiresAt" mapstructure:"accessTokenExpiresAt"`
+}
+
+func (a *Azure) Name() string {
+	return "azure"
+}
+
+func (a *Azure) Label() string {
+	return "Microsoft Azure"
+}
+
+func (a *Azure) Icon() string {
+	return "azure"
+}
+
+func (a *Azure) Description() string {
+	return "Manage Azure virtual machines and react to resource changes in your workflows"
+}
+
+func (a *Azure) Instructions() string {
+	return `## Connection method
+
+### Service principal
+
+1. Go to **Microsoft Entra ID → App registrations** and create a new registration.
+2. In **Certificates & secrets**, create a new client secret.
+3. Enter the **Directory (tenant) ID**, **Application (client) ID** and the client secret below.
+
+### Workload identity federation (keyless)
+
+1. Go to **Microsoft Entra ID → App registrations** and create a new registration.
+2. In **Certificates & secrets → Federated credentials**, add a credential for **Other issuer**:
+   - **Issuer**: this SuperPlane instance's URL
+   - **Subject identifier**: ` + "`app-installation:<integration ID>`" + `, shown in the error message of the first connection attempt
+   - **Audience**: ` + "`api://AzureADTokenExchange`" + `
+3. Enter the **Directory (tenant) ID** and **Application (client) ID** below.
+
+## Permissions
+
+Grant the application a role on the subscription, in **Subscriptions → Access control (IAM)**:
+
+- **Reader**, to list resource groups, locations and virtual machines
+- **Virtual Machine Contributor** and **Network Contributor**, to create virtual machines
+- **EventGrid Contributor**, for the Activity Log events trigger`
+}
+
+func (a *Azure) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "connectionMethod",
+			Label:       "Connection method",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Description: "Authenticate with a client secret or with workload identity federation (keyless)",
+			Default:     ConnectionMethodServicePrincipal,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Service Principal", Value: ConnectionMethodServicePrincipal},
+						{Label: "Workload Identity Federation", Value: ConnectionMethodWorkloadIdentity},
+					},
+				},
+			},
+		},
+		{
+			Name:        "tenantId",
+			Label:       "Directory (tenant) ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			Name:        "clientId",
+			Label:       "Application (client) ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			Name:      "clientSecret",
+			Label:     "Client Secret",
+			Type:      configuration.FieldTypeString,
+			Required:  true,
+			Sensitive: true,
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "connectionMethod", Values: []string{ConnectionMethodServicePrincipal}},
+			},
+		},
+		{
+			Name:        "subscriptionId",
+			Label:       "Subscription ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The subscription where resources are managed",
+			Placeholder: "00000000-0000-0000-0000-000000000000",
+		},
+	}
+}
+
+func (a *Azure) Components() []core.Component {
+	return []core.Component{
+		&CreateVM{},
+	}
+}
+
+func (a *Azure) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnActivityLogEvent{},
+	}
+}
+
+func (a *Azure) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (a *Azure) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.TenantID = strings.TrimSpace(config.TenantID)
+	config.ClientID = strings.TrimSpace(config.ClientID)
+	config.SubscriptionID = strings.TrimSpace(config.SubscriptionID)
+
+	if config.TenantID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+
+	if config.ClientID == "" {
+		return fmt.Errorf("client ID is required")
+	}
+
+	if config.SubscriptionID == "" {
+		return fmt.Errorf("subscription ID is required")
+	}
+
+	token, err := a.requestToken(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Integration.SetSecret(SecretNameAccessToken, []byte(token.Token)); err != nil {
+		return fmt.Errorf("failed to store access token: %w", err)
+	}
+
+	metadata := Metadata{
+		SubscriptionID:       config.SubscriptionID,
+		TenantID:             config.TenantID,
+		AuthMethod:           config.ConnectionMethod,
+		AccessTokenExpiresAt: time.Now().Add(token.ExpiresIn).Format(time.RFC3339),
+	}
+
+	ctx.Integration.SetMetadata(metadata)
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := client.GetSubscription()
+	if err != nil {
+		return fmt.Errorf("failed to access subscription %s. Ensure the application has the Reader role on it: %w", config.SubscriptionID, err)
+	}
+
+	metadata.SubscriptionName = subscription.DisplayName
+	ctx.Integration.SetMetadata(metadata)
+
+	//
+	// Access tokens are short-lived, so we refresh them
+	// well before they expire.
+	//
+	refreshAfter := token.ExpiresIn / 2
+	if refreshAfter < time.Minute {
+		refreshAfter = time.Minute
+	}
+
+	if err := ctx.Integration.ScheduleResync(refreshAfter); err != nil {
+		return fmt.Errorf("failed to schedule access token refresh: %w", err)
+	}
+
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (a *Azure) requestToken(ctx core.SyncContext, config Configuration) (*AccessToken, error) {
+	switch config.ConnectionMethod {
+	case ConnectionMethodServicePrincipal:
+		secret, err := ctx.Integration.GetConfig("clientSecret")
+		if err != nil || len(strings.TrimSpace(string(secret))) == 0 {
+			return nil, fmt.Errorf("client secret is required")
+		}
+
+		token, err := requestAccessToken(ctx.HTTP, config.TenantID, config.ClientID, clientSecretCredential(strings.TrimSpace(string(secret))))
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate with client secret: %w", err)
+		}
+
+		return token, nil
+
+	case ConnectionMethodWorkloadIdentity:
+		subject := fmt.Sprintf("app-installation:%s", ctx.Integration.ID())
+		assertion, err := ctx.OIDC.Sign(subject, 5*time.Minute, federatedTokenAudience, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate OIDC token: %w", err)
+		}
+
+		token, err := requestAccessToken(ctx.HTTP, config.TenantID, config.ClientID, clientAssertionCredential(assertion))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"workload identity federation failed. Ensure the application has a federated credential with issuer %s, subject %s and audience %s: %w",
+				ctx.BaseURL, subject, federatedTokenAudience, err,
+			)
+		}
+
+		return token, nil
+
+	default:
+		return nil, fmt.Errorf("unknown connection method: %s", config.ConnectionMethod)
+	}
+}
+
+func (a *Azure) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (a *Azure) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resourceType {
+	case ResourceTypeResourceGroup:
+		groups, err := client.ListResourceGroups()
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(groups))
+		for _, group := range groups {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: group.Name, ID: group.Name})
+		}
+
+		return resources, nil
+
+	case ResourceTypeLocation:
+		locations, err := client.ListLocations()
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(locations))
+		for _, location := range locations {
+			// Logical regions, like "europe", can't host resources.
+			if location.Metadata.RegionType == "Logical" {
+				continue
+			}
+
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: location.DisplayName, ID: location.Name})
+		}
+
+		return resources, nil
+
+	case ResourceTypeVMSize:
+		location := ctx.Parameters["location"]
+		if !isResolvedValue(location) {
+			return []core.IntegrationResource{}, nil
+		}
+
+		sizes, err := client.ListVirtualMachineSizes(location)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(sizes))
+		for _, size := range sizes {
+			resources = append(resources, core.IntegrationResource{
+				Type: resourceType,
+				Name: fmt.Sprintf("%s (%d vCPUs, %s memory)", size.Name, size.NumberOfCores, formatMemory(size.MemoryInMB)),
+				ID:   size.Name,
+			})
+		}
+
+		return resources, nil
+
+	case ResourceTypeVirtualMachine:
+		resourceGroup := ctx.Parameters["resourceGroup"]
+		if strings.Contains(resourceGroup, "{{") {
+			resourceGroup = ""
+		}
+
+		machines, err := client.ListVirtualMachines(resourceGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(machines))
+		for _, machine := range machines {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: machine.Name, ID: machine.ID})
+		}
+
+		return resources, nil
+
+	case ResourceTypeVirtualNetwork:
+		resourceGroup := ctx.Parameters["resourceGroup"]
+		if !isResolvedValue(resourceGroup) {
+			return []core.IntegrationResource{}, nil
+		}
+
+		networks, err := client.ListVirtualNetworks(resourceGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(networks))
+		for _, network := range networks {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: network.Name, ID: network.Name})
+		}
+
+		return resources, nil
+
+	case ResourceTypeSubnet:
+		resourceGroup := ctx.Parameters["resourceGroup"]
+		virtualNetwork := ctx.Parameters["virtualNetwork"]
+		if !isResolvedValue(resourceGroup) || !isResolvedValue(virtualNetwork) {
+			return []core.IntegrationResource{}, nil
+		}
+
+		subnets, err := client.ListSubnets(resourceGroup, virtualNetwork)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(subnets))
+		for _, subnet := range subnets {
+			resources = append(resources, core.IntegrationResource{
+				Type: resourceType,
+				Name: fmt.Sprintf("%s (%s)", subnet.Name, subnet.Properties.AddressPrefix),
+				ID:   subnet.Name,
+			})
+		}
+
+		return resources, nil
+
+	default:
+		return []core.IntegrationResource{}, nil
+	}
+}
+
+func (a *Azure) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (a *Azure) HandleAction(ctx core.IntegrationActionContext) error {
+	return nil
+}
+
+func isResolvedValue(value string) bool {
+	return value != "" && !strings.Contains(value, "{{")
+}
+
+func formatMemory(memoryInMB int) string {
+	if memoryInMB%1024 == 0 {
+		return fmt.Sprintf("%d GiB", memoryInMB/1024)
+	}
+
+	return fmt.Sprintf("%.1f GiB", float64(memoryInMB)/1024)
+}
+
+// resourceGroupFromID returns the resource group of an Azure resource ID.
+func resourceGroupFromID(resourceID string) string {
+	parts := strings.Split(strings.Trim(resourceID, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], "resourceGroups") {
+			name, err := url.PathUnescape(parts[i+1])
+			if err != nil {
+				return parts[i+1]
+			}
+
+			return name
+		}
+	}
+
+	return ""
+}
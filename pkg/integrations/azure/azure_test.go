@@ -0,0 +1,215 @@
+package azure
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testSubscriptionID = "8f3c2a1e-6d4b-4c9a-9e7f-1a2b3c4d5e6f"
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// testIntegrationContext returns an integration that already went through Sync.
+func testIntegrationContext() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Metadata: Metadata{SubscriptionID: testSubscriptionID},
+		Secrets: map[string]core.IntegrationSecret{
+			SecretNameAccessToken: {Name: SecretNameAccessToken, Value: []byte("access-token")},
+		},
+	}
+}
+
+func readForm(t *testing.T, req *http.Request) url.Values {
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	form, err := url.ParseQuery(string(body))
+	require.NoError(t, err)
+	return form
+}
+
+func Test__Azure__Sync(t *testing.T) {
+	integration := &Azure{}
+
+	t.Run("service principal -> ready", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`),
+				jsonResponse(http.StatusOK, `{"subscriptionId":"`+testSubscriptionID+`","displayName":"Production","state":"Enabled"}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"connectionMethod": ConnectionMethodServicePrincipal,
+				"tenantId":         "tenant-1",
+				"clientId":         "client-1",
+				"clientSecret":     "secret-1",
+				"subscriptionId":   testSubscriptionID,
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, []byte("access-token"), integrationCtx.Secrets[SecretNameAccessToken].Value)
+
+		metadata := integrationCtx.Metadata.(Metadata)
+		assert.Equal(t, "Production", metadata.SubscriptionName)
+		assert.Equal(t, ConnectionMethodServicePrincipal, metadata.AuthMethod)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://login.microsoftonline.com/tenant-1/oauth2/v2.0/token", httpCtx.Requests[0].URL.String())
+		form := readForm(t, httpCtx.Requests[0])
+		assert.Equal(t, "client_credentials", form.Get("grant_type"))
+		assert.Equal(t, "client-1", form.Get("client_id"))
+		assert.Equal(t, "secret-1", form.Get("client_secret"))
+		assert.Equal(t, managementScope, form.Get("scope"))
+
+		assert.Equal(t, "/subscriptions/"+testSubscriptionID, httpCtx.Requests[1].URL.Path)
+		assert.Equal(t, "Bearer access-token", httpCtx.Requests[1].Header.Get("Authorization"))
+
+		require.Len(t, integrationCtx.ResyncRequests, 1)
+		assert.Equal(t, 30, int(integrationCtx.ResyncRequests[0].Minutes()))
+	})
+
+	t.Run("workload identity -> uses client assertion", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"access_token":"access-token","expires_in":3600}`),
+				jsonResponse(http.StatusOK, `{"subscriptionId":"`+testSubscriptionID+`","displayName":"Production"}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"connectionMethod": ConnectionMethodWorkloadIdentity,
+				"tenantId":         "tenant-1",
+				"clientId":         "client-1",
+				"subscriptionId":   testSubscriptionID,
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+			OIDC:          support.NewOIDCProvider(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+
+		form := readForm(t, httpCtx.Requests[0])
+		assert.Equal(t, clientAssertionType, form.Get("client_assertion_type"))
+		assert.Equal(t, "test", form.Get("client_assertion"))
+		assert.Empty(t, form.Get("client_secret"))
+	})
+
+	t.Run("invalid credentials -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusUnauthorized, `{"error":"invalid_client","error_description":"AADSTS7000215: Invalid client secret provided."}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"connectionMethod": ConnectionMethodServicePrincipal,
+				"tenantId":         "tenant-1",
+				"clientId":         "client-1",
+				"clientSecret":     "wrong",
+				"subscriptionId":   testSubscriptionID,
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "Invalid client secret provided")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+}
+
+func Test__Azure__ListResources(t *testing.T) {
+	integration := &Azure{}
+
+	t.Run("locations skip logical regions", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"value":[
+					{"name":"westeurope","displayName":"West Europe","metadata":{"regionType":"Physical"}},
+					{"name":"europe","displayName":"Europe","metadata":{"regionType":"Logical"}}
+				]}`),
+			},
+		}
+
+		resources, err := integration.ListResources(ResourceTypeLocation, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "westeurope", resources[0].ID)
+		assert.Equal(t, "West Europe", resources[0].Name)
+	})
+
+	t.Run("vm sizes follow next link", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"value":[{"name":"Standard_B2s","numberOfCores":2,"memoryInMB":4096}],"nextLink":"https://management.azure.com/subscriptions/`+testSubscriptionID+`/providers/Microsoft.Compute/locations/westeurope/vmSizes?api-version=2024-07-01&page=2"}`),
+				jsonResponse(http.StatusOK, `{"value":[{"name":"Standard_B1ls","numberOfCores":1,"memoryInMB":512}]}`),
+			},
+		}
+
+		resources, err := integration.ListResources(ResourceTypeVMSize, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Parameters:  map[string]string{"location": "westeurope"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "Standard_B2s (2 vCPUs, 4 GiB memory)", resources[0].Name)
+		assert.Equal(t, "Standard_B1ls (1 vCPUs, 0.5 GiB memory)", resources[1].Name)
+		assert.Equal(t, "2", httpCtx.Requests[1].URL.Query().Get("page"))
+	})
+
+	t.Run("subnets require a virtual network", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		resources, err := integration.ListResources(ResourceTypeSubnet, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Parameters:  map[string]string{"resourceGroup": "production"},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Empty(t, httpCtx.Requests)
+	})
+}
@@ -0,0 +1,379 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	managementBaseURL = "https://management.azure.com"
+
+	apiVersionResources   = "2022-09-01"
+	apiVersionLocations   = "2022-12-01"
+	apiVersionCompute     = "2024-07-01"
+	apiVersionNetwork     = "2024-01-01"
+	apiVersionEventGrid   = "2022-06-15"
+	SecretNameAccessToken = "accessToken"
+)
+
+type Client struct {
+	SubscriptionID string
+	BaseURL        string
+	token          string
+	http           core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("request failed with %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Message)
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+func NewClient(httpCtx core.HTTPContext, integration core.IntegrationContext) (*Client, error) {
+	if integration == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	metadata := Metadata{}
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode integration metadata: %w", err)
+	}
+
+	if metadata.SubscriptionID == "" {
+		return nil, fmt.Errorf("integration metadata has no subscription ID")
+	}
+
+	secrets, err := integration.GetSecrets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration secrets: %w", err)
+	}
+
+	token := ""
+	for _, secret := range secrets {
+		if secret.Name == SecretNameAccessToken {
+			token = string(secret.Value)
+			break
+		}
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf("no access token available, sync the integration again")
+	}
+
+	return &Client{
+		SubscriptionID: metadata.SubscriptionID,
+		BaseURL:        managementBaseURL,
+		token:          token,
+		http:           httpCtx,
+	}, nil
+}
+
+type Subscription struct {
+	ID             string `json:"id"`
+	SubscriptionID string `json:"subscriptionId"`
+	TenantID       string `json:"tenantId"`
+	DisplayName    string `json:"displayName"`
+	State          string `json:"state"`
+}
+
+type ResourceGroup struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Location string            `json:"location"`
+	Tags     map[string]string `json:"tags,omitempty"`
+}
+
+type Location struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Metadata    struct {
+		RegionType string `json:"regionType"`
+	} `json:"metadata"`
+}
+
+type VirtualMachineSize struct {
+	Name                 string `json:"name"`
+	NumberOfCores        int    `json:"numberOfCores"`
+	MemoryInMB           int    `json:"memoryInMB"`
+	MaxDataDiskCount     int    `json:"maxDataDiskCount"`
+	OSDiskSizeInMB       int    `json:"osDiskSizeInMB"`
+	ResourceDiskSizeInMB int    `json:"resourceDiskSizeInMB"`
+}
+
+type VirtualNetwork struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Location   string `json:"location"`
+	Properties struct {
+		Subnets []Subnet `json:"subnets"`
+	} `json:"properties"`
+}
+
+type Subnet struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Properties struct {
+		AddressPrefix string `json:"addressPrefix"`
+	} `json:"properties"`
+}
+
+type list[T any] struct {
+	Value    []T    `json:"value"`
+	NextLink string `json:"nextLink"`
+}
+
+func (c *Client) GetSubscription() (*Subscription, error) {
+	subscription := Subscription{}
+	err := c.get(c.subscriptionPath("")+"?api-version="+apiVersionLocations, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (c *Client) ListResourceGroups() ([]ResourceGroup, error) {
+	return listAll[ResourceGroup](c, c.subscriptionPath("/resourcegroups")+"?api-version="+apiVersionResources)
+}
+
+func (c *Client) GetResourceGroup(name string) (*ResourceGroup, error) {
+	group := ResourceGroup{}
+	err := c.get(c.resourceGroupPath(name, "")+"?api-version="+apiVersionResources, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (c *Client) ListLocations() ([]Location, error) {
+	return listAll[Location](c, c.subscriptionPath("/locations")+"?api-version="+apiVersionLocations)
+}
+
+func (c *Client) ListVirtualMachineSizes(location string) ([]VirtualMachineSize, error) {
+	path := c.subscriptionPath(fmt.Sprintf("/providers/Microsoft.Compute/locations/%s/vmSizes", url.PathEscape(location)))
+	return listAll[VirtualMachineSize](c, path+"?api-version="+apiVersionCompute)
+}
+
+func (c *Client) ListVirtualMachines(resourceGroup string) ([]VirtualMachine, error) {
+	path := c.subscriptionPath("/providers/Microsoft.Compute/virtualMachines")
+	if resourceGroup != "" {
+		path = c.resourceGroupPath(resourceGroup, "/providers/Microsoft.Compute/virtualMachines")
+	}
+
+	return listAll[VirtualMachine](c, path+"?api-version="+apiVersionCompute)
+}
+
+func (c *Client) ListVirtualNetworks(resourceGroup string) ([]VirtualNetwork, error) {
+	path := c.resourceGroupPath(resourceGroup, "/providers/Microsoft.Network/virtualNetworks")
+	return listAll[VirtualNetwork](c, path+"?api-version="+apiVersionNetwork)
+}
+
+func (c *Client) ListSubnets(resourceGroup, virtualNetwork string) ([]Subnet, error) {
+	path := c.resourceGroupPath(resourceGroup, fmt.Sprintf("/providers/Microsoft.Network/virtualNetworks/%s/subnets", url.PathEscape(virtualNetwork)))
+	return listAll[Subnet](c, path+"?api-version="+apiVersionNetwork)
+}
+
+// PutResource creates or updates a resource by its ID, and decodes the response into out.
+func (c *Client) PutResource(resourceID, apiVersion string, payload any, out any) error {
+	body, err := c.execRequest(http.MethodPut, resourceID+"?api-version="+apiVersion, payload)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// GetResource reads a resource by its ID, and decodes the response into out.
+func (c *Client) GetResource(resourceID, apiVersion string, out any) error {
+	return c.get(resourceID+"?api-version="+apiVersion, out)
+}
+
+func (c *Client) DeleteResource(resourceID, apiVersion string) error {
+	_, err := c.execRequest(http.MethodDelete, resourceID+"?api-version="+apiVersion, nil)
+	return err
+}
+
+func (c *Client) subscriptionPath(suffix string) string {
+	return fmt.Sprintf("/subscriptions/%s%s", url.PathEscape(c.SubscriptionID), suffix)
+}
+
+func (c *Client) resourceGroupPath(resourceGroup, suffix string) string {
+	return c.subscriptionPath(fmt.Sprintf("/resourceGroups/%s%s", url.PathEscape(resourceGroup), suffix))
+}
+
+func (c *Client) get(path string, out any) error {
+	body, err := c.execRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// listAll follows the nextLink of list responses until all pages are read.
+func listAll[T any](c *Client, path string) ([]T, error) {
+	items := []T{}
+	next := path
+
+	for next != "" {
+		page := list[T]{}
+		if err := c.get(next, &page); err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.Value...)
+		next = strings.TrimPrefix(page.NextLink, c.BaseURL)
+	}
+
+	return items, nil
+}
+
+func (c *Client) execRequest(method, path string, payload any) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, parseAPIError(res.StatusCode, responseBody)
+	}
+
+	return responseBody, nil
+}
+
+func parseAPIError(statusCode int, body []byte) error {
+	response := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+
+	if err := json.Unmarshal(body, &response); err == nil && response.Error.Message != "" {
+		return &APIError{StatusCode: statusCode, Code: response.Error.Code, Message: response.Error.Message}
+	}
+
+	return &APIError{StatusCode: statusCode, Message: string(body)}
+}
+
+type VirtualMachine struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Location   string            `json:"location"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Properties struct {
+		VMID              string `json:"vmId"`
+		ProvisioningState string `json:"provisioningState"`
+		Priority          string `json:"priority"`
+		HardwareProfile   struct {
+			VMSize string `json:"vmSize"`
+		} `json:"hardwareProfile"`
+		NetworkProfile struct {
+			NetworkInterfaces []struct {
+				ID string `json:"id"`
+			} `json:"networkInterfaces"`
+		} `json:"networkProfile"`
+		InstanceView *struct {
+			Statuses []InstanceViewStatus `json:"statuses"`
+		} `json:"instanceView,omitempty"`
+	} `json:"properties"`
+}
+
+type InstanceViewStatus struct {
+	Code          string `json:"code"`
+	Level         string `json:"level"`
+	DisplayStatus string `json:"displayStatus"`
+	Message       string `json:"message"`
+}
+
+type NetworkInterface struct {
+	ID         string `json:"id"`
+	Properties struct {
+		IPConfigurations []struct {
+			Properties struct {
+				PrivateIPAddress string `json:"privateIPAddress"`
+				PublicIPAddress  *struct {
+					ID string `json:"id"`
+				} `json:"publicIPAddress,omitempty"`
+			} `json:"properties"`
+		} `json:"ipConfigurations"`
+	} `json:"properties"`
+}
+
+type PublicIPAddress struct {
+	ID         string `json:"id"`
+	Properties struct {
+		IPAddress string `json:"ipAddress"`
+	} `json:"properties"`
+}
+
+func (c *Client) GetVirtualMachine(resourceID string) (*VirtualMachine, error) {
+	machine := VirtualMachine{}
+	err := c.get(resourceID+"?$expand=instanceView&api-version="+apiVersionCompute, &machine)
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine, nil
+}
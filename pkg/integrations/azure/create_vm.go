@@ -0,0 +1,878 @@
+package azure
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	CreateVMPayloadType   = "azure.vm.created"
+	CreateVMActionPoll    = "poll"
+	CreateVMPollInterval  = 15 * time.Second
+	CreateVMTimeout       = 30 * time.Minute
+	createVMFailureReason = "error"
+
+	ImageCustom = "custom"
+
+	OSTypeLinux   = "Linux"
+	OSTypeWindows = "Windows"
+
+	AuthenticationTypeSSH      = "sshPublicKey"
+	AuthenticationTypePassword = "password"
+
+	PriorityRegular = "Regular"
+	PrioritySpot    = "Spot"
+)
+
+var vmNameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,62}[a-zA-Z0-9])?$`)
+
+// ImagePreset is a marketplace image offered in the image picker.
+type ImagePreset struct {
+	Label     string
+	Publisher string
+	Offer     string
+	SKU       string
+	OSType    string
+}
+
+var imagePresets = map[string]ImagePreset{
+	"ubuntu-24.04": {Label: "Ubuntu 24.04 LTS", Publisher: "Canonical", Offer: "ubuntu-24_04-lts", SKU: "server", OSType: OSTypeLinux},
+	"ubuntu-22.04": {Label: "Ubuntu 22.04 LTS", Publisher: "Canonical", Offer: "0001-com-ubuntu-server-jammy", SKU: "22_04-lts-gen2", OSType: OSTypeLinux},
+	"debian-12":    {Label: "Debian 12", Publisher: "Debian", Offer: "debian-12", SKU: "12-gen2", OSType: OSTypeLinux},
+	"rhel-9":       {Label: "Red Hat Enterprise Linux 9", Publisher: "RedHat", Offer: "RHEL", SKU: "9-lvm-gen2", OSType: OSTypeLinux},
+	"windows-2022": {Label: "Windows Server 2022 Datacenter", Publisher: "MicrosoftWindowsServer", Offer: "WindowsServer", SKU: "2022-datacenter-azure-edition", OSType: OSTypeWindows},
+}
+
+var imagePresetOrder = []string{"ubuntu-24.04", "ubuntu-22.04", "debian-12", "rhel-9", "windows-2022"}
+
+type CreateVM struct{}
+
+type CreateVMConfiguration struct {
+	ResourceGroup      string     `json:"resourceGroup" mapstructure:"resourceGroup"`
+	Location           string     `json:"location" mapstructure:"location"`
+	Name               string     `json:"name" mapstructure:"name"`
+	Size               string     `json:"size" mapstructure:"size"`
+	Image              string     `json:"image" mapstructure:"image"`
+	CustomImageID      string     `json:"customImageId" mapstructure:"customImageId"`
+	CustomImageOSType  string     `json:"customImageOsType" mapstructure:"customImageOsType"`
+	OSDiskType         string     `json:"osDiskType" mapstructure:"osDiskType"`
+	OSDiskSizeGB       int        `json:"osDiskSizeGb" mapstructure:"osDiskSizeGb"`
+	VirtualNetwork     string     `json:"virtualNetwork" mapstructure:"virtualNetwork"`
+	Subnet             string     `json:"subnet" mapstructure:"subnet"`
+	PublicIP           bool       `json:"publicIp" mapstructure:"publicIp"`
+	AdminUsername      string     `json:"adminUsername" mapstructure:"adminUsername"`
+	AuthenticationType string     `json:"authenticationType" mapstructure:"authenticationType"`
+	SSHPublicKey       string     `json:"sshPublicKey" mapstructure:"sshPublicKey"`
+	AdminPassword      string     `json:"adminPassword" mapstructure:"adminPassword"`
+	Spot               bool       `json:"spot" mapstructure:"spot"`
+	EvictionPolicy     string     `json:"evictionPolicy" mapstructure:"evictionPolicy"`
+	MaxPrice           string     `json:"maxPrice" mapstructure:"maxPrice"`
+	CustomData         string     `json:"customData" mapstructure:"customData"`
+	Tags               []TagEntry `json:"tags" mapstructure:"tags"`
+}
+
+type TagEntry struct {
+	Key   string `json:"key" mapstructure:"key"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type CreateVMExecutionMetadata struct {
+	VirtualMachineID   string `json:"virtualMachineId" mapstructure:"virtualMachineId"`
+	NetworkInterfaceID string `json:"networkInterfaceId" mapstructure:"networkInterfaceId"`
+	PublicIPAddressID  string `json:"publicIpAddressId,omitempty" mapstructure:"publicIpAddressId"`
+	Deadline           string `json:"deadline" mapstructure:"deadline"`
+}
+
+func (c *CreateVM) Name() string {
+	return "azure.createVM"
+}
+
+func (c *CreateVM) Label() string {
+	return "Compute • Create Virtual Machine"
+}
+
+func (c *CreateVM) Description() string {
+	return "Create an Azure virtual machine. Configure size, image, network and Spot pricing."
+}
+
+func (c *CreateVM) Documentation() string {
+	return `Creates a new Azure virtual machine, and waits until it is provisioned.
+
+## Steps
+
+1. **Placement** – Resource group, location and name.
+2. **Size & Image** – VM size, marketplace image or custom image, OS disk type and size.
+3. **Networking** – Virtual network and subnet from the resource group, and an optional public IP.
+4. **Access** – Administrator username, with an SSH public key or a password. Windows images require a password.
+5. **Spot** – Run the VM with Spot pricing, choosing the eviction policy and an optional maximum price.
+6. **Advanced** – Custom data (e.g. cloud-init) and tags.
+
+## How It Works
+
+A network interface, and a public IP if requested, are created next to the VM, named after it.
+The component then checks the provisioning state of the VM every 15 seconds, for up to 30 minutes.
+
+## Output
+
+Emits a payload with the VM details: id, vmId, name, resourceGroup, location, size, priority, provisioningState, powerState, privateIP and publicIP.`
+}
+
+func (c *CreateVM) Icon() string {
+	return "server"
+}
+
+func (c *CreateVM) Color() string {
+	return "blue"
+}
+
+func (c *CreateVM) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateVM) Configuration() []configuration.Field {
+	imageOptions := make([]configuration.FieldOption, 0, len(imagePresetOrder)+1)
+	for _, key := range imagePresetOrder {
+		imageOptions = append(imageOptions, configuration.FieldOption{Label: imagePresets[key].Label, Value: key})
+	}
+	imageOptions = append(imageOptions, configuration.FieldOption{Label: "Custom image", Value: ImageCustom})
+
+	return []configuration.Field{
+		{
+			Name:     "resourceGroup",
+			Label:    "Resource group",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{Type: ResourceTypeResourceGroup},
+			},
+		},
+		{
+			Name:     "location",
+			Label:    "Location",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{Type: ResourceTypeLocation},
+			},
+		},
+		{
+			Name:        "name",
+			Label:       "VM name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "1–64 characters: letters, digits and hyphens. Windows computer names are limited to 15 characters.",
+			Placeholder: "my-vm",
+		},
+		{
+			Name:     "size",
+			Label:    "Size",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeVMSize,
+					Parameters: []configuration.ParameterRef{
+						{Name: "location", ValueFrom: &configuration.ParameterValueFrom{Field: "location"}},
+					},
+				},
+			},
+		},
+		{
+			Name:     "image",
+			Label:    "Image",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  imagePresetOrder[0],
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{Options: imageOptions},
+			},
+		},
+		{
+			Name:        "customImageId",
+			Label:       "Custom image ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Resource ID of a managed image or a Compute Gallery image version",
+			Placeholder: "/subscriptions/.../resourceGroups/.../providers/Microsoft.Compute/galleries/.../images/.../versions/1.0.0",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "image", Values: []string{ImageCustom}},
+			},
+		},
+		{
+			Name:     "customImageOsType",
+			Label:    "Custom image OS",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  OSTypeLinux,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Linux", Value: OSTypeLinux},
+						{Label: "Windows", Value: OSTypeWindows},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "image", Values: []string{ImageCustom}},
+			},
+		},
+		{
+			Name:     "osDiskType",
+			Label:    "OS disk type",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  "StandardSSD_LRS",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Standard HDD", Value: "Standard_LRS"},
+						{Label: "Standard SSD", Value: "StandardSSD_LRS"},
+						{Label: "Premium SSD", Value: "Premium_LRS"},
+					},
+				},
+			},
+		},
+		{
+			Name:        "osDiskSizeGb",
+			Label:       "OS disk size (GB)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Leave empty to use the size of the image",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 30; return &min }(),
+					Max: func() *int { max := 4095; return &max }(),
+				},
+			},
+		},
+		{
+			Name:     "virtualNetwork",
+			Label:    "Virtual network",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeVirtualNetwork,
+					Parameters: []configuration.ParameterRef{
+						{Name: "resourceGroup", ValueFrom: &configuration.ParameterValueFrom{Field: "resourceGroup"}},
+					},
+				},
+			},
+		},
+		{
+			Name:     "subnet",
+			Label:    "Subnet",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeSubnet,
+					Parameters: []configuration.ParameterRef{
+						{Name: "resourceGroup", ValueFrom: &configuration.ParameterValueFrom{Field: "resourceGroup"}},
+						{Name: "virtualNetwork", ValueFrom: &configuration.ParameterValueFrom{Field: "virtualNetwork"}},
+					},
+				},
+			},
+		},
+		{
+			Name:        "publicIp",
+			Label:       "Public IP",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Create a static public IP for the VM",
+		},
+		{
+			Name:     "adminUsername",
+			Label:    "Administrator username",
+			Type:     configuration.FieldTypeString,
+			Required: true,
+			Default:  "azureuser",
+		},
+		{
+			Name:     "authenticationType",
+			Label:    "Authentication",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  AuthenticationTypeSSH,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "SSH public key", Value: AuthenticationTypeSSH},
+						{Label: "Password", Value: AuthenticationTypePassword},
+					},
+				},
+			},
+		},
+		{
+			Name:        "sshPublicKey",
+			Label:       "SSH public key",
+			Type:        configuration.FieldTypeText,
+			Required:    true,
+			Placeholder: "ssh-ed25519 AAAA...",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authenticationType", Values: []string{AuthenticationTypeSSH}},
+			},
+		},
+		{
+			Name:      "adminPassword",
+			Label:     "Administrator password",
+			Type:      configuration.FieldTypeString,
+			Required:  true,
+			Sensitive: true,
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authenticationType", Values: []string{AuthenticationTypePassword}},
+			},
+		},
+		{
+			Name:        "spot",
+			Label:       "Spot",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Use Spot pricing. Spot VMs are cheaper, but Azure can evict them at any time.",
+		},
+		{
+			Name:     "evictionPolicy",
+			Label:    "Eviction policy",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  "Deallocate",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Stop / Deallocate", Value: "Deallocate"},
+						{Label: "Delete", Value: "Delete"},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "spot", Values: []string{"true"}},
+			},
+		},
+		{
+			Name:        "maxPrice",
+			Label:       "Maximum price (USD/hour)",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Leave empty to pay up to the on-demand price, and never be evicted because of price",
+			Placeholder: "0.05",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "spot", Values: []string{"true"}},
+			},
+		},
+		{
+			Name:        "customData",
+			Label:       "Custom data",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Script or cloud-init configuration passed to the VM on first boot",
+		},
+		{
+			Name:     "tags",
+			Label:    "Tags",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{Name: "key", Label: "Key", Type: configuration.FieldTypeString, Required: true},
+							{Name: "value", Label: "Value", Type: configuration.FieldTypeString, Required: false},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func decodeCreateVMConfiguration(value any) (CreateVMConfiguration, error) {
+	config := CreateVMConfiguration{}
+	if err := mapstructure.WeakDecode(value, &config); err != nil {
+		return config, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.ResourceGroup = strings.TrimSpace(config.ResourceGroup)
+	config.Location = strings.TrimSpace(config.Location)
+	config.Name = strings.TrimSpace(config.Name)
+	config.Size = strings.TrimSpace(config.Size)
+	config.AdminUsername = strings.TrimSpace(config.AdminUsername)
+	if config.Image == "" {
+		config.Image = imagePresetOrder[0]
+	}
+
+	if config.AuthenticationType == "" {
+		config.AuthenticationType = AuthenticationTypeSSH
+	}
+
+	return config, nil
+}
+
+// validateCreateVMConfiguration checks the configuration values that are not built from expressions.
+func validateCreateVMConfiguration(config CreateVMConfiguration) error {
+	if config.ResourceGroup == "" {
+		return fmt.Errorf("resource group is required")
+	}
+
+	if config.Location == "" {
+		return fmt.Errorf("location is required")
+	}
+
+	if config.Name == "" {
+		return fmt.Errorf("VM name is required")
+	}
+
+	if isResolvedValue(config.Name) && !vmNameRegex.MatchString(config.Name) {
+		return fmt.Errorf("VM name must be 1–64 characters, use only letters, digits and hyphens, and start and end with a letter or digit")
+	}
+
+	if config.Size == "" {
+		return fmt.Errorf("size is required")
+	}
+
+	if config.VirtualNetwork == "" || config.Subnet == "" {
+		return fmt.Errorf("virtual network and subnet are required")
+	}
+
+	if config.AdminUsername == "" {
+		return fmt.Errorf("administrator username is required")
+	}
+
+	osType, err := config.osType()
+	if err != nil {
+		return err
+	}
+
+	if osType == OSTypeWindows {
+		if config.AuthenticationType != AuthenticationTypePassword {
+			return fmt.Errorf("windows VMs require password authentication")
+		}
+
+		if isResolvedValue(config.Name) && len(config.Name) > 15 {
+			return fmt.Errorf("windows VM names are limited to 15 characters")
+		}
+	}
+
+	switch config.AuthenticationType {
+	case AuthenticationTypeSSH:
+		if strings.TrimSpace(config.SSHPublicKey) == "" {
+			return fmt.Errorf("SSH public key is required")
+		}
+	case AuthenticationTypePassword:
+		if config.AdminPassword == "" {
+			return fmt.Errorf("administrator password is required")
+		}
+	default:
+		return fmt.Errorf("unknown authentication type: %s", config.AuthenticationType)
+	}
+
+	if config.Spot && config.MaxPrice != "" && isResolvedValue(config.MaxPrice) {
+		if _, err := parseMaxPrice(config.MaxPrice); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c CreateVMConfiguration) osType() (string, error) {
+	if c.Image == ImageCustom {
+		if strings.TrimSpace(c.CustomImageID) == "" {
+			return "", fmt.Errorf("custom image ID is required")
+		}
+
+		if c.CustomImageOSType == OSTypeWindows {
+			return OSTypeWindows, nil
+		}
+
+		return OSTypeLinux, nil
+	}
+
+	preset, ok := imagePresets[c.Image]
+	if !ok {
+		return "", fmt.Errorf("unknown image: %s", c.Image)
+	}
+
+	return preset.OSType, nil
+}
+
+func parseMaxPrice(value string) (float64, error) {
+	price, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || (price <= 0 && price != -1) {
+		return 0, fmt.Errorf("maximum price must be a positive number of USD per hour")
+	}
+
+	return price, nil
+}
+
+func (c *CreateVM) Setup(ctx core.SetupContext) error {
+	config, err := decodeCreateVMConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateCreateVMConfiguration(config)
+}
+
+func (c *CreateVM) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateVM) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCreateVMConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail(createVMFailureReason, err.Error())
+	}
+
+	if err := validateCreateVMConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail(createVMFailureReason, err.Error())
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return ctx.ExecutionState.Fail(createVMFailureReason, fmt.Sprintf("failed to create Azure client: %v", err))
+	}
+
+	metadata, err := createVM(client, config)
+	if err != nil {
+		return ctx.ExecutionState.Fail(createVMFailureReason, err.Error())
+	}
+
+	metadata.Deadline = time.Now().Add(CreateVMTimeout).UTC().Format(time.RFC3339)
+	if err := ctx.Metadata.Set(*metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(CreateVMActionPoll, map[string]any{}, CreateVMPollInterval)
+}
+
+// createVM creates the public IP, network interface and virtual machine.
+// Azure provisions the virtual machine asynchronously.
+func createVM(client *Client, config CreateVMConfiguration) (*CreateVMExecutionMetadata, error) {
+	tags := map[string]string{}
+	for _, tag := range config.Tags {
+		if key := strings.TrimSpace(tag.Key); key != "" {
+			tags[key] = tag.Value
+		}
+	}
+
+	metadata := &CreateVMExecutionMetadata{
+		VirtualMachineID:   resourceID(client.SubscriptionID, config.ResourceGroup, "Microsoft.Compute/virtualMachines", config.Name),
+		NetworkInterfaceID: resourceID(client.SubscriptionID, config.ResourceGroup, "Microsoft.Network/networkInterfaces", config.Name+"-nic"),
+	}
+
+	ipConfiguration := map[string]any{
+		"subnet": map[string]any{
+			"id": resourceID(client.SubscriptionID, config.ResourceGroup, "Microsoft.Network/virtualNetworks", config.VirtualNetwork) + "/subnets/" + url.PathEscape(config.Subnet),
+		},
+		"privateIPAllocationMethod": "Dynamic",
+	}
+
+	if config.PublicIP {
+		metadata.PublicIPAddressID = resourceID(client.SubscriptionID, config.ResourceGroup, "Microsoft.Network/publicIPAddresses", config.Name+"-ip")
+		err := client.PutResource(metadata.PublicIPAddressID, apiVersionNetwork, map[string]any{
+			"location": config.Location,
+			"tags":     tags,
+			"sku":      map[string]any{"name": "Standard"},
+			"properties": map[string]any{
+				"publicIPAllocationMethod": "Static",
+			},
+		}, nil)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create public IP: %w", err)
+		}
+
+		ipConfiguration["publicIPAddress"] = map[string]any{"id": metadata.PublicIPAddressID}
+	}
+
+	err := client.PutResource(metadata.NetworkInterfaceID, apiVersionNetwork, map[string]any{
+		"location": config.Location,
+		"tags":     tags,
+		"properties": map[string]any{
+			"ipConfigurations": []any{
+				map[string]any{"name": "ipconfig1", "properties": ipConfiguration},
+			},
+		},
+	}, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network interface: %w", err)
+	}
+
+	body, err := buildVirtualMachine(config, metadata.NetworkInterfaceID, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.PutResource(metadata.VirtualMachineID, apiVersionCompute, body, nil); err != nil {
+		return nil, fmt.Errorf("failed to create virtual machine: %w", err)
+	}
+
+	return metadata, nil
+}
+
+func buildVirtualMachine(config CreateVMConfiguration, networkInterfaceID string, tags map[string]string) (map[string]any, error) {
+	osType, err := config.osType()
+	if err != nil {
+		return nil, err
+	}
+
+	imageReference := map[string]any{}
+	if config.Image == ImageCustom {
+		imageReference["id"] = strings.TrimSpace(config.CustomImageID)
+	} else {
+		preset := imagePresets[config.Image]
+		imageReference["publisher"] = preset.Publisher
+		imageReference["offer"] = preset.Offer
+		imageReference["sku"] = preset.SKU
+		imageReference["version"] = "latest"
+	}
+
+	osDisk := map[string]any{
+		"createOption": "FromImage",
+		"deleteOption": "Delete",
+		"managedDisk": map[string]any{
+			"storageAccountType": defaultString(config.OSDiskType, "StandardSSD_LRS"),
+		},
+	}
+
+	if config.OSDiskSizeGB > 0 {
+		osDisk["diskSizeGB"] = config.OSDiskSizeGB
+	}
+
+	osProfile := map[string]any{
+		"computerName":  config.Name,
+		"adminUsername": config.AdminUsername,
+	}
+
+	if config.CustomData != "" {
+		osProfile["customData"] = base64.StdEncoding.EncodeToString([]byte(config.CustomData))
+	}
+
+	if config.AuthenticationType == AuthenticationTypePassword {
+		osProfile["adminPassword"] = config.AdminPassword
+	}
+
+	if osType == OSTypeWindows {
+		osProfile["windowsConfiguration"] = map[string]any{"provisionVMAgent": true}
+	} else {
+		linuxConfiguration := map[string]any{
+			"disablePasswordAuthentication": config.AuthenticationType == AuthenticationTypeSSH,
+		}
+
+		if config.AuthenticationType == AuthenticationTypeSSH {
+			linuxConfiguration["ssh"] = map[string]any{
+				"publicKeys": []any{
+					map[string]any{
+						"path":    fmt.Sprintf("/home/%s/.ssh/authorized_keys", config.AdminUsername),
+						"keyData": strings.TrimSpace(config.SSHPublicKey),
+					},
+				},
+			}
+		}
+
+		osProfile["linuxConfiguration"] = linuxConfiguration
+	}
+
+	properties := map[string]any{
+		"hardwareProfile": map[string]any{"vmSize": config.Size},
+		"storageProfile": map[string]any{
+			"imageReference": imageReference,
+			"osDisk":         osDisk,
+		},
+		"osProfile": osProfile,
+		"networkProfile": map[string]any{
+			"networkInterfaces": []any{
+				map[string]any{
+					"id":         networkInterfaceID,
+					"properties": map[string]any{"primary": true, "deleteOption": "Delete"},
+				},
+			},
+		},
+		"priority": PriorityRegular,
+	}
+
+	if config.Spot {
+		maxPrice := float64(-1)
+		if strings.TrimSpace(config.MaxPrice) != "" {
+			maxPrice, err = parseMaxPrice(config.MaxPrice)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		properties["priority"] = PrioritySpot
+		properties["evictionPolicy"] = defaultString(config.EvictionPolicy, "Deallocate")
+		properties["billingProfile"] = map[string]any{"maxPrice": maxPrice}
+	}
+
+	return map[string]any{
+		"location":   config.Location,
+		"tags":       tags,
+		"properties": properties,
+	}, nil
+}
+
+func (c *CreateVM) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:           CreateVMActionPoll,
+			Description:    "Check the provisioning state of the virtual machine",
+			UserAccessible: false,
+		},
+	}
+}
+
+func (c *CreateVM) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case CreateVMActionPoll:
+		return c.poll(ctx)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *CreateVM) poll(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := CreateVMExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	machine, err := client.GetVirtualMachine(metadata.VirtualMachineID)
+	if err != nil {
+		ctx.Logger.Warnf("failed to get virtual machine %s: %v", metadata.VirtualMachineID, err)
+		return ctx.Requests.ScheduleActionCall(CreateVMActionPoll, map[string]any{}, CreateVMPollInterval)
+	}
+
+	switch machine.Properties.ProvisioningState {
+	case "Succeeded":
+		payload := virtualMachinePayload(machine)
+		payload["privateIP"], payload["publicIP"] = virtualMachineAddresses(ctx, client, metadata)
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, CreateVMPayloadType, []any{payload})
+
+	case "Failed", "Canceled":
+		return ctx.ExecutionState.Fail(createVMFailureReason, provisioningFailureMessage(machine))
+	}
+
+	deadline, err := time.Parse(time.RFC3339, metadata.Deadline)
+	if err == nil && time.Now().After(deadline) {
+		return ctx.ExecutionState.Fail(createVMFailureReason, fmt.Sprintf("timed out waiting for VM %s to be provisioned", machine.Name))
+	}
+
+	return ctx.Requests.ScheduleActionCall(CreateVMActionPoll, map[string]any{}, CreateVMPollInterval)
+}
+
+// virtualMachineAddresses returns the private and public IP of the VM.
+// Addresses are best-effort, and left empty if they can't be read.
+func virtualMachineAddresses(ctx core.ActionContext, client *Client, metadata CreateVMExecutionMetadata) (string, string) {
+	nic := NetworkInterface{}
+	if err := client.GetResource(metadata.NetworkInterfaceID, apiVersionNetwork, &nic); err != nil {
+		ctx.Logger.Warnf("failed to get network interface %s: %v", metadata.NetworkInterfaceID, err)
+		return "", ""
+	}
+
+	privateIP := ""
+	if len(nic.Properties.IPConfigurations) > 0 {
+		privateIP = nic.Properties.IPConfigurations[0].Properties.PrivateIPAddress
+	}
+
+	if metadata.PublicIPAddressID == "" {
+		return privateIP, ""
+	}
+
+	address := PublicIPAddress{}
+	if err := client.GetResource(metadata.PublicIPAddressID, apiVersionNetwork, &address); err != nil {
+		ctx.Logger.Warnf("failed to get public IP %s: %v", metadata.PublicIPAddressID, err)
+		return privateIP, ""
+	}
+
+	return privateIP, address.Properties.IPAddress
+}
+
+func virtualMachinePayload(machine *VirtualMachine) map[string]any {
+	powerState := ""
+	if machine.Properties.InstanceView != nil {
+		for _, status := range machine.Properties.InstanceView.Statuses {
+			if strings.HasPrefix(status.Code, "PowerState/") {
+				powerState = strings.TrimPrefix(status.Code, "PowerState/")
+			}
+		}
+	}
+
+	return map[string]any{
+		"id":                machine.ID,
+		"vmId":              machine.Properties.VMID,
+		"name":              machine.Name,
+		"resourceGroup":     resourceGroupFromID(machine.ID),
+		"location":          machine.Location,
+		"size":              machine.Properties.HardwareProfile.VMSize,
+		"priority":          defaultString(machine.Properties.Priority, PriorityRegular),
+		"provisioningState": machine.Properties.ProvisioningState,
+		"powerState":        powerState,
+	}
+}
+
+func provisioningFailureMessage(machine *VirtualMachine) string {
+	if machine.Properties.InstanceView != nil {
+		for _, status := range machine.Properties.InstanceView.Statuses {
+			if status.Level == "Error" && status.Message != "" {
+				return fmt.Sprintf("VM %s failed to provision: %s", machine.Name, status.Message)
+			}
+		}
+	}
+
+	return fmt.Sprintf("VM %s failed to provision: provisioning state is %s", machine.Name, machine.Properties.ProvisioningState)
+}
+
+func (c *CreateVM) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *CreateVM) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateVM) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func resourceID(subscriptionID, resourceGroup, resourceType, name string) string {
+	return fmt.Sprintf(
+		"/subscriptions/%s/resourceGroups/%s/providers/%s/%s",
+		url.PathEscape(subscriptionID),
+		url.PathEscape(resourceGroup),
+		resourceType,
+		url.PathEscape(name),
+	)
+}
+
+func defaultString(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,227 @@
+package azure
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func createVMConfiguration() map[string]any {
+	return map[string]any{
+		"resourceGroup":      "production",
+		"location":           "westeurope",
+		"name":               "web-01",
+		"size":               "Standard_D2s_v5",
+		"image":              "ubuntu-24.04",
+		"virtualNetwork":     "main",
+		"subnet":             "default",
+		"adminUsername":      "azureuser",
+		"authenticationType": AuthenticationTypeSSH,
+		"sshPublicKey":       "ssh-ed25519 AAAAC3Nza deployer",
+	}
+}
+
+func Test__CreateVM__Setup(t *testing.T) {
+	component := &CreateVM{}
+
+	t.Run("valid configuration -> ok", func(t *testing.T) {
+		require.NoError(t, component.Setup(core.SetupContext{Configuration: createVMConfiguration()}))
+	})
+
+	t.Run("invalid name -> error", func(t *testing.T) {
+		config := createVMConfiguration()
+		config["name"] = "web_01"
+		require.ErrorContains(t, component.Setup(core.SetupContext{Configuration: config}), "VM name must be")
+	})
+
+	t.Run("windows with ssh -> error", func(t *testing.T) {
+		config := createVMConfiguration()
+		config["image"] = "windows-2022"
+		require.ErrorContains(t, component.Setup(core.SetupContext{Configuration: config}), "password authentication")
+	})
+
+	t.Run("invalid spot max price -> error", func(t *testing.T) {
+		config := createVMConfiguration()
+		config["spot"] = true
+		config["maxPrice"] = "cheap"
+		require.ErrorContains(t, component.Setup(core.SetupContext{Configuration: config}), "maximum price")
+	})
+}
+
+func Test__CreateVM__Execute(t *testing.T) {
+	component := &CreateVM{}
+
+	config := createVMConfiguration()
+	config["publicIp"] = true
+	config["spot"] = true
+	config["maxPrice"] = "0.05"
+	config["customData"] = "#cloud-config"
+	config["tags"] = []any{map[string]any{"key": "env", "value": "production"}}
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusCreated, `{}`),
+			jsonResponse(http.StatusCreated, `{}`),
+			jsonResponse(http.StatusCreated, `{}`),
+		},
+	}
+
+	metadata := &contexts.MetadataContext{}
+	requests := &contexts.RequestContext{}
+	state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+	err := component.Execute(core.ExecutionContext{
+		Configuration:  config,
+		HTTP:           httpCtx,
+		Integration:    testIntegrationContext(),
+		Metadata:       metadata,
+		Requests:       requests,
+		ExecutionState: state,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, state.Finished)
+	assert.Equal(t, CreateVMActionPoll, requests.Action)
+
+	require.Len(t, httpCtx.Requests, 3)
+	prefix := "/subscriptions/" + testSubscriptionID + "/resourceGroups/production/providers/"
+	assert.Equal(t, prefix+"Microsoft.Network/publicIPAddresses/web-01-ip", httpCtx.Requests[0].URL.Path)
+	assert.Equal(t, prefix+"Microsoft.Network/networkInterfaces/web-01-nic", httpCtx.Requests[1].URL.Path)
+	assert.Equal(t, prefix+"Microsoft.Compute/virtualMachines/web-01", httpCtx.Requests[2].URL.Path)
+	for _, req := range httpCtx.Requests {
+		assert.Equal(t, http.MethodPut, req.Method)
+	}
+
+	body, err := io.ReadAll(httpCtx.Requests[2].Body)
+	require.NoError(t, err)
+	vm := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &vm))
+
+	properties := vm["properties"].(map[string]any)
+	assert.Equal(t, PrioritySpot, properties["priority"])
+	assert.Equal(t, "Deallocate", properties["evictionPolicy"])
+	assert.Equal(t, 0.05, properties["billingProfile"].(map[string]any)["maxPrice"])
+	assert.Equal(t, map[string]any{"env": "production"}, vm["tags"])
+
+	osProfile := properties["osProfile"].(map[string]any)
+	assert.Equal(t, "I2Nsb3VkLWNvbmZpZw==", osProfile["customData"])
+	assert.NotContains(t, osProfile, "adminPassword")
+	linux := osProfile["linuxConfiguration"].(map[string]any)
+	assert.Equal(t, true, linux["disablePasswordAuthentication"])
+
+	image := properties["storageProfile"].(map[string]any)["imageReference"].(map[string]any)
+	assert.Equal(t, "Canonical", image["publisher"])
+	assert.Equal(t, "latest", image["version"])
+
+	stored := metadata.Metadata.(CreateVMExecutionMetadata)
+	assert.Equal(t, prefix+"Microsoft.Network/publicIPAddresses/web-01-ip", stored.PublicIPAddressID)
+	assert.NotEmpty(t, stored.Deadline)
+}
+
+func Test__CreateVM__Poll(t *testing.T) {
+	component := &CreateVM{}
+	prefix := "/subscriptions/" + testSubscriptionID + "/resourceGroups/production/providers/"
+	metadata := map[string]any{
+		"virtualMachineId":   prefix + "Microsoft.Compute/virtualMachines/web-01",
+		"networkInterfaceId": prefix + "Microsoft.Network/networkInterfaces/web-01-nic",
+		"publicIpAddressId":  prefix + "Microsoft.Network/publicIPAddresses/web-01-ip",
+		"deadline":           time.Now().Add(time.Hour).Format(time.RFC3339),
+	}
+
+	t.Run("provisioned -> emits VM", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{
+					"id":"`+prefix+`Microsoft.Compute/virtualMachines/web-01",
+					"name":"web-01",
+					"location":"westeurope",
+					"properties":{
+						"vmId":"vm-id",
+						"provisioningState":"Succeeded",
+						"priority":"Spot",
+						"hardwareProfile":{"vmSize":"Standard_D2s_v5"},
+						"instanceView":{"statuses":[{"code":"ProvisioningState/succeeded"},{"code":"PowerState/running"}]}
+					}
+				}`),
+				jsonResponse(http.StatusOK, `{"properties":{"ipConfigurations":[{"properties":{"privateIPAddress":"10.0.1.4"}}]}}`),
+				jsonResponse(http.StatusOK, `{"properties":{"ipAddress":"20.61.14.102"}}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           CreateVMActionPoll,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: metadata},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: state,
+			Logger:         logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, CreateVMPayloadType, state.Type)
+		assert.Equal(t, "instanceView", httpCtx.Requests[0].URL.Query().Get("$expand"))
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "production", payload["resourceGroup"])
+		assert.Equal(t, "running", payload["powerState"])
+		assert.Equal(t, "10.0.1.4", payload["privateIP"])
+		assert.Equal(t, "20.61.14.102", payload["publicIP"])
+	})
+
+	t.Run("still creating -> schedules poll", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"name":"web-01","properties":{"provisioningState":"Creating"}}`),
+			},
+		}
+
+		requests := &contexts.RequestContext{}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           CreateVMActionPoll,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: metadata},
+			Requests:       requests,
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Finished)
+		assert.Equal(t, CreateVMActionPoll, requests.Action)
+	})
+
+	t.Run("failed -> fails execution", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"name":"web-01","properties":{"provisioningState":"Failed","instanceView":{"statuses":[{"code":"ProvisioningState/failed/SkuNotAvailable","level":"Error","message":"The requested size is not available"}]}}}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           CreateVMActionPoll,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: metadata},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "The requested size is not available")
+	})
+}
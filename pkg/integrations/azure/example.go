@@ -0,0 +1,36 @@
+package azure
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_create_vm.json
+var exampleOutputCreateVMBytes []byte
+
+//go:embed example_data_on_activity_log_event.json
+var exampleDataOnActivityLogEventBytes []byte
+
+var exampleOutputCreateVMOnce sync.Once
+var exampleOutputCreateVM map[string]any
+
+var exampleDataOnActivityLogEventOnce sync.Once
+var exampleDataOnActivityLogEvent map[string]any
+
+func (c *CreateVM) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCreateVMOnce,
+		exampleOutputCreateVMBytes,
+		&exampleOutputCreateVM,
+	)
+}
+
+func (t *OnActivityLogEvent) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnActivityLogEventOnce,
+		exampleDataOnActivityLogEventBytes,
+		&exampleDataOnActivityLogEvent,
+	)
+}
@@ -0,0 +1,337 @@
+package azure
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	OnActivityLogEventPayloadType = "azure.activityLog.event"
+
+	eventGridEventTypeHeader        = "aeg-event-type"
+	eventGridSubscriptionValidation = "SubscriptionValidation"
+	subscriptionValidationEventType = "Microsoft.EventGrid.SubscriptionValidationEvent"
+
+	EventTypeResourceWriteSuccess  = "Microsoft.Resources.ResourceWriteSuccess"
+	EventTypeResourceWriteFailure  = "Microsoft.Resources.ResourceWriteFailure"
+	EventTypeResourceDeleteSuccess = "Microsoft.Resources.ResourceDeleteSuccess"
+	EventTypeResourceDeleteFailure = "Microsoft.Resources.ResourceDeleteFailure"
+	EventTypeResourceActionSuccess = "Microsoft.Resources.ResourceActionSuccess"
+	EventTypeResourceActionFailure = "Microsoft.Resources.ResourceActionFailure"
+)
+
+var activityLogEventTypeOptions = []configuration.FieldOption{
+	{Label: "Resource write succeeded", Value: EventTypeResourceWriteSuccess},
+	{Label: "Resource write failed", Value: EventTypeResourceWriteFailure},
+	{Label: "Resource delete succeeded", Value: EventTypeResourceDeleteSuccess},
+	{Label: "Resource delete failed", Value: EventTypeResourceDeleteFailure},
+	{Label: "Resource action succeeded", Value: EventTypeResourceActionSuccess},
+	{Label: "Resource action failed", Value: EventTypeResourceActionFailure},
+}
+
+var defaultActivityLogEventTypes = []string{EventTypeResourceWriteSuccess, EventTypeResourceDeleteSuccess}
+
+type OnActivityLogEvent struct{}
+
+type OnActivityLogEventConfiguration struct {
+	ResourceGroup  string   `json:"resourceGroup" mapstructure:"resourceGroup"`
+	EventTypes     []string `json:"eventTypes" mapstructure:"eventTypes"`
+	OperationNames []string `json:"operationNames" mapstructure:"operationNames"`
+}
+
+// EventGridEvent is an event delivered with the Event Grid schema.
+type EventGridEvent struct {
+	ID          string         `json:"id"`
+	Topic       string         `json:"topic"`
+	Subject     string         `json:"subject"`
+	EventType   string         `json:"eventType"`
+	EventTime   string         `json:"eventTime"`
+	Data        map[string]any `json:"data"`
+	DataVersion string         `json:"dataVersion"`
+}
+
+func (t *OnActivityLogEvent) Name() string {
+	return "azure.onActivityLogEvent"
+}
+
+func (t *OnActivityLogEvent) Label() string {
+	return "On Activity Log Event"
+}
+
+func (t *OnActivityLogEvent) Description() string {
+	return "Listen to resource changes in an Azure resource group"
+}
+
+func (t *OnActivityLogEvent) Documentation() string {
+	return `The On Activity Log Event trigger starts a workflow execution when resources in an Azure resource group are created, updated, deleted or acted on.
+
+## Use Cases
+
+- **Drift detection**: Notify the team when resources are changed outside of your pipelines
+- **Inventory**: Register new virtual machines in a CMDB or monitoring tool
+- **Cleanup**: Remove DNS records or secrets when a resource is deleted
+
+## How It Works
+
+The trigger subscribes to the Azure Resource Manager events of the resource group through Event Grid, the same operations recorded in the Activity Log.
+SuperPlane creates the Event Grid system topic of the resource group if it doesn't exist, and an event subscription delivering to SuperPlane.
+
+## Configuration
+
+- **Resource group** (required): The resource group to listen to
+- **Event types**: The events to listen to. Defaults to successful writes and deletes
+- **Operations** (optional): Only trigger for these operations, e.g. ` + "`Microsoft.Compute/virtualMachines/write`" + `. Supports ` + "`*`" + ` as a suffix wildcard, e.g. ` + "`Microsoft.Compute/*`" + `
+
+## Event Data
+
+Each event contains the Event Grid event, with id, eventType, subject (the resource ID), eventTime, and data with operationName, resourceUri, status, correlationId and the authorization and claims of the caller.`
+}
+
+func (t *OnActivityLogEvent) Icon() string {
+	return "azure"
+}
+
+func (t *OnActivityLogEvent) Color() string {
+	return "blue"
+}
+
+func (t *OnActivityLogEvent) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "resourceGroup",
+			Label:    "Resource group",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{Type: ResourceTypeResourceGroup},
+			},
+		},
+		{
+			Name:     "eventTypes",
+			Label:    "Event types",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: false,
+			Default:  defaultActivityLogEventTypes,
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: activityLogEventTypeOptions,
+				},
+			},
+		},
+		{
+			Name:        "operationNames",
+			Label:       "Operations",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Only trigger for these operations",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Operation",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func decodeOnActivityLogEventConfiguration(value any) (OnActivityLogEventConfiguration, error) {
+	config := OnActivityLogEventConfiguration{}
+	if err := mapstructure.Decode(value, &config); err != nil {
+		return config, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.ResourceGroup = strings.TrimSpace(config.ResourceGroup)
+	if config.ResourceGroup == "" {
+		return config, fmt.Errorf("resource group is required")
+	}
+
+	eventTypes := []string{}
+	for _, eventType := range normalizeEventTypes(config.EventTypes) {
+		if !slices.ContainsFunc(activityLogEventTypeOptions, func(option configuration.FieldOption) bool { return option.Value == eventType }) {
+			return config, fmt.Errorf("unknown event type: %s", eventType)
+		}
+
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	if len(eventTypes) == 0 {
+		eventTypes = defaultActivityLogEventTypes
+	}
+
+	config.EventTypes = eventTypes
+	return config, nil
+}
+
+func (t *OnActivityLogEvent) Setup(ctx core.TriggerContext) error {
+	config, err := decodeOnActivityLogEventConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.GetResourceGroup(config.ResourceGroup); err != nil {
+		return fmt.Errorf("failed to find resource group %s: %w", config.ResourceGroup, err)
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		ResourceGroup: config.ResourceGroup,
+		EventTypes:    config.EventTypes,
+	})
+}
+
+func (t *OnActivityLogEvent) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *OnActivityLogEvent) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnActivityLogEvent) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	events := []EventGridEvent{}
+	if err := json.Unmarshal(ctx.Body, &events); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %w", err)
+	}
+
+	//
+	// Event Grid validates the endpoint when the event subscription is created,
+	// before the secret header is delivered. Echoing the validation code back
+	// is harmless, so it is answered without verifying the secret.
+	//
+	if ctx.Headers.Get(eventGridEventTypeHeader) == eventGridSubscriptionValidation {
+		return subscriptionValidationResponse(events)
+	}
+
+	if err := verifyWebhookSecret(ctx); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	config, err := decodeOnActivityLogEventConfiguration(ctx.Configuration)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	for _, event := range events {
+		if !matchesActivityLogEvent(config, event) {
+			continue
+		}
+
+		if err := ctx.Events.Emit(OnActivityLogEventPayloadType, eventPayload(event)); err != nil {
+			return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %w", err)
+		}
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func subscriptionValidationResponse(events []EventGridEvent) (int, *core.WebhookResponseBody, error) {
+	for _, event := range events {
+		if event.EventType != subscriptionValidationEventType {
+			continue
+		}
+
+		code, _ := event.Data["validationCode"].(string)
+		if code == "" {
+			return http.StatusBadRequest, nil, fmt.Errorf("missing validation code")
+		}
+
+		body, err := json.Marshal(map[string]string{"validationResponse": code})
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+
+		return http.StatusOK, &core.WebhookResponseBody{Body: body, ContentType: "application/json"}, nil
+	}
+
+	return http.StatusBadRequest, nil, fmt.Errorf("missing subscription validation event")
+}
+
+func verifyWebhookSecret(ctx core.WebhookRequestContext) error {
+	if ctx.Webhook == nil {
+		return fmt.Errorf("missing webhook context")
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return fmt.Errorf("error reading webhook secret")
+	}
+
+	if len(secret) == 0 {
+		return fmt.Errorf("missing webhook secret")
+	}
+
+	value := ctx.Headers.Get(webhookSecretHeader)
+	if value == "" {
+		return fmt.Errorf("missing %s header", webhookSecretHeader)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(value), secret) != 1 {
+		return fmt.Errorf("invalid webhook secret")
+	}
+
+	return nil
+}
+
+// matchesActivityLogEvent checks the event against the trigger configuration.
+// The event subscription is shared by all the triggers on the resource group,
+// so events for other event types are also received.
+func matchesActivityLogEvent(config OnActivityLogEventConfiguration, event EventGridEvent) bool {
+	if !slices.Contains(config.EventTypes, event.EventType) {
+		return false
+	}
+
+	if len(config.OperationNames) == 0 {
+		return true
+	}
+
+	operationName, _ := event.Data["operationName"].(string)
+	for _, pattern := range config.OperationNames {
+		if matchesOperationName(strings.TrimSpace(pattern), operationName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesOperationName(pattern, operationName string) bool {
+	if pattern == "" {
+		return false
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(strings.ToLower(operationName), strings.ToLower(prefix))
+	}
+
+	return strings.EqualFold(pattern, operationName)
+}
+
+func eventPayload(event EventGridEvent) map[string]any {
+	return map[string]any{
+		"id":          event.ID,
+		"topic":       event.Topic,
+		"subject":     event.Subject,
+		"eventType":   event.EventType,
+		"eventTime":   event.EventTime,
+		"data":        event.Data,
+		"dataVersion": event.DataVersion,
+	}
+}
+
+func (t *OnActivityLogEvent) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
@@ -0,0 +1,159 @@
+package azure
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testEvents = `[
+	{"id":"1","subject":"/vm/web-01","eventType":"Microsoft.Resources.ResourceWriteSuccess","data":{"operationName":"Microsoft.Compute/virtualMachines/write"}},
+	{"id":"2","subject":"/nic/web-01-nic","eventType":"Microsoft.Resources.ResourceWriteSuccess","data":{"operationName":"Microsoft.Network/networkInterfaces/write"}},
+	{"id":"3","subject":"/vm/web-02","eventType":"Microsoft.Resources.ResourceDeleteSuccess","data":{"operationName":"Microsoft.Compute/virtualMachines/delete"}}
+]`
+
+func Test__OnActivityLogEvent__HandleWebhook(t *testing.T) {
+	trigger := &OnActivityLogEvent{}
+	configuration := map[string]any{
+		"resourceGroup":  "production",
+		"eventTypes":     []string{EventTypeResourceWriteSuccess},
+		"operationNames": []string{"Microsoft.Compute/*"},
+	}
+
+	t.Run("subscription validation -> echoes validation code", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set(eventGridEventTypeHeader, eventGridSubscriptionValidation)
+
+		events := &contexts.EventContext{}
+		status, response, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(`[{"id":"1","eventType":"Microsoft.EventGrid.SubscriptionValidationEvent","data":{"validationCode":"512d38b6-c7b8-40c8-89fe-f46f9e9622b6"}}]`),
+			Headers:       headers,
+			Configuration: configuration,
+			Webhook:       &contexts.NodeWebhookContext{},
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		require.NotNil(t, response)
+		assert.JSONEq(t, `{"validationResponse":"512d38b6-c7b8-40c8-89fe-f46f9e9622b6"}`, string(response.Body))
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("missing secret -> forbidden", func(t *testing.T) {
+		status, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(testEvents),
+			Headers:       http.Header{},
+			Configuration: configuration,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "secret"},
+			Events:        &contexts.EventContext{},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("emits matching events", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set(webhookSecretHeader, "secret")
+
+		events := &contexts.EventContext{}
+		status, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(testEvents),
+			Headers:       headers,
+			Configuration: configuration,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "secret"},
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnActivityLogEventPayloadType, events.Payloads[0].Type)
+		assert.Equal(t, "1", events.Payloads[0].Data.(map[string]any)["id"])
+	})
+}
+
+func Test__AzureWebhookHandler__Setup(t *testing.T) {
+	handler := &AzureWebhookHandler{}
+	groupID := "/subscriptions/" + testSubscriptionID + "/resourceGroups/production"
+
+	t.Run("reuses the existing system topic", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":"`+groupID+`","name":"production","location":"westeurope"}`),
+				jsonResponse(http.StatusOK, `{"value":[{"id":"`+groupID+`/providers/Microsoft.EventGrid/systemTopics/existing","name":"existing","properties":{"source":"`+groupID+`","topicType":"Microsoft.Resources.ResourceGroups"}}]}`),
+				jsonResponse(http.StatusCreated, `{}`),
+			},
+		}
+
+		webhook := &contexts.WebhookContext{
+			ID:  "webhook-1",
+			URL: "https://superplane.example.com/api/v1/webhooks/webhook-1",
+			Configuration: WebhookConfiguration{
+				ResourceGroup: "production",
+				EventTypes:    []string{EventTypeResourceWriteSuccess},
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Webhook:     webhook,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WebhookMetadata{
+			SystemTopicID:       groupID + "/providers/Microsoft.EventGrid/systemTopics/existing",
+			SystemTopicCreated:  false,
+			EventSubscriptionID: groupID + "/providers/Microsoft.EventGrid/systemTopics/existing/eventSubscriptions/superplane-webhook-1",
+		}, metadata)
+
+		require.Len(t, httpCtx.Requests, 3)
+		body, err := io.ReadAll(httpCtx.Requests[2].Body)
+		require.NoError(t, err)
+
+		subscription := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &subscription))
+		destination := subscription["properties"].(map[string]any)["destination"].(map[string]any)["properties"].(map[string]any)
+		assert.Equal(t, webhook.URL, destination["endpointUrl"])
+
+		attribute := destination["deliveryAttributeMappings"].([]any)[0].(map[string]any)
+		assert.Equal(t, webhookSecretHeader, attribute["name"])
+		assert.Equal(t, string(webhook.Secret), attribute["properties"].(map[string]any)["value"])
+	})
+
+	t.Run("creates the system topic", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":"`+groupID+`","name":"production"}`),
+				jsonResponse(http.StatusOK, `{"value":[]}`),
+				jsonResponse(http.StatusCreated, `{"id":"`+groupID+`/providers/Microsoft.EventGrid/systemTopics/superplane-production"}`),
+				jsonResponse(http.StatusCreated, `{}`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Webhook: &contexts.WebhookContext{
+				ID:  "webhook-1",
+				URL: "https://superplane.example.com/api/v1/webhooks/webhook-1",
+				Configuration: WebhookConfiguration{
+					ResourceGroup: "production",
+					EventTypes:    []string{EventTypeResourceWriteSuccess},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, metadata.(WebhookMetadata).SystemTopicCreated)
+		assert.Equal(t, groupID+"/providers/Microsoft.EventGrid/systemTopics/superplane-production", httpCtx.Requests[2].URL.Path)
+	})
+}
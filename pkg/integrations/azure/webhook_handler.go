@@ -0,0 +1,273 @@
+package azure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	systemTopicType         = "Microsoft.Resources.ResourceGroups"
+	systemTopicNamePrefix   = "superplane-"
+	eventSubscriptionPrefix = "superplane-"
+	webhookSecretHeader     = "X-SuperPlane-Secret"
+)
+
+type AzureWebhookHandler struct{}
+
+// WebhookConfiguration describes an Event Grid event subscription
+// on the system topic of a resource group. Event subscriptions are per
+// resource group, so one webhook is shared by all the nodes using it.
+type WebhookConfiguration struct {
+	ResourceGroup string   `json:"resourceGroup" mapstructure:"resourceGroup"`
+	EventTypes    []string `json:"eventTypes" mapstructure:"eventTypes"`
+}
+
+type WebhookMetadata struct {
+	SystemTopicID       string `json:"systemTopicId" mapstructure:"systemTopicId"`
+	SystemTopicCreated  bool   `json:"systemTopicCreated" mapstructure:"systemTopicCreated"`
+	EventSubscriptionID string `json:"eventSubscriptionId" mapstructure:"eventSubscriptionId"`
+}
+
+type systemTopic struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Properties struct {
+		Source    string `json:"source"`
+		TopicType string `json:"topicType"`
+	} `json:"properties"`
+}
+
+func (h *AzureWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	configA, err := decodeWebhookConfiguration(a)
+	if err != nil {
+		return false, err
+	}
+
+	configB, err := decodeWebhookConfiguration(b)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.EqualFold(configA.ResourceGroup, configB.ResourceGroup), nil
+}
+
+func (h *AzureWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	currentConfig, err := decodeWebhookConfiguration(current)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode current webhook configuration: %w", err)
+	}
+
+	requestedConfig, err := decodeWebhookConfiguration(requested)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode requested webhook configuration: %w", err)
+	}
+
+	merged := WebhookConfiguration{
+		ResourceGroup: currentConfig.ResourceGroup,
+		EventTypes:    normalizeEventTypes(append(currentConfig.EventTypes, requestedConfig.EventTypes...)),
+	}
+
+	return merged, !slices.Equal(currentConfig.EventTypes, merged.EventTypes), nil
+}
+
+func (h *AzureWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookURL := ctx.Webhook.GetURL()
+	if webhookURL == "" {
+		return nil, fmt.Errorf("webhook URL is required")
+	}
+
+	config, err := decodeWebhookConfiguration(ctx.Webhook.GetConfiguration())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode webhook configuration: %w", err)
+	}
+
+	if config.ResourceGroup == "" {
+		return nil, fmt.Errorf("resource group is required")
+	}
+
+	if len(config.EventTypes) == 0 {
+		return nil, fmt.Errorf("at least one event type is required")
+	}
+
+	topic, created, err := ensureSystemTopic(client, config.ResourceGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up Event Grid system topic: %w", err)
+	}
+
+	//
+	// Event Grid sends the secret as a header on every delivery.
+	// Secret delivery attributes are not returned by the API.
+	//
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	if err := ctx.Webhook.SetSecret([]byte(secret)); err != nil {
+		return nil, fmt.Errorf("failed to store webhook secret: %w", err)
+	}
+
+	subscriptionID := topic.ID + "/eventSubscriptions/" + eventSubscriptionPrefix + ctx.Webhook.GetID()
+	err = client.PutResource(subscriptionID, apiVersionEventGrid, map[string]any{
+		"properties": map[string]any{
+			"destination": map[string]any{
+				"endpointType": "WebHook",
+				"properties": map[string]any{
+					"endpointUrl": webhookURL,
+					"deliveryAttributeMappings": []any{
+						map[string]any{
+							"name": webhookSecretHeader,
+							"type": "Static",
+							"properties": map[string]any{
+								"value":    secret,
+								"isSecret": true,
+							},
+						},
+					},
+				},
+			},
+			"filter": map[string]any{
+				"includedEventTypes": config.EventTypes,
+			},
+			"eventDeliverySchema": "EventGridSchema",
+		},
+	}, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Event Grid subscription: %w", err)
+	}
+
+	return WebhookMetadata{
+		SystemTopicID:       topic.ID,
+		SystemTopicCreated:  created,
+		EventSubscriptionID: subscriptionID,
+	}, nil
+}
+
+// ensureSystemTopic returns the Event Grid system topic for the resource group,
+// creating it if needed. Azure allows a single system topic per source.
+func ensureSystemTopic(client *Client, resourceGroup string) (*systemTopic, bool, error) {
+	group, err := client.GetResourceGroup(resourceGroup)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get resource group %s: %w", resourceGroup, err)
+	}
+
+	topics, err := listAll[systemTopic](
+		client,
+		client.resourceGroupPath(resourceGroup, "/providers/Microsoft.EventGrid/systemTopics")+"?api-version="+apiVersionEventGrid,
+	)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, topic := range topics {
+		if strings.EqualFold(topic.Properties.Source, group.ID) && strings.EqualFold(topic.Properties.TopicType, systemTopicType) {
+			return &topic, false, nil
+		}
+	}
+
+	topicID := resourceID(client.SubscriptionID, resourceGroup, "Microsoft.EventGrid/systemTopics", systemTopicNamePrefix+group.Name)
+	topic := systemTopic{}
+	err = client.PutResource(topicID, apiVersionEventGrid, map[string]any{
+		"location": "global",
+		"properties": map[string]any{
+			"source":    group.ID,
+			"topicType": systemTopicType,
+		},
+	}, &topic)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if topic.ID == "" {
+		topic.ID = topicID
+	}
+
+	return &topic, true, nil
+}
+
+func (h *AzureWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode webhook metadata: %w", err)
+	}
+
+	if metadata.EventSubscriptionID == "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteResource(metadata.EventSubscriptionID, apiVersionEventGrid)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete Event Grid subscription: %w", err)
+	}
+
+	//
+	// System topics that existed before are left alone,
+	// since other event subscriptions may use them.
+	//
+	if !metadata.SystemTopicCreated {
+		return nil
+	}
+
+	err = client.DeleteResource(metadata.SystemTopicID, apiVersionEventGrid)
+	if err != nil && !isNotFound(err) {
+		ctx.Logger.Warnf("failed to delete Event Grid system topic %s: %v", metadata.SystemTopicID, err)
+	}
+
+	return nil
+}
+
+func decodeWebhookConfiguration(configuration any) (WebhookConfiguration, error) {
+	config := WebhookConfiguration{}
+	if configuration == nil {
+		return config, nil
+	}
+
+	if err := mapstructure.Decode(configuration, &config); err != nil {
+		return WebhookConfiguration{}, err
+	}
+
+	config.ResourceGroup = strings.TrimSpace(config.ResourceGroup)
+	config.EventTypes = normalizeEventTypes(config.EventTypes)
+	return config, nil
+}
+
+func normalizeEventTypes(eventTypes []string) []string {
+	normalized := []string{}
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if eventType != "" && !slices.Contains(normalized, eventType) {
+			normalized = append(normalized, eventType)
+		}
+	}
+
+	slices.Sort(normalized)
+	return normalized
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,164 @@
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type AnnotateDeployment struct{}
+
+type AnnotateDeploymentSpec struct {
+	Tags string `json:"tags"`
+}
+
+func (c *AnnotateDeployment) Name() string {
+	return "datadog.annotateDeployment"
+}
+
+func (c *AnnotateDeployment) Label() string {
+	return "Annotate Deployment"
+}
+
+func (c *AnnotateDeployment) Description() string {
+	return "Record a deployment as a Datadog event"
+}
+
+func (c *AnnotateDeployment) Icon() string {
+	return "rocket"
+}
+
+func (c *AnnotateDeployment) Color() string {
+	return "gray"
+}
+
+func (c *AnnotateDeployment) Documentation() string {
+	return `The Annotate Deployment component records a deployment as an event in Datadog, so it shows up next to the metrics of the service.
+
+## Use Cases
+
+- **Deployment tracking**: Correlate latency or error rate changes with the deployments that caused them
+- **Rollback visibility**: Record failed deployments and rollbacks in the event stream
+
+## Configuration
+
+- **Service**, **Version**, **Environment**: The deployed service, its version, and the environment it was deployed to
+- **Status**: Started, succeeded, failed or rolled back
+- **URL** and **Description**: Optional link and details added to the event text
+- **Tags**: Optional comma-separated list of additional tags
+
+The same deployment fields are used by the Grafana and Slack deployment components,
+so they can be mapped the same way for all of them.
+
+## Event
+
+- The title describes the deployment, e.g. ` + "`Deployed checkout v1.4.2 to production`" + `
+- The alert type follows the status: info when started, success when succeeded, error when failed, warning when rolled back
+- The event is tagged with ` + "`service`, `version`, `env`" + ` and ` + "`deployment_status`" + `
+- Events of the same deployment share an aggregation key, so Datadog groups them
+
+## Outputs
+
+The component emits the created event, like Create Event, with the deployment under ` + "`deployment`" + `.
+`
+}
+
+func (c *AnnotateDeployment) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *AnnotateDeployment) Configuration() []configuration.Field {
+	return append(core.DeploymentConfigurationFields(), configuration.Field{
+		Name:        "tags",
+		Label:       "Tags",
+		Type:        configuration.FieldTypeString,
+		Required:    false,
+		Description: "Comma-separated list of additional tags (e.g., team:payments)",
+		Placeholder: "team:payments",
+	})
+}
+
+func (c *AnnotateDeployment) Setup(ctx core.SetupContext) error {
+	_, err := core.DecodeDeployment(ctx.Configuration)
+	return err
+}
+
+func (c *AnnotateDeployment) Execute(ctx core.ExecutionContext) error {
+	deployment, err := core.DecodeDeployment(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	spec := AnnotateDeploymentSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	event, err := client.CreateEvent(CreateEventRequest{
+		Title:          deployment.Title(),
+		Text:           deployment.Text(),
+		AlertType:      deploymentAlertType(deployment.Status),
+		Priority:       "normal",
+		Tags:           append(deployment.Tags(), parseTags(spec.Tags)...),
+		AggregationKey: fmt.Sprintf("deployment:%s:%s:%s", deployment.Service, deployment.Environment, deployment.Version),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create event: %v", err)
+	}
+
+	payload := eventToMap(event)
+	payload["deployment"] = deployment.ToMap()
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.deployment.event",
+		[]any{payload},
+	)
+}
+
+func deploymentAlertType(status string) string {
+	switch status {
+	case core.DeploymentStatusSucceeded:
+		return "success"
+	case core.DeploymentStatusFailed:
+		return "error"
+	case core.DeploymentStatusRolledBack:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+func (c *AnnotateDeployment) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *AnnotateDeployment) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *AnnotateDeployment) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *AnnotateDeployment) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *AnnotateDeployment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *AnnotateDeployment) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
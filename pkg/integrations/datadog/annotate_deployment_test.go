@@ -0,0 +1,116 @@
+package datadog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__AnnotateDeployment__Setup(t *testing.T) {
+	component := &AnnotateDeployment{}
+
+	t.Run("missing service -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"version":     "v1.4.2",
+				"environment": "production",
+			},
+		})
+
+		require.ErrorContains(t, err, "service is required")
+	})
+
+	t.Run("valid configuration -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"service":     "checkout",
+				"version":     "{{ $['Build'].data.tag }}",
+				"environment": "production",
+				"status":      "succeeded",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__AnnotateDeployment__Execute(t *testing.T) {
+	component := &AnnotateDeployment{}
+
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{
+					"event": {
+						"id": 12345,
+						"title": "Failed to deploy checkout v1.4.2 to production",
+						"alert_type": "error",
+						"url": "https://app.datadoghq.com/event/event?id=12345"
+					},
+					"status": "ok"
+				}`)),
+			},
+		},
+	}
+
+	executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"service":     "checkout",
+			"version":     "v1.4.2",
+			"environment": "production",
+			"status":      "failed",
+			"url":         "https://ci.example.com/pipelines/42",
+			"tags":        "team:payments",
+		},
+		HTTP: httpContext,
+		Integration: &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":   "datadoghq.com",
+				"apiKey": "test-api-key",
+				"appKey": "test-app-key",
+			},
+		},
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "datadog.deployment.event", executionState.Type)
+
+	require.Len(t, httpContext.Requests, 1)
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+
+	request := CreateEventRequest{}
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Equal(t, "Failed to deploy checkout v1.4.2 to production", request.Title)
+	assert.Equal(t, "Failed to deploy checkout v1.4.2 to production\n\nhttps://ci.example.com/pipelines/42", request.Text)
+	assert.Equal(t, "error", request.AlertType)
+	assert.Equal(t, "deployment:checkout:production:v1.4.2", request.AggregationKey)
+	assert.Equal(t, []string{
+		"service:checkout",
+		"version:v1.4.2",
+		"env:production",
+		"deployment_status:failed",
+		"team:payments",
+	}, request.Tags)
+
+	require.Len(t, executionState.Payloads, 1)
+	payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, int64(12345), payload["id"])
+	assert.Equal(t, map[string]any{
+		"service":     "checkout",
+		"version":     "v1.4.2",
+		"environment": "production",
+		"status":      "failed",
+		"url":         "https://ci.example.com/pipelines/42",
+	}, payload["deployment"])
+}
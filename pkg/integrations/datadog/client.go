@@ -79,11 +79,12 @@ func (c *Client) ValidateCredentials() error {
 
 // CreateEventRequest represents the request payload for creating a Datadog event.
 type CreateEventRequest struct {
-	Title     string   `json:"title"`
-	Text      string   `json:"text"`
-	AlertType string   `json:"alert_type,omitempty"`
-	Priority  string   `json:"priority,omitempty"`
-	Tags      []string `json:"tags,omitempty"`
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	AlertType      string   `json:"alert_type,omitempty"`
+	Priority       string   `json:"priority,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	AggregationKey string   `json:"aggregation_key,omitempty"`
 }
 
 // Event represents a Datadog event response.
@@ -92,6 +92,7 @@ func (d *Datadog) Configuration() []configuration.Field {
 func (d *Datadog) Components() []core.Component {
 	return []core.Component{
 		&CreateEvent{},
+		&AnnotateDeployment{},
 	}
 }
 
@@ -161,8 +161,9 @@ func Test__Datadog__Components(t *testing.T) {
 	d := &Datadog{}
 	components := d.Components()
 
-	require.Len(t, components, 1)
+	require.Len(t, components, 2)
 	assert.Equal(t, "datadog.createEvent", components[0].Name())
+	assert.Equal(t, "datadog.annotateDeployment", components[1].Name())
 }
 
 func Test__Datadog__Triggers(t *testing.T) {
@@ -16,3 +16,13 @@ var exampleOutputCreateEvent map[string]any
 func (c *CreateEvent) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateEventOnce, exampleOutputCreateEventBytes, &exampleOutputCreateEvent)
 }
+
+//go:embed example_output_annotate_deployment.json
+var exampleOutputAnnotateDeploymentBytes []byte
+
+var exampleOutputAnnotateDeploymentOnce sync.Once
+var exampleOutputAnnotateDeployment map[string]any
+
+func (c *AnnotateDeployment) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputAnnotateDeploymentOnce, exampleOutputAnnotateDeploymentBytes, &exampleOutputAnnotateDeployment)
+}
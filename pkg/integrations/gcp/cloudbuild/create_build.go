@@ -49,7 +49,7 @@ type CreateBuildConfiguration struct {
 	Steps                  string   `json:"steps" mapstructure:"steps"`
 	Images                 []string `json:"images" mapstructure:"images"`
 	Substitutions          string   `json:"substitutions" mapstructure:"substitutions"`
-	Timeout                string   `json:"timeout" mapstructure:"timeout"`
+	Timeout                any      `json:"timeout" mapstructure:"timeout"`
 }
 
 type CreateBuildNodeMetadata struct {
@@ -366,10 +366,10 @@ func (c *CreateBuild) Configuration() []configuration.Field {
 		{
 			Name:        "timeout",
 			Label:       "Timeout",
-			Type:        configuration.FieldTypeString,
+			Type:        configuration.FieldTypeDuration,
 			Required:    false,
-			Description: "Build timeout in seconds format (e.g. 600s). Defaults to 10 minutes.",
-			Placeholder: "e.g. 600s",
+			Description: "Build timeout (e.g. 600s, 30m). Defaults to 10 minutes.",
+			Placeholder: "e.g. 30m",
 		},
 	}
 }
@@ -498,8 +498,13 @@ func buildRequest(config CreateBuildConfiguration) (map[string]any, error) {
 		build["substitutions"] = subs
 	}
 
-	if config.Timeout != "" {
-		build["timeout"] = config.Timeout
+	if config.Timeout != nil && config.Timeout != "" {
+		timeout, err := configuration.ParseDuration(config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+
+		build["timeout"] = fmt.Sprintf("%ds", int64(timeout.Seconds()))
 	}
 
 	return build, nil
@@ -553,7 +558,9 @@ func normalizeCreateBuildConfiguration(raw any) (CreateBuildConfiguration, error
 	config.CommitSHA = strings.TrimSpace(config.CommitSHA)
 	config.Steps = strings.TrimSpace(config.Steps)
 	config.Substitutions = strings.TrimSpace(config.Substitutions)
-	config.Timeout = strings.TrimSpace(config.Timeout)
+	if timeout, ok := config.Timeout.(string); ok {
+		config.Timeout = strings.TrimSpace(timeout)
+	}
 	return config, nil
 }
 
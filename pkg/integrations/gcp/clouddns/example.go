@@ -16,6 +16,9 @@ var exampleOutputDeleteRecordBytes []byte
 //go:embed example_output_update_record.json
 var exampleOutputUpdateRecordBytes []byte
 
+//go:embed example_output_upsert_record.json
+var exampleOutputUpsertRecordBytes []byte
+
 var (
 	exampleOutputCreateRecordOnce sync.Once
 	exampleOutputCreateRecord     map[string]any
@@ -25,6 +28,9 @@ var (
 
 	exampleOutputUpdateRecordOnce sync.Once
 	exampleOutputUpdateRecord     map[string]any
+
+	exampleOutputUpsertRecordOnce sync.Once
+	exampleOutputUpsertRecord     map[string]any
 )
 
 func (c *CreateRecord) ExampleOutput() map[string]any {
@@ -38,3 +44,7 @@ func (c *DeleteRecord) ExampleOutput() map[string]any {
 func (c *UpdateRecord) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputUpdateRecordOnce, exampleOutputUpdateRecordBytes, &exampleOutputUpdateRecord)
 }
+
+func (c *UpsertRecord) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputUpsertRecordOnce, exampleOutputUpsertRecordBytes, &exampleOutputUpsertRecord)
+}
@@ -0,0 +1,213 @@
+package clouddns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type UpsertRecord struct{}
+
+type UpsertRecordConfiguration struct {
+	ManagedZone string   `json:"managedZone" mapstructure:"managedZone"`
+	Name        string   `json:"name" mapstructure:"name"`
+	Type        string   `json:"type" mapstructure:"type"`
+	TTL         int      `json:"ttl" mapstructure:"ttl"`
+	Rrdatas     []string `json:"rrdatas" mapstructure:"rrdatas"`
+}
+
+func (c *UpsertRecord) Name() string {
+	return "gcp.clouddns.upsertRecord"
+}
+
+func (c *UpsertRecord) Label() string {
+	return "Cloud DNS • Upsert Record"
+}
+
+func (c *UpsertRecord) Description() string {
+	return "Create or replace a DNS record in a Google Cloud DNS managed zone"
+}
+
+func (c *UpsertRecord) Documentation() string {
+	return `The Upsert Record component creates a DNS record set in a Google Cloud DNS managed zone, or replaces it if it already exists.
+
+## Use Cases
+
+- **Register VMs**: Point a name at the IP address of a VM created earlier in the workflow, e.g. ` + "`{{ $['Create Virtual Machine'].data.externalIP }}`" + `
+- **Re-runnable workflows**: Unlike Create Record, running the workflow again does not fail because the record exists
+
+## Configuration
+
+- **Managed Zone** (required): The Cloud DNS managed zone of the record.
+- **Record Name** (required): The DNS name for the record (e.g. ` + "`api.example.com`" + `). A trailing dot is added automatically.
+- **Record Type** (required): The DNS record type (A, AAAA, CNAME, TXT, MX, etc.).
+- **TTL** (required): Time to live in seconds. Defaults to 300.
+- **Record Values** (required): The values for the record (e.g. IP addresses for A records).
+
+If the record already has the same TTL and values, no change is submitted and ` + "`change.id`" + ` is empty.
+
+## Required IAM roles
+
+The service account must have ` + "`roles/dns.admin`" + ` or ` + "`roles/dns.editor`" + ` on the project.
+
+## Output
+
+- ` + "`change.id`" + `: The Cloud DNS change ID.
+- ` + "`change.status`" + `: The change status (` + "`done`" + `).
+- ` + "`change.startTime`" + `: When the change was submitted.
+- ` + "`record.name`" + `: The DNS record name.
+- ` + "`record.type`" + `: The DNS record type.`
+}
+
+func (c *UpsertRecord) Icon() string  { return "gcp" }
+func (c *UpsertRecord) Color() string { return "gray" }
+
+func (c *UpsertRecord) OutputChannels(_ any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *UpsertRecord) Configuration() []configuration.Field {
+	fields := baseRecordConfigurationFields()
+	fields = append(fields, ttlConfigurationField())
+	fields = append(fields, rrdatasConfigurationField())
+	return fields
+}
+
+func decodeUpsertRecordConfig(raw any) (UpsertRecordConfiguration, error) {
+	var config UpsertRecordConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return UpsertRecordConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	config.ManagedZone = strings.TrimSpace(config.ManagedZone)
+	config.Name = normalizeRecordName(config.Name)
+	config.Type = strings.TrimSpace(config.Type)
+	config.Rrdatas = normalizeRrdatas(config.Rrdatas)
+	return config, nil
+}
+
+func (c *UpsertRecord) Setup(ctx core.SetupContext) error {
+	config, err := decodeUpsertRecordConfig(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+	if err := validateBaseConfig(config.ManagedZone, config.Name, config.Type); err != nil {
+		return err
+	}
+	return validateRrdatas(config.Rrdatas)
+}
+
+func (c *UpsertRecord) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeUpsertRecordConfig(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	projectID := client.ProjectID()
+	existing, err := getRecordSet(context.Background(), client, projectID, config.ManagedZone, config.Name, config.Type)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to look up existing record: %v", err))
+	}
+
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = 300
+	}
+
+	record := ResourceRecordSet{
+		Name:    config.Name,
+		Type:    config.Type,
+		TTL:     ttl,
+		Rrdatas: config.Rrdatas,
+	}
+
+	// Nothing to submit when the record is already up to date, so re-running
+	// a workflow does not create empty changes.
+	if existing != nil && recordSetsEqual(*existing, record) {
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, "gcp.clouddns.change", []any{
+			buildChangeOutput(&ChangeInfo{Status: "done"}, config.Name, config.Type),
+		})
+	}
+
+	var deletions []ResourceRecordSet
+	if existing != nil {
+		deletions = []ResourceRecordSet{*existing}
+	}
+
+	change, err := applyChange(context.Background(), client, projectID, config.ManagedZone, []ResourceRecordSet{record}, deletions)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to upsert DNS record: %v", err))
+	}
+
+	if change.Status == "done" {
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, "gcp.clouddns.change", []any{
+			buildChangeOutput(change, config.Name, config.Type),
+		})
+	}
+
+	if change.Status != "pending" {
+		return ctx.ExecutionState.Fail(
+			"error",
+			fmt.Sprintf("unexpected Cloud DNS change status %q for change %q", change.Status, change.ID),
+		)
+	}
+
+	if err := ctx.Metadata.Set(RecordSetPollMetadata{
+		ChangeID:    change.ID,
+		ManagedZone: config.ManagedZone,
+		RecordName:  config.Name,
+		RecordType:  config.Type,
+		StartTime:   change.StartTime,
+	}); err != nil {
+		return fmt.Errorf("failed to set poll metadata: %w", err)
+	}
+	return ctx.Requests.ScheduleActionCall(pollChangeActionName, map[string]any{}, pollInterval)
+}
+
+// recordSetsEqual reports whether two record sets have the same TTL and values, in any order.
+func recordSetsEqual(a, b ResourceRecordSet) bool {
+	if a.TTL != b.TTL || len(a.Rrdatas) != len(b.Rrdatas) {
+		return false
+	}
+	left := slices.Clone(a.Rrdatas)
+	right := slices.Clone(b.Rrdatas)
+	slices.Sort(left)
+	slices.Sort(right)
+	return slices.Equal(left, right)
+}
+
+func (c *UpsertRecord) Actions() []core.Action {
+	return []core.Action{
+		{Name: pollChangeActionName, Description: "Poll for change status"},
+	}
+}
+
+func (c *UpsertRecord) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case pollChangeActionName:
+		return pollChangeUntilDone(ctx)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *UpsertRecord) HandleWebhook(_ core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *UpsertRecord) Cancel(_ core.ExecutionContext) error { return nil }
+func (c *UpsertRecord) Cleanup(_ core.SetupContext) error    { return nil }
+func (c *UpsertRecord) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
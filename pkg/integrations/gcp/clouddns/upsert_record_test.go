@@ -0,0 +1,231 @@
+package clouddns
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	testcontexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func TestUpsertRecord_Metadata(t *testing.T) {
+	c := &UpsertRecord{}
+	assert.Equal(t, "gcp.clouddns.upsertRecord", c.Name())
+	assert.Equal(t, "Cloud DNS • Upsert Record", c.Label())
+	assert.NotEmpty(t, c.Description())
+	assert.Equal(t, "gcp", c.Icon())
+}
+
+func TestUpsertRecord_ExampleOutput(t *testing.T) {
+	c := &UpsertRecord{}
+	output := c.ExampleOutput()
+	assert.NotEmpty(t, output["type"])
+	assert.NotEmpty(t, output["data"])
+}
+
+func TestUpsertRecord_Setup(t *testing.T) {
+	c := &UpsertRecord{}
+
+	t.Run("succeeds with valid config", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"managedZone": "my-zone",
+				"name":        "api.example.com",
+				"type":        "A",
+				"ttl":         300,
+				"rrdatas":     []string{"1.2.3.4"},
+			},
+			Metadata: &testcontexts.MetadataContext{},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("fails when managed zone is missing", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"name":    "api.example.com",
+				"type":    "A",
+				"rrdatas": []string{"1.2.3.4"},
+			},
+			Metadata: &testcontexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "managed zone is required")
+	})
+
+	t.Run("fails when rrdatas is empty", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"managedZone": "my-zone",
+				"name":        "api.example.com",
+				"type":        "A",
+				"rrdatas":     []string{" "},
+			},
+			Metadata: &testcontexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "at least one record value is required")
+	})
+}
+
+func TestUpsertRecord_Execute(t *testing.T) {
+	configuration := map[string]any{
+		"managedZone": "my-zone",
+		"name":        "api.example.com",
+		"type":        "A",
+		"ttl":         300,
+		"rrdatas":     []string{"5.6.7.8"},
+	}
+
+	rrsets := func(rrdatas ...any) map[string]any {
+		if len(rrdatas) == 0 {
+			return map[string]any{"rrsets": []any{}}
+		}
+		return map[string]any{
+			"rrsets": []any{
+				map[string]any{
+					"name":    "api.example.com.",
+					"type":    "A",
+					"ttl":     float64(300),
+					"rrdatas": rrdatas,
+				},
+			},
+		}
+	}
+
+	t.Run("creates record when it does not exist", func(t *testing.T) {
+		var capturedBody any
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(rrsets())
+				},
+				postURL: func(_ context.Context, _ string, body any) ([]byte, error) {
+					capturedBody = body
+					return json.Marshal(map[string]any{
+						"id":        "20",
+						"status":    "done",
+						"startTime": "2026-01-28T10:30:00.000Z",
+					})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := (&UpsertRecord{}).Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			ExecutionState: state,
+			Metadata:       &testcontexts.MetadataContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+
+		bodyMap := capturedBody.(map[string]any)
+		additions := bodyMap["additions"].([]ResourceRecordSet)
+		assert.Equal(t, "api.example.com.", additions[0].Name)
+		assert.Equal(t, []string{"5.6.7.8"}, additions[0].Rrdatas)
+		assert.NotContains(t, bodyMap, "deletions")
+	})
+
+	t.Run("replaces existing record", func(t *testing.T) {
+		var capturedBody any
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(rrsets("1.2.3.4"))
+				},
+				postURL: func(_ context.Context, _ string, body any) ([]byte, error) {
+					capturedBody = body
+					return json.Marshal(map[string]any{
+						"id":        "21",
+						"status":    "done",
+						"startTime": "2026-01-28T10:30:00.000Z",
+					})
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := (&UpsertRecord{}).Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			ExecutionState: state,
+			Metadata:       &testcontexts.MetadataContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+
+		bodyMap := capturedBody.(map[string]any)
+		additions := bodyMap["additions"].([]ResourceRecordSet)
+		deletions := bodyMap["deletions"].([]ResourceRecordSet)
+		assert.Equal(t, []string{"5.6.7.8"}, additions[0].Rrdatas)
+		assert.Equal(t, []string{"1.2.3.4"}, deletions[0].Rrdatas)
+	})
+
+	t.Run("skips change when record is up to date", func(t *testing.T) {
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(rrsets("5.6.7.8"))
+				},
+				postURL: func(_ context.Context, _ string, _ any) ([]byte, error) {
+					t.Fatal("unexpected change submitted")
+					return nil, nil
+				},
+			}, nil
+		})
+
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := (&UpsertRecord{}).Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			ExecutionState: state,
+			Metadata:       &testcontexts.MetadataContext{},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		data := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "done", data["change"].(map[string]any)["status"])
+		assert.Equal(t, "", data["change"].(map[string]any)["id"])
+	})
+
+	t.Run("schedules poll when change is pending", func(t *testing.T) {
+		SetClientFactory(func(_ core.HTTPContext, _ core.IntegrationContext) (Client, error) {
+			return &mockClient{
+				projectID: "my-project",
+				getURL: func(_ context.Context, _ string) ([]byte, error) {
+					return json.Marshal(rrsets())
+				},
+				postURL: func(_ context.Context, _ string, _ any) ([]byte, error) {
+					return json.Marshal(map[string]any{
+						"id":        "22",
+						"status":    "pending",
+						"startTime": "2026-01-28T10:30:00.000Z",
+					})
+				},
+			}, nil
+		})
+
+		meta := &testcontexts.MetadataContext{}
+		requests := &testcontexts.RequestContext{}
+		state := &testcontexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := (&UpsertRecord{}).Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			ExecutionState: state,
+			Metadata:       meta,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Finished)
+		assert.Equal(t, pollChangeActionName, requests.Action)
+		stored := meta.Get().(RecordSetPollMetadata)
+		assert.Equal(t, "22", stored.ChangeID)
+		assert.Equal(t, "my-zone", stored.ManagedZone)
+	})
+}
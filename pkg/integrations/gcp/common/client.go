@@ -104,6 +104,12 @@ func (c *Client) GetURL(ctx context.Context, fullURL string) ([]byte, error) {
 	return c.ExecRequest(ctx, http.MethodGet, fullURL, nil)
 }
 
+func (c *Client) Delete(ctx context.Context, path string) ([]byte, error) {
+	path = strings.TrimPrefix(path, "/")
+	url := strings.TrimSuffix(c.baseURL, "/") + "/" + path
+	return c.ExecRequest(ctx, http.MethodDelete, url, nil)
+}
+
 func marshalRequestBody(body any) (io.Reader, error) {
 	if body == nil {
 		return nil, nil
@@ -127,6 +133,16 @@ func (c *Client) Post(ctx context.Context, path string, body any) ([]byte, error
 	return c.ExecRequest(ctx, http.MethodPost, url, bodyReader)
 }
 
+func (c *Client) Patch(ctx context.Context, path string, body any) ([]byte, error) {
+	path = strings.TrimPrefix(path, "/")
+	url := strings.TrimSuffix(c.baseURL, "/") + "/" + path
+	bodyReader, err := marshalRequestBody(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.ExecRequest(ctx, http.MethodPatch, url, bodyReader)
+}
+
 func (c *Client) PostURL(ctx context.Context, fullURL string, body any) ([]byte, error) {
 	bodyReader, err := marshalRequestBody(body)
 	if err != nil {
@@ -6,12 +6,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/core"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
+var serviceAccountTokenSources = core.NewClientPool[oauth2.TokenSource]()
+
 type wifMetadata struct {
 	AccessTokenExpiresAt string `json:"accessTokenExpiresAt" mapstructure:"accessTokenExpiresAt"`
 }
@@ -29,11 +32,20 @@ func TokenSourceFromIntegration(ctx core.IntegrationContext, scopes ...string) (
 		if len(scopes) == 0 {
 			scopes = []string{ScopeCloudPlatform}
 		}
-		creds, err := google.CredentialsFromJSONWithType(context.Background(), keyJSON, google.ServiceAccount, scopes...)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create credentials from service account key: %w", err)
-		}
-		return creds.TokenSource, nil
+		//
+		// The token source caches its access token until it expires,
+		// so it is shared by every call to the installation with the same scopes,
+		// instead of exchanging the key for a new token on every call.
+		//
+		key := strings.Join(scopes, " ")
+		fingerprint := core.ClientFingerprint(keyJSON)
+		return serviceAccountTokenSources.Get(ctx.ID(), key, fingerprint, func() (oauth2.TokenSource, error) {
+			creds, err := google.CredentialsFromJSONWithType(context.Background(), keyJSON, google.ServiceAccount, scopes...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create credentials from service account key: %w", err)
+			}
+			return creds.TokenSource, nil
+		})
 	}
 
 	accessToken := FindSecretValue(secrets, SecretNameAccessToken)
@@ -63,6 +75,14 @@ func TokenSourceFromIntegration(ctx core.IntegrationContext, scopes ...string) (
 	return oauth2.StaticTokenSource(tok), nil
 }
 
+/*
+ * RemoveTokenSources drops the token sources kept for an installation,
+ * when it is deleted or its credentials change.
+ */
+func RemoveTokenSources(integrationID uuid.UUID) {
+	serviceAccountTokenSources.Remove(integrationID)
+}
+
 func CredentialsFromIntegration(ctx core.IntegrationContext, scopes ...string) (*google.Credentials, error) {
 	ts, err := TokenSourceFromIntegration(ctx, scopes...)
 	if err != nil {
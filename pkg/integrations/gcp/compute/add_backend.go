@@ -0,0 +1,665 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	addBackendPayloadType = "gcp.compute.backend"
+
+	BalancingModeUtilization = "UTILIZATION"
+	BalancingModeRate        = "RATE"
+	BalancingModeConnection  = "CONNECTION"
+
+	healthStateHealthy = "HEALTHY"
+
+	//
+	// Backend services send traffic to this port name
+	// when they don't set one.
+	//
+	defaultBackendServicePortName = "http"
+
+	defaultHealthTimeoutMinutes = 5
+	maxHealthTimeoutMinutes     = 30
+	backendHealthPollInterval   = 10 * time.Second
+)
+
+type AddBackend struct{}
+
+type AddBackendConfiguration struct {
+	BackendService            string `mapstructure:"backendService"`
+	Region                    string `mapstructure:"region"`
+	Zone                      string `mapstructure:"zone"`
+	InstanceGroup             string `mapstructure:"instanceGroup"`
+	Port                      int64  `mapstructure:"port"`
+	BalancingMode             string `mapstructure:"balancingMode"`
+	MaxUtilization            int64  `mapstructure:"maxUtilization"`
+	MaxRatePerInstance        int64  `mapstructure:"maxRatePerInstance"`
+	MaxConnectionsPerInstance int64  `mapstructure:"maxConnectionsPerInstance"`
+	WaitForHealthy            bool   `mapstructure:"waitForHealthy"`
+	HealthTimeoutMinutes      int64  `mapstructure:"healthTimeoutMinutes"`
+}
+
+type backendServiceResp struct {
+	Name        string           `json:"name"`
+	SelfLink    string           `json:"selfLink"`
+	PortName    string           `json:"portName"`
+	Fingerprint string           `json:"fingerprint"`
+	Backends    []map[string]any `json:"backends"`
+}
+
+/*
+ * BackendHealth is the health of an instance of the group,
+ * as seen by the health checks of the backend service.
+ */
+type BackendHealth struct {
+	Instance    string `json:"instance"`
+	HealthState string `json:"healthState"`
+}
+
+type backendHealthResp struct {
+	HealthStatus []struct {
+		Instance    string `json:"instance"`
+		HealthState string `json:"healthState"`
+	} `json:"healthStatus"`
+}
+
+func (c *AddBackend) Name() string {
+	return "gcp.addBackend"
+}
+
+func (c *AddBackend) Label() string {
+	return "Compute • Add Backend"
+}
+
+func (c *AddBackend) Description() string {
+	return "Attach an instance group as a backend of an existing load balancer backend service, and wait for it to be healthy"
+}
+
+func (c *AddBackend) Documentation() string {
+	return `The Add Backend component attaches an instance group to an existing backend service of a load balancer, so its instances start serving traffic.
+
+## Use Cases
+
+- **Provisioning**: End a Create Virtual Machine, Create Instance Group and Add Instances to Instance Group flow with traffic being served
+- **Blue/green rollouts**: Attach the group of the new version, wait for it to be healthy, then detach the old one
+
+## Configuration
+
+- **Backend service**: The name of the backend service.
+- **Region**: The region of a regional backend service. Leave empty for a global backend service.
+- **Zone** and **Instance group**: The instance group to attach, e.g. from the output of a Create Instance Group step.
+- **Port**: The port the instances serve on. The group gets a named port with the port name of the backend service, ` + "`http`" + ` when the service has none. Leave empty when the group already has the named port.
+- **Balancing mode**: Utilization, rate or connections, with the target utilization, requests per second or connections per instance.
+- **Wait for healthy**: Wait until the health checks of the backend service report every instance of the group as healthy, up to the health timeout. The execution fails if they are not healthy by then.
+
+A group that is already a backend of the service is not attached again.
+
+## Output
+
+The backend service, its region (or global), the instance group, zone, port name, balancing mode, whether the group was already attached, whether every instance is healthy, and the health of each instance.`
+}
+
+func (c *AddBackend) Icon() string {
+	return "gcp"
+}
+
+func (c *AddBackend) Color() string {
+	return "gray"
+}
+
+// DefaultExecutionTimeout leaves time for the longest health timeout,
+// after the backend service operation.
+func (c *AddBackend) DefaultExecutionTimeout() time.Duration {
+	return operationExecutionTimeout + maxHealthTimeoutMinutes*time.Minute
+}
+
+func (c *AddBackend) ExampleOutput() map[string]any {
+	return map[string]any{
+		"backendService":  "web-backend",
+		"region":          "global",
+		"instanceGroup":   "web",
+		"zone":            "us-central1-a",
+		"portName":        "http",
+		"balancingMode":   BalancingModeUtilization,
+		"alreadyAttached": false,
+		"healthy":         true,
+		"health": []any{
+			map[string]any{"instance": "web-1", "healthState": healthStateHealthy},
+			map[string]any{"instance": "web-2", "healthState": healthStateHealthy},
+		},
+	}
+}
+
+func (c *AddBackend) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *AddBackend) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "backendService",
+			Label:       "Backend service",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the backend service.",
+			Placeholder: "e.g. web-backend",
+		},
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Region of a regional backend service. Leave empty for a global backend service.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeRegion,
+				},
+			},
+		},
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "GCP zone of the instance group (e.g. us-central1-a).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeZone,
+				},
+			},
+		},
+		{
+			Name:        "instanceGroup",
+			Label:       "Instance group",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the instance group to attach.",
+			Placeholder: "e.g. {{ $['Create Instance Group'].data.name }}",
+		},
+		{
+			Name:        "port",
+			Label:       "Port",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Port the instances serve on, set as the named port of the group for the port name of the backend service.",
+			Placeholder: "e.g. 8080",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(65535)},
+			},
+		},
+		{
+			Name:        "balancingMode",
+			Label:       "Balancing mode",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "How the load balancer spreads traffic over the backends.",
+			Default:     BalancingModeUtilization,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Utilization", Value: BalancingModeUtilization},
+						{Label: "Rate", Value: BalancingModeRate},
+						{Label: "Connections", Value: BalancingModeConnection},
+					},
+				},
+			},
+		},
+		{
+			Name:        "maxUtilization",
+			Label:       "Target utilization (%)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "CPU utilization of the instances above which traffic goes to other backends.",
+			Default:     80,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(100)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "balancingMode", Values: []string{BalancingModeUtilization}},
+			},
+		},
+		{
+			Name:        "maxRatePerInstance",
+			Label:       "Requests per second per instance",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Target requests per second of each instance.",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "balancingMode", Values: []string{BalancingModeRate}},
+			},
+		},
+		{
+			Name:        "maxConnectionsPerInstance",
+			Label:       "Connections per instance",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Target concurrent connections of each instance.",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "balancingMode", Values: []string{BalancingModeConnection}},
+			},
+		},
+		{
+			Name:        "waitForHealthy",
+			Label:       "Wait for healthy",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Wait until the health checks report every instance of the group as healthy.",
+			Default:     true,
+		},
+		{
+			Name:        "healthTimeoutMinutes",
+			Label:       "Health timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "How long to wait for the instances to be healthy.",
+			Default:     defaultHealthTimeoutMinutes,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(maxHealthTimeoutMinutes)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "waitForHealthy", Values: []string{"true"}},
+			},
+		},
+	}
+}
+
+func decodeAddBackendConfiguration(raw any) (AddBackendConfiguration, error) {
+	config := AddBackendConfiguration{WaitForHealthy: true}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return AddBackendConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.BackendService = lastSegment(strings.TrimSpace(config.BackendService))
+	config.Region = lastSegment(strings.TrimSpace(config.Region))
+	config.Zone = lastSegment(strings.TrimSpace(config.Zone))
+	config.InstanceGroup = lastSegment(strings.TrimSpace(config.InstanceGroup))
+	config.BalancingMode = strings.TrimSpace(config.BalancingMode)
+	if config.BalancingMode == "" {
+		config.BalancingMode = BalancingModeUtilization
+	}
+
+	if config.BalancingMode == BalancingModeUtilization && config.MaxUtilization == 0 {
+		config.MaxUtilization = 80
+	}
+
+	if config.HealthTimeoutMinutes == 0 {
+		config.HealthTimeoutMinutes = defaultHealthTimeoutMinutes
+	}
+
+	return config, nil
+}
+
+func validateAddBackendConfiguration(config AddBackendConfiguration) error {
+	if config.BackendService == "" {
+		return fmt.Errorf("backendService is required")
+	}
+
+	if config.Zone == "" {
+		return fmt.Errorf("zone is required")
+	}
+
+	if config.InstanceGroup == "" {
+		return fmt.Errorf("instanceGroup is required")
+	}
+
+	if config.Port < 0 || config.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+
+	switch config.BalancingMode {
+	case BalancingModeUtilization:
+		if config.MaxUtilization < 1 || config.MaxUtilization > 100 {
+			return fmt.Errorf("maxUtilization must be between 1 and 100")
+		}
+	case BalancingModeRate:
+		if config.MaxRatePerInstance < 1 {
+			return fmt.Errorf("maxRatePerInstance is required for the RATE balancing mode")
+		}
+	case BalancingModeConnection:
+		if config.MaxConnectionsPerInstance < 1 {
+			return fmt.Errorf("maxConnectionsPerInstance is required for the CONNECTION balancing mode")
+		}
+	default:
+		return fmt.Errorf("invalid balancingMode %q", config.BalancingMode)
+	}
+
+	if config.HealthTimeoutMinutes < 1 || config.HealthTimeoutMinutes > maxHealthTimeoutMinutes {
+		return fmt.Errorf("healthTimeoutMinutes must be between 1 and %d", maxHealthTimeoutMinutes)
+	}
+
+	return nil
+}
+
+func (c *AddBackend) Setup(ctx core.SetupContext) error {
+	config, err := decodeAddBackendConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateAddBackendConfiguration(config)
+}
+
+func (c *AddBackend) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *AddBackend) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeAddBackendConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := validateAddBackendConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	callCtx := ctx.GoContext()
+	project := client.ProjectID()
+	servicePath := backendServicePath(project, config.Region, config.BackendService)
+	groupPath := fmt.Sprintf("projects/%s/zones/%s/instanceGroups/%s", project, config.Zone, config.InstanceGroup)
+
+	body, err := client.Get(callCtx, servicePath)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get backend service %s: %v", config.BackendService, err))
+	}
+
+	var service backendServiceResp
+	if err := json.Unmarshal(body, &service); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse backend service response: %v", err))
+	}
+
+	portName := service.PortName
+	if portName == "" {
+		portName = defaultBackendServicePortName
+	}
+
+	if config.Port > 0 {
+		if err := ensureNamedPort(callCtx, client, project, config.Zone, config.InstanceGroup, NamedPortEntry{Name: portName, Port: config.Port}); err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+	}
+
+	alreadyAttached := hasBackendGroup(service.Backends, groupPath)
+	if !alreadyAttached {
+		service.Backends = append(service.Backends, buildBackend(groupPath, config))
+		path := fmt.Sprintf("%s?requestId=%s", servicePath, url.QueryEscape(ctx.IdempotencyKey()))
+		body, err := client.Patch(callCtx, path, map[string]any{
+			"backends":    service.Backends,
+			"fingerprint": service.Fingerprint,
+		})
+
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to add backend to %s: %v", config.BackendService, err))
+		}
+
+		operation, err := operationName(body)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+
+		if config.Region == "" {
+			err = WaitForGlobalOperation(callCtx, client, project, operation)
+		} else {
+			err = WaitForRegionOperation(callCtx, client, project, config.Region, operation)
+		}
+
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to add backend to %s: %v", config.BackendService, err))
+		}
+	}
+
+	health := []BackendHealth{}
+	healthy := false
+	if config.WaitForHealthy {
+		timeout := time.Duration(config.HealthTimeoutMinutes) * time.Minute
+		health, healthy, err = waitForHealthyBackend(callCtx, client, servicePath, groupPath, timeout)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+
+		if !healthy {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf(
+				"instances of %s are not healthy after %d minutes: %s",
+				config.InstanceGroup,
+				config.HealthTimeoutMinutes,
+				unhealthyInstances(health),
+			))
+		}
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "global"
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, addBackendPayloadType, []any{
+		map[string]any{
+			"backendService":  config.BackendService,
+			"region":          region,
+			"instanceGroup":   config.InstanceGroup,
+			"zone":            config.Zone,
+			"portName":        portName,
+			"balancingMode":   config.BalancingMode,
+			"alreadyAttached": alreadyAttached,
+			"healthy":         healthy,
+			"health":          health,
+		},
+	})
+}
+
+func backendServicePath(project, region, name string) string {
+	if region == "" {
+		return fmt.Sprintf("projects/%s/global/backendServices/%s", project, name)
+	}
+
+	return fmt.Sprintf("projects/%s/regions/%s/backendServices/%s", project, region, name)
+}
+
+/*
+ * Backends reference their group with a full URL,
+ * so they are compared with the group path as a suffix.
+ */
+func hasBackendGroup(backends []map[string]any, groupPath string) bool {
+	for _, backend := range backends {
+		group, _ := backend["group"].(string)
+		if group == groupPath || strings.HasSuffix(group, "/"+groupPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func buildBackend(groupPath string, config AddBackendConfiguration) map[string]any {
+	backend := map[string]any{
+		"group":         groupPath,
+		"balancingMode": config.BalancingMode,
+	}
+
+	switch config.BalancingMode {
+	case BalancingModeUtilization:
+		backend["maxUtilization"] = float64(config.MaxUtilization) / 100
+	case BalancingModeRate:
+		backend["maxRatePerInstance"] = config.MaxRatePerInstance
+	case BalancingModeConnection:
+		backend["maxConnectionsPerInstance"] = config.MaxConnectionsPerInstance
+	}
+
+	return backend
+}
+
+/*
+ * Sets a named port on an instance group, keeping its other named ports.
+ * setNamedPorts replaces all of them, with the fingerprint of the group
+ * guarding against concurrent changes.
+ */
+func ensureNamedPort(ctx context.Context, client Client, project, zone, groupName string, port NamedPortEntry) error {
+	group, err := getInstanceGroup(ctx, client, project, zone, groupName)
+	if err != nil {
+		return err
+	}
+
+	namedPorts := []NamedPortEntry{}
+	for _, existing := range group.NamedPorts {
+		if existing.Name == port.Name {
+			if existing.Port == port.Port {
+				return nil
+			}
+
+			continue
+		}
+
+		namedPorts = append(namedPorts, existing)
+	}
+
+	namedPorts = append(namedPorts, port)
+	path := fmt.Sprintf("projects/%s/zones/%s/instanceGroups/%s/setNamedPorts", project, zone, groupName)
+	body, err := client.Post(ctx, path, map[string]any{
+		"namedPorts":  namedPorts,
+		"fingerprint": group.Fingerprint,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set named port %s on %s: %w", port.Name, groupName, err)
+	}
+
+	operation, err := operationName(body)
+	if err != nil {
+		return err
+	}
+
+	if err := WaitForZoneOperation(ctx, client, project, zone, operation); err != nil {
+		return fmt.Errorf("failed to set named port %s on %s: %w", port.Name, groupName, err)
+	}
+
+	return nil
+}
+
+/*
+ * Polls the health of the instances of the group, until they are all healthy,
+ * or the timeout expires. The last health seen is returned in both cases.
+ */
+func waitForHealthyBackend(ctx context.Context, client Client, servicePath, groupPath string, timeout time.Duration) ([]BackendHealth, bool, error) {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(backendHealthPollInterval)
+	defer ticker.Stop()
+
+	for {
+		health, err := getBackendHealth(ctx, client, servicePath, groupPath)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if allHealthy(health) {
+			return health, true, nil
+		}
+
+		if time.Now().After(deadline) {
+			return health, false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func getBackendHealth(ctx context.Context, client Client, servicePath, groupPath string) ([]BackendHealth, error) {
+	body, err := client.Post(ctx, servicePath+"/getHealth", map[string]any{"group": groupPath})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backend health: %w", err)
+	}
+
+	var resp backendHealthResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse backend health response: %w", err)
+	}
+
+	health := []BackendHealth{}
+	for _, status := range resp.HealthStatus {
+		health = append(health, BackendHealth{
+			Instance:    lastSegment(status.Instance),
+			HealthState: status.HealthState,
+		})
+	}
+
+	return health, nil
+}
+
+/*
+ * A group with no health status yet is not healthy,
+ * since the health checks have not probed its instances.
+ */
+func allHealthy(health []BackendHealth) bool {
+	if len(health) == 0 {
+		return false
+	}
+
+	for _, h := range health {
+		if h.HealthState != healthStateHealthy {
+			return false
+		}
+	}
+
+	return true
+}
+
+func unhealthyInstances(health []BackendHealth) string {
+	if len(health) == 0 {
+		return "no health status reported"
+	}
+
+	unhealthy := []string{}
+	for _, h := range health {
+		if h.HealthState != healthStateHealthy {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", h.Instance, h.HealthState))
+		}
+	}
+
+	return strings.Join(unhealthy, ", ")
+}
+
+func (c *AddBackend) Actions() []core.Action {
+	return nil
+}
+
+func (c *AddBackend) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *AddBackend) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *AddBackend) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *AddBackend) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
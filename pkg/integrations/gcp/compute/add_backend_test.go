@@ -0,0 +1,157 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_AddBackend_Setup(t *testing.T) {
+	component := &AddBackend{}
+	base := func(extra map[string]any) map[string]any {
+		configuration := map[string]any{"backendService": "web-backend", "zone": "us-central1-a", "instanceGroup": "web"}
+		for k, v := range extra {
+			configuration[k] = v
+		}
+
+		return configuration
+	}
+
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		err           string
+	}{
+		{name: "backend service is required", configuration: map[string]any{"zone": "us-central1-a", "instanceGroup": "web"}, err: "backendService is required"},
+		{name: "instance group is required", configuration: map[string]any{"backendService": "web-backend", "zone": "us-central1-a"}, err: "instanceGroup is required"},
+		{name: "rate is required", configuration: base(map[string]any{"balancingMode": BalancingModeRate}), err: "maxRatePerInstance is required"},
+		{name: "utilization is limited", configuration: base(map[string]any{"maxUtilization": 120}), err: "maxUtilization must be between 1 and 100"},
+		{name: "health timeout is limited", configuration: base(map[string]any{"healthTimeoutMinutes": 60}), err: "healthTimeoutMinutes must be between 1 and 30"},
+		{name: "valid", configuration: base(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.Setup(core.SetupContext{Configuration: tt.configuration})
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_AddBackend_Execute(t *testing.T) {
+	const groupURL = "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instanceGroups/web"
+
+	newClient := func(backendService string, patched *map[string]any, namedPorts *any) *mockOSClient {
+		return &mockOSClient{
+			projectID: "p",
+			get: func(ctx context.Context, path string) ([]byte, error) {
+				switch path {
+				case "projects/p/global/backendServices/web-backend":
+					return []byte(backendService), nil
+				case "projects/p/zones/us-central1-a/instanceGroups/web":
+					return []byte(`{"name": "web", "fingerprint": "ig-fp", "namedPorts": [{"name": "metrics", "port": 9090}]}`), nil
+				case "projects/p/global/operations/operation-1", "projects/p/zones/us-central1-a/operations/operation-2":
+					return []byte(`{"status": "DONE"}`), nil
+				}
+
+				return nil, fmt.Errorf("unexpected GET %s", path)
+			},
+			post: func(ctx context.Context, path string, body any) ([]byte, error) {
+				switch path {
+				case "projects/p/zones/us-central1-a/instanceGroups/web/setNamedPorts":
+					*namedPorts = body
+					return []byte(`{"name": "operation-2"}`), nil
+				case "projects/p/global/backendServices/web-backend/getHealth":
+					return []byte(`{"healthStatus": [
+						{"instance": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/web-1", "healthState": "HEALTHY"}
+					]}`), nil
+				}
+
+				return nil, fmt.Errorf("unexpected POST %s", path)
+			},
+			patch: func(ctx context.Context, path string, body any) ([]byte, error) {
+				*patched = body.(map[string]any)
+				return []byte(`{"name": "operation-1"}`), nil
+			},
+		}
+	}
+
+	t.Run("group is attached, with its named port, and waits for health", func(t *testing.T) {
+		var patched map[string]any
+		var namedPorts any
+		useInstanceClient(t, newClient(`{
+			"name": "web-backend",
+			"portName": "web",
+			"fingerprint": "bs-fp",
+			"backends": [{"group": "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-b/instanceGroups/old", "balancingMode": "RATE", "maxRatePerInstance": 100}]
+		}`, &patched, &namedPorts))
+
+		state := &contexts.ExecutionStateContext{}
+		err := (&AddBackend{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"backendService": "web-backend",
+				"zone":           "us-central1-a",
+				"instanceGroup":  "web",
+				"port":           8080,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"namedPorts":  []NamedPortEntry{{Name: "metrics", Port: 9090}, {Name: "web", Port: 8080}},
+			"fingerprint": "ig-fp",
+		}, namedPorts)
+
+		assert.Equal(t, "bs-fp", patched["fingerprint"])
+		backends := patched["backends"].([]map[string]any)
+		require.Len(t, backends, 2)
+		assert.Equal(t, map[string]any{
+			"group":          "projects/p/zones/us-central1-a/instanceGroups/web",
+			"balancingMode":  BalancingModeUtilization,
+			"maxUtilization": 0.8,
+		}, backends[1])
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "global", payload["region"])
+		assert.Equal(t, "web", payload["portName"])
+		assert.Equal(t, false, payload["alreadyAttached"])
+		assert.Equal(t, true, payload["healthy"])
+		assert.Equal(t, []BackendHealth{{Instance: "web-1", HealthState: healthStateHealthy}}, payload["health"])
+	})
+
+	t.Run("group already attached -> backend service is not updated", func(t *testing.T) {
+		var patched map[string]any
+		var namedPorts any
+		useInstanceClient(t, newClient(`{"name": "web-backend", "backends": [{"group": "`+groupURL+`"}]}`, &patched, &namedPorts))
+
+		state := &contexts.ExecutionStateContext{}
+		err := (&AddBackend{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"backendService": "web-backend",
+				"zone":           "us-central1-a",
+				"instanceGroup":  "web",
+				"waitForHealthy": false,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, patched)
+		assert.Nil(t, namedPorts)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["alreadyAttached"])
+		assert.Equal(t, defaultBackendServicePortName, payload["portName"])
+	})
+}
@@ -0,0 +1,307 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const addInstancesToInstanceGroupPayloadType = "gcp.compute.instanceGroup.instances"
+
+type AddInstancesToInstanceGroup struct{}
+
+type AddInstancesToInstanceGroupConfiguration struct {
+	Zone          string   `mapstructure:"zone"`
+	InstanceGroup string   `mapstructure:"instanceGroup"`
+	Instances     []string `mapstructure:"instances"`
+}
+
+type instanceGroupMembersResp struct {
+	Items []struct {
+		Instance string `json:"instance"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func (c *AddInstancesToInstanceGroup) Name() string {
+	return "gcp.addInstancesToInstanceGroup"
+}
+
+func (c *AddInstancesToInstanceGroup) Label() string {
+	return "Compute • Add Instances to Instance Group"
+}
+
+func (c *AddInstancesToInstanceGroup) Description() string {
+	return "Add VM instances to an unmanaged instance group"
+}
+
+func (c *AddInstancesToInstanceGroup) Documentation() string {
+	return `The Add Instances to Instance Group component adds Compute Engine VM instances to an unmanaged instance group.
+
+## Use Cases
+
+- **Load balancing**: Add the VMs created by Create Virtual Machine to the group of a load balancer backend
+- **Scaling out**: Add new instances to a group already serving traffic
+
+## Configuration
+
+- **Zone**: The zone of the instance group and the instances.
+- **Instance group**: The name of the instance group, e.g. from the output of a Create Instance Group step.
+- **Instances**: The names of the instances to add.
+
+Instances that are already in the group are skipped, so the component can be run again safely.
+
+## Output
+
+The instance group, zone, the instances added, the instances that were already in the group, and the size of the group.`
+}
+
+func (c *AddInstancesToInstanceGroup) Icon() string {
+	return "gcp"
+}
+
+func (c *AddInstancesToInstanceGroup) Color() string {
+	return "gray"
+}
+
+func (c *AddInstancesToInstanceGroup) DefaultExecutionTimeout() time.Duration {
+	return operationExecutionTimeout
+}
+
+func (c *AddInstancesToInstanceGroup) ExampleOutput() map[string]any {
+	return map[string]any{
+		"instanceGroup":  "web",
+		"zone":           "us-central1-a",
+		"added":          []string{"web-2"},
+		"alreadyMembers": []string{"web-1"},
+		"size":           2,
+	}
+}
+
+func (c *AddInstancesToInstanceGroup) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *AddInstancesToInstanceGroup) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "GCP zone of the instance group and the instances (e.g. us-central1-a).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeZone,
+				},
+			},
+		},
+		{
+			Name:        "instanceGroup",
+			Label:       "Instance group",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the unmanaged instance group.",
+			Placeholder: "e.g. {{ $['Create Instance Group'].data.name }}",
+		},
+		{
+			Name:        "instances",
+			Label:       "Instances",
+			Type:        configuration.FieldTypeList,
+			Required:    true,
+			Description: "Names of the instances to add.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Instance",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func decodeAddInstancesToInstanceGroupConfiguration(raw any) (AddInstancesToInstanceGroupConfiguration, error) {
+	var config AddInstancesToInstanceGroupConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return AddInstancesToInstanceGroupConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Zone = lastSegment(strings.TrimSpace(config.Zone))
+	config.InstanceGroup = lastSegment(strings.TrimSpace(config.InstanceGroup))
+
+	instances := []string{}
+	for _, instance := range config.Instances {
+		name := lastSegment(strings.TrimSpace(instance))
+		if name != "" && !slices.Contains(instances, name) {
+			instances = append(instances, name)
+		}
+	}
+
+	config.Instances = instances
+	return config, nil
+}
+
+func validateAddInstancesToInstanceGroupConfiguration(config AddInstancesToInstanceGroupConfiguration) error {
+	if config.Zone == "" {
+		return fmt.Errorf("zone is required")
+	}
+
+	if config.InstanceGroup == "" {
+		return fmt.Errorf("instanceGroup is required")
+	}
+
+	if len(config.Instances) == 0 {
+		return fmt.Errorf("at least one instance is required")
+	}
+
+	return nil
+}
+
+func (c *AddInstancesToInstanceGroup) Setup(ctx core.SetupContext) error {
+	config, err := decodeAddInstancesToInstanceGroupConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateAddInstancesToInstanceGroupConfiguration(config)
+}
+
+func (c *AddInstancesToInstanceGroup) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *AddInstancesToInstanceGroup) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeAddInstancesToInstanceGroupConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := validateAddInstancesToInstanceGroupConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	groupPath := fmt.Sprintf("projects/%s/zones/%s/instanceGroups/%s", project, config.Zone, config.InstanceGroup)
+	members, err := listInstanceGroupMembers(ctx.GoContext(), client, groupPath)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	//
+	// Adding an instance that is already in the group fails the whole request,
+	// so members are skipped, and executions can be retried.
+	//
+	added := []string{}
+	alreadyMembers := []string{}
+	references := []map[string]string{}
+	for _, instance := range config.Instances {
+		if slices.Contains(members, instance) {
+			alreadyMembers = append(alreadyMembers, instance)
+			continue
+		}
+
+		added = append(added, instance)
+		references = append(references, map[string]string{
+			"instance": fmt.Sprintf("projects/%s/zones/%s/instances/%s", project, config.Zone, instance),
+		})
+	}
+
+	if len(references) > 0 {
+		path := fmt.Sprintf("%s/addInstances?requestId=%s", groupPath, url.QueryEscape(ctx.IdempotencyKey()))
+		body, err := client.Post(ctx.GoContext(), path, map[string]any{"instances": references})
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to add instances to %s: %v", config.InstanceGroup, err))
+		}
+
+		operation, err := operationName(body)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+
+		if err := WaitForZoneOperation(ctx.GoContext(), client, project, config.Zone, operation); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to add instances to %s: %v", config.InstanceGroup, err))
+		}
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, addInstancesToInstanceGroupPayloadType, []any{
+		map[string]any{
+			"instanceGroup":  config.InstanceGroup,
+			"zone":           config.Zone,
+			"added":          added,
+			"alreadyMembers": alreadyMembers,
+			"size":           len(members) + len(added),
+		},
+	})
+}
+
+/*
+ * Names of the instances in an instance group.
+ * listInstances is a POST, since it takes a filter on the instance state.
+ */
+func listInstanceGroupMembers(ctx context.Context, client Client, groupPath string) ([]string, error) {
+	members := []string{}
+	pageToken := ""
+	for {
+		path := groupPath + "/listInstances?maxResults=500"
+		if pageToken != "" {
+			path += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		body, err := client.Post(ctx, path, map[string]any{"instanceState": "ALL"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances of %s: %w", lastSegment(groupPath), err)
+		}
+
+		var resp instanceGroupMembersResp
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse instance group instances: %w", err)
+		}
+
+		for _, item := range resp.Items {
+			members = append(members, lastSegment(item.Instance))
+		}
+
+		if resp.NextPageToken == "" {
+			return members, nil
+		}
+
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (c *AddInstancesToInstanceGroup) Actions() []core.Action {
+	return nil
+}
+
+func (c *AddInstancesToInstanceGroup) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *AddInstancesToInstanceGroup) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *AddInstancesToInstanceGroup) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *AddInstancesToInstanceGroup) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
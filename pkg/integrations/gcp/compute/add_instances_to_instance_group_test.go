@@ -0,0 +1,69 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_AddInstancesToInstanceGroup_Setup(t *testing.T) {
+	component := &AddInstancesToInstanceGroup{}
+	assert.ErrorContains(t, component.Setup(core.SetupContext{Configuration: map[string]any{"instanceGroup": "web", "instances": []any{"web-1"}}}), "zone is required")
+	assert.ErrorContains(t, component.Setup(core.SetupContext{Configuration: map[string]any{"zone": "us-central1-a", "instances": []any{"web-1"}}}), "instanceGroup is required")
+	assert.ErrorContains(t, component.Setup(core.SetupContext{Configuration: map[string]any{"zone": "us-central1-a", "instanceGroup": "web", "instances": []any{" "}}}), "at least one instance is required")
+	assert.NoError(t, component.Setup(core.SetupContext{Configuration: map[string]any{"zone": "us-central1-a", "instanceGroup": "web", "instances": []any{"web-1"}}}))
+}
+
+func Test_AddInstancesToInstanceGroup_Execute(t *testing.T) {
+	posts := map[string]any{}
+	useInstanceClient(t, &mockOSClient{
+		projectID: "p",
+		post: func(ctx context.Context, path string, body any) ([]byte, error) {
+			posts[strings.Split(path, "?")[0]] = body
+			switch {
+			case strings.HasPrefix(path, "projects/p/zones/us-central1-a/instanceGroups/web/listInstances"):
+				return []byte(`{"items": [{"instance": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/web-1"}]}`), nil
+			case strings.HasPrefix(path, "projects/p/zones/us-central1-a/instanceGroups/web/addInstances"):
+				return []byte(`{"name": "operation-1"}`), nil
+			}
+
+			return nil, fmt.Errorf("unexpected path %s", path)
+		},
+		get: func(ctx context.Context, path string) ([]byte, error) {
+			if path == "projects/p/zones/us-central1-a/operations/operation-1" {
+				return []byte(`{"status": "DONE"}`), nil
+			}
+
+			return nil, fmt.Errorf("unexpected path %s", path)
+		},
+	})
+
+	state := &contexts.ExecutionStateContext{}
+	err := (&AddInstancesToInstanceGroup{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"zone":          "us-central1-a",
+			"instanceGroup": "web",
+			"instances":     []any{"web-1", "web-2", "zones/us-central1-a/instances/web-3", "web-2"},
+		},
+		ExecutionState: state,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"instances": []map[string]string{
+			{"instance": "projects/p/zones/us-central1-a/instances/web-2"},
+			{"instance": "projects/p/zones/us-central1-a/instances/web-3"},
+		},
+	}, posts["projects/p/zones/us-central1-a/instanceGroups/web/addInstances"])
+
+	payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, []string{"web-2", "web-3"}, payload["added"])
+	assert.Equal(t, []string{"web-1"}, payload["alreadyMembers"])
+	assert.Equal(t, 3, payload["size"])
+}
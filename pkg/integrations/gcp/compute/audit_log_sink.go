@@ -0,0 +1,158 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	gcppubsub "github.com/superplanehq/superplane/pkg/integrations/gcp/pubsub"
+)
+
+/*
+ * Subscribes the trigger to audit log events matching pattern,
+ * and schedules the creation of its logging sink.
+ */
+func setupAuditLogSink(ctx core.TriggerContext, pattern map[string]any) error {
+	if ctx.Integration == nil {
+		return fmt.Errorf("connect the GCP integration to this trigger to enable automatic event routing")
+	}
+
+	var metadata OnVMInstanceMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.SubscriptionID != "" && metadata.SinkID != "" {
+		return nil
+	}
+
+	subscriptionID, err := ctx.Integration.Subscribe(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	sinkID := "sp-sink-" + sanitizeSinkID(subscriptionID.String())
+
+	if err := ctx.Metadata.Set(OnVMInstanceMetadata{
+		SubscriptionID: subscriptionID.String(),
+		SinkID:         sinkID,
+	}); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	return ctx.Requests.ScheduleActionCall("provisionSink", map[string]any{
+		"sinkId": sinkID,
+	}, 2*time.Second)
+}
+
+/*
+ * Creates the logging sink routing the audit log entries matching filter
+ * to the integration topic, and allows the sink to publish on it.
+ */
+func provisionAuditLogSink(ctx core.TriggerActionContext, filter string) error {
+	meta, err := integrationMetadata(ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if meta.PubSubTopic == "" {
+		return fmt.Errorf("integration Pub/Sub topic not configured; re-sync the GCP integration")
+	}
+
+	sinkID, _ := ctx.Parameters["sinkId"].(string)
+	if sinkID == "" {
+		return fmt.Errorf("sinkId parameter is required")
+	}
+
+	client, err := gcpcommon.NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("create GCP client: %w", err)
+	}
+
+	projectID := client.ProjectID()
+	reqCtx := context.Background()
+
+	writerIdentity, err := gcppubsub.CreateSink(reqCtx, client, projectID, sinkID, meta.PubSubTopic, filter)
+	if err != nil {
+		if !gcpcommon.IsAlreadyExistsError(err) {
+			return fmt.Errorf("create logging sink: %w", err)
+		}
+
+		writerIdentity, err = gcppubsub.GetSink(reqCtx, client, projectID, sinkID)
+		if err != nil {
+			return fmt.Errorf("get existing logging sink: %w", err)
+		}
+	}
+
+	if err := gcppubsub.EnsureTopicPublisher(reqCtx, client, projectID, meta.PubSubTopic, writerIdentity); err != nil {
+		return fmt.Errorf("grant sink publisher permission on topic: %w", err)
+	}
+
+	return nil
+}
+
+func cleanupAuditLogSink(ctx core.TriggerContext) error {
+	var metadata OnVMInstanceMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil || metadata.SinkID == "" {
+		return nil
+	}
+
+	if ctx.Integration == nil {
+		return nil
+	}
+
+	client, err := gcpcommon.NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		ctx.Logger.Warnf("failed to create GCP client for sink cleanup: %v", err)
+		return nil
+	}
+
+	if err := gcppubsub.DeleteSink(context.Background(), client, client.ProjectID(), metadata.SinkID); err != nil {
+		if !gcpcommon.IsNotFoundError(err) {
+			ctx.Logger.Warnf("failed to delete logging sink %s: %v", metadata.SinkID, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Cloud Logging filter for the compute audit log entries with the given methods.
+ */
+func auditLogSinkFilter(methodNames []string) string {
+	conditions := make([]string, 0, len(methodNames))
+	for _, methodName := range methodNames {
+		conditions = append(conditions, fmt.Sprintf("protoPayload.methodName=%q", methodName))
+	}
+
+	return fmt.Sprintf(`protoPayload.serviceName=%q AND (%s)`, computeServiceName, strings.Join(conditions, " OR "))
+}
+
+func integrationMetadata(integration core.IntegrationContext) (*gcpcommon.Metadata, error) {
+	var m gcpcommon.Metadata
+	if err := mapstructure.Decode(integration.GetMetadata(), &m); err != nil {
+		return nil, fmt.Errorf("failed to read integration metadata: %w", err)
+	}
+	if m.ProjectID == "" {
+		return nil, fmt.Errorf("integration metadata does not contain a project ID; re-sync the GCP integration")
+	}
+	return &m, nil
+}
+
+func sanitizeSinkID(s string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(s) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' {
+			b.WriteRune(c)
+		}
+	}
+	result := b.String()
+	if len(result) > 80 {
+		result = result[:80]
+	}
+	return result
+}
@@ -0,0 +1,353 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	checkQuotaPayloadType         = "gcp.compute.quota"
+	checkQuotaSufficientChannel   = "sufficient"
+	checkQuotaInsufficientChannel = "insufficient"
+
+	quotaMetricCPUs      = "CPUS"
+	quotaMetricAddresses = "IN_USE_ADDRESSES"
+)
+
+var gpuQuotaMetrics = []configuration.FieldOption{
+	{Label: "NVIDIA T4", Value: "NVIDIA_T4_GPUS"},
+	{Label: "NVIDIA L4", Value: "NVIDIA_L4_GPUS"},
+	{Label: "NVIDIA V100", Value: "NVIDIA_V100_GPUS"},
+	{Label: "NVIDIA P100", Value: "NVIDIA_P100_GPUS"},
+	{Label: "NVIDIA P4", Value: "NVIDIA_P4_GPUS"},
+	{Label: "NVIDIA A100 40GB", Value: "NVIDIA_A100_GPUS"},
+	{Label: "NVIDIA A100 80GB", Value: "NVIDIA_A100_80GB_GPUS"},
+	{Label: "NVIDIA H100 80GB", Value: "NVIDIA_H100_GPUS"},
+}
+
+type CheckQuota struct{}
+
+type CheckQuotaConfiguration struct {
+	Region    string `mapstructure:"region"`
+	CPUs      int64  `mapstructure:"cpus"`
+	GPUType   string `mapstructure:"gpuType"`
+	GPUs      int64  `mapstructure:"gpus"`
+	Addresses int64  `mapstructure:"addresses"`
+}
+
+/*
+ * QuotaCheck is the headroom of a quota in the region,
+ * compared with the headroom requested for it.
+ */
+type QuotaCheck struct {
+	Metric     string  `json:"metric"`
+	Limit      float64 `json:"limit"`
+	Usage      float64 `json:"usage"`
+	Available  float64 `json:"available"`
+	Requested  int64   `json:"requested"`
+	Sufficient bool    `json:"sufficient"`
+}
+
+type regionQuotasResp struct {
+	Quotas []struct {
+		Metric string  `json:"metric"`
+		Limit  float64 `json:"limit"`
+		Usage  float64 `json:"usage"`
+	} `json:"quotas"`
+}
+
+func (c *CheckQuota) Name() string {
+	return "gcp.checkQuota"
+}
+
+func (c *CheckQuota) Label() string {
+	return "Compute • Check Quota"
+}
+
+func (c *CheckQuota) Description() string {
+	return "Check the Compute Engine CPU, GPU and address quotas of a region before creating VMs"
+}
+
+func (c *CheckQuota) Documentation() string {
+	return `The Check Quota component reads the Compute Engine quota limits and usage of a region, and checks there is enough headroom for the resources you are about to create.
+
+## Use Cases
+
+- **Fail fast**: Check quotas before a Create Virtual Machine step, instead of failing halfway through a rollout
+- **Capacity alerts**: Notify the team when a region is running out of CPUs, GPUs or external IP addresses
+
+## Configuration
+
+- **Region**: The region whose quotas are checked.
+- **CPUs**: The number of vCPUs you need, checked against the ` + "`CPUS`" + ` quota.
+- **GPU type** and **GPUs**: The number of GPUs of a type you need, checked against the quota of that type, e.g. ` + "`NVIDIA_T4_GPUS`" + `.
+- **External IP addresses**: The number of external IP addresses you need, checked against the ` + "`IN_USE_ADDRESSES`" + ` quota.
+
+Quotas with no requested headroom are not checked. A GPU type with no quota in the region has no headroom.
+
+## Output Channels
+
+- **Sufficient**: Every checked quota has the requested headroom.
+- **Insufficient**: At least one checked quota does not. The message lists them, e.g. ` + "`CPUS: 2 available in us-central1, 8 requested`" + `.
+
+## Output
+
+The region, project, whether the headroom is sufficient, a message, and for each checked quota its metric, limit, usage, available headroom, requested headroom and whether it is sufficient.`
+}
+
+func (c *CheckQuota) Icon() string {
+	return "gcp"
+}
+
+func (c *CheckQuota) Color() string {
+	return "gray"
+}
+
+func (c *CheckQuota) ExampleOutput() map[string]any {
+	return map[string]any{
+		"region":     "us-central1",
+		"project":    "my-project",
+		"sufficient": false,
+		"message":    "NVIDIA_T4_GPUS: 0 available in us-central1, 1 requested",
+		"quotas": []any{
+			map[string]any{"metric": "CPUS", "limit": 24, "usage": 8, "available": 16, "requested": 8, "sufficient": true},
+			map[string]any{"metric": "NVIDIA_T4_GPUS", "limit": 0, "usage": 0, "available": 0, "requested": 1, "sufficient": false},
+		},
+	}
+}
+
+func (c *CheckQuota) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: checkQuotaSufficientChannel, Label: "Sufficient"},
+		{Name: checkQuotaInsufficientChannel, Label: "Insufficient"},
+	}
+}
+
+func (c *CheckQuota) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "GCP region whose quotas are checked (e.g. us-central1).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeRegion,
+				},
+			},
+		},
+		{
+			Name:        "cpus",
+			Label:       "CPUs",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Number of vCPUs you need in the region.",
+			Default:     0,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0)},
+			},
+		},
+		{
+			Name:        "gpuType",
+			Label:       "GPU type",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "GPU type whose quota is checked.",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: gpuQuotaMetrics,
+				},
+			},
+		},
+		{
+			Name:        "gpus",
+			Label:       "GPUs",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Number of GPUs of the selected type you need in the region.",
+			Default:     0,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "gpuType", Values: []string{"*"}},
+			},
+		},
+		{
+			Name:        "addresses",
+			Label:       "External IP addresses",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Number of external IP addresses you need in the region.",
+			Default:     0,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0)},
+			},
+		},
+	}
+}
+
+func decodeCheckQuotaConfiguration(raw any) (CheckQuotaConfiguration, error) {
+	var config CheckQuotaConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CheckQuotaConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = lastSegment(strings.TrimSpace(config.Region))
+	config.GPUType = strings.TrimSpace(config.GPUType)
+	return config, nil
+}
+
+func validateCheckQuotaConfiguration(config CheckQuotaConfiguration) error {
+	if config.Region == "" {
+		return fmt.Errorf("region is required")
+	}
+
+	if config.CPUs < 0 || config.GPUs < 0 || config.Addresses < 0 {
+		return fmt.Errorf("requested headroom cannot be negative")
+	}
+
+	if config.GPUs > 0 && config.GPUType == "" {
+		return fmt.Errorf("gpuType is required when GPUs are requested")
+	}
+
+	if config.CPUs == 0 && config.GPUs == 0 && config.Addresses == 0 {
+		return fmt.Errorf("request headroom for at least one quota")
+	}
+
+	return nil
+}
+
+func (c *CheckQuota) Setup(ctx core.SetupContext) error {
+	config, err := decodeCheckQuotaConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateCheckQuotaConfiguration(config)
+}
+
+func (c *CheckQuota) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CheckQuota) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCheckQuotaConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := validateCheckQuotaConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	body, err := client.Get(context.Background(), fmt.Sprintf("projects/%s/regions/%s", project, config.Region))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get region %s: %v", config.Region, err))
+	}
+
+	var region regionQuotasResp
+	if err := json.Unmarshal(body, &region); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse region response: %v", err))
+	}
+
+	checks := checkQuotas(region, config)
+	sufficient := true
+	insufficient := []string{}
+	for _, check := range checks {
+		if !check.Sufficient {
+			sufficient = false
+			insufficient = append(insufficient, fmt.Sprintf("%s: %g available in %s, %d requested", check.Metric, check.Available, config.Region, check.Requested))
+		}
+	}
+
+	message := fmt.Sprintf("quotas in %s have the requested headroom", config.Region)
+	channel := checkQuotaSufficientChannel
+	if !sufficient {
+		message = strings.Join(insufficient, "; ")
+		channel = checkQuotaInsufficientChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, checkQuotaPayloadType, []any{
+		map[string]any{
+			"region":     config.Region,
+			"project":    project,
+			"sufficient": sufficient,
+			"message":    message,
+			"quotas":     checks,
+		},
+	})
+}
+
+/*
+ * Compares the region quotas with the requested headroom.
+ * Quotas with no requested headroom are not checked, and a quota
+ * missing from the region, like a GPU type it doesn't offer, has no headroom.
+ */
+func checkQuotas(region regionQuotasResp, config CheckQuotaConfiguration) []QuotaCheck {
+	requested := []struct {
+		metric string
+		amount int64
+	}{
+		{metric: quotaMetricCPUs, amount: config.CPUs},
+		{metric: config.GPUType, amount: config.GPUs},
+		{metric: quotaMetricAddresses, amount: config.Addresses},
+	}
+
+	checks := []QuotaCheck{}
+	for _, r := range requested {
+		if r.amount <= 0 {
+			continue
+		}
+
+		check := QuotaCheck{Metric: r.metric, Requested: r.amount}
+		for _, quota := range region.Quotas {
+			if quota.Metric == r.metric {
+				check.Limit = quota.Limit
+				check.Usage = quota.Usage
+				check.Available = max(quota.Limit-quota.Usage, 0)
+				break
+			}
+		}
+
+		check.Sufficient = check.Available >= float64(r.amount)
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func (c *CheckQuota) Actions() []core.Action {
+	return nil
+}
+
+func (c *CheckQuota) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CheckQuota) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CheckQuota) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CheckQuota) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,91 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const regionQuotasResponse = `{
+	"name": "us-central1",
+	"quotas": [
+		{"metric": "CPUS", "limit": 24, "usage": 20},
+		{"metric": "IN_USE_ADDRESSES", "limit": 8, "usage": 2},
+		{"metric": "NVIDIA_T4_GPUS", "limit": 4, "usage": 0}
+	]
+}`
+
+func Test_CheckQuota_Setup(t *testing.T) {
+	component := &CheckQuota{}
+
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		err           string
+	}{
+		{name: "region is required", configuration: map[string]any{"cpus": 2}, err: "region is required"},
+		{name: "headroom is required", configuration: map[string]any{"region": "us-central1"}, err: "request headroom for at least one quota"},
+		{name: "GPU type is required for GPUs", configuration: map[string]any{"region": "us-central1", "gpus": 1}, err: "gpuType is required"},
+		{name: "valid", configuration: map[string]any{"region": "us-central1", "cpus": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.Setup(core.SetupContext{Configuration: tt.configuration})
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_CheckQuota_Execute(t *testing.T) {
+	component := &CheckQuota{}
+
+	t.Run("sufficient headroom", func(t *testing.T) {
+		client := &fakeInstanceClient{body: []byte(regionQuotasResponse)}
+		useInstanceClient(t, client)
+
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-central1", "cpus": 4, "gpuType": "NVIDIA_T4_GPUS", "gpus": 2},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"projects/my-project/regions/us-central1"}, client.paths)
+		assert.Equal(t, checkQuotaSufficientChannel, state.Channel)
+		assert.Equal(t, checkQuotaPayloadType, state.Type)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["sufficient"])
+		assert.Equal(t, []QuotaCheck{
+			{Metric: "CPUS", Limit: 24, Usage: 20, Available: 4, Requested: 4, Sufficient: true},
+			{Metric: "NVIDIA_T4_GPUS", Limit: 4, Usage: 0, Available: 4, Requested: 2, Sufficient: true},
+		}, payload["quotas"])
+	})
+
+	t.Run("insufficient headroom", func(t *testing.T) {
+		useInstanceClient(t, &fakeInstanceClient{body: []byte(regionQuotasResponse)})
+
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-central1", "cpus": 8, "gpuType": "NVIDIA_L4_GPUS", "gpus": 1, "addresses": 2},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, checkQuotaInsufficientChannel, state.Channel)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, payload["sufficient"])
+		assert.Equal(t, "CPUS: 4 available in us-central1, 8 requested; NVIDIA_L4_GPUS: 0 available in us-central1, 1 requested", payload["message"])
+		assert.Len(t, payload["quotas"], 3)
+	})
+}
@@ -0,0 +1,433 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	checkVMCompliancePayloadType      = "gcp.compute.compliance"
+	checkVMComplianceCompliantChannel = "compliant"
+	checkVMComplianceViolationChannel = "violation"
+
+	constraintAllowedMachineFamilies = "allowedMachineFamilies"
+	constraintRequiredLabels         = "requiredLabels"
+	constraintNoExternalIP           = "noExternalIP"
+	constraintRequireCMEK            = "requireCMEK"
+)
+
+type CheckVMCompliance struct{}
+
+type CheckVMComplianceConfiguration struct {
+	VM                     any                  `mapstructure:"vm"`
+	AllowedMachineFamilies []string             `mapstructure:"allowedMachineFamilies"`
+	RequiredLabels         []RequiredLabelEntry `mapstructure:"requiredLabels"`
+	NoExternalIP           bool                 `mapstructure:"noExternalIP"`
+	RequireCMEK            bool                 `mapstructure:"requireCMEK"`
+}
+
+/*
+ * RequiredLabelEntry is a label the VM must have.
+ * With no value, the label can have any value.
+ */
+type RequiredLabelEntry struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+}
+
+type ComplianceViolation struct {
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
+}
+
+func (c *CheckVMCompliance) Name() string {
+	return "gcp.checkVMCompliance"
+}
+
+func (c *CheckVMCompliance) Label() string {
+	return "Compute • Check VM Compliance"
+}
+
+func (c *CheckVMCompliance) Description() string {
+	return "Check a VM configuration against organization constraints before creating it"
+}
+
+func (c *CheckVMCompliance) Documentation() string {
+	return `The Check VM Compliance component checks the configuration of a VM against organization constraints, like allowed machine families and required labels, before it is created.
+
+## Use Cases
+
+- **Policy pre-check**: Stop a Create Virtual Machine step before it creates a VM the organization policy would reject
+- **Audits**: Check the VMs requested by other teams, and notify them of the violations
+
+## Configuration
+
+- **VM configuration**: The VM to check, in the format of the Create Virtual Machine configuration, e.g. ` + "`{{ $['Plan VM'].data }}`" + `.
+- **Allowed machine families**: The machine families the VM can use, e.g. ` + "`e2`" + ` or ` + "`n2d`" + `.
+- **Required labels**: The labels the VM must have. A label with no value can have any value.
+- **No external IP**: The VM cannot have an external IP address.
+- **Require CMEK**: The new disks of the VM must be encrypted with a customer-managed encryption key, their own or the default disk encryption key of the VM. Existing disks attached to the VM, and local SSDs, are not checked.
+
+Only the constraints that are set are checked.
+
+## Output Channels
+
+- **Compliant**: The VM meets every constraint.
+- **Violation**: The VM does not meet at least one constraint. The violations are listed, e.g. ` + "`machine family M1 is not allowed`" + `.
+
+## Output
+
+The instance name, whether the VM is compliant, a message, and for each violation the constraint and a message.`
+}
+
+func (c *CheckVMCompliance) Icon() string {
+	return "gcp"
+}
+
+func (c *CheckVMCompliance) Color() string {
+	return "gray"
+}
+
+func (c *CheckVMCompliance) ExampleOutput() map[string]any {
+	return map[string]any{
+		"instanceName": "web-1",
+		"compliant":    false,
+		"message":      "machine family M1 is not allowed; label team is required",
+		"violations": []any{
+			map[string]any{"constraint": constraintAllowedMachineFamilies, "message": "machine family M1 is not allowed"},
+			map[string]any{"constraint": constraintRequiredLabels, "message": "label team is required"},
+		},
+	}
+}
+
+func (c *CheckVMCompliance) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: checkVMComplianceCompliantChannel, Label: "Compliant"},
+		{Name: checkVMComplianceViolationChannel, Label: "Violation"},
+	}
+}
+
+func (c *CheckVMCompliance) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "vm",
+			Label:       "VM configuration",
+			Type:        configuration.FieldTypeExpression,
+			Required:    true,
+			Description: "The VM to check, in the format of the Create Virtual Machine configuration.",
+			Placeholder: "e.g. {{ $['Plan VM'].data }}",
+		},
+		{
+			Name:        "allowedMachineFamilies",
+			Label:       "Allowed machine families",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Machine families the VM can use (e.g. e2, n2d).",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Machine family",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:        "requiredLabels",
+			Label:       "Required labels",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Labels the VM must have.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Label",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "key",
+								Label:       "Key",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "Label key.",
+								Placeholder: "e.g. team",
+							},
+							{
+								Name:        "value",
+								Label:       "Value",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Description: "Required value. Leave empty to allow any value.",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "noExternalIP",
+			Label:       "No external IP",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "The VM cannot have an external IP address.",
+		},
+		{
+			Name:        "requireCMEK",
+			Label:       "Require CMEK",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "New disks must be encrypted with a customer-managed encryption key.",
+		},
+	}
+}
+
+func decodeCheckVMComplianceConfiguration(raw any) (CheckVMComplianceConfiguration, error) {
+	var config CheckVMComplianceConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CheckVMComplianceConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	families := make([]string, 0, len(config.AllowedMachineFamilies))
+	for _, family := range config.AllowedMachineFamilies {
+		if family = strings.ToUpper(strings.TrimSpace(family)); family != "" {
+			families = append(families, family)
+		}
+	}
+
+	config.AllowedMachineFamilies = families
+	for i := range config.RequiredLabels {
+		config.RequiredLabels[i].Key = strings.TrimSpace(config.RequiredLabels[i].Key)
+		config.RequiredLabels[i].Value = strings.TrimSpace(config.RequiredLabels[i].Value)
+	}
+
+	return config, nil
+}
+
+func validateCheckVMComplianceConfiguration(config CheckVMComplianceConfiguration) error {
+	if config.VM == nil || config.VM == "" {
+		return fmt.Errorf("vm is required")
+	}
+
+	for _, label := range config.RequiredLabels {
+		if label.Key == "" {
+			return fmt.Errorf("required labels must have a key")
+		}
+	}
+
+	if len(config.AllowedMachineFamilies) == 0 && len(config.RequiredLabels) == 0 && !config.NoExternalIP && !config.RequireCMEK {
+		return fmt.Errorf("set at least one constraint")
+	}
+
+	return nil
+}
+
+/*
+ * decodeVMConfiguration decodes the VM to check. It is usually
+ * the result of an expression, but it can also be given as JSON.
+ */
+func decodeVMConfiguration(raw any) (CreateVMConfig, error) {
+	if s, ok := raw.(string); ok {
+		var parsed map[string]any
+		if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+			return CreateVMConfig{}, fmt.Errorf("vm must be an object: %v", err)
+		}
+
+		raw = parsed
+	}
+
+	var config CreateVMConfig
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CreateVMConfig{}, fmt.Errorf("failed to decode vm: %w", err)
+	}
+
+	return config, nil
+}
+
+func (c *CheckVMCompliance) Setup(ctx core.SetupContext) error {
+	config, err := decodeCheckVMComplianceConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateCheckVMComplianceConfiguration(config)
+}
+
+func (c *CheckVMCompliance) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CheckVMCompliance) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCheckVMComplianceConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := validateCheckVMComplianceConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	vm, err := decodeVMConfiguration(config.VM)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	violations := checkVMCompliance(vm, config)
+	message := "VM meets every constraint"
+	channel := checkVMComplianceCompliantChannel
+	if len(violations) > 0 {
+		messages := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			messages = append(messages, violation.Message)
+		}
+
+		message = strings.Join(messages, "; ")
+		channel = checkVMComplianceViolationChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, checkVMCompliancePayloadType, []any{
+		map[string]any{
+			"instanceName": strings.TrimSpace(vm.InstanceName),
+			"compliant":    len(violations) == 0,
+			"message":      message,
+			"violations":   violations,
+		},
+	})
+}
+
+/*
+ * Checks the VM against the constraints that are set,
+ * in the order they are configured, and lists every violation.
+ */
+func checkVMCompliance(vm CreateVMConfig, config CheckVMComplianceConfiguration) []ComplianceViolation {
+	violations := []ComplianceViolation{}
+	if len(config.AllowedMachineFamilies) > 0 {
+		family := DeriveFamily(lastSegment(strings.TrimSpace(vm.MachineType)))
+		if family == "" {
+			family = strings.ToUpper(strings.TrimSpace(vm.MachineFamily))
+		}
+
+		if !slices.Contains(config.AllowedMachineFamilies, family) {
+			violations = append(violations, ComplianceViolation{
+				Constraint: constraintAllowedMachineFamilies,
+				Message:    fmt.Sprintf("machine family %s is not allowed", machineFamilyLabel(family)),
+			})
+		}
+	}
+
+	labels := BuildLabels(AdvancedConfig{Labels: vm.Labels})
+	for _, required := range config.RequiredLabels {
+		value, ok := labels[required.Key]
+		if !ok {
+			violations = append(violations, ComplianceViolation{
+				Constraint: constraintRequiredLabels,
+				Message:    fmt.Sprintf("label %s is required", required.Key),
+			})
+			continue
+		}
+
+		if required.Value != "" && value != required.Value {
+			violations = append(violations, ComplianceViolation{
+				Constraint: constraintRequiredLabels,
+				Message:    fmt.Sprintf("label %s must be %s, not %s", required.Key, required.Value, value),
+			})
+		}
+	}
+
+	if config.NoExternalIP && hasExternalIP(vm.NetworkingConfig) {
+		violations = append(violations, ComplianceViolation{
+			Constraint: constraintNoExternalIP,
+			Message:    "VM cannot have an external IP address",
+		})
+	}
+
+	if config.RequireCMEK {
+		for _, disk := range disksWithoutCMEK(vm.OSAndStorageConfig) {
+			violations = append(violations, ComplianceViolation{
+				Constraint: constraintRequireCMEK,
+				Message:    fmt.Sprintf("%s is not encrypted with a customer-managed key", disk),
+			})
+		}
+	}
+
+	return violations
+}
+
+func machineFamilyLabel(family string) string {
+	if family == "" {
+		return "(none)"
+	}
+
+	return family
+}
+
+/*
+ * An empty external IP type is an ephemeral external IP,
+ * like in BuildNetworkInterfaces.
+ */
+func hasExternalIP(config NetworkingConfig) bool {
+	return strings.TrimSpace(config.ExternalIPType) != ExternalIPNone
+}
+
+/*
+ * Lists the new disks of the VM with no customer-managed key,
+ * of their own or the default key of the VM.
+ * Existing disks keep the key they were created with,
+ * and local SSDs can only use Google-managed keys, so they are not listed.
+ */
+func disksWithoutCMEK(config OSAndStorageConfig) []string {
+	disks := []string{}
+	if config.BootDiskSourceType != BootDiskSourceExistingDisk && bootDiskEncryptionKey(config) == "" {
+		disks = append(disks, "boot disk")
+	}
+
+	for i, disk := range config.AdditionalDisks {
+		if disk.Mode == AdditionalDiskModeExisting || strings.TrimSpace(disk.DiskType) == "local-ssd" {
+			continue
+		}
+
+		if additionalDiskEncryptionKey(config, disk) != "" {
+			continue
+		}
+
+		name := strings.TrimSpace(disk.Name)
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+
+		disks = append(disks, fmt.Sprintf("additional disk %s", name))
+	}
+
+	return disks
+}
+
+func (c *CheckVMCompliance) Actions() []core.Action {
+	return nil
+}
+
+func (c *CheckVMCompliance) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CheckVMCompliance) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CheckVMCompliance) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CheckVMCompliance) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,127 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_CheckVMCompliance_Setup(t *testing.T) {
+	component := &CheckVMCompliance{}
+
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		err           string
+	}{
+		{name: "vm is required", configuration: map[string]any{"noExternalIP": true}, err: "vm is required"},
+		{name: "constraint is required", configuration: map[string]any{"vm": "{{ $['Plan VM'].data }}"}, err: "set at least one constraint"},
+		{name: "label key is required", configuration: map[string]any{"vm": "{{ $['Plan VM'].data }}", "requiredLabels": []any{map[string]any{"value": "x"}}}, err: "must have a key"},
+		{name: "valid", configuration: map[string]any{"vm": "{{ $['Plan VM'].data }}", "allowedMachineFamilies": []any{"e2"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.Setup(core.SetupContext{Configuration: tt.configuration})
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_CheckVMCompliance_Execute(t *testing.T) {
+	component := &CheckVMCompliance{}
+	constraints := map[string]any{
+		"allowedMachineFamilies": []any{"e2", " n2d "},
+		"requiredLabels": []any{
+			map[string]any{"key": "team"},
+			map[string]any{"key": "env", "value": "prod"},
+		},
+		"noExternalIP": true,
+		"requireCMEK":  true,
+	}
+
+	withVM := func(vm any) map[string]any {
+		configuration := map[string]any{"vm": vm}
+		for k, v := range constraints {
+			configuration[k] = v
+		}
+
+		return configuration
+	}
+
+	t.Run("compliant VM", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: withVM(map[string]any{
+				"instanceName":      "web-1",
+				"machineType":       "zones/us-central1-a/machineTypes/n2d-standard-4",
+				"labels":            []any{map[string]any{"key": "team", "value": "web"}, map[string]any{"key": "env", "value": "prod"}},
+				"externalIPType":    ExternalIPNone,
+				"diskEncryptionKey": "projects/p/locations/us-central1/keyRings/r/cryptoKeys/k",
+				"additionalDisks": []any{
+					map[string]any{"mode": AdditionalDiskModeExisting, "existingDisk": "data"},
+					map[string]any{"mode": AdditionalDiskModeNew, "name": "logs"},
+					map[string]any{"mode": AdditionalDiskModeNew, "name": "scratch", "diskType": "local-ssd"},
+				},
+			}),
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, checkVMComplianceCompliantChannel, state.Channel)
+		assert.Equal(t, checkVMCompliancePayloadType, state.Type)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["compliant"])
+		assert.Equal(t, "web-1", payload["instanceName"])
+		assert.Empty(t, payload["violations"])
+	})
+
+	t.Run("violations are listed", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: withVM(`{
+				"instanceName": "web-2",
+				"machineType": "m1-ultramem-40",
+				"labels": [{"key": "env", "value": "dev"}],
+				"additionalDisks": [{"mode": "newDisk", "name": "scratch"}]
+			}`),
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, checkVMComplianceViolationChannel, state.Channel)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, payload["compliant"])
+		assert.Equal(t, []ComplianceViolation{
+			{Constraint: constraintAllowedMachineFamilies, Message: "machine family M1 is not allowed"},
+			{Constraint: constraintRequiredLabels, Message: "label team is required"},
+			{Constraint: constraintRequiredLabels, Message: "label env must be prod, not dev"},
+			{Constraint: constraintNoExternalIP, Message: "VM cannot have an external IP address"},
+			{Constraint: constraintRequireCMEK, Message: "boot disk is not encrypted with a customer-managed key"},
+			{Constraint: constraintRequireCMEK, Message: "additional disk scratch is not encrypted with a customer-managed key"},
+		}, payload["violations"])
+		assert.Contains(t, payload["message"], "machine family M1 is not allowed; label team is required")
+	})
+
+	t.Run("invalid vm -> fails", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  withVM("not json"),
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "vm must be an object")
+	})
+}
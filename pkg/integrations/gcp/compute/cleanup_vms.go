@@ -0,0 +1,448 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	cleanupVMsPayloadType    = "gcp.compute.cleanup"
+	cleanupVMsDeletedChannel = "deleted"
+	cleanupVMsDryRunChannel  = "dryRun"
+)
+
+var labelKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+var labelValueRegex = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+
+type CleanupVMs struct{}
+
+type CleanupVMsConfiguration struct {
+	LabelSelector  string `mapstructure:"labelSelector"`
+	OlderThanHours int64  `mapstructure:"olderThanHours"`
+	Zone           string `mapstructure:"zone"`
+	DryRun         bool   `mapstructure:"dryRun"`
+}
+
+/*
+ * A label selector requirement: the label must have the value,
+ * or only exist, when no value is given.
+ */
+type labelRequirement struct {
+	Key   string
+	Value *string
+}
+
+type CleanupVMsInstance struct {
+	Name              string            `json:"name"`
+	Zone              string            `json:"zone"`
+	Status            string            `json:"status"`
+	CreationTimestamp string            `json:"creationTimestamp"`
+	AgeHours          float64           `json:"ageHours"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+type listedInstance struct {
+	Name              string            `json:"name"`
+	Zone              string            `json:"zone"`
+	Status            string            `json:"status"`
+	CreationTimestamp string            `json:"creationTimestamp"`
+	Labels            map[string]string `json:"labels"`
+}
+
+type zoneInstancesListResp struct {
+	Items         []listedInstance `json:"items"`
+	NextPageToken string           `json:"nextPageToken"`
+}
+
+type aggregatedInstancesListResp struct {
+	Items map[string]struct {
+		Instances []listedInstance `json:"instances"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func (c *CleanupVMs) Name() string {
+	return "gcp.cleanupVMs"
+}
+
+func (c *CleanupVMs) Label() string {
+	return "Compute • Clean Up VMs"
+}
+
+func (c *CleanupVMs) Description() string {
+	return "Delete the Compute Engine VMs matching a label selector that are older than an age threshold"
+}
+
+func (c *CleanupVMs) Documentation() string {
+	return `The Clean Up VMs component deletes the Compute Engine VM instances that match a label selector and were created more than a number of hours ago.
+
+## Use Cases
+
+- **Reap CI VMs**: Run on a schedule to delete the CI runners older than a few hours
+- **Ephemeral environments**: Delete preview environments that were not torn down
+- **Audit**: Use a dry run to list what would be deleted before enabling the cleanup
+
+## Configuration
+
+- **Label selector**: Comma-separated requirements, e.g. ` + "`purpose=ci,team=platform`" + `. A key without a value, e.g. ` + "`ephemeral`" + `, only requires the label to exist.
+- **Older than (hours)**: Only instances created more than this many hours ago are deleted.
+- **Zone**: Only clean up a zone. All the zones of the project are cleaned up when empty.
+- **Dry run**: List the matching instances on the Dry Run channel, without deleting them.
+
+## Output Channels
+
+- **Deleted**: The instances whose deletion was requested, and the ones that could not be deleted, e.g. because they have deletion protection.
+- **Dry Run**: The instances that would be deleted.
+
+Deletions are requested without waiting for them to finish.`
+}
+
+func (c *CleanupVMs) Icon() string {
+	return "gcp"
+}
+
+func (c *CleanupVMs) Color() string {
+	return "gray"
+}
+
+func (c *CleanupVMs) ExampleOutput() map[string]any {
+	return map[string]any{
+		"labelSelector":  "purpose=ci",
+		"olderThanHours": 6,
+		"dryRun":         false,
+		"deleted": []any{
+			map[string]any{
+				"name":              "ci-runner-1",
+				"zone":              "us-central1-a",
+				"status":            "RUNNING",
+				"creationTimestamp": "2025-02-14T04:00:00.000-08:00",
+				"ageHours":          8.5,
+				"labels":            map[string]any{"purpose": "ci"},
+			},
+		},
+		"failed": []any{},
+	}
+}
+
+func (c *CleanupVMs) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: cleanupVMsDeletedChannel, Label: "Deleted"},
+		{Name: cleanupVMsDryRunChannel, Label: "Dry Run"},
+	}
+}
+
+func (c *CleanupVMs) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "labelSelector",
+			Label:       "Label selector",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Comma-separated label requirements, e.g. purpose=ci,team=platform. A key without a value requires the label to exist.",
+			Placeholder: "e.g. purpose=ci",
+		},
+		{
+			Name:        "olderThanHours",
+			Label:       "Older than (hours)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    true,
+			Description: "Only delete instances created more than this many hours ago.",
+			Default:     24,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1)},
+			},
+		},
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Only clean up instances in this zone. Leave empty to clean up all zones.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeZone,
+				},
+			},
+		},
+		{
+			Name:        "dryRun",
+			Label:       "Dry run",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "List the instances that would be deleted, without deleting them.",
+			Default:     false,
+		},
+	}
+}
+
+func decodeCleanupVMsConfiguration(raw any) (CleanupVMsConfiguration, []labelRequirement, error) {
+	var config CleanupVMsConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CleanupVMsConfiguration{}, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Zone = lastSegment(strings.TrimSpace(config.Zone))
+	if config.OlderThanHours < 1 {
+		return CleanupVMsConfiguration{}, nil, fmt.Errorf("olderThanHours must be at least 1")
+	}
+
+	requirements, err := parseLabelSelector(config.LabelSelector)
+	if err != nil {
+		return CleanupVMsConfiguration{}, nil, err
+	}
+
+	return config, requirements, nil
+}
+
+/*
+ * Parses a comma-separated label selector, e.g. "purpose=ci,ephemeral".
+ * A selector is required, so a misconfigured node never matches every instance.
+ */
+func parseLabelSelector(selector string) ([]labelRequirement, error) {
+	requirements := []labelRequirement{}
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if !labelKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid label key %q", key)
+		}
+
+		requirement := labelRequirement{Key: key}
+		if hasValue {
+			value = strings.TrimSpace(value)
+			if !labelValueRegex.MatchString(value) {
+				return nil, fmt.Errorf("invalid value %q for label %s", value, key)
+			}
+
+			requirement.Value = &value
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	if len(requirements) == 0 {
+		return nil, fmt.Errorf("labelSelector is required")
+	}
+
+	return requirements, nil
+}
+
+/*
+ * Compute Engine list filter for the label requirements.
+ */
+func labelSelectorFilter(requirements []labelRequirement) string {
+	expressions := make([]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		if requirement.Value == nil {
+			expressions = append(expressions, fmt.Sprintf("(labels.%s:*)", requirement.Key))
+			continue
+		}
+
+		expressions = append(expressions, fmt.Sprintf("(labels.%s = %q)", requirement.Key, *requirement.Value))
+	}
+
+	return strings.Join(expressions, " ")
+}
+
+func matchesLabelSelector(labels map[string]string, requirements []labelRequirement) bool {
+	for _, requirement := range requirements {
+		value, ok := labels[requirement.Key]
+		if !ok {
+			return false
+		}
+
+		if requirement.Value != nil && value != *requirement.Value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *CleanupVMs) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeCleanupVMsConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *CleanupVMs) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CleanupVMs) Execute(ctx core.ExecutionContext) error {
+	config, requirements, err := decodeCleanupVMsConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	reqCtx := context.Background()
+	instances, err := listInstances(reqCtx, client, config.Zone, labelSelectorFilter(requirements))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to list instances: %v", err))
+	}
+
+	//
+	// The list filter already selects the labels, but the instances
+	// are checked again, since they are about to be deleted.
+	//
+	expired := expiredInstances(instances, requirements, time.Duration(config.OlderThanHours)*time.Hour, time.Now())
+
+	payload := map[string]any{
+		"labelSelector":  config.LabelSelector,
+		"olderThanHours": config.OlderThanHours,
+		"dryRun":         config.DryRun,
+	}
+
+	if config.DryRun {
+		payload["instances"] = expired
+		return ctx.ExecutionState.Emit(cleanupVMsDryRunChannel, cleanupVMsPayloadType, []any{payload})
+	}
+
+	deleted := []CleanupVMsInstance{}
+	failed := []CleanupVMsInstance{}
+	for _, instance := range expired {
+		path := fmt.Sprintf("projects/%s/zones/%s/instances/%s", client.ProjectID(), instance.Zone, instance.Name)
+		if _, err := client.Delete(reqCtx, path); err != nil {
+			ctx.Logger.Warnf("failed to delete instance %s in %s: %v", instance.Name, instance.Zone, err)
+			instance.Error = err.Error()
+			failed = append(failed, instance)
+			continue
+		}
+
+		deleted = append(deleted, instance)
+	}
+
+	payload["deleted"] = deleted
+	payload["failed"] = failed
+	return ctx.ExecutionState.Emit(cleanupVMsDeletedChannel, cleanupVMsPayloadType, []any{payload})
+}
+
+/*
+ * Lists the instances matching filter in a zone, or in all zones, if zone is empty.
+ */
+func listInstances(ctx context.Context, client Client, zone, filter string) ([]listedInstance, error) {
+	path := fmt.Sprintf("projects/%s/aggregated/instances", client.ProjectID())
+	if zone != "" {
+		path = fmt.Sprintf("projects/%s/zones/%s/instances", client.ProjectID(), zone)
+	}
+
+	instances := []listedInstance{}
+	pageToken := ""
+	for {
+		params := url.Values{}
+		params.Set("filter", filter)
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+
+		body, err := client.Get(ctx, path+"?"+params.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		if zone != "" {
+			var resp zoneInstancesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse instances response: %w", err)
+			}
+
+			instances = append(instances, resp.Items...)
+			pageToken = resp.NextPageToken
+		} else {
+			var resp aggregatedInstancesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse instances response: %w", err)
+			}
+
+			for _, scope := range resp.Items {
+				instances = append(instances, scope.Instances...)
+			}
+
+			pageToken = resp.NextPageToken
+		}
+
+		if pageToken == "" {
+			return instances, nil
+		}
+	}
+}
+
+func expiredInstances(instances []listedInstance, requirements []labelRequirement, olderThan time.Duration, now time.Time) []CleanupVMsInstance {
+	expired := []CleanupVMsInstance{}
+	for _, instance := range instances {
+		if !matchesLabelSelector(instance.Labels, requirements) {
+			continue
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, instance.CreationTimestamp)
+		if err != nil {
+			continue
+		}
+
+		age := now.Sub(createdAt)
+		if age < olderThan {
+			continue
+		}
+
+		expired = append(expired, CleanupVMsInstance{
+			Name:              instance.Name,
+			Zone:              lastSegment(instance.Zone),
+			Status:            instance.Status,
+			CreationTimestamp: instance.CreationTimestamp,
+			AgeHours:          float64(age.Truncate(time.Minute)) / float64(time.Hour),
+			Labels:            instance.Labels,
+		})
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		if expired[i].Zone != expired[j].Zone {
+			return expired[i].Zone < expired[j].Zone
+		}
+
+		return expired[i].Name < expired[j].Name
+	})
+
+	return expired
+}
+
+func (c *CleanupVMs) Actions() []core.Action {
+	return nil
+}
+
+func (c *CleanupVMs) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CleanupVMs) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CleanupVMs) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CleanupVMs) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
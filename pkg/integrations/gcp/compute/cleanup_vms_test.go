@@ -0,0 +1,129 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_parseLabelSelector(t *testing.T) {
+	requirements, err := parseLabelSelector(" purpose=ci, ephemeral ,team=platform")
+	require.NoError(t, err)
+	require.Len(t, requirements, 3)
+	assert.Equal(t, "(labels.purpose = \"ci\") (labels.ephemeral:*) (labels.team = \"platform\")", labelSelectorFilter(requirements))
+
+	for _, selector := range []string{"", " , ", "Purpose=ci", "purpose=CI", "purpose=ci)"} {
+		_, err := parseLabelSelector(selector)
+		assert.Error(t, err, selector)
+	}
+}
+
+func Test_expiredInstances(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	createdAt := func(age time.Duration) string {
+		return now.Add(-age).Format(time.RFC3339)
+	}
+
+	requirements, err := parseLabelSelector("purpose=ci")
+	require.NoError(t, err)
+
+	instances := []listedInstance{
+		//
+		// Compute returns timestamps with milliseconds and a -00:00 offset.
+		//
+		{Name: "old", Zone: "zones/us-east1-b", CreationTimestamp: now.Add(-630*time.Minute).Format("2006-01-02T15:04:05.000") + "-00:00", Labels: map[string]string{"purpose": "ci"}},
+		{Name: "recent", Zone: "zones/us-east1-b", CreationTimestamp: createdAt(2 * time.Hour), Labels: map[string]string{"purpose": "ci"}},
+		{Name: "other-label", Zone: "zones/us-east1-b", CreationTimestamp: createdAt(28 * 24 * time.Hour), Labels: map[string]string{"purpose": "prod"}},
+		{Name: "unlabeled", Zone: "zones/us-east1-b", CreationTimestamp: createdAt(28 * 24 * time.Hour)},
+		{Name: "older", Zone: "zones/us-central1-a", CreationTimestamp: createdAt(24 * time.Hour), Labels: map[string]string{"purpose": "ci"}},
+	}
+
+	expired := expiredInstances(instances, requirements, 6*time.Hour, now)
+	require.Len(t, expired, 2)
+	assert.Equal(t, "older", expired[0].Name)
+	assert.Equal(t, "us-central1-a", expired[0].Zone)
+	assert.Equal(t, 24.0, expired[0].AgeHours)
+	assert.Equal(t, "old", expired[1].Name)
+	assert.Equal(t, 10.5, expired[1].AgeHours)
+}
+
+func Test_CleanupVMs_Execute(t *testing.T) {
+	component := &CleanupVMs{}
+	createdAt := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+	listResponse := fmt.Sprintf(`{
+		"items": {
+			"zones/us-east1-b": {"instances": [{"name": "ci-1", "zone": "zones/us-east1-b", "creationTimestamp": %q, "labels": {"purpose": "ci"}}]},
+			"zones/us-west1-a": {"warning": {"code": "NO_RESULTS_ON_PAGE"}}
+		}
+	}`, createdAt)
+
+	configuration := map[string]any{"labelSelector": "purpose=ci", "olderThanHours": 24}
+
+	t.Run("dry run lists the instances", func(t *testing.T) {
+		client := &fakeInstanceClient{body: []byte(listResponse)}
+		useInstanceClient(t, client)
+
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"labelSelector": "purpose=ci", "olderThanHours": 24, "dryRun": true},
+			ExecutionState: state,
+			Logger:         logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"projects/my-project/aggregated/instances?filter=%28labels.purpose+%3D+%22ci%22%29"}, client.paths)
+		assert.Empty(t, client.deleted)
+		assert.Equal(t, cleanupVMsDryRunChannel, state.Channel)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		instances := payload["instances"].([]CleanupVMsInstance)
+		require.Len(t, instances, 1)
+		assert.Equal(t, "ci-1", instances[0].Name)
+	})
+
+	t.Run("instances are deleted", func(t *testing.T) {
+		client := &fakeInstanceClient{body: []byte(listResponse)}
+		useInstanceClient(t, client)
+
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			ExecutionState: state,
+			Logger:         logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"projects/my-project/zones/us-east1-b/instances/ci-1"}, client.deleted)
+		assert.Equal(t, cleanupVMsDeletedChannel, state.Channel)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Len(t, payload["deleted"], 1)
+		assert.Empty(t, payload["failed"])
+	})
+
+	t.Run("failed deletions are reported", func(t *testing.T) {
+		useInstanceClient(t, &fakeInstanceClient{body: []byte(listResponse), deleteErr: errors.New("deletion protection")})
+
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			ExecutionState: state,
+			Logger:         logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Empty(t, payload["deleted"])
+
+		failed := payload["failed"].([]CleanupVMsInstance)
+		require.Len(t, failed, 1)
+		assert.Equal(t, "deletion protection", failed[0].Error)
+	})
+}
@@ -0,0 +1,343 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const createInstanceGroupPayloadType = "gcp.compute.instanceGroup"
+
+type CreateInstanceGroup struct{}
+
+type CreateInstanceGroupConfiguration struct {
+	Zone       string           `mapstructure:"zone"`
+	Name       string           `mapstructure:"name"`
+	Network    string           `mapstructure:"network"`
+	NamedPorts []NamedPortEntry `mapstructure:"namedPorts"`
+}
+
+type NamedPortEntry struct {
+	Name string `mapstructure:"name" json:"name"`
+	Port int64  `mapstructure:"port" json:"port"`
+}
+
+type instanceGroupResp struct {
+	Name        string           `json:"name"`
+	SelfLink    string           `json:"selfLink"`
+	Zone        string           `json:"zone"`
+	Network     string           `json:"network"`
+	Size        int64            `json:"size"`
+	NamedPorts  []NamedPortEntry `json:"namedPorts"`
+	Fingerprint string           `json:"fingerprint"`
+}
+
+func (c *CreateInstanceGroup) Name() string {
+	return "gcp.createInstanceGroup"
+}
+
+func (c *CreateInstanceGroup) Label() string {
+	return "Compute • Create Instance Group"
+}
+
+func (c *CreateInstanceGroup) Description() string {
+	return "Create an unmanaged instance group, to use the VMs created by a canvas as a load balancer backend"
+}
+
+func (c *CreateInstanceGroup) Documentation() string {
+	return `The Create Instance Group component creates an unmanaged Compute Engine instance group in a zone.
+
+## Use Cases
+
+- **Load balancing**: Group the VMs created by Create Virtual Machine, with Add Instances to Instance Group, and serve traffic from them with Add Backend
+- **Blue/green rollouts**: Create a group for the new VMs, attach it to the backend service, then detach the old one
+
+## Configuration
+
+- **Zone**: The zone of the group. Only instances of this zone can be added to it.
+- **Name**: The name of the group.
+- **VPC network**: The network of the instances of the group. When empty, the network of the first instance added is used.
+- **Named ports**: Names for the ports the instances serve on, e.g. ` + "`http`" + ` for port 8080. Backend services send traffic to the port with their port name.
+
+## Output
+
+The name, zone, network, size, named ports and self link of the group.`
+}
+
+func (c *CreateInstanceGroup) Icon() string {
+	return "gcp"
+}
+
+func (c *CreateInstanceGroup) Color() string {
+	return "gray"
+}
+
+func (c *CreateInstanceGroup) DefaultExecutionTimeout() time.Duration {
+	return operationExecutionTimeout
+}
+
+func (c *CreateInstanceGroup) ExampleOutput() map[string]any {
+	return map[string]any{
+		"name":       "web",
+		"zone":       "us-central1-a",
+		"network":    "default",
+		"size":       0,
+		"namedPorts": []any{map[string]any{"name": "http", "port": 8080}},
+		"selfLink":   "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instanceGroups/web",
+	}
+}
+
+func (c *CreateInstanceGroup) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateInstanceGroup) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "GCP zone of the instance group (e.g. us-central1-a).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeZone,
+				},
+			},
+		},
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the instance group. Lowercase letters, numbers and hyphens; must start with a letter.",
+			Placeholder: "e.g. web",
+		},
+		{
+			Name:        "network",
+			Label:       "VPC network",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Network of the instances. Leave empty to use the network of the first instance added.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       ResourceTypeNetwork,
+					Parameters: []configuration.ParameterRef{},
+				},
+			},
+		},
+		{
+			Name:        "namedPorts",
+			Label:       "Named ports",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Names for the ports the instances serve on. Backend services send traffic to the port with their port name.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Named port",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "name",
+								Label:       "Name",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "Port name, e.g. the port name of a backend service.",
+								Placeholder: "e.g. http",
+							},
+							{
+								Name:        "port",
+								Label:       "Port",
+								Type:        configuration.FieldTypeNumber,
+								Required:    true,
+								Description: "Port the instances serve on.",
+								Placeholder: "e.g. 8080",
+								TypeOptions: &configuration.TypeOptions{
+									Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(65535)},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func decodeCreateInstanceGroupConfiguration(raw any) (CreateInstanceGroupConfiguration, error) {
+	var config CreateInstanceGroupConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CreateInstanceGroupConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Zone = lastSegment(strings.TrimSpace(config.Zone))
+	config.Name = strings.TrimSpace(config.Name)
+	config.Network = strings.TrimSpace(config.Network)
+	for i := range config.NamedPorts {
+		config.NamedPorts[i].Name = strings.TrimSpace(config.NamedPorts[i].Name)
+	}
+
+	return config, nil
+}
+
+func validateCreateInstanceGroupConfiguration(config CreateInstanceGroupConfiguration) error {
+	if config.Zone == "" {
+		return fmt.Errorf("zone is required")
+	}
+
+	if config.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if !isExpression(config.Name) && !gcpInstanceNameRegex.MatchString(config.Name) {
+		return fmt.Errorf("name must be 1-63 characters, lowercase letters, numbers and hyphens, starting with a letter")
+	}
+
+	return validateNamedPorts(config.NamedPorts)
+}
+
+func validateNamedPorts(ports []NamedPortEntry) error {
+	names := map[string]bool{}
+	for _, port := range ports {
+		if port.Name == "" {
+			return fmt.Errorf("named ports must have a name")
+		}
+
+		if port.Port < 1 || port.Port > 65535 {
+			return fmt.Errorf("named port %s must be between 1 and 65535", port.Name)
+		}
+
+		if names[port.Name] {
+			return fmt.Errorf("named port %s is set more than once", port.Name)
+		}
+
+		names[port.Name] = true
+	}
+
+	return nil
+}
+
+func (c *CreateInstanceGroup) Setup(ctx core.SetupContext) error {
+	config, err := decodeCreateInstanceGroupConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateCreateInstanceGroupConfiguration(config)
+}
+
+func (c *CreateInstanceGroup) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateInstanceGroup) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCreateInstanceGroupConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := validateCreateInstanceGroupConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	group := &compute.InstanceGroup{
+		Name:    config.Name,
+		Network: resolveNetworkURL(project, config.Network),
+	}
+
+	for _, port := range config.NamedPorts {
+		group.NamedPorts = append(group.NamedPorts, &compute.NamedPort{Name: port.Name, Port: port.Port})
+	}
+
+	path := fmt.Sprintf("projects/%s/zones/%s/instanceGroups?requestId=%s", project, config.Zone, url.QueryEscape(ctx.IdempotencyKey()))
+	body, err := client.Post(ctx.GoContext(), path, group)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create instance group %s: %v", config.Name, err))
+	}
+
+	operation, err := operationName(body)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := WaitForZoneOperation(ctx.GoContext(), client, project, config.Zone, operation); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create instance group %s: %v", config.Name, err))
+	}
+
+	created, err := getInstanceGroup(ctx.GoContext(), client, project, config.Zone, config.Name)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, createInstanceGroupPayloadType, []any{
+		instanceGroupPayload(created, config.Zone),
+	})
+}
+
+func getInstanceGroup(ctx context.Context, client Client, project, zone, name string) (*instanceGroupResp, error) {
+	body, err := client.Get(ctx, fmt.Sprintf("projects/%s/zones/%s/instanceGroups/%s", project, zone, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance group %s: %w", name, err)
+	}
+
+	var group instanceGroupResp
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse instance group response: %w", err)
+	}
+
+	return &group, nil
+}
+
+func instanceGroupPayload(group *instanceGroupResp, zone string) map[string]any {
+	namedPorts := group.NamedPorts
+	if namedPorts == nil {
+		namedPorts = []NamedPortEntry{}
+	}
+
+	return map[string]any{
+		"name":       group.Name,
+		"zone":       zone,
+		"network":    lastSegment(group.Network),
+		"size":       group.Size,
+		"namedPorts": namedPorts,
+		"selfLink":   group.SelfLink,
+	}
+}
+
+func (c *CreateInstanceGroup) Actions() []core.Action {
+	return nil
+}
+
+func (c *CreateInstanceGroup) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CreateInstanceGroup) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateInstanceGroup) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateInstanceGroup) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,95 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func Test_CreateInstanceGroup_Setup(t *testing.T) {
+	component := &CreateInstanceGroup{}
+
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		err           string
+	}{
+		{name: "zone is required", configuration: map[string]any{"name": "web"}, err: "zone is required"},
+		{name: "name is required", configuration: map[string]any{"zone": "us-central1-a"}, err: "name is required"},
+		{name: "named port name is required", configuration: map[string]any{"zone": "us-central1-a", "name": "web", "namedPorts": []any{map[string]any{"port": 80}}}, err: "named ports must have a name"},
+		{name: "named port is limited", configuration: map[string]any{"zone": "us-central1-a", "name": "web", "namedPorts": []any{map[string]any{"name": "http", "port": 70000}}}, err: "named port http must be between 1 and 65535"},
+		{name: "named port is set once", configuration: map[string]any{"zone": "us-central1-a", "name": "web", "namedPorts": []any{map[string]any{"name": "http", "port": 80}, map[string]any{"name": "http", "port": 8080}}}, err: "named port http is set more than once"},
+		{name: "valid", configuration: map[string]any{"zone": "us-central1-a", "name": "web", "namedPorts": []any{map[string]any{"name": "http", "port": 8080}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.Setup(core.SetupContext{Configuration: tt.configuration})
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_CreateInstanceGroup_Execute(t *testing.T) {
+	var posted *compute.InstanceGroup
+	var postPath string
+	useInstanceClient(t, &mockOSClient{
+		projectID: "p",
+		post: func(ctx context.Context, path string, body any) ([]byte, error) {
+			postPath = path
+			posted = body.(*compute.InstanceGroup)
+			return []byte(`{"name": "operation-1"}`), nil
+		},
+		get: func(ctx context.Context, path string) ([]byte, error) {
+			switch path {
+			case "projects/p/zones/us-central1-a/operations/operation-1":
+				return []byte(`{"status": "DONE"}`), nil
+			case "projects/p/zones/us-central1-a/instanceGroups/web":
+				return []byte(`{
+					"name": "web",
+					"network": "https://www.googleapis.com/compute/v1/projects/p/global/networks/prod",
+					"size": 0,
+					"namedPorts": [{"name": "http", "port": 8080}],
+					"selfLink": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instanceGroups/web"
+				}`), nil
+			}
+
+			return nil, fmt.Errorf("unexpected path %s", path)
+		},
+	})
+
+	state := &contexts.ExecutionStateContext{}
+	err := (&CreateInstanceGroup{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"zone":       "us-central1-a",
+			"name":       "web",
+			"network":    "prod",
+			"namedPorts": []any{map[string]any{"name": "http", "port": 8080}},
+		},
+		ExecutionState: state,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(postPath, "projects/p/zones/us-central1-a/instanceGroups?requestId="))
+	assert.Equal(t, "web", posted.Name)
+	assert.Equal(t, "projects/p/global/networks/prod", posted.Network)
+	assert.Equal(t, []*compute.NamedPort{{Name: "http", Port: 8080}}, posted.NamedPorts)
+
+	assert.Equal(t, createInstanceGroupPayloadType, state.Type)
+	payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, "web", payload["name"])
+	assert.Equal(t, "prod", payload["network"])
+	assert.Equal(t, []NamedPortEntry{{Name: "http", Port: 8080}}, payload["namedPorts"])
+}
@@ -0,0 +1,451 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	createNodeGroupPayloadType = "gcp.compute.nodeGroup"
+
+	//
+	// Every node of a group has this affinity label,
+	// with the name of the group as its value.
+	//
+	NodeGroupNameAffinityKey = "compute.googleapis.com/node-group-name"
+
+	NodeGroupAutoscalingOff          = "OFF"
+	NodeGroupAutoscalingOn           = "ON"
+	NodeGroupAutoscalingOnlyScaleOut = "ONLY_SCALE_OUT"
+
+	NodeGroupMaintenanceDefault        = "DEFAULT"
+	NodeGroupMaintenanceRestartInPlace = "RESTART_IN_PLACE"
+	NodeGroupMaintenanceMigrate        = "MIGRATE_WITHIN_NODE_GROUP"
+
+	maxNodeGroupNodes = 100
+)
+
+type CreateNodeGroup struct{}
+
+type CreateNodeGroupConfiguration struct {
+	Zone              string `mapstructure:"zone"`
+	Name              string `mapstructure:"name"`
+	NodeTemplate      string `mapstructure:"nodeTemplate"`
+	InitialSize       int64  `mapstructure:"initialSize"`
+	AutoscalingMode   string `mapstructure:"autoscalingMode"`
+	MinNodes          int64  `mapstructure:"minNodes"`
+	MaxNodes          int64  `mapstructure:"maxNodes"`
+	MaintenancePolicy string `mapstructure:"maintenancePolicy"`
+}
+
+type nodeGroupResp struct {
+	Name              string `json:"name"`
+	SelfLink          string `json:"selfLink"`
+	NodeTemplate      string `json:"nodeTemplate"`
+	Size              int64  `json:"size"`
+	Status            string `json:"status"`
+	MaintenancePolicy string `json:"maintenancePolicy"`
+	AutoscalingPolicy *struct {
+		Mode     string `json:"mode"`
+		MinNodes int64  `json:"minNodes"`
+		MaxNodes int64  `json:"maxNodes"`
+	} `json:"autoscalingPolicy"`
+}
+
+func (c *CreateNodeGroup) Name() string {
+	return "gcp.createNodeGroup"
+}
+
+func (c *CreateNodeGroup) Label() string {
+	return "Compute • Create Node Group"
+}
+
+func (c *CreateNodeGroup) Description() string {
+	return "Create a sole-tenant node group from a node template, with optional autoscaling"
+}
+
+func (c *CreateNodeGroup) Documentation() string {
+	return `The Create Node Group component creates a Compute Engine sole-tenant node group in a zone, from a node template of the zone's region.
+
+## Use Cases
+
+- **Sole-tenant environments**: Create the node group after Create Node Template, then place VMs on it with Create Virtual Machine
+- **Dedicated capacity**: Reserve physical servers for a team or a workload, growing with autoscaling
+
+## Configuration
+
+- **Zone**: The zone of the node group.
+- **Name**: The name of the node group.
+- **Node template**: The name of a node template in the region of the zone, e.g. from the output of a Create Node Template step.
+- **Initial size**: The number of nodes created with the group.
+- **Autoscaling**: Off, on, or only scale out. With autoscaling, the group keeps between the minimum and maximum number of nodes, and the initial size must be in that range.
+- **Maintenance policy**: How VMs on the nodes are handled during host maintenance: migrated to other servers (default), restarted in place, or migrated within the node group.
+
+## Placing VMs on the group
+
+Every node of the group has the ` + "`" + NodeGroupNameAffinityKey + "`" + ` affinity label, set to the name of the group. The output has a node affinity rule with that label, for the node affinity of Create Virtual Machine.
+
+## Output
+
+The name, zone, node template, size, status, maintenance policy, autoscaling policy and self link of the group, and the node affinity rule to place VMs on it.`
+}
+
+func (c *CreateNodeGroup) Icon() string {
+	return "gcp"
+}
+
+func (c *CreateNodeGroup) Color() string {
+	return "gray"
+}
+
+func (c *CreateNodeGroup) DefaultExecutionTimeout() time.Duration {
+	return operationExecutionTimeout
+}
+
+func (c *CreateNodeGroup) ExampleOutput() map[string]any {
+	return map[string]any{
+		"name":              "licensed-group",
+		"zone":              "us-central1-a",
+		"nodeTemplate":      "licensed-nodes",
+		"size":              2,
+		"status":            "READY",
+		"maintenancePolicy": NodeGroupMaintenanceDefault,
+		"autoscaling": map[string]any{
+			"mode":     NodeGroupAutoscalingOn,
+			"minNodes": 1,
+			"maxNodes": 5,
+		},
+		"nodeAffinity": map[string]any{
+			"key":      NodeGroupNameAffinityKey,
+			"operator": NodeAffinityOperatorIn,
+			"values":   []string{"licensed-group"},
+		},
+		"selfLink": "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/nodeGroups/licensed-group",
+	}
+}
+
+func (c *CreateNodeGroup) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateNodeGroup) Configuration() []configuration.Field {
+	autoscaling := []string{NodeGroupAutoscalingOn, NodeGroupAutoscalingOnlyScaleOut}
+
+	return []configuration.Field{
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "GCP zone of the node group (e.g. us-central1-a).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeZone,
+				},
+			},
+		},
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the node group. Lowercase letters, numbers and hyphens; must start with a letter.",
+			Placeholder: "e.g. licensed-group",
+		},
+		{
+			Name:        "nodeTemplate",
+			Label:       "Node template",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of a node template in the region of the zone.",
+			Placeholder: "e.g. {{ $['Create Node Template'].data.name }}",
+		},
+		{
+			Name:        "initialSize",
+			Label:       "Initial size",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Number of nodes created with the group.",
+			Default:     1,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0), Max: intPtr(maxNodeGroupNodes)},
+			},
+		},
+		{
+			Name:        "autoscalingMode",
+			Label:       "Autoscaling",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "Add and remove nodes based on the VMs placed on the group.",
+			Default:     NodeGroupAutoscalingOff,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Off", Value: NodeGroupAutoscalingOff},
+						{Label: "On", Value: NodeGroupAutoscalingOn},
+						{Label: "Only scale out", Value: NodeGroupAutoscalingOnlyScaleOut},
+					},
+				},
+			},
+		},
+		{
+			Name:        "minNodes",
+			Label:       "Minimum nodes",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Minimum number of nodes kept by autoscaling.",
+			Default:     0,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0), Max: intPtr(maxNodeGroupNodes)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "autoscalingMode", Values: autoscaling},
+			},
+		},
+		{
+			Name:        "maxNodes",
+			Label:       "Maximum nodes",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Maximum number of nodes added by autoscaling.",
+			Default:     1,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(maxNodeGroupNodes)},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "autoscalingMode", Values: autoscaling},
+			},
+		},
+		{
+			Name:        "maintenancePolicy",
+			Label:       "Maintenance policy",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "How VMs on the nodes are handled during host maintenance.",
+			Default:     NodeGroupMaintenanceDefault,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Migrate to other servers", Value: NodeGroupMaintenanceDefault},
+						{Label: "Restart in place", Value: NodeGroupMaintenanceRestartInPlace},
+						{Label: "Migrate within node group", Value: NodeGroupMaintenanceMigrate},
+					},
+				},
+			},
+		},
+	}
+}
+
+func decodeCreateNodeGroupConfiguration(raw any) (CreateNodeGroupConfiguration, error) {
+	config := CreateNodeGroupConfiguration{InitialSize: 1}
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CreateNodeGroupConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Zone = lastSegment(strings.TrimSpace(config.Zone))
+	config.Name = strings.TrimSpace(config.Name)
+	config.NodeTemplate = lastSegment(strings.TrimSpace(config.NodeTemplate))
+	config.AutoscalingMode = strings.TrimSpace(config.AutoscalingMode)
+	if config.AutoscalingMode == "" {
+		config.AutoscalingMode = NodeGroupAutoscalingOff
+	}
+
+	config.MaintenancePolicy = strings.TrimSpace(config.MaintenancePolicy)
+	if config.MaintenancePolicy == "" {
+		config.MaintenancePolicy = NodeGroupMaintenanceDefault
+	}
+
+	return config, nil
+}
+
+func validateCreateNodeGroupConfiguration(config CreateNodeGroupConfiguration) error {
+	if config.Zone == "" {
+		return fmt.Errorf("zone is required")
+	}
+
+	if config.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if !isExpression(config.Name) && !gcpInstanceNameRegex.MatchString(config.Name) {
+		return fmt.Errorf("name must be 1-63 characters, lowercase letters, numbers and hyphens, starting with a letter")
+	}
+
+	if config.NodeTemplate == "" {
+		return fmt.Errorf("nodeTemplate is required")
+	}
+
+	if config.InitialSize < 0 || config.InitialSize > maxNodeGroupNodes {
+		return fmt.Errorf("initialSize must be between 0 and %d", maxNodeGroupNodes)
+	}
+
+	switch config.MaintenancePolicy {
+	case NodeGroupMaintenanceDefault, NodeGroupMaintenanceRestartInPlace, NodeGroupMaintenanceMigrate:
+	default:
+		return fmt.Errorf("invalid maintenancePolicy %q", config.MaintenancePolicy)
+	}
+
+	switch config.AutoscalingMode {
+	case NodeGroupAutoscalingOff:
+		return nil
+	case NodeGroupAutoscalingOn, NodeGroupAutoscalingOnlyScaleOut:
+	default:
+		return fmt.Errorf("invalid autoscalingMode %q", config.AutoscalingMode)
+	}
+
+	if config.MaxNodes < 1 || config.MaxNodes > maxNodeGroupNodes {
+		return fmt.Errorf("maxNodes must be between 1 and %d", maxNodeGroupNodes)
+	}
+
+	if config.MinNodes < 0 || config.MinNodes > config.MaxNodes {
+		return fmt.Errorf("minNodes must be between 0 and maxNodes")
+	}
+
+	if config.InitialSize < config.MinNodes || config.InitialSize > config.MaxNodes {
+		return fmt.Errorf("initialSize must be between minNodes and maxNodes")
+	}
+
+	return nil
+}
+
+func (c *CreateNodeGroup) Setup(ctx core.SetupContext) error {
+	config, err := decodeCreateNodeGroupConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateCreateNodeGroupConfiguration(config)
+}
+
+func (c *CreateNodeGroup) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateNodeGroup) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCreateNodeGroupConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := validateCreateNodeGroupConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	path := fmt.Sprintf(
+		"projects/%s/zones/%s/nodeGroups?initialNodeCount=%d&requestId=%s",
+		project,
+		config.Zone,
+		config.InitialSize,
+		url.QueryEscape(ctx.IdempotencyKey()),
+	)
+
+	body, err := client.Post(ctx.GoContext(), path, buildNodeGroup(project, config))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create node group %s: %v", config.Name, err))
+	}
+
+	operation, err := operationName(body)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := WaitForZoneOperation(ctx.GoContext(), client, project, config.Zone, operation); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create node group %s: %v", config.Name, err))
+	}
+
+	body, err = client.Get(ctx.GoContext(), fmt.Sprintf("projects/%s/zones/%s/nodeGroups/%s", project, config.Zone, config.Name))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get node group %s: %v", config.Name, err))
+	}
+
+	var group nodeGroupResp
+	if err := json.Unmarshal(body, &group); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse node group response: %v", err))
+	}
+
+	autoscaling := map[string]any{"mode": NodeGroupAutoscalingOff}
+	if group.AutoscalingPolicy != nil && group.AutoscalingPolicy.Mode != "" {
+		autoscaling = map[string]any{
+			"mode":     group.AutoscalingPolicy.Mode,
+			"minNodes": group.AutoscalingPolicy.MinNodes,
+			"maxNodes": group.AutoscalingPolicy.MaxNodes,
+		}
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, createNodeGroupPayloadType, []any{
+		map[string]any{
+			"name":              group.Name,
+			"zone":              config.Zone,
+			"nodeTemplate":      lastSegment(group.NodeTemplate),
+			"size":              group.Size,
+			"status":            group.Status,
+			"maintenancePolicy": group.MaintenancePolicy,
+			"autoscaling":       autoscaling,
+			"nodeAffinity": map[string]any{
+				"key":      NodeGroupNameAffinityKey,
+				"operator": NodeAffinityOperatorIn,
+				"values":   []string{group.Name},
+			},
+			"selfLink": group.SelfLink,
+		},
+	})
+}
+
+/*
+ * The node template is always in the region of the zone,
+ * so it is referenced by name.
+ */
+func buildNodeGroup(project string, config CreateNodeGroupConfiguration) *compute.NodeGroup {
+	group := &compute.NodeGroup{
+		Name:              config.Name,
+		NodeTemplate:      fmt.Sprintf("projects/%s/regions/%s/nodeTemplates/%s", project, deriveRegionFromZone(config.Zone), config.NodeTemplate),
+		MaintenancePolicy: config.MaintenancePolicy,
+	}
+
+	if config.AutoscalingMode != NodeGroupAutoscalingOff {
+		group.AutoscalingPolicy = &compute.NodeGroupAutoscalingPolicy{
+			Mode:            config.AutoscalingMode,
+			MinNodes:        config.MinNodes,
+			MaxNodes:        config.MaxNodes,
+			ForceSendFields: []string{"MinNodes"},
+		}
+	}
+
+	return group
+}
+
+func (c *CreateNodeGroup) Actions() []core.Action {
+	return nil
+}
+
+func (c *CreateNodeGroup) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CreateNodeGroup) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateNodeGroup) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateNodeGroup) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,120 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func Test_CreateNodeGroup_Setup(t *testing.T) {
+	component := &CreateNodeGroup{}
+	base := func(extra map[string]any) map[string]any {
+		configuration := map[string]any{"zone": "us-central1-a", "name": "group", "nodeTemplate": "nodes"}
+		for k, v := range extra {
+			configuration[k] = v
+		}
+
+		return configuration
+	}
+
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		err           string
+	}{
+		{name: "zone is required", configuration: map[string]any{"name": "group", "nodeTemplate": "nodes"}, err: "zone is required"},
+		{name: "node template is required", configuration: map[string]any{"zone": "us-central1-a", "name": "group"}, err: "nodeTemplate is required"},
+		{name: "initial size is limited", configuration: base(map[string]any{"initialSize": 101}), err: "initialSize must be between 0 and 100"},
+		{name: "invalid maintenance policy", configuration: base(map[string]any{"maintenancePolicy": "NEVER"}), err: "invalid maintenancePolicy"},
+		{name: "max nodes is required with autoscaling", configuration: base(map[string]any{"autoscalingMode": "ON", "maxNodes": 0}), err: "maxNodes must be between 1 and 100"},
+		{name: "min nodes above max nodes", configuration: base(map[string]any{"autoscalingMode": "ON", "minNodes": 3, "maxNodes": 2}), err: "minNodes must be between 0 and maxNodes"},
+		{name: "initial size outside of autoscaling range", configuration: base(map[string]any{"autoscalingMode": "ONLY_SCALE_OUT", "initialSize": 5, "maxNodes": 3}), err: "initialSize must be between minNodes and maxNodes"},
+		{name: "valid", configuration: base(nil)},
+		{name: "valid with autoscaling", configuration: base(map[string]any{"autoscalingMode": "ON", "initialSize": 2, "minNodes": 1, "maxNodes": 5})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.Setup(core.SetupContext{Configuration: tt.configuration})
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_CreateNodeGroup_Execute(t *testing.T) {
+	component := &CreateNodeGroup{}
+
+	var posted *compute.NodeGroup
+	var postPath string
+	client := &mockOSClient{
+		projectID: "p",
+		post: func(ctx context.Context, path string, body any) ([]byte, error) {
+			postPath = path
+			posted = body.(*compute.NodeGroup)
+			return []byte(`{"name": "operation-1"}`), nil
+		},
+		get: func(ctx context.Context, path string) ([]byte, error) {
+			switch path {
+			case "projects/p/zones/us-central1-a/operations/operation-1":
+				return []byte(`{"status": "DONE"}`), nil
+			case "projects/p/zones/us-central1-a/nodeGroups/licensed-group":
+				return []byte(`{
+					"name": "licensed-group",
+					"nodeTemplate": "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1/nodeTemplates/licensed-nodes",
+					"size": 2,
+					"status": "READY",
+					"maintenancePolicy": "DEFAULT",
+					"autoscalingPolicy": {"mode": "ON", "minNodes": 1, "maxNodes": 5}
+				}`), nil
+			}
+
+			return nil, fmt.Errorf("unexpected path %s", path)
+		},
+	}
+
+	useInstanceClient(t, client)
+
+	state := &contexts.ExecutionStateContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"zone":            "us-central1-a",
+			"name":            "licensed-group",
+			"nodeTemplate":    "licensed-nodes",
+			"initialSize":     2,
+			"autoscalingMode": "ON",
+			"minNodes":        1,
+			"maxNodes":        5,
+		},
+		ExecutionState: state,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(postPath, "projects/p/zones/us-central1-a/nodeGroups?initialNodeCount=2&requestId="))
+	assert.Equal(t, "licensed-group", posted.Name)
+	assert.Equal(t, "projects/p/regions/us-central1/nodeTemplates/licensed-nodes", posted.NodeTemplate)
+	assert.Equal(t, NodeGroupMaintenanceDefault, posted.MaintenancePolicy)
+	assert.Equal(t, &compute.NodeGroupAutoscalingPolicy{Mode: "ON", MinNodes: 1, MaxNodes: 5, ForceSendFields: []string{"MinNodes"}}, posted.AutoscalingPolicy)
+
+	assert.Equal(t, createNodeGroupPayloadType, state.Type)
+	payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, "licensed-nodes", payload["nodeTemplate"])
+	assert.Equal(t, int64(2), payload["size"])
+	assert.Equal(t, map[string]any{"mode": "ON", "minNodes": int64(1), "maxNodes": int64(5)}, payload["autoscaling"])
+	assert.Equal(t, map[string]any{
+		"key":      NodeGroupNameAffinityKey,
+		"operator": NodeAffinityOperatorIn,
+		"values":   []string{"licensed-group"},
+	}, payload["nodeAffinity"])
+}
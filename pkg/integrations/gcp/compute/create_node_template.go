@@ -0,0 +1,385 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	createNodeTemplatePayloadType = "gcp.compute.nodeTemplate"
+
+	ServerBindingAnyServer      = "RESTART_NODE_ON_ANY_SERVER"
+	ServerBindingMinimalServers = "RESTART_NODE_ON_MINIMAL_SERVERS"
+)
+
+type CreateNodeTemplate struct{}
+
+type CreateNodeTemplateConfiguration struct {
+	Region             string       `mapstructure:"region"`
+	Name               string       `mapstructure:"name"`
+	NodeType           string       `mapstructure:"nodeType"`
+	NodeAffinityLabels []LabelEntry `mapstructure:"nodeAffinityLabels"`
+	ServerBinding      string       `mapstructure:"serverBinding"`
+	CPUOvercommit      bool         `mapstructure:"cpuOvercommit"`
+}
+
+type nodeTemplateResp struct {
+	Name               string            `json:"name"`
+	SelfLink           string            `json:"selfLink"`
+	Region             string            `json:"region"`
+	NodeType           string            `json:"nodeType"`
+	NodeAffinityLabels map[string]string `json:"nodeAffinityLabels"`
+	Status             string            `json:"status"`
+	CPUOvercommitType  string            `json:"cpuOvercommitType"`
+	ServerBinding      *struct {
+		Type string `json:"type"`
+	} `json:"serverBinding"`
+}
+
+func (c *CreateNodeTemplate) Name() string {
+	return "gcp.createNodeTemplate"
+}
+
+func (c *CreateNodeTemplate) Label() string {
+	return "Compute • Create Node Template"
+}
+
+func (c *CreateNodeTemplate) Description() string {
+	return "Create a sole-tenant node template, the blueprint of the nodes of a node group"
+}
+
+func (c *CreateNodeTemplate) Documentation() string {
+	return `The Create Node Template component creates a Compute Engine sole-tenant node template in a region. Node groups created from the template get its node type and affinity labels.
+
+## Use Cases
+
+- **Sole-tenant environments**: Create the template, then a node group from it with Create Node Group, then VMs on the group with Create Virtual Machine
+- **Licensing and compliance**: Keep workloads with bring-your-own-license or isolation requirements on dedicated hosts
+
+## Configuration
+
+- **Region**: The region of the template. Node groups using it must be in a zone of this region.
+- **Name**: The name of the template.
+- **Node type**: The node type of the nodes, e.g. ` + "`n2-node-80-640`" + `.
+- **Node affinity labels**: Labels set on the nodes. VMs can target them with the node affinity rules of Create Virtual Machine.
+- **Server binding**: Whether nodes restart on any physical server after a maintenance event, or on as few servers as possible, e.g. for per-core licenses.
+- **CPU overcommit**: Lets VMs on the nodes share CPUs, so more VMs fit on a node.
+
+## Output
+
+The name, region, node type, node affinity labels, status and self link of the template.`
+}
+
+func (c *CreateNodeTemplate) Icon() string {
+	return "gcp"
+}
+
+func (c *CreateNodeTemplate) Color() string {
+	return "gray"
+}
+
+func (c *CreateNodeTemplate) DefaultExecutionTimeout() time.Duration {
+	return operationExecutionTimeout
+}
+
+func (c *CreateNodeTemplate) ExampleOutput() map[string]any {
+	return map[string]any{
+		"name":               "licensed-nodes",
+		"region":             "us-central1",
+		"nodeType":           "n2-node-80-640",
+		"nodeAffinityLabels": map[string]any{"workload": "licensed"},
+		"serverBinding":      ServerBindingMinimalServers,
+		"cpuOvercommitType":  "NONE",
+		"status":             "READY",
+		"selfLink":           "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/nodeTemplates/licensed-nodes",
+	}
+}
+
+func (c *CreateNodeTemplate) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateNodeTemplate) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "GCP region of the template (e.g. us-central1).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeRegion,
+				},
+			},
+		},
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the template. Lowercase letters, numbers and hyphens; must start with a letter.",
+			Placeholder: "e.g. licensed-nodes",
+		},
+		{
+			Name:        "nodeType",
+			Label:       "Node type",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Node type of the nodes (e.g. n2-node-80-640, c2-node-60-240).",
+			Placeholder: "e.g. n2-node-80-640",
+		},
+		{
+			Name:        "nodeAffinityLabels",
+			Label:       "Node affinity labels",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Labels set on the nodes, which VMs can target with node affinity rules.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Label",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "key",
+								Label:       "Key",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "Label key (e.g. workload).",
+								Placeholder: "e.g. workload",
+							},
+							{
+								Name:        "value",
+								Label:       "Value",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Description: "Label value.",
+								Placeholder: "e.g. licensed",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "serverBinding",
+			Label:       "Server binding",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "Where nodes restart after a maintenance event.",
+			Default:     ServerBindingAnyServer,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Any server", Value: ServerBindingAnyServer},
+						{Label: "Minimal servers (per-core licenses)", Value: ServerBindingMinimalServers},
+					},
+				},
+			},
+		},
+		{
+			Name:        "cpuOvercommit",
+			Label:       "CPU overcommit",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Let VMs on the nodes share CPUs.",
+			Default:     false,
+		},
+	}
+}
+
+func decodeCreateNodeTemplateConfiguration(raw any) (CreateNodeTemplateConfiguration, error) {
+	var config CreateNodeTemplateConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return CreateNodeTemplateConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Region = lastSegment(strings.TrimSpace(config.Region))
+	config.Name = strings.TrimSpace(config.Name)
+	config.NodeType = lastSegment(strings.TrimSpace(config.NodeType))
+	config.ServerBinding = strings.TrimSpace(config.ServerBinding)
+	if config.ServerBinding == "" {
+		config.ServerBinding = ServerBindingAnyServer
+	}
+
+	return config, nil
+}
+
+func validateCreateNodeTemplateConfiguration(config CreateNodeTemplateConfiguration) error {
+	if config.Region == "" {
+		return fmt.Errorf("region is required")
+	}
+
+	if config.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if !isExpression(config.Name) && !gcpInstanceNameRegex.MatchString(config.Name) {
+		return fmt.Errorf("name must be 1-63 characters, lowercase letters, numbers and hyphens, starting with a letter")
+	}
+
+	if config.NodeType == "" {
+		return fmt.Errorf("nodeType is required")
+	}
+
+	if config.ServerBinding != ServerBindingAnyServer && config.ServerBinding != ServerBindingMinimalServers {
+		return fmt.Errorf("invalid serverBinding %q", config.ServerBinding)
+	}
+
+	for _, label := range config.NodeAffinityLabels {
+		if strings.TrimSpace(label.Key) == "" {
+			return fmt.Errorf("node affinity labels must have a key")
+		}
+	}
+
+	return nil
+}
+
+func (c *CreateNodeTemplate) Setup(ctx core.SetupContext) error {
+	config, err := decodeCreateNodeTemplateConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return validateCreateNodeTemplateConfiguration(config)
+}
+
+func (c *CreateNodeTemplate) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateNodeTemplate) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeCreateNodeTemplateConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := validateCreateNodeTemplateConfiguration(config); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	project := client.ProjectID()
+	path := fmt.Sprintf("projects/%s/regions/%s/nodeTemplates?requestId=%s", project, config.Region, url.QueryEscape(ctx.IdempotencyKey()))
+	body, err := client.Post(ctx.GoContext(), path, buildNodeTemplate(config))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create node template %s: %v", config.Name, err))
+	}
+
+	operation, err := operationName(body)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if err := WaitForRegionOperation(ctx.GoContext(), client, project, config.Region, operation); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create node template %s: %v", config.Name, err))
+	}
+
+	body, err = client.Get(ctx.GoContext(), fmt.Sprintf("projects/%s/regions/%s/nodeTemplates/%s", project, config.Region, config.Name))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get node template %s: %v", config.Name, err))
+	}
+
+	var template nodeTemplateResp
+	if err := json.Unmarshal(body, &template); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to parse node template response: %v", err))
+	}
+
+	serverBinding := config.ServerBinding
+	if template.ServerBinding != nil && template.ServerBinding.Type != "" {
+		serverBinding = template.ServerBinding.Type
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, createNodeTemplatePayloadType, []any{
+		map[string]any{
+			"name":               template.Name,
+			"region":             config.Region,
+			"nodeType":           lastSegment(template.NodeType),
+			"nodeAffinityLabels": template.NodeAffinityLabels,
+			"serverBinding":      serverBinding,
+			"cpuOvercommitType":  template.CPUOvercommitType,
+			"status":             template.Status,
+			"selfLink":           template.SelfLink,
+		},
+	})
+}
+
+func buildNodeTemplate(config CreateNodeTemplateConfiguration) *compute.NodeTemplate {
+	template := &compute.NodeTemplate{
+		Name:              config.Name,
+		NodeType:          config.NodeType,
+		ServerBinding:     &compute.ServerBinding{Type: config.ServerBinding},
+		CpuOvercommitType: "NONE",
+	}
+
+	if config.CPUOvercommit {
+		template.CpuOvercommitType = "ENABLED"
+	}
+
+	for _, label := range config.NodeAffinityLabels {
+		key := strings.TrimSpace(label.Key)
+		if key == "" {
+			continue
+		}
+
+		if template.NodeAffinityLabels == nil {
+			template.NodeAffinityLabels = map[string]string{}
+		}
+
+		template.NodeAffinityLabels[key] = strings.TrimSpace(label.Value)
+	}
+
+	return template
+}
+
+/*
+ * operationName reads the name of the operation
+ * started by an insert request.
+ */
+func operationName(body []byte) (string, error) {
+	var op struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.Unmarshal(body, &op); err != nil || op.Name == "" {
+		return "", fmt.Errorf("failed to parse operation response: %v", err)
+	}
+
+	return lastSegment(op.Name), nil
+}
+
+func (c *CreateNodeTemplate) Actions() []core.Action {
+	return nil
+}
+
+func (c *CreateNodeTemplate) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CreateNodeTemplate) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateNodeTemplate) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateNodeTemplate) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,131 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func Test_CreateNodeTemplate_Setup(t *testing.T) {
+	component := &CreateNodeTemplate{}
+
+	tests := []struct {
+		name          string
+		configuration map[string]any
+		err           string
+	}{
+		{name: "region is required", configuration: map[string]any{"name": "nodes", "nodeType": "n2-node-80-640"}, err: "region is required"},
+		{name: "name is required", configuration: map[string]any{"region": "us-central1", "nodeType": "n2-node-80-640"}, err: "name is required"},
+		{name: "name must be valid", configuration: map[string]any{"region": "us-central1", "name": "Nodes", "nodeType": "n2-node-80-640"}, err: "name must be 1-63 characters"},
+		{name: "node type is required", configuration: map[string]any{"region": "us-central1", "name": "nodes"}, err: "nodeType is required"},
+		{name: "label key is required", configuration: map[string]any{"region": "us-central1", "name": "nodes", "nodeType": "n2-node-80-640", "nodeAffinityLabels": []any{map[string]any{"value": "x"}}}, err: "must have a key"},
+		{name: "valid", configuration: map[string]any{"region": "us-central1", "name": "nodes", "nodeType": "n2-node-80-640"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.Setup(core.SetupContext{Configuration: tt.configuration})
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_CreateNodeTemplate_Execute(t *testing.T) {
+	component := &CreateNodeTemplate{}
+
+	t.Run("template is created and emitted", func(t *testing.T) {
+		var posted *compute.NodeTemplate
+		var postPath string
+		client := &mockOSClient{
+			projectID: "p",
+			post: func(ctx context.Context, path string, body any) ([]byte, error) {
+				postPath = path
+				posted = body.(*compute.NodeTemplate)
+				return []byte(`{"name": "operation-1"}`), nil
+			},
+			get: func(ctx context.Context, path string) ([]byte, error) {
+				switch path {
+				case "projects/p/regions/us-central1/operations/operation-1":
+					return []byte(`{"status": "DONE"}`), nil
+				case "projects/p/regions/us-central1/nodeTemplates/licensed-nodes":
+					return []byte(`{
+						"name": "licensed-nodes",
+						"nodeType": "n2-node-80-640",
+						"nodeAffinityLabels": {"workload": "licensed"},
+						"serverBinding": {"type": "RESTART_NODE_ON_MINIMAL_SERVERS"},
+						"cpuOvercommitType": "ENABLED",
+						"status": "READY",
+						"selfLink": "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1/nodeTemplates/licensed-nodes"
+					}`), nil
+				}
+
+				return nil, fmt.Errorf("unexpected path %s", path)
+			},
+		}
+
+		useInstanceClient(t, client)
+
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":             "us-central1",
+				"name":               "licensed-nodes",
+				"nodeType":           "n2-node-80-640",
+				"nodeAffinityLabels": []any{map[string]any{"key": "workload", "value": "licensed"}},
+				"serverBinding":      ServerBindingMinimalServers,
+				"cpuOvercommit":      true,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(postPath, "projects/p/regions/us-central1/nodeTemplates?requestId="))
+		assert.Equal(t, "licensed-nodes", posted.Name)
+		assert.Equal(t, "n2-node-80-640", posted.NodeType)
+		assert.Equal(t, map[string]string{"workload": "licensed"}, posted.NodeAffinityLabels)
+		assert.Equal(t, ServerBindingMinimalServers, posted.ServerBinding.Type)
+		assert.Equal(t, "ENABLED", posted.CpuOvercommitType)
+
+		assert.Equal(t, core.DefaultOutputChannel.Name, state.Channel)
+		assert.Equal(t, createNodeTemplatePayloadType, state.Type)
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "licensed-nodes", payload["name"])
+		assert.Equal(t, "us-central1", payload["region"])
+		assert.Equal(t, "READY", payload["status"])
+		assert.Equal(t, map[string]string{"workload": "licensed"}, payload["nodeAffinityLabels"])
+	})
+
+	t.Run("failed operation -> fails", func(t *testing.T) {
+		useInstanceClient(t, &mockOSClient{
+			projectID: "p",
+			post: func(ctx context.Context, path string, body any) ([]byte, error) {
+				return []byte(`{"name": "operation-1"}`), nil
+			},
+			get: func(ctx context.Context, path string) ([]byte, error) {
+				return []byte(`{"status": "DONE", "error": {"errors": [{"message": "node type not found"}]}}`), nil
+			},
+		})
+
+		state := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"region": "us-central1", "name": "nodes", "nodeType": "x-node"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "node type not found")
+	})
+}
@@ -3,9 +3,13 @@ package compute
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -20,7 +24,9 @@ import (
 type Client interface {
 	Get(ctx context.Context, path string) ([]byte, error)
 	Post(ctx context.Context, path string, body any) ([]byte, error)
+	Patch(ctx context.Context, path string, body any) ([]byte, error)
 	GetURL(ctx context.Context, fullURL string) ([]byte, error)
+	Delete(ctx context.Context, path string) ([]byte, error)
 	ProjectID() string
 }
 
@@ -113,15 +119,17 @@ type OSAndStorageConfig struct {
 	BootDiskAutoDelete       bool                  `mapstructure:"bootDiskAutoDelete"`
 	LocalSSDCount            int64                 `mapstructure:"localSSDCount"`
 	AdditionalDisks          []AdditionalDiskEntry `mapstructure:"additionalDisks"`
+	DiskEncryptionKey        string                `mapstructure:"diskEncryptionKey"`
 }
 
 type AdditionalDiskEntry struct {
-	Mode         string `mapstructure:"mode"`
-	Name         string `mapstructure:"name"`
-	SizeGb       int64  `mapstructure:"sizeGb"`
-	DiskType     string `mapstructure:"diskType"`
-	ExistingDisk string `mapstructure:"existingDisk"`
-	AutoDelete   bool   `mapstructure:"autoDelete"`
+	Mode          string `mapstructure:"mode"`
+	Name          string `mapstructure:"name"`
+	SizeGb        int64  `mapstructure:"sizeGb"`
+	DiskType      string `mapstructure:"diskType"`
+	ExistingDisk  string `mapstructure:"existingDisk"`
+	AutoDelete    bool   `mapstructure:"autoDelete"`
+	EncryptionKey string `mapstructure:"encryptionKey"`
 }
 
 type BootDiskConfig struct {
@@ -137,11 +145,12 @@ type BootDiskConfig struct {
 }
 
 type AdditionalDisk struct {
-	Name       string
-	SizeGb     int64
-	DiskType   string
-	SourceDisk string
-	AutoDelete bool
+	Name              string
+	SizeGb            int64
+	DiskType          string
+	SourceDisk        string
+	AutoDelete        bool
+	DiskEncryptionKey string
 }
 
 func BuildBootDisk(project, zone string, config BootDiskConfig) *compute.AttachedDisk {
@@ -253,6 +262,8 @@ func buildOneAdditionalDisk(project, zone string, d AdditionalDisk) *compute.Att
 	if isLocalSSD {
 		att.Interface = "NVME"
 		att.Type = "SCRATCH"
+	} else if diskEncryptionKey := buildDiskEncryptionKey(d.DiskEncryptionKey); diskEncryptionKey != nil {
+		att.DiskEncryptionKey = diskEncryptionKey
 	}
 	return att
 }
@@ -377,6 +388,11 @@ const (
 	StackTypeDualStack = "IPV4_IPV6"
 )
 
+const (
+	FirewallRuleModeCreate = "create"
+	FirewallRuleModeReuse  = "reuse"
+)
+
 const (
 	ExternalIPNone      = "none"
 	ExternalIPEphemeral = "ephemeral"
@@ -505,19 +521,7 @@ func CreateFirewallRule(ctx context.Context, c Client, project, network string,
 	if err != nil {
 		return err
 	}
-	sourceRanges := strings.Split(rule.SourceRanges, ",")
-	for i := range sourceRanges {
-		sourceRanges[i] = strings.TrimSpace(sourceRanges[i])
-		if sourceRanges[i] == "" {
-			continue
-		}
-	}
-	trimmed := make([]string, 0, len(sourceRanges))
-	for _, r := range sourceRanges {
-		if r != "" {
-			trimmed = append(trimmed, r)
-		}
-	}
+	trimmed := parseSourceRanges(rule.SourceRanges)
 	if len(trimmed) == 0 {
 		return fmt.Errorf("sourceRanges is required")
 	}
@@ -575,17 +579,134 @@ func EnsureFirewallRules(ctx context.Context, c Client, project, network string,
 	return tags, nil
 }
 
+// ReuseFirewallRules applies the target tag of an existing rule with the same allowed protocols, ports
+// and source ranges in the network, and only creates the rules that have no match. A matching rule
+// with no target tags already applies to every instance of the network, so no tag is needed for it.
+func ReuseFirewallRules(ctx context.Context, c Client, project, network string, rules []CreateFirewallRuleEntry) ([]string, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	firewalls, err := ListFirewalls(ctx, c, project)
+	if err != nil {
+		return nil, fmt.Errorf("list firewall rules: %w", err)
+	}
+	seen := make(map[string]struct{})
+	var tags []string
+	for _, r := range rules {
+		if strings.TrimSpace(r.Name) == "" {
+			continue
+		}
+		tag, found, err := matchingFirewallTag(firewalls, network, r)
+		if err != nil {
+			return nil, fmt.Errorf("firewall rule %q: %w", r.Name, err)
+		}
+		if !found {
+			if err := CreateFirewallRule(ctx, c, project, network, r); err != nil {
+				return nil, fmt.Errorf("create firewall rule %q: %w", r.Name, err)
+			}
+			tag = strings.TrimSpace(r.TargetTag)
+		}
+		if tag != "" {
+			if _, ok := seen[tag]; !ok {
+				seen[tag] = struct{}{}
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags, nil
+}
+
+// matchingFirewallTag finds an enabled ingress rule of the network that allows exactly the same protocols
+// and ports from exactly the same source ranges, and returns the tag to apply for it. The rule's own target
+// tag is preferred when an existing rule has it. Rules scoped with source tags or service accounts never match.
+func matchingFirewallTag(firewalls []Firewall, network string, rule CreateFirewallRuleEntry) (string, bool, error) {
+	allowed, err := parseAllowed(rule.Allowed)
+	if err != nil {
+		return "", false, err
+	}
+	wantAllowed := make([]FirewallAllowed, 0, len(allowed))
+	for _, a := range allowed {
+		wantAllowed = append(wantAllowed, FirewallAllowed{IPProtocol: a.IPProtocol, Ports: a.Ports})
+	}
+	wantAllowedKey := firewallAllowedKey(wantAllowed)
+	wantSourcesKey := firewallSourceRangesKey(parseSourceRanges(rule.SourceRanges))
+	targetTag := strings.TrimSpace(rule.TargetTag)
+
+	networkName := lastSegment(strings.TrimSpace(network))
+	if networkName == "" {
+		networkName = "default"
+	}
+
+	var candidate *Firewall
+	for i := range firewalls {
+		f := &firewalls[i]
+		if f.Disabled || (f.Direction != "" && f.Direction != "INGRESS") || lastSegment(f.Network) != networkName {
+			continue
+		}
+		if len(f.SourceTags) > 0 || len(f.TargetServiceAccounts) > 0 {
+			continue
+		}
+		if firewallAllowedKey(f.Allowed) != wantAllowedKey || firewallSourceRangesKey(f.SourceRanges) != wantSourcesKey {
+			continue
+		}
+		if targetTag != "" && slices.Contains(f.TargetTags, targetTag) {
+			return targetTag, true, nil
+		}
+		if candidate == nil {
+			candidate = f
+		}
+	}
+	if candidate == nil {
+		return "", false, nil
+	}
+	if len(candidate.TargetTags) == 0 {
+		return "", true, nil
+	}
+	return candidate.TargetTags[0], true, nil
+}
+
+// firewallAllowedKey is an order-independent representation of allowed protocols and ports, e.g. "tcp:443,80;udp:53".
+func firewallAllowedKey(allowed []FirewallAllowed) string {
+	entries := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		ports := slices.Clone(a.Ports)
+		slices.Sort(ports)
+		entries = append(entries, strings.ToLower(a.IPProtocol)+":"+strings.Join(ports, ","))
+	}
+	slices.Sort(entries)
+	return strings.Join(entries, ";")
+}
+
+func firewallSourceRangesKey(ranges []string) string {
+	sorted := slices.Clone(ranges)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func parseSourceRanges(s string) []string {
+	out := []string{}
+	for _, r := range strings.Split(s, ",") {
+		r = strings.TrimSpace(r)
+		if r != "" {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
 type NetworkingConfig struct {
-	Network             string                    `mapstructure:"network"`
-	Subnetwork          string                    `mapstructure:"subnetwork"`
-	NicType             string                    `mapstructure:"nicType"`
-	InternalIPType      string                    `mapstructure:"internalIPType"`
-	InternalIPAddress   string                    `mapstructure:"internalIPAddress"`
-	ExternalIPType      string                    `mapstructure:"externalIPType"`
-	ExternalIPAddress   string                    `mapstructure:"externalIPAddress"`
-	NetworkTags         string                    `mapstructure:"networkTags"`
-	StackType           string                    `mapstructure:"stackType"`
-	CreateFirewallRules []CreateFirewallRuleEntry `mapstructure:"createFirewallRules"`
+	Network                   string                    `mapstructure:"network"`
+	Subnetwork                string                    `mapstructure:"subnetwork"`
+	NicType                   string                    `mapstructure:"nicType"`
+	InternalIPType            string                    `mapstructure:"internalIPType"`
+	InternalIPAddress         string                    `mapstructure:"internalIPAddress"`
+	ReservedInternalIPAddress string                    `mapstructure:"reservedInternalIPAddress"`
+	ExternalIPType            string                    `mapstructure:"externalIPType"`
+	ExternalIPAddress         string                    `mapstructure:"externalIPAddress"`
+	NetworkTags               string                    `mapstructure:"networkTags"`
+	StackType                 string                    `mapstructure:"stackType"`
+	CreateFirewallRules       []CreateFirewallRuleEntry `mapstructure:"createFirewallRules"`
+	FirewallRuleMode          string                    `mapstructure:"firewallRuleMode"`
 }
 
 type CreateFirewallRuleEntry struct {
@@ -610,6 +731,14 @@ func ParseNetworkTags(s string) []string {
 	return out
 }
 
+// StaticInternalIPAddress returns the internal IP address entered, or else the reserved internal address selected.
+func (c NetworkingConfig) StaticInternalIPAddress() string {
+	if address := strings.TrimSpace(c.InternalIPAddress); address != "" {
+		return address
+	}
+	return strings.TrimSpace(c.ReservedInternalIPAddress)
+}
+
 func BuildNetworkInterfaces(project, region string, config NetworkingConfig) []*compute.NetworkInterface {
 	network := strings.TrimSpace(config.Network)
 	subnetwork := strings.TrimSpace(config.Subnetwork)
@@ -626,8 +755,8 @@ func BuildNetworkInterfaces(project, region string, config NetworkingConfig) []*
 	if config.StackType != "" {
 		ni.StackType = config.StackType
 	}
-	if config.InternalIPType == InternalIPStatic && strings.TrimSpace(config.InternalIPAddress) != "" {
-		ni.NetworkIP = strings.TrimSpace(config.InternalIPAddress)
+	if config.InternalIPType == InternalIPStatic && config.StaticInternalIPAddress() != "" {
+		ni.NetworkIP = config.StaticInternalIPAddress()
 	}
 	externalType := strings.TrimSpace(config.ExternalIPType)
 	if externalType == "" {
@@ -816,10 +945,29 @@ func BuildNodeAffinities(config AdvancedConfig) []*compute.SchedulingNodeAffinit
 	return out
 }
 
+// withManagedByLabel adds the managed-by label,
+// unless the instance labels already set it.
+func withManagedByLabel(labels map[string]string) map[string]string {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if _, exists := labels[ManagedByLabelKey]; !exists {
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+	}
+	return labels
+}
+
 func BuildInstanceResourcePolicies(config AdvancedConfig) []string {
 	return trimmedNonEmptyStrings(config.ResourcePolicies)
 }
 
+// Instances created by SuperPlane have this label,
+// so triggers like On Spot Preemption can tell them apart.
+const (
+	ManagedByLabelKey   = "managed-by"
+	ManagedByLabelValue = "superplane"
+)
+
 func BuildLabels(config AdvancedConfig) map[string]string {
 	if len(config.Labels) == 0 {
 		return nil
@@ -851,11 +999,14 @@ func ApplyAdvancedScheduling(s *compute.Scheduling, config AdvancedConfig) {
 }
 
 const (
-	defaultOperationWaitTimeout = 10 * time.Minute
-	operationPollInterval       = 3 * time.Second
-	defaultOAuthScope           = "https://www.googleapis.com/auth/cloud-platform"
+	operationPollInterval = 3 * time.Second
+	defaultOAuthScope     = "https://www.googleapis.com/auth/cloud-platform"
 )
 
+// operationExecutionTimeout is the default execution timeout of the components
+// waiting for Compute Engine operations. The operation waits use the execution deadline.
+const operationExecutionTimeout = 10 * time.Minute
+
 const (
 	opStatusDone    = "DONE"
 	opStatusPending = "PENDING"
@@ -868,7 +1019,7 @@ func bootDiskConfigFromOSConfig(project, zone string, c OSAndStorageConfig) Boot
 		SizeGb:            c.BootDiskSizeGb,
 		SnapshotSchedule:  strings.TrimSpace(c.BootDiskSnapshotSchedule),
 		AutoDelete:        c.BootDiskAutoDelete,
-		DiskEncryptionKey: strings.TrimSpace(c.BootDiskEncryptionKey),
+		DiskEncryptionKey: bootDiskEncryptionKey(c),
 	}
 	if cfg.DiskType == "" {
 		cfg.DiskType = DefaultDiskType
@@ -916,10 +1067,11 @@ func additionalDisksFromOSConfig(c OSAndStorageConfig) []AdditionalDisk {
 			continue
 		}
 		out = append(out, AdditionalDisk{
-			Name:       strings.TrimSpace(e.Name),
-			SizeGb:     e.SizeGb,
-			DiskType:   strings.TrimSpace(e.DiskType),
-			AutoDelete: e.AutoDelete,
+			Name:              strings.TrimSpace(e.Name),
+			SizeGb:            e.SizeGb,
+			DiskType:          strings.TrimSpace(e.DiskType),
+			AutoDelete:        e.AutoDelete,
+			DiskEncryptionKey: additionalDiskEncryptionKey(c, e),
 		})
 		if out[len(out)-1].DiskType == "" {
 			out[len(out)-1].DiskType = DefaultDiskType
@@ -928,6 +1080,29 @@ func additionalDisksFromOSConfig(c OSAndStorageConfig) []AdditionalDisk {
 	return out
 }
 
+// bootDiskEncryptionKey is the key of the boot disk: its own key, or the default key of the VM for a new disk.
+func bootDiskEncryptionKey(c OSAndStorageConfig) string {
+	if key := strings.TrimSpace(c.BootDiskEncryptionKey); key != "" {
+		return key
+	}
+	if strings.TrimSpace(c.BootDiskSourceType) == BootDiskSourceExistingDisk {
+		return ""
+	}
+	return strings.TrimSpace(c.DiskEncryptionKey)
+}
+
+// additionalDiskEncryptionKey is the key of a new disk: its own key, or the default key of the VM.
+// Local SSDs are always encrypted with Google-managed keys.
+func additionalDiskEncryptionKey(c OSAndStorageConfig, e AdditionalDiskEntry) string {
+	if strings.TrimSpace(e.DiskType) == "local-ssd" {
+		return ""
+	}
+	if key := strings.TrimSpace(e.EncryptionKey); key != "" {
+		return key
+	}
+	return strings.TrimSpace(c.DiskEncryptionKey)
+}
+
 func managementConfigFromCreateVMConfig(c CreateVMConfig) ManagementConfig {
 	return ManagementConfig{
 		MetadataItems:     c.MetadataItems,
@@ -1124,7 +1299,7 @@ func BuildInstanceFromConfig(project, zone, region string, config CreateVMConfig
 		Scheduling:                 scheduling,
 		Metadata:                   metadata,
 		Tags:                       &compute.Tags{Items: ParseNetworkTags(config.NetworkTags)},
-		Labels:                     BuildLabels(adv),
+		Labels:                     withManagedByLabel(BuildLabels(adv)),
 		ShieldedInstanceConfig:     BuildShieldedInstanceConfig(config.SecurityConfig),
 		ConfidentialInstanceConfig: BuildConfidentialInstanceConfig(config.SecurityConfig),
 		GuestAccelerators:          guestAccel,
@@ -1137,11 +1312,16 @@ func BuildInstanceFromConfig(project, zone, region string, config CreateVMConfig
 	return instance, nil
 }
 
-func InsertInstance(ctx context.Context, client Client, project, zone string, instance *compute.Instance) ([]byte, error) {
+// InsertInstance creates the instance. If requestID is set, GCP ignores
+// repeated requests with the same ID, so retries do not create a second VM.
+func InsertInstance(ctx context.Context, client Client, project, zone string, instance *compute.Instance, requestID string) ([]byte, error) {
 	if project == "" {
 		project = client.ProjectID()
 	}
 	path := fmt.Sprintf("projects/%s/zones/%s/instances", project, zone)
+	if requestID != "" {
+		path += "?requestId=" + url.QueryEscape(requestID)
+	}
 	return client.Post(ctx, path, instance)
 }
 
@@ -1157,13 +1337,27 @@ type zoneOperationResp struct {
 }
 
 func WaitForZoneOperation(ctx context.Context, client Client, project, zone, operationName string) error {
-	path := fmt.Sprintf("projects/%s/zones/%s/operations/%s", project, zone, operationName)
-	deadline := time.Now().Add(defaultOperationWaitTimeout)
+	return waitForOperation(ctx, client, fmt.Sprintf("projects/%s/zones/%s/operations/%s", project, zone, operationName), operationName)
+}
+
+// WaitForRegionOperation waits for an operation on a regional resource,
+// like a node template.
+func WaitForRegionOperation(ctx context.Context, client Client, project, region, operationName string) error {
+	return waitForOperation(ctx, client, fmt.Sprintf("projects/%s/regions/%s/operations/%s", project, region, operationName), operationName)
+}
+
+// WaitForGlobalOperation waits for an operation on a global resource,
+// like a global backend service.
+func WaitForGlobalOperation(ctx context.Context, client Client, project, operationName string) error {
+	return waitForOperation(ctx, client, fmt.Sprintf("projects/%s/global/operations/%s", project, operationName), operationName)
+}
+
+func waitForOperation(ctx context.Context, client Client, path, operationName string) error {
 	ticker := time.NewTicker(operationPollInterval)
 	defer ticker.Stop()
 	for {
-		if time.Now().After(deadline) {
-			return fmt.Errorf("timeout waiting for operation %s", operationName)
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("stopped waiting for operation %s: %w", operationName, err)
 		}
 		body, err := client.Get(ctx, path)
 		if err != nil {
@@ -1189,7 +1383,7 @@ func WaitForZoneOperation(ctx context.Context, client Client, project, zone, ope
 		}
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return fmt.Errorf("stopped waiting for operation %s: %w", operationName, ctx.Err())
 		case <-ticker.C:
 		}
 	}
@@ -1203,11 +1397,25 @@ type instanceGetResp struct {
 	Zone              string `json:"zone"`
 	MachineType       string `json:"machineType"`
 	NetworkInterfaces []struct {
+		Name          string `json:"name"`
+		Network       string `json:"network"`
+		Subnetwork    string `json:"subnetwork"`
 		NetworkIP     string `json:"networkIP"`
 		AccessConfigs []struct {
 			NatIP string `json:"natIP"`
 		} `json:"accessConfigs"`
 	} `json:"networkInterfaces"`
+	Disks []struct {
+		DeviceName string `json:"deviceName"`
+		Source     string `json:"source"`
+		Boot       bool   `json:"boot"`
+		Mode       string `json:"mode"`
+		DiskSizeGb int64  `json:"diskSizeGb,string"`
+	} `json:"disks"`
+	Labels          map[string]string `json:"labels"`
+	ServiceAccounts []struct {
+		Email string `json:"email"`
+	} `json:"serviceAccounts"`
 }
 
 func GetInstance(ctx context.Context, client Client, project, zone, name string) ([]byte, error) {
@@ -1238,13 +1446,63 @@ func InstancePayloadFromGetResponse(body []byte, zone string) (map[string]any, e
 			payload["externalIP"] = ni.AccessConfigs[0].NatIP
 		}
 	}
+
+	//
+	// internalIP and externalIP are from the first network interface.
+	// The lists below have every interface and disk, for inventory.
+	//
+	networkInterfaces := []map[string]any{}
+	for _, ni := range inst.NetworkInterfaces {
+		nic := map[string]any{
+			"name":       ni.Name,
+			"network":    lastSegment(ni.Network),
+			"subnetwork": lastSegment(ni.Subnetwork),
+			"internalIP": ni.NetworkIP,
+		}
+		for _, ac := range ni.AccessConfigs {
+			if ac.NatIP != "" {
+				nic["externalIP"] = ac.NatIP
+				break
+			}
+		}
+		networkInterfaces = append(networkInterfaces, nic)
+	}
+	payload["networkInterfaces"] = networkInterfaces
+
+	disks := []map[string]any{}
+	for _, d := range inst.Disks {
+		disks = append(disks, map[string]any{
+			"deviceName": d.DeviceName,
+			"name":       lastSegment(d.Source),
+			"boot":       d.Boot,
+			"mode":       d.Mode,
+			"sizeGb":     d.DiskSizeGb,
+		})
+	}
+	payload["disks"] = disks
+
+	labels := inst.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	payload["labels"] = labels
+
+	if len(inst.ServiceAccounts) > 0 && inst.ServiceAccounts[0].Email != "" {
+		payload["serviceAccount"] = inst.ServiceAccounts[0].Email
+	}
+
 	if payload["zone"] == "" && zone != "" {
 		payload["zone"] = zone
 	}
 	return payload, nil
 }
 
-func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig) (map[string]any, error) {
+/*
+ * CreateVMAndWait creates the instance and waits until it is running.
+ * onInsert, if set, is called with the name of the insert operation,
+ * before waiting for it.
+ */
+func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig, requestID string, onInsert func(operation string)) (map[string]any, error) {
 	project := client.ProjectID()
 	zone := strings.TrimSpace(config.Zone)
 	region := strings.TrimSpace(config.Region)
@@ -1260,8 +1518,8 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 	zone = lastSegment(zone)
 	region = lastSegment(region)
 
-	if config.InternalIPType == InternalIPStatic && strings.TrimSpace(config.InternalIPAddress) != "" {
-		resolved, err := ResolveInternalIPAddress(ctx, client, project, region, config.InternalIPAddress)
+	if config.InternalIPType == InternalIPStatic && config.StaticInternalIPAddress() != "" {
+		resolved, err := ResolveInternalIPAddress(ctx, client, project, region, config.StaticInternalIPAddress())
 		if err != nil {
 			return nil, fmt.Errorf("reserved internal IP: %w", err)
 		}
@@ -1275,7 +1533,11 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 
 	var firewallTags []string
 	if len(config.CreateFirewallRules) > 0 {
-		createdTags, err := EnsureFirewallRules(ctx, client, project, config.Network, config.CreateFirewallRules)
+		ensureFirewallRules := EnsureFirewallRules
+		if config.FirewallRuleMode == FirewallRuleModeReuse {
+			ensureFirewallRules = ReuseFirewallRules
+		}
+		createdTags, err := ensureFirewallRules(ctx, client, project, config.Network, config.CreateFirewallRules)
 		if err != nil {
 			return nil, err
 		}
@@ -1285,7 +1547,7 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 		instance.Tags = &compute.Tags{Items: BuildInstanceTags(config.NetworkTags, firewallTags)}
 	}
 
-	body, err := InsertInstance(ctx, client, project, zone, instance)
+	body, err := InsertInstance(ctx, client, project, zone, instance, requestID)
 	if err != nil {
 		return nil, err
 	}
@@ -1296,11 +1558,24 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 		return nil, fmt.Errorf("parse insert operation response: %w", err)
 	}
 
-	if err := WaitForZoneOperation(ctx, client, project, zone, lastSegment(opResp.Name)); err != nil {
+	operation := lastSegment(opResp.Name)
+	if onInsert != nil {
+		onInsert(operation)
+	}
+
+	return WaitForCreatedVM(ctx, client, project, zone, instance.Name, operation)
+}
+
+/*
+ * WaitForCreatedVM waits for the insert operation of an instance,
+ * and returns the payload of the instance created.
+ */
+func WaitForCreatedVM(ctx context.Context, client Client, project, zone, instanceName, operation string) (map[string]any, error) {
+	if err := WaitForZoneOperation(ctx, client, project, zone, operation); err != nil {
 		return nil, err
 	}
 
-	instBody, err := GetInstance(ctx, client, project, zone, instance.Name)
+	instBody, err := GetInstance(ctx, client, project, zone, instanceName)
 	if err != nil {
 		return nil, fmt.Errorf("fetch created instance: %w", err)
 	}
@@ -1309,17 +1584,41 @@ func CreateVMAndWait(ctx context.Context, client Client, config CreateVMConfig)
 
 var gcpInstanceNameRegex = regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])?$`)
 
+var kmsKeyNameRegex = regexp.MustCompile(`^projects/[^/]+/locations/([^/]+)/keyRings/[^/]+/cryptoKeys/[^/]+(?:/cryptoKeyVersions/[^/]+)?$`)
+
 const (
 	createVMPayloadType   = "gcp.createVM.completed"
 	createVMOutputChannel = "default"
+
+	createVMWaitForInsertStep = "waitForInsert"
+
+	fetchSerialPortOutputAction = "fetchSerialPortOutput"
 )
 
 type CreateVM struct{}
 
+/*
+ * CreateVMMetadata is the instance an execution creates,
+ * used by the actions on the execution.
+ */
+type CreateVMMetadata struct {
+	Project      string `json:"project" mapstructure:"project"`
+	Zone         string `json:"zone" mapstructure:"zone"`
+	InstanceName string `json:"instanceName" mapstructure:"instanceName"`
+}
+
 func (c *CreateVM) Name() string {
 	return "gcp.createVM"
 }
 
+/*
+ * The service account of the VM is masked in the payloads displayed,
+ * but is still available to the nodes after this one.
+ */
+func (c *CreateVM) SensitivePaths() []string {
+	return []string{"data.serviceAccount"}
+}
+
 func (c *CreateVM) Label() string {
 	return "Compute • Create Virtual Machine"
 }
@@ -1334,16 +1633,24 @@ func (c *CreateVM) Documentation() string {
 ## Steps
 
 1. **Machine Configuration** – Region, zone, machine type, provisioning model (Spot/Standard), instance name.
-2. **OS & Storage** – Boot disk source (public/custom image, snapshot, existing disk), disk type, size, snapshot schedule.
+2. **OS & Storage** – Boot disk source (public/custom image, snapshot, existing disk), disk type, size, snapshot schedule, additional disks and their customer-managed encryption keys (CMEK), with a default key for new disks. Keys must be in the region of the zone, or global.
 3. **Security** – Shielded VM (secure boot, vTPM, integrity monitoring), Confidential VM (AMD SEV/SEV-SNP, Intel TDX).
 4. **Identity & API access** – VM service account, OAuth scopes, OS Login, block project-wide SSH keys.
-5. **Networking** – VPC, subnet, NIC type, internal/external IP (including static), network tags, firewall rules.
+5. **Networking** – VPC, subnet, NIC type, internal/external IP (including static), network tags, firewall rules. Firewall rules are always created by default; with **Reuse matching rules**, an existing rule of the network with the same allowed ports and source ranges is reused by applying its target tag instead.
 6. **Management** – Metadata, startup script, automatic restart, on host maintenance, maintenance policy.
 7. **Advanced** – GPU accelerators, placement policy (min node CPUs), sole-tenant/host affinity, resource policies.
 
 ## Output
 
-Emits a payload with instance details: instanceId, selfLink, internalIP, externalIP, status, zone, name, machineType.`
+Emits a payload with instance details: instanceId, selfLink, internalIP, externalIP, status, zone, name, machineType, labels and serviceAccount.
+
+internalIP and externalIP are from the first network interface. The payload also lists every network interface, with its network, subnetwork, internal and external IPs, and every attached disk, with its device name, disk name, size in GB, mode and whether it is the boot disk.
+
+Instances get the ` + "`managed-by: superplane`" + ` label, unless the labels already set ` + "`managed-by`" + `, so triggers like On Spot Preemption can tell them apart.
+
+## Actions
+
+- **Fetch serial port output**: Writes the serial port output of the instance to the execution logs, to debug startup scripts that failed.`
 }
 
 func (c *CreateVM) Icon() string {
@@ -1354,6 +1661,10 @@ func (c *CreateVM) Color() string {
 	return "gray"
 }
 
+func (c *CreateVM) DefaultExecutionTimeout() time.Duration {
+	return operationExecutionTimeout
+}
+
 func (c *CreateVM) ExampleOutput() map[string]any {
 	return map[string]any{
 		"instanceId":  "1234567890123456789",
@@ -1364,6 +1675,26 @@ func (c *CreateVM) ExampleOutput() map[string]any {
 		"zone":        "us-central1-a",
 		"name":        "my-vm",
 		"machineType": "e2-medium",
+		"networkInterfaces": []any{
+			map[string]any{
+				"name":       "nic0",
+				"network":    "default",
+				"subnetwork": "default",
+				"internalIP": "10.0.0.2",
+				"externalIP": "34.1.2.3",
+			},
+		},
+		"disks": []any{
+			map[string]any{
+				"deviceName": "my-vm",
+				"name":       "my-vm",
+				"boot":       true,
+				"mode":       "READ_WRITE",
+				"sizeGb":     10,
+			},
+		},
+		"labels":         map[string]any{"env": "staging"},
+		"serviceAccount": "123456789-compute@developer.gserviceaccount.com",
 	}
 }
 
@@ -1373,6 +1704,19 @@ func (c *CreateVM) OutputChannels(configuration any) []core.OutputChannel {
 	}
 }
 
+/*
+ * Sections of the configuration form, in the order they are displayed.
+ * Instance name, region and zone are not grouped, so they are always shown first.
+ */
+const (
+	createVMGroupMachine      = "Machine"
+	createVMGroupOSAndStorage = "OS & Storage"
+	createVMGroupNetworking   = "Networking"
+	createVMGroupSecurity     = "Security"
+	createVMGroupManagement   = "Management"
+	createVMGroupAdvanced     = "Advanced"
+)
+
 func (c *CreateVM) Configuration() []configuration.Field {
 	return []configuration.Field{
 		{
@@ -1382,6 +1726,13 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			Required:    true,
 			Description: "Start with a letter; use only a-z, 0-9, and hyphens; end with a letter or digit. 1 to 63 characters length.",
 			Placeholder: "e.g. my-vm-01",
+			ValidationRules: []configuration.ValidationRule{
+				{
+					Type:    configuration.ValidationRulePattern,
+					Value:   gcpInstanceNameRegex.String(),
+					Message: "instance name must be 1–63 characters: start with a lowercase letter, use only lowercase letters (a-z), digits (0-9), and hyphens (-), and end with a letter or digit (e.g. my-vm-01)",
+				},
+			},
 		},
 		{
 			Name:        "region",
@@ -1412,6 +1763,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "machineFamily",
+			Group:       createVMGroupMachine,
 			Label:       "Machine family",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1427,6 +1779,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "machineType",
+			Group:       createVMGroupMachine,
 			Label:       "Machine type",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    true,
@@ -1443,6 +1796,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "provisioningModel",
+			Group:       createVMGroupMachine,
 			Label:       "Provisioning model",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -1459,6 +1813,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskSourceType",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Boot disk source",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -1477,6 +1832,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskOS",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Operating system",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -1492,6 +1848,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskPublicImage",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Version",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1510,6 +1867,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskCustomImage",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Custom image",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1526,6 +1884,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskSnapshot",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Snapshot",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1542,6 +1901,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskExistingDisk",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Existing disk",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1560,6 +1920,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskType",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Boot disk type",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1579,6 +1940,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskSizeGb",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Boot disk size (GB)",
 			Type:        configuration.FieldTypeNumber,
 			Required:    false,
@@ -1593,6 +1955,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskEncryptionKey",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Disk encryption key (optional)",
 			Type:        configuration.FieldTypeString,
 			Required:    false,
@@ -1602,8 +1965,18 @@ func (c *CreateVM) Configuration() []configuration.Field {
 				{Field: "bootDiskSourceType", Values: []string{BootDiskSourcePublicImage, BootDiskSourceCustomImage, BootDiskSourceSnapshot}},
 			},
 		},
+		{
+			Name:        "diskEncryptionKey",
+			Group:       createVMGroupOSAndStorage,
+			Label:       "Default disk encryption key (optional)",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Cloud KMS key used for the new disks with no encryption key of their own, boot disk included. Must be in the region of the zone, or global. Local SSDs always use Google-managed encryption.",
+			Placeholder: "e.g. projects/my-project/locations/region/keyRings/ring/cryptoKeys/key",
+		},
 		{
 			Name:        "bootDiskSnapshotSchedule",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Snapshot schedule",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1619,6 +1992,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "bootDiskAutoDelete",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Delete boot disk on termination",
 			Type:        configuration.FieldTypeBool,
 			Required:    false,
@@ -1627,6 +2001,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "localSSDCount",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Local SSD count",
 			Type:        configuration.FieldTypeNumber,
 			Required:    false,
@@ -1638,6 +2013,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "additionalDisks",
+			Group:       createVMGroupOSAndStorage,
 			Label:       "Additional disks",
 			Type:        configuration.FieldTypeList,
 			Required:    false,
@@ -1731,6 +2107,18 @@ func (c *CreateVM) Configuration() []configuration.Field {
 									{Field: "mode", Values: []string{AdditionalDiskModeExisting}},
 								},
 							},
+							{
+								Name:        "encryptionKey",
+								Label:       "Encryption key (optional)",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Description: "Cloud KMS key for this disk. Leave empty to use the default disk encryption key.",
+								Placeholder: "e.g. projects/my-project/locations/region/keyRings/ring/cryptoKeys/key",
+								VisibilityConditions: []configuration.VisibilityCondition{
+									{Field: "mode", Values: []string{AdditionalDiskModeNew}},
+									{Field: "diskType", Values: []string{"pd-balanced", "pd-ssd", "pd-standard", "hyperdisk-balanced", "hyperdisk-throughput"}},
+								},
+							},
 							{
 								Name:        "autoDelete",
 								Label:       "Delete on termination",
@@ -1744,109 +2132,9 @@ func (c *CreateVM) Configuration() []configuration.Field {
 				},
 			},
 		},
-		{
-			Name:        fieldNameShieldedVM,
-			Label:       "Shielded VM",
-			Type:        configuration.FieldTypeBool,
-			Required:    false,
-			Description: "Use Shielded VM for verified boot and measured boot. Enables vTPM and integrity monitoring by default; you can optionally enable Secure Boot.",
-			Default:     false,
-		},
-		{
-			Name:                 "shieldedVMEnableSecureBoot",
-			Label:                "Secure Boot",
-			Type:                 configuration.FieldTypeBool,
-			Required:             false,
-			Description:          "Verify digital signatures of all boot components. Disabled by default due to possible compatibility issues with unsigned drivers.",
-			Default:              false,
-			VisibilityConditions: visibleWhenShieldedVM,
-		},
-		{
-			Name:                 "shieldedVMEnableVtpm",
-			Label:                "vTPM",
-			Type:                 configuration.FieldTypeBool,
-			Required:             false,
-			Description:          "Virtual Trusted Platform Module for measured boot and key storage.",
-			Default:              true,
-			VisibilityConditions: visibleWhenShieldedVM,
-		},
-		{
-			Name:                 "shieldedVMEnableIntegrityMonitoring",
-			Label:                "Integrity monitoring",
-			Type:                 configuration.FieldTypeBool,
-			Required:             false,
-			Description:          "Monitor boot integrity against a baseline from the trusted boot image.",
-			Default:              true,
-			VisibilityConditions: visibleWhenShieldedVM,
-		},
-		{
-			Name:        fieldNameConfidentialVM,
-			Label:       "Confidential VM",
-			Type:        configuration.FieldTypeBool,
-			Required:    false,
-			Description: "Run the VM with Confidential Computing (memory encrypted by the host). Requires a supported machine type (e.g. N2D, C2D).",
-			Default:     false,
-		},
-		{
-			Name:                 "confidentialVMType",
-			Label:                "Confidential instance type",
-			Type:                 configuration.FieldTypeSelect,
-			Required:             false,
-			Description:          "Technology used for confidential compute. SEV (AMD) is common; SEV-SNP and TDX (Intel) depend on machine type and availability.",
-			Default:              ConfidentialInstanceTypeSEV,
-			VisibilityConditions: visibleWhenConfidentialVM,
-			TypeOptions: &configuration.TypeOptions{
-				Select: &configuration.SelectTypeOptions{
-					Options: []configuration.FieldOption{
-						{Label: "AMD SEV", Value: ConfidentialInstanceTypeSEV},
-						{Label: "AMD SEV-SNP", Value: ConfidentialInstanceTypeSEVSNP},
-						{Label: "Intel TDX", Value: ConfidentialInstanceTypeTDX},
-					},
-				},
-			},
-		},
-		{
-			Name:        "serviceAccount",
-			Label:       "Service account (VM identity)",
-			Type:        configuration.FieldTypeString,
-			Required:    false,
-			Description: "Email of the service account this VM will run as. Leave empty to use the project's default Compute Engine service account.",
-			Placeholder: "e.g. my-sa@my-project.iam.gserviceaccount.com",
-		},
-		{
-			Name:        "oauthScopes",
-			Label:       "OAuth scopes",
-			Type:        configuration.FieldTypeList,
-			Required:    false,
-			Togglable:   true,
-			Description: "Access scopes for the VM (which APIs the instance can call). Leave empty for default (cloud-platform).",
-			TypeOptions: &configuration.TypeOptions{
-				List: &configuration.ListTypeOptions{
-					ItemLabel: "Scope",
-					ItemDefinition: &configuration.ListItemDefinition{
-						Type: configuration.FieldTypeString,
-					},
-				},
-			},
-		},
-		{
-			Name:        "blockProjectSSHKeys",
-			Label:       "Block project-wide SSH keys",
-			Type:        configuration.FieldTypeBool,
-			Required:    false,
-			Description: "If enabled, only instance-level SSH keys or OS Login will work; project-wide SSH keys are ignored.",
-			Default:     false,
-		},
-		{
-			Name:        "enableOSLogin",
-			Label:       "Enable OS Login",
-			Type:        configuration.FieldTypeBool,
-			Required:    false,
-			Description: "Use OS Login for SSH access (IAM-based). When enabled, SSH keys are managed via IAM and OS Login.",
-			Default:     false,
-		},
 		{
 			Name:        "network",
+			Group:       createVMGroupNetworking,
 			Label:       "VPC network",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1860,6 +2148,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "subnetwork",
+			Group:       createVMGroupNetworking,
 			Label:       "Subnet",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1875,6 +2164,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "nicType",
+			Group:       createVMGroupNetworking,
 			Label:       "NIC type",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -1890,6 +2180,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "internalIPType",
+			Group:       createVMGroupNetworking,
 			Label:       "Internal IP",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -1905,11 +2196,32 @@ func (c *CreateVM) Configuration() []configuration.Field {
 			},
 		},
 		{
-			Name:        "internalIPAddress",
+			Name:        "reservedInternalIPAddress",
+			Group:       createVMGroupNetworking,
 			Label:       "Reserved internal IP",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Select a reserved internal IP address in the same region as the VM.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeAddress,
+					Parameters: []configuration.ParameterRef{
+						{Name: "region", ValueFrom: &configuration.ParameterValueFrom{Field: "region"}},
+						{Name: "addressType", Value: strPtr(AddressTypeInternal)},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "internalIPType", Values: []string{InternalIPStatic}},
+			},
+		},
+		{
+			Name:        "internalIPAddress",
+			Group:       createVMGroupNetworking,
+			Label:       "Internal IP address",
 			Type:        configuration.FieldTypeString,
 			Required:    false,
-			Description: "Reserved internal IP address or its full URL. Used when Internal IP is Static.",
+			Description: "Reserved internal IP address or its full URL, instead of selecting one above. Used when Internal IP is Static.",
 			Placeholder: "e.g. 10.0.0.5 or full address URL",
 			VisibilityConditions: []configuration.VisibilityCondition{
 				{Field: "internalIPType", Values: []string{InternalIPStatic}},
@@ -1917,6 +2229,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "externalIPType",
+			Group:       createVMGroupNetworking,
 			Label:       "External IP",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -1934,6 +2247,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "externalIPAddress",
+			Group:       createVMGroupNetworking,
 			Label:       "Reserved external IP",
 			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
@@ -1943,6 +2257,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 					Type: ResourceTypeAddress,
 					Parameters: []configuration.ParameterRef{
 						{Name: "region", ValueFrom: &configuration.ParameterValueFrom{Field: "region"}},
+						{Name: "addressType", Value: strPtr(AddressTypeExternal)},
 					},
 				},
 			},
@@ -1952,6 +2267,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "networkTags",
+			Group:       createVMGroupNetworking,
 			Label:       "Network tags",
 			Type:        configuration.FieldTypeString,
 			Required:    false,
@@ -1960,6 +2276,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "stackType",
+			Group:       createVMGroupNetworking,
 			Label:       "IP stack type",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -1976,6 +2293,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "createFirewallRules",
+			Group:       createVMGroupNetworking,
 			Label:       "Create firewall rules",
 			Type:        configuration.FieldTypeList,
 			Required:    false,
@@ -2024,8 +2342,137 @@ func (c *CreateVM) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "firewallRuleMode",
+			Group:       createVMGroupNetworking,
+			Label:       "Firewall rule mode",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "Always create the firewall rules, or reuse existing rules of the network that allow the same ports from the same source ranges, and only create the others.",
+			Default:     FirewallRuleModeCreate,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Always create", Value: FirewallRuleModeCreate},
+						{Label: "Reuse matching rules", Value: FirewallRuleModeReuse},
+					},
+				},
+			},
+		},
+		{
+			Name:        fieldNameShieldedVM,
+			Group:       createVMGroupSecurity,
+			Label:       "Shielded VM",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Use Shielded VM for verified boot and measured boot. Enables vTPM and integrity monitoring by default; you can optionally enable Secure Boot.",
+			Default:     false,
+		},
+		{
+			Name:                 "shieldedVMEnableSecureBoot",
+			Group:                createVMGroupSecurity,
+			Label:                "Secure Boot",
+			Type:                 configuration.FieldTypeBool,
+			Required:             false,
+			Description:          "Verify digital signatures of all boot components. Disabled by default due to possible compatibility issues with unsigned drivers.",
+			Default:              false,
+			VisibilityConditions: visibleWhenShieldedVM,
+		},
+		{
+			Name:                 "shieldedVMEnableVtpm",
+			Group:                createVMGroupSecurity,
+			Label:                "vTPM",
+			Type:                 configuration.FieldTypeBool,
+			Required:             false,
+			Description:          "Virtual Trusted Platform Module for measured boot and key storage.",
+			Default:              true,
+			VisibilityConditions: visibleWhenShieldedVM,
+		},
+		{
+			Name:                 "shieldedVMEnableIntegrityMonitoring",
+			Group:                createVMGroupSecurity,
+			Label:                "Integrity monitoring",
+			Type:                 configuration.FieldTypeBool,
+			Required:             false,
+			Description:          "Monitor boot integrity against a baseline from the trusted boot image.",
+			Default:              true,
+			VisibilityConditions: visibleWhenShieldedVM,
+		},
+		{
+			Name:        fieldNameConfidentialVM,
+			Group:       createVMGroupSecurity,
+			Label:       "Confidential VM",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Run the VM with Confidential Computing (memory encrypted by the host). Requires a supported machine type (e.g. N2D, C2D).",
+			Default:     false,
+		},
+		{
+			Name:                 "confidentialVMType",
+			Group:                createVMGroupSecurity,
+			Label:                "Confidential instance type",
+			Type:                 configuration.FieldTypeSelect,
+			Required:             false,
+			Description:          "Technology used for confidential compute. SEV (AMD) is common; SEV-SNP and TDX (Intel) depend on machine type and availability.",
+			Default:              ConfidentialInstanceTypeSEV,
+			VisibilityConditions: visibleWhenConfidentialVM,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "AMD SEV", Value: ConfidentialInstanceTypeSEV},
+						{Label: "AMD SEV-SNP", Value: ConfidentialInstanceTypeSEVSNP},
+						{Label: "Intel TDX", Value: ConfidentialInstanceTypeTDX},
+					},
+				},
+			},
+		},
+		{
+			Name:        "serviceAccount",
+			Group:       createVMGroupSecurity,
+			Label:       "Service account (VM identity)",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Email of the service account this VM will run as. Leave empty to use the project's default Compute Engine service account.",
+			Placeholder: "e.g. my-sa@my-project.iam.gserviceaccount.com",
+		},
+		{
+			Name:        "oauthScopes",
+			Group:       createVMGroupSecurity,
+			Label:       "OAuth scopes",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Access scopes for the VM (which APIs the instance can call). Leave empty for default (cloud-platform).",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Scope",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:        "blockProjectSSHKeys",
+			Group:       createVMGroupSecurity,
+			Label:       "Block project-wide SSH keys",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "If enabled, only instance-level SSH keys or OS Login will work; project-wide SSH keys are ignored.",
+			Default:     false,
+		},
+		{
+			Name:        "enableOSLogin",
+			Group:       createVMGroupSecurity,
+			Label:       "Enable OS Login",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Use OS Login for SSH access (IAM-based). When enabled, SSH keys are managed via IAM and OS Login.",
+			Default:     false,
+		},
 		{
 			Name:        "metadataItems",
+			Group:       createVMGroupManagement,
 			Label:       "Custom metadata",
 			Type:        configuration.FieldTypeList,
 			Required:    false,
@@ -2060,6 +2507,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "startupScript",
+			Group:       createVMGroupManagement,
 			Label:       "Startup script (optional)",
 			Type:        configuration.FieldTypeText,
 			Required:    false,
@@ -2068,6 +2516,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "shutdownScript",
+			Group:       createVMGroupManagement,
 			Label:       "Shutdown script (optional)",
 			Type:        configuration.FieldTypeText,
 			Required:    false,
@@ -2076,6 +2525,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "automaticRestart",
+			Group:       createVMGroupManagement,
 			Label:       "Automatic restart",
 			Type:        configuration.FieldTypeBool,
 			Required:    false,
@@ -2084,6 +2534,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "onHostMaintenance",
+			Group:       createVMGroupManagement,
 			Label:       "On host maintenance",
 			Type:        configuration.FieldTypeSelect,
 			Required:    false,
@@ -2100,6 +2551,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "maintenancePolicy",
+			Group:       createVMGroupManagement,
 			Label:       "Maintenance policy",
 			Type:        configuration.FieldTypeString,
 			Required:    false,
@@ -2108,6 +2560,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "labels",
+			Group:       createVMGroupManagement,
 			Label:       "Labels",
 			Type:        configuration.FieldTypeList,
 			Required:    false,
@@ -2142,6 +2595,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "guestAccelerators",
+			Group:       createVMGroupAdvanced,
 			Label:       "GPU accelerators",
 			Type:        configuration.FieldTypeList,
 			Required:    false,
@@ -2176,6 +2630,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "minNodeCpus",
+			Group:       createVMGroupAdvanced,
 			Label:       "Min node CPUs (placement)",
 			Type:        configuration.FieldTypeNumber,
 			Required:    false,
@@ -2184,6 +2639,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "nodeAffinities",
+			Group:       createVMGroupAdvanced,
 			Label:       "Node affinity (sole-tenant / host)",
 			Type:        configuration.FieldTypeList,
 			Required:    false,
@@ -2241,6 +2697,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "resourcePolicies",
+			Group:       createVMGroupAdvanced,
 			Label:       "Resource policies",
 			Type:        configuration.FieldTypeList,
 			Required:    false,
@@ -2257,6 +2714,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "enableDisplayDevice",
+			Group:       createVMGroupAdvanced,
 			Label:       "Enable display device",
 			Type:        configuration.FieldTypeBool,
 			Required:    false,
@@ -2265,6 +2723,7 @@ func (c *CreateVM) Configuration() []configuration.Field {
 		},
 		{
 			Name:        "enableSerialPortAccess",
+			Group:       createVMGroupAdvanced,
 			Label:       "Enable serial port access",
 			Type:        configuration.FieldTypeBool,
 			Required:    false,
@@ -2296,19 +2755,205 @@ func (c *CreateVM) Execute(ctx core.ExecutionContext) error {
 		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
 	}
 
-	callCtx := context.Background()
-	payload, err := CreateVMAndWait(callCtx, client, config)
+	//
+	// The instance is recorded before it is created, so its serial port output
+	// can be fetched from the execution, even if waiting for it fails.
+	//
+	if ctx.Metadata != nil {
+		err := ctx.Metadata.Set(CreateVMMetadata{
+			Project:      client.ProjectID(),
+			Zone:         lastSegment(strings.TrimSpace(config.Zone)),
+			InstanceName: strings.TrimSpace(config.InstanceName),
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to set metadata: %w", err)
+		}
+	}
+
+	//
+	// Once the insert operation is started, the execution can be resumed
+	// from it after a restart, instead of inserting the instance again.
+	//
+	onInsert := func(operation string) {
+		ctx.SaveCheckpoint(core.Checkpoint{
+			Step:      createVMWaitForInsertStep,
+			Operation: operation,
+			Target: map[string]any{
+				"project":      client.ProjectID(),
+				"zone":         lastSegment(strings.TrimSpace(config.Zone)),
+				"instanceName": strings.TrimSpace(config.InstanceName),
+			},
+		})
+	}
+
+	callCtx := ctx.GoContext()
+	payload, err := CreateVMAndWait(callCtx, client, config, ctx.IdempotencyKey(), onInsert)
+	return c.finish(ctx, client, config, payload, err)
+}
+
+/*
+ * Resume waits for the insert operation started before a restart,
+ * instead of creating the instance again.
+ */
+func (c *CreateVM) Resume(ctx core.ExecutionContext, checkpoint core.Checkpoint) error {
+	if checkpoint.Step != createVMWaitForInsertStep {
+		return c.Execute(ctx)
+	}
+
+	var config CreateVMConfig
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode configuration: %v", err))
+	}
+
+	var target CreateVMMetadata
+	if err := mapstructure.Decode(checkpoint.Target, &target); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to decode checkpoint: %v", err))
+	}
+
+	if target.Zone == "" || target.InstanceName == "" || checkpoint.Operation == "" {
+		return ctx.ExecutionState.Fail("error", "invalid checkpoint: zone, instance name and operation are required")
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	if target.Project == "" {
+		target.Project = client.ProjectID()
+	}
+
+	//
+	// The metadata set by Execute() was rolled back with the execution.
+	//
+	if ctx.Metadata != nil {
+		if err := ctx.Metadata.Set(target); err != nil {
+			return fmt.Errorf("failed to set metadata: %w", err)
+		}
+	}
+
+	callCtx := ctx.GoContext()
+	payload, err := WaitForCreatedVM(callCtx, client, target.Project, target.Zone, target.InstanceName, checkpoint.Operation)
+	return c.finish(ctx, client, config, payload, err)
+}
+
+func (c *CreateVM) finish(ctx core.ExecutionContext, client Client, config CreateVMConfig, payload map[string]any, err error) error {
+	callCtx := ctx.GoContext()
 	if err != nil {
+		//
+		// Let the executor fail the execution as timed out.
+		//
+		if errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			return err
+		}
+
 		return ctx.ExecutionState.Fail("error", err.Error())
 	}
+
+	reportMonthlyEstimate(ctx, client, config)
 	return ctx.ExecutionState.Emit(createVMOutputChannel, createVMPayloadType, []any{payload})
 }
 
+/*
+ * Reports the monthly estimate of the machine type created,
+ * the same one displayed when selecting the machine type.
+ */
+func reportMonthlyEstimate(ctx core.ExecutionContext, client Client, config CreateVMConfig) {
+	zone := lastSegment(strings.TrimSpace(config.Zone))
+	mt, err := GetMachineType(ctx.GoContext(), client, zone, lastSegment(strings.TrimSpace(config.MachineType)))
+	if err != nil {
+		ctx.Logger.Warnf("error getting machine type for the monthly estimate: %v", err)
+		return
+	}
+
+	provisioningModel := strings.TrimSpace(config.ProvisioningModel)
+	if provisioningModel == "" {
+		provisioningModel = string(ProvisioningStandard)
+	}
+
+	ctx.ReportCost(core.Cost{
+		Amount:      monthlyEstimateFromMachineType(mt, zone, provisioningModel),
+		Unit:        core.CostUnitUSDPerMonth,
+		Description: fmt.Sprintf("Estimate for %s in %s", mt.Name, zone),
+	})
+}
+
+func (c *CreateVM) RequiredPermissions() core.Permissions {
+	return core.Permissions{
+		Roles: []string{"roles/compute.admin"},
+	}
+}
+
 func (c *CreateVM) Actions() []core.Action {
-	return nil
+	return []core.Action{
+		{
+			Name:           fetchSerialPortOutputAction,
+			Description:    "Fetch serial port output",
+			UserAccessible: true,
+			Parameters: []configuration.Field{
+				{
+					Name:        "port",
+					Label:       "Port",
+					Type:        configuration.FieldTypeNumber,
+					Required:    false,
+					Description: "Serial port to read, from 1 to 4.",
+					Default:     1,
+					TypeOptions: &configuration.TypeOptions{
+						Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(4)},
+					},
+				},
+			},
+		},
+	}
 }
 
 func (c *CreateVM) HandleAction(ctx core.ActionContext) error {
+	if ctx.Name != fetchSerialPortOutputAction {
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return c.fetchSerialPortOutput(ctx)
+}
+
+/*
+ * Writes the serial port output of the instance created
+ * by the execution to its logs.
+ */
+func (c *CreateVM) fetchSerialPortOutput(ctx core.ActionContext) error {
+	var metadata CreateVMMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.InstanceName == "" || metadata.Zone == "" {
+		return fmt.Errorf("execution has not created an instance")
+	}
+
+	port := int64(1)
+	if p, ok := ctx.Parameters["port"].(float64); ok && p > 0 {
+		port = int64(p)
+	}
+
+	client, err := getClient(core.ExecutionContext{HTTP: ctx.HTTP, Integration: ctx.Integration})
+	if err != nil {
+		return fmt.Errorf("failed to create GCP client: %w", err)
+	}
+
+	output, err := FetchSerialPortOutput(ctx.GoContext(), client, metadata.Project, metadata.Zone, metadata.InstanceName, port)
+	if err != nil {
+		return fmt.Errorf("failed to get serial port output: %w", err)
+	}
+
+	ctx.Logs.Printf("Serial port %d output of %s in %s:", port, metadata.InstanceName, metadata.Zone)
+	if output.Truncated {
+		ctx.Logs.Printf("(only the last %dKB are shown)", maxSerialPortOutputBytes/1024)
+	}
+
+	if _, err := io.WriteString(ctx.Logs, output.Contents); err != nil {
+		return fmt.Errorf("failed to write serial port output: %w", err)
+	}
+
 	return nil
 }
 
@@ -2325,20 +2970,92 @@ func (c *CreateVM) Cleanup(ctx core.SetupContext) error {
 }
 
 func validateCreateVMConfig(config CreateVMConfig) (invalidMessage string, ok bool) {
+	results := createVMValidationResults(config)
+	if len(results) > 0 {
+		return results[0].Message, false
+	}
+	return "", true
+}
+
+// Validate checks the configuration when the canvas is saved.
+// Values that are expressions are only checked after they are resolved, in Execute().
+func (c *CreateVM) Validate(configuration any) []core.ValidationResult {
+	var config CreateVMConfig
+	if err := mapstructure.Decode(configuration, &config); err != nil {
+		return []core.ValidationResult{core.FieldError("", fmt.Sprintf("failed to decode configuration: %v", err))}
+	}
+	return createVMValidationResults(config)
+}
+
+func isExpression(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func createVMValidationResults(config CreateVMConfig) []core.ValidationResult {
+	results := []core.ValidationResult{}
 	name := strings.TrimSpace(config.InstanceName)
 	if name == "" {
-		return "instance name is required", false
-	}
-	if !gcpInstanceNameRegex.MatchString(name) {
-		return "instance name must be 1–63 characters: start with a lowercase letter, use only lowercase letters (a-z), digits (0-9), and hyphens (-), and end with a letter or digit (e.g. my-vm-01)", false
+		results = append(results, core.FieldError("instanceName", "instance name is required"))
+	} else if !isExpression(name) && !gcpInstanceNameRegex.MatchString(name) {
+		results = append(results, core.FieldError("instanceName", "instance name must be 1–63 characters: start with a lowercase letter, use only lowercase letters (a-z), digits (0-9), and hyphens (-), and end with a letter or digit (e.g. my-vm-01)"))
 	}
 	if strings.TrimSpace(config.Zone) == "" {
-		return "zone is required", false
+		results = append(results, core.FieldError("zone", "zone is required"))
 	}
 	if strings.TrimSpace(config.MachineType) == "" {
-		return "machine type is required", false
+		results = append(results, core.FieldError("machineType", "machine type is required"))
 	}
-	return "", true
+	if config.InternalIPType == InternalIPStatic && config.StaticInternalIPAddress() == "" {
+		results = append(results, core.FieldError("reservedInternalIPAddress", "select a reserved internal IP or enter an internal IP address"))
+	}
+	return append(results, diskEncryptionKeyValidationResults(config)...)
+}
+
+// diskEncryptionKeyValidationResults checks the KMS keys of the disks are in the region of the VM zone.
+// Keys in the global location can be used in any region.
+func diskEncryptionKeyValidationResults(config CreateVMConfig) []core.ValidationResult {
+	results := []core.ValidationResult{}
+	zone := lastSegment(strings.TrimSpace(config.Zone))
+	if key := strings.TrimSpace(config.BootDiskEncryptionKey); key != "" {
+		if message := validateDiskEncryptionKey(key, zone); message != "" {
+			results = append(results, core.FieldError("bootDiskEncryptionKey", message))
+		}
+	}
+	if key := strings.TrimSpace(config.DiskEncryptionKey); key != "" {
+		if message := validateDiskEncryptionKey(key, zone); message != "" {
+			results = append(results, core.FieldError("diskEncryptionKey", message))
+		}
+	}
+	for i, disk := range config.AdditionalDisks {
+		key := strings.TrimSpace(disk.EncryptionKey)
+		if key == "" || disk.Mode == AdditionalDiskModeExisting {
+			continue
+		}
+		if strings.TrimSpace(disk.DiskType) == "local-ssd" {
+			results = append(results, core.FieldError("additionalDisks", fmt.Sprintf("disk %d: local SSDs cannot use a customer-managed encryption key", i+1)))
+			continue
+		}
+		if message := validateDiskEncryptionKey(key, zone); message != "" {
+			results = append(results, core.FieldError("additionalDisks", fmt.Sprintf("disk %d: %s", i+1, message)))
+		}
+	}
+	return results
+}
+
+func validateDiskEncryptionKey(key, zone string) string {
+	if isExpression(key) {
+		return ""
+	}
+	match := kmsKeyNameRegex.FindStringSubmatch(key)
+	if match == nil {
+		return "encryption key must be a Cloud KMS key resource name (projects/PROJECT/locations/LOCATION/keyRings/RING/cryptoKeys/KEY)"
+	}
+	location := match[1]
+	region := deriveRegionFromZone(zone)
+	if location == "global" || region == "" || isExpression(zone) || location == region {
+		return ""
+	}
+	return fmt.Sprintf("encryption key is in %s, but disks in zone %s need a key in %s", location, zone, region)
 }
 
 type CreateVMConfig struct {
@@ -1,11 +1,16 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
 	compute "google.golang.org/api/compute/v1"
 )
 
@@ -38,6 +43,17 @@ func Test_CreateVMConfiguration(t *testing.T) {
 	assert.Contains(t, names, "network")
 	assert.Contains(t, names, "serviceAccount")
 	assert.Contains(t, names, "labels")
+
+	t.Run("fields are grouped into sections", func(t *testing.T) {
+		groups := configuration.GroupFields(fields)
+		groupNames := make([]string, 0, len(groups))
+		for _, group := range groups {
+			groupNames = append(groupNames, group.Name)
+		}
+
+		assert.Equal(t, []string{"", "Machine", "OS & Storage", "Networking", "Security", "Management", "Advanced"}, groupNames)
+		assert.Len(t, groups[0].Fields, 3)
+	})
 }
 
 func Test_resolveDiskTypeURL(t *testing.T) {
@@ -108,9 +124,68 @@ func Test_BuildAdditionalDisks(t *testing.T) {
 		assert.Equal(t, "data", out[0].InitializeParams.DiskName)
 		assert.Equal(t, int64(100), out[0].InitializeParams.DiskSizeGb)
 		assert.Contains(t, out[0].InitializeParams.DiskType, "pd-ssd")
+		assert.Nil(t, out[0].DiskEncryptionKey)
+	})
+	t.Run("new disk with encryption key", func(t *testing.T) {
+		key := "projects/p/locations/us-central1/keyRings/r/cryptoKeys/k"
+		disks := []AdditionalDisk{
+			{Name: "data", DiskType: "pd-ssd", DiskEncryptionKey: key},
+			{Name: "scratch", DiskType: "local-ssd", DiskEncryptionKey: key},
+		}
+		out := BuildAdditionalDisks("p", "us-central1-a", disks)
+		require.Len(t, out, 2)
+		require.NotNil(t, out[0].DiskEncryptionKey)
+		assert.Equal(t, key, out[0].DiskEncryptionKey.KmsKeyName)
+		assert.Nil(t, out[1].DiskEncryptionKey)
 	})
 }
 
+func Test_DiskEncryptionKeys(t *testing.T) {
+	defaultKey := "projects/p/locations/us-central1/keyRings/r/cryptoKeys/default"
+	diskKey := "projects/p/locations/us-central1/keyRings/r/cryptoKeys/disk"
+	config := OSAndStorageConfig{
+		BootDiskSourceType: BootDiskSourcePublicImage,
+		DiskEncryptionKey:  defaultKey,
+		AdditionalDisks: []AdditionalDiskEntry{
+			{Mode: AdditionalDiskModeNew, Name: "data"},
+			{Mode: AdditionalDiskModeNew, Name: "logs", EncryptionKey: diskKey},
+			{Mode: AdditionalDiskModeNew, Name: "scratch", DiskType: "local-ssd"},
+			{Mode: AdditionalDiskModeExisting, ExistingDisk: "shared"},
+		},
+	}
+
+	t.Run("new disks use their own key or the default key", func(t *testing.T) {
+		assert.Equal(t, defaultKey, bootDiskConfigFromOSConfig("p", "us-central1-a", config).DiskEncryptionKey)
+
+		disks := additionalDisksFromOSConfig(config)
+		require.Len(t, disks, 4)
+		assert.Equal(t, defaultKey, disks[0].DiskEncryptionKey)
+		assert.Equal(t, diskKey, disks[1].DiskEncryptionKey)
+		assert.Empty(t, disks[2].DiskEncryptionKey)
+		assert.Empty(t, disks[3].DiskEncryptionKey)
+	})
+
+	t.Run("existing boot disk -> default key not used", func(t *testing.T) {
+		existing := config
+		existing.BootDiskSourceType = BootDiskSourceExistingDisk
+		existing.BootDiskExistingDisk = "boot"
+		assert.Empty(t, bootDiskConfigFromOSConfig("p", "us-central1-a", existing).DiskEncryptionKey)
+	})
+}
+
+func Test_validateDiskEncryptionKey(t *testing.T) {
+	assert.Empty(t, validateDiskEncryptionKey("projects/p/locations/us-central1/keyRings/r/cryptoKeys/k", "us-central1-a"))
+	assert.Empty(t, validateDiskEncryptionKey("projects/p/locations/us-central1/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1", "us-central1-a"))
+	assert.Empty(t, validateDiskEncryptionKey("projects/p/locations/global/keyRings/r/cryptoKeys/k", "europe-west1-b"))
+	assert.Empty(t, validateDiskEncryptionKey("{{ $['trigger'].data.key }}", "us-central1-a"))
+	assert.Empty(t, validateDiskEncryptionKey("projects/p/locations/us-central1/keyRings/r/cryptoKeys/k", "{{ $['trigger'].data.zone }}"))
+	assert.Equal(t,
+		"encryption key is in us-east1, but disks in zone us-central1-a need a key in us-central1",
+		validateDiskEncryptionKey("projects/p/locations/us-east1/keyRings/r/cryptoKeys/k", "us-central1-a"),
+	)
+	assert.Contains(t, validateDiskEncryptionKey("my-key", "us-central1-a"), "must be a Cloud KMS key resource name")
+}
+
 func Test_BuildLocalSSDDisks(t *testing.T) {
 	assert.Nil(t, BuildLocalSSDDisks("p", "z", 0))
 	assert.Nil(t, BuildLocalSSDDisks("p", "z", -1))
@@ -273,6 +348,20 @@ func Test_BuildNetworkInterfaces(t *testing.T) {
 		require.Len(t, out, 1)
 		assert.Equal(t, "10.0.0.5", out[0].NetworkIP)
 	})
+	t.Run("static internal IP selected from reserved addresses", func(t *testing.T) {
+		cfg := NetworkingConfig{
+			Network:                   "default",
+			InternalIPType:            InternalIPStatic,
+			ReservedInternalIPAddress: "10.0.0.7",
+		}
+		out := BuildNetworkInterfaces("p", "r", cfg)
+		require.Len(t, out, 1)
+		assert.Equal(t, "10.0.0.7", out[0].NetworkIP)
+
+		cfg.InternalIPAddress = "10.0.0.5"
+		out = BuildNetworkInterfaces("p", "r", cfg)
+		assert.Equal(t, "10.0.0.5", out[0].NetworkIP)
+	})
 	t.Run("external IP none has no access configs", func(t *testing.T) {
 		cfg := NetworkingConfig{Network: "default", ExternalIPType: ExternalIPNone}
 		out := BuildNetworkInterfaces("p", "r", cfg)
@@ -311,6 +400,75 @@ func Test_BuildInstanceTags(t *testing.T) {
 	})
 }
 
+func Test_ReuseFirewallRules(t *testing.T) {
+	firewalls := `{"items": [
+		{"name": "disabled-ssh", "network": "projects/p/global/networks/default", "disabled": true, "allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["0.0.0.0/0"], "targetTags": ["disabled"]},
+		{"name": "other-network-ssh", "network": "projects/p/global/networks/other", "allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["0.0.0.0/0"], "targetTags": ["other"]},
+		{"name": "team-ssh", "network": "https://www.googleapis.com/compute/v1/projects/p/global/networks/default", "direction": "INGRESS", "allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["0.0.0.0/0"], "targetTags": ["team-ssh"]},
+		{"name": "web", "network": "projects/p/global/networks/default", "direction": "INGRESS", "allowed": [{"IPProtocol": "tcp", "ports": ["443", "80"]}], "sourceRanges": ["10.0.0.0/8", "192.168.0.0/16"]},
+		{"name": "internal-dns", "network": "projects/p/global/networks/default", "direction": "INGRESS", "allowed": [{"IPProtocol": "udp", "ports": ["53"]}], "sourceRanges": ["10.0.0.0/8"], "sourceTags": ["dns-clients"], "targetTags": ["dns"]}
+	]}`
+
+	newClient := func(created *[]string) *mockOSClient {
+		return &mockOSClient{
+			projectID: "p",
+			get: func(ctx context.Context, path string) ([]byte, error) {
+				require.Equal(t, "projects/p/global/firewalls", path)
+				return []byte(firewalls), nil
+			},
+			post: func(ctx context.Context, path string, body any) ([]byte, error) {
+				*created = append(*created, body.(*compute.Firewall).Name)
+				return []byte(`{}`), nil
+			},
+		}
+	}
+
+	t.Run("reuses the target tag of a matching rule", func(t *testing.T) {
+		created := []string{}
+		tags, err := ReuseFirewallRules(context.Background(), newClient(&created), "p", "", []CreateFirewallRuleEntry{
+			{Name: "allow-ssh", Allowed: "tcp:22", SourceRanges: "0.0.0.0/0", TargetTag: "ssh"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-ssh"}, tags)
+		assert.Empty(t, created)
+	})
+
+	t.Run("rule with no target tags needs no tag", func(t *testing.T) {
+		created := []string{}
+		tags, err := ReuseFirewallRules(context.Background(), newClient(&created), "p", "default", []CreateFirewallRuleEntry{
+			{Name: "allow-web", Allowed: "tcp:80,tcp:443", SourceRanges: "192.168.0.0/16, 10.0.0.0/8", TargetTag: "web"},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+		assert.Empty(t, created)
+	})
+
+	t.Run("creates rules with no match", func(t *testing.T) {
+		created := []string{}
+		tags, err := ReuseFirewallRules(context.Background(), newClient(&created), "p", "", []CreateFirewallRuleEntry{
+			{Name: "allow-ssh-office", Allowed: "tcp:22", SourceRanges: "203.0.113.50/32", TargetTag: "ssh-office"},
+			{Name: "allow-dns", Allowed: "udp:53", SourceRanges: "10.0.0.0/8", TargetTag: "dns"},
+			{Name: "allow-ssh-other", Allowed: "tcp:22", SourceRanges: "0.0.0.0/0", TargetTag: "ssh"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ssh-office", "dns", "team-ssh"}, tags)
+		assert.Equal(t, []string{"allow-ssh-office", "allow-dns"}, created)
+	})
+
+	t.Run("invalid allowed returns error", func(t *testing.T) {
+		created := []string{}
+		_, err := ReuseFirewallRules(context.Background(), newClient(&created), "p", "", []CreateFirewallRuleEntry{
+			{Name: "bad", Allowed: "ssh", SourceRanges: "0.0.0.0/0", TargetTag: "ssh"},
+		})
+
+		require.Error(t, err)
+		assert.Empty(t, created)
+	})
+}
+
 func Test_BuildInstanceMetadata(t *testing.T) {
 	t.Run("empty config returns nil", func(t *testing.T) {
 		out := BuildInstanceMetadata(ManagementConfig{})
@@ -602,6 +760,50 @@ func Test_InstancePayloadFromGetResponse(t *testing.T) {
 		assert.Equal(t, "34.1.2.3", payload["externalIP"])
 	})
 
+	t.Run("all network interfaces, disks, labels and service account", func(t *testing.T) {
+		body := []byte(`{
+			"id": "1",
+			"name": "my-vm",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"networkInterfaces": [
+				{
+					"name": "nic0",
+					"network": "https://www.googleapis.com/compute/v1/projects/p/global/networks/default",
+					"subnetwork": "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1/subnetworks/default",
+					"networkIP": "10.0.0.2",
+					"accessConfigs": [{"natIP": "34.1.2.3"}]
+				},
+				{
+					"name": "nic1",
+					"network": "projects/p/global/networks/backend",
+					"subnetwork": "projects/p/regions/us-central1/subnetworks/backend-a",
+					"networkIP": "10.1.0.5"
+				}
+			],
+			"disks": [
+				{"deviceName": "my-vm", "source": "projects/p/zones/us-central1-a/disks/my-vm", "boot": true, "mode": "READ_WRITE", "diskSizeGb": "20"},
+				{"deviceName": "data", "source": "projects/p/zones/us-central1-a/disks/my-vm-data", "mode": "READ_ONLY", "diskSizeGb": "100"}
+			],
+			"labels": {"env": "staging"},
+			"serviceAccounts": [{"email": "sa@p.iam.gserviceaccount.com", "scopes": []}]
+		}`)
+
+		payload, err := InstancePayloadFromGetResponse(body, "")
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.2", payload["internalIP"])
+		assert.Equal(t, "34.1.2.3", payload["externalIP"])
+		assert.Equal(t, []map[string]any{
+			{"name": "nic0", "network": "default", "subnetwork": "default", "internalIP": "10.0.0.2", "externalIP": "34.1.2.3"},
+			{"name": "nic1", "network": "backend", "subnetwork": "backend-a", "internalIP": "10.1.0.5"},
+		}, payload["networkInterfaces"])
+		assert.Equal(t, []map[string]any{
+			{"deviceName": "my-vm", "name": "my-vm", "boot": true, "mode": "READ_WRITE", "sizeGb": int64(20)},
+			{"deviceName": "data", "name": "my-vm-data", "boot": false, "mode": "READ_ONLY", "sizeGb": int64(100)},
+		}, payload["disks"])
+		assert.Equal(t, map[string]string{"env": "staging"}, payload["labels"])
+		assert.Equal(t, "sa@p.iam.gserviceaccount.com", payload["serviceAccount"])
+	})
+
 	t.Run("invalid JSON returns error", func(t *testing.T) {
 		_, err := InstancePayloadFromGetResponse([]byte(`{invalid`), "")
 		require.Error(t, err)
@@ -614,6 +816,9 @@ func Test_InstancePayloadFromGetResponse(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "1", payload["instanceId"])
 		assert.Equal(t, "z1", payload["zone"])
+		assert.Empty(t, payload["networkInterfaces"])
+		assert.Empty(t, payload["disks"])
+		assert.NotContains(t, payload, "serviceAccount")
 	})
 }
 
@@ -645,6 +850,18 @@ func Test_BuildInstanceFromConfig(t *testing.T) {
 		assert.NotEmpty(t, inst.NetworkInterfaces[0].Network)
 	})
 
+	t.Run("instance is labelled as managed by SuperPlane", func(t *testing.T) {
+		config := minimalConfig()
+		inst, err := BuildInstanceFromConfig("p", "us-central1-a", "us-central1", config)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{ManagedByLabelKey: ManagedByLabelValue}, inst.Labels)
+
+		config.Labels = []LabelEntry{{Key: "env", Value: "prod"}, {Key: ManagedByLabelKey, Value: "terraform"}}
+		inst, err = BuildInstanceFromConfig("p", "us-central1-a", "us-central1", config)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "prod", ManagedByLabelKey: "terraform"}, inst.Labels)
+	})
+
 	t.Run("empty instance name returns error", func(t *testing.T) {
 		config := minimalConfig()
 		config.InstanceName = ""
@@ -713,3 +930,172 @@ func Test_validateCreateVMConfig(t *testing.T) {
 		assert.Equal(t, "machine type is required", msg)
 	})
 }
+
+func Test__CreateVM__Validate(t *testing.T) {
+	component := &CreateVM{}
+
+	t.Run("valid configuration -> no results", func(t *testing.T) {
+		results := component.Validate(map[string]any{
+			"instanceName": "my-vm",
+			"zone":         "us-central1-a",
+			"machineType":  "e2-medium",
+		})
+		assert.Empty(t, results)
+	})
+
+	t.Run("missing fields -> one error per field", func(t *testing.T) {
+		results := component.Validate(map[string]any{"instanceName": "My_VM"})
+		require.Len(t, results, 3)
+		assert.Equal(t, "instanceName", results[0].Field)
+		assert.Equal(t, core.ValidationSeverityError, results[0].Severity)
+		assert.Equal(t, "zone", results[1].Field)
+		assert.Equal(t, "machineType", results[2].Field)
+	})
+
+	t.Run("static internal IP without an address -> error", func(t *testing.T) {
+		results := component.Validate(map[string]any{
+			"instanceName":   "my-vm",
+			"zone":           "us-central1-a",
+			"machineType":    "e2-medium",
+			"internalIPType": InternalIPStatic,
+		})
+		require.Len(t, results, 1)
+		assert.Equal(t, "reservedInternalIPAddress", results[0].Field)
+
+		results = component.Validate(map[string]any{
+			"instanceName":              "my-vm",
+			"zone":                      "us-central1-a",
+			"machineType":               "e2-medium",
+			"internalIPType":            InternalIPStatic,
+			"reservedInternalIPAddress": "10.0.0.7",
+		})
+		assert.Empty(t, results)
+	})
+
+	t.Run("encryption keys in another region -> error", func(t *testing.T) {
+		results := component.Validate(map[string]any{
+			"instanceName":          "my-vm",
+			"zone":                  "us-central1-a",
+			"machineType":           "e2-medium",
+			"bootDiskEncryptionKey": "projects/p/locations/us-central1/keyRings/r/cryptoKeys/k",
+			"diskEncryptionKey":     "projects/p/locations/us-east1/keyRings/r/cryptoKeys/k",
+			"additionalDisks": []any{
+				map[string]any{"mode": AdditionalDiskModeNew, "name": "data", "encryptionKey": "projects/p/locations/europe-west1/keyRings/r/cryptoKeys/k"},
+				map[string]any{"mode": AdditionalDiskModeNew, "name": "scratch", "diskType": "local-ssd", "encryptionKey": "projects/p/locations/us-central1/keyRings/r/cryptoKeys/k"},
+			},
+		})
+		require.Len(t, results, 3)
+		assert.Equal(t, "diskEncryptionKey", results[0].Field)
+		assert.Equal(t, "additionalDisks", results[1].Field)
+		assert.Contains(t, results[1].Message, "disk 1: encryption key is in europe-west1")
+		assert.Equal(t, "additionalDisks", results[2].Field)
+		assert.Contains(t, results[2].Message, "disk 2: local SSDs cannot use")
+	})
+
+	t.Run("instance name expression -> not checked against the name pattern", func(t *testing.T) {
+		results := component.Validate(map[string]any{
+			"instanceName": "{{ $['trigger'].data.name }}",
+			"zone":         "us-central1-a",
+			"machineType":  "e2-medium",
+		})
+		assert.Empty(t, results)
+	})
+}
+
+func Test__CreateVM__Resume(t *testing.T) {
+	component := &CreateVM{}
+	checkpoint := core.Checkpoint{
+		Step:      createVMWaitForInsertStep,
+		Operation: "operation-1712345",
+		Target: map[string]any{
+			"project":      "p",
+			"zone":         "us-central1-a",
+			"instanceName": "my-vm",
+		},
+	}
+
+	t.Run("waits for the insert operation instead of inserting the instance again", func(t *testing.T) {
+		paths := []string{}
+		client := &mockOSClient{
+			projectID: "p",
+			get: func(ctx context.Context, path string) ([]byte, error) {
+				paths = append(paths, path)
+				switch path {
+				case "projects/p/zones/us-central1-a/operations/operation-1712345":
+					return []byte(`{"status": "DONE"}`), nil
+				case "projects/p/zones/us-central1-a/instances/my-vm":
+					return []byte(`{"id": "123", "name": "my-vm", "status": "RUNNING", "machineType": "zones/us-central1-a/machineTypes/e2-medium"}`), nil
+				}
+				return nil, fmt.Errorf("unexpected path %s", path)
+			},
+			post: func(ctx context.Context, path string, body any) ([]byte, error) {
+				t.Fatalf("unexpected POST %s", path)
+				return nil, nil
+			},
+		}
+		useInstanceClient(t, client)
+
+		executionState := &contexts.ExecutionStateContext{}
+		metadata := &contexts.MetadataContext{}
+		err := component.Resume(core.ExecutionContext{
+			Configuration:  map[string]any{"instanceName": "my-vm", "zone": "us-central1-a", "machineType": "e2-medium"},
+			Logger:         logrus.NewEntry(logrus.New()),
+			Metadata:       metadata,
+			ExecutionState: executionState,
+		}, checkpoint)
+
+		require.NoError(t, err)
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, createVMPayloadType, executionState.Type)
+		assert.Equal(t, CreateVMMetadata{Project: "p", Zone: "us-central1-a", InstanceName: "my-vm"}, metadata.Metadata)
+		assert.Equal(t, []string{
+			"projects/p/zones/us-central1-a/operations/operation-1712345",
+			"projects/p/zones/us-central1-a/instances/my-vm",
+		}, paths[:2])
+	})
+
+	t.Run("failed insert operation -> execution fails", func(t *testing.T) {
+		useInstanceClient(t, &mockOSClient{
+			projectID: "p",
+			get: func(ctx context.Context, path string) ([]byte, error) {
+				return []byte(`{"status": "DONE", "error": {"errors": [{"message": "quota exceeded"}]}}`), nil
+			},
+		})
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Resume(core.ExecutionContext{
+			Configuration:  map[string]any{"instanceName": "my-vm", "zone": "us-central1-a", "machineType": "e2-medium"},
+			Logger:         logrus.NewEntry(logrus.New()),
+			Metadata:       &contexts.MetadataContext{},
+			ExecutionState: executionState,
+		}, checkpoint)
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "quota exceeded")
+	})
+
+	t.Run("checkpoint without an operation -> execution fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Resume(core.ExecutionContext{
+			Configuration:  map[string]any{"instanceName": "my-vm", "zone": "us-central1-a", "machineType": "e2-medium"},
+			Metadata:       &contexts.MetadataContext{},
+			ExecutionState: executionState,
+		}, core.Checkpoint{Step: createVMWaitForInsertStep, Target: checkpoint.Target})
+
+		require.NoError(t, err)
+		assert.Contains(t, executionState.FailureMessage, "invalid checkpoint")
+	})
+}
+
+func Test_CreateVMSensitivePaths(t *testing.T) {
+	c := &CreateVM{}
+	require.NoError(t, core.ValidateSensitivePaths(c.SensitivePaths()))
+
+	payload := map[string]any{"type": createVMPayloadType, "data": c.ExampleOutput()}
+	redacted := core.RedactPayload(payload, c.SensitivePaths()).(map[string]any)
+	data := redacted["data"].(map[string]any)
+
+	assert.Equal(t, core.RedactedValue, data["serviceAccount"])
+	assert.Equal(t, "34.1.2.3", data["externalIP"])
+}
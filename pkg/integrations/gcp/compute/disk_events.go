@@ -0,0 +1,188 @@
+package compute
+
+import (
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+//
+// Disk and snapshot operations are long-running, and write an audit log entry
+// when they start and another one when they finish. These triggers only react
+// to the last one, so the disk or snapshot is ready, or gone, by the time
+// the workflow runs.
+//
+
+const operationLastFilter = "operation.last=true"
+
+type diskEvent struct {
+	EventType    string
+	ResourceType string
+	MethodNames  []string
+}
+
+var (
+	diskCreated = diskEvent{
+		EventType:    "gcp.compute.disk.created",
+		ResourceType: "disk",
+		MethodNames:  append(computeMethodNames("disks", "insert"), computeMethodNames("regionDisks", "insert")...),
+	}
+
+	diskDeleted = diskEvent{
+		EventType:    "gcp.compute.disk.deleted",
+		ResourceType: "disk",
+		MethodNames:  append(computeMethodNames("disks", "delete"), computeMethodNames("regionDisks", "delete")...),
+	}
+
+	snapshotCompleted = diskEvent{
+		EventType:    "gcp.compute.snapshot.completed",
+		ResourceType: "snapshot",
+		MethodNames: append(
+			computeMethodNames("snapshots", "insert"),
+			append(computeMethodNames("disks", "createSnapshot"), computeMethodNames("regionDisks", "createSnapshot")...)...,
+		),
+	}
+)
+
+func (e diskEvent) sinkFilter() string {
+	return auditLogSinkFilter(e.MethodNames) + " AND " + operationLastFilter
+}
+
+func (e diskEvent) subscriptionPattern() map[string]any {
+	return computeSubscriptionPattern()
+}
+
+func (e diskEvent) exampleData() map[string]any {
+	methodName := e.MethodNames[0]
+	resourceName := "projects/my-project/zones/us-central1-a/disks/my-disk"
+	resource := map[string]any{
+		"type":    "disk",
+		"project": "my-project",
+		"zone":    "us-central1-a",
+		"name":    "my-disk",
+	}
+
+	if e.ResourceType == "snapshot" {
+		resourceName = "projects/my-project/global/snapshots/my-snapshot"
+		resource = map[string]any{
+			"type":       "snapshot",
+			"project":    "my-project",
+			"name":       "my-snapshot",
+			"sourceDisk": "projects/my-project/zones/us-central1-a/disks/my-disk",
+		}
+	}
+
+	return map[string]any{
+		"serviceName":  computeServiceName,
+		"methodName":   methodName,
+		"resourceName": resourceName,
+		"logName":      "projects/my-project/logs/cloudaudit.googleapis.com%2Factivity",
+		"timestamp":    "2025-02-14T12:00:00Z",
+		"succeeded":    true,
+		"resource":     resource,
+		"data": map[string]any{
+			"operation": map[string]any{
+				"id":   "operation-1739534400000-abc123",
+				"last": true,
+			},
+			"protoPayload": map[string]any{
+				"methodName":   methodName,
+				"resourceName": resourceName,
+				"serviceName":  computeServiceName,
+			},
+		},
+	}
+}
+
+func (e diskEvent) emit(ctx core.IntegrationMessageContext) error {
+	event, ok, err := decodeComputeAuditLogEvent(ctx.Message, e.MethodNames)
+	if err != nil || !ok {
+		return err
+	}
+
+	data, _ := event.Data.(map[string]any)
+	operation, _ := data["operation"].(map[string]any)
+	if last, _ := operation["last"].(bool); !last {
+		return nil
+	}
+
+	protoPayload, _ := data["protoPayload"].(map[string]any)
+
+	payload := event.payload()
+	payload["resource"] = e.resource(event, protoPayload)
+	payload["succeeded"] = true
+
+	//
+	// Failed operations are emitted too, e.g. so a backup verification
+	// workflow knows a snapshot did not complete.
+	//
+	status, _ := protoPayload["status"].(map[string]any)
+	if code, _ := status["code"].(float64); code != 0 {
+		payload["succeeded"] = false
+		payload["error"] = status["message"]
+	}
+
+	_, err = core.EmitDeduplicated(ctx.Events, event.InsertID, e.EventType, payload)
+	return err
+}
+
+/*
+ * Normalized resource for the event. Snapshots created from a disk
+ * have the disk as the audit log resource, and the snapshot name in the request.
+ */
+func (e diskEvent) resource(event *computeAuditLogEvent, protoPayload map[string]any) map[string]any {
+	request, _ := protoPayload["request"].(map[string]any)
+
+	if e.ResourceType == "snapshot" && strings.HasSuffix(event.MethodName, ".createSnapshot") {
+		resource := map[string]any{
+			"type":       e.ResourceType,
+			"sourceDisk": event.ResourceName,
+		}
+
+		if project, ok := computeResourceDetails(event.ResourceName)["project"]; ok {
+			resource["project"] = project
+		}
+
+		if name, ok := request["name"].(string); ok {
+			resource["name"] = name
+		}
+
+		return resource
+	}
+
+	resource := computeResourceDetails(event.ResourceName)
+	resource["type"] = e.ResourceType
+
+	if sourceDisk, ok := request["sourceDisk"].(string); ok && e.ResourceType == "snapshot" {
+		resource["sourceDisk"] = sourceDisk
+	}
+
+	return resource
+}
+
+/*
+ * Project, zone or region, and name from a compute resource name,
+ * e.g. projects/my-project/zones/us-central1-a/disks/my-disk
+ * or projects/my-project/global/snapshots/my-snapshot.
+ */
+func computeResourceDetails(resourceName string) map[string]any {
+	details := map[string]any{}
+
+	parts := strings.Split(strings.Trim(resourceName, "/"), "/")
+	if len(parts) < 4 || parts[0] != "projects" {
+		return details
+	}
+
+	details["project"] = parts[1]
+	for i := 2; i+1 < len(parts); i++ {
+		switch parts[i] {
+		case "zones":
+			details["zone"] = parts[i+1]
+		case "regions":
+			details["region"] = parts[i+1]
+		}
+	}
+
+	details["name"] = parts[len(parts)-1]
+	return details
+}
@@ -0,0 +1,144 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func diskEventMessage(methodName, resourceName string, last bool, protoPayload map[string]any) map[string]any {
+	return map[string]any{
+		"serviceName":  computeServiceName,
+		"methodName":   methodName,
+		"resourceName": resourceName,
+		"insertId":     "abc123",
+		"data": map[string]any{
+			"operation":    map[string]any{"id": "operation-1", "last": last},
+			"protoPayload": protoPayload,
+		},
+	}
+}
+
+func emitDiskEvent(t *testing.T, trigger core.IntegrationTrigger, message map[string]any) *contexts.EventContext {
+	t.Helper()
+
+	events := &contexts.EventContext{}
+	err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+		Message: message,
+		Logger:  logrus.NewEntry(logrus.New()),
+		Events:  events,
+	})
+
+	require.NoError(t, err)
+	return events
+}
+
+func Test_OnDiskCreated(t *testing.T) {
+	trigger := &OnDiskCreated{}
+	assert.Equal(t, "gcp.compute.onDiskCreated", trigger.Name())
+	assert.Contains(t, diskCreated.sinkFilter(), `protoPayload.methodName="v1.compute.regionDisks.insert"`)
+	assert.Contains(t, diskCreated.sinkFilter(), "AND operation.last=true")
+
+	t.Run("first operation entry does not emit", func(t *testing.T) {
+		events := emitDiskEvent(t, trigger, diskEventMessage("v1.compute.disks.insert", "projects/p/zones/us-east1-b/disks/d1", false, nil))
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("disk deletion does not emit", func(t *testing.T) {
+		events := emitDiskEvent(t, trigger, diskEventMessage("v1.compute.disks.delete", "projects/p/zones/us-east1-b/disks/d1", true, nil))
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("last operation entry emits the disk", func(t *testing.T) {
+		events := emitDiskEvent(t, trigger, diskEventMessage("v1.compute.disks.insert", "projects/p/zones/us-east1-b/disks/d1", true, nil))
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gcp.compute.disk.created", events.Payloads[0].Type)
+
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, true, payload["succeeded"])
+		assert.Equal(t, map[string]any{
+			"type":    "disk",
+			"project": "p",
+			"zone":    "us-east1-b",
+			"name":    "d1",
+		}, payload["resource"])
+	})
+}
+
+func Test_OnDiskDeleted(t *testing.T) {
+	trigger := &OnDiskDeleted{}
+	assert.Equal(t, "gcp.compute.onDiskDeleted", trigger.Name())
+
+	events := emitDiskEvent(t, trigger, diskEventMessage("compute.regionDisks.delete", "projects/p/regions/us-east1/disks/d1", true, nil))
+	require.Equal(t, 1, events.Count())
+	assert.Equal(t, "gcp.compute.disk.deleted", events.Payloads[0].Type)
+
+	payload := events.Payloads[0].Data.(map[string]any)
+	assert.Equal(t, map[string]any{
+		"type":    "disk",
+		"project": "p",
+		"region":  "us-east1",
+		"name":    "d1",
+	}, payload["resource"])
+}
+
+func Test_OnSnapshotCompleted(t *testing.T) {
+	trigger := &OnSnapshotCompleted{}
+	assert.Equal(t, "gcp.compute.onSnapshotCompleted", trigger.Name())
+
+	t.Run("snapshot insert", func(t *testing.T) {
+		events := emitDiskEvent(t, trigger, diskEventMessage(
+			"v1.compute.snapshots.insert",
+			"projects/p/global/snapshots/s1",
+			true,
+			map[string]any{"request": map[string]any{"sourceDisk": "projects/p/zones/us-east1-b/disks/d1"}},
+		))
+
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gcp.compute.snapshot.completed", events.Payloads[0].Type)
+
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, map[string]any{
+			"type":       "snapshot",
+			"project":    "p",
+			"name":       "s1",
+			"sourceDisk": "projects/p/zones/us-east1-b/disks/d1",
+		}, payload["resource"])
+	})
+
+	t.Run("snapshot created from a disk", func(t *testing.T) {
+		events := emitDiskEvent(t, trigger, diskEventMessage(
+			"v1.compute.disks.createSnapshot",
+			"projects/p/zones/us-east1-b/disks/d1",
+			true,
+			map[string]any{"request": map[string]any{"name": "s1"}},
+		))
+
+		require.Equal(t, 1, events.Count())
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, map[string]any{
+			"type":       "snapshot",
+			"project":    "p",
+			"name":       "s1",
+			"sourceDisk": "projects/p/zones/us-east1-b/disks/d1",
+		}, payload["resource"])
+	})
+
+	t.Run("failed snapshot", func(t *testing.T) {
+		events := emitDiskEvent(t, trigger, diskEventMessage(
+			"v1.compute.snapshots.insert",
+			"projects/p/global/snapshots/s1",
+			true,
+			map[string]any{"status": map[string]any{"code": float64(3), "message": "disk not found"}},
+		))
+
+		require.Equal(t, 1, events.Count())
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, false, payload["succeeded"])
+		assert.Equal(t, "disk not found", payload["error"])
+	})
+}
@@ -0,0 +1,255 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	getSerialPortOutputPayloadType = "gcp.compute.serialPortOutput"
+
+	//
+	// Instances keep up to 1MB of serial port output.
+	// Only the end is kept, since that is where startup failures are.
+	//
+	maxSerialPortOutputBytes = 256 * 1024
+)
+
+type GetSerialPortOutput struct{}
+
+type GetSerialPortOutputConfiguration struct {
+	InstanceName string `mapstructure:"instanceName"`
+	Zone         string `mapstructure:"zone"`
+	Port         int64  `mapstructure:"port"`
+}
+
+type serialPortOutputResp struct {
+	Contents string `json:"contents"`
+	Next     string `json:"next"`
+}
+
+/*
+ * SerialPortOutput is the end of the output of an instance serial port.
+ * Truncated is set when the beginning was cut to maxSerialPortOutputBytes.
+ */
+type SerialPortOutput struct {
+	InstanceName string `json:"instanceName"`
+	Zone         string `json:"zone"`
+	Port         int64  `json:"port"`
+	Contents     string `json:"contents"`
+	Truncated    bool   `json:"truncated"`
+}
+
+func (c *GetSerialPortOutput) Name() string {
+	return "gcp.getSerialPortOutput"
+}
+
+func (c *GetSerialPortOutput) Label() string {
+	return "Compute • Get Serial Port Output"
+}
+
+func (c *GetSerialPortOutput) Description() string {
+	return "Fetch the serial port output of a Compute Engine VM, e.g. to debug startup scripts"
+}
+
+func (c *GetSerialPortOutput) Documentation() string {
+	return `The Get Serial Port Output component fetches the serial port output of a Compute Engine VM instance, where the boot logs and startup script output are written.
+
+## Use Cases
+
+- **Debug startup scripts**: Inspect the output of a startup script that failed after a Create Virtual Machine step
+- **Health checks**: Look for a line written by the startup script once the instance is ready
+
+## Configuration
+
+- **Zone**: The zone of the instance.
+- **Instance name**: The name of the instance, e.g. from the output of a Create Virtual Machine step.
+- **Port**: The serial port, from 1 to 4. Port 1 has the boot logs and the startup script output.
+
+## Output
+
+The instance name, zone, port and contents. Only the last 256KB of the output are kept, and truncated is set when the beginning was cut.
+
+Create Virtual Machine executions also have a **Fetch serial port output** action, which writes the output of the instance they created to the execution logs.`
+}
+
+func (c *GetSerialPortOutput) Icon() string {
+	return "gcp"
+}
+
+func (c *GetSerialPortOutput) Color() string {
+	return "gray"
+}
+
+func (c *GetSerialPortOutput) ExampleOutput() map[string]any {
+	return map[string]any{
+		"instanceName": "my-vm",
+		"zone":         "us-central1-a",
+		"port":         1,
+		"contents":     "google_metadata_script_runner: startup-script: Installing nginx...\ngoogle_metadata_script_runner: startup-script exit status 0\n",
+		"truncated":    false,
+	}
+}
+
+func (c *GetSerialPortOutput) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetSerialPortOutput) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "GCP zone of the instance (e.g. us-central1-a).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeZone,
+				},
+			},
+		},
+		{
+			Name:        "instanceName",
+			Label:       "Instance name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the instance.",
+			Placeholder: "e.g. my-vm-01",
+		},
+		{
+			Name:        "port",
+			Label:       "Port",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Serial port to read, from 1 to 4. Port 1 has the boot logs and startup script output.",
+			Default:     1,
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(4)},
+			},
+		},
+	}
+}
+
+func decodeGetSerialPortOutputConfiguration(raw any) (GetSerialPortOutputConfiguration, error) {
+	var config GetSerialPortOutputConfiguration
+	if err := mapstructure.Decode(raw, &config); err != nil {
+		return GetSerialPortOutputConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.InstanceName = strings.TrimSpace(config.InstanceName)
+	config.Zone = lastSegment(strings.TrimSpace(config.Zone))
+	if config.Port == 0 {
+		config.Port = 1
+	}
+
+	if config.Zone == "" {
+		return GetSerialPortOutputConfiguration{}, fmt.Errorf("zone is required")
+	}
+
+	if config.InstanceName == "" {
+		return GetSerialPortOutputConfiguration{}, fmt.Errorf("instanceName is required")
+	}
+
+	if config.Port < 1 || config.Port > 4 {
+		return GetSerialPortOutputConfiguration{}, fmt.Errorf("port must be between 1 and 4")
+	}
+
+	return config, nil
+}
+
+func (c *GetSerialPortOutput) Setup(ctx core.SetupContext) error {
+	_, err := decodeGetSerialPortOutputConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *GetSerialPortOutput) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetSerialPortOutput) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeGetSerialPortOutputConfiguration(ctx.Configuration)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
+	}
+
+	output, err := FetchSerialPortOutput(ctx.GoContext(), client, "", config.Zone, config.InstanceName, config.Port)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get serial port output: %v", err))
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, getSerialPortOutputPayloadType, []any{output})
+}
+
+/*
+ * Fetches the serial port output of an instance, keeping the last
+ * maxSerialPortOutputBytes, starting at a line boundary.
+ */
+func FetchSerialPortOutput(ctx context.Context, client Client, project, zone, name string, port int64) (*SerialPortOutput, error) {
+	if project == "" {
+		project = client.ProjectID()
+	}
+
+	path := fmt.Sprintf("projects/%s/zones/%s/instances/%s/serialPort?port=%d", project, zone, name, port)
+	body, err := client.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp serialPortOutputResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse serial port output response: %w", err)
+	}
+
+	output := &SerialPortOutput{
+		InstanceName: name,
+		Zone:         zone,
+		Port:         port,
+		Contents:     resp.Contents,
+	}
+
+	if len(output.Contents) > maxSerialPortOutputBytes {
+		contents := output.Contents[len(output.Contents)-maxSerialPortOutputBytes:]
+		if i := strings.IndexByte(contents, '\n'); i >= 0 {
+			contents = contents[i+1:]
+		}
+
+		output.Contents = contents
+		output.Truncated = true
+	}
+
+	return output, nil
+}
+
+func (c *GetSerialPortOutput) Actions() []core.Action {
+	return nil
+}
+
+func (c *GetSerialPortOutput) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *GetSerialPortOutput) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *GetSerialPortOutput) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetSerialPortOutput) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
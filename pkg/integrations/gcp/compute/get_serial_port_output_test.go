@@ -0,0 +1,105 @@
+package compute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type bufferLogs struct {
+	bytes.Buffer
+}
+
+func (l *bufferLogs) Printf(format string, args ...any) {
+	fmt.Fprintf(&l.Buffer, format+"\n", args...)
+}
+
+func serialPortResponse(t *testing.T, contents string) []byte {
+	body, err := json.Marshal(map[string]any{"contents": contents, "next": "42"})
+	require.NoError(t, err)
+	return body
+}
+
+func Test_GetSerialPortOutput_Execute(t *testing.T) {
+	client := &fakeInstanceClient{body: serialPortResponse(t, "booting\nstartup-script exit status 1\n")}
+	useInstanceClient(t, client)
+
+	state := &contexts.ExecutionStateContext{}
+	err := (&GetSerialPortOutput{}).Execute(core.ExecutionContext{
+		Configuration:  map[string]any{"zone": "us-central1-a", "instanceName": "my-vm"},
+		ExecutionState: state,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"projects/my-project/zones/us-central1-a/instances/my-vm/serialPort?port=1"}, client.paths)
+
+	output := state.Payloads[0].(map[string]any)["data"].(*SerialPortOutput)
+	assert.Equal(t, "booting\nstartup-script exit status 1\n", output.Contents)
+	assert.False(t, output.Truncated)
+}
+
+func Test_GetSerialPortOutput_Setup(t *testing.T) {
+	component := &GetSerialPortOutput{}
+	assert.ErrorContains(t, component.Setup(core.SetupContext{Configuration: map[string]any{"instanceName": "my-vm"}}), "zone is required")
+	assert.ErrorContains(t, component.Setup(core.SetupContext{Configuration: map[string]any{"zone": "us-central1-a"}}), "instanceName is required")
+	assert.ErrorContains(t, component.Setup(core.SetupContext{Configuration: map[string]any{"zone": "us-central1-a", "instanceName": "my-vm", "port": 5}}), "port must be between 1 and 4")
+}
+
+func Test_FetchSerialPortOutput_Truncates(t *testing.T) {
+	line := strings.Repeat("x", 1023) + "\n"
+	contents := strings.Repeat(line, 300)
+	useInstanceClient(t, &fakeInstanceClient{body: serialPortResponse(t, contents)})
+
+	client, err := getClient(core.ExecutionContext{})
+	require.NoError(t, err)
+
+	output, err := FetchSerialPortOutput(t.Context(), client, "", "us-central1-a", "my-vm", 1)
+	require.NoError(t, err)
+	assert.True(t, output.Truncated)
+	assert.Len(t, output.Contents, 255*1024)
+	assert.True(t, strings.HasPrefix(output.Contents, "xxx"))
+}
+
+func Test_CreateVM_FetchSerialPortOutputAction(t *testing.T) {
+	component := &CreateVM{}
+	require.Len(t, component.Actions(), 1)
+	assert.True(t, component.Actions()[0].UserAccessible)
+
+	t.Run("execution without instance", func(t *testing.T) {
+		err := component.HandleAction(core.ActionContext{
+			Name:     fetchSerialPortOutputAction,
+			Metadata: &contexts.MetadataContext{},
+			Logs:     &bufferLogs{},
+		})
+
+		require.ErrorContains(t, err, "execution has not created an instance")
+	})
+
+	t.Run("output is written to the execution logs", func(t *testing.T) {
+		client := &fakeInstanceClient{body: serialPortResponse(t, "startup-script exit status 1\n")}
+		useInstanceClient(t, client)
+
+		logs := &bufferLogs{}
+		err := component.HandleAction(core.ActionContext{
+			Name:       fetchSerialPortOutputAction,
+			Parameters: map[string]any{"port": float64(2)},
+			Metadata: &contexts.MetadataContext{Metadata: map[string]any{
+				"project":      "other-project",
+				"zone":         "us-central1-a",
+				"instanceName": "my-vm",
+			}},
+			Logs: logs,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"projects/other-project/zones/us-central1-a/instances/my-vm/serialPort?port=2"}, client.paths)
+		assert.Equal(t, "Serial port 2 output of my-vm in us-central1-a:\nstartup-script exit status 1\n", logs.String())
+	})
+}
@@ -0,0 +1,136 @@
+package compute
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/telemetry"
+)
+
+/*
+ * Lists that expired are still served for this long,
+ * while they are listed again in the background.
+ * Older ones are dropped, and listed again before being returned.
+ */
+const cacheMaxStaleness = 7 * 24 * time.Hour
+
+/*
+ * Background refreshes are not tied to the request that triggered them,
+ * which may be done before the list is, so they get their own timeout.
+ */
+const cacheRefreshTimeout = 2 * time.Minute
+
+type cacheEntry struct {
+	data    any
+	expires time.Time
+}
+
+/*
+ * listCache caches the lists shown in configuration forms,
+ * with a stale-while-revalidate policy: once a list expires,
+ * it is still returned right away, and refreshed in the background,
+ * so a form never waits on a full list after the first one.
+ */
+type listCache struct {
+	ttl    time.Duration
+	metric string
+
+	mu         sync.RWMutex
+	entries    map[string]*cacheEntry
+	refreshing map[string]bool
+}
+
+func newListCache(ttl time.Duration, metric string) *listCache {
+	return &listCache{
+		ttl:        ttl,
+		metric:     metric,
+		entries:    map[string]*cacheEntry{},
+		refreshing: map[string]bool{},
+	}
+}
+
+/*
+ * get returns the cached list for the key, listing and caching it if there is none.
+ * Expired lists are returned, and refreshed in the background. Only one refresh
+ * runs per key, and if it fails, the expired list is kept until the next lookup tries again.
+ */
+func (c *listCache) get(ctx context.Context, key string, list func(ctx context.Context) (any, error)) (any, error) {
+	now := time.Now()
+	data, expires, ok := c.lookup(key, now)
+	if c.metric != "" {
+		telemetry.RecordIntegrationCacheLookup(ctx, c.metric, ok)
+	}
+
+	if !ok {
+		data, err := list(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.set(key, data)
+		return data, nil
+	}
+
+	if now.After(expires) {
+		c.refresh(ctx, key, list)
+	}
+
+	return data, nil
+}
+
+func (c *listCache) lookup(key string, now time.Time) (any, time.Time, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || e == nil {
+		return nil, time.Time{}, false
+	}
+
+	if now.After(e.expires.Add(cacheMaxStaleness)) {
+		// Lazy eviction: remove entries too old to serve, to avoid unbounded memory growth
+		c.mu.Lock()
+		if e2, ok2 := c.entries[key]; ok2 && e2 != nil && now.After(e2.expires.Add(cacheMaxStaleness)) {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		return nil, time.Time{}, false
+	}
+
+	return e.data, e.expires, true
+}
+
+func (c *listCache) set(key string, data any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = &cacheEntry{data: data, expires: time.Now().Add(c.ttl)}
+}
+
+/*
+ * refresh lists the key again in the background, unless it is already being refreshed.
+ * The list keeps the values of the request context, but not its cancellation.
+ */
+func (c *listCache) refresh(ctx context.Context, key string, list func(ctx context.Context) (any, error)) {
+	c.mu.Lock()
+	if c.refreshing[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[key] = true
+	c.mu.Unlock()
+
+	go func() {
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheRefreshTimeout)
+		defer cancel()
+
+		data, err := list(refreshCtx)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.refreshing, key)
+		if err == nil {
+			c.entries[key] = &cacheEntry{data: data, expires: time.Now().Add(c.ttl)}
+		}
+	}()
+}
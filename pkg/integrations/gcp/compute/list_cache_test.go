@@ -0,0 +1,112 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lists and caches on the first lookup", func(t *testing.T) {
+		cache := newListCache(time.Hour, "")
+		var calls atomic.Int32
+		list := func(context.Context) (any, error) {
+			calls.Add(1)
+			return []string{"a"}, nil
+		}
+
+		v, err := cache.get(ctx, "key", list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, v)
+
+		v, err = cache.get(ctx, "key", list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, v)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("expired list is returned and refreshed in the background", func(t *testing.T) {
+		cache := newListCache(time.Hour, "")
+		cache.entries["key"] = &cacheEntry{data: []string{"stale"}, expires: time.Now().Add(-time.Minute)}
+
+		release := make(chan struct{})
+		var calls atomic.Int32
+		list := func(context.Context) (any, error) {
+			calls.Add(1)
+			<-release
+			return []string{"fresh"}, nil
+		}
+
+		//
+		// The lookups do not wait on the refresh,
+		// and only one refresh runs at a time.
+		//
+		for range 3 {
+			v, err := cache.get(ctx, "key", list)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"stale"}, v)
+		}
+
+		close(release)
+		require.Eventually(t, func() bool {
+			v, _, _ := cache.lookup("key", time.Now())
+			return assert.ObjectsAreEqual([]string{"fresh"}, v)
+		}, time.Second, time.Millisecond)
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("failed refresh keeps the expired list", func(t *testing.T) {
+		cache := newListCache(time.Hour, "")
+		cache.entries["key"] = &cacheEntry{data: []string{"stale"}, expires: time.Now().Add(-time.Minute)}
+
+		var calls atomic.Int32
+		list := func(context.Context) (any, error) {
+			calls.Add(1)
+			return nil, errors.New("quota exceeded")
+		}
+
+		v, err := cache.get(ctx, "key", list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"stale"}, v)
+
+		require.Eventually(t, func() bool {
+			cache.mu.RLock()
+			defer cache.mu.RUnlock()
+			return calls.Load() == 1 && !cache.refreshing["key"]
+		}, time.Second, time.Millisecond)
+
+		v, err = cache.get(ctx, "key", list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"stale"}, v)
+	})
+
+	t.Run("list too old is listed again before being returned", func(t *testing.T) {
+		cache := newListCache(time.Hour, "")
+		cache.entries["key"] = &cacheEntry{data: []string{"old"}, expires: time.Now().Add(-cacheMaxStaleness - time.Minute)}
+
+		v, err := cache.get(ctx, "key", func(context.Context) (any, error) {
+			return []string{"fresh"}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"fresh"}, v)
+	})
+
+	t.Run("lists that fail are not cached", func(t *testing.T) {
+		cache := newListCache(time.Hour, "")
+		_, err := cache.get(ctx, "key", func(context.Context) (any, error) {
+			return nil, errors.New("permission denied")
+		})
+
+		require.ErrorContains(t, err, "permission denied")
+		assert.Empty(t, cache.entries)
+	})
+}
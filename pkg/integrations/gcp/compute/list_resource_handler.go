@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
@@ -14,6 +16,8 @@ import (
 
 const cacheTTL = 24 * time.Hour
 
+const cacheMetricName = "gcp.compute.machineConfig"
+
 const (
 	ResourceTypeRegion        = "region"
 	ResourceTypeZone          = "zone"
@@ -41,6 +45,13 @@ type MachineType struct {
 	Description string `json:"description"`
 	SharedCPU   bool   `json:"isSharedCpu"`
 	Family      string `json:"family,omitempty"`
+
+	Accelerators []MachineTypeAccelerator `json:"accelerators,omitempty"`
+}
+
+type MachineTypeAccelerator struct {
+	Type  string `json:"guestAcceleratorType"`
+	Count int    `json:"guestAcceleratorCount"`
 }
 
 type MachineFamily struct {
@@ -70,45 +81,23 @@ type machineTypeItem struct {
 	MemoryMb    int64  `json:"memoryMb"`
 	Description string `json:"description"`
 	IsSharedCPU bool   `json:"isSharedCpu"`
+
+	Accelerators []MachineTypeAccelerator `json:"accelerators"`
 }
 
-type cacheEntry struct {
-	data    any
-	expires time.Time
+type acceleratorTypesListResp struct {
+	Items []*struct {
+		Name string `json:"name"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
 }
 
-var (
-	machineConfigCache   = make(map[string]*cacheEntry)
-	machineConfigCacheMu sync.RWMutex
-)
+var machineConfigCache = newListCache(cacheTTL, cacheMetricName)
 
-func cacheGet(key string) (any, bool) {
-	machineConfigCacheMu.RLock()
-	e, ok := machineConfigCache[key]
-	if !ok || e == nil {
-		machineConfigCacheMu.RUnlock()
-		return nil, false
-	}
-	if time.Now().After(e.expires) {
-		machineConfigCacheMu.RUnlock()
-		// Lazy eviction: remove expired entry to avoid unbounded memory growth
-		machineConfigCacheMu.Lock()
-		if e2, ok2 := machineConfigCache[key]; ok2 && e2 != nil && time.Now().After(e2.expires) {
-			delete(machineConfigCache, key)
-		}
-		machineConfigCacheMu.Unlock()
-		return nil, false
-	}
-	data := e.data
-	machineConfigCacheMu.RUnlock()
-	return data, true
+func cacheGet(ctx context.Context, key string, list func(ctx context.Context) (any, error)) (any, error) {
+	return machineConfigCache.get(ctx, key, list)
 }
 
-func cacheSet(key string, data any) {
-	machineConfigCacheMu.Lock()
-	defer machineConfigCacheMu.Unlock()
-	machineConfigCache[key] = &cacheEntry{data: data, expires: time.Now().Add(cacheTTL)}
-}
 func regionFromAPI(it *regionItem) Region {
 	zoneNames := make([]string, 0, len(it.Zones))
 	for _, z := range it.Zones {
@@ -132,6 +121,8 @@ func machineTypeFromAPI(it *machineTypeItem) MachineType {
 		Description: it.Description,
 		SharedCPU:   it.IsSharedCPU,
 		Family:      DeriveFamily(it.Name),
+
+		Accelerators: it.Accelerators,
 	}
 }
 
@@ -291,35 +282,36 @@ func formatFloatWithCommas(x float64) string {
 }
 func ListRegions(ctx context.Context, c Client) ([]Region, error) {
 	cacheKey := "regions:" + c.ProjectID()
-	if v, ok := cacheGet(cacheKey); ok {
-		return v.([]Region), nil
-	}
-
-	path := fmt.Sprintf("projects/%s/regions", c.ProjectID())
-	var all []Region
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withPageToken(path, pageToken))
-		if err != nil {
-			return nil, err
-		}
-		var resp regionsListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse regions response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
+	v, err := cacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/regions", c.ProjectID())
+		var all []Region
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp regionsListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse regions response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, regionFromAPI(it))
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
 			}
-			all = append(all, regionFromAPI(it))
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
 		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	cacheSet(cacheKey, all)
-	return all, nil
+	return v.([]Region), nil
 }
 
 func ListZones(ctx context.Context, c Client, region string) ([]Zone, error) {
@@ -347,35 +339,71 @@ func ListZones(ctx context.Context, c Client, region string) ([]Zone, error) {
 func ListMachineTypes(ctx context.Context, c Client, zone string) ([]MachineType, error) {
 	zone = strings.TrimSpace(zone)
 	cacheKey := "machineTypes:" + c.ProjectID() + ":" + zone
-	if v, ok := cacheGet(cacheKey); ok {
-		return v.([]MachineType), nil
+	v, err := cacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/zones/%s/machineTypes", c.ProjectID(), zone)
+		var all []MachineType
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp machineTypesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse machineTypes response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, machineTypeFromAPI(it))
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
+			}
+		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return v.([]MachineType), nil
+}
 
-	path := fmt.Sprintf("projects/%s/zones/%s/machineTypes", c.ProjectID(), zone)
-	var all []MachineType
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withPageToken(path, pageToken))
-		if err != nil {
-			return nil, err
-		}
-		var resp machineTypesListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse machineTypes response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
+func ListAcceleratorTypes(ctx context.Context, c Client, zone string) ([]string, error) {
+	zone = strings.TrimSpace(zone)
+	cacheKey := "acceleratorTypes:" + c.ProjectID() + ":" + zone
+	v, err := cacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/zones/%s/acceleratorTypes", c.ProjectID(), zone)
+		var all []string
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp acceleratorTypesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse acceleratorTypes response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil || it.Name == "" {
+					continue
+				}
+				all = append(all, it.Name)
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
 			}
-			all = append(all, machineTypeFromAPI(it))
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
 		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	cacheSet(cacheKey, all)
-	return all, nil
+	return v.([]string), nil
 }
 
 func GetMachineType(ctx context.Context, c Client, zone, machineType string) (*MachineType, error) {
@@ -467,15 +495,24 @@ func ListMachineTypeResources(ctx context.Context, c Client, zone, machineFamily
 		if machineFamily != "" && mt.Family != machineFamily {
 			continue
 		}
-		summary := FormatMachineTypeSummary(&mt)
-		name := mt.Name
-		if summary != "" {
-			name = fmt.Sprintf("%s (%s)", mt.Name, summary)
+		metadata := map[string]any{
+			"guestCpus": mt.GuestCPUs,
+			"memoryMb":  mt.MemoryMB,
+			"sharedCpu": mt.SharedCPU,
 		}
+		description := FormatMachineTypeSummary(&mt)
 		if monthly := monthlyEstimateFromMachineType(&mt, zone, string(ProvisioningStandard)); monthly > 0 {
-			name += formatMonthlyEstimate(monthly)
+			description += formatMonthlyEstimate(monthly)
+			metadata["monthlyEstimateUsd"] = monthly
 		}
-		out = append(out, core.IntegrationResource{Type: ResourceTypeMachineType, Name: name, ID: mt.Name})
+		out = append(out, core.IntegrationResource{
+			Type:        ResourceTypeMachineType,
+			Name:        mt.Name,
+			ID:          mt.Name,
+			Description: description,
+			Group:       mt.Family,
+			Metadata:    metadata,
+		})
 	}
 	return out, nil
 }
@@ -611,35 +648,37 @@ func ListPublicImages(ctx context.Context, c Client, project string) ([]Image, e
 		return nil, nil
 	}
 	cacheKey := "publicImages:" + project
-	if v, ok := osStorageCacheGet(cacheKey); ok {
-		return v.([]Image), nil
-	}
-	path := fmt.Sprintf("projects/%s/global/images", project)
-	var all []Image
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withMaxResults(path, maxPublicImagesPerPage, pageToken))
-		if err != nil {
-			return nil, fmt.Errorf("list public images for %s: %w", project, err)
-		}
-		var resp imagesListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse images response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
+	v, err := osStorageCacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/global/images", project)
+		var all []Image
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withMaxResults(path, maxPublicImagesPerPage, pageToken))
+			if err != nil {
+				return nil, fmt.Errorf("list public images for %s: %w", project, err)
+			}
+			var resp imagesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse images response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, imageItemToImage(it))
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
 			}
-			all = append(all, imageItemToImage(it))
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
 		}
+		sortPublicImagesForProject(all)
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	sortPublicImagesForProject(all)
-	osStorageCacheSet(cacheKey, all)
-	return all, nil
+	return v.([]Image), nil
 }
 
 func GetImageFromFamily(ctx context.Context, c Client, project, family string) (*Image, error) {
@@ -670,34 +709,122 @@ func ListCustomImages(ctx context.Context, c Client, project string) ([]Image, e
 		project = c.ProjectID()
 	}
 	cacheKey := "customImages:" + project
-	if v, ok := osStorageCacheGet(cacheKey); ok {
-		return v.([]Image), nil
+	v, err := osStorageCacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/global/images", project)
+		var all []Image
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp imagesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse custom images response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, imageItemToImage(it))
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
+			}
+		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]Image), nil
+}
+
+/*
+ * ListCustomImagePage lists a single page of custom images, filtered through
+ * the Compute API, so projects with many images don't need to be listed in full.
+ */
+func ListCustomImagePage(ctx context.Context, c Client, project, query, pageToken string, pageSize int) ([]Image, string, error) {
+	project = strings.TrimSpace(project)
+	if project == "" {
+		project = c.ProjectID()
+	}
+
+	params := url.Values{}
+	if filter := imageNameFilter(query); filter != "" {
+		params.Set("filter", filter)
 	}
+	if pageSize > 0 {
+		params.Set("maxResults", strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		params.Set("pageToken", pageToken)
+	}
+
 	path := fmt.Sprintf("projects/%s/global/images", project)
-	var all []Image
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withPageToken(path, pageToken))
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var resp imagesListResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, "", fmt.Errorf("parse custom images response: %w", err)
+	}
+
+	images := make([]Image, 0, len(resp.Items))
+	for _, it := range resp.Items {
+		if it == nil {
+			continue
+		}
+		images = append(images, imageItemToImage(it))
+	}
+
+	return images, resp.NextPageToken, nil
+}
+
+/*
+ * imageNameFilter matches image names containing the query, ignoring case.
+ * The Compute API filter uses RE2 regular expressions.
+ */
+func imageNameFilter(query string) string {
+	query = strings.ReplaceAll(strings.TrimSpace(query), `"`, "")
+	if query == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`name eq "(?i).*%s.*"`, regexp.QuoteMeta(query))
+}
+
+func ListCustomImageResourcePage(ctx context.Context, c Client, project string, listCtx core.ListResourcesContext) (*core.ResourcePage, error) {
+	//
+	// Without a query or page, the full cached list is returned.
+	//
+	if listCtx.Query == "" && listCtx.PageToken == "" && listCtx.PageSize <= 0 {
+		resources, err := ListCustomImageResources(ctx, c, project)
 		if err != nil {
 			return nil, err
 		}
-		var resp imagesListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse custom images response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
-			}
-			all = append(all, imageItemToImage(it))
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
-		}
+
+		return &core.ResourcePage{Resources: resources}, nil
+	}
+
+	images, nextPageToken, err := ListCustomImagePage(ctx, c, project, listCtx.Query, listCtx.PageToken, listCtx.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(images))
+	for _, img := range images {
+		resources = append(resources, core.IntegrationResource{Type: ResourceTypeCustomImages, Name: img.Name, ID: imageSelfLinkOrName(img)})
 	}
-	osStorageCacheSet(cacheKey, all)
-	return all, nil
+
+	return &core.ResourcePage{Resources: resources, NextPageToken: nextPageToken}, nil
 }
 
 func ListPublicImageResources(ctx context.Context, c Client, project string) ([]core.IntegrationResource, error) {
@@ -782,74 +909,50 @@ type resourcePolicyItem struct {
 	Name string `json:"name"`
 }
 
-var (
-	osStorageCache   = make(map[string]*cacheEntry)
-	osStorageCacheMu sync.RWMutex
-)
-
 const osStorageCacheTTL = 24 * time.Hour
 
-func osStorageCacheGet(key string) (any, bool) {
-	osStorageCacheMu.RLock()
-	e, ok := osStorageCache[key]
-	if !ok || e == nil {
-		osStorageCacheMu.RUnlock()
-		return nil, false
-	}
-	if time.Now().After(e.expires) {
-		osStorageCacheMu.RUnlock()
-		// Lazy eviction: remove expired entry to avoid unbounded memory growth
-		osStorageCacheMu.Lock()
-		if e2, ok2 := osStorageCache[key]; ok2 && e2 != nil && time.Now().After(e2.expires) {
-			delete(osStorageCache, key)
-		}
-		osStorageCacheMu.Unlock()
-		return nil, false
-	}
-	data := e.data
-	osStorageCacheMu.RUnlock()
-	return data, true
-}
+var osStorageCache = newListCache(osStorageCacheTTL, "")
 
-func osStorageCacheSet(key string, data any) {
-	osStorageCacheMu.Lock()
-	defer osStorageCacheMu.Unlock()
-	osStorageCache[key] = &cacheEntry{data: data, expires: time.Now().Add(osStorageCacheTTL)}
+func osStorageCacheGet(ctx context.Context, key string, list func(ctx context.Context) (any, error)) (any, error) {
+	return osStorageCache.get(ctx, key, list)
 }
+
 func ListSnapshots(ctx context.Context, c Client, project string) ([]Snapshot, error) {
 	project = strings.TrimSpace(project)
 	if project == "" {
 		project = c.ProjectID()
 	}
 	cacheKey := "snapshots:" + project
-	if v, ok := osStorageCacheGet(cacheKey); ok {
-		return v.([]Snapshot), nil
-	}
-	path := fmt.Sprintf("projects/%s/global/snapshots", project)
-	var all []Snapshot
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withPageToken(path, pageToken))
-		if err != nil {
-			return nil, err
-		}
-		var resp snapshotsListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse snapshots response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
+	v, err := osStorageCacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/global/snapshots", project)
+		var all []Snapshot
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp snapshotsListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse snapshots response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, Snapshot{Name: it.Name})
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
 			}
-			all = append(all, Snapshot{Name: it.Name})
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
 		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	osStorageCacheSet(cacheKey, all)
-	return all, nil
+	return v.([]Snapshot), nil
 }
 
 func ListDisks(ctx context.Context, c Client, project, zone string) ([]Disk, error) {
@@ -862,34 +965,36 @@ func ListDisks(ctx context.Context, c Client, project, zone string) ([]Disk, err
 		return nil, fmt.Errorf("zone is required")
 	}
 	cacheKey := "disks:" + project + ":" + zone
-	if v, ok := osStorageCacheGet(cacheKey); ok {
-		return v.([]Disk), nil
-	}
-	path := fmt.Sprintf("projects/%s/zones/%s/disks", project, zone)
-	var all []Disk
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withPageToken(path, pageToken))
-		if err != nil {
-			return nil, err
-		}
-		var resp disksListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse disks response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
+	v, err := osStorageCacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/zones/%s/disks", project, zone)
+		var all []Disk
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp disksListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse disks response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, Disk{Name: it.Name})
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
 			}
-			all = append(all, Disk{Name: it.Name})
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
 		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	osStorageCacheSet(cacheKey, all)
-	return all, nil
+	return v.([]Disk), nil
 }
 
 func ListDiskTypes(ctx context.Context, c Client, project, zone string) ([]DiskType, error) {
@@ -902,34 +1007,36 @@ func ListDiskTypes(ctx context.Context, c Client, project, zone string) ([]DiskT
 		return nil, fmt.Errorf("zone is required")
 	}
 	cacheKey := "diskTypes:" + project + ":" + zone
-	if v, ok := osStorageCacheGet(cacheKey); ok {
-		return v.([]DiskType), nil
-	}
-	path := fmt.Sprintf("projects/%s/zones/%s/diskTypes", project, zone)
-	var all []DiskType
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withPageToken(path, pageToken))
-		if err != nil {
-			return nil, err
-		}
-		var resp diskTypesListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse diskTypes response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
+	v, err := osStorageCacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/zones/%s/diskTypes", project, zone)
+		var all []DiskType
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp diskTypesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse diskTypes response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, DiskType{Name: it.Name, Description: it.Description})
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
 			}
-			all = append(all, DiskType{Name: it.Name, Description: it.Description})
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
 		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	osStorageCacheSet(cacheKey, all)
-	return all, nil
+	return v.([]DiskType), nil
 }
 
 func ListSnapshotSchedules(ctx context.Context, c Client, project, region string) ([]ResourcePolicy, error) {
@@ -942,34 +1049,36 @@ func ListSnapshotSchedules(ctx context.Context, c Client, project, region string
 		return nil, fmt.Errorf("region is required")
 	}
 	cacheKey := "resourcePolicies:" + project + ":" + region
-	if v, ok := osStorageCacheGet(cacheKey); ok {
-		return v.([]ResourcePolicy), nil
-	}
-	path := fmt.Sprintf("projects/%s/regions/%s/resourcePolicies", project, region)
-	var all []ResourcePolicy
-	var pageToken string
-	for {
-		body, err := c.Get(ctx, withPageToken(path, pageToken))
-		if err != nil {
-			return nil, err
-		}
-		var resp resourcePoliciesListResp
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("parse resourcePolicies response: %w", err)
-		}
-		for _, it := range resp.Items {
-			if it == nil {
-				continue
+	v, err := osStorageCacheGet(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("projects/%s/regions/%s/resourcePolicies", project, region)
+		var all []ResourcePolicy
+		var pageToken string
+		for {
+			body, err := c.Get(ctx, withPageToken(path, pageToken))
+			if err != nil {
+				return nil, err
+			}
+			var resp resourcePoliciesListResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("parse resourcePolicies response: %w", err)
+			}
+			for _, it := range resp.Items {
+				if it == nil {
+					continue
+				}
+				all = append(all, ResourcePolicy{Name: it.Name})
+			}
+			pageToken = resp.NextPageToken
+			if pageToken == "" {
+				break
 			}
-			all = append(all, ResourcePolicy{Name: it.Name})
-		}
-		pageToken = resp.NextPageToken
-		if pageToken == "" {
-			break
 		}
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	osStorageCacheSet(cacheKey, all)
-	return all, nil
+	return v.([]ResourcePolicy), nil
 }
 
 var allowedBootDiskTypes = []string{"pd-balanced", "pd-ssd", "pd-standard"}
@@ -1048,7 +1157,10 @@ const (
 	ResourceTypeAddress    = "address"
 	ResourceTypeFirewall   = "firewall"
 )
-const AddressTypeExternal = "EXTERNAL"
+const (
+	AddressTypeExternal = "EXTERNAL"
+	AddressTypeInternal = "INTERNAL"
+)
 
 type Network struct {
 	Name     string `json:"name"`
@@ -1071,9 +1183,21 @@ type Address struct {
 }
 
 type Firewall struct {
-	Name     string `json:"name"`
-	SelfLink string `json:"selfLink"`
-	Network  string `json:"network,omitempty"`
+	Name                  string            `json:"name"`
+	SelfLink              string            `json:"selfLink"`
+	Network               string            `json:"network,omitempty"`
+	Direction             string            `json:"direction,omitempty"`
+	Disabled              bool              `json:"disabled,omitempty"`
+	Allowed               []FirewallAllowed `json:"allowed,omitempty"`
+	SourceRanges          []string          `json:"sourceRanges,omitempty"`
+	SourceTags            []string          `json:"sourceTags,omitempty"`
+	TargetTags            []string          `json:"targetTags,omitempty"`
+	TargetServiceAccounts []string          `json:"targetServiceAccounts,omitempty"`
+}
+
+type FirewallAllowed struct {
+	IPProtocol string   `json:"IPProtocol"`
+	Ports      []string `json:"ports,omitempty"`
 }
 
 type networksListResp struct {
@@ -1117,10 +1241,16 @@ type firewallsListResp struct {
 }
 
 type firewallItem struct {
-	Name       string   `json:"name"`
-	SelfLink   string   `json:"selfLink"`
-	Network    string   `json:"network"`
-	TargetTags []string `json:"targetTags"`
+	Name                  string            `json:"name"`
+	SelfLink              string            `json:"selfLink"`
+	Network               string            `json:"network"`
+	Direction             string            `json:"direction"`
+	Disabled              bool              `json:"disabled"`
+	Allowed               []FirewallAllowed `json:"allowed"`
+	SourceRanges          []string          `json:"sourceRanges"`
+	SourceTags            []string          `json:"sourceTags"`
+	TargetTags            []string          `json:"targetTags"`
+	TargetServiceAccounts []string          `json:"targetServiceAccounts"`
 }
 
 func ensureProject(project string, c Client) string {
@@ -1229,20 +1359,38 @@ func ListAddresses(ctx context.Context, c Client, project, region string) ([]Add
 func ListFirewalls(ctx context.Context, c Client, project string) ([]Firewall, error) {
 	project = ensureProject(project, c)
 	path := fmt.Sprintf("projects/%s/global/firewalls", project)
-	body, err := c.Get(ctx, path)
-	if err != nil {
-		return nil, err
-	}
-	var resp firewallsListResp
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parse firewalls list: %w", err)
-	}
-	out := make([]Firewall, 0, len(resp.Items))
-	for _, f := range resp.Items {
-		if f == nil {
-			continue
+	out := []Firewall{}
+	var pageToken string
+	for {
+		body, err := c.Get(ctx, withPageToken(path, pageToken))
+		if err != nil {
+			return nil, err
+		}
+		var resp firewallsListResp
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("parse firewalls list: %w", err)
+		}
+		for _, f := range resp.Items {
+			if f == nil {
+				continue
+			}
+			out = append(out, Firewall{
+				Name:                  f.Name,
+				SelfLink:              f.SelfLink,
+				Network:               f.Network,
+				Direction:             f.Direction,
+				Disabled:              f.Disabled,
+				Allowed:               f.Allowed,
+				SourceRanges:          f.SourceRanges,
+				SourceTags:            f.SourceTags,
+				TargetTags:            f.TargetTags,
+				TargetServiceAccounts: f.TargetServiceAccounts,
+			})
+		}
+		pageToken = resp.NextPageToken
+		if pageToken == "" {
+			break
 		}
-		out = append(out, Firewall{Name: f.Name, SelfLink: f.SelfLink, Network: f.Network})
 	}
 	return out, nil
 }
@@ -1278,17 +1426,22 @@ func ListSubnetworkResources(ctx context.Context, c Client, project, region stri
 	return out, nil
 }
 
-func ListAddressResources(ctx context.Context, c Client, project, region string) ([]core.IntegrationResource, error) {
+// ListAddressResources lists the reserved addresses of a region with the given type, EXTERNAL by default.
+func ListAddressResources(ctx context.Context, c Client, project, region, addressType string) ([]core.IntegrationResource, error) {
 	if strings.TrimSpace(region) == "" {
 		return []core.IntegrationResource{}, nil
 	}
+	addressType = strings.ToUpper(strings.TrimSpace(addressType))
+	if addressType == "" {
+		addressType = AddressTypeExternal
+	}
 	list, err := ListAddresses(ctx, c, project, region)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]core.IntegrationResource, 0, len(list))
 	for _, a := range list {
-		if a.AddressType != AddressTypeExternal {
+		if a.AddressType != addressType {
 			continue
 		}
 		label := a.Name
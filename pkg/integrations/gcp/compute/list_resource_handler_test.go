@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
 )
 
 func Test_lastSegment(t *testing.T) {
@@ -56,6 +59,8 @@ func Test_FormatMachineTypeSummary(t *testing.T) {
 type mockOSClient struct {
 	projectID string
 	get       func(ctx context.Context, path string) ([]byte, error)
+	post      func(ctx context.Context, path string, body any) ([]byte, error)
+	patch     func(ctx context.Context, path string, body any) ([]byte, error)
 }
 
 func (m *mockOSClient) Get(ctx context.Context, path string) ([]byte, error) {
@@ -66,6 +71,16 @@ func (m *mockOSClient) Get(ctx context.Context, path string) ([]byte, error) {
 }
 
 func (m *mockOSClient) Post(ctx context.Context, path string, body any) ([]byte, error) {
+	if m.post != nil {
+		return m.post(ctx, path, body)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockOSClient) Patch(ctx context.Context, path string, body any) ([]byte, error) {
+	if m.patch != nil {
+		return m.patch(ctx, path, body)
+	}
 	return nil, errors.New("not implemented")
 }
 
@@ -73,6 +88,10 @@ func (m *mockOSClient) GetURL(ctx context.Context, fullURL string) ([]byte, erro
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockOSClient) Delete(ctx context.Context, path string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockOSClient) ProjectID() string {
 	return m.projectID
 }
@@ -360,6 +379,51 @@ func Test_ListCustomImages(t *testing.T) {
 	})
 }
 
+func Test_ListMachineTypeResources(t *testing.T) {
+	ctx := context.Background()
+	resp := machineTypesListResp{
+		Items: []*machineTypeItem{
+			{Name: "e2-medium", GuestCpus: 2, MemoryMb: 4096},
+			{Name: "n2-standard-4", GuestCpus: 4, MemoryMb: 16384},
+		},
+	}
+	body, _ := json.Marshal(resp)
+	c := &mockOSClient{
+		projectID: "machine-type-resources-project",
+		get: func(_ context.Context, path string) ([]byte, error) {
+			return body, nil
+		},
+	}
+
+	t.Run("details are not encoded into the name", func(t *testing.T) {
+		resources, err := ListMachineTypeResources(ctx, c, "us-central1-a", "")
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, ResourceTypeMachineType, resources[0].Type)
+		assert.Equal(t, "e2-medium", resources[0].Name)
+		assert.Equal(t, "e2-medium", resources[0].ID)
+		assert.Equal(t, "E2", resources[0].Group)
+		assert.True(t, strings.HasPrefix(resources[0].Description, "2 vCPU, 4 GB memory — ~US$"))
+		assert.Equal(t, 2, resources[0].Metadata["guestCpus"])
+		assert.Equal(t, 4096, resources[0].Metadata["memoryMb"])
+		assert.Greater(t, resources[0].Metadata["monthlyEstimateUsd"], 0.0)
+	})
+
+	t.Run("filters by machine family", func(t *testing.T) {
+		resources, err := ListMachineTypeResources(ctx, c, "us-central1-a", "N2")
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "n2-standard-4", resources[0].Name)
+		assert.Equal(t, "N2", resources[0].Group)
+	})
+
+	t.Run("empty zone returns no resources", func(t *testing.T) {
+		resources, err := ListMachineTypeResources(ctx, c, " ", "")
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+	})
+}
+
 func Test_ListPublicImageResources(t *testing.T) {
 	ctx := context.Background()
 
@@ -451,6 +515,102 @@ func Test_ListCustomImageResources(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+func Test_ListCustomImageResourcePage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("query and page are sent to the Compute API", func(t *testing.T) {
+		resp := imagesListResp{
+			Items:         []*imageItem{{Name: "web-image", SelfLink: "https://.../web-image"}},
+			NextPageToken: "next-token",
+		}
+		body, _ := json.Marshal(resp)
+
+		var requestedPath string
+		c := &mockOSClient{
+			projectID: "my-project",
+			get: func(_ context.Context, path string) ([]byte, error) {
+				requestedPath = path
+				return body, nil
+			},
+		}
+
+		page, err := ListCustomImageResourcePage(ctx, c, "", core.ListResourcesContext{
+			Query:     "web.app",
+			PageToken: "token-1",
+			PageSize:  10,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, page.Resources, 1)
+		assert.Equal(t, "web-image", page.Resources[0].Name)
+		assert.Equal(t, "https://.../web-image", page.Resources[0].ID)
+		assert.Equal(t, "next-token", page.NextPageToken)
+
+		path, rawQuery, found := strings.Cut(requestedPath, "?")
+		require.True(t, found)
+		assert.Equal(t, "projects/my-project/global/images", path)
+		values, err := url.ParseQuery(rawQuery)
+		require.NoError(t, err)
+		assert.Equal(t, `name eq "(?i).*web\.app.*"`, values.Get("filter"))
+		assert.Equal(t, "10", values.Get("maxResults"))
+		assert.Equal(t, "token-1", values.Get("pageToken"))
+	})
+
+	t.Run("no query or page -> full list", func(t *testing.T) {
+		body, _ := json.Marshal(imagesListResp{Items: []*imageItem{{Name: "img-1"}, {Name: "img-2"}}})
+		c := &mockOSClient{
+			projectID: "my-project",
+			get: func(_ context.Context, path string) ([]byte, error) {
+				assert.NotContains(t, path, "filter")
+				return body, nil
+			},
+		}
+
+		page, err := ListCustomImageResourcePage(ctx, c, "full-list-project", core.ListResourcesContext{})
+		require.NoError(t, err)
+		assert.Len(t, page.Resources, 2)
+		assert.Empty(t, page.NextPageToken)
+	})
+}
+
+func Test_ListAddressResources(t *testing.T) {
+	ctx := context.Background()
+	c := &mockOSClient{
+		projectID: "p",
+		get: func(_ context.Context, path string) ([]byte, error) {
+			assert.Equal(t, "projects/p/regions/us-central1/addresses", path)
+			return []byte(`{"items": [
+				{"name": "web-ip", "address": "34.1.2.3", "addressType": "EXTERNAL"},
+				{"name": "db-ip", "address": "10.0.0.7", "addressType": "INTERNAL"},
+				{"name": "cache-ip", "address": "10.0.0.8", "addressType": "INTERNAL"}
+			]}`), nil
+		},
+	}
+
+	t.Run("external addresses by default", func(t *testing.T) {
+		resources, err := ListAddressResources(ctx, c, "", "us-central1", "")
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "web-ip (34.1.2.3)", resources[0].Name)
+		assert.Equal(t, "34.1.2.3", resources[0].ID)
+	})
+
+	t.Run("internal addresses", func(t *testing.T) {
+		resources, err := ListAddressResources(ctx, c, "", "us-central1", AddressTypeInternal)
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "db-ip (10.0.0.7)", resources[0].Name)
+		assert.Equal(t, "10.0.0.7", resources[0].ID)
+		assert.Equal(t, "cache-ip (10.0.0.8)", resources[1].Name)
+	})
+
+	t.Run("empty region returns no resources", func(t *testing.T) {
+		resources, err := ListAddressResources(ctx, c, "", "", AddressTypeInternal)
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+	})
+}
+
 func Test_isAllowedBootDiskType(t *testing.T) {
 	assert.True(t, isAllowedBootDiskType("pd-balanced"))
 	assert.True(t, isAllowedBootDiskType("pd-ssd"))
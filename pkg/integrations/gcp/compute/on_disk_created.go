@@ -0,0 +1,90 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnDiskCreated struct{}
+
+func (t *OnDiskCreated) Name() string {
+	return "gcp.compute.onDiskCreated"
+}
+
+func (t *OnDiskCreated) Label() string {
+	return "Compute • On Disk Created"
+}
+
+func (t *OnDiskCreated) Description() string {
+	return "Listen to GCP Compute Engine disks being created"
+}
+
+func (t *OnDiskCreated) Documentation() string {
+	return `The On Disk Created trigger starts a workflow execution when a zonal or regional Compute Engine persistent disk is created.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures the Compute Engine audit log entries written when the operation finishes, and routes them to a shared Pub/Sub topic. Events are pushed to SuperPlane and matched to this trigger automatically.
+
+## Use Cases
+
+- **Compliance**: Check the encryption, labels or size of new disks
+- **Backup setup**: Attach a snapshot schedule to new disks
+- **Inventory**: Record new disks
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions.
+
+## Event Data
+
+Each event includes the audit log entry with resourceName, serviceName, methodName and the full log entry data, whether the operation succeeded, with its error if it failed, and the disk project, zone or region, and name under resource.`
+}
+
+func (t *OnDiskCreated) Icon() string {
+	return "gcp"
+}
+
+func (t *OnDiskCreated) Color() string {
+	return "gray"
+}
+
+func (t *OnDiskCreated) Configuration() []configuration.Field {
+	return []configuration.Field{
+		core.DeduplicationWindowConfigurationField(),
+	}
+}
+
+func (t *OnDiskCreated) ExampleData() map[string]any {
+	return diskCreated.exampleData()
+}
+
+func (t *OnDiskCreated) Setup(ctx core.TriggerContext) error {
+	return setupAuditLogSink(ctx, diskCreated.subscriptionPattern())
+}
+
+func (t *OnDiskCreated) Actions() []core.Action {
+	return []core.Action{
+		{Name: "provisionSink"},
+	}
+}
+
+func (t *OnDiskCreated) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return nil, provisionAuditLogSink(ctx, diskCreated.sinkFilter())
+}
+
+func (t *OnDiskCreated) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	return diskCreated.emit(ctx)
+}
+
+func (t *OnDiskCreated) Cleanup(ctx core.TriggerContext) error {
+	return cleanupAuditLogSink(ctx)
+}
+
+func (t *OnDiskCreated) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
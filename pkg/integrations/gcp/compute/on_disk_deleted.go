@@ -0,0 +1,90 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnDiskDeleted struct{}
+
+func (t *OnDiskDeleted) Name() string {
+	return "gcp.compute.onDiskDeleted"
+}
+
+func (t *OnDiskDeleted) Label() string {
+	return "Compute • On Disk Deleted"
+}
+
+func (t *OnDiskDeleted) Description() string {
+	return "Listen to GCP Compute Engine disks being deleted"
+}
+
+func (t *OnDiskDeleted) Documentation() string {
+	return `The On Disk Deleted trigger starts a workflow execution when a zonal or regional Compute Engine persistent disk is deleted.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures the Compute Engine audit log entries written when the operation finishes, and routes them to a shared Pub/Sub topic. Events are pushed to SuperPlane and matched to this trigger automatically.
+
+## Use Cases
+
+- **Cleanup**: Delete the snapshots or snapshot schedules of deleted disks
+- **Inventory and compliance**: Record deleted disks
+- **Notifications**: Alert teams when disks are deleted
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions.
+
+## Event Data
+
+Each event includes the audit log entry with resourceName, serviceName, methodName and the full log entry data, whether the operation succeeded, with its error if it failed, and the disk project, zone or region, and name under resource.`
+}
+
+func (t *OnDiskDeleted) Icon() string {
+	return "gcp"
+}
+
+func (t *OnDiskDeleted) Color() string {
+	return "gray"
+}
+
+func (t *OnDiskDeleted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		core.DeduplicationWindowConfigurationField(),
+	}
+}
+
+func (t *OnDiskDeleted) ExampleData() map[string]any {
+	return diskDeleted.exampleData()
+}
+
+func (t *OnDiskDeleted) Setup(ctx core.TriggerContext) error {
+	return setupAuditLogSink(ctx, diskDeleted.subscriptionPattern())
+}
+
+func (t *OnDiskDeleted) Actions() []core.Action {
+	return []core.Action{
+		{Name: "provisionSink"},
+	}
+}
+
+func (t *OnDiskDeleted) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return nil, provisionAuditLogSink(ctx, diskDeleted.sinkFilter())
+}
+
+func (t *OnDiskDeleted) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	return diskDeleted.emit(ctx)
+}
+
+func (t *OnDiskDeleted) Cleanup(ctx core.TriggerContext) error {
+	return cleanupAuditLogSink(ctx)
+}
+
+func (t *OnDiskDeleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
@@ -0,0 +1,90 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnSnapshotCompleted struct{}
+
+func (t *OnSnapshotCompleted) Name() string {
+	return "gcp.compute.onSnapshotCompleted"
+}
+
+func (t *OnSnapshotCompleted) Label() string {
+	return "Compute • On Snapshot Completed"
+}
+
+func (t *OnSnapshotCompleted) Description() string {
+	return "Listen to GCP Compute Engine snapshots being completed"
+}
+
+func (t *OnSnapshotCompleted) Documentation() string {
+	return `The On Snapshot Completed trigger starts a workflow execution when a Compute Engine disk snapshot finishes, whether it was created with ` + "`snapshots.insert`" + ` or ` + "`disks.createSnapshot`" + `.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures the Compute Engine audit log entries written when the operation finishes, and routes them to a shared Pub/Sub topic. Events are pushed to SuperPlane and matched to this trigger automatically.
+
+## Use Cases
+
+- **Backup verification**: Check that scheduled or manual snapshots complete, and alert when they fail
+- **Retention**: Delete older snapshots once a new one completes
+- **Replication**: Copy completed snapshots to another project or region
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions.
+
+## Event Data
+
+Each event includes the audit log entry with resourceName, serviceName, methodName and the full log entry data, whether the operation succeeded, with its error if it failed, and the snapshot project, name and source disk under resource.`
+}
+
+func (t *OnSnapshotCompleted) Icon() string {
+	return "gcp"
+}
+
+func (t *OnSnapshotCompleted) Color() string {
+	return "gray"
+}
+
+func (t *OnSnapshotCompleted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		core.DeduplicationWindowConfigurationField(),
+	}
+}
+
+func (t *OnSnapshotCompleted) ExampleData() map[string]any {
+	return snapshotCompleted.exampleData()
+}
+
+func (t *OnSnapshotCompleted) Setup(ctx core.TriggerContext) error {
+	return setupAuditLogSink(ctx, snapshotCompleted.subscriptionPattern())
+}
+
+func (t *OnSnapshotCompleted) Actions() []core.Action {
+	return []core.Action{
+		{Name: "provisionSink"},
+	}
+}
+
+func (t *OnSnapshotCompleted) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return nil, provisionAuditLogSink(ctx, snapshotCompleted.sinkFilter())
+}
+
+func (t *OnSnapshotCompleted) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	return snapshotCompleted.emit(ctx)
+}
+
+func (t *OnSnapshotCompleted) Cleanup(ctx core.TriggerContext) error {
+	return cleanupAuditLogSink(ctx)
+}
+
+func (t *OnSnapshotCompleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
@@ -0,0 +1,138 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnSpotPreemption struct{}
+
+type OnSpotPreemptionConfiguration struct {
+	OnlyManagedBySuperPlane bool `mapstructure:"onlyManagedBySuperPlane"`
+}
+
+func (t *OnSpotPreemption) Name() string {
+	return "gcp.compute.onSpotPreemption"
+}
+
+func (t *OnSpotPreemption) Label() string {
+	return "Compute • On Spot Preemption"
+}
+
+func (t *OnSpotPreemption) Description() string {
+	return "Listen to GCP Compute Engine Spot VMs being preempted"
+}
+
+func (t *OnSpotPreemption) Documentation() string {
+	return `The On Spot Preemption trigger starts a workflow execution when Compute Engine preempts a Spot or preemptible VM instance.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures the Compute Engine system event audit log entries for ` + "`compute.instances.preempted`" + `, and routes them to a shared Pub/Sub topic. Events are pushed to SuperPlane and matched to this trigger automatically.
+
+## Use Cases
+
+- **Recover capacity**: Create a new VM with the machine type and zone of the preempted one, or fall back to a standard VM
+- **Batch jobs**: Re-queue the work that was running on the preempted instance
+- **Notifications**: Alert teams when Spot capacity is reclaimed
+
+## Configuration
+
+- **Only VMs created by SuperPlane**: Only fire for instances with the ` + "`managed-by: superplane`" + ` label, set by Create Virtual Machine. Enabled by default. Preemptions of instances that cannot be fetched are skipped then.
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions. Fetching the instance details requires ` + "`compute.instances.get`" + `, e.g. with ` + "`roles/compute.viewer`" + `.
+
+## Event Data
+
+Each event includes the audit log entry with resourceName (e.g. projects/my-project/zones/us-central1-a/instances/my-vm), serviceName, methodName and the full log entry data, and the instance details under instance. Preempted instances are stopped, not deleted, so the details include their status, machine type, labels, network interfaces and disks.`
+}
+
+func (t *OnSpotPreemption) Icon() string {
+	return "gcp"
+}
+
+func (t *OnSpotPreemption) Color() string {
+	return "gray"
+}
+
+func (t *OnSpotPreemption) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "onlyManagedBySuperPlane",
+			Label:       "Only VMs created by SuperPlane",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Description: "Only fire for instances with the managed-by: superplane label, set by Create Virtual Machine.",
+			Default:     true,
+		},
+		core.DeduplicationWindowConfigurationField(),
+	}
+}
+
+func (t *OnSpotPreemption) ExampleData() map[string]any {
+	example := vmInstancePreempted.exampleData()
+	example["logName"] = "projects/my-project/logs/cloudaudit.googleapis.com%2Fsystem_event"
+
+	instance := example["instance"].(map[string]any)
+	instance["status"] = "TERMINATED"
+	instance["labels"] = map[string]any{ManagedByLabelKey: ManagedByLabelValue}
+	return example
+}
+
+func (t *OnSpotPreemption) Setup(ctx core.TriggerContext) error {
+	return setupAuditLogSink(ctx, vmInstancePreempted.subscriptionPattern())
+}
+
+func (t *OnSpotPreemption) Actions() []core.Action {
+	return []core.Action{
+		{Name: "provisionSink"},
+	}
+}
+
+func (t *OnSpotPreemption) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return nil, provisionAuditLogSink(ctx, vmInstancePreempted.sinkFilter())
+}
+
+func (t *OnSpotPreemption) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	config := OnSpotPreemptionConfiguration{OnlyManagedBySuperPlane: true}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	event, ok, err := decodeComputeAuditLogEvent(ctx.Message, vmInstancePreempted.methodNames())
+	if err != nil || !ok {
+		return err
+	}
+
+	instance := instanceDetails(ctx, event.ResourceName)
+	if config.OnlyManagedBySuperPlane && !isManagedBySuperPlane(instance) {
+		ctx.Logger.Infof("skipping preemption of %s: instance was not created by SuperPlane", event.ResourceName)
+		return nil
+	}
+
+	payload := event.payload()
+	payload["instance"] = instance
+
+	_, err = core.EmitDeduplicated(ctx.Events, event.InsertID, vmInstancePreempted.EventType, payload)
+	return err
+}
+
+func isManagedBySuperPlane(instance map[string]any) bool {
+	labels, ok := instance["labels"].(map[string]string)
+	return ok && labels[ManagedByLabelKey] == ManagedByLabelValue
+}
+
+func (t *OnSpotPreemption) Cleanup(ctx core.TriggerContext) error {
+	return cleanupAuditLogSink(ctx)
+}
+
+func (t *OnSpotPreemption) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
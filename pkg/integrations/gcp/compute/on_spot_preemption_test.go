@@ -0,0 +1,80 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test_OnSpotPreemption(t *testing.T) {
+	trigger := &OnSpotPreemption{}
+	message := map[string]any{
+		"serviceName":  computeServiceName,
+		"methodName":   "compute.instances.preempted",
+		"resourceName": "projects/my-project/zones/us-central1-a/instances/spot-vm",
+		"insertId":     "abc123",
+	}
+
+	emit := func(configuration map[string]any, message map[string]any) *contexts.EventContext {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Message:       message,
+			Configuration: configuration,
+			Logger:        logrus.NewEntry(logrus.New()),
+			Events:        events,
+			Integration:   &contexts.IntegrationContext{},
+		})
+
+		require.NoError(t, err)
+		return events
+	}
+
+	managedInstance := []byte(`{"id": "42", "name": "spot-vm", "status": "TERMINATED", "machineType": "zones/us-central1-a/machineTypes/n2-standard-4", "labels": {"managed-by": "superplane"}}`)
+	otherInstance := []byte(`{"id": "43", "name": "spot-vm", "status": "TERMINATED", "machineType": "zones/us-central1-a/machineTypes/n2-standard-4"}`)
+
+	t.Run("sink filter matches preemption system events", func(t *testing.T) {
+		assert.Equal(t,
+			`protoPayload.serviceName="compute.googleapis.com" AND (protoPayload.methodName="v1.compute.instances.preempted" OR protoPayload.methodName="beta.compute.instances.preempted" OR protoPayload.methodName="compute.instances.preempted")`,
+			vmInstancePreempted.sinkFilter(),
+		)
+	})
+
+	t.Run("preempted instance created by SuperPlane -> event is emitted", func(t *testing.T) {
+		useInstanceClient(t, &fakeInstanceClient{body: managedInstance})
+
+		events := emit(map[string]any{}, message)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gcp.compute.vmInstance.preempted", events.Payloads[0].Type)
+
+		instance := events.Payloads[0].Data.(map[string]any)["instance"].(map[string]any)
+		assert.Equal(t, "spot-vm", instance["name"])
+		assert.Equal(t, "us-central1-a", instance["zone"])
+		assert.Equal(t, "n2-standard-4", instance["machineType"])
+		assert.Equal(t, "TERMINATED", instance["status"])
+	})
+
+	t.Run("instance not created by SuperPlane -> no event", func(t *testing.T) {
+		useInstanceClient(t, &fakeInstanceClient{body: otherInstance})
+		assert.Equal(t, 0, emit(map[string]any{}, message).Count())
+	})
+
+	t.Run("every instance -> event is emitted", func(t *testing.T) {
+		useInstanceClient(t, &fakeInstanceClient{body: otherInstance})
+		assert.Equal(t, 1, emit(map[string]any{"onlyManagedBySuperPlane": false}, message).Count())
+	})
+
+	t.Run("other methods -> no event", func(t *testing.T) {
+		useInstanceClient(t, &fakeInstanceClient{body: managedInstance})
+		events := emit(map[string]any{}, map[string]any{
+			"serviceName":  computeServiceName,
+			"methodName":   "v1.compute.instances.stop",
+			"resourceName": "projects/my-project/zones/us-central1-a/instances/spot-vm",
+		})
+
+		assert.Equal(t, 0, events.Count())
+	})
+}
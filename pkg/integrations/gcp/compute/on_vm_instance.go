@@ -1,17 +1,13 @@
 package compute
 
 import (
-	"context"
 	"fmt"
 	"slices"
 	"strings"
-	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
-	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
-	gcppubsub "github.com/superplanehq/superplane/pkg/integrations/gcp/pubsub"
 )
 
 const (
@@ -87,7 +83,9 @@ func (t *OnVMInstance) Color() string {
 }
 
 func (t *OnVMInstance) Configuration() []configuration.Field {
-	return nil
+	return []configuration.Field{
+		core.DeduplicationWindowConfigurationField(),
+	}
 }
 
 func (t *OnVMInstance) ExampleData() map[string]any {
@@ -108,36 +106,7 @@ func (t *OnVMInstance) ExampleData() map[string]any {
 }
 
 func (t *OnVMInstance) Setup(ctx core.TriggerContext) error {
-	if ctx.Integration == nil {
-		return fmt.Errorf("connect the GCP integration to this trigger to enable automatic event routing")
-	}
-
-	var metadata OnVMInstanceMetadata
-	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
-		return fmt.Errorf("failed to decode metadata: %w", err)
-	}
-
-	if metadata.SubscriptionID != "" && metadata.SinkID != "" {
-		return nil
-	}
-
-	subscriptionID, err := ctx.Integration.Subscribe(subscriptionPattern())
-	if err != nil {
-		return fmt.Errorf("failed to subscribe: %w", err)
-	}
-
-	sinkID := "sp-sink-" + sanitizeSinkID(subscriptionID.String())
-
-	if err := ctx.Metadata.Set(OnVMInstanceMetadata{
-		SubscriptionID: subscriptionID.String(),
-		SinkID:         sinkID,
-	}); err != nil {
-		return fmt.Errorf("failed to set metadata: %w", err)
-	}
-
-	return ctx.Requests.ScheduleActionCall("provisionSink", map[string]any{
-		"sinkId": sinkID,
-	}, 2*time.Second)
+	return setupAuditLogSink(ctx, subscriptionPattern())
 }
 
 func (t *OnVMInstance) Actions() []core.Action {
@@ -151,49 +120,7 @@ func (t *OnVMInstance) HandleAction(ctx core.TriggerActionContext) (map[string]a
 		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
 	}
 
-	return t.provisionSink(ctx)
-}
-
-func (t *OnVMInstance) provisionSink(ctx core.TriggerActionContext) (map[string]any, error) {
-	meta, err := integrationMetadata(ctx.Integration)
-	if err != nil {
-		return nil, err
-	}
-
-	if meta.PubSubTopic == "" {
-		return nil, fmt.Errorf("integration Pub/Sub topic not configured; re-sync the GCP integration")
-	}
-
-	sinkID, _ := ctx.Parameters["sinkId"].(string)
-	if sinkID == "" {
-		return nil, fmt.Errorf("sinkId parameter is required")
-	}
-
-	client, err := gcpcommon.NewClient(ctx.HTTP, ctx.Integration)
-	if err != nil {
-		return nil, fmt.Errorf("create GCP client: %w", err)
-	}
-
-	projectID := client.ProjectID()
-	reqCtx := context.Background()
-
-	writerIdentity, err := gcppubsub.CreateSink(reqCtx, client, projectID, sinkID, meta.PubSubTopic, SinkFilter)
-	if err != nil {
-		if !gcpcommon.IsAlreadyExistsError(err) {
-			return nil, fmt.Errorf("create logging sink: %w", err)
-		}
-
-		writerIdentity, err = gcppubsub.GetSink(reqCtx, client, projectID, sinkID)
-		if err != nil {
-			return nil, fmt.Errorf("get existing logging sink: %w", err)
-		}
-	}
-
-	if err := gcppubsub.EnsureTopicPublisher(reqCtx, client, projectID, meta.PubSubTopic, writerIdentity); err != nil {
-		return nil, fmt.Errorf("grant sink publisher permission on topic: %w", err)
-	}
-
-	return nil, nil
+	return nil, provisionAuditLogSink(ctx, SinkFilter)
 }
 
 func (t *OnVMInstance) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
@@ -201,6 +128,7 @@ func (t *OnVMInstance) OnIntegrationMessage(ctx core.IntegrationMessageContext)
 		ServiceName  string `mapstructure:"serviceName"`
 		MethodName   string `mapstructure:"methodName"`
 		ResourceName string `mapstructure:"resourceName"`
+		InsertID     string `mapstructure:"insertId"`
 		Data         any    `mapstructure:"data"`
 	}
 	if err := mapstructure.Decode(ctx.Message, &event); err != nil {
@@ -216,32 +144,16 @@ func (t *OnVMInstance) OnIntegrationMessage(ctx core.IntegrationMessageContext)
 		return nil
 	}
 
-	return ctx.Events.Emit(EmittedEventType, ctx.Message)
+	//
+	// Audit log entries can be pushed more than once by Pub/Sub,
+	// but keep the same insert ID.
+	//
+	_, err := core.EmitDeduplicated(ctx.Events, event.InsertID, EmittedEventType, ctx.Message)
+	return err
 }
 
 func (t *OnVMInstance) Cleanup(ctx core.TriggerContext) error {
-	var metadata OnVMInstanceMetadata
-	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil || metadata.SinkID == "" {
-		return nil
-	}
-
-	if ctx.Integration == nil {
-		return nil
-	}
-
-	client, err := gcpcommon.NewClient(ctx.HTTP, ctx.Integration)
-	if err != nil {
-		ctx.Logger.Warnf("failed to create GCP client for sink cleanup: %v", err)
-		return nil
-	}
-
-	if err := gcppubsub.DeleteSink(context.Background(), client, client.ProjectID(), metadata.SinkID); err != nil {
-		if !gcpcommon.IsNotFoundError(err) {
-			ctx.Logger.Warnf("failed to delete logging sink %s: %v", metadata.SinkID, err)
-		}
-	}
-
-	return nil
+	return cleanupAuditLogSink(ctx)
 }
 
 func (t *OnVMInstance) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
@@ -254,28 +166,3 @@ func subscriptionPattern() map[string]any {
 		"methodName":  instancesInsertMethod,
 	}
 }
-
-func integrationMetadata(integration core.IntegrationContext) (*gcpcommon.Metadata, error) {
-	var m gcpcommon.Metadata
-	if err := mapstructure.Decode(integration.GetMetadata(), &m); err != nil {
-		return nil, fmt.Errorf("failed to read integration metadata: %w", err)
-	}
-	if m.ProjectID == "" {
-		return nil, fmt.Errorf("integration metadata does not contain a project ID; re-sync the GCP integration")
-	}
-	return &m, nil
-}
-
-func sanitizeSinkID(s string) string {
-	var b strings.Builder
-	for _, c := range strings.ToLower(s) {
-		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' {
-			b.WriteRune(c)
-		}
-	}
-	result := b.String()
-	if len(result) > 80 {
-		result = result[:80]
-	}
-	return result
-}
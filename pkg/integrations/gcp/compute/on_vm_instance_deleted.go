@@ -0,0 +1,90 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnVMInstanceDeleted struct{}
+
+func (t *OnVMInstanceDeleted) Name() string {
+	return "gcp.compute.onVMInstanceDeleted"
+}
+
+func (t *OnVMInstanceDeleted) Label() string {
+	return "Compute • On VM Instance Deleted"
+}
+
+func (t *OnVMInstanceDeleted) Description() string {
+	return "Listen to GCP Compute Engine VM instances being deleted"
+}
+
+func (t *OnVMInstanceDeleted) Documentation() string {
+	return `The On VM Instance Deleted trigger starts a workflow execution when a Compute Engine VM instance is deleted.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures the Compute Engine audit log entries for ` + "`v1.compute.instances.delete`" + `, and routes them to a shared Pub/Sub topic. Events are pushed to SuperPlane and matched to this trigger automatically.
+
+## Use Cases
+
+- **Cleanup**: Remove DNS records, firewall rules or monitoring for the deleted instance
+- **Inventory and compliance**: Record deleted VMs
+- **Notifications**: Alert teams when instances are deleted
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions. Fetching the instance details requires ` + "`compute.instances.get`" + `, e.g. with ` + "`roles/compute.viewer`" + `.
+
+## Event Data
+
+Each event includes the audit log entry with resourceName (e.g. projects/my-project/zones/us-central1-a/instances/my-vm), serviceName, methodName and the full log entry data, and the instance details under instance. The instance is fetched when the event is received, but it may already be gone: the project, zone and name are always included, and the status, machine type and IPs only while the instance still exists.`
+}
+
+func (t *OnVMInstanceDeleted) Icon() string {
+	return "gcp"
+}
+
+func (t *OnVMInstanceDeleted) Color() string {
+	return "gray"
+}
+
+func (t *OnVMInstanceDeleted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		core.DeduplicationWindowConfigurationField(),
+	}
+}
+
+func (t *OnVMInstanceDeleted) ExampleData() map[string]any {
+	return vmInstanceDeleted.exampleData()
+}
+
+func (t *OnVMInstanceDeleted) Setup(ctx core.TriggerContext) error {
+	return setupAuditLogSink(ctx, vmInstanceDeleted.subscriptionPattern())
+}
+
+func (t *OnVMInstanceDeleted) Actions() []core.Action {
+	return []core.Action{
+		{Name: "provisionSink"},
+	}
+}
+
+func (t *OnVMInstanceDeleted) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return nil, provisionAuditLogSink(ctx, vmInstanceDeleted.sinkFilter())
+}
+
+func (t *OnVMInstanceDeleted) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	return vmInstanceDeleted.emit(ctx)
+}
+
+func (t *OnVMInstanceDeleted) Cleanup(ctx core.TriggerContext) error {
+	return cleanupAuditLogSink(ctx)
+}
+
+func (t *OnVMInstanceDeleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
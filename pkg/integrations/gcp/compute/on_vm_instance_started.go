@@ -0,0 +1,90 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnVMInstanceStarted struct{}
+
+func (t *OnVMInstanceStarted) Name() string {
+	return "gcp.compute.onVMInstanceStarted"
+}
+
+func (t *OnVMInstanceStarted) Label() string {
+	return "Compute • On VM Instance Started"
+}
+
+func (t *OnVMInstanceStarted) Description() string {
+	return "Listen to GCP Compute Engine VM instances being started"
+}
+
+func (t *OnVMInstanceStarted) Documentation() string {
+	return `The On VM Instance Started trigger starts a workflow execution when a Compute Engine VM instance is started.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures the Compute Engine audit log entries for ` + "`v1.compute.instances.start` or `v1.compute.instances.startWithEncryptionKey`" + `, and routes them to a shared Pub/Sub topic. Events are pushed to SuperPlane and matched to this trigger automatically.
+
+## Use Cases
+
+- **Post-start configuration**: Register the instance in a load balancer or service discovery once it is running
+- **Cost tracking**: Record when instances start running
+- **Notifications**: Notify teams when instances are started
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions. Fetching the instance details requires ` + "`compute.instances.get`" + `, e.g. with ` + "`roles/compute.viewer`" + `.
+
+## Event Data
+
+Each event includes the audit log entry with resourceName (e.g. projects/my-project/zones/us-central1-a/instances/my-vm), serviceName, methodName and the full log entry data, and the instance details under instance. The instance is fetched when the event is received, so its status, machine type and IPs are included.`
+}
+
+func (t *OnVMInstanceStarted) Icon() string {
+	return "gcp"
+}
+
+func (t *OnVMInstanceStarted) Color() string {
+	return "gray"
+}
+
+func (t *OnVMInstanceStarted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		core.DeduplicationWindowConfigurationField(),
+	}
+}
+
+func (t *OnVMInstanceStarted) ExampleData() map[string]any {
+	return vmInstanceStarted.exampleData()
+}
+
+func (t *OnVMInstanceStarted) Setup(ctx core.TriggerContext) error {
+	return setupAuditLogSink(ctx, vmInstanceStarted.subscriptionPattern())
+}
+
+func (t *OnVMInstanceStarted) Actions() []core.Action {
+	return []core.Action{
+		{Name: "provisionSink"},
+	}
+}
+
+func (t *OnVMInstanceStarted) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return nil, provisionAuditLogSink(ctx, vmInstanceStarted.sinkFilter())
+}
+
+func (t *OnVMInstanceStarted) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	return vmInstanceStarted.emit(ctx)
+}
+
+func (t *OnVMInstanceStarted) Cleanup(ctx core.TriggerContext) error {
+	return cleanupAuditLogSink(ctx)
+}
+
+func (t *OnVMInstanceStarted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
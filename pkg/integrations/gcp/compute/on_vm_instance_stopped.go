@@ -0,0 +1,90 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnVMInstanceStopped struct{}
+
+func (t *OnVMInstanceStopped) Name() string {
+	return "gcp.compute.onVMInstanceStopped"
+}
+
+func (t *OnVMInstanceStopped) Label() string {
+	return "Compute • On VM Instance Stopped"
+}
+
+func (t *OnVMInstanceStopped) Description() string {
+	return "Listen to GCP Compute Engine VM instances being stopped"
+}
+
+func (t *OnVMInstanceStopped) Documentation() string {
+	return `The On VM Instance Stopped trigger starts a workflow execution when a Compute Engine VM instance is stopped.
+
+**Trigger behavior:** SuperPlane creates a Cloud Logging sink that captures the Compute Engine audit log entries for ` + "`v1.compute.instances.stop`" + `, and routes them to a shared Pub/Sub topic. Events are pushed to SuperPlane and matched to this trigger automatically.
+
+## Use Cases
+
+- **Cleanup**: Deregister the instance from load balancers or monitoring
+- **Cost tracking**: Record when instances stop running
+- **Notifications**: Alert teams when instances are stopped unexpectedly
+
+## Setup
+
+**Required GCP setup:** Ensure the **Pub/Sub** API is enabled in your project and the integration's service account has ` + "`roles/logging.configWriter`" + ` and ` + "`roles/pubsub.admin`" + ` permissions. Fetching the instance details requires ` + "`compute.instances.get`" + `, e.g. with ` + "`roles/compute.viewer`" + `.
+
+## Event Data
+
+Each event includes the audit log entry with resourceName (e.g. projects/my-project/zones/us-central1-a/instances/my-vm), serviceName, methodName and the full log entry data, and the instance details under instance. The instance is fetched when the event is received, so its status, machine type and IPs are included.`
+}
+
+func (t *OnVMInstanceStopped) Icon() string {
+	return "gcp"
+}
+
+func (t *OnVMInstanceStopped) Color() string {
+	return "gray"
+}
+
+func (t *OnVMInstanceStopped) Configuration() []configuration.Field {
+	return []configuration.Field{
+		core.DeduplicationWindowConfigurationField(),
+	}
+}
+
+func (t *OnVMInstanceStopped) ExampleData() map[string]any {
+	return vmInstanceStopped.exampleData()
+}
+
+func (t *OnVMInstanceStopped) Setup(ctx core.TriggerContext) error {
+	return setupAuditLogSink(ctx, vmInstanceStopped.subscriptionPattern())
+}
+
+func (t *OnVMInstanceStopped) Actions() []core.Action {
+	return []core.Action{
+		{Name: "provisionSink"},
+	}
+}
+
+func (t *OnVMInstanceStopped) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	if ctx.Name != "provisionSink" {
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+
+	return nil, provisionAuditLogSink(ctx, vmInstanceStopped.sinkFilter())
+}
+
+func (t *OnVMInstanceStopped) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	return vmInstanceStopped.emit(ctx)
+}
+
+func (t *OnVMInstanceStopped) Cleanup(ctx core.TriggerContext) error {
+	return cleanupAuditLogSink(ctx)
+}
+
+func (t *OnVMInstanceStopped) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
@@ -26,7 +26,8 @@ func Test_OnVMInstance_Metadata(t *testing.T) {
 func Test_OnVMInstance_Configuration(t *testing.T) {
 	trigger := &OnVMInstance{}
 	fields := trigger.Configuration()
-	assert.Nil(t, fields)
+	require.Len(t, fields, 1)
+	assert.Equal(t, core.DeduplicationWindowField, fields[0].Name)
 }
 
 func Test_OnVMInstance_ExampleData(t *testing.T) {
@@ -125,6 +126,25 @@ func Test_OnVMInstance_OnIntegrationMessage(t *testing.T) {
 		require.Equal(t, 1, events.Count())
 		assert.Equal(t, EmittedEventType, events.Payloads[0].Type)
 	})
+
+	t.Run("redelivered event emits once", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		for range 2 {
+			err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+				Message: map[string]any{
+					"serviceName":  computeServiceName,
+					"methodName":   instancesInsertMethod,
+					"resourceName": "projects/p/zones/z/instances/vm1",
+					"insertId":     "abc123",
+				},
+				Logger: logger,
+				Events: events,
+			})
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, events.Count())
+	})
 }
 
 func Test_sanitizeSinkID(t *testing.T) {
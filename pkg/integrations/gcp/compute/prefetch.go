@@ -0,0 +1,86 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+/*
+ * Machine types are listed per zone, and projects have access to more than a hundred,
+ * so they are listed a few at a time, to stay well under the Compute API read quota.
+ */
+const prefetchConcurrency = 4
+
+/*
+ * Prefetch caches the regions, zones, machine types and images
+ * used by the VM configuration forms. Lists already cached are not
+ * requested again, and expired ones are refreshed in the background.
+ * A list that fails does not stop the others.
+ */
+func Prefetch(ctx context.Context, c Client) error {
+	regions, err := ListRegions(ctx, c)
+	if err != nil {
+		return fmt.Errorf("list regions: %w", err)
+	}
+
+	lists := []func() error{}
+	for _, region := range regions {
+		for _, zone := range region.Zones {
+			lists = append(lists, func() error {
+				if _, err := ListMachineTypes(ctx, c, zone); err != nil {
+					return fmt.Errorf("list machine types for %s: %w", zone, err)
+				}
+				return nil
+			})
+		}
+	}
+
+	for _, project := range publicImageProjects {
+		lists = append(lists, func() error {
+			_, err := ListPublicImages(ctx, c, project)
+			return err
+		})
+	}
+
+	lists = append(lists, func() error {
+		if _, err := ListCustomImages(ctx, c, c.ProjectID()); err != nil {
+			return fmt.Errorf("list custom images: %w", err)
+		}
+		return nil
+	})
+
+	return runPrefetch(ctx, lists)
+}
+
+func runPrefetch(ctx context.Context, lists []func() error) error {
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, prefetchConcurrency)
+
+	for _, list := range lists {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return errors.Join(append(errs, ctx.Err())...)
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := list(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Prefetch(t *testing.T) {
+	regions, _ := json.Marshal(regionsListResp{
+		Items: []*regionItem{
+			{
+				Name:   "us-central1",
+				Status: "UP",
+				Zones: []string{
+					"https://www.googleapis.com/compute/v1/projects/prefetch-project/zones/us-central1-a",
+					"https://www.googleapis.com/compute/v1/projects/prefetch-project/zones/us-central1-b",
+				},
+			},
+		},
+	})
+
+	machineTypes, _ := json.Marshal(machineTypesListResp{Items: []*machineTypeItem{{Name: "e2-medium"}}})
+	images, _ := json.Marshal(imagesListResp{Items: []*imageItem{{Name: "image-1"}}})
+
+	var mu sync.Mutex
+	requested := map[string]int{}
+	c := &mockOSClient{
+		projectID: "prefetch-project",
+		get: func(_ context.Context, path string) ([]byte, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			path = strings.SplitN(path, "?", 2)[0]
+			requested[path]++
+			switch {
+			case strings.HasSuffix(path, "/regions"):
+				return regions, nil
+			case strings.HasSuffix(path, "/machineTypes"):
+				return machineTypes, nil
+			case path == "projects/prefetch-project/global/images":
+				return nil, errors.New("permission denied")
+			default:
+				return images, nil
+			}
+		},
+	}
+
+	err := Prefetch(context.Background(), c)
+	require.ErrorContains(t, err, "list custom images: permission denied")
+
+	assert.Equal(t, 1, requested["projects/prefetch-project/regions"])
+	assert.Equal(t, 1, requested["projects/prefetch-project/zones/us-central1-a/machineTypes"])
+	assert.Equal(t, 1, requested["projects/prefetch-project/zones/us-central1-b/machineTypes"])
+	assert.Equal(t, 1, requested["projects/prefetch-project/global/images"])
+
+	t.Run("cached lists are not requested again, failed ones are", func(t *testing.T) {
+		types, err := ListMachineTypes(context.Background(), c, "us-central1-b")
+		require.NoError(t, err)
+		require.Len(t, types, 1)
+
+		_ = Prefetch(context.Background(), c)
+		assert.Equal(t, 1, requested["projects/prefetch-project/zones/us-central1-b/machineTypes"])
+		assert.Equal(t, 1, requested["projects/prefetch-project/regions"])
+		assert.Equal(t, 2, requested["projects/prefetch-project/global/images"])
+	})
+}
@@ -0,0 +1,167 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func recommendMachineTypeClient(projectID string) *mockOSClient {
+	responses := map[string]string{
+		"projects/%s/regions": `{"items": [
+			{"name": "us-central1", "status": "UP", "zones": ["zones/us-central1-a", "zones/us-central1-b"]}
+		]}`,
+		"projects/%s/zones/us-central1-a/machineTypes": `{"items": [
+			{"name": "e2-micro", "guestCpus": 2, "memoryMb": 1024, "isSharedCpu": true},
+			{"name": "e2-standard-4", "guestCpus": 4, "memoryMb": 16384},
+			{"name": "n1-standard-4", "guestCpus": 4, "memoryMb": 15360},
+			{"name": "n2-standard-8", "guestCpus": 8, "memoryMb": 32768}
+		]}`,
+		"projects/%s/zones/us-central1-b/machineTypes": `{"items": [
+			{"name": "e2-standard-2", "guestCpus": 2, "memoryMb": 8192},
+			{"name": "n1-standard-4", "guestCpus": 4, "memoryMb": 15360},
+			{"name": "g2-standard-4", "guestCpus": 4, "memoryMb": 16384, "accelerators": [{"guestAcceleratorType": "nvidia-l4", "guestAcceleratorCount": 1}]}
+		]}`,
+		"projects/%s/zones/us-central1-a/acceleratorTypes": `{"items": [{"name": "nvidia-tesla-t4"}]}`,
+		"projects/%s/zones/us-central1-b/acceleratorTypes": `{"items": [{"name": "nvidia-l4"}]}`,
+	}
+
+	return &mockOSClient{
+		projectID: projectID,
+		get: func(ctx context.Context, path string) ([]byte, error) {
+			for pattern, body := range responses {
+				if path == fmt.Sprintf(pattern, projectID) {
+					return []byte(body), nil
+				}
+			}
+
+			return nil, fmt.Errorf("unexpected path %s", path)
+		},
+	}
+}
+
+func Test_recommendMachineTypes(t *testing.T) {
+	t.Run("cheapest machine type across zones", func(t *testing.T) {
+		client := recommendMachineTypeClient("recommend-cheapest")
+		recommendations, err := recommendMachineTypes(context.Background(), client, RecommendMachineTypeConfiguration{
+			Region:   "us-central1",
+			CPUs:     4,
+			MemoryGB: 15,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, recommendations, 4)
+		assert.Equal(t, "n1-standard-4", recommendations[0].MachineType)
+		assert.Equal(t, "us-central1-a", recommendations[0].Zone)
+		assert.Equal(t, "n1-standard-4", recommendations[1].MachineType)
+		assert.Equal(t, "us-central1-b", recommendations[1].Zone)
+		assert.Equal(t, "e2-standard-4", recommendations[2].MachineType)
+		assert.Equal(t, "n2-standard-8", recommendations[3].MachineType)
+	})
+
+	t.Run("shared-core and families", func(t *testing.T) {
+		client := recommendMachineTypeClient("recommend-families")
+		config := RecommendMachineTypeConfiguration{Region: "us-central1", CPUs: 1}
+
+		recommendations, err := recommendMachineTypes(context.Background(), client, config)
+		require.NoError(t, err)
+		assert.Equal(t, "e2-standard-2", recommendations[0].MachineType)
+
+		config.AllowSharedCPU = true
+		recommendations, err = recommendMachineTypes(context.Background(), client, config)
+		require.NoError(t, err)
+		assert.Equal(t, "e2-micro", recommendations[0].MachineType)
+
+		config.MachineFamilies = []string{"N2"}
+		recommendations, err = recommendMachineTypes(context.Background(), client, config)
+		require.NoError(t, err)
+		require.Len(t, recommendations, 1)
+		assert.Equal(t, "n2-standard-8", recommendations[0].MachineType)
+	})
+
+	t.Run("attached GPUs", func(t *testing.T) {
+		client := recommendMachineTypeClient("recommend-attached-gpus")
+		recommendations, err := recommendMachineTypes(context.Background(), client, RecommendMachineTypeConfiguration{
+			Region:  "us-central1",
+			CPUs:    2,
+			GPUType: "nvidia-tesla-t4",
+			GPUs:    1,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, recommendations, 1)
+		assert.Equal(t, "n1-standard-4", recommendations[0].MachineType)
+		assert.Equal(t, "us-central1-a", recommendations[0].Zone)
+		assert.True(t, recommendations[0].AttachGPUs)
+	})
+
+	t.Run("built-in GPUs", func(t *testing.T) {
+		client := recommendMachineTypeClient("recommend-built-in-gpus")
+		config := RecommendMachineTypeConfiguration{
+			Region:  "us-central1",
+			CPUs:    2,
+			GPUType: "nvidia-l4",
+			GPUs:    1,
+		}
+
+		recommendations, err := recommendMachineTypes(context.Background(), client, config)
+		require.NoError(t, err)
+		require.Len(t, recommendations, 1)
+		assert.Equal(t, "g2-standard-4", recommendations[0].MachineType)
+		assert.False(t, recommendations[0].AttachGPUs)
+
+		config.GPUs = 2
+		recommendations, err = recommendMachineTypes(context.Background(), client, config)
+		require.NoError(t, err)
+		assert.Empty(t, recommendations)
+	})
+}
+
+func Test_RecommendMachineType_Execute(t *testing.T) {
+	component := &RecommendMachineType{}
+
+	t.Run("emits the cheapest machine type", func(t *testing.T) {
+		useInstanceClient(t, recommendMachineTypeClient("recommend-execute"))
+		state := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region":          "us-central1",
+				"cpus":            2,
+				"memoryGb":        8,
+				"machineFamilies": []string{"e2"},
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, state.Payloads, 1)
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "e2-standard-2", payload["machineType"])
+		assert.Equal(t, "us-central1-b", payload["zone"])
+		assert.Equal(t, "STANDARD", payload["provisioningModel"])
+		assert.Len(t, payload["alternatives"], 1)
+	})
+
+	t.Run("fails when nothing matches", func(t *testing.T) {
+		useInstanceClient(t, recommendMachineTypeClient("recommend-execute-none"))
+		state := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"region": "us-central1",
+				"cpus":   64,
+			},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "no machine type in us-central1 has 64 vCPUs")
+	})
+}
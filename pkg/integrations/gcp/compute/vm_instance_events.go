@@ -0,0 +1,241 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+)
+
+//
+// The started, stopped and deleted triggers are OnVMInstance with
+// the audit log method names filled in, and the instance details
+// attached to the event, so users don't need to know GCP method names.
+//
+
+type vmInstanceEvent struct {
+	EventType string
+	Methods   []string
+}
+
+var (
+	vmInstanceStarted = vmInstanceEvent{
+		EventType: "gcp.compute.vmInstance.started",
+		Methods:   []string{"start", "startWithEncryptionKey"},
+	}
+
+	vmInstanceStopped = vmInstanceEvent{
+		EventType: "gcp.compute.vmInstance.stopped",
+		Methods:   []string{"stop"},
+	}
+
+	vmInstanceDeleted = vmInstanceEvent{
+		EventType: "gcp.compute.vmInstance.deleted",
+		Methods:   []string{"delete"},
+	}
+
+	//
+	// Preemptions are system events, written by Compute Engine
+	// to the system_event audit log, not the activity log.
+	//
+	vmInstancePreempted = vmInstanceEvent{
+		EventType: "gcp.compute.vmInstance.preempted",
+		Methods:   []string{"preempted"},
+	}
+)
+
+func (e vmInstanceEvent) methodNames() []string {
+	return computeMethodNames("instances", e.Methods...)
+}
+
+/*
+ * Audit log method names for methods on a compute collection,
+ * in their v1, beta and unversioned forms.
+ */
+func computeMethodNames(collection string, methods ...string) []string {
+	names := []string{}
+	for _, method := range methods {
+		names = append(names,
+			"v1.compute."+collection+"."+method,
+			"beta.compute."+collection+"."+method,
+			"compute."+collection+"."+method,
+		)
+	}
+
+	return names
+}
+
+func (e vmInstanceEvent) sinkFilter() string {
+	return auditLogSinkFilter(e.methodNames())
+}
+
+func (e vmInstanceEvent) subscriptionPattern() map[string]any {
+	return computeSubscriptionPattern()
+}
+
+/*
+ * Subscription patterns match a single method name,
+ * so triggers on several methods receive all compute events and filter them by method.
+ */
+func computeSubscriptionPattern() map[string]any {
+	return map[string]any{
+		"serviceName": computeServiceName,
+	}
+}
+
+func (e vmInstanceEvent) exampleData() map[string]any {
+	methodName := e.methodNames()[0]
+	resourceName := "projects/my-project/zones/us-central1-a/instances/my-vm"
+
+	return map[string]any{
+		"serviceName":  computeServiceName,
+		"methodName":   methodName,
+		"resourceName": resourceName,
+		"logName":      "projects/my-project/logs/cloudaudit.googleapis.com%2Factivity",
+		"timestamp":    "2025-02-14T12:00:00Z",
+		"instance": map[string]any{
+			"project":     "my-project",
+			"zone":        "us-central1-a",
+			"name":        "my-vm",
+			"instanceId":  "1234567890123456789",
+			"selfLink":    "https://www.googleapis.com/compute/v1/" + resourceName,
+			"status":      "RUNNING",
+			"machineType": "e2-medium",
+			"internalIP":  "10.128.0.2",
+			"externalIP":  "34.123.45.67",
+		},
+		"data": map[string]any{
+			"protoPayload": map[string]any{
+				"methodName":   methodName,
+				"resourceName": resourceName,
+				"serviceName":  computeServiceName,
+			},
+		},
+	}
+}
+
+func (e vmInstanceEvent) emit(ctx core.IntegrationMessageContext) error {
+	event, ok, err := decodeComputeAuditLogEvent(ctx.Message, e.methodNames())
+	if err != nil || !ok {
+		return err
+	}
+
+	payload := event.payload()
+	payload["instance"] = instanceDetails(ctx, event.ResourceName)
+
+	_, err = core.EmitDeduplicated(ctx.Events, event.InsertID, e.EventType, payload)
+	return err
+}
+
+type computeAuditLogEvent struct {
+	ServiceName  string `mapstructure:"serviceName"`
+	MethodName   string `mapstructure:"methodName"`
+	ResourceName string `mapstructure:"resourceName"`
+	LogName      string `mapstructure:"logName"`
+	Timestamp    string `mapstructure:"timestamp"`
+	InsertID     string `mapstructure:"insertId"`
+	Data         any    `mapstructure:"data"`
+}
+
+/*
+ * Decodes an integration message, and reports if it is a compute audit log event
+ * for one of the method names.
+ */
+func decodeComputeAuditLogEvent(message any, methodNames []string) (*computeAuditLogEvent, bool, error) {
+	var event computeAuditLogEvent
+	if err := mapstructure.Decode(message, &event); err != nil {
+		return nil, false, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	if event.ServiceName != computeServiceName {
+		return nil, false, nil
+	}
+
+	event.MethodName = strings.TrimSpace(event.MethodName)
+	if !slices.Contains(methodNames, event.MethodName) {
+		return nil, false, nil
+	}
+
+	return &event, true, nil
+}
+
+func (e *computeAuditLogEvent) payload() map[string]any {
+	return map[string]any{
+		"serviceName":  e.ServiceName,
+		"methodName":   e.MethodName,
+		"resourceName": e.ResourceName,
+		"logName":      e.LogName,
+		"timestamp":    e.Timestamp,
+		"insertId":     e.InsertID,
+		"data":         e.Data,
+	}
+}
+
+/*
+ * Instance details for the resource name of an audit log entry.
+ * The instance is fetched on a best effort basis: it is gone after a delete,
+ * and the project, zone and name from the resource name are returned then.
+ */
+func instanceDetails(ctx core.IntegrationMessageContext, resourceName string) map[string]any {
+	project, zone, name, ok := parseInstanceResourceName(resourceName)
+	if !ok {
+		return map[string]any{}
+	}
+
+	details := map[string]any{
+		"project": project,
+		"zone":    zone,
+		"name":    name,
+	}
+
+	if ctx.Integration == nil {
+		return details
+	}
+
+	client, err := getClient(core.ExecutionContext{HTTP: ctx.HTTP, Integration: ctx.Integration})
+	if err != nil {
+		ctx.Logger.Warnf("failed to create GCP client to fetch instance %s: %v", resourceName, err)
+		return details
+	}
+
+	body, err := GetInstance(context.Background(), client, project, zone, name)
+	if err != nil {
+		if !gcpcommon.IsNotFoundError(err) {
+			ctx.Logger.Warnf("failed to fetch instance %s: %v", resourceName, err)
+		}
+
+		return details
+	}
+
+	instance, err := InstancePayloadFromGetResponse(body, zone)
+	if err != nil {
+		ctx.Logger.Warnf("failed to parse instance %s: %v", resourceName, err)
+		return details
+	}
+
+	for k, v := range instance {
+		details[k] = v
+	}
+
+	return details
+}
+
+/*
+ * Splits projects/{project}/zones/{zone}/instances/{name}.
+ */
+func parseInstanceResourceName(resourceName string) (string, string, string, bool) {
+	parts := strings.Split(strings.Trim(resourceName, "/"), "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[2] != "zones" || parts[4] != "instances" {
+		return "", "", "", false
+	}
+
+	if parts[1] == "" || parts[3] == "" || parts[5] == "" {
+		return "", "", "", false
+	}
+
+	return parts[1], parts[3], parts[5], true
+}
@@ -0,0 +1,223 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gcpcommon "github.com/superplanehq/superplane/pkg/integrations/gcp/common"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type fakeInstanceClient struct {
+	body      []byte
+	err       error
+	paths     []string
+	deleted   []string
+	deleteErr error
+}
+
+func (c *fakeInstanceClient) Get(ctx context.Context, path string) ([]byte, error) {
+	c.paths = append(c.paths, path)
+	return c.body, c.err
+}
+
+func (c *fakeInstanceClient) Post(ctx context.Context, path string, body any) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *fakeInstanceClient) Patch(ctx context.Context, path string, body any) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *fakeInstanceClient) GetURL(ctx context.Context, fullURL string) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *fakeInstanceClient) Delete(ctx context.Context, path string) ([]byte, error) {
+	if c.deleteErr != nil {
+		return nil, c.deleteErr
+	}
+
+	c.deleted = append(c.deleted, path)
+	return nil, nil
+}
+
+func (c *fakeInstanceClient) ProjectID() string {
+	return "my-project"
+}
+
+func useInstanceClient(t *testing.T, client Client) {
+	SetClientFactory(func(ctx core.ExecutionContext) (Client, error) {
+		return client, nil
+	})
+
+	t.Cleanup(func() { SetClientFactory(nil) })
+}
+
+func Test_VMInstanceEventTriggers(t *testing.T) {
+	triggers := []struct {
+		trigger   core.IntegrationTrigger
+		name      string
+		methods   []string
+		eventType string
+	}{
+		{
+			trigger:   &OnVMInstanceStarted{},
+			name:      "gcp.compute.onVMInstanceStarted",
+			methods:   []string{"v1.compute.instances.start", "compute.instances.startWithEncryptionKey"},
+			eventType: "gcp.compute.vmInstance.started",
+		},
+		{
+			trigger:   &OnVMInstanceStopped{},
+			name:      "gcp.compute.onVMInstanceStopped",
+			methods:   []string{"v1.compute.instances.stop", "beta.compute.instances.stop"},
+			eventType: "gcp.compute.vmInstance.stopped",
+		},
+		{
+			trigger:   &OnVMInstanceDeleted{},
+			name:      "gcp.compute.onVMInstanceDeleted",
+			methods:   []string{"v1.compute.instances.delete", "compute.instances.delete"},
+			eventType: "gcp.compute.vmInstance.deleted",
+		},
+	}
+
+	for _, tt := range triggers {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, tt.trigger.Name())
+			assert.NotEmpty(t, tt.trigger.Documentation())
+			require.Len(t, tt.trigger.Actions(), 1)
+			assert.Equal(t, "provisionSink", tt.trigger.Actions()[0].Name)
+
+			example := tt.trigger.ExampleData()
+			assert.Contains(t, tt.methods, example["methodName"])
+			assert.NotEmpty(t, example["instance"])
+
+			for _, method := range tt.methods {
+				events := &contexts.EventContext{}
+				err := tt.trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+					Message: map[string]any{
+						"serviceName":  computeServiceName,
+						"methodName":   method,
+						"resourceName": "projects/p/zones/z/instances/vm1",
+					},
+					Logger: logrus.NewEntry(logrus.New()),
+					Events: events,
+				})
+
+				require.NoError(t, err)
+				require.Equal(t, 1, events.Count())
+				assert.Equal(t, tt.eventType, events.Payloads[0].Type)
+			}
+
+			events := &contexts.EventContext{}
+			err := tt.trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+				Message: map[string]any{
+					"serviceName":  computeServiceName,
+					"methodName":   instancesInsertMethod,
+					"resourceName": "projects/p/zones/z/instances/vm1",
+				},
+				Logger: logrus.NewEntry(logrus.New()),
+				Events: events,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, 0, events.Count())
+		})
+	}
+}
+
+func Test_VMInstanceEvent_SinkFilter(t *testing.T) {
+	assert.Equal(t,
+		`protoPayload.serviceName="compute.googleapis.com" AND (protoPayload.methodName="v1.compute.instances.stop" OR protoPayload.methodName="beta.compute.instances.stop" OR protoPayload.methodName="compute.instances.stop")`,
+		vmInstanceStopped.sinkFilter(),
+	)
+
+	assert.Equal(t, map[string]any{"serviceName": computeServiceName}, vmInstanceStopped.subscriptionPattern())
+}
+
+func Test_VMInstanceEvent_InstanceDetails(t *testing.T) {
+	message := map[string]any{
+		"serviceName":  computeServiceName,
+		"methodName":   "v1.compute.instances.start",
+		"resourceName": "projects/my-project/zones/us-central1-a/instances/my-vm",
+		"insertId":     "abc123",
+	}
+
+	integration := &contexts.IntegrationContext{}
+
+	t.Run("instance is fetched and attached", func(t *testing.T) {
+		client := &fakeInstanceClient{
+			body: []byte(`{"id": "42", "name": "my-vm", "status": "RUNNING", "machineType": "zones/us-central1-a/machineTypes/e2-medium", "networkInterfaces": [{"networkIP": "10.0.0.2"}]}`),
+		}
+		useInstanceClient(t, client)
+
+		events := &contexts.EventContext{}
+		err := vmInstanceStarted.emit(core.IntegrationMessageContext{
+			Message:     message,
+			Logger:      logrus.NewEntry(logrus.New()),
+			Events:      events,
+			Integration: integration,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, []string{"projects/my-project/zones/us-central1-a/instances/my-vm"}, client.paths)
+
+		payload := events.Payloads[0].Data.(map[string]any)
+		instance := payload["instance"].(map[string]any)
+		assert.Equal(t, "my-project", instance["project"])
+		assert.Equal(t, "us-central1-a", instance["zone"])
+		assert.Equal(t, "my-vm", instance["name"])
+		assert.Equal(t, "42", instance["instanceId"])
+		assert.Equal(t, "RUNNING", instance["status"])
+		assert.Equal(t, "e2-medium", instance["machineType"])
+		assert.Equal(t, "10.0.0.2", instance["internalIP"])
+	})
+
+	t.Run("deleted instance keeps the resource name details", func(t *testing.T) {
+		useInstanceClient(t, &fakeInstanceClient{
+			err: &gcpcommon.GCPAPIError{StatusCode: http.StatusNotFound},
+		})
+
+		events := &contexts.EventContext{}
+		err := vmInstanceDeleted.emit(core.IntegrationMessageContext{
+			Message: map[string]any{
+				"serviceName":  computeServiceName,
+				"methodName":   "v1.compute.instances.delete",
+				"resourceName": "projects/my-project/zones/us-central1-a/instances/my-vm",
+			},
+			Logger:      logrus.NewEntry(logrus.New()),
+			Events:      events,
+			Integration: integration,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, map[string]any{
+			"project": "my-project",
+			"zone":    "us-central1-a",
+			"name":    "my-vm",
+		}, payload["instance"])
+	})
+}
+
+func Test_parseInstanceResourceName(t *testing.T) {
+	project, zone, name, ok := parseInstanceResourceName("projects/p/zones/us-east1-b/instances/vm1")
+	require.True(t, ok)
+	assert.Equal(t, "p", project)
+	assert.Equal(t, "us-east1-b", zone)
+	assert.Equal(t, "vm1", name)
+
+	_, _, _, ok = parseInstanceResourceName("projects/p/global/networks/default")
+	assert.False(t, ok)
+
+	_, _, _, ok = parseInstanceResourceName("")
+	assert.False(t, ok)
+}
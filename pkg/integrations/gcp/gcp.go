@@ -156,12 +156,23 @@ func (g *GCP) Configuration() []configuration.Field {
 				{Field: "connectionMethod", Values: []string{ConnectionMethodWIF}},
 			},
 		},
+		core.SourceValidationField(),
 	}
 }
 
 func (g *GCP) Components() []core.Component {
 	return []core.Component{
 		&compute.CreateVM{},
+		&compute.AddBackend{},
+		&compute.AddInstancesToInstanceGroup{},
+		&compute.CheckQuota{},
+		&compute.CheckVMCompliance{},
+		&compute.CleanupVMs{},
+		&compute.CreateInstanceGroup{},
+		&compute.CreateNodeGroup{},
+		&compute.CreateNodeTemplate{},
+		&compute.GetSerialPortOutput{},
+		&compute.RecommendMachineType{},
 		&cloudbuild.CreateBuild{},
 		&cloudbuild.GetBuild{},
 		&cloudbuild.RunTrigger{},
@@ -176,12 +187,20 @@ func (g *GCP) Components() []core.Component {
 		&clouddns.CreateRecord{},
 		&clouddns.DeleteRecord{},
 		&clouddns.UpdateRecord{},
+		&clouddns.UpsertRecord{},
 	}
 }
 
 func (g *GCP) Triggers() []core.Trigger {
 	return []core.Trigger{
 		&compute.OnVMInstance{},
+		&compute.OnVMInstanceStarted{},
+		&compute.OnVMInstanceStopped{},
+		&compute.OnVMInstanceDeleted{},
+		&compute.OnSpotPreemption{},
+		&compute.OnDiskCreated{},
+		&compute.OnDiskDeleted{},
+		&compute.OnSnapshotCompleted{},
 		&cloudbuild.OnBuildComplete{},
 		&artifactregistry.OnArtifactPush{},
 		&artifactregistry.OnArtifactAnalysis{},
@@ -190,11 +209,21 @@ func (g *GCP) Triggers() []core.Trigger {
 }
 
 func (g *GCP) Sync(ctx core.SyncContext) error {
+	//
+	// Sync runs when the configuration changes, so token sources
+	// created from the previous credentials are not kept.
+	//
+	gcpcommon.RemoveTokenSources(ctx.Integration.ID())
+
 	config := Configuration{}
 	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
+	if _, err := core.SourceValidationFromConfiguration(ctx.Configuration); err != nil {
+		return err
+	}
+
 	switch strings.TrimSpace(config.ConnectionMethod) {
 	case ConnectionMethodServiceAccountKey:
 		return g.syncServiceAccountKey(ctx, config)
@@ -727,7 +756,39 @@ func sanitizeID(s string) string {
 	return result
 }
 
+/*
+ * Fetches the project through the Cloud Resource Manager API,
+ * the same request used to verify the connection when syncing.
+ */
+func (g *GCP) HealthCheck(ctx core.HealthCheckContext) error {
+	client, err := gcpcommon.NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create GCP client: %w", err)
+	}
+
+	crmURL := fmt.Sprintf("https://cloudresourcemanager.googleapis.com/v3/projects/%s", client.ProjectID())
+	if _, err := client.GetURL(ctx.Context, crmURL); err != nil {
+		return fmt.Errorf("failed to get project %s: %w", client.ProjectID(), err)
+	}
+
+	return nil
+}
+
+/*
+ * Caches the Compute Engine resources shown in the VM configuration forms.
+ */
+func (g *GCP) Prefetch(ctx core.PrefetchContext) error {
+	client, err := gcpcommon.NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create GCP client: %w", err)
+	}
+
+	return compute.Prefetch(ctx.Context, client)
+}
+
 func (g *GCP) Cleanup(ctx core.IntegrationCleanupContext) error {
+	defer gcpcommon.RemoveTokenSources(ctx.Integration.ID())
+
 	var m gcpcommon.Metadata
 	if err := mapstructure.Decode(ctx.Integration.GetMetadata(), &m); err != nil || m.ProjectID == "" {
 		return nil
@@ -779,6 +840,16 @@ func (g *GCP) Cleanup(ctx core.IntegrationCleanupContext) error {
 	return nil
 }
 
+/*
+ * Roles needed to deliver events to triggers.
+ * Components declare the roles for the resources they manage.
+ */
+func (g *GCP) RequiredPermissions() core.Permissions {
+	return core.Permissions{
+		Roles: []string{"roles/logging.configWriter", "roles/pubsub.admin"},
+	}
+}
+
 func (g *GCP) Actions() []core.Action {
 	return []core.Action{
 		{Name: gcpcommon.ActionNameEnsureCloudBuild},
@@ -932,7 +1003,7 @@ func (g *GCP) ListResources(resourceType string, ctx core.ListResourcesContext)
 	case compute.ResourceTypeSubnetwork:
 		return compute.ListSubnetworkResources(reqCtx, client, p["project"], p["region"])
 	case compute.ResourceTypeAddress:
-		return compute.ListAddressResources(reqCtx, client, p["project"], p["region"])
+		return compute.ListAddressResources(reqCtx, client, p["project"], p["region"], p["addressType"])
 	case compute.ResourceTypeFirewall:
 		return compute.ListFirewallResources(reqCtx, client, p["project"])
 	case clouddns.ResourceTypeManagedZone:
@@ -968,6 +1039,28 @@ func (g *GCP) ListResources(resourceType string, ctx core.ListResourcesContext)
 	}
 }
 
+/*
+ * ListResourcePage searches and paginates custom images through the Compute API,
+ * since projects can have thousands of them. Other resource types are listed in full.
+ */
+func (g *GCP) ListResourcePage(resourceType string, ctx core.ListResourcesContext) (*core.ResourcePage, error) {
+	if resourceType != compute.ResourceTypeCustomImages {
+		resources, err := g.ListResources(resourceType, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return core.PageResources(resources, ctx)
+	}
+
+	client, err := gcpcommon.NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	return compute.ListCustomImageResourcePage(context.Background(), client, ctx.Parameters["project"], ctx)
+}
+
 func (g *GCP) HandleRequest(ctx core.HTTPRequestContext) {
 	if strings.HasSuffix(ctx.Request.URL.Path, "/events") {
 		g.handleEvent(ctx)
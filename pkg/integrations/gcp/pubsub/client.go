@@ -120,11 +120,70 @@ type pushConfig struct {
 }
 
 type subscriptionRequest struct {
-	Topic                    string      `json:"topic"`
-	PushConfig               *pushConfig `json:"pushConfig,omitempty"`
-	AckDeadlineSeconds       int         `json:"ackDeadlineSeconds"`
-	MessageRetentionDuration string      `json:"messageRetentionDuration"`
-	Filter                   string      `json:"filter,omitempty"`
+	Topic                    string            `json:"topic"`
+	PushConfig               *pushConfig       `json:"pushConfig,omitempty"`
+	AckDeadlineSeconds       int               `json:"ackDeadlineSeconds"`
+	MessageRetentionDuration string            `json:"messageRetentionDuration"`
+	Filter                   string            `json:"filter,omitempty"`
+	DeadLetterPolicy         *deadLetterPolicy `json:"deadLetterPolicy,omitempty"`
+	RetryPolicy              *retryPolicy      `json:"retryPolicy,omitempty"`
+}
+
+type deadLetterPolicy struct {
+	DeadLetterTopic     string `json:"deadLetterTopic"`
+	MaxDeliveryAttempts int    `json:"maxDeliveryAttempts"`
+}
+
+type retryPolicy struct {
+	MinimumBackoff string `json:"minimumBackoff"`
+	MaximumBackoff string `json:"maximumBackoff"`
+}
+
+// SubscriptionOptions are the delivery options of a subscription created from a workflow.
+// Messages are pulled when PushEndpoint is empty, and redelivered immediately when
+// MaximumBackoffSeconds is zero.
+type SubscriptionOptions struct {
+	PushEndpoint          string
+	DeadLetterTopic       string
+	MaxDeliveryAttempts   int
+	MinimumBackoffSeconds int
+	MaximumBackoffSeconds int
+}
+
+func CreateSubscription(ctx context.Context, client *common.Client, projectID, subscriptionID, topicID string, options SubscriptionOptions) error {
+	url := fmt.Sprintf("%s/projects/%s/subscriptions/%s", pubsubBaseURL, projectID, subscriptionID)
+	req := subscriptionRequest{
+		Topic:                    fmt.Sprintf("projects/%s/topics/%s", projectID, topicID),
+		AckDeadlineSeconds:       30,
+		MessageRetentionDuration: "604800s",
+	}
+	if options.PushEndpoint != "" {
+		req.PushConfig = &pushConfig{PushEndpoint: options.PushEndpoint}
+	}
+	if options.DeadLetterTopic != "" {
+		req.DeadLetterPolicy = &deadLetterPolicy{
+			DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", projectID, options.DeadLetterTopic),
+			MaxDeliveryAttempts: options.MaxDeliveryAttempts,
+		}
+	}
+	if options.MaximumBackoffSeconds > 0 {
+		req.RetryPolicy = &retryPolicy{
+			MinimumBackoff: fmt.Sprintf("%ds", options.MinimumBackoffSeconds),
+			MaximumBackoff: fmt.Sprintf("%ds", options.MaximumBackoffSeconds),
+		}
+	}
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshal subscription body: %w", err)
+	}
+	_, err = client.ExecRequest(ctx, "PUT", url, strings.NewReader(string(raw)))
+	if err != nil {
+		if common.IsAlreadyExistsError(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
 }
 
 func CreatePushSubscription(ctx context.Context, client *common.Client, projectID, subscriptionID, topicID, pushEndpoint string, filter ...string) error {
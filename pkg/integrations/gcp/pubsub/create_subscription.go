@@ -16,6 +16,13 @@ import (
 const (
 	createSubscriptionOutputChannel = "default"
 	createSubscriptionPayloadType   = "gcp.pubsub.subscription"
+
+	retryPolicyImmediate   = "immediate"
+	retryPolicyExponential = "exponential"
+
+	defaultMaxDeliveryAttempts   = 5
+	defaultMinimumBackoffSeconds = 10
+	defaultMaximumBackoffSeconds = 600
 )
 
 type CreateSubscriptionComponent struct{}
@@ -25,6 +32,12 @@ type CreateSubscriptionConfiguration struct {
 	Subscription string `json:"subscription" mapstructure:"subscription"`
 	Type         string `json:"type" mapstructure:"type"`
 	PushEndpoint string `json:"pushEndpoint" mapstructure:"pushEndpoint"`
+
+	DeadLetterTopic       string `json:"deadLetterTopic" mapstructure:"deadLetterTopic"`
+	MaxDeliveryAttempts   int    `json:"maxDeliveryAttempts" mapstructure:"maxDeliveryAttempts"`
+	RetryPolicy           string `json:"retryPolicy" mapstructure:"retryPolicy"`
+	MinimumBackoffSeconds int    `json:"minimumBackoffSeconds" mapstructure:"minimumBackoffSeconds"`
+	MaximumBackoffSeconds int    `json:"maximumBackoffSeconds" mapstructure:"maximumBackoffSeconds"`
 }
 
 func (c *CreateSubscriptionComponent) Name() string  { return "gcp.pubsub.createSubscription" }
@@ -42,7 +55,13 @@ func (c *CreateSubscriptionComponent) Documentation() string {
 
 - **Provisioning workflows**: Wire up subscriptions as part of service deployment
 - **Pull queue setup**: Create pull subscriptions for batch processing workflows
-- **Push integration**: Create push subscriptions that deliver messages to an HTTP endpoint`
+- **Push integration**: Create push subscriptions that deliver messages to an HTTP endpoint
+- **Reliable delivery**: Forward messages that keep failing to a dead-letter topic, and back off between redeliveries
+
+## Delivery Options
+
+- **Dead-letter topic**: Messages that are not acknowledged after **Max delivery attempts** (5 to 100) are forwarded to this topic. The Pub/Sub service account of the project needs the Publisher role on the dead-letter topic and the Subscriber role on the subscription.
+- **Retry policy**: Redeliver immediately, or with an exponential backoff between **Minimum backoff** and **Maximum backoff** seconds (up to 600).`
 }
 
 func (c *CreateSubscriptionComponent) OutputChannels(_ any) []core.OutputChannel {
@@ -105,7 +124,134 @@ func (c *CreateSubscriptionComponent) Configuration() []configuration.Field {
 				{Field: "type", Values: []string{"push"}},
 			},
 		},
+		{
+			Name:        "deadLetterTopic",
+			Label:       "Dead-letter Topic",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Topic that receives the messages that could not be delivered.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "topic", Values: []string{"*"}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       ResourceTypeTopic,
+					Parameters: []configuration.ParameterRef{},
+				},
+			},
+		},
+		{
+			Name:        "maxDeliveryAttempts",
+			Label:       "Max Delivery Attempts",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     defaultMaxDeliveryAttempts,
+			Description: "Delivery attempts before a message is forwarded to the dead-letter topic.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "deadLetterTopic", Values: []string{"*"}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 5; return &min }(),
+					Max: func() *int { max := 100; return &max }(),
+				},
+			},
+		},
+		{
+			Name:     "retryPolicy",
+			Label:    "Retry Policy",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  retryPolicyImmediate,
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "topic", Values: []string{"*"}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Retry immediately", Value: retryPolicyImmediate},
+						{Label: "Exponential backoff", Value: retryPolicyExponential},
+					},
+				},
+			},
+		},
+		{
+			Name:        "minimumBackoffSeconds",
+			Label:       "Minimum Backoff (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     defaultMinimumBackoffSeconds,
+			Description: "Delay before the first redelivery.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "retryPolicy", Values: []string{retryPolicyExponential}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+					Max: func() *int { max := 600; return &max }(),
+				},
+			},
+		},
+		{
+			Name:        "maximumBackoffSeconds",
+			Label:       "Maximum Backoff (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     defaultMaximumBackoffSeconds,
+			Description: "Longest delay between redeliveries.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "retryPolicy", Values: []string{retryPolicyExponential}},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+					Max: func() *int { max := 600; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+/*
+ * Delivery options of the configuration, with defaults applied.
+ */
+func (config CreateSubscriptionConfiguration) subscriptionOptions() (SubscriptionOptions, error) {
+	options := SubscriptionOptions{
+		DeadLetterTopic: normalizeTopicName(config.DeadLetterTopic),
+	}
+
+	if config.Type == "push" {
+		options.PushEndpoint = strings.TrimSpace(config.PushEndpoint)
+		if options.PushEndpoint == "" {
+			return SubscriptionOptions{}, fmt.Errorf("pushEndpoint is required for push subscriptions")
+		}
+	}
+
+	if options.DeadLetterTopic != "" {
+		options.MaxDeliveryAttempts = config.MaxDeliveryAttempts
+		if options.MaxDeliveryAttempts == 0 {
+			options.MaxDeliveryAttempts = defaultMaxDeliveryAttempts
+		}
+		if options.MaxDeliveryAttempts < 5 || options.MaxDeliveryAttempts > 100 {
+			return SubscriptionOptions{}, fmt.Errorf("maxDeliveryAttempts must be between 5 and 100")
+		}
 	}
+
+	if config.RetryPolicy == retryPolicyExponential {
+		options.MinimumBackoffSeconds = config.MinimumBackoffSeconds
+		options.MaximumBackoffSeconds = config.MaximumBackoffSeconds
+		if options.MaximumBackoffSeconds == 0 {
+			options.MaximumBackoffSeconds = defaultMaximumBackoffSeconds
+		}
+		if options.MinimumBackoffSeconds < 0 || options.MaximumBackoffSeconds > 600 {
+			return SubscriptionOptions{}, fmt.Errorf("backoff must be between 0 and 600 seconds")
+		}
+		if options.MinimumBackoffSeconds > options.MaximumBackoffSeconds {
+			return SubscriptionOptions{}, fmt.Errorf("minimumBackoffSeconds cannot be greater than maximumBackoffSeconds")
+		}
+	}
+
+	return options, nil
 }
 
 func (c *CreateSubscriptionComponent) Setup(ctx core.SetupContext) error {
@@ -119,10 +265,8 @@ func (c *CreateSubscriptionComponent) Setup(ctx core.SetupContext) error {
 	if strings.TrimSpace(config.Subscription) == "" {
 		return fmt.Errorf("subscription is required")
 	}
-	if config.Type == "push" && strings.TrimSpace(config.PushEndpoint) == "" {
-		return fmt.Errorf("pushEndpoint is required for push subscriptions")
-	}
-	return nil
+	_, err := config.subscriptionOptions()
+	return err
 }
 
 func (c *CreateSubscriptionComponent) Execute(ctx core.ExecutionContext) error {
@@ -146,31 +290,36 @@ func (c *CreateSubscriptionComponent) Execute(ctx core.ExecutionContext) error {
 		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create GCP client: %v", err))
 	}
 
+	options, err := config.subscriptionOptions()
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
 	projectID := client.ProjectID()
-	reqCtx := context.Background()
+	if err := CreateSubscription(context.Background(), client, projectID, config.Subscription, config.Topic, options); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create %s subscription: %v", config.Type, err))
+	}
 
-	if config.Type == "push" {
-		endpoint := strings.TrimSpace(config.PushEndpoint)
-		if endpoint == "" {
-			return ctx.ExecutionState.Fail("error", "pushEndpoint is required for push subscriptions")
-		}
-		if err := CreatePushSubscription(reqCtx, client, projectID, config.Subscription, config.Topic, endpoint); err != nil {
-			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create push subscription: %v", err))
-		}
-	} else {
-		if err := CreatePullSubscription(reqCtx, client, projectID, config.Subscription, config.Topic); err != nil {
-			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create pull subscription: %v", err))
+	payload := map[string]any{
+		"subscription": config.Subscription,
+		"topic":        config.Topic,
+		"type":         config.Type,
+		"name":         fmt.Sprintf("projects/%s/subscriptions/%s", projectID, config.Subscription),
+	}
+
+	if options.DeadLetterTopic != "" {
+		payload["deadLetterTopic"] = options.DeadLetterTopic
+		payload["maxDeliveryAttempts"] = options.MaxDeliveryAttempts
+	}
+
+	if options.MaximumBackoffSeconds > 0 {
+		payload["retryPolicy"] = map[string]any{
+			"minimumBackoffSeconds": options.MinimumBackoffSeconds,
+			"maximumBackoffSeconds": options.MaximumBackoffSeconds,
 		}
 	}
 
-	return ctx.ExecutionState.Emit(createSubscriptionOutputChannel, createSubscriptionPayloadType, []any{
-		map[string]any{
-			"subscription": config.Subscription,
-			"topic":        config.Topic,
-			"type":         config.Type,
-			"name":         fmt.Sprintf("projects/%s/subscriptions/%s", projectID, config.Subscription),
-		},
-	})
+	return ctx.ExecutionState.Emit(createSubscriptionOutputChannel, createSubscriptionPayloadType, []any{payload})
 }
 
 func (c *CreateSubscriptionComponent) Actions() []core.Action                  { return nil }
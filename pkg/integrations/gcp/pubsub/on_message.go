@@ -109,6 +109,7 @@ func (t *OnMessage) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.DeduplicationWindowConfigurationField(),
 	}
 }
 
@@ -169,7 +170,17 @@ func (t *OnMessage) HandleAction(ctx core.TriggerActionContext) (map[string]any,
 }
 
 func (t *OnMessage) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
-	return ctx.Events.Emit(OnMessageEmittedEventType, ctx.Message)
+	//
+	// Pub/Sub push subscriptions deliver messages at least once,
+	// so redeliveries are discarded through the message ID.
+	//
+	messageID := ""
+	if message, ok := ctx.Message.(map[string]any); ok {
+		messageID, _ = message["messageId"].(string)
+	}
+
+	_, err := core.EmitDeduplicated(ctx.Events, messageID, OnMessageEmittedEventType, ctx.Message)
+	return err
 }
 
 func (t *OnMessage) Cleanup(ctx core.TriggerContext) error {
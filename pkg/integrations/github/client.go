@@ -8,6 +8,7 @@ import (
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v74/github"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 )
 
 func NewClient(ctx core.IntegrationContext, ghAppID int64, installationID string) (*github.Client, error) {
@@ -22,7 +23,7 @@ func NewClient(ctx core.IntegrationContext, ghAppID int64, installationID string
 	}
 
 	itr, err := ghinstallation.New(
-		http.DefaultTransport,
+		telemetry.NewHTTPTransport(),
 		ghAppID,
 		int64(ID),
 		[]byte(pem),
@@ -170,6 +170,10 @@ func (c *GetIssue) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (c *GetIssue) HandlesTestMode() bool {
+	return true
+}
+
 func (c *GetIssue) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
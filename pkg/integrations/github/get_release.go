@@ -264,6 +264,10 @@ func (c *GetRelease) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (c *GetRelease) HandlesTestMode() bool {
+	return true
+}
+
 func (c *GetRelease) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
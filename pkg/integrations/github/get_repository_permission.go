@@ -149,6 +149,10 @@ func (c *GetRepositoryPermission) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (c *GetRepositoryPermission) HandlesTestMode() bool {
+	return true
+}
+
 func (c *GetRepositoryPermission) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
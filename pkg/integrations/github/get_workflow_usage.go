@@ -224,6 +224,10 @@ func (g *GetWorkflowUsage) Cancel(ctx core.ExecutionContext) error {
 	return nil
 }
 
+func (g *GetWorkflowUsage) HandlesTestMode() bool {
+	return true
+}
+
 func (g *GetWorkflowUsage) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
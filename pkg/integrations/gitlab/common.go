@@ -137,3 +137,16 @@ func normalizePipelineRef(ref string) string {
 
 	return ref
 }
+
+// parseUserIDs converts the selected member resources into GitLab user IDs.
+func parseUserIDs(values []string) []int {
+	var ids []int
+	for _, idStr := range values {
+		var id int
+		if _, err := fmt.Sscanf(idStr, "%d", &id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
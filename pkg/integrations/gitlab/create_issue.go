@@ -197,14 +197,6 @@ func (c *CreateIssue) Execute(ctx core.ExecutionContext) error {
 		return fmt.Errorf("failed to initialize GitLab client: %w", err)
 	}
 
-	var assigneeIDs []int
-	for _, idStr := range config.Assignees {
-		var id int
-		if _, err := fmt.Sscanf(idStr, "%d", &id); err == nil {
-			assigneeIDs = append(assigneeIDs, id)
-		}
-	}
-
 	var milestoneID *int
 	if config.Milestone != "" {
 		var id int
@@ -217,7 +209,7 @@ func (c *CreateIssue) Execute(ctx core.ExecutionContext) error {
 		Title:       config.Title,
 		Description: config.Body,
 		Labels:      strings.Join(config.Labels, ","),
-		AssigneeIDs: assigneeIDs,
+		AssigneeIDs: parseUserIDs(config.Assignees),
 		MilestoneID: milestoneID,
 		DueDate:     config.DueDate,
 	}
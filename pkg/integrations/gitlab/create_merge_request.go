@@ -0,0 +1,288 @@
+package gitlab
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+//go:embed example_output_create_merge_request.json
+var exampleOutputCreateMergeRequest []byte
+
+type CreateMergeRequest struct{}
+
+type CreateMergeRequestConfiguration struct {
+	Project            string   `mapstructure:"project"`
+	SourceBranch       string   `mapstructure:"sourceBranch"`
+	TargetBranch       string   `mapstructure:"targetBranch"`
+	Title              string   `mapstructure:"title"`
+	Body               string   `mapstructure:"body"`
+	Assignees          []string `mapstructure:"assignees"`
+	Reviewers          []string `mapstructure:"reviewers"`
+	Labels             []string `mapstructure:"labels"`
+	RemoveSourceBranch bool     `mapstructure:"removeSourceBranch"`
+	Squash             bool     `mapstructure:"squash"`
+}
+
+func (c *CreateMergeRequest) Name() string {
+	return "gitlab.createMergeRequest"
+}
+
+func (c *CreateMergeRequest) Label() string {
+	return "Create Merge Request"
+}
+
+func (c *CreateMergeRequest) Description() string {
+	return "Create a new merge request in a GitLab project"
+}
+
+func (c *CreateMergeRequest) Documentation() string {
+	return `The Create Merge Request component opens a merge request in a specified GitLab project.
+
+## Use Cases
+
+- **Automated updates**: Open merge requests for dependency or configuration bumps
+- **Promotion flows**: Propose promoting changes from a staging branch to production
+- **Release preparation**: Open a merge request from a release branch once checks pass
+
+## Configuration
+
+- **Project** (required): The GitLab project where the merge request will be created
+- **Source Branch** (required): The branch containing the changes
+- **Target Branch** (required): The branch to merge the changes into
+- **Title** (required): The title of the merge request. Prefix it with "Draft:" to open a draft merge request
+- **Description** (optional): The description of the merge request
+- **Assignees** (optional): Users to assign the merge request to
+- **Reviewers** (optional): Users to request a review from
+- **Labels** (optional): Labels to apply to the merge request
+- **Delete source branch** (optional): Delete the source branch when the merge request is merged
+- **Squash commits** (optional): Squash the commits when the merge request is merged
+
+## Output
+
+The component outputs the created merge request object, including:
+- **id**: The internal ID of the merge request
+- **iid**: The project-relative ID of the merge request
+- **web_url**: The URL to view the merge request in GitLab
+- **state**: The current state of the merge request
+- **sha**: The head commit of the source branch
+
+## Notes
+
+- GitLab rejects the request if an open merge request already exists for the same source and target branches`
+}
+
+func (c *CreateMergeRequest) Icon() string {
+	return "gitlab"
+}
+
+func (c *CreateMergeRequest) Color() string {
+	return "orange"
+}
+
+func (c *CreateMergeRequest) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateMergeRequest) ExampleOutput() map[string]any {
+	var example map[string]any
+	if err := json.Unmarshal(exampleOutputCreateMergeRequest, &example); err != nil {
+		return map[string]any{}
+	}
+	return example
+}
+
+func (c *CreateMergeRequest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "project",
+			Label:    "Project",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeProject,
+				},
+			},
+		},
+		{
+			Name:        "sourceBranch",
+			Label:       "Source Branch",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "feature/my-change",
+		},
+		{
+			Name:     "targetBranch",
+			Label:    "Target Branch",
+			Type:     configuration.FieldTypeString,
+			Required: true,
+			Default:  "main",
+		},
+		{
+			Name:     "title",
+			Label:    "Title",
+			Type:     configuration.FieldTypeString,
+			Required: true,
+		},
+		{
+			Name:     "body",
+			Label:    "Description",
+			Type:     configuration.FieldTypeText,
+			Required: false,
+		},
+		{
+			Name:     "assignees",
+			Label:    "Assignees",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:  ResourceTypeMember,
+					Multi: true,
+				},
+			},
+		},
+		{
+			Name:     "reviewers",
+			Label:    "Reviewers",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:  ResourceTypeMember,
+					Multi: true,
+				},
+			},
+		},
+		{
+			Name:     "labels",
+			Label:    "Labels",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Label",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:     "removeSourceBranch",
+			Label:    "Delete source branch",
+			Type:     configuration.FieldTypeBool,
+			Required: false,
+			Default:  false,
+		},
+		{
+			Name:     "squash",
+			Label:    "Squash commits",
+			Type:     configuration.FieldTypeBool,
+			Required: false,
+			Default:  false,
+		},
+	}
+}
+
+func (c *CreateMergeRequest) Setup(ctx core.SetupContext) error {
+	var config CreateMergeRequestConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	if config.SourceBranch == "" {
+		return fmt.Errorf("source branch is required")
+	}
+
+	if config.TargetBranch == "" {
+		return fmt.Errorf("target branch is required")
+	}
+
+	if config.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+
+	return ensureProjectInMetadata(
+		ctx.Metadata,
+		ctx.Integration,
+		config.Project,
+	)
+}
+
+func (c *CreateMergeRequest) Execute(ctx core.ExecutionContext) error {
+	var config CreateMergeRequestConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	sourceBranch := normalizePipelineRef(strings.TrimSpace(config.SourceBranch))
+	targetBranch := normalizePipelineRef(strings.TrimSpace(config.TargetBranch))
+	if sourceBranch == targetBranch {
+		return fmt.Errorf("source and target branch must be different")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to initialize GitLab client: %w", err)
+	}
+
+	req := &MergeRequestRequest{
+		SourceBranch:       sourceBranch,
+		TargetBranch:       targetBranch,
+		Title:              config.Title,
+		Description:        config.Body,
+		Labels:             strings.Join(config.Labels, ","),
+		AssigneeIDs:        parseUserIDs(config.Assignees),
+		ReviewerIDs:        parseUserIDs(config.Reviewers),
+		RemoveSourceBranch: config.RemoveSourceBranch,
+		Squash:             config.Squash,
+	}
+
+	mergeRequest, err := client.CreateMergeRequest(context.Background(), config.Project, req)
+	if err != nil {
+		return fmt.Errorf("failed to create merge request: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gitlab.mergeRequest",
+		[]any{mergeRequest},
+	)
+}
+
+func (c *CreateMergeRequest) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateMergeRequest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *CreateMergeRequest) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *CreateMergeRequest) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CreateMergeRequest) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateMergeRequest) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,182 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateMergeRequest__Setup(t *testing.T) {
+	c := &CreateMergeRequest{}
+
+	t.Run("missing project", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"sourceBranch": "feature",
+				"targetBranch": "main",
+				"title":        "MR Title",
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "project is required")
+	})
+
+	t.Run("missing source branch", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"project":      "123",
+				"targetBranch": "main",
+				"title":        "MR Title",
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "source branch is required")
+	})
+
+	t.Run("missing title", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"project":      "123",
+				"sourceBranch": "feature",
+				"targetBranch": "main",
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "title is required")
+	})
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := c.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"project":      "123",
+				"sourceBranch": "feature",
+				"targetBranch": "main",
+				"title":        "MR Title",
+			},
+			Integration: &contexts.IntegrationContext{
+				Metadata: Metadata{
+					Projects: []ProjectMetadata{
+						{ID: 123, Name: "repo", URL: "http://repo"},
+					},
+				},
+			},
+			Metadata: &contexts.MetadataContext{},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__CreateMergeRequest__Execute(t *testing.T) {
+	c := &CreateMergeRequest{}
+	integration := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"authType":    AuthTypePersonalAccessToken,
+			"groupId":     "123",
+			"accessToken": "pat",
+			"baseUrl":     "https://gitlab.com",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				GitlabMockResponse(http.StatusCreated, `{
+					"id": 101,
+					"iid": 12,
+					"title": "MR Title",
+					"source_branch": "feature",
+					"target_branch": "main",
+					"web_url": "https://gitlab.com/group/repo/-/merge_requests/12"
+				}`),
+			},
+		}
+
+		err := c.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"project":            "123",
+				"sourceBranch":       "refs/heads/feature",
+				"targetBranch":       "main",
+				"title":              "MR Title",
+				"body":               "MR Body",
+				"assignees":          []string{"7"},
+				"reviewers":          []string{"8", "9"},
+				"labels":             []string{"bug", "deps"},
+				"removeSourceBranch": true,
+			},
+			Integration:    integration,
+			HTTP:           httpContext,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://gitlab.com/api/v4/projects/123/merge_requests", req.URL.String())
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		var sent map[string]any
+		require.NoError(t, json.Unmarshal(body, &sent))
+		assert.Equal(t, "feature", sent["source_branch"])
+		assert.Equal(t, "main", sent["target_branch"])
+		assert.Equal(t, "MR Body", sent["description"])
+		assert.Equal(t, "bug,deps", sent["labels"])
+		assert.Equal(t, []any{float64(7)}, sent["assignee_ids"])
+		assert.Equal(t, []any{float64(8), float64(9)}, sent["reviewer_ids"])
+		assert.Equal(t, true, sent["remove_source_branch"])
+		assert.NotContains(t, sent, "squash")
+
+		assert.Equal(t, core.DefaultOutputChannel.Name, executionState.Channel)
+		assert.Equal(t, "gitlab.mergeRequest", executionState.Type)
+		require.Len(t, executionState.Payloads, 1)
+		mergeRequest := executionState.Payloads[0].(map[string]any)["data"].(*MergeRequest)
+		assert.Equal(t, 12, mergeRequest.IID)
+	})
+
+	t.Run("same source and target branch", func(t *testing.T) {
+		err := c.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"project":      "123",
+				"sourceBranch": "main",
+				"targetBranch": "refs/heads/main",
+				"title":        "MR Title",
+			},
+			Integration: integration,
+			HTTP:        &contexts.HTTPContext{},
+		})
+
+		require.ErrorContains(t, err, "source and target branch must be different")
+	})
+
+	t.Run("merge request already exists", func(t *testing.T) {
+		err := c.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"project":      "123",
+				"sourceBranch": "feature",
+				"targetBranch": "main",
+				"title":        "MR Title",
+			},
+			Integration: integration,
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					GitlabMockResponse(http.StatusConflict, `{"message": ["Another open merge request already exists for this source branch: !11"]}`),
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "status 409")
+		assert.Contains(t, err.Error(), "Another open merge request already exists")
+	})
+}
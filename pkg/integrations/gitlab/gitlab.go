@@ -169,6 +169,7 @@ func (g *GitLab) Configuration() []configuration.Field {
 func (g *GitLab) Components() []core.Component {
 	return []core.Component{
 		&CreateIssue{},
+		&CreateMergeRequest{},
 		&RunPipeline{},
 		&GetPipeline{},
 		&GetLatestPipeline{},
@@ -0,0 +1,177 @@
+package grafana
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type AnnotateDeployment struct{}
+
+type AnnotateDeploymentSpec struct {
+	DashboardUID *string `json:"dashboardUid,omitempty"`
+	Tags         *string `json:"tags,omitempty"`
+}
+
+func (a *AnnotateDeployment) Name() string {
+	return "grafana.annotateDeployment"
+}
+
+func (a *AnnotateDeployment) Label() string {
+	return "Annotate Deployment"
+}
+
+func (a *AnnotateDeployment) Description() string {
+	return "Mark a deployment on Grafana dashboards with an annotation"
+}
+
+func (a *AnnotateDeployment) Documentation() string {
+	return `The Annotate Deployment component creates a Grafana annotation for a deployment, so it shows up as a marker on the graphs of the service.
+
+## Use Cases
+
+- **Deployment markers**: See on the dashboards when each version of a service was deployed
+- **Incident review**: Find the deployment that happened right before a regression
+
+## Configuration
+
+- **Service**, **Version**, **Environment**: The deployed service, its version, and the environment it was deployed to
+- **Status**: Started, succeeded, failed or rolled back
+- **URL** and **Description**: Optional link and details added to the annotation text
+- **Dashboard UID**: Optional dashboard to add the annotation to. Without it, the annotation is organization-wide
+- **Tags**: Optional comma-separated list of additional tags
+
+The same deployment fields are used by the Datadog and Slack deployment components,
+so they can be mapped the same way for all of them.
+
+## Annotation
+
+The annotation is tagged with ` + "`service:<service>`, `version:<version>`, `env:<environment>`" + ` and ` + "`deployment_status:<status>`" + `.
+Organization-wide annotations show up on the dashboards that have an annotation query filtering by these tags.
+
+## Output
+
+Returns the ID, time, text and tags of the annotation, with the deployment under ` + "`deployment`" + `.
+`
+}
+
+func (a *AnnotateDeployment) Icon() string {
+	return "rocket"
+}
+
+func (a *AnnotateDeployment) Color() string {
+	return "blue"
+}
+
+func (a *AnnotateDeployment) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (a *AnnotateDeployment) Configuration() []configuration.Field {
+	return append(core.DeploymentConfigurationFields(),
+		configuration.Field{
+			Name:        "dashboardUid",
+			Label:       "Dashboard UID",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Description: "Dashboard to add the annotation to. Leave empty for an organization-wide annotation",
+		},
+		configuration.Field{
+			Name:        "tags",
+			Label:       "Tags",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Comma-separated list of additional tags",
+			Placeholder: "team:payments",
+		},
+	)
+}
+
+func (a *AnnotateDeployment) Setup(ctx core.SetupContext) error {
+	_, err := core.DecodeDeployment(ctx.Configuration)
+	return err
+}
+
+func (a *AnnotateDeployment) Execute(ctx core.ExecutionContext) error {
+	deployment, err := core.DecodeDeployment(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	spec := AnnotateDeploymentSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration, true)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	request := CreateAnnotationRequest{
+		Time: time.Now().UnixMilli(),
+		Tags: deployment.Tags(),
+		Text: deployment.Text(),
+	}
+
+	if spec.DashboardUID != nil {
+		request.DashboardUID = strings.TrimSpace(*spec.DashboardUID)
+	}
+
+	if spec.Tags != nil {
+		for _, tag := range strings.Split(*spec.Tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				request.Tags = append(request.Tags, tag)
+			}
+		}
+	}
+
+	id, err := client.CreateAnnotation(request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"grafana.annotation",
+		[]any{map[string]any{
+			"id":           id,
+			"time":         request.Time,
+			"dashboardUid": request.DashboardUID,
+			"text":         request.Text,
+			"tags":         request.Tags,
+			"deployment":   deployment.ToMap(),
+		}},
+	)
+}
+
+func (a *AnnotateDeployment) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (a *AnnotateDeployment) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (a *AnnotateDeployment) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (a *AnnotateDeployment) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (a *AnnotateDeployment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (a *AnnotateDeployment) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
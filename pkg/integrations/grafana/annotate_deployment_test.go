@@ -0,0 +1,132 @@
+package grafana
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__AnnotateDeployment__Setup(t *testing.T) {
+	component := AnnotateDeployment{}
+
+	t.Run("environment is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"service": "checkout",
+				"version": "v1.4.2",
+			},
+		})
+
+		require.ErrorContains(t, err, "environment is required")
+	})
+
+	t.Run("valid configuration passes", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"service":     "checkout",
+				"version":     "v1.4.2",
+				"environment": "production",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__AnnotateDeployment__Execute(t *testing.T) {
+	component := AnnotateDeployment{}
+	integration := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"apiToken": "token123",
+			"baseURL":  "https://grafana.example.com",
+		},
+	}
+
+	t.Run("creates annotation and emits it", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"message": "Annotation added", "id": 1842}`)),
+				},
+			},
+		}
+
+		execCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"service":      "checkout",
+				"version":      "v1.4.2",
+				"environment":  "production",
+				"status":       "rolled_back",
+				"description":  "Error rate above 5%",
+				"dashboardUid": "checkout-overview",
+				"tags":         "team:payments, ",
+			},
+			HTTP:           httpContext,
+			Integration:    integration,
+			ExecutionState: execCtx,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, execCtx.Passed)
+		assert.Equal(t, "grafana.annotation", execCtx.Type)
+
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://grafana.example.com/api/annotations", req.URL.String())
+		assert.Equal(t, "Bearer token123", req.Header.Get("Authorization"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		request := CreateAnnotationRequest{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, "checkout-overview", request.DashboardUID)
+		assert.Equal(t, "Rolled back checkout v1.4.2 in production\n\nError rate above 5%", request.Text)
+		assert.Equal(t, []string{
+			"service:checkout",
+			"version:v1.4.2",
+			"env:production",
+			"deployment_status:rolled_back",
+			"team:payments",
+		}, request.Tags)
+		assert.NotZero(t, request.Time)
+
+		require.Len(t, execCtx.Payloads, 1)
+		payload := execCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, int64(1842), payload["id"])
+		assert.Equal(t, "rolled_back", payload["deployment"].(map[string]any)["status"])
+	})
+
+	t.Run("API error is returned", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"message": "Permission denied"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"service":     "checkout",
+				"version":     "v1.4.2",
+				"environment": "production",
+			},
+			HTTP:           httpContext,
+			Integration:    integration,
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "grafana annotation create failed with status 403")
+	})
+}
@@ -343,3 +343,41 @@ func (c *Client) ListDataSources() ([]DataSource, error) {
 
 	return sources, nil
 }
+
+type CreateAnnotationRequest struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	Time         int64    `json:"time"`
+	Tags         []string `json:"tags,omitempty"`
+	Text         string   `json:"text"`
+}
+
+/*
+ * CreateAnnotation creates an annotation and returns its ID.
+ * Annotations without a dashboard are organization-wide,
+ * and show up in every dashboard that queries annotations by tags.
+ */
+func (c *Client) CreateAnnotation(request CreateAnnotationRequest) (int64, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return 0, fmt.Errorf("error marshaling annotation: %v", err)
+	}
+
+	responseBody, status, err := c.execRequest(http.MethodPost, "/api/annotations", bytes.NewReader(body), "application/json")
+	if err != nil {
+		return 0, fmt.Errorf("error creating annotation: %v", err)
+	}
+
+	if status < 200 || status >= 300 {
+		return 0, newAPIStatusError("grafana annotation create", status, responseBody)
+	}
+
+	response := struct {
+		ID int64 `json:"id"`
+	}{}
+
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return 0, fmt.Errorf("error parsing annotation response: %v", err)
+	}
+
+	return response.ID, nil
+}
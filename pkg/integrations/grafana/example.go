@@ -10,12 +10,18 @@ import (
 //go:embed example_output_query_data_source.json
 var exampleOutputQueryDataSourceBytes []byte
 
+//go:embed example_output_annotate_deployment.json
+var exampleOutputAnnotateDeploymentBytes []byte
+
 //go:embed example_data_on_alert_firing.json
 var exampleDataOnAlertFiringBytes []byte
 
 var exampleOutputQueryDataSourceOnce sync.Once
 var exampleOutputQueryDataSource map[string]any
 
+var exampleOutputAnnotateDeploymentOnce sync.Once
+var exampleOutputAnnotateDeployment map[string]any
+
 var exampleDataOnAlertFiringOnce sync.Once
 var exampleDataOnAlertFiring map[string]any
 
@@ -23,6 +29,10 @@ func (q *QueryDataSource) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputQueryDataSourceOnce, exampleOutputQueryDataSourceBytes, &exampleOutputQueryDataSource)
 }
 
+func (a *AnnotateDeployment) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputAnnotateDeploymentOnce, exampleOutputAnnotateDeploymentBytes, &exampleOutputAnnotateDeployment)
+}
+
 func (t *OnAlertFiring) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnAlertFiringOnce, exampleDataOnAlertFiringBytes, &exampleDataOnAlertFiring)
 }
@@ -81,6 +81,7 @@ func (g *Grafana) HandleAction(ctx core.IntegrationActionContext) error {
 func (g *Grafana) Components() []core.Component {
 	return []core.Component{
 		&QueryDataSource{},
+		&AnnotateDeployment{},
 	}
 }
 
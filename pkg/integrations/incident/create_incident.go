@@ -168,10 +168,8 @@ func (c *CreateIncident) Execute(ctx core.ExecutionContext) error {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
-	// Use execution ID as idempotency key so the same workflow run does not create duplicates on retry
-	idempotencyKey := ctx.ID.String()
-
-	incident, err := client.CreateIncident(spec.Name, idempotencyKey, spec.SeverityID, spec.Visibility, spec.Summary)
+	// Use the execution idempotency key so the same workflow run does not create duplicates on retry
+	incident, err := client.CreateIncident(spec.Name, ctx.IdempotencyKey(), spec.SeverityID, spec.Visibility, spec.Summary)
 	if err != nil {
 		return fmt.Errorf("failed to create incident: %w", err)
 	}
@@ -166,6 +166,9 @@ func Test__CreateIncident__Execute(t *testing.T) {
 		req := httpContext.Requests[0]
 		assert.Equal(t, http.MethodPost, req.Method)
 		assert.Equal(t, "https://api.incident.io/v2/incidents", req.URL.String())
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"idempotency_key":"`+execID.String()+`"`)
 		assert.True(t, execStateCtx.Passed)
 		require.Len(t, execStateCtx.Payloads, 1)
 		payload := execStateCtx.Payloads[0].(map[string]any)
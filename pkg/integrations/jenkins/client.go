@@ -0,0 +1,343 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// Tree used to list jobs, descending into up to three levels of folders.
+const jobsTree = "jobs[name,fullName,url,buildable,jobs[name,fullName,url,buildable,jobs[name,fullName,url,buildable]]]"
+
+type Client struct {
+	BaseURL  string
+	Username string
+	APIToken string
+	http     core.HTTPContext
+}
+
+func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	baseURL, err := ctx.GetConfig("url")
+	if err != nil {
+		return nil, fmt.Errorf("error getting url: %v", err)
+	}
+
+	username, err := ctx.GetConfig("username")
+	if err != nil {
+		return nil, fmt.Errorf("error getting username: %v", err)
+	}
+
+	apiToken, err := ctx.GetConfig("apiToken")
+	if err != nil {
+		return nil, fmt.Errorf("error getting API token: %v", err)
+	}
+
+	return &Client{
+		BaseURL:  strings.TrimSuffix(string(baseURL), "/"),
+		Username: string(username),
+		APIToken: string(apiToken),
+		http:     http,
+	}, nil
+}
+
+func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+
+	// Requests authenticated with an API token are exempt from CSRF crumbs.
+	req.SetBasicAuth(c.Username, c.APIToken)
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, []byte, error) {
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing request: %v", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading body: %v", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("request got %d code: %s", res.StatusCode, string(responseBody))
+	}
+
+	return res, responseBody, nil
+}
+
+func (c *Client) getJSON(path string, out any) error {
+	req, err := c.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	_, body, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error parsing response: %v", err)
+	}
+
+	return nil
+}
+
+// jobPath converts a job full name, e.g. "team/app/deploy",
+// into its URL path, e.g. "/job/team/job/app/job/deploy".
+func jobPath(fullName string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(strings.Trim(fullName, "/"), "/") {
+		b.WriteString("/job/")
+		b.WriteString(url.PathEscape(part))
+	}
+
+	return b.String()
+}
+
+type ServerInfo struct {
+	Mode            string `json:"mode"`
+	NodeDescription string `json:"nodeDescription"`
+	Version         string `json:"-"`
+}
+
+func (c *Client) GetServerInfo() (*ServerInfo, error) {
+	req, err := c.newRequest(http.MethodGet, "/api/json?tree=mode,nodeDescription", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	info := ServerInfo{}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	info.Version = res.Header.Get("X-Jenkins")
+	return &info, nil
+}
+
+type Job struct {
+	Name      string `json:"name"`
+	FullName  string `json:"fullName"`
+	URL       string `json:"url"`
+	Buildable bool   `json:"buildable"`
+	Jobs      []Job  `json:"jobs"`
+}
+
+// ListJobs returns all buildable jobs, including the ones inside folders.
+func (c *Client) ListJobs() ([]Job, error) {
+	response := struct {
+		Jobs []Job `json:"jobs"`
+	}{}
+
+	if err := c.getJSON("/api/json?tree="+url.QueryEscape(jobsTree), &response); err != nil {
+		return nil, err
+	}
+
+	return flattenJobs(response.Jobs), nil
+}
+
+func flattenJobs(jobs []Job) []Job {
+	result := []Job{}
+	for _, job := range jobs {
+		if len(job.Jobs) > 0 {
+			result = append(result, flattenJobs(job.Jobs)...)
+			continue
+		}
+
+		if job.Buildable {
+			result = append(result, Job{Name: job.Name, FullName: job.FullName, URL: job.URL, Buildable: true})
+		}
+	}
+
+	return result
+}
+
+type JobDetails struct {
+	Name       string           `json:"name"`
+	FullName   string           `json:"fullName"`
+	URL        string           `json:"url"`
+	Buildable  bool             `json:"buildable"`
+	Properties []map[string]any `json:"property"`
+}
+
+// IsParameterized reports whether the job defines build parameters.
+func (j *JobDetails) IsParameterized() bool {
+	for _, property := range j.Properties {
+		if definitions, ok := property["parameterDefinitions"].([]any); ok && len(definitions) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Client) GetJob(fullName string) (*JobDetails, error) {
+	job := JobDetails{}
+	tree := "name,fullName,url,buildable,property[parameterDefinitions[name]]"
+	if err := c.getJSON(jobPath(fullName)+"/api/json?tree="+url.QueryEscape(tree), &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// TriggerBuild queues a build of the job and returns the ID of the queue item.
+// Parameterized jobs are always started through buildWithParameters,
+// so parameters that are not given use their default values.
+func (c *Client) TriggerBuild(fullName string, parameterized bool, parameters map[string]string) (int64, error) {
+	path := jobPath(fullName) + "/build"
+	var body io.Reader
+	if parameterized || len(parameters) > 0 {
+		path = jobPath(fullName) + "/buildWithParameters"
+		form := url.Values{}
+		for name, value := range parameters {
+			form.Set(name, value)
+		}
+
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := c.newRequest(http.MethodPost, path, body)
+	if err != nil {
+		return 0, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	res, _, err := c.do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseQueueItemID(res.Header.Get("Location"))
+}
+
+// parseQueueItemID extracts the ID from a queue item location,
+// e.g. "https://jenkins.example.com/queue/item/42/".
+func parseQueueItemID(location string) (int64, error) {
+	_, after, found := strings.Cut(location, "/queue/item/")
+	if !found {
+		return 0, fmt.Errorf("unexpected queue item location %q", location)
+	}
+
+	id, err := strconv.ParseInt(strings.Trim(after, "/"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected queue item location %q", location)
+	}
+
+	return id, nil
+}
+
+type QueueItem struct {
+	ID         int64            `json:"id"`
+	Why        string           `json:"why"`
+	Blocked    bool             `json:"blocked"`
+	Cancelled  bool             `json:"cancelled"`
+	Executable *QueueExecutable `json:"executable"`
+}
+
+type QueueExecutable struct {
+	Number int64  `json:"number"`
+	URL    string `json:"url"`
+}
+
+func (c *Client) GetQueueItem(id int64) (*QueueItem, error) {
+	item := QueueItem{}
+	if err := c.getJSON(fmt.Sprintf("/queue/item/%d/api/json", id), &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *Client) CancelQueueItem(id int64) error {
+	req, err := c.newRequest(http.MethodPost, fmt.Sprintf("/queue/cancelItem?id=%d", id), nil)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.do(req)
+	return err
+}
+
+type Build struct {
+	Number      int64  `json:"number"`
+	URL         string `json:"url"`
+	DisplayName string `json:"displayName"`
+	Result      string `json:"result"`
+	Building    bool   `json:"building"`
+	Duration    int64  `json:"duration"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+func (c *Client) GetBuild(fullName string, number int64) (*Build, error) {
+	build := Build{}
+	tree := "number,url,displayName,result,building,duration,timestamp"
+	if err := c.getJSON(fmt.Sprintf("%s/%d/api/json?tree=%s", jobPath(fullName), number, url.QueryEscape(tree)), &build); err != nil {
+		return nil, err
+	}
+
+	return &build, nil
+}
+
+func (c *Client) StopBuild(fullName string, number int64) error {
+	req, err := c.newRequest(http.MethodPost, fmt.Sprintf("%s/%d/stop", jobPath(fullName), number), nil)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.do(req)
+	return err
+}
+
+type ConsoleChunk struct {
+	Text     string
+	Next     int64
+	MoreData bool
+}
+
+// GetConsoleOutput returns the console output of a build starting at the given byte offset.
+func (c *Client) GetConsoleOutput(fullName string, number int64, start int64) (*ConsoleChunk, error) {
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("%s/%d/logText/progressiveText?start=%d", jobPath(fullName), number, start), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	next := start + int64(len(body))
+	if size := res.Header.Get("X-Text-Size"); size != "" {
+		if parsed, err := strconv.ParseInt(size, 10, 64); err == nil {
+			next = parsed
+		}
+	}
+
+	return &ConsoleChunk{
+		Text:     string(body),
+		Next:     next,
+		MoreData: res.Header.Get("X-More-Data") == "true",
+	}, nil
+}
@@ -0,0 +1,18 @@
+package jenkins
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_trigger_job.json
+var exampleOutputTriggerJobBytes []byte
+
+var exampleOutputTriggerJobOnce sync.Once
+var exampleOutputTriggerJob map[string]any
+
+func (t *TriggerJob) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputTriggerJobOnce, exampleOutputTriggerJobBytes, &exampleOutputTriggerJob)
+}
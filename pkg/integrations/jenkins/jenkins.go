@@ -0,0 +1,179 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ResourceTypeJob = "job"
+
+const installationInstructions = `
+To connect Jenkins, create an API token:
+
+1. In Jenkins, click your user name in the top right corner and go to **Security**.
+2. Under **API Token**, click **Add new Token**, give it a name and click **Generate**.
+3. Copy the token and paste it into the configuration for this integration, together with your Jenkins URL and user name.
+
+The user needs the **Overall/Read**, **Job/Read**, **Job/Build** and **Job/Cancel** permissions on the jobs SuperPlane should run.
+`
+
+func init() {
+	registry.RegisterIntegration("jenkins", &Jenkins{})
+}
+
+type Jenkins struct{}
+
+type Configuration struct {
+	URL      string `json:"url" mapstructure:"url"`
+	Username string `json:"username" mapstructure:"username"`
+	APIToken string `json:"apiToken" mapstructure:"apiToken"`
+}
+
+type Metadata struct {
+	Version string `json:"version" mapstructure:"version"`
+}
+
+func (j *Jenkins) Name() string {
+	return "jenkins"
+}
+
+func (j *Jenkins) Label() string {
+	return "Jenkins"
+}
+
+func (j *Jenkins) Icon() string {
+	return "workflow"
+}
+
+func (j *Jenkins) Description() string {
+	return "Run and monitor Jenkins jobs"
+}
+
+func (j *Jenkins) Instructions() string {
+	return installationInstructions
+}
+
+func (j *Jenkins) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "url",
+			Label:       "URL",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "URL of your Jenkins controller",
+			Placeholder: "e.g. https://jenkins.example.com",
+		},
+		{
+			Name:        "username",
+			Label:       "Username",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Jenkins user the API token belongs to",
+		},
+		{
+			Name:        "apiToken",
+			Label:       "API Token",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Jenkins API token",
+		},
+	}
+}
+
+func (j *Jenkins) Components() []core.Component {
+	return []core.Component{
+		&TriggerJob{},
+	}
+}
+
+func (j *Jenkins) Triggers() []core.Trigger {
+	return []core.Trigger{}
+}
+
+func (j *Jenkins) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (j *Jenkins) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode config: %v", err)
+	}
+
+	if config.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	parsedURL, err := url.Parse(config.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+
+	if config.Username == "" {
+		return fmt.Errorf("username is required")
+	}
+
+	if config.APIToken == "" {
+		return fmt.Errorf("API token is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	info, err := client.GetServerInfo()
+	if err != nil {
+		return fmt.Errorf("error verifying credentials: %v", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{Version: info.Version})
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (j *Jenkins) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	if resourceType != ResourceTypeJob {
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	jobs, err := client.ListJobs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(jobs))
+	for _, job := range jobs {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeJob,
+			Name: job.FullName,
+			ID:   job.FullName,
+		})
+	}
+
+	return resources, nil
+}
+
+func (j *Jenkins) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (j *Jenkins) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (j *Jenkins) HandleAction(ctx core.IntegrationActionContext) error {
+	return nil
+}
@@ -0,0 +1,112 @@
+package jenkins
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegrationContext() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"url":      "https://jenkins.example.com/",
+			"username": "superplane",
+			"apiToken": "token",
+		},
+	}
+}
+
+func Test__Jenkins__Sync(t *testing.T) {
+	integration := &Jenkins{}
+
+	t.Run("valid credentials -> ready", func(t *testing.T) {
+		response := jsonResponse(http.StatusOK, `{"mode":"NORMAL","nodeDescription":"the Jenkins controller"}`)
+		response.Header.Set("X-Jenkins", "2.479.1")
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{response}}
+
+		integrationCtx := testIntegrationContext()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, Metadata{Version: "2.479.1"}, integrationCtx.Metadata)
+
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://jenkins.example.com/api/json?tree=mode,nodeDescription", httpCtx.Requests[0].URL.String())
+		username, password, ok := httpCtx.Requests[0].BasicAuth()
+		require.True(t, ok)
+		assert.Equal(t, "superplane", username)
+		assert.Equal(t, "token", password)
+	})
+
+	t.Run("invalid credentials -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusUnauthorized, `Unauthorized`)},
+		}
+
+		integrationCtx := testIntegrationContext()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "401")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("invalid url -> error", func(t *testing.T) {
+		err := integration.Sync(core.SyncContext{
+			Configuration: map[string]any{"url": "jenkins.example.com", "username": "superplane", "apiToken": "token"},
+			Integration:   &contexts.IntegrationContext{},
+		})
+
+		require.ErrorContains(t, err, "url must be an http or https URL")
+	})
+}
+
+func Test__Jenkins__ListResources(t *testing.T) {
+	integration := &Jenkins{}
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"jobs":[
+				{"name":"build","fullName":"build","buildable":true},
+				{"name":"disabled","fullName":"disabled","buildable":false},
+				{"name":"platform","fullName":"platform","jobs":[
+					{"name":"checkout","fullName":"platform/checkout","jobs":[
+						{"name":"deploy","fullName":"platform/checkout/deploy","buildable":true}
+					]}
+				]}
+			]}`),
+		},
+	}
+
+	resources, err := integration.ListResources(ResourceTypeJob, core.ListResourcesContext{
+		HTTP:        httpCtx,
+		Integration: testIntegrationContext(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []core.IntegrationResource{
+		{Type: ResourceTypeJob, Name: "build", ID: "build"},
+		{Type: ResourceTypeJob, Name: "platform/checkout/deploy", ID: "platform/checkout/deploy"},
+	}, resources)
+}
@@ -0,0 +1,421 @@
+package jenkins
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	PayloadType         = "jenkins.build.finished"
+	PassedOutputChannel = "passed"
+	FailedOutputChannel = "failed"
+	PollInterval        = 10 * time.Second
+
+	BuildResultSuccess   = "SUCCESS"
+	BuildResultCancelled = "CANCELLED"
+
+	// Only the end of the console output is kept in the execution metadata and output.
+	MaxConsoleTailBytes = 8 * 1024
+)
+
+type TriggerJob struct{}
+
+type TriggerJobSpec struct {
+	Job        string      `json:"job" mapstructure:"job"`
+	Parameters []Parameter `json:"parameters" mapstructure:"parameters"`
+}
+
+type Parameter struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type TriggerJobNodeMetadata struct {
+	Job string `json:"job" mapstructure:"job"`
+	URL string `json:"url" mapstructure:"url"`
+}
+
+type TriggerJobExecutionMetadata struct {
+	Job           string         `json:"job" mapstructure:"job"`
+	JobURL        string         `json:"jobUrl" mapstructure:"jobUrl"`
+	QueueItemID   int64          `json:"queueItemId" mapstructure:"queueItemId"`
+	Build         *BuildMetadata `json:"build,omitempty" mapstructure:"build"`
+	ConsoleOffset int64          `json:"consoleOffset" mapstructure:"consoleOffset"`
+	Console       string         `json:"console" mapstructure:"console"`
+}
+
+type BuildMetadata struct {
+	Number int64  `json:"number" mapstructure:"number"`
+	URL    string `json:"url" mapstructure:"url"`
+}
+
+func (t *TriggerJob) Name() string {
+	return "jenkins.triggerJob"
+}
+
+func (t *TriggerJob) Label() string {
+	return "Trigger Job"
+}
+
+func (t *TriggerJob) Description() string {
+	return "Run a Jenkins job and wait for the build to finish"
+}
+
+func (t *TriggerJob) Documentation() string {
+	return `The Trigger Job component starts a Jenkins build and waits for it to finish.
+
+## Use Cases
+
+- **Legacy CI orchestration**: Run existing Jenkins jobs next to CodePipeline, Cloud Build or GitHub Actions
+- **Deployments**: Start a parameterized deployment job and continue the workflow based on its result
+- **Release pipelines**: Chain Jenkins builds with approvals and other workflow steps
+
+## How It Works
+
+1. Queues a build of the selected job with the given parameters
+2. Follows the queue item until Jenkins starts the build
+3. Polls the build, collecting the console output while it runs
+4. Routes execution based on the build result:
+   - **Passed channel**: The build finished with SUCCESS
+   - **Failed channel**: The build finished with any other result, or the queue item was cancelled
+
+## Configuration
+
+- **Job**: Jenkins job to run. Jobs inside folders are listed by their full name, e.g. team/app/deploy.
+- **Parameters**: Build parameters as name/value pairs (supports expressions). Parameters that are not set use the job's default values.
+
+## Output
+
+- **job**: Job name and URL
+- **build**: Build number, URL, result, duration and start timestamp
+- **console**: The last 8 KiB of the console output
+
+Cancelling the execution aborts the build, or removes it from the queue if it has not started yet.`
+}
+
+func (t *TriggerJob) Icon() string {
+	return "workflow"
+}
+
+func (t *TriggerJob) Color() string {
+	return "gray"
+}
+
+func (t *TriggerJob) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{
+			Name:  PassedOutputChannel,
+			Label: "Passed",
+		},
+		{
+			Name:  FailedOutputChannel,
+			Label: "Failed",
+		},
+	}
+}
+
+func (t *TriggerJob) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "job",
+			Label:       "Job",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Jenkins job to run",
+			Placeholder: "Select a job",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeJob,
+				},
+			},
+		},
+		{
+			Name:  "parameters",
+			Label: "Parameters",
+			Type:  configuration.FieldTypeList,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Parameter",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *TriggerJob) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (t *TriggerJob) Setup(ctx core.SetupContext) error {
+	spec := TriggerJobSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Job) == "" {
+		return errors.New("job is required")
+	}
+
+	for _, parameter := range spec.Parameters {
+		if strings.TrimSpace(parameter.Name) == "" {
+			return errors.New("parameter name is required")
+		}
+	}
+
+	metadata := TriggerJobNodeMetadata{}
+	err = mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Job == spec.Job {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	job, err := client.GetJob(spec.Job)
+	if err != nil {
+		return fmt.Errorf("job %s not found or inaccessible: %w", spec.Job, err)
+	}
+
+	if !job.Buildable {
+		return fmt.Errorf("job %s is not buildable", spec.Job)
+	}
+
+	return ctx.Metadata.Set(TriggerJobNodeMetadata{Job: job.FullName, URL: job.URL})
+}
+
+func (t *TriggerJob) Execute(ctx core.ExecutionContext) error {
+	spec := TriggerJobSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	job, err := client.GetJob(spec.Job)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get job %s: %v", spec.Job, err))
+	}
+
+	parameters := map[string]string{}
+	for _, parameter := range spec.Parameters {
+		parameters[strings.TrimSpace(parameter.Name)] = parameter.Value
+	}
+
+	queueItemID, err := client.TriggerBuild(spec.Job, job.IsParameterized(), parameters)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to trigger job %s: %v", spec.Job, err))
+	}
+
+	err = ctx.Metadata.Set(TriggerJobExecutionMetadata{
+		Job:         spec.Job,
+		JobURL:      job.URL,
+		QueueItemID: queueItemID,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error setting metadata: %v", err)
+	}
+
+	return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, PollInterval)
+}
+
+func (t *TriggerJob) Cancel(ctx core.ExecutionContext) error {
+	metadata := TriggerJobExecutionMetadata{}
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Job == "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if metadata.Build != nil {
+		return client.StopBuild(metadata.Job, metadata.Build.Number)
+	}
+
+	return client.CancelQueueItem(metadata.QueueItemID)
+}
+
+func (t *TriggerJob) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (t *TriggerJob) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:           "poll",
+			UserAccessible: false,
+		},
+	}
+}
+
+func (t *TriggerJob) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case "poll":
+		return t.poll(ctx)
+	}
+
+	return fmt.Errorf("unknown action: %s", ctx.Name)
+}
+
+func (t *TriggerJob) poll(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := TriggerJobExecutionMetadata{}
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if metadata.Build == nil {
+		item, err := client.GetQueueItem(metadata.QueueItemID)
+		if err != nil {
+			return fmt.Errorf("failed to get queue item %d: %w", metadata.QueueItemID, err)
+		}
+
+		if item.Cancelled {
+			return ctx.ExecutionState.Emit(FailedOutputChannel, PayloadType, []any{
+				buildPayload(metadata, &Build{Result: BuildResultCancelled}),
+			})
+		}
+
+		if item.Executable == nil {
+			return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, PollInterval)
+		}
+
+		metadata.Build = &BuildMetadata{
+			Number: item.Executable.Number,
+			URL:    item.Executable.URL,
+		}
+	}
+
+	build, err := client.GetBuild(metadata.Job, metadata.Build.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get build %d: %w", metadata.Build.Number, err)
+	}
+
+	// Read the console after the build status, so a finished build has its full output.
+	if err := readConsole(client, &metadata); err != nil {
+		return err
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("error setting metadata: %v", err)
+	}
+
+	if build.Building || build.Result == "" {
+		return ctx.Requests.ScheduleActionCall("poll", map[string]any{}, PollInterval)
+	}
+
+	channel := PassedOutputChannel
+	if build.Result != BuildResultSuccess {
+		channel = FailedOutputChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, PayloadType, []any{buildPayload(metadata, build)})
+}
+
+func readConsole(client *Client, metadata *TriggerJobExecutionMetadata) error {
+	for {
+		chunk, err := client.GetConsoleOutput(metadata.Job, metadata.Build.Number, metadata.ConsoleOffset)
+		if err != nil {
+			return fmt.Errorf("failed to get console output: %w", err)
+		}
+
+		metadata.Console = consoleTail(metadata.Console + chunk.Text)
+		progressed := chunk.Next > metadata.ConsoleOffset
+		metadata.ConsoleOffset = chunk.Next
+
+		if !chunk.MoreData || !progressed {
+			return nil
+		}
+	}
+}
+
+// consoleTail keeps the last MaxConsoleTailBytes of the console output,
+// starting at a line boundary when possible.
+func consoleTail(console string) string {
+	if len(console) <= MaxConsoleTailBytes {
+		return console
+	}
+
+	tail := console[len(console)-MaxConsoleTailBytes:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		return tail[i+1:]
+	}
+
+	return tail
+}
+
+func buildPayload(metadata TriggerJobExecutionMetadata, build *Build) map[string]any {
+	payload := map[string]any{
+		"job": map[string]any{
+			"name": metadata.Job,
+			"url":  metadata.JobURL,
+		},
+		"build": map[string]any{
+			"number":      build.Number,
+			"url":         build.URL,
+			"displayName": build.DisplayName,
+			"result":      build.Result,
+			"duration":    build.Duration,
+			"timestamp":   build.Timestamp,
+		},
+		"console": metadata.Console,
+	}
+
+	return payload
+}
+
+func (t *TriggerJob) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,277 @@
+package jenkins
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testJobResponse = `{
+	"name": "deploy",
+	"fullName": "platform/deploy",
+	"url": "https://jenkins.example.com/job/platform/job/deploy/",
+	"buildable": true,
+	"property": [{"_class": "hudson.model.ParametersDefinitionProperty", "parameterDefinitions": [{"name": "ENVIRONMENT"}]}]
+}`
+
+func consoleResponse(text string, size int, more bool) *http.Response {
+	response := jsonResponse(http.StatusOK, text)
+	response.Header.Set("X-Text-Size", strconv.Itoa(size))
+	if more {
+		response.Header.Set("X-More-Data", "true")
+	}
+
+	return response
+}
+
+func Test__TriggerJob__Setup(t *testing.T) {
+	component := &TriggerJob{}
+
+	t.Run("missing job -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{},
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "job is required")
+	})
+
+	t.Run("stores job metadata", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusOK, testJobResponse)},
+		}
+
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"job": "platform/deploy"},
+			HTTP:          httpCtx,
+			Integration:   testIntegrationContext(),
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, TriggerJobNodeMetadata{
+			Job: "platform/deploy",
+			URL: "https://jenkins.example.com/job/platform/job/deploy/",
+		}, metadata.Metadata)
+		assert.True(t, strings.HasPrefix(httpCtx.Requests[0].URL.Path, "/job/platform/job/deploy/api/json"))
+	})
+}
+
+func Test__TriggerJob__Execute(t *testing.T) {
+	component := &TriggerJob{}
+
+	created := jsonResponse(http.StatusCreated, "")
+	created.Header.Set("Location", "https://jenkins.example.com/queue/item/42/")
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, testJobResponse),
+			created,
+		},
+	}
+
+	metadata := &contexts.MetadataContext{}
+	requests := &contexts.RequestContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"job":        "platform/deploy",
+			"parameters": []any{map[string]any{"name": "ENVIRONMENT", "value": "production"}},
+		},
+		HTTP:           httpCtx,
+		Integration:    testIntegrationContext(),
+		Metadata:       metadata,
+		Requests:       requests,
+		ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "poll", requests.Action)
+	assert.Equal(t, int64(42), metadata.Metadata.(TriggerJobExecutionMetadata).QueueItemID)
+
+	require.Len(t, httpCtx.Requests, 2)
+	assert.Equal(t, http.MethodPost, httpCtx.Requests[1].Method)
+	assert.Equal(t, "https://jenkins.example.com/job/platform/job/deploy/buildWithParameters", httpCtx.Requests[1].URL.String())
+	body, err := io.ReadAll(httpCtx.Requests[1].Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ENVIRONMENT=production", string(body))
+}
+
+func Test__TriggerJob__Poll(t *testing.T) {
+	component := &TriggerJob{}
+
+	queued := map[string]any{
+		"job":         "platform/deploy",
+		"jobUrl":      "https://jenkins.example.com/job/platform/job/deploy/",
+		"queueItemId": 42,
+	}
+
+	t.Run("still queued -> polls again", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":42,"why":"Waiting for next available executor"}`),
+			},
+		}
+
+		requests := &contexts.RequestContext{}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "poll",
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: queued},
+			Requests:       requests,
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Finished)
+		assert.Equal(t, "poll", requests.Action)
+		assert.Equal(t, "https://jenkins.example.com/queue/item/42/api/json", httpCtx.Requests[0].URL.String())
+	})
+
+	t.Run("queue item cancelled -> failed channel", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":42,"cancelled":true}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "poll",
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: queued},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, state.Channel)
+	})
+
+	t.Run("build running -> collects console and polls again", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":42,"executable":{"number":142,"url":"https://jenkins.example.com/job/platform/job/deploy/142/"}}`),
+				jsonResponse(http.StatusOK, `{"number":142,"building":true}`),
+				consoleResponse("Started\n", 8, true),
+				consoleResponse("", 8, true),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{Metadata: queued}
+		requests := &contexts.RequestContext{}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           "poll",
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Finished)
+		assert.Equal(t, "poll", requests.Action)
+
+		updated := metadata.Metadata.(TriggerJobExecutionMetadata)
+		require.NotNil(t, updated.Build)
+		assert.Equal(t, int64(142), updated.Build.Number)
+		assert.Equal(t, int64(8), updated.ConsoleOffset)
+		assert.Equal(t, "Started\n", updated.Console)
+		assert.Equal(t, "https://jenkins.example.com/job/platform/job/deploy/142/logText/progressiveText?start=0", httpCtx.Requests[2].URL.String())
+		assert.Equal(t, "https://jenkins.example.com/job/platform/job/deploy/142/logText/progressiveText?start=8", httpCtx.Requests[3].URL.String())
+	})
+
+	for _, tc := range []struct {
+		result  string
+		channel string
+	}{
+		{result: "SUCCESS", channel: PassedOutputChannel},
+		{result: "FAILURE", channel: FailedOutputChannel},
+		{result: "UNSTABLE", channel: FailedOutputChannel},
+		{result: "ABORTED", channel: FailedOutputChannel},
+	} {
+		t.Run(tc.result+" -> "+tc.channel, func(t *testing.T) {
+			httpCtx := &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusOK, `{"number":142,"url":"https://jenkins.example.com/job/platform/job/deploy/142/","building":false,"result":"`+tc.result+`","duration":187342}`),
+					consoleResponse("Finished: "+tc.result+"\n", 120, false),
+				},
+			}
+
+			state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+			err := component.HandleAction(core.ActionContext{
+				Name:        "poll",
+				HTTP:        httpCtx,
+				Integration: testIntegrationContext(),
+				Metadata: &contexts.MetadataContext{Metadata: map[string]any{
+					"job":           "platform/deploy",
+					"queueItemId":   42,
+					"build":         map[string]any{"number": 142},
+					"consoleOffset": 100,
+					"console":       "Started\n",
+				}},
+				Requests:       &contexts.RequestContext{},
+				ExecutionState: state,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.channel, state.Channel)
+			assert.Equal(t, PayloadType, state.Type)
+
+			payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+			assert.Equal(t, tc.result, payload["build"].(map[string]any)["result"])
+			assert.Equal(t, "Started\nFinished: "+tc.result+"\n", payload["console"])
+		})
+	}
+}
+
+func Test__TriggerJob__Cancel(t *testing.T) {
+	component := &TriggerJob{}
+
+	t.Run("queued -> cancels queue item", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusNoContent, "")}}
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Metadata:    &contexts.MetadataContext{Metadata: map[string]any{"job": "platform/deploy", "queueItemId": 42}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://jenkins.example.com/queue/cancelItem?id=42", httpCtx.Requests[0].URL.String())
+	})
+
+	t.Run("running -> stops build", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, "")}}
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Metadata: &contexts.MetadataContext{Metadata: map[string]any{
+				"job":   "platform/deploy",
+				"build": map[string]any{"number": 142},
+			}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://jenkins.example.com/job/platform/job/deploy/142/stop", httpCtx.Requests[0].URL.String())
+	})
+}
+
+func Test__ConsoleTail(t *testing.T) {
+	short := "line 1\nline 2\n"
+	assert.Equal(t, short, consoleTail(short))
+
+	long := strings.Repeat("x", MaxConsoleTailBytes) + "\nlast line\n"
+	tail := consoleTail(long)
+	assert.Equal(t, "last line\n", tail)
+}
@@ -0,0 +1,302 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ApplyManifestPayloadType = "kubernetes.manifest.applied"
+	maxManifestObjects       = 100
+)
+
+type ApplyManifest struct{}
+
+type ApplyManifestConfiguration struct {
+	Connection `mapstructure:",squash"`
+	Manifest   string `json:"manifest" mapstructure:"manifest"`
+	Namespace  string `json:"namespace" mapstructure:"namespace"`
+	Force      bool   `json:"force" mapstructure:"force"`
+}
+
+func (c *ApplyManifest) Name() string {
+	return "kubernetes.applyManifest"
+}
+
+func (c *ApplyManifest) Label() string {
+	return "Apply Manifest"
+}
+
+func (c *ApplyManifest) Description() string {
+	return "Create or update Kubernetes objects from a YAML manifest"
+}
+
+func (c *ApplyManifest) Documentation() string {
+	return `The Apply Manifest component creates or updates Kubernetes objects from a manifest, like ` + "`kubectl apply`" + `.
+
+## Use Cases
+
+- **Deployments**: Update the image of a deployment after a build
+- **Environment setup**: Create namespaces, config maps and secrets for a new environment
+- **Bootstrap**: Install workloads in a cluster created earlier in the workflow
+
+## How It Works
+
+Objects are applied with server-side apply, using ` + "`superplane`" + ` as the field manager. Objects that don't exist are created, and existing objects are updated with the fields in the manifest.
+
+## Configuration
+
+- **Manifest** (required): One or more YAML documents separated by ` + "`---`" + `. Supports expressions
+- **Namespace** (optional): Namespace for namespaced objects that don't specify one. Defaults to the namespace of the kubeconfig context, or ` + "`default`" + `
+- **Force conflicts**: Take ownership of fields managed by other tools, like ` + "`kubectl apply --force-conflicts`" + `
+- **Kubeconfig** (optional): Connect with this kubeconfig instead of the integration, e.g. the short-lived kubeconfig of EKS • Get Cluster Credentials
+
+## Output
+
+The list of applied objects, with their apiVersion, kind, namespace, name, uid and resourceVersion.
+
+## Notes
+
+- Objects are applied in the order they appear in the manifest, so namespaces and CRDs should come first
+- If an object fails to apply, the execution fails and the following objects are not applied`
+}
+
+func (c *ApplyManifest) Icon() string {
+	return "kubernetes"
+}
+
+func (c *ApplyManifest) Color() string {
+	return "blue"
+}
+
+func (c *ApplyManifest) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ApplyManifest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "manifest",
+			Label:       "Manifest",
+			Type:        configuration.FieldTypeText,
+			Required:    true,
+			Description: "YAML manifest with one or more objects",
+			Placeholder: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-config\ndata:\n  key: value",
+		},
+		namespaceField(false, "Namespace for objects that don't specify one"),
+		{
+			Name:        "force",
+			Label:       "Force conflicts",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Take ownership of fields managed by other tools",
+		},
+		kubeconfigField(),
+	}
+}
+
+// parseManifest decodes all the objects in a multi-document YAML manifest.
+func parseManifest(manifest string) ([]map[string]any, error) {
+	if strings.TrimSpace(manifest) == "" {
+		return nil, errors.New("manifest is required")
+	}
+
+	decoder := yaml.NewDecoder(strings.NewReader(manifest))
+	objects := []map[string]any{}
+
+	for {
+		object := map[string]any{}
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+
+		// Empty documents, e.g. a trailing "---"
+		if len(object) == 0 {
+			continue
+		}
+
+		index := len(objects)
+		apiVersion, _ := object["apiVersion"].(string)
+		kind, _ := object["kind"].(string)
+		if apiVersion == "" || kind == "" {
+			return nil, fmt.Errorf("object %d: apiVersion and kind are required", index)
+		}
+
+		metadata, _ := object["metadata"].(map[string]any)
+		if name, _ := metadata["name"].(string); name == "" {
+			return nil, fmt.Errorf("object %d: metadata.name is required", index)
+		}
+
+		objects = append(objects, object)
+	}
+
+	if len(objects) == 0 {
+		return nil, errors.New("manifest has no objects")
+	}
+
+	if len(objects) > maxManifestObjects {
+		return nil, fmt.Errorf("manifest has %d objects, maximum is %d", len(objects), maxManifestObjects)
+	}
+
+	return objects, nil
+}
+
+func (c *ApplyManifest) Setup(ctx core.SetupContext) error {
+	config := ApplyManifestConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	//
+	// Manifests built from expressions can only be validated on execution.
+	//
+	if strings.Contains(config.Manifest, "{{") {
+		return nil
+	}
+
+	_, err := parseManifest(config.Manifest)
+	return err
+}
+
+func (c *ApplyManifest) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ApplyManifest) Execute(ctx core.ExecutionContext) error {
+	config := ApplyManifestConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	objects, err := parseManifest(config.Manifest)
+	if err != nil {
+		return err
+	}
+
+	client, err := config.client(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	defaultNamespace := strings.TrimSpace(config.Namespace)
+	if defaultNamespace == "" {
+		defaultNamespace = client.Namespace
+	}
+
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+
+	discovered := map[string][]APIResource{}
+	applied := make([]map[string]any, 0, len(objects))
+
+	for i, object := range objects {
+		apiVersion := object["apiVersion"].(string)
+		kind := object["kind"].(string)
+		metadata := object["metadata"].(map[string]any)
+		name := metadata["name"].(string)
+
+		resources, ok := discovered[apiVersion]
+		if !ok {
+			resources, err = client.DiscoverResources(apiVersion)
+			if err != nil {
+				return fmt.Errorf("object %d (%s %s): failed to discover API %s: %w", i, kind, name, apiVersion, err)
+			}
+
+			discovered[apiVersion] = resources
+		}
+
+		resource, err := findResourceForKind(resources, kind)
+		if err != nil {
+			return fmt.Errorf("object %d (%s %s): %w", i, kind, name, err)
+		}
+
+		namespace := ""
+		if resource.Namespaced {
+			namespace, _ = metadata["namespace"].(string)
+			if namespace == "" {
+				namespace = defaultNamespace
+				metadata["namespace"] = namespace
+			}
+		}
+
+		result, err := client.ApplyObject(ObjectPath(apiVersion, *resource, namespace, name), object, config.Force)
+		if err != nil {
+			return fmt.Errorf("object %d (%s %s): failed to apply, %d objects applied before it: %w", i, kind, name, len(applied), err)
+		}
+
+		applied = append(applied, appliedObjectSummary(result))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		ApplyManifestPayloadType,
+		[]any{map[string]any{"objects": applied}},
+	)
+}
+
+func findResourceForKind(resources []APIResource, kind string) (*APIResource, error) {
+	for i := range resources {
+		// Subresources, like deployments/scale, share the kind of their parent.
+		if strings.Contains(resources[i].Name, "/") {
+			continue
+		}
+
+		if resources[i].Kind == kind {
+			return &resources[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("kind %s is not served by the cluster", kind)
+}
+
+func appliedObjectSummary(object map[string]any) map[string]any {
+	metadata, _ := object["metadata"].(map[string]any)
+	summary := map[string]any{
+		"apiVersion":      object["apiVersion"],
+		"kind":            object["kind"],
+		"name":            metadata["name"],
+		"uid":             metadata["uid"],
+		"resourceVersion": metadata["resourceVersion"],
+	}
+
+	if namespace, ok := metadata["namespace"]; ok {
+		summary["namespace"] = namespace
+	}
+
+	return summary
+}
+
+func (c *ApplyManifest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *ApplyManifest) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *ApplyManifest) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ApplyManifest) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ApplyManifest) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
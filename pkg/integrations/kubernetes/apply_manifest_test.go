@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: api-config
+data:
+  LOG_LEVEL: info
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: team-a
+---
+`
+
+func Test__ApplyManifest__Setup(t *testing.T) {
+	component := &ApplyManifest{}
+
+	t.Run("valid manifest -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"manifest": testManifest}})
+		require.NoError(t, err)
+	})
+
+	t.Run("manifest with expressions -> not validated", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"manifest": "{{ $['Build'].data.manifest }}"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid yaml -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"manifest": "kind: [Deployment"}})
+		require.ErrorContains(t, err, "invalid manifest")
+	})
+
+	t.Run("object without name -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"manifest": "apiVersion: v1\nkind: ConfigMap\n"}})
+		require.ErrorContains(t, err, "metadata.name is required")
+	})
+}
+
+func Test__ApplyManifest__Execute(t *testing.T) {
+	component := &ApplyManifest{}
+
+	t.Run("applies namespaced and cluster-scoped objects", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"resources":[
+					{"name":"configmaps","kind":"ConfigMap","namespaced":true},
+					{"name":"namespaces","kind":"Namespace","namespaced":false},
+					{"name":"namespaces/status","kind":"Namespace","namespaced":false}
+				]}`),
+				jsonResponse(http.StatusOK, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"api-config","namespace":"production","uid":"uid-1","resourceVersion":"10"}}`),
+				jsonResponse(http.StatusOK, `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"team-a","uid":"uid-2","resourceVersion":"11"}}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"manifest": testManifest, "namespace": "production", "force": true},
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, core.DefaultOutputChannel.Name, state.Channel)
+		assert.Equal(t, ApplyManifestPayloadType, state.Type)
+
+		// API discovery is done once per apiVersion
+		require.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, "/api/v1", httpCtx.Requests[0].URL.Path)
+
+		apply := httpCtx.Requests[1]
+		assert.Equal(t, http.MethodPatch, apply.Method)
+		assert.Equal(t, "/api/v1/namespaces/production/configmaps/api-config", apply.URL.Path)
+		assert.Equal(t, "application/apply-patch+yaml", apply.Header.Get("Content-Type"))
+		assert.Equal(t, FieldManager, apply.URL.Query().Get("fieldManager"))
+		assert.Equal(t, "true", apply.URL.Query().Get("force"))
+
+		body, err := io.ReadAll(apply.Body)
+		require.NoError(t, err)
+		object := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &object))
+		assert.Equal(t, "production", object["metadata"].(map[string]any)["namespace"])
+
+		assert.Equal(t, "/api/v1/namespaces/team-a", httpCtx.Requests[2].URL.Path)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		objects := payload["objects"].([]map[string]any)
+		require.Len(t, objects, 2)
+		assert.Equal(t, "uid-1", objects[0]["uid"])
+		assert.Equal(t, "production", objects[0]["namespace"])
+		assert.NotContains(t, objects[1], "namespace")
+	})
+
+	t.Run("unknown kind -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"resources":[{"name":"pods","kind":"Pod","namespaced":true}]}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"manifest": testManifest},
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			ExecutionState: state,
+		})
+
+		require.ErrorContains(t, err, "kind ConfigMap is not served by the cluster")
+		assert.False(t, state.Finished)
+	})
+}
@@ -0,0 +1,512 @@
+package kubernetes
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ConnectionMethodToken      = "token"
+	ConnectionMethodKubeconfig = "kubeconfig"
+
+	FieldManager = "superplane"
+)
+
+type Client struct {
+	Server    string
+	Token     string
+	Namespace string
+	http      core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+// tlsConfigurable is implemented by HTTP contexts that can connect
+// to servers using private certificate authorities or client certificates.
+type tlsConfigurable interface {
+	WithTLSConfig(tlsConfig *tls.Config) core.HTTPContext
+}
+
+type ServerVersion struct {
+	Major      string `json:"major"`
+	Minor      string `json:"minor"`
+	GitVersion string `json:"gitVersion"`
+	Platform   string `json:"platform"`
+}
+
+type ObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace,omitempty"`
+	UID               string            `json:"uid,omitempty"`
+	ResourceVersion   string            `json:"resourceVersion,omitempty"`
+	Generation        int64             `json:"generation,omitempty"`
+	CreationTimestamp string            `json:"creationTimestamp,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+}
+
+type Namespace struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Status   struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+type Deployment struct {
+	Metadata ObjectMeta       `json:"metadata"`
+	Spec     DeploymentSpec   `json:"spec"`
+	Status   DeploymentStatus `json:"status"`
+}
+
+type DeploymentSpec struct {
+	Replicas *int32 `json:"replicas,omitempty"`
+	Paused   bool   `json:"paused,omitempty"`
+}
+
+type DeploymentStatus struct {
+	ObservedGeneration  int64                 `json:"observedGeneration"`
+	Replicas            int32                 `json:"replicas"`
+	UpdatedReplicas     int32                 `json:"updatedReplicas"`
+	ReadyReplicas       int32                 `json:"readyReplicas"`
+	AvailableReplicas   int32                 `json:"availableReplicas"`
+	UnavailableReplicas int32                 `json:"unavailableReplicas"`
+	Conditions          []DeploymentCondition `json:"conditions"`
+}
+
+type DeploymentCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+}
+
+type Scale struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas int32 `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Replicas int32 `json:"replicas"`
+	} `json:"status"`
+}
+
+// APIResource describes a resource served by the API, as returned by discovery.
+type APIResource struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Namespaced bool   `json:"namespaced"`
+}
+
+type apiResourceList struct {
+	GroupVersion string        `json:"groupVersion"`
+	Resources    []APIResource `json:"resources"`
+}
+
+type list[T any] struct {
+	Items    []T `json:"items"`
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+}
+
+func NewClient(httpCtx core.HTTPContext, integration core.IntegrationContext) (*Client, error) {
+	config, err := ClusterConfigFromIntegration(integration)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientFromClusterConfig(httpCtx, config)
+}
+
+func NewClientFromClusterConfig(httpCtx core.HTTPContext, config *ClusterConfig) (*Client, error) {
+	if config.Server == "" {
+		return nil, fmt.Errorf("server is required")
+	}
+
+	serverURL, err := url.Parse(config.Server)
+	if err != nil || serverURL.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q", config.Server)
+	}
+
+	if serverURL.Scheme != "https" {
+		return nil, fmt.Errorf("server URL must use https")
+	}
+
+	tlsConfig, err := buildTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		if configurable, ok := httpCtx.(tlsConfigurable); ok {
+			httpCtx = configurable.WithTLSConfig(tlsConfig)
+		}
+	}
+
+	return &Client{
+		Server:    strings.TrimRight(config.Server, "/"),
+		Token:     config.Token,
+		Namespace: config.Namespace,
+		http:      httpCtx,
+	}, nil
+}
+
+// ClusterConfigFromIntegration reads the cluster connection from the integration configuration.
+func ClusterConfigFromIntegration(integration core.IntegrationContext) (*ClusterConfig, error) {
+	if integration == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	method := ConnectionMethodToken
+	if value, err := integration.GetConfig("connectionMethod"); err == nil && len(value) > 0 {
+		method = string(value)
+	}
+
+	switch method {
+	case ConnectionMethodKubeconfig:
+		data, err := integration.GetConfig("kubeconfig")
+		if err != nil {
+			return nil, err
+		}
+
+		kubeconfig, err := decodeKubeconfig(string(data))
+		if err != nil {
+			return nil, err
+		}
+
+		contextName := ""
+		if value, err := integration.GetConfig("context"); err == nil {
+			contextName = strings.TrimSpace(string(value))
+		}
+
+		return ParseKubeconfig(kubeconfig, contextName)
+
+	case ConnectionMethodToken:
+		server, err := integration.GetConfig("server")
+		if err != nil {
+			return nil, err
+		}
+
+		token, err := integration.GetConfig("token")
+		if err != nil {
+			return nil, err
+		}
+
+		config := &ClusterConfig{
+			Server: strings.TrimRight(strings.TrimSpace(string(server)), "/"),
+			Token:  strings.TrimSpace(string(token)),
+		}
+
+		if config.Token == "" {
+			return nil, fmt.Errorf("token is required")
+		}
+
+		if value, err := integration.GetConfig("certificateAuthorityData"); err == nil && len(bytes.TrimSpace(value)) > 0 {
+			config.CertificateAuthority, err = decodeBase64Field("certificate authority data", string(value))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return config, nil
+
+	default:
+		return nil, fmt.Errorf("unknown connection method %q", method)
+	}
+}
+
+// decodeKubeconfig accepts the kubeconfig as YAML, JSON or base64-encoded.
+func decodeKubeconfig(data string) (string, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return "", fmt.Errorf("kubeconfig is required")
+	}
+
+	if decoded, err := base64.StdEncoding.DecodeString(data); err == nil {
+		return string(decoded), nil
+	}
+
+	return data, nil
+}
+
+func buildTLSConfig(config *ClusterConfig) (*tls.Config, error) {
+	if len(config.CertificateAuthority) == 0 && len(config.ClientCertificate) == 0 && !config.InsecureSkipTLSVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402 -- only when explicitly set in the kubeconfig.
+		InsecureSkipVerify: config.InsecureSkipTLSVerify,
+	}
+
+	if len(config.CertificateAuthority) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CertificateAuthority) {
+			return nil, fmt.Errorf("invalid certificate authority data")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(config.ClientCertificate) > 0 {
+		certificate, err := tls.X509KeyPair(config.ClientCertificate, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+func (c *Client) GetVersion() (*ServerVersion, error) {
+	body, err := c.execRequest(http.MethodGet, "/version", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	version := ServerVersion{}
+	if err := json.Unmarshal(body, &version); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal version response: %w", err)
+	}
+
+	return &version, nil
+}
+
+func (c *Client) ListNamespaces() ([]Namespace, error) {
+	return listAll[Namespace](c, "/api/v1/namespaces")
+}
+
+func (c *Client) ListDeployments(namespace string) ([]Deployment, error) {
+	return listAll[Deployment](c, fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments", url.PathEscape(namespace)))
+}
+
+func (c *Client) GetDeployment(namespace, name string) (*Deployment, error) {
+	body, err := c.execRequest(http.MethodGet, deploymentPath(namespace, name), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := Deployment{}
+	if err := json.Unmarshal(body, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal deployment response: %w", err)
+	}
+
+	return &deployment, nil
+}
+
+// RestartDeployment triggers a rollout the same way `kubectl rollout restart` does,
+// by updating an annotation on the pod template.
+func (c *Client) RestartDeployment(namespace, name, restartedAt string) (*Deployment, error) {
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{
+						"kubectl.kubernetes.io/restartedAt": restartedAt,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := c.execRequest(http.MethodPatch, deploymentPath(namespace, name), "application/strategic-merge-patch+json", patch)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := Deployment{}
+	if err := json.Unmarshal(body, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal deployment response: %w", err)
+	}
+
+	return &deployment, nil
+}
+
+func (c *Client) GetDeploymentScale(namespace, name string) (*Scale, error) {
+	body, err := c.execRequest(http.MethodGet, deploymentPath(namespace, name)+"/scale", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := Scale{}
+	if err := json.Unmarshal(body, &scale); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scale response: %w", err)
+	}
+
+	return &scale, nil
+}
+
+func (c *Client) ScaleDeployment(namespace, name string, replicas int32) (*Scale, error) {
+	patch := map[string]any{
+		"spec": map[string]any{"replicas": replicas},
+	}
+
+	body, err := c.execRequest(http.MethodPatch, deploymentPath(namespace, name)+"/scale", "application/merge-patch+json", patch)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := Scale{}
+	if err := json.Unmarshal(body, &scale); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scale response: %w", err)
+	}
+
+	return &scale, nil
+}
+
+// DiscoverResources lists the resources served for an API group version, e.g. "v1" or "apps/v1".
+func (c *Client) DiscoverResources(apiVersion string) ([]APIResource, error) {
+	path := "/apis/" + apiVersion
+	if !strings.Contains(apiVersion, "/") {
+		path = "/api/" + apiVersion
+	}
+
+	body, err := c.execRequest(http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := apiResourceList{}
+	if err := json.Unmarshal(body, &resources); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal discovery response: %w", err)
+	}
+
+	return resources.Resources, nil
+}
+
+// ApplyObject creates or updates an object with server-side apply.
+// The path is the object path, as returned by ObjectPath.
+func (c *Client) ApplyObject(path string, object map[string]any, force bool) (map[string]any, error) {
+	query := url.Values{}
+	query.Set("fieldManager", FieldManager)
+	if force {
+		query.Set("force", "true")
+	}
+
+	body, err := c.execRequest(http.MethodPatch, path+"?"+query.Encode(), "application/apply-patch+yaml", object)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[string]any{}
+	if err := json.Unmarshal(body, &applied); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal apply response: %w", err)
+	}
+
+	return applied, nil
+}
+
+// ObjectPath returns the API path of an object.
+func ObjectPath(apiVersion string, resource APIResource, namespace, name string) string {
+	prefix := "/apis/" + apiVersion
+	if !strings.Contains(apiVersion, "/") {
+		prefix = "/api/" + apiVersion
+	}
+
+	if resource.Namespaced {
+		return fmt.Sprintf("%s/namespaces/%s/%s/%s", prefix, url.PathEscape(namespace), resource.Name, url.PathEscape(name))
+	}
+
+	return fmt.Sprintf("%s/%s/%s", prefix, resource.Name, url.PathEscape(name))
+}
+
+func deploymentPath(namespace, name string) string {
+	return fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", url.PathEscape(namespace), url.PathEscape(name))
+}
+
+func listAll[T any](c *Client, path string) ([]T, error) {
+	items := []T{}
+	continueToken := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", "500")
+		if continueToken != "" {
+			query.Set("continue", continueToken)
+		}
+
+		body, err := c.execRequest(http.MethodGet, path+"?"+query.Encode(), "", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page := list[T]{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal list response: %w", err)
+		}
+
+		items = append(items, page.Items...)
+		if page.Metadata.Continue == "" {
+			return items, nil
+		}
+
+		continueToken = page.Metadata.Continue
+	}
+}
+
+func (c *Client) execRequest(method, path, contentType string, payload any) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, c.Server+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	if payload != nil {
+		if contentType == "" {
+			contentType = "application/json"
+		}
+
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, &APIError{StatusCode: res.StatusCode, Body: string(responseBody)}
+	}
+
+	return responseBody, nil
+}
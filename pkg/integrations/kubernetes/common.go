@@ -0,0 +1,175 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func namespaceField(required bool, description string) configuration.Field {
+	return configuration.Field{
+		Name:        "namespace",
+		Label:       "Namespace",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    required,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeNamespace,
+			},
+		},
+	}
+}
+
+func deploymentField() configuration.Field {
+	return configuration.Field{
+		Name:     "deployment",
+		Label:    "Deployment",
+		Type:     configuration.FieldTypeIntegrationResource,
+		Required: true,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeDeployment,
+				Parameters: []configuration.ParameterRef{
+					{
+						Name:      "namespace",
+						ValueFrom: &configuration.ParameterValueFrom{Field: "namespace"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func kubeconfigField() configuration.Field {
+	return configuration.Field{
+		Name:        "kubeconfig",
+		Label:       "Kubeconfig",
+		Type:        configuration.FieldTypeString,
+		Required:    false,
+		Togglable:   true,
+		Description: "Connect with this kubeconfig instead of the integration, e.g. the kubeconfig of EKS • Get Cluster Credentials",
+	}
+}
+
+// Connection is the configuration shared by all components to override
+// the cluster connection of the integration for a single execution.
+type Connection struct {
+	Kubeconfig string `json:"kubeconfig,omitempty" mapstructure:"kubeconfig"`
+}
+
+// client connects with the kubeconfig of the component if it is set,
+// and with the integration otherwise.
+func (c Connection) client(httpCtx core.HTTPContext, integration core.IntegrationContext) (*Client, error) {
+	if strings.TrimSpace(c.Kubeconfig) == "" {
+		return NewClient(httpCtx, integration)
+	}
+
+	data, err := decodeKubeconfig(c.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseKubeconfig(data, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientFromClusterConfig(httpCtx, config)
+}
+
+// DeploymentTarget is the configuration shared by the components acting on a deployment.
+type DeploymentTarget struct {
+	Connection `mapstructure:",squash"`
+	Namespace  string `json:"namespace" mapstructure:"namespace"`
+	Deployment string `json:"deployment" mapstructure:"deployment"`
+}
+
+func (t *DeploymentTarget) validate() error {
+	t.Namespace = strings.TrimSpace(t.Namespace)
+	t.Deployment = strings.TrimSpace(t.Deployment)
+
+	if t.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	if t.Deployment == "" {
+		return fmt.Errorf("deployment is required")
+	}
+
+	return nil
+}
+
+func deploymentPayload(deployment *Deployment) map[string]any {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	conditions := make([]map[string]any, 0, len(deployment.Status.Conditions))
+	for _, condition := range deployment.Status.Conditions {
+		conditions = append(conditions, map[string]any{
+			"type":    condition.Type,
+			"status":  condition.Status,
+			"reason":  condition.Reason,
+			"message": condition.Message,
+		})
+	}
+
+	return map[string]any{
+		"namespace":          deployment.Metadata.Namespace,
+		"name":               deployment.Metadata.Name,
+		"uid":                deployment.Metadata.UID,
+		"generation":         deployment.Metadata.Generation,
+		"observedGeneration": deployment.Status.ObservedGeneration,
+		"replicas":           desired,
+		"updatedReplicas":    deployment.Status.UpdatedReplicas,
+		"readyReplicas":      deployment.Status.ReadyReplicas,
+		"availableReplicas":  deployment.Status.AvailableReplicas,
+		"conditions":         conditions,
+	}
+}
+
+// RolloutStatus follows the same rules as `kubectl rollout status`.
+type RolloutStatus struct {
+	Done    bool
+	Failed  bool
+	Message string
+}
+
+func rolloutStatus(deployment *Deployment) RolloutStatus {
+	if deployment.Metadata.Generation > deployment.Status.ObservedGeneration {
+		return RolloutStatus{Message: "Waiting for deployment spec update to be observed"}
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == "Progressing" && condition.Reason == "ProgressDeadlineExceeded" {
+			return RolloutStatus{
+				Failed:  true,
+				Message: fmt.Sprintf("Deployment %q exceeded its progress deadline", deployment.Metadata.Name),
+			}
+		}
+	}
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	status := deployment.Status
+	if status.UpdatedReplicas < desired {
+		return RolloutStatus{Message: fmt.Sprintf("%d out of %d new replicas have been updated", status.UpdatedReplicas, desired)}
+	}
+
+	if status.Replicas > status.UpdatedReplicas {
+		return RolloutStatus{Message: fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas)}
+	}
+
+	if status.AvailableReplicas < status.UpdatedReplicas {
+		return RolloutStatus{Message: fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas)}
+	}
+
+	return RolloutStatus{Done: true, Message: fmt.Sprintf("Deployment %q successfully rolled out", deployment.Metadata.Name)}
+}
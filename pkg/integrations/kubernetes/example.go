@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_apply_manifest.json
+var exampleOutputApplyManifestBytes []byte
+
+//go:embed example_output_rollout_restart.json
+var exampleOutputRolloutRestartBytes []byte
+
+//go:embed example_output_scale_deployment.json
+var exampleOutputScaleDeploymentBytes []byte
+
+//go:embed example_output_wait_for_rollout.json
+var exampleOutputWaitForRolloutBytes []byte
+
+var exampleOutputApplyManifestOnce sync.Once
+var exampleOutputApplyManifest map[string]any
+
+var exampleOutputRolloutRestartOnce sync.Once
+var exampleOutputRolloutRestart map[string]any
+
+var exampleOutputScaleDeploymentOnce sync.Once
+var exampleOutputScaleDeployment map[string]any
+
+var exampleOutputWaitForRolloutOnce sync.Once
+var exampleOutputWaitForRollout map[string]any
+
+func (c *ApplyManifest) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputApplyManifestOnce,
+		exampleOutputApplyManifestBytes,
+		&exampleOutputApplyManifest,
+	)
+}
+
+func (c *RolloutRestart) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputRolloutRestartOnce,
+		exampleOutputRolloutRestartBytes,
+		&exampleOutputRolloutRestart,
+	)
+}
+
+func (c *ScaleDeployment) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputScaleDeploymentOnce,
+		exampleOutputScaleDeploymentBytes,
+		&exampleOutputScaleDeployment,
+	)
+}
+
+func (c *WaitForRollout) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputWaitForRolloutOnce,
+		exampleOutputWaitForRolloutBytes,
+		&exampleOutputWaitForRollout,
+	)
+}
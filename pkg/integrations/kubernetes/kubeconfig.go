@@ -0,0 +1,177 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ClusterConfig holds everything needed to connect to a cluster,
+// regardless of how the integration was configured.
+type ClusterConfig struct {
+	Server                string
+	Token                 string
+	CertificateAuthority  []byte
+	ClientCertificate     []byte
+	ClientKey             []byte
+	InsecureSkipTLSVerify bool
+	Namespace             string
+}
+
+type kubeconfig struct {
+	CurrentContext string              `yaml:"current-context"`
+	Clusters       []kubeconfigCluster `yaml:"clusters"`
+	Users          []kubeconfigUser    `yaml:"users"`
+	Contexts       []kubeconfigContext `yaml:"contexts"`
+}
+
+type kubeconfigCluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		Server                   string `yaml:"server"`
+		CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		CertificateAuthority     string `yaml:"certificate-authority"`
+		InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+	} `yaml:"cluster"`
+}
+
+type kubeconfigUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		Token                 string         `yaml:"token"`
+		TokenFile             string         `yaml:"tokenFile"`
+		ClientCertificateData string         `yaml:"client-certificate-data"`
+		ClientKeyData         string         `yaml:"client-key-data"`
+		ClientCertificate     string         `yaml:"client-certificate"`
+		Exec                  map[string]any `yaml:"exec"`
+		AuthProvider          map[string]any `yaml:"auth-provider"`
+	} `yaml:"user"`
+}
+
+type kubeconfigContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster   string `yaml:"cluster"`
+		User      string `yaml:"user"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"context"`
+}
+
+// ParseKubeconfig reads the cluster and credentials of a context in a kubeconfig.
+// If contextName is empty, the current context is used.
+// Only self-contained kubeconfigs are supported: credentials must be embedded,
+// since there is no local filesystem or CLI to read them from.
+func ParseKubeconfig(data string, contextName string) (*ClusterConfig, error) {
+	config := kubeconfig{}
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+
+	if contextName == "" {
+		if len(config.Contexts) != 1 {
+			return nil, fmt.Errorf("kubeconfig has no current-context, and a context must be specified")
+		}
+
+		contextName = config.Contexts[0].Name
+	}
+
+	var context *kubeconfigContext
+	for i := range config.Contexts {
+		if config.Contexts[i].Name == contextName {
+			context = &config.Contexts[i]
+			break
+		}
+	}
+
+	if context == nil {
+		return nil, fmt.Errorf("context %q not found in kubeconfig", contextName)
+	}
+
+	var cluster *kubeconfigCluster
+	for i := range config.Clusters {
+		if config.Clusters[i].Name == context.Context.Cluster {
+			cluster = &config.Clusters[i]
+			break
+		}
+	}
+
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig", context.Context.Cluster)
+	}
+
+	var user *kubeconfigUser
+	for i := range config.Users {
+		if config.Users[i].Name == context.Context.User {
+			user = &config.Users[i]
+			break
+		}
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user %q not found in kubeconfig", context.Context.User)
+	}
+
+	if cluster.Cluster.Server == "" {
+		return nil, fmt.Errorf("cluster %q has no server", cluster.Name)
+	}
+
+	if cluster.Cluster.CertificateAuthority != "" {
+		return nil, fmt.Errorf("cluster %q references a certificate authority file, use certificate-authority-data instead", cluster.Name)
+	}
+
+	if len(user.User.Exec) > 0 || len(user.User.AuthProvider) > 0 {
+		return nil, fmt.Errorf("user %q uses an exec or auth-provider plugin, which is not supported. Use a service account token instead", user.Name)
+	}
+
+	if user.User.TokenFile != "" || user.User.ClientCertificate != "" {
+		return nil, fmt.Errorf("user %q references credential files, embed the credentials instead", user.Name)
+	}
+
+	clusterConfig := &ClusterConfig{
+		Server:                strings.TrimRight(cluster.Cluster.Server, "/"),
+		Token:                 user.User.Token,
+		InsecureSkipTLSVerify: cluster.Cluster.InsecureSkipTLSVerify,
+		Namespace:             context.Context.Namespace,
+	}
+
+	var err error
+	clusterConfig.CertificateAuthority, err = decodeBase64Field("certificate-authority-data", cluster.Cluster.CertificateAuthorityData)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterConfig.ClientCertificate, err = decodeBase64Field("client-certificate-data", user.User.ClientCertificateData)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterConfig.ClientKey, err = decodeBase64Field("client-key-data", user.User.ClientKeyData)
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterConfig.Token == "" && len(clusterConfig.ClientCertificate) == 0 {
+		return nil, fmt.Errorf("user %q has no token or client certificate", user.Name)
+	}
+
+	return clusterConfig, nil
+}
+
+func decodeBase64Field(name, value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return decoded, nil
+}
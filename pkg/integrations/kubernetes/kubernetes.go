@@ -0,0 +1,252 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	ResourceTypeNamespace  = "namespace"
+	ResourceTypeDeployment = "deployment"
+)
+
+func init() {
+	registry.RegisterIntegration("kubernetes", &Kubernetes{})
+}
+
+type Kubernetes struct{}
+
+type Configuration struct {
+	ConnectionMethod         string `json:"connectionMethod" mapstructure:"connectionMethod"`
+	Server                   string `json:"server" mapstructure:"server"`
+	Token                    string `json:"token" mapstructure:"token"`
+	CertificateAuthorityData string `json:"certificateAuthorityData" mapstructure:"certificateAuthorityData"`
+	Kubeconfig               string `json:"kubeconfig" mapstructure:"kubeconfig"`
+	Context                  string `json:"context" mapstructure:"context"`
+}
+
+type Metadata struct {
+	Server        string `json:"server" mapstructure:"server"`
+	ServerVersion string `json:"serverVersion" mapstructure:"serverVersion"`
+}
+
+func (k *Kubernetes) Name() string {
+	return "kubernetes"
+}
+
+func (k *Kubernetes) Label() string {
+	return "Kubernetes"
+}
+
+func (k *Kubernetes) Icon() string {
+	return "kubernetes"
+}
+
+func (k *Kubernetes) Description() string {
+	return "Apply manifests and manage deployments in any Kubernetes cluster"
+}
+
+func (k *Kubernetes) Instructions() string {
+	return `## Connection method
+
+### Service account token
+
+1. Create a service account and bind it to a role with the permissions your workflows need:
+   ` + "`kubectl create serviceaccount superplane -n kube-system`" + `
+2. Create a long-lived token for it:
+   ` + "`kubectl create token superplane -n kube-system --duration=8760h`" + `
+3. **Server:** The API server URL, e.g. ` + "`https://my-cluster.example.com:6443`" + `.
+4. **Certificate Authority Data:** The base64-encoded CA of the cluster, as found in ` + "`certificate-authority-data`" + ` in your kubeconfig. Leave empty if the API server uses a publicly trusted certificate.
+
+### Kubeconfig
+
+Paste a self-contained kubeconfig, as YAML, JSON or base64. You can produce one with:
+` + "`kubectl config view --minify --flatten`" + `
+
+Credentials must be embedded in the kubeconfig. Exec plugins like ` + "`aws eks get-token`" + ` or ` + "`gke-gcloud-auth-plugin`" + ` are not supported, use a service account token instead.`
+}
+
+func (k *Kubernetes) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "connectionMethod",
+			Label:       "Connection method",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     ConnectionMethodToken,
+			Description: "Connect with a service account token or a kubeconfig",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Service account token", Value: ConnectionMethodToken},
+						{Label: "Kubeconfig", Value: ConnectionMethodKubeconfig},
+					},
+				},
+			},
+		},
+		{
+			Name:        "server",
+			Label:       "Server",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "https://my-cluster.example.com:6443",
+			Description: "Kubernetes API server URL",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "connectionMethod", Values: []string{ConnectionMethodToken}},
+			},
+		},
+		{
+			Name:        "token",
+			Label:       "Token",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Service account token",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "connectionMethod", Values: []string{ConnectionMethodToken}},
+			},
+		},
+		{
+			Name:        "certificateAuthorityData",
+			Label:       "Certificate Authority Data",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Base64-encoded CA certificate of the cluster",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "connectionMethod", Values: []string{ConnectionMethodToken}},
+			},
+		},
+		{
+			Name:        "kubeconfig",
+			Label:       "Kubeconfig",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Self-contained kubeconfig, as YAML, JSON or base64",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "connectionMethod", Values: []string{ConnectionMethodKubeconfig}},
+			},
+		},
+		{
+			Name:        "context",
+			Label:       "Context",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Kubeconfig context to use. Defaults to the current context",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "connectionMethod", Values: []string{ConnectionMethodKubeconfig}},
+			},
+		},
+	}
+}
+
+func (k *Kubernetes) Components() []core.Component {
+	return []core.Component{
+		&ApplyManifest{},
+		&RolloutRestart{},
+		&ScaleDeployment{},
+		&WaitForRollout{},
+	}
+}
+
+func (k *Kubernetes) Triggers() []core.Trigger {
+	return []core.Trigger{}
+}
+
+func (k *Kubernetes) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (k *Kubernetes) Sync(ctx core.SyncContext) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	version, err := client.GetVersion()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the cluster: %w", err)
+	}
+
+	//
+	// The version endpoint can be readable anonymously,
+	// so we also verify the credentials by listing namespaces.
+	//
+	if _, err := client.ListNamespaces(); err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{
+		Server:        client.Server,
+		ServerVersion: version.GitVersion,
+	})
+
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (k *Kubernetes) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (k *Kubernetes) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resourceType {
+	case ResourceTypeNamespace:
+		namespaces, err := client.ListNamespaces()
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(namespaces))
+		for _, namespace := range namespaces {
+			resources = append(resources, core.IntegrationResource{
+				Type: ResourceTypeNamespace,
+				Name: namespace.Metadata.Name,
+				ID:   namespace.Metadata.Name,
+			})
+		}
+
+		return resources, nil
+
+	case ResourceTypeDeployment:
+		namespace := ctx.Parameters["namespace"]
+		if namespace == "" {
+			return []core.IntegrationResource{}, nil
+		}
+
+		deployments, err := client.ListDeployments(namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(deployments))
+		for _, deployment := range deployments {
+			resources = append(resources, core.IntegrationResource{
+				Type: ResourceTypeDeployment,
+				Name: deployment.Metadata.Name,
+				ID:   deployment.Metadata.Name,
+			})
+		}
+
+		return resources, nil
+
+	default:
+		return []core.IntegrationResource{}, nil
+	}
+}
+
+func (k *Kubernetes) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (k *Kubernetes) HandleAction(ctx core.IntegrationActionContext) error {
+	return nil
+}
@@ -0,0 +1,234 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testIntegrationContext() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"connectionMethod": ConnectionMethodToken,
+			"server":           "https://cluster.example.com:6443",
+			"token":            "token-123",
+		},
+	}
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+  - name: production
+    cluster:
+      server: https://production.example.com
+  - name: staging
+    cluster:
+      server: https://staging.example.com
+      certificate-authority-data: Y2EtZGF0YQ==
+users:
+  - name: deployer
+    user:
+      token: deployer-token
+contexts:
+  - name: production
+    context:
+      cluster: production
+      user: deployer
+      namespace: apps
+  - name: staging
+    context:
+      cluster: staging
+      user: deployer
+`
+
+func Test__ParseKubeconfig(t *testing.T) {
+	t.Run("uses current context by default", func(t *testing.T) {
+		config, err := ParseKubeconfig(testKubeconfig, "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://staging.example.com", config.Server)
+		assert.Equal(t, "deployer-token", config.Token)
+		assert.Equal(t, []byte("ca-data"), config.CertificateAuthority)
+		assert.Empty(t, config.Namespace)
+	})
+
+	t.Run("uses the given context", func(t *testing.T) {
+		config, err := ParseKubeconfig(testKubeconfig, "production")
+		require.NoError(t, err)
+		assert.Equal(t, "https://production.example.com", config.Server)
+		assert.Equal(t, "apps", config.Namespace)
+	})
+
+	t.Run("unknown context -> error", func(t *testing.T) {
+		_, err := ParseKubeconfig(testKubeconfig, "development")
+		require.ErrorContains(t, err, "development")
+	})
+
+	t.Run("exec plugin -> error", func(t *testing.T) {
+		kubeconfig := `
+clusters:
+  - name: eks
+    cluster:
+      server: https://eks.example.com
+users:
+  - name: eks
+    user:
+      exec:
+        command: aws
+contexts:
+  - name: eks
+    context:
+      cluster: eks
+      user: eks
+`
+		_, err := ParseKubeconfig(kubeconfig, "")
+		require.ErrorContains(t, err, "exec")
+	})
+}
+
+func Test__Kubernetes__Sync(t *testing.T) {
+	integration := &Kubernetes{}
+
+	t.Run("valid credentials -> ready", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"major":"1","minor":"30","gitVersion":"v1.30.2"}`),
+				jsonResponse(http.StatusOK, `{"items":[{"metadata":{"name":"default"}}],"metadata":{}}`),
+			},
+		}
+
+		integrationCtx := testIntegrationContext()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, Metadata{Server: "https://cluster.example.com:6443", ServerVersion: "v1.30.2"}, integrationCtx.Metadata)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://cluster.example.com:6443/version", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "Bearer token-123", httpCtx.Requests[0].Header.Get("Authorization"))
+		assert.Equal(t, "/api/v1/namespaces", httpCtx.Requests[1].URL.Path)
+	})
+
+	t.Run("kubeconfig connection -> ready", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"gitVersion":"v1.29.0"}`),
+				jsonResponse(http.StatusOK, `{"items":[],"metadata":{}}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"connectionMethod": ConnectionMethodKubeconfig,
+				"kubeconfig":       base64.StdEncoding.EncodeToString([]byte(testKubeconfig)),
+				"context":          "production",
+			},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, "production.example.com", httpCtx.Requests[0].URL.Host)
+		assert.Equal(t, "Bearer deployer-token", httpCtx.Requests[0].Header.Get("Authorization"))
+	})
+
+	t.Run("http server -> error", func(t *testing.T) {
+		integrationCtx := testIntegrationContext()
+		integrationCtx.Configuration["server"] = "http://cluster.example.com"
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "https")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("unauthorized -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"gitVersion":"v1.30.2"}`),
+				jsonResponse(http.StatusUnauthorized, `{"kind":"Status","message":"Unauthorized"}`),
+			},
+		}
+
+		integrationCtx := testIntegrationContext()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "failed to verify credentials")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+}
+
+func Test__Kubernetes__ListResources(t *testing.T) {
+	integration := &Kubernetes{}
+
+	t.Run("deployments require a namespace", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		resources, err := integration.ListResources(ResourceTypeDeployment, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Parameters:  map[string]string{},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Empty(t, httpCtx.Requests)
+	})
+
+	t.Run("lists deployments across pages", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"items":[{"metadata":{"name":"api"}}],"metadata":{"continue":"next"}}`),
+				jsonResponse(http.StatusOK, `{"items":[{"metadata":{"name":"worker"}}],"metadata":{}}`),
+			},
+		}
+
+		resources, err := integration.ListResources(ResourceTypeDeployment, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Parameters:  map[string]string{"namespace": "production"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "api", resources[0].ID)
+		assert.Equal(t, "worker", resources[1].ID)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "/apis/apps/v1/namespaces/production/deployments", httpCtx.Requests[0].URL.Path)
+		assert.Equal(t, "next", httpCtx.Requests[1].URL.Query().Get("continue"))
+	})
+}
@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const RolloutRestartPayloadType = "kubernetes.deployment.restarted"
+
+type RolloutRestart struct{}
+
+func (c *RolloutRestart) Name() string {
+	return "kubernetes.rolloutRestart"
+}
+
+func (c *RolloutRestart) Label() string {
+	return "Rollout Restart"
+}
+
+func (c *RolloutRestart) Description() string {
+	return "Restart the pods of a Kubernetes deployment"
+}
+
+func (c *RolloutRestart) Documentation() string {
+	return `The Rollout Restart component restarts the pods of a deployment with a rolling update, like ` + "`kubectl rollout restart`" + `.
+
+## Use Cases
+
+- **Configuration reloads**: Restart pods after updating a config map or secret they read on startup
+- **Recovery**: Restart a deployment stuck in a bad state
+- **Refresh images**: Pull the latest version of a mutable image tag
+
+## Configuration
+
+- **Namespace** (required): The namespace of the deployment
+- **Deployment** (required): The deployment to restart
+- **Kubeconfig** (optional): Connect with this kubeconfig instead of the integration, e.g. the short-lived kubeconfig of EKS • Get Cluster Credentials
+
+## Output
+
+The deployment, with its namespace, name and replica counts when the restart was requested.
+
+## Notes
+
+- The component returns once the restart is requested. Use Wait for Rollout to wait for the new pods to be available`
+}
+
+func (c *RolloutRestart) Icon() string {
+	return "kubernetes"
+}
+
+func (c *RolloutRestart) Color() string {
+	return "blue"
+}
+
+func (c *RolloutRestart) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *RolloutRestart) Configuration() []configuration.Field {
+	return []configuration.Field{
+		namespaceField(true, "Namespace of the deployment"),
+		deploymentField(),
+		kubeconfigField(),
+	}
+}
+
+func (c *RolloutRestart) Setup(ctx core.SetupContext) error {
+	target := DeploymentTarget{}
+	if err := mapstructure.Decode(ctx.Configuration, &target); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return target.validate()
+}
+
+func (c *RolloutRestart) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *RolloutRestart) Execute(ctx core.ExecutionContext) error {
+	target := DeploymentTarget{}
+	if err := mapstructure.Decode(ctx.Configuration, &target); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := target.validate(); err != nil {
+		return err
+	}
+
+	client, err := target.client(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := client.RestartDeployment(target.Namespace, target.Deployment, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to restart deployment %s/%s: %w", target.Namespace, target.Deployment, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		RolloutRestartPayloadType,
+		[]any{deploymentPayload(deployment)},
+	)
+}
+
+func (c *RolloutRestart) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *RolloutRestart) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *RolloutRestart) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *RolloutRestart) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *RolloutRestart) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
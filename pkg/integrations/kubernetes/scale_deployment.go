@@ -0,0 +1,177 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ScaleDeploymentPayloadType = "kubernetes.deployment.scaled"
+	MaxReplicas                = 1000
+)
+
+type ScaleDeployment struct{}
+
+type ScaleDeploymentConfiguration struct {
+	DeploymentTarget `mapstructure:",squash"`
+	Replicas         *int `json:"replicas" mapstructure:"replicas"`
+}
+
+func (c *ScaleDeployment) Name() string {
+	return "kubernetes.scaleDeployment"
+}
+
+func (c *ScaleDeployment) Label() string {
+	return "Scale Deployment"
+}
+
+func (c *ScaleDeployment) Description() string {
+	return "Change the number of replicas of a Kubernetes deployment"
+}
+
+func (c *ScaleDeployment) Documentation() string {
+	return `The Scale Deployment component sets the number of replicas of a deployment, like ` + "`kubectl scale`" + `.
+
+## Use Cases
+
+- **Cost savings**: Scale environments down outside working hours and back up in the morning
+- **Events**: Scale up ahead of expected traffic
+- **Maintenance**: Scale a worker to zero while a migration runs
+
+## Configuration
+
+- **Namespace** (required): The namespace of the deployment
+- **Deployment** (required): The deployment to scale
+- **Replicas** (required): The number of replicas. Supports expressions
+- **Kubeconfig** (optional): Connect with this kubeconfig instead of the integration, e.g. the short-lived kubeconfig of EKS • Get Cluster Credentials
+
+## Output
+
+- **namespace** and **name**: The deployment
+- **replicas**: The requested number of replicas
+- **previousReplicas**: The number of replicas before scaling
+
+## Notes
+
+- If a HorizontalPodAutoscaler manages the deployment, it may change the number of replicas again`
+}
+
+func (c *ScaleDeployment) Icon() string {
+	return "kubernetes"
+}
+
+func (c *ScaleDeployment) Color() string {
+	return "blue"
+}
+
+func (c *ScaleDeployment) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ScaleDeployment) Configuration() []configuration.Field {
+	return []configuration.Field{
+		namespaceField(true, "Namespace of the deployment"),
+		deploymentField(),
+		{
+			Name:     "replicas",
+			Label:    "Replicas",
+			Type:     configuration.FieldTypeNumber,
+			Required: true,
+			Default:  "1",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+					Max: func() *int { max := MaxReplicas; return &max }(),
+				},
+			},
+		},
+		kubeconfigField(),
+	}
+}
+
+func decodeScaleDeploymentConfiguration(value any) (ScaleDeploymentConfiguration, error) {
+	config := ScaleDeploymentConfiguration{}
+	if err := mapstructure.WeakDecode(value, &config); err != nil {
+		return config, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := config.validate(); err != nil {
+		return config, err
+	}
+
+	if config.Replicas == nil {
+		return config, fmt.Errorf("replicas is required")
+	}
+
+	if *config.Replicas < 0 || *config.Replicas > MaxReplicas {
+		return config, fmt.Errorf("replicas must be between 0 and %d", MaxReplicas)
+	}
+
+	return config, nil
+}
+
+func (c *ScaleDeployment) Setup(ctx core.SetupContext) error {
+	_, err := decodeScaleDeploymentConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *ScaleDeployment) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ScaleDeployment) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeScaleDeploymentConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := config.client(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.GetDeploymentScale(config.Namespace, config.Deployment)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s/%s: %w", config.Namespace, config.Deployment, err)
+	}
+
+	scale, err := client.ScaleDeployment(config.Namespace, config.Deployment, int32(*config.Replicas))
+	if err != nil {
+		return fmt.Errorf("failed to scale deployment %s/%s: %w", config.Namespace, config.Deployment, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		ScaleDeploymentPayloadType,
+		[]any{map[string]any{
+			"namespace":        config.Namespace,
+			"name":             config.Deployment,
+			"replicas":         scale.Spec.Replicas,
+			"previousReplicas": current.Spec.Replicas,
+		}},
+	)
+}
+
+func (c *ScaleDeployment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *ScaleDeployment) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *ScaleDeployment) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ScaleDeployment) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ScaleDeployment) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ScaleDeployment__Setup(t *testing.T) {
+	component := &ScaleDeployment{}
+
+	t.Run("missing replicas -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"namespace": "production", "deployment": "api"}})
+		require.ErrorContains(t, err, "replicas is required")
+	})
+
+	t.Run("negative replicas -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"namespace": "production", "deployment": "api", "replicas": -1}})
+		require.ErrorContains(t, err, "replicas must be between")
+	})
+
+	t.Run("zero replicas -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"namespace": "production", "deployment": "api", "replicas": "0"}})
+		require.NoError(t, err)
+	})
+}
+
+func Test__ScaleDeployment__Execute(t *testing.T) {
+	component := &ScaleDeployment{}
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"metadata":{"name":"worker"},"spec":{"replicas":2}}`),
+			jsonResponse(http.StatusOK, `{"metadata":{"name":"worker"},"spec":{"replicas":5}}`),
+		},
+	}
+
+	state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+	err := component.Execute(core.ExecutionContext{
+		Configuration:  map[string]any{"namespace": "production", "deployment": "worker", "replicas": 5},
+		HTTP:           httpCtx,
+		Integration:    testIntegrationContext(),
+		ExecutionState: state,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, ScaleDeploymentPayloadType, state.Type)
+
+	require.Len(t, httpCtx.Requests, 2)
+	patch := httpCtx.Requests[1]
+	assert.Equal(t, http.MethodPatch, patch.Method)
+	assert.Equal(t, "/apis/apps/v1/namespaces/production/deployments/worker/scale", patch.URL.Path)
+	assert.Equal(t, "application/merge-patch+json", patch.Header.Get("Content-Type"))
+	body, err := io.ReadAll(patch.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"replicas":5}}`, string(body))
+
+	payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, int32(5), payload["replicas"])
+	assert.Equal(t, int32(2), payload["previousReplicas"])
+}
+
+func Test__ScaleDeployment__ExecuteWithKubeconfig(t *testing.T) {
+	component := &ScaleDeployment{}
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"metadata":{"name":"worker"},"spec":{"replicas":2}}`),
+			jsonResponse(http.StatusOK, `{"metadata":{"name":"worker"},"spec":{"replicas":3}}`),
+		},
+	}
+
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+current-context: eks
+clusters:
+  - name: eks
+    cluster:
+      server: https://ABCDEF.gr7.us-east-1.eks.amazonaws.com
+users:
+  - name: eks
+    user:
+      token: k8s-aws-v1.abc
+contexts:
+  - name: eks
+    context:
+      cluster: eks
+      user: eks
+`
+
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"namespace":  "production",
+			"deployment": "worker",
+			"replicas":   3,
+			"kubeconfig": kubeconfig,
+		},
+		HTTP:           httpCtx,
+		Integration:    testIntegrationContext(),
+		ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpCtx.Requests, 2)
+	assert.Equal(t, "ABCDEF.gr7.us-east-1.eks.amazonaws.com", httpCtx.Requests[0].URL.Host)
+	assert.Equal(t, "Bearer k8s-aws-v1.abc", httpCtx.Requests[0].Header.Get("Authorization"))
+}
@@ -0,0 +1,260 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	WaitForRolloutPayloadType          = "kubernetes.rollout.finished"
+	WaitForRolloutSuccessOutputChannel = "success"
+	WaitForRolloutFailedOutputChannel  = "failed"
+	WaitForRolloutActionPoll           = "poll"
+	WaitForRolloutPollInterval         = 15 * time.Second
+	DefaultRolloutTimeoutMinutes       = 10
+	MaxRolloutTimeoutMinutes           = 24 * 60
+)
+
+type WaitForRollout struct{}
+
+type WaitForRolloutConfiguration struct {
+	DeploymentTarget `mapstructure:",squash"`
+	TimeoutMinutes   int `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+type WaitForRolloutExecutionMetadata struct {
+	StartedAt string `json:"startedAt" mapstructure:"startedAt"`
+	Deadline  string `json:"deadline" mapstructure:"deadline"`
+	Message   string `json:"message,omitempty" mapstructure:"message"`
+}
+
+func (c *WaitForRollout) Name() string {
+	return "kubernetes.waitForRollout"
+}
+
+func (c *WaitForRollout) Label() string {
+	return "Wait for Rollout"
+}
+
+func (c *WaitForRollout) Description() string {
+	return "Wait until a Kubernetes deployment finishes rolling out"
+}
+
+func (c *WaitForRollout) Documentation() string {
+	return `The Wait for Rollout component waits until the rollout of a deployment finishes, like ` + "`kubectl rollout status`" + `.
+
+## Use Cases
+
+- **Deployment gates**: Wait for the new version to be available before running smoke tests
+- **Restart verification**: Confirm a restart completed before notifying the team
+- **Automatic rollback**: Route failed rollouts to a rollback step
+
+## How It Works
+
+The component checks the deployment every 15 seconds. The rollout is finished when all replicas are updated and available, and no old replicas are left.
+The rollout fails if the deployment exceeds its progress deadline, or if it doesn't finish within the configured timeout.
+
+## Configuration
+
+- **Namespace** (required): The namespace of the deployment
+- **Deployment** (required): The deployment to watch
+- **Timeout (minutes)**: How long to wait before failing. Defaults to 10 minutes
+- **Kubeconfig** (optional): Connect with this kubeconfig instead of the integration, e.g. the short-lived kubeconfig of EKS • Get Cluster Credentials
+
+## Output Channels
+
+- **Success**: The rollout finished
+- **Failed**: The rollout exceeded its progress deadline, or timed out
+
+## Output
+
+The deployment status, with replica counts, conditions and a message describing the result.`
+}
+
+func (c *WaitForRollout) Icon() string {
+	return "kubernetes"
+}
+
+func (c *WaitForRollout) Color() string {
+	return "blue"
+}
+
+func (c *WaitForRollout) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: WaitForRolloutSuccessOutputChannel, Label: "Success"},
+		{Name: WaitForRolloutFailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (c *WaitForRollout) Configuration() []configuration.Field {
+	return []configuration.Field{
+		namespaceField(true, "Namespace of the deployment"),
+		deploymentField(),
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     fmt.Sprintf("%d", DefaultRolloutTimeoutMinutes),
+			Description: "How long to wait for the rollout to finish",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := MaxRolloutTimeoutMinutes; return &max }(),
+				},
+			},
+		},
+		kubeconfigField(),
+	}
+}
+
+func decodeWaitForRolloutConfiguration(value any) (WaitForRolloutConfiguration, error) {
+	config := WaitForRolloutConfiguration{}
+	if err := mapstructure.WeakDecode(value, &config); err != nil {
+		return config, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := config.validate(); err != nil {
+		return config, err
+	}
+
+	if config.TimeoutMinutes == 0 {
+		config.TimeoutMinutes = DefaultRolloutTimeoutMinutes
+	}
+
+	if config.TimeoutMinutes < 1 || config.TimeoutMinutes > MaxRolloutTimeoutMinutes {
+		return config, fmt.Errorf("timeoutMinutes must be between 1 and %d", MaxRolloutTimeoutMinutes)
+	}
+
+	return config, nil
+}
+
+func (c *WaitForRollout) Setup(ctx core.SetupContext) error {
+	_, err := decodeWaitForRolloutConfiguration(ctx.Configuration)
+	return err
+}
+
+func (c *WaitForRollout) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *WaitForRollout) Execute(ctx core.ExecutionContext) error {
+	config, err := decodeWaitForRolloutConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	metadata := WaitForRolloutExecutionMetadata{
+		StartedAt: now.Format(time.RFC3339),
+		Deadline:  now.Add(time.Duration(config.TimeoutMinutes) * time.Minute).Format(time.RFC3339),
+	}
+
+	return c.check(config, &metadata, ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.ExecutionState, ctx.Requests, now)
+}
+
+func (c *WaitForRollout) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:           WaitForRolloutActionPoll,
+			Description:    "Check the rollout status",
+			UserAccessible: false,
+		},
+	}
+}
+
+func (c *WaitForRollout) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case WaitForRolloutActionPoll:
+		return c.poll(ctx)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *WaitForRollout) poll(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	config, err := decodeWaitForRolloutConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := WaitForRolloutExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	return c.check(config, &metadata, ctx.HTTP, ctx.Integration, ctx.Metadata, ctx.ExecutionState, ctx.Requests, time.Now().UTC())
+}
+
+// check looks at the current state of the deployment, and either finishes
+// the execution or schedules the next check.
+func (c *WaitForRollout) check(
+	config WaitForRolloutConfiguration,
+	metadata *WaitForRolloutExecutionMetadata,
+	httpCtx core.HTTPContext,
+	integration core.IntegrationContext,
+	metadataCtx core.MetadataContext,
+	state core.ExecutionStateContext,
+	requests core.RequestContext,
+	now time.Time,
+) error {
+	client, err := config.client(httpCtx, integration)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := client.GetDeployment(config.Namespace, config.Deployment)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s/%s: %w", config.Namespace, config.Deployment, err)
+	}
+
+	status := rolloutStatus(deployment)
+	metadata.Message = status.Message
+
+	channel := ""
+	switch {
+	case status.Done:
+		channel = WaitForRolloutSuccessOutputChannel
+	case status.Failed:
+		channel = WaitForRolloutFailedOutputChannel
+	default:
+		deadline, err := time.Parse(time.RFC3339, metadata.Deadline)
+		if err == nil && !now.Before(deadline) {
+			channel = WaitForRolloutFailedOutputChannel
+			metadata.Message = fmt.Sprintf("Timed out waiting for rollout: %s", status.Message)
+		}
+	}
+
+	if err := metadataCtx.Set(*metadata); err != nil {
+		return err
+	}
+
+	if channel == "" {
+		return requests.ScheduleActionCall(WaitForRolloutActionPoll, map[string]any{}, WaitForRolloutPollInterval)
+	}
+
+	payload := deploymentPayload(deployment)
+	payload["message"] = metadata.Message
+	return state.Emit(channel, WaitForRolloutPayloadType, []any{payload})
+}
+
+func (c *WaitForRollout) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *WaitForRollout) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *WaitForRollout) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const (
+	rolledOutDeployment   = `{"metadata":{"name":"api","namespace":"production","generation":2},"spec":{"replicas":3},"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":3,"readyReplicas":3,"availableReplicas":3}}`
+	progressingDeployment = `{"metadata":{"name":"api","namespace":"production","generation":2},"spec":{"replicas":3},"status":{"observedGeneration":2,"replicas":4,"updatedReplicas":2,"readyReplicas":3,"availableReplicas":3}}`
+	stuckDeployment       = `{"metadata":{"name":"api","namespace":"production","generation":2},"spec":{"replicas":3},"status":{"observedGeneration":2,"replicas":4,"updatedReplicas":1,"conditions":[{"type":"Progressing","status":"False","reason":"ProgressDeadlineExceeded"}]}}`
+)
+
+func Test__WaitForRollout__Execute(t *testing.T) {
+	component := &WaitForRollout{}
+	configuration := map[string]any{"namespace": "production", "deployment": "api", "timeoutMinutes": "5"}
+
+	t.Run("already rolled out -> success", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, rolledOutDeployment)}}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{},
+			ExecutionState: state,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WaitForRolloutSuccessOutputChannel, state.Channel)
+		assert.Empty(t, requests.Action)
+		assert.Equal(t, "/apis/apps/v1/namespaces/production/deployments/api", httpCtx.Requests[0].URL.Path)
+	})
+
+	t.Run("in progress -> schedules poll", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, progressingDeployment)}}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+		metadata := &contexts.MetadataContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       metadata,
+			ExecutionState: state,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Finished)
+		assert.Equal(t, WaitForRolloutActionPoll, requests.Action)
+		assert.Equal(t, WaitForRolloutPollInterval, requests.Duration)
+
+		stored := metadata.Metadata.(WaitForRolloutExecutionMetadata)
+		assert.Equal(t, "2 out of 3 new replicas have been updated", stored.Message)
+		assert.NotEmpty(t, stored.Deadline)
+	})
+
+	t.Run("progress deadline exceeded -> failed", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, stuckDeployment)}}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{},
+			ExecutionState: state,
+			Requests:       &contexts.RequestContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WaitForRolloutFailedOutputChannel, state.Channel)
+	})
+}
+
+func Test__WaitForRollout__Poll(t *testing.T) {
+	component := &WaitForRollout{}
+	configuration := map[string]any{"namespace": "production", "deployment": "api"}
+
+	t.Run("deadline passed -> failed", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, progressingDeployment)}}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+
+		err := component.HandleAction(core.ActionContext{
+			Name:          WaitForRolloutActionPoll,
+			Configuration: configuration,
+			HTTP:          httpCtx,
+			Integration:   testIntegrationContext(),
+			Metadata: &contexts.MetadataContext{Metadata: map[string]any{
+				"startedAt": time.Now().Add(-20 * time.Minute).Format(time.RFC3339),
+				"deadline":  time.Now().Add(-10 * time.Minute).Format(time.RFC3339),
+			}},
+			ExecutionState: state,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WaitForRolloutFailedOutputChannel, state.Channel)
+		assert.Empty(t, requests.Action)
+
+		payload := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Contains(t, payload["message"], "Timed out")
+	})
+
+	t.Run("finished execution -> no-op", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		err := component.HandleAction(core.ActionContext{
+			Name:           WaitForRolloutActionPoll,
+			Configuration:  configuration,
+			HTTP:           httpCtx,
+			ExecutionState: &contexts.ExecutionStateContext{Finished: true},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpCtx.Requests)
+	})
+}
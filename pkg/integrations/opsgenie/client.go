@@ -0,0 +1,306 @@
+package opsgenie
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RegionUS = "us"
+	RegionEU = "eu"
+
+	BaseURLUS = "https://api.opsgenie.com"
+	BaseURLEU = "https://api.eu.opsgenie.com"
+)
+
+type Client struct {
+	APIKey  string
+	BaseURL string
+	http    core.HTTPContext
+}
+
+func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	apiKey, err := ctx.GetConfig("apiKey")
+	if err != nil {
+		return nil, fmt.Errorf("error getting API key: %v", err)
+	}
+
+	// Default to the US instance when no region is set.
+	region, err := ctx.GetConfig("region")
+	if err != nil {
+		region = []byte(RegionUS)
+	}
+
+	return &Client{
+		APIKey:  string(apiKey),
+		BaseURL: baseURLForRegion(string(region)),
+		http:    http,
+	}, nil
+}
+
+func baseURLForRegion(region string) string {
+	if region == RegionEU {
+		return BaseURLEU
+	}
+
+	return BaseURLUS
+}
+
+// APIError is returned for non-2xx responses from the OpsGenie API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request got %d code: %s", e.StatusCode, e.Message)
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) execRequest(method, path string, body any) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling request: %v", err)
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("GenieKey %s", c.APIKey))
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %v", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading body: %v", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, parseAPIError(res.StatusCode, responseBody)
+	}
+
+	return responseBody, nil
+}
+
+func parseAPIError(statusCode int, body []byte) error {
+	response := struct {
+		Message string            `json:"message"`
+		Errors  map[string]string `json:"errors"`
+	}{}
+
+	if err := json.Unmarshal(body, &response); err != nil || response.Message == "" {
+		return &APIError{StatusCode: statusCode, Message: string(body)}
+	}
+
+	message := response.Message
+	for field, detail := range response.Errors {
+		message = fmt.Sprintf("%s (%s: %s)", message, field, detail)
+	}
+
+	return &APIError{StatusCode: statusCode, Message: message}
+}
+
+type Team struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (c *Client) ListTeams() ([]Team, error) {
+	responseBody, err := c.execRequest(http.MethodGet, "/v2/teams", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Data []Team `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	return response.Data, nil
+}
+
+type Responder struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type CreateAlertRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Responders  []Responder       `json:"responders,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+	User        string            `json:"user,omitempty"`
+	Note        string            `json:"note,omitempty"`
+}
+
+type CloseAlertRequest struct {
+	User   string `json:"user,omitempty"`
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// AsyncResponse is returned by the alert API for write operations.
+// OpsGenie processes them asynchronously, and the outcome is
+// available through GetRequestStatus once the request is processed.
+type AsyncResponse struct {
+	Result    string  `json:"result"`
+	Took      float64 `json:"took"`
+	RequestID string  `json:"requestId"`
+}
+
+func (c *Client) CreateAlert(request CreateAlertRequest) (*AsyncResponse, error) {
+	responseBody, err := c.execRequest(http.MethodPost, "/v2/alerts", request)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAsyncResponse(responseBody)
+}
+
+func (c *Client) CloseAlert(identifier, identifierType string, request CloseAlertRequest) (*AsyncResponse, error) {
+	path := fmt.Sprintf("/v2/alerts/%s/close?identifierType=%s", url.PathEscape(identifier), url.QueryEscape(identifierType))
+	responseBody, err := c.execRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAsyncResponse(responseBody)
+}
+
+func parseAsyncResponse(body []byte) (*AsyncResponse, error) {
+	var response AsyncResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	if response.RequestID == "" {
+		return nil, fmt.Errorf("response does not include a request ID")
+	}
+
+	return &response, nil
+}
+
+type RequestStatus struct {
+	Success       bool   `json:"success"`
+	IsSuccess     bool   `json:"isSuccess"`
+	Action        string `json:"action"`
+	ProcessedAt   string `json:"processedAt"`
+	IntegrationID string `json:"integrationId"`
+	Status        string `json:"status"`
+	AlertID       string `json:"alertId"`
+	Alias         string `json:"alias"`
+}
+
+// GetRequestStatus returns nil, without an error, when OpsGenie
+// has not processed the request yet.
+func (c *Client) GetRequestStatus(requestID string) (*RequestStatus, error) {
+	responseBody, err := c.execRequest(http.MethodGet, "/v2/alerts/requests/"+url.PathEscape(requestID), nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	response := struct {
+		Data RequestStatus `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	return &response.Data, nil
+}
+
+func (c *Client) GetAlert(alertID string) (map[string]any, error) {
+	responseBody, err := c.execRequest(http.MethodGet, "/v2/alerts/"+url.PathEscape(alertID)+"?identifierType=id", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Data map[string]any `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	return response.Data, nil
+}
+
+type WebhookIntegration struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// CreateWebhookIntegration creates an outgoing Webhook integration
+// that forwards alert actions to the given URL.
+func (c *Client) CreateWebhookIntegration(name, webhookURL string, headers map[string]string) (*WebhookIntegration, error) {
+	request := map[string]any{
+		"type":                "Webhook",
+		"name":                name,
+		"enabled":             true,
+		"url":                 webhookURL,
+		"addAlertDescription": true,
+		"addAlertDetails":     true,
+		"headers":             headers,
+	}
+
+	responseBody, err := c.execRequest(http.MethodPost, "/v2/integrations", request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Data WebhookIntegration `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	return &response.Data, nil
+}
+
+func (c *Client) DeleteIntegration(integrationID string) error {
+	_, err := c.execRequest(http.MethodDelete, "/v2/integrations/"+url.PathEscape(integrationID), nil)
+	return err
+}
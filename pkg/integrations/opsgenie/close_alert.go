@@ -0,0 +1,200 @@
+package opsgenie
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	CloseAlertPayloadType = "opsgenie.alert.closed"
+
+	IdentifierTypeID    = "id"
+	IdentifierTypeAlias = "alias"
+	IdentifierTypeTiny  = "tiny"
+)
+
+type CloseAlert struct{}
+
+type CloseAlertSpec struct {
+	Identifier     string `json:"identifier" mapstructure:"identifier"`
+	IdentifierType string `json:"identifierType" mapstructure:"identifierType"`
+	Note           string `json:"note" mapstructure:"note"`
+}
+
+func (c *CloseAlert) Name() string {
+	return "opsgenie.closeAlert"
+}
+
+func (c *CloseAlert) Label() string {
+	return "Close Alert"
+}
+
+func (c *CloseAlert) Description() string {
+	return "Close an alert in OpsGenie"
+}
+
+func (c *CloseAlert) Documentation() string {
+	return `The Close Alert component closes an existing OpsGenie alert and waits until OpsGenie has processed the request.
+
+## Use Cases
+
+- **Auto-recovery**: Close the alert once a follow-up check passes
+- **Rollback workflows**: Close the alert after a failed deployment was rolled back
+- **Lifecycle pairing**: Close alerts opened earlier in the workflow with Create Alert
+
+## Configuration
+
+- **Identifier**: ID, alias or tiny ID of the alert (required, supports expressions)
+- **Identifier Type**: How the identifier should be interpreted. Defaults to alert ID.
+- **Note**: Note added to the alert when closing it (optional)
+
+## Output
+
+Returns the closed alert as reported by OpsGenie, including its **id**, **tinyId**, **alias** and **status**.`
+}
+
+func (c *CloseAlert) Icon() string {
+	return "check-circle"
+}
+
+func (c *CloseAlert) Color() string {
+	return "gray"
+}
+
+func (c *CloseAlert) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CloseAlert) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "identifier",
+			Label:       "Identifier",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "ID, alias or tiny ID of the alert",
+			Placeholder: "{{ $['Create Alert'].data.id }}",
+		},
+		{
+			Name:     "identifierType",
+			Label:    "Identifier Type",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  IdentifierTypeID,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Alert ID", Value: IdentifierTypeID},
+						{Label: "Alias", Value: IdentifierTypeAlias},
+						{Label: "Tiny ID", Value: IdentifierTypeTiny},
+					},
+				},
+			},
+		},
+		{
+			Name:        "note",
+			Label:       "Note",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Note added to the alert when closing it",
+		},
+	}
+}
+
+func (c *CloseAlert) Setup(ctx core.SetupContext) error {
+	spec := CloseAlertSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	return validateCloseAlertSpec(spec)
+}
+
+func validateCloseAlertSpec(spec CloseAlertSpec) error {
+	if strings.TrimSpace(spec.Identifier) == "" {
+		return errors.New("identifier is required")
+	}
+
+	switch spec.IdentifierType {
+	case "", IdentifierTypeID, IdentifierTypeAlias, IdentifierTypeTiny:
+		return nil
+	default:
+		return fmt.Errorf("invalid identifier type %q", spec.IdentifierType)
+	}
+}
+
+func (c *CloseAlert) Execute(ctx core.ExecutionContext) error {
+	spec := CloseAlertSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if err := validateCloseAlertSpec(spec); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	identifierType := spec.IdentifierType
+	if identifierType == "" {
+		identifierType = IdentifierTypeID
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	response, err := client.CloseAlert(strings.TrimSpace(spec.Identifier), identifierType, CloseAlertRequest{
+		User:   alertUser,
+		Source: alertSource,
+		Note:   spec.Note,
+	})
+
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to close alert: %v", err))
+	}
+
+	return startPollingRequest(ctx, response.RequestID)
+}
+
+func (c *CloseAlert) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CloseAlert) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CloseAlert) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:           PollRequestAction,
+			UserAccessible: false,
+		},
+	}
+}
+
+func (c *CloseAlert) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case PollRequestAction:
+		return pollRequest(ctx, CloseAlertPayloadType)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *CloseAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CloseAlert) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
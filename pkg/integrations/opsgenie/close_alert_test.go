@@ -0,0 +1,99 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CloseAlert__Setup(t *testing.T) {
+	component := &CloseAlert{}
+
+	t.Run("missing identifier -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"identifierType": IdentifierTypeID},
+		})
+
+		require.ErrorContains(t, err, "identifier is required")
+	})
+
+	t.Run("invalid identifier type -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"identifier": "1791", "identifierType": "name"},
+		})
+
+		require.ErrorContains(t, err, "invalid identifier type")
+	})
+}
+
+func Test__CloseAlert__Execute(t *testing.T) {
+	component := &CloseAlert{}
+
+	t.Run("closes alert by alias", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusAccepted, `{"result":"Request will be processed","took":0.107,"requestId":"request-1"}`),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"identifier":     "checkout api/error-rate",
+				"identifierType": IdentifierTypeAlias,
+				"note":           "Error rate back to normal",
+			},
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, PollRequestAction, requests.Action)
+		assert.Equal(t, "request-1", metadata.Metadata.(AlertRequestMetadata).RequestID)
+
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://api.opsgenie.com/v2/alerts/checkout%20api%2Ferror-rate/close?identifierType=alias", httpCtx.Requests[0].URL.String())
+
+		body, err := io.ReadAll(httpCtx.Requests[0].Body)
+		require.NoError(t, err)
+		request := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, "Error rate back to normal", request["note"])
+	})
+
+	t.Run("unknown alert -> fails execution", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"message":"Alert does not exist","took":0.004,"requestId":"request-2"}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"identifier": "alert-1"},
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "Alert does not exist")
+		assert.Contains(t, httpCtx.Requests[0].URL.String(), "identifierType=id")
+	})
+}
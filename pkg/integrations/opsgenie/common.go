@@ -0,0 +1,89 @@
+package opsgenie
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ResourceTypeTeam = "team"
+
+	// Alert write requests are processed asynchronously by OpsGenie,
+	// usually within a couple of seconds.
+	PollRequestAction   = "pollRequest"
+	PollRequestInterval = 2 * time.Second
+	PollRequestTimeout  = 2 * time.Minute
+
+	// User and source recorded on alerts changed through SuperPlane.
+	alertUser   = "SuperPlane"
+	alertSource = "SuperPlane"
+)
+
+// AlertRequestMetadata tracks an asynchronous alert request
+// until OpsGenie reports it as processed.
+type AlertRequestMetadata struct {
+	RequestID string `json:"requestId" mapstructure:"requestId"`
+	Deadline  string `json:"deadline" mapstructure:"deadline"`
+}
+
+func startPollingRequest(ctx core.ExecutionContext, requestID string) error {
+	err := ctx.Metadata.Set(AlertRequestMetadata{
+		RequestID: requestID,
+		Deadline:  time.Now().Add(PollRequestTimeout).Format(time.RFC3339),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error setting metadata: %v", err)
+	}
+
+	return ctx.Requests.ScheduleActionCall(PollRequestAction, map[string]any{}, PollRequestInterval)
+}
+
+// pollRequest checks the status of the alert request stored in the execution metadata.
+// Once processed successfully, the alert is fetched and emitted with the given payload type.
+func pollRequest(ctx core.ActionContext, payloadType string) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := AlertRequestMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("error decoding metadata: %v", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	status, err := client.GetRequestStatus(metadata.RequestID)
+	if err != nil {
+		return fmt.Errorf("error getting request status: %v", err)
+	}
+
+	if status == nil {
+		deadline, err := time.Parse(time.RFC3339, metadata.Deadline)
+		if err == nil && time.Now().After(deadline) {
+			return ctx.ExecutionState.Fail(
+				"error",
+				fmt.Sprintf("request %s was not processed by OpsGenie within %s", metadata.RequestID, PollRequestTimeout),
+			)
+		}
+
+		return ctx.Requests.ScheduleActionCall(PollRequestAction, map[string]any{}, PollRequestInterval)
+	}
+
+	if !status.IsSuccess {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("OpsGenie request failed: %s", status.Status))
+	}
+
+	alert, err := client.GetAlert(status.AlertID)
+	if err != nil {
+		return fmt.Errorf("error getting alert %s: %v", status.AlertID, err)
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, payloadType, []any{alert})
+}
@@ -0,0 +1,338 @@
+package opsgenie
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	CreateAlertPayloadType = "opsgenie.alert.created"
+
+	// OpsGenie truncates longer messages.
+	MaxAlertMessageLength = 130
+)
+
+var alertPriorities = []configuration.FieldOption{
+	{Label: "P1 - Critical", Value: "P1"},
+	{Label: "P2 - High", Value: "P2"},
+	{Label: "P3 - Moderate", Value: "P3"},
+	{Label: "P4 - Low", Value: "P4"},
+	{Label: "P5 - Informational", Value: "P5"},
+}
+
+type CreateAlert struct{}
+
+type CreateAlertSpec struct {
+	Message     string        `json:"message" mapstructure:"message"`
+	Alias       string        `json:"alias" mapstructure:"alias"`
+	Description string        `json:"description" mapstructure:"description"`
+	Priority    string        `json:"priority" mapstructure:"priority"`
+	Teams       []string      `json:"teams" mapstructure:"teams"`
+	Tags        []string      `json:"tags" mapstructure:"tags"`
+	Entity      string        `json:"entity" mapstructure:"entity"`
+	Details     []AlertDetail `json:"details" mapstructure:"details"`
+	Note        string        `json:"note" mapstructure:"note"`
+}
+
+type AlertDetail struct {
+	Key   string `json:"key" mapstructure:"key"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+func (c *CreateAlert) Name() string {
+	return "opsgenie.createAlert"
+}
+
+func (c *CreateAlert) Label() string {
+	return "Create Alert"
+}
+
+func (c *CreateAlert) Description() string {
+	return "Create a new alert in OpsGenie"
+}
+
+func (c *CreateAlert) Documentation() string {
+	return `The Create Alert component creates a new alert in OpsGenie and waits until OpsGenie has processed it.
+
+## Use Cases
+
+- **Paging on-call**: Page the responsible team when a deployment or check fails
+- **Monitoring bridge**: Forward alerts from tools that OpsGenie does not integrate with
+- **Deduplication**: Use an alias so repeated runs update a single open alert instead of creating new ones
+
+## Configuration
+
+- **Message**: Alert message, up to 130 characters (required, supports expressions)
+- **Alias**: Client-defined identifier used for deduplication (optional)
+- **Description**: Longer description of the alert (optional)
+- **Priority**: P1 (critical) to P5 (informational). Defaults to P3.
+- **Teams**: Teams the alert is routed to (optional)
+- **Tags**: Tags added to the alert (optional)
+- **Entity**: Entity the alert is related to, e.g. a service or host name (optional)
+- **Details**: Custom key/value properties (optional)
+- **Note**: Note added while creating the alert (optional)
+
+## Output
+
+Returns the alert as reported by OpsGenie, including:
+- **id**: Alert ID
+- **tinyId**: Short alert ID
+- **alias**: Alert alias
+- **status**: Alert status
+- **priority**: Alert priority
+
+## Notes
+
+OpsGenie processes alert requests asynchronously. The component polls the request status and fails if OpsGenie rejects the request.`
+}
+
+func (c *CreateAlert) Icon() string {
+	return "alert-triangle"
+}
+
+func (c *CreateAlert) Color() string {
+	return "gray"
+}
+
+func (c *CreateAlert) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateAlert) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Alert message, up to 130 characters",
+		},
+		{
+			Name:        "alias",
+			Label:       "Alias",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Identifier used to deduplicate alerts",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Longer description of the alert",
+		},
+		{
+			Name:     "priority",
+			Label:    "Priority",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "P3",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: alertPriorities,
+				},
+			},
+		},
+		{
+			Name:        "teams",
+			Label:       "Teams",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Teams the alert is routed to",
+			Placeholder: "Select teams",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:  ResourceTypeTeam,
+					Multi: true,
+				},
+			},
+		},
+		{
+			Name:     "tags",
+			Label:    "Tags",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:        "entity",
+			Label:       "Entity",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Entity the alert is related to, e.g. a service or host name",
+		},
+		{
+			Name:     "details",
+			Label:    "Details",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Detail",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{Name: "key", Label: "Key", Type: configuration.FieldTypeString, Required: true},
+							{Name: "value", Label: "Value", Type: configuration.FieldTypeString, Required: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "note",
+			Label:       "Note",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Note added to the alert",
+		},
+	}
+}
+
+func (c *CreateAlert) Setup(ctx core.SetupContext) error {
+	spec := CreateAlertSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	return validateCreateAlertSpec(spec)
+}
+
+func validateCreateAlertSpec(spec CreateAlertSpec) error {
+	if strings.TrimSpace(spec.Message) == "" {
+		return errors.New("message is required")
+	}
+
+	if spec.Priority != "" && !isValidPriority(spec.Priority) {
+		return fmt.Errorf("invalid priority %q", spec.Priority)
+	}
+
+	for _, detail := range spec.Details {
+		if detail.Key == "" {
+			return errors.New("detail key is required")
+		}
+	}
+
+	return nil
+}
+
+func isValidPriority(priority string) bool {
+	for _, option := range alertPriorities {
+		if option.Value == priority {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *CreateAlert) Execute(ctx core.ExecutionContext) error {
+	spec := CreateAlertSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	// The message may only be known after expressions are resolved.
+	if err := validateCreateAlertSpec(spec); err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	response, err := client.CreateAlert(buildCreateAlertRequest(spec))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create alert: %v", err))
+	}
+
+	return startPollingRequest(ctx, response.RequestID)
+}
+
+func buildCreateAlertRequest(spec CreateAlertSpec) CreateAlertRequest {
+	message := strings.TrimSpace(spec.Message)
+	if len(message) > MaxAlertMessageLength {
+		message = message[:MaxAlertMessageLength]
+	}
+
+	request := CreateAlertRequest{
+		Message:     message,
+		Alias:       spec.Alias,
+		Description: spec.Description,
+		Priority:    spec.Priority,
+		Entity:      spec.Entity,
+		Note:        spec.Note,
+		User:        alertUser,
+		Source:      alertSource,
+	}
+
+	for _, team := range spec.Teams {
+		request.Responders = append(request.Responders, Responder{Type: "team", ID: team})
+	}
+
+	for _, tag := range spec.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			request.Tags = append(request.Tags, tag)
+		}
+	}
+
+	if len(spec.Details) > 0 {
+		request.Details = map[string]string{}
+		for _, detail := range spec.Details {
+			request.Details[detail.Key] = detail.Value
+		}
+	}
+
+	return request
+}
+
+func (c *CreateAlert) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateAlert) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateAlert) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:           PollRequestAction,
+			UserAccessible: false,
+		},
+	}
+}
+
+func (c *CreateAlert) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case PollRequestAction:
+		return pollRequest(ctx, CreateAlertPayloadType)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *CreateAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateAlert) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,209 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func testIntegrationContext() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{"apiKey": "key", "region": RegionUS},
+	}
+}
+
+func Test__CreateAlert__Setup(t *testing.T) {
+	component := &CreateAlert{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"message": "Checkout is down", "priority": "P1"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing message -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"priority": "P1"},
+		})
+
+		require.ErrorContains(t, err, "message is required")
+	})
+
+	t.Run("invalid priority -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"message": "Checkout is down", "priority": "P9"},
+		})
+
+		require.ErrorContains(t, err, "invalid priority")
+	})
+}
+
+func Test__CreateAlert__Execute(t *testing.T) {
+	component := &CreateAlert{}
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusAccepted, `{"result":"Request will be processed","took":0.302,"requestId":"43a29c5c-3dbf-4fa4-9c26-f4f71023e120"}`),
+		},
+	}
+
+	metadata := &contexts.MetadataContext{}
+	requests := &contexts.RequestContext{}
+	state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"message":  "Checkout API error rate above 5%",
+			"alias":    "checkout-api-error-rate",
+			"priority": "P2",
+			"teams":    []string{"team-1"},
+			"tags":     []string{"checkout", " "},
+			"details":  []any{map[string]any{"key": "region", "value": "us-east-1"}},
+		},
+		HTTP:           httpCtx,
+		Integration:    testIntegrationContext(),
+		Metadata:       metadata,
+		Requests:       requests,
+		ExecutionState: state,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, state.Finished)
+	assert.Equal(t, PollRequestAction, requests.Action)
+	assert.Equal(t, PollRequestInterval, requests.Duration)
+	assert.Equal(t, "43a29c5c-3dbf-4fa4-9c26-f4f71023e120", metadata.Metadata.(AlertRequestMetadata).RequestID)
+
+	require.Len(t, httpCtx.Requests, 1)
+	assert.Equal(t, http.MethodPost, httpCtx.Requests[0].Method)
+	assert.Equal(t, "https://api.opsgenie.com/v2/alerts", httpCtx.Requests[0].URL.String())
+
+	body, err := io.ReadAll(httpCtx.Requests[0].Body)
+	require.NoError(t, err)
+
+	request := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Equal(t, "Checkout API error rate above 5%", request["message"])
+	assert.Equal(t, "checkout-api-error-rate", request["alias"])
+	assert.Equal(t, "P2", request["priority"])
+	assert.Equal(t, []any{map[string]any{"type": "team", "id": "team-1"}}, request["responders"])
+	assert.Equal(t, []any{"checkout"}, request["tags"])
+	assert.Equal(t, map[string]any{"region": "us-east-1"}, request["details"])
+	assert.Equal(t, "SuperPlane", request["source"])
+}
+
+func Test__CreateAlert__PollRequest(t *testing.T) {
+	component := &CreateAlert{}
+	metadata := map[string]any{
+		"requestId": "43a29c5c-3dbf-4fa4-9c26-f4f71023e120",
+		"deadline":  time.Now().Add(time.Minute).Format(time.RFC3339),
+	}
+
+	t.Run("request processed -> emits alert", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"success":true,"action":"Create","isSuccess":true,"status":"Created alert","alertId":"alert-1","alias":"checkout-api-error-rate"}}`),
+				jsonResponse(http.StatusOK, `{"data":{"id":"alert-1","tinyId":"1791","status":"open","priority":"P2"}}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           PollRequestAction,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: metadata},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, CreateAlertPayloadType, state.Type)
+		assert.Equal(t, "https://api.opsgenie.com/v2/alerts/requests/43a29c5c-3dbf-4fa4-9c26-f4f71023e120", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "https://api.opsgenie.com/v2/alerts/alert-1?identifierType=id", httpCtx.Requests[1].URL.String())
+
+		alert := state.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "1791", alert["tinyId"])
+	})
+
+	t.Run("request not processed yet -> polls again", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"message":"Request not found. It might not be processed, yet."}`),
+			},
+		}
+
+		requests := &contexts.RequestContext{}
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           PollRequestAction,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: metadata},
+			Requests:       requests,
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, state.Finished)
+		assert.Equal(t, PollRequestAction, requests.Action)
+	})
+
+	t.Run("request failed -> fails execution", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"success":false,"action":"Create","isSuccess":false,"status":"Team with id [team-1] does not exist"}}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:           PollRequestAction,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       &contexts.MetadataContext{Metadata: metadata},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "Team with id [team-1] does not exist")
+	})
+
+	t.Run("deadline exceeded -> fails execution", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"message":"Request not found. It might not be processed, yet."}`),
+			},
+		}
+
+		state := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:        PollRequestAction,
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Metadata: &contexts.MetadataContext{Metadata: map[string]any{
+				"requestId": "43a29c5c-3dbf-4fa4-9c26-f4f71023e120",
+				"deadline":  time.Now().Add(-time.Second).Format(time.RFC3339),
+			}},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Finished)
+		assert.False(t, state.Passed)
+		assert.Contains(t, state.FailureMessage, "was not processed")
+	})
+}
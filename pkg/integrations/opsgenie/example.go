@@ -0,0 +1,38 @@
+package opsgenie
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_alert.json
+var exampleDataOnAlertBytes []byte
+
+var exampleDataOnAlertOnce sync.Once
+var exampleDataOnAlert map[string]any
+
+func (t *OnAlert) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnAlertOnce, exampleDataOnAlertBytes, &exampleDataOnAlert)
+}
+
+//go:embed example_output_create_alert.json
+var exampleOutputCreateAlertBytes []byte
+
+var exampleOutputCreateAlertOnce sync.Once
+var exampleOutputCreateAlert map[string]any
+
+func (c *CreateAlert) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateAlertOnce, exampleOutputCreateAlertBytes, &exampleOutputCreateAlert)
+}
+
+//go:embed example_output_close_alert.json
+var exampleOutputCloseAlertBytes []byte
+
+var exampleOutputCloseAlertOnce sync.Once
+var exampleOutputCloseAlert map[string]any
+
+func (c *CloseAlert) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCloseAlertOnce, exampleOutputCloseAlertBytes, &exampleOutputCloseAlert)
+}
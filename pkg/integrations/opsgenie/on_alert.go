@@ -0,0 +1,251 @@
+package opsgenie
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	OnAlertPayloadType = "opsgenie.alert"
+
+	AlertActionCreate         = "Create"
+	AlertActionAcknowledge    = "Acknowledge"
+	AlertActionUnAcknowledge  = "UnAcknowledge"
+	AlertActionClose          = "Close"
+	AlertActionAddNote        = "AddNote"
+	AlertActionEscalate       = "Escalate"
+	AlertActionSnooze         = "Snooze"
+	AlertActionUpdatePriority = "UpdatePriority"
+)
+
+type OnAlert struct{}
+
+type OnAlertConfiguration struct {
+	Actions    []string `json:"actions" mapstructure:"actions"`
+	Priorities []string `json:"priorities" mapstructure:"priorities"`
+	Tags       []string `json:"tags" mapstructure:"tags"`
+}
+
+// AlertWebhook is the payload sent by OpsGenie Webhook integrations.
+type AlertWebhook struct {
+	Action          string         `json:"action"`
+	Alert           map[string]any `json:"alert"`
+	Source          map[string]any `json:"source"`
+	IntegrationName string         `json:"integrationName"`
+	IntegrationID   string         `json:"integrationId"`
+	IntegrationType string         `json:"integrationType"`
+}
+
+func (t *OnAlert) Name() string {
+	return "opsgenie.onAlert"
+}
+
+func (t *OnAlert) Label() string {
+	return "On Alert"
+}
+
+func (t *OnAlert) Description() string {
+	return "Runs when an alert is created or changes in OpsGenie"
+}
+
+func (t *OnAlert) Documentation() string {
+	return `The On Alert trigger starts a workflow execution when an OpsGenie alert is created, acknowledged, closed or otherwise changes.
+
+## Use Cases
+
+- **Automated remediation**: Run a remediation workflow when a specific alert is created
+- **Notifications**: Post to chat when a P1 alert is acknowledged or closed
+- **Ticketing**: Open a ticket for every alert with a given tag
+
+## Configuration
+
+- **Actions**: Alert actions that start the workflow. Defaults to alert creation.
+- **Priorities** (optional): Only trigger for alerts with these priorities. If empty, all priorities are accepted.
+- **Tags** (optional): Only trigger for alerts that have all of these tags.
+
+## Event Data
+
+Each event includes:
+- **action**: Alert action, e.g. Create or Close
+- **alert**: Alert data, including **alertId**, **tinyId**, **message**, **alias**, **priority**, **tags** and **username**
+- **source**: What performed the action
+
+## Webhook Setup
+
+SuperPlane creates an OpsGenie Webhook integration that forwards alert actions to SuperPlane when the trigger is configured, and removes it when no trigger uses it anymore.`
+}
+
+func (t *OnAlert) Icon() string {
+	return "alert-triangle"
+}
+
+func (t *OnAlert) Color() string {
+	return "gray"
+}
+
+func (t *OnAlert) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "actions",
+			Label:       "Actions",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    true,
+			Description: "Alert actions that start the workflow",
+			Default:     []string{AlertActionCreate},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Created", Value: AlertActionCreate},
+						{Label: "Acknowledged", Value: AlertActionAcknowledge},
+						{Label: "Unacknowledged", Value: AlertActionUnAcknowledge},
+						{Label: "Closed", Value: AlertActionClose},
+						{Label: "Note added", Value: AlertActionAddNote},
+						{Label: "Escalated", Value: AlertActionEscalate},
+						{Label: "Snoozed", Value: AlertActionSnooze},
+						{Label: "Priority updated", Value: AlertActionUpdatePriority},
+					},
+				},
+			},
+		},
+		{
+			Name:        "priorities",
+			Label:       "Priorities",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only trigger for alerts with these priorities. Leave empty for all priorities.",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: alertPriorities,
+				},
+			},
+		},
+		{
+			Name:        "tags",
+			Label:       "Tags",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Only trigger for alerts that have all of these tags",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *OnAlert) Setup(ctx core.TriggerContext) error {
+	config := OnAlertConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if len(config.Actions) == 0 {
+		return fmt.Errorf("at least one action must be selected")
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{})
+}
+
+func (t *OnAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnAlertConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error getting secret: %v", err)
+	}
+
+	if err := verifyWebhookSecret(ctx.Headers.Get(webhookSecretHeader), secret); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	var webhook AlertWebhook
+	if err := json.Unmarshal(ctx.Body, &webhook); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	if !matchesAlertFilters(config, webhook) {
+		return http.StatusOK, nil, nil
+	}
+
+	err = ctx.Events.Emit(OnAlertPayloadType, map[string]any{
+		"action": webhook.Action,
+		"alert":  webhook.Alert,
+		"source": webhook.Source,
+	})
+
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func verifyWebhookSecret(value string, secret []byte) error {
+	if value == "" {
+		return fmt.Errorf("missing %s header", webhookSecretHeader)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(value), secret) != 1 {
+		return fmt.Errorf("invalid webhook secret")
+	}
+
+	return nil
+}
+
+func matchesAlertFilters(config OnAlertConfiguration, webhook AlertWebhook) bool {
+	if !slices.Contains(config.Actions, webhook.Action) {
+		return false
+	}
+
+	if len(config.Priorities) > 0 {
+		priority, _ := webhook.Alert["priority"].(string)
+		if !slices.Contains(config.Priorities, priority) {
+			return false
+		}
+	}
+
+	alertTags := []string{}
+	if tags, ok := webhook.Alert["tags"].([]any); ok {
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				alertTags = append(alertTags, s)
+			}
+		}
+	}
+
+	for _, tag := range config.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(alertTags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *OnAlert) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *OnAlert) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnAlert) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
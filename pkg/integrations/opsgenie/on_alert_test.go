@@ -0,0 +1,129 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testAlertWebhook = `{
+	"action": "Create",
+	"alert": {
+		"alertId": "alert-1",
+		"tinyId": "1791",
+		"message": "Checkout API error rate above 5%",
+		"priority": "P2",
+		"tags": ["checkout", "production"]
+	},
+	"source": {"name": "Datadog", "type": "Datadog"},
+	"integrationName": "SuperPlane",
+	"integrationType": "Webhook"
+}`
+
+func Test__OnAlert__Setup(t *testing.T) {
+	trigger := &OnAlert{}
+
+	t.Run("no actions -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"actions": []string{}},
+			Integration:   &contexts.IntegrationContext{},
+		})
+
+		require.ErrorContains(t, err, "at least one action")
+	})
+
+	t.Run("requests webhook", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"actions": []string{AlertActionCreate}},
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, integrationCtx.WebhookRequests, 1)
+	})
+}
+
+func Test__OnAlert__HandleWebhook(t *testing.T) {
+	trigger := &OnAlert{}
+
+	headers := http.Header{}
+	headers.Set(webhookSecretHeader, "secret")
+
+	handle := func(config map[string]any, headers http.Header) (int, *contexts.EventContext, error) {
+		events := &contexts.EventContext{}
+		status, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(testAlertWebhook),
+			Headers:       headers,
+			Configuration: config,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "secret"},
+			Events:        events,
+		})
+
+		return status, events, err
+	}
+
+	t.Run("missing secret -> forbidden", func(t *testing.T) {
+		status, events, err := handle(map[string]any{"actions": []string{AlertActionCreate}}, http.Header{})
+		require.ErrorContains(t, err, "missing")
+		assert.Equal(t, http.StatusForbidden, status)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("invalid secret -> forbidden", func(t *testing.T) {
+		invalid := http.Header{}
+		invalid.Set(webhookSecretHeader, "other")
+
+		status, _, err := handle(map[string]any{"actions": []string{AlertActionCreate}}, invalid)
+		require.ErrorContains(t, err, "invalid webhook secret")
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("matching alert -> emits event", func(t *testing.T) {
+		status, events, err := handle(map[string]any{
+			"actions":    []string{AlertActionCreate},
+			"priorities": []string{"P1", "P2"},
+			"tags":       []string{"checkout"},
+		}, headers)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnAlertPayloadType, events.Payloads[0].Type)
+
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, AlertActionCreate, payload["action"])
+		assert.Equal(t, "alert-1", payload["alert"].(map[string]any)["alertId"])
+	})
+
+	t.Run("other action -> ignored", func(t *testing.T) {
+		status, events, err := handle(map[string]any{"actions": []string{AlertActionClose}}, headers)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("other priority -> ignored", func(t *testing.T) {
+		_, events, err := handle(map[string]any{
+			"actions":    []string{AlertActionCreate},
+			"priorities": []string{"P1"},
+		}, headers)
+
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("missing tag -> ignored", func(t *testing.T) {
+		_, events, err := handle(map[string]any{
+			"actions": []string{AlertActionCreate},
+			"tags":    []string{"checkout", "staging"},
+		}, headers)
+
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+}
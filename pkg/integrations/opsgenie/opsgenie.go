@@ -0,0 +1,163 @@
+package opsgenie
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const installationInstructions = `
+To connect OpsGenie, create an API key:
+
+1. In OpsGenie, go to **Settings → Integrations**, add an **API** integration and copy its API key.
+2. Make sure the integration has **Read**, **Create and Update** and **Delete** access, and that **Configuration access** is enabled. Configuration access is needed to manage the webhook used by the **On Alert** trigger.
+3. Paste the API key into the configuration for this integration and select the region your OpsGenie account is hosted in.
+`
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("opsgenie", &OpsGenie{}, &OpsGenieWebhookHandler{})
+}
+
+type OpsGenie struct{}
+
+type Configuration struct {
+	APIKey string `json:"apiKey" mapstructure:"apiKey"`
+	Region string `json:"region" mapstructure:"region"`
+}
+
+func (o *OpsGenie) Name() string {
+	return "opsgenie"
+}
+
+func (o *OpsGenie) Label() string {
+	return "OpsGenie"
+}
+
+func (o *OpsGenie) Icon() string {
+	return "alert-triangle"
+}
+
+func (o *OpsGenie) Description() string {
+	return "Create, close and react to alerts in OpsGenie"
+}
+
+func (o *OpsGenie) Instructions() string {
+	return installationInstructions
+}
+
+func (o *OpsGenie) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "apiKey",
+			Label:       "API Key",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "API key of an OpsGenie API integration",
+		},
+		{
+			Name:        "region",
+			Label:       "Region",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     RegionUS,
+			Description: "Region your OpsGenie account is hosted in",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "US", Value: RegionUS},
+						{Label: "EU", Value: RegionEU},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (o *OpsGenie) Components() []core.Component {
+	return []core.Component{
+		&CreateAlert{},
+		&CloseAlert{},
+	}
+}
+
+func (o *OpsGenie) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnAlert{},
+	}
+}
+
+func (o *OpsGenie) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (o *OpsGenie) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode config: %v", err)
+	}
+
+	if config.APIKey == "" {
+		return fmt.Errorf("API key is required")
+	}
+
+	if config.Region != "" && config.Region != RegionUS && config.Region != RegionEU {
+		return fmt.Errorf("invalid region %q", config.Region)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	// Validate the API key by listing teams
+	if _, err = client.ListTeams(); err != nil {
+		return fmt.Errorf("error listing teams: %v", err)
+	}
+
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (o *OpsGenie) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	if resourceType != ResourceTypeTeam {
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	teams, err := client.ListTeams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(teams))
+	for _, team := range teams {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeTeam,
+			Name: team.Name,
+			ID:   team.ID,
+		})
+	}
+
+	return resources, nil
+}
+
+func (o *OpsGenie) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (o *OpsGenie) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (o *OpsGenie) HandleAction(ctx core.IntegrationActionContext) error {
+	return nil
+}
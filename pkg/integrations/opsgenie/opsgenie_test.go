@@ -0,0 +1,99 @@
+package opsgenie
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func Test__OpsGenie__Sync(t *testing.T) {
+	integration := &OpsGenie{}
+
+	t.Run("valid API key -> ready", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"team-1","name":"Platform"}]}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiKey": "key", "region": RegionEU},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://api.eu.opsgenie.com/v2/teams", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "GenieKey key", httpCtx.Requests[0].Header.Get("Authorization"))
+	})
+
+	t.Run("invalid API key -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusUnauthorized, `{"message":"Could not authenticate","took":0.001,"requestId":"r-1"}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiKey": "wrong", "region": RegionUS},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "Could not authenticate")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+		assert.Equal(t, "https://api.opsgenie.com/v2/teams", httpCtx.Requests[0].URL.String())
+	})
+
+	t.Run("missing API key -> error", func(t *testing.T) {
+		err := integration.Sync(core.SyncContext{
+			Configuration: map[string]any{"region": RegionUS},
+			Integration:   &contexts.IntegrationContext{},
+		})
+
+		require.ErrorContains(t, err, "API key is required")
+	})
+}
+
+func Test__OpsGenie__ListResources(t *testing.T) {
+	integration := &OpsGenie{}
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"data":[{"id":"team-1","name":"Platform"},{"id":"team-2","name":"Payments"}]}`),
+		},
+	}
+
+	resources, err := integration.ListResources(ResourceTypeTeam, core.ListResourcesContext{
+		HTTP:        httpCtx,
+		Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "key"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []core.IntegrationResource{
+		{Type: ResourceTypeTeam, Name: "Platform", ID: "team-1"},
+		{Type: ResourceTypeTeam, Name: "Payments", ID: "team-2"},
+	}, resources)
+}
@@ -0,0 +1,78 @@
+package opsgenie
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// OpsGenie Webhook integrations cannot sign payloads,
+// so the webhook secret is sent as a custom header instead.
+const webhookSecretHeader = "X-SuperPlane-Secret"
+
+// All alert triggers of an OpsGenie integration share one webhook,
+// and each trigger filters the alert actions it receives.
+type WebhookConfiguration struct{}
+
+type WebhookMetadata struct {
+	IntegrationID string `json:"integrationId" mapstructure:"integrationId"`
+}
+
+type OpsGenieWebhookHandler struct{}
+
+func (h *OpsGenieWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	return true, nil
+}
+
+func (h *OpsGenieWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
+
+func (h *OpsGenieWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook secret: %v", err)
+	}
+
+	integration, err := client.CreateWebhookIntegration(
+		fmt.Sprintf("SuperPlane %s", ctx.Webhook.GetID()),
+		ctx.Webhook.GetURL(),
+		map[string]string{webhookSecretHeader: string(secret)},
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook integration: %v", err)
+	}
+
+	return WebhookMetadata{IntegrationID: integration.ID}, nil
+}
+
+func (h *OpsGenieWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata)
+	if err != nil {
+		return fmt.Errorf("error decoding webhook metadata: %v", err)
+	}
+
+	if metadata.IntegrationID == "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteIntegration(metadata.IntegrationID)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting webhook integration: %v", err)
+	}
+
+	return nil
+}
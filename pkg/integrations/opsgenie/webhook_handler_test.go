@@ -0,0 +1,85 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OpsGenieWebhookHandler__Setup(t *testing.T) {
+	handler := &OpsGenieWebhookHandler{}
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusCreated, `{"data":{"id":"integration-1","name":"SuperPlane webhook-1","type":"Webhook","enabled":true}}`),
+		},
+	}
+
+	metadata, err := handler.Setup(core.WebhookHandlerContext{
+		HTTP:        httpCtx,
+		Integration: testIntegrationContext(),
+		Webhook: &contexts.WebhookContext{
+			ID:     "webhook-1",
+			URL:    "https://superplane.example.com/api/v1/webhooks/webhook-1",
+			Secret: []byte("secret"),
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, WebhookMetadata{IntegrationID: "integration-1"}, metadata)
+
+	require.Len(t, httpCtx.Requests, 1)
+	assert.Equal(t, "https://api.opsgenie.com/v2/integrations", httpCtx.Requests[0].URL.String())
+
+	body, err := io.ReadAll(httpCtx.Requests[0].Body)
+	require.NoError(t, err)
+	request := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Equal(t, "Webhook", request["type"])
+	assert.Equal(t, "https://superplane.example.com/api/v1/webhooks/webhook-1", request["url"])
+	assert.Equal(t, map[string]any{webhookSecretHeader: "secret"}, request["headers"])
+}
+
+func Test__OpsGenieWebhookHandler__Cleanup(t *testing.T) {
+	handler := &OpsGenieWebhookHandler{}
+
+	t.Run("deletes webhook integration", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"result":"Deleted","took":0.1,"requestId":"r-1"}`),
+			},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Webhook:     &contexts.WebhookContext{Metadata: map[string]any{"integrationId": "integration-1"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, http.MethodDelete, httpCtx.Requests[0].Method)
+		assert.Equal(t, "https://api.opsgenie.com/v2/integrations/integration-1", httpCtx.Requests[0].URL.String())
+	})
+
+	t.Run("already deleted -> no error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"message":"Integration not found"}`),
+			},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Webhook:     &contexts.WebhookContext{Metadata: map[string]any{"integrationId": "integration-1"}},
+		})
+
+		require.NoError(t, err)
+	})
+}
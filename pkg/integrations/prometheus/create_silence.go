@@ -15,7 +15,7 @@ type CreateSilence struct{}
 
 type CreateSilenceConfiguration struct {
 	Matchers  []MatcherConfiguration `json:"matchers" mapstructure:"matchers"`
-	Duration  string                 `json:"duration" mapstructure:"duration"`
+	Duration  any                    `json:"duration" mapstructure:"duration"`
 	CreatedBy string                 `json:"createdBy" mapstructure:"createdBy"`
 	Comment   string                 `json:"comment" mapstructure:"comment"`
 }
@@ -123,7 +123,7 @@ func (c *CreateSilence) Configuration() []configuration.Field {
 		{
 			Name:        "duration",
 			Label:       "Duration",
-			Type:        configuration.FieldTypeString,
+			Type:        configuration.FieldTypeDuration,
 			Required:    true,
 			Placeholder: "1h",
 			Description: "Duration for the silence (e.g. 1h, 30m, 2h30m)",
@@ -166,12 +166,12 @@ func (c *CreateSilence) Setup(ctx core.SetupContext) error {
 		}
 	}
 
-	if config.Duration == "" {
+	if config.Duration == nil || config.Duration == "" {
 		return fmt.Errorf("duration is required")
 	}
 
-	if _, err := time.ParseDuration(config.Duration); err != nil {
-		return fmt.Errorf("invalid duration %q: %w", config.Duration, err)
+	if _, err := configuration.ParseDuration(config.Duration); err != nil {
+		return fmt.Errorf("invalid duration %v: %w", config.Duration, err)
 	}
 
 	if config.CreatedBy == "" {
@@ -192,7 +192,7 @@ func (c *CreateSilence) Execute(ctx core.ExecutionContext) error {
 	}
 	config = sanitizeCreateSilenceConfiguration(config)
 
-	duration, err := time.ParseDuration(config.Duration)
+	duration, err := configuration.ParseDuration(config.Duration)
 	if err != nil {
 		return fmt.Errorf("invalid duration: %w", err)
 	}
@@ -293,7 +293,9 @@ func sanitizeCreateSilenceConfiguration(config CreateSilenceConfiguration) Creat
 		config.Matchers[i].Name = strings.TrimSpace(config.Matchers[i].Name)
 		config.Matchers[i].Value = strings.TrimSpace(config.Matchers[i].Value)
 	}
-	config.Duration = strings.TrimSpace(config.Duration)
+	if duration, ok := config.Duration.(string); ok {
+		config.Duration = strings.TrimSpace(duration)
+	}
 	config.CreatedBy = strings.TrimSpace(config.CreatedBy)
 	config.Comment = strings.TrimSpace(config.Comment)
 	return config
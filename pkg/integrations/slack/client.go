@@ -9,6 +9,7 @@ import (
 	"net/url"
 
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 )
 
 type Client struct {
@@ -128,10 +129,10 @@ type ConversationsListResponse struct {
 }
 
 type ChatPostMessageRequest struct {
-	Channel         string        `json:"channel"`
-	Text            string        `json:"text,omitempty"`
-	Blocks          []interface{} `json:"blocks,omitempty"`
-	ThreadTimestamp string        `json:"thread_ts,omitempty"`
+	Channel         string `json:"channel"`
+	Text            string `json:"text,omitempty"`
+	Blocks          []any  `json:"blocks,omitempty"`
+	ThreadTimestamp string `json:"thread_ts,omitempty"`
 }
 
 type ChatPostMessageResponse struct {
@@ -167,6 +168,46 @@ func (c *Client) PostMessage(req ChatPostMessageRequest) (*ChatPostMessageRespon
 	return &result, nil
 }
 
+type ChatUpdateRequest struct {
+	Channel   string `json:"channel"`
+	Timestamp string `json:"ts"`
+	Text      string `json:"text,omitempty"`
+	Blocks    []any  `json:"blocks,omitempty"`
+}
+
+type ChatUpdateResponse struct {
+	OK      bool           `json:"ok"`
+	Error   string         `json:"error,omitempty"`
+	TS      string         `json:"ts,omitempty"`
+	Message map[string]any `json:"message,omitempty"`
+}
+
+func (c *Client) UpdateMessage(req ChatUpdateRequest) (*ChatUpdateResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	responseBody, err := c.execRequest(http.MethodPost, "https://slack.com/api/chat.update", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var result ChatUpdateResponse
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+
+	if !result.OK {
+		if result.Error != "" {
+			return nil, fmt.Errorf("failed to update message: %s", result.Error)
+		}
+		return nil, fmt.Errorf("failed to update message")
+	}
+
+	return &result, nil
+}
+
 func (c *Client) execRequest(method, URL string, body io.Reader) ([]byte, error) {
 	req, err := http.NewRequest(method, URL, body)
 	if err != nil {
@@ -176,7 +217,7 @@ func (c *Client) execRequest(method, URL string, body io.Reader) ([]byte, error)
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.BotToken))
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := &http.Client{Transport: telemetry.NewHTTPTransport()}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %v", err)
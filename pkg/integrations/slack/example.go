@@ -10,21 +10,45 @@ import (
 //go:embed example_output_send_text_message.json
 var exampleOutputSendTextMessageBytes []byte
 
+//go:embed example_output_send_blocks_message.json
+var exampleOutputSendBlocksMessageBytes []byte
+
+//go:embed example_output_send_deployment_message.json
+var exampleOutputSendDeploymentMessageBytes []byte
+
 //go:embed example_output_wait_for_button_click.json
 var exampleOutputWaitForButtonClickBytes []byte
 
+//go:embed example_output_wait_for_approval.json
+var exampleOutputWaitForApprovalBytes []byte
+
 //go:embed example_data_on_app_mention.json
 var exampleDataOnAppMentionBytes []byte
 
+//go:embed example_data_on_slash_command.json
+var exampleDataOnSlashCommandBytes []byte
+
 var exampleOutputSendTextMessageOnce sync.Once
 var exampleOutputSendTextMessage map[string]any
 
+var exampleOutputSendBlocksMessageOnce sync.Once
+var exampleOutputSendBlocksMessage map[string]any
+
+var exampleOutputSendDeploymentMessageOnce sync.Once
+var exampleOutputSendDeploymentMessage map[string]any
+
 var exampleOutputWaitForButtonClickOnce sync.Once
 var exampleOutputWaitForButtonClick map[string]any
 
+var exampleOutputWaitForApprovalOnce sync.Once
+var exampleOutputWaitForApproval map[string]any
+
 var exampleDataOnce sync.Once
 var exampleData map[string]any
 
+var exampleDataOnSlashCommandOnce sync.Once
+var exampleDataOnSlashCommand map[string]any
+
 func (c *SendTextMessage) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputSendTextMessageOnce, exampleOutputSendTextMessageBytes, &exampleOutputSendTextMessage)
 }
@@ -36,3 +60,19 @@ func (c *WaitForButtonClick) ExampleOutput() map[string]any {
 func (t *OnAppMention) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnce, exampleDataOnAppMentionBytes, &exampleData)
 }
+
+func (c *SendBlocksMessage) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSendBlocksMessageOnce, exampleOutputSendBlocksMessageBytes, &exampleOutputSendBlocksMessage)
+}
+
+func (c *SendDeploymentMessage) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSendDeploymentMessageOnce, exampleOutputSendDeploymentMessageBytes, &exampleOutputSendDeploymentMessage)
+}
+
+func (c *WaitForApproval) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputWaitForApprovalOnce, exampleOutputWaitForApprovalBytes, &exampleOutputWaitForApproval)
+}
+
+func (t *OnSlashCommand) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnSlashCommandOnce, exampleDataOnSlashCommandBytes, &exampleDataOnSlashCommand)
+}
@@ -0,0 +1,221 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const SlashCommandEventType = "slash_command"
+
+type OnSlashCommand struct{}
+
+type OnSlashCommandConfiguration struct {
+	Command string `json:"command" mapstructure:"command"`
+	Channel string `json:"channel" mapstructure:"channel"`
+}
+
+type OnSlashCommandMetadata struct {
+	Channel           *ChannelMetadata `json:"channel,omitempty" mapstructure:"channel,omitempty"`
+	AppSubscriptionID *string          `json:"appSubscriptionID,omitempty" mapstructure:"appSubscriptionID,omitempty"`
+}
+
+func (t *OnSlashCommand) Name() string {
+	return "slack.onSlashCommand"
+}
+
+func (t *OnSlashCommand) Label() string {
+	return "On Slash Command"
+}
+
+func (t *OnSlashCommand) Description() string {
+	return "Listen to slash commands sent to the Slack App"
+}
+
+func (t *OnSlashCommand) Documentation() string {
+	return `The On Slash Command trigger starts a workflow execution when someone runs a slash command handled by the Slack app.
+
+## Use Cases
+
+- **ChatOps**: Deploy, roll back or restart services with commands like ` + "`/deploy production`" + `
+- **Self-service**: Let team members request environments or access from Slack
+- **Incident response**: Kick off runbooks directly from an incident channel
+
+## Configuration
+
+- **Command**: The slash command to listen to, e.g. ` + "`/deploy`" + ` (required)
+- **Channel**: Optional channel filter - if specified, only commands sent in this channel will trigger
+
+## Event Data
+
+Each event includes the fields sent by Slack:
+- **command**: The command that was run
+- **text**: Everything typed after the command
+- **user_id** and **user_name**: Who ran the command
+- **channel_id** and **channel_name**: Where the command was run
+- **response_url**: URL that can be used to reply to the command for up to 30 minutes
+
+## Setup
+
+Slash commands are created in the Slack app settings. Go to "Slash Commands", click "Create New Command", and use the same request URL as the app interactivity, replacing ` + "`/interactions`" + ` with ` + "`/commands`" + `. Then reinstall the app to the workspace.`
+}
+
+func (t *OnSlashCommand) Icon() string {
+	return "slack"
+}
+
+func (t *OnSlashCommand) Color() string {
+	return "gray"
+}
+
+func (t *OnSlashCommand) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "command",
+			Label:       "Command",
+			Type:        configuration.FieldTypeString,
+			Description: "The slash command to listen to",
+			Placeholder: "/deploy",
+			Required:    true,
+		},
+		{
+			Name:     "channel",
+			Label:    "Channel",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "channel",
+				},
+			},
+		},
+	}
+}
+
+// normalizeCommand returns the command with a single leading slash.
+func normalizeCommand(command string) string {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return ""
+	}
+
+	return "/" + strings.TrimLeft(command, "/")
+}
+
+func (t *OnSlashCommand) Setup(ctx core.TriggerContext) error {
+	var metadata OnSlashCommandMetadata
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	var config OnSlashCommandConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	command := normalizeCommand(config.Command)
+	if command == "" {
+		return errors.New("command is required")
+	}
+
+	if strings.ContainsAny(command, " \t") {
+		return fmt.Errorf("command %q must not contain spaces", command)
+	}
+
+	channel, err := t.validateChannel(ctx, config, metadata)
+	if err != nil {
+		return fmt.Errorf("failed to validate channel: %w", err)
+	}
+
+	subscriptionID := metadata.AppSubscriptionID
+	if subscriptionID == nil {
+		id, err := ctx.Integration.Subscribe(SubscriptionConfiguration{
+			EventTypes: []string{SlashCommandEventType},
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to slash commands: %w", err)
+		}
+
+		s := id.String()
+		subscriptionID = &s
+	}
+
+	return ctx.Metadata.Set(OnSlashCommandMetadata{
+		AppSubscriptionID: subscriptionID,
+		Channel:           channel,
+	})
+}
+
+func (t *OnSlashCommand) validateChannel(ctx core.TriggerContext, config OnSlashCommandConfiguration, metadata OnSlashCommandMetadata) (*ChannelMetadata, error) {
+	if config.Channel == "" {
+		return nil, nil
+	}
+
+	if metadata.Channel != nil && config.Channel == metadata.Channel.ID {
+		return metadata.Channel, nil
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Slack client: %w", err)
+	}
+
+	channelInfo, err := client.GetChannelInfo(config.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("channel validation failed: %w", err)
+	}
+
+	return &ChannelMetadata{
+		ID:   channelInfo.ID,
+		Name: channelInfo.Name,
+	}, nil
+}
+
+func (t *OnSlashCommand) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *OnSlashCommand) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnSlashCommand) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnSlashCommand) OnIntegrationMessage(ctx core.IntegrationMessageContext) error {
+	config := OnSlashCommandConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	message, ok := ctx.Message.(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", ctx.Message)
+	}
+
+	command, _ := message["command"].(string)
+	if command != normalizeCommand(config.Command) {
+		return nil
+	}
+
+	channel, _ := message["channel_id"].(string)
+	if config.Channel != "" && config.Channel != channel {
+		ctx.Logger.Infof("command channel %s does not match configuration channel %s, ignoring", channel, config.Channel)
+		return nil
+	}
+
+	return ctx.Events.Emit("slack.slash.command", message)
+}
+
+func (t *OnSlashCommand) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
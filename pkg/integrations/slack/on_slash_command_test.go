@@ -0,0 +1,115 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnSlashCommand__Setup(t *testing.T) {
+	trigger := &OnSlashCommand{}
+
+	t.Run("missing command -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"command": " "},
+		})
+
+		require.ErrorContains(t, err, "command is required")
+	})
+
+	t.Run("command with spaces -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"command": "/deploy now"},
+		})
+
+		require.ErrorContains(t, err, "must not contain spaces")
+	})
+
+	t.Run("valid command -> subscribes to slash commands", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		integrationCtx := &contexts.IntegrationContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   integrationCtx,
+			Metadata:      metadata,
+			Configuration: map[string]any{"command": "deploy"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integrationCtx.Subscriptions, 1)
+		subConfig, ok := integrationCtx.Subscriptions[0].Configuration.(SubscriptionConfiguration)
+		require.True(t, ok)
+		assert.Equal(t, []string{SlashCommandEventType}, subConfig.EventTypes)
+
+		stored, ok := metadata.Metadata.(OnSlashCommandMetadata)
+		require.True(t, ok)
+		require.NotNil(t, stored.AppSubscriptionID)
+	})
+
+	t.Run("existing subscription -> does not subscribe again", func(t *testing.T) {
+		subscriptionID := uuid.NewString()
+		integrationCtx := &contexts.IntegrationContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   integrationCtx,
+			Metadata:      &contexts.MetadataContext{Metadata: OnSlashCommandMetadata{AppSubscriptionID: &subscriptionID}},
+			Configuration: map[string]any{"command": "/deploy"},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, integrationCtx.Subscriptions)
+	})
+}
+
+func Test__OnSlashCommand__OnIntegrationMessage(t *testing.T) {
+	trigger := &OnSlashCommand{}
+
+	t.Run("different command -> ignore", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Configuration: map[string]any{"command": "/deploy"},
+			Message:       map[string]any{"command": "/rollback", "channel_id": "C123"},
+			Logger:        logrus.NewEntry(logrus.New()),
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("channel mismatch -> ignore", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Configuration: map[string]any{"command": "/deploy", "channel": "C999"},
+			Message:       map[string]any{"command": "/deploy", "channel_id": "C123"},
+			Logger:        logrus.NewEntry(logrus.New()),
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("matching command -> emit", func(t *testing.T) {
+		message := map[string]any{"command": "/deploy", "text": "api production", "channel_id": "C123"}
+		events := &contexts.EventContext{}
+		err := trigger.OnIntegrationMessage(core.IntegrationMessageContext{
+			Configuration: map[string]any{"command": "deploy"},
+			Message:       message,
+			Logger:        logrus.NewEntry(logrus.New()),
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "slack.slash.command", events.Payloads[0].Type)
+		assert.Equal(t, message, events.Payloads[0].Data)
+	})
+}
@@ -0,0 +1,238 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SendBlocksMessage struct{}
+
+type SendBlocksMessageConfiguration struct {
+	Channel string `json:"channel" mapstructure:"channel"`
+	Blocks  string `json:"blocks" mapstructure:"blocks"`
+	Text    string `json:"text" mapstructure:"text"`
+}
+
+func (c *SendBlocksMessage) Name() string {
+	return "slack.sendBlocksMessage"
+}
+
+func (c *SendBlocksMessage) Label() string {
+	return "Send Blocks Message"
+}
+
+func (c *SendBlocksMessage) Description() string {
+	return "Send a Block Kit message to a Slack channel"
+}
+
+func (c *SendBlocksMessage) Documentation() string {
+	return `The Send Blocks Message component sends a rich message built with Slack Block Kit to a Slack channel.
+
+## Use Cases
+
+- **Rich notifications**: Send deployment summaries with sections, fields and links
+- **Reports**: Post structured reports with headers, dividers and context blocks
+- **Custom layouts**: Use layouts designed in the Slack Block Kit Builder
+
+## Configuration
+
+- **Channel**: Select the Slack channel to send the message to
+- **Blocks**: JSON array of Block Kit blocks (supports expressions). You can design the blocks in the Slack Block Kit Builder and paste the "blocks" array here
+- **Fallback Text**: Plain text shown in notifications and by clients that cannot render blocks (optional)
+
+## Output
+
+Returns the message sent to Slack, including the channel and message timestamp.
+
+## Notes
+
+- The Slack app must be installed and have permission to post to the selected channel
+- Slack accepts at most 50 blocks per message
+- Interactive elements in the blocks are not routed back to the workflow. Use Wait for Button Click or Wait for Approval for that`
+}
+
+func (c *SendBlocksMessage) Icon() string {
+	return "slack"
+}
+
+func (c *SendBlocksMessage) Color() string {
+	return "gray"
+}
+
+func (c *SendBlocksMessage) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SendBlocksMessage) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "channel",
+			Label:    "Channel",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "channel",
+				},
+			},
+		},
+		{
+			Name:        "blocks",
+			Label:       "Blocks",
+			Type:        configuration.FieldTypeText,
+			Description: "JSON array of Slack Block Kit blocks",
+			Required:    true,
+			Default:     `[{"type":"section","text":{"type":"mrkdwn","text":"Hello from *SuperPlane*"}}]`,
+		},
+		{
+			Name:        "text",
+			Label:       "Fallback Text",
+			Type:        configuration.FieldTypeString,
+			Description: "Text shown in notifications and by clients that cannot render blocks",
+			Required:    false,
+		},
+	}
+}
+
+// parseBlocks decodes the configured blocks into a list of Block Kit blocks.
+func parseBlocks(blocks string) ([]any, error) {
+	blocks = strings.TrimSpace(blocks)
+	if blocks == "" {
+		return nil, errors.New("blocks are required")
+	}
+
+	var parsed []any
+	if err := json.Unmarshal([]byte(blocks), &parsed); err != nil {
+		return nil, fmt.Errorf("blocks must be a JSON array: %w", err)
+	}
+
+	if len(parsed) == 0 {
+		return nil, errors.New("at least one block is required")
+	}
+
+	if len(parsed) > 50 {
+		return nil, errors.New("maximum of 50 blocks allowed")
+	}
+
+	for i, block := range parsed {
+		object, ok := block.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("block %d: must be an object", i)
+		}
+
+		if blockType, _ := object["type"].(string); blockType == "" {
+			return nil, fmt.Errorf("block %d: type is required", i)
+		}
+	}
+
+	return parsed, nil
+}
+
+func (c *SendBlocksMessage) Setup(ctx core.SetupContext) error {
+	var config SendBlocksMessageConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Channel == "" {
+		return errors.New("channel is required")
+	}
+
+	//
+	// Blocks built from expressions can only be validated on execution.
+	//
+	if !strings.Contains(config.Blocks, "{{") {
+		if _, err := parseBlocks(config.Blocks); err != nil {
+			return err
+		}
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+
+	channelInfo, err := client.GetChannelInfo(config.Channel)
+	if err != nil {
+		return fmt.Errorf("channel validation failed: %w", err)
+	}
+
+	if channelInfo == nil {
+		return fmt.Errorf("channel validation failed: GetChannelInfo returned nil for '%s'", config.Channel)
+	}
+
+	return ctx.Metadata.Set(SendTextMessageMetadata{
+		Channel: &ChannelMetadata{
+			ID:   channelInfo.ID,
+			Name: channelInfo.Name,
+		},
+	})
+}
+
+func (c *SendBlocksMessage) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *SendBlocksMessage) Execute(ctx core.ExecutionContext) error {
+	var config SendBlocksMessageConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Channel == "" {
+		return errors.New("channel is required")
+	}
+
+	blocks, err := parseBlocks(config.Blocks)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+
+	response, err := client.PostMessage(ChatPostMessageRequest{
+		Channel: config.Channel,
+		Text:    config.Text,
+		Blocks:  blocks,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"slack.message.sent",
+		[]any{response.Message},
+	)
+}
+
+func (c *SendBlocksMessage) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *SendBlocksMessage) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *SendBlocksMessage) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *SendBlocksMessage) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SendBlocksMessage) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
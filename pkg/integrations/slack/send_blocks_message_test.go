@@ -0,0 +1,134 @@
+package slack
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SendBlocksMessage__Setup(t *testing.T) {
+	component := &SendBlocksMessage{}
+
+	t.Run("missing channel -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"channel": ""},
+		})
+
+		require.ErrorContains(t, err, "channel is required")
+	})
+
+	t.Run("blocks are not a JSON array -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"channel": "C123",
+				"blocks":  `{"type": "section"}`,
+			},
+		})
+
+		require.ErrorContains(t, err, "blocks must be a JSON array")
+	})
+
+	t.Run("block without type -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"channel": "C123",
+				"blocks":  `[{"text": {"type": "mrkdwn", "text": "hi"}}]`,
+			},
+		})
+
+		require.ErrorContains(t, err, "block 0: type is required")
+	})
+
+	t.Run("blocks with expressions -> validated on execution", func(t *testing.T) {
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"ok": true, "channel": {"id": "C123", "name": "general"}}`), nil
+		})
+
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"botToken": "token-123"},
+			},
+			Metadata: metadata,
+			Configuration: map[string]any{
+				"channel": "C123",
+				"blocks":  `{{ $['Build Blocks'].data.blocks }}`,
+			},
+		})
+
+		require.NoError(t, err)
+		stored, ok := metadata.Metadata.(SendTextMessageMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "general", stored.Channel.Name)
+	})
+}
+
+func Test__SendBlocksMessage__Execute(t *testing.T) {
+	component := &SendBlocksMessage{}
+
+	t.Run("valid blocks -> sends message and emits", func(t *testing.T) {
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://slack.com/api/chat.postMessage", req.URL.String())
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			var payload ChatPostMessageRequest
+			require.NoError(t, json.Unmarshal(body, &payload))
+			assert.Equal(t, "C123", payload.Channel)
+			assert.Equal(t, "Deployment finished", payload.Text)
+			require.Len(t, payload.Blocks, 2)
+
+			return jsonResponse(http.StatusOK, `{"ok": true, "ts": "1.2", "message": {"ts": "1.2", "text": "Deployment finished"}}`), nil
+		})
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"botToken": "token-123"},
+			},
+			ExecutionState: execState,
+			Configuration: map[string]any{
+				"channel": "C123",
+				"text":    "Deployment finished",
+				"blocks":  `[{"type": "header", "text": {"type": "plain_text", "text": "Deploy"}}, {"type": "divider"}]`,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, core.DefaultOutputChannel.Name, execState.Channel)
+		assert.Equal(t, "slack.message.sent", execState.Type)
+		require.Len(t, execState.Payloads, 1)
+	})
+
+	t.Run("invalid blocks -> error without sending", func(t *testing.T) {
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request to %s", req.URL.String())
+			return nil, nil
+		})
+
+		err := component.Execute(core.ExecutionContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"botToken": "token-123"},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Configuration: map[string]any{
+				"channel": "C123",
+				"blocks":  `[]`,
+			},
+		})
+
+		require.ErrorContains(t, err, "at least one block is required")
+	})
+}
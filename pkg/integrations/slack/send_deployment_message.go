@@ -0,0 +1,241 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SendDeploymentMessage struct{}
+
+type SendDeploymentMessageConfiguration struct {
+	Channel string `json:"channel" mapstructure:"channel"`
+}
+
+func (c *SendDeploymentMessage) Name() string {
+	return "slack.sendDeploymentMessage"
+}
+
+func (c *SendDeploymentMessage) Label() string {
+	return "Send Deployment Message"
+}
+
+func (c *SendDeploymentMessage) Description() string {
+	return "Announce a deployment in a Slack channel"
+}
+
+func (c *SendDeploymentMessage) Documentation() string {
+	return `The Send Deployment Message component announces a deployment in a Slack channel, with its service, version, environment and status.
+
+## Use Cases
+
+- **Deployment announcements**: Let the team know what was deployed, and where
+- **Failure alerts**: Tell the channel of a service when one of its deployments fails or is rolled back
+
+## Configuration
+
+- **Channel**: Select the Slack channel to send the message to
+- **Service**, **Version**, **Environment**: The deployed service, its version, and the environment it was deployed to
+- **Status**: Started, succeeded, failed or rolled back
+- **URL** and **Description**: Optional link and details added to the message
+
+The same deployment fields are used by the Datadog and Grafana deployment components,
+so they can be mapped the same way for all of them.
+
+## Output
+
+Returns the message sent to Slack, including the channel and message timestamp, with the deployment under ` + "`deployment`" + `.
+
+## Notes
+
+- The Slack app must be installed and have permission to post to the selected channel`
+}
+
+func (c *SendDeploymentMessage) Icon() string {
+	return "slack"
+}
+
+func (c *SendDeploymentMessage) Color() string {
+	return "gray"
+}
+
+func (c *SendDeploymentMessage) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SendDeploymentMessage) Configuration() []configuration.Field {
+	return append([]configuration.Field{
+		{
+			Name:     "channel",
+			Label:    "Channel",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "channel",
+				},
+			},
+		},
+	}, core.DeploymentConfigurationFields()...)
+}
+
+func (c *SendDeploymentMessage) Setup(ctx core.SetupContext) error {
+	var config SendDeploymentMessageConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Channel == "" {
+		return errors.New("channel is required")
+	}
+
+	if _, err := core.DecodeDeployment(ctx.Configuration); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+
+	channelInfo, err := client.GetChannelInfo(config.Channel)
+	if err != nil {
+		return fmt.Errorf("channel validation failed: %w", err)
+	}
+
+	if channelInfo == nil {
+		return fmt.Errorf("channel validation failed: GetChannelInfo returned nil for '%s'", config.Channel)
+	}
+
+	return ctx.Metadata.Set(SendTextMessageMetadata{
+		Channel: &ChannelMetadata{
+			ID:   channelInfo.ID,
+			Name: channelInfo.Name,
+		},
+	})
+}
+
+func (c *SendDeploymentMessage) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *SendDeploymentMessage) Execute(ctx core.ExecutionContext) error {
+	var config SendDeploymentMessageConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Channel == "" {
+		return errors.New("channel is required")
+	}
+
+	deployment, err := core.DecodeDeployment(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+
+	response, err := client.PostMessage(ChatPostMessageRequest{
+		Channel: config.Channel,
+		Text:    deployment.Title(),
+		Blocks:  deploymentBlocks(deployment),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	message := maps.Clone(response.Message)
+	if message == nil {
+		message = map[string]any{}
+	}
+
+	message["deployment"] = deployment.ToMap()
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"slack.message.sent",
+		[]any{message},
+	)
+}
+
+var deploymentStatusEmojis = map[string]string{
+	core.DeploymentStatusStarted:    ":rocket:",
+	core.DeploymentStatusSucceeded:  ":white_check_mark:",
+	core.DeploymentStatusFailed:     ":x:",
+	core.DeploymentStatusRolledBack: ":rewind:",
+}
+
+var deploymentStatusLabels = map[string]string{
+	core.DeploymentStatusStarted:    "Started",
+	core.DeploymentStatusSucceeded:  "Succeeded",
+	core.DeploymentStatusFailed:     "Failed",
+	core.DeploymentStatusRolledBack: "Rolled back",
+}
+
+func deploymentBlocks(deployment core.Deployment) []any {
+	mrkdwn := func(text string) map[string]any {
+		return map[string]any{"type": "mrkdwn", "text": text}
+	}
+
+	blocks := []any{
+		map[string]any{
+			"type": "section",
+			"text": mrkdwn(fmt.Sprintf("%s *%s*", deploymentStatusEmojis[deployment.Status], deployment.Title())),
+		},
+		map[string]any{
+			"type": "section",
+			"fields": []any{
+				mrkdwn("*Service*\n" + deployment.Service),
+				mrkdwn("*Version*\n`" + deployment.Version + "`"),
+				mrkdwn("*Environment*\n" + deployment.Environment),
+				mrkdwn("*Status*\n" + deploymentStatusLabels[deployment.Status]),
+			},
+		},
+	}
+
+	if deployment.Description != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": mrkdwn(deployment.Description),
+		})
+	}
+
+	if deployment.URL != "" {
+		blocks = append(blocks, map[string]any{
+			"type":     "context",
+			"elements": []any{mrkdwn(fmt.Sprintf("<%s|View deployment>", deployment.URL))},
+		})
+	}
+
+	return blocks
+}
+
+func (c *SendDeploymentMessage) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+
+func (c *SendDeploymentMessage) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *SendDeploymentMessage) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *SendDeploymentMessage) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SendDeploymentMessage) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
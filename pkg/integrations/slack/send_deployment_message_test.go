@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SendDeploymentMessage__Setup(t *testing.T) {
+	component := &SendDeploymentMessage{}
+
+	t.Run("missing channel -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"channel": ""},
+		})
+
+		require.ErrorContains(t, err, "channel is required")
+	})
+
+	t.Run("missing deployment fields -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"channel": "C123",
+				"service": "checkout",
+			},
+		})
+
+		require.ErrorContains(t, err, "version is required")
+	})
+
+	t.Run("valid configuration -> stores channel metadata", func(t *testing.T) {
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"ok": true, "channel": {"id": "C123", "name": "deploys"}}`), nil
+		})
+
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"botToken": "token-123"},
+			},
+			Metadata: metadata,
+			Configuration: map[string]any{
+				"channel":     "C123",
+				"service":     "checkout",
+				"version":     "v1.4.2",
+				"environment": "production",
+			},
+		})
+
+		require.NoError(t, err)
+		stored, ok := metadata.Metadata.(SendTextMessageMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "deploys", stored.Channel.Name)
+	})
+}
+
+func Test__SendDeploymentMessage__Execute(t *testing.T) {
+	component := &SendDeploymentMessage{}
+
+	withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://slack.com/api/chat.postMessage", req.URL.String())
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		var payload ChatPostMessageRequest
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "C123", payload.Channel)
+		assert.Equal(t, "Failed to deploy checkout v1.4.2 to production", payload.Text)
+		require.Len(t, payload.Blocks, 3)
+		assert.Contains(t, string(body), `:x: *Failed to deploy checkout v1.4.2 to production*`)
+		link := payload.Blocks[2].(map[string]any)["elements"].([]any)[0].(map[string]any)
+		assert.Equal(t, "<https://ci.example.com/runs/1|View deployment>", link["text"])
+
+		return jsonResponse(http.StatusOK, `{"ok": true, "ts": "1.2", "message": {"ts": "1.2", "text": "Failed to deploy checkout v1.4.2 to production"}}`), nil
+	})
+
+	execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+	err := component.Execute(core.ExecutionContext{
+		Integration: &contexts.IntegrationContext{
+			Configuration: map[string]any{"botToken": "token-123"},
+		},
+		ExecutionState: execState,
+		Configuration: map[string]any{
+			"channel":     "C123",
+			"service":     "checkout",
+			"version":     "v1.4.2",
+			"environment": "production",
+			"status":      core.DeploymentStatusFailed,
+			"url":         "https://ci.example.com/runs/1",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, core.DefaultOutputChannel.Name, execState.Channel)
+	assert.Equal(t, "slack.message.sent", execState.Type)
+	require.Len(t, execState.Payloads, 1)
+
+	data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, "1.2", data["ts"])
+	deployment := data["deployment"].(map[string]any)
+	assert.Equal(t, "checkout", deployment["service"])
+	assert.Equal(t, core.DeploymentStatusFailed, deployment["status"])
+}
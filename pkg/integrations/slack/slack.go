@@ -109,13 +109,17 @@ func (s *Slack) HandleAction(ctx core.IntegrationActionContext) error {
 func (s *Slack) Components() []core.Component {
 	return []core.Component{
 		&SendTextMessage{},
+		&SendBlocksMessage{},
+		&SendDeploymentMessage{},
 		&WaitForButtonClick{},
+		&WaitForApproval{},
 	}
 }
 
 func (s *Slack) Triggers() []core.Trigger {
 	return []core.Trigger{
 		&OnAppMention{},
+		&OnSlashCommand{},
 	}
 }
 
@@ -230,6 +234,7 @@ func (s *Slack) appManifest(ctx core.SyncContext) ([]byte, error) {
 				"bot": []string{
 					"app_mentions:read",
 					"chat:write",
+					"commands",
 					"chat:write.public",
 					"channels:history",
 					"groups:history",
@@ -290,6 +295,11 @@ func (s *Slack) HandleRequest(ctx core.HTTPRequestContext) {
 		return
 	}
 
+	if strings.HasSuffix(ctx.Request.URL.Path, "/commands") {
+		s.handleSlashCommand(ctx, body)
+		return
+	}
+
 	ctx.Logger.Warnf("unknown path: %s", ctx.Request.URL.Path)
 	ctx.Response.WriteHeader(http.StatusNotFound)
 }
@@ -345,6 +355,80 @@ func (s *Slack) handleEvent(ctx core.HTTPRequestContext, body []byte) {
 	}
 }
 
+var slashCommandFields = []string{
+	"command",
+	"text",
+	"user_id",
+	"user_name",
+	"channel_id",
+	"channel_name",
+	"team_id",
+	"team_domain",
+	"enterprise_id",
+	"api_app_id",
+	"response_url",
+	"trigger_id",
+}
+
+// handleSlashCommand forwards slash commands to the On Slash Command triggers.
+// Slack sends them as form data and expects an answer within 3 seconds,
+// so the command is acknowledged right away with an ephemeral message.
+func (s *Slack) handleSlashCommand(ctx core.HTTPRequestContext, body []byte) {
+	formValues, err := url.ParseQuery(string(body))
+	if err != nil {
+		ctx.Logger.Errorf("error parsing form data: %v", err)
+		ctx.Response.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	command := formValues.Get("command")
+	if command == "" {
+		ctx.Logger.Errorf("missing command in form data")
+		ctx.Response.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	event := map[string]any{}
+	for _, field := range slashCommandFields {
+		if value := formValues.Get(field); value != "" {
+			event[field] = value
+		}
+	}
+
+	subscriptions, err := ctx.Integration.ListSubscriptions()
+	if err != nil {
+		ctx.Logger.Errorf("error listing subscriptions: %v", err)
+		ctx.Response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !s.subscriptionApplies(ctx, subscription, SlashCommandEventType) {
+			continue
+		}
+
+		err = subscription.SendMessage(event)
+		if err != nil {
+			ctx.Logger.Errorf("error sending message from app: %v", err)
+		}
+	}
+
+	response, err := json.Marshal(map[string]string{
+		"response_type": "ephemeral",
+		"text":          fmt.Sprintf("Received `%s`", strings.TrimSpace(command+" "+formValues.Get("text"))),
+	})
+	if err != nil {
+		ctx.Logger.Errorf("error marshaling slash command response: %v", err)
+		return
+	}
+
+	ctx.Response.Header().Set("Content-Type", "application/json")
+	_, err = ctx.Response.Write(response)
+	if err != nil {
+		ctx.Logger.Errorf("error writing slash command response: %v", err)
+	}
+}
+
 func (s *Slack) handleChallenge(ctx core.HTTPRequestContext, payload EventPayload) {
 	if payload.Challenge == "" {
 		ctx.Logger.Errorf("missing challenge in event payload")
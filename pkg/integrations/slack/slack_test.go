@@ -200,3 +200,44 @@ func Test__Slack__HandleEvent__Challenge(t *testing.T) {
 		assert.Equal(t, "challenge-token", recorder.Body.String())
 	})
 }
+
+func Test__Slack__HandleSlashCommand(t *testing.T) {
+	s := &Slack{}
+
+	t.Run("missing command -> 400", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		s.handleSlashCommand(core.HTTPRequestContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			Response:    recorder,
+			Integration: &contexts.IntegrationContext{},
+		}, []byte("text=hello"))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("command -> acknowledged with ephemeral message", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("command", "/deploy")
+		form.Set("text", "api production")
+		form.Set("channel_id", "C123")
+		form.Set("user_id", "U123")
+
+		recorder := httptest.NewRecorder()
+		s.handleSlashCommand(core.HTTPRequestContext{
+			Logger:   logrus.NewEntry(logrus.New()),
+			Response: recorder,
+			Integration: &contexts.IntegrationContext{
+				Subscriptions: []contexts.Subscription{
+					{ID: uuid.New(), Configuration: SubscriptionConfiguration{EventTypes: []string{SlashCommandEventType}}},
+				},
+			},
+		}, []byte(form.Encode()))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response map[string]string
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "ephemeral", response["response_type"])
+		assert.Equal(t, "Received `/deploy api production`", response["text"])
+	})
+}
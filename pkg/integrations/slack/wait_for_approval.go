@@ -0,0 +1,437 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ChannelApproved = "approved"
+	ChannelDenied   = "denied"
+
+	ApprovalValueApprove = "approve"
+	ApprovalValueDeny    = "deny"
+)
+
+type WaitForApproval struct{}
+
+type WaitForApprovalConfiguration struct {
+	Channel string `json:"channel" mapstructure:"channel"`
+	Message string `json:"message" mapstructure:"message"`
+	Timeout *int   `json:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+}
+
+type WaitForApprovalMetadata struct {
+	Channel           *ChannelMetadata `json:"channel" mapstructure:"channel"`
+	MessageTS         *string          `json:"messageTS,omitempty" mapstructure:"messageTS,omitempty"`
+	Decision          *string          `json:"decision,omitempty" mapstructure:"decision,omitempty"`
+	AppSubscriptionID *string          `json:"appSubscriptionID,omitempty" mapstructure:"appSubscriptionID,omitempty"`
+}
+
+func (c *WaitForApproval) Name() string {
+	return "slack.waitForApproval"
+}
+
+func (c *WaitForApproval) Label() string {
+	return "Wait for Approval"
+}
+
+func (c *WaitForApproval) Description() string {
+	return "Send an approval request to Slack and wait for someone to approve or deny it"
+}
+
+func (c *WaitForApproval) Documentation() string {
+	return `The Wait for Approval component posts a message with **Approve** and **Deny** buttons to a Slack channel and waits for someone to click one of them.
+
+## Use Cases
+
+- **Deployment gates**: Require a human approval in Slack before deploying to production
+- **Change requests**: Approve or deny infrastructure changes from the team channel
+- **Access requests**: Let channel members approve temporary access grants
+
+## Configuration
+
+- **Channel**: Slack channel to post the approval request to (required)
+- **Message**: Message text describing what needs approval (supports Slack formatting, required)
+- **Timeout**: Maximum time to wait in seconds (optional)
+
+## Output Channels
+
+- **Approved**: Emits when someone clicks Approve
+- **Denied**: Emits when someone clicks Deny
+- **Timeout**: Emits when nobody answers within the configured timeout
+
+## Behavior
+
+- Only the first click is processed; subsequent clicks are ignored
+- Once a decision is made, the buttons are replaced with the decision and who made it
+- If timeout is not configured, the component waits indefinitely
+
+## Notes
+
+- The Slack app must be installed and have permission to post to the selected channel
+- Anyone who can see the message can approve or deny it`
+}
+
+func (c *WaitForApproval) Icon() string {
+	return "slack"
+}
+
+func (c *WaitForApproval) Color() string {
+	return "gray"
+}
+
+func (c *WaitForApproval) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: ChannelApproved, Label: "Approved", Description: "Emits when the request is approved"},
+		{Name: ChannelDenied, Label: "Denied", Description: "Emits when the request is denied"},
+		{Name: ChannelTimeout, Label: "Timeout", Description: "Emits when timeout is reached"},
+	}
+}
+
+func (c *WaitForApproval) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "channel",
+			Label:    "Channel",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "channel",
+				},
+			},
+		},
+		{
+			Name:     "message",
+			Label:    "Message",
+			Type:     configuration.FieldTypeText,
+			Required: true,
+		},
+		{
+			Name:        "timeout",
+			Label:       "Timeout",
+			Type:        configuration.FieldTypeNumber,
+			Description: "Maximum time to wait in seconds (leave empty to wait indefinitely)",
+			Required:    false,
+			Default:     "3600",
+		},
+	}
+}
+
+func (c *WaitForApproval) Setup(ctx core.SetupContext) error {
+	var config WaitForApprovalConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Channel == "" {
+		return errors.New("channel is required")
+	}
+
+	if config.Message == "" {
+		return errors.New("message is required")
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+
+	channelInfo, err := client.GetChannelInfo(config.Channel)
+	if err != nil {
+		return fmt.Errorf("channel validation failed: %w", err)
+	}
+
+	if channelInfo == nil {
+		return fmt.Errorf("channel validation failed: GetChannelInfo returned nil for '%s'", config.Channel)
+	}
+
+	return ctx.Metadata.Set(WaitForApprovalMetadata{
+		Channel: &ChannelMetadata{
+			ID:   channelInfo.ID,
+			Name: channelInfo.Name,
+		},
+	})
+}
+
+func (c *WaitForApproval) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func approvalBlocks(message string) []any {
+	return []any{
+		map[string]any{
+			"type": "section",
+			"text": map[string]string{
+				"type": "mrkdwn",
+				"text": message,
+			},
+		},
+		map[string]any{
+			"type": "actions",
+			"elements": []map[string]any{
+				{
+					"type":      "button",
+					"text":      map[string]string{"type": "plain_text", "text": "Approve"},
+					"style":     "primary",
+					"value":     ApprovalValueApprove,
+					"action_id": "approval_" + ApprovalValueApprove,
+				},
+				{
+					"type":      "button",
+					"text":      map[string]string{"type": "plain_text", "text": "Deny"},
+					"style":     "danger",
+					"value":     ApprovalValueDeny,
+					"action_id": "approval_" + ApprovalValueDeny,
+				},
+			},
+		},
+	}
+}
+
+// decisionBlocks replaces the approval buttons with the outcome,
+// so the message cannot be answered again once a decision is made.
+func decisionBlocks(message, outcome string) []any {
+	return []any{
+		map[string]any{
+			"type": "section",
+			"text": map[string]string{
+				"type": "mrkdwn",
+				"text": message,
+			},
+		},
+		map[string]any{
+			"type": "context",
+			"elements": []map[string]string{
+				{"type": "mrkdwn", "text": outcome},
+			},
+		},
+	}
+}
+
+func (c *WaitForApproval) Execute(ctx core.ExecutionContext) error {
+	var config WaitForApprovalConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Channel == "" {
+		return errors.New("channel is required")
+	}
+
+	if config.Message == "" {
+		return errors.New("message is required")
+	}
+
+	var metadata WaitForApprovalMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+
+	//
+	// Interactions reference the channel by ID,
+	// so we need a real channel ID for the subscription.
+	//
+	if metadata.Channel == nil || metadata.Channel.ID == "" {
+		channelInfo, err := client.GetChannelInfo(config.Channel)
+		if err != nil {
+			return fmt.Errorf("failed to resolve channel id for '%s': %w", config.Channel, err)
+		}
+
+		if channelInfo == nil {
+			return fmt.Errorf("failed to resolve channel info for '%s': GetChannelInfo returned nil", config.Channel)
+		}
+
+		metadata.Channel = &ChannelMetadata{ID: channelInfo.ID, Name: channelInfo.Name}
+	}
+
+	response, err := client.PostMessage(ChatPostMessageRequest{
+		Channel: config.Channel,
+		Text:    config.Message,
+		Blocks:  approvalBlocks(config.Message),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	subscriptionID, err := ctx.Integration.Subscribe(map[string]any{
+		"type":         "button_click",
+		"message_ts":   response.TS,
+		"channel_id":   metadata.Channel.ID,
+		"execution_id": ctx.ID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to button clicks: %w", err)
+	}
+
+	messageTS := response.TS
+	subIDStr := subscriptionID.String()
+	metadata.MessageTS = &messageTS
+	metadata.AppSubscriptionID = &subIDStr
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	if config.Timeout != nil && *config.Timeout > 0 {
+		timeout := time.Duration(*config.Timeout) * time.Second
+		if err := ctx.Requests.ScheduleActionCall(ActionTimeout, map[string]any{}, timeout); err != nil {
+			return fmt.Errorf("failed to schedule timeout: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *WaitForApproval) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *WaitForApproval) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name: ActionButtonClick,
+		},
+		{
+			Name: ActionTimeout,
+		},
+	}
+}
+
+func (c *WaitForApproval) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case ActionButtonClick:
+		return c.handleButtonClick(ctx)
+	case ActionTimeout:
+		return c.handleTimeout(ctx)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *WaitForApproval) handleButtonClick(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	value, ok := ctx.Parameters["value"].(string)
+	if !ok {
+		return errors.New("button value not found in parameters")
+	}
+
+	var channel, payloadType, outcome string
+	switch value {
+	case ApprovalValueApprove:
+		channel = ChannelApproved
+		payloadType = "slack.approval.approved"
+		outcome = ":white_check_mark: Approved"
+	case ApprovalValueDeny:
+		channel = ChannelDenied
+		payloadType = "slack.approval.denied"
+		outcome = ":x: Denied"
+	default:
+		return fmt.Errorf("unknown approval value: %s", value)
+	}
+
+	var metadata WaitForApprovalMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	metadata.Decision = &channel
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	payload := map[string]any{
+		"decision":   channel,
+		"decided_at": time.Now().Format(time.RFC3339),
+	}
+
+	if clickedBy, ok := ctx.Parameters["clicked_by"].(map[string]any); ok && len(clickedBy) > 0 {
+		payload["decided_by"] = clickedBy
+		if userID, ok := clickedBy["id"].(string); ok && userID != "" {
+			outcome = fmt.Sprintf("%s by <@%s>", outcome, userID)
+		}
+	}
+
+	c.replaceButtons(ctx, metadata, outcome)
+
+	return ctx.ExecutionState.Emit(channel, payloadType, []any{payload})
+}
+
+func (c *WaitForApproval) handleTimeout(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	var metadata WaitForApprovalMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	c.replaceButtons(ctx, metadata, ":hourglass: Approval request timed out")
+
+	payload := map[string]any{
+		"timeout_at": time.Now().Format(time.RFC3339),
+	}
+
+	return ctx.ExecutionState.Emit(
+		ChannelTimeout,
+		"slack.approval.timeout",
+		[]any{payload},
+	)
+}
+
+// replaceButtons updates the approval message with the outcome.
+// The decision is already made at this point, so failures are only logged.
+func (c *WaitForApproval) replaceButtons(ctx core.ActionContext, metadata WaitForApprovalMetadata, outcome string) {
+	if metadata.Channel == nil || metadata.MessageTS == nil {
+		return
+	}
+
+	var config WaitForApprovalConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		ctx.Logger.Warnf("failed to decode configuration: %v", err)
+		return
+	}
+
+	client, err := NewClient(ctx.Integration)
+	if err != nil {
+		ctx.Logger.Warnf("failed to create Slack client: %v", err)
+		return
+	}
+
+	_, err = client.UpdateMessage(ChatUpdateRequest{
+		Channel:   metadata.Channel.ID,
+		Timestamp: *metadata.MessageTS,
+		Text:      config.Message,
+		Blocks:    decisionBlocks(config.Message, outcome),
+	})
+
+	if err != nil {
+		ctx.Logger.Warnf("failed to update approval message: %v", err)
+	}
+}
+
+func (c *WaitForApproval) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *WaitForApproval) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
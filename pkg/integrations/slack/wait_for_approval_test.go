@@ -0,0 +1,230 @@
+package slack
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__WaitForApproval__Setup(t *testing.T) {
+	component := &WaitForApproval{}
+
+	t.Run("missing channel -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"channel": ""},
+		})
+
+		require.ErrorContains(t, err, "channel is required")
+	})
+
+	t.Run("missing message -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"channel": "C123"},
+		})
+
+		require.ErrorContains(t, err, "message is required")
+	})
+}
+
+func Test__WaitForApproval__Execute(t *testing.T) {
+	component := &WaitForApproval{}
+
+	t.Run("sends approve and deny buttons and subscribes to clicks", func(t *testing.T) {
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://slack.com/api/chat.postMessage", req.URL.String())
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(body, &payload))
+			blocks := payload["blocks"].([]any)
+			require.Len(t, blocks, 2)
+			elements := blocks[1].(map[string]any)["elements"].([]any)
+			require.Len(t, elements, 2)
+			assert.Equal(t, ApprovalValueApprove, elements[0].(map[string]any)["value"])
+			assert.Equal(t, ApprovalValueDeny, elements[1].(map[string]any)["value"])
+
+			return jsonResponse(http.StatusOK, `{"ok": true, "ts": "1234567890.123456"}`), nil
+		})
+
+		executionID := uuid.New()
+		metadata := &contexts.MetadataContext{
+			Metadata: WaitForApprovalMetadata{Channel: &ChannelMetadata{ID: "C123", Name: "general"}},
+		}
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{"botToken": "token-123"},
+		}
+		requestsCtx := &contexts.RequestContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:          executionID,
+			Integration: integrationCtx,
+			Metadata:    metadata,
+			Requests:    requestsCtx,
+			Configuration: map[string]any{
+				"channel": "C123",
+				"message": "Deploy to production?",
+				"timeout": 60,
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integrationCtx.Subscriptions, 1)
+		assert.Equal(t, map[string]any{
+			"type":         "button_click",
+			"message_ts":   "1234567890.123456",
+			"channel_id":   "C123",
+			"execution_id": executionID.String(),
+		}, integrationCtx.Subscriptions[0].Configuration)
+
+		stored, ok := metadata.Metadata.(WaitForApprovalMetadata)
+		require.True(t, ok)
+		require.NotNil(t, stored.MessageTS)
+		assert.Equal(t, "1234567890.123456", *stored.MessageTS)
+
+		assert.Equal(t, ActionTimeout, requestsCtx.Action)
+		assert.Equal(t, 60*time.Second, requestsCtx.Duration)
+	})
+}
+
+func Test__WaitForApproval__HandleAction(t *testing.T) {
+	component := &WaitForApproval{}
+	messageTS := "1234567890.123456"
+
+	newMetadata := func() *contexts.MetadataContext {
+		return &contexts.MetadataContext{
+			Metadata: WaitForApprovalMetadata{
+				Channel:   &ChannelMetadata{ID: "C123", Name: "general"},
+				MessageTS: &messageTS,
+			},
+		}
+	}
+
+	t.Run("approve -> emits on approved channel and replaces buttons", func(t *testing.T) {
+		var update map[string]any
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://slack.com/api/chat.update", req.URL.String())
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(body, &update))
+			return jsonResponse(http.StatusOK, `{"ok": true}`), nil
+		})
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		metadata := newMetadata()
+		err := component.HandleAction(core.ActionContext{
+			Name:          ActionButtonClick,
+			Logger:        logrus.NewEntry(logrus.New()),
+			Metadata:      metadata,
+			Configuration: map[string]any{"channel": "C123", "message": "Deploy to production?"},
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"botToken": "token-123"},
+			},
+			Parameters: map[string]any{
+				"value":      ApprovalValueApprove,
+				"clicked_by": map[string]any{"id": "U123", "username": "pedro"},
+			},
+			ExecutionState: execState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelApproved, execState.Channel)
+		assert.Equal(t, "slack.approval.approved", execState.Type)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, ChannelApproved, payload["decision"])
+		assert.Equal(t, map[string]any{"id": "U123", "username": "pedro"}, payload["decided_by"])
+
+		assert.Equal(t, "C123", update["channel"])
+		assert.Equal(t, messageTS, update["ts"])
+		blocks := update["blocks"].([]any)
+		require.Len(t, blocks, 2)
+		outcome := blocks[1].(map[string]any)["elements"].([]any)[0].(map[string]any)
+		assert.Equal(t, ":white_check_mark: Approved by <@U123>", outcome["text"])
+
+		stored := metadata.Metadata.(WaitForApprovalMetadata)
+		require.NotNil(t, stored.Decision)
+		assert.Equal(t, ChannelApproved, *stored.Decision)
+	})
+
+	t.Run("deny -> emits on denied channel even if message update fails", func(t *testing.T) {
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"ok": false, "error": "message_not_found"}`), nil
+		})
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:          ActionButtonClick,
+			Logger:        logrus.NewEntry(logrus.New()),
+			Metadata:      newMetadata(),
+			Configuration: map[string]any{"channel": "C123", "message": "Deploy to production?"},
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"botToken": "token-123"},
+			},
+			Parameters:     map[string]any{"value": ApprovalValueDeny},
+			ExecutionState: execState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelDenied, execState.Channel)
+		assert.Equal(t, "slack.approval.denied", execState.Type)
+	})
+
+	t.Run("unknown value -> error", func(t *testing.T) {
+		err := component.HandleAction(core.ActionContext{
+			Name:           ActionButtonClick,
+			Metadata:       newMetadata(),
+			Parameters:     map[string]any{"value": "maybe"},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "unknown approval value")
+	})
+
+	t.Run("already finished -> ignored", func(t *testing.T) {
+		execState := &contexts.ExecutionStateContext{Finished: true}
+		err := component.HandleAction(core.ActionContext{
+			Name:           ActionButtonClick,
+			Metadata:       newMetadata(),
+			Parameters:     map[string]any{"value": ApprovalValueApprove},
+			ExecutionState: execState,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, execState.Payloads)
+	})
+
+	t.Run("timeout -> emits on timeout channel", func(t *testing.T) {
+		withDefaultTransport(t, func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"ok": true}`), nil
+		})
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleAction(core.ActionContext{
+			Name:          ActionTimeout,
+			Logger:        logrus.NewEntry(logrus.New()),
+			Metadata:      newMetadata(),
+			Configuration: map[string]any{"channel": "C123", "message": "Deploy to production?"},
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"botToken": "token-123"},
+			},
+			ExecutionState: execState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ChannelTimeout, execState.Channel)
+		assert.Equal(t, "slack.approval.timeout", execState.Type)
+	})
+}
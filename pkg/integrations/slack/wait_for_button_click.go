@@ -282,7 +282,7 @@ func (c *WaitForButtonClick) Execute(ctx core.ExecutionContext) error {
 	}
 
 	// Build blocks with text and buttons
-	blocks := []interface{}{
+	blocks := []any{
 		map[string]any{
 			"type": "section",
 			"text": map[string]string{
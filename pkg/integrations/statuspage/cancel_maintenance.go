@@ -0,0 +1,272 @@
+package statuspage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	CancelModeDelete   = "delete"
+	CancelModeComplete = "complete"
+)
+
+type CancelMaintenance struct{}
+
+// CancelMaintenanceSpec is the strongly typed configuration for the Cancel Maintenance component.
+type CancelMaintenanceSpec struct {
+	Page                 string `json:"page"`
+	Incident             string `json:"incident"`
+	IncidentExpression   string `json:"incidentExpression"`
+	Mode                 string `json:"mode"`
+	Body                 string `json:"body"`
+	DeliverNotifications *bool  `json:"deliverNotifications,omitempty"`
+}
+
+func (c *CancelMaintenance) Name() string {
+	return "statuspage.cancelMaintenance"
+}
+
+func (c *CancelMaintenance) Label() string {
+	return "Cancel Maintenance"
+}
+
+func (c *CancelMaintenance) Description() string {
+	return "Delete or complete a scheduled maintenance on your Statuspage."
+}
+
+func (c *CancelMaintenance) Documentation() string {
+	return `The Cancel Maintenance component cancels a scheduled maintenance on your Atlassian Statuspage.
+
+## Use Cases
+
+- **Rescheduling**: Remove a maintenance window when the planned change is postponed
+- **Early completion**: Mark a maintenance as completed as soon as the work is done
+- **Change management**: Cancel maintenance windows when a change request is rejected
+
+## Configuration
+
+- **Page** (required): The Statuspage containing the maintenance
+- **Maintenance** (required): Scheduled maintenance ID. Supports expressions for workflow chaining (e.g. {{ $['Create Incident'].data.id }}).
+- **Mode**: **Delete** removes the maintenance from the page. **Complete** transitions it to completed, keeping it in the page history
+- **Message** (optional, complete only): Final update message shown on the maintenance
+- **Deliver notifications** (optional, complete only): Whether to send notifications for this update (default: true)
+
+The component fails if the incident is not a scheduled maintenance.
+
+## Output
+
+Returns the deleted or updated Statuspage Incident object from the API. The payload has structure { type, timestamp, data } where data is the incident.`
+}
+
+func (c *CancelMaintenance) Icon() string {
+	return "activity"
+}
+
+func (c *CancelMaintenance) Color() string {
+	return "gray"
+}
+
+func (c *CancelMaintenance) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CancelMaintenance) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "page",
+			Label:       "Page",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The Statuspage containing the maintenance",
+			Placeholder: "Select a page",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypePage,
+				},
+			},
+		},
+		{
+			Name:        "incident",
+			Label:       "Maintenance",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Select a scheduled maintenance or choose 'Use expression' when page is an expression",
+			Placeholder: "Select a maintenance",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeIncident,
+					Parameters: []configuration.ParameterRef{
+						{Name: "page_id", ValueFrom: &configuration.ParameterValueFrom{Field: "page"}},
+					},
+				},
+			},
+		},
+		{
+			Name:        "incidentExpression",
+			Label:       "Maintenance expression",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Expression for maintenance ID when using expression for page (e.g. {{ $['Create Incident'].data.id }})",
+			Placeholder: "e.g. {{ $['Create Incident'].data.id }}",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "incident", Values: []string{IncidentUseExpressionID}},
+			},
+		},
+		{
+			Name:     "mode",
+			Label:    "Mode",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  CancelModeDelete,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Delete", Value: CancelModeDelete},
+						{Label: "Complete", Value: CancelModeComplete},
+					},
+				},
+			},
+		},
+		{
+			Name:        "body",
+			Label:       "Message",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Final update message shown on the maintenance",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "mode", Values: []string{CancelModeComplete}},
+			},
+		},
+		{
+			Name:        "deliverNotifications",
+			Label:       "Deliver notifications",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Send notifications for this update (default: true)",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "mode", Values: []string{CancelModeComplete}},
+			},
+		},
+	}
+}
+
+func (c *CancelMaintenance) Setup(ctx core.SetupContext) error {
+	spec := CancelMaintenanceSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %w", err)
+	}
+
+	if spec.Page == "" {
+		return errors.New("page is required")
+	}
+
+	if spec.Incident == "" {
+		return errors.New("maintenance is required")
+	}
+	if spec.Incident == IncidentUseExpressionID {
+		if spec.IncidentExpression == "" {
+			return errors.New("maintenance expression is required when using expression for maintenance")
+		}
+	}
+
+	if spec.Mode != "" && spec.Mode != CancelModeDelete && spec.Mode != CancelModeComplete {
+		return fmt.Errorf("mode must be %s or %s, got %q", CancelModeDelete, CancelModeComplete, spec.Mode)
+	}
+
+	metadata, err := resolveMetadataSetup(ctx, spec.Page, nil)
+	if err != nil {
+		return err
+	}
+	if spec.Incident != IncidentUseExpressionID && !strings.Contains(spec.Incident, "{{") {
+		incidentName, err := resolveIncidentName(ctx, spec.Page, spec.Incident)
+		if err != nil {
+			return fmt.Errorf("maintenance not found or inaccessible: %w", err)
+		}
+		metadata.IncidentName = incidentName
+	}
+	return ctx.Metadata.Set(metadata)
+}
+
+func (c *CancelMaintenance) Execute(ctx core.ExecutionContext) error {
+	spec := CancelMaintenanceSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %w", err)
+	}
+
+	incidentID := spec.Incident
+	if incidentID == IncidentUseExpressionID {
+		incidentID = spec.IncidentExpression
+	}
+	if incidentID == "" {
+		return fmt.Errorf("maintenance ID is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	existing, err := client.GetIncident(spec.Page, incidentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch maintenance: %w", err)
+	}
+
+	existingStatus, _ := existing["status"].(string)
+	if !isScheduledStatus(existingStatus) {
+		return fmt.Errorf("incident %s is not a scheduled maintenance (status %q)", incidentID, existingStatus)
+	}
+
+	var incident map[string]any
+	if spec.Mode == CancelModeComplete {
+		incident, err = client.UpdateIncident(spec.Page, incidentID, UpdateIncidentRequest{
+			Status:               "completed",
+			Body:                 spec.Body,
+			DeliverNotifications: spec.DeliverNotifications,
+		})
+	} else {
+		incident, err = client.DeleteIncident(spec.Page, incidentID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to cancel maintenance: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"statuspage.incident",
+		[]any{incident},
+	)
+}
+
+func (c *CancelMaintenance) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CancelMaintenance) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CancelMaintenance) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *CancelMaintenance) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CancelMaintenance) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CancelMaintenance) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,108 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CancelMaintenance__Setup(t *testing.T) {
+	component := &CancelMaintenance{}
+
+	t.Run("missing maintenance returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd"},
+		})
+		require.ErrorContains(t, err, "maintenance is required")
+	})
+
+	t.Run("invalid mode returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd", "incident": "sched123", "mode": "archive"},
+		})
+		require.ErrorContains(t, err, "mode must be delete or complete")
+	})
+}
+
+func Test__CancelMaintenance__Execute(t *testing.T) {
+	component := &CancelMaintenance{}
+
+	t.Run("delete mode deletes the maintenance", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleScheduledIncidentJSON))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleScheduledIncidentJSON))},
+			},
+		}
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"page": "kctbh9vrtdwd", "incident": "sched123", "mode": CancelModeDelete},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[1].Method)
+		assert.Equal(t, "statuspage.incident", executionState.Type)
+	})
+
+	t.Run("complete mode marks the maintenance as completed", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleScheduledIncidentJSON))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleScheduledIncidentJSON))},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"page":     "kctbh9vrtdwd",
+				"incident": "sched123",
+				"mode":     CancelModeComplete,
+				"body":     "Maintenance finished early.",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPatch, httpContext.Requests[1].Method)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		var payload map[string]map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "completed", payload["incident"]["status"])
+		assert.Equal(t, "Maintenance finished early.", payload["incident"]["body"])
+	})
+
+	t.Run("realtime incident returns error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleRealtimeIncidentJSON))},
+			},
+		}
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"page": "kctbh9vrtdwd", "incident": "p31zjtct2jer"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: executionState,
+		})
+
+		require.ErrorContains(t, err, "is not a scheduled maintenance")
+		assert.Len(t, httpContext.Requests, 1)
+		assert.Empty(t, executionState.Payloads)
+	})
+}
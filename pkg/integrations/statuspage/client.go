@@ -9,17 +9,33 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
 )
 
 const defaultBaseURL = "https://api.statuspage.io/v1"
 
+const (
+	// The API allows 1 request per second per API key, with short bursts,
+	// and answers with 420 or 429 above that.
+	maxRateLimitRetries = 3
+	rateLimitBackoff    = time.Second
+	maxRateLimitBackoff = 10 * time.Second
+
+	// List endpoints return up to listPageSize items per page.
+	// At most maxListPages pages are fetched, to bound the memory used by a listing.
+	listPageSize = 100
+	maxListPages = 20
+)
+
 type Client struct {
 	apiKey  string
 	baseURL string
 	http    core.HTTPContext
+	sleep   func(time.Duration)
 }
 
 // validateBaseURL returns an error if the URL is invalid.
@@ -57,13 +73,46 @@ func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, err
 		apiKey:  string(apiKey),
 		baseURL: baseURL,
 		http:    http,
+		sleep:   time.Sleep,
 	}, nil
 }
 
-func (c *Client) do(method, path string, body io.Reader, contentType string) ([]byte, error) {
-	req, err := http.NewRequest(method, c.baseURL+path, body)
+// do sends the request, waiting and sending it again while the API rate limits it.
+// Rate limited requests are not processed by the API, so retrying them is safe for any method.
+func (c *Client) do(method, path string, body []byte, contentType string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		statusCode, header, resBody, err := c.send(method, path, body, contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		if statusCode == 420 || statusCode == http.StatusTooManyRequests {
+			if attempt < maxRateLimitRetries {
+				c.sleep(rateLimitDelay(header, attempt))
+				continue
+			}
+			return nil, fmt.Errorf("rate limited (HTTP %d): %s", statusCode, string(resBody))
+		}
+		if statusCode == 404 {
+			return nil, fmt.Errorf("resource not found (404): %s", string(resBody))
+		}
+		if statusCode < 200 || statusCode >= 300 {
+			return nil, fmt.Errorf("request failed with %d: %s", statusCode, string(resBody))
+		}
+
+		return resBody, nil
+	}
+}
+
+func (c *Client) send(method, path string, body []byte, contentType string) (int, http.Header, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
 	if err != nil {
-		return nil, fmt.Errorf("building request: %w", err)
+		return 0, nil, nil, fmt.Errorf("building request: %w", err)
 	}
 	req.Header.Set("Authorization", "OAuth "+c.apiKey)
 	if contentType != "" {
@@ -72,26 +121,57 @@ func (c *Client) do(method, path string, body io.Reader, contentType string) ([]
 
 	res, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		return 0, nil, nil, fmt.Errorf("executing request: %w", err)
 	}
 	defer res.Body.Close()
 
 	resBody, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return 0, nil, nil, fmt.Errorf("reading response: %w", err)
 	}
 
-	if res.StatusCode == 420 || res.StatusCode == 429 {
-		return nil, fmt.Errorf("rate limited (HTTP %d): %s", res.StatusCode, string(resBody))
-	}
-	if res.StatusCode == 404 {
-		return nil, fmt.Errorf("resource not found (404): %s", string(resBody))
+	return res.StatusCode, res.Header, resBody, nil
+}
+
+// rateLimitDelay uses the Retry-After header if the API sends it,
+// and doubles the delay on every attempt otherwise.
+func rateLimitDelay(header http.Header, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		return min(time.Duration(seconds)*time.Second, maxRateLimitBackoff)
 	}
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, fmt.Errorf("request failed with %d: %s", res.StatusCode, string(resBody))
+
+	return min(rateLimitBackoff<<attempt, maxRateLimitBackoff)
+}
+
+// listAll fetches the pages of a list endpoint until one has fewer than listPageSize items,
+// or until maxListPages pages were fetched.
+func listAll[T any](c *Client, path string, params url.Values, pageSizeParam string) ([]T, error) {
+	all := []T{}
+	for page := 1; page <= maxListPages; page++ {
+		query := url.Values{}
+		for key, values := range params {
+			query[key] = values
+		}
+		query.Set(pageSizeParam, strconv.Itoa(listPageSize))
+		query.Set("page", strconv.Itoa(page))
+
+		body, err := c.do(http.MethodGet, path+"?"+query.Encode(), nil, "")
+		if err != nil {
+			return nil, err
+		}
+
+		var items []T
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+
+		all = append(all, items...)
+		if len(items) < listPageSize {
+			break
+		}
 	}
 
-	return resBody, nil
+	return all, nil
 }
 
 type Page struct {
@@ -119,15 +199,7 @@ type Component struct {
 
 func (c *Client) ListComponents(pageID string) ([]Component, error) {
 	path := fmt.Sprintf("/pages/%s/components", url.PathEscape(pageID))
-	body, err := c.do(http.MethodGet, path, nil, "")
-	if err != nil {
-		return nil, err
-	}
-	var components []Component
-	if err := json.Unmarshal(body, &components); err != nil {
-		return nil, fmt.Errorf("parsing components: %w", err)
-	}
-	return components, nil
+	return listAll[Component](c, path, url.Values{}, "per_page")
 }
 
 // CreateIncidentRequest holds the payload for creating an incident.
@@ -199,7 +271,7 @@ func (c *Client) CreateIncident(pageID string, req CreateIncidentRequest) (map[s
 	}
 
 	path := fmt.Sprintf("/pages/%s/incidents", url.PathEscape(pageID))
-	resBody, err := c.do(http.MethodPost, path, bytes.NewReader(raw), "application/json")
+	resBody, err := c.do(http.MethodPost, path, raw, "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -212,28 +284,14 @@ type Incident struct {
 	Name string `json:"name"`
 }
 
-// ListIncidents returns incidents for a page. Use empty q and limit 0 for defaults.
-func (c *Client) ListIncidents(pageID string, q string, limit int) ([]Incident, error) {
+// ListIncidents returns the incidents of a page, most recent first. Use an empty q to list all of them.
+func (c *Client) ListIncidents(pageID string, q string) ([]Incident, error) {
 	path := fmt.Sprintf("/pages/%s/incidents", url.PathEscape(pageID))
-	if q != "" || limit > 0 {
-		params := url.Values{}
-		if q != "" {
-			params.Set("q", q)
-		}
-		if limit > 0 {
-			params.Set("limit", fmt.Sprintf("%d", limit))
-		}
-		path += "?" + params.Encode()
+	params := url.Values{}
+	if q != "" {
+		params.Set("q", q)
 	}
-	body, err := c.do(http.MethodGet, path, nil, "")
-	if err != nil {
-		return nil, err
-	}
-	var incidents []Incident
-	if err := json.Unmarshal(body, &incidents); err != nil {
-		return nil, fmt.Errorf("parsing incidents: %w", err)
-	}
-	return incidents, nil
+	return listAll[Incident](c, path, params, "limit")
 }
 
 // UpdateIncidentRequest holds the payload for PATCH /pages/{page_id}/incidents/{incident_id}.
@@ -279,7 +337,7 @@ func (c *Client) UpdateIncident(pageID, incidentID string, req UpdateIncidentReq
 	}
 
 	path := fmt.Sprintf("/pages/%s/incidents/%s", url.PathEscape(pageID), url.PathEscape(incidentID))
-	resBody, err := c.do(http.MethodPatch, path, bytes.NewReader(raw), "application/json")
+	resBody, err := c.do(http.MethodPatch, path, raw, "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -309,3 +367,27 @@ func (c *Client) GetIncident(pageID, incidentID string) (map[string]any, error)
 	}
 	return extractIncident(resBody)
 }
+
+// ListUpcomingMaintenances returns the scheduled maintenances of a page that have not started yet.
+func (c *Client) ListUpcomingMaintenances(pageID string) ([]map[string]any, error) {
+	path := fmt.Sprintf("/pages/%s/incidents/upcoming", url.PathEscape(pageID))
+	body, err := c.do(http.MethodGet, path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var maintenances []map[string]any
+	if err := json.Unmarshal(body, &maintenances); err != nil {
+		return nil, fmt.Errorf("parsing scheduled maintenances: %w", err)
+	}
+	return maintenances, nil
+}
+
+// DeleteIncident deletes an incident or scheduled maintenance and returns the deleted object.
+func (c *Client) DeleteIncident(pageID, incidentID string) (map[string]any, error) {
+	path := fmt.Sprintf("/pages/%s/incidents/%s", url.PathEscape(pageID), url.PathEscape(incidentID))
+	resBody, err := c.do(http.MethodDelete, path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return extractIncident(resBody)
+}
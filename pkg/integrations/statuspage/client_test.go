@@ -0,0 +1,142 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func newTestClient(t *testing.T, httpContext *contexts.HTTPContext, sleeps *[]time.Duration) *Client {
+	client, err := NewClient(httpContext, &contexts.IntegrationContext{
+		Configuration: map[string]any{"apiKey": "test-key"},
+	})
+	require.NoError(t, err)
+
+	client.sleep = func(d time.Duration) { *sleeps = append(*sleeps, d) }
+	return client
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func componentsPage(from, count int) string {
+	components := make([]Component, 0, count)
+	for i := from; i < from+count; i++ {
+		components = append(components, Component{ID: fmt.Sprintf("comp%d", i), Name: fmt.Sprintf("Component %d", i)})
+	}
+
+	data, _ := json.Marshal(components)
+	return string(data)
+}
+
+func Test__Client__RateLimit(t *testing.T) {
+	t.Run("retries rate limited requests with backoff", func(t *testing.T) {
+		retryAfter := jsonResponse(429, `{"error":"rate limited"}`)
+		retryAfter.Header.Set("Retry-After", "3")
+
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(420, `{"error":"enhance your calm"}`),
+				retryAfter,
+				jsonResponse(http.StatusOK, `{"id":"inc1","name":"Outage"}`),
+			},
+		}
+
+		sleeps := []time.Duration{}
+		client := newTestClient(t, httpContext, &sleeps)
+
+		incident, err := client.UpdateIncident("page1", "inc1", UpdateIncidentRequest{Status: "resolved"})
+		require.NoError(t, err)
+		assert.Equal(t, "inc1", incident["id"])
+		assert.Equal(t, []time.Duration{time.Second, 3 * time.Second}, sleeps)
+
+		require.Len(t, httpContext.Requests, 3)
+		for _, req := range httpContext.Requests {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"incident":{"status":"resolved"}}`, string(body))
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		for range maxRateLimitRetries + 1 {
+			httpContext.Responses = append(httpContext.Responses, jsonResponse(429, `{"error":"rate limited"}`))
+		}
+
+		sleeps := []time.Duration{}
+		client := newTestClient(t, httpContext, &sleeps)
+
+		_, err := client.GetIncident("page1", "inc1")
+		require.ErrorContains(t, err, "rate limited (HTTP 429)")
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, sleeps)
+		assert.Len(t, httpContext.Requests, maxRateLimitRetries+1)
+	})
+}
+
+func Test__Client__ListComponents(t *testing.T) {
+	t.Run("fetches pages until a page is not full", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, componentsPage(0, listPageSize)),
+				jsonResponse(http.StatusOK, componentsPage(listPageSize, 5)),
+			},
+		}
+
+		client := newTestClient(t, httpContext, &[]time.Duration{})
+		components, err := client.ListComponents("page1")
+		require.NoError(t, err)
+		require.Len(t, components, listPageSize+5)
+		assert.Equal(t, "comp104", components[len(components)-1].ID)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "1", httpContext.Requests[0].URL.Query().Get("page"))
+		assert.Equal(t, "100", httpContext.Requests[0].URL.Query().Get("per_page"))
+		assert.Equal(t, "2", httpContext.Requests[1].URL.Query().Get("page"))
+	})
+
+	t.Run("stops after max pages", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		for page := range maxListPages + 1 {
+			httpContext.Responses = append(httpContext.Responses, jsonResponse(http.StatusOK, componentsPage(page*listPageSize, listPageSize)))
+		}
+
+		client := newTestClient(t, httpContext, &[]time.Duration{})
+		components, err := client.ListComponents("page1")
+		require.NoError(t, err)
+		assert.Len(t, components, maxListPages*listPageSize)
+		assert.Len(t, httpContext.Requests, maxListPages)
+	})
+}
+
+func Test__Client__ListIncidents(t *testing.T) {
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `[{"id":"inc1","name":"Outage"}]`),
+		},
+	}
+
+	client := newTestClient(t, httpContext, &[]time.Duration{})
+	incidents, err := client.ListIncidents("page1", "outage")
+	require.NoError(t, err)
+	require.Len(t, incidents, 1)
+
+	require.Len(t, httpContext.Requests, 1)
+	query := httpContext.Requests[0].URL.Query()
+	assert.Equal(t, "outage", query.Get("q"))
+	assert.Equal(t, "100", query.Get("limit"))
+	assert.Equal(t, "1", query.Get("page"))
+}
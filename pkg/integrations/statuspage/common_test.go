@@ -143,4 +143,25 @@ func Test_toUTCISO8601(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "2026-02-15T02:00:00Z", out)
 	})
+	t.Run("timezone outside the former hardcoded list", func(t *testing.T) {
+		// "2026-02-15T02:00" in Australia/Adelaide (ACDT, UTC+10:30) = 15:30 UTC the previous day
+		out, err := toUTCISO8601("2026-02-15T02:00", "Australia/Adelaide")
+		require.NoError(t, err)
+		assert.Equal(t, "2026-02-14T15:30:00Z", out)
+	})
+
+	t.Run("wrong case suggests the IANA name", func(t *testing.T) {
+		_, err := toUTCISO8601("2026-02-15T02:00", "europe/berlin")
+		require.ErrorContains(t, err, `did you mean "Europe/Berlin"?`)
+	})
+
+	t.Run("UTC offset returns error", func(t *testing.T) {
+		_, err := toUTCISO8601("2026-02-15T02:00", "+02:00")
+		require.ErrorContains(t, err, "UTC offsets are not supported")
+	})
+
+	t.Run("unknown timezone returns error", func(t *testing.T) {
+		_, err := toUTCISO8601("2026-02-15T02:00", "Mars/Olympus_Mons")
+		require.ErrorContains(t, err, `invalid timezone "Mars/Olympus_Mons": use an IANA time zone name`)
+	})
 }
@@ -85,7 +85,7 @@ func (c *CreateIncident) Documentation() string {
 - **Impact override** (realtime): none, minor, major, or critical
 - **Components** (optional): List of components and their status. Each item has Component ID (supports expressions) and Status (operational, degraded_performance, partial_outage, major_outage, under_maintenance)
 - **Scheduled For / Until** (scheduled): Start and end time for scheduled maintenance (ISO 8601, e.g. 2026-02-15T02:00)
-- **Scheduled timezone** (scheduled): Timezone for the scheduled times (default UTC). Output is converted to UTC for the API.
+- **Scheduled timezone** (scheduled): IANA timezone for the scheduled times, e.g. Europe/Berlin (default UTC). Supports expressions. Output is converted to UTC for the API.
 - **Scheduled options** (scheduled): Remind prior, auto in-progress, auto completed
 - **Deliver notifications** (optional): Whether to send notifications for the initial update (default: true)
 
@@ -277,22 +277,13 @@ func (c *CreateIncident) Configuration() []configuration.Field {
 		{
 			Name:        "scheduledTimezone",
 			Label:       "Timezone",
-			Type:        configuration.FieldTypeSelect,
+			Type:        configuration.FieldTypeIntegrationResource,
 			Required:    false,
 			Default:     "UTC",
-			Description: "Timezone for scheduled times. Values are converted to UTC for the API.",
+			Description: "IANA timezone for scheduled times (supports expressions). Values are converted to UTC for the API.",
 			TypeOptions: &configuration.TypeOptions{
-				Select: &configuration.SelectTypeOptions{
-					Options: []configuration.FieldOption{
-						{Label: "UTC", Value: "UTC"},
-						{Label: "America/New_York", Value: "America/New_York"},
-						{Label: "America/Los_Angeles", Value: "America/Los_Angeles"},
-						{Label: "America/Chicago", Value: "America/Chicago"},
-						{Label: "Europe/London", Value: "Europe/London"},
-						{Label: "Europe/Paris", Value: "Europe/Paris"},
-						{Label: "Asia/Tokyo", Value: "Asia/Tokyo"},
-						{Label: "Asia/Singapore", Value: "Asia/Singapore"},
-					},
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeTimezone,
 				},
 			},
 			VisibilityConditions: []configuration.VisibilityCondition{
@@ -535,9 +526,9 @@ func toUTCISO8601(dt, timezone string) (string, error) {
 		}
 		return "", fmt.Errorf("could not parse UTC datetime %q", dt)
 	}
-	loc, err := time.LoadLocation(timezone)
+	loc, err := loadTimezone(timezone)
 	if err != nil {
-		return "", fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		return "", err
 	}
 	formats := []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
 	var t time.Time
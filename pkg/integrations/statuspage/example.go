@@ -25,6 +25,30 @@ var exampleOutputUpdateIncidentBytes []byte
 var exampleOutputUpdateIncidentOnce sync.Once
 var exampleOutputUpdateIncident map[string]any
 
+//go:embed example_output_resolve_incident.json
+var exampleOutputResolveIncidentBytes []byte
+
+var exampleOutputResolveIncidentOnce sync.Once
+var exampleOutputResolveIncident map[string]any
+
+//go:embed example_output_list_maintenances.json
+var exampleOutputListMaintenancesBytes []byte
+
+var exampleOutputListMaintenancesOnce sync.Once
+var exampleOutputListMaintenances map[string]any
+
+//go:embed example_output_cancel_maintenance.json
+var exampleOutputCancelMaintenanceBytes []byte
+
+var exampleOutputCancelMaintenanceOnce sync.Once
+var exampleOutputCancelMaintenance map[string]any
+
+//go:embed example_data_on_scheduled_maintenance_starting.json
+var exampleDataOnScheduledMaintenanceStartingBytes []byte
+
+var exampleDataOnScheduledMaintenanceStartingOnce sync.Once
+var exampleDataOnScheduledMaintenanceStarting map[string]any
+
 func (c *GetIncident) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetIncidentOnce, exampleOutputGetIncidentBytes, &exampleOutputGetIncident)
 }
@@ -36,3 +60,19 @@ func (c *CreateIncident) ExampleOutput() map[string]any {
 func (c *UpdateIncident) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputUpdateIncidentOnce, exampleOutputUpdateIncidentBytes, &exampleOutputUpdateIncident)
 }
+
+func (c *ResolveIncident) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputResolveIncidentOnce, exampleOutputResolveIncidentBytes, &exampleOutputResolveIncident)
+}
+
+func (c *ListMaintenances) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputListMaintenancesOnce, exampleOutputListMaintenancesBytes, &exampleOutputListMaintenances)
+}
+
+func (c *CancelMaintenance) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCancelMaintenanceOnce, exampleOutputCancelMaintenanceBytes, &exampleOutputCancelMaintenance)
+}
+
+func (t *OnScheduledMaintenanceStarting) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnScheduledMaintenanceStartingOnce, exampleDataOnScheduledMaintenanceStartingBytes, &exampleDataOnScheduledMaintenanceStarting)
+}
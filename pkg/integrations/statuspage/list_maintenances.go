@@ -0,0 +1,204 @@
+package statuspage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const MaxMaintenanceWindowHours = 24 * 90
+
+type ListMaintenances struct{}
+
+// ListMaintenancesSpec is the strongly typed configuration for the List Maintenances component.
+type ListMaintenancesSpec struct {
+	Page        string `json:"page"`
+	WithinHours int    `json:"withinHours"`
+}
+
+// maintenanceStart parses the scheduled_for timestamp of a scheduled maintenance.
+func maintenanceStart(maintenance map[string]any) (time.Time, bool) {
+	scheduledFor, _ := maintenance["scheduled_for"].(string)
+	if scheduledFor == "" {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(time.RFC3339, scheduledFor)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// filterMaintenancesStartingBefore keeps the maintenances scheduled to start before the given time.
+func filterMaintenancesStartingBefore(maintenances []map[string]any, before time.Time) []map[string]any {
+	filtered := make([]map[string]any, 0, len(maintenances))
+	for _, maintenance := range maintenances {
+		start, ok := maintenanceStart(maintenance)
+		if !ok || start.After(before) {
+			continue
+		}
+		filtered = append(filtered, maintenance)
+	}
+	return filtered
+}
+
+func (c *ListMaintenances) Name() string {
+	return "statuspage.listMaintenances"
+}
+
+func (c *ListMaintenances) Label() string {
+	return "List Maintenances"
+}
+
+func (c *ListMaintenances) Description() string {
+	return "List upcoming scheduled maintenances on your Statuspage."
+}
+
+func (c *ListMaintenances) Documentation() string {
+	return `The List Maintenances component lists the upcoming scheduled maintenances on your Atlassian Statuspage.
+
+## Use Cases
+
+- **Change freezes**: Block deployments when a maintenance window is about to start
+- **Calendars**: Sync upcoming maintenance windows to other calendars or chat channels
+- **Reporting**: Summarize planned maintenance for the week
+
+## Configuration
+
+- **Page** (required): The Statuspage to list maintenances from
+- **Within (hours)** (optional): Only include maintenances scheduled to start in the next N hours. 0 includes all upcoming maintenances
+
+## Output
+
+The payload has structure { type, timestamp, data } where data contains:
+- data.maintenances — array of Statuspage Incident objects for the scheduled maintenances, including scheduled_for and scheduled_until
+- data.count — number of maintenances returned`
+}
+
+func (c *ListMaintenances) Icon() string {
+	return "activity"
+}
+
+func (c *ListMaintenances) Color() string {
+	return "gray"
+}
+
+func (c *ListMaintenances) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ListMaintenances) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "page",
+			Label:       "Page",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The Statuspage to list maintenances from",
+			Placeholder: "Select a page",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypePage,
+				},
+			},
+		},
+		{
+			Name:        "withinHours",
+			Label:       "Within (hours)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     "0",
+			Togglable:   true,
+			Description: "Only include maintenances starting in the next N hours (0 includes all)",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+					Max: func() *int { max := MaxMaintenanceWindowHours; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func (c *ListMaintenances) Setup(ctx core.SetupContext) error {
+	spec := ListMaintenancesSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %w", err)
+	}
+
+	if spec.Page == "" {
+		return errors.New("page is required")
+	}
+
+	if spec.WithinHours < 0 || spec.WithinHours > MaxMaintenanceWindowHours {
+		return fmt.Errorf("withinHours must be between 0 and %d", MaxMaintenanceWindowHours)
+	}
+
+	metadata, err := resolveMetadataSetup(ctx, spec.Page, nil)
+	if err != nil {
+		return err
+	}
+	return ctx.Metadata.Set(metadata)
+}
+
+func (c *ListMaintenances) Execute(ctx core.ExecutionContext) error {
+	spec := ListMaintenancesSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	maintenances, err := client.ListUpcomingMaintenances(spec.Page)
+	if err != nil {
+		return fmt.Errorf("failed to list scheduled maintenances: %w", err)
+	}
+
+	if spec.WithinHours > 0 {
+		maintenances = filterMaintenancesStartingBefore(maintenances, time.Now().Add(time.Duration(spec.WithinHours)*time.Hour))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"statuspage.maintenances",
+		[]any{map[string]any{
+			"maintenances": maintenances,
+			"count":        len(maintenances),
+		}},
+	)
+}
+
+func (c *ListMaintenances) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ListMaintenances) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ListMaintenances) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *ListMaintenances) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ListMaintenances) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ListMaintenances) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
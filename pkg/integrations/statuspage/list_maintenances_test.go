@@ -0,0 +1,94 @@
+package statuspage
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func upcomingMaintenancesJSON(starts ...time.Time) string {
+	items := make([]string, 0, len(starts))
+	for i, start := range starts {
+		items = append(items, `{
+			"id": "sched`+string(rune('a'+i))+`",
+			"name": "Maintenance",
+			"status": "scheduled",
+			"scheduled_for": "`+start.UTC().Format(time.RFC3339)+`",
+			"scheduled_until": "`+start.Add(time.Hour).UTC().Format(time.RFC3339)+`"
+		}`)
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+func Test__ListMaintenances__Setup(t *testing.T) {
+	component := &ListMaintenances{}
+
+	t.Run("missing page returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{}})
+		require.ErrorContains(t, err, "page is required")
+	})
+
+	t.Run("withinHours out of range returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd", "withinHours": -1},
+		})
+		require.ErrorContains(t, err, "withinHours must be between 0")
+	})
+}
+
+func Test__ListMaintenances__Execute(t *testing.T) {
+	component := &ListMaintenances{}
+	now := time.Now()
+
+	t.Run("lists all upcoming maintenances", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(upcomingMaintenancesJSON(now.Add(time.Hour), now.Add(72*time.Hour))))},
+			},
+		}
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"page": "kctbh9vrtdwd"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/v1/pages/kctbh9vrtdwd/incidents/upcoming", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "statuspage.maintenances", executionState.Type)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 2, data["count"])
+	})
+
+	t.Run("withinHours filters maintenances starting later", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(upcomingMaintenancesJSON(now.Add(time.Hour), now.Add(72*time.Hour))))},
+			},
+		}
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"page": "kctbh9vrtdwd", "withinHours": 24},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 1, data["count"])
+		maintenances := data["maintenances"].([]map[string]any)
+		assert.Equal(t, "scheda", maintenances[0]["id"])
+	})
+}
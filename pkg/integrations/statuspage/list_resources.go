@@ -16,6 +16,7 @@ const (
 	ResourceTypeIncidentStatusRealtime  = "incident_status_realtime"
 	ResourceTypeIncidentStatusScheduled = "incident_status_scheduled"
 	ResourceTypeComponentStatus         = "component_status"
+	ResourceTypeTimezone                = "timezone"
 )
 
 func (s *Statuspage) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
@@ -36,6 +37,8 @@ func (s *Statuspage) ListResources(resourceType string, ctx core.ListResourcesCo
 		return listIncidentStatusScheduledResources()
 	case ResourceTypeComponentStatus:
 		return listComponentStatusResources()
+	case ResourceTypeTimezone:
+		return listTimezoneResources()
 	default:
 		return []core.IntegrationResource{}, nil
 	}
@@ -156,7 +159,7 @@ func listIncidents(ctx core.ListResourcesContext) ([]core.IntegrationResource, e
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
-	incidents, err := client.ListIncidents(pageID, "", 100)
+	incidents, err := client.ListIncidents(pageID, "")
 	if err != nil {
 		// When page_id is invalid (e.g. from expression or typo), API returns 404.
 		// Return "Use expression" option so user can select it and type expression in incidentExpression field.
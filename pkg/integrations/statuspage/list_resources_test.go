@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -309,3 +310,19 @@ func Test__ListResources__ComponentStatus(t *testing.T) {
 	assert.Equal(t, "Under maintenance", resources[4].Name)
 	assert.Equal(t, "under_maintenance", resources[4].ID)
 }
+
+func Test__ListResources__Timezone(t *testing.T) {
+	s := &Statuspage{}
+	ctx := core.ListResourcesContext{Parameters: map[string]string{}}
+
+	resources, err := s.ListResources(ResourceTypeTimezone, ctx)
+	require.NoError(t, err)
+	require.Greater(t, len(resources), 300)
+	assert.Equal(t, ResourceTypeTimezone, resources[0].Type)
+	assert.Equal(t, "UTC", resources[0].ID)
+
+	for _, resource := range resources {
+		_, err := time.LoadLocation(resource.ID)
+		require.NoError(t, err, "timezone %s cannot be loaded", resource.ID)
+	}
+}
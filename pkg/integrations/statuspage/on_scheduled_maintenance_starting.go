@@ -0,0 +1,286 @@
+package statuspage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	OnScheduledMaintenanceStartingPayloadType  = "statuspage.maintenance.starting"
+	OnScheduledMaintenanceStartingPollAction   = "poll"
+	OnScheduledMaintenanceStartingPollInterval = 1 * time.Minute
+	MaxMaintenanceLeadTimeMinutes              = 7 * 24 * 60
+)
+
+type OnScheduledMaintenanceStarting struct{}
+
+type OnScheduledMaintenanceStartingConfiguration struct {
+	Page            string `json:"page" mapstructure:"page"`
+	LeadTimeMinutes int    `json:"leadTimeMinutes" mapstructure:"leadTimeMinutes"`
+}
+
+// OnScheduledMaintenanceStartingMetadata keeps track of the maintenances
+// already emitted, keyed by ID, with the start time they were emitted for.
+// A maintenance that gets rescheduled is emitted again.
+type OnScheduledMaintenanceStartingMetadata struct {
+	PageName string            `json:"pageName,omitempty" mapstructure:"pageName"`
+	Emitted  map[string]string `json:"emitted,omitempty" mapstructure:"emitted"`
+}
+
+func (t *OnScheduledMaintenanceStarting) Name() string {
+	return "statuspage.onScheduledMaintenanceStarting"
+}
+
+func (t *OnScheduledMaintenanceStarting) Label() string {
+	return "On Scheduled Maintenance Starting"
+}
+
+func (t *OnScheduledMaintenanceStarting) Description() string {
+	return "Trigger shortly before a scheduled maintenance starts on your Statuspage"
+}
+
+func (t *OnScheduledMaintenanceStarting) Documentation() string {
+	return `The On Scheduled Maintenance Starting trigger starts a workflow execution when a scheduled maintenance on your Atlassian Statuspage is about to start.
+
+## Use Cases
+
+- **Change freezes**: Pause deployment pipelines before a maintenance window opens
+- **Notifications**: Remind the on-call team in chat before the maintenance starts
+- **Automation**: Transition the maintenance to in progress or prepare systems for the window
+
+## How It Works
+
+The trigger checks the upcoming scheduled maintenances of the page every minute.
+A maintenance is emitted once when its start time is within the configured lead time.
+If a maintenance is rescheduled, it is emitted again for the new start time.
+
+## Configuration
+
+- **Page** (required): The Statuspage to watch
+- **Lead time (minutes)**: How long before the start time to trigger. 0 triggers when the maintenance starts
+
+## Event Data
+
+Each event contains the Statuspage Incident object for the scheduled maintenance, including id, name, status, scheduled_for, scheduled_until, shortlink and components.`
+}
+
+func (t *OnScheduledMaintenanceStarting) Icon() string {
+	return "activity"
+}
+
+func (t *OnScheduledMaintenanceStarting) Color() string {
+	return "gray"
+}
+
+func (t *OnScheduledMaintenanceStarting) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "page",
+			Label:       "Page",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The Statuspage to watch",
+			Placeholder: "Select a page",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypePage,
+				},
+			},
+		},
+		{
+			Name:        "leadTimeMinutes",
+			Label:       "Lead time (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     "15",
+			Description: "How long before the maintenance starts to trigger",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+					Max: func() *int { max := MaxMaintenanceLeadTimeMinutes; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func decodeOnScheduledMaintenanceStartingConfiguration(config any) (OnScheduledMaintenanceStartingConfiguration, error) {
+	spec := OnScheduledMaintenanceStartingConfiguration{}
+	err := mapstructure.Decode(config, &spec)
+	if err != nil {
+		return spec, fmt.Errorf("error decoding configuration: %w", err)
+	}
+
+	spec.Page = strings.TrimSpace(spec.Page)
+	if spec.Page == "" {
+		return spec, errors.New("page is required")
+	}
+
+	if spec.LeadTimeMinutes < 0 || spec.LeadTimeMinutes > MaxMaintenanceLeadTimeMinutes {
+		return spec, fmt.Errorf("leadTimeMinutes must be between 0 and %d", MaxMaintenanceLeadTimeMinutes)
+	}
+
+	return spec, nil
+}
+
+func (t *OnScheduledMaintenanceStarting) Setup(ctx core.TriggerContext) error {
+	config, err := decodeOnScheduledMaintenanceStartingConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := OnScheduledMaintenanceStartingMetadata{}
+	_ = mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	pages, err := client.ListPages()
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+
+	metadata.PageName = ""
+	for _, page := range pages {
+		if page.ID == config.Page {
+			metadata.PageName = page.Name
+			break
+		}
+	}
+	if metadata.PageName == "" {
+		return fmt.Errorf("page %q not found or not accessible", config.Page)
+	}
+
+	err = ctx.Metadata.Set(metadata)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(
+		OnScheduledMaintenanceStartingPollAction,
+		map[string]any{},
+		OnScheduledMaintenanceStartingPollInterval,
+	)
+}
+
+func (t *OnScheduledMaintenanceStarting) Actions() []core.Action {
+	return []core.Action{
+		{
+			Name:           OnScheduledMaintenanceStartingPollAction,
+			Description:    "Check for scheduled maintenances about to start",
+			UserAccessible: false,
+		},
+	}
+}
+
+func (t *OnScheduledMaintenanceStarting) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	switch ctx.Name {
+	case OnScheduledMaintenanceStartingPollAction:
+		return nil, t.poll(ctx)
+	default:
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (t *OnScheduledMaintenanceStarting) poll(ctx core.TriggerActionContext) error {
+	config, err := decodeOnScheduledMaintenanceStartingConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := OnScheduledMaintenanceStartingMetadata{}
+	err = mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode trigger metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	maintenances, err := client.ListUpcomingMaintenances(config.Page)
+	if err != nil {
+		ctx.Logger.Warnf("failed to list scheduled maintenances: %v", err)
+		return t.schedulePoll(ctx.Requests)
+	}
+
+	emitted, err := t.emitStartingMaintenances(ctx, config, metadata.Emitted, maintenances, time.Now())
+	if err != nil {
+		return err
+	}
+
+	metadata.Emitted = emitted
+	err = ctx.Metadata.Set(metadata)
+	if err != nil {
+		return err
+	}
+
+	return t.schedulePoll(ctx.Requests)
+}
+
+// emitStartingMaintenances emits an event for every maintenance starting within the lead time
+// that was not already emitted for its current start time. It returns the updated record of
+// emitted maintenances, pruned to the ones that are still upcoming.
+func (t *OnScheduledMaintenanceStarting) emitStartingMaintenances(
+	ctx core.TriggerActionContext,
+	config OnScheduledMaintenanceStartingConfiguration,
+	previous map[string]string,
+	maintenances []map[string]any,
+	now time.Time,
+) (map[string]string, error) {
+	emitted := map[string]string{}
+	threshold := now.Add(time.Duration(config.LeadTimeMinutes) * time.Minute)
+
+	for _, maintenance := range maintenances {
+		id, _ := maintenance["id"].(string)
+		scheduledFor, _ := maintenance["scheduled_for"].(string)
+		if id == "" {
+			continue
+		}
+
+		if previous[id] == scheduledFor {
+			emitted[id] = scheduledFor
+			continue
+		}
+
+		start, ok := maintenanceStart(maintenance)
+		if !ok || start.After(threshold) {
+			continue
+		}
+
+		err := ctx.Events.Emit(OnScheduledMaintenanceStartingPayloadType, maintenance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to emit event for maintenance %s: %w", id, err)
+		}
+
+		emitted[id] = scheduledFor
+	}
+
+	return emitted, nil
+}
+
+func (t *OnScheduledMaintenanceStarting) schedulePoll(requests core.RequestContext) error {
+	return requests.ScheduleActionCall(
+		OnScheduledMaintenanceStartingPollAction,
+		map[string]any{},
+		OnScheduledMaintenanceStartingPollInterval,
+	)
+}
+
+func (t *OnScheduledMaintenanceStarting) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnScheduledMaintenanceStarting) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
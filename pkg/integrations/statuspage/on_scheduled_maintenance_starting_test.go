@@ -0,0 +1,141 @@
+package statuspage
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnScheduledMaintenanceStarting__Setup(t *testing.T) {
+	trigger := &OnScheduledMaintenanceStarting{}
+
+	t.Run("missing page returns error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.ErrorContains(t, err, "page is required")
+	})
+
+	t.Run("page not found returns error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id":"other","name":"Other"}]`))},
+			},
+		}
+
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd"},
+			HTTP:          httpContext,
+			Integration:   &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			Metadata:      &contexts.MetadataContext{},
+			Requests:      &contexts.RequestContext{},
+		})
+		require.ErrorContains(t, err, "not found or not accessible")
+	})
+
+	t.Run("valid page stores metadata and schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id":"kctbh9vrtdwd","name":"My Page"}]`))},
+			},
+		}
+		metadataCtx := &contexts.MetadataContext{}
+		requestCtx := &contexts.RequestContext{}
+
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd", "leadTimeMinutes": 15},
+			HTTP:          httpContext,
+			Integration:   &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			Metadata:      metadataCtx,
+			Requests:      requestCtx,
+		})
+
+		require.NoError(t, err)
+		metadata, ok := metadataCtx.Metadata.(OnScheduledMaintenanceStartingMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "My Page", metadata.PageName)
+		assert.Equal(t, OnScheduledMaintenanceStartingPollAction, requestCtx.Action)
+		assert.Equal(t, OnScheduledMaintenanceStartingPollInterval, requestCtx.Duration)
+	})
+}
+
+func Test__OnScheduledMaintenanceStarting__Poll(t *testing.T) {
+	trigger := &OnScheduledMaintenanceStarting{}
+	now := time.Now()
+
+	poll := func(metadataCtx *contexts.MetadataContext, events *contexts.EventContext, body string) error {
+		_, err := trigger.HandleAction(core.TriggerActionContext{
+			Name:          OnScheduledMaintenanceStartingPollAction,
+			Configuration: map[string]any{"page": "kctbh9vrtdwd", "leadTimeMinutes": 15},
+			Logger:        logrus.NewEntry(logrus.New()),
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))},
+				},
+			},
+			Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			Metadata:    metadataCtx,
+			Events:      events,
+			Requests:    &contexts.RequestContext{},
+		})
+		return err
+	}
+
+	t.Run("emits maintenances within lead time once", func(t *testing.T) {
+		metadataCtx := &contexts.MetadataContext{Metadata: OnScheduledMaintenanceStartingMetadata{}}
+		events := &contexts.EventContext{}
+		body := upcomingMaintenancesJSON(now.Add(10*time.Minute), now.Add(2*time.Hour))
+
+		require.NoError(t, poll(metadataCtx, events, body))
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnScheduledMaintenanceStartingPayloadType, events.Payloads[0].Type)
+		assert.Equal(t, "scheda", events.Payloads[0].Data.(map[string]any)["id"])
+
+		require.NoError(t, poll(metadataCtx, events, body))
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("rescheduled maintenance is emitted again", func(t *testing.T) {
+		metadataCtx := &contexts.MetadataContext{
+			Metadata: OnScheduledMaintenanceStartingMetadata{
+				Emitted: map[string]string{"scheda": "2026-01-01T00:00:00Z"},
+			},
+		}
+		events := &contexts.EventContext{}
+
+		require.NoError(t, poll(metadataCtx, events, upcomingMaintenancesJSON(now.Add(5*time.Minute))))
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("API error schedules next poll without emitting", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		requestCtx := &contexts.RequestContext{}
+		_, err := trigger.HandleAction(core.TriggerActionContext{
+			Name:          OnScheduledMaintenanceStartingPollAction,
+			Configuration: map[string]any{"page": "kctbh9vrtdwd"},
+			Logger:        logrus.NewEntry(logrus.New()),
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(`{}`))},
+				},
+			},
+			Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			Metadata:    &contexts.MetadataContext{},
+			Events:      events,
+			Requests:    requestCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+		assert.Equal(t, OnScheduledMaintenanceStartingPollAction, requestCtx.Action)
+	})
+}
@@ -0,0 +1,292 @@
+package statuspage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type ResolveIncident struct{}
+
+// ResolveIncidentSpec is the strongly typed configuration for the Resolve Incident component.
+type ResolveIncidentSpec struct {
+	Page                 string `json:"page"`
+	Incident             string `json:"incident"`
+	IncidentExpression   string `json:"incidentExpression"`
+	Body                 string `json:"body"`
+	RestoreComponents    *bool  `json:"restoreComponents,omitempty"`
+	DeliverNotifications *bool  `json:"deliverNotifications,omitempty"`
+}
+
+// resolvedStatusFor returns the final status for an incident:
+// "completed" for scheduled maintenances, "resolved" for realtime incidents.
+func resolvedStatusFor(currentStatus string) string {
+	if isScheduledStatus(currentStatus) {
+		return "completed"
+	}
+	return "resolved"
+}
+
+// affectedComponentIDs returns the IDs of the components attached to an incident.
+func affectedComponentIDs(incident map[string]any) []string {
+	list, ok := incident["components"].([]any)
+	if !ok {
+		return nil
+	}
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		component, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if id, _ := component["id"].(string); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (c *ResolveIncident) Name() string {
+	return "statuspage.resolveIncident"
+}
+
+func (c *ResolveIncident) Label() string {
+	return "Resolve Incident"
+}
+
+func (c *ResolveIncident) Description() string {
+	return "Resolve an incident on your Statuspage and optionally restore its components to operational."
+}
+
+func (c *ResolveIncident) Documentation() string {
+	return `The Resolve Incident component transitions an existing incident on your Atlassian Statuspage to its final state.
+
+## Use Cases
+
+- **Automated recovery**: Resolve incidents when monitoring reports that the service is healthy again
+- **Maintenance wrap-up**: Complete scheduled maintenances once the deployment finishes
+- **Incident workflows**: Close the loop after a Create Incident node, restoring all affected components
+
+## How It Works
+
+1. Fetches the incident to determine its type
+2. Sets the status to **resolved** for realtime incidents, or **completed** for scheduled maintenances
+3. When **Restore components** is enabled, sets every component attached to the incident back to operational
+4. Emits the final incident object
+
+## Configuration
+
+- **Page** (required): The Statuspage containing the incident. Select from the dropdown, or switch to expression mode for workflow chaining (e.g. {{ $['Create Incident'].data.page_id }}).
+- **Incident** (required): Incident ID to resolve. Supports expressions for workflow chaining (e.g. {{ $['Create Incident'].data.id }}).
+- **Message** (optional): Final update message shown on the incident
+- **Restore components** (optional): Set all affected components to operational (default: true)
+- **Deliver notifications** (optional): Whether to send notifications for this update (default: true)
+
+## Output
+
+Returns the full Statuspage Incident object from the API. The payload has structure { type, timestamp, data } where data is the incident. Common expression paths (use $['Node Name'].data. as prefix):
+- data.id, data.name, data.status, data.impact
+- data.shortlink — link to the incident
+- data.resolved_at — when the incident was resolved
+- data.components — array of affected components
+- data.incident_updates — array of update messages`
+}
+
+func (c *ResolveIncident) Icon() string {
+	return "activity"
+}
+
+func (c *ResolveIncident) Color() string {
+	return "gray"
+}
+
+func (c *ResolveIncident) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ResolveIncident) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "page",
+			Label:       "Page",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The Statuspage containing the incident",
+			Placeholder: "Select a page",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypePage,
+				},
+			},
+		},
+		{
+			Name:        "incident",
+			Label:       "Incident",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Select an incident or choose 'Use expression' when page is an expression",
+			Placeholder: "Select an incident",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeIncident,
+					Parameters: []configuration.ParameterRef{
+						{Name: "page_id", ValueFrom: &configuration.ParameterValueFrom{Field: "page"}},
+					},
+				},
+			},
+		},
+		{
+			Name:        "incidentExpression",
+			Label:       "Incident expression",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Expression for incident ID when using expression for page (e.g. {{ $['Create Incident'].data.id }})",
+			Placeholder: "e.g. {{ $['Create Incident'].data.id }}",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "incident", Values: []string{IncidentUseExpressionID}},
+			},
+		},
+		{
+			Name:        "body",
+			Label:       "Message",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Final update message shown on the incident",
+		},
+		{
+			Name:        "restoreComponents",
+			Label:       "Restore components",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Set all components affected by the incident back to operational",
+		},
+		{
+			Name:        "deliverNotifications",
+			Label:       "Deliver notifications",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Send notifications for this update (default: true)",
+		},
+	}
+}
+
+func (c *ResolveIncident) Setup(ctx core.SetupContext) error {
+	spec := ResolveIncidentSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %w", err)
+	}
+
+	if spec.Page == "" {
+		return errors.New("page is required")
+	}
+
+	if spec.Incident == "" {
+		return errors.New("incident is required")
+	}
+	if spec.Incident == IncidentUseExpressionID {
+		if spec.IncidentExpression == "" {
+			return errors.New("incident expression is required when using expression for incident")
+		}
+	}
+
+	metadata, err := resolveMetadataSetup(ctx, spec.Page, nil)
+	if err != nil {
+		return err
+	}
+	if spec.Incident != "" && spec.Incident != IncidentUseExpressionID && !strings.Contains(spec.Incident, "{{") {
+		incidentName, err := resolveIncidentName(ctx, spec.Page, spec.Incident)
+		if err != nil {
+			return fmt.Errorf("incident not found or inaccessible: %w", err)
+		}
+		metadata.IncidentName = incidentName
+	}
+	return ctx.Metadata.Set(metadata)
+}
+
+func (c *ResolveIncident) Execute(ctx core.ExecutionContext) error {
+	spec := ResolveIncidentSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %w", err)
+	}
+
+	incidentID := spec.Incident
+	if incidentID == IncidentUseExpressionID {
+		incidentID = spec.IncidentExpression
+	}
+	if incidentID == "" {
+		return fmt.Errorf("incident ID is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	existing, err := client.GetIncident(spec.Page, incidentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch incident: %w", err)
+	}
+
+	currentStatus, _ := existing["status"].(string)
+	req := UpdateIncidentRequest{
+		Status:               resolvedStatusFor(currentStatus),
+		Body:                 spec.Body,
+		DeliverNotifications: spec.DeliverNotifications,
+	}
+
+	if spec.RestoreComponents == nil || *spec.RestoreComponents {
+		componentIDs := affectedComponentIDs(existing)
+		if len(componentIDs) > 0 {
+			req.ComponentIDs = componentIDs
+			req.Components = make(map[string]string, len(componentIDs))
+			for _, id := range componentIDs {
+				req.Components[id] = "operational"
+			}
+		}
+	}
+
+	incident, err := client.UpdateIncident(spec.Page, incidentID, req)
+	if err != nil {
+		return fmt.Errorf("failed to resolve incident: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"statuspage.incident",
+		[]any{incident},
+	)
+}
+
+func (c *ResolveIncident) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ResolveIncident) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ResolveIncident) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *ResolveIncident) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ResolveIncident) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ResolveIncident) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
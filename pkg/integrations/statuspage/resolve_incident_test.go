@@ -0,0 +1,194 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const sampleIncidentWithComponentsJSON = `{
+	"id": "p31zjtct2jer",
+	"name": "Database Connection Issues",
+	"status": "monitoring",
+	"impact": "major",
+	"page_id": "kctbh9vrtdwd",
+	"components": [
+		{"id": "8kbf7d35c070", "name": "API", "status": "partial_outage"},
+		{"id": "9kbf7d35c071", "name": "Management Portal", "status": "degraded_performance"}
+	],
+	"incident_updates": []
+}`
+
+func Test__ResolveIncident__Setup(t *testing.T) {
+	component := &ResolveIncident{}
+
+	t.Run("missing page returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"incident": "p31zjtct2jer"},
+		})
+		require.ErrorContains(t, err, "page is required")
+	})
+
+	t.Run("missing incident returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd"},
+		})
+		require.ErrorContains(t, err, "incident is required")
+	})
+
+	t.Run("use expression without incidentExpression returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd", "incident": IncidentUseExpressionID},
+		})
+		require.ErrorContains(t, err, "incident expression is required")
+	})
+
+	t.Run("valid configuration stores page and incident names", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id":"kctbh9vrtdwd","name":"My Page"}]`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"incident":` + sampleRealtimeIncidentJSON + `}`))},
+			},
+		}
+		metadataCtx := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"page": "kctbh9vrtdwd", "incident": "p31zjtct2jer"},
+			HTTP:          httpContext,
+			Integration:   &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			Metadata:      metadataCtx,
+		})
+		require.NoError(t, err)
+		metadata, ok := metadataCtx.Metadata.(NodeMetadata)
+		require.True(t, ok)
+		assert.Equal(t, "My Page", metadata.PageName)
+		assert.Equal(t, "Database Connection Issues", metadata.IncidentName)
+	})
+}
+
+func Test__ResolveIncident__Execute(t *testing.T) {
+	component := &ResolveIncident{}
+
+	t.Run("realtime incident is resolved and components restored", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleIncidentWithComponentsJSON))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleUpdatedIncidentJSON))},
+			},
+		}
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"page":     "kctbh9vrtdwd",
+				"incident": "p31zjtct2jer",
+				"body":     "All systems operational.",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodPatch, httpContext.Requests[1].Method)
+
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		var payload map[string]map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "resolved", payload["incident"]["status"])
+		assert.Equal(t, "All systems operational.", payload["incident"]["body"])
+		assert.Equal(t, map[string]any{
+			"8kbf7d35c070": "operational",
+			"9kbf7d35c071": "operational",
+		}, payload["incident"]["components"])
+
+		assert.Equal(t, core.DefaultOutputChannel.Name, executionState.Channel)
+		assert.Equal(t, "statuspage.incident", executionState.Type)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "resolved", data["status"])
+	})
+
+	t.Run("scheduled maintenance is completed", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleScheduledIncidentJSON))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleScheduledIncidentJSON))},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"page":     "kctbh9vrtdwd",
+				"incident": "sched123",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.NoError(t, err)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		var payload map[string]map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "completed", payload["incident"]["status"])
+	})
+
+	t.Run("restoreComponents false leaves components untouched", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleIncidentWithComponentsJSON))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(sampleUpdatedIncidentJSON))},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"page":              "kctbh9vrtdwd",
+				"incident":          "p31zjtct2jer",
+				"restoreComponents": false,
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.NoError(t, err)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		var payload map[string]map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.NotContains(t, payload["incident"], "components")
+		assert.NotContains(t, payload["incident"], "component_ids")
+	})
+
+	t.Run("API error returns error and no emit", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"error":"not found"}`))},
+			},
+		}
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"page":     "kctbh9vrtdwd",
+				"incident": "missing",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+			ExecutionState: executionState,
+		})
+
+		require.ErrorContains(t, err, "failed to fetch incident")
+		assert.Empty(t, executionState.Payloads)
+	})
+}
@@ -33,7 +33,7 @@ func (s *Statuspage) Icon() string {
 }
 
 func (s *Statuspage) Description() string {
-	return "Create and manage incidents on your Atlassian Statuspage"
+	return "Create and manage incidents and scheduled maintenances on your Atlassian Statuspage"
 }
 
 func (s *Statuspage) Instructions() string {
@@ -90,6 +90,20 @@ func (s *Statuspage) Sync(ctx core.SyncContext) error {
 	return nil
 }
 
+func (s *Statuspage) HealthCheck(ctx core.HealthCheckContext) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	_, err = client.ListPages()
+	if err != nil {
+		return fmt.Errorf("error listing pages: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Statuspage) HandleRequest(ctx core.HTTPRequestContext) {
 	// no-op
 }
@@ -106,10 +120,15 @@ func (s *Statuspage) Components() []core.Component {
 	return []core.Component{
 		&CreateIncident{},
 		&UpdateIncident{},
+		&ResolveIncident{},
+		&ListMaintenances{},
+		&CancelMaintenance{},
 		&GetIncident{},
 	}
 }
 
 func (s *Statuspage) Triggers() []core.Trigger {
-	return []core.Trigger{}
+	return []core.Trigger{
+		&OnScheduledMaintenanceStarting{},
+	}
 }
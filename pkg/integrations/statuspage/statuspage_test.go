@@ -0,0 +1,59 @@
+package statuspage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Statuspage__HealthCheck(t *testing.T) {
+	s := &Statuspage{}
+
+	t.Run("pages listed -> healthy", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`[{"id":"page1","name":"My Page"}]`)),
+				},
+			},
+		}
+
+		err := s.HealthCheck(core.HealthCheckContext{
+			Context:     context.Background(),
+			HTTP:        httpContext,
+			Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Contains(t, httpContext.Requests[0].URL.String(), "/pages")
+		assert.Equal(t, "OAuth test-key", httpContext.Requests[0].Header.Get("Authorization"))
+	})
+
+	t.Run("API key revoked -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader(`{"error":"Could not authenticate"}`)),
+				},
+			},
+		}
+
+		err := s.HealthCheck(core.HealthCheckContext{
+			Context:     context.Background(),
+			HTTP:        httpContext,
+			Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "revoked"}},
+		})
+
+		require.ErrorContains(t, err, "request failed with 401")
+	})
+}
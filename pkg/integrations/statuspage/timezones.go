@@ -0,0 +1,59 @@
+package statuspage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	// Embed the time zone database, so every listed zone
+	// can be loaded regardless of the host system.
+	_ "time/tzdata"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// listTimezoneResources lists the IANA time zones generated by scripts/timezones.
+func listTimezoneResources() ([]core.IntegrationResource, error) {
+	resources := make([]core.IntegrationResource, 0, len(ianaTimezones))
+	for _, zone := range ianaTimezones {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeTimezone,
+			Name: zone,
+			ID:   zone,
+		})
+	}
+	return resources, nil
+}
+
+// loadTimezone resolves an IANA time zone name, returning an error that
+// explains what was wrong with the value. Besides the listed zones, any name
+// accepted by time.LoadLocation (e.g. legacy aliases like US/Eastern) is allowed.
+func loadTimezone(timezone string) (*time.Location, error) {
+	name := strings.TrimSpace(timezone)
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	if strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("invalid timezone %q: use an IANA time zone name such as America/New_York or UTC", timezone)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		return loc, nil
+	}
+
+	idx := slices.IndexFunc(ianaTimezones, func(zone string) bool {
+		return strings.EqualFold(zone, name)
+	})
+	if idx >= 0 {
+		return nil, fmt.Errorf("invalid timezone %q: time zone names are case-sensitive, did you mean %q?", timezone, ianaTimezones[idx])
+	}
+
+	if strings.HasPrefix(name, "+") || strings.HasPrefix(name, "-") || strings.HasPrefix(strings.ToUpper(name), "GMT") {
+		return nil, fmt.Errorf("invalid timezone %q: UTC offsets are not supported, use an IANA time zone name such as Europe/Berlin", timezone)
+	}
+
+	return nil, fmt.Errorf("invalid timezone %q: use an IANA time zone name such as America/New_York or UTC", timezone)
+}
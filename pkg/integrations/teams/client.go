@@ -14,6 +14,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 )
 
 const (
@@ -55,7 +56,7 @@ func NewClient(ctx core.IntegrationContext) (*Client, error) {
 		AppID:       string(appID),
 		AppPassword: string(appPassword),
 		TenantID:    tenantID,
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		httpClient:  &http.Client{Timeout: 30 * time.Second, Transport: telemetry.NewHTTPTransport()},
 	}, nil
 }
 
@@ -65,7 +66,7 @@ func NewClientFromConfig(appID, appPassword, tenantID string) *Client {
 		AppID:       appID,
 		AppPassword: appPassword,
 		TenantID:    tenantID,
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		httpClient:  &http.Client{Timeout: 30 * time.Second, Transport: telemetry.NewHTTPTransport()},
 	}
 }
 
@@ -500,7 +501,7 @@ func (v *JWTValidator) fetchKeys() error {
 		return nil
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: 10 * time.Second, Transport: telemetry.NewHTTPTransport()}
 
 	// Fetch OpenID configuration
 	resp, err := client.Get(botFrameworkOpenIDURL)
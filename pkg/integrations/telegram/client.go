@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 )
 
 const telegramAPIBase = "https://api.telegram.org/bot"
@@ -205,7 +206,8 @@ func (c *Client) doRequest(method, endpoint string, body io.Reader) ([]byte, err
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	client := &http.Client{Transport: telemetry.NewHTTPTransport()}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
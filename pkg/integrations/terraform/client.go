@@ -0,0 +1,493 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	DefaultAddress = "https://app.terraform.io"
+	jsonAPIMedia   = "application/vnd.api+json"
+)
+
+type Client struct {
+	Address      string
+	Token        string
+	Organization string
+	http         core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+type Organization struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type Workspace struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	AutoApply        bool   `json:"autoApply"`
+	TerraformVersion string `json:"terraformVersion"`
+	WorkingDirectory string `json:"workingDirectory"`
+}
+
+type Variable struct {
+	ID        string `json:"id"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Category  string `json:"category"`
+	HCL       bool   `json:"hcl"`
+	Sensitive bool   `json:"sensitive"`
+}
+
+type Run struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	Message       string `json:"message"`
+	Source        string `json:"source"`
+	IsDestroy     bool   `json:"isDestroy"`
+	PlanOnly      bool   `json:"planOnly"`
+	AutoApply     bool   `json:"autoApply"`
+	HasChanges    bool   `json:"hasChanges"`
+	CreatedAt     string `json:"createdAt"`
+	WorkspaceID   string `json:"workspaceId"`
+	PolicyCheckID string `json:"policyCheckId,omitempty"`
+	CanApply      bool   `json:"canApply"`
+	CanDiscard    bool   `json:"canDiscard"`
+	CanOverride   bool   `json:"canOverride"`
+}
+
+type RunVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type CreateRunRequest struct {
+	WorkspaceID string
+	Message     string
+	IsDestroy   bool
+	PlanOnly    bool
+	AutoApply   bool
+	Variables   []RunVariable
+}
+
+type NotificationConfiguration struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	URL      string   `json:"url"`
+	Enabled  bool     `json:"enabled"`
+	Triggers []string `json:"triggers"`
+}
+
+// resource is a single JSON:API resource object, as returned by the Terraform Cloud API.
+type resource struct {
+	ID            string                  `json:"id"`
+	Type          string                  `json:"type"`
+	Attributes    map[string]any          `json:"attributes"`
+	Relationships map[string]relationship `json:"relationships"`
+}
+
+type relationship struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type resourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type singleDocument struct {
+	Data resource `json:"data"`
+}
+
+type listDocument struct {
+	Data []resource `json:"data"`
+	Meta struct {
+		Pagination struct {
+			NextPage *int `json:"next-page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	address := DefaultAddress
+	configuredAddress, err := ctx.GetConfig("address")
+	if err == nil && strings.TrimSpace(string(configuredAddress)) != "" {
+		address = strings.TrimSpace(string(configuredAddress))
+	}
+
+	token, err := ctx.GetConfig("apiToken")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedToken := strings.TrimSpace(string(token))
+	if trimmedToken == "" {
+		return nil, fmt.Errorf("apiToken is required")
+	}
+
+	organization, err := ctx.GetConfig("organization")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedOrganization := strings.TrimSpace(string(organization))
+	if trimmedOrganization == "" {
+		return nil, fmt.Errorf("organization is required")
+	}
+
+	return &Client{
+		Address:      strings.TrimRight(address, "/"),
+		Token:        trimmedToken,
+		Organization: trimmedOrganization,
+		http:         httpClient,
+	}, nil
+}
+
+func (c *Client) GetOrganization() (Organization, error) {
+	path := fmt.Sprintf("/api/v2/organizations/%s", url.PathEscape(c.Organization))
+	_, body, err := c.execRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return Organization{}, err
+	}
+
+	var document singleDocument
+	if err := json.Unmarshal(body, &document); err != nil {
+		return Organization{}, fmt.Errorf("failed to unmarshal organization response: %w", err)
+	}
+
+	return Organization{
+		Name:  readString(document.Data.Attributes["name"]),
+		Email: readString(document.Data.Attributes["email"]),
+	}, nil
+}
+
+func (c *Client) ListWorkspaces() ([]Workspace, error) {
+	path := fmt.Sprintf("/api/v2/organizations/%s/workspaces", url.PathEscape(c.Organization))
+	resources, err := c.listAll(path)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make([]Workspace, 0, len(resources))
+	for _, r := range resources {
+		workspaces = append(workspaces, workspaceFromResource(r))
+	}
+
+	return workspaces, nil
+}
+
+func (c *Client) GetWorkspace(workspaceID string) (Workspace, error) {
+	path := fmt.Sprintf("/api/v2/workspaces/%s", url.PathEscape(workspaceID))
+	_, body, err := c.execRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	var document singleDocument
+	if err := json.Unmarshal(body, &document); err != nil {
+		return Workspace{}, fmt.Errorf("failed to unmarshal workspace response: %w", err)
+	}
+
+	return workspaceFromResource(document.Data), nil
+}
+
+func (c *Client) ListVariables(workspaceID string) ([]Variable, error) {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/vars", url.PathEscape(workspaceID))
+	_, body, err := c.execRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var document listDocument
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variables response: %w", err)
+	}
+
+	variables := make([]Variable, 0, len(document.Data))
+	for _, r := range document.Data {
+		hcl, _ := r.Attributes["hcl"].(bool)
+		sensitive, _ := r.Attributes["sensitive"].(bool)
+		variables = append(variables, Variable{
+			ID:        r.ID,
+			Key:       readString(r.Attributes["key"]),
+			Value:     readString(r.Attributes["value"]),
+			Category:  readString(r.Attributes["category"]),
+			HCL:       hcl,
+			Sensitive: sensitive,
+		})
+	}
+
+	return variables, nil
+}
+
+func (c *Client) CreateRun(request CreateRunRequest) (Run, error) {
+	attributes := map[string]any{
+		"message":    request.Message,
+		"is-destroy": request.IsDestroy,
+		"plan-only":  request.PlanOnly,
+		"auto-apply": request.AutoApply,
+	}
+
+	if len(request.Variables) > 0 {
+		attributes["variables"] = request.Variables
+	}
+
+	payload := map[string]any{
+		"data": map[string]any{
+			"type":       "runs",
+			"attributes": attributes,
+			"relationships": map[string]any{
+				"workspace": map[string]any{
+					"data": resourceIdentifier{ID: request.WorkspaceID, Type: "workspaces"},
+				},
+			},
+		},
+	}
+
+	_, body, err := c.execRequest(http.MethodPost, "/api/v2/runs", nil, payload)
+	if err != nil {
+		return Run{}, err
+	}
+
+	var document singleDocument
+	if err := json.Unmarshal(body, &document); err != nil {
+		return Run{}, fmt.Errorf("failed to unmarshal run response: %w", err)
+	}
+
+	return runFromResource(document.Data), nil
+}
+
+func (c *Client) GetRun(runID string) (Run, error) {
+	path := fmt.Sprintf("/api/v2/runs/%s", url.PathEscape(runID))
+	_, body, err := c.execRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return Run{}, err
+	}
+
+	var document singleDocument
+	if err := json.Unmarshal(body, &document); err != nil {
+		return Run{}, fmt.Errorf("failed to unmarshal run response: %w", err)
+	}
+
+	return runFromResource(document.Data), nil
+}
+
+func (c *Client) ApplyRun(runID, comment string) error {
+	return c.runAction(runID, "apply", comment)
+}
+
+func (c *Client) DiscardRun(runID, comment string) error {
+	return c.runAction(runID, "discard", comment)
+}
+
+func (c *Client) CancelRun(runID, comment string) error {
+	return c.runAction(runID, "cancel", comment)
+}
+
+func (c *Client) OverridePolicyCheck(policyCheckID string) error {
+	path := fmt.Sprintf("/api/v2/policy-checks/%s/actions/override", url.PathEscape(policyCheckID))
+	_, _, err := c.execRequest(http.MethodPost, path, nil, nil)
+	return err
+}
+
+func (c *Client) CreateNotificationConfiguration(workspaceID, name, destinationURL, token string, triggers []string) (NotificationConfiguration, error) {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/notification-configurations", url.PathEscape(workspaceID))
+	payload := map[string]any{
+		"data": map[string]any{
+			"type": "notification-configurations",
+			"attributes": map[string]any{
+				"destination-type": "generic",
+				"enabled":          true,
+				"name":             name,
+				"url":              destinationURL,
+				"token":            token,
+				"triggers":         triggers,
+			},
+		},
+	}
+
+	_, body, err := c.execRequest(http.MethodPost, path, nil, payload)
+	if err != nil {
+		return NotificationConfiguration{}, err
+	}
+
+	var document singleDocument
+	if err := json.Unmarshal(body, &document); err != nil {
+		return NotificationConfiguration{}, fmt.Errorf("failed to unmarshal notification configuration response: %w", err)
+	}
+
+	enabled, _ := document.Data.Attributes["enabled"].(bool)
+	return NotificationConfiguration{
+		ID:       document.Data.ID,
+		Name:     readString(document.Data.Attributes["name"]),
+		URL:      readString(document.Data.Attributes["url"]),
+		Enabled:  enabled,
+		Triggers: readStrings(document.Data.Attributes["triggers"]),
+	}, nil
+}
+
+func (c *Client) DeleteNotificationConfiguration(notificationConfigurationID string) error {
+	path := fmt.Sprintf("/api/v2/notification-configurations/%s", url.PathEscape(notificationConfigurationID))
+	_, _, err := c.execRequest(http.MethodDelete, path, nil, nil)
+	return err
+}
+
+func (c *Client) runAction(runID, action, comment string) error {
+	path := fmt.Sprintf("/api/v2/runs/%s/actions/%s", url.PathEscape(runID), action)
+
+	var payload any
+	if comment != "" {
+		payload = map[string]string{"comment": comment}
+	}
+
+	_, _, err := c.execRequest(http.MethodPost, path, nil, payload)
+	return err
+}
+
+// listAll follows the JSON:API pagination links until all pages are read.
+func (c *Client) listAll(path string) ([]resource, error) {
+	resources := []resource{}
+	page := 1
+
+	for {
+		query := url.Values{}
+		query.Set("page[size]", "100")
+		query.Set("page[number]", fmt.Sprintf("%d", page))
+
+		_, body, err := c.execRequest(http.MethodGet, path, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var document listDocument
+		if err := json.Unmarshal(body, &document); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal list response: %w", err)
+		}
+
+		resources = append(resources, document.Data...)
+		next := document.Meta.Pagination.NextPage
+		if next == nil || *next <= page {
+			return resources, nil
+		}
+
+		page = *next
+	}
+}
+
+func (c *Client) execRequest(
+	method string,
+	path string,
+	query url.Values,
+	payload any,
+) (*http.Response, []byte, error) {
+	endpoint := c.Address + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		encodedBody, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(encodedBody)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", jsonAPIMedia)
+	if payload != nil {
+		req.Header.Set("Content-Type", jsonAPIMedia)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, nil, &APIError{StatusCode: res.StatusCode, Body: string(responseBody)}
+	}
+
+	return res, responseBody, nil
+}
+
+func workspaceFromResource(r resource) Workspace {
+	autoApply, _ := r.Attributes["auto-apply"].(bool)
+	return Workspace{
+		ID:               r.ID,
+		Name:             readString(r.Attributes["name"]),
+		AutoApply:        autoApply,
+		TerraformVersion: readString(r.Attributes["terraform-version"]),
+		WorkingDirectory: readString(r.Attributes["working-directory"]),
+	}
+}
+
+func runFromResource(r resource) Run {
+	run := Run{
+		ID:        r.ID,
+		Status:    readString(r.Attributes["status"]),
+		Message:   readString(r.Attributes["message"]),
+		Source:    readString(r.Attributes["source"]),
+		CreatedAt: readString(r.Attributes["created-at"]),
+	}
+
+	run.IsDestroy, _ = r.Attributes["is-destroy"].(bool)
+	run.PlanOnly, _ = r.Attributes["plan-only"].(bool)
+	run.AutoApply, _ = r.Attributes["auto-apply"].(bool)
+	run.HasChanges, _ = r.Attributes["has-changes"].(bool)
+
+	actions := readMap(r.Attributes["actions"])
+	run.CanApply, _ = actions["is-confirmable"].(bool)
+	run.CanDiscard, _ = actions["is-discardable"].(bool)
+
+	permissions := readMap(r.Attributes["permissions"])
+	run.CanOverride, _ = permissions["can-override-policy-check"].(bool)
+
+	if workspace, ok := r.Relationships["workspace"]; ok {
+		var identifier resourceIdentifier
+		if err := json.Unmarshal(workspace.Data, &identifier); err == nil {
+			run.WorkspaceID = identifier.ID
+		}
+	}
+
+	if policyChecks, ok := r.Relationships["policy-checks"]; ok {
+		var identifiers []resourceIdentifier
+		if err := json.Unmarshal(policyChecks.Data, &identifiers); err == nil && len(identifiers) > 0 {
+			run.PolicyCheckID = identifiers[len(identifiers)-1].ID
+		}
+	}
+
+	return run
+}
@@ -0,0 +1,169 @@
+package terraform
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	// Notification triggers in Terraform Cloud
+	NotificationTriggerRunCompleted      = "run:completed"
+	NotificationTriggerRunErrored        = "run:errored"
+	NotificationTriggerRunNeedsAttention = "run:needs_attention"
+	notificationTriggerVerification      = "verification"
+
+	// Run statuses in Terraform Cloud
+	RunStatusPending            = "pending"
+	RunStatusPlanned            = "planned"
+	RunStatusCostEstimated      = "cost_estimated"
+	RunStatusPolicyChecked      = "policy_checked"
+	RunStatusPolicyOverride     = "policy_override"
+	RunStatusPolicySoftFailed   = "policy_soft_failed"
+	RunStatusPostPlanCompleted  = "post_plan_completed"
+	RunStatusPlannedAndFinished = "planned_and_finished"
+	RunStatusPlannedAndSaved    = "planned_and_saved"
+	RunStatusApplied            = "applied"
+	RunStatusErrored            = "errored"
+	RunStatusDiscarded          = "discarded"
+	RunStatusCanceled           = "canceled"
+	RunStatusForceCanceled      = "force_canceled"
+
+	// Header carrying the HMAC-SHA512 signature of notification payloads
+	notificationSignatureHeader = "X-TFE-Notification-Signature"
+)
+
+// verifyNotificationSignature checks the notification signature,
+// computed by Terraform Cloud with the token set on the notification configuration.
+func verifyNotificationSignature(ctx core.WebhookRequestContext) error {
+	if ctx.Webhook == nil {
+		return fmt.Errorf("missing webhook context")
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return fmt.Errorf("error reading webhook secret")
+	}
+
+	if len(secret) == 0 {
+		return fmt.Errorf("missing webhook secret")
+	}
+
+	signature := strings.TrimSpace(ctx.Headers.Get(notificationSignatureHeader))
+	if signature == "" {
+		return fmt.Errorf("missing %s header", notificationSignatureHeader)
+	}
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature format")
+	}
+
+	h := hmac.New(sha512.New, secret)
+	h.Write(ctx.Body)
+	if !hmac.Equal(decoded, h.Sum(nil)) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+func isRunSuccessful(status string) bool {
+	switch status {
+	case RunStatusApplied, RunStatusPlannedAndFinished, RunStatusPlannedAndSaved:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRunFailed(status string) bool {
+	switch status {
+	case RunStatusErrored, RunStatusDiscarded, RunStatusCanceled, RunStatusForceCanceled, RunStatusPolicySoftFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRunFinished(status string) bool {
+	return isRunSuccessful(status) || isRunFailed(status)
+}
+
+// isRunAwaitingConfirmation returns true if the run is paused, waiting for someone to apply or discard it.
+func isRunAwaitingConfirmation(status string) bool {
+	switch status {
+	case RunStatusPlanned, RunStatusCostEstimated, RunStatusPolicyChecked, RunStatusPostPlanCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+func normalizeTriggers(triggers []string) []string {
+	normalized := make([]string, 0, len(triggers))
+	for _, trigger := range triggers {
+		trimmed := strings.TrimSpace(trigger)
+		if trimmed == "" || slices.Contains(normalized, trimmed) {
+			continue
+		}
+
+		normalized = append(normalized, trimmed)
+	}
+
+	sort.Strings(normalized)
+	return normalized
+}
+
+func readString(value any) string {
+	str, ok := value.(string)
+	if !ok {
+		return ""
+	}
+
+	return str
+}
+
+func readMap(value any) map[string]any {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+
+	return m
+}
+
+func readStrings(value any) []string {
+	items, ok := value.([]any)
+	if !ok {
+		return []string{}
+	}
+
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			result = append(result, str)
+		}
+	}
+
+	return result
+}
+
+func errorResponse(statusCode int, format string, a ...any) (int, *core.WebhookResponseBody, error) {
+	return statusCode, nil, fmt.Errorf(format, a...)
+}
+
+func okResponse() (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func isResolvedValue(value string) bool {
+	return value != "" && !strings.Contains(value, "{{")
+}
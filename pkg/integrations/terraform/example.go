@@ -0,0 +1,36 @@
+package terraform
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_run_completed.json
+var exampleDataOnRunCompletedBytes []byte
+
+//go:embed example_output_run_workspace.json
+var exampleOutputRunWorkspaceBytes []byte
+
+var exampleDataOnRunCompletedOnce sync.Once
+var exampleDataOnRunCompleted map[string]any
+
+var exampleOutputRunWorkspaceOnce sync.Once
+var exampleOutputRunWorkspace map[string]any
+
+func (t *OnRunCompleted) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnRunCompletedOnce,
+		exampleDataOnRunCompletedBytes,
+		&exampleDataOnRunCompleted,
+	)
+}
+
+func (c *RunWorkspace) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputRunWorkspaceOnce,
+		exampleOutputRunWorkspaceBytes,
+		&exampleOutputRunWorkspace,
+	)
+}
@@ -0,0 +1,237 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnRunCompleted struct{}
+
+type OnRunCompletedConfiguration struct {
+	Workspace string   `json:"workspace" mapstructure:"workspace"`
+	Triggers  []string `json:"triggers" mapstructure:"triggers"`
+}
+
+type OnRunCompletedMetadata struct {
+	WorkspaceName string `json:"workspaceName,omitempty" mapstructure:"workspaceName"`
+}
+
+var runCompletedTriggerOptions = []configuration.FieldOption{
+	{Label: "Completed", Value: NotificationTriggerRunCompleted},
+	{Label: "Errored", Value: NotificationTriggerRunErrored},
+}
+
+var runCompletedAllowedTriggers = []string{
+	NotificationTriggerRunCompleted,
+	NotificationTriggerRunErrored,
+}
+
+func (t *OnRunCompleted) Name() string {
+	return "terraform.onRunCompleted"
+}
+
+func (t *OnRunCompleted) Label() string {
+	return "On Run Completed"
+}
+
+func (t *OnRunCompleted) Description() string {
+	return "Listen to completed runs in a Terraform Cloud workspace"
+}
+
+func (t *OnRunCompleted) Documentation() string {
+	return `The On Run Completed trigger starts a workflow execution when a run in a Terraform Cloud workspace completes or errors.
+
+## Use Cases
+
+- **Post-provisioning**: Deploy an application once its infrastructure is applied
+- **Notifications**: Notify a channel when a run fails
+- **Chained workspaces**: Run a downstream workspace after an upstream one is applied
+
+## Configuration
+
+- **Workspace** (required): The Terraform Cloud workspace to listen to
+- **Triggers**: Run events to listen to. Defaults to both completed and errored runs
+
+## Webhook Verification
+
+SuperPlane creates a notification on the workspace, and verifies the ` + "`X-TFE-Notification-Signature`" + ` header of every notification with the token set on it.
+
+## Event Data
+
+Each event includes:
+- **run_id** and **run_url**: The run and its URL in Terraform Cloud
+- **run_message**: The message of the run
+- **run_status**: The status of the run, e.g. ` + "`applied`" + ` or ` + "`errored`" + `
+- **trigger**: The notification trigger, ` + "`run:completed`" + ` or ` + "`run:errored`" + `
+- **workspace_id**, **workspace_name** and **organization_name**: Where the run happened`
+}
+
+func (t *OnRunCompleted) Icon() string {
+	return "layers"
+}
+
+func (t *OnRunCompleted) Color() string {
+	return "purple"
+}
+
+func (t *OnRunCompleted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "workspace",
+			Label:    "Workspace",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeWorkspace,
+				},
+			},
+			Description: "Terraform Cloud workspace to listen to",
+		},
+		{
+			Name:        "triggers",
+			Label:       "Triggers",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Default:     runCompletedAllowedTriggers,
+			Description: "Run events to listen to",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: runCompletedTriggerOptions,
+				},
+			},
+		},
+	}
+}
+
+func decodeOnRunCompletedConfiguration(value any) (OnRunCompletedConfiguration, error) {
+	config := OnRunCompletedConfiguration{}
+	if err := mapstructure.Decode(value, &config); err != nil {
+		return config, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Workspace = strings.TrimSpace(config.Workspace)
+	if config.Workspace == "" {
+		return config, fmt.Errorf("workspace is required")
+	}
+
+	triggers := []string{}
+	for _, trigger := range normalizeTriggers(config.Triggers) {
+		if slices.Contains(runCompletedAllowedTriggers, trigger) {
+			triggers = append(triggers, trigger)
+		}
+	}
+
+	if len(triggers) == 0 {
+		triggers = runCompletedAllowedTriggers
+	}
+
+	config.Triggers = triggers
+	return config, nil
+}
+
+func (t *OnRunCompleted) Setup(ctx core.TriggerContext) error {
+	config, err := decodeOnRunCompletedConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	workspace, err := client.GetWorkspace(config.Workspace)
+	if err != nil {
+		return fmt.Errorf("failed to find workspace %s: %w", config.Workspace, err)
+	}
+
+	if err := ctx.Metadata.Set(OnRunCompletedMetadata{WorkspaceName: workspace.Name}); err != nil {
+		return fmt.Errorf("failed to store metadata: %w", err)
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		Workspace: config.Workspace,
+		Triggers:  config.Triggers,
+	})
+}
+
+func (t *OnRunCompleted) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *OnRunCompleted) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnRunCompleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	if err := verifyNotificationSignature(ctx); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	config, err := decodeOnRunCompletedConfiguration(ctx.Configuration)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, "%v", err)
+	}
+
+	payload := NotificationPayload{}
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		return errorResponse(http.StatusBadRequest, "error parsing request body: %w", err)
+	}
+
+	// Workspace notifications are shared with the Run Workspace component,
+	// so this trigger also receives notifications it must ignore.
+	if payload.WorkspaceID != config.Workspace {
+		return okResponse()
+	}
+
+	for _, notification := range payload.Notifications {
+		if notification.Trigger == notificationTriggerVerification {
+			continue
+		}
+
+		if !slices.Contains(config.Triggers, notification.Trigger) {
+			continue
+		}
+
+		event := map[string]any{
+			"run_id":            payload.RunID,
+			"run_url":           payload.RunURL,
+			"run_message":       payload.RunMessage,
+			"run_created_at":    payload.RunCreatedAt,
+			"run_created_by":    payload.RunCreatedBy,
+			"run_status":        notification.RunStatus,
+			"run_updated_at":    notification.RunUpdatedAt,
+			"trigger":           notification.Trigger,
+			"message":           notification.Message,
+			"workspace_id":      payload.WorkspaceID,
+			"workspace_name":    payload.WorkspaceName,
+			"organization_name": payload.OrganizationName,
+		}
+
+		if err := ctx.Events.Emit(payloadTypeForTrigger(notification.Trigger), event); err != nil {
+			return errorResponse(http.StatusInternalServerError, "error emitting event: %w", err)
+		}
+	}
+
+	return okResponse()
+}
+
+func (t *OnRunCompleted) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func payloadTypeForTrigger(trigger string) string {
+	if trigger == NotificationTriggerRunErrored {
+		return "terraform.run.errored"
+	}
+
+	return "terraform.run.completed"
+}
@@ -0,0 +1,145 @@
+package terraform
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Terraform_OnRunCompleted__Setup(t *testing.T) {
+	trigger := &OnRunCompleted{}
+
+	t.Run("missing workspace -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   testIntegrationContext(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{},
+		})
+
+		require.ErrorContains(t, err, "workspace is required")
+	})
+
+	t.Run("defaults to completed and errored runs", func(t *testing.T) {
+		integrationCtx := testIntegrationContext()
+		metadataCtx := &contexts.MetadataContext{}
+		err := trigger.Setup(core.TriggerContext{
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusOK, `{"data":{"id":"ws-1","type":"workspaces","attributes":{"name":"production"}}}`),
+				},
+			},
+			Integration:   integrationCtx,
+			Metadata:      metadataCtx,
+			Configuration: map[string]any{"workspace": "ws-1"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, OnRunCompletedMetadata{WorkspaceName: "production"}, metadataCtx.Metadata)
+		require.Len(t, integrationCtx.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{
+			Workspace: "ws-1",
+			Triggers:  []string{NotificationTriggerRunCompleted, NotificationTriggerRunErrored},
+		}, integrationCtx.WebhookRequests[0])
+	})
+}
+
+func Test__Terraform_OnRunCompleted__HandleWebhook(t *testing.T) {
+	trigger := &OnRunCompleted{}
+	secret := "notification-token"
+
+	notification := func(workspaceID, trigger, status string) []byte {
+		body, err := json.Marshal(map[string]any{
+			"payload_version":   1,
+			"run_id":            "run-1",
+			"run_url":           "https://app.terraform.io/app/my-organization/workspaces/production/runs/run-1",
+			"workspace_id":      workspaceID,
+			"workspace_name":    "production",
+			"organization_name": "my-organization",
+			"notifications": []any{
+				map[string]any{"trigger": trigger, "run_status": status, "message": "Applied"},
+			},
+		})
+		require.NoError(t, err)
+		return body
+	}
+
+	t.Run("missing signature -> 403", func(t *testing.T) {
+		body := notification("ws-1", NotificationTriggerRunCompleted, RunStatusApplied)
+		events := &contexts.EventContext{}
+		status, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       http.Header{},
+			Configuration: map[string]any{"workspace": "ws-1"},
+			Webhook:       &contexts.NodeWebhookContext{Secret: secret},
+			Events:        events,
+		})
+
+		assert.Equal(t, http.StatusForbidden, status)
+		require.Error(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("completed run -> emits event", func(t *testing.T) {
+		body := notification("ws-1", NotificationTriggerRunCompleted, RunStatusApplied)
+		events := &contexts.EventContext{}
+		status, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       signedHeaders(secret, body),
+			Configuration: map[string]any{"workspace": "ws-1"},
+			Webhook:       &contexts.NodeWebhookContext{Secret: secret},
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "terraform.run.completed", events.Payloads[0].Type)
+
+		data, ok := events.Payloads[0].Data.(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "run-1", data["run_id"])
+		assert.Equal(t, RunStatusApplied, data["run_status"])
+		assert.Equal(t, "production", data["workspace_name"])
+	})
+
+	t.Run("errored run not selected -> no event", func(t *testing.T) {
+		body := notification("ws-1", NotificationTriggerRunErrored, RunStatusErrored)
+		events := &contexts.EventContext{}
+		status, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: signedHeaders(secret, body),
+			Configuration: map[string]any{
+				"workspace": "ws-1",
+				"triggers":  []string{NotificationTriggerRunCompleted},
+			},
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  events,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("needs attention and verification notifications are ignored", func(t *testing.T) {
+		for _, notificationTrigger := range []string{NotificationTriggerRunNeedsAttention, "verification"} {
+			body := notification("ws-1", notificationTrigger, RunStatusPlanned)
+			events := &contexts.EventContext{}
+			_, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+				Body:          body,
+				Headers:       signedHeaders(secret, body),
+				Configuration: map[string]any{"workspace": "ws-1"},
+				Webhook:       &contexts.NodeWebhookContext{Secret: secret},
+				Events:        events,
+			})
+
+			require.NoError(t, err)
+			assert.Zero(t, events.Count())
+		}
+	})
+}
@@ -0,0 +1,623 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunWorkspacePayloadType          = "terraform.run.finished"
+	RunWorkspaceSuccessOutputChannel = "success"
+	RunWorkspaceFailedOutputChannel  = "failed"
+	RunWorkspacePollInterval         = 5 * time.Minute
+	runWorkspaceExecutionKey         = "run_id"
+
+	RunWorkspaceActionPoll           = "poll"
+	RunWorkspaceActionApply          = "apply"
+	RunWorkspaceActionOverridePolicy = "overridePolicy"
+	RunWorkspaceActionDiscard        = "discard"
+)
+
+type RunWorkspace struct{}
+
+type RunWorkspaceConfiguration struct {
+	Workspace string                 `json:"workspace" mapstructure:"workspace"`
+	Message   string                 `json:"message" mapstructure:"message"`
+	Variables []RunWorkspaceVariable `json:"variables" mapstructure:"variables"`
+	IsDestroy bool                   `json:"isDestroy" mapstructure:"isDestroy"`
+	PlanOnly  bool                   `json:"planOnly" mapstructure:"planOnly"`
+	AutoApply bool                   `json:"autoApply" mapstructure:"autoApply"`
+}
+
+type RunWorkspaceVariable struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type RunWorkspaceNodeMetadata struct {
+	WorkspaceName string `json:"workspaceName,omitempty" mapstructure:"workspaceName"`
+}
+
+type RunWorkspaceExecutionMetadata struct {
+	Run *RunMetadata `json:"run" mapstructure:"run"`
+}
+
+type RunMetadata struct {
+	ID            string `json:"id" mapstructure:"id"`
+	URL           string `json:"url" mapstructure:"url"`
+	Status        string `json:"status" mapstructure:"status"`
+	WorkspaceID   string `json:"workspaceId" mapstructure:"workspaceId"`
+	PolicyCheckID string `json:"policyCheckId,omitempty" mapstructure:"policyCheckId"`
+	CreatedAt     string `json:"createdAt" mapstructure:"createdAt"`
+	FinishedAt    string `json:"finishedAt,omitempty" mapstructure:"finishedAt"`
+}
+
+func (c *RunWorkspace) Name() string {
+	return "terraform.runWorkspace"
+}
+
+func (c *RunWorkspace) Label() string {
+	return "Run Workspace"
+}
+
+func (c *RunWorkspace) Description() string {
+	return "Queue a run in a Terraform Cloud workspace and wait for it to finish"
+}
+
+func (c *RunWorkspace) Documentation() string {
+	return `The Run Workspace component queues a run in a Terraform Cloud workspace and waits for the plan and apply to finish.
+
+## Use Cases
+
+- **Infrastructure provisioning**: Create the infrastructure an application needs before deploying it
+- **Ephemeral environments**: Create and destroy preview environments from a workflow
+- **Drift correction**: Re-apply a workspace on a schedule
+- **Gated applies**: Review the plan and policy checks before applying changes
+
+## How It Works
+
+1. Queues a run in the selected workspace, with the configured variables
+2. Waits for the run to finish (via workspace notifications and polling fallback)
+3. If the run needs attention, the execution stays waiting until someone acts on it:
+   - **Apply**: Confirms a run that finished planning and is waiting for confirmation
+   - **Override Policy**: Overrides a soft-failed policy check, so the run can continue
+   - **Discard**: Discards the run
+4. Routes execution based on the final run status:
+   - **Success channel**: The run was applied, or the plan finished for plan-only runs and plans without changes
+   - **Failed channel**: The run errored, was discarded, canceled, or failed a policy check
+
+## Configuration
+
+- **Workspace**: The Terraform Cloud workspace to run
+- **Message**: Message shown on the run in Terraform Cloud
+- **Variables**: Values for Terraform variables, only used for this run. Values are HCL, so strings must be quoted, e.g. ` + "`\"us-east-1\"`" + `
+- **Destroy**: Queue a destroy run
+- **Plan only**: Queue a speculative plan that cannot be applied
+- **Auto apply**: Apply the run automatically once the plan succeeds, instead of waiting for confirmation
+
+## Output Channels
+
+- **Success**: Emitted when the run finishes successfully
+- **Failed**: Emitted when the run errors, is discarded, canceled, or fails a policy check
+
+## Notes
+
+- Polls the run status every 5 minutes as a fallback if the notification does not arrive
+- Cancelling the execution cancels the run in Terraform Cloud`
+}
+
+func (c *RunWorkspace) Icon() string {
+	return "layers"
+}
+
+func (c *RunWorkspace) Color() string {
+	return "purple"
+}
+
+func (c *RunWorkspace) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: RunWorkspaceSuccessOutputChannel, Label: "Success"},
+		{Name: RunWorkspaceFailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (c *RunWorkspace) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "workspace",
+			Label:    "Workspace",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeWorkspace,
+				},
+			},
+			Description: "Terraform Cloud workspace to run",
+		},
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     "Queued by SuperPlane",
+			Description: "Message shown on the run",
+		},
+		{
+			Name:     "variables",
+			Label:    "Variables",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeIntegrationResource,
+								Required: true,
+								TypeOptions: &configuration.TypeOptions{
+									Resource: &configuration.ResourceTypeOptions{
+										Type: ResourceTypeVariable,
+										Parameters: []configuration.ParameterRef{
+											{
+												Name:      "workspace",
+												ValueFrom: &configuration.ParameterValueFrom{Field: "workspace"},
+											},
+										},
+									},
+								},
+							},
+							{
+								Name:        "value",
+								Label:       "Value",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "HCL value, e.g. \"us-east-1\"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "isDestroy",
+			Label:       "Destroy",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Queue a destroy run",
+		},
+		{
+			Name:        "planOnly",
+			Label:       "Plan only",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Queue a speculative plan that cannot be applied",
+		},
+		{
+			Name:        "autoApply",
+			Label:       "Auto apply",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Apply the run automatically once the plan succeeds",
+		},
+	}
+}
+
+func decodeRunWorkspaceConfiguration(configuration any) (RunWorkspaceConfiguration, error) {
+	spec := RunWorkspaceConfiguration{}
+	if err := mapstructure.Decode(configuration, &spec); err != nil {
+		return RunWorkspaceConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Workspace = strings.TrimSpace(spec.Workspace)
+	if spec.Workspace == "" {
+		return RunWorkspaceConfiguration{}, fmt.Errorf("workspace is required")
+	}
+
+	for i, variable := range spec.Variables {
+		if strings.TrimSpace(variable.Name) == "" {
+			return RunWorkspaceConfiguration{}, fmt.Errorf("variable %d: name is required", i)
+		}
+	}
+
+	if spec.PlanOnly && spec.AutoApply {
+		return RunWorkspaceConfiguration{}, fmt.Errorf("plan only runs cannot be auto applied")
+	}
+
+	return spec, nil
+}
+
+func (c *RunWorkspace) Setup(ctx core.SetupContext) error {
+	spec, err := decodeRunWorkspaceConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if !isResolvedValue(spec.Workspace) {
+		return fmt.Errorf("workspace must be selected")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	workspace, err := client.GetWorkspace(spec.Workspace)
+	if err != nil {
+		return fmt.Errorf("failed to find workspace %s: %w", spec.Workspace, err)
+	}
+
+	if err := ctx.Metadata.Set(RunWorkspaceNodeMetadata{WorkspaceName: workspace.Name}); err != nil {
+		return fmt.Errorf("failed to store node metadata: %w", err)
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		Workspace: spec.Workspace,
+		Triggers: []string{
+			NotificationTriggerRunCompleted,
+			NotificationTriggerRunErrored,
+			NotificationTriggerRunNeedsAttention,
+		},
+	})
+}
+
+func (c *RunWorkspace) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *RunWorkspace) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeRunWorkspaceConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	variables := make([]RunVariable, 0, len(spec.Variables))
+	for _, variable := range spec.Variables {
+		variables = append(variables, RunVariable{Key: strings.TrimSpace(variable.Name), Value: variable.Value})
+	}
+
+	run, err := client.CreateRun(CreateRunRequest{
+		WorkspaceID: spec.Workspace,
+		Message:     spec.Message,
+		IsDestroy:   spec.IsDestroy,
+		PlanOnly:    spec.PlanOnly,
+		AutoApply:   spec.AutoApply,
+		Variables:   variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create run: %w", err)
+	}
+
+	if run.ID == "" {
+		return fmt.Errorf("run response missing ID")
+	}
+
+	err = ctx.Metadata.Set(RunWorkspaceExecutionMetadata{
+		Run: &RunMetadata{
+			ID:          run.ID,
+			URL:         runURL(client, workspaceNameFromNodeMetadata(ctx.NodeMetadata, spec.Workspace), run.ID),
+			Status:      run.Status,
+			WorkspaceID: spec.Workspace,
+			CreatedAt:   run.CreatedAt,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.ExecutionState.SetKV(runWorkspaceExecutionKey, run.ID); err != nil {
+		return err
+	}
+
+	// Wait for notification; poll as fallback
+	return ctx.Requests.ScheduleActionCall(RunWorkspaceActionPoll, map[string]any{}, RunWorkspacePollInterval)
+}
+
+func (c *RunWorkspace) Actions() []core.Action {
+	commentParameter := configuration.Field{
+		Name:        "comment",
+		Label:       "Comment",
+		Type:        configuration.FieldTypeText,
+		Required:    false,
+		Description: "Optional comment added to the run",
+	}
+
+	return []core.Action{
+		{
+			Name:           RunWorkspaceActionPoll,
+			UserAccessible: false,
+			Description:    "Check the status of the run",
+		},
+		{
+			Name:           RunWorkspaceActionApply,
+			UserAccessible: true,
+			Description:    "Apply a run waiting for confirmation",
+			Parameters:     []configuration.Field{commentParameter},
+		},
+		{
+			Name:           RunWorkspaceActionOverridePolicy,
+			UserAccessible: true,
+			Description:    "Override a soft-failed policy check",
+		},
+		{
+			Name:           RunWorkspaceActionDiscard,
+			UserAccessible: true,
+			Description:    "Discard a run waiting for confirmation",
+			Parameters:     []configuration.Field{commentParameter},
+		},
+	}
+}
+
+func (c *RunWorkspace) HandleAction(ctx core.ActionContext) error {
+	switch ctx.Name {
+	case RunWorkspaceActionPoll:
+		return c.poll(ctx)
+	case RunWorkspaceActionApply, RunWorkspaceActionOverridePolicy, RunWorkspaceActionDiscard:
+		return c.act(ctx)
+	default:
+		return fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (c *RunWorkspace) poll(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := RunWorkspaceExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Run == nil || metadata.Run.ID == "" || metadata.Run.FinishedAt != "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(metadata.Run.ID)
+	if err != nil {
+		return err
+	}
+
+	finished, err := updateRunStatus(ctx.Metadata, ctx.ExecutionState, &metadata, run.Status, run.PolicyCheckID)
+	if err != nil {
+		return err
+	}
+
+	if finished {
+		return nil
+	}
+
+	return ctx.Requests.ScheduleActionCall(RunWorkspaceActionPoll, map[string]any{}, RunWorkspacePollInterval)
+}
+
+// act runs one of the user actions on a run that needs attention.
+// The result of the action is picked up by the next notification or poll.
+func (c *RunWorkspace) act(ctx core.ActionContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return fmt.Errorf("run already finished")
+	}
+
+	metadata := RunWorkspaceExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Run == nil || metadata.Run.ID == "" {
+		return fmt.Errorf("no run found for execution")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(metadata.Run.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get run: %w", err)
+	}
+
+	comment, _ := ctx.Parameters["comment"].(string)
+
+	switch ctx.Name {
+	case RunWorkspaceActionApply:
+		if !isRunAwaitingConfirmation(run.Status) {
+			return fmt.Errorf("run %s cannot be applied in status %s", run.ID, run.Status)
+		}
+
+		err = client.ApplyRun(run.ID, comment)
+
+	case RunWorkspaceActionOverridePolicy:
+		if run.Status != RunStatusPolicyOverride || run.PolicyCheckID == "" {
+			return fmt.Errorf("run %s has no policy check to override in status %s", run.ID, run.Status)
+		}
+
+		err = client.OverridePolicyCheck(run.PolicyCheckID)
+
+	case RunWorkspaceActionDiscard:
+		if !isRunAwaitingConfirmation(run.Status) && run.Status != RunStatusPolicyOverride {
+			return fmt.Errorf("run %s cannot be discarded in status %s", run.ID, run.Status)
+		}
+
+		err = client.DiscardRun(run.ID, comment)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to %s run: %w", ctx.Name, err)
+	}
+
+	return nil
+}
+
+func (c *RunWorkspace) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	if err := verifyNotificationSignature(ctx); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	payload := NotificationPayload{}
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		return errorResponse(http.StatusBadRequest, "error parsing request body: %w", err)
+	}
+
+	if payload.RunID == "" || len(payload.Notifications) == 0 || ctx.FindExecutionByKV == nil {
+		return okResponse()
+	}
+
+	executionCtx, err := ctx.FindExecutionByKV(runWorkspaceExecutionKey, payload.RunID)
+	if err != nil || executionCtx == nil {
+		return okResponse()
+	}
+
+	if executionCtx.ExecutionState.IsFinished() {
+		return okResponse()
+	}
+
+	metadata := RunWorkspaceExecutionMetadata{}
+	if err := mapstructure.Decode(executionCtx.Metadata.Get(), &metadata); err != nil {
+		return errorResponse(http.StatusInternalServerError, "error decoding metadata: %w", err)
+	}
+
+	if metadata.Run == nil {
+		metadata.Run = &RunMetadata{ID: payload.RunID, URL: payload.RunURL}
+	}
+
+	// Prefer the current run from the API, falling back to the status in the notification.
+	notification := payload.Notifications[len(payload.Notifications)-1]
+	status := notification.RunStatus
+	policyCheckID := metadata.Run.PolicyCheckID
+	if client, err := NewClient(ctx.HTTP, ctx.Integration); err == nil {
+		if run, err := client.GetRun(payload.RunID); err == nil && run.Status != "" {
+			status = run.Status
+			policyCheckID = run.PolicyCheckID
+		}
+	}
+
+	_, err = updateRunStatus(executionCtx.Metadata, executionCtx.ExecutionState, &metadata, status, policyCheckID)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, "error updating run: %w", err)
+	}
+
+	return okResponse()
+}
+
+// updateRunStatus records the latest run status on the execution,
+// and emits the result if the run finished. It returns true if the run finished.
+func updateRunStatus(
+	metadataCtx core.MetadataContext,
+	state core.ExecutionStateContext,
+	metadata *RunWorkspaceExecutionMetadata,
+	status string,
+	policyCheckID string,
+) (bool, error) {
+	if status == "" {
+		return false, nil
+	}
+
+	metadata.Run.Status = status
+	if policyCheckID != "" {
+		metadata.Run.PolicyCheckID = policyCheckID
+	}
+
+	finished := isRunFinished(status)
+	if finished {
+		metadata.Run.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if err := metadataCtx.Set(*metadata); err != nil {
+		return false, err
+	}
+
+	if !finished {
+		return false, nil
+	}
+
+	payload := map[string]any{
+		"runId":       metadata.Run.ID,
+		"url":         metadata.Run.URL,
+		"status":      metadata.Run.Status,
+		"workspaceId": metadata.Run.WorkspaceID,
+		"createdAt":   metadata.Run.CreatedAt,
+		"finishedAt":  metadata.Run.FinishedAt,
+	}
+
+	channel := RunWorkspaceFailedOutputChannel
+	if isRunSuccessful(status) {
+		channel = RunWorkspaceSuccessOutputChannel
+	}
+
+	return true, state.Emit(channel, RunWorkspacePayloadType, []any{payload})
+}
+
+func (c *RunWorkspace) Cancel(ctx core.ExecutionContext) error {
+	metadata := RunWorkspaceExecutionMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return nil
+	}
+
+	if metadata.Run == nil || metadata.Run.ID == "" || isRunFinished(metadata.Run.Status) {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil
+	}
+
+	//
+	// Runs waiting for confirmation can only be discarded.
+	//
+	if isRunAwaitingConfirmation(metadata.Run.Status) || metadata.Run.Status == RunStatusPolicyOverride {
+		_ = client.DiscardRun(metadata.Run.ID, "Cancelled from SuperPlane")
+		return nil
+	}
+
+	_ = client.CancelRun(metadata.Run.ID, "Cancelled from SuperPlane")
+	return nil
+}
+
+func (c *RunWorkspace) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func runURL(client *Client, workspaceName, runID string) string {
+	return fmt.Sprintf("%s/app/%s/workspaces/%s/runs/%s", client.Address, client.Organization, workspaceName, runID)
+}
+
+// workspaceNameFromNodeMetadata returns the workspace name resolved during setup,
+// since the Terraform Cloud UI addresses workspaces by name.
+func workspaceNameFromNodeMetadata(nodeMetadata core.MetadataContext, workspaceID string) string {
+	if nodeMetadata == nil {
+		return workspaceID
+	}
+
+	metadata := RunWorkspaceNodeMetadata{}
+	if err := mapstructure.Decode(nodeMetadata.Get(), &metadata); err != nil || metadata.WorkspaceName == "" {
+		return workspaceID
+	}
+
+	return metadata.WorkspaceName
+}
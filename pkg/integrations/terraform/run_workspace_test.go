@@ -0,0 +1,378 @@
+package terraform
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func runResponse(status string) *http.Response {
+	return jsonResponse(http.StatusOK, `{"data":{"id":"run-1","type":"runs","attributes":{"status":"`+status+`","created-at":"2026-01-15T10:00:00Z"},"relationships":{"workspace":{"data":{"id":"ws-1","type":"workspaces"}},"policy-checks":{"data":[{"id":"polchk-1","type":"policy-checks"}]}}}}`)
+}
+
+func waitingRunMetadata(status string) *contexts.MetadataContext {
+	return &contexts.MetadataContext{
+		Metadata: map[string]any{
+			"run": map[string]any{
+				"id":          "run-1",
+				"status":      status,
+				"workspaceId": "ws-1",
+				"createdAt":   "2026-01-15T10:00:00Z",
+			},
+		},
+	}
+}
+
+func Test__Terraform_RunWorkspace__Setup(t *testing.T) {
+	component := &RunWorkspace{}
+
+	t.Run("missing workspace -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   testIntegrationContext(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{},
+		})
+
+		require.ErrorContains(t, err, "workspace is required")
+	})
+
+	t.Run("plan only with auto apply -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   testIntegrationContext(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"workspace": "ws-1", "planOnly": true, "autoApply": true},
+		})
+
+		require.ErrorContains(t, err, "plan only runs cannot be auto applied")
+	})
+
+	t.Run("valid configuration -> stores workspace name and requests webhook", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"id":"ws-1","type":"workspaces","attributes":{"name":"production"}}}`),
+			},
+		}
+
+		integrationCtx := testIntegrationContext()
+		metadataCtx := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+			Metadata:      metadataCtx,
+			Configuration: map[string]any{"workspace": "ws-1"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, RunWorkspaceNodeMetadata{WorkspaceName: "production"}, metadataCtx.Metadata)
+		require.Len(t, integrationCtx.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{
+			Workspace: "ws-1",
+			Triggers: []string{
+				NotificationTriggerRunCompleted,
+				NotificationTriggerRunErrored,
+				NotificationTriggerRunNeedsAttention,
+			},
+		}, integrationCtx.WebhookRequests[0])
+	})
+}
+
+func Test__Terraform_RunWorkspace__Execute(t *testing.T) {
+	component := &RunWorkspace{}
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusCreated, `{"data":{"id":"run-1","type":"runs","attributes":{"status":"pending","created-at":"2026-01-15T10:00:00Z"}}}`),
+		},
+	}
+
+	metadataCtx := &contexts.MetadataContext{}
+	executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+	requests := &contexts.RequestContext{}
+
+	err := component.Execute(core.ExecutionContext{
+		HTTP:           httpCtx,
+		Integration:    testIntegrationContext(),
+		Metadata:       metadataCtx,
+		NodeMetadata:   &contexts.MetadataContext{Metadata: map[string]any{"workspaceName": "production"}},
+		ExecutionState: executionState,
+		Requests:       requests,
+		Configuration: map[string]any{
+			"workspace": "ws-1",
+			"message":   "Provision preview",
+			"autoApply": true,
+			"variables": []any{
+				map[string]any{"name": "region", "value": `"us-east-1"`},
+			},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", executionState.KVs["run_id"])
+	assert.Equal(t, RunWorkspaceActionPoll, requests.Action)
+	assert.Equal(t, RunWorkspacePollInterval, requests.Duration)
+
+	metadata, ok := metadataCtx.Metadata.(RunWorkspaceExecutionMetadata)
+	require.True(t, ok)
+	assert.Equal(t, "run-1", metadata.Run.ID)
+	assert.Equal(t, "pending", metadata.Run.Status)
+	assert.Equal(t, "https://app.terraform.io/app/my-organization/workspaces/production/runs/run-1", metadata.Run.URL)
+
+	require.Len(t, httpCtx.Requests, 1)
+	assert.Equal(t, "/api/v2/runs", httpCtx.Requests[0].URL.Path)
+	assert.Equal(t, "application/vnd.api+json", httpCtx.Requests[0].Header.Get("Content-Type"))
+
+	body := map[string]any{}
+	require.NoError(t, json.NewDecoder(httpCtx.Requests[0].Body).Decode(&body))
+	data := readMap(body["data"])
+	attributes := readMap(data["attributes"])
+	assert.Equal(t, "Provision preview", attributes["message"])
+	assert.Equal(t, true, attributes["auto-apply"])
+	assert.Equal(t, []any{map[string]any{"key": "region", "value": `"us-east-1"`}}, attributes["variables"])
+	workspace := readMap(readMap(readMap(data["relationships"])["workspace"])["data"])
+	assert.Equal(t, "ws-1", workspace["id"])
+}
+
+func Test__Terraform_RunWorkspace__HandleWebhook(t *testing.T) {
+	component := &RunWorkspace{}
+	secret := "notification-token"
+
+	notification := func(trigger, status string) []byte {
+		body, err := json.Marshal(map[string]any{
+			"payload_version": 1,
+			"run_id":          "run-1",
+			"workspace_id":    "ws-1",
+			"notifications": []any{
+				map[string]any{"trigger": trigger, "run_status": status},
+			},
+		})
+		require.NoError(t, err)
+		return body
+	}
+
+	t.Run("invalid signature -> 403", func(t *testing.T) {
+		body := notification(NotificationTriggerRunCompleted, RunStatusApplied)
+		status, _, err := component.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: signedHeaders("other-token", body),
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+		})
+
+		assert.Equal(t, http.StatusForbidden, status)
+		require.Error(t, err)
+	})
+
+	t.Run("run applied -> emits to success channel", func(t *testing.T) {
+		body := notification(NotificationTriggerRunCompleted, RunStatusApplied)
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		metadataCtx := waitingRunMetadata(RunStatusPending)
+
+		status, _, err := component.HandleWebhook(core.WebhookRequestContext{
+			Body:        body,
+			Headers:     signedHeaders(secret, body),
+			Webhook:     &contexts.NodeWebhookContext{Secret: secret},
+			HTTP:        &contexts.HTTPContext{Responses: []*http.Response{runResponse(RunStatusApplied)}},
+			Integration: testIntegrationContext(),
+			FindExecutionByKV: func(key, value string) (*core.ExecutionContext, error) {
+				if key == "run_id" && value == "run-1" {
+					return &core.ExecutionContext{Metadata: metadataCtx, ExecutionState: executionState}, nil
+				}
+				return nil, assert.AnError
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, RunWorkspaceSuccessOutputChannel, executionState.Channel)
+		assert.Equal(t, RunWorkspacePayloadType, executionState.Type)
+		require.Len(t, executionState.Payloads, 1)
+		data := readMap(readMap(executionState.Payloads[0])["data"])
+		assert.Equal(t, "run-1", data["runId"])
+		assert.Equal(t, RunStatusApplied, data["status"])
+	})
+
+	t.Run("run errored and API unavailable -> uses notification status", func(t *testing.T) {
+		body := notification(NotificationTriggerRunErrored, RunStatusErrored)
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		metadataCtx := waitingRunMetadata(RunStatusPending)
+
+		status, _, err := component.HandleWebhook(core.WebhookRequestContext{
+			Body:        body,
+			Headers:     signedHeaders(secret, body),
+			Webhook:     &contexts.NodeWebhookContext{Secret: secret},
+			HTTP:        &contexts.HTTPContext{},
+			Integration: testIntegrationContext(),
+			FindExecutionByKV: func(key, value string) (*core.ExecutionContext, error) {
+				return &core.ExecutionContext{Metadata: metadataCtx, ExecutionState: executionState}, nil
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, RunWorkspaceFailedOutputChannel, executionState.Channel)
+	})
+
+	t.Run("run needs attention -> keeps waiting and records policy check", func(t *testing.T) {
+		body := notification(NotificationTriggerRunNeedsAttention, RunStatusPolicyOverride)
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		metadataCtx := waitingRunMetadata(RunStatusPending)
+
+		status, _, err := component.HandleWebhook(core.WebhookRequestContext{
+			Body:        body,
+			Headers:     signedHeaders(secret, body),
+			Webhook:     &contexts.NodeWebhookContext{Secret: secret},
+			HTTP:        &contexts.HTTPContext{Responses: []*http.Response{runResponse(RunStatusPolicyOverride)}},
+			Integration: testIntegrationContext(),
+			FindExecutionByKV: func(key, value string) (*core.ExecutionContext, error) {
+				return &core.ExecutionContext{Metadata: metadataCtx, ExecutionState: executionState}, nil
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.False(t, executionState.Finished)
+		assert.Empty(t, executionState.Payloads)
+
+		metadata, ok := metadataCtx.Metadata.(RunWorkspaceExecutionMetadata)
+		require.True(t, ok)
+		assert.Equal(t, RunStatusPolicyOverride, metadata.Run.Status)
+		assert.Equal(t, "polchk-1", metadata.Run.PolicyCheckID)
+	})
+}
+
+func Test__Terraform_RunWorkspace__Poll(t *testing.T) {
+	component := &RunWorkspace{}
+
+	t.Run("run still planning -> schedules another poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.HandleAction(core.ActionContext{
+			Name:           RunWorkspaceActionPoll,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{runResponse("planning")}},
+			Integration:    testIntegrationContext(),
+			Metadata:       waitingRunMetadata(RunStatusPending),
+			ExecutionState: executionState,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, RunWorkspaceActionPoll, requests.Action)
+		assert.Empty(t, executionState.Payloads)
+	})
+
+	t.Run("run finished without changes -> emits to success channel", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+
+		err := component.HandleAction(core.ActionContext{
+			Name:           RunWorkspaceActionPoll,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{runResponse(RunStatusPlannedAndFinished)}},
+			Integration:    testIntegrationContext(),
+			Metadata:       waitingRunMetadata(RunStatusPending),
+			ExecutionState: executionState,
+			Requests:       requests,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, requests.Action)
+		assert.Equal(t, RunWorkspaceSuccessOutputChannel, executionState.Channel)
+	})
+}
+
+func Test__Terraform_RunWorkspace__UserActions(t *testing.T) {
+	component := &RunWorkspace{}
+
+	t.Run("apply run waiting for confirmation", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runResponse(RunStatusPlanned),
+				jsonResponse(http.StatusAccepted, ``),
+			},
+		}
+
+		err := component.HandleAction(core.ActionContext{
+			Name:           RunWorkspaceActionApply,
+			Parameters:     map[string]any{"comment": "Looks good"},
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       waitingRunMetadata(RunStatusPlanned),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "/api/v2/runs/run-1/actions/apply", httpCtx.Requests[1].URL.Path)
+
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[1].Body).Decode(&body))
+		assert.Equal(t, "Looks good", body["comment"])
+	})
+
+	t.Run("apply run still planning -> error", func(t *testing.T) {
+		err := component.HandleAction(core.ActionContext{
+			Name:           RunWorkspaceActionApply,
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{runResponse("planning")}},
+			Integration:    testIntegrationContext(),
+			Metadata:       waitingRunMetadata(RunStatusPending),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "cannot be applied")
+	})
+
+	t.Run("override soft-failed policy check", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				runResponse(RunStatusPolicyOverride),
+				jsonResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		err := component.HandleAction(core.ActionContext{
+			Name:           RunWorkspaceActionOverridePolicy,
+			HTTP:           httpCtx,
+			Integration:    testIntegrationContext(),
+			Metadata:       waitingRunMetadata(RunStatusPolicyOverride),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "/api/v2/policy-checks/polchk-1/actions/override", httpCtx.Requests[1].URL.Path)
+	})
+}
+
+func Test__Terraform_RunWorkspace__Cancel(t *testing.T) {
+	component := &RunWorkspace{}
+
+	t.Run("running run is cancelled", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusAccepted, ``)}}
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Metadata:    waitingRunMetadata("applying"),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "/api/v2/runs/run-1/actions/cancel", httpCtx.Requests[0].URL.Path)
+	})
+
+	t.Run("run waiting for confirmation is discarded", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusAccepted, ``)}}
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Metadata:    waitingRunMetadata(RunStatusPlanned),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "/api/v2/runs/run-1/actions/discard", httpCtx.Requests[0].URL.Path)
+	})
+}
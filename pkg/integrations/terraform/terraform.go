@@ -0,0 +1,203 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	ResourceTypeWorkspace = "workspace"
+	ResourceTypeVariable  = "variable"
+)
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("terraform", &Terraform{}, &TerraformWebhookHandler{})
+}
+
+type Terraform struct{}
+
+type Configuration struct {
+	Address      string `json:"address" mapstructure:"address"`
+	APIToken     string `json:"apiToken" mapstructure:"apiToken"`
+	Organization string `json:"organization" mapstructure:"organization"`
+}
+
+type Metadata struct {
+	Organization string `json:"organization" mapstructure:"organization"`
+}
+
+func (t *Terraform) Name() string {
+	return "terraform"
+}
+
+func (t *Terraform) Label() string {
+	return "Terraform Cloud"
+}
+
+func (t *Terraform) Icon() string {
+	return "layers"
+}
+
+func (t *Terraform) Description() string {
+	return "Queue runs and react to run events in Terraform Cloud workspaces"
+}
+
+func (t *Terraform) Instructions() string {
+	return `
+1. **Address:** Leave the default for Terraform Cloud, or use the URL of your Terraform Enterprise instance.
+2. **API Token:** Create a team token in **Organization Settings → API Tokens**, or a user token in **User Settings → Tokens**.
+   - The token needs permission to queue and apply runs and to manage notifications on the workspaces you use.
+3. **Organization:** The name of the Terraform Cloud organization.
+4. **Notifications:** SuperPlane creates workspace notifications automatically to receive run events. No manual setup is required.`
+}
+
+func (t *Terraform) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "address",
+			Label:       "Address",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     DefaultAddress,
+			Description: "Terraform Cloud or Terraform Enterprise URL",
+		},
+		{
+			Name:        "apiToken",
+			Label:       "API Token",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Terraform Cloud team or user API token",
+		},
+		{
+			Name:        "organization",
+			Label:       "Organization",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "my-organization",
+			Description: "Terraform Cloud organization name",
+		},
+	}
+}
+
+func (t *Terraform) Components() []core.Component {
+	return []core.Component{
+		&RunWorkspace{},
+	}
+}
+
+func (t *Terraform) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnRunCompleted{},
+	}
+}
+
+func (t *Terraform) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (t *Terraform) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.APIToken == "" {
+		return fmt.Errorf("apiToken is required")
+	}
+
+	if config.Organization == "" {
+		return fmt.Errorf("organization is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	organization, err := client.GetOrganization()
+	if err != nil {
+		return fmt.Errorf("failed to verify Terraform Cloud credentials: %w", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{Organization: organization.Name})
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (t *Terraform) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (t *Terraform) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resourceType {
+	case ResourceTypeWorkspace:
+		return listWorkspaceResources(client)
+	case ResourceTypeVariable:
+		return listVariableResources(client, ctx.Parameters)
+	default:
+		return []core.IntegrationResource{}, nil
+	}
+}
+
+func (t *Terraform) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *Terraform) HandleAction(ctx core.IntegrationActionContext) error {
+	return nil
+}
+
+func listWorkspaceResources(client *Client) ([]core.IntegrationResource, error) {
+	workspaces, err := client.ListWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		if workspace.ID == "" || workspace.Name == "" {
+			continue
+		}
+
+		resources = append(resources, core.IntegrationResource{Type: ResourceTypeWorkspace, Name: workspace.Name, ID: workspace.ID})
+	}
+
+	return resources, nil
+}
+
+func listVariableResources(client *Client, parameters map[string]string) ([]core.IntegrationResource, error) {
+	workspaceID := parameters["workspace"]
+	if !isResolvedValue(workspaceID) {
+		return []core.IntegrationResource{}, nil
+	}
+
+	variables, err := client.ListVariables(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(variables))
+	for _, variable := range variables {
+		//
+		// Only Terraform variables can be set on a run.
+		// Environment variables are not listed.
+		//
+		if variable.Key == "" || variable.Category != "terraform" {
+			continue
+		}
+
+		resources = append(resources, core.IntegrationResource{Type: ResourceTypeVariable, Name: variable.Key, ID: variable.Key})
+	}
+
+	return resources, nil
+}
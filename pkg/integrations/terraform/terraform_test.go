@@ -0,0 +1,333 @@
+package terraform
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+// integrationWebhookContext implements core.IntegrationWebhookContext for testing
+// the TerraformWebhookHandler Setup/Cleanup flow.
+type integrationWebhookContext struct {
+	id            string
+	url           string
+	configuration any
+	metadata      any
+	secret        []byte
+}
+
+func (w *integrationWebhookContext) GetID() string              { return w.id }
+func (w *integrationWebhookContext) GetURL() string             { return w.url }
+func (w *integrationWebhookContext) GetSecret() ([]byte, error) { return w.secret, nil }
+func (w *integrationWebhookContext) GetMetadata() any           { return w.metadata }
+func (w *integrationWebhookContext) GetConfiguration() any      { return w.configuration }
+func (w *integrationWebhookContext) SetSecret(secret []byte) error {
+	w.secret = secret
+	return nil
+}
+
+func testIntegrationContext() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"apiToken":     "token-123",
+			"organization": "my-organization",
+		},
+	}
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func signedHeaders(secret string, body []byte) http.Header {
+	h := hmac.New(sha512.New, []byte(secret))
+	h.Write(body)
+
+	headers := http.Header{}
+	headers.Set("X-TFE-Notification-Signature", hex.EncodeToString(h.Sum(nil)))
+	return headers
+}
+
+func Test__Terraform__Sync(t *testing.T) {
+	integration := &Terraform{}
+
+	t.Run("valid credentials -> ready", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"id":"my-organization","type":"organizations","attributes":{"name":"my-organization","email":"ops@example.com"}}}`),
+			},
+		}
+
+		integrationCtx := testIntegrationContext()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, Metadata{Organization: "my-organization"}, integrationCtx.Metadata)
+
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://app.terraform.io/api/v2/organizations/my-organization", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "Bearer token-123", httpCtx.Requests[0].Header.Get("Authorization"))
+	})
+
+	t.Run("custom address is used", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"id":"my-organization","attributes":{"name":"my-organization"}}}`),
+			},
+		}
+
+		integrationCtx := testIntegrationContext()
+		integrationCtx.Configuration["address"] = "https://tfe.example.com/"
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "tfe.example.com", httpCtx.Requests[0].URL.Host)
+	})
+
+	t.Run("invalid credentials -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusUnauthorized, `{"errors":[{"status":"401","title":"unauthorized"}]}`),
+			},
+		}
+
+		integrationCtx := testIntegrationContext()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "failed to verify Terraform Cloud credentials")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("missing organization -> error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiToken": "token-123"},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "organization is required")
+	})
+}
+
+func Test__Terraform__ListResources(t *testing.T) {
+	integration := &Terraform{}
+
+	t.Run("lists workspaces across pages", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"ws-1","type":"workspaces","attributes":{"name":"production"}}],"meta":{"pagination":{"next-page":2}}}`),
+				jsonResponse(http.StatusOK, `{"data":[{"id":"ws-2","type":"workspaces","attributes":{"name":"staging"}}],"meta":{"pagination":{"next-page":null}}}`),
+			},
+		}
+
+		resources, err := integration.ListResources(ResourceTypeWorkspace, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationResource{
+			{Type: ResourceTypeWorkspace, Name: "production", ID: "ws-1"},
+			{Type: ResourceTypeWorkspace, Name: "staging", ID: "ws-2"},
+		}, resources)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "/api/v2/organizations/my-organization/workspaces", httpCtx.Requests[0].URL.Path)
+		assert.Equal(t, "2", httpCtx.Requests[1].URL.Query().Get("page[number]"))
+	})
+
+	t.Run("lists terraform variables of the workspace", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[
+					{"id":"var-1","type":"vars","attributes":{"key":"region","value":"us-east-1","category":"terraform"}},
+					{"id":"var-2","type":"vars","attributes":{"key":"AWS_ACCESS_KEY_ID","category":"env","sensitive":true}}
+				]}`),
+			},
+		}
+
+		resources, err := integration.ListResources(ResourceTypeVariable, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Parameters:  map[string]string{"workspace": "ws-1"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationResource{
+			{Type: ResourceTypeVariable, Name: "region", ID: "region"},
+		}, resources)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "/api/v2/workspaces/ws-1/vars", httpCtx.Requests[0].URL.Path)
+	})
+
+	t.Run("variables without workspace -> empty", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		resources, err := integration.ListResources(ResourceTypeVariable, core.ListResourcesContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Parameters:  map[string]string{},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Empty(t, httpCtx.Requests)
+	})
+}
+
+func Test__Terraform__CompareWebhookConfig(t *testing.T) {
+	handler := &TerraformWebhookHandler{}
+
+	equal, err := handler.CompareConfig(
+		WebhookConfiguration{Workspace: "ws-1", Triggers: []string{NotificationTriggerRunCompleted}},
+		WebhookConfiguration{Workspace: "ws-1", Triggers: []string{NotificationTriggerRunErrored}},
+	)
+	require.NoError(t, err)
+	assert.True(t, equal)
+
+	equal, err = handler.CompareConfig(
+		WebhookConfiguration{Workspace: "ws-1"},
+		WebhookConfiguration{Workspace: "ws-2"},
+	)
+	require.NoError(t, err)
+	assert.False(t, equal)
+}
+
+func Test__Terraform__MergeWebhookConfig(t *testing.T) {
+	handler := &TerraformWebhookHandler{}
+
+	t.Run("new triggers -> changed", func(t *testing.T) {
+		merged, changed, err := handler.Merge(
+			WebhookConfiguration{Workspace: "ws-1", Triggers: []string{NotificationTriggerRunCompleted}},
+			WebhookConfiguration{Workspace: "ws-1", Triggers: []string{NotificationTriggerRunErrored, NotificationTriggerRunCompleted}},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, WebhookConfiguration{
+			Workspace: "ws-1",
+			Triggers:  []string{NotificationTriggerRunCompleted, NotificationTriggerRunErrored},
+		}, merged)
+	})
+
+	t.Run("same triggers -> unchanged", func(t *testing.T) {
+		_, changed, err := handler.Merge(
+			WebhookConfiguration{Workspace: "ws-1", Triggers: []string{NotificationTriggerRunErrored, NotificationTriggerRunCompleted}},
+			WebhookConfiguration{Workspace: "ws-1", Triggers: []string{NotificationTriggerRunCompleted}},
+		)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+}
+
+func Test__Terraform__SetupWebhook(t *testing.T) {
+	handler := &TerraformWebhookHandler{}
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusCreated, `{"data":{"id":"nc-123","type":"notification-configurations","attributes":{"name":"SuperPlane-wh-1","enabled":true,"triggers":["run:completed"]}}}`),
+		},
+	}
+
+	webhookCtx := &integrationWebhookContext{
+		id:  "wh-1",
+		url: "https://superplane.example.com/webhooks/wh-1",
+		configuration: map[string]any{
+			"workspace": "ws-1",
+			"triggers":  []string{NotificationTriggerRunCompleted},
+		},
+	}
+
+	metadata, err := handler.Setup(core.WebhookHandlerContext{
+		HTTP:        httpCtx,
+		Integration: testIntegrationContext(),
+		Webhook:     webhookCtx,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, WebhookMetadata{NotificationConfigurationID: "nc-123", WorkspaceID: "ws-1"}, metadata)
+	assert.Len(t, webhookCtx.secret, 64)
+
+	require.Len(t, httpCtx.Requests, 1)
+	request := httpCtx.Requests[0]
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, "/api/v2/workspaces/ws-1/notification-configurations", request.URL.Path)
+
+	body := map[string]any{}
+	require.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+	attributes := readMap(readMap(body["data"])["attributes"])
+	assert.Equal(t, "generic", attributes["destination-type"])
+	assert.Equal(t, "https://superplane.example.com/webhooks/wh-1", attributes["url"])
+	assert.Equal(t, string(webhookCtx.secret), attributes["token"])
+	assert.Equal(t, []any{"run:completed"}, attributes["triggers"])
+}
+
+func Test__Terraform__CleanupWebhook(t *testing.T) {
+	handler := &TerraformWebhookHandler{}
+
+	t.Run("deletes notification configuration", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusNoContent, ``)},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Webhook: &integrationWebhookContext{
+				metadata: map[string]any{"notificationConfigurationId": "nc-123", "workspaceId": "ws-1"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, http.MethodDelete, httpCtx.Requests[0].Method)
+		assert.Equal(t, "/api/v2/notification-configurations/nc-123", httpCtx.Requests[0].URL.Path)
+	})
+
+	t.Run("already deleted -> no error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusNotFound, `{}`)},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: testIntegrationContext(),
+			Webhook: &integrationWebhookContext{
+				metadata: map[string]any{"notificationConfigurationId": "nc-123"},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,186 @@
+package terraform
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const notificationNamePrefix = "SuperPlane"
+
+type TerraformWebhookHandler struct{}
+
+type WebhookMetadata struct {
+	NotificationConfigurationID string `json:"notificationConfigurationId" mapstructure:"notificationConfigurationId"`
+	WorkspaceID                 string `json:"workspaceId" mapstructure:"workspaceId"`
+}
+
+// WebhookConfiguration describes a notification configuration on a workspace.
+// Notification configurations are per workspace, so one webhook is shared
+// by all the nodes using the same workspace.
+type WebhookConfiguration struct {
+	Workspace string   `json:"workspace" mapstructure:"workspace"`
+	Triggers  []string `json:"triggers" mapstructure:"triggers"`
+}
+
+// NotificationPayload is the payload sent by Terraform Cloud generic notifications.
+type NotificationPayload struct {
+	PayloadVersion              int            `json:"payload_version"`
+	NotificationConfigurationID string         `json:"notification_configuration_id"`
+	RunURL                      string         `json:"run_url"`
+	RunID                       string         `json:"run_id"`
+	RunMessage                  string         `json:"run_message"`
+	RunCreatedAt                string         `json:"run_created_at"`
+	RunCreatedBy                string         `json:"run_created_by"`
+	WorkspaceID                 string         `json:"workspace_id"`
+	WorkspaceName               string         `json:"workspace_name"`
+	OrganizationName            string         `json:"organization_name"`
+	Notifications               []Notification `json:"notifications"`
+}
+
+type Notification struct {
+	Message      string `json:"message"`
+	Trigger      string `json:"trigger"`
+	RunStatus    string `json:"run_status"`
+	RunUpdatedAt string `json:"run_updated_at"`
+	RunUpdatedBy string `json:"run_updated_by"`
+}
+
+func (h *TerraformWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	configA, err := decodeWebhookConfiguration(a)
+	if err != nil {
+		return false, err
+	}
+
+	configB, err := decodeWebhookConfiguration(b)
+	if err != nil {
+		return false, err
+	}
+
+	return configA.Workspace == configB.Workspace, nil
+}
+
+func (h *TerraformWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	currentConfig, err := decodeWebhookConfiguration(current)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode current webhook configuration: %w", err)
+	}
+
+	requestedConfig, err := decodeWebhookConfiguration(requested)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode requested webhook configuration: %w", err)
+	}
+
+	merged := WebhookConfiguration{
+		Workspace: currentConfig.Workspace,
+		Triggers:  normalizeTriggers(append(currentConfig.Triggers, requestedConfig.Triggers...)),
+	}
+
+	return merged, !slices.Equal(currentConfig.Triggers, merged.Triggers), nil
+}
+
+func (h *TerraformWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookURL := ctx.Webhook.GetURL()
+	if webhookURL == "" {
+		return nil, fmt.Errorf("webhook URL is required")
+	}
+
+	webhookConfig, err := decodeWebhookConfiguration(ctx.Webhook.GetConfiguration())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode webhook configuration: %w", err)
+	}
+
+	if webhookConfig.Workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	if len(webhookConfig.Triggers) == 0 {
+		return nil, fmt.Errorf("at least one trigger is required")
+	}
+
+	// The token is used by Terraform Cloud to sign the notification payloads.
+	token, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	notificationConfiguration, err := client.CreateNotificationConfiguration(
+		webhookConfig.Workspace,
+		fmt.Sprintf("%s-%s", notificationNamePrefix, ctx.Webhook.GetID()),
+		webhookURL,
+		token,
+		webhookConfig.Triggers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Terraform notification configuration: %w", err)
+	}
+
+	if err := ctx.Webhook.SetSecret([]byte(token)); err != nil {
+		return nil, fmt.Errorf("failed to store webhook secret: %w", err)
+	}
+
+	return WebhookMetadata{
+		NotificationConfigurationID: notificationConfiguration.ID,
+		WorkspaceID:                 webhookConfig.Workspace,
+	}, nil
+}
+
+func (h *TerraformWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode webhook metadata: %w", err)
+	}
+
+	if metadata.NotificationConfigurationID == "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteNotificationConfiguration(metadata.NotificationConfigurationID)
+	if err == nil {
+		return nil
+	}
+
+	apiErr, ok := err.(*APIError)
+	if ok && apiErr.StatusCode == 404 {
+		return nil
+	}
+
+	return err
+}
+
+func decodeWebhookConfiguration(configuration any) (WebhookConfiguration, error) {
+	webhookConfig := WebhookConfiguration{}
+	if configuration == nil {
+		return webhookConfig, nil
+	}
+
+	if err := mapstructure.Decode(configuration, &webhookConfig); err != nil {
+		return WebhookConfiguration{}, err
+	}
+
+	webhookConfig.Triggers = normalizeTriggers(webhookConfig.Triggers)
+	return webhookConfig, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
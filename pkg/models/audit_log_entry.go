@@ -0,0 +1,123 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	AuditActionNodeCreated              = "node.created"
+	AuditActionNodeConfigurationUpdated = "node.configuration.updated"
+	AuditActionNodeDeleted              = "node.deleted"
+	AuditActionExecutionActionInvoked   = "execution.action.invoked"
+	AuditActionTriggerActionInvoked     = "trigger.action.invoked"
+	AuditActionSecretAccessed           = "secret.accessed"
+	AuditActionCanvasTestModeUpdated    = "canvas.test_mode.updated"
+	AuditActionCanvasParametersUpdated  = "canvas.parameters.updated"
+	AuditActionCanvasBackfillStarted    = "canvas.backfill.started"
+	AuditActionCanvasBackfillCancelled  = "canvas.backfill.cancelled"
+)
+
+//
+// AuditLogEntry records who changed the nodes of a canvas, its test mode or its parameters,
+// who invoked manual actions on nodes, who started and cancelled backfills,
+// and which secrets were accessed by executions.
+//
+// UserID is empty for entries recorded by the system, e.g. secret accesses by executions.
+// Details never include secret values, or node configuration values,
+// only the names of what was accessed or changed.
+//
+
+type AuditLogEntry struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null"`
+	UserID         *uuid.UUID
+	WorkflowID     *uuid.UUID
+	NodeID         *string
+	ExecutionID    *uuid.UUID
+	Action         string
+	Details        datatypes.JSONType[map[string]any]
+	CreatedAt      *time.Time
+}
+
+func (e *AuditLogEntry) TableName() string {
+	return "audit_log_entries"
+}
+
+type AuditLogFilters struct {
+	CanvasID    *uuid.UUID
+	NodeID      string
+	ExecutionID *uuid.UUID
+	UserID      *uuid.UUID
+	Action      string
+
+	// Only entries older than the entry with this ID are listed, if set.
+	BeforeID int64
+}
+
+func CreateAuditLogEntry(entry *AuditLogEntry) error {
+	return CreateAuditLogEntryInTransaction(database.Conn(), entry)
+}
+
+func CreateAuditLogEntryInTransaction(tx *gorm.DB, entry *AuditLogEntry) error {
+	now := time.Now()
+	entry.CreatedAt = &now
+
+	if entry.Details.Data() == nil {
+		entry.Details = datatypes.NewJSONType(map[string]any{})
+	}
+
+	return tx.Create(entry).Error
+}
+
+/*
+ * Lists the entries of an organization, most recent first.
+ * Clients page through older entries by passing the ID of the last entry received as BeforeID.
+ */
+func ListAuditLogEntries(organizationID uuid.UUID, filters AuditLogFilters, limit int) ([]AuditLogEntry, error) {
+	return ListAuditLogEntriesInTransaction(database.Conn(), organizationID, filters, limit)
+}
+
+func ListAuditLogEntriesInTransaction(tx *gorm.DB, organizationID uuid.UUID, filters AuditLogFilters, limit int) ([]AuditLogEntry, error) {
+	var entries []AuditLogEntry
+
+	query := tx.
+		Where("organization_id = ?", organizationID).
+		Order("id DESC").
+		Limit(limit)
+
+	if filters.CanvasID != nil {
+		query = query.Where("workflow_id = ?", *filters.CanvasID)
+	}
+
+	if filters.NodeID != "" {
+		query = query.Where("node_id = ?", filters.NodeID)
+	}
+
+	if filters.ExecutionID != nil {
+		query = query.Where("execution_id = ?", *filters.ExecutionID)
+	}
+
+	if filters.UserID != nil {
+		query = query.Where("user_id = ?", *filters.UserID)
+	}
+
+	if filters.Action != "" {
+		query = query.Where("action = ?", filters.Action)
+	}
+
+	if filters.BeforeID > 0 {
+		query = query.Where("id < ?", filters.BeforeID)
+	}
+
+	err := query.Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
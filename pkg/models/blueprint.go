@@ -9,6 +9,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/database"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Blueprint struct {
@@ -100,6 +101,25 @@ func FindBlueprintByName(name string, orgID uuid.UUID) (*Blueprint, error) {
 	return &blueprint, nil
 }
 
+/*
+ * LockBlueprintsUsingComponentsInTransaction locks the blueprints
+ * with nodes using any of the components, e.g. to upgrade their configuration.
+ */
+func LockBlueprintsUsingComponentsInTransaction(tx *gorm.DB, components []string) ([]Blueprint, error) {
+	var blueprints []Blueprint
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("EXISTS (SELECT 1 FROM jsonb_array_elements(nodes) AS n WHERE n->'ref'->'component'->>'name' IN ?)", components).
+		Find(&blueprints).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return blueprints, nil
+}
+
 func FindUnscopedBlueprint(id string) (*Blueprint, error) {
 	return FindUnscopedBlueprintInTransaction(database.Conn(), id)
 }
@@ -146,6 +166,13 @@ type NodeRef struct {
 
 type ComponentRef struct {
 	Name string `json:"name"`
+
+	//
+	// Version of the component configuration fields the node configuration uses.
+	// 0 for nodes created before the component declared a version.
+	// See core.VersionedConfiguration.
+	//
+	ConfigurationVersion int `json:"configurationVersion,omitempty"`
 }
 
 type TriggerRef struct {
@@ -18,6 +18,9 @@ type Canvas struct {
 	IsTemplate              bool
 	CanvasVersioningEnabled bool
 	ChangeRequestApprovers  datatypes.JSONSlice[CanvasChangeRequestApprover]
+	TestMode                bool
+	Parameters              datatypes.JSONSlice[CanvasParameter]
+	Environment             string
 	Name                    string
 	Description             string
 	CreatedBy               *uuid.UUID
@@ -125,6 +128,11 @@ func (c *Canvas) SoftDeleteInTransaction(tx *gorm.DB) error {
 	}).Error
 }
 
+func (c *Canvas) UpdateTestModeInTransaction(tx *gorm.DB, enabled bool) error {
+	c.TestMode = enabled
+	return tx.Model(c).Update("test_mode", enabled).Error
+}
+
 func FindCanvas(orgID, id uuid.UUID) (*Canvas, error) {
 	return FindCanvasInTransaction(database.Conn(), orgID, id)
 }
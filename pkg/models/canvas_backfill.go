@@ -0,0 +1,194 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CanvasBackfillStatePending   = "pending"
+	CanvasBackfillStateRunning   = "running"
+	CanvasBackfillStateCompleted = "completed"
+	CanvasBackfillStateCancelled = "cancelled"
+)
+
+//
+// CanvasBackfill replays the events emitted by a trigger node
+// between Since and Until, in the order they were emitted.
+//
+// Without a TargetNodeID, each event is emitted again by the trigger,
+// so it goes through the whole canvas. With one, only the target node
+// runs again, with the input it received in the run of the event.
+//
+// LastEventAt and LastEventID are the position of the last event replayed,
+// and NextRunAt is when the next batch of events can be replayed,
+// so no more than RatePerMinute events are replayed per minute.
+//
+
+type CanvasBackfill struct {
+	ID            uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	WorkflowID    uuid.UUID
+	TriggerNodeID string
+	TargetNodeID  *string
+	Since         time.Time
+	Until         time.Time
+	RatePerMinute int
+	State         string
+	LastEventAt   *time.Time
+	LastEventID   *uuid.UUID
+	ReplayedCount int
+	SkippedCount  int
+	NextRunAt     time.Time
+	CreatedBy     *uuid.UUID
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+}
+
+func (b *CanvasBackfill) TableName() string {
+	return "workflow_backfills"
+}
+
+func (b *CanvasBackfill) IsFinished() bool {
+	return b.State == CanvasBackfillStateCompleted || b.State == CanvasBackfillStateCancelled
+}
+
+func CreateCanvasBackfillInTransaction(tx *gorm.DB, backfill *CanvasBackfill) error {
+	return tx.Create(backfill).Error
+}
+
+func FindCanvasBackfill(canvasID uuid.UUID, id uuid.UUID) (*CanvasBackfill, error) {
+	return FindCanvasBackfillInTransaction(database.Conn(), canvasID, id)
+}
+
+func FindCanvasBackfillInTransaction(tx *gorm.DB, canvasID uuid.UUID, id uuid.UUID) (*CanvasBackfill, error) {
+	var backfill CanvasBackfill
+	err := tx.
+		Where("workflow_id = ?", canvasID).
+		Where("id = ?", id).
+		First(&backfill).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &backfill, nil
+}
+
+func ListCanvasBackfills(canvasID uuid.UUID) ([]CanvasBackfill, error) {
+	var backfills []CanvasBackfill
+	err := database.Conn().
+		Where("workflow_id = ?", canvasID).
+		Order("created_at DESC").
+		Find(&backfills).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return backfills, nil
+}
+
+/*
+ * Lists the backfills with events ready to be replayed.
+ */
+func ListDueCanvasBackfills() ([]CanvasBackfill, error) {
+	var backfills []CanvasBackfill
+	err := database.Conn().
+		Joins("JOIN workflows ON workflow_backfills.workflow_id = workflows.id").
+		Where("workflow_backfills.state IN ?", []string{CanvasBackfillStatePending, CanvasBackfillStateRunning}).
+		Where("workflow_backfills.next_run_at <= ?", time.Now()).
+		Where("workflows.deleted_at IS NULL").
+		Find(&backfills).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return backfills, nil
+}
+
+func LockDueCanvasBackfill(tx *gorm.DB, id uuid.UUID) (*CanvasBackfill, error) {
+	var backfill CanvasBackfill
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("id = ?", id).
+		Where("state IN ?", []string{CanvasBackfillStatePending, CanvasBackfillStateRunning}).
+		Where("next_run_at <= ?", time.Now()).
+		First(&backfill).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &backfill, nil
+}
+
+/*
+ * Lists the next events to replay, the root events emitted by the trigger
+ * in the range of the backfill, after the last event replayed.
+ * Events emitted at the same time are ordered by ID, so none is replayed twice.
+ */
+func (b *CanvasBackfill) ListNextEvents(tx *gorm.DB, limit int) ([]CanvasEvent, error) {
+	var events []CanvasEvent
+
+	query := tx.
+		Where("workflow_id = ?", b.WorkflowID).
+		Where("node_id = ?", b.TriggerNodeID).
+		Where("execution_id IS NULL").
+		Where("created_at >= ?", b.Since).
+		Where("created_at <= ?", b.Until)
+
+	if b.LastEventAt != nil && b.LastEventID != nil {
+		query = query.Where("(created_at, id) > (?, ?)", *b.LastEventAt, *b.LastEventID)
+	}
+
+	err := query.
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&events).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (b *CanvasBackfill) CancelInTransaction(tx *gorm.DB) error {
+	now := time.Now()
+	b.State = CanvasBackfillStateCancelled
+	b.UpdatedAt = &now
+	return tx.Save(b).Error
+}
+
+/*
+ * Finds the first event a node emitted on a channel, in the run started by a root event.
+ */
+func FindRunEventEmittedByNode(tx *gorm.DB, canvasID uuid.UUID, rootEventID uuid.UUID, nodeID string, channel string) (*CanvasEvent, error) {
+	var event CanvasEvent
+	err := tx.
+		Joins("JOIN workflow_node_executions ON workflow_events.execution_id = workflow_node_executions.id").
+		Where("workflow_node_executions.workflow_id = ?", canvasID).
+		Where("workflow_node_executions.root_event_id = ?", rootEventID).
+		Where("workflow_node_executions.node_id = ?", nodeID).
+		Where("workflow_events.channel = ?", channel).
+		Order("workflow_events.created_at ASC").
+		First(&event).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
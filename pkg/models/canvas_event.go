@@ -25,6 +25,12 @@ type CanvasEvent struct {
 	ExecutionID *uuid.UUID
 	State       string
 	CreatedAt   *time.Time
+
+	//
+	// IDs of the canvases that called the canvas of this event,
+	// outermost first. Only set on root events of runs started by another canvas.
+	//
+	CallChain datatypes.JSONSlice[string]
 }
 
 func (e *CanvasEvent) TableName() string {
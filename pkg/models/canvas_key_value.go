@@ -0,0 +1,122 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var ErrCanvasKeyValueNotNumber = errors.New("value is not a number")
+
+type CanvasKeyValue struct {
+	CanvasID  uuid.UUID `gorm:"primaryKey"`
+	Key       string    `gorm:"primaryKey"`
+	Value     datatypes.JSONType[any]
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (CanvasKeyValue) TableName() string {
+	return "canvas_key_values"
+}
+
+func FindCanvasKeyValueInTransaction(tx *gorm.DB, canvasID uuid.UUID, key string) (*CanvasKeyValue, error) {
+	var record CanvasKeyValue
+	err := tx.
+		Where("canvas_id = ? AND key = ?", canvasID, key).
+		First(&record).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func FindCanvasKeyValue(canvasID uuid.UUID, key string) (*CanvasKeyValue, error) {
+	return FindCanvasKeyValueInTransaction(database.Conn(), canvasID, key)
+}
+
+// SetCanvasKeyValueInTransaction stores the value under the key,
+// replacing any existing value.
+func SetCanvasKeyValueInTransaction(tx *gorm.DB, canvasID uuid.UUID, key string, value any) error {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(
+		`INSERT INTO canvas_key_values (canvas_id, key, value, created_at, updated_at)
+		VALUES (?, ?, ?::jsonb, NOW(), NOW())
+		ON CONFLICT (canvas_id, key)
+		DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`,
+		canvasID,
+		key,
+		valueJSON,
+	).Error
+}
+
+// SetCanvasKeyValueIfMissingInTransaction stores the value under the key
+// only if the key does not exist yet. Returns whether the value was stored.
+func SetCanvasKeyValueIfMissingInTransaction(tx *gorm.DB, canvasID uuid.UUID, key string, value any) (bool, error) {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	result := tx.Exec(
+		`INSERT INTO canvas_key_values (canvas_id, key, value, created_at, updated_at)
+		VALUES (?, ?, ?::jsonb, NOW(), NOW())
+		ON CONFLICT (canvas_id, key) DO NOTHING`,
+		canvasID,
+		key,
+		valueJSON,
+	)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// IncrementCanvasKeyValueInTransaction atomically adds the amount to the
+// numeric value stored under the key, starting from zero if the key
+// does not exist yet, and returns the new value.
+func IncrementCanvasKeyValueInTransaction(tx *gorm.DB, canvasID uuid.UUID, key string, amount float64) (float64, error) {
+	var record CanvasKeyValue
+	result := tx.Raw(
+		`INSERT INTO canvas_key_values (canvas_id, key, value, created_at, updated_at)
+		VALUES (?, ?, to_jsonb(?::numeric), NOW(), NOW())
+		ON CONFLICT (canvas_id, key)
+		DO UPDATE SET
+			value = to_jsonb((canvas_key_values.value #>> '{}')::numeric + ?::numeric),
+			updated_at = NOW()
+		WHERE jsonb_typeof(canvas_key_values.value) = 'number'
+		RETURNING *`,
+		canvasID,
+		key,
+		amount,
+		amount,
+	).Scan(&record)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	//
+	// No rows are returned when the existing value is not a number.
+	//
+	value, ok := record.Value.Data().(float64)
+	if result.RowsAffected == 0 || !ok {
+		return 0, ErrCanvasKeyValueNotNumber
+	}
+
+	return value, nil
+}
@@ -189,6 +189,30 @@ func ListCanvasNodesReady() ([]CanvasNode, error) {
 		Where("workflow_nodes.state = ?", CanvasNodeStateReady).
 		Where("workflow_nodes.type IN ?", []string{NodeTypeComponent, NodeTypeBlueprint}).
 		Where("workflows.deleted_at IS NULL").
+		Where(
+			"workflow_nodes.app_installation_id IS NULL OR workflow_nodes.app_installation_id NOT IN (SELECT id FROM app_installations WHERE state = ?)",
+			IntegrationStateDegraded,
+		).
+		Find(&nodes).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+/*
+ * LockCanvasNodesUsingComponentsInTransaction locks the nodes using any of the components,
+ * e.g. to upgrade their configuration.
+ */
+func LockCanvasNodesUsingComponentsInTransaction(tx *gorm.DB, components []string) ([]CanvasNode, error) {
+	var nodes []CanvasNode
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("type = ?", NodeTypeComponent).
+		Where("ref->'component'->>'name' IN ?", components).
 		Find(&nodes).
 		Error
 
@@ -249,13 +273,17 @@ func LockCanvasNodeForUpdate(tx *gorm.DB, workflowID uuid.UUID, nodeId string) (
 	return &node, nil
 }
 
-func ResumeStateForNodeInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string) (string, error) {
-	runningCount, err := CountRunningExecutionsForNodeInTransaction(tx, workflowID, nodeID)
+/*
+ * The node resumes processing its queue only if
+ * less than maxInFlight executions are still in flight.
+ */
+func ResumeStateForNodeInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string, maxInFlight int) (string, error) {
+	inFlight, err := CountInFlightExecutionsForNodeInTransaction(tx, workflowID, nodeID)
 	if err != nil {
 		return "", err
 	}
 
-	if runningCount > 0 {
+	if inFlight >= int64(max(maxInFlight, 1)) {
 		return CanvasNodeStateProcessing, nil
 	}
 
@@ -269,6 +297,36 @@ func (c *CanvasNode) UpdateState(tx *gorm.DB, state string) error {
 		Error
 }
 
+func (c *CanvasNode) LastQueueItem(tx *gorm.DB) (*CanvasNodeQueueItem, error) {
+	var queueItem CanvasNodeQueueItem
+	err := tx.
+		Where("workflow_id = ?", c.WorkflowID).
+		Where("node_id = ?", c.NodeID).
+		Order("created_at DESC").
+		First(&queueItem).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &queueItem, nil
+}
+
+/*
+ * Deletes all the queue items of the node, except the given one.
+ * Returns how many queue items were deleted.
+ */
+func (c *CanvasNode) DeleteOtherQueueItems(tx *gorm.DB, keep *CanvasNodeQueueItem) (int64, error) {
+	result := tx.
+		Where("workflow_id = ?", c.WorkflowID).
+		Where("node_id = ?", c.NodeID).
+		Where("id <> ?", keep.ID).
+		Delete(&CanvasNodeQueueItem{})
+
+	return result.RowsAffected, result.Error
+}
+
 func (c *CanvasNode) FirstQueueItem(tx *gorm.DB) (*CanvasNodeQueueItem, error) {
 	var queueItem CanvasNodeQueueItem
 	err := tx.
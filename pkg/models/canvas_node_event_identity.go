@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/gorm"
+)
+
+/*
+ * CanvasNodeEventIdentity records the identity of an event emitted by a trigger node,
+ * so redeliveries of the same event are discarded until the identity expires.
+ */
+type CanvasNodeEventIdentity struct {
+	WorkflowID uuid.UUID `gorm:"primaryKey"`
+	NodeID     string    `gorm:"primaryKey"`
+	Identity   string    `gorm:"primaryKey"`
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+}
+
+func (i *CanvasNodeEventIdentity) TableName() string {
+	return "workflow_node_event_identities"
+}
+
+/*
+ * Claims the event identity for the node for the given window.
+ * Returns false if the identity was already claimed, and did not expire yet.
+ *
+ * Expired identities are claimed again in place,
+ * so concurrent deliveries of the same event are claimed only once.
+ */
+func ClaimEventIdentityInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string, identity string, window time.Duration) (bool, error) {
+	now := time.Now()
+	result := tx.Exec(`
+		INSERT INTO workflow_node_event_identities (workflow_id, node_id, identity, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (workflow_id, node_id, identity) DO UPDATE
+		SET expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+		WHERE workflow_node_event_identities.expires_at <= EXCLUDED.created_at
+	`, workflowID, nodeID, identity, now.Add(window), now)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func DeleteExpiredEventIdentities(now time.Time, limit int) (int64, error) {
+	result := database.Conn().Exec(`
+		DELETE FROM workflow_node_event_identities
+		WHERE ctid IN (
+			SELECT ctid FROM workflow_node_event_identities
+			WHERE expires_at <= ?
+			LIMIT ?
+		)
+	`, now, limit)
+
+	return result.RowsAffected, result.Error
+}
@@ -1,11 +1,13 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/database"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -24,6 +26,7 @@ const (
 	CanvasNodeExecutionResultReasonOk            = "ok"
 	CanvasNodeExecutionResultReasonError         = "error"
 	CanvasNodeExecutionResultReasonErrorResolved = "error_resolved"
+	CanvasNodeExecutionResultReasonTimeout       = "timeout"
 )
 
 type CanvasNodeExecution struct {
@@ -231,6 +234,42 @@ func CountRunningExecutionsForNodeInTransaction(tx *gorm.DB, workflowID uuid.UUI
 	return runningCount, nil
 }
 
+/*
+ * Counts the executions of the node which were created but did not finish yet.
+ */
+func CountInFlightExecutionsForNodeInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string) (int64, error) {
+	var count int64
+	err := tx.
+		Model(&CanvasNodeExecution{}).
+		Where("workflow_id = ?", workflowID).
+		Where("node_id = ?", nodeID).
+		Where("state IN ?", []string{CanvasNodeExecutionStatePending, CanvasNodeExecutionStateStarted}).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func CountFailedExecutionsForRootEventInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string, rootEventID uuid.UUID) (int64, error) {
+	var failedCount int64
+	err := tx.
+		Model(&CanvasNodeExecution{}).
+		Where("workflow_id = ?", workflowID).
+		Where("node_id = ?", nodeID).
+		Where("root_event_id = ?", rootEventID).
+		Where("result = ?", CanvasNodeExecutionResultFailed).
+		Count(&failedCount).
+		Error
+	if err != nil {
+		return 0, err
+	}
+
+	return failedCount, nil
+}
+
 func FindNodeExecution(workflowID, id uuid.UUID) (*CanvasNodeExecution, error) {
 	return FindNodeExecutionInTransaction(database.Conn(), workflowID, id)
 }
@@ -445,6 +484,11 @@ func (e *CanvasNodeExecution) PassInTransaction(tx *gorm.DB, channelOutputs map[
 		return nil, err
 	}
 
+	err = DeleteNodeExecutionCheckpointInTransaction(tx, e.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return events, nil
 }
 
@@ -455,6 +499,38 @@ func (e *CanvasNodeExecution) Fail(reason, message string) error {
 }
 
 func (e *CanvasNodeExecution) FailInTransaction(tx *gorm.DB, reason, message string) error {
+	_, err := e.FailWithEventsInTransaction(tx, reason, message)
+	return err
+}
+
+/*
+ * FailWithEventsInTransaction fails the execution, and returns
+ * the events emitted on the error channel, if the node routes its errors.
+ */
+func (e *CanvasNodeExecution) FailWithEventsInTransaction(tx *gorm.DB, reason, message string) ([]CanvasEvent, error) {
+	return e.failInTransaction(tx, reason, message, nil)
+}
+
+/*
+ * TimeOutInTransaction fails an execution that did not finish before its deadline.
+ * If the node routes its errors, the event on the error channel
+ * includes the execution timeout and the elapsed time, in seconds.
+ */
+func (e *CanvasNodeExecution) TimeOutInTransaction(tx *gorm.DB, timeout, elapsed time.Duration) ([]CanvasEvent, error) {
+	return e.failInTransaction(
+		tx,
+		CanvasNodeExecutionResultReasonTimeout,
+		fmt.Sprintf("execution timed out after %s", timeout),
+		&executionTimeoutDetails{Timeout: timeout, Elapsed: elapsed},
+	)
+}
+
+type executionTimeoutDetails struct {
+	Timeout time.Duration
+	Elapsed time.Duration
+}
+
+func (e *CanvasNodeExecution) failInTransaction(tx *gorm.DB, reason, message string, timeout *executionTimeoutDetails) ([]CanvasEvent, error) {
 	now := time.Now()
 
 	err := tx.Model(e).
@@ -467,7 +543,12 @@ func (e *CanvasNodeExecution) FailInTransaction(tx *gorm.DB, reason, message str
 		}).Error
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = DeleteNodeExecutionCheckpointInTransaction(tx, e.ID)
+	if err != nil {
+		return nil, err
 	}
 
 	//
@@ -475,14 +556,14 @@ func (e *CanvasNodeExecution) FailInTransaction(tx *gorm.DB, reason, message str
 	//
 	node, err := FindCanvasNode(tx, e.WorkflowID, e.NodeID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return err
+		return nil, err
 	}
 
 	if node != nil {
 		if node.State != CanvasNodeStatePaused {
 			err := node.UpdateState(tx, CanvasNodeStateReady)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
@@ -491,17 +572,91 @@ func (e *CanvasNodeExecution) FailInTransaction(tx *gorm.DB, reason, message str
 	// Since an execution failure does not emit anything,
 	// we need to update the parent execution here too,
 	// if this execution is a child one.
+	// Failures of child executions are not routed,
+	// since the parent execution failure is.
 	//
 	if e.ParentExecutionID != nil {
 		parent, err := FindNodeExecution(e.WorkflowID, *e.ParentExecutionID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return parent.FailInTransaction(tx, reason, message)
+		return parent.failInTransaction(tx, reason, message, timeout)
 	}
 
-	return nil
+	if node == nil || !core.RouteErrorsEnabled(e.Configuration.Data()) {
+		return []CanvasEvent{}, nil
+	}
+
+	event, err := e.routeErrorInTransaction(tx, node, reason, message, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return []CanvasEvent{*event}, nil
+}
+
+/*
+ * routeErrorInTransaction emits an event describing the failure on the error channel.
+ * The execution remains failed, but the event is routed like any other
+ * output of the node, so the failure can be handled by remediation nodes.
+ */
+func (e *CanvasNodeExecution) routeErrorInTransaction(tx *gorm.DB, node *CanvasNode, reason, message string, timeout *executionTimeoutDetails) (*CanvasEvent, error) {
+	attempt, err := CountFailedExecutionsForRootEventInTransaction(tx, e.WorkflowID, e.NodeID, e.RootEventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failed executions: %w", err)
+	}
+
+	component := ""
+	ref := node.Ref.Data()
+	if ref.Component != nil {
+		component = ref.Component.Name
+	}
+
+	payloadType := core.ErrorPayloadType
+	details := map[string]any{
+		"error":       message,
+		"reason":      reason,
+		"component":   component,
+		"nodeId":      node.NodeID,
+		"nodeName":    node.Name,
+		"executionId": e.ID.String(),
+		"attempt":     attempt,
+	}
+
+	if timeout != nil {
+		payloadType = core.TimeoutPayloadType
+		details["timeout"] = timeout.Timeout.Seconds()
+		details["elapsed"] = timeout.Elapsed.Seconds()
+	}
+
+	now := time.Now()
+	payload := map[string]any{
+		"type":      payloadType,
+		"timestamp": now,
+		"data":      details,
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal error payload: %w", err)
+	}
+
+	event := CanvasEvent{
+		WorkflowID:  e.WorkflowID,
+		NodeID:      e.NodeID,
+		Channel:     core.ErrorOutputChannel.Name,
+		Data:        datatypes.NewJSONType[any](json.RawMessage(data)),
+		ExecutionID: &e.ID,
+		State:       CanvasEventStatePending,
+		CreatedAt:   &now,
+	}
+
+	if err := tx.Create(&event).Error; err != nil {
+		return nil, fmt.Errorf("failed to create error event: %w", err)
+	}
+
+	return &event, nil
 }
 
 func (e *CanvasNodeExecution) Cancel(cancelledBy *uuid.UUID) error {
@@ -523,6 +678,11 @@ func (e *CanvasNodeExecution) CancelInTransaction(tx *gorm.DB, cancelledBy *uuid
 		return err
 	}
 
+	err = DeleteNodeExecutionCheckpointInTransaction(tx, e.ID)
+	if err != nil {
+		return err
+	}
+
 	node, err := FindCanvasNode(tx, e.WorkflowID, e.NodeID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//
+// CanvasNodeExecutionCheckpoint is the step an execution was on
+// when it started waiting on something outside SuperPlane,
+// e.g. the cloud operation creating a VM.
+//
+// Checkpoints are written with their own connection, so they are kept
+// if the process stops while the execution is running, and the execution
+// transaction is rolled back. The executor then resumes the execution from it.
+//
+
+type CanvasNodeExecutionCheckpoint struct {
+	ExecutionID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	WorkflowID  uuid.UUID `gorm:"type:uuid;not null"`
+	NodeID      string    `gorm:"type:varchar(128);not null"`
+	Step        string
+	Operation   string
+	Target      datatypes.JSONType[map[string]any]
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
+
+func (c *CanvasNodeExecutionCheckpoint) TableName() string {
+	return "workflow_node_execution_checkpoints"
+}
+
+/*
+ * SaveNodeExecutionCheckpoint creates or replaces the checkpoint of an execution.
+ * An execution only has one checkpoint, the last step it reached.
+ */
+func SaveNodeExecutionCheckpoint(checkpoint *CanvasNodeExecutionCheckpoint) error {
+	now := time.Now()
+	checkpoint.CreatedAt = &now
+	checkpoint.UpdatedAt = &now
+
+	return database.Conn().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "execution_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"step", "operation", "target", "updated_at"}),
+		}).
+		Create(checkpoint).
+		Error
+}
+
+func FindNodeExecutionCheckpointInTransaction(tx *gorm.DB, executionID uuid.UUID) (*CanvasNodeExecutionCheckpoint, error) {
+	var checkpoint CanvasNodeExecutionCheckpoint
+	err := tx.
+		Where("execution_id = ?", executionID).
+		First(&checkpoint).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+/*
+ * DeleteNodeExecutionCheckpointInTransaction removes the checkpoint of an execution.
+ * It is called when the execution finishes, is cancelled, or after Execute() returns.
+ */
+func DeleteNodeExecutionCheckpointInTransaction(tx *gorm.DB, executionID uuid.UUID) error {
+	return tx.
+		Where("execution_id = ?", executionID).
+		Delete(&CanvasNodeExecutionCheckpoint{}).
+		Error
+}
+
+/*
+ * ListPendingCheckpointedNodeExecutions lists the pending executions with a checkpoint,
+ * i.e. the ones that were running when the process stopped.
+ */
+func ListPendingCheckpointedNodeExecutions() ([]CanvasNodeExecution, error) {
+	var executions []CanvasNodeExecution
+	err := database.Conn().
+		Joins("JOIN workflow_node_execution_checkpoints AS c ON c.execution_id = workflow_node_executions.id").
+		Where("workflow_node_executions.state = ?", CanvasNodeExecutionStatePending).
+		Order("workflow_node_executions.created_at ASC").
+		Find(&executions).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return executions, nil
+}
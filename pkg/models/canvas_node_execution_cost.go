@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/gorm"
+)
+
+//
+// CanvasNodeExecutionCost is a cost hint reported by a component
+// for an execution, e.g. the monthly estimate of a VM it created.
+//
+// Like logs, costs are written with their own connection,
+// since the cost was incurred even if the execution transaction is rolled back.
+//
+
+type CanvasNodeExecutionCost struct {
+	ID          uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	WorkflowID  uuid.UUID `gorm:"type:uuid;not null"`
+	NodeID      string    `gorm:"type:varchar(128);not null"`
+	ExecutionID uuid.UUID `gorm:"type:uuid;not null"`
+	Amount      float64
+	Unit        string
+	Description *string
+	CreatedAt   *time.Time
+}
+
+func (c *CanvasNodeExecutionCost) TableName() string {
+	return "workflow_node_execution_costs"
+}
+
+/*
+ * CostTotal is the sum of the costs reported by a node with the same unit,
+ * and the number of executions that reported them.
+ */
+type CostTotal struct {
+	NodeID     string
+	Unit       string
+	Amount     float64
+	Executions int
+}
+
+func CreateNodeExecutionCost(workflowID uuid.UUID, nodeID string, executionID uuid.UUID, amount float64, unit string, description *string) error {
+	now := time.Now()
+	cost := CanvasNodeExecutionCost{
+		WorkflowID:  workflowID,
+		NodeID:      nodeID,
+		ExecutionID: executionID,
+		Amount:      amount,
+		Unit:        unit,
+		Description: description,
+		CreatedAt:   &now,
+	}
+
+	return database.Conn().Create(&cost).Error
+}
+
+func ListNodeExecutionCosts(executionID uuid.UUID) ([]CanvasNodeExecutionCost, error) {
+	return ListNodeExecutionCostsInTransaction(database.Conn(), executionID)
+}
+
+func ListNodeExecutionCostsInTransaction(tx *gorm.DB, executionID uuid.UUID) ([]CanvasNodeExecutionCost, error) {
+	var costs []CanvasNodeExecutionCost
+
+	err := tx.
+		Where("execution_id = ?", executionID).
+		Order("created_at ASC").
+		Find(&costs).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return costs, nil
+}
+
+/*
+ * Sums the costs reported for a canvas between since and until, by node and unit.
+ */
+func SumCanvasCostsByNode(workflowID uuid.UUID, since, until time.Time) ([]CostTotal, error) {
+	var totals []CostTotal
+
+	err := database.Conn().
+		Model(&CanvasNodeExecutionCost{}).
+		Select("node_id, unit, SUM(amount) AS amount, COUNT(DISTINCT execution_id) AS executions").
+		Where("workflow_id = ?", workflowID).
+		Where("created_at >= ?", since).
+		Where("created_at < ?", until).
+		Group("node_id, unit").
+		Order("node_id, unit").
+		Scan(&totals).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/gorm"
+)
+
+//
+// CanvasNodeExecutionLog is a line of output streamed by a component
+// while an execution is running, e.g. the output of a remote command.
+//
+// Logs are written with their own connection, and not with the transaction
+// running the execution, so they are visible while the execution is running,
+// and are kept even if that transaction is rolled back.
+//
+
+type CanvasNodeExecutionLog struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement"`
+	WorkflowID  uuid.UUID `gorm:"type:uuid;not null"`
+	NodeID      string    `gorm:"type:varchar(128);not null"`
+	ExecutionID uuid.UUID `gorm:"type:uuid;not null"`
+	Message     string    `gorm:"type:text;not null"`
+	CreatedAt   *time.Time
+}
+
+func (l *CanvasNodeExecutionLog) TableName() string {
+	return "workflow_node_execution_logs"
+}
+
+func CreateNodeExecutionLogs(workflowID uuid.UUID, nodeID string, executionID uuid.UUID, messages []string) error {
+	return CreateNodeExecutionLogsInTransaction(database.Conn(), workflowID, nodeID, executionID, messages)
+}
+
+func CreateNodeExecutionLogsInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string, executionID uuid.UUID, messages []string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	logs := make([]CanvasNodeExecutionLog, 0, len(messages))
+	for _, message := range messages {
+		logs = append(logs, CanvasNodeExecutionLog{
+			WorkflowID:  workflowID,
+			NodeID:      nodeID,
+			ExecutionID: executionID,
+			Message:     message,
+			CreatedAt:   &now,
+		})
+	}
+
+	return tx.Create(&logs).Error
+}
+
+/*
+ * Lists the logs of an execution written after the log with ID afterID,
+ * so clients following an execution only fetch new lines.
+ */
+func ListNodeExecutionLogs(executionID uuid.UUID, afterID int64, limit int) ([]CanvasNodeExecutionLog, error) {
+	return ListNodeExecutionLogsInTransaction(database.Conn(), executionID, afterID, limit)
+}
+
+func ListNodeExecutionLogsInTransaction(tx *gorm.DB, executionID uuid.UUID, afterID int64, limit int) ([]CanvasNodeExecutionLog, error) {
+	var logs []CanvasNodeExecutionLog
+
+	err := tx.
+		Where("execution_id = ?", executionID).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&logs).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
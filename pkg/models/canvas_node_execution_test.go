@@ -0,0 +1,136 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func Test__CanvasNodeExecution_FailInTransaction(t *testing.T) {
+	require.NoError(t, database.TruncateTables())
+
+	steps := CanvasNodeExecutionKVTestSteps{t: t}
+	steps.CreateCanvas()
+	steps.CreateCanvasNode()
+	steps.CreateEvent()
+
+	createExecution := func(configuration map[string]any, parent *CanvasNodeExecution) *CanvasNodeExecution {
+		execution := &CanvasNodeExecution{
+			WorkflowID:    steps.wf.ID,
+			NodeID:        steps.node.NodeID,
+			RootEventID:   steps.rootEvent.ID,
+			EventID:       steps.rootEvent.ID,
+			Configuration: datatypes.NewJSONType(configuration),
+		}
+
+		if parent != nil {
+			execution.ParentExecutionID = &parent.ID
+		}
+
+		require.NoError(t, database.Conn().Create(execution).Error)
+		return execution
+	}
+
+	t.Run("failure is not routed when the node does not opt in", func(t *testing.T) {
+		tx := database.Conn().Begin()
+		defer tx.Rollback()
+
+		execution := createExecution(map[string]any{}, nil)
+		events, err := execution.FailWithEventsInTransaction(tx, CanvasNodeExecutionResultReasonError, "boom")
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("failure is routed to the error channel", func(t *testing.T) {
+		tx := database.Conn().Begin()
+		defer tx.Rollback()
+
+		execution := createExecution(map[string]any{core.RouteErrorsField: true}, nil)
+		require.NoError(t, execution.FailInTransaction(tx, CanvasNodeExecutionResultReasonError, "integration not found"))
+
+		outputs, err := execution.GetOutputsInTransaction(tx)
+		require.NoError(t, err)
+		require.Len(t, outputs, 1)
+		assert.Equal(t, core.ErrorOutputChannel.Name, outputs[0].Channel)
+
+		event := outputs[0].Data.Data().(map[string]any)
+		assert.Equal(t, core.ErrorPayloadType, event["type"])
+		data := event["data"].(map[string]any)
+		assert.Equal(t, "integration not found", data["error"])
+		assert.Equal(t, float64(1), data["attempt"])
+	})
+
+	t.Run("child failure is routed on the parent execution", func(t *testing.T) {
+		tx := database.Conn().Begin()
+		defer tx.Rollback()
+
+		parent := createExecution(map[string]any{core.RouteErrorsField: true}, nil)
+		child := createExecution(map[string]any{core.RouteErrorsField: true}, parent)
+
+		events, err := child.FailWithEventsInTransaction(tx, CanvasNodeExecutionResultReasonError, "boom")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, parent.ID, *events[0].ExecutionID)
+
+		childOutputs, err := child.GetOutputsInTransaction(tx)
+		require.NoError(t, err)
+		assert.Empty(t, childOutputs)
+	})
+}
+
+func Test__CanvasNodeExecution_FinishDeletesCheckpoint(t *testing.T) {
+	require.NoError(t, database.TruncateTables())
+
+	steps := CanvasNodeExecutionKVTestSteps{t: t}
+	steps.CreateCanvas()
+	steps.CreateCanvasNode()
+	steps.CreateEvent()
+
+	createCheckpointedExecution := func() *CanvasNodeExecution {
+		execution := &CanvasNodeExecution{
+			WorkflowID:  steps.wf.ID,
+			NodeID:      steps.node.NodeID,
+			RootEventID: steps.rootEvent.ID,
+			EventID:     steps.rootEvent.ID,
+		}
+
+		require.NoError(t, database.Conn().Create(execution).Error)
+		require.NoError(t, SaveNodeExecutionCheckpoint(&CanvasNodeExecutionCheckpoint{
+			ExecutionID: execution.ID,
+			WorkflowID:  execution.WorkflowID,
+			NodeID:      execution.NodeID,
+			Step:        "waitForOperation",
+		}))
+
+		return execution
+	}
+
+	assertNoCheckpoint := func(execution *CanvasNodeExecution) {
+		_, err := FindNodeExecutionCheckpointInTransaction(database.Conn(), execution.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	}
+
+	t.Run("passed execution -> checkpoint deleted", func(t *testing.T) {
+		execution := createCheckpointedExecution()
+		_, err := execution.PassInTransaction(database.Conn(), map[string][]any{})
+		require.NoError(t, err)
+		assertNoCheckpoint(execution)
+	})
+
+	t.Run("failed execution -> checkpoint deleted", func(t *testing.T) {
+		execution := createCheckpointedExecution()
+		require.NoError(t, execution.FailInTransaction(database.Conn(), CanvasNodeExecutionResultReasonError, "boom"))
+		assertNoCheckpoint(execution)
+	})
+
+	t.Run("cancelled execution -> checkpoint deleted", func(t *testing.T) {
+		execution := createCheckpointedExecution()
+		require.NoError(t, execution.CancelInTransaction(database.Conn(), nil))
+		assertNoCheckpoint(execution)
+	})
+}
@@ -11,7 +11,8 @@ import (
 )
 
 const (
-	NodeRequestTypeInvokeAction = "invoke-action"
+	NodeRequestTypeInvokeAction     = "invoke-action"
+	NodeRequestTypeExecutionTimeout = "execution-timeout"
 
 	NodeExecutionRequestStatePending   = "pending"
 	NodeExecutionRequestStateCompleted = "completed"
@@ -26,6 +27,8 @@ type CanvasNodeRequest struct {
 	Type        string
 	Spec        datatypes.JSONType[NodeExecutionRequestSpec]
 	RunAt       time.Time
+	Attempts    int
+	LastError   *string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -98,6 +101,63 @@ func FindPendingRequestForNode(tx *gorm.DB, workflowID uuid.UUID, nodeID string)
 	return &request, nil
 }
 
+func FindPendingExecutionRequest(tx *gorm.DB, executionID uuid.UUID, reqType string) (*CanvasNodeRequest, error) {
+	var request CanvasNodeRequest
+
+	err := tx.
+		Where("execution_id = ?", executionID).
+		Where("type = ?", reqType).
+		Where("state = ?", NodeExecutionRequestStatePending).
+		Order("run_at ASC").
+		First(&request).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+/*
+ * Lists the pending requests of an execution, in the order they will run.
+ */
+func ListPendingExecutionRequests(workflowID uuid.UUID, executionID uuid.UUID) ([]CanvasNodeRequest, error) {
+	var requests []CanvasNodeRequest
+
+	err := database.Conn().
+		Where("workflow_id = ?", workflowID).
+		Where("execution_id = ?", executionID).
+		Where("state = ?", NodeExecutionRequestStatePending).
+		Order("run_at ASC").
+		Find(&requests).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+/*
+ * Records a failed attempt to process a pending request,
+ * and reschedules it to run again at retryAt.
+ */
+func RecordNodeRequestFailure(id uuid.UUID, cause error, retryAt time.Time) error {
+	return database.Conn().
+		Model(&CanvasNodeRequest{}).
+		Where("id = ?", id).
+		Where("state = ?", NodeExecutionRequestStatePending).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": cause.Error(),
+			"run_at":     retryAt,
+			"updated_at": time.Now(),
+		}).
+		Error
+}
+
 func (r *CanvasNodeRequest) Complete(tx *gorm.DB) error {
 	return tx.Model(r).
 		Update("state", NodeExecutionRequestStateCompleted).
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//
+// CanvasNodeWebhookSecret is the webhook secret of a single node.
+// Secrets are encrypted, with the workflow and node IDs as associated data.
+//
+// When the secret is rotated, the previous one is kept
+// until PreviousSecretExpiresAt, so requests signed with it
+// are still accepted while the external system is updated.
+//
+
+type CanvasNodeWebhookSecret struct {
+	WorkflowID              uuid.UUID `gorm:"primaryKey;type:uuid"`
+	NodeID                  string    `gorm:"primaryKey;type:varchar(128)"`
+	Secret                  []byte
+	PreviousSecret          []byte
+	PreviousSecretExpiresAt *time.Time
+	CreatedAt               *time.Time
+	UpdatedAt               *time.Time
+}
+
+func (s *CanvasNodeWebhookSecret) TableName() string {
+	return "workflow_node_webhook_secrets"
+}
+
+/*
+ * HasValidPreviousSecret returns true if the previous secret
+ * is still accepted at the given time.
+ */
+func (s *CanvasNodeWebhookSecret) HasValidPreviousSecret(now time.Time) bool {
+	return len(s.PreviousSecret) > 0 &&
+		s.PreviousSecretExpiresAt != nil &&
+		now.Before(*s.PreviousSecretExpiresAt)
+}
+
+func FindNodeWebhookSecretInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string) (*CanvasNodeWebhookSecret, error) {
+	var secret CanvasNodeWebhookSecret
+	err := tx.
+		Where("workflow_id = ?", workflowID).
+		Where("node_id = ?", nodeID).
+		First(&secret).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+/*
+ * CreateNodeWebhookSecretInTransaction creates the first secret of a node.
+ * If the node got one in the meantime, that one is kept, and returned.
+ */
+func CreateNodeWebhookSecretInTransaction(tx *gorm.DB, workflowID uuid.UUID, nodeID string, secret []byte) (*CanvasNodeWebhookSecret, error) {
+	now := time.Now()
+	nodeSecret := CanvasNodeWebhookSecret{
+		WorkflowID: workflowID,
+		NodeID:     nodeID,
+		Secret:     secret,
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+	}
+
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&nodeSecret).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return FindNodeWebhookSecretInTransaction(tx, workflowID, nodeID)
+}
+
+/*
+ * RotateInTransaction replaces the secret, and keeps the current one
+ * as the previous secret until previousExpiresAt.
+ */
+func (s *CanvasNodeWebhookSecret) RotateInTransaction(tx *gorm.DB, secret []byte, previousExpiresAt time.Time) error {
+	now := time.Now()
+	s.PreviousSecret = s.Secret
+	s.PreviousSecretExpiresAt = &previousExpiresAt
+	s.Secret = secret
+	s.UpdatedAt = &now
+
+	return tx.Model(s).
+		Updates(map[string]any{
+			"secret":                     s.Secret,
+			"previous_secret":            s.PreviousSecret,
+			"previous_secret_expires_at": s.PreviousSecretExpiresAt,
+			"updated_at":                 s.UpdatedAt,
+		}).
+		Error
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	CanvasParameterTypeString = "string"
+	CanvasParameterTypeSecret = "secret"
+	CanvasParameterTypeEnum   = "enum"
+
+	MaxCanvasParameters = 100
+)
+
+var canvasParameterNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+var canvasEnvironmentRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{0,63}$`)
+
+//
+// CanvasParameter is a value shared by all the nodes of a canvas,
+// referenced from their configuration with {{ params.<name> }}.
+//
+// Overrides replace the value for a given environment, e.g. dev, staging or prod.
+// The environment of the canvas selects which override is used, if any.
+//
+// Secret parameters never hold the secret value itself, only a reference
+// to an organization secret key. They resolve to that reference,
+// so they can only be used in secret key fields.
+//
+
+type CanvasParameter struct {
+	Name        string                             `json:"name"`
+	Type        string                             `json:"type"`
+	Description string                             `json:"description,omitempty"`
+	Options     []string                           `json:"options,omitempty"`
+	Value       string                             `json:"value,omitempty"`
+	Secret      *configuration.SecretKeyRef        `json:"secret,omitempty"`
+	Overrides   map[string]CanvasParameterOverride `json:"overrides,omitempty"`
+}
+
+type CanvasParameterOverride struct {
+	Value  string                      `json:"value,omitempty"`
+	Secret *configuration.SecretKeyRef `json:"secret,omitempty"`
+}
+
+func (p *CanvasParameter) Validate() error {
+	if !canvasParameterNameRegex.MatchString(p.Name) {
+		return fmt.Errorf("invalid parameter name %q: must start with a letter or underscore, and contain only letters, digits and underscores", p.Name)
+	}
+
+	switch p.Type {
+	case CanvasParameterTypeString:
+	case CanvasParameterTypeEnum:
+		if len(p.Options) == 0 {
+			return fmt.Errorf("parameter %s: enum parameters need at least one option", p.Name)
+		}
+	case CanvasParameterTypeSecret:
+	default:
+		return fmt.Errorf("parameter %s: invalid type %q", p.Name, p.Type)
+	}
+
+	if err := p.validateValue("", CanvasParameterOverride{Value: p.Value, Secret: p.Secret}); err != nil {
+		return err
+	}
+
+	for environment, override := range p.Overrides {
+		if !canvasEnvironmentRegex.MatchString(environment) {
+			return fmt.Errorf("parameter %s: invalid environment %q", p.Name, environment)
+		}
+
+		if err := p.validateValue(environment, override); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *CanvasParameter) validateValue(environment string, value CanvasParameterOverride) error {
+	where := p.Name
+	if environment != "" {
+		where = fmt.Sprintf("%s (%s)", p.Name, environment)
+	}
+
+	if p.Type == CanvasParameterTypeSecret {
+		if value.Value != "" {
+			return fmt.Errorf("parameter %s: secret parameters reference a secret key instead of holding a value", where)
+		}
+
+		if value.Secret != nil && !value.Secret.IsSet() {
+			return fmt.Errorf("parameter %s: secret and key are required", where)
+		}
+
+		return nil
+	}
+
+	if value.Secret != nil {
+		return fmt.Errorf("parameter %s: only secret parameters can reference a secret key", where)
+	}
+
+	if p.Type == CanvasParameterTypeEnum && value.Value != "" && !slices.Contains(p.Options, value.Value) {
+		return fmt.Errorf("parameter %s: value %q must be one of %s", where, value.Value, strings.Join(p.Options, ", "))
+	}
+
+	return nil
+}
+
+/*
+ * Resolve returns the value of the parameter for an environment:
+ * a string for string and enum parameters, and a secret key reference
+ * for secret parameters. Without an override for the environment,
+ * the default value of the parameter is used.
+ */
+func (p *CanvasParameter) Resolve(environment string) any {
+	value := CanvasParameterOverride{Value: p.Value, Secret: p.Secret}
+	if override, ok := p.Overrides[environment]; ok && environment != "" {
+		value = override
+	}
+
+	if p.Type != CanvasParameterTypeSecret {
+		return value.Value
+	}
+
+	if value.Secret == nil {
+		return nil
+	}
+
+	return map[string]any{
+		"secret": value.Secret.Secret,
+		"key":    value.Secret.Key,
+	}
+}
+
+func ValidateCanvasParameters(environment string, parameters []CanvasParameter) error {
+	if environment != "" && !canvasEnvironmentRegex.MatchString(environment) {
+		return fmt.Errorf("invalid environment %q: must start with a lowercase letter, and contain only lowercase letters, digits and dashes", environment)
+	}
+
+	if len(parameters) > MaxCanvasParameters {
+		return fmt.Errorf("too many parameters: at most %d are allowed", MaxCanvasParameters)
+	}
+
+	names := make(map[string]bool, len(parameters))
+	for _, parameter := range parameters {
+		if err := parameter.Validate(); err != nil {
+			return err
+		}
+
+		if names[parameter.Name] {
+			return fmt.Errorf("duplicate parameter %s", parameter.Name)
+		}
+
+		names[parameter.Name] = true
+	}
+
+	return nil
+}
+
+/*
+ * ParameterValues returns the values of the canvas parameters
+ * for the environment of the canvas, by parameter name.
+ */
+func (c *Canvas) ParameterValues() map[string]any {
+	values := make(map[string]any, len(c.Parameters))
+	for _, parameter := range c.Parameters {
+		values[parameter.Name] = parameter.Resolve(c.Environment)
+	}
+
+	return values
+}
+
+func (c *Canvas) UpdateParametersInTransaction(tx *gorm.DB, environment string, parameters []CanvasParameter) error {
+	c.Environment = environment
+	c.Parameters = datatypes.NewJSONSlice(parameters)
+	return tx.Model(c).Updates(map[string]any{
+		"environment": c.Environment,
+		"parameters":  c.Parameters,
+	}).Error
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"gorm.io/datatypes"
+)
+
+func Test__ValidateCanvasParameters(t *testing.T) {
+	t.Run("valid parameters", func(t *testing.T) {
+		err := ValidateCanvasParameters("prod", []CanvasParameter{
+			{Name: "project", Type: CanvasParameterTypeString, Value: "dev-project", Overrides: map[string]CanvasParameterOverride{"prod": {Value: "prod-project"}}},
+			{Name: "region", Type: CanvasParameterTypeEnum, Options: []string{"us-east-1", "eu-west-1"}, Value: "us-east-1"},
+			{Name: "token", Type: CanvasParameterTypeSecret, Secret: &configuration.SecretKeyRef{Secret: "deploy", Key: "token"}},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name -> error", func(t *testing.T) {
+		err := ValidateCanvasParameters("", []CanvasParameter{{Name: "my-project", Type: CanvasParameterTypeString}})
+		require.ErrorContains(t, err, `invalid parameter name "my-project"`)
+	})
+
+	t.Run("duplicate name -> error", func(t *testing.T) {
+		err := ValidateCanvasParameters("", []CanvasParameter{
+			{Name: "project", Type: CanvasParameterTypeString},
+			{Name: "project", Type: CanvasParameterTypeString},
+		})
+
+		require.ErrorContains(t, err, "duplicate parameter project")
+	})
+
+	t.Run("invalid environment -> error", func(t *testing.T) {
+		err := ValidateCanvasParameters("Prod", nil)
+		require.ErrorContains(t, err, `invalid environment "Prod"`)
+
+		err = ValidateCanvasParameters("", []CanvasParameter{
+			{Name: "project", Type: CanvasParameterTypeString, Overrides: map[string]CanvasParameterOverride{"Prod": {Value: "x"}}},
+		})
+		require.ErrorContains(t, err, `invalid environment "Prod"`)
+	})
+
+	t.Run("enum override not in options -> error", func(t *testing.T) {
+		err := ValidateCanvasParameters("", []CanvasParameter{
+			{
+				Name:      "region",
+				Type:      CanvasParameterTypeEnum,
+				Options:   []string{"us-east-1"},
+				Overrides: map[string]CanvasParameterOverride{"prod": {Value: "eu-west-1"}},
+			},
+		})
+
+		require.ErrorContains(t, err, `parameter region (prod): value "eu-west-1" must be one of us-east-1`)
+	})
+
+	t.Run("secret parameter with plain value -> error", func(t *testing.T) {
+		err := ValidateCanvasParameters("", []CanvasParameter{{Name: "token", Type: CanvasParameterTypeSecret, Value: "abc"}})
+		require.ErrorContains(t, err, "secret parameters reference a secret key")
+	})
+
+	t.Run("string parameter with secret -> error", func(t *testing.T) {
+		err := ValidateCanvasParameters("", []CanvasParameter{
+			{Name: "project", Type: CanvasParameterTypeString, Secret: &configuration.SecretKeyRef{Secret: "s", Key: "k"}},
+		})
+
+		require.ErrorContains(t, err, "only secret parameters can reference a secret key")
+	})
+}
+
+func Test__Canvas__ParameterValues(t *testing.T) {
+	canvas := Canvas{
+		Environment: "prod",
+		Parameters: datatypes.NewJSONSlice([]CanvasParameter{
+			{
+				Name:      "project",
+				Type:      CanvasParameterTypeString,
+				Value:     "dev-project",
+				Overrides: map[string]CanvasParameterOverride{"prod": {Value: "prod-project"}},
+			},
+			{
+				Name:      "region",
+				Type:      CanvasParameterTypeEnum,
+				Options:   []string{"us-east-1", "eu-west-1"},
+				Value:     "us-east-1",
+				Overrides: map[string]CanvasParameterOverride{"staging": {Value: "eu-west-1"}},
+			},
+			{
+				Name:   "token",
+				Type:   CanvasParameterTypeSecret,
+				Secret: &configuration.SecretKeyRef{Secret: "deploy", Key: "dev-token"},
+				Overrides: map[string]CanvasParameterOverride{
+					"prod": {Secret: &configuration.SecretKeyRef{Secret: "deploy", Key: "prod-token"}},
+				},
+			},
+		}),
+	}
+
+	assert.Equal(t, map[string]any{
+		"project": "prod-project",
+		"region":  "us-east-1",
+		"token":   map[string]any{"secret": "deploy", "key": "prod-token"},
+	}, canvas.ParameterValues())
+
+	canvas.Environment = ""
+	assert.Equal(t, map[string]any{
+		"project": "dev-project",
+		"region":  "us-east-1",
+		"token":   map[string]any{"secret": "deploy", "key": "dev-token"},
+	}, canvas.ParameterValues())
+}
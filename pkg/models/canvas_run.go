@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	CanvasRunStateRunning   = "running"
+	CanvasRunStatePassed    = "passed"
+	CanvasRunStateFailed    = "failed"
+	CanvasRunStateCancelled = "cancelled"
+)
+
+//
+// CanvasRun is the state of all the executions
+// started from the same root event of a canvas.
+//
+// TerminalEvents are the events emitted by those executions
+// that were not consumed by further executions of the run.
+//
+
+type CanvasRun struct {
+	RootEventID    uuid.UUID
+	State          string
+	Message        string
+	TerminalEvents []CanvasEvent
+}
+
+func FindCanvasRunInTransaction(tx *gorm.DB, workflowID, rootEventID uuid.UUID) (*CanvasRun, error) {
+	rootEvent, err := FindCanvasEventInTransaction(tx, rootEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rootEvent.WorkflowID != workflowID {
+		return nil, fmt.Errorf("run %s not found", rootEventID)
+	}
+
+	run := &CanvasRun{RootEventID: rootEventID, State: CanvasRunStateRunning}
+	if rootEvent.State == CanvasEventStatePending {
+		return run, nil
+	}
+
+	var queued int64
+	err = tx.Model(&CanvasNodeQueueItem{}).
+		Where("workflow_id = ?", workflowID).
+		Where("root_event_id = ?", rootEventID).
+		Count(&queued).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	if queued > 0 {
+		return run, nil
+	}
+
+	//
+	// Executions inside of blueprint nodes are left out,
+	// since the blueprint node execution emits their outputs.
+	//
+	var executions []CanvasNodeExecution
+	err = tx.
+		Where("workflow_id = ?", workflowID).
+		Where("root_event_id = ?", rootEventID).
+		Where("parent_execution_id IS NULL").
+		Order("created_at ASC").
+		Find(&executions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	consumed := map[uuid.UUID]bool{}
+	executionIDs := make([]uuid.UUID, 0, len(executions))
+	for _, execution := range executions {
+		if execution.State != CanvasNodeExecutionStateFinished {
+			return run, nil
+		}
+
+		consumed[execution.EventID] = true
+		executionIDs = append(executionIDs, execution.ID)
+	}
+
+	events, err := ListCanvasEventsForExecutionsInTransaction(tx, executionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		if event.State == CanvasEventStatePending {
+			return run, nil
+		}
+
+		if !consumed[event.ID] {
+			run.TerminalEvents = append(run.TerminalEvents, event)
+		}
+	}
+
+	run.State = CanvasRunStatePassed
+	for _, execution := range executions {
+		switch {
+		case execution.Result == CanvasNodeExecutionResultFailed && execution.ResultReason != CanvasNodeExecutionResultReasonErrorResolved:
+			run.State = CanvasRunStateFailed
+			run.Message = fmt.Sprintf("node %s failed: %s", execution.NodeID, execution.ResultMessage)
+			return run, nil
+
+		case execution.Result == CanvasNodeExecutionResultCancelled:
+			run.State = CanvasRunStateCancelled
+			run.Message = fmt.Sprintf("node %s was cancelled", execution.NodeID)
+		}
+	}
+
+	return run, nil
+}
@@ -56,6 +56,25 @@ func FindCanvasVersionInTransaction(tx *gorm.DB, workflowID, versionID uuid.UUID
 	return &version, nil
 }
 
+/*
+ * LockCanvasVersionsUsingComponentsInTransaction locks the canvas versions
+ * with nodes using any of the components, e.g. to upgrade their configuration.
+ */
+func LockCanvasVersionsUsingComponentsInTransaction(tx *gorm.DB, components []string) ([]CanvasVersion, error) {
+	var versions []CanvasVersion
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("EXISTS (SELECT 1 FROM jsonb_array_elements(nodes) AS n WHERE n->'ref'->'component'->>'name' IN ?)", components).
+		Find(&versions).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
 func FindCanvasVersion(workflowID, versionID uuid.UUID) (*CanvasVersion, error) {
 	return FindCanvasVersionInTransaction(database.Conn(), workflowID, versionID)
 }
@@ -15,6 +15,12 @@ const (
 	IntegrationStatePending = "pending"
 	IntegrationStateReady   = "ready"
 	IntegrationStateError   = "error"
+
+	//
+	// An integration which was ready, but whose periodic health check failed.
+	// Executions of nodes using it stay queued until a health check passes again.
+	//
+	IntegrationStateDegraded = "degraded"
 )
 
 type Integration struct {
@@ -27,6 +33,7 @@ type Integration struct {
 	Configuration    datatypes.JSONType[map[string]any]
 	Metadata         datatypes.JSONType[map[string]any]
 	BrowserAction    *datatypes.JSONType[BrowserAction]
+	HealthCheckedAt  *time.Time
 	CreatedAt        *time.Time
 	UpdatedAt        *time.Time
 	DeletedAt        gorm.DeletedAt `gorm:"index"`
@@ -213,6 +220,76 @@ func ListDeletedIntegrations() ([]Integration, error) {
 	return integrations, nil
 }
 
+/*
+ * Lists integrations in the ready state, across all organizations.
+ */
+func ListReadyIntegrations() ([]Integration, error) {
+	var integrations []Integration
+	err := database.Conn().
+		Where("state = ?", IntegrationStateReady).
+		Find(&integrations).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return integrations, nil
+}
+
+/*
+ * Lists ready and degraded integrations which were
+ * never health checked, or were last checked before the given time.
+ */
+func ListIntegrationsDueForHealthCheck(checkedBefore time.Time) ([]Integration, error) {
+	var integrations []Integration
+	err := database.Conn().
+		Where("state IN ?", []string{IntegrationStateReady, IntegrationStateDegraded}).
+		Where("health_checked_at IS NULL OR health_checked_at < ?", checkedBefore).
+		Find(&integrations).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return integrations, nil
+}
+
+func (a *Integration) UpdateHealthCheckedAtInTransaction(tx *gorm.DB, checkedAt time.Time) error {
+	a.HealthCheckedAt = &checkedAt
+	return tx.Model(a).UpdateColumn("health_checked_at", checkedAt).Error
+}
+
+/*
+ * Moves the integration from one state to another,
+ * only if it is still in the state the caller observed.
+ * Returns false if the integration changed in the meantime.
+ */
+func (a *Integration) TransitionState(from, to, description string) (bool, error) {
+	result := database.Conn().
+		Model(&Integration{}).
+		Where("id = ?", a.ID).
+		Where("state = ?", from).
+		Updates(map[string]any{
+			"state":             to,
+			"state_description": description,
+			"updated_at":        time.Now(),
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	a.State = to
+	a.StateDescription = description
+	return true, nil
+}
+
 func LockIntegration(tx *gorm.DB, ID uuid.UUID) (*Integration, error) {
 	var integration Integration
 
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+)
+
+const (
+	WebhookDeliveryRetention = 7 * 24 * time.Hour
+
+	//
+	// Bodies bigger than this are not kept,
+	// and deliveries without a body cannot be replayed.
+	//
+	MaxWebhookDeliveryBodySize = 1024 * 1024
+)
+
+/*
+ * WebhookDelivery is a raw inbound request received for an integration,
+ * either through a node webhook, or through the integration HTTP endpoint,
+ * e.g. Pub/Sub pushes. StatusCode and Error hold the verdict returned for it.
+ */
+type WebhookDelivery struct {
+	ID                uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	OrganizationID    uuid.UUID
+	AppInstallationID uuid.UUID
+	WebhookID         *uuid.UUID
+	ReplayOf          *uuid.UUID
+	Method            string
+	URL               string
+	Headers           datatypes.JSONType[map[string][]string]
+	Body              []byte
+	StatusCode        int
+	Error             *string
+	CreatedAt         *time.Time
+}
+
+func (d *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func CreateWebhookDelivery(delivery *WebhookDelivery) error {
+	now := time.Now()
+	delivery.CreatedAt = &now
+
+	if len(delivery.Body) > MaxWebhookDeliveryBodySize {
+		delivery.Body = nil
+	}
+
+	return database.Conn().Create(delivery).Error
+}
+
+func FindWebhookDelivery(integrationID uuid.UUID, id uuid.UUID) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := database.Conn().
+		Where("app_installation_id = ?", integrationID).
+		Where("id = ?", id).
+		First(&delivery).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+/*
+ * Lists the deliveries of an integration, most recent first.
+ * Older deliveries are fetched by passing the creation time of the last one received as before.
+ */
+func ListWebhookDeliveries(integrationID uuid.UUID, before *time.Time, limit int) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	query := database.Conn().
+		Where("app_installation_id = ?", integrationID).
+		Order("created_at DESC").
+		Limit(limit)
+
+	if before != nil {
+		query = query.Where("created_at < ?", before)
+	}
+
+	err := query.Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func DeleteWebhookDeliveriesBefore(before time.Time) (int64, error) {
+	result := database.Conn().
+		Where("created_at < ?", before).
+		Delete(&WebhookDelivery{})
+
+	return result.RowsAffected, result.Error
+}
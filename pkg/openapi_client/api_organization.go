@@ -1225,6 +1225,8 @@ type ApiOrganizationsListIntegrationResourcesRequest struct {
 	id            string
 	integrationId string
 	parameters    *string
+	pageToken     *string
+	pageSize      *int32
 }
 
 func (r ApiOrganizationsListIntegrationResourcesRequest) Parameters(parameters string) ApiOrganizationsListIntegrationResourcesRequest {
@@ -1232,6 +1234,16 @@ func (r ApiOrganizationsListIntegrationResourcesRequest) Parameters(parameters s
 	return r
 }
 
+func (r ApiOrganizationsListIntegrationResourcesRequest) PageToken(pageToken string) ApiOrganizationsListIntegrationResourcesRequest {
+	r.pageToken = &pageToken
+	return r
+}
+
+func (r ApiOrganizationsListIntegrationResourcesRequest) PageSize(pageSize int32) ApiOrganizationsListIntegrationResourcesRequest {
+	r.pageSize = &pageSize
+	return r
+}
+
 func (r ApiOrganizationsListIntegrationResourcesRequest) Execute() (*OrganizationsListIntegrationResourcesResponse, *http.Response, error) {
 	return r.ApiService.OrganizationsListIntegrationResourcesExecute(r)
 }
@@ -1282,6 +1294,12 @@ func (a *OrganizationAPIService) OrganizationsListIntegrationResourcesExecute(r
 	if r.parameters != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "parameters", r.parameters, "", "")
 	}
+	if r.pageToken != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pageToken", r.pageToken, "", "")
+	}
+	if r.pageSize != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
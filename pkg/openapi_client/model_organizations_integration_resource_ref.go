@@ -20,9 +20,14 @@ var _ MappedNullable = &OrganizationsIntegrationResourceRef{}
 
 // OrganizationsIntegrationResourceRef struct for OrganizationsIntegrationResourceRef
 type OrganizationsIntegrationResourceRef struct {
-	Type *string `json:"type,omitempty"`
-	Name *string `json:"name,omitempty"`
-	Id   *string `json:"id,omitempty"`
+	Type        *string                `json:"type,omitempty"`
+	Name        *string                `json:"name,omitempty"`
+	Id          *string                `json:"id,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	Group       *string                `json:"group,omitempty"`
+	Icon        *string                `json:"icon,omitempty"`
+	Disabled    *bool                  `json:"disabled,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // NewOrganizationsIntegrationResourceRef instantiates a new OrganizationsIntegrationResourceRef object
@@ -138,6 +143,166 @@ func (o *OrganizationsIntegrationResourceRef) SetId(v string) {
 	o.Id = &v
 }
 
+// GetDescription returns the Description field value if set, zero value otherwise.
+func (o *OrganizationsIntegrationResourceRef) GetDescription() string {
+	if o == nil || IsNil(o.Description) {
+		var ret string
+		return ret
+	}
+	return *o.Description
+}
+
+// GetDescriptionOk returns a tuple with the Description field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *OrganizationsIntegrationResourceRef) GetDescriptionOk() (*string, bool) {
+	if o == nil || IsNil(o.Description) {
+		return nil, false
+	}
+	return o.Description, true
+}
+
+// HasDescription returns a boolean if a field has been set.
+func (o *OrganizationsIntegrationResourceRef) HasDescription() bool {
+	if o != nil && !IsNil(o.Description) {
+		return true
+	}
+
+	return false
+}
+
+// SetDescription gets a reference to the given string and assigns it to the Description field.
+func (o *OrganizationsIntegrationResourceRef) SetDescription(v string) {
+	o.Description = &v
+}
+
+// GetGroup returns the Group field value if set, zero value otherwise.
+func (o *OrganizationsIntegrationResourceRef) GetGroup() string {
+	if o == nil || IsNil(o.Group) {
+		var ret string
+		return ret
+	}
+	return *o.Group
+}
+
+// GetGroupOk returns a tuple with the Group field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *OrganizationsIntegrationResourceRef) GetGroupOk() (*string, bool) {
+	if o == nil || IsNil(o.Group) {
+		return nil, false
+	}
+	return o.Group, true
+}
+
+// HasGroup returns a boolean if a field has been set.
+func (o *OrganizationsIntegrationResourceRef) HasGroup() bool {
+	if o != nil && !IsNil(o.Group) {
+		return true
+	}
+
+	return false
+}
+
+// SetGroup gets a reference to the given string and assigns it to the Group field.
+func (o *OrganizationsIntegrationResourceRef) SetGroup(v string) {
+	o.Group = &v
+}
+
+// GetIcon returns the Icon field value if set, zero value otherwise.
+func (o *OrganizationsIntegrationResourceRef) GetIcon() string {
+	if o == nil || IsNil(o.Icon) {
+		var ret string
+		return ret
+	}
+	return *o.Icon
+}
+
+// GetIconOk returns a tuple with the Icon field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *OrganizationsIntegrationResourceRef) GetIconOk() (*string, bool) {
+	if o == nil || IsNil(o.Icon) {
+		return nil, false
+	}
+	return o.Icon, true
+}
+
+// HasIcon returns a boolean if a field has been set.
+func (o *OrganizationsIntegrationResourceRef) HasIcon() bool {
+	if o != nil && !IsNil(o.Icon) {
+		return true
+	}
+
+	return false
+}
+
+// SetIcon gets a reference to the given string and assigns it to the Icon field.
+func (o *OrganizationsIntegrationResourceRef) SetIcon(v string) {
+	o.Icon = &v
+}
+
+// GetDisabled returns the Disabled field value if set, zero value otherwise.
+func (o *OrganizationsIntegrationResourceRef) GetDisabled() bool {
+	if o == nil || IsNil(o.Disabled) {
+		var ret bool
+		return ret
+	}
+	return *o.Disabled
+}
+
+// GetDisabledOk returns a tuple with the Disabled field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *OrganizationsIntegrationResourceRef) GetDisabledOk() (*bool, bool) {
+	if o == nil || IsNil(o.Disabled) {
+		return nil, false
+	}
+	return o.Disabled, true
+}
+
+// HasDisabled returns a boolean if a field has been set.
+func (o *OrganizationsIntegrationResourceRef) HasDisabled() bool {
+	if o != nil && !IsNil(o.Disabled) {
+		return true
+	}
+
+	return false
+}
+
+// SetDisabled gets a reference to the given bool and assigns it to the Disabled field.
+func (o *OrganizationsIntegrationResourceRef) SetDisabled(v bool) {
+	o.Disabled = &v
+}
+
+// GetMetadata returns the Metadata field value if set, zero value otherwise.
+func (o *OrganizationsIntegrationResourceRef) GetMetadata() map[string]interface{} {
+	if o == nil || IsNil(o.Metadata) {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.Metadata
+}
+
+// GetMetadataOk returns a tuple with the Metadata field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *OrganizationsIntegrationResourceRef) GetMetadataOk() (map[string]interface{}, bool) {
+	if o == nil || IsNil(o.Metadata) {
+		return map[string]interface{}{}, false
+	}
+	return o.Metadata, true
+}
+
+// HasMetadata returns a boolean if a field has been set.
+func (o *OrganizationsIntegrationResourceRef) HasMetadata() bool {
+	if o != nil && !IsNil(o.Metadata) {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadata gets a reference to the given map[string]interface{} and assigns it to the Metadata field.
+func (o *OrganizationsIntegrationResourceRef) SetMetadata(v map[string]interface{}) {
+	o.Metadata = v
+}
+
 func (o OrganizationsIntegrationResourceRef) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -157,6 +322,21 @@ func (o OrganizationsIntegrationResourceRef) ToMap() (map[string]interface{}, er
 	if !IsNil(o.Id) {
 		toSerialize["id"] = o.Id
 	}
+	if !IsNil(o.Description) {
+		toSerialize["description"] = o.Description
+	}
+	if !IsNil(o.Group) {
+		toSerialize["group"] = o.Group
+	}
+	if !IsNil(o.Icon) {
+		toSerialize["icon"] = o.Icon
+	}
+	if !IsNil(o.Disabled) {
+		toSerialize["disabled"] = o.Disabled
+	}
+	if !IsNil(o.Metadata) {
+		toSerialize["metadata"] = o.Metadata
+	}
 	return toSerialize, nil
 }
 
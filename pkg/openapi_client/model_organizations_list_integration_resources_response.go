@@ -20,7 +20,8 @@ var _ MappedNullable = &OrganizationsListIntegrationResourcesResponse{}
 
 // OrganizationsListIntegrationResourcesResponse struct for OrganizationsListIntegrationResourcesResponse
 type OrganizationsListIntegrationResourcesResponse struct {
-	Resources []OrganizationsIntegrationResourceRef `json:"resources,omitempty"`
+	Resources     []OrganizationsIntegrationResourceRef `json:"resources,omitempty"`
+	NextPageToken *string                               `json:"nextPageToken,omitempty"`
 }
 
 // NewOrganizationsListIntegrationResourcesResponse instantiates a new OrganizationsListIntegrationResourcesResponse object
@@ -72,6 +73,38 @@ func (o *OrganizationsListIntegrationResourcesResponse) SetResources(v []Organiz
 	o.Resources = v
 }
 
+// GetNextPageToken returns the NextPageToken field value if set, zero value otherwise.
+func (o *OrganizationsListIntegrationResourcesResponse) GetNextPageToken() string {
+	if o == nil || IsNil(o.NextPageToken) {
+		var ret string
+		return ret
+	}
+	return *o.NextPageToken
+}
+
+// GetNextPageTokenOk returns a tuple with the NextPageToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *OrganizationsListIntegrationResourcesResponse) GetNextPageTokenOk() (*string, bool) {
+	if o == nil || IsNil(o.NextPageToken) {
+		return nil, false
+	}
+	return o.NextPageToken, true
+}
+
+// HasNextPageToken returns a boolean if a field has been set.
+func (o *OrganizationsListIntegrationResourcesResponse) HasNextPageToken() bool {
+	if o != nil && !IsNil(o.NextPageToken) {
+		return true
+	}
+
+	return false
+}
+
+// SetNextPageToken gets a reference to the given string and assigns it to the NextPageToken field.
+func (o *OrganizationsListIntegrationResourcesResponse) SetNextPageToken(v string) {
+	o.NextPageToken = &v
+}
+
 func (o OrganizationsListIntegrationResourcesResponse) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -85,6 +118,9 @@ func (o OrganizationsListIntegrationResourcesResponse) ToMap() (map[string]inter
 	if !IsNil(o.Resources) {
 		toSerialize["resources"] = o.Resources
 	}
+	if !IsNil(o.NextPageToken) {
+		toSerialize["nextPageToken"] = o.NextPageToken
+	}
 	return toSerialize, nil
 }
 
@@ -0,0 +1,257 @@
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	handshakeTimeout = 10 * time.Second
+
+	//
+	// Timeout for calls without a deadline of their own.
+	//
+	defaultCallTimeout = time.Minute
+)
+
+/*
+ * Plugin is a running plugin process.
+ */
+type Plugin struct {
+	path        string
+	cmd         *exec.Cmd
+	stdin       io.WriteCloser
+	conn        *grpc.ClientConn
+	client      *client
+	integration core.Integration
+}
+
+/*
+ * Start runs the plugin binary at path,
+ * and waits for it to be ready to receive calls.
+ */
+func Start(path string) (*Plugin, error) {
+	// #nosec
+	cmd := exec.Command(path)
+	cmd.Env = append(os.Environ(), MagicCookieKey+"="+MagicCookieValue)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.WithField("plugin", filepath.Base(path))
+	cmd.Stderr = logger.WriterLevel(log.InfoLevel)
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting plugin %s: %w", path, err)
+	}
+
+	plugin := &Plugin{path: path, cmd: cmd, stdin: stdin}
+	socket, err := readHandshake(stdout)
+	if err != nil {
+		_ = plugin.Close()
+		return nil, fmt.Errorf("plugin %s: %w", path, err)
+	}
+
+	//
+	// Keep reading stdout, so plugins writing to it do not block.
+	//
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			logger.Info(scanner.Text())
+		}
+	}()
+
+	conn, err := grpc.NewClient(
+		"unix://"+socket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	)
+
+	if err != nil {
+		_ = plugin.Close()
+		return nil, fmt.Errorf("error connecting to plugin %s: %w", path, err)
+	}
+
+	plugin.conn = conn
+	plugin.client = &client{conn: conn}
+	plugin.integration, err = plugin.client.integration()
+	if err != nil {
+		_ = plugin.Close()
+		return nil, fmt.Errorf("plugin %s: %w", path, err)
+	}
+
+	return plugin, nil
+}
+
+/*
+ * Reads the handshake line written by Serve(),
+ * and returns the path of the socket the plugin listens on.
+ */
+func readHandshake(stdout io.Reader) (string, error) {
+	lines := make(chan string, 1)
+	errs := make(chan error, 1)
+	go func() {
+		line, err := bufio.NewReader(stdout).ReadString('\n')
+		if err != nil {
+			errs <- fmt.Errorf("error reading handshake: %w", err)
+			return
+		}
+
+		lines <- strings.TrimSpace(line)
+	}()
+
+	var line string
+	select {
+	case line = <-lines:
+	case err := <-errs:
+		return "", err
+	case <-time.After(handshakeTimeout):
+		return "", fmt.Errorf("timed out waiting for handshake")
+	}
+
+	parts := strings.Split(line, "|")
+	if len(parts) != 4 || parts[1] != "unix" || parts[3] != "grpc" {
+		return "", fmt.Errorf("invalid handshake %q", line)
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil || version != ProtocolVersion {
+		return "", fmt.Errorf("unsupported protocol version %q, expected %d", parts[0], ProtocolVersion)
+	}
+
+	return parts[2], nil
+}
+
+func (p *Plugin) Integration() core.Integration {
+	return p.integration
+}
+
+/*
+ * Close stops the plugin process.
+ */
+func (p *Plugin) Close() error {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+
+	_ = p.stdin.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- p.cmd.Wait() }()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(5 * time.Second):
+		_ = p.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+/*
+ * LoadDir starts every executable file in dir as a plugin,
+ * and registers the integrations they expose.
+ * It must be called before the registry is created.
+ */
+func LoadDir(dir string) ([]*Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	plugins := []*Plugin{}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() || info.Mode()&0o111 == 0 {
+			continue
+		}
+
+		plugin, err := Start(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			for _, started := range plugins {
+				_ = started.Close()
+			}
+
+			return nil, err
+		}
+
+		log.Infof("Registering integration %s from plugin %s", plugin.Integration().Name(), entry.Name())
+		registry.RegisterIntegration(plugin.Integration().Name(), plugin.Integration())
+		plugins = append(plugins, plugin)
+	}
+
+	return plugins, nil
+}
+
+type client struct {
+	conn *grpc.ClientConn
+}
+
+func (c *client) call(ctx context.Context, method string, request, response any) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultCallTimeout)
+		defer cancel()
+	}
+
+	err := c.conn.Invoke(ctx, "/"+serviceName+"/"+method, request, response)
+	if err != nil {
+		//
+		// Errors returned by the plugin are returned as they are,
+		// without the gRPC status code.
+		//
+		if s, ok := status.FromError(err); ok {
+			return fmt.Errorf("%s", s.Message())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) integration() (core.Integration, error) {
+	response := DescribeResponse{}
+	err := c.call(context.Background(), "Describe", &DescribeRequest{ProtocolVersion: ProtocolVersion}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error describing plugin: %w", err)
+	}
+
+	if response.ProtocolVersion != ProtocolVersion {
+		return nil, fmt.Errorf("unsupported protocol version %d, expected %d", response.ProtocolVersion, ProtocolVersion)
+	}
+
+	if response.Integration.Name == "" {
+		return nil, fmt.Errorf("plugin integration has no name")
+	}
+
+	return newIntegration(c, response.Integration), nil
+}
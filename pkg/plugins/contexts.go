@@ -0,0 +1,260 @@
+package plugins
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * The contexts below are used inside the plugin process.
+ * They start from the snapshot sent by SuperPlane,
+ * and record every change as Effects, returned to SuperPlane with the response.
+ */
+
+type recorder struct {
+	effects Effects
+}
+
+func newRecorder() *recorder {
+	return &recorder{}
+}
+
+type metadataContext struct {
+	value any
+	onSet func(*Value)
+}
+
+func (m *metadataContext) Get() any {
+	return m.value
+}
+
+func (m *metadataContext) Set(value any) error {
+	m.value = value
+	m.onSet(&Value{Value: value})
+	return nil
+}
+
+func (r *recorder) metadata(value any) core.MetadataContext {
+	return &metadataContext{value: value, onSet: func(v *Value) { r.effects.Metadata = v }}
+}
+
+func (r *recorder) nodeMetadata(value any) core.MetadataContext {
+	return &metadataContext{value: value, onSet: func(v *Value) { r.effects.NodeMetadata = v }}
+}
+
+type executionStateContext struct {
+	recorder *recorder
+}
+
+func (s *executionStateContext) IsFinished() bool {
+	return s.recorder.effects.Passed || s.recorder.effects.Failure != nil
+}
+
+func (s *executionStateContext) SetKV(key, value string) error {
+	s.recorder.effects.KVs = append(s.recorder.effects.KVs, KV{Key: key, Value: value})
+	return nil
+}
+
+func (s *executionStateContext) Emit(channel, payloadType string, payloads []any) error {
+	return s.EmitOutputs([]core.ChannelOutput{{Channel: channel, PayloadType: payloadType, Payloads: payloads}})
+}
+
+func (s *executionStateContext) EmitOutputs(outputs []core.ChannelOutput) error {
+	if s.IsFinished() {
+		return fmt.Errorf("execution already finished")
+	}
+
+	for _, output := range outputs {
+		s.recorder.effects.Outputs = append(s.recorder.effects.Outputs, Output(output))
+	}
+
+	s.recorder.effects.Passed = true
+	return nil
+}
+
+func (s *executionStateContext) Pass() error {
+	return s.EmitOutputs(nil)
+}
+
+func (s *executionStateContext) Fail(reason, message string) error {
+	if s.IsFinished() {
+		return fmt.Errorf("execution already finished")
+	}
+
+	s.recorder.effects.Failure = &Failure{Reason: reason, Message: message}
+	return nil
+}
+
+type requestContext struct {
+	recorder *recorder
+}
+
+func (r *requestContext) ScheduleActionCall(actionName string, parameters map[string]any, interval time.Duration) error {
+	r.recorder.effects.ScheduledActions = append(r.recorder.effects.ScheduledActions, ScheduledAction{
+		Action:     actionName,
+		Parameters: parameters,
+		IntervalMs: interval.Milliseconds(),
+	})
+
+	return nil
+}
+
+type logsContext struct {
+	recorder *recorder
+	partial  string
+}
+
+func (l *logsContext) Write(p []byte) (int, error) {
+	lines := strings.Split(l.partial+string(p), "\n")
+	l.partial = lines[len(lines)-1]
+	l.recorder.effects.Logs = append(l.recorder.effects.Logs, lines[:len(lines)-1]...)
+	return len(p), nil
+}
+
+func (l *logsContext) Printf(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+
+	_, _ = l.Write([]byte(line))
+}
+
+func (l *logsContext) flush() {
+	if l.partial != "" {
+		l.recorder.effects.Logs = append(l.recorder.effects.Logs, l.partial)
+		l.partial = ""
+	}
+}
+
+/*
+ * Webhooks and subscriptions are not supported for plugins yet,
+ * since they need SuperPlane to call the plugin outside of a request.
+ */
+type integrationContext struct {
+	recorder *recorder
+	snapshot Integration
+	id       uuid.UUID
+}
+
+func (r *recorder) integration(snapshot *Integration) (core.IntegrationContext, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(snapshot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integration ID %q: %w", snapshot.ID, err)
+	}
+
+	return &integrationContext{recorder: r, snapshot: *snapshot, id: id}, nil
+}
+
+func (c *integrationContext) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *integrationContext) GetMetadata() any {
+	return c.snapshot.Metadata
+}
+
+func (c *integrationContext) SetMetadata(metadata any) {
+	c.snapshot.Metadata = metadata
+	c.recorder.effects.Integration.Metadata = &Value{Value: metadata}
+}
+
+func (c *integrationContext) GetConfig(name string) ([]byte, error) {
+	value, ok := c.snapshot.Configuration[name]
+	if !ok {
+		return nil, fmt.Errorf("config %s not found", name)
+	}
+
+	return value, nil
+}
+
+func (c *integrationContext) Ready() {
+	c.recorder.effects.Integration.Ready = true
+	c.recorder.effects.Integration.Error = ""
+}
+
+func (c *integrationContext) Error(message string) {
+	c.recorder.effects.Integration.Ready = false
+	c.recorder.effects.Integration.Error = message
+}
+
+func (c *integrationContext) NewBrowserAction(action core.BrowserAction) {}
+
+func (c *integrationContext) RemoveBrowserAction() {}
+
+func (c *integrationContext) SetSecret(name string, value []byte) error {
+	c.recorder.effects.Integration.Secrets = append(c.recorder.effects.Integration.Secrets, Secret{Name: name, Value: value})
+	for i, secret := range c.snapshot.Secrets {
+		if secret.Name == name {
+			c.snapshot.Secrets[i].Value = value
+			return nil
+		}
+	}
+
+	c.snapshot.Secrets = append(c.snapshot.Secrets, Secret{Name: name, Value: value})
+	return nil
+}
+
+func (c *integrationContext) GetSecrets() ([]core.IntegrationSecret, error) {
+	secrets := make([]core.IntegrationSecret, 0, len(c.snapshot.Secrets))
+	for _, secret := range c.snapshot.Secrets {
+		secrets = append(secrets, core.IntegrationSecret(secret))
+	}
+
+	return secrets, nil
+}
+
+func (c *integrationContext) RequestWebhook(configuration any) error {
+	return fmt.Errorf("webhooks are not supported for plugin integrations")
+}
+
+func (c *integrationContext) Subscribe(any) (*uuid.UUID, error) {
+	return nil, fmt.Errorf("subscriptions are not supported for plugin integrations")
+}
+
+func (c *integrationContext) ScheduleResync(interval time.Duration) error {
+	c.recorder.effects.Integration.ResyncIntervalMs = interval.Milliseconds()
+	return nil
+}
+
+func (c *integrationContext) ScheduleActionCall(actionName string, parameters any, interval time.Duration) error {
+	c.recorder.effects.Integration.ScheduledActions = append(c.recorder.effects.Integration.ScheduledActions, ScheduledAction{
+		Action:     actionName,
+		Parameters: parameters,
+		IntervalMs: interval.Milliseconds(),
+	})
+
+	return nil
+}
+
+func (c *integrationContext) ListSubscriptions() ([]core.IntegrationSubscriptionContext, error) {
+	return nil, nil
+}
+
+func (c *integrationContext) FindSubscription(predicate func(core.IntegrationSubscriptionContext) bool) (core.IntegrationSubscriptionContext, error) {
+	return nil, nil
+}
+
+/*
+ * Plugins send HTTP requests themselves, with a default timeout.
+ */
+type httpContext struct {
+	client *http.Client
+}
+
+func newHTTPContext() core.HTTPContext {
+	return &httpContext{client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (c *httpContext) Do(request *http.Request) (*http.Response, error) {
+	return c.client.Do(request)
+}
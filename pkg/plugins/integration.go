@@ -0,0 +1,483 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * pluginIntegration implements core.Integration by calling the plugin.
+ * Definitions are read once, when the plugin starts.
+ *
+ * Triggers, integration actions and HTTP requests
+ * are not supported by version 1 of the protocol.
+ */
+type pluginIntegration struct {
+	client     *client
+	descriptor IntegrationDescriptor
+	components []core.Component
+}
+
+func newIntegration(client *client, descriptor IntegrationDescriptor) *pluginIntegration {
+	integration := &pluginIntegration{client: client, descriptor: descriptor}
+	for _, component := range descriptor.Components {
+		integration.components = append(integration.components, &pluginComponent{
+			integration: integration,
+			descriptor:  component,
+		})
+	}
+
+	return integration
+}
+
+func (i *pluginIntegration) Name() string {
+	return i.descriptor.Name
+}
+
+func (i *pluginIntegration) Label() string {
+	return i.descriptor.Label
+}
+
+func (i *pluginIntegration) Icon() string {
+	return i.descriptor.Icon
+}
+
+func (i *pluginIntegration) Description() string {
+	return i.descriptor.Description
+}
+
+func (i *pluginIntegration) Instructions() string {
+	return i.descriptor.Instructions
+}
+
+func (i *pluginIntegration) Configuration() []configuration.Field {
+	return i.descriptor.Configuration
+}
+
+func (i *pluginIntegration) RequiredPermissions() core.Permissions {
+	return i.descriptor.Permissions
+}
+
+func (i *pluginIntegration) Components() []core.Component {
+	return i.components
+}
+
+func (i *pluginIntegration) Triggers() []core.Trigger {
+	return []core.Trigger{}
+}
+
+func (i *pluginIntegration) Sync(ctx core.SyncContext) error {
+	if ctx.Integration == nil {
+		return fmt.Errorf("integration context is required")
+	}
+
+	snapshot, err := i.snapshot(ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	effects := Effects{}
+	err = i.client.call(context.Background(), "Sync", &SyncRequest{
+		Configuration:   ctx.Configuration,
+		BaseURL:         ctx.BaseURL,
+		WebhooksBaseURL: ctx.WebhooksBaseURL,
+		OrganizationID:  ctx.OrganizationID,
+		Integration:     *snapshot,
+	}, &effects)
+
+	if err != nil {
+		return err
+	}
+
+	return applyIntegrationEffects(effects.Integration, ctx.Integration)
+}
+
+func (i *pluginIntegration) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (i *pluginIntegration) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (i *pluginIntegration) HandleAction(ctx core.IntegrationActionContext) error {
+	return fmt.Errorf("actions are not supported for plugin integrations")
+}
+
+func (i *pluginIntegration) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	if ctx.Integration == nil {
+		return nil, fmt.Errorf("integration context is required")
+	}
+
+	snapshot, err := i.snapshot(ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	response := ListResourcesResponse{}
+	err = i.client.call(context.Background(), "ListResources", &ListResourcesRequest{
+		ResourceType: resourceType,
+		Parameters:   ctx.Parameters,
+		Integration:  *snapshot,
+	}, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(response.Resources))
+	for _, resource := range response.Resources {
+		resources = append(resources, core.IntegrationResource(resource))
+	}
+
+	return resources, nil
+}
+
+func (i *pluginIntegration) HandleRequest(ctx core.HTTPRequestContext) {
+	ctx.Response.WriteHeader(http.StatusNotFound)
+}
+
+/*
+ * snapshot reads the integration configuration, metadata and secrets,
+ * so they can be sent to the plugin.
+ */
+func (i *pluginIntegration) snapshot(integration core.IntegrationContext) (*Integration, error) {
+	if integration == nil {
+		return nil, nil
+	}
+
+	snapshot := &Integration{
+		ID:            integration.ID().String(),
+		Configuration: map[string][]byte{},
+		Metadata:      integration.GetMetadata(),
+		Secrets:       []Secret{},
+	}
+
+	for _, field := range i.descriptor.Configuration {
+		value, err := integration.GetConfig(field.Name)
+		if err != nil {
+			continue
+		}
+
+		snapshot.Configuration[field.Name] = value
+	}
+
+	secrets, err := integration.GetSecrets()
+	if err != nil {
+		return nil, fmt.Errorf("error reading integration secrets: %w", err)
+	}
+
+	for _, secret := range secrets {
+		snapshot.Secrets = append(snapshot.Secrets, Secret(secret))
+	}
+
+	return snapshot, nil
+}
+
+type pluginComponent struct {
+	integration *pluginIntegration
+	descriptor  ComponentDescriptor
+}
+
+func (c *pluginComponent) Name() string {
+	return c.descriptor.Name
+}
+
+func (c *pluginComponent) Label() string {
+	return c.descriptor.Label
+}
+
+func (c *pluginComponent) Description() string {
+	return c.descriptor.Description
+}
+
+func (c *pluginComponent) Documentation() string {
+	return c.descriptor.Documentation
+}
+
+func (c *pluginComponent) Icon() string {
+	return c.descriptor.Icon
+}
+
+func (c *pluginComponent) Color() string {
+	return c.descriptor.Color
+}
+
+func (c *pluginComponent) ExampleOutput() map[string]any {
+	return c.descriptor.ExampleOutput
+}
+
+func (c *pluginComponent) RequiredPermissions() core.Permissions {
+	return c.descriptor.Permissions
+}
+
+/*
+ * Output channels are described once, without a configuration,
+ * so they cannot depend on the configuration of the node.
+ */
+func (c *pluginComponent) OutputChannels(config any) []core.OutputChannel {
+	channels := make([]core.OutputChannel, 0, len(c.descriptor.OutputChannels))
+	for _, channel := range c.descriptor.OutputChannels {
+		channels = append(channels, core.OutputChannel(channel))
+	}
+
+	return channels
+}
+
+func (c *pluginComponent) Configuration() []configuration.Field {
+	return c.descriptor.Configuration
+}
+
+func (c *pluginComponent) Actions() []core.Action {
+	actions := make([]core.Action, 0, len(c.descriptor.Actions))
+	for _, action := range c.descriptor.Actions {
+		actions = append(actions, core.Action(action))
+	}
+
+	return actions
+}
+
+func (c *pluginComponent) Setup(ctx core.SetupContext) error {
+	snapshot, err := c.integration.snapshot(ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	effects := Effects{}
+	err = c.integration.client.call(context.Background(), "Setup", &SetupRequest{
+		Component:     c.descriptor.Name,
+		Configuration: ctx.Configuration,
+		Metadata:      getMetadata(ctx.Metadata),
+		Integration:   snapshot,
+	}, &effects)
+
+	if err != nil {
+		return err
+	}
+
+	return applyEffects(effects, callContexts{
+		nodeMetadata: ctx.Metadata,
+		requests:     ctx.Requests,
+		integration:  ctx.Integration,
+	})
+}
+
+func (c *pluginComponent) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *pluginComponent) Execute(ctx core.ExecutionContext) error {
+	snapshot, err := c.integration.snapshot(ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	effects := Effects{}
+	err = c.integration.client.call(ctx.GoContext(), "Execute", &ExecuteRequest{
+		Component: c.descriptor.Name,
+		Execution: Execution{
+			ID:             ctx.ID.String(),
+			WorkflowID:     ctx.WorkflowID,
+			OrganizationID: ctx.OrganizationID,
+			NodeID:         ctx.NodeID,
+			SourceNodeID:   ctx.SourceNodeID,
+			BaseURL:        ctx.BaseURL,
+			Configuration:  ctx.Configuration,
+			Data:           ctx.Data,
+			Metadata:       getMetadata(ctx.Metadata),
+			NodeMetadata:   getMetadata(ctx.NodeMetadata),
+		},
+		Integration: snapshot,
+	}, &effects)
+
+	if err != nil {
+		return err
+	}
+
+	return applyEffects(effects, callContexts{
+		metadata:     ctx.Metadata,
+		nodeMetadata: ctx.NodeMetadata,
+		state:        ctx.ExecutionState,
+		requests:     ctx.Requests,
+		integration:  ctx.Integration,
+		logs:         ctx.Logs,
+	})
+}
+
+func (c *pluginComponent) HandleAction(ctx core.ActionContext) error {
+	snapshot, err := c.integration.snapshot(ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	effects := Effects{}
+	err = c.integration.client.call(ctx.GoContext(), "HandleAction", &ActionRequest{
+		Component:  c.descriptor.Name,
+		Action:     ctx.Name,
+		Parameters: ctx.Parameters,
+		Execution: Execution{
+			Configuration: ctx.Configuration,
+			Metadata:      getMetadata(ctx.Metadata),
+		},
+		Integration: snapshot,
+	}, &effects)
+
+	if err != nil {
+		return err
+	}
+
+	return applyEffects(effects, callContexts{
+		metadata:    ctx.Metadata,
+		state:       ctx.ExecutionState,
+		requests:    ctx.Requests,
+		integration: ctx.Integration,
+		logs:        ctx.Logs,
+	})
+}
+
+func (c *pluginComponent) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *pluginComponent) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *pluginComponent) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func getMetadata(metadata core.MetadataContext) any {
+	if metadata == nil {
+		return nil
+	}
+
+	return metadata.Get()
+}
+
+/*
+ * The contexts the effects of a call are applied to.
+ * Contexts that are not available for a call are nil.
+ */
+type callContexts struct {
+	metadata     core.MetadataContext
+	nodeMetadata core.MetadataContext
+	state        core.ExecutionStateContext
+	requests     core.RequestContext
+	integration  core.IntegrationContext
+	logs         core.LogsContext
+}
+
+func applyEffects(effects Effects, ctx callContexts) error {
+	if ctx.logs != nil {
+		for _, line := range effects.Logs {
+			if _, err := ctx.logs.Write([]byte(line + "\n")); err != nil {
+				return err
+			}
+		}
+	}
+
+	if ctx.state != nil {
+		for _, kv := range effects.KVs {
+			if err := ctx.state.SetKV(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if effects.Metadata != nil && ctx.metadata != nil {
+		if err := ctx.metadata.Set(effects.Metadata.Value); err != nil {
+			return err
+		}
+	}
+
+	if effects.NodeMetadata != nil && ctx.nodeMetadata != nil {
+		if err := ctx.nodeMetadata.Set(effects.NodeMetadata.Value); err != nil {
+			return err
+		}
+	}
+
+	if ctx.requests != nil {
+		for _, call := range effects.ScheduledActions {
+			parameters, _ := call.Parameters.(map[string]any)
+			err := ctx.requests.ScheduleActionCall(call.Action, parameters, time.Duration(call.IntervalMs)*time.Millisecond)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := applyIntegrationEffects(effects.Integration, ctx.integration); err != nil {
+		return err
+	}
+
+	if ctx.state == nil {
+		return nil
+	}
+
+	if effects.Failure != nil {
+		return ctx.state.Fail(effects.Failure.Reason, effects.Failure.Message)
+	}
+
+	if !effects.Passed {
+		return nil
+	}
+
+	if len(effects.Outputs) == 0 {
+		return ctx.state.Pass()
+	}
+
+	outputs := make([]core.ChannelOutput, 0, len(effects.Outputs))
+	for _, output := range effects.Outputs {
+		outputs = append(outputs, core.ChannelOutput(output))
+	}
+
+	if len(outputs) == 1 {
+		return ctx.state.Emit(outputs[0].Channel, outputs[0].PayloadType, outputs[0].Payloads)
+	}
+
+	return ctx.state.EmitOutputs(outputs)
+}
+
+func applyIntegrationEffects(effects IntegrationEffects, integration core.IntegrationContext) error {
+	if integration == nil {
+		return nil
+	}
+
+	if effects.Metadata != nil {
+		integration.SetMetadata(effects.Metadata.Value)
+	}
+
+	for _, secret := range effects.Secrets {
+		if err := integration.SetSecret(secret.Name, secret.Value); err != nil {
+			return err
+		}
+	}
+
+	if effects.Error != "" {
+		integration.Error(effects.Error)
+	} else if effects.Ready {
+		integration.Ready()
+	}
+
+	if effects.ResyncIntervalMs > 0 {
+		if err := integration.ScheduleResync(time.Duration(effects.ResyncIntervalMs) * time.Millisecond); err != nil {
+			return err
+		}
+	}
+
+	for _, call := range effects.ScheduledActions {
+		err := integration.ScheduleActionCall(call.Action, call.Parameters, time.Duration(call.IntervalMs)*time.Millisecond)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package plugins
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/core/coretest"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+// echoIntegration is the integration served by the plugin in these tests
+type echoIntegration struct{}
+
+func (e *echoIntegration) Name() string         { return "echo" }
+func (e *echoIntegration) Label() string        { return "Echo" }
+func (e *echoIntegration) Icon() string         { return "echo" }
+func (e *echoIntegration) Description() string  { return "Echoes messages" }
+func (e *echoIntegration) Instructions() string { return "" }
+func (e *echoIntegration) Configuration() []configuration.Field {
+	return []configuration.Field{{Name: "greeting", Type: configuration.FieldTypeString, Required: true}}
+}
+func (e *echoIntegration) Components() []core.Component { return []core.Component{&echoSay{}} }
+func (e *echoIntegration) Triggers() []core.Trigger     { return nil }
+func (e *echoIntegration) Actions() []core.Action       { return nil }
+func (e *echoIntegration) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+func (e *echoIntegration) HandleAction(ctx core.IntegrationActionContext) error {
+	return nil
+}
+func (e *echoIntegration) HandleRequest(ctx core.HTTPRequestContext) {}
+func (e *echoIntegration) RequiredPermissions() core.Permissions {
+	return core.Permissions{Scopes: []string{"echo:write"}}
+}
+
+func (e *echoIntegration) Sync(ctx core.SyncContext) error {
+	greeting, err := ctx.Integration.GetConfig("greeting")
+	if err != nil {
+		ctx.Integration.Error("greeting is required")
+		return nil
+	}
+
+	ctx.Integration.SetMetadata(map[string]any{"greeting": string(greeting)})
+	ctx.Integration.Ready()
+	return ctx.Integration.ScheduleResync(time.Hour)
+}
+
+func (e *echoIntegration) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	return []core.IntegrationResource{{Type: resourceType, Name: "general", ID: "1", Group: "public"}}, nil
+}
+
+type echoSay struct{}
+
+func (e *echoSay) Name() string                  { return "echo.say" }
+func (e *echoSay) Label() string                 { return "Say" }
+func (e *echoSay) Description() string           { return "Says something" }
+func (e *echoSay) Documentation() string         { return "" }
+func (e *echoSay) Icon() string                  { return "echo" }
+func (e *echoSay) Color() string                 { return "gray" }
+func (e *echoSay) ExampleOutput() map[string]any { return nil }
+func (e *echoSay) OutputChannels(config any) []core.OutputChannel {
+	return []core.OutputChannel{{Name: "default", Label: "Default"}}
+}
+func (e *echoSay) Configuration() []configuration.Field {
+	return []configuration.Field{{Name: "wait", Type: configuration.FieldTypeBool}}
+}
+func (e *echoSay) Setup(ctx core.SetupContext) error {
+	return ctx.Metadata.Set(map[string]any{"ready": true})
+}
+func (e *echoSay) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+func (e *echoSay) Actions() []core.Action {
+	return []core.Action{{Name: "check", Description: "Check the message"}}
+}
+func (e *echoSay) HandleAction(ctx core.ActionContext) error {
+	return ctx.ExecutionState.Fail("error", "message was not delivered")
+}
+func (e *echoSay) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 200, nil, nil
+}
+func (e *echoSay) Cancel(ctx core.ExecutionContext) error { return nil }
+func (e *echoSay) Cleanup(ctx core.SetupContext) error    { return nil }
+
+func (e *echoSay) Execute(ctx core.ExecutionContext) error {
+	config := struct {
+		Wait bool `mapstructure:"wait"`
+	}{}
+
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return err
+	}
+
+	greeting, err := ctx.Integration.GetConfig("greeting")
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Metadata.Set(map[string]any{"said": true}); err != nil {
+		return err
+	}
+
+	if config.Wait {
+		return ctx.Requests.ScheduleActionCall("check", map[string]any{"attempt": 1}, time.Minute)
+	}
+
+	data, _ := ctx.Data.(map[string]any)
+	ctx.Logs.Printf("saying hello to %s", data["name"])
+	return ctx.ExecutionState.Emit("default", "echo.said", []any{
+		map[string]any{"message": string(greeting) + " " + data["name"].(string)},
+	})
+}
+
+/*
+ * Serves the echo integration on a unix socket, like Serve() does,
+ * and returns the integration as seen by SuperPlane.
+ */
+func startPluginForTest(t *testing.T) core.Integration {
+	t.Helper()
+
+	//
+	// Unix socket paths are limited to ~100 characters,
+	// so t.TempDir() cannot be used.
+	//
+	dir, err := os.MkdirTemp("", "plugin")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := newGRPCServer(&echoIntegration{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"unix://"+socket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	)
+
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	integration, err := (&client{conn: conn}).integration()
+	require.NoError(t, err)
+	return integration
+}
+
+func Test__Plugin__Describe(t *testing.T) {
+	integration := startPluginForTest(t)
+
+	assert.Equal(t, "echo", integration.Name())
+	assert.Equal(t, "Echo", integration.Label())
+	assert.Equal(t, "greeting", integration.Configuration()[0].Name)
+	assert.Equal(t, []string{"echo:write"}, integration.(core.PermissionsProvider).RequiredPermissions().Scopes)
+
+	require.Len(t, integration.Components(), 1)
+	component := integration.Components()[0]
+	assert.Equal(t, "echo.say", component.Name())
+	assert.Equal(t, "check", component.Actions()[0].Name)
+	assert.Equal(t, "default", component.OutputChannels(nil)[0].Name)
+}
+
+func Test__Plugin__Sync(t *testing.T) {
+	integration := startPluginForTest(t)
+
+	t.Run("missing configuration -> error state", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{Configuration: map[string]any{}}
+		require.NoError(t, integration.Sync(core.SyncContext{Integration: integrationCtx}))
+		assert.Equal(t, "error", integrationCtx.State)
+		assert.Equal(t, "greeting is required", integrationCtx.StateDescription)
+	})
+
+	t.Run("metadata, state and resync are applied", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{Configuration: map[string]any{"greeting": "hello"}}
+		require.NoError(t, integration.Sync(core.SyncContext{Integration: integrationCtx}))
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, map[string]any{"greeting": "hello"}, integrationCtx.Metadata)
+		assert.Equal(t, []time.Duration{time.Hour}, integrationCtx.ResyncRequests)
+	})
+}
+
+func Test__Plugin__ListResources(t *testing.T) {
+	integration := startPluginForTest(t)
+
+	resources, err := integration.ListResources("channel", core.ListResourcesContext{
+		Integration: &contexts.IntegrationContext{},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []core.IntegrationResource{{Type: "channel", Name: "general", ID: "1", Group: "public"}}, resources)
+}
+
+func Test__Plugin__Component(t *testing.T) {
+	component := startPluginForTest(t).Components()[0]
+	integrationCtx := &contexts.IntegrationContext{Configuration: map[string]any{"greeting": "hello"}}
+
+	t.Run("setup sets node metadata", func(t *testing.T) {
+		metadata := &coretest.Metadata{}
+		require.NoError(t, component.Setup(core.SetupContext{Metadata: metadata, Integration: integrationCtx}))
+		assert.Equal(t, map[string]any{"ready": true}, metadata.Metadata)
+	})
+
+	t.Run("execute emits payloads", func(t *testing.T) {
+		execution := coretest.NewExecution(t).
+			WithConfiguration(map[string]any{"wait": false}).
+			WithData(map[string]any{"name": "world"}).
+			WithIntegration(integrationCtx).
+			Build()
+
+		require.NoError(t, component.Execute(execution.Context))
+		assert.True(t, execution.ExecutionState.Passed)
+		assert.Equal(t, map[string]any{"said": true}, execution.Metadata.Metadata)
+		assert.Equal(t, []string{"saying hello to world"}, execution.Logs.Lines)
+
+		payloads := execution.ExecutionState.Payloads("default")
+		require.Len(t, payloads, 1)
+		assert.Equal(t, map[string]any{"message": "hello world"}, payloads[0])
+	})
+
+	t.Run("execute schedules actions, and actions fail executions", func(t *testing.T) {
+		execution := coretest.NewExecution(t).
+			WithConfiguration(map[string]any{"wait": true}).
+			WithIntegration(integrationCtx).
+			Build()
+
+		require.NoError(t, component.Execute(execution.Context))
+		assert.False(t, execution.ExecutionState.Finished)
+
+		execution.Clock.Advance(time.Minute)
+		calls := execution.Requests.Due()
+		require.Len(t, calls, 1)
+		assert.Equal(t, "check", calls[0].Action)
+		assert.Equal(t, float64(1), calls[0].Parameters["attempt"])
+
+		require.NoError(t, component.HandleAction(execution.Action("check", calls[0].Parameters)))
+		assert.True(t, execution.ExecutionState.Finished)
+		assert.Equal(t, "message was not delivered", execution.ExecutionState.FailureMessage)
+	})
+
+	t.Run("component errors are returned", func(t *testing.T) {
+		execution := coretest.NewExecution(t).
+			WithIntegration(&contexts.IntegrationContext{Configuration: map[string]any{}}).
+			Build()
+
+		err := component.Execute(execution.Context)
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "greeting"))
+	})
+}
+
+func Test__ReadHandshake(t *testing.T) {
+	socket, err := readHandshake(strings.NewReader("1|unix|/tmp/plugin.sock|grpc\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/plugin.sock", socket)
+
+	_, err = readHandshake(strings.NewReader("2|unix|/tmp/plugin.sock|grpc\n"))
+	require.ErrorContains(t, err, "unsupported protocol version")
+
+	_, err = readHandshake(strings.NewReader("hello\n"))
+	require.ErrorContains(t, err, "invalid handshake")
+}
@@ -0,0 +1,237 @@
+package plugins
+
+import (
+	"encoding/json"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * Plugins are separate binaries exposing a single integration,
+ * started by SuperPlane and called over gRPC on a unix socket.
+ *
+ * The process is started with MagicCookieKey=MagicCookieValue in its environment,
+ * so running a plugin binary by hand fails with a helpful message.
+ * Once it is listening, the plugin writes a handshake line to stdout:
+ *
+ *   <protocol version>|unix|<socket path>|grpc
+ *
+ * Messages are encoded as JSON, so they are readable and can be extended
+ * with new fields without breaking older plugins or older SuperPlane versions.
+ * Breaking changes to the messages below require a new ProtocolVersion.
+ */
+const (
+	ProtocolVersion  = 1
+	MagicCookieKey   = "SUPERPLANE_PLUGIN_MAGIC_COOKIE"
+	MagicCookieValue = "d7e5c0b4a1f94f3e8f5e3c2b9a6d1e07"
+
+	serviceName = "superplane.plugins.v1.Plugin"
+	codecName   = "json"
+)
+
+/*
+ * jsonCodec is set on the plugin connections and server with
+ * grpc.ForceCodec and grpc.ForceServerCodec, instead of being
+ * registered globally, so other gRPC services in the process
+ * are not affected by it.
+ */
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+type DescribeRequest struct {
+	ProtocolVersion int `json:"protocolVersion"`
+}
+
+type DescribeResponse struct {
+	ProtocolVersion int                   `json:"protocolVersion"`
+	Integration     IntegrationDescriptor `json:"integration"`
+}
+
+type IntegrationDescriptor struct {
+	Name          string                `json:"name"`
+	Label         string                `json:"label"`
+	Icon          string                `json:"icon"`
+	Description   string                `json:"description"`
+	Instructions  string                `json:"instructions"`
+	Configuration []configuration.Field `json:"configuration"`
+	Permissions   core.Permissions      `json:"permissions"`
+	Components    []ComponentDescriptor `json:"components"`
+}
+
+type ComponentDescriptor struct {
+	Name           string                `json:"name"`
+	Label          string                `json:"label"`
+	Description    string                `json:"description"`
+	Documentation  string                `json:"documentation"`
+	Icon           string                `json:"icon"`
+	Color          string                `json:"color"`
+	ExampleOutput  map[string]any        `json:"exampleOutput,omitempty"`
+	OutputChannels []ChannelDescriptor   `json:"outputChannels"`
+	Configuration  []configuration.Field `json:"configuration"`
+	Actions        []ActionDescriptor    `json:"actions"`
+	Permissions    core.Permissions      `json:"permissions"`
+}
+
+type ChannelDescriptor struct {
+	Name        string `json:"name"`
+	Label       string `json:"label,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type ActionDescriptor struct {
+	Name           string                `json:"name"`
+	Description    string                `json:"description,omitempty"`
+	UserAccessible bool                  `json:"userAccessible"`
+	Parameters     []configuration.Field `json:"parameters"`
+}
+
+/*
+ * Integration is a snapshot of the integration used by a call,
+ * since the plugin cannot read it from the database.
+ */
+type Integration struct {
+	ID            string            `json:"id"`
+	Configuration map[string][]byte `json:"configuration"`
+	Metadata      any               `json:"metadata"`
+	Secrets       []Secret          `json:"secrets"`
+}
+
+type Secret struct {
+	Name  string `json:"name"`
+	Value []byte `json:"value"`
+}
+
+type Execution struct {
+	ID             string `json:"id"`
+	WorkflowID     string `json:"workflowId"`
+	OrganizationID string `json:"organizationId"`
+	NodeID         string `json:"nodeId"`
+	SourceNodeID   string `json:"sourceNodeId"`
+	BaseURL        string `json:"baseUrl"`
+	Configuration  any    `json:"configuration"`
+	Data           any    `json:"data"`
+	Metadata       any    `json:"metadata"`
+	NodeMetadata   any    `json:"nodeMetadata"`
+}
+
+type SyncRequest struct {
+	Configuration   any         `json:"configuration"`
+	BaseURL         string      `json:"baseUrl"`
+	WebhooksBaseURL string      `json:"webhooksBaseUrl"`
+	OrganizationID  string      `json:"organizationId"`
+	Integration     Integration `json:"integration"`
+}
+
+type ListResourcesRequest struct {
+	ResourceType string            `json:"resourceType"`
+	Parameters   map[string]string `json:"parameters"`
+	Integration  Integration       `json:"integration"`
+}
+
+type ListResourcesResponse struct {
+	Resources []Resource `json:"resources"`
+}
+
+type Resource struct {
+	Type        string         `json:"type"`
+	Name        string         `json:"name"`
+	ID          string         `json:"id"`
+	Description string         `json:"description,omitempty"`
+	Group       string         `json:"group,omitempty"`
+	Icon        string         `json:"icon,omitempty"`
+	Disabled    bool           `json:"disabled,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+type SetupRequest struct {
+	Component     string       `json:"component"`
+	Configuration any          `json:"configuration"`
+	Metadata      any          `json:"metadata"`
+	Integration   *Integration `json:"integration,omitempty"`
+}
+
+type ExecuteRequest struct {
+	Component   string       `json:"component"`
+	Execution   Execution    `json:"execution"`
+	Integration *Integration `json:"integration,omitempty"`
+}
+
+type ActionRequest struct {
+	Component   string         `json:"component"`
+	Action      string         `json:"action"`
+	Parameters  map[string]any `json:"parameters"`
+	Execution   Execution      `json:"execution"`
+	Integration *Integration   `json:"integration,omitempty"`
+}
+
+/*
+ * Effects are the changes made by the plugin through its contexts during a call.
+ * They are applied to the real contexts by SuperPlane, in the order of the fields.
+ */
+type Effects struct {
+	KVs              []KV               `json:"kvs,omitempty"`
+	Metadata         *Value             `json:"metadata,omitempty"`
+	NodeMetadata     *Value             `json:"nodeMetadata,omitempty"`
+	ScheduledActions []ScheduledAction  `json:"scheduledActions,omitempty"`
+	Outputs          []Output           `json:"outputs,omitempty"`
+	Passed           bool               `json:"passed,omitempty"`
+	Failure          *Failure           `json:"failure,omitempty"`
+	Integration      IntegrationEffects `json:"integration"`
+
+	//
+	// Lines written to ctx.Logs. Plugins return them with the response,
+	// so they are only stored when the call finishes.
+	//
+	Logs []string `json:"logs,omitempty"`
+}
+
+type IntegrationEffects struct {
+	Metadata         *Value            `json:"metadata,omitempty"`
+	Secrets          []Secret          `json:"secrets,omitempty"`
+	Ready            bool              `json:"ready,omitempty"`
+	Error            string            `json:"error,omitempty"`
+	ResyncIntervalMs int64             `json:"resyncIntervalMs,omitempty"`
+	ScheduledActions []ScheduledAction `json:"scheduledActions,omitempty"`
+}
+
+/*
+ * Value wraps values that may be set to nil,
+ * so a nil value is not confused with a value that was not set.
+ */
+type Value struct {
+	Value any `json:"value"`
+}
+
+type KV struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type ScheduledAction struct {
+	Action     string `json:"action"`
+	Parameters any    `json:"parameters"`
+	IntervalMs int64  `json:"intervalMs"`
+}
+
+type Output struct {
+	Channel     string `json:"channel"`
+	PayloadType string `json:"payloadType"`
+	Payloads    []any  `json:"payloads"`
+}
+
+type Failure struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
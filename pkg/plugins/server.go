@@ -0,0 +1,350 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+ * Serve is called from the main() of a plugin binary,
+ * to expose an integration to SuperPlane:
+ *
+ *   func main() {
+ *     plugins.Serve(&myintegration.MyIntegration{})
+ *   }
+ *
+ * It returns when SuperPlane closes the stdin of the plugin, or stops it.
+ */
+func Serve(integration core.Integration) {
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		fmt.Fprintln(os.Stderr, "This binary is a SuperPlane plugin, and it is started by SuperPlane.")
+		fmt.Fprintln(os.Stderr, "Put it in the directory set in SUPERPLANE_PLUGINS_DIR instead of running it.")
+		os.Exit(1)
+	}
+
+	dir, err := os.MkdirTemp("", "superplane-plugin-")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	server := newGRPCServer(integration)
+
+	//
+	// SuperPlane keeps the stdin of the plugin open while it is running,
+	// so the plugin stops when SuperPlane exits, even if it is killed.
+	//
+	go func() {
+		_, _ = io.Copy(io.Discard, os.Stdin)
+		server.Stop()
+	}()
+
+	fmt.Printf("%d|unix|%s|grpc\n", ProtocolVersion, socket)
+	if err := server.Serve(listener); err != nil {
+		exitWithError(err)
+	}
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+func newGRPCServer(integration core.Integration) *grpc.Server {
+	server := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	server.RegisterService(&serviceDesc, &pluginServer{
+		integration: integration,
+		logger:      log.NewEntry(log.New()).WithField("integration", integration.Name()),
+	})
+
+	return server
+}
+
+type pluginService interface {
+	describe(ctx context.Context, request *DescribeRequest) (*DescribeResponse, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*pluginService)(nil),
+	Methods: []grpc.MethodDesc{
+		unary("Describe", (*pluginServer).describe),
+		unary("Sync", (*pluginServer).sync),
+		unary("ListResources", (*pluginServer).listResources),
+		unary("Setup", (*pluginServer).setup),
+		unary("Execute", (*pluginServer).execute),
+		unary("HandleAction", (*pluginServer).handleAction),
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func unary[Req any, Res any](name string, call func(*pluginServer, context.Context, *Req) (*Res, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv any, ctx context.Context, decode func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+			request := new(Req)
+			if err := decode(request); err != nil {
+				return nil, err
+			}
+
+			response, err := call(srv.(*pluginServer), ctx, request)
+			if err != nil {
+				return nil, status.Error(codes.Unknown, err.Error())
+			}
+
+			return response, nil
+		},
+	}
+}
+
+type pluginServer struct {
+	integration core.Integration
+	logger      *log.Entry
+}
+
+func (s *pluginServer) describe(ctx context.Context, request *DescribeRequest) (*DescribeResponse, error) {
+	return &DescribeResponse{
+		ProtocolVersion: ProtocolVersion,
+		Integration:     DescribeIntegration(s.integration),
+	}, nil
+}
+
+func (s *pluginServer) sync(ctx context.Context, request *SyncRequest) (*Effects, error) {
+	recorder := newRecorder()
+	integration, err := recorder.integration(&request.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.integration.Sync(core.SyncContext{
+		Logger:          s.logger,
+		Configuration:   request.Configuration,
+		BaseURL:         request.BaseURL,
+		WebhooksBaseURL: request.WebhooksBaseURL,
+		OrganizationID:  request.OrganizationID,
+		HTTP:            newHTTPContext(),
+		Integration:     integration,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &recorder.effects, nil
+}
+
+func (s *pluginServer) listResources(ctx context.Context, request *ListResourcesRequest) (*ListResourcesResponse, error) {
+	integration, err := newRecorder().integration(&request.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.integration.ListResources(request.ResourceType, core.ListResourcesContext{
+		Logger:      s.logger,
+		HTTP:        newHTTPContext(),
+		Integration: integration,
+		Parameters:  request.Parameters,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListResourcesResponse{Resources: make([]Resource, 0, len(resources))}
+	for _, resource := range resources {
+		response.Resources = append(response.Resources, Resource(resource))
+	}
+
+	return response, nil
+}
+
+func (s *pluginServer) setup(ctx context.Context, request *SetupRequest) (*Effects, error) {
+	component, err := s.findComponent(request.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := newRecorder()
+	integration, err := recorder.integration(request.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	err = component.Setup(core.SetupContext{
+		Logger:        s.logger.WithField("component", component.Name()),
+		Configuration: request.Configuration,
+		HTTP:          newHTTPContext(),
+		Metadata:      recorder.nodeMetadata(request.Metadata),
+		Requests:      &requestContext{recorder: recorder},
+		Integration:   integration,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &recorder.effects, nil
+}
+
+func (s *pluginServer) execute(ctx context.Context, request *ExecuteRequest) (*Effects, error) {
+	component, err := s.findComponent(request.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := newRecorder()
+	integration, err := recorder.integration(request.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	executionID, err := uuid.Parse(request.Execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid execution ID %q: %w", request.Execution.ID, err)
+	}
+
+	logs := &logsContext{recorder: recorder}
+	err = component.Execute(core.ExecutionContext{
+		ID:             executionID,
+		WorkflowID:     request.Execution.WorkflowID,
+		OrganizationID: request.Execution.OrganizationID,
+		NodeID:         request.Execution.NodeID,
+		SourceNodeID:   request.Execution.SourceNodeID,
+		BaseURL:        request.Execution.BaseURL,
+		Data:           request.Execution.Data,
+		Configuration:  request.Execution.Configuration,
+		Logger:         s.logger.WithField("component", component.Name()),
+		HTTP:           newHTTPContext(),
+		Metadata:       recorder.metadata(request.Execution.Metadata),
+		NodeMetadata:   recorder.nodeMetadata(request.Execution.NodeMetadata),
+		ExecutionState: &executionStateContext{recorder: recorder},
+		Requests:       &requestContext{recorder: recorder},
+		Integration:    integration,
+		Logs:           logs,
+		Context:        ctx,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs.flush()
+	return &recorder.effects, nil
+}
+
+func (s *pluginServer) handleAction(ctx context.Context, request *ActionRequest) (*Effects, error) {
+	component, err := s.findComponent(request.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := newRecorder()
+	integration, err := recorder.integration(request.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := &logsContext{recorder: recorder}
+	err = component.HandleAction(core.ActionContext{
+		Name:           request.Action,
+		Configuration:  request.Execution.Configuration,
+		Parameters:     request.Parameters,
+		Logger:         s.logger.WithField("component", component.Name()),
+		HTTP:           newHTTPContext(),
+		Metadata:       recorder.metadata(request.Execution.Metadata),
+		ExecutionState: &executionStateContext{recorder: recorder},
+		Requests:       &requestContext{recorder: recorder},
+		Integration:    integration,
+		Logs:           logs,
+		Context:        ctx,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs.flush()
+	return &recorder.effects, nil
+}
+
+func (s *pluginServer) findComponent(name string) (core.Component, error) {
+	for _, component := range s.integration.Components() {
+		if component.Name() == name {
+			return component, nil
+		}
+	}
+
+	return nil, fmt.Errorf("component %s not found for integration %s", name, s.integration.Name())
+}
+
+/*
+ * DescribeIntegration returns the definition of an integration,
+ * as sent by plugins to SuperPlane.
+ */
+func DescribeIntegration(integration core.Integration) IntegrationDescriptor {
+	descriptor := IntegrationDescriptor{
+		Name:          integration.Name(),
+		Label:         integration.Label(),
+		Icon:          integration.Icon(),
+		Description:   integration.Description(),
+		Instructions:  integration.Instructions(),
+		Configuration: integration.Configuration(),
+		Permissions:   permissionsOf(integration),
+		Components:    []ComponentDescriptor{},
+	}
+
+	for _, component := range integration.Components() {
+		channels := []ChannelDescriptor{}
+		for _, channel := range component.OutputChannels(nil) {
+			channels = append(channels, ChannelDescriptor(channel))
+		}
+
+		actions := []ActionDescriptor{}
+		for _, action := range component.Actions() {
+			actions = append(actions, ActionDescriptor(action))
+		}
+
+		descriptor.Components = append(descriptor.Components, ComponentDescriptor{
+			Name:           component.Name(),
+			Label:          component.Label(),
+			Description:    component.Description(),
+			Documentation:  component.Documentation(),
+			Icon:           component.Icon(),
+			Color:          component.Color(),
+			ExampleOutput:  component.ExampleOutput(),
+			OutputChannels: channels,
+			Configuration:  component.Configuration(),
+			Actions:        actions,
+			Permissions:    permissionsOf(component),
+		})
+	}
+
+	return descriptor
+}
+
+func permissionsOf(value any) core.Permissions {
+	provider, ok := value.(core.PermissionsProvider)
+	if !ok {
+		return core.Permissions{}
+	}
+
+	return provider.RequiredPermissions()
+}
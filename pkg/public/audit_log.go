@@ -0,0 +1,141 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+)
+
+const (
+	DefaultAuditLogLimit = 100
+	MaxAuditLogLimit     = 1000
+)
+
+type AuditLogEntry struct {
+	ID          int64          `json:"id"`
+	Action      string         `json:"action"`
+	UserID      *string        `json:"userId,omitempty"`
+	CanvasID    *string        `json:"canvasId,omitempty"`
+	NodeID      *string        `json:"nodeId,omitempty"`
+	ExecutionID *string        `json:"executionId,omitempty"`
+	Details     map[string]any `json:"details"`
+	CreatedAt   *time.Time     `json:"createdAt"`
+}
+
+type AuditLogResponse struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
+
+/*
+ * Lists the audit log of the organization, most recent first.
+ * Entries can be filtered by canvas, node, execution, user and action,
+ * and older entries are fetched by passing the ID of the last entry received as `before`.
+ */
+func (s *Server) listAuditLog(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	allowed, err := s.authService.CheckOrganizationPermission(user.ID.String(), user.OrganizationID.String(), "audit_log", "read")
+	if err != nil || !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	filters, limit, err := parseAuditLogQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entries, err := models.ListAuditLogEntries(user.OrganizationID, filters, limit)
+	if err != nil {
+		log.Errorf("error listing audit log for organization %s: %v", user.OrganizationID, err)
+		http.Error(w, "error listing audit log", http.StatusInternalServerError)
+		return
+	}
+
+	response := AuditLogResponse{Entries: make([]AuditLogEntry, 0, len(entries))}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, serializeAuditLogEntry(entry))
+	}
+
+	respondJSON(w, response)
+}
+
+func serializeAuditLogEntry(entry models.AuditLogEntry) AuditLogEntry {
+	return AuditLogEntry{
+		ID:          entry.ID,
+		Action:      entry.Action,
+		UserID:      uuidString(entry.UserID),
+		CanvasID:    uuidString(entry.WorkflowID),
+		NodeID:      entry.NodeID,
+		ExecutionID: uuidString(entry.ExecutionID),
+		Details:     entry.Details.Data(),
+		CreatedAt:   entry.CreatedAt,
+	}
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	s := id.String()
+	return &s
+}
+
+func parseAuditLogQuery(r *http.Request) (models.AuditLogFilters, int, error) {
+	query := r.URL.Query()
+	filters := models.AuditLogFilters{
+		NodeID: query.Get("nodeId"),
+		Action: query.Get("action"),
+	}
+
+	for name, target := range map[string]**uuid.UUID{
+		"canvasId":    &filters.CanvasID,
+		"executionId": &filters.ExecutionID,
+		"userId":      &filters.UserID,
+	} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return filters, 0, fmt.Errorf("invalid %s: %q", name, v)
+		}
+
+		*target = &id
+	}
+
+	if v := query.Get("before"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed <= 0 {
+			return filters, 0, fmt.Errorf("invalid before: %q", v)
+		}
+
+		filters.BeforeID = parsed
+	}
+
+	limit := DefaultAuditLogLimit
+	if v := query.Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			return filters, 0, fmt.Errorf("invalid limit: %q", v)
+		}
+
+		limit = min(parsed, MaxAuditLogLimit)
+	}
+
+	return filters, limit, nil
+}
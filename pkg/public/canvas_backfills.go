@@ -0,0 +1,321 @@
+package public
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultBackfillRatePerMinute = 60
+	MaxBackfillRatePerMinute     = 600
+)
+
+type CreateCanvasBackfillRequest struct {
+	TriggerNodeID string     `json:"triggerNodeId"`
+	TargetNodeID  *string    `json:"targetNodeId,omitempty"`
+	Since         *time.Time `json:"since"`
+	Until         *time.Time `json:"until,omitempty"`
+	RatePerMinute int        `json:"ratePerMinute,omitempty"`
+}
+
+type CanvasBackfill struct {
+	ID            string     `json:"id"`
+	TriggerNodeID string     `json:"triggerNodeId"`
+	TargetNodeID  *string    `json:"targetNodeId,omitempty"`
+	Since         time.Time  `json:"since"`
+	Until         time.Time  `json:"until"`
+	RatePerMinute int        `json:"ratePerMinute"`
+	State         string     `json:"state"`
+	ReplayedCount int        `json:"replayedCount"`
+	SkippedCount  int        `json:"skippedCount"`
+	LastEventAt   *time.Time `json:"lastEventAt,omitempty"`
+	CreatedAt     *time.Time `json:"createdAt"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+}
+
+type CanvasBackfillsResponse struct {
+	Backfills []CanvasBackfill `json:"backfills"`
+}
+
+func (s *Server) listCanvasBackfills(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "read")
+	if !ok {
+		return
+	}
+
+	backfills, err := models.ListCanvasBackfills(canvas.ID)
+	if err != nil {
+		log.Errorf("error listing backfills for canvas %s: %v", canvas.ID, err)
+		http.Error(w, "error listing backfills", http.StatusInternalServerError)
+		return
+	}
+
+	response := CanvasBackfillsResponse{Backfills: make([]CanvasBackfill, 0, len(backfills))}
+	for _, backfill := range backfills {
+		response.Backfills = append(response.Backfills, serializeCanvasBackfill(&backfill))
+	}
+
+	respondJSON(w, response)
+}
+
+func (s *Server) getCanvasBackfill(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "read")
+	if !ok {
+		return
+	}
+
+	backfill, ok := findCanvasBackfill(w, r, canvas)
+	if !ok {
+		return
+	}
+
+	respondJSON(w, serializeCanvasBackfill(backfill))
+}
+
+/*
+ * Starts replaying the events emitted by a trigger node in a time range,
+ * through the whole canvas, or only through a target node.
+ * Events are replayed by the backfill worker, at the rate of the backfill.
+ */
+func (s *Server) createCanvasBackfill(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "update")
+	if !ok {
+		return
+	}
+
+	var req CreateCanvasBackfillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	backfill, err := newCanvasBackfill(canvas, &req, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	user, _ := middleware.GetUserFromContext(r.Context())
+	backfill.CreatedBy = &user.ID
+
+	err = database.Conn().Transaction(func(tx *gorm.DB) error {
+		if err := validateBackfillNodes(tx, canvas, backfill); err != nil {
+			return err
+		}
+
+		if err := models.CreateCanvasBackfillInTransaction(tx, backfill); err != nil {
+			return err
+		}
+
+		details := map[string]any{
+			"backfillId":    backfill.ID.String(),
+			"triggerNodeId": backfill.TriggerNodeID,
+			"since":         backfill.Since,
+			"until":         backfill.Until,
+			"ratePerMinute": backfill.RatePerMinute,
+		}
+
+		if backfill.TargetNodeID != nil {
+			details["targetNodeId"] = *backfill.TargetNodeID
+		}
+
+		return models.CreateAuditLogEntryInTransaction(tx, &models.AuditLogEntry{
+			OrganizationID: canvas.OrganizationID,
+			UserID:         &user.ID,
+			WorkflowID:     &canvas.ID,
+			NodeID:         backfill.TargetNodeID,
+			Action:         models.AuditActionCanvasBackfillStarted,
+			Details:        datatypes.NewJSONType(details),
+		})
+	})
+
+	var invalid *invalidBackfillError
+	if errors.As(err, &invalid) {
+		http.Error(w, invalid.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		log.Errorf("error creating backfill for canvas %s: %v", canvas.ID, err)
+		http.Error(w, "error creating backfill", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, serializeCanvasBackfill(backfill))
+}
+
+/*
+ * Cancels a backfill. Events already replayed are not affected.
+ */
+func (s *Server) cancelCanvasBackfill(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "update")
+	if !ok {
+		return
+	}
+
+	backfill, ok := findCanvasBackfill(w, r, canvas)
+	if !ok {
+		return
+	}
+
+	if backfill.IsFinished() {
+		http.Error(w, fmt.Sprintf("backfill is already %s", backfill.State), http.StatusConflict)
+		return
+	}
+
+	user, _ := middleware.GetUserFromContext(r.Context())
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		if err := backfill.CancelInTransaction(tx); err != nil {
+			return err
+		}
+
+		return models.CreateAuditLogEntryInTransaction(tx, &models.AuditLogEntry{
+			OrganizationID: canvas.OrganizationID,
+			UserID:         &user.ID,
+			WorkflowID:     &canvas.ID,
+			NodeID:         backfill.TargetNodeID,
+			Action:         models.AuditActionCanvasBackfillCancelled,
+			Details: datatypes.NewJSONType(map[string]any{
+				"backfillId":    backfill.ID.String(),
+				"replayedCount": backfill.ReplayedCount,
+			}),
+		})
+	})
+
+	if err != nil {
+		log.Errorf("error cancelling backfill %s: %v", backfill.ID, err)
+		http.Error(w, "error cancelling backfill", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, serializeCanvasBackfill(backfill))
+}
+
+func findCanvasBackfill(w http.ResponseWriter, r *http.Request, canvas *models.Canvas) (*models.CanvasBackfill, bool) {
+	backfillID, err := uuid.Parse(mux.Vars(r)["backfillId"])
+	if err != nil {
+		http.Error(w, "backfill not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	backfill, err := models.FindCanvasBackfill(canvas.ID, backfillID)
+	if err != nil {
+		http.Error(w, "backfill not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	return backfill, true
+}
+
+/*
+ * newCanvasBackfill validates the range and rate of a backfill request.
+ * Until defaults to now, and cannot be later than now,
+ * so the events emitted again by the backfill are never replayed by it.
+ */
+func newCanvasBackfill(canvas *models.Canvas, req *CreateCanvasBackfillRequest, now time.Time) (*models.CanvasBackfill, error) {
+	if req.TriggerNodeID == "" {
+		return nil, fmt.Errorf("triggerNodeId is required")
+	}
+
+	if req.TargetNodeID != nil && *req.TargetNodeID == "" {
+		req.TargetNodeID = nil
+	}
+
+	if req.Since == nil {
+		return nil, fmt.Errorf("since is required")
+	}
+
+	until := now
+	if req.Until != nil && req.Until.Before(now) {
+		until = *req.Until
+	}
+
+	if !req.Since.Before(until) {
+		return nil, fmt.Errorf("since must be before until")
+	}
+
+	rate := req.RatePerMinute
+	if rate == 0 {
+		rate = DefaultBackfillRatePerMinute
+	}
+
+	if rate < 1 || rate > MaxBackfillRatePerMinute {
+		return nil, fmt.Errorf("ratePerMinute must be between 1 and %d", MaxBackfillRatePerMinute)
+	}
+
+	return &models.CanvasBackfill{
+		WorkflowID:    canvas.ID,
+		TriggerNodeID: req.TriggerNodeID,
+		TargetNodeID:  req.TargetNodeID,
+		Since:         *req.Since,
+		Until:         until,
+		RatePerMinute: rate,
+		State:         models.CanvasBackfillStatePending,
+		NextRunAt:     now,
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}, nil
+}
+
+type invalidBackfillError struct {
+	message string
+}
+
+func (e *invalidBackfillError) Error() string {
+	return e.message
+}
+
+func validateBackfillNodes(tx *gorm.DB, canvas *models.Canvas, backfill *models.CanvasBackfill) error {
+	trigger, err := models.FindCanvasNode(tx, canvas.ID, backfill.TriggerNodeID)
+	if err != nil {
+		return &invalidBackfillError{message: fmt.Sprintf("node %s not found", backfill.TriggerNodeID)}
+	}
+
+	if trigger.Type != models.NodeTypeTrigger {
+		return &invalidBackfillError{message: fmt.Sprintf("node %s is not a trigger", trigger.NodeID)}
+	}
+
+	if backfill.TargetNodeID == nil {
+		return nil
+	}
+
+	target, err := models.FindCanvasNode(tx, canvas.ID, *backfill.TargetNodeID)
+	if err != nil {
+		return &invalidBackfillError{message: fmt.Sprintf("node %s not found", *backfill.TargetNodeID)}
+	}
+
+	if target.Type != models.NodeTypeComponent && target.Type != models.NodeTypeBlueprint {
+		return &invalidBackfillError{message: fmt.Sprintf("node %s cannot be re-run", target.NodeID)}
+	}
+
+	return nil
+}
+
+func serializeCanvasBackfill(backfill *models.CanvasBackfill) CanvasBackfill {
+	return CanvasBackfill{
+		ID:            backfill.ID.String(),
+		TriggerNodeID: backfill.TriggerNodeID,
+		TargetNodeID:  backfill.TargetNodeID,
+		Since:         backfill.Since,
+		Until:         backfill.Until,
+		RatePerMinute: backfill.RatePerMinute,
+		State:         backfill.State,
+		ReplayedCount: backfill.ReplayedCount,
+		SkippedCount:  backfill.SkippedCount,
+		LastEventAt:   backfill.LastEventAt,
+		CreatedAt:     backfill.CreatedAt,
+		UpdatedAt:     backfill.UpdatedAt,
+	}
+}
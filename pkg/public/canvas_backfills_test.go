@@ -0,0 +1,50 @@
+package public
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+func Test__NewCanvasBackfill(t *testing.T) {
+	now := time.Now()
+	canvas := &models.Canvas{ID: uuid.New()}
+	since := now.Add(-24 * time.Hour)
+
+	t.Run("no until and rate -> until now, at the default rate", func(t *testing.T) {
+		backfill, err := newCanvasBackfill(canvas, &CreateCanvasBackfillRequest{TriggerNodeID: "trigger-1", Since: &since}, now)
+		require.NoError(t, err)
+		assert.Equal(t, canvas.ID, backfill.WorkflowID)
+		assert.Equal(t, since, backfill.Since)
+		assert.Equal(t, now, backfill.Until)
+		assert.Equal(t, DefaultBackfillRatePerMinute, backfill.RatePerMinute)
+		assert.Equal(t, models.CanvasBackfillStatePending, backfill.State)
+		assert.Nil(t, backfill.TargetNodeID)
+	})
+
+	t.Run("until in the future -> until now", func(t *testing.T) {
+		until := now.Add(time.Hour)
+		backfill, err := newCanvasBackfill(canvas, &CreateCanvasBackfillRequest{TriggerNodeID: "trigger-1", Since: &since, Until: &until}, now)
+		require.NoError(t, err)
+		assert.Equal(t, now, backfill.Until)
+	})
+
+	t.Run("invalid requests -> error", func(t *testing.T) {
+		_, err := newCanvasBackfill(canvas, &CreateCanvasBackfillRequest{Since: &since}, now)
+		assert.ErrorContains(t, err, "triggerNodeId is required")
+
+		_, err = newCanvasBackfill(canvas, &CreateCanvasBackfillRequest{TriggerNodeID: "trigger-1"}, now)
+		assert.ErrorContains(t, err, "since is required")
+
+		until := since.Add(-time.Hour)
+		_, err = newCanvasBackfill(canvas, &CreateCanvasBackfillRequest{TriggerNodeID: "trigger-1", Since: &since, Until: &until}, now)
+		assert.ErrorContains(t, err, "since must be before until")
+
+		_, err = newCanvasBackfill(canvas, &CreateCanvasBackfillRequest{TriggerNodeID: "trigger-1", Since: &since, RatePerMinute: MaxBackfillRatePerMinute + 1}, now)
+		assert.ErrorContains(t, err, "ratePerMinute must be between")
+	})
+}
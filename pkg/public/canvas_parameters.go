@@ -0,0 +1,96 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type CanvasParameters struct {
+	Environment string                   `json:"environment"`
+	Parameters  []models.CanvasParameter `json:"parameters"`
+}
+
+func (s *Server) getCanvasParameters(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "read")
+	if !ok {
+		return
+	}
+
+	respondJSON(w, canvasParametersResponse(canvas))
+}
+
+/*
+ * Replaces the parameters of a canvas, and the environment
+ * whose overrides are used when resolving them.
+ */
+func (s *Server) updateCanvasParameters(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "update")
+	if !ok {
+		return
+	}
+
+	var req CanvasParameters
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Parameters == nil {
+		req.Parameters = []models.CanvasParameter{}
+	}
+
+	if err := models.ValidateCanvasParameters(req.Environment, req.Parameters); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	names := make([]string, 0, len(req.Parameters))
+	for _, parameter := range req.Parameters {
+		names = append(names, parameter.Name)
+	}
+
+	user, _ := middleware.GetUserFromContext(r.Context())
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		if err := canvas.UpdateParametersInTransaction(tx, req.Environment, req.Parameters); err != nil {
+			return err
+		}
+
+		return models.CreateAuditLogEntryInTransaction(tx, &models.AuditLogEntry{
+			OrganizationID: canvas.OrganizationID,
+			UserID:         &user.ID,
+			WorkflowID:     &canvas.ID,
+			Action:         models.AuditActionCanvasParametersUpdated,
+			Details: datatypes.NewJSONType(map[string]any{
+				"environment": req.Environment,
+				"parameters":  names,
+			}),
+		})
+	})
+
+	if err != nil {
+		log.Errorf("error updating parameters for canvas %s: %v", canvas.ID, err)
+		http.Error(w, "error updating parameters", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, canvasParametersResponse(canvas))
+}
+
+func canvasParametersResponse(canvas *models.Canvas) CanvasParameters {
+	parameters := []models.CanvasParameter(canvas.Parameters)
+	if parameters == nil {
+		parameters = []models.CanvasParameter{}
+	}
+
+	return CanvasParameters{
+		Environment: canvas.Environment,
+		Parameters:  parameters,
+	}
+}
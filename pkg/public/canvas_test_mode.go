@@ -0,0 +1,72 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type CanvasTestMode struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (s *Server) getCanvasTestMode(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "read")
+	if !ok {
+		return
+	}
+
+	respondJSON(w, CanvasTestMode{Enabled: canvas.TestMode})
+}
+
+/*
+ * Turns test mode on or off for a canvas.
+ * In test mode, components emit their example output instead
+ * of changing external systems, unless they handle test mode themselves.
+ */
+func (s *Server) updateCanvasTestMode(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "update")
+	if !ok {
+		return
+	}
+
+	var req CanvasTestMode
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Enabled == canvas.TestMode {
+		respondJSON(w, req)
+		return
+	}
+
+	user, _ := middleware.GetUserFromContext(r.Context())
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		if err := canvas.UpdateTestModeInTransaction(tx, req.Enabled); err != nil {
+			return err
+		}
+
+		return models.CreateAuditLogEntryInTransaction(tx, &models.AuditLogEntry{
+			OrganizationID: canvas.OrganizationID,
+			UserID:         &user.ID,
+			WorkflowID:     &canvas.ID,
+			Action:         models.AuditActionCanvasTestModeUpdated,
+			Details:        datatypes.NewJSONType(map[string]any{"enabled": req.Enabled}),
+		})
+	})
+
+	if err != nil {
+		log.Errorf("error updating test mode for canvas %s: %v", canvas.ID, err)
+		http.Error(w, "error updating test mode", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, CanvasTestMode{Enabled: canvas.TestMode})
+}
@@ -0,0 +1,35 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+type CatalogIntegrationsResponse struct {
+	Integrations []registry.CatalogIntegration `json:"integrations"`
+}
+
+type CatalogComponentsResponse struct {
+	Components []registry.CatalogComponent `json:"components"`
+}
+
+type CatalogTriggersResponse struct {
+	Triggers []registry.CatalogTrigger `json:"triggers"`
+}
+
+/*
+ * The catalog only describes what is registered in this instance,
+ * so it is the same for every organization.
+ */
+func (s *Server) listCatalogIntegrations(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, CatalogIntegrationsResponse{Integrations: s.registry.Catalog().ListIntegrations()})
+}
+
+func (s *Server) listCatalogComponents(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, CatalogComponentsResponse{Components: s.registry.Catalog().ListComponents()})
+}
+
+func (s *Server) listCatalogTriggers(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, CatalogTriggersResponse{Triggers: s.registry.Catalog().ListTriggers()})
+}
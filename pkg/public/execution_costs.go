@@ -0,0 +1,206 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+)
+
+// Period summed by the canvas costs endpoint when since is not given.
+const DefaultCanvasCostsPeriod = 30 * 24 * time.Hour
+
+type ExecutionCost struct {
+	Amount      float64    `json:"amount"`
+	Unit        string     `json:"unit"`
+	Description *string    `json:"description,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt"`
+}
+
+type CostTotal struct {
+	NodeID     string  `json:"nodeId,omitempty"`
+	Unit       string  `json:"unit"`
+	Amount     float64 `json:"amount"`
+	Executions int     `json:"executions,omitempty"`
+}
+
+type ExecutionCostsResponse struct {
+	Costs  []ExecutionCost `json:"costs"`
+	Totals []CostTotal     `json:"totals"`
+}
+
+type CanvasCostsResponse struct {
+	Since  time.Time   `json:"since"`
+	Until  time.Time   `json:"until"`
+	Totals []CostTotal `json:"totals"`
+	Nodes  []CostTotal `json:"nodes"`
+}
+
+/*
+ * Lists the cost hints reported for an execution, and their totals by unit.
+ */
+func (s *Server) listExecutionCosts(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "read")
+	if !ok {
+		return
+	}
+
+	executionID, err := uuid.Parse(mux.Vars(r)["executionId"])
+	if err != nil {
+		http.Error(w, "execution not found", http.StatusNotFound)
+		return
+	}
+
+	execution, err := models.FindNodeExecution(canvas.ID, executionID)
+	if err != nil {
+		http.Error(w, "execution not found", http.StatusNotFound)
+		return
+	}
+
+	costs, err := models.ListNodeExecutionCosts(execution.ID)
+	if err != nil {
+		log.Errorf("error listing costs for execution %s: %v", execution.ID, err)
+		http.Error(w, "error listing execution costs", http.StatusInternalServerError)
+		return
+	}
+
+	response := ExecutionCostsResponse{
+		Costs:  make([]ExecutionCost, 0, len(costs)),
+		Totals: []CostTotal{},
+	}
+
+	totals := map[string]int{}
+	for _, cost := range costs {
+		response.Costs = append(response.Costs, ExecutionCost{
+			Amount:      cost.Amount,
+			Unit:        cost.Unit,
+			Description: cost.Description,
+			CreatedAt:   cost.CreatedAt,
+		})
+
+		i, ok := totals[cost.Unit]
+		if !ok {
+			i = len(response.Totals)
+			totals[cost.Unit] = i
+			response.Totals = append(response.Totals, CostTotal{Unit: cost.Unit})
+		}
+
+		response.Totals[i].Amount += cost.Amount
+	}
+
+	respondJSON(w, response)
+}
+
+/*
+ * Sums the cost hints reported for a canvas by unit, and by node and unit,
+ * between since and until, which default to the last 30 days.
+ */
+func (s *Server) sumCanvasCosts(w http.ResponseWriter, r *http.Request) {
+	canvas, ok := s.findCanvas(w, r, "read")
+	if !ok {
+		return
+	}
+
+	since, until, err := parseCanvasCostsQuery(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	nodes, err := models.SumCanvasCostsByNode(canvas.ID, since, until)
+	if err != nil {
+		log.Errorf("error summing costs for canvas %s: %v", canvas.ID, err)
+		http.Error(w, "error summing canvas costs", http.StatusInternalServerError)
+		return
+	}
+
+	response := CanvasCostsResponse{
+		Since:  since,
+		Until:  until,
+		Totals: []CostTotal{},
+		Nodes:  make([]CostTotal, 0, len(nodes)),
+	}
+
+	totals := map[string]int{}
+	for _, node := range nodes {
+		response.Nodes = append(response.Nodes, CostTotal{
+			NodeID:     node.NodeID,
+			Unit:       node.Unit,
+			Amount:     node.Amount,
+			Executions: node.Executions,
+		})
+
+		i, ok := totals[node.Unit]
+		if !ok {
+			i = len(response.Totals)
+			totals[node.Unit] = i
+			response.Totals = append(response.Totals, CostTotal{Unit: node.Unit})
+		}
+
+		response.Totals[i].Amount += node.Amount
+		response.Totals[i].Executions += node.Executions
+	}
+
+	respondJSON(w, response)
+}
+
+func (s *Server) findCanvas(w http.ResponseWriter, r *http.Request, action string) (*models.Canvas, bool) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	allowed, err := s.authService.CheckOrganizationPermission(user.ID.String(), user.OrganizationID.String(), "canvases", action)
+	if err != nil || !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return nil, false
+	}
+
+	canvasID, err := uuid.Parse(mux.Vars(r)["canvasId"])
+	if err != nil {
+		http.Error(w, "canvas not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	canvas, err := models.FindCanvas(user.OrganizationID, canvasID)
+	if err != nil {
+		http.Error(w, "canvas not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	return canvas, true
+}
+
+func parseCanvasCostsQuery(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	until := now
+	if v := r.URL.Query().Get("until"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid until: %q", v)
+		}
+
+		until = parsed
+	}
+
+	since := until.Add(-DefaultCanvasCostsPeriod)
+	if v := r.URL.Query().Get("since"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid since: %q", v)
+		}
+
+		since = parsed
+	}
+
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since must be before until")
+	}
+
+	return since, until, nil
+}
@@ -0,0 +1,42 @@
+package public
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__ParseCanvasCostsQuery(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	query := func(since, until time.Time) string {
+		return fmt.Sprintf("/api/v1/canvases/123/costs?since=%s&until=%s", since.Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+
+	t.Run("no parameters -> last 30 days", func(t *testing.T) {
+		since, until, err := parseCanvasCostsQuery(httptest.NewRequest("GET", "/api/v1/canvases/123/costs", nil), now)
+		require.NoError(t, err)
+		assert.Equal(t, now, until)
+		assert.Equal(t, now.Add(-DefaultCanvasCostsPeriod), since)
+	})
+
+	t.Run("since and until -> used as given", func(t *testing.T) {
+		expectedSince := now.Add(-48 * time.Hour)
+		expectedUntil := now.Add(-24 * time.Hour)
+		since, until, err := parseCanvasCostsQuery(httptest.NewRequest("GET", query(expectedSince, expectedUntil), nil), now)
+		require.NoError(t, err)
+		assert.Equal(t, expectedSince, since)
+		assert.Equal(t, expectedUntil, until)
+	})
+
+	t.Run("invalid period -> error", func(t *testing.T) {
+		_, _, err := parseCanvasCostsQuery(httptest.NewRequest("GET", "/api/v1/canvases/123/costs?since=yesterday", nil), now)
+		assert.ErrorContains(t, err, "invalid since")
+
+		_, _, err = parseCanvasCostsQuery(httptest.NewRequest("GET", query(now.Add(-24*time.Hour), now.Add(-48*time.Hour)), nil), now)
+		assert.ErrorContains(t, err, "since must be before until")
+	})
+}
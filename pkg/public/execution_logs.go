@@ -0,0 +1,123 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+)
+
+const (
+	DefaultExecutionLogsLimit = 500
+	MaxExecutionLogsLimit     = 5000
+)
+
+type ExecutionLog struct {
+	ID        int64      `json:"id"`
+	Message   string     `json:"message"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+type ExecutionLogsResponse struct {
+	Logs     []ExecutionLog `json:"logs"`
+	Finished bool           `json:"finished"`
+}
+
+/*
+ * Lists the logs of an execution written after the `after` log ID.
+ * Clients follow a running execution by polling with the ID of the last log received,
+ * until the execution is finished and no more logs are returned.
+ */
+func (s *Server) listExecutionLogs(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	allowed, err := s.authService.CheckOrganizationPermission(user.ID.String(), user.OrganizationID.String(), "canvases", "read")
+	if err != nil || !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	vars := mux.Vars(r)
+	canvasID, err := uuid.Parse(vars["canvasId"])
+	if err != nil {
+		http.Error(w, "canvas not found", http.StatusNotFound)
+		return
+	}
+
+	executionID, err := uuid.Parse(vars["executionId"])
+	if err != nil {
+		http.Error(w, "execution not found", http.StatusNotFound)
+		return
+	}
+
+	after, limit, err := parseExecutionLogsQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	canvas, err := models.FindCanvas(user.OrganizationID, canvasID)
+	if err != nil {
+		http.Error(w, "canvas not found", http.StatusNotFound)
+		return
+	}
+
+	execution, err := models.FindNodeExecution(canvas.ID, executionID)
+	if err != nil {
+		http.Error(w, "execution not found", http.StatusNotFound)
+		return
+	}
+
+	logs, err := models.ListNodeExecutionLogs(execution.ID, after, limit)
+	if err != nil {
+		log.Errorf("error listing logs for execution %s: %v", execution.ID, err)
+		http.Error(w, "error listing logs", http.StatusInternalServerError)
+		return
+	}
+
+	response := ExecutionLogsResponse{
+		Logs:     make([]ExecutionLog, 0, len(logs)),
+		Finished: execution.State == models.CanvasNodeExecutionStateFinished,
+	}
+
+	for _, l := range logs {
+		response.Logs = append(response.Logs, ExecutionLog{ID: l.ID, Message: l.Message, CreatedAt: l.CreatedAt})
+	}
+
+	respondJSON(w, response)
+}
+
+func parseExecutionLogsQuery(r *http.Request) (int64, int, error) {
+	after := int64(0)
+	limit := DefaultExecutionLogsLimit
+
+	if v := r.URL.Query().Get("after"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 0 {
+			return 0, 0, fmt.Errorf("invalid after: %q", v)
+		}
+
+		after = parsed
+	}
+
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			return 0, 0, fmt.Errorf("invalid limit: %q", v)
+		}
+
+		limit = min(parsed, MaxExecutionLogsLimit)
+	}
+
+	return after, limit, nil
+}
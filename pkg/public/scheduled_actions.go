@@ -0,0 +1,101 @@
+package public
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+)
+
+type ScheduledAction struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	ActionName string         `json:"actionName,omitempty"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+	RunAt      time.Time      `json:"runAt"`
+	Attempts   int            `json:"attempts"`
+	LastError  *string        `json:"lastError,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+type ScheduledActionsResponse struct {
+	ScheduledActions []ScheduledAction `json:"scheduledActions"`
+}
+
+/*
+ * Lists the pending scheduled actions of an execution,
+ * e.g. polls and timeouts, to debug executions that seem stuck waiting.
+ */
+func (s *Server) listScheduledActions(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	allowed, err := s.authService.CheckOrganizationPermission(user.ID.String(), user.OrganizationID.String(), "canvases", "read")
+	if err != nil || !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	vars := mux.Vars(r)
+	canvasID, err := uuid.Parse(vars["canvasId"])
+	if err != nil {
+		http.Error(w, "canvas not found", http.StatusNotFound)
+		return
+	}
+
+	executionID, err := uuid.Parse(vars["executionId"])
+	if err != nil {
+		http.Error(w, "execution not found", http.StatusNotFound)
+		return
+	}
+
+	canvas, err := models.FindCanvas(user.OrganizationID, canvasID)
+	if err != nil {
+		http.Error(w, "canvas not found", http.StatusNotFound)
+		return
+	}
+
+	execution, err := models.FindNodeExecution(canvas.ID, executionID)
+	if err != nil {
+		http.Error(w, "execution not found", http.StatusNotFound)
+		return
+	}
+
+	requests, err := models.ListPendingExecutionRequests(canvas.ID, execution.ID)
+	if err != nil {
+		log.Errorf("error listing scheduled actions for execution %s: %v", execution.ID, err)
+		http.Error(w, "error listing scheduled actions", http.StatusInternalServerError)
+		return
+	}
+
+	response := ScheduledActionsResponse{
+		ScheduledActions: make([]ScheduledAction, 0, len(requests)),
+	}
+
+	for _, request := range requests {
+		action := ScheduledAction{
+			ID:        request.ID.String(),
+			Type:      request.Type,
+			RunAt:     request.RunAt,
+			Attempts:  request.Attempts,
+			LastError: request.LastError,
+			CreatedAt: request.CreatedAt,
+		}
+
+		if spec := request.Spec.Data(); spec.InvokeAction != nil {
+			action.ActionName = spec.InvokeAction.ActionName
+			action.Parameters = spec.InvokeAction.Parameters
+		}
+
+		response.ScheduledActions = append(response.ScheduledActions, action)
+	}
+
+	respondJSON(w, response)
+}
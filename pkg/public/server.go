@@ -60,6 +60,9 @@ const (
 	// Event payload can be up to 64k in size
 	MaxEventSize = 64 * 1024
 
+	// Requests pushed to integrations, e.g. Pub/Sub messages, can be up to 1MB in size
+	MaxIntegrationRequestSize = 1024 * 1024
+
 	// The size of the stage execution outputs can be up to 4k
 	MaxExecutionOutputsSize = 4 * 1024
 )
@@ -79,6 +82,7 @@ type Server struct {
 	WebhooksBaseURL       string
 	wsHub                 *ws.Hub
 	authHandler           *authentication.Handler
+	sourceResolver        *sourceResolver
 	isDev                 bool
 }
 
@@ -121,6 +125,7 @@ func NewServer(
 		oidcProvider:          oidcProvider,
 		registry:              registry,
 		authService:           authorizationService,
+		sourceResolver:        newSourceResolverFromEnv(),
 		upgrader: &websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				// Allow all connections - you may want to restrict this in production
@@ -419,6 +424,15 @@ func (s *Server) InitRouter(additionalMiddlewares ...mux.MiddlewareFunc) {
 		HandleFunc(s.BasePath+"/webhooks/{webhookID}", s.HandleWebhook).
 		Methods("POST")
 
+	//
+	// Inbound requests received for integrations, and their replay.
+	// Registered before the integration endpoints below, which match every path under them.
+	//
+	deliveriesRoute := r.PathPrefix("/api/v1/integrations/{integrationId}/deliveries").Subrouter()
+	deliveriesRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	deliveriesRoute.HandleFunc("", s.listWebhookDeliveries).Methods("GET")
+	deliveriesRoute.HandleFunc("/{deliveryId}/replay", s.replayWebhookDelivery).Methods("POST")
+
 	//
 	// HTTP endpoints for app installations
 	// Match all paths under /integrations/{integrationID}/ including subpaths
@@ -433,6 +447,57 @@ func (s *Server) InitRouter(additionalMiddlewares ...mux.MiddlewareFunc) {
 	accountRoute.HandleFunc("/organizations", s.listAccountOrganizations).Methods("GET")
 	accountRoute.HandleFunc("/organizations", s.createOrganization).Methods("POST")
 
+	// Catalog of integrations, components and triggers, for the UI and the CLI
+	catalogRoute := r.PathPrefix("/api/v1/catalog").Subrouter()
+	catalogRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	catalogRoute.HandleFunc("/integrations", s.listCatalogIntegrations).Methods("GET")
+	catalogRoute.HandleFunc("/components", s.listCatalogComponents).Methods("GET")
+	catalogRoute.HandleFunc("/triggers", s.listCatalogTriggers).Methods("GET")
+
+	// Execution logs, registered before the gRPC gateway handles the rest of /api/v1/canvases
+	executionLogsRoute := r.Path("/api/v1/canvases/{canvasId}/executions/{executionId}/logs").Subrouter()
+	executionLogsRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	executionLogsRoute.Methods("GET").HandlerFunc(s.listExecutionLogs)
+
+	// Pending scheduled actions of an execution, for debugging executions stuck waiting
+	scheduledActionsRoute := r.Path("/api/v1/canvases/{canvasId}/executions/{executionId}/scheduled-actions").Subrouter()
+	scheduledActionsRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	scheduledActionsRoute.Methods("GET").HandlerFunc(s.listScheduledActions)
+
+	// Cost hints reported by components, per execution and per canvas
+	executionCostsRoute := r.Path("/api/v1/canvases/{canvasId}/executions/{executionId}/costs").Subrouter()
+	executionCostsRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	executionCostsRoute.Methods("GET").HandlerFunc(s.listExecutionCosts)
+
+	canvasCostsRoute := r.Path("/api/v1/canvases/{canvasId}/costs").Subrouter()
+	canvasCostsRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	canvasCostsRoute.Methods("GET").HandlerFunc(s.sumCanvasCosts)
+
+	// Backfills, replaying the past events of a trigger through a canvas or a node
+	backfillsRoute := r.PathPrefix("/api/v1/canvases/{canvasId}/backfills").Subrouter()
+	backfillsRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	backfillsRoute.HandleFunc("", s.listCanvasBackfills).Methods("GET")
+	backfillsRoute.HandleFunc("", s.createCanvasBackfill).Methods("POST")
+	backfillsRoute.HandleFunc("/{backfillId}", s.getCanvasBackfill).Methods("GET")
+	backfillsRoute.HandleFunc("/{backfillId}/cancel", s.cancelCanvasBackfill).Methods("POST")
+
+	// Test mode of a canvas, in which components do not change external systems
+	testModeRoute := r.Path("/api/v1/canvases/{canvasId}/test-mode").Subrouter()
+	testModeRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	testModeRoute.Methods("GET").HandlerFunc(s.getCanvasTestMode)
+	testModeRoute.Methods("PUT").HandlerFunc(s.updateCanvasTestMode)
+
+	// Parameters of a canvas, referenced from node configurations with {{ params.<name> }}
+	parametersRoute := r.Path("/api/v1/canvases/{canvasId}/parameters").Subrouter()
+	parametersRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	parametersRoute.Methods("GET").HandlerFunc(s.getCanvasParameters)
+	parametersRoute.Methods("PUT").HandlerFunc(s.updateCanvasParameters)
+
+	// Audit log of node changes, manual actions and secret accesses
+	auditLogRoute := r.Path("/api/v1/audit-log").Subrouter()
+	auditLogRoute.Use(middleware.OrganizationAuthMiddleware(s.jwt))
+	auditLogRoute.Methods("GET").HandlerFunc(s.listAuditLog)
+
 	// Apply additional middlewares
 	for _, middleware := range additionalMiddlewares {
 		publicRoute.Use(middleware)
@@ -503,6 +568,45 @@ func (s *Server) HandleIntegrationRequest(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if !s.validateSource(w, r, integrationInstance) {
+		return
+	}
+
+	//
+	// Requests pushed to the integration, e.g. Pub/Sub messages,
+	// are kept, so they can be inspected and replayed.
+	//
+	if r.Method != http.MethodPost {
+		s.handleIntegrationRequest(w, r, integrationInstance, integration)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxIntegrationRequestSize)
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		if _, ok := err.(*http.MaxBytesError); ok {
+			http.Error(
+				w,
+				fmt.Sprintf("Request body is too large - must be up to %d bytes", MaxIntegrationRequestSize),
+				http.StatusRequestEntityTooLarge,
+			)
+
+			return
+		}
+
+		http.Error(w, "Error reading request body", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	recorder := newDeliveryRecorder(w)
+	s.handleIntegrationRequest(recorder, r, integrationInstance, integration)
+	s.recordWebhookDelivery(r, integrationInstance, nil, body, recorder)
+}
+
+func (s *Server) handleIntegrationRequest(w http.ResponseWriter, r *http.Request, integrationInstance *models.Integration, integration core.Integration) {
 	newEvents := []models.CanvasEvent{}
 	onNewEvents := func(events []models.CanvasEvent) {
 		newEvents = append(newEvents, events...)
@@ -526,7 +630,7 @@ func (s *Server) HandleIntegrationRequest(w http.ResponseWriter, r *http.Request
 		),
 	})
 
-	err = database.Conn().Save(&integrationInstance).Error
+	err := database.Conn().Save(&integrationInstance).Error
 	if err != nil {
 		http.Error(w, "integration not found", http.StatusNotFound)
 		return
@@ -748,7 +852,7 @@ func (s *Server) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = models.FindWebhook(webhookID)
+	webhook, err := models.FindWebhook(webhookID)
 	if err != nil {
 		http.Error(w, "webhook not found", http.StatusNotFound)
 		return
@@ -773,6 +877,31 @@ func (s *Server) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	//
+	// Requests received for integration webhooks are kept,
+	// so they can be inspected and replayed.
+	//
+	if webhook.AppInstallationID == nil {
+		s.handleWebhookNodes(w, r, webhookID, body)
+		return
+	}
+
+	integration, err := models.FindUnscopedIntegration(*webhook.AppInstallationID)
+	if err != nil {
+		s.handleWebhookNodes(w, r, webhookID, body)
+		return
+	}
+
+	if !s.validateSource(w, r, integration) {
+		return
+	}
+
+	recorder := newDeliveryRecorder(w)
+	s.handleWebhookNodes(recorder, r, webhookID, body)
+	s.recordWebhookDelivery(r, integration, &webhook.ID, body, recorder)
+}
+
+func (s *Server) handleWebhookNodes(w http.ResponseWriter, r *http.Request, webhookID uuid.UUID, body []byte) {
 	nodes, err := models.FindWebhookNodes(webhookID)
 	if err != nil {
 		http.Error(w, "webhook not found", http.StatusNotFound)
@@ -851,6 +980,7 @@ func (s *Server) executeTriggerNode(ctx context.Context, body []byte, headers ht
 		Logger:        logger,
 		HTTP:          s.registry.HTTPContext(),
 		Webhook:       contexts.NewNodeWebhookContext(ctx, tx, s.encryptor, &node, s.BaseURL+s.BasePath),
+		WebhookSecret: contexts.NewNodeWebhookSecretContext(ctx, tx, s.encryptor, &node),
 		Events:        contexts.NewEventContext(tx, &node, onNewEvents),
 		Integration:   integrationCtx,
 	})
@@ -886,6 +1016,7 @@ func (s *Server) executeComponentNode(ctx context.Context, body []byte, headers
 		Logger:        logger,
 		HTTP:          s.registry.HTTPContext(),
 		Webhook:       contexts.NewNodeWebhookContext(ctx, tx, s.encryptor, &node, s.BaseURL+s.BasePath),
+		WebhookSecret: contexts.NewNodeWebhookSecretContext(ctx, tx, s.encryptor, &node),
 		Events:        contexts.NewEventContext(tx, &node, onNewEvents),
 		Integration:   integrationCtx,
 		FindExecutionByKV: func(key string, value string) (*core.ExecutionContext, error) {
@@ -908,6 +1039,8 @@ func (s *Server) executeComponentNode(ctx context.Context, body []byte, headers
 				Logger:         logging.ForExecution(execution, nil),
 				Notifications:  contexts.NewNotificationContext(tx, uuid.Nil, execution.WorkflowID),
 				CanvasMemory:   contexts.NewCanvasMemoryContext(tx, execution.WorkflowID),
+				Logs:           contexts.NewExecutionLogsContext(execution),
+				Costs:          contexts.NewExecutionCostsContext(execution),
 			}, nil
 		},
 	})
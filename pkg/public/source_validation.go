@@ -0,0 +1,148 @@
+package public
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+// The value of the client certificate header set by the proxy
+// when the client certificate is verified, like nginx's $ssl_client_verify.
+const clientCertificateVerified = "SUCCESS"
+
+/*
+ * sourceResolver finds where inbound events come from, see core.RequestSource.
+ *
+ * Requests usually reach SuperPlane through a load balancer or ingress,
+ * so the client IP is taken from X-Forwarded-For, but only when the request
+ * comes from one of the trusted proxies, since anyone can set the header.
+ * In the same way, the client certificate is verified by the proxy
+ * terminating TLS, which reports the result in a header.
+ */
+type sourceResolver struct {
+	trustedProxies          []*net.IPNet
+	clientCertificateHeader string
+}
+
+/*
+ * newSourceResolverFromEnv reads the trusted proxies from TRUSTED_PROXIES,
+ * as a comma-separated list of IP ranges in CIDR notation, and the header
+ * with the result of the client certificate verification from CLIENT_CERTIFICATE_VERIFY_HEADER.
+ */
+func newSourceResolverFromEnv() *sourceResolver {
+	resolver := &sourceResolver{
+		clientCertificateHeader: strings.TrimSpace(os.Getenv("CLIENT_CERTIFICATE_VERIFY_HEADER")),
+	}
+
+	for _, value := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			log.Warnf("Ignoring invalid trusted proxy %q: %v", value, err)
+			continue
+		}
+
+		resolver.trustedProxies = append(resolver.trustedProxies, network)
+	}
+
+	return resolver
+}
+
+func (s *sourceResolver) Resolve(r *http.Request) core.RequestSource {
+	peer := parseIP(r.RemoteAddr)
+	source := core.RequestSource{
+		IP:                        peer,
+		ClientCertificateVerified: r.TLS != nil && len(r.TLS.VerifiedChains) > 0,
+	}
+
+	if !s.trusted(peer) {
+		return source
+	}
+
+	if s.clientCertificateHeader != "" && r.Header.Get(s.clientCertificateHeader) == clientCertificateVerified {
+		source.ClientCertificateVerified = true
+	}
+
+	//
+	// Each proxy appends the address it received the request from,
+	// so the client is the last address not belonging to a trusted proxy.
+	//
+	forwarded := []string{}
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := parseIP(forwarded[i])
+		if ip == nil {
+			return source
+		}
+
+		source.IP = ip
+		if !s.trusted(ip) {
+			return source
+		}
+	}
+
+	return source
+}
+
+func (s *sourceResolver) trusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range s.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseIP(address string) net.IP {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	return net.ParseIP(address)
+}
+
+/*
+ * validateSource checks the request against the source validation
+ * configured for the integration, if any. If the request is not accepted,
+ * the response is written, and false is returned.
+ */
+func (s *Server) validateSource(w http.ResponseWriter, r *http.Request, integration *models.Integration) bool {
+	validation, err := core.SourceValidationFromConfiguration(integration.Configuration.Data())
+	if err != nil {
+		log.Errorf("Error reading source validation for integration %s: %v", integration.ID, err)
+		http.Error(w, "invalid source validation", http.StatusInternalServerError)
+		return false
+	}
+
+	if validation == nil {
+		return true
+	}
+
+	source := s.sourceResolver.Resolve(r)
+	status, err := validation.Check(r.Header, source)
+	if err != nil {
+		log.Infof("Rejected request to integration %s from %s: %v", integration.ID, source.IP, err)
+		http.Error(w, err.Error(), status)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,57 @@
+package public
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceResolver_Resolve(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, invalid")
+	t.Setenv("CLIENT_CERTIFICATE_VERIFY_HEADER", "X-SSL-Client-Verify")
+	resolver := newSourceResolverFromEnv()
+
+	t.Run("untrusted peer ignores forwarded headers", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "203.0.113.7:1234"
+		r.Header.Set("X-Forwarded-For", "198.51.100.1")
+		r.Header.Set("X-SSL-Client-Verify", "SUCCESS")
+
+		source := resolver.Resolve(r)
+		assert.Equal(t, "203.0.113.7", source.IP.String())
+		assert.False(t, source.ClientCertificateVerified)
+	})
+
+	t.Run("trusted proxies are skipped", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "10.0.0.2:1234"
+		r.Header.Add("X-Forwarded-For", "1.2.3.4, 198.51.100.1")
+		r.Header.Add("X-Forwarded-For", "10.0.0.3")
+		r.Header.Set("X-SSL-Client-Verify", "SUCCESS")
+
+		source := resolver.Resolve(r)
+		assert.Equal(t, "198.51.100.1", source.IP.String())
+		assert.True(t, source.ClientCertificateVerified)
+	})
+
+	t.Run("failed client certificate verification", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "10.0.0.2:1234"
+		r.Header.Set("X-SSL-Client-Verify", "FAILED:certificate has expired")
+
+		source := resolver.Resolve(r)
+		assert.Equal(t, "10.0.0.2", source.IP.String())
+		assert.False(t, source.ClientCertificateVerified)
+	})
+
+	t.Run("client certificate verified by the server", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "203.0.113.7:1234"
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+
+		assert.True(t, resolver.Resolve(r).ClientCertificateVerified)
+	})
+}
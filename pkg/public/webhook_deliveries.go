@@ -0,0 +1,301 @@
+package public
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+	"gorm.io/datatypes"
+)
+
+const (
+	DefaultWebhookDeliveriesLimit = 50
+	MaxWebhookDeliveriesLimit     = 500
+
+	// How much of the response body is kept as the error of a failed delivery.
+	maxDeliveryErrorSize = 1024
+)
+
+var redactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "X-Api-Key"}
+var redactedQueryParams = []string{"token", "secret", "key", "code"}
+
+type replayOfKey struct{}
+
+/*
+ * deliveryRecorder keeps the status code and error written for a delivery.
+ */
+type deliveryRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	errorBody  bytes.Buffer
+}
+
+func newDeliveryRecorder(w http.ResponseWriter) *deliveryRecorder {
+	return &deliveryRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+}
+
+func (r *deliveryRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *deliveryRecorder) Write(b []byte) (int, error) {
+	if r.statusCode >= http.StatusBadRequest && r.errorBody.Len() < maxDeliveryErrorSize {
+		r.errorBody.Write(b[:min(len(b), maxDeliveryErrorSize-r.errorBody.Len())])
+	}
+
+	return r.ResponseWriter.Write(b)
+}
+
+func (s *Server) recordWebhookDelivery(r *http.Request, integration *models.Integration, webhookID *uuid.UUID, body []byte, recorder *deliveryRecorder) {
+	delivery := models.WebhookDelivery{
+		OrganizationID:    integration.OrganizationID,
+		AppInstallationID: integration.ID,
+		WebhookID:         webhookID,
+		Method:            r.Method,
+		URL:               r.URL.RequestURI(),
+		Headers:           datatypes.NewJSONType(map[string][]string(r.Header.Clone())),
+		Body:              body,
+		StatusCode:        recorder.statusCode,
+	}
+
+	if replayOf, ok := r.Context().Value(replayOfKey{}).(uuid.UUID); ok {
+		delivery.ReplayOf = &replayOf
+	}
+
+	if recorder.errorBody.Len() > 0 {
+		e := strings.TrimSpace(recorder.errorBody.String())
+		delivery.Error = &e
+	}
+
+	if err := models.CreateWebhookDelivery(&delivery); err != nil {
+		log.Errorf("error recording webhook delivery for integration %s: %v", integration.ID, err)
+	}
+}
+
+type WebhookDelivery struct {
+	ID         string              `json:"id"`
+	WebhookID  *string             `json:"webhookId,omitempty"`
+	ReplayOf   *string             `json:"replayOf,omitempty"`
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	Headers    map[string][]string `json:"headers"`
+	Body       *string             `json:"body,omitempty"`
+	BodyBase64 *string             `json:"bodyBase64,omitempty"`
+	StatusCode int                 `json:"statusCode"`
+	Error      *string             `json:"error,omitempty"`
+	CreatedAt  *time.Time          `json:"createdAt"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+type ReplayWebhookDeliveryResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Body       string `json:"body"`
+}
+
+/*
+ * Lists the inbound requests received for an integration, most recent first.
+ * Credentials in headers and query parameters are redacted.
+ */
+func (s *Server) listWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	integration, ok := s.findDeliveriesIntegration(w, r, "read")
+	if !ok {
+		return
+	}
+
+	before, limit, err := parseWebhookDeliveriesQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := models.ListWebhookDeliveries(integration.ID, before, limit)
+	if err != nil {
+		log.Errorf("error listing webhook deliveries for integration %s: %v", integration.ID, err)
+		http.Error(w, "error listing webhook deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	response := WebhookDeliveriesResponse{Deliveries: make([]WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, serializeWebhookDelivery(delivery))
+	}
+
+	respondJSON(w, response)
+}
+
+/*
+ * Handles a delivery again, through the same webhook or integration endpoint
+ * that received it. The replay is recorded as a new delivery.
+ */
+func (s *Server) replayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	integration, ok := s.findDeliveriesIntegration(w, r, "update")
+	if !ok {
+		return
+	}
+
+	deliveryID, err := uuid.Parse(mux.Vars(r)["deliveryId"])
+	if err != nil {
+		http.Error(w, "delivery not found", http.StatusNotFound)
+		return
+	}
+
+	delivery, err := models.FindWebhookDelivery(integration.ID, deliveryID)
+	if err != nil {
+		http.Error(w, "delivery not found", http.StatusNotFound)
+		return
+	}
+
+	if delivery.Body == nil {
+		http.Error(w, "delivery body was not kept and cannot be replayed", http.StatusUnprocessableEntity)
+		return
+	}
+
+	ctx := context.WithValue(context.Background(), replayOfKey{}, delivery.ID)
+	req, err := http.NewRequestWithContext(ctx, delivery.Method, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid delivery: %v", err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	req.Header = http.Header(delivery.Headers.Data())
+	recorder := httptest.NewRecorder()
+
+	if delivery.WebhookID != nil {
+		s.HandleWebhook(recorder, mux.SetURLVars(req, map[string]string{"webhookID": delivery.WebhookID.String()}))
+	} else {
+		s.HandleIntegrationRequest(recorder, mux.SetURLVars(req, map[string]string{"integrationID": integration.ID.String()}))
+	}
+
+	respondJSON(w, ReplayWebhookDeliveryResponse{
+		StatusCode: recorder.Code,
+		Body:       recorder.Body.String(),
+	})
+}
+
+func (s *Server) findDeliveriesIntegration(w http.ResponseWriter, r *http.Request, action string) (*models.Integration, bool) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	allowed, err := s.authService.CheckOrganizationPermission(user.ID.String(), user.OrganizationID.String(), "integrations", action)
+	if err != nil || !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return nil, false
+	}
+
+	integrationID, err := uuid.Parse(mux.Vars(r)["integrationId"])
+	if err != nil {
+		http.Error(w, "integration not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	integration, err := models.FindIntegration(user.OrganizationID, integrationID)
+	if err != nil {
+		http.Error(w, "integration not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	return integration, true
+}
+
+func serializeWebhookDelivery(delivery models.WebhookDelivery) WebhookDelivery {
+	d := WebhookDelivery{
+		ID:         delivery.ID.String(),
+		WebhookID:  uuidString(delivery.WebhookID),
+		ReplayOf:   uuidString(delivery.ReplayOf),
+		Method:     delivery.Method,
+		URL:        redactURL(delivery.URL),
+		Headers:    redactHeaders(delivery.Headers.Data()),
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		CreatedAt:  delivery.CreatedAt,
+	}
+
+	if delivery.Body != nil {
+		if utf8.Valid(delivery.Body) {
+			body := string(delivery.Body)
+			d.Body = &body
+		} else {
+			body := base64.StdEncoding.EncodeToString(delivery.Body)
+			d.BodyBase64 = &body
+		}
+	}
+
+	return d
+}
+
+func redactHeaders(headers map[string][]string) map[string][]string {
+	redacted := http.Header{}
+	for name, values := range headers {
+		redacted[name] = values
+	}
+
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[REDACTED]")
+		}
+	}
+
+	return redacted
+}
+
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	for _, name := range redactedQueryParams {
+		if query.Has(name) {
+			query.Set(name, "REDACTED")
+		}
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func parseWebhookDeliveriesQuery(r *http.Request) (*time.Time, int, error) {
+	var before *time.Time
+	limit := DefaultWebhookDeliveriesLimit
+
+	if v := r.URL.Query().Get("before"); v != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid before: %q", v)
+		}
+
+		before = &parsed
+	}
+
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			return nil, 0, fmt.Errorf("invalid limit: %q", v)
+		}
+
+		limit = min(parsed, MaxWebhookDeliveriesLimit)
+	}
+
+	return before, limit, nil
+}
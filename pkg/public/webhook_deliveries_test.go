@@ -0,0 +1,83 @@
+package public
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/jwt"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/test/support"
+)
+
+func Test__RedactWebhookDelivery(t *testing.T) {
+	t.Run("credentials in headers are redacted", func(t *testing.T) {
+		headers := redactHeaders(map[string][]string{
+			"Authorization":   {"Bearer abc"},
+			"Content-Type":    {"application/json"},
+			"X-Hub-Signature": {"sha256=123"},
+		})
+
+		assert.Equal(t, []string{"[REDACTED]"}, headers["Authorization"])
+		assert.Equal(t, []string{"application/json"}, headers["Content-Type"])
+		assert.Equal(t, []string{"sha256=123"}, headers["X-Hub-Signature"])
+	})
+
+	t.Run("credentials in query parameters are redacted", func(t *testing.T) {
+		assert.Equal(t, "/api/v1/integrations/123/events?token=REDACTED", redactURL("/api/v1/integrations/123/events?token=secret"))
+		assert.Equal(t, "/webhooks/123?page=2", redactURL("/webhooks/123?page=2"))
+	})
+}
+
+func Test__DeliveryRecorder(t *testing.T) {
+	t.Run("successful response -> no error kept", func(t *testing.T) {
+		recorder := newDeliveryRecorder(httptest.NewRecorder())
+		recorder.WriteHeader(http.StatusOK)
+		_, _ = recorder.Write([]byte("ok"))
+
+		assert.Equal(t, http.StatusOK, recorder.statusCode)
+		assert.Zero(t, recorder.errorBody.Len())
+	})
+
+	t.Run("failed response -> error kept, up to the limit", func(t *testing.T) {
+		recorder := newDeliveryRecorder(httptest.NewRecorder())
+		http.Error(recorder, strings.Repeat("a", 2*maxDeliveryErrorSize), http.StatusBadRequest)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.statusCode)
+		assert.Equal(t, maxDeliveryErrorSize, recorder.errorBody.Len())
+	})
+}
+
+func Test__WebhookDeliveriesRoutes(t *testing.T) {
+	registry, err := registry.NewRegistry(&crypto.NoOpEncryptor{}, registry.HTTPOptions{})
+	require.NoError(t, err)
+
+	signer := jwt.NewSigner("test")
+	server, err := NewServer(&crypto.NoOpEncryptor{}, registry, signer, support.NewOIDCProvider(), "/api/v1", "", "", "test", "/app/templates", nil, false)
+	require.NoError(t, err)
+
+	integrationID := uuid.NewString()
+	matchedPath := func(method, path string) string {
+		var match mux.RouteMatch
+		require.True(t, server.Router.Match(httptest.NewRequest(method, path, nil), &match))
+		template, err := match.Route.GetPathTemplate()
+		require.NoError(t, err)
+		return template
+	}
+
+	t.Run("deliveries are not handled by the integration endpoint", func(t *testing.T) {
+		path := "/api/v1/integrations/" + integrationID + "/deliveries"
+		assert.Equal(t, "/api/v1/integrations/{integrationId}/deliveries", matchedPath(http.MethodGet, path))
+		assert.Equal(t, "/api/v1/integrations/{integrationId}/deliveries/{deliveryId}/replay", matchedPath(http.MethodPost, path+"/"+uuid.NewString()+"/replay"))
+	})
+
+	t.Run("other paths are handled by the integration endpoint", func(t *testing.T) {
+		assert.Equal(t, "/api/v1/integrations/{integrationID}", matchedPath(http.MethodPost, "/api/v1/integrations/"+integrationID+"/events"))
+	})
+}
@@ -0,0 +1,258 @@
+package registry
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * Catalog describes what the registered integrations, components and triggers
+ * can do, in a form that can be serialized as it is by the API layer and the CLI.
+ *
+ * Components and triggers include the ones exposed by integrations,
+ * with the name of the integration in Integration.
+ */
+type Catalog struct {
+	registry *Registry
+}
+
+type CatalogIntegration struct {
+	Name                string                `json:"name"`
+	Label               string                `json:"label"`
+	Icon                string                `json:"icon"`
+	Description         string                `json:"description"`
+	Instructions        string                `json:"instructions,omitempty"`
+	Configuration       []configuration.Field `json:"configuration"`
+	ConfigurationSchema map[string]any        `json:"configurationSchema"`
+	Permissions         core.Permissions      `json:"permissions"`
+	Actions             []CatalogAction       `json:"actions"`
+	Components          []string              `json:"components"`
+	Triggers            []string              `json:"triggers"`
+}
+
+type CatalogComponent struct {
+	Name                string                `json:"name"`
+	Label               string                `json:"label"`
+	Icon                string                `json:"icon"`
+	Color               string                `json:"color"`
+	Description         string                `json:"description"`
+	Integration         string                `json:"integration,omitempty"`
+	Configuration       []configuration.Field `json:"configuration"`
+	ConfigurationSchema map[string]any        `json:"configurationSchema"`
+	OutputChannels      []CatalogChannel      `json:"outputChannels"`
+	Permissions         core.Permissions      `json:"permissions"`
+	Actions             []CatalogAction       `json:"actions"`
+	ExampleOutput       map[string]any        `json:"exampleOutput,omitempty"`
+}
+
+type CatalogTrigger struct {
+	Name                string                `json:"name"`
+	Label               string                `json:"label"`
+	Icon                string                `json:"icon"`
+	Color               string                `json:"color"`
+	Description         string                `json:"description"`
+	Integration         string                `json:"integration,omitempty"`
+	Configuration       []configuration.Field `json:"configuration"`
+	ConfigurationSchema map[string]any        `json:"configurationSchema"`
+	Permissions         core.Permissions      `json:"permissions"`
+	Actions             []CatalogAction       `json:"actions"`
+	ExampleData         map[string]any        `json:"exampleData,omitempty"`
+}
+
+type CatalogChannel struct {
+	Name        string `json:"name"`
+	Label       string `json:"label,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type CatalogAction struct {
+	Name             string                `json:"name"`
+	Description      string                `json:"description,omitempty"`
+	UserAccessible   bool                  `json:"userAccessible"`
+	Parameters       []configuration.Field `json:"parameters"`
+	ParametersSchema map[string]any        `json:"parametersSchema"`
+}
+
+func (r *Registry) Catalog() *Catalog {
+	return &Catalog{registry: r}
+}
+
+func (c *Catalog) ListIntegrations() []CatalogIntegration {
+	integrations := []CatalogIntegration{}
+	for _, integration := range c.registry.ListIntegrations() {
+		integrations = append(integrations, catalogIntegration(integration))
+	}
+
+	return integrations
+}
+
+func (c *Catalog) ListComponents() []CatalogComponent {
+	components := []CatalogComponent{}
+	for _, component := range c.registry.ListComponents() {
+		components = append(components, catalogComponent(component, nil))
+	}
+
+	for _, integration := range c.registry.ListIntegrations() {
+		for _, component := range integration.Components() {
+			components = append(components, catalogComponent(component, integration))
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	return components
+}
+
+func (c *Catalog) ListTriggers() []CatalogTrigger {
+	triggers := []CatalogTrigger{}
+	for _, trigger := range c.registry.ListTriggers() {
+		triggers = append(triggers, catalogTrigger(trigger, nil))
+	}
+
+	for _, integration := range c.registry.ListIntegrations() {
+		for _, trigger := range integration.Triggers() {
+			triggers = append(triggers, catalogTrigger(trigger, integration))
+		}
+	}
+
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Name < triggers[j].Name
+	})
+
+	return triggers
+}
+
+func catalogIntegration(integration core.Integration) CatalogIntegration {
+	components := []string{}
+	for _, component := range integration.Components() {
+		components = append(components, component.Name())
+	}
+
+	triggers := []string{}
+	for _, trigger := range integration.Triggers() {
+		triggers = append(triggers, trigger.Name())
+	}
+
+	sort.Strings(components)
+	sort.Strings(triggers)
+
+	return CatalogIntegration{
+		Name:                integration.Name(),
+		Label:               integration.Label(),
+		Icon:                integration.Icon(),
+		Description:         integration.Description(),
+		Instructions:        integration.Instructions(),
+		Configuration:       integration.Configuration(),
+		ConfigurationSchema: configuration.JSONSchema(integration.Configuration()),
+		Permissions:         requiredPermissions(integration, nil),
+		Actions:             catalogActions(integration.Actions()),
+		Components:          components,
+		Triggers:            triggers,
+	}
+}
+
+func catalogComponent(component core.Component, integration core.Integration) CatalogComponent {
+	channels := []CatalogChannel{}
+	for _, channel := range component.OutputChannels(nil) {
+		channels = append(channels, CatalogChannel(channel))
+	}
+
+	c := CatalogComponent{
+		Name:                component.Name(),
+		Label:               component.Label(),
+		Icon:                component.Icon(),
+		Color:               component.Color(),
+		Description:         component.Description(),
+		Configuration:       component.Configuration(),
+		ConfigurationSchema: configuration.JSONSchema(component.Configuration()),
+		OutputChannels:      channels,
+		Permissions:         requiredPermissions(component, integration),
+		Actions:             catalogActions(component.Actions()),
+		ExampleOutput:       component.ExampleOutput(),
+	}
+
+	if integration != nil {
+		c.Integration = integration.Name()
+	}
+
+	return c
+}
+
+func catalogTrigger(trigger core.Trigger, integration core.Integration) CatalogTrigger {
+	t := CatalogTrigger{
+		Name:                trigger.Name(),
+		Label:               trigger.Label(),
+		Icon:                trigger.Icon(),
+		Color:               trigger.Color(),
+		Description:         trigger.Description(),
+		Configuration:       trigger.Configuration(),
+		ConfigurationSchema: configuration.JSONSchema(trigger.Configuration()),
+		Permissions:         requiredPermissions(trigger, integration),
+		Actions:             catalogActions(trigger.Actions()),
+		ExampleData:         trigger.ExampleData(),
+	}
+
+	if integration != nil {
+		t.Integration = integration.Name()
+	}
+
+	return t
+}
+
+func catalogActions(actions []core.Action) []CatalogAction {
+	out := []CatalogAction{}
+	for _, action := range actions {
+		parameters := action.Parameters
+		if parameters == nil {
+			parameters = []configuration.Field{}
+		}
+
+		out = append(out, CatalogAction{
+			Name:             action.Name,
+			Description:      action.Description,
+			UserAccessible:   action.UserAccessible,
+			Parameters:       parameters,
+			ParametersSchema: configuration.JSONSchema(parameters),
+		})
+	}
+
+	return out
+}
+
+/*
+ * Components and triggers need the permissions of their integration,
+ * in addition to the ones they declare themselves.
+ */
+func requiredPermissions(value any, integration core.Integration) core.Permissions {
+	permissions := core.Permissions{}
+	if integration != nil {
+		permissions = requiredPermissions(integration, nil)
+	}
+
+	provider, ok := value.(core.PermissionsProvider)
+	if !ok {
+		return permissions
+	}
+
+	own := provider.RequiredPermissions()
+	return core.Permissions{
+		Scopes: mergeUnique(permissions.Scopes, own.Scopes),
+		Roles:  mergeUnique(permissions.Roles, own.Roles),
+	}
+}
+
+func mergeUnique(a, b []string) []string {
+	merged := slices.Clone(a)
+	for _, value := range b {
+		if !slices.Contains(merged, value) {
+			merged = append(merged, value)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// permissionsIntegration requires a role, and exposes a component requiring another one
+type permissionsIntegration struct {
+	panickingIntegration
+}
+
+func (p *permissionsIntegration) Name() string { return "cloud" }
+
+func (p *permissionsIntegration) Configuration() []configuration.Field {
+	return []configuration.Field{{Name: "region", Type: configuration.FieldTypeString, Required: true}}
+}
+
+func (p *permissionsIntegration) Components() []core.Component {
+	return []core.Component{&permissionsComponent{panickingComponent{name: "cloud.createServer"}}}
+}
+
+func (p *permissionsIntegration) RequiredPermissions() core.Permissions {
+	return core.Permissions{Roles: []string{"roles/logs.writer"}}
+}
+
+type permissionsComponent struct {
+	panickingComponent
+}
+
+func (p *permissionsComponent) RequiredPermissions() core.Permissions {
+	return core.Permissions{Roles: []string{"roles/logs.writer", "roles/servers.admin"}}
+}
+
+func newCatalogRegistryForTest() *Registry {
+	return &Registry{
+		Components: map[string]core.Component{
+			"noop": NewPanicableComponent(&panickingComponent{name: "noop"}),
+		},
+		Triggers: map[string]core.Trigger{
+			"schedule": NewPanicableTrigger(&panickingTrigger{name: "schedule"}),
+		},
+		Integrations: map[string]core.Integration{
+			"cloud": NewPanicableIntegration(&permissionsIntegration{}),
+		},
+	}
+}
+
+func TestCatalog_ListIntegrations(t *testing.T) {
+	integrations := newCatalogRegistryForTest().Catalog().ListIntegrations()
+	require.Len(t, integrations, 1)
+
+	integration := integrations[0]
+	assert.Equal(t, "cloud", integration.Name)
+	assert.Equal(t, []string{"cloud.createServer"}, integration.Components)
+	assert.Equal(t, []string{}, integration.Triggers)
+	assert.Equal(t, []string{"roles/logs.writer"}, integration.Permissions.Roles)
+	assert.Equal(t, []string{"region"}, integration.ConfigurationSchema["required"])
+}
+
+func TestCatalog_ListComponents(t *testing.T) {
+	components := newCatalogRegistryForTest().Catalog().ListComponents()
+	require.Len(t, components, 2)
+
+	assert.Equal(t, "cloud.createServer", components[0].Name)
+	assert.Equal(t, "cloud", components[0].Integration)
+	assert.Equal(t, []string{"roles/logs.writer", "roles/servers.admin"}, components[0].Permissions.Roles)
+
+	assert.Equal(t, "noop", components[1].Name)
+	assert.Empty(t, components[1].Integration)
+	assert.True(t, components[1].Permissions.IsEmpty())
+
+	//
+	// Framework fields are part of the configuration of every component.
+	//
+	assert.NotEmpty(t, components[1].Configuration)
+	assert.NotEmpty(t, components[1].ConfigurationSchema["properties"])
+}
+
+func TestCatalog_ListTriggers(t *testing.T) {
+	triggers := newCatalogRegistryForTest().Catalog().ListTriggers()
+	require.Len(t, triggers, 1)
+	assert.Equal(t, "schedule", triggers[0].Name)
+
+	data, err := json.Marshal(triggers)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"configurationSchema"`)
+	assert.Contains(t, string(data), `"permissions":{}`)
+}
@@ -1,8 +1,11 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/configuration"
@@ -53,16 +56,145 @@ func (s *PanicableComponent) ExampleOutput() map[string]any {
 	return s.underlying.ExampleOutput()
 }
 
+/*
+ * Every component gets the fields handled by the framework,
+ * unless it already defines a field with the same name.
+ */
 func (s *PanicableComponent) Configuration() []configuration.Field {
-	return s.underlying.Configuration()
+	fields := s.underlying.Configuration()
+	for _, frameworkField := range core.FrameworkConfigurationFields() {
+		if !slices.ContainsFunc(fields, func(field configuration.Field) bool {
+			return field.Name == frameworkField.Name
+		}) {
+			fields = append(fields, frameworkField)
+		}
+	}
+
+	return fields
+}
+
+/*
+ * DefaultExecutionTimeout returns the default execution timeout
+ * declared with core.DefaultExecutionTimeoutProvider, if any.
+ */
+func (s *PanicableComponent) DefaultExecutionTimeout() time.Duration {
+	provider, ok := s.underlying.(core.DefaultExecutionTimeoutProvider)
+	if !ok {
+		return 0
+	}
+
+	return provider.DefaultExecutionTimeout()
+}
+
+/*
+ * HandlesTestMode forwards the test mode opt-in
+ * declared with core.TestModeComponent, if any.
+ */
+func (s *PanicableComponent) HandlesTestMode() bool {
+	return core.HandlesTestMode(s.underlying)
 }
 
 func (s *PanicableComponent) Actions() []core.Action {
 	return s.underlying.Actions()
 }
 
+/*
+ * RequiredPermissions returns the permissions declared with core.PermissionsProvider,
+ * or no permissions if the component does not declare any.
+ */
+func (s *PanicableComponent) RequiredPermissions() core.Permissions {
+	provider, ok := s.underlying.(core.PermissionsProvider)
+	if !ok {
+		return core.Permissions{}
+	}
+
+	return provider.RequiredPermissions()
+}
+
+/*
+ * OutputSchemas returns the schemas declared with core.OutputSchemaProvider,
+ * or no schemas if the component does not declare any.
+ */
+func (s *PanicableComponent) OutputSchemas() map[string]map[string]any {
+	return core.OutputSchemas(s.underlying)
+}
+
+/*
+ * SensitivePaths returns the paths declared with core.SensitivePayload,
+ * or no paths if the component does not declare any.
+ */
+func (s *PanicableComponent) SensitivePaths() []string {
+	sensitive, ok := s.underlying.(core.SensitivePayload)
+	if !ok {
+		return nil
+	}
+
+	return sensitive.SensitivePaths()
+}
+
+/*
+ * ConfigurationVersion and UpgradeConfiguration use core.VersionedConfiguration,
+ * so components that do not declare a version stay on the initial one.
+ */
+func (s *PanicableComponent) ConfigurationVersion() int {
+	return core.ConfigurationVersion(s.underlying)
+}
+
+func (s *PanicableComponent) UpgradeConfiguration(version int, config map[string]any) (upgraded map[string]any, err error) {
+	versioned, ok := s.underlying.(core.VersionedConfiguration)
+	if !ok {
+		return config, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("component %s panicked in UpgradeConfiguration(): %v",
+				s.underlying.Name(), r)
+		}
+	}()
+
+	return versioned.UpgradeConfiguration(version, config)
+}
+
+/*
+ * In development and test, payloads emitted by the component
+ * are validated against the schemas of their output channels.
+ */
+func (s *PanicableComponent) validateOutputs(state core.ExecutionStateContext) core.ExecutionStateContext {
+	if !core.OutputSchemaValidationEnabled() {
+		return state
+	}
+
+	return core.ValidateOutputs(s.underlying, state)
+}
+
+func (s *PanicableComponent) validateFoundExecutions(find func(key, value string) (*core.ExecutionContext, error)) func(key, value string) (*core.ExecutionContext, error) {
+	if find == nil || !core.OutputSchemaValidationEnabled() {
+		return find
+	}
+
+	return func(key, value string) (*core.ExecutionContext, error) {
+		ctx, err := find(key, value)
+		if err != nil || ctx == nil {
+			return ctx, err
+		}
+
+		ctx.ExecutionState = s.validateOutputs(ctx.ExecutionState)
+		return ctx, nil
+	}
+}
+
 func (s *PanicableComponent) OutputChannels(config any) []core.OutputChannel {
-	return s.underlying.OutputChannels(config)
+	channels := s.underlying.OutputChannels(config)
+	if !core.RouteErrorsEnabled(config) {
+		return channels
+	}
+
+	if len(channels) == 0 {
+		channels = []core.OutputChannel{core.DefaultOutputChannel}
+	}
+
+	return append(channels, core.ErrorOutputChannel)
 }
 
 /*
@@ -71,6 +203,10 @@ func (s *PanicableComponent) OutputChannels(config any) []core.OutputChannel {
  * so they could panic, and if they do, the system shouldn't crash.
  */
 func (s *PanicableComponent) Setup(ctx core.SetupContext) (err error) {
+	goCtx, span := startSpan(context.Background(), "component.Setup", componentAttributes(s.underlying.Name())...)
+	defer endSpan(span, &err)
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Component %s panicked in Setup(): %v\nStack: %s",
@@ -79,10 +215,137 @@ func (s *PanicableComponent) Setup(ctx core.SetupContext) (err error) {
 				s.underlying.Name(), r)
 		}
 	}()
+
+	//
+	// Configuration errors reported by the component
+	// are also Setup() errors, so the node is not used.
+	//
+	if err := core.ValidationError(s.Validate(ctx.Configuration)); err != nil {
+		return err
+	}
+
+	if err := s.validateDynamicFields(ctx); err != nil {
+		return err
+	}
+
 	return s.underlying.Setup(ctx)
 }
 
+/*
+ * DynamicSchema returns the schema for a field using configuration.DynamicSchemaTypeOptions,
+ * or nil if the component does not implement core.DynamicSchemaProvider.
+ */
+func (s *PanicableComponent) DynamicSchema(field string, ctx core.DynamicSchemaContext) (fields []configuration.Field, err error) {
+	provider, ok := s.underlying.(core.DynamicSchemaProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Logger.Errorf("Component %s panicked in DynamicSchema(): %v\nStack: %s",
+				s.underlying.Name(), r, debug.Stack())
+			err = fmt.Errorf("component %s panicked in DynamicSchema(): %v",
+				s.underlying.Name(), r)
+		}
+	}()
+
+	return provider.DynamicSchema(field, ctx)
+}
+
+/*
+ * Values of fields with a dynamic schema are validated
+ * against the schema the component returns for them.
+ */
+func (s *PanicableComponent) validateDynamicFields(ctx core.SetupContext) error {
+	config, _ := ctx.Configuration.(map[string]any)
+
+	for _, field := range s.underlying.Configuration() {
+		if field.TypeOptions == nil || field.TypeOptions.DynamicSchema == nil {
+			continue
+		}
+
+		if !dependenciesSet(config, field.TypeOptions.DynamicSchema.DependsOn) {
+			continue
+		}
+
+		var value map[string]any
+		switch v := config[field.Name].(type) {
+		case nil:
+			value = map[string]any{}
+		case map[string]any:
+			value = v
+		default:
+			return fmt.Errorf("field '%s': must be an object", field.Name)
+		}
+
+		schema, err := s.DynamicSchema(field.Name, core.DynamicSchemaContext{
+			Logger:        ctx.Logger,
+			Configuration: ctx.Configuration,
+			HTTP:          ctx.HTTP,
+			Integration:   ctx.Integration,
+		})
+
+		if err != nil {
+			return fmt.Errorf("field '%s': failed to load schema: %w", field.Name, err)
+		}
+
+		if err := configuration.ValidateConfiguration(schema, value); err != nil {
+			return fmt.Errorf("field '%s': %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func dependenciesSet(config map[string]any, dependsOn []string) bool {
+	for _, name := range dependsOn {
+		value, ok := config[name]
+		if !ok || value == nil || value == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+ * Validate returns the results of the component configuration validation,
+ * or nil if the component does not implement core.ConfigurationValidator.
+ */
+func (s *PanicableComponent) Validate(configuration any) (results []core.ValidationResult) {
+	validator, ok := s.underlying.(core.ConfigurationValidator)
+	if !ok {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			results = []core.ValidationResult{
+				{
+					Message:  fmt.Sprintf("component %s panicked in Validate(): %v", s.underlying.Name(), r),
+					Severity: core.ValidationSeverityError,
+				},
+			}
+		}
+	}()
+
+	return validator.Validate(configuration)
+}
+
 func (s *PanicableComponent) Execute(ctx core.ExecutionContext) (err error) {
+	goCtx, span := startSpan(ctx.GoContext(), "component.Execute", componentAttributes(
+		s.underlying.Name(),
+		AttributeCanvasID.String(ctx.WorkflowID),
+		AttributeNodeID.String(ctx.NodeID),
+		AttributeExecutionID.String(ctx.ID.String()),
+	)...)
+
+	defer endSpan(span, &err)
+	ctx.Context = goCtx
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+	ctx.ExecutionState = s.validateOutputs(ctx.ExecutionState)
+
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Component %s panicked in Execute(): %v\nStack: %s",
@@ -94,6 +357,39 @@ func (s *PanicableComponent) Execute(ctx core.ExecutionContext) (err error) {
 	return s.underlying.Execute(ctx)
 }
 
+/*
+ * Resume resumes the execution with core.ResumableComponent,
+ * or executes it again if the component cannot resume executions.
+ */
+func (s *PanicableComponent) Resume(ctx core.ExecutionContext, checkpoint core.Checkpoint) (err error) {
+	resumable, ok := s.underlying.(core.ResumableComponent)
+	if !ok {
+		return s.Execute(ctx)
+	}
+
+	goCtx, span := startSpan(ctx.GoContext(), "component.Resume", componentAttributes(
+		s.underlying.Name(),
+		AttributeCanvasID.String(ctx.WorkflowID),
+		AttributeNodeID.String(ctx.NodeID),
+		AttributeExecutionID.String(ctx.ID.String()),
+	)...)
+
+	defer endSpan(span, &err)
+	ctx.Context = goCtx
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+	ctx.ExecutionState = s.validateOutputs(ctx.ExecutionState)
+
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Logger.Errorf("Component %s panicked in Resume(): %v\nStack: %s",
+				s.underlying.Name(), r, debug.Stack())
+			err = fmt.Errorf("component %s panicked in Resume(): %v",
+				s.underlying.Name(), r)
+		}
+	}()
+	return resumable.Resume(ctx, checkpoint)
+}
+
 func (s *PanicableComponent) ProcessQueueItem(ctx core.ProcessQueueContext) (id *uuid.UUID, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -104,6 +400,16 @@ func (s *PanicableComponent) ProcessQueueItem(ctx core.ProcessQueueContext) (id
 }
 
 func (s *PanicableComponent) HandleAction(ctx core.ActionContext) (err error) {
+	goCtx, span := startSpan(ctx.GoContext(), "component.HandleAction", componentAttributes(
+		s.underlying.Name(),
+		AttributeAction.String(ctx.Name),
+	)...)
+
+	defer endSpan(span, &err)
+	ctx.Context = goCtx
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+	ctx.ExecutionState = s.validateOutputs(ctx.ExecutionState)
+
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Component %s panicked in HandleAction(%s): %v\nStack: %s",
@@ -116,6 +422,16 @@ func (s *PanicableComponent) HandleAction(ctx core.ActionContext) (err error) {
 }
 
 func (s *PanicableComponent) HandleWebhook(ctx core.WebhookRequestContext) (status int, response *core.WebhookResponseBody, err error) {
+	goCtx, span := startSpan(context.Background(), "component.HandleWebhook", componentAttributes(
+		s.underlying.Name(),
+		AttributeCanvasID.String(ctx.WorkflowID),
+		AttributeNodeID.String(ctx.NodeID),
+	)...)
+
+	defer endSpan(span, &err)
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+	ctx.FindExecutionByKV = s.validateFoundExecutions(ctx.FindExecutionByKV)
+
 	defer func() {
 		if r := recover(); r != nil {
 			status = 500
@@ -126,6 +442,17 @@ func (s *PanicableComponent) HandleWebhook(ctx core.WebhookRequestContext) (stat
 }
 
 func (s *PanicableComponent) Cancel(ctx core.ExecutionContext) (err error) {
+	goCtx, span := startSpan(ctx.GoContext(), "component.Cancel", componentAttributes(
+		s.underlying.Name(),
+		AttributeCanvasID.String(ctx.WorkflowID),
+		AttributeNodeID.String(ctx.NodeID),
+		AttributeExecutionID.String(ctx.ID.String()),
+	)...)
+
+	defer endSpan(span, &err)
+	ctx.Context = goCtx
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Component %s panicked in Cancel(): %v\nStack: %s",
@@ -166,5 +493,6 @@ func (s *PanicableComponent) OnIntegrationMessage(ctx core.IntegrationMessageCon
 		return fmt.Errorf("component %s is not an integration component", s.underlying.Name())
 	}
 
+	ctx.FindExecutionByKV = s.validateFoundExecutions(ctx.FindExecutionByKV)
 	return integrationComponent.OnIntegrationMessage(ctx)
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
 // panickingComponent is a component that panics in all panicable methods
@@ -67,6 +68,53 @@ func TestPanicableComponent_Execute_CatchesPanic(t *testing.T) {
 	assert.Contains(t, err.Error(), "execute panic")
 }
 
+type resumableComponent struct {
+	panickingComponent
+}
+
+func (r *resumableComponent) Resume(ctx core.ExecutionContext, checkpoint core.Checkpoint) error {
+	panic("resume panic")
+}
+
+func TestPanicableComponent_Resume(t *testing.T) {
+	ctx := core.ExecutionContext{
+		Logger: log.NewEntry(log.StandardLogger()),
+	}
+
+	t.Run("resumable component -> catches panic in Resume()", func(t *testing.T) {
+		panicable := NewPanicableComponent(&resumableComponent{panickingComponent{name: "panicking-comp"}})
+
+		resumable, ok := panicable.(core.ResumableComponent)
+		require.True(t, ok)
+
+		err := resumable.Resume(ctx, core.Checkpoint{Step: "waitForInsert"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "panicking-comp panicked in Resume()")
+		assert.Contains(t, err.Error(), "resume panic")
+	})
+
+	t.Run("component that cannot resume -> executes again", func(t *testing.T) {
+		panicable := NewPanicableComponent(&panickingComponent{name: "panicking-comp"})
+
+		err := panicable.(core.ResumableComponent).Resume(ctx, core.Checkpoint{Step: "waitForInsert"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "execute panic")
+	})
+}
+
+type testModeComponent struct {
+	panickingComponent
+}
+
+func (c *testModeComponent) HandlesTestMode() bool {
+	return true
+}
+
+func TestPanicableComponent_HandlesTestMode(t *testing.T) {
+	assert.True(t, core.HandlesTestMode(NewPanicableComponent(&testModeComponent{panickingComponent{name: "test-mode-comp"}})))
+	assert.False(t, core.HandlesTestMode(NewPanicableComponent(&panickingComponent{name: "panicking-comp"})))
+}
+
 func TestPanicableComponent_ProcessQueueItem_CatchesPanic(t *testing.T) {
 	comp := &panickingComponent{name: "panicking-comp"}
 	panicable := NewPanicableComponent(comp)
@@ -135,3 +183,173 @@ func TestPanicableComponent_Cleanup_CatchesPanic(t *testing.T) {
 	assert.Contains(t, err.Error(), "panicking-comp panicked in Cleanup()")
 	assert.Contains(t, err.Error(), "cleanup panic")
 }
+
+func TestPanicableComponent_RouteErrors(t *testing.T) {
+	panicable := NewPanicableComponent(&panickingComponent{name: "panicking-comp"})
+
+	t.Run("configuration includes the opt-in field", func(t *testing.T) {
+		fields := panicable.Configuration()
+		require.Len(t, fields, 5)
+		assert.Equal(t, core.RouteErrorsField, fields[0].Name)
+		assert.Equal(t, configuration.FieldTypeBool, fields[0].Type)
+		assert.Equal(t, core.ExecutionTimeoutField, fields[1].Name)
+		assert.Equal(t, core.QueueModeField, fields[2].Name)
+		assert.Equal(t, core.MaxInFlightField, fields[3].Name)
+		assert.Equal(t, core.DebounceWindowField, fields[4].Name)
+	})
+
+	t.Run("error channel is not added by default", func(t *testing.T) {
+		assert.Empty(t, panicable.OutputChannels(nil))
+		assert.Empty(t, panicable.OutputChannels(map[string]any{core.RouteErrorsField: false}))
+	})
+
+	t.Run("error channel is added when enabled", func(t *testing.T) {
+		channels := panicable.OutputChannels(map[string]any{core.RouteErrorsField: true})
+		require.Len(t, channels, 2)
+		assert.Equal(t, core.DefaultOutputChannel.Name, channels[0].Name)
+		assert.Equal(t, core.ErrorOutputChannel.Name, channels[1].Name)
+	})
+}
+
+// validatingComponent reports a configuration error when zone is missing
+type validatingComponent struct {
+	panickingComponent
+}
+
+func (v *validatingComponent) Validate(configuration any) []core.ValidationResult {
+	config, _ := configuration.(map[string]any)
+	if config["zone"] == nil {
+		return []core.ValidationResult{core.FieldError("zone", "zone is required")}
+	}
+
+	return []core.ValidationResult{core.FieldWarning("zone", "zone is deprecated")}
+}
+
+func TestPanicableComponent_Validate(t *testing.T) {
+	t.Run("component without validator -> no results", func(t *testing.T) {
+		panicable := NewPanicableComponent(&panickingComponent{name: "panicking-comp"})
+		validator, ok := panicable.(core.ConfigurationValidator)
+		require.True(t, ok)
+		assert.Nil(t, validator.Validate(map[string]any{}))
+	})
+
+	t.Run("configuration errors fail Setup()", func(t *testing.T) {
+		panicable := NewPanicableComponent(&validatingComponent{panickingComponent{name: "validating-comp"}})
+		err := panicable.Setup(core.SetupContext{
+			Logger:        log.NewEntry(log.StandardLogger()),
+			Configuration: map[string]any{},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, "field 'zone': zone is required", err.Error())
+	})
+
+	t.Run("warnings do not fail Setup()", func(t *testing.T) {
+		panicable := NewPanicableComponent(&validatingComponent{panickingComponent{name: "validating-comp"}})
+		results := panicable.(core.ConfigurationValidator).Validate(map[string]any{"zone": "us-central1-a"})
+		require.NoError(t, core.ValidationError(results))
+		assert.Equal(t, "field 'zone': zone is deprecated", core.ValidationWarnings(results))
+	})
+}
+
+// dynamicSchemaComponent declares a field whose schema depends on the selected pipeline
+type dynamicSchemaComponent struct {
+	panickingComponent
+}
+
+func (d *dynamicSchemaComponent) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{Name: "pipeline", Type: configuration.FieldTypeString},
+		{
+			Name: "variables",
+			Type: configuration.FieldTypeObject,
+			TypeOptions: &configuration.TypeOptions{
+				DynamicSchema: &configuration.DynamicSchemaTypeOptions{DependsOn: []string{"pipeline"}},
+			},
+		},
+	}
+}
+
+func (d *dynamicSchemaComponent) Setup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (d *dynamicSchemaComponent) DynamicSchema(field string, ctx core.DynamicSchemaContext) ([]configuration.Field, error) {
+	return []configuration.Field{
+		{Name: "ENVIRONMENT", Type: configuration.FieldTypeString, Required: true},
+	}, nil
+}
+
+func TestPanicableComponent_DynamicSchema(t *testing.T) {
+	panicable := NewPanicableComponent(&dynamicSchemaComponent{panickingComponent{name: "dynamic-comp"}})
+	setup := func(config map[string]any) error {
+		return panicable.Setup(core.SetupContext{
+			Logger:        log.NewEntry(log.StandardLogger()),
+			Configuration: config,
+		})
+	}
+
+	t.Run("dependencies not set -> schema is not loaded", func(t *testing.T) {
+		require.NoError(t, setup(map[string]any{}))
+	})
+
+	t.Run("value matches schema -> no error", func(t *testing.T) {
+		require.NoError(t, setup(map[string]any{
+			"pipeline":  "my-pipeline",
+			"variables": map[string]any{"ENVIRONMENT": "staging"},
+		}))
+	})
+
+	t.Run("value does not match schema -> error", func(t *testing.T) {
+		err := setup(map[string]any{"pipeline": "my-pipeline"})
+		require.Error(t, err)
+		assert.Equal(t, "field 'variables': field 'ENVIRONMENT' is required", err.Error())
+	})
+}
+
+type schemaComponent struct {
+	panickingComponent
+	payload any
+}
+
+func (c *schemaComponent) OutputSchemas() map[string]map[string]any {
+	return map[string]map[string]any{
+		core.DefaultOutputChannel.Name: {"type": "object", "required": []any{"id"}},
+	}
+}
+
+func (c *schemaComponent) Execute(ctx core.ExecutionContext) error {
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, "example", []any{c.payload})
+}
+
+func TestPanicableComponent_ValidatesOutputs(t *testing.T) {
+	execute := func(payload any) (*contexts.ExecutionStateContext, error) {
+		state := &contexts.ExecutionStateContext{}
+		err := NewPanicableComponent(&schemaComponent{payload: payload}).Execute(core.ExecutionContext{
+			Logger:         log.NewEntry(log.StandardLogger()),
+			ExecutionState: state,
+		})
+
+		return state, err
+	}
+
+	t.Run("development -> payload not matching the schema fails", func(t *testing.T) {
+		t.Setenv("APP_ENV", "development")
+
+		state, err := execute(map[string]any{"data": map[string]any{"id": "1"}})
+		require.ErrorContains(t, err, `missing required property "id"`)
+		assert.False(t, state.Passed)
+
+		state, err = execute(map[string]any{"id": "1"})
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+	})
+
+	t.Run("production -> payloads are not validated", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+
+		state, err := execute(map[string]any{"data": map[string]any{"id": "1"}})
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+	})
+}
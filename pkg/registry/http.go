@@ -2,6 +2,7 @@ package registry
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -10,6 +11,9 @@ import (
 	"strings"
 	"syscall"
 	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 type HTTPContext struct {
@@ -18,12 +22,19 @@ type HTTPContext struct {
 	blockedHosts     []string
 	privateIPRanges  []*net.IPNet
 	maxResponseBytes int64
+	rateLimiter      *integrationRateLimiter
 }
 
 type HTTPOptions struct {
 	BlockedHosts     []string
 	PrivateIPRanges  []string
 	MaxResponseBytes int64
+
+	//
+	// Rate limit applied to the requests of each integration installation.
+	// Only applies to HTTP contexts returned by ForIntegration().
+	//
+	IntegrationRateLimit RateLimit
 }
 
 func NewHTTPContext(options HTTPOptions) (*HTTPContext, error) {
@@ -33,6 +44,10 @@ func NewHTTPContext(options HTTPOptions) (*HTTPContext, error) {
 		maxResponseBytes: options.MaxResponseBytes,
 	}
 
+	if options.IntegrationRateLimit.enabled() {
+		httpCtx.rateLimiter = newIntegrationRateLimiter(options.IntegrationRateLimit)
+	}
+
 	for _, cidr := range options.PrivateIPRanges {
 		_, ipNet, err := net.ParseCIDR(cidr)
 		if err != nil {
@@ -68,32 +83,80 @@ func NewHTTPContext(options HTTPOptions) (*HTTPContext, error) {
 		},
 	}
 
-	httpCtx.client = &http.Client{
+	httpCtx.client = httpCtx.newClient(nil)
+	return httpCtx, nil
+}
+
+func (c *HTTPContext) newClient(tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
 		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
+
+		//
+		// Each request is a span, child of the span of the component call sending it,
+		// and the trace context is propagated to the server, if tracing is enabled.
+		//
+		Transport: otelhttp.NewTransport(&http.Transport{
 			ForceAttemptHTTP2:     true,
 			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   20,
 			IdleConnTimeout:       90 * time.Second,
 			TLSHandshakeTimeout:   10 * time.Second,
 			ExpectContinueTimeout: 1 * time.Second,
+			TLSClientConfig:       tlsConfig,
 			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-				return httpCtx.dialer.DialContext(ctx, network, addr)
+				return c.dialer.DialContext(ctx, network, addr)
 			},
-		},
+		}),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if len(via) >= 10 {
 				return fmt.Errorf("stopped after 10 redirects")
 			}
 
-			if err := httpCtx.validateURL(req.URL); err != nil {
+			if err := c.validateURL(req.URL); err != nil {
 				return fmt.Errorf("redirect blocked: %w", err)
 			}
 
 			return nil
 		},
 	}
+}
 
-	return httpCtx, nil
+/*
+ * WithTLSConfig returns a copy of the HTTP context using the given TLS configuration,
+ * for servers using private certificate authorities or requiring client certificates.
+ * The same host and IP validations still apply.
+ */
+func (c *HTTPContext) WithTLSConfig(tlsConfig *tls.Config) core.HTTPContext {
+	return c.withTLSConfig(tlsConfig)
+}
+
+func (c *HTTPContext) withTLSConfig(tlsConfig *tls.Config) *HTTPContext {
+	httpCtx := &HTTPContext{
+		dialer:           c.dialer,
+		blockedHosts:     c.blockedHosts,
+		privateIPRanges:  c.privateIPRanges,
+		maxResponseBytes: c.maxResponseBytes,
+		rateLimiter:      c.rateLimiter,
+	}
+
+	httpCtx.client = httpCtx.newClient(tlsConfig)
+	return httpCtx
+}
+
+/*
+ * ForIntegration returns an HTTP context whose requests are rate limited
+ * by the token bucket of the given integration installation.
+ * If no integration rate limit is configured, the context itself is returned.
+ */
+func (c *HTTPContext) ForIntegration(integrationID string) core.HTTPContext {
+	if c.rateLimiter == nil {
+		return c
+	}
+
+	return &RateLimitedHTTPContext{
+		underlying: c,
+		bucket:     c.rateLimiter.bucket(integrationID),
+	}
 }
 
 func (c *HTTPContext) Do(request *http.Request) (*http.Response, error) {
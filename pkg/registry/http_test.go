@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"io"
 	"net"
 	"net/http"
@@ -186,6 +188,49 @@ func Test__HTTPContext__Do(t *testing.T) {
 	})
 }
 
+func Test__HTTPContext__WithTLSConfig(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(testServer.Close)
+
+	ctx, err := NewHTTPContext(HTTPOptions{})
+	require.NoError(t, err)
+
+	t.Run("unknown certificate authority -> error", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, testServer.URL, nil)
+		require.NoError(t, err)
+
+		_, err = ctx.Do(req)
+		require.Error(t, err)
+	})
+
+	t.Run("trusted certificate authority -> ok", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		pool.AddCert(testServer.Certificate())
+
+		req, err := http.NewRequest(http.MethodGet, testServer.URL, nil)
+		require.NoError(t, err)
+
+		res, err := ctx.WithTLSConfig(&tls.Config{RootCAs: pool}).Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("private IP ranges still apply", func(t *testing.T) {
+		ctx, err := NewHTTPContext(defaultHTTPOptions())
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, testServer.URL, nil)
+		require.NoError(t, err)
+
+		_, err = ctx.WithTLSConfig(&tls.Config{InsecureSkipVerify: true}).Do(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access to 127.0.0.1 is not allowed")
+	})
+}
+
 func Test__HTTPContext__Do__RedirectLimit(t *testing.T) {
 	var hits atomic.Int32
 
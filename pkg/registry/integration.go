@@ -1,11 +1,16 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"golang.org/x/sync/singleflight"
 )
 
 /*
@@ -14,6 +19,12 @@ import (
  */
 type PanicableIntegration struct {
 	underlying core.Integration
+
+	//
+	// Concurrent identical resource lists, e.g. from every node
+	// of a canvas opened at the same time, share the same upstream call.
+	//
+	resourceLists singleflight.Group
 }
 
 func NewPanicableIntegration(i core.Integration) core.Integration {
@@ -52,6 +63,19 @@ func (s *PanicableIntegration) Actions() []core.Action {
 	return s.underlying.Actions()
 }
 
+/*
+ * RequiredPermissions returns the permissions declared with core.PermissionsProvider,
+ * or no permissions if the integration does not declare any.
+ */
+func (s *PanicableIntegration) RequiredPermissions() core.Permissions {
+	provider, ok := s.underlying.(core.PermissionsProvider)
+	if !ok {
+		return core.Permissions{}
+	}
+
+	return provider.RequiredPermissions()
+}
+
 func (s *PanicableIntegration) Components() []core.Component {
 	components := s.underlying.Components()
 	safe := make([]core.Component, len(components))
@@ -76,6 +100,10 @@ func (s *PanicableIntegration) Triggers() []core.Trigger {
  * so they could panic, and if they do, the system shouldn't crash.
  */
 func (s *PanicableIntegration) Sync(ctx core.SyncContext) (err error) {
+	goCtx, span := startSpan(context.Background(), "integration.Sync", AttributeIntegration.String(s.underlying.Name()))
+	defer endSpan(span, &err)
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("integration %s panicked in Sync(): %v",
@@ -96,6 +124,14 @@ func (s *PanicableIntegration) Cleanup(ctx core.IntegrationCleanupContext) (err
 }
 
 func (s *PanicableIntegration) HandleAction(ctx core.IntegrationActionContext) (err error) {
+	goCtx, span := startSpan(context.Background(), "integration.HandleAction",
+		AttributeIntegration.String(s.underlying.Name()),
+		AttributeAction.String(ctx.Name),
+	)
+
+	defer endSpan(span, &err)
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Component %s panicked in HandleAction(): %v\nStack: %s",
@@ -118,6 +154,119 @@ func (s *PanicableIntegration) ListResources(resourceType string, ctx core.ListR
 	return s.underlying.ListResources(resourceType, ctx)
 }
 
+/*
+ * ListResourcePage uses the integration pagination, if it has one.
+ * Otherwise, the resources from ListResources() are filtered and paginated.
+ * Identical lists requested while one is in progress wait for it,
+ * and receive the same page, instead of calling the integration again.
+ */
+func (s *PanicableIntegration) ListResourcePage(resourceType string, ctx core.ListResourcesContext) (*core.ResourcePage, error) {
+	if ctx.Integration == nil {
+		return s.listResourcePage(resourceType, ctx)
+	}
+
+	key := resourceListKey(ctx.Integration.ID().String(), resourceType, ctx)
+	page, err, _ := s.resourceLists.Do(key, func() (any, error) {
+		return s.listResourcePage(resourceType, ctx)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return page.(*core.ResourcePage), nil
+}
+
+/*
+ * Two lists are identical if they are for the same installation,
+ * resource type, parameters, query and page.
+ */
+func resourceListKey(integrationID, resourceType string, ctx core.ListResourcesContext) string {
+	names := make([]string, 0, len(ctx.Parameters))
+	for name := range ctx.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := []string{integrationID, resourceType, ctx.Query, ctx.PageToken, strconv.Itoa(ctx.PageSize)}
+	for _, name := range names {
+		parts = append(parts, name+"="+ctx.Parameters[name])
+	}
+
+	return strings.Join(parts, "\x00")
+}
+
+func (s *PanicableIntegration) listResourcePage(resourceType string, ctx core.ListResourcesContext) (page *core.ResourcePage, err error) {
+	lister, ok := s.underlying.(core.ResourcePageLister)
+	if !ok {
+		resources, err := s.ListResources(resourceType, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return core.PageResources(resources, ctx)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			page = nil
+			err = fmt.Errorf("integration %s panicked in ListResourcePage(): %v",
+				s.underlying.Name(), r)
+		}
+	}()
+
+	return lister.ListResourcePage(resourceType, ctx)
+}
+
+/*
+ * HealthCheck runs the health check of the integration, if it has one.
+ * Integrations without a health check are always healthy.
+ */
+func (s *PanicableIntegration) HealthCheck(ctx core.HealthCheckContext) (err error) {
+	checker, ok := s.underlying.(core.HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	goCtx, span := startSpan(ctx.Context, "integration.HealthCheck", AttributeIntegration.String(s.underlying.Name()))
+	defer endSpan(span, &err)
+	ctx.Context = goCtx
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("integration %s panicked in HealthCheck(): %v",
+				s.underlying.Name(), r)
+		}
+	}()
+
+	return checker.HealthCheck(ctx)
+}
+
+/*
+ * Prefetch warms the caches of the integration, if it has any.
+ */
+func (s *PanicableIntegration) Prefetch(ctx core.PrefetchContext) (err error) {
+	prefetcher, ok := s.underlying.(core.Prefetcher)
+	if !ok {
+		return nil
+	}
+
+	goCtx, span := startSpan(ctx.Context, "integration.Prefetch", AttributeIntegration.String(s.underlying.Name()))
+	defer endSpan(span, &err)
+	ctx.Context = goCtx
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("integration %s panicked in Prefetch(): %v",
+				s.underlying.Name(), r)
+		}
+	}()
+
+	return prefetcher.Prefetch(ctx)
+}
+
 func (s *PanicableIntegration) HandleRequest(ctx core.HTTPRequestContext) {
 	defer func() {
 		if r := recover(); r != nil {
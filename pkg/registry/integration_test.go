@@ -2,14 +2,19 @@ package registry
 
 import (
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
 // panickingIntegration is an integration that panics in all panicable methods
@@ -65,3 +70,115 @@ func TestPanicableIntegration_HandleRequest_CatchesPanic(t *testing.T) {
 
 	assert.Equal(t, 500, recorder.Code)
 }
+
+// listingIntegration lists resources, but does not paginate them
+type listingIntegration struct {
+	panickingIntegration
+}
+
+func (l *listingIntegration) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	return []core.IntegrationResource{
+		{Type: resourceType, Name: "orders", ID: "1"},
+		{Type: resourceType, Name: "payments", ID: "2"},
+		{Type: resourceType, Name: "orders-dlq", ID: "3"},
+	}, nil
+}
+
+// pagingIntegration panics when paginating resources
+type pagingIntegration struct {
+	panickingIntegration
+}
+
+func (p *pagingIntegration) ListResourcePage(resourceType string, ctx core.ListResourcesContext) (*core.ResourcePage, error) {
+	panic("list resource page panic")
+}
+
+func TestPanicableIntegration_ListResourcePage_PaginatesListedResources(t *testing.T) {
+	panicable := NewPanicableIntegration(&listingIntegration{}).(*PanicableIntegration)
+
+	page, err := panicable.ListResourcePage("queue", core.ListResourcesContext{Query: "orders", PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Resources, 1)
+	assert.Equal(t, "orders", page.Resources[0].Name)
+	assert.Equal(t, "1", page.NextPageToken)
+
+	page, err = panicable.ListResourcePage("queue", core.ListResourcesContext{Query: "orders", PageSize: 1, PageToken: page.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, page.Resources, 1)
+	assert.Equal(t, "orders-dlq", page.Resources[0].Name)
+	assert.Empty(t, page.NextPageToken)
+}
+
+func TestPanicableIntegration_ListResourcePage_CatchesPanic(t *testing.T) {
+	panicable := NewPanicableIntegration(&pagingIntegration{}).(*PanicableIntegration)
+
+	_, err := panicable.ListResourcePage("queue", core.ListResourcesContext{})
+	require.ErrorContains(t, err, "integration panicking-integration panicked in ListResourcePage()")
+
+	_, err = NewPanicableIntegration(&panickingIntegration{}).(*PanicableIntegration).ListResourcePage("queue", core.ListResourcesContext{})
+	require.ErrorContains(t, err, "panicked in ListResources()")
+}
+
+// blockingIntegration counts resource lists, and holds them until released
+type blockingIntegration struct {
+	panickingIntegration
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (b *blockingIntegration) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	b.calls.Add(1)
+	<-b.release
+	return []core.IntegrationResource{{Type: resourceType, Name: ctx.Parameters["zone"], ID: "1"}}, nil
+}
+
+func TestPanicableIntegration_ListResourcePage_SharesConcurrentLists(t *testing.T) {
+	underlying := &blockingIntegration{release: make(chan struct{})}
+	panicable := NewPanicableIntegration(underlying).(*PanicableIntegration)
+	integration := &contexts.IntegrationContext{IntegrationID: uuid.NewString()}
+
+	list := func(zone string) (*core.ResourcePage, error) {
+		return panicable.ListResourcePage("machineType", core.ListResourcesContext{
+			Integration: integration,
+			Parameters:  map[string]string{"zone": zone},
+		})
+	}
+
+	var wg sync.WaitGroup
+	pages := make([]*core.ResourcePage, 10)
+	for i := range pages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			page, err := list("us-central1-a")
+			assert.NoError(t, err)
+			pages[i] = page
+		}()
+	}
+
+	//
+	// Lists with different parameters are not shared.
+	//
+	var other *core.ResourcePage
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		page, err := list("europe-west1-b")
+		assert.NoError(t, err)
+		other = page
+	}()
+
+	require.Eventually(t, func() bool { return underlying.calls.Load() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(underlying.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), underlying.calls.Load())
+	for _, page := range pages {
+		require.NotNil(t, page)
+		assert.Equal(t, "us-central1-a", page.Resources[0].Name)
+	}
+
+	require.NotNil(t, other)
+	assert.Equal(t, "europe-west1-b", other.Resources[0].Name)
+}
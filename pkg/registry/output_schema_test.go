@@ -0,0 +1,66 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/registry"
+
+	// Import server package which imports all components, triggers, and applications
+	_ "github.com/superplanehq/superplane/pkg/server"
+)
+
+/*
+ * The example output is emitted on the first output channel in test mode,
+ * so it must match the schema of that channel.
+ */
+func TestExampleOutputsMatchOutputSchemas(t *testing.T) {
+	reg, err := registry.NewRegistry(&crypto.NoOpEncryptor{}, registry.HTTPOptions{})
+	require.NoError(t, err)
+
+	components := reg.ListComponents()
+	for _, integration := range reg.ListIntegrations() {
+		components = append(components, integration.Components()...)
+	}
+
+	for _, component := range components {
+		schemas := core.OutputSchemas(component)
+		if len(schemas) == 0 {
+			continue
+		}
+
+		channel := core.DefaultOutputChannel.Name
+		if channels := component.OutputChannels(nil); len(channels) > 0 {
+			channel = channels[0].Name
+		}
+
+		for name := range schemas {
+			assert.Contains(t, outputChannelNames(component), name, "%s declares a schema for an unknown channel", component.Name())
+		}
+
+		schema, ok := schemas[channel]
+		if !ok {
+			continue
+		}
+
+		err := core.ValidateOutputSchema(schema, component.ExampleOutput()["data"])
+		assert.NoError(t, err, "example output of %s does not match the schema of %s", component.Name(), channel)
+	}
+}
+
+func outputChannelNames(component core.Component) []string {
+	channels := component.OutputChannels(nil)
+	if len(channels) == 0 {
+		return []string{core.DefaultOutputChannel.Name}
+	}
+
+	names := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		names = append(names, channel.Name)
+	}
+
+	return names
+}
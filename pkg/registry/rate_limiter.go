@@ -0,0 +1,208 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * RateLimit configures the token bucket used for every integration installation.
+ * A zero RequestsPerSecond disables rate limiting.
+ */
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+func (r RateLimit) enabled() bool {
+	return r.RequestsPerSecond > 0
+}
+
+/*
+ * TokenBucket allows bursts of up to burst requests,
+ * and refills at a constant rate of requests per second.
+ */
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func NewTokenBucket(limit RateLimit) *TokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+	}
+}
+
+/*
+ * reserve takes a token and returns how long
+ * the caller needs to wait before using it.
+ */
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+/*
+ * cancel gives back a token reserved by a caller
+ * that stopped waiting before using it.
+ */
+func (b *TokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+/*
+ * idle checks if the bucket was not used since the given time,
+ * and was refilled up to the burst size, so dropping it
+ * and creating a new one later does not change the rate.
+ */
+func (b *TokenBucket) idle(now time.Time, since time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.last.IsZero() {
+		return true
+	}
+
+	if b.last.After(since) {
+		return false
+	}
+
+	return b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.burst
+}
+
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+/*
+ * Buckets of installations without requests for bucketIdleTimeout
+ * are evicted, checking at most once every bucketEvictionInterval,
+ * so the map does not grow with every installation ever seen.
+ */
+const (
+	bucketIdleTimeout      = 10 * time.Minute
+	bucketEvictionInterval = time.Minute
+)
+
+type integrationRateLimiter struct {
+	mu        sync.Mutex
+	limit     RateLimit
+	buckets   map[string]*TokenBucket
+	lastEvict time.Time
+	now       func() time.Time
+}
+
+func newIntegrationRateLimiter(limit RateLimit) *integrationRateLimiter {
+	return &integrationRateLimiter{
+		limit:   limit,
+		buckets: map[string]*TokenBucket{},
+		now:     time.Now,
+	}
+}
+
+func (l *integrationRateLimiter) bucket(integrationID string) *TokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.evictIdleBuckets()
+
+	bucket, ok := l.buckets[integrationID]
+	if !ok {
+		bucket = NewTokenBucket(l.limit)
+		l.buckets[integrationID] = bucket
+	}
+
+	return bucket
+}
+
+func (l *integrationRateLimiter) evictIdleBuckets() {
+	now := l.now()
+	if now.Sub(l.lastEvict) < bucketEvictionInterval {
+		return
+	}
+
+	l.lastEvict = now
+	for integrationID, bucket := range l.buckets {
+		if bucket.idle(now, now.Add(-bucketIdleTimeout)) {
+			delete(l.buckets, integrationID)
+		}
+	}
+}
+
+/*
+ * RateLimitedHTTPContext waits for the integration installation's
+ * token bucket before sending each request, so executions sharing
+ * the same installation do not exceed the provider API quotas.
+ */
+type RateLimitedHTTPContext struct {
+	underlying *HTTPContext
+	bucket     *TokenBucket
+}
+
+func (c *RateLimitedHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	if err := c.bucket.Wait(request.Context()); err != nil {
+		return nil, err
+	}
+
+	return c.underlying.Do(request)
+}
+
+func (c *RateLimitedHTTPContext) WithTLSConfig(tlsConfig *tls.Config) core.HTTPContext {
+	return &RateLimitedHTTPContext{
+		underlying: c.underlying.withTLSConfig(tlsConfig),
+		bucket:     c.bucket,
+	}
+}
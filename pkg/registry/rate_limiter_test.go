@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__TokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := NewTokenBucket(RateLimit{RequestsPerSecond: 2, Burst: 3})
+	bucket.now = func() time.Time { return now }
+
+	t.Run("allows bursts up to the burst size", func(t *testing.T) {
+		assert.Zero(t, bucket.reserve())
+		assert.Zero(t, bucket.reserve())
+		assert.Zero(t, bucket.reserve())
+	})
+
+	t.Run("requests over the burst wait for the refill", func(t *testing.T) {
+		assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+		assert.Equal(t, time.Second, bucket.reserve())
+	})
+
+	t.Run("tokens are refilled over time, up to the burst size", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		for range 3 {
+			assert.Zero(t, bucket.reserve())
+		}
+
+		assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+	})
+
+	t.Run("cancelled waits give back their token", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.ErrorIs(t, bucket.Wait(ctx), context.Canceled)
+		assert.Equal(t, time.Second, bucket.reserve())
+	})
+}
+
+func Test__IntegrationRateLimiter__EvictsIdleBuckets(t *testing.T) {
+	now := time.Now()
+	limiter := newIntegrationRateLimiter(RateLimit{RequestsPerSecond: 1, Burst: 1})
+	limiter.now = func() time.Time { return now }
+
+	useBucket := func(integrationID string) *TokenBucket {
+		bucket := limiter.bucket(integrationID)
+		bucket.now = func() time.Time { return now }
+		bucket.reserve()
+		return bucket
+	}
+
+	idle := useBucket("integration-1")
+	active := useBucket("integration-2")
+
+	now = now.Add(bucketIdleTimeout - time.Second)
+	assert.Same(t, active, useBucket("integration-2"))
+
+	//
+	// Only the bucket without requests for the idle timeout is evicted.
+	//
+	now = now.Add(bucketEvictionInterval)
+	assert.Same(t, active, limiter.bucket("integration-2"))
+	assert.Len(t, limiter.buckets, 1)
+	assert.NotSame(t, idle, limiter.bucket("integration-1"))
+}
+
+func Test__HTTPContext__ForIntegration(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(testServer.Close)
+
+	t.Run("no rate limit -> same context", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{})
+		require.NoError(t, err)
+		assert.Same(t, ctx, ctx.ForIntegration("integration-1"))
+	})
+
+	t.Run("installations have separate buckets", func(t *testing.T) {
+		ctx, err := NewHTTPContext(HTTPOptions{IntegrationRateLimit: RateLimit{RequestsPerSecond: 0.001, Burst: 1}})
+		require.NoError(t, err)
+
+		first := ctx.ForIntegration("integration-1").(*RateLimitedHTTPContext)
+		second := ctx.ForIntegration("integration-2").(*RateLimitedHTTPContext)
+		assert.Same(t, first.bucket, ctx.ForIntegration("integration-1").(*RateLimitedHTTPContext).bucket)
+		assert.NotSame(t, first.bucket, second.bucket)
+
+		req, err := http.NewRequest(http.MethodGet, testServer.URL, nil)
+		require.NoError(t, err)
+
+		res, err := first.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		res, err = second.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		//
+		// The bucket of the first installation is empty,
+		// so the request waits until its context expires.
+		//
+		timeoutCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = first.Do(req.WithContext(timeoutCtx))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
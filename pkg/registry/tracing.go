@@ -0,0 +1,161 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/superplanehq/superplane/pkg/registry"
+
+const (
+	AttributeIntegration = attribute.Key("superplane.integration")
+	AttributeComponent   = attribute.Key("superplane.component")
+	AttributeTrigger     = attribute.Key("superplane.trigger")
+	AttributeAction      = attribute.Key("superplane.action")
+	AttributeCanvasID    = attribute.Key("superplane.canvas.id")
+	AttributeNodeID      = attribute.Key("superplane.node.id")
+	AttributeExecutionID = attribute.Key("superplane.execution.id")
+)
+
+/*
+ * callSpan is the span of a call into a component, trigger or integration.
+ * Besides the trace, it is used to record the duration and outcome of the call as metrics,
+ * and to record metrics for the HTTP requests sent during the call.
+ */
+type callSpan struct {
+	trace.Span
+
+	name        string
+	integration string
+	component   string
+	startedAt   time.Time
+}
+
+type callSpanKey struct{}
+
+/*
+ * Spans are created for every call into components, triggers and integrations.
+ * Until a tracer provider is configured, otel returns no-op spans, so this is cheap.
+ */
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, *callSpan) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	call := &callSpan{name: name, startedAt: time.Now()}
+	for _, a := range attributes {
+		switch a.Key {
+		case AttributeIntegration:
+			call.integration = a.Value.AsString()
+		case AttributeComponent, AttributeTrigger:
+			call.component = a.Value.AsString()
+		}
+	}
+
+	ctx, call.Span = otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+	return context.WithValue(ctx, callSpanKey{}, call), call
+}
+
+/*
+ * Ends the span, recording the error returned by the call, if any.
+ * It is deferred, so panics recovered by the Panicable wrappers are also recorded.
+ */
+func endSpan(span *callSpan, err *error) {
+	var callErr error
+	if err != nil {
+		callErr = *err
+	}
+
+	if callErr != nil {
+		span.RecordError(callErr)
+		span.SetStatus(codes.Error, callErr.Error())
+	}
+
+	span.End()
+	telemetry.RecordIntegrationCall(context.Background(), span.name, span.integration, span.component, time.Since(span.startedAt), callErr)
+}
+
+/*
+ * Components are named after the integration they belong to,
+ * e.g. aws.ec2.createImage belongs to the aws integration.
+ */
+func integrationAttributes(name string) []attribute.KeyValue {
+	integration, _, found := strings.Cut(name, ".")
+	if !found {
+		return nil
+	}
+
+	return []attribute.KeyValue{AttributeIntegration.String(integration)}
+}
+
+func componentAttributes(name string, attributes ...attribute.KeyValue) []attribute.KeyValue {
+	return append(append(integrationAttributes(name), AttributeComponent.String(name)), attributes...)
+}
+
+func triggerAttributes(name string, attributes ...attribute.KeyValue) []attribute.KeyValue {
+	return append(append(integrationAttributes(name), AttributeTrigger.String(name)), attributes...)
+}
+
+/*
+ * TracedHTTPContext makes the requests sent by a component
+ * children of the span of the call that sends them,
+ * even if the request is not created with a context carrying the span.
+ * The latency and status of each request are recorded as metrics
+ * for the integration and component sending it.
+ */
+type TracedHTTPContext struct {
+	underlying core.HTTPContext
+	span       *callSpan
+}
+
+func traceHTTP(httpCtx core.HTTPContext, ctx context.Context) core.HTTPContext {
+	if httpCtx == nil {
+		return nil
+	}
+
+	span, ok := ctx.Value(callSpanKey{}).(*callSpan)
+	if !ok {
+		span = &callSpan{Span: trace.SpanFromContext(ctx)}
+	}
+
+	return &TracedHTTPContext{underlying: httpCtx, span: span}
+}
+
+func (c *TracedHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(request.Context()).IsValid() {
+		request = request.WithContext(trace.ContextWithSpan(request.Context(), c.span.Span))
+	}
+
+	startedAt := time.Now()
+	response, err := c.underlying.Do(request)
+
+	statusCode := 0
+	if response != nil {
+		statusCode = response.StatusCode
+	}
+
+	telemetry.RecordIntegrationHTTPRequest(request.Context(), c.span.integration, c.span.component, time.Since(startedAt), statusCode, err)
+	return response, err
+}
+
+func (c *TracedHTTPContext) WithTLSConfig(tlsConfig *tls.Config) core.HTTPContext {
+	configurable, ok := c.underlying.(interface {
+		WithTLSConfig(tlsConfig *tls.Config) core.HTTPContext
+	})
+
+	if !ok {
+		return c
+	}
+
+	return &TracedHTTPContext{underlying: configurable.WithTLSConfig(tlsConfig), span: c.span}
+}
@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// requestingComponent sends a request with ctx.HTTP in Execute(), and fails
+type requestingComponent struct {
+	panickingComponent
+}
+
+func (c *requestingComponent) Execute(ctx core.ExecutionContext) error {
+	request, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctx.HTTP.Do(request)
+	if err != nil {
+		return err
+	}
+
+	return errors.New("instance not found")
+}
+
+type recordingHTTPContext struct {
+	spanContexts []trace.SpanContext
+}
+
+func (c *recordingHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	c.spanContexts = append(c.spanContexts, trace.SpanContextFromContext(request.Context()))
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func recordSpansForTest(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestPanicableComponent_Execute_Traced(t *testing.T) {
+	recorder := recordSpansForTest(t)
+	httpCtx := &recordingHTTPContext{}
+	executionID := uuid.New()
+
+	component := NewPanicableComponent(&requestingComponent{panickingComponent{name: "aws.ec2.createImage"}})
+	err := component.Execute(core.ExecutionContext{
+		ID:         executionID,
+		WorkflowID: "canvas-1",
+		NodeID:     "node-1",
+		Logger:     log.NewEntry(log.StandardLogger()),
+		HTTP:       httpCtx,
+	})
+
+	require.ErrorContains(t, err, "instance not found")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "component.Execute", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "instance not found", span.Status().Description)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		AttributeIntegration.String("aws"),
+		AttributeComponent.String("aws.ec2.createImage"),
+		AttributeCanvasID.String("canvas-1"),
+		AttributeNodeID.String("node-1"),
+		AttributeExecutionID.String(executionID.String()),
+	}, span.Attributes())
+
+	//
+	// The request was created without a context,
+	// but it is still sent as part of the Execute() span.
+	//
+	require.Len(t, httpCtx.spanContexts, 1)
+	assert.Equal(t, span.SpanContext().TraceID(), httpCtx.spanContexts[0].TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), httpCtx.spanContexts[0].SpanID())
+}
+
+func TestPanicableComponent_Execute_TracesPanics(t *testing.T) {
+	recorder := recordSpansForTest(t)
+
+	component := NewPanicableComponent(&panickingComponent{name: "noop"})
+	err := component.Execute(core.ExecutionContext{Logger: log.NewEntry(log.StandardLogger())})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Status().Description, "panicked in Execute()")
+	assert.NotContains(t, spans[0].Attributes(), AttributeIntegration.String("noop"))
+}
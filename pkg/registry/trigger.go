@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
 
@@ -60,12 +61,42 @@ func (s *PanicableTrigger) Actions() []core.Action {
 	return s.underlying.Actions()
 }
 
+/*
+ * RequiredPermissions returns the permissions declared with core.PermissionsProvider,
+ * or no permissions if the trigger does not declare any.
+ */
+func (s *PanicableTrigger) RequiredPermissions() core.Permissions {
+	provider, ok := s.underlying.(core.PermissionsProvider)
+	if !ok {
+		return core.Permissions{}
+	}
+
+	return provider.RequiredPermissions()
+}
+
+/*
+ * SensitivePaths returns the paths declared with core.SensitivePayload,
+ * or no paths if the trigger does not declare any.
+ */
+func (s *PanicableTrigger) SensitivePaths() []string {
+	sensitive, ok := s.underlying.(core.SensitivePayload)
+	if !ok {
+		return nil
+	}
+
+	return sensitive.SensitivePaths()
+}
+
 /*
  * Panicking methods.
  * These are where the component logic is implemented,
  * so they could panic, and if they do, the system shouldn't crash.
  */
 func (s *PanicableTrigger) Setup(ctx core.TriggerContext) (err error) {
+	goCtx, span := startSpan(context.Background(), "trigger.Setup", triggerAttributes(s.underlying.Name())...)
+	defer endSpan(span, &err)
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Trigger %s panicked in Setup(): %v\nStack: %s",
@@ -78,6 +109,15 @@ func (s *PanicableTrigger) Setup(ctx core.TriggerContext) (err error) {
 }
 
 func (s *PanicableTrigger) HandleWebhook(ctx core.WebhookRequestContext) (status int, response *core.WebhookResponseBody, err error) {
+	goCtx, span := startSpan(context.Background(), "trigger.HandleWebhook", triggerAttributes(
+		s.underlying.Name(),
+		AttributeCanvasID.String(ctx.WorkflowID),
+		AttributeNodeID.String(ctx.NodeID),
+	)...)
+
+	defer endSpan(span, &err)
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			status = 500
@@ -89,6 +129,14 @@ func (s *PanicableTrigger) HandleWebhook(ctx core.WebhookRequestContext) (status
 }
 
 func (s *PanicableTrigger) HandleAction(ctx core.TriggerActionContext) (result map[string]any, err error) {
+	goCtx, span := startSpan(context.Background(), "trigger.HandleAction", triggerAttributes(
+		s.underlying.Name(),
+		AttributeAction.String(ctx.Name),
+	)...)
+
+	defer endSpan(span, &err)
+	ctx.HTTP = traceHTTP(ctx.HTTP, goCtx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Trigger %s panicked in HandleAction(%s): %v\nStack: %s",
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	grpc "github.com/superplanehq/superplane/pkg/grpc"
 	"github.com/superplanehq/superplane/pkg/jwt"
 	"github.com/superplanehq/superplane/pkg/oidc"
+	"github.com/superplanehq/superplane/pkg/plugins"
 	"github.com/superplanehq/superplane/pkg/public"
 	registry "github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/services"
@@ -25,19 +27,27 @@ import (
 	// Import integrations, components and triggers to register them via init()
 	_ "github.com/superplanehq/superplane/pkg/components/addmemory"
 	_ "github.com/superplanehq/superplane/pkg/components/approval"
+	_ "github.com/superplanehq/superplane/pkg/components/callcanvas"
 	_ "github.com/superplanehq/superplane/pkg/components/deletememory"
 	_ "github.com/superplanehq/superplane/pkg/components/filter"
+	_ "github.com/superplanehq/superplane/pkg/components/foreach"
+	_ "github.com/superplanehq/superplane/pkg/components/getvalue"
 	_ "github.com/superplanehq/superplane/pkg/components/http"
 	_ "github.com/superplanehq/superplane/pkg/components/if"
+	_ "github.com/superplanehq/superplane/pkg/components/incrementcounter"
 	_ "github.com/superplanehq/superplane/pkg/components/merge"
 	_ "github.com/superplanehq/superplane/pkg/components/noop"
 	_ "github.com/superplanehq/superplane/pkg/components/readmemory"
+	_ "github.com/superplanehq/superplane/pkg/components/setvalue"
 	_ "github.com/superplanehq/superplane/pkg/components/ssh"
+	_ "github.com/superplanehq/superplane/pkg/components/switch"
 	_ "github.com/superplanehq/superplane/pkg/components/timegate"
+	_ "github.com/superplanehq/superplane/pkg/components/transform"
 	_ "github.com/superplanehq/superplane/pkg/components/updatememory"
 	_ "github.com/superplanehq/superplane/pkg/components/upsertmemory"
 	_ "github.com/superplanehq/superplane/pkg/components/wait"
 	_ "github.com/superplanehq/superplane/pkg/integrations/aws"
+	_ "github.com/superplanehq/superplane/pkg/integrations/azure"
 	_ "github.com/superplanehq/superplane/pkg/integrations/bitbucket"
 	_ "github.com/superplanehq/superplane/pkg/integrations/circleci"
 	_ "github.com/superplanehq/superplane/pkg/integrations/claude"
@@ -58,12 +68,15 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/integrations/hetzner"
 	_ "github.com/superplanehq/superplane/pkg/integrations/honeycomb"
 	_ "github.com/superplanehq/superplane/pkg/integrations/incident"
+	_ "github.com/superplanehq/superplane/pkg/integrations/jenkins"
 	_ "github.com/superplanehq/superplane/pkg/integrations/jfrog_artifactory"
 	_ "github.com/superplanehq/superplane/pkg/integrations/jira"
+	_ "github.com/superplanehq/superplane/pkg/integrations/kubernetes"
 	_ "github.com/superplanehq/superplane/pkg/integrations/launchdarkly"
 	_ "github.com/superplanehq/superplane/pkg/integrations/newrelic"
 	_ "github.com/superplanehq/superplane/pkg/integrations/octopus"
 	_ "github.com/superplanehq/superplane/pkg/integrations/openai"
+	_ "github.com/superplanehq/superplane/pkg/integrations/opsgenie"
 	_ "github.com/superplanehq/superplane/pkg/integrations/pagerduty"
 	_ "github.com/superplanehq/superplane/pkg/integrations/prometheus"
 	_ "github.com/superplanehq/superplane/pkg/integrations/render"
@@ -76,6 +89,7 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/integrations/statuspage"
 	_ "github.com/superplanehq/superplane/pkg/integrations/teams"
 	_ "github.com/superplanehq/superplane/pkg/integrations/telegram"
+	_ "github.com/superplanehq/superplane/pkg/integrations/terraform"
 	_ "github.com/superplanehq/superplane/pkg/triggers/schedule"
 	_ "github.com/superplanehq/superplane/pkg/triggers/start"
 	_ "github.com/superplanehq/superplane/pkg/triggers/webhook"
@@ -94,6 +108,13 @@ func startWorkers(encryptor crypto.Encryptor, registry *registry.Registry, oidcP
 		startEmailConsumers(rabbitMQURL, encryptor, baseURL, authService)
 	}
 
+	// Upgrades node configurations stored on older versions of their component fields.
+	// Can be disabled by setting START_CONFIGURATION_UPGRADER=no.
+	if os.Getenv("START_CONFIGURATION_UPGRADER") != "no" {
+		w := workers.NewConfigurationUpgrader(registry)
+		go w.Start(context.Background())
+	}
+
 	if os.Getenv("START_WORKFLOW_EVENT_ROUTER") == "yes" || os.Getenv("START_EVENT_ROUTER") == "yes" {
 		log.Println("Starting Event Router")
 
@@ -105,7 +126,12 @@ func startWorkers(encryptor crypto.Encryptor, registry *registry.Registry, oidcP
 		log.Println("Starting Node Executor")
 
 		webhookBaseURL := getWebhookBaseURL(baseURL)
-		w := workers.NewNodeExecutor(encryptor, registry, baseURL, webhookBaseURL, rabbitMQURL)
+		quotas := workers.NewExecutionQuotas(
+			lookupExecutionConcurrency("NODE_EXECUTOR_CANVAS_CONCURRENCY", workers.DefaultCanvasExecutionConcurrency),
+			lookupExecutionConcurrency("NODE_EXECUTOR_INTEGRATION_CONCURRENCY", workers.DefaultIntegrationExecutionConcurrency),
+		)
+
+		w := workers.NewNodeExecutor(encryptor, registry, baseURL, webhookBaseURL, rabbitMQURL).WithExecutionQuotas(quotas)
 		go w.Start(context.Background())
 	}
 
@@ -125,6 +151,22 @@ func startWorkers(encryptor crypto.Encryptor, registry *registry.Registry, oidcP
 		go w.Start(context.Background())
 	}
 
+	if os.Getenv("START_INTEGRATION_HEALTH_CHECK_WORKER") == "yes" {
+		log.Println("Starting Integration Health Check Worker")
+
+		w := workers.NewIntegrationHealthCheckWorker(encryptor, registry, lookupIntegrationHealthCheckInterval())
+		go w.Start(context.Background())
+	}
+
+	// Integration caches are in memory, so this should run
+	// in every process serving the public or internal API.
+	if os.Getenv("START_INTEGRATION_PREFETCH_WORKER") == "yes" {
+		log.Println("Starting Integration Prefetch Worker")
+
+		w := workers.NewIntegrationPrefetchWorker(encryptor, registry, lookupIntegrationPrefetchInterval())
+		go w.Start(context.Background())
+	}
+
 	if os.Getenv("START_WORKFLOW_NODE_QUEUE_WORKER") == "yes" || os.Getenv("START_NODE_QUEUE_WORKER") == "yes" {
 		log.Println("Starting Node Queue Worker")
 
@@ -157,6 +199,13 @@ func startWorkers(encryptor crypto.Encryptor, registry *registry.Registry, oidcP
 		go w.Start(context.Background())
 	}
 
+	if os.Getenv("START_CANVAS_BACKFILL_WORKER") == "yes" {
+		log.Println("Starting Canvas Backfill Worker")
+
+		w := workers.NewCanvasBackfillWorker()
+		go w.Start(context.Background())
+	}
+
 	if os.Getenv("START_WORKFLOW_CLEANUP_WORKER") == "yes" || os.Getenv("START_CANVAS_CLEANUP_WORKER") == "yes" {
 		log.Println("Starting Canvas Cleanup Worker")
 
@@ -223,7 +272,7 @@ func startPublicAPI(baseURL, basePath string, encryptor crypto.Encryptor, regist
 	// Start the EventDistributer worker if enabled
 	if os.Getenv("START_EVENT_DISTRIBUTER") == "yes" {
 		log.Println("Starting Event Distributer Worker")
-		eventDistributer := workers.NewEventDistributer(server.WebsocketHub())
+		eventDistributer := workers.NewEventDistributer(server.WebsocketHub(), registry)
 		go eventDistributer.Start()
 	} else {
 		log.Println("Event Distributer not started (START_EVENT_DISTRIBUTER != yes)")
@@ -304,24 +353,128 @@ func configureLogging() {
 	}
 }
 
-func setupOtelMetrics() {
-	if os.Getenv("OTEL_ENABLED") != "yes" {
+func setupOtel() {
+	otelEnabled := os.Getenv("OTEL_ENABLED") == "yes"
+	metricsPort := lookupPrometheusMetricsPort()
+	if !otelEnabled && metricsPort == 0 {
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := telemetry.InitMetrics(ctx); err != nil {
+	err := telemetry.InitMetrics(ctx, telemetry.MetricsOptions{
+		OTLP:       otelEnabled,
+		Prometheus: metricsPort != 0,
+	})
+
+	if err != nil {
 		log.Warnf("Failed to initialize OpenTelemetry metrics: %v", err)
 	} else {
 		log.Info("OpenTelemetry metrics initialized")
 	}
+
+	if err == nil && metricsPort != 0 {
+		go startPrometheusMetrics(metricsPort)
+	}
+
+	//
+	// Tracing is enabled separately, since existing collectors
+	// may only be configured to receive metrics.
+	//
+	if !otelEnabled || os.Getenv("OTEL_TRACING_ENABLED") != "yes" {
+		return
+	}
+
+	if err := telemetry.InitTracing(ctx); err != nil {
+		log.Warnf("Failed to initialize OpenTelemetry tracing: %v", err)
+	} else {
+		log.Info("OpenTelemetry tracing initialized")
+	}
+}
+
+/*
+ * Metrics are exposed for Prometheus on their own port,
+ * so they are not reachable through the public API.
+ */
+func startPrometheusMetrics(port int) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", telemetry.MetricsHandler())
+
+	log.Infof("Serving Prometheus metrics on port %d", port)
+	err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", port), mux)
+	if err != nil {
+		log.Errorf("Prometheus metrics server stopped: %v", err)
+	}
+}
+
+func lookupPrometheusMetricsPort() int {
+	p := os.Getenv("PROMETHEUS_METRICS_PORT")
+	if p == "" {
+		return 0
+	}
+
+	port, err := strconv.Atoi(p)
+	if err != nil || port <= 0 {
+		log.Warnf("Invalid PROMETHEUS_METRICS_PORT %q, not serving Prometheus metrics", p)
+		return 0
+	}
+
+	return port
+}
+
+func lookupIntegrationHealthCheckInterval() time.Duration {
+	v := os.Getenv("INTEGRATION_HEALTH_CHECK_INTERVAL")
+	if v == "" {
+		return workers.DefaultIntegrationHealthCheckInterval
+	}
+
+	interval, err := time.ParseDuration(v)
+	if err != nil || interval <= 0 {
+		log.Warnf("Invalid INTEGRATION_HEALTH_CHECK_INTERVAL %q, using %s", v, workers.DefaultIntegrationHealthCheckInterval)
+		return workers.DefaultIntegrationHealthCheckInterval
+	}
+
+	return interval
+}
+
+func lookupIntegrationPrefetchInterval() time.Duration {
+	v := os.Getenv("INTEGRATION_PREFETCH_INTERVAL")
+	if v == "" {
+		return workers.DefaultIntegrationPrefetchInterval
+	}
+
+	interval, err := time.ParseDuration(v)
+	if err != nil || interval <= 0 {
+		log.Warnf("Invalid INTEGRATION_PREFETCH_INTERVAL %q, using %s", v, workers.DefaultIntegrationPrefetchInterval)
+		return workers.DefaultIntegrationPrefetchInterval
+	}
+
+	return interval
+}
+
+/*
+ * lookupExecutionConcurrency reads how many executions of the same canvas,
+ * or integration, a node executor runs at the same time. 0 means no limit.
+ */
+func lookupExecutionConcurrency(name string, defaultValue int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return defaultValue
+	}
+
+	concurrency, err := strconv.Atoi(v)
+	if err != nil || concurrency < 0 {
+		log.Warnf("Invalid %s %q, using %d", name, v, defaultValue)
+		return defaultValue
+	}
+
+	return concurrency
 }
 
 func Start() {
 	configureLogging()
-	setupOtelMetrics()
+	setupOtel()
 
 	telemetry.InitSentry()
 	telemetry.StartBeacon()
@@ -366,6 +519,15 @@ func Start() {
 		panic("OIDC_KEYS_PATH must be set")
 	}
 
+	//
+	// Uploaded files are written by the API and read by the workers,
+	// so outside of development they must be stored in a directory they share.
+	//
+	appEnv := os.Getenv("APP_ENV")
+	if os.Getenv("BLOB_STORAGE_PATH") == "" && appEnv != "development" && appEnv != "test" {
+		panic("BLOB_STORAGE_PATH must be set")
+	}
+
 	jwtSigner := jwt.NewSigner(jwtSecret)
 	webhooksBaseURL := getWebhookBaseURL(baseURL)
 	oidcProvider, err := oidc.NewProviderFromKeyDir(webhooksBaseURL, oidcKeysPath)
@@ -373,10 +535,13 @@ func Start() {
 		panic(fmt.Sprintf("failed to load OIDC keys: %v", err))
 	}
 
+	loadPlugins()
+
 	registry, err := registry.NewRegistry(encryptorInstance, registry.HTTPOptions{
-		BlockedHosts:     getBlockedHTTPHosts(),
-		PrivateIPRanges:  getPrivateIPRanges(),
-		MaxResponseBytes: DefaultMaxHTTPResponseBytes,
+		BlockedHosts:         getBlockedHTTPHosts(),
+		PrivateIPRanges:      getPrivateIPRanges(),
+		MaxResponseBytes:     DefaultMaxHTTPResponseBytes,
+		IntegrationRateLimit: getIntegrationRateLimit(),
 	})
 
 	if err != nil {
@@ -404,6 +569,25 @@ func Start() {
 // Use WEBHOOKS_BASE_URL if set, otherwise fall back to baseURL.
 // This allows e2e tests to use a fake/mock webhook URL, and local installations to use a different
 // URL for webhooks (e.g., a tunnel URL) when the base app is running on localhost.
+/*
+ * Integrations from plugins are registered before the registry is created,
+ * like the ones compiled into SuperPlane.
+ * Plugin processes run until SuperPlane exits.
+ */
+func loadPlugins() {
+	dir := os.Getenv("SUPERPLANE_PLUGINS_DIR")
+	if dir == "" {
+		return
+	}
+
+	loaded, err := plugins.LoadDir(dir)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load plugins from %s: %v", dir, err))
+	}
+
+	log.Infof("Loaded %d plugins from %s", len(loaded), dir)
+}
+
 func getWebhookBaseURL(baseURL string) string {
 	webhookBaseURL := os.Getenv("WEBHOOKS_BASE_URL")
 	if webhookBaseURL == "" {
@@ -419,6 +603,41 @@ func getWebhookBaseURL(baseURL string) string {
  */
 var DefaultMaxHTTPResponseBytes int64 = 8 * 1024 * 1024
 
+/*
+ * Default rate limit for the HTTP requests of each integration installation.
+ * Keeps canvases that fan out many executions for the same installation
+ * from exceeding the provider API quotas and getting the installation throttled.
+ * Setting INTEGRATION_RATE_LIMIT_RPS to 0 disables it.
+ */
+var DefaultIntegrationRateLimit = registry.RateLimit{
+	RequestsPerSecond: 10,
+	Burst:             20,
+}
+
+func getIntegrationRateLimit() registry.RateLimit {
+	limit := DefaultIntegrationRateLimit
+
+	if value := os.Getenv("INTEGRATION_RATE_LIMIT_RPS"); value != "" {
+		rps, err := strconv.ParseFloat(value, 64)
+		if err != nil || rps < 0 {
+			log.Warnf("Invalid INTEGRATION_RATE_LIMIT_RPS %q, using %v", value, limit.RequestsPerSecond)
+		} else {
+			limit.RequestsPerSecond = rps
+		}
+	}
+
+	if value := os.Getenv("INTEGRATION_RATE_LIMIT_BURST"); value != "" {
+		burst, err := strconv.Atoi(value)
+		if err != nil || burst < 1 {
+			log.Warnf("Invalid INTEGRATION_RATE_LIMIT_BURST %q, using %d", value, limit.Burst)
+		} else {
+			limit.Burst = burst
+		}
+	}
+
+	return limit
+}
+
 /*
  * Default blocked HTTP hosts include:
  * - Cloud metadata endpoints
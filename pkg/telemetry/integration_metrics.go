@@ -0,0 +1,198 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+//
+// Metrics for the calls into integrations, components and triggers.
+// The component attribute holds the name of the component or trigger called,
+// and is empty for calls into the integration itself.
+//
+
+const (
+	AttributeIntegration = attribute.Key("integration")
+	AttributeComponent   = attribute.Key("component")
+	AttributeOperation   = attribute.Key("operation")
+	AttributeOutcome     = attribute.Key("outcome")
+	AttributeStatusClass = attribute.Key("status_class")
+	AttributeCache       = attribute.Key("cache")
+	AttributeResult      = attribute.Key("result")
+	AttributeType        = attribute.Key("type")
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// Calls and requests usually take from milliseconds to a few seconds.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+var (
+	integrationCallsCounter        metric.Int64Counter
+	integrationCallDuration        metric.Float64Histogram
+	integrationHTTPRequestsCounter metric.Int64Counter
+	integrationHTTPRequestDuration metric.Float64Histogram
+	integrationCacheLookupsCounter metric.Int64Counter
+	nodeRequestsBacklogGauge       metric.Int64Gauge
+)
+
+func initIntegrationMetrics() error {
+	var err error
+
+	integrationCallsCounter, err = meter.Int64Counter(
+		"integration.calls",
+		metric.WithDescription("Number of calls into integrations, components and triggers"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+
+	integrationCallDuration, err = meter.Float64Histogram(
+		"integration.call.duration.seconds",
+		metric.WithDescription("Duration of calls into integrations, components and triggers"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return err
+	}
+
+	integrationHTTPRequestsCounter, err = meter.Int64Counter(
+		"integration.http.requests",
+		metric.WithDescription("Number of HTTP requests sent to external APIs by integrations"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+
+	integrationHTTPRequestDuration, err = meter.Float64Histogram(
+		"integration.http.request.duration.seconds",
+		metric.WithDescription("Latency of HTTP requests sent to external APIs by integrations"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return err
+	}
+
+	integrationCacheLookupsCounter, err = meter.Int64Counter(
+		"integration.cache.lookups",
+		metric.WithDescription("Number of lookups in the resource caches of integrations"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+
+	nodeRequestsBacklogGauge, err = meter.Int64Gauge(
+		"node_requests.backlog",
+		metric.WithDescription("Number of workflow node requests due, but not processed yet"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/*
+ * MetricsHandler serves the metrics in the Prometheus format.
+ * Metrics are only exposed if InitMetrics() was called with the Prometheus option.
+ */
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+/*
+ * Records a call into an integration, component or trigger.
+ * Operation is the method called, e.g. component.Execute or trigger.HandleWebhook.
+ */
+func RecordIntegrationCall(ctx context.Context, operation, integration, component string, d time.Duration, err error) {
+	if !metricsReady.Load() {
+		return
+	}
+
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeError
+	}
+
+	attributes := metric.WithAttributes(
+		AttributeOperation.String(operation),
+		AttributeIntegration.String(integration),
+		AttributeComponent.String(component),
+		AttributeOutcome.String(outcome),
+	)
+
+	integrationCallsCounter.Add(ctx, 1, attributes)
+	integrationCallDuration.Record(ctx, d.Seconds(), attributes)
+}
+
+/*
+ * Records an HTTP request sent by an integration.
+ * Requests that fail without a response are recorded with the "error" status class.
+ */
+func RecordIntegrationHTTPRequest(ctx context.Context, integration, component string, d time.Duration, statusCode int, err error) {
+	if !metricsReady.Load() {
+		return
+	}
+
+	attributes := metric.WithAttributes(
+		AttributeIntegration.String(integration),
+		AttributeComponent.String(component),
+		AttributeStatusClass.String(statusClass(statusCode, err)),
+	)
+
+	integrationHTTPRequestsCounter.Add(ctx, 1, attributes)
+	integrationHTTPRequestDuration.Record(ctx, d.Seconds(), attributes)
+}
+
+func RecordIntegrationCacheLookup(ctx context.Context, cache string, hit bool) {
+	if !metricsReady.Load() {
+		return
+	}
+
+	result := CacheMiss
+	if hit {
+		result = CacheHit
+	}
+
+	integrationCacheLookupsCounter.Add(ctx, 1, metric.WithAttributes(
+		AttributeCache.String(cache),
+		AttributeResult.String(result),
+	))
+}
+
+func RecordNodeRequestsBacklog(ctx context.Context, requestType, component string, count int64) {
+	if !metricsReady.Load() {
+		return
+	}
+
+	nodeRequestsBacklogGauge.Record(ctx, count, metric.WithAttributes(
+		AttributeType.String(requestType),
+		AttributeComponent.String(component),
+	))
+}
+
+func statusClass(statusCode int, err error) string {
+	if err != nil || statusCode < 100 {
+		return OutcomeError
+	}
+
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
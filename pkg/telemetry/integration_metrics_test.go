@@ -0,0 +1,37 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationMetrics_ExposedForPrometheus(t *testing.T) {
+	require.NoError(t, InitMetrics(context.Background(), MetricsOptions{Prometheus: true}))
+
+	ctx := context.Background()
+	RecordIntegrationCall(ctx, "component.Execute", "aws", "aws.ec2.createImage", time.Second, nil)
+	RecordIntegrationCall(ctx, "component.Execute", "aws", "aws.ec2.createImage", time.Second, errors.New("oops"))
+	RecordIntegrationHTTPRequest(ctx, "aws", "aws.ec2.createImage", 100*time.Millisecond, http.StatusTooManyRequests, nil)
+	RecordIntegrationHTTPRequest(ctx, "aws", "aws.ec2.createImage", 100*time.Millisecond, 0, errors.New("connection refused"))
+	RecordIntegrationCacheLookup(ctx, "gcp.compute.machineConfig", true)
+	RecordNodeRequestsBacklog(ctx, "invoke-action", "noop", 3)
+
+	recorder := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body := recorder.Body.String()
+	assert.Regexp(t, `superplane_integration_calls_total\{component="aws.ec2.createImage",integration="aws",operation="component.Execute",[^}]*outcome="success"\} 1`, body)
+	assert.Regexp(t, `superplane_integration_calls_total\{component="aws.ec2.createImage",integration="aws",operation="component.Execute",[^}]*outcome="error"\} 1`, body)
+	assert.Regexp(t, `superplane_integration_http_requests_total\{component="aws.ec2.createImage",integration="aws",[^}]*status_class="4xx"\} 1`, body)
+	assert.Regexp(t, `superplane_integration_http_requests_total\{component="aws.ec2.createImage",integration="aws",[^}]*status_class="error"\} 1`, body)
+	assert.Regexp(t, `superplane_integration_cache_lookups_total\{cache="gcp.compute.machineConfig",[^}]*result="hit"\} 1`, body)
+	assert.Regexp(t, `superplane_node_requests_backlog\{component="noop",[^}]*type="invoke-action"\} 3`, body)
+}
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/metric"
 
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
@@ -36,21 +37,41 @@ var (
 	dbLongQueriesCountHistogram metric.Int64Histogram
 )
 
-func InitMetrics(ctx context.Context) error {
-	exporter, err := otlpmetricgrpc.New(ctx)
-	if err != nil {
-		return err
+type MetricsOptions struct {
+	// Push metrics to the OTLP collector configured with the OTEL_EXPORTER_OTLP_* variables.
+	OTLP bool
+
+	// Expose metrics to be scraped by Prometheus, through MetricsHandler().
+	Prometheus bool
+}
+
+func InitMetrics(ctx context.Context, options MetricsOptions) error {
+	readers := []sdkmetric.Option{}
+
+	if options.OTLP {
+		exporter, err := otlpmetricgrpc.New(ctx)
+		if err != nil {
+			return err
+		}
+
+		readers = append(readers, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)))
 	}
 
-	provider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(
-			sdkmetric.NewPeriodicReader(exporter),
-		),
-	)
+	if options.Prometheus {
+		exporter, err := otelprometheus.New(otelprometheus.WithNamespace("superplane"))
+		if err != nil {
+			return err
+		}
+
+		readers = append(readers, sdkmetric.WithReader(exporter))
+	}
+
+	provider := sdkmetric.NewMeterProvider(readers...)
 
 	otel.SetMeterProvider(provider)
 	meter = provider.Meter("superplane")
 
+	var err error
 	queueWorkerTickHistogram, err = meter.Float64Histogram(
 		"queue_worker.tick.duration.seconds",
 		metric.WithDescription("Duration of each WorkflowNodeQueueWorker tick"),
@@ -168,6 +189,10 @@ func InitMetrics(ctx context.Context) error {
 		return err
 	}
 
+	if err := initIntegrationMetrics(); err != nil {
+		return err
+	}
+
 	StartPeriodicMetricsReporter()
 
 	metricsReady.Store(true)
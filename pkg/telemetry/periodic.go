@@ -13,11 +13,23 @@ import (
 
 type Periodic struct {
 	ctx context.Context
+
+	//
+	// Backlogs reported on the last tick,
+	// so backlogs that were cleared are reported as empty.
+	//
+	backlogs map[nodeRequestsBacklog]bool
+}
+
+type nodeRequestsBacklog struct {
+	Type      string
+	Component string
 }
 
 func NewPeriodic(ctx context.Context) *Periodic {
 	return &Periodic{
-		ctx: ctx,
+		ctx:      ctx,
+		backlogs: map[nodeRequestsBacklog]bool{},
 	}
 }
 
@@ -36,6 +48,7 @@ func (p *Periodic) report() {
 	p.reportDatabaseLocks()
 	p.reportLongQueries()
 	p.reportStuckQueueItems()
+	p.reportNodeRequestsBacklog()
 }
 
 func (p *Periodic) reportDatabaseLocks() {
@@ -58,6 +71,28 @@ func (p *Periodic) reportStuckQueueItems() {
 	RecordStuckQueueItemsCount(p.ctx, int(count))
 }
 
+func (p *Periodic) reportNodeRequestsBacklog() {
+	counts, err := countNodeRequestsBacklog(time.Now())
+	if err != nil {
+		return
+	}
+
+	backlogs := map[nodeRequestsBacklog]bool{}
+	for _, c := range counts {
+		backlog := nodeRequestsBacklog{Type: c.Type, Component: c.Component}
+		backlogs[backlog] = true
+		RecordNodeRequestsBacklog(p.ctx, c.Type, c.Component, c.Count)
+	}
+
+	for backlog := range p.backlogs {
+		if !backlogs[backlog] {
+			RecordNodeRequestsBacklog(p.ctx, backlog.Type, backlog.Component, 0)
+		}
+	}
+
+	p.backlogs = backlogs
+}
+
 func (p *Periodic) reportLongQueries() {
 	var count int64
 
@@ -104,3 +139,39 @@ func countStuckQueueNodes() (int64, error) {
 
 	return count, nil
 }
+
+type nodeRequestsBacklogCount struct {
+	Type      string
+	Component string
+	Count     int64
+}
+
+/*
+ * Counts the pending node requests that are already due,
+ * grouped by request type and by the component or trigger of the node.
+ */
+func countNodeRequestsBacklog(now time.Time) ([]nodeRequestsBacklogCount, error) {
+	var counts []nodeRequestsBacklogCount
+
+	err := database.Conn().
+		Raw(`
+			SELECT
+				r.type AS type,
+				COALESCE(n.ref->'component'->>'name', n.ref->'trigger'->>'name', '') AS component,
+				COUNT(*) AS count
+			FROM workflow_node_requests r
+			JOIN workflow_nodes n
+			  ON n.workflow_id = r.workflow_id
+			 AND n.node_id = r.node_id
+			WHERE r.state = 'pending'
+			  AND r.run_at <= ?
+			GROUP BY 1, 2
+		`, now).
+		Scan(&counts).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
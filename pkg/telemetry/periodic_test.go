@@ -104,6 +104,40 @@ func TestCountStuckQueueNodes_NodeWithNonFinishedExecutionIsNotCounted(t *testin
 	require.Equal(t, int64(0), count)
 }
 
+func TestCountNodeRequestsBacklog_OnlyDuePendingRequestsAreCounted(t *testing.T) {
+	database.TruncateTables()
+
+	steps := stuckQueueItemsTestSteps{t: t}
+	steps.CreateWorkflow()
+	steps.CreateWorkflowNode()
+
+	db := database.Conn()
+	now := time.Now()
+	requests := []models.CanvasNodeRequest{
+		{State: models.NodeExecutionRequestStatePending, Type: models.NodeRequestTypeInvokeAction, RunAt: now.Add(-time.Minute)},
+		{State: models.NodeExecutionRequestStatePending, Type: models.NodeRequestTypeInvokeAction, RunAt: now.Add(-time.Second)},
+		{State: models.NodeExecutionRequestStatePending, Type: models.NodeRequestTypeInvokeAction, RunAt: now.Add(time.Hour)},
+		{State: models.NodeExecutionRequestStateCompleted, Type: models.NodeRequestTypeInvokeAction, RunAt: now.Add(-time.Minute)},
+		{State: models.NodeExecutionRequestStatePending, Type: models.NodeRequestTypeExecutionTimeout, RunAt: now.Add(-time.Minute)},
+	}
+
+	for _, request := range requests {
+		request.ID = uuid.New()
+		request.WorkflowID = steps.workflow.ID
+		request.NodeID = steps.node.NodeID
+		request.CreatedAt = now
+		request.UpdatedAt = now
+		require.NoError(t, db.Create(&request).Error)
+	}
+
+	counts, err := countNodeRequestsBacklog(now)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []nodeRequestsBacklogCount{
+		{Type: models.NodeRequestTypeInvokeAction, Component: "noop", Count: 2},
+		{Type: models.NodeRequestTypeExecutionTimeout, Component: "noop", Count: 1},
+	}, counts)
+}
+
 type stuckQueueItemsTestSteps struct {
 	t         *testing.T
 	workflow  *models.Canvas
@@ -146,6 +180,7 @@ func (s *stuckQueueItemsTestSteps) CreateWorkflowNode() {
 	s.node = &models.CanvasNode{
 		WorkflowID: s.workflow.ID,
 		NodeID:     "node-1",
+		Ref:        datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "noop"}}),
 	}
 
 	require.NoError(s.t, database.Conn().Create(s.node).Error)
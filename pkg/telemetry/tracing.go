@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+/*
+ * InitTracing exports the spans created around component, trigger and integration calls,
+ * and around the HTTP requests they send, with OTLP.
+ * The exporter is configured with the standard OTEL_EXPORTER_OTLP_* variables.
+ */
+func InitTracing(ctx context.Context) error {
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	//
+	// The trace context is propagated to the APIs called by integrations,
+	// so their spans are part of the same trace, if they support it.
+	//
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return nil
+}
+
+/*
+ * NewHTTPTransport returns a transport that creates a span for each request
+ * and propagates the trace context, for integrations with their own HTTP clients.
+ *
+ * Requests are sent with http.DefaultTransport, looked up on every request,
+ * so tests that replace http.DefaultTransport are not bypassed.
+ */
+func NewHTTPTransport() http.RoundTripper {
+	return otelhttp.NewTransport(defaultTransport{})
+}
+
+type defaultTransport struct{}
+
+func (defaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return http.DefaultTransport.RoundTrip(req)
+}
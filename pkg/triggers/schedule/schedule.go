@@ -2,6 +2,7 @@ package schedule
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"strconv"
 	"strings"
@@ -33,6 +34,19 @@ const (
 	WeekDayFriday    = "friday"
 	WeekDaySaturday  = "saturday"
 	WeekDaySunday    = "sunday"
+
+	CatchUpOnce = "once"
+	CatchUpAll  = "all"
+	CatchUpSkip = "skip"
+
+	// Triggers firing later than this (plus the configured jitter)
+	// are considered missed by the catch-up policy.
+	CatchUpGracePeriod = time.Minute
+
+	// Upper bound on the number of events emitted when catching up.
+	MaxCatchUpEvents = 10
+
+	MaxJitterSeconds = 3600
 )
 
 type Schedule struct{}
@@ -54,7 +68,9 @@ type Configuration struct {
 	WeekDays        []string `json:"weekDays"`        // For weeks scheduling (multiple days)
 	DayOfMonth      *int     `json:"dayOfMonth"`      // 1-31 for months scheduling
 	CronExpression  *string  `json:"cronExpression"`  // For cron scheduling
-	Timezone        *string  `json:"timezone"`        // Timezone offset (e.g., "0", "-5", "5.5")
+	Timezone        *string  `json:"timezone"`        // Timezone offset (e.g., "0", "-5", "5.5") or IANA name
+	Jitter          *int     `json:"jitter"`          // 0-3600 seconds of random delay added to each trigger
+	CatchUp         *string  `json:"catchUp"`         // What to do with triggers missed while SuperPlane was unavailable
 }
 
 func (s *Schedule) Name() string {
@@ -92,6 +108,20 @@ func (s *Schedule) Documentation() string {
 
 For days, weeks, months, and cron schedules, you can specify a timezone to ensure triggers occur at the correct local time.
 
+The timezone can be a UTC offset in hours (e.g. ` + "`-5`" + `, ` + "`5.5`" + `) or an IANA name (e.g. ` + "`Europe/Berlin`" + `).
+
+## Jitter
+
+Set **Jitter** to delay each trigger by a random number of seconds (up to 1 hour).
+This spreads out load when many schedules fire at the same time, e.g. at midnight.
+
+## Catch-up Policy
+
+If a trigger fires late, for example because SuperPlane was unavailable, the catch-up policy decides what happens with the missed occurrences:
+- **Run once** (default): emit a single event for all missed occurrences
+- **Run all missed**: emit one event per missed occurrence (at most 10)
+- **Skip**: emit nothing for missed occurrences and wait for the next one
+
 ## Cron Expressions
 
 Supports both 5-field and 6-field cron expressions:
@@ -349,6 +379,35 @@ func (s *Schedule) Configuration() []configuration.Field {
 				Cron: &configuration.CronTypeOptions{},
 			},
 		},
+		{
+			Name:        "jitter",
+			Label:       "Jitter (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Default:     intPtr(0),
+			Description: "Random delay of up to this many seconds added to each trigger (0-3600)",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: intPtr(0),
+					Max: intPtr(MaxJitterSeconds),
+				},
+			},
+		},
+		{
+			Name:        "catchUp",
+			Label:       "Missed triggers",
+			Type:        configuration.FieldTypeSelect,
+			Default:     CatchUpOnce,
+			Description: "What to do with triggers missed while SuperPlane was unavailable",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Run once", Value: CatchUpOnce},
+						{Label: "Run all missed", Value: CatchUpAll},
+						{Label: "Skip", Value: CatchUpSkip},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -394,7 +453,7 @@ func (s *Schedule) Setup(ctx core.TriggerContext) error {
 	//
 	// Always schedule the next and save the next trigger in the metadata.
 	//
-	err = ctx.Requests.ScheduleActionCall("emitEvent", map[string]any{}, time.Until(*nextTrigger))
+	err = ctx.Requests.ScheduleActionCall("emitEvent", map[string]any{}, time.Until(*nextTrigger)+jitterDelay(config))
 	if err != nil {
 		return err
 	}
@@ -431,44 +490,26 @@ func (s *Schedule) emitEvent(ctx core.TriggerActionContext) error {
 		return err
 	}
 
-	var timezone *time.Location
-	var now time.Time
-
-	// Only use timezone for schedule types that support it
-	if spec.Type == TypeDays || spec.Type == TypeWeeks || spec.Type == TypeMonths || spec.Type == TypeCron {
-		timezone = parseTimezone(spec.Timezone)
-		now = time.Now().In(timezone)
-	} else {
-		now = time.Now()
-	}
-
-	payload := map[string]any{
-		"calendar": map[string]any{
-			"year":     now.Format("2006"),
-			"month":    now.Format("January"),
-			"day":      now.Format("2"),
-			"hour":     now.Format("15"),
-			"minute":   now.Format("04"),
-			"second":   now.Format("05"),
-			"week_day": now.Format("Monday"),
-		},
-	}
-
-	// Only include timezone for schedule types that support it
-	if timezone != nil {
-		payload["timezone"] = formatTimezone(timezone)
+	var existingMetadata Metadata
+	err = mapstructure.Decode(ctx.Metadata.Get(), &existingMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse existing metadata: %w", err)
 	}
 
-	err = ctx.Events.Emit("scheduler.tick", payload)
-
+	occurrences, err := dueOccurrences(spec, existingMetadata, time.Now())
 	if err != nil {
 		return err
 	}
 
-	var existingMetadata Metadata
-	err = mapstructure.Decode(ctx.Metadata.Get(), &existingMetadata)
-	if err != nil {
-		return fmt.Errorf("failed to parse existing metadata: %w", err)
+	if len(occurrences) == 0 {
+		ctx.Logger.Infof("Skipping missed trigger scheduled for %s", *existingMetadata.NextTrigger)
+	}
+
+	for _, occurrence := range occurrences {
+		err = ctx.Events.Emit("scheduler.tick", buildPayload(spec, occurrence))
+		if err != nil {
+			return err
+		}
 	}
 
 	nowUTC := time.Now()
@@ -477,7 +518,7 @@ func (s *Schedule) emitEvent(ctx core.TriggerActionContext) error {
 		return err
 	}
 
-	err = ctx.Requests.ScheduleActionCall("emitEvent", map[string]any{}, time.Until(*nextTrigger))
+	err = ctx.Requests.ScheduleActionCall("emitEvent", map[string]any{}, time.Until(*nextTrigger)+jitterDelay(spec))
 	if err != nil {
 		return err
 	}
@@ -491,6 +532,98 @@ func (s *Schedule) emitEvent(ctx core.TriggerActionContext) error {
 	})
 }
 
+// dueOccurrences returns the times for which an event should be emitted,
+// according to the catch-up policy. A trigger is considered missed when it
+// fires later than the scheduled time plus the jitter and a grace period.
+func dueOccurrences(spec Configuration, metadata Metadata, now time.Time) ([]time.Time, error) {
+	if metadata.NextTrigger == nil {
+		return []time.Time{now}, nil
+	}
+
+	scheduled, err := time.Parse(time.RFC3339, *metadata.NextTrigger)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing next trigger: %v", err)
+	}
+
+	tolerance := CatchUpGracePeriod
+	if spec.Jitter != nil {
+		tolerance += time.Duration(*spec.Jitter) * time.Second
+	}
+
+	if now.Sub(scheduled) <= tolerance {
+		return []time.Time{now}, nil
+	}
+
+	switch spec.CatchUpPolicy() {
+	case CatchUpSkip:
+		return []time.Time{}, nil
+
+	case CatchUpAll:
+		occurrences := []time.Time{}
+		for occurrence := scheduled; !occurrence.After(now) && len(occurrences) < MaxCatchUpEvents; {
+			occurrences = append(occurrences, occurrence)
+
+			next, err := getNextTrigger(spec, occurrence, metadata.ReferenceTime)
+			if err != nil {
+				return nil, err
+			}
+
+			occurrence = *next
+		}
+
+		return occurrences, nil
+
+	default:
+		return []time.Time{now}, nil
+	}
+}
+
+func buildPayload(spec Configuration, at time.Time) map[string]any {
+	var timezone *time.Location
+
+	// Only use timezone for schedule types that support it
+	if spec.Type == TypeDays || spec.Type == TypeWeeks || spec.Type == TypeMonths || spec.Type == TypeCron {
+		timezone = parseTimezone(spec.Timezone)
+		at = at.In(timezone)
+	}
+
+	payload := map[string]any{
+		"calendar": map[string]any{
+			"year":     at.Format("2006"),
+			"month":    at.Format("January"),
+			"day":      at.Format("2"),
+			"hour":     at.Format("15"),
+			"minute":   at.Format("04"),
+			"second":   at.Format("05"),
+			"week_day": at.Format("Monday"),
+		},
+	}
+
+	// Only include timezone for schedule types that support it
+	if timezone != nil {
+		payload["timezone"] = formatTimezone(timezone)
+	}
+
+	return payload
+}
+
+func jitterDelay(spec Configuration) time.Duration {
+	if spec.Jitter == nil || *spec.Jitter <= 0 {
+		return 0
+	}
+
+	jitter := min(*spec.Jitter, MaxJitterSeconds)
+	return rand.N(time.Duration(jitter) * time.Second)
+}
+
+func (c Configuration) CatchUpPolicy() string {
+	if c.CatchUp == nil || *c.CatchUp == "" {
+		return CatchUpOnce
+	}
+
+	return *c.CatchUp
+}
+
 func getNextTrigger(config Configuration, now time.Time, referenceTime *string) (*time.Time, error) {
 	timezone := parseTimezone(config.Timezone)
 	nowInTZ := now.In(timezone)
@@ -657,7 +790,12 @@ func parseTimezone(timezoneStr *string) *time.Location {
 
 	offsetHours, err := strconv.ParseFloat(*timezoneStr, 64)
 	if err != nil {
-		return time.UTC
+		location, err := time.LoadLocation(*timezoneStr)
+		if err != nil {
+			return time.UTC
+		}
+
+		return location
 	}
 	offsetSeconds := int(offsetHours * 3600)
 
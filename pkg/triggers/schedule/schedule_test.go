@@ -553,3 +553,134 @@ func TestEmitEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestDueOccurrences(t *testing.T) {
+	hourly := Configuration{
+		Type:          TypeHours,
+		HoursInterval: intPtr(1),
+		Minute:        intPtr(0),
+	}
+
+	withPolicy := func(policy string, jitter int) Configuration {
+		config := hourly
+		config.CatchUp = stringPtr(policy)
+		config.Jitter = intPtr(jitter)
+		return config
+	}
+
+	tests := []struct {
+		name     string
+		config   Configuration
+		next     *string
+		now      time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "no previous trigger emits once",
+			config:   withPolicy(CatchUpSkip, 0),
+			now:      mustParseTime("2025-01-01T10:00:00Z"),
+			expected: []time.Time{mustParseTime("2025-01-01T10:00:00Z")},
+		},
+		{
+			name:     "on time emits once regardless of policy",
+			config:   withPolicy(CatchUpSkip, 0),
+			next:     stringPtr("2025-01-01T10:00:00Z"),
+			now:      mustParseTime("2025-01-01T10:00:30Z"),
+			expected: []time.Time{mustParseTime("2025-01-01T10:00:30Z")},
+		},
+		{
+			name:     "late within jitter is not considered missed",
+			config:   withPolicy(CatchUpSkip, 300),
+			next:     stringPtr("2025-01-01T10:00:00Z"),
+			now:      mustParseTime("2025-01-01T10:05:00Z"),
+			expected: []time.Time{mustParseTime("2025-01-01T10:05:00Z")},
+		},
+		{
+			name:     "missed with skip policy emits nothing",
+			config:   withPolicy(CatchUpSkip, 0),
+			next:     stringPtr("2025-01-01T10:00:00Z"),
+			now:      mustParseTime("2025-01-01T12:30:00Z"),
+			expected: []time.Time{},
+		},
+		{
+			name:     "missed with once policy emits once",
+			config:   withPolicy(CatchUpOnce, 0),
+			next:     stringPtr("2025-01-01T10:00:00Z"),
+			now:      mustParseTime("2025-01-01T12:30:00Z"),
+			expected: []time.Time{mustParseTime("2025-01-01T12:30:00Z")},
+		},
+		{
+			name:   "missed with all policy emits every missed occurrence",
+			config: withPolicy(CatchUpAll, 0),
+			next:   stringPtr("2025-01-01T10:00:00Z"),
+			now:    mustParseTime("2025-01-01T12:30:00Z"),
+			expected: []time.Time{
+				mustParseTime("2025-01-01T10:00:00Z"),
+				mustParseTime("2025-01-01T11:00:00Z"),
+				mustParseTime("2025-01-01T12:00:00Z"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := dueOccurrences(tt.config, Metadata{NextTrigger: tt.next}, tt.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Fatalf("expected %d occurrences, got %d: %v", len(tt.expected), len(result), result)
+			}
+
+			for i := range result {
+				if !result[i].Equal(tt.expected[i]) {
+					t.Errorf("expected occurrence %d at %v, got %v", i, tt.expected[i], result[i])
+				}
+			}
+		})
+	}
+
+	t.Run("all policy is capped", func(t *testing.T) {
+		result, err := dueOccurrences(withPolicy(CatchUpAll, 0), Metadata{NextTrigger: stringPtr("2025-01-01T00:00:00Z")}, mustParseTime("2025-01-03T00:00:00Z"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result) != MaxCatchUpEvents {
+			t.Errorf("expected %d occurrences, got %d", MaxCatchUpEvents, len(result))
+		}
+	})
+}
+
+func TestJitterDelay(t *testing.T) {
+	if delay := jitterDelay(Configuration{}); delay != 0 {
+		t.Errorf("expected no delay without jitter, got %v", delay)
+	}
+
+	for i := 0; i < 100; i++ {
+		delay := jitterDelay(Configuration{Jitter: intPtr(30)})
+		if delay < 0 || delay >= 30*time.Second {
+			t.Fatalf("expected delay in [0, 30s), got %v", delay)
+		}
+	}
+}
+
+func TestIANATimezone(t *testing.T) {
+	config := Configuration{
+		Type:           TypeCron,
+		CronExpression: stringPtr("0 9 * * *"),
+		Timezone:       stringPtr("America/New_York"),
+	}
+
+	// 9 AM EST is 2 PM UTC in January.
+	result, err := getNextTrigger(config, mustParseTime("2025-01-01T12:00:00Z"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := mustParseTime("2025-01-01T14:00:00Z")
+	if !result.Equal(expected) {
+		t.Errorf("expected next trigger at %v, got %v", expected, *result)
+	}
+}
@@ -0,0 +1,325 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is the subset of JSON Schema used to filter incoming
+// webhook bodies. Keywords not listed here are ignored.
+type Schema struct {
+	Type                 any                `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+var schemaTypes = []string{"object", "array", "string", "number", "integer", "boolean", "null"}
+
+// ParseSchema accepts either a JSON string or an already
+// decoded object, and returns the compiled schema.
+func ParseSchema(value any) (*Schema, error) {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	default:
+		var err error
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+func (s *Schema) types() ([]string, error) {
+	switch t := s.Type.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{t}, nil
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid schema: type must be a string or a list of strings")
+			}
+			types = append(types, name)
+		}
+		return types, nil
+	default:
+		return nil, fmt.Errorf("invalid schema: type must be a string or a list of strings")
+	}
+}
+
+func (s *Schema) compile() error {
+	types, err := s.types()
+	if err != nil {
+		return err
+	}
+
+	for _, t := range types {
+		if !slices.Contains(schemaTypes, t) {
+			return fmt.Errorf("invalid schema: unknown type %q", t)
+		}
+	}
+
+	if s.Pattern != "" {
+		s.pattern, err = regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid schema: invalid pattern %q: %w", s.Pattern, err)
+		}
+	}
+
+	children := []*Schema{s.Items, s.Not}
+	for _, property := range s.Properties {
+		children = append(children, property)
+	}
+	children = append(children, s.AnyOf...)
+	children = append(children, s.AllOf...)
+
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+
+		if err := child.compile(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate returns an error describing the first
+// mismatch between the value and the schema.
+func (s *Schema) Validate(value any) error {
+	return s.validate("$", value)
+}
+
+func (s *Schema) validate(path string, value any) error {
+	types, _ := s.types()
+	if len(types) > 0 && !matchesAnyType(types, value) {
+		return fmt.Errorf("%s: expected %s", path, strings.Join(types, " or "))
+	}
+
+	if s.Const != nil && !jsonEqual(s.Const, value) {
+		return fmt.Errorf("%s: does not match the expected constant", path)
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, option := range s.Enum {
+			if jsonEqual(option, value) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: is not one of the allowed values", path)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if err := s.validateObject(path, v); err != nil {
+			return err
+		}
+	case []any:
+		if err := s.validateArray(path, v); err != nil {
+			return err
+		}
+	case string:
+		if err := s.validateString(path, v); err != nil {
+			return err
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fmt.Errorf("%s: must be >= %v", path, *s.Minimum)
+		}
+
+		if s.Maximum != nil && v > *s.Maximum {
+			return fmt.Errorf("%s: must be <= %v", path, *s.Maximum)
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		if err := sub.validate(path, value); err != nil {
+			return err
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, sub := range s.AnyOf {
+			if sub.validate(path, value) == nil {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fmt.Errorf("%s: does not match any of the allowed schemas", path)
+		}
+	}
+
+	if s.Not != nil && s.Not.validate(path, value) == nil {
+		return fmt.Errorf("%s: matches a disallowed schema", path)
+	}
+
+	return nil
+}
+
+func (s *Schema) validateObject(path string, value map[string]any) error {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", path, name)
+		}
+	}
+
+	for name, item := range value {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return fmt.Errorf("%s: unexpected property %q", path, name)
+			}
+			continue
+		}
+
+		if err := property.validate(path+"."+name, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateArray(path string, value []any) error {
+	if s.MinItems != nil && len(value) < *s.MinItems {
+		return fmt.Errorf("%s: must have at least %d items", path, *s.MinItems)
+	}
+
+	if s.MaxItems != nil && len(value) > *s.MaxItems {
+		return fmt.Errorf("%s: must have at most %d items", path, *s.MaxItems)
+	}
+
+	if s.Items == nil {
+		return nil
+	}
+
+	for i, item := range value {
+		if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateString(path string, value string) error {
+	length := utf8.RuneCountInString(value)
+	if s.MinLength != nil && length < *s.MinLength {
+		return fmt.Errorf("%s: must be at least %d characters", path, *s.MinLength)
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return fmt.Errorf("%s: must be at most %d characters", path, *s.MaxLength)
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		return fmt.Errorf("%s: does not match pattern %q", path, s.Pattern)
+	}
+
+	return nil
+}
+
+func matchesAnyType(types []string, value any) bool {
+	for _, t := range types {
+		if matchesType(t, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesType(t string, value any) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+
+	return false
+}
+
+// jsonEqual compares values after normalizing them through JSON,
+// so numbers from the schema and from the body compare equally.
+func jsonEqual(a, b any) bool {
+	return reflect.DeepEqual(normalizeJSON(a), normalizeJSON(b))
+}
+
+func normalizeJSON(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+
+	return normalized
+}
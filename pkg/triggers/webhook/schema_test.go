@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__ParseSchema(t *testing.T) {
+	t.Run("invalid JSON -> error", func(t *testing.T) {
+		_, err := ParseSchema(`{"type":`)
+		require.ErrorContains(t, err, "invalid schema")
+	})
+
+	t.Run("unknown type -> error", func(t *testing.T) {
+		_, err := ParseSchema(map[string]any{"type": "date"})
+		require.ErrorContains(t, err, "unknown type")
+	})
+
+	t.Run("invalid nested pattern -> error", func(t *testing.T) {
+		_, err := ParseSchema(map[string]any{
+			"properties": map[string]any{"name": map[string]any{"pattern": "("}},
+		})
+		require.ErrorContains(t, err, "invalid pattern")
+	})
+}
+
+func Test__Schema__Validate(t *testing.T) {
+	schema, err := ParseSchema(`{
+		"type": "object",
+		"required": ["ref", "commits"],
+		"additionalProperties": false,
+		"properties": {
+			"ref": {"type": "string", "pattern": "^refs/heads/"},
+			"commits": {"type": "array", "minItems": 1, "items": {"type": "object", "required": ["id"]}},
+			"size": {"type": ["integer", "null"], "minimum": 0, "maximum": 10},
+			"kind": {"anyOf": [{"const": "push"}, {"const": "tag"}]},
+			"name": {"type": "string", "minLength": 2, "not": {"enum": ["admin"]}}
+		}
+	}`)
+	require.NoError(t, err)
+
+	valid := map[string]any{
+		"ref":     "refs/heads/main",
+		"commits": []any{map[string]any{"id": "abc"}},
+		"size":    float64(3),
+		"kind":    "push",
+		"name":    "bob",
+	}
+	require.NoError(t, schema.Validate(valid))
+
+	cases := map[string]func(body map[string]any){
+		"missing required":   func(body map[string]any) { delete(body, "commits") },
+		"additional":         func(body map[string]any) { body["extra"] = true },
+		"pattern mismatch":   func(body map[string]any) { body["ref"] = "refs/tags/v1" },
+		"min items":          func(body map[string]any) { body["commits"] = []any{} },
+		"invalid item":       func(body map[string]any) { body["commits"] = []any{map[string]any{}} },
+		"not an integer":     func(body map[string]any) { body["size"] = 1.5 },
+		"above maximum":      func(body map[string]any) { body["size"] = float64(11) },
+		"no anyOf match":     func(body map[string]any) { body["kind"] = "release" },
+		"too short":          func(body map[string]any) { body["name"] = "b" },
+		"matches not schema": func(body map[string]any) { body["name"] = "admin" },
+	}
+
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := map[string]any{}
+			for k, v := range valid {
+				body[k] = v
+			}
+
+			mutate(body)
+			assert.Error(t, schema.Validate(body))
+		})
+	}
+
+	t.Run("null is accepted by union type", func(t *testing.T) {
+		body := map[string]any{}
+		for k, v := range valid {
+			body[k] = v
+		}
+
+		body["size"] = nil
+		assert.NoError(t, schema.Validate(body))
+	})
+}
@@ -1,6 +1,7 @@
 package webhook
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 const (
 	MaxEventSize           = 64 * 1024
 	DefaultHeaderTokenName = "X-Webhook-Token"
+	DefaultSignatureHeader = "X-Signature-256"
 )
 
 func init() {
@@ -30,8 +32,10 @@ type Metadata struct {
 }
 
 type Configuration struct {
-	Authentication string `json:"authentication"`
-	HeaderName     string `json:"headerName" mapstructure:"headerName"`
+	Authentication  string `json:"authentication"`
+	HeaderName      string `json:"headerName" mapstructure:"headerName"`
+	SignatureHeader string `json:"signatureHeader" mapstructure:"signatureHeader"`
+	SchemaFilter    any    `json:"schemaFilter" mapstructure:"schemaFilter"`
 }
 
 func (w *Webhook) Name() string {
@@ -65,11 +69,18 @@ func (w *Webhook) Documentation() string {
 
 ## Authentication Methods
 
-- **Signature (HMAC)**: Verify requests using HMAC-SHA256 signature in the ` + "`X-Signature-256`" + ` header
+- **Signature (HMAC)**: Verify requests using HMAC-SHA256 signature in a configurable header (default: ` + "`X-Signature-256`" + `). A ` + "`sha256=`" + ` prefix is accepted
 - **Bearer Token**: Require a Bearer token in the ` + "`Authorization`" + ` header
 - **Header Token**: Require a raw token in a custom header (default: ` + "`X-Webhook-Token`" + `)
 - **None (unsafe)**: No authentication (not recommended for production)
 
+## Schema Filter
+
+Enable **Schema Filter** and provide a JSON Schema to only accept requests whose body matches it.
+Requests that do not match are acknowledged with a 200 response but do not start an execution.
+
+Supported keywords: ` + "`type`, `properties`, `required`, `additionalProperties`, `items`, `minItems`, `maxItems`, `enum`, `const`, `minimum`, `maximum`, `minLength`, `maxLength`, `pattern`, `anyOf`, `allOf` and `not`" + `.
+
 ## Request Data
 
 The webhook payload includes:
@@ -129,6 +140,24 @@ func (w *Webhook) Configuration() []configuration.Field {
 				{Field: "authentication", Values: []string{"header_token"}},
 			},
 		},
+		{
+			Name:        "signatureHeader",
+			Label:       "Signature Header",
+			Type:        configuration.FieldTypeString,
+			Default:     DefaultSignatureHeader,
+			Placeholder: DefaultSignatureHeader,
+			Description: "HTTP header that contains the HMAC-SHA256 signature of the body",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authentication", Values: []string{"signature"}},
+			},
+		},
+		{
+			Name:        "schemaFilter",
+			Label:       "Schema Filter",
+			Type:        configuration.FieldTypeObject,
+			Togglable:   true,
+			Description: "JSON Schema the request body must match to start an execution",
+		},
 	}
 }
 
@@ -145,6 +174,10 @@ func (w *Webhook) Setup(ctx core.TriggerContext) error {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
+	if _, err := config.Schema(); err != nil {
+		return err
+	}
+
 	if metadata.URL != "" && metadata.Authentication == config.Authentication {
 
 		return nil
@@ -242,9 +275,10 @@ func (w *Webhook) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.Webh
 
 	switch config.Authentication {
 	case "signature":
-		signature := ctx.Headers.Get("X-Signature-256")
+		signatureHeader := config.SignatureHeaderName()
+		signature := ctx.Headers.Get(signatureHeader)
 		if signature == "" {
-			return http.StatusForbidden, nil, fmt.Errorf("missing signature header")
+			return http.StatusForbidden, nil, fmt.Errorf("missing %s header", signatureHeader)
 		}
 
 		signature = strings.TrimPrefix(signature, "sha256=")
@@ -262,7 +296,7 @@ func (w *Webhook) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.Webh
 		}
 
 		expectedToken := "Bearer " + string(secret)
-		if authHeader != expectedToken {
+		if subtle.ConstantTimeCompare([]byte(authHeader), []byte(expectedToken)) != 1 {
 			return http.StatusUnauthorized, nil, fmt.Errorf("invalid Bearer token")
 		}
 
@@ -274,7 +308,7 @@ func (w *Webhook) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.Webh
 			return http.StatusUnauthorized, nil, fmt.Errorf("missing %s header", headerName)
 		}
 
-		if headerToken != string(secret) {
+		if subtle.ConstantTimeCompare([]byte(headerToken), secret) != 1 {
 			return http.StatusUnauthorized, nil, fmt.Errorf("invalid header token")
 		}
 
@@ -287,6 +321,15 @@ func (w *Webhook) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.Webh
 		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
 	}
 
+	schema, err := config.Schema()
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	if schema != nil && schema.Validate(parsedData) != nil {
+		return http.StatusOK, nil, nil
+	}
+
 	output := map[string]any{
 		"body":    parsedData,
 		"headers": ctx.Headers,
@@ -311,3 +354,20 @@ func (c Configuration) HeaderTokenName() string {
 
 	return DefaultHeaderTokenName
 }
+
+func (c Configuration) SignatureHeaderName() string {
+	if c.SignatureHeader != "" {
+		return c.SignatureHeader
+	}
+
+	return DefaultSignatureHeader
+}
+
+// Schema returns the compiled schema filter, or nil if none is configured.
+func (c Configuration) Schema() (*Schema, error) {
+	if c.SchemaFilter == nil || c.SchemaFilter == "" {
+		return nil, nil
+	}
+
+	return ParseSchema(c.SchemaFilter)
+}
@@ -350,3 +350,73 @@ func computeSignature(key string, data []byte) string {
 	hash.Write(data)
 	return fmt.Sprintf("%x", hash.Sum(nil))
 }
+
+func Test__Webhook__SchemaFilter(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"action"},
+		"properties": map[string]any{
+			"action": map[string]any{"type": "string", "enum": []any{"deploy"}},
+		},
+	}
+
+	t.Run("emits event when body matches schema", func(t *testing.T) {
+		webhook := &Webhook{}
+		ctx, eventCtx := webhookRequestContext([]byte(`{"action":"deploy"}`), "none", "secret")
+		ctx.Configuration.(map[string]any)["schemaFilter"] = schema
+
+		status, _, err := webhook.HandleWebhook(ctx)
+		require.Equal(t, http.StatusOK, status)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventCtx.Count())
+	})
+
+	t.Run("ignores body that does not match schema", func(t *testing.T) {
+		webhook := &Webhook{}
+		ctx, eventCtx := webhookRequestContext([]byte(`{"action":"rollback"}`), "none", "secret")
+		ctx.Configuration.(map[string]any)["schemaFilter"] = schema
+
+		status, _, err := webhook.HandleWebhook(ctx)
+		require.Equal(t, http.StatusOK, status)
+		require.NoError(t, err)
+		require.Equal(t, 0, eventCtx.Count())
+	})
+
+	t.Run("accepts schema as JSON string", func(t *testing.T) {
+		webhook := &Webhook{}
+		ctx, eventCtx := webhookRequestContext([]byte(`{"action":"deploy"}`), "none", "secret")
+		ctx.Configuration.(map[string]any)["schemaFilter"] = `{"required": ["action"]}`
+
+		status, _, err := webhook.HandleWebhook(ctx)
+		require.Equal(t, http.StatusOK, status)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventCtx.Count())
+	})
+}
+
+func Test__Webhook__SignatureHeader(t *testing.T) {
+	body := []byte(`{"ok":true}`)
+
+	t.Run("rejects signature in default header when custom header is configured", func(t *testing.T) {
+		webhook := &Webhook{}
+		ctx, _ := webhookRequestContext(body, "signature", "secret")
+		ctx.Configuration.(map[string]any)["signatureHeader"] = "X-Hub-Signature-256"
+		ctx.Headers.Set(DefaultSignatureHeader, "sha256="+computeSignature("secret", body))
+
+		status, _, err := webhook.HandleWebhook(ctx)
+		require.Equal(t, http.StatusForbidden, status)
+		require.ErrorContains(t, err, "missing X-Hub-Signature-256 header")
+	})
+
+	t.Run("accepts signature in custom header", func(t *testing.T) {
+		webhook := &Webhook{}
+		ctx, eventCtx := webhookRequestContext(body, "signature", "secret")
+		ctx.Configuration.(map[string]any)["signatureHeader"] = "X-Hub-Signature-256"
+		ctx.Headers.Set("X-Hub-Signature-256", "sha256="+computeSignature("secret", body))
+
+		status, _, err := webhook.HandleWebhook(ctx)
+		require.Equal(t, http.StatusOK, status)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventCtx.Count())
+	})
+}
@@ -0,0 +1,274 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"gorm.io/gorm"
+
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/grpc/actions/messages"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+/*
+ * Backfills replay their events in batches. Each batch replays
+ * the events of this interval, at the rate of the backfill,
+ * so the rate is spread over the minute instead of replaying
+ * all the events of a minute at once.
+ */
+const BackfillBatchInterval = 10 * time.Second
+
+type CanvasBackfillWorker struct {
+	semaphore *semaphore.Weighted
+}
+
+func NewCanvasBackfillWorker() *CanvasBackfillWorker {
+	return &CanvasBackfillWorker{
+		semaphore: semaphore.NewWeighted(25),
+	}
+}
+
+func (w *CanvasBackfillWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			backfills, err := models.ListDueCanvasBackfills()
+			if err != nil {
+				w.log("Error finding backfills ready to be processed: %v", err)
+			}
+
+			for _, backfill := range backfills {
+				if err := w.semaphore.Acquire(context.Background(), 1); err != nil {
+					w.log("Error acquiring semaphore: %v", err)
+					continue
+				}
+
+				go func(backfill models.CanvasBackfill) {
+					defer w.semaphore.Release(1)
+
+					if err := w.LockAndProcessBackfill(backfill); err != nil {
+						w.log("Error processing backfill %s: %v", backfill.ID, err)
+					}
+				}(backfill)
+			}
+		}
+	}
+}
+
+func (w *CanvasBackfillWorker) LockAndProcessBackfill(backfill models.CanvasBackfill) error {
+	var events []models.CanvasEvent
+	var queueItems []models.CanvasNodeQueueItem
+
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		b, err := models.LockDueCanvasBackfill(tx, backfill.ID)
+		if err != nil {
+			w.log("Backfill %s already being processed - skipping", backfill.ID)
+			return nil
+		}
+
+		events, queueItems, err = w.processBackfill(tx, b)
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	//
+	// Messages are only published once the transaction is committed,
+	// so the event router and queue worker can find what they refer to.
+	//
+	for _, event := range events {
+		messages.NewCanvasEventCreatedMessage(event.WorkflowID.String(), &event).Publish()
+	}
+
+	for _, queueItem := range queueItems {
+		messages.NewCanvasQueueItemMessage(
+			queueItem.WorkflowID.String(),
+			queueItem.ID.String(),
+			queueItem.NodeID,
+		).Publish(false)
+	}
+
+	return nil
+}
+
+func (w *CanvasBackfillWorker) processBackfill(tx *gorm.DB, backfill *models.CanvasBackfill) ([]models.CanvasEvent, []models.CanvasNodeQueueItem, error) {
+	now := time.Now()
+	batchSize := backfillBatchSize(backfill.RatePerMinute)
+
+	events, err := backfill.ListNextEvents(tx, batchSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(events) == 0 {
+		w.log("Backfill %s completed: %d events replayed, %d skipped", backfill.ID, backfill.ReplayedCount, backfill.SkippedCount)
+		backfill.State = models.CanvasBackfillStateCompleted
+		backfill.UpdatedAt = &now
+		return nil, nil, tx.Save(backfill).Error
+	}
+
+	var createdEvents []models.CanvasEvent
+	var createdQueueItems []models.CanvasNodeQueueItem
+	if backfill.TargetNodeID == nil {
+		createdEvents, err = w.replayThroughCanvas(tx, events, now)
+	} else {
+		createdQueueItems, err = w.replayThroughNode(tx, backfill, events, now)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := events[len(events)-1]
+	backfill.LastEventAt = last.CreatedAt
+	backfill.LastEventID = &last.ID
+	backfill.ReplayedCount += len(createdEvents) + len(createdQueueItems)
+	backfill.SkippedCount += len(events) - len(createdEvents) - len(createdQueueItems)
+	backfill.State = models.CanvasBackfillStateRunning
+	backfill.NextRunAt = now.Add(backfillDelay(len(events), backfill.RatePerMinute))
+	backfill.UpdatedAt = &now
+
+	if err := tx.Save(backfill).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return createdEvents, createdQueueItems, nil
+}
+
+/*
+ * The trigger emits the events again,
+ * and the event router sends them through the canvas, as if they were new.
+ */
+func (w *CanvasBackfillWorker) replayThroughCanvas(tx *gorm.DB, events []models.CanvasEvent, now time.Time) ([]models.CanvasEvent, error) {
+	created := make([]models.CanvasEvent, 0, len(events))
+	for _, event := range events {
+		replayed := models.CanvasEvent{
+			WorkflowID: event.WorkflowID,
+			NodeID:     event.NodeID,
+			Channel:    event.Channel,
+			CustomName: event.CustomName,
+			Data:       event.Data,
+			State:      models.CanvasEventStatePending,
+			CreatedAt:  &now,
+		}
+
+		if err := tx.Create(&replayed).Error; err != nil {
+			return nil, err
+		}
+
+		created = append(created, replayed)
+	}
+
+	return created, nil
+}
+
+/*
+ * The target node is queued again in the runs of the events,
+ * with the input it received from its upstream nodes in that run.
+ * Runs which never reached the target node are skipped.
+ */
+func (w *CanvasBackfillWorker) replayThroughNode(tx *gorm.DB, backfill *models.CanvasBackfill, events []models.CanvasEvent, now time.Time) ([]models.CanvasNodeQueueItem, error) {
+	targetNode, err := models.FindCanvasNode(tx, backfill.WorkflowID, *backfill.TargetNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetNode.State == models.CanvasNodeStateError {
+		return nil, nil
+	}
+
+	_, edges, err := models.FindLiveCanvasSpecInTransaction(tx, backfill.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	incomingEdges := findIncomingEdges(edges, targetNode.NodeID)
+	created := []models.CanvasNodeQueueItem{}
+	for _, event := range events {
+		input, err := findBackfillInput(tx, backfill, incomingEdges, event)
+		if err != nil {
+			return nil, err
+		}
+
+		if input == nil {
+			continue
+		}
+
+		queueItem := models.CanvasNodeQueueItem{
+			WorkflowID:  backfill.WorkflowID,
+			NodeID:      targetNode.NodeID,
+			RootEventID: event.ID,
+			EventID:     input.ID,
+			CreatedAt:   &now,
+		}
+
+		if err := tx.Create(&queueItem).Error; err != nil {
+			return nil, err
+		}
+
+		created = append(created, queueItem)
+	}
+
+	return created, nil
+}
+
+func findIncomingEdges(edges []models.Edge, targetID string) []models.Edge {
+	matches := []models.Edge{}
+	for _, edge := range edges {
+		if edge.TargetID == targetID {
+			matches = append(matches, edge)
+		}
+	}
+
+	return matches
+}
+
+func findBackfillInput(tx *gorm.DB, backfill *models.CanvasBackfill, edges []models.Edge, rootEvent models.CanvasEvent) (*models.CanvasEvent, error) {
+	for _, edge := range edges {
+		if edge.SourceID == backfill.TriggerNodeID {
+			if edge.Channel == rootEvent.Channel {
+				return &rootEvent, nil
+			}
+
+			continue
+		}
+
+		event, err := models.FindRunEventEmittedByNode(tx, backfill.WorkflowID, rootEvent.ID, edge.SourceID, edge.Channel)
+		if err == nil {
+			return event, nil
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func backfillBatchSize(ratePerMinute int) int {
+	return max(1, ratePerMinute*int(BackfillBatchInterval/time.Second)/60)
+}
+
+/*
+ * backfillDelay is how long to wait after replaying a batch,
+ * for the batch to stay within the rate of the backfill.
+ */
+func backfillDelay(replayed int, ratePerMinute int) time.Duration {
+	return time.Duration(replayed) * time.Minute / time.Duration(max(1, ratePerMinute))
+}
+
+func (w *CanvasBackfillWorker) log(format string, v ...any) {
+	log.Printf("[CanvasBackfillWorker] "+format, v...)
+}
@@ -0,0 +1,123 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+)
+
+func Test__BackfillRate(t *testing.T) {
+	t.Run("batches replay the events of the batch interval", func(t *testing.T) {
+		assert.Equal(t, 1, backfillBatchSize(1))
+		assert.Equal(t, 1, backfillBatchSize(6))
+		assert.Equal(t, 10, backfillBatchSize(60))
+		assert.Equal(t, 100, backfillBatchSize(600))
+	})
+
+	t.Run("delay keeps batches within the rate", func(t *testing.T) {
+		assert.Equal(t, time.Minute, backfillDelay(1, 1))
+		assert.Equal(t, 10*time.Second, backfillDelay(10, 60))
+		assert.Equal(t, 5*time.Second, backfillDelay(5, 60))
+	})
+}
+
+func Test__CanvasBackfillWorker(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{NodeID: "trigger-1", Type: models.NodeTypeTrigger},
+			{NodeID: "node-1", Type: models.NodeTypeComponent},
+			{NodeID: "node-2", Type: models.NodeTypeComponent},
+		},
+		[]models.Edge{
+			{SourceID: "trigger-1", TargetID: "node-1", Channel: "default"},
+			{SourceID: "node-1", TargetID: "node-2", Channel: "default"},
+		},
+	)
+
+	since := time.Now().Add(-time.Minute)
+	event1 := support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "default", nil)
+	event2 := support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "default", nil)
+	until := time.Now()
+
+	createBackfill := func(targetNodeID *string, rate int) *models.CanvasBackfill {
+		backfill := &models.CanvasBackfill{
+			WorkflowID:    canvas.ID,
+			TriggerNodeID: "trigger-1",
+			TargetNodeID:  targetNodeID,
+			Since:         since,
+			Until:         until,
+			RatePerMinute: rate,
+			State:         models.CanvasBackfillStatePending,
+			NextRunAt:     time.Now(),
+		}
+
+		require.NoError(t, models.CreateCanvasBackfillInTransaction(database.Conn(), backfill))
+		return backfill
+	}
+
+	t.Run("whole canvas -> trigger events are emitted again, in batches", func(t *testing.T) {
+		backfill := createBackfill(nil, 6)
+		worker := NewCanvasBackfillWorker()
+
+		require.NoError(t, worker.LockAndProcessBackfill(*backfill))
+		backfill, err := models.FindCanvasBackfill(canvas.ID, backfill.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.CanvasBackfillStateRunning, backfill.State)
+		assert.Equal(t, 1, backfill.ReplayedCount)
+		assert.Equal(t, event1.ID, *backfill.LastEventID)
+		assert.True(t, backfill.NextRunAt.After(time.Now().Add(9*time.Second)))
+		support.VerifyCanvasNodeEventsCount(t, canvas.ID, "trigger-1", 3)
+
+		//
+		// The next batch is not due yet.
+		//
+		require.NoError(t, worker.LockAndProcessBackfill(*backfill))
+		support.VerifyCanvasNodeEventsCount(t, canvas.ID, "trigger-1", 3)
+
+		require.NoError(t, database.Conn().Model(backfill).Update("next_run_at", time.Now()).Error)
+		require.NoError(t, worker.LockAndProcessBackfill(*backfill))
+		require.NoError(t, database.Conn().Model(backfill).Update("next_run_at", time.Now()).Error)
+		require.NoError(t, worker.LockAndProcessBackfill(*backfill))
+
+		backfill, err = models.FindCanvasBackfill(canvas.ID, backfill.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.CanvasBackfillStateCompleted, backfill.State)
+		assert.Equal(t, 2, backfill.ReplayedCount)
+		assert.Equal(t, event2.ID, *backfill.LastEventID)
+		support.VerifyCanvasNodeEventsCount(t, canvas.ID, "trigger-1", 4)
+	})
+
+	t.Run("target node -> node is queued with its input in each run", func(t *testing.T) {
+		//
+		// Only the run of the first event reached node-2.
+		//
+		execution := support.CreateCanvasNodeExecution(t, canvas.ID, "node-1", event1.ID, event1.ID, nil)
+		output := support.EmitCanvasEventForNode(t, canvas.ID, "node-1", "default", &execution.ID)
+
+		targetNodeID := "node-2"
+		backfill := createBackfill(&targetNodeID, 60)
+		require.NoError(t, NewCanvasBackfillWorker().LockAndProcessBackfill(*backfill))
+
+		backfill, err := models.FindCanvasBackfill(canvas.ID, backfill.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, backfill.ReplayedCount)
+		assert.Equal(t, 1, backfill.SkippedCount)
+
+		var queueItems []models.CanvasNodeQueueItem
+		require.NoError(t, database.Conn().Where("workflow_id = ? AND node_id = ?", canvas.ID, "node-2").Find(&queueItems).Error)
+		require.Len(t, queueItems, 1)
+		assert.Equal(t, event1.ID, queueItems[0].RootEventID)
+		assert.Equal(t, output.ID, queueItems[0].EventID)
+	})
+}
@@ -62,11 +62,28 @@ func (w *CanvasCleanupWorker) Start(ctx context.Context) {
 				}(canvas)
 			}
 
+			w.deleteExpiredEventIdentities()
+
 			telemetry.RecordWorkflowCleanupWorkerTickDuration(context.Background(), time.Since(tickStart))
 		}
 	}
 }
 
+/*
+ * Event identities are only needed within the deduplication window of their node.
+ */
+func (w *CanvasCleanupWorker) deleteExpiredEventIdentities() {
+	deleted, err := models.DeleteExpiredEventIdentities(time.Now(), w.maxResourcesPerTick)
+	if err != nil {
+		w.logger.Errorf("Error deleting expired event identities: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		w.logger.Infof("Deleted %d expired event identities", deleted)
+	}
+}
+
 func (w *CanvasCleanupWorker) LockAndProcessCanvas(canvas models.Canvas) error {
 	return database.Conn().Transaction(func(tx *gorm.DB) error {
 		lockedCanvas, err := models.LockCanvas(tx, canvas.ID)
@@ -153,6 +170,9 @@ func (w *CanvasCleanupWorker) deleteNodeResourcesBatched(tx *gorm.DB, workflowID
 	}{
 		{&models.CanvasNodeRequest{}, "canvas_node_requests"},
 		{&models.CanvasNodeExecutionKV{}, "canvas_node_execution_kvs"},
+		{&models.CanvasNodeExecutionLog{}, "canvas_node_execution_logs"},
+		{&models.CanvasNodeExecutionCost{}, "canvas_node_execution_costs"},
+		{&models.CanvasNodeExecutionCheckpoint{}, "canvas_node_execution_checkpoints"},
 		{&models.CanvasNodeExecution{}, "canvas_node_executions"},
 		{&models.CanvasNodeQueueItem{}, "canvas_node_queue_items"},
 		{&models.CanvasEvent{}, "canvas_events"},
@@ -60,6 +60,15 @@ func Test__CanvasCleanupWorker_ProcessesDeletedWorkflow(t *testing.T) {
 		"test-value",
 	))
 
+	// Create checkpoint for the execution
+	require.NoError(t, models.SaveNodeExecutionCheckpoint(&models.CanvasNodeExecutionCheckpoint{
+		ExecutionID: execution.ID,
+		WorkflowID:  canvas.ID,
+		NodeID:      "node-1",
+		Step:        "waitForOperation",
+		Operation:   "operation-1",
+	}))
+
 	// Create workflow node request
 	nodeRequest := models.CanvasNodeRequest{
 		ID:         uuid.New(),
@@ -149,6 +158,11 @@ func Test__CanvasCleanupWorker_ProcessesDeletedWorkflow(t *testing.T) {
 	// KV and request should be deleted
 	support.VerifyNodeExecutionKVCount(t, canvas.ID, 0)
 	support.VerifyNodeRequestCount(t, canvas.ID, 0)
+
+	// Checkpoint should be deleted
+	var checkpointCount int64
+	database.Conn().Model(&models.CanvasNodeExecutionCheckpoint{}).Where("workflow_id = ?", canvas.ID).Count(&checkpointCount)
+	assert.Equal(t, int64(0), checkpointCount)
 }
 
 func Test__CanvasCleanupWorker_ProcessesWorkflowWithWebhook(t *testing.T) {
@@ -0,0 +1,212 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+/*
+ * ConfigurationUpgrader upgrades the configuration of nodes stored
+ * on an older version of their component fields, see core.VersionedConfiguration.
+ *
+ * New versions only come with new releases, so it runs once, on startup.
+ * Nodes are upgraded in the canvas nodes used to run executions,
+ * in every canvas version, and in blueprints.
+ */
+type ConfigurationUpgrader struct {
+	registry *registry.Registry
+	logger   *log.Entry
+}
+
+func NewConfigurationUpgrader(registry *registry.Registry) *ConfigurationUpgrader {
+	return &ConfigurationUpgrader{
+		registry: registry,
+		logger:   log.WithFields(log.Fields{"worker": "ConfigurationUpgrader"}),
+	}
+}
+
+func (w *ConfigurationUpgrader) Start(ctx context.Context) {
+	if err := w.UpgradeAll(); err != nil {
+		w.logger.Errorf("Error upgrading node configurations: %v", err)
+	}
+}
+
+func (w *ConfigurationUpgrader) UpgradeAll() error {
+	components := w.versionedComponents()
+	if len(components) == 0 {
+		return nil
+	}
+
+	return database.Conn().Transaction(func(tx *gorm.DB) error {
+		if err := w.upgradeCanvasNodes(tx, components); err != nil {
+			return fmt.Errorf("error upgrading canvas nodes: %w", err)
+		}
+
+		if err := w.upgradeCanvasVersions(tx, components); err != nil {
+			return fmt.Errorf("error upgrading canvas versions: %w", err)
+		}
+
+		if err := w.upgradeBlueprints(tx, components); err != nil {
+			return fmt.Errorf("error upgrading blueprints: %w", err)
+		}
+
+		return nil
+	})
+}
+
+/*
+ * Only components past their initial version can have nodes to upgrade.
+ */
+func (w *ConfigurationUpgrader) versionedComponents() []string {
+	components := w.registry.ListComponents()
+	for _, integration := range w.registry.ListIntegrations() {
+		components = append(components, integration.Components()...)
+	}
+
+	names := []string{}
+	for _, component := range components {
+		if core.ConfigurationVersion(component) > core.InitialConfigurationVersion {
+			names = append(names, component.Name())
+		}
+	}
+
+	return names
+}
+
+func (w *ConfigurationUpgrader) upgradeCanvasNodes(tx *gorm.DB, components []string) error {
+	nodes, err := models.LockCanvasNodesUsingComponentsInTransaction(tx, components)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		ref := node.Ref.Data()
+		config, upgraded, err := UpgradeNodeConfiguration(w.registry, &ref, node.Configuration.Data())
+		if err != nil {
+			w.logger.Errorf("Error upgrading node %s of canvas %s: %v", node.NodeID, node.WorkflowID, err)
+			continue
+		}
+
+		if !upgraded {
+			continue
+		}
+
+		err = tx.Model(&node).
+			Updates(map[string]any{
+				"ref":           datatypes.NewJSONType(ref),
+				"configuration": datatypes.NewJSONType(config),
+			}).
+			Error
+
+		if err != nil {
+			return err
+		}
+
+		w.logger.Infof("Upgraded node %s of canvas %s to configuration version %d", node.NodeID, node.WorkflowID, ref.Component.ConfigurationVersion)
+	}
+
+	return nil
+}
+
+func (w *ConfigurationUpgrader) upgradeCanvasVersions(tx *gorm.DB, components []string) error {
+	versions, err := models.LockCanvasVersionsUsingComponentsInTransaction(tx, components)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		nodes := []models.Node(version.Nodes)
+		if !w.upgradeNodes(nodes, fmt.Sprintf("canvas version %s", version.ID)) {
+			continue
+		}
+
+		err := tx.Model(&version).Update("nodes", datatypes.NewJSONSlice(nodes)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *ConfigurationUpgrader) upgradeBlueprints(tx *gorm.DB, components []string) error {
+	blueprints, err := models.LockBlueprintsUsingComponentsInTransaction(tx, components)
+	if err != nil {
+		return err
+	}
+
+	for _, blueprint := range blueprints {
+		nodes := []models.Node(blueprint.Nodes)
+		if !w.upgradeNodes(nodes, fmt.Sprintf("blueprint %s", blueprint.ID)) {
+			continue
+		}
+
+		err := tx.Model(&blueprint).Update("nodes", datatypes.NewJSONSlice(nodes)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+ * upgradeNodes upgrades the nodes in place, and returns true if any node was upgraded.
+ * Nodes that cannot be upgraded are left as they are.
+ */
+func (w *ConfigurationUpgrader) upgradeNodes(nodes []models.Node, owner string) bool {
+	changed := false
+	for i := range nodes {
+		config, upgraded, err := UpgradeNodeConfiguration(w.registry, &nodes[i].Ref, nodes[i].Configuration)
+		if err != nil {
+			w.logger.Errorf("Error upgrading node %s of %s: %v", nodes[i].ID, owner, err)
+			continue
+		}
+
+		if upgraded {
+			nodes[i].Configuration = config
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+/*
+ * UpgradeNodeConfiguration upgrades the configuration of a component node
+ * to the current version of its component, and records the version in its ref.
+ * Returns false if the node did not need to be upgraded.
+ */
+func UpgradeNodeConfiguration(registry *registry.Registry, ref *models.NodeRef, config map[string]any) (map[string]any, bool, error) {
+	if ref.Component == nil {
+		return config, false, nil
+	}
+
+	component, err := registry.GetComponent(ref.Component.Name)
+	if err != nil {
+		return config, false, nil
+	}
+
+	if ref.Component.ConfigurationVersion >= core.ConfigurationVersion(component) {
+		return config, false, nil
+	}
+
+	upgraded, version, err := core.UpgradeConfiguration(component, ref.Component.ConfigurationVersion, config)
+	if err != nil {
+		return config, false, err
+	}
+
+	componentRef := *ref.Component
+	componentRef.ConfigurationVersion = version
+	ref.Component = &componentRef
+
+	return upgraded, true, nil
+}
@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -100,3 +101,48 @@ func (c *CanvasMemoryContext) Update(namespace string, matches map[string]any, v
 
 	return updatedValues, nil
 }
+
+func (c *CanvasMemoryContext) GetValue(key string) (any, bool, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, false, fmt.Errorf("key is required")
+	}
+
+	record, err := models.FindCanvasKeyValueInTransaction(c.tx, c.canvasID, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return record.Value.Data(), true, nil
+}
+
+func (c *CanvasMemoryContext) SetValue(key string, value any) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("key is required")
+	}
+
+	return models.SetCanvasKeyValueInTransaction(c.tx, c.canvasID, key, value)
+}
+
+func (c *CanvasMemoryContext) SetValueIfMissing(key string, value any) (bool, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return false, fmt.Errorf("key is required")
+	}
+
+	return models.SetCanvasKeyValueIfMissingInTransaction(c.tx, c.canvasID, key, value)
+}
+
+func (c *CanvasMemoryContext) IncrementValue(key string, amount float64) (float64, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return 0, fmt.Errorf("key is required")
+	}
+
+	return models.IncrementCanvasKeyValueInTransaction(c.tx, c.canvasID, key, amount)
+}
@@ -0,0 +1,186 @@
+package contexts
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const manualRunTriggerName = "start"
+
+/*
+ * Canvases can call canvases that call other canvases,
+ * but a run can have at most this many canvases calling it.
+ */
+const MaxCanvasCallDepth = 10
+
+type CanvasRunsContext struct {
+	tx             *gorm.DB
+	organizationID uuid.UUID
+	canvasID       uuid.UUID
+	rootEventID    uuid.UUID
+	onNewEvents    func([]models.CanvasEvent)
+}
+
+func NewCanvasRunsContext(tx *gorm.DB, organizationID, canvasID, rootEventID uuid.UUID, onNewEvents func([]models.CanvasEvent)) *CanvasRunsContext {
+	return &CanvasRunsContext{
+		tx:             tx,
+		organizationID: organizationID,
+		canvasID:       canvasID,
+		rootEventID:    rootEventID,
+		onNewEvents:    onNewEvents,
+	}
+}
+
+func (c *CanvasRunsContext) Start(canvasID, nodeID string, payload map[string]any) (string, error) {
+	canvas, err := c.findCanvas(canvasID)
+	if err != nil {
+		return "", err
+	}
+
+	if canvas.ID == c.canvasID {
+		return "", fmt.Errorf("a canvas cannot call itself")
+	}
+
+	callChain, err := c.callChain(canvas)
+	if err != nil {
+		return "", err
+	}
+
+	node, err := c.findManualRunNode(canvas, nodeID)
+	if err != nil {
+		return "", err
+	}
+
+	if payload == nil {
+		payload = map[string]any{}
+	}
+
+	now := time.Now()
+	event := models.CanvasEvent{
+		WorkflowID: canvas.ID,
+		NodeID:     node.NodeID,
+		Channel:    core.DefaultOutputChannel.Name,
+		Data:       datatypes.NewJSONType[any](payload),
+		State:      models.CanvasEventStatePending,
+		CreatedAt:  &now,
+		CallChain:  callChain,
+	}
+
+	if err := c.tx.Create(&event).Error; err != nil {
+		return "", fmt.Errorf("error creating event: %w", err)
+	}
+
+	if c.onNewEvents != nil {
+		c.onNewEvents([]models.CanvasEvent{event})
+	}
+
+	return event.ID.String(), nil
+}
+
+func (c *CanvasRunsContext) Get(canvasID, runID string) (*core.CanvasRun, error) {
+	canvas, err := c.findCanvas(canvasID)
+	if err != nil {
+		return nil, err
+	}
+
+	rootEventID, err := uuid.Parse(runID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid run ID %q", runID)
+	}
+
+	run, err := models.FindCanvasRunInTransaction(c.tx, canvas.ID, rootEventID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding run %s: %w", runID, err)
+	}
+
+	outputs := make([]core.CanvasRunOutput, 0, len(run.TerminalEvents))
+	for _, event := range run.TerminalEvents {
+		outputs = append(outputs, core.CanvasRunOutput{
+			NodeID:  event.NodeID,
+			Channel: event.Channel,
+			Data:    event.Data.Data(),
+		})
+	}
+
+	return &core.CanvasRun{
+		ID:      runID,
+		State:   run.State,
+		Message: run.Message,
+		Outputs: outputs,
+	}, nil
+}
+
+/*
+ * callChain returns the chain of callers of the run started on the canvas:
+ * the chain of the calling run, followed by the calling canvas.
+ * Runs that would loop back to a canvas in the chain, or go too deep, are rejected.
+ */
+func (c *CanvasRunsContext) callChain(canvas *models.Canvas) ([]string, error) {
+	rootEvent, err := models.FindCanvasEventInTransaction(c.tx, c.rootEventID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding root event: %w", err)
+	}
+
+	callChain := append(slices.Clone(rootEvent.CallChain), c.canvasID.String())
+	if slices.Contains(callChain, canvas.ID.String()) {
+		return nil, fmt.Errorf("canvas %s is already part of the call chain", canvas.ID)
+	}
+
+	if len(callChain) > MaxCanvasCallDepth {
+		return nil, fmt.Errorf("canvas calls cannot go more than %d levels deep", MaxCanvasCallDepth)
+	}
+
+	return callChain, nil
+}
+
+func (c *CanvasRunsContext) findCanvas(canvasID string) (*models.Canvas, error) {
+	id, err := uuid.Parse(canvasID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid canvas ID %q", canvasID)
+	}
+
+	canvas, err := models.FindCanvasInTransaction(c.tx, c.organizationID, id)
+	if err != nil {
+		return nil, fmt.Errorf("canvas %s not found: %w", canvasID, err)
+	}
+
+	return canvas, nil
+}
+
+func (c *CanvasRunsContext) findManualRunNode(canvas *models.Canvas, nodeID string) (*models.CanvasNode, error) {
+	nodes, err := models.FindCanvasNodesInTransaction(c.tx, canvas.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []models.CanvasNode{}
+	for _, node := range nodes {
+		trigger := node.Ref.Data().Trigger
+		if trigger == nil || trigger.Name != manualRunTriggerName {
+			continue
+		}
+
+		if nodeID != "" && node.NodeID == nodeID {
+			return &node, nil
+		}
+
+		candidates = append(candidates, node)
+	}
+
+	if nodeID != "" {
+		return nil, fmt.Errorf("manual run node %s not found in canvas %s", nodeID, canvas.ID)
+	}
+
+	if len(candidates) != 1 {
+		return nil, fmt.Errorf("canvas %s has %d manual run nodes, the node to start from is required", canvas.ID, len(candidates))
+	}
+
+	return &candidates[0], nil
+}
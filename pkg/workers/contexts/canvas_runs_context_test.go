@@ -0,0 +1,88 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+	"gorm.io/datatypes"
+)
+
+func Test__CanvasRunsContext__Start(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	createCanvas := func() *models.Canvas {
+		canvas, _ := support.CreateCanvas(
+			t,
+			r.Organization.ID,
+			r.User,
+			[]models.CanvasNode{
+				{
+					NodeID:        "start",
+					Name:          "start",
+					Type:          models.NodeTypeTrigger,
+					Ref:           datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+					Configuration: datatypes.NewJSONType(map[string]any{}),
+				},
+			},
+			nil,
+		)
+
+		return canvas
+	}
+
+	canvasA := createCanvas()
+	canvasB := createCanvas()
+	canvasC := createCanvas()
+
+	rootEvent := support.EmitCanvasEventForNode(t, canvasA.ID, "start", core.DefaultOutputChannel.Name, nil)
+	runsA := NewCanvasRunsContext(database.Conn(), r.Organization.ID, canvasA.ID, rootEvent.ID, nil)
+
+	runB, err := runsA.Start(canvasB.ID.String(), "", nil)
+	require.NoError(t, err)
+
+	eventB, err := models.FindCanvasEvent(uuid.MustParse(runB))
+	require.NoError(t, err)
+	assert.Equal(t, []string{canvasA.ID.String()}, []string(eventB.CallChain))
+
+	runsB := NewCanvasRunsContext(database.Conn(), r.Organization.ID, canvasB.ID, eventB.ID, nil)
+
+	t.Run("canvas calling itself -> error", func(t *testing.T) {
+		_, err := runsA.Start(canvasA.ID.String(), "", nil)
+		require.ErrorContains(t, err, "a canvas cannot call itself")
+	})
+
+	t.Run("canvas calling back a canvas in the chain -> error", func(t *testing.T) {
+		_, err := runsB.Start(canvasA.ID.String(), "", nil)
+		require.ErrorContains(t, err, "already part of the call chain")
+	})
+
+	t.Run("canvas calling another canvas -> chain extended", func(t *testing.T) {
+		runC, err := runsB.Start(canvasC.ID.String(), "", nil)
+		require.NoError(t, err)
+
+		eventC, err := models.FindCanvasEvent(uuid.MustParse(runC))
+		require.NoError(t, err)
+		assert.Equal(t, []string{canvasA.ID.String(), canvasB.ID.String()}, []string(eventC.CallChain))
+	})
+
+	t.Run("chain too deep -> error", func(t *testing.T) {
+		callChain := []string{}
+		for range MaxCanvasCallDepth {
+			callChain = append(callChain, uuid.NewString())
+		}
+
+		deepEvent := support.EmitCanvasEventForNode(t, canvasB.ID, "start", core.DefaultOutputChannel.Name, nil)
+		require.NoError(t, database.Conn().Model(deepEvent).Update("call_chain", datatypes.JSONSlice[string](callChain)).Error)
+
+		runs := NewCanvasRunsContext(database.Conn(), r.Organization.ID, canvasB.ID, deepEvent.ID, nil)
+		_, err := runs.Start(canvasC.ID.String(), "", nil)
+		require.ErrorContains(t, err, "levels deep")
+	})
+}
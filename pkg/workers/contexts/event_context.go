@@ -1,16 +1,21 @@
 package contexts
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/models"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+const maxEventIdentityLength = 256
+
 type EventContext struct {
 	tx             *gorm.DB
 	node           *models.CanvasNode
@@ -70,6 +75,36 @@ func (s *EventContext) Emit(payloadType string, payload any) error {
 	return nil
 }
 
+/*
+ * EmitIdentified emits the event only if the node did not emit
+ * another event with the same identity within its deduplication window.
+ */
+func (s *EventContext) EmitIdentified(identity string, payloadType string, payload any) (bool, error) {
+	//
+	// Identities longer than the column are hashed.
+	//
+	if len(identity) > maxEventIdentityLength {
+		sum := sha256.Sum256([]byte(identity))
+		identity = hex.EncodeToString(sum[:])
+	}
+
+	window := core.DeduplicationWindow(s.node.Configuration.Data())
+	claimed, err := models.ClaimEventIdentityInTransaction(s.tx, s.node.WorkflowID, s.node.NodeID, identity, window)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event identity: %w", err)
+	}
+
+	if !claimed {
+		return false, nil
+	}
+
+	if err := s.Emit(payloadType, payload); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (s *EventContext) resolveCustomName(payload any) (*string, error) {
 	config := s.node.Configuration.Data()
 	if config == nil {
@@ -3,9 +3,11 @@ package contexts
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/test/support"
@@ -57,3 +59,70 @@ func Test__EventContext__Emit(t *testing.T) {
 		assert.Len(t, newEvents, 2)
 	})
 }
+
+func Test__EventContext__EmitIdentified(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	triggerNodeID := "trigger-1"
+	canvas, nodes := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{
+				NodeID:        triggerNodeID,
+				Name:          triggerNodeID,
+				Type:          models.NodeTypeTrigger,
+				Ref:           datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+				Configuration: datatypes.NewJSONType(map[string]any{core.DeduplicationWindowField: "1h"}),
+			},
+		},
+		nil,
+	)
+
+	ctx := NewEventContext(database.Conn(), &nodes[0], nil)
+
+	t.Run("same identity is emitted once", func(t *testing.T) {
+		emitted, err := ctx.EmitIdentified("event-1", "test.payload", map[string]any{"n": 1})
+		require.NoError(t, err)
+		assert.True(t, emitted)
+
+		emitted, err = ctx.EmitIdentified("event-1", "test.payload", map[string]any{"n": 1})
+		require.NoError(t, err)
+		assert.False(t, emitted)
+		support.VerifyCanvasEventsCount(t, canvas.ID, 1)
+	})
+
+	t.Run("different identity is emitted", func(t *testing.T) {
+		emitted, err := ctx.EmitIdentified("event-2", "test.payload", map[string]any{"n": 2})
+		require.NoError(t, err)
+		assert.True(t, emitted)
+		support.VerifyCanvasEventsCount(t, canvas.ID, 2)
+	})
+
+	t.Run("expired identity is emitted again", func(t *testing.T) {
+		require.NoError(t, database.Conn().
+			Model(&models.CanvasNodeEventIdentity{}).
+			Where("identity = ?", "event-1").
+			Update("expires_at", time.Now().Add(-time.Minute)).
+			Error)
+
+		emitted, err := ctx.EmitIdentified("event-1", "test.payload", map[string]any{"n": 1})
+		require.NoError(t, err)
+		assert.True(t, emitted)
+		support.VerifyCanvasEventsCount(t, canvas.ID, 3)
+	})
+
+	t.Run("long identities are hashed", func(t *testing.T) {
+		identity := strings.Repeat("a", 1000)
+		emitted, err := ctx.EmitIdentified(identity, "test.payload", map[string]any{"n": 3})
+		require.NoError(t, err)
+		assert.True(t, emitted)
+
+		emitted, err = ctx.EmitIdentified(identity, "test.payload", map[string]any{"n": 3})
+		require.NoError(t, err)
+		assert.False(t, emitted)
+		support.VerifyCanvasEventsCount(t, canvas.ID, 4)
+	})
+}
@@ -0,0 +1,36 @@
+package contexts
+
+import (
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+	"gorm.io/datatypes"
+)
+
+/*
+ * ExecutionCheckpointContext stores the checkpoints saved by a component.
+ * They are written with their own connection, so they are not lost
+ * if the execution transaction is rolled back.
+ */
+type ExecutionCheckpointContext struct {
+	execution *models.CanvasNodeExecution
+}
+
+func NewExecutionCheckpointContext(execution *models.CanvasNodeExecution) *ExecutionCheckpointContext {
+	return &ExecutionCheckpointContext{execution: execution}
+}
+
+func (c *ExecutionCheckpointContext) Save(checkpoint core.Checkpoint) error {
+	target := checkpoint.Target
+	if target == nil {
+		target = map[string]any{}
+	}
+
+	return models.SaveNodeExecutionCheckpoint(&models.CanvasNodeExecutionCheckpoint{
+		ExecutionID: c.execution.ID,
+		WorkflowID:  c.execution.WorkflowID,
+		NodeID:      c.execution.NodeID,
+		Step:        checkpoint.Step,
+		Operation:   checkpoint.Operation,
+		Target:      datatypes.NewJSONType(target),
+	})
+}
@@ -0,0 +1,42 @@
+package contexts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const MaxCostUnitLength = 32
+
+/*
+ * ExecutionCostsContext stores the cost hints reported
+ * by a component as costs of the execution.
+ */
+type ExecutionCostsContext struct {
+	execution *models.CanvasNodeExecution
+}
+
+func NewExecutionCostsContext(execution *models.CanvasNodeExecution) *ExecutionCostsContext {
+	return &ExecutionCostsContext{execution: execution}
+}
+
+func (c *ExecutionCostsContext) Report(cost core.Cost) error {
+	unit := strings.TrimSpace(cost.Unit)
+	if unit == "" || len(unit) > MaxCostUnitLength {
+		return fmt.Errorf("invalid cost unit %q", cost.Unit)
+	}
+
+	if math.IsNaN(cost.Amount) || math.IsInf(cost.Amount, 0) || cost.Amount < 0 {
+		return fmt.Errorf("invalid cost amount %v", cost.Amount)
+	}
+
+	var description *string
+	if d := strings.TrimSpace(cost.Description); d != "" {
+		description = &d
+	}
+
+	return models.CreateNodeExecutionCost(c.execution.WorkflowID, c.execution.NodeID, c.execution.ID, cost.Amount, unit, description)
+}
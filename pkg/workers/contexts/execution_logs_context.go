@@ -0,0 +1,101 @@
+package contexts
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+// Lines longer than this are truncated,
+// so a stream without newlines does not grow the buffer forever.
+const MaxExecutionLogLineLength = 16 * 1024
+
+/*
+ * ExecutionLogsContext stores the lines written by a component
+ * as logs of the execution. Each write with complete lines is stored right away,
+ * and partial lines are kept until a newline is written, or Flush() is called.
+ */
+type ExecutionLogsContext struct {
+	mu        sync.Mutex
+	execution *models.CanvasNodeExecution
+	partial   []byte
+}
+
+func NewExecutionLogsContext(execution *models.CanvasNodeExecution) *ExecutionLogsContext {
+	return &ExecutionLogsContext{execution: execution}
+}
+
+func (c *ExecutionLogsContext) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.partial = append(c.partial, p...)
+	i := bytes.LastIndexByte(c.partial, '\n')
+	if i < 0 {
+		if len(c.partial) >= MaxExecutionLogLineLength {
+			return len(p), c.flush()
+		}
+
+		return len(p), nil
+	}
+
+	lines := strings.Split(string(c.partial[:i]), "\n")
+	c.partial = append([]byte{}, c.partial[i+1:]...)
+	if err := c.create(lines); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (c *ExecutionLogsContext) Printf(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+
+	if _, err := c.Write([]byte(line)); err != nil {
+		log.Errorf("error writing logs for execution %s: %v", c.execution.ID, err)
+	}
+}
+
+/*
+ * Flush stores the partial line written last, if any.
+ * It is called when the component returns.
+ */
+func (c *ExecutionLogsContext) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.flush()
+}
+
+func (c *ExecutionLogsContext) flush() error {
+	if len(c.partial) == 0 {
+		return nil
+	}
+
+	line := string(c.partial)
+	c.partial = nil
+	return c.create([]string{line})
+}
+
+func (c *ExecutionLogsContext) create(lines []string) error {
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if len(line) > MaxExecutionLogLineLength {
+			line = line[:MaxExecutionLogLineLength]
+		}
+
+		//
+		// Postgres does not accept invalid UTF-8, or NUL characters, in text columns.
+		//
+		lines[i] = strings.ReplaceAll(strings.ToValidUTF8(line, "\uFFFD"), "\x00", "")
+	}
+
+	return models.CreateNodeExecutionLogs(c.execution.WorkflowID, c.execution.NodeID, c.execution.ID, lines)
+}
@@ -2,6 +2,7 @@ package contexts
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/superplanehq/superplane/pkg/models"
@@ -22,7 +23,7 @@ func (c *ExecutionRequestContext) ScheduleActionCall(actionName string, paramete
 		return fmt.Errorf("interval must be bigger than 1s")
 	}
 
-	runAt := time.Now().Add(interval)
+	runAt := scheduledRunAt(interval)
 	return c.execution.CreateRequest(c.tx, models.NodeRequestTypeInvokeAction, models.NodeExecutionRequestSpec{
 		InvokeAction: &models.InvokeAction{
 			ActionName: actionName,
@@ -30,3 +31,19 @@ func (c *ExecutionRequestContext) ScheduleActionCall(actionName string, paramete
 		},
 	}, &runAt)
 }
+
+const maxScheduleJitter = 30 * time.Second
+
+/*
+ * Actions scheduled with the same interval, e.g. polls started
+ * by the same event, get up to 10% of jitter, capped at maxScheduleJitter,
+ * so they do not all hit the external system at the same time.
+ */
+func scheduledRunAt(interval time.Duration) time.Time {
+	jitter := min(interval/10, maxScheduleJitter)
+	if jitter <= 0 {
+		return time.Now().Add(interval)
+	}
+
+	return time.Now().Add(interval + rand.N(jitter))
+}
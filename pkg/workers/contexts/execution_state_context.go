@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/models"
 	"gorm.io/gorm"
 )
@@ -47,27 +48,37 @@ func (s *ExecutionStateContext) Pass() error {
 }
 
 func (s *ExecutionStateContext) Emit(channel, payloadType string, payloads []any) error {
-	outputs := map[string][]any{
-		channel: {},
-	}
+	return s.EmitOutputs([]core.ChannelOutput{
+		{Channel: channel, PayloadType: payloadType, Payloads: payloads},
+	})
+}
 
-	for _, payload := range payloads {
-		event := map[string]any{
-			"type":      payloadType,
-			"timestamp": time.Now(),
-			"data":      payload,
-		}
+func (s *ExecutionStateContext) EmitOutputs(channelOutputs []core.ChannelOutput) error {
+	outputs := map[string][]any{}
 
-		data, err := json.Marshal(event)
-		if err != nil {
-			return fmt.Errorf("failed to marshal payload: %w", err)
+	for _, output := range channelOutputs {
+		if _, ok := outputs[output.Channel]; !ok {
+			outputs[output.Channel] = []any{}
 		}
 
-		if len(data) > s.maxPayloadSize {
-			return fmt.Errorf("event payload too large: %d bytes (max %d)", len(data), s.maxPayloadSize)
-		}
+		for _, payload := range output.Payloads {
+			event := map[string]any{
+				"type":      output.PayloadType,
+				"timestamp": time.Now(),
+				"data":      payload,
+			}
 
-		outputs[channel] = append(outputs[channel], json.RawMessage(data))
+			data, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to marshal payload: %w", err)
+			}
+
+			if len(data) > s.maxPayloadSize {
+				return fmt.Errorf("event payload too large: %d bytes (max %d)", len(data), s.maxPayloadSize)
+			}
+
+			outputs[output.Channel] = append(outputs[output.Channel], json.RawMessage(data))
+		}
 	}
 
 	newEvents, err := s.execution.PassInTransaction(s.tx, outputs)
@@ -83,8 +94,16 @@ func (s *ExecutionStateContext) Emit(channel, payloadType string, payloads []any
 }
 
 func (s *ExecutionStateContext) Fail(reason, message string) error {
-	err := s.execution.FailInTransaction(s.tx, reason, message)
-	return err
+	newEvents, err := s.execution.FailWithEventsInTransaction(s.tx, reason, message)
+	if err != nil {
+		return err
+	}
+
+	if s.onNewEvents != nil && len(newEvents) > 0 {
+		s.onNewEvents(newEvents)
+	}
+
+	return nil
 }
 
 func (s *ExecutionStateContext) SetKV(key, value string) error {
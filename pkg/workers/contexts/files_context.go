@@ -0,0 +1,51 @@
+package contexts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/blobs"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// FilesContext reads the content of file fields from blob storage.
+type FilesContext struct {
+	store          blobs.Store
+	organizationID uuid.UUID
+}
+
+// NewFilesContext returns a FilesContext that only opens
+// files uploaded for the given organization.
+func NewFilesContext(store blobs.Store, organizationID uuid.UUID) *FilesContext {
+	return &FilesContext{
+		store:          store,
+		organizationID: organizationID,
+	}
+}
+
+// Open implements core.FilesContext.
+func (c *FilesContext) Open(value any) (*core.File, error) {
+	reference, err := configuration.ParseFileReference(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(reference.Key, c.organizationID.String()+"/") {
+		return nil, fmt.Errorf("file %s not found", reference.Name)
+	}
+
+	reader, err := c.store.Get(context.Background(), reference.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", reference.Name, err)
+	}
+
+	return &core.File{
+		Name:        reference.Name,
+		ContentType: reference.ContentType,
+		Size:        reference.Size,
+		Reader:      reader,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package contexts
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/blobs"
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+func Test__FilesContext_Open(t *testing.T) {
+	store := blobs.NewFilesystemStore(t.TempDir())
+	organizationID := uuid.New()
+	key := organizationID.String() + "/" + uuid.NewString()
+	require.NoError(t, store.Put(context.Background(), key, strings.NewReader("hello")))
+
+	filesCtx := NewFilesContext(store, organizationID)
+
+	t.Run("file from organization -> opened", func(t *testing.T) {
+		reference := configuration.FileReference{Key: key, Name: "hello.txt", ContentType: "text/plain", Size: 5}
+		file, err := filesCtx.Open(reference.ToMap())
+		require.NoError(t, err)
+		defer file.Reader.Close()
+
+		data, err := io.ReadAll(file.Reader)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Equal(t, "hello.txt", file.Name)
+		assert.Equal(t, "text/plain", file.ContentType)
+		assert.Equal(t, int64(5), file.Size)
+	})
+
+	t.Run("file from another organization -> error", func(t *testing.T) {
+		otherCtx := NewFilesContext(store, uuid.New())
+		_, err := otherCtx.Open(configuration.FileReference{Key: key, Name: "hello.txt"}.ToMap())
+		require.ErrorContains(t, err, "file hello.txt not found")
+	})
+
+	t.Run("value that is not a file -> error", func(t *testing.T) {
+		_, err := filesCtx.Open("hello")
+		require.Error(t, err)
+	})
+}
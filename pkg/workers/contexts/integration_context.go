@@ -191,7 +191,7 @@ func (c *IntegrationContext) ScheduleActionCall(actionName string, parameters an
 		return fmt.Errorf("interval must be bigger than 1s")
 	}
 
-	runAt := time.Now().Add(interval)
+	runAt := scheduledRunAt(interval)
 	return c.integration.CreateActionRequest(c.tx, actionName, parameters, &runAt)
 }
 
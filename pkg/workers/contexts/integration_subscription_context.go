@@ -140,5 +140,6 @@ func (c *IntegrationSubscriptionContext) findExecutionByKV(key string, value str
 		Logger:         logging.WithExecution(logging.ForNode(*c.node), execution, nil),
 		Notifications:  NewNotificationContext(c.tx, c.integration.OrganizationID, execution.WorkflowID),
 		CanvasMemory:   NewCanvasMemoryContext(c.tx, execution.WorkflowID),
+		Logs:           NewExecutionLogsContext(execution),
 	}, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/expr-lang/expr/parser"
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/expressions"
 	"github.com/superplanehq/superplane/pkg/models"
 	"gorm.io/gorm"
 )
@@ -128,6 +129,12 @@ func (b *NodeConfigurationBuilder) resolveFieldValue(value any, field configurat
 		return value, nil
 	}
 
+	if field.Type == configuration.FieldTypeSecretKey {
+		if expression, ok := value.(string); ok {
+			return b.resolveSecretKeyExpression(expression)
+		}
+	}
+
 	if field.TypeOptions != nil {
 		if field.TypeOptions.Object != nil && len(field.TypeOptions.Object.Schema) > 0 {
 			if obj, ok := asAnyMap(value); ok {
@@ -244,6 +251,20 @@ func (b *NodeConfigurationBuilder) ResolveExpression(expression string) (any, er
 	return result, nil
 }
 
+/*
+ * Secret key fields hold a {secret, key} reference, so an expression
+ * used as the whole value of one, e.g. {{ params.apiToken }},
+ * is kept as the reference it evaluates to instead of being formatted as a string.
+ */
+func (b *NodeConfigurationBuilder) resolveSecretKeyExpression(expression string) (any, error) {
+	matches := expressionRegex.FindStringSubmatch(strings.TrimSpace(expression))
+	if len(matches) != 2 || matches[0] != strings.TrimSpace(expression) {
+		return b.ResolveExpression(expression)
+	}
+
+	return b.resolveExpression(matches[1])
+}
+
 func (b *NodeConfigurationBuilder) BuildMessageChainForExpression(expression string) (map[string]any, error) {
 	referencedNodes, err := parseReferencedNodes(expression)
 	if err != nil {
@@ -264,6 +285,14 @@ func (b *NodeConfigurationBuilder) BuildExpressionEnv(expression string) (map[st
 		"memory": b.buildMemoryExpressionNamespace(),
 	}
 
+	if strings.Contains(expression, "params") {
+		params, err := b.buildParamsExpressionNamespace()
+		if err != nil {
+			return nil, err
+		}
+		env["params"] = params
+	}
+
 	if strings.Contains(expression, "root(") {
 		rootPayload, err := b.resolveRootPayload()
 		if err != nil {
@@ -311,6 +340,14 @@ func (b *NodeConfigurationBuilder) resolveExpression(expression string) (any, er
 		env["config"] = b.parentBlueprintNode.Configuration.Data()
 	}
 
+	if strings.Contains(expression, "params") {
+		params, err := b.buildParamsExpressionNamespace()
+		if err != nil {
+			return "", err
+		}
+		env["params"] = params
+	}
+
 	exprOptions := []expr.Option{
 		expr.Env(env),
 		expr.AsAny(),
@@ -340,6 +377,7 @@ func (b *NodeConfigurationBuilder) resolveExpression(expression string) (any, er
 		}),
 	}
 
+	exprOptions = append(exprOptions, expressions.Functions()...)
 	vm, err := expr.Compile(expression, exprOptions...)
 	if err != nil {
 		return "", err
@@ -798,6 +836,19 @@ func (b *NodeConfigurationBuilder) buildMemoryExpressionNamespace() map[string]a
 	}
 }
 
+/*
+ * The canvas parameters, with the values for the environment of the canvas.
+ * Only loaded when an expression mentions params.
+ */
+func (b *NodeConfigurationBuilder) buildParamsExpressionNamespace() (map[string]any, error) {
+	canvas, err := models.FindCanvasWithoutOrgScopeInTransaction(b.tx, b.workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding canvas parameters: %w", err)
+	}
+
+	return canvas.ParameterValues(), nil
+}
+
 func parseMemoryFindParams(params []any) (string, map[string]any, error) {
 	if len(params) == 0 || len(params) > 2 {
 		return "", nil, fmt.Errorf("memory.find() and memory.findFirst() require a namespace and matches")
@@ -28,7 +28,7 @@ func (c *NodeRequestContext) ScheduleActionCall(actionName string, parameters ma
 		return err
 	}
 
-	runAt := time.Now().Add(interval)
+	runAt := scheduledRunAt(interval)
 	return c.node.CreateRequest(c.tx, models.NodeRequestTypeInvokeAction, models.NodeExecutionRequestSpec{
 		InvokeAction: &models.InvokeAction{
 			ActionName: actionName,
@@ -0,0 +1,110 @@
+package contexts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/models"
+	"gorm.io/gorm"
+)
+
+type NodeWebhookSecretContext struct {
+	tx        *gorm.DB
+	ctx       context.Context
+	encryptor crypto.Encryptor
+	node      *models.CanvasNode
+}
+
+func NewNodeWebhookSecretContext(ctx context.Context, tx *gorm.DB, encryptor crypto.Encryptor, node *models.CanvasNode) *NodeWebhookSecretContext {
+	return &NodeWebhookSecretContext{
+		tx:        tx,
+		ctx:       ctx,
+		encryptor: encryptor,
+		node:      node,
+	}
+}
+
+func (c *NodeWebhookSecretContext) GetSecret() ([]byte, error) {
+	secret, err := c.findOrCreate()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decrypt(secret.Secret)
+}
+
+func (c *NodeWebhookSecretContext) AcceptedSecrets() ([][]byte, error) {
+	secret, err := c.findOrCreate()
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := c.decrypt(secret.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := [][]byte{current}
+	if !secret.HasValidPreviousSecret(time.Now()) {
+		return secrets, nil
+	}
+
+	previous, err := c.decrypt(secret.PreviousSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(secrets, previous), nil
+}
+
+func (c *NodeWebhookSecretContext) RotateSecret(gracePeriod time.Duration) ([]byte, error) {
+	secret, err := c.findOrCreate()
+	if err != nil {
+		return nil, err
+	}
+
+	plainKey, encryptedKey, err := crypto.NewRandomKey(c.ctx, c.encryptor, c.associatedData())
+	if err != nil {
+		return nil, fmt.Errorf("error generating node webhook secret: %v", err)
+	}
+
+	err = secret.RotateInTransaction(c.tx, encryptedKey, time.Now().Add(gracePeriod))
+	if err != nil {
+		return nil, fmt.Errorf("error rotating node webhook secret: %v", err)
+	}
+
+	return []byte(plainKey), nil
+}
+
+func (c *NodeWebhookSecretContext) findOrCreate() (*models.CanvasNodeWebhookSecret, error) {
+	secret, err := models.FindNodeWebhookSecretInTransaction(c.tx, c.node.WorkflowID, c.node.NodeID)
+	if err == nil {
+		return secret, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	_, encryptedKey, err := crypto.NewRandomKey(c.ctx, c.encryptor, c.associatedData())
+	if err != nil {
+		return nil, fmt.Errorf("error generating node webhook secret: %v", err)
+	}
+
+	return models.CreateNodeWebhookSecretInTransaction(c.tx, c.node.WorkflowID, c.node.NodeID, encryptedKey)
+}
+
+func (c *NodeWebhookSecretContext) decrypt(secret []byte) ([]byte, error) {
+	return c.encryptor.Decrypt(c.ctx, secret, []byte(c.associatedData()))
+}
+
+/*
+ * Secrets are bound to their node, so a secret copied
+ * to another node cannot be decrypted.
+ */
+func (c *NodeWebhookSecretContext) associatedData() string {
+	return c.node.WorkflowID.String() + "/" + c.node.NodeID
+}
@@ -0,0 +1,92 @@
+package contexts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+	"gorm.io/datatypes"
+)
+
+func Test__NodeWebhookSecretContext(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{
+				NodeID: "trigger-1",
+				Name:   "trigger-1",
+				Type:   models.NodeTypeTrigger,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+			},
+			{
+				NodeID: "trigger-2",
+				Name:   "trigger-2",
+				Type:   models.NodeTypeTrigger,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+			},
+		},
+		[]models.Edge{},
+	)
+
+	node1, err := models.FindCanvasNode(database.Conn(), canvas.ID, "trigger-1")
+	require.NoError(t, err)
+	node2, err := models.FindCanvasNode(database.Conn(), canvas.ID, "trigger-2")
+	require.NoError(t, err)
+
+	ctx1 := NewNodeWebhookSecretContext(context.Background(), database.Conn(), r.Encryptor, node1)
+	ctx2 := NewNodeWebhookSecretContext(context.Background(), database.Conn(), r.Encryptor, node2)
+
+	t.Run("secret is created on first use, and kept", func(t *testing.T) {
+		secret, err := ctx1.GetSecret()
+		require.NoError(t, err)
+		require.NotEmpty(t, secret)
+
+		again, err := ctx1.GetSecret()
+		require.NoError(t, err)
+		assert.Equal(t, secret, again)
+	})
+
+	t.Run("each node has its own secret", func(t *testing.T) {
+		secret1, err := ctx1.GetSecret()
+		require.NoError(t, err)
+		secret2, err := ctx2.GetSecret()
+		require.NoError(t, err)
+		assert.NotEqual(t, secret1, secret2)
+	})
+
+	t.Run("previous secret is accepted during the grace period", func(t *testing.T) {
+		previous, err := ctx1.GetSecret()
+		require.NoError(t, err)
+
+		rotated, err := ctx1.RotateSecret(time.Hour)
+		require.NoError(t, err)
+		assert.NotEqual(t, previous, rotated)
+
+		current, err := ctx1.GetSecret()
+		require.NoError(t, err)
+		assert.Equal(t, rotated, current)
+
+		accepted, err := ctx1.AcceptedSecrets()
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{rotated, previous}, accepted)
+	})
+
+	t.Run("previous secret is not accepted after the grace period", func(t *testing.T) {
+		rotated, err := ctx1.RotateSecret(0)
+		require.NoError(t, err)
+
+		accepted, err := ctx1.AcceptedSecrets()
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{rotated}, accepted)
+	})
+}
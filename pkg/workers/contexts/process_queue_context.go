@@ -135,6 +135,7 @@ func BuildProcessQueueContext(
 			Logger:         logging.WithExecution(logging.ForNode(*node), &execution, nil),
 			Notifications:  NewNotificationContext(tx, uuid.Nil, execution.WorkflowID),
 			CanvasMemory:   NewCanvasMemoryContext(tx, execution.WorkflowID),
+			Logs:           NewExecutionLogsContext(&execution),
 		}, nil
 	}
 
@@ -156,10 +157,21 @@ func BuildProcessQueueContext(
 			return nil, err
 		}
 
-		if err := ctx.UpdateNodeState(models.CanvasNodeStateProcessing); err != nil {
+		//
+		// The node stops taking items from its queue
+		// once it has as many executions in flight as its queue mode allows.
+		//
+		inFlight, err := models.CountInFlightExecutionsForNodeInTransaction(tx, node.WorkflowID, node.NodeID)
+		if err != nil {
 			return nil, err
 		}
 
+		if inFlight >= int64(core.QueueSettingsFromConfiguration(config).MaxExecutions()) {
+			if err := ctx.UpdateNodeState(models.CanvasNodeStateProcessing); err != nil {
+				return nil, err
+			}
+		}
+
 		return &executionCtx.ID, nil
 	}
 
@@ -236,6 +248,7 @@ func BuildProcessQueueContext(
 			Logger:         logging.WithExecution(logging.ForNode(*node), execution, nil),
 			Notifications:  NewNotificationContext(tx, uuid.Nil, execution.WorkflowID),
 			CanvasMemory:   NewCanvasMemoryContext(tx, execution.WorkflowID),
+			Logs:           NewExecutionLogsContext(execution),
 		}, nil
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/crypto"
 	"github.com/superplanehq/superplane/pkg/models"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +17,7 @@ type SecretsContext struct {
 	tx             *gorm.DB
 	organizationID uuid.UUID
 	encryptor      crypto.Encryptor
+	execution      *models.CanvasNodeExecution
 }
 
 // NewSecretsContext returns a SecretsContext that looks up secrets in the given transaction
@@ -28,6 +30,17 @@ func NewSecretsContext(tx *gorm.DB, organizationID uuid.UUID, encryptor crypto.E
 	}
 }
 
+// ForExecution returns a SecretsContext that records the keys accessed
+// by the given execution in the audit log.
+func (c *SecretsContext) ForExecution(execution *models.CanvasNodeExecution) *SecretsContext {
+	return &SecretsContext{
+		tx:             c.tx,
+		organizationID: c.organizationID,
+		encryptor:      c.encryptor,
+		execution:      execution,
+	}
+}
+
 // GetKey implements core.SecretsContext.
 func (c *SecretsContext) GetKey(secretName, keyName string) ([]byte, error) {
 	if secretName == "" || keyName == "" {
@@ -49,9 +62,34 @@ func (c *SecretsContext) GetKey(secretName, keyName string) ([]byte, error) {
 		return nil, core.ErrSecretKeyNotFound
 	}
 
+	if err := c.audit(secretName, keyName); err != nil {
+		return nil, err
+	}
+
 	return []byte(val), nil
 }
 
+// audit records the access with its own connection, so it is kept
+// even if the transaction running the execution is rolled back.
+// If the access cannot be recorded, the key is not returned.
+func (c *SecretsContext) audit(secretName, keyName string) error {
+	if c.execution == nil {
+		return nil
+	}
+
+	return models.CreateAuditLogEntry(&models.AuditLogEntry{
+		OrganizationID: c.organizationID,
+		WorkflowID:     &c.execution.WorkflowID,
+		NodeID:         &c.execution.NodeID,
+		ExecutionID:    &c.execution.ID,
+		Action:         models.AuditActionSecretAccessed,
+		Details: datatypes.NewJSONType(map[string]any{
+			"secret": secretName,
+			"key":    keyName,
+		}),
+	})
+}
+
 func (c *SecretsContext) decryptSecretData(secret *models.Secret) (map[string]string, error) {
 	plain, err := c.encryptor.Decrypt(context.Background(), secret.Data, []byte(secret.Name))
 	if err != nil {
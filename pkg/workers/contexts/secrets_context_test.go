@@ -0,0 +1,87 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+	"gorm.io/datatypes"
+)
+
+func Test__SecretsContext__AuditsKeysAccessedByExecutions(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	triggerNodeID := "trigger-1"
+	componentNodeID := "component-1"
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{
+				NodeID: triggerNodeID,
+				Name:   triggerNodeID,
+				Type:   models.NodeTypeTrigger,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+			},
+			{
+				NodeID: componentNodeID,
+				Name:   componentNodeID,
+				Type:   models.NodeTypeComponent,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "noop"}}),
+			},
+		},
+		[]models.Edge{
+			{SourceID: triggerNodeID, TargetID: componentNodeID, Channel: "default"},
+		},
+	)
+
+	secret, err := support.CreateSecret(t, r, map[string]string{"token": "hello"})
+	require.NoError(t, err)
+
+	rootEvent := support.EmitCanvasEventForNodeWithData(t, canvas.ID, triggerNodeID, "default", nil, map[string]any{})
+	execution := support.CreateCanvasNodeExecution(t, canvas.ID, componentNodeID, rootEvent.ID, rootEvent.ID, nil)
+
+	t.Run("without execution -> not audited", func(t *testing.T) {
+		ctx := NewSecretsContext(database.Conn(), r.Organization.ID, r.Encryptor)
+		value, err := ctx.GetKey(secret.Name, "token")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(value))
+
+		entries, err := models.ListAuditLogEntries(r.Organization.ID, models.AuditLogFilters{}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("missing key -> not audited", func(t *testing.T) {
+		ctx := NewSecretsContext(database.Conn(), r.Organization.ID, r.Encryptor).ForExecution(execution)
+		_, err := ctx.GetKey(secret.Name, "missing")
+		require.Error(t, err)
+
+		entries, err := models.ListAuditLogEntries(r.Organization.ID, models.AuditLogFilters{}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("with execution -> audited, without the value", func(t *testing.T) {
+		ctx := NewSecretsContext(database.Conn(), r.Organization.ID, r.Encryptor).ForExecution(execution)
+		value, err := ctx.GetKey(secret.Name, "token")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(value))
+
+		entries, err := models.ListAuditLogEntries(r.Organization.ID, models.AuditLogFilters{ExecutionID: &execution.ID}, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		entry := entries[0]
+		assert.Equal(t, models.AuditActionSecretAccessed, entry.Action)
+		assert.Nil(t, entry.UserID)
+		assert.Equal(t, canvas.ID, *entry.WorkflowID)
+		assert.Equal(t, componentNodeID, *entry.NodeID)
+		assert.Equal(t, map[string]any{"secret": secret.Name, "key": "token"}, entry.Details.Data())
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/grpc/actions/messages"
 	"github.com/superplanehq/superplane/pkg/logging"
 	"github.com/superplanehq/superplane/pkg/public/ws"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/workers/eventdistributer"
 )
 
@@ -27,13 +28,15 @@ const (
 // and distributes events to websocket clients
 type EventDistributer struct {
 	wsHub    *ws.Hub
+	registry *registry.Registry
 	shutdown chan struct{}
 }
 
 // NewEventDistributer creates a new event distributer coordinator
-func NewEventDistributer(wsHub *ws.Hub) *EventDistributer {
+func NewEventDistributer(wsHub *ws.Hub, registry *registry.Registry) *EventDistributer {
 	return &EventDistributer{
 		wsHub:    wsHub,
+		registry: registry,
 		shutdown: make(chan struct{}),
 	}
 }
@@ -73,11 +76,11 @@ func (e *EventDistributer) Start() error {
 }
 
 // createHandler returns a tackle handler that calls the given processing function
-func (e *EventDistributer) createHandler(processFn func([]byte, *ws.Hub) error) func(delivery tackle.Delivery) error {
+func (e *EventDistributer) createHandler(processFn func([]byte, *ws.Hub, *registry.Registry) error) func(delivery tackle.Delivery) error {
 	return func(delivery tackle.Delivery) error {
 		// Call the Body() function to get the message body bytes
 		messageBody := delivery.Body()
-		err := processFn(messageBody, e.wsHub)
+		err := processFn(messageBody, e.wsHub, e.registry)
 		if err != nil {
 			log.Errorf("Error processing message: %v", err)
 			// Don't return the error to avoid redelivery, just log it
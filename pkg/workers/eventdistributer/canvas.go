@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/public/ws"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -27,15 +28,15 @@ type CanvasStateWebsocketEvent struct {
 	Payload CanvasStatePayload `json:"payload"`
 }
 
-func HandleCanvasUpdated(messageBody []byte, wsHub *ws.Hub) error {
+func HandleCanvasUpdated(messageBody []byte, wsHub *ws.Hub, _ *registry.Registry) error {
 	return handleCanvasState(messageBody, wsHub, CanvasUpdatedEvent)
 }
 
-func HandleCanvasDeleted(messageBody []byte, wsHub *ws.Hub) error {
+func HandleCanvasDeleted(messageBody []byte, wsHub *ws.Hub, _ *registry.Registry) error {
 	return handleCanvasState(messageBody, wsHub, CanvasDeletedEvent)
 }
 
-func HandleCanvasVersionUpdated(messageBody []byte, wsHub *ws.Hub) error {
+func HandleCanvasVersionUpdated(messageBody []byte, wsHub *ws.Hub, _ *registry.Registry) error {
 	pbMsg := &pb.CanvasVersionMessage{}
 	if err := proto.Unmarshal(messageBody, pbMsg); err != nil {
 		return fmt.Errorf("failed to unmarshal %s message: %w", CanvasVersionUpdatedEvent, err)
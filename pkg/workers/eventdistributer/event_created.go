@@ -10,6 +10,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/models"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/public/ws"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -19,7 +20,7 @@ type CanvasEventWebsocketEvent struct {
 	Payload json.RawMessage `json:"payload"`
 }
 
-func HandleCanvasEventCreated(messageBody []byte, wsHub *ws.Hub) error {
+func HandleCanvasEventCreated(messageBody []byte, wsHub *ws.Hub, registry *registry.Registry) error {
 	log.Debugf("Received execution_created event")
 
 	pbMsg := &pb.CanvasNodeEventMessage{}
@@ -27,10 +28,10 @@ func HandleCanvasEventCreated(messageBody []byte, wsHub *ws.Hub) error {
 		return fmt.Errorf("failed to unmarshal execution_created message: %w", err)
 	}
 
-	return handleWorkflowEventState(pbMsg.CanvasId, pbMsg.Id, wsHub, "event_created")
+	return handleWorkflowEventState(pbMsg.CanvasId, pbMsg.Id, wsHub, registry, "event_created")
 }
 
-func handleWorkflowEventState(canvasID string, eventID string, wsHub *ws.Hub, eventName string) error {
+func handleWorkflowEventState(canvasID string, eventID string, wsHub *ws.Hub, registry *registry.Registry, eventName string) error {
 	canvasUUID, err := uuid.Parse(canvasID)
 	if err != nil {
 		return fmt.Errorf("failed to parse canvas id: %w", err)
@@ -46,7 +47,7 @@ func handleWorkflowEventState(canvasID string, eventID string, wsHub *ws.Hub, ev
 		return fmt.Errorf("failed to find event: %w", err)
 	}
 
-	serializedEvent, err := canvases.SerializeCanvasEvent(*event)
+	serializedEvent, err := canvases.SerializeCanvasEvent(registry, *event)
 	if err != nil {
 		return fmt.Errorf("failed to serialize event: %w", err)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/models"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/public/ws"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -25,7 +26,7 @@ const (
 	ExecutionStartedEvent  = "execution_started"
 )
 
-func HandleCanvasExecution(messageBody []byte, wsHub *ws.Hub) error {
+func HandleCanvasExecution(messageBody []byte, wsHub *ws.Hub, registry *registry.Registry) error {
 	log.Debugf("Received execution event")
 
 	pbMsg := &pb.CanvasNodeExecutionMessage{}
@@ -33,7 +34,7 @@ func HandleCanvasExecution(messageBody []byte, wsHub *ws.Hub) error {
 		return fmt.Errorf("failed to unmarshal execution event: %w", err)
 	}
 
-	return handleExecutionState(pbMsg.CanvasId, pbMsg.Id, wsHub)
+	return handleExecutionState(pbMsg.CanvasId, pbMsg.Id, wsHub, registry)
 }
 
 func workflowExecutionStateToWsEvent(workflowState string) string {
@@ -49,7 +50,7 @@ func workflowExecutionStateToWsEvent(workflowState string) string {
 	}
 }
 
-func handleExecutionState(workflowID string, executionID string, wsHub *ws.Hub) error {
+func handleExecutionState(workflowID string, executionID string, wsHub *ws.Hub, registry *registry.Registry) error {
 	workflowUUID, err := uuid.Parse(workflowID)
 	if err != nil {
 		return fmt.Errorf("failed to parse workflow id: %w", err)
@@ -70,7 +71,7 @@ func handleExecutionState(workflowID string, executionID string, wsHub *ws.Hub)
 		return fmt.Errorf("unknown execution state: %s", execution.State)
 	}
 
-	serializedExecutions, err := canvases.SerializeNodeExecutions([]models.CanvasNodeExecution{*execution}, []models.CanvasNodeExecution{})
+	serializedExecutions, err := canvases.SerializeNodeExecutions(registry, []models.CanvasNodeExecution{*execution}, []models.CanvasNodeExecution{})
 	if err != nil {
 		return fmt.Errorf("failed to serialize execution: %w", err)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/models"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/public/ws"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -24,7 +25,7 @@ const (
 	QueueItemConsumedEvent = "queue_item_consumed"
 )
 
-func HandleQueueItemCreated(messageBody []byte, wsHub *ws.Hub) error {
+func HandleQueueItemCreated(messageBody []byte, wsHub *ws.Hub, registry *registry.Registry) error {
 	log.Debugf("Received queue_item_created event")
 
 	pbMsg := &pb.CanvasNodeQueueItemMessage{}
@@ -32,10 +33,10 @@ func HandleQueueItemCreated(messageBody []byte, wsHub *ws.Hub) error {
 		return fmt.Errorf("failed to unmarshal queue_item_created message: %w", err)
 	}
 
-	return handleQueueItemState(pbMsg.CanvasId, pbMsg.Id, pbMsg.NodeId, wsHub, QueueItemCreatedEvent)
+	return handleQueueItemState(pbMsg.CanvasId, pbMsg.Id, pbMsg.NodeId, wsHub, registry, QueueItemCreatedEvent)
 }
 
-func HandleQueueItemConsumed(messageBody []byte, wsHub *ws.Hub) error {
+func HandleQueueItemConsumed(messageBody []byte, wsHub *ws.Hub, registry *registry.Registry) error {
 	log.Debugf("Received queue_item_consumed event")
 
 	pbMsg := &pb.CanvasNodeQueueItemMessage{}
@@ -43,10 +44,10 @@ func HandleQueueItemConsumed(messageBody []byte, wsHub *ws.Hub) error {
 		return fmt.Errorf("failed to unmarshal queue_item_consumed message: %w", err)
 	}
 
-	return handleQueueItemState(pbMsg.CanvasId, pbMsg.Id, pbMsg.NodeId, wsHub, QueueItemConsumedEvent)
+	return handleQueueItemState(pbMsg.CanvasId, pbMsg.Id, pbMsg.NodeId, wsHub, registry, QueueItemConsumedEvent)
 }
 
-func handleQueueItemState(workflowID string, queueItemID string, nodeID string, wsHub *ws.Hub, eventName string) error {
+func handleQueueItemState(workflowID string, queueItemID string, nodeID string, wsHub *ws.Hub, registry *registry.Registry, eventName string) error {
 	workflowUUID, err := uuid.Parse(workflowID)
 	if err != nil {
 		return fmt.Errorf("failed to parse workflow id: %w", err)
@@ -74,7 +75,7 @@ func handleQueueItemState(workflowID string, queueItemID string, nodeID string,
 			return fmt.Errorf("failed to find queue item: %w", err)
 		}
 
-		serializedQueueItems, err := canvases.SerializeNodeQueueItems([]models.CanvasNodeQueueItem{*queueItem})
+		serializedQueueItems, err := canvases.SerializeNodeQueueItems(registry, []models.CanvasNodeQueueItem{*queueItem})
 		if err != nil {
 			return fmt.Errorf("failed to serialize queue item: %w", err)
 		}
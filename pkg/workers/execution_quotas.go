@@ -0,0 +1,122 @@
+package workers
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	DefaultCanvasExecutionConcurrency      = 10
+	DefaultIntegrationExecutionConcurrency = 10
+)
+
+/*
+ * ExecutionQuotas limits how many executions of the same canvas,
+ * and of nodes using the same integration, a node executor runs at the same time,
+ * so a canvas with a runaway trigger, or a slow integration,
+ * cannot take all the capacity of the executor from the other canvases.
+ *
+ * Quotas are per process, like the capacity of the executor itself.
+ * A limit of 0 means no limit.
+ */
+type ExecutionQuotas struct {
+	canvasLimit      int
+	integrationLimit int
+
+	mu           sync.Mutex
+	canvases     map[uuid.UUID]int
+	integrations map[uuid.UUID]int
+}
+
+func NewExecutionQuotas(canvasLimit, integrationLimit int) *ExecutionQuotas {
+	return &ExecutionQuotas{
+		canvasLimit:      canvasLimit,
+		integrationLimit: integrationLimit,
+		canvases:         map[uuid.UUID]int{},
+		integrations:     map[uuid.UUID]int{},
+	}
+}
+
+func (q *ExecutionQuotas) CanvasAvailable(canvasID uuid.UUID) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return available(q.canvases, canvasID, q.canvasLimit)
+}
+
+func (q *ExecutionQuotas) AcquireCanvas(canvasID uuid.UUID) bool {
+	return q.acquire(q.canvases, canvasID, q.canvasLimit)
+}
+
+func (q *ExecutionQuotas) ReleaseCanvas(canvasID uuid.UUID) {
+	q.release(q.canvases, canvasID)
+}
+
+func (q *ExecutionQuotas) AcquireIntegration(integrationID uuid.UUID) bool {
+	return q.acquire(q.integrations, integrationID, q.integrationLimit)
+}
+
+func (q *ExecutionQuotas) ReleaseIntegration(integrationID uuid.UUID) {
+	q.release(q.integrations, integrationID)
+}
+
+func (q *ExecutionQuotas) acquire(running map[uuid.UUID]int, id uuid.UUID, limit int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !available(running, id, limit) {
+		return false
+	}
+
+	running[id]++
+	return true
+}
+
+func (q *ExecutionQuotas) release(running map[uuid.UUID]int, id uuid.UUID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	running[id]--
+	if running[id] <= 0 {
+		delete(running, id)
+	}
+}
+
+func available(running map[uuid.UUID]int, id uuid.UUID, limit int) bool {
+	return limit <= 0 || running[id] < limit
+}
+
+/*
+ * fairExecutionOrder interleaves the executions of different canvases,
+ * taking one execution of each canvas in turn, so the executions
+ * of a canvas with many pending ones do not all run before the others.
+ * Canvases are taken in the order of their first execution,
+ * and the executions of each canvas keep their order.
+ */
+func fairExecutionOrder(executions []models.CanvasNodeExecution) []models.CanvasNodeExecution {
+	canvases := []uuid.UUID{}
+	byCanvas := map[uuid.UUID][]models.CanvasNodeExecution{}
+	for _, execution := range executions {
+		if _, ok := byCanvas[execution.WorkflowID]; !ok {
+			canvases = append(canvases, execution.WorkflowID)
+		}
+
+		byCanvas[execution.WorkflowID] = append(byCanvas[execution.WorkflowID], execution)
+	}
+
+	ordered := make([]models.CanvasNodeExecution, 0, len(executions))
+	for len(ordered) < len(executions) {
+		for _, canvasID := range canvases {
+			pending := byCanvas[canvasID]
+			if len(pending) == 0 {
+				continue
+			}
+
+			ordered = append(ordered, pending[0])
+			byCanvas[canvasID] = pending[1:]
+		}
+	}
+
+	return ordered
+}
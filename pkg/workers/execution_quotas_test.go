@@ -0,0 +1,73 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+func TestExecutionQuotas(t *testing.T) {
+	canvasA := uuid.New()
+	canvasB := uuid.New()
+	integration := uuid.New()
+
+	t.Run("canvas quota", func(t *testing.T) {
+		quotas := NewExecutionQuotas(2, 0)
+
+		assert.True(t, quotas.AcquireCanvas(canvasA))
+		assert.True(t, quotas.AcquireCanvas(canvasA))
+		assert.False(t, quotas.AcquireCanvas(canvasA))
+		assert.False(t, quotas.CanvasAvailable(canvasA))
+
+		// Other canvases are not affected
+		assert.True(t, quotas.AcquireCanvas(canvasB))
+
+		quotas.ReleaseCanvas(canvasA)
+		assert.True(t, quotas.CanvasAvailable(canvasA))
+		assert.True(t, quotas.AcquireCanvas(canvasA))
+	})
+
+	t.Run("integration quota", func(t *testing.T) {
+		quotas := NewExecutionQuotas(0, 1)
+
+		assert.True(t, quotas.AcquireIntegration(integration))
+		assert.False(t, quotas.AcquireIntegration(integration))
+
+		quotas.ReleaseIntegration(integration)
+		assert.True(t, quotas.AcquireIntegration(integration))
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		quotas := NewExecutionQuotas(0, 0)
+		for range 100 {
+			assert.True(t, quotas.AcquireCanvas(canvasA))
+			assert.True(t, quotas.AcquireIntegration(integration))
+		}
+	})
+}
+
+func TestFairExecutionOrder(t *testing.T) {
+	canvasA := uuid.New()
+	canvasB := uuid.New()
+	canvasC := uuid.New()
+
+	execution := func(canvasID uuid.UUID) models.CanvasNodeExecution {
+		return models.CanvasNodeExecution{ID: uuid.New(), WorkflowID: canvasID}
+	}
+
+	a1, a2, a3, a4 := execution(canvasA), execution(canvasA), execution(canvasA), execution(canvasA)
+	b1, b2 := execution(canvasB), execution(canvasB)
+	c1 := execution(canvasC)
+
+	ordered := fairExecutionOrder([]models.CanvasNodeExecution{a1, a2, a3, b1, a4, c1, b2})
+
+	ids := []uuid.UUID{}
+	for _, e := range ordered {
+		ids = append(ids, e.ID)
+	}
+
+	assert.Equal(t, []uuid.UUID{a1.ID, b1.ID, c1.ID, a2.ID, b2.ID, a3.ID, a4.ID}, ids)
+	assert.Empty(t, fairExecutionOrder(nil))
+}
@@ -22,6 +22,8 @@ type IntegrationCleanupWorker struct {
 	registry  *registry.Registry
 	encryptor crypto.Encryptor
 	baseURL   string
+
+	lastDeliveriesCleanup time.Time
 }
 
 func NewIntegrationCleanupWorker(registry *registry.Registry, encryptor crypto.Encryptor, baseURL string) *IntegrationCleanupWorker {
@@ -42,6 +44,8 @@ func (w *IntegrationCleanupWorker) Start(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			w.deleteExpiredWebhookDeliveries()
+
 			integrations, err := models.ListDeletedIntegrations()
 			if err != nil {
 				w.log("Error finding deleted integrations: %v", err)
@@ -65,6 +69,27 @@ func (w *IntegrationCleanupWorker) Start(ctx context.Context) {
 	}
 }
 
+/*
+ * Webhook deliveries are kept for models.WebhookDeliveryRetention.
+ * They are not removed on every tick, since they expire slowly.
+ */
+func (w *IntegrationCleanupWorker) deleteExpiredWebhookDeliveries() {
+	if time.Since(w.lastDeliveriesCleanup) < time.Minute {
+		return
+	}
+
+	w.lastDeliveriesCleanup = time.Now()
+	deleted, err := models.DeleteWebhookDeliveriesBefore(time.Now().Add(-models.WebhookDeliveryRetention))
+	if err != nil {
+		w.log("Error deleting expired webhook deliveries: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		w.log("Deleted %d expired webhook deliveries", deleted)
+	}
+}
+
 func (w *IntegrationCleanupWorker) LockAndProcessIntegration(integration models.Integration) error {
 	return database.Conn().Transaction(func(tx *gorm.DB) error {
 		r, err := models.LockIntegration(tx, integration.ID)
@@ -0,0 +1,186 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"gorm.io/gorm"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/logging"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/workers/contexts"
+)
+
+const (
+	DefaultIntegrationHealthCheckInterval = 5 * time.Minute
+	IntegrationHealthCheckTimeout         = 30 * time.Second
+)
+
+/*
+ * IntegrationHealthCheckWorker periodically runs the health check
+ * of ready and degraded integrations. A ready integration failing it
+ * is marked as degraded, and a degraded one passing it is ready again.
+ */
+type IntegrationHealthCheckWorker struct {
+	semaphore *semaphore.Weighted
+	registry  *registry.Registry
+	encryptor crypto.Encryptor
+	interval  time.Duration
+}
+
+func NewIntegrationHealthCheckWorker(encryptor crypto.Encryptor, registry *registry.Registry, interval time.Duration) *IntegrationHealthCheckWorker {
+	return &IntegrationHealthCheckWorker{
+		encryptor: encryptor,
+		registry:  registry,
+		interval:  interval,
+		semaphore: semaphore.NewWeighted(25),
+	}
+}
+
+func (w *IntegrationHealthCheckWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			integrations, err := models.ListIntegrationsDueForHealthCheck(time.Now().Add(-w.interval))
+			if err != nil {
+				w.log("Error finding integrations due for health check: %v", err)
+			}
+
+			for _, integration := range integrations {
+				if err := w.semaphore.Acquire(context.Background(), 1); err != nil {
+					w.log("Error acquiring semaphore: %v", err)
+					continue
+				}
+
+				go func(integration models.Integration) {
+					defer w.semaphore.Release(1)
+
+					if err := w.LockAndCheck(ctx, integration); err != nil {
+						w.log("Error checking integration %s: %v", integration.ID, err)
+					}
+				}(integration)
+			}
+		}
+	}
+}
+
+/*
+ * Claims the integration by bumping its health_checked_at,
+ * so other workers do not check it again during this interval.
+ * The check itself runs outside of the transaction,
+ * since it calls external systems.
+ */
+func (w *IntegrationHealthCheckWorker) LockAndCheck(ctx context.Context, integration models.Integration) error {
+	var instance *models.Integration
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		i, err := models.LockIntegration(tx, integration.ID)
+		if err != nil {
+			w.log("Integration %s already being checked - skipping", integration.ID)
+			return nil
+		}
+
+		if i.DeletedAt.Valid {
+			return nil
+		}
+
+		if i.HealthCheckedAt != nil && i.HealthCheckedAt.After(time.Now().Add(-w.interval)) {
+			return nil
+		}
+
+		if err := i.UpdateHealthCheckedAtInTransaction(tx, time.Now()); err != nil {
+			return err
+		}
+
+		instance = i
+		return nil
+	})
+
+	if err != nil || instance == nil {
+		return err
+	}
+
+	return w.check(ctx, instance)
+}
+
+func (w *IntegrationHealthCheckWorker) check(ctx context.Context, instance *models.Integration) error {
+	if instance.State != models.IntegrationStateReady && instance.State != models.IntegrationStateDegraded {
+		return nil
+	}
+
+	integration, err := w.registry.GetIntegration(instance.AppName)
+	if err != nil {
+		return fmt.Errorf("integration %s not found", instance.AppName)
+	}
+
+	checker, ok := integration.(core.HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, IntegrationHealthCheckTimeout)
+	defer cancel()
+
+	//
+	// The integration context gets a copy of the integration,
+	// so anything the health check changes on it is discarded.
+	//
+	snapshot := *instance
+	logger := logging.ForIntegration(*instance)
+	checkErr := checker.HealthCheck(core.HealthCheckContext{
+		Context:       checkCtx,
+		Logger:        logger,
+		Configuration: instance.Configuration.Data(),
+		HTTP:          w.registry.HTTPContext().ForIntegration(instance.ID.String()),
+		Integration:   contexts.NewIntegrationContext(database.Conn(), nil, &snapshot, w.encryptor, w.registry, nil),
+	})
+
+	if checkErr == nil && instance.State == models.IntegrationStateDegraded {
+		logger.Info("Health check passed - integration is ready again")
+		_, err := instance.TransitionState(models.IntegrationStateDegraded, models.IntegrationStateReady, "")
+		return err
+	}
+
+	if checkErr != nil && instance.State == models.IntegrationStateReady {
+		logger.Warnf("Health check failed - integration is degraded: %v", checkErr)
+		_, err := instance.TransitionState(
+			models.IntegrationStateReady,
+			models.IntegrationStateDegraded,
+			truncateStateDescription(fmt.Sprintf("Health check failed: %v", checkErr)),
+		)
+
+		return err
+	}
+
+	if checkErr != nil {
+		logger.Warnf("Health check still failing: %v", checkErr)
+	}
+
+	return nil
+}
+
+/*
+ * state_description is a varchar(1024).
+ */
+func truncateStateDescription(description string) string {
+	if len(description) <= 1024 {
+		return description
+	}
+
+	return description[:1021] + "..."
+}
+
+func (w *IntegrationHealthCheckWorker) log(format string, v ...any) {
+	log.Printf("[IntegrationHealthCheckWorker] "+format, v...)
+}
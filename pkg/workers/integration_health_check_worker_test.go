@@ -0,0 +1,82 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+)
+
+func Test__IntegrationHealthCheckWorker(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	var checkErr error
+	checks := 0
+	r.Registry.Integrations["dummy"] = support.NewDummyIntegration(support.DummyIntegrationOptions{
+		OnHealthCheck: func(ctx core.HealthCheckContext) error {
+			checks++
+			return checkErr
+		},
+	})
+
+	worker := NewIntegrationHealthCheckWorker(r.Encryptor, r.Registry, time.Minute)
+	integration, err := models.CreateIntegration(uuid.New(), r.Organization.ID, "dummy", support.RandomName("integration"), nil)
+	require.NoError(t, err)
+	require.NoError(t, database.Conn().Model(integration).Update("state", models.IntegrationStateReady).Error)
+
+	reload := func() *models.Integration {
+		i, err := models.FindUnscopedIntegration(integration.ID)
+		require.NoError(t, err)
+		return i
+	}
+
+	t.Run("healthy ready integration stays ready", func(t *testing.T) {
+		require.NoError(t, worker.LockAndCheck(t.Context(), *integration))
+
+		i := reload()
+		assert.Equal(t, 1, checks)
+		assert.Equal(t, models.IntegrationStateReady, i.State)
+		assert.NotNil(t, i.HealthCheckedAt)
+	})
+
+	t.Run("recently checked integration is not checked again", func(t *testing.T) {
+		due, err := models.ListIntegrationsDueForHealthCheck(time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, due)
+
+		require.NoError(t, worker.LockAndCheck(t.Context(), *integration))
+		assert.Equal(t, 1, checks)
+	})
+
+	t.Run("failing health check -> degraded", func(t *testing.T) {
+		checkErr = errors.New("credentials revoked")
+		require.NoError(t, database.Conn().Model(integration).UpdateColumn("health_checked_at", time.Now().Add(-time.Hour)).Error)
+
+		require.NoError(t, worker.LockAndCheck(t.Context(), *integration))
+
+		i := reload()
+		assert.Equal(t, 2, checks)
+		assert.Equal(t, models.IntegrationStateDegraded, i.State)
+		assert.Equal(t, "Health check failed: credentials revoked", i.StateDescription)
+	})
+
+	t.Run("passing health check -> ready again", func(t *testing.T) {
+		checkErr = nil
+		require.NoError(t, database.Conn().Model(integration).UpdateColumn("health_checked_at", time.Now().Add(-time.Hour)).Error)
+
+		require.NoError(t, worker.LockAndCheck(t.Context(), *integration))
+
+		i := reload()
+		assert.Equal(t, 3, checks)
+		assert.Equal(t, models.IntegrationStateReady, i.State)
+		assert.Empty(t, i.StateDescription)
+	})
+}
@@ -0,0 +1,154 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/logging"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/workers/contexts"
+)
+
+const (
+	DefaultIntegrationPrefetchInterval = time.Hour
+	IntegrationPrefetchTimeout         = 5 * time.Minute
+)
+
+/*
+ * IntegrationPrefetchWorker warms the caches of ready integrations,
+ * see core.Prefetcher.
+ *
+ * Caches are in memory, so every process serving the API runs its own worker,
+ * and keeps track of the integrations it prefetched itself, instead of claiming
+ * them in the database like the health check worker does.
+ *
+ * An integration is prefetched when this process never prefetched it,
+ * when it was updated since, e.g. synced, or once the interval passes.
+ */
+type IntegrationPrefetchWorker struct {
+	semaphore *semaphore.Weighted
+	registry  *registry.Registry
+	encryptor crypto.Encryptor
+	interval  time.Duration
+
+	mu         sync.Mutex
+	prefetched map[uuid.UUID]time.Time
+}
+
+func NewIntegrationPrefetchWorker(encryptor crypto.Encryptor, registry *registry.Registry, interval time.Duration) *IntegrationPrefetchWorker {
+	return &IntegrationPrefetchWorker{
+		encryptor:  encryptor,
+		registry:   registry,
+		interval:   interval,
+		semaphore:  semaphore.NewWeighted(5),
+		prefetched: map[uuid.UUID]time.Time{},
+	}
+}
+
+func (w *IntegrationPrefetchWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			integrations, err := models.ListReadyIntegrations()
+			if err != nil {
+				w.log("Error finding integrations to prefetch: %v", err)
+			}
+
+			for _, integration := range w.due(integrations, time.Now()) {
+				if err := w.semaphore.Acquire(context.Background(), 1); err != nil {
+					w.log("Error acquiring semaphore: %v", err)
+					continue
+				}
+
+				go func(integration models.Integration) {
+					defer w.semaphore.Release(1)
+
+					if err := w.Prefetch(ctx, &integration); err != nil {
+						w.log("Error prefetching integration %s: %v", integration.ID, err)
+					}
+				}(integration)
+			}
+		}
+	}
+}
+
+/*
+ * due returns the integrations to prefetch, and records them as prefetched,
+ * so the next tick does not prefetch them again while they are still running.
+ * Integrations no longer ready are forgotten.
+ */
+func (w *IntegrationPrefetchWorker) due(integrations []models.Integration, now time.Time) []models.Integration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ready := make(map[uuid.UUID]bool, len(integrations))
+	due := []models.Integration{}
+	for _, integration := range integrations {
+		ready[integration.ID] = true
+
+		prefetchedAt, ok := w.prefetched[integration.ID]
+		if ok && prefetchedAt.After(now.Add(-w.interval)) {
+			if integration.UpdatedAt == nil || !integration.UpdatedAt.After(prefetchedAt) {
+				continue
+			}
+		}
+
+		w.prefetched[integration.ID] = now
+		due = append(due, integration)
+	}
+
+	for id := range w.prefetched {
+		if !ready[id] {
+			delete(w.prefetched, id)
+		}
+	}
+
+	return due
+}
+
+func (w *IntegrationPrefetchWorker) Prefetch(ctx context.Context, instance *models.Integration) error {
+	integration, err := w.registry.GetIntegration(instance.AppName)
+	if err != nil {
+		return fmt.Errorf("integration %s not found", instance.AppName)
+	}
+
+	prefetcher, ok := integration.(core.Prefetcher)
+	if !ok {
+		return nil
+	}
+
+	prefetchCtx, cancel := context.WithTimeout(ctx, IntegrationPrefetchTimeout)
+	defer cancel()
+
+	//
+	// The integration context gets a copy of the integration,
+	// so anything the prefetch changes on it is discarded.
+	//
+	snapshot := *instance
+	return prefetcher.Prefetch(core.PrefetchContext{
+		Context:       prefetchCtx,
+		Logger:        logging.ForIntegration(*instance),
+		Configuration: instance.Configuration.Data(),
+		HTTP:          w.registry.HTTPContext().ForIntegration(instance.ID.String()),
+		Integration:   contexts.NewIntegrationContext(database.Conn(), nil, &snapshot, w.encryptor, w.registry, nil),
+	})
+}
+
+func (w *IntegrationPrefetchWorker) log(format string, v ...any) {
+	log.Printf("[IntegrationPrefetchWorker] "+format, v...)
+}
@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+func Test__IntegrationPrefetchWorker__Due(t *testing.T) {
+	w := NewIntegrationPrefetchWorker(nil, nil, time.Hour)
+	now := time.Now()
+	updatedAt := now.Add(-time.Minute)
+	integration := models.Integration{ID: uuid.New(), UpdatedAt: &updatedAt}
+
+	t.Run("integration never prefetched is due", func(t *testing.T) {
+		due := w.due([]models.Integration{integration}, now)
+		require.Len(t, due, 1)
+		assert.Equal(t, integration.ID, due[0].ID)
+	})
+
+	t.Run("integration prefetched during the interval is not due", func(t *testing.T) {
+		assert.Empty(t, w.due([]models.Integration{integration}, now.Add(time.Minute)))
+	})
+
+	t.Run("integration updated since it was prefetched is due", func(t *testing.T) {
+		synced := now.Add(2 * time.Minute)
+		updated := integration
+		updated.UpdatedAt = &synced
+
+		assert.Len(t, w.due([]models.Integration{updated}, now.Add(3*time.Minute)), 1)
+		assert.Empty(t, w.due([]models.Integration{updated}, now.Add(4*time.Minute)))
+	})
+
+	t.Run("integration is due again once the interval passes", func(t *testing.T) {
+		assert.Len(t, w.due([]models.Integration{integration}, now.Add(2*time.Hour)), 1)
+	})
+
+	t.Run("integrations no longer ready are forgotten", func(t *testing.T) {
+		w.due([]models.Integration{}, now.Add(2*time.Hour))
+		assert.Empty(t, w.prefetched)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/renderedtext/go-tackle"
 	"github.com/sirupsen/logrus"
 	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/blobs"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/crypto"
@@ -29,6 +30,8 @@ import (
 )
 
 var ErrRecordLocked = errors.New("record locked")
+var ErrIntegrationDegraded = errors.New("integration degraded")
+var ErrExecutionQuotaExceeded = errors.New("execution quota exceeded")
 
 type NodeExecutor struct {
 	encryptor      crypto.Encryptor
@@ -36,6 +39,7 @@ type NodeExecutor struct {
 	baseURL        string
 	webhookBaseURL string
 	semaphore      *semaphore.Weighted
+	quotas         *ExecutionQuotas
 	logger         *logrus.Entry
 
 	rabbitMQURL string
@@ -49,11 +53,17 @@ func NewNodeExecutor(encryptor crypto.Encryptor, registry *registry.Registry, ba
 		baseURL:        baseURL,
 		webhookBaseURL: webhookBaseURL,
 		semaphore:      semaphore.NewWeighted(25),
+		quotas:         NewExecutionQuotas(DefaultCanvasExecutionConcurrency, DefaultIntegrationExecutionConcurrency),
 		logger:         logrus.WithFields(logrus.Fields{"worker": "NodeExecutor"}),
 		rabbitMQURL:    rabbitMQURL,
 	}
 }
 
+func (w *NodeExecutor) WithExecutionQuotas(quotas *ExecutionQuotas) *NodeExecutor {
+	w.quotas = quotas
+	return w
+}
+
 func (w *NodeExecutor) Name() string {
 	return "NodeExecutor"
 }
@@ -61,6 +71,12 @@ func (w *NodeExecutor) Name() string {
 func (w *NodeExecutor) Start(ctx context.Context) {
 	go w.StartRabbitMQConsumer(ctx)
 
+	//
+	// Executions interrupted by a restart are resumed right away,
+	// instead of waiting for the first tick.
+	//
+	w.resumeCheckpointedExecutions()
+
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
@@ -77,32 +93,58 @@ func (w *NodeExecutor) Start(ctx context.Context) {
 			}
 
 			telemetry.RecordExecutorWorkerNodesCount(context.Background(), len(executions))
+			w.processExecutions(executions)
+			telemetry.RecordExecutorWorkerTickDuration(context.Background(), time.Since(tickStart))
+		}
+	}
+}
+
+func (w *NodeExecutor) resumeCheckpointedExecutions() {
+	executions, err := models.ListPendingCheckpointedNodeExecutions()
+	if err != nil {
+		w.logger.Errorf("Error finding checkpointed executions: %v", err)
+		return
+	}
+
+	if len(executions) > 0 {
+		w.logger.Infof("Resuming %d checkpointed executions", len(executions))
+	}
 
-			for _, execution := range executions {
-				if err := w.semaphore.Acquire(context.Background(), 1); err != nil {
-					w.logger.Errorf("Error acquiring semaphore: %v", err)
-					continue
-				}
+	w.processExecutions(executions)
+}
 
-				go func(execution models.CanvasNodeExecution) {
-					defer w.semaphore.Release(1)
+func (w *NodeExecutor) processExecutions(executions []models.CanvasNodeExecution) {
+	for _, execution := range fairExecutionOrder(executions) {
 
-					err := w.LockAndProcessNodeExecution(execution.ID)
-					if err == nil {
-						messages.NewCanvasExecutionMessage(execution.WorkflowID.String(), execution.ID.String(), execution.NodeID).Publish()
-						return
-					}
+		//
+		// Executions of canvases already running as many executions as their quota
+		// are left pending for the next tick, instead of waiting for capacity
+		// that other canvases could use.
+		//
+		if !w.quotas.CanvasAvailable(execution.WorkflowID) {
+			continue
+		}
 
-					if err == ErrRecordLocked {
-						return
-					}
+		if err := w.semaphore.Acquire(context.Background(), 1); err != nil {
+			w.logger.Errorf("Error acquiring semaphore: %v", err)
+			continue
+		}
+
+		go func(execution models.CanvasNodeExecution) {
+			defer w.semaphore.Release(1)
 
-					w.logger.Errorf("Error processing node execution - node=%s, execution=%s: %v", execution.NodeID, execution.ID, err)
-				}(execution)
+			err := w.LockAndProcessNodeExecution(execution.ID)
+			if err == nil {
+				messages.NewCanvasExecutionMessage(execution.WorkflowID.String(), execution.ID.String(), execution.NodeID).Publish()
+				return
 			}
 
-			telemetry.RecordExecutorWorkerTickDuration(context.Background(), time.Since(tickStart))
-		}
+			if err == ErrRecordLocked || err == ErrIntegrationDegraded || err == ErrExecutionQuotaExceeded {
+				return
+			}
+
+			w.logger.Errorf("Error processing node execution - node=%s, execution=%s: %v", execution.NodeID, execution.ID, err)
+		}(execution)
 	}
 }
 
@@ -154,7 +196,7 @@ func (w *NodeExecutor) Consume(delivery tackle.Delivery) error {
 		return nil
 	}
 
-	if err == ErrRecordLocked {
+	if err == ErrRecordLocked || err == ErrIntegrationDegraded || err == ErrExecutionQuotaExceeded {
 		return nil
 	}
 
@@ -222,6 +264,42 @@ func (w *NodeExecutor) processNodeExecution(tx *gorm.DB, execution *models.Canva
 		return err
 	}
 
+	//
+	// Executions over the quota of their canvas or integration stay pending,
+	// and are picked up again by a later tick, once others finish.
+	//
+	if !w.quotas.AcquireCanvas(execution.WorkflowID) {
+		w.logger.Debugf("Execution %s not started - canvas %s is at its execution quota", execution.ID, execution.WorkflowID)
+		return ErrExecutionQuotaExceeded
+	}
+
+	defer w.quotas.ReleaseCanvas(execution.WorkflowID)
+
+	if node.AppInstallationID != nil {
+		if !w.quotas.AcquireIntegration(*node.AppInstallationID) {
+			w.logger.Debugf("Execution %s not started - integration %s is at its execution quota", execution.ID, *node.AppInstallationID)
+			return ErrExecutionQuotaExceeded
+		}
+
+		defer w.quotas.ReleaseIntegration(*node.AppInstallationID)
+	}
+
+	//
+	// Executions of nodes using a degraded integration stay pending,
+	// and are picked up again once the integration health check passes.
+	//
+	if node.AppInstallationID != nil {
+		integration, err := models.FindUnscopedIntegrationInTransaction(tx, *node.AppInstallationID)
+		if err == nil && integration.State == models.IntegrationStateDegraded {
+			w.logger.Warnf(
+				"Execution %s not started - integration %s is degraded: %s",
+				execution.ID, integration.InstallationName, integration.StateDescription,
+			)
+
+			return ErrIntegrationDegraded
+		}
+	}
+
 	if node.Type == models.NodeTypeBlueprint {
 		return w.executeBlueprintNode(tx, execution, node)
 	}
@@ -358,6 +436,7 @@ func (w *NodeExecutor) executeComponentNode(tx *gorm.DB, execution *models.Canva
 		return fmt.Errorf("failed to find workflow: %v", err)
 	}
 
+	logs := contexts.NewExecutionLogsContext(execution)
 	ctx := core.ExecutionContext{
 		ID:             execution.ID,
 		WorkflowID:     execution.WorkflowID.String(),
@@ -374,9 +453,15 @@ func (w *NodeExecutor) executeComponentNode(tx *gorm.DB, execution *models.Canva
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 		Auth:           contexts.NewAuthContext(tx, workflow.OrganizationID, nil, nil),
 		Notifications:  contexts.NewNotificationContext(tx, workflow.OrganizationID, execution.WorkflowID),
-		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor),
+		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor).ForExecution(execution),
+		Files:          contexts.NewFilesContext(blobs.Default(), workflow.OrganizationID),
 		CanvasMemory:   contexts.NewCanvasMemoryContext(tx, execution.WorkflowID),
+		CanvasRuns:     contexts.NewCanvasRunsContext(tx, workflow.OrganizationID, execution.WorkflowID, execution.RootEventID, onNewEvents),
 		Webhook:        contexts.NewNodeWebhookContext(context.Background(), tx, w.encryptor, node, w.webhookBaseURL),
+		Logs:           logs,
+		Costs:          contexts.NewExecutionCostsContext(execution),
+		Checkpoints:    contexts.NewExecutionCheckpointContext(execution),
+		TestMode:       workflow.TestMode,
 	}
 	ctx.ExpressionEnv = func(expression string) (map[string]any, error) {
 		builder := contexts.NewNodeConfigurationBuilder(tx, execution.WorkflowID).
@@ -403,16 +488,153 @@ func (w *NodeExecutor) executeComponentNode(tx *gorm.DB, execution *models.Canva
 
 		logger = logging.WithIntegration(logger, *instance)
 		ctx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry, onNewEvents)
+		ctx.HTTP = w.registry.HTTPContext().ForIntegration(instance.ID.String())
+	}
+
+	//
+	// Secret fields are only decrypted for Execute(),
+	// the execution configuration keeps the sealed values.
+	//
+	config, err := configuration.UnsealSecretFields(
+		context.Background(),
+		w.encryptor,
+		component.Configuration(),
+		execution.Configuration.Data(),
+		[]byte(workflow.OrganizationID.String()),
+	)
+
+	if err != nil {
+		logger.Errorf("failed to decrypt secret fields: %v", err)
+		return ctx.ExecutionState.Fail(models.CanvasNodeExecutionResultReasonError, "failed to decrypt secret fields")
+	}
+
+	ctx.Configuration = config
+
+	//
+	// If the node has an execution timeout, Execute() receives
+	// a context with the execution deadline.
+	//
+	timeout := core.ExecutionTimeoutFor(component, ctx.Configuration)
+	startedAt := time.Now()
+	deadline := startedAt.Add(timeout)
+	if timeout > 0 {
+		goCtx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+		ctx.Context = goCtx
 	}
 
 	ctx.Logger = logger
-	if err := component.Execute(ctx); err != nil {
+	defer flushExecutionLogs(logs, logger)
+
+	if ctx.TestMode && !core.HandlesTestMode(component) {
+		logger.Info("Canvas in test mode - emitting example output")
+		logs.Printf("Test mode: %s was not executed, its example output was emitted instead", ref.Component.Name)
+		if err := core.EmitExampleOutput(ctx, component); err != nil {
+			return ctx.ExecutionState.Fail(models.CanvasNodeExecutionResultReasonError, err.Error())
+		}
+
+		return tx.Save(execution).Error
+	}
+
+	checkpoint, err := findCheckpoint(tx, execution)
+	if err != nil {
+		logger.Errorf("failed to find checkpoint: %v", err)
+		return fmt.Errorf("failed to find checkpoint: %w", err)
+	}
+
+	err = w.executeOrResume(ctx, component, checkpoint)
+
+	//
+	// Checkpoints are only needed if this transaction is rolled back.
+	// They are saved with a separate connection, so they are only
+	// removed after Execute() returns, to avoid waiting on our own lock.
+	//
+	if deleteErr := models.DeleteNodeExecutionCheckpointInTransaction(tx, execution.ID); deleteErr != nil {
+		logger.Errorf("failed to delete checkpoint: %v", deleteErr)
+		return fmt.Errorf("failed to delete checkpoint: %w", deleteErr)
+	}
+
+	if err != nil {
 		logger.Errorf("failed to execute component: %v", err)
-		err = execution.FailInTransaction(tx, models.CanvasNodeExecutionResultReasonError, err.Error())
+		if timeout > 0 && errors.Is(ctx.Context.Err(), context.DeadlineExceeded) {
+			return timeOutExecution(tx, execution, timeout, time.Since(startedAt), onNewEvents)
+		}
+
+		err = ctx.ExecutionState.Fail(models.CanvasNodeExecutionResultReasonError, err.Error())
 		return err
 	}
 
 	logger.Info("Component executed successfully")
 
+	//
+	// Executions that are still running after Execute(),
+	// are cancelled and failed by the node request worker
+	// if they do not finish before the deadline.
+	//
+	if timeout > 0 && execution.State != models.CanvasNodeExecutionStateFinished {
+		err := execution.CreateRequest(tx, models.NodeRequestTypeExecutionTimeout, models.NodeExecutionRequestSpec{}, &deadline)
+		if err != nil {
+			return fmt.Errorf("failed to schedule execution timeout: %w", err)
+		}
+	}
+
 	return tx.Save(execution).Error
 }
+
+/*
+ * executeOrResume calls Resume() for executions interrupted by a restart
+ * after saving a checkpoint, if the component can resume them,
+ * and Execute() otherwise.
+ */
+func (w *NodeExecutor) executeOrResume(ctx core.ExecutionContext, component core.Component, checkpoint *core.Checkpoint) error {
+	if checkpoint == nil {
+		return component.Execute(ctx)
+	}
+
+	resumable, ok := component.(core.ResumableComponent)
+	if !ok {
+		return component.Execute(ctx)
+	}
+
+	ctx.Logger.Infof("Resuming execution from checkpoint %s", checkpoint.Step)
+	return resumable.Resume(ctx, *checkpoint)
+}
+
+func findCheckpoint(tx *gorm.DB, execution *models.CanvasNodeExecution) (*core.Checkpoint, error) {
+	checkpoint, err := models.FindNodeExecutionCheckpointInTransaction(tx, execution.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &core.Checkpoint{
+		Step:      checkpoint.Step,
+		Operation: checkpoint.Operation,
+		Target:    checkpoint.Target.Data(),
+	}, nil
+}
+
+/*
+ * timeOutExecution fails an execution that did not finish before its deadline.
+ */
+func timeOutExecution(tx *gorm.DB, execution *models.CanvasNodeExecution, timeout, elapsed time.Duration, onNewEvents func([]models.CanvasEvent)) error {
+	newEvents, err := execution.TimeOutInTransaction(tx, timeout, elapsed)
+	if err != nil {
+		return err
+	}
+
+	if onNewEvents != nil && len(newEvents) > 0 {
+		onNewEvents(newEvents)
+	}
+
+	return nil
+}
+
+func flushExecutionLogs(logs *contexts.ExecutionLogsContext, logger *log.Entry) {
+	if err := logs.Flush(); err != nil {
+		logger.Errorf("failed to flush execution logs: %v", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/test/support"
 	"gorm.io/datatypes"
@@ -302,6 +304,81 @@ func Test__NodeExecutor_ComponentNodeWithStateChange(t *testing.T) {
 	assert.Equal(t, models.CanvasNodeExecutionResultPassed, updatedExecution.Result)
 }
 
+func Test__NodeExecutor_ComponentNodeFailureRoutedToErrorChannel(t *testing.T) {
+	r := support.Setup(t)
+
+	//
+	// Create a canvas with an if component node that routes its errors
+	// to the error channel, and a noop node handling them.
+	//
+	triggerNode := "trigger-1"
+	ifNode := "if-1"
+	handlerNode := "handler-1"
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{
+				NodeID: triggerNode,
+				Type:   models.NodeTypeTrigger,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+			},
+			{
+				NodeID: ifNode,
+				Type:   models.NodeTypeComponent,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "if"}}),
+			},
+			{
+				NodeID: handlerNode,
+				Type:   models.NodeTypeComponent,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "noop"}}),
+			},
+		},
+		[]models.Edge{
+			{SourceID: triggerNode, TargetID: ifNode, Channel: "default"},
+			{SourceID: ifNode, TargetID: handlerNode, Channel: core.ErrorOutputChannel.Name},
+		},
+	)
+
+	//
+	// The expression does not evaluate to a boolean, so the execution fails.
+	//
+	rootEvent := support.EmitCanvasEventForNode(t, canvas.ID, triggerNode, "default", nil)
+	execution := support.CreateCanvasNodeExecution(t, canvas.ID, ifNode, rootEvent.ID, rootEvent.ID, nil)
+	require.NoError(t, database.Conn().Model(execution).Update("configuration", datatypes.NewJSONType(map[string]any{
+		"expression":          `"yes"`,
+		core.RouteErrorsField: true,
+	})).Error)
+
+	executor := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost", "")
+	require.NoError(t, executor.LockAndProcessNodeExecution(execution.ID))
+
+	//
+	// The execution is still failed, but an event is emitted on the error channel.
+	//
+	updatedExecution, err := models.FindNodeExecution(canvas.ID, execution.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CanvasNodeExecutionStateFinished, updatedExecution.State)
+	assert.Equal(t, models.CanvasNodeExecutionResultFailed, updatedExecution.Result)
+
+	outputs, err := updatedExecution.GetOutputs()
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	assert.Equal(t, core.ErrorOutputChannel.Name, outputs[0].Channel)
+
+	event, ok := outputs[0].Data.Data().(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, core.ErrorPayloadType, event["type"])
+
+	data, ok := event["data"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "if", data["component"])
+	assert.Equal(t, ifNode, data["nodeId"])
+	assert.Equal(t, float64(1), data["attempt"])
+	assert.Contains(t, data["error"], "expression must evaluate to boolean")
+}
+
 func Test__NodeExecutor_BlueprintNodeExecutionFailsWhenConfigurationCannotBeBuilt(t *testing.T) {
 	r := support.Setup(t)
 
@@ -399,3 +476,58 @@ func countConcurrentExecutionResults(t *testing.T, results []error) (successCoun
 	}
 	return successCount, lockedCount
 }
+
+func Test__NodeExecutor_CanvasAtExecutionQuota(t *testing.T) {
+	r := support.Setup(t)
+
+	triggerNode := "trigger-1"
+	componentNode := "component-1"
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{
+				NodeID: triggerNode,
+				Type:   models.NodeTypeTrigger,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+			},
+			{
+				NodeID: componentNode,
+				Type:   models.NodeTypeComponent,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "noop"}}),
+			},
+		},
+		[]models.Edge{
+			{SourceID: triggerNode, TargetID: componentNode, Channel: "default"},
+		},
+	)
+
+	rootEvent := support.EmitCanvasEventForNode(t, canvas.ID, triggerNode, "default", nil)
+	execution := support.CreateCanvasNodeExecution(t, canvas.ID, componentNode, rootEvent.ID, rootEvent.ID, nil)
+
+	//
+	// With the canvas quota taken, the execution stays pending.
+	//
+	quotas := NewExecutionQuotas(1, 0)
+	require.True(t, quotas.AcquireCanvas(canvas.ID))
+
+	executor := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost", "").WithExecutionQuotas(quotas)
+	err := executor.LockAndProcessNodeExecution(execution.ID)
+	require.ErrorIs(t, err, ErrExecutionQuotaExceeded)
+
+	pending, err := models.FindNodeExecution(canvas.ID, execution.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CanvasNodeExecutionStatePending, pending.State)
+
+	//
+	// Once the quota is released, the execution runs.
+	//
+	quotas.ReleaseCanvas(canvas.ID)
+	require.NoError(t, executor.LockAndProcessNodeExecution(execution.ID))
+
+	finished, err := models.FindNodeExecution(canvas.ID, execution.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CanvasNodeExecutionStateFinished, finished.State)
+	assert.True(t, quotas.CanvasAvailable(canvas.ID))
+}
@@ -212,7 +212,7 @@ func (w *NodeQueueWorker) LockAndProcessNode(logger *log.Entry, node models.Canv
 }
 
 func (w *NodeQueueWorker) processNode(tx *gorm.DB, logger *log.Entry, node *models.CanvasNode, onNewEvents func([]models.CanvasEvent)) ([]*uuid.UUID, *models.CanvasNodeQueueItem, error) {
-	queueItem, err := node.FirstQueueItem(tx)
+	queueItem, err := w.nextQueueItem(tx, logger, node)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil, nil
@@ -221,6 +221,10 @@ func (w *NodeQueueWorker) processNode(tx *gorm.DB, logger *log.Entry, node *mode
 		return nil, nil, err
 	}
 
+	if queueItem == nil {
+		return nil, nil, nil
+	}
+
 	logger = logging.WithQueueItem(logger, *queueItem)
 	logger.Info("Processing queue item")
 
@@ -279,6 +283,56 @@ func (w *NodeQueueWorker) processNode(tx *gorm.DB, logger *log.Entry, node *mode
 	return []*uuid.UUID{executionID}, queueItem, err
 }
 
+/*
+ * Picks the queue item to process next, according to the queue mode of the node.
+ * Returns nil if no queue item should be processed yet.
+ */
+func (w *NodeQueueWorker) nextQueueItem(tx *gorm.DB, logger *log.Entry, node *models.CanvasNode) (*models.CanvasNodeQueueItem, error) {
+	settings := core.QueueSettingsFromConfiguration(node.Configuration.Data())
+
+	switch settings.Mode {
+	case core.QueueModeDropOldest:
+		return w.latestQueueItem(tx, logger, node)
+
+	case core.QueueModeDebounce:
+		latest, err := node.LastQueueItem(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		//
+		// Events are still arriving, wait for the window to pass.
+		// The worker checks the node again on its next tick.
+		//
+		if latest.CreatedAt != nil && time.Since(*latest.CreatedAt) < settings.DebounceWindow {
+			return nil, nil
+		}
+
+		return w.latestQueueItem(tx, logger, node)
+
+	default:
+		return node.FirstQueueItem(tx)
+	}
+}
+
+func (w *NodeQueueWorker) latestQueueItem(tx *gorm.DB, logger *log.Entry, node *models.CanvasNode) (*models.CanvasNodeQueueItem, error) {
+	latest, err := node.LastQueueItem(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	dropped, err := node.DeleteOtherQueueItems(tx, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	if dropped > 0 {
+		logger.Infof("Dropped %d queue items older than %s", dropped, latest.ID)
+	}
+
+	return latest, nil
+}
+
 func (w *NodeQueueWorker) configurationFieldsForNode(tx *gorm.DB, node *models.CanvasNode) ([]configuration.Field, error) {
 	ref := node.Ref.Data()
 	switch node.Type {
@@ -811,3 +811,135 @@ func Test__WorkflowNodeQueueWorker_ConfigurationBuildFailure_PropagateToParent(t
 	assert.Equal(t, models.CanvasNodeExecutionResultFailed, updatedParent.Result)
 	assert.Equal(t, models.CanvasNodeExecutionResultReasonError, updatedParent.ResultReason)
 }
+
+func Test__NodeQueueWorker_QueueModes(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	amqpURL, _ := config.RabbitMQURL()
+	worker := NewNodeQueueWorker(r.Registry, amqpURL)
+	logger := log.NewEntry(log.New())
+
+	triggerNode := "trigger-1"
+	componentNode := "component-1"
+
+	setup := func(configuration map[string]any) *models.Canvas {
+		canvas, _ := support.CreateCanvas(
+			t,
+			r.Organization.ID,
+			r.User,
+			[]models.CanvasNode{
+				{NodeID: triggerNode, Type: models.NodeTypeTrigger},
+				{
+					NodeID:        componentNode,
+					Type:          models.NodeTypeComponent,
+					Ref:           datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "noop"}}),
+					Configuration: datatypes.NewJSONType(configuration),
+				},
+			},
+			[]models.Edge{
+				{SourceID: triggerNode, TargetID: componentNode, Channel: "default"},
+			},
+		)
+
+		return canvas
+	}
+
+	enqueue := func(canvas *models.Canvas, createdAt time.Time) *models.CanvasEvent {
+		event := support.EmitCanvasEventForNode(t, canvas.ID, triggerNode, "default", nil)
+		require.NoError(t, database.Conn().Create(&models.CanvasNodeQueueItem{
+			ID:          uuid.New(),
+			WorkflowID:  canvas.ID,
+			NodeID:      componentNode,
+			RootEventID: event.ID,
+			EventID:     event.ID,
+			CreatedAt:   &createdAt,
+		}).Error)
+
+		return event
+	}
+
+	process := func(canvas *models.Canvas) *models.CanvasNode {
+		node, err := models.FindCanvasNode(database.Conn(), canvas.ID, componentNode)
+		require.NoError(t, err)
+		require.NoError(t, worker.LockAndProcessNode(logger, *node))
+
+		node, err = models.FindCanvasNode(database.Conn(), canvas.ID, componentNode)
+		require.NoError(t, err)
+		return node
+	}
+
+	t.Run("parallel -> node keeps processing its queue until max in flight is reached", func(t *testing.T) {
+		canvas := setup(map[string]any{"queueMode": "parallel", "maxInFlight": 2})
+		for i := 0; i < 3; i++ {
+			enqueue(canvas, time.Now().Add(time.Duration(i-10)*time.Minute))
+		}
+
+		node := process(canvas)
+		assert.Equal(t, models.CanvasNodeStateReady, node.State)
+
+		node = process(canvas)
+		assert.Equal(t, models.CanvasNodeStateProcessing, node.State)
+
+		executions, err := models.ListNodeExecutions(canvas.ID, componentNode, nil, nil, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, executions, 2)
+
+		queueItems, err := models.ListNodeQueueItems(canvas.ID, componentNode, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, queueItems, 1)
+	})
+
+	t.Run("drop oldest -> only the latest queue item is processed", func(t *testing.T) {
+		canvas := setup(map[string]any{"queueMode": "dropOldest"})
+		enqueue(canvas, time.Now().Add(-10*time.Minute))
+		enqueue(canvas, time.Now().Add(-5*time.Minute))
+		latest := enqueue(canvas, time.Now().Add(-time.Minute))
+
+		node := process(canvas)
+		assert.Equal(t, models.CanvasNodeStateProcessing, node.State)
+
+		executions, err := models.ListNodeExecutions(canvas.ID, componentNode, nil, nil, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, executions, 1)
+		assert.Equal(t, latest.ID, executions[0].EventID)
+
+		queueItems, err := models.ListNodeQueueItems(canvas.ID, componentNode, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, queueItems)
+	})
+
+	t.Run("debounce -> waits until no new events arrive for the window", func(t *testing.T) {
+		canvas := setup(map[string]any{"queueMode": "debounce", "debounceWindow": "1m"})
+		enqueue(canvas, time.Now().Add(-10*time.Minute))
+		latest := enqueue(canvas, time.Now())
+
+		node := process(canvas)
+		assert.Equal(t, models.CanvasNodeStateReady, node.State)
+
+		executions, err := models.ListNodeExecutions(canvas.ID, componentNode, nil, nil, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, executions)
+
+		//
+		// Once the window passes, only the latest event is processed.
+		//
+		require.NoError(t, database.Conn().
+			Model(&models.CanvasNodeQueueItem{}).
+			Where("event_id = ?", latest.ID).
+			Update("created_at", time.Now().Add(-2*time.Minute)).
+			Error)
+
+		node = process(canvas)
+		assert.Equal(t, models.CanvasNodeStateProcessing, node.State)
+
+		executions, err = models.ListNodeExecutions(canvas.ID, componentNode, nil, nil, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, executions, 1)
+		assert.Equal(t, latest.ID, executions[0].EventID)
+
+		queueItems, err := models.ListNodeQueueItems(canvas.ID, componentNode, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, queueItems)
+	})
+}
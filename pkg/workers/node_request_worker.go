@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand/v2"
 	"strings"
 	"time"
 
@@ -23,6 +24,11 @@ import (
 	"github.com/superplanehq/superplane/pkg/workers/contexts"
 )
 
+const (
+	NodeRequestMinRetryInterval = 5 * time.Second
+	NodeRequestMaxRetryInterval = 5 * time.Minute
+)
+
 type NodeRequestWorker struct {
 	semaphore      *semaphore.Weighted
 	registry       *registry.Registry
@@ -97,7 +103,16 @@ func (w *NodeRequestWorker) LockAndProcessRequest(request models.CanvasNodeReque
 		return w.processRequest(tx, r, onNewEvents)
 	})
 
+	//
+	// The request stays pending when processing it fails,
+	// so it is retried with a backoff, and the error is kept on it.
+	//
 	if err != nil {
+		retryAt := time.Now().Add(nodeRequestRetryInterval(request.Attempts + 1))
+		if recordErr := models.RecordNodeRequestFailure(request.ID, err, retryAt); recordErr != nil {
+			w.log("Error recording failure of request %s: %v", request.ID, recordErr)
+		}
+
 		return err
 	}
 
@@ -112,6 +127,8 @@ func (w *NodeRequestWorker) processRequest(tx *gorm.DB, request *models.CanvasNo
 	switch request.Type {
 	case models.NodeRequestTypeInvokeAction:
 		return w.invokeAction(tx, request, onNewEvents)
+	case models.NodeRequestTypeExecutionTimeout:
+		return w.timeoutExecution(tx, request, onNewEvents)
 	}
 
 	return fmt.Errorf("unsupported node execution request type %s", request.Type)
@@ -173,6 +190,7 @@ func (w *NodeRequestWorker) invokeTriggerAction(tx *gorm.DB, request *models.Can
 		Metadata:      contexts.NewNodeMetadataContext(tx, node),
 		Events:        contexts.NewEventContext(tx, node, onNewEvents),
 		Requests:      contexts.NewNodeRequestContext(tx, node),
+		WebhookSecret: contexts.NewNodeWebhookSecretContext(context.Background(), tx, w.encryptor, node),
 	}
 
 	if node.WebhookID != nil {
@@ -191,6 +209,7 @@ func (w *NodeRequestWorker) invokeTriggerAction(tx *gorm.DB, request *models.Can
 		}
 
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry, onNewEvents)
+		actionCtx.HTTP = w.registry.HTTPContext().ForIntegration(instance.ID.String())
 	}
 
 	_, err = trigger.HandleAction(actionCtx)
@@ -252,6 +271,7 @@ func (w *NodeRequestWorker) invokeNodeComponentAction(tx *gorm.DB, request *mode
 
 		logger = logging.WithIntegration(logger, *instance)
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry, onNewEvents)
+		actionCtx.HTTP = w.registry.HTTPContext().ForIntegration(instance.ID.String())
 		actionCtx.Logger = logger
 	}
 
@@ -314,6 +334,7 @@ func (w *NodeRequestWorker) invokeParentNodeComponentAction(
 	}
 
 	logger := logging.ForExecution(execution, nil)
+	logs := contexts.NewExecutionLogsContext(execution)
 	actionCtx := core.ActionContext{
 		Name:           actionName,
 		Configuration:  node.Configuration.Data(),
@@ -324,7 +345,9 @@ func (w *NodeRequestWorker) invokeParentNodeComponentAction(
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 		Notifications:  contexts.NewNotificationContext(tx, uuid.Nil, node.WorkflowID),
 		Auth:           contexts.NewAuthContext(tx, workflow.OrganizationID, nil, nil),
-		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor),
+		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor).ForExecution(execution),
+		CanvasRuns:     contexts.NewCanvasRunsContext(tx, workflow.OrganizationID, execution.WorkflowID, execution.RootEventID, onNewEvents),
+		Logs:           logs,
 	}
 
 	if node.AppInstallationID != nil {
@@ -335,9 +358,15 @@ func (w *NodeRequestWorker) invokeParentNodeComponentAction(
 
 		logger = logging.WithIntegration(logger, *instance)
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry, onNewEvents)
+		actionCtx.HTTP = w.registry.HTTPContext().ForIntegration(instance.ID.String())
 	}
 
+	goCtx, cancel := actionContext(tx, execution)
+	defer cancel()
+
+	actionCtx.Context = goCtx
 	actionCtx.Logger = logger
+	defer flushExecutionLogs(logs, logger)
 	err = component.HandleAction(actionCtx)
 	if err != nil {
 		return fmt.Errorf("action execution failed: %w", err)
@@ -399,6 +428,7 @@ func (w *NodeRequestWorker) invokeChildNodeComponentAction(
 		return fmt.Errorf("workflow not found: %w", err)
 	}
 
+	logs := contexts.NewExecutionLogsContext(execution)
 	actionCtx := core.ActionContext{
 		Name:           actionName,
 		Configuration:  execution.Configuration.Data(),
@@ -410,9 +440,16 @@ func (w *NodeRequestWorker) invokeChildNodeComponentAction(
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 		Notifications:  contexts.NewNotificationContext(tx, uuid.Nil, execution.WorkflowID),
 		Auth:           contexts.NewAuthContext(tx, workflow.OrganizationID, nil, nil),
-		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor),
+		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor).ForExecution(execution),
+		CanvasRuns:     contexts.NewCanvasRunsContext(tx, workflow.OrganizationID, execution.WorkflowID, execution.RootEventID, onNewEvents),
+		Logs:           logs,
 	}
 
+	goCtx, cancel := actionContext(tx, execution)
+	defer cancel()
+
+	actionCtx.Context = goCtx
+	defer flushExecutionLogs(logs, actionCtx.Logger)
 	err = component.HandleAction(actionCtx)
 	if err != nil {
 		return fmt.Errorf("action execution failed: %w", err)
@@ -426,6 +463,166 @@ func (w *NodeRequestWorker) invokeChildNodeComponentAction(
 	return request.Complete(tx)
 }
 
+func (w *NodeRequestWorker) timeoutExecution(tx *gorm.DB, request *models.CanvasNodeRequest, onNewEvents func([]models.CanvasEvent)) error {
+	if request.ExecutionID == nil {
+		return fmt.Errorf("execution is not specified")
+	}
+
+	execution, err := models.FindNodeExecutionInTransaction(tx, request.WorkflowID, *request.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("execution %s not found: %w", request.ExecutionID, err)
+	}
+
+	//
+	// Execution finished before its deadline, nothing to do.
+	//
+	if execution.State == models.CanvasNodeExecutionStateFinished {
+		return request.Complete(tx)
+	}
+
+	component, integrationID, err := w.findExecutionComponent(tx, execution)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := models.FindCanvasWithoutOrgScopeInTransaction(tx, execution.WorkflowID)
+	if err != nil {
+		return fmt.Errorf("workflow not found: %w", err)
+	}
+
+	logger := logging.ForExecution(execution, nil)
+	logs := contexts.NewExecutionLogsContext(execution)
+	ctx := core.ExecutionContext{
+		ID:             execution.ID,
+		WorkflowID:     execution.WorkflowID.String(),
+		Configuration:  execution.Configuration.Data(),
+		HTTP:           w.registry.HTTPContext(),
+		Metadata:       contexts.NewExecutionMetadataContext(tx, execution),
+		ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents),
+		Requests:       contexts.NewExecutionRequestContext(tx, execution),
+		Auth:           contexts.NewAuthContext(tx, workflow.OrganizationID, nil, nil),
+		Notifications:  contexts.NewNotificationContext(tx, workflow.OrganizationID, execution.WorkflowID),
+		CanvasMemory:   contexts.NewCanvasMemoryContext(tx, execution.WorkflowID),
+		Secrets:        contexts.NewSecretsContext(tx, workflow.OrganizationID, w.encryptor).ForExecution(execution),
+		Logs:           logs,
+		Costs:          contexts.NewExecutionCostsContext(execution),
+		TestMode:       workflow.TestMode,
+	}
+
+	if integrationID != nil {
+		integration, err := models.FindUnscopedIntegrationInTransaction(tx, *integrationID)
+		if err != nil {
+			return fmt.Errorf("failed to find integration: %v", err)
+		}
+
+		node, err := models.FindCanvasNode(tx, execution.WorkflowID, execution.NodeID)
+		if err != nil {
+			return fmt.Errorf("node not found: %w", err)
+		}
+
+		logger = logging.WithIntegration(logger, *integration)
+		ctx.Integration = contexts.NewIntegrationContext(tx, node, integration, w.encryptor, w.registry, onNewEvents)
+		ctx.HTTP = w.registry.HTTPContext().ForIntegration(integration.ID.String())
+	}
+
+	//
+	// Cancel() gives the component a chance to stop
+	// any external work before the execution is failed.
+	//
+	ctx.Logger = logger
+	defer flushExecutionLogs(logs, logger)
+	if err := component.Cancel(ctx); err != nil {
+		logger.Errorf("failed to cancel timed out execution: %v", err)
+	}
+
+	timeout := core.ExecutionTimeoutFor(component, ctx.Configuration)
+	elapsed := time.Since(request.RunAt.Add(-timeout))
+	err = timeOutExecution(tx, execution, timeout, elapsed, onNewEvents)
+	if err != nil {
+		return fmt.Errorf("failed to fail timed out execution: %w", err)
+	}
+
+	return request.Complete(tx)
+}
+
+/*
+ * findExecutionComponent returns the component for the execution,
+ * and the integration used by its node, if any.
+ */
+func (w *NodeRequestWorker) findExecutionComponent(tx *gorm.DB, execution *models.CanvasNodeExecution) (core.Component, *uuid.UUID, error) {
+	if execution.ParentExecutionID == nil {
+		node, err := models.FindCanvasNode(tx, execution.WorkflowID, execution.NodeID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("node not found: %w", err)
+		}
+
+		ref := node.Ref.Data()
+		if ref.Component == nil {
+			return nil, nil, fmt.Errorf("node %s is not a component", node.NodeID)
+		}
+
+		component, err := w.registry.GetComponent(ref.Component.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("component not found: %w", err)
+		}
+
+		return component, node.AppInstallationID, nil
+	}
+
+	parentExecution, err := models.FindNodeExecutionInTransaction(tx, execution.WorkflowID, *execution.ParentExecutionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parent execution %s not found: %w", execution.ParentExecutionID, err)
+	}
+
+	parentNode, err := models.FindCanvasNode(tx, execution.WorkflowID, parentExecution.NodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("node not found: %w", err)
+	}
+
+	blueprint, err := models.FindUnscopedBlueprintInTransaction(tx, parentNode.Ref.Data().Blueprint.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("blueprint not found: %w", err)
+	}
+
+	childNode, err := blueprint.FindNode(strings.Split(execution.NodeID, ":")[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("node not found: %w", err)
+	}
+
+	component, err := w.registry.GetComponent(childNode.Ref.Component.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("component not found: %w", err)
+	}
+
+	return component, nil, nil
+}
+
+/*
+ * actionContext returns the context for an action handler,
+ * carrying the execution deadline, if the node has one.
+ */
+func actionContext(tx *gorm.DB, execution *models.CanvasNodeExecution) (context.Context, context.CancelFunc) {
+	request, err := models.FindPendingExecutionRequest(tx, execution.ID, models.NodeRequestTypeExecutionTimeout)
+	if err != nil {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithDeadline(context.Background(), request.RunAt)
+}
+
+/*
+ * Exponential backoff with jitter, so requests failing
+ * for the same reason are not all retried at the same time.
+ */
+func nodeRequestRetryInterval(attempt int) time.Duration {
+	interval := NodeRequestMaxRetryInterval
+	if attempt < 7 {
+		interval = min(NodeRequestMinRetryInterval<<(attempt-1), NodeRequestMaxRetryInterval)
+	}
+
+	return interval/2 + rand.N(interval/2)
+}
+
 func (w *NodeRequestWorker) log(format string, v ...any) {
 	log.Printf("[NodeRequestWorker] "+format, v...)
 }
@@ -2,11 +2,13 @@ package workers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/config"
+	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/grpc/actions/messages"
 	"github.com/superplanehq/superplane/pkg/models"
@@ -607,3 +609,167 @@ func Test__NodeRequestWorker_DoesNotProcessDeletedWorkflowRequests(t *testing.T)
 
 	assert.False(t, executionConsumer.HasReceivedMessage())
 }
+
+func Test__NodeRequestWorker_ExecutionTimeout(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+	worker := NewNodeRequestWorker(r.Encryptor, r.Registry, "")
+
+	triggerNode := "trigger-1"
+	componentNode := "component-1"
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{
+				NodeID: triggerNode,
+				Type:   models.NodeTypeTrigger,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+			},
+			{
+				NodeID: componentNode,
+				Type:   models.NodeTypeComponent,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "noop"}}),
+			},
+		},
+		[]models.Edge{
+			{SourceID: triggerNode, TargetID: componentNode, Channel: "default"},
+		},
+	)
+
+	t.Run("running execution is failed as timed out", func(t *testing.T) {
+		rootEvent := support.EmitCanvasEventForNode(t, canvas.ID, triggerNode, "default", nil)
+		execution := support.CreateCanvasNodeExecution(t, canvas.ID, componentNode, rootEvent.ID, rootEvent.ID, nil)
+		require.NoError(t, database.Conn().Model(execution).Updates(map[string]any{
+			"state":         models.CanvasNodeExecutionStateStarted,
+			"configuration": datatypes.NewJSONType(map[string]any{core.ExecutionTimeoutField: 30, core.RouteErrorsField: true}),
+		}).Error)
+
+		deadline := time.Now().Add(-time.Second)
+		require.NoError(t, execution.CreateRequest(database.Conn(), models.NodeRequestTypeExecutionTimeout, models.NodeExecutionRequestSpec{}, &deadline))
+		request, err := models.FindPendingExecutionRequest(database.Conn(), execution.ID, models.NodeRequestTypeExecutionTimeout)
+		require.NoError(t, err)
+
+		require.NoError(t, worker.LockAndProcessRequest(*request))
+
+		updatedExecution, err := models.FindNodeExecution(canvas.ID, execution.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.CanvasNodeExecutionStateFinished, updatedExecution.State)
+		assert.Equal(t, models.CanvasNodeExecutionResultFailed, updatedExecution.Result)
+		assert.Equal(t, models.CanvasNodeExecutionResultReasonTimeout, updatedExecution.ResultReason)
+		assert.Equal(t, "execution timed out after 30s", updatedExecution.ResultMessage)
+
+		//
+		// The timeout is emitted on the error channel, with the timeout details.
+		//
+		outputs, err := updatedExecution.GetOutputs()
+		require.NoError(t, err)
+		require.Len(t, outputs, 1)
+		assert.Equal(t, core.ErrorOutputChannel.Name, outputs[0].Channel)
+
+		event := outputs[0].Data.Data().(map[string]any)
+		assert.Equal(t, core.TimeoutPayloadType, event["type"])
+		data := event["data"].(map[string]any)
+		assert.Equal(t, componentNode, data["nodeId"])
+		assert.Equal(t, float64(30), data["timeout"])
+		assert.GreaterOrEqual(t, data["elapsed"], float64(30))
+
+		_, err = models.FindPendingExecutionRequest(database.Conn(), execution.ID, models.NodeRequestTypeExecutionTimeout)
+		assert.Error(t, err)
+	})
+
+	t.Run("finished execution is left untouched", func(t *testing.T) {
+		rootEvent := support.EmitCanvasEventForNode(t, canvas.ID, triggerNode, "default", nil)
+		execution := support.CreateCanvasNodeExecution(t, canvas.ID, componentNode, rootEvent.ID, rootEvent.ID, nil)
+		require.NoError(t, database.Conn().Model(execution).Updates(map[string]any{
+			"state":  models.CanvasNodeExecutionStateFinished,
+			"result": models.CanvasNodeExecutionResultPassed,
+		}).Error)
+
+		deadline := time.Now().Add(-time.Second)
+		require.NoError(t, execution.CreateRequest(database.Conn(), models.NodeRequestTypeExecutionTimeout, models.NodeExecutionRequestSpec{}, &deadline))
+		request, err := models.FindPendingExecutionRequest(database.Conn(), execution.ID, models.NodeRequestTypeExecutionTimeout)
+		require.NoError(t, err)
+
+		require.NoError(t, worker.LockAndProcessRequest(*request))
+
+		updatedExecution, err := models.FindNodeExecution(canvas.ID, execution.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.CanvasNodeExecutionResultPassed, updatedExecution.Result)
+
+		_, err = models.FindPendingExecutionRequest(database.Conn(), execution.ID, models.NodeRequestTypeExecutionTimeout)
+		assert.Error(t, err)
+	})
+}
+
+func Test__NodeRequestWorker_FailedRequestIsRetriedLater(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+	worker := NewNodeRequestWorker(r.Encryptor, r.Registry, "")
+
+	triggerNode := "trigger-1"
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{
+				NodeID: triggerNode,
+				Type:   models.NodeTypeTrigger,
+				Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "schedule"}}),
+				Configuration: datatypes.NewJSONType(map[string]interface{}{
+					"type":         "days",
+					"daysInterval": 1,
+					"hour":         12,
+					"minute":       0,
+				}),
+			},
+		},
+		[]models.Edge{},
+	)
+
+	request := models.CanvasNodeRequest{
+		ID:         uuid.New(),
+		WorkflowID: canvas.ID,
+		NodeID:     triggerNode,
+		Type:       models.NodeRequestTypeInvokeAction,
+		Spec: datatypes.NewJSONType(models.NodeExecutionRequestSpec{
+			InvokeAction: &models.InvokeAction{ActionName: "does-not-exist"},
+		}),
+		State:     models.NodeExecutionRequestStatePending,
+		RunAt:     time.Now().Add(-time.Second),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, database.Conn().Create(&request).Error)
+
+	err := worker.LockAndProcessRequest(request)
+	require.Error(t, err)
+
+	//
+	// The request stays pending, with the error recorded,
+	// and it is not picked up again right away.
+	//
+	var updated models.CanvasNodeRequest
+	require.NoError(t, database.Conn().Where("id = ?", request.ID).First(&updated).Error)
+	assert.Equal(t, models.NodeExecutionRequestStatePending, updated.State)
+	assert.Equal(t, 1, updated.Attempts)
+	require.NotNil(t, updated.LastError)
+	assert.Contains(t, *updated.LastError, "action 'does-not-exist' not found")
+	assert.True(t, updated.RunAt.After(time.Now()))
+
+	requests, err := models.ListNodeRequests()
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+}
+
+func Test__NodeRequestRetryInterval(t *testing.T) {
+	for attempt := 1; attempt <= 20; attempt++ {
+		interval := nodeRequestRetryInterval(attempt)
+		assert.GreaterOrEqual(t, interval, NodeRequestMinRetryInterval/2)
+		assert.LessOrEqual(t, interval, NodeRequestMaxRetryInterval)
+	}
+
+	assert.Less(t, nodeRequestRetryInterval(1), NodeRequestMinRetryInterval+time.Nanosecond)
+}
@@ -40,6 +40,7 @@ START_CONSUMERS="${START_CONSUMERS:-yes}"
 START_WEB_SERVER="${START_WEB_SERVER:-yes}"
 JWT_SECRET="${JWT_SECRET:-}"
 OIDC_KEYS_PATH="${OIDC_KEYS_PATH:-/app/data/oidc-keys}"
+BLOB_STORAGE_PATH="${BLOB_STORAGE_PATH:-/app/data/blobs}"
 START_EVENT_DISTRIBUTER="${START_EVENT_DISTRIBUTER:-yes}"
 START_EVENT_ROUTER="${START_EVENT_ROUTER:-yes}"
 START_NODE_EXECUTOR="${START_NODE_EXECUTOR:-yes}"
@@ -49,6 +50,7 @@ START_INTEGRATION_REQUEST_WORKER="${START_INTEGRATION_REQUEST_WORKER:-yes}"
 START_WEBHOOK_PROVISIONER="${START_WEBHOOK_PROVISIONER:-yes}"
 START_WEBHOOK_CLEANUP_WORKER="${START_WEBHOOK_CLEANUP_WORKER:-yes}"
 START_CANVAS_CLEANUP_WORKER="${START_CANVAS_CLEANUP_WORKER:-yes}"
+START_CANVAS_BACKFILL_WORKER="${START_CANVAS_BACKFILL_WORKER:-yes}"
 NO_ENCRYPTION="${NO_ENCRYPTION:-yes}"
 SUPERPLANE_BEACON_ENABLED="${SUPERPLANE_BEACON_ENABLED:-yes}"
 SUPERPLANE_INSTALLATION_TYPE="${SUPERPLANE_INSTALLATION_TYPE:-demo}"
@@ -138,9 +140,11 @@ export START_INTEGRATION_REQUEST_WORKER="${START_INTEGRATION_REQUEST_WORKER}"
 export START_WEBHOOK_PROVISIONER="${START_WEBHOOK_PROVISIONER}"
 export START_WEBHOOK_CLEANUP_WORKER="${START_WEBHOOK_CLEANUP_WORKER}"
 export START_CANVAS_CLEANUP_WORKER="${START_CANVAS_CLEANUP_WORKER}"
+export START_CANVAS_BACKFILL_WORKER="${START_CANVAS_BACKFILL_WORKER}"
 export ENCRYPTION_KEY="${ENCRYPTION_KEY}"
 export JWT_SECRET="${JWT_SECRET}"
 export OIDC_KEYS_PATH="${OIDC_KEYS_PATH}"
+export BLOB_STORAGE_PATH="${BLOB_STORAGE_PATH}"
 export SESSION_SECRET="${SESSION_SECRET}"
 export NO_ENCRYPTION="${NO_ENCRYPTION}"
 export SUPERPLANE_BEACON_ENABLED="${SUPERPLANE_BEACON_ENABLED}"
@@ -106,6 +106,7 @@ TEMPLATE_DIR=/app/templates
 ENCRYPTION_KEY=${ENCRYPTION_KEY}
 JWT_SECRET=${JWT_SECRET}
 OIDC_KEYS_PATH=${OIDC_KEYS_CONTAINER_PATH}
+BLOB_STORAGE_PATH=/app/blobs
 SESSION_SECRET=${SESSION_SECRET}
 NO_ENCRYPTION=no
 
@@ -127,6 +128,7 @@ START_WEBHOOK_PROVISIONER=yes
 START_WEBHOOK_CLEANUP_WORKER=yes
 START_INTEGRATION_CLEANUP_WORKER=yes
 START_CANVAS_CLEANUP_WORKER=yes
+START_CANVAS_BACKFILL_WORKER=yes
 
 SENTRY_DSN=
 SENTRY_ENVIRONMENT=single-host
@@ -0,0 +1,93 @@
+// Command timezones generates the list of IANA time zones offered by integrations
+// that ask for a time zone name. The list is read from the time zone database
+// shipped with the Go toolchain, so it matches what time.LoadLocation accepts.
+//
+// Usage: go run ./scripts/timezones
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+const outputPath = "pkg/integrations/statuspage/timezones_generated.go"
+
+// Only canonical region-based names are listed.
+// Legacy aliases (e.g. US/Eastern, EST5EDT) are still accepted by time.LoadLocation.
+var regions = []string{
+	"Africa/",
+	"America/",
+	"Antarctica/",
+	"Arctic/",
+	"Asia/",
+	"Atlantic/",
+	"Australia/",
+	"Europe/",
+	"Indian/",
+	"Pacific/",
+}
+
+func main() {
+	zones, err := readZones(filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"))
+	if err != nil {
+		exitWithError(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by scripts/timezones; DO NOT EDIT.\n\n")
+	buf.WriteString("package statuspage\n\n")
+	buf.WriteString("// ianaTimezones lists the IANA time zone names offered for scheduled maintenances.\n")
+	buf.WriteString("var ianaTimezones = []string{\n")
+	for _, zone := range zones {
+		fmt.Fprintf(&buf, "\t%q,\n", zone)
+	}
+	buf.WriteString("}\n")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := os.WriteFile(outputPath, source, 0644); err != nil {
+		exitWithError(err)
+	}
+}
+
+func readZones(path string) ([]string, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open time zone database: %w", err)
+	}
+	defer reader.Close()
+
+	zones := []string{"UTC"}
+	for _, file := range reader.File {
+		if isRegionZone(file.Name) {
+			zones = append(zones, file.Name)
+		}
+	}
+
+	sort.Strings(zones[1:])
+	return zones, nil
+}
+
+func isRegionZone(name string) bool {
+	for _, region := range regions {
+		if strings.HasPrefix(name, region) {
+			return true
+		}
+	}
+	return false
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
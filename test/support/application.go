@@ -10,31 +10,34 @@ import (
 //
 
 type DummyIntegration struct {
-	components   []core.Component
-	triggers     []core.Trigger
-	actions      []core.Action
-	handleAction func(ctx core.IntegrationActionContext) error
-	onSync       func(ctx core.SyncContext) error
-	onCleanup    func(ctx core.IntegrationCleanupContext) error
+	components    []core.Component
+	triggers      []core.Trigger
+	actions       []core.Action
+	handleAction  func(ctx core.IntegrationActionContext) error
+	onSync        func(ctx core.SyncContext) error
+	onCleanup     func(ctx core.IntegrationCleanupContext) error
+	onHealthCheck func(ctx core.HealthCheckContext) error
 }
 
 type DummyIntegrationOptions struct {
-	Components   []core.Component
-	Triggers     []core.Trigger
-	Actions      []core.Action
-	HandleAction func(ctx core.IntegrationActionContext) error
-	OnSync       func(ctx core.SyncContext) error
-	OnCleanup    func(ctx core.IntegrationCleanupContext) error
+	Components    []core.Component
+	Triggers      []core.Trigger
+	Actions       []core.Action
+	HandleAction  func(ctx core.IntegrationActionContext) error
+	OnSync        func(ctx core.SyncContext) error
+	OnCleanup     func(ctx core.IntegrationCleanupContext) error
+	OnHealthCheck func(ctx core.HealthCheckContext) error
 }
 
 func NewDummyIntegration(options DummyIntegrationOptions) *DummyIntegration {
 	return &DummyIntegration{
-		components:   options.Components,
-		triggers:     options.Triggers,
-		actions:      options.Actions,
-		handleAction: options.HandleAction,
-		onSync:       options.OnSync,
-		onCleanup:    options.OnCleanup,
+		components:    options.Components,
+		triggers:      options.Triggers,
+		actions:       options.Actions,
+		handleAction:  options.HandleAction,
+		onSync:        options.OnSync,
+		onCleanup:     options.OnCleanup,
+		onHealthCheck: options.OnHealthCheck,
 	}
 }
 
@@ -95,6 +98,13 @@ func (t *DummyIntegration) Cleanup(ctx core.IntegrationCleanupContext) error {
 	return t.onCleanup(ctx)
 }
 
+func (t *DummyIntegration) HealthCheck(ctx core.HealthCheckContext) error {
+	if t.onHealthCheck == nil {
+		return nil
+	}
+	return t.onHealthCheck(ctx)
+}
+
 func (t *DummyIntegration) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
 	return []core.IntegrationResource{}, nil
 }
@@ -10,7 +10,8 @@ import (
 )
 
 type EventContext struct {
-	Payloads []Payload
+	Payloads   []Payload
+	identities map[string]bool
 }
 
 type Payload struct {
@@ -23,6 +24,19 @@ func (e *EventContext) Emit(payloadType string, payload any) error {
 	return nil
 }
 
+func (e *EventContext) EmitIdentified(identity string, payloadType string, payload any) (bool, error) {
+	if e.identities == nil {
+		e.identities = map[string]bool{}
+	}
+
+	if e.identities[identity] {
+		return false, nil
+	}
+
+	e.identities[identity] = true
+	return true, e.Emit(payloadType, payload)
+}
+
 func (e *EventContext) Count() int {
 	return len(e.Payloads)
 }
@@ -53,6 +67,30 @@ func (w *NodeWebhookContext) GetBaseURL() string {
 	return "http://localhost:3000/api/v1"
 }
 
+type NodeWebhookSecretContext struct {
+	Secret         string
+	PreviousSecret string
+}
+
+func (w *NodeWebhookSecretContext) GetSecret() ([]byte, error) {
+	return []byte(w.Secret), nil
+}
+
+func (w *NodeWebhookSecretContext) AcceptedSecrets() ([][]byte, error) {
+	secrets := [][]byte{[]byte(w.Secret)}
+	if w.PreviousSecret != "" {
+		secrets = append(secrets, []byte(w.PreviousSecret))
+	}
+
+	return secrets, nil
+}
+
+func (w *NodeWebhookSecretContext) RotateSecret(gracePeriod time.Duration) ([]byte, error) {
+	w.PreviousSecret = w.Secret
+	w.Secret = uuid.NewString()
+	return []byte(w.Secret), nil
+}
+
 type WebhookContext struct {
 	ID            string
 	URL           string
@@ -233,6 +271,7 @@ type ExecutionStateContext struct {
 	Channel        string
 	Type           string
 	Payloads       []any
+	Outputs        map[string][]any
 	KVs            map[string]string
 }
 
@@ -265,6 +304,29 @@ func (c *ExecutionStateContext) Emit(channel, payloadType string, payloads []any
 	return nil
 }
 
+func (c *ExecutionStateContext) EmitOutputs(outputs []core.ChannelOutput) error {
+	c.Outputs = map[string][]any{}
+	for _, output := range outputs {
+		for _, payload := range output.Payloads {
+			c.Outputs[output.Channel] = append(c.Outputs[output.Channel], map[string]any{
+				"type":      output.PayloadType,
+				"timestamp": time.Now(),
+				"data":      payload,
+			})
+		}
+	}
+
+	c.Finished = true
+	c.Passed = true
+	if len(outputs) > 0 {
+		c.Channel = outputs[0].Channel
+		c.Type = outputs[0].PayloadType
+		c.Payloads = c.Outputs[outputs[0].Channel]
+	}
+
+	return nil
+}
+
 func (c *ExecutionStateContext) Fail(reason, message string) error {
 	c.Finished = true
 	c.Passed = false
@@ -338,6 +400,52 @@ func (c *RequestContext) ScheduleActionCall(action string, params map[string]any
 	return nil
 }
 
+type CheckpointContext struct {
+	Checkpoints []core.Checkpoint
+}
+
+func (c *CheckpointContext) Save(checkpoint core.Checkpoint) error {
+	c.Checkpoints = append(c.Checkpoints, checkpoint)
+	return nil
+}
+
+type CanvasRunsContext struct {
+	StartedCanvasID string
+	StartedNodeID   string
+	StartedPayload  map[string]any
+	RunID           string
+	Run             *core.CanvasRun
+	Err             error
+}
+
+func (c *CanvasRunsContext) Start(canvasID, nodeID string, payload map[string]any) (string, error) {
+	if c.Err != nil {
+		return "", c.Err
+	}
+
+	c.StartedCanvasID = canvasID
+	c.StartedNodeID = nodeID
+	c.StartedPayload = payload
+	return c.RunID, nil
+}
+
+func (c *CanvasRunsContext) Get(canvasID, runID string) (*core.CanvasRun, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	return c.Run, nil
+}
+
+type CostContext struct {
+	Costs []core.Cost
+}
+
+func (c *CostContext) Report(cost core.Cost) error {
+	c.Costs = append(c.Costs, cost)
+	return nil
+}
+
 type HTTPContext struct {
 	Requests  []*http.Request
 	Responses []*http.Response
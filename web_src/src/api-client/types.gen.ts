@@ -915,6 +915,13 @@ export type OrganizationsIntegrationResourceRef = {
   type?: string;
   name?: string;
   id?: string;
+  description?: string;
+  group?: string;
+  icon?: string;
+  disabled?: boolean;
+  metadata?: {
+    [key: string]: unknown;
+  };
 };
 
 export type OrganizationsIntegrationSpec = {
@@ -953,6 +960,7 @@ export type OrganizationsInviteLink = {
 
 export type OrganizationsListIntegrationResourcesResponse = {
   resources?: Array<OrganizationsIntegrationResourceRef>;
+  nextPageToken?: string;
 };
 
 export type OrganizationsListInvitationsResponse = {
@@ -3215,6 +3223,8 @@ export type OrganizationsListIntegrationResourcesData = {
   };
   query?: {
     parameters?: string;
+    pageToken?: string;
+    pageSize?: number;
   };
   url: "/api/v1/organizations/{id}/integrations/{integrationId}/resources";
 };
@@ -293,12 +293,13 @@ export function IntegrationDetails({ organizationId }: IntegrationDetailsProps)
       </div>
 
       <div className="space-y-6">
-        {integration.status?.state === "error" && integration.status?.stateDescription && (
-          <Alert variant="destructive" className="[&>svg+div]:translate-y-0 [&>svg]:top-[14px]">
-            <CircleX className="h-4 w-4" />
-            <AlertDescription>{integration.status.stateDescription}</AlertDescription>
-          </Alert>
-        )}
+        {(integration.status?.state === "error" || integration.status?.state === "degraded") &&
+          integration.status?.stateDescription && (
+            <Alert variant="destructive" className="[&>svg+div]:translate-y-0 [&>svg]:top-[14px]">
+              <CircleX className="h-4 w-4" />
+              <AlertDescription>{integration.status.stateDescription}</AlertDescription>
+            </Alert>
+          )}
 
         {integration?.status?.browserAction && (
           <IntegrationInstructions
@@ -0,0 +1,139 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../../types";
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { MetadataItem } from "@/ui/metadataList";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getState, getStateMap, getTriggerRenderer } from "../..";
+import { formatTimeAgo } from "@/utils/date";
+import { stringOrDash } from "../../utils";
+import awsEc2Icon from "@/assets/icons/integrations/aws.ec2.svg";
+
+interface Configuration {
+  region?: string;
+  imageId?: string;
+  accountIds?: string[];
+  removeAccountIds?: string[];
+  organizationArns?: string[];
+  removeOrganizationArns?: string[];
+}
+
+interface ShareTargets {
+  accountIds?: string[];
+  organizationArns?: string[];
+}
+
+interface Output {
+  requestId?: string;
+  image?: {
+    imageId?: string;
+  };
+  added?: ShareTargets;
+  removed?: ShareTargets;
+  launchPermissions?: ShareTargets & {
+    public?: boolean;
+  };
+}
+
+export const shareImageMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+    const configuration = context.node.configuration as Configuration | undefined;
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsEc2Icon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution
+        ? shareImageEventSections(context.nodes, lastExecution, componentName)
+        : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: shareImageMetadata(configuration),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const output = outputs?.default?.[0]?.data as Output | undefined;
+
+    if (!output) {
+      return {};
+    }
+
+    return {
+      "Request ID": stringOrDash(output.requestId),
+      "Image ID": stringOrDash(output.image?.imageId),
+      Added: formatShareTargets(output.added),
+      Removed: formatShareTargets(output.removed),
+      "Shared With": formatShareTargets(output.launchPermissions),
+      Public: output.launchPermissions?.public ? "Yes" : "No",
+    };
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function shareImageMetadata(configuration?: Configuration): MetadataItem[] {
+  const items: MetadataItem[] = [];
+
+  if (configuration?.region) {
+    items.push({ icon: "globe", label: configuration.region });
+  }
+
+  if (configuration?.imageId) {
+    items.push({ icon: "disc", label: configuration.imageId });
+  }
+
+  const added = (configuration?.accountIds?.length || 0) + (configuration?.organizationArns?.length || 0);
+  if (added > 0) {
+    items.push({ icon: "user-plus", label: `Share with ${added}` });
+  }
+
+  const removed = (configuration?.removeAccountIds?.length || 0) + (configuration?.removeOrganizationArns?.length || 0);
+  if (removed > 0) {
+    items.push({ icon: "user-minus", label: `Unshare from ${removed}` });
+  }
+
+  return items;
+}
+
+function formatShareTargets(targets?: ShareTargets): string {
+  const values = [...(targets?.accountIds || []), ...(targets?.organizationArns || [])];
+  return values.length > 0 ? values.join(", ") : "-";
+}
+
+function shareImageEventSections(
+  nodes: NodeInfo[],
+  execution: ExecutionInfo,
+  componentName: string,
+): EventSection[] {
+  const rootTriggerNode = nodes.find((node) => node.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id!,
+    },
+  ];
+}
@@ -0,0 +1,118 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../../types";
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getState, getStateMap, getTriggerRenderer } from "../..";
+import { MetadataItem } from "@/ui/metadataList";
+import { formatTimeAgo } from "@/utils/date";
+import awsEc2Icon from "@/assets/icons/integrations/aws.ec2.svg";
+import { stringOrDash } from "../../utils";
+import { Ec2Snapshot } from "./types";
+
+interface Configuration {
+  region?: string;
+  volumeId?: string;
+  snapshotId?: string;
+  sourceRegion?: string;
+  sourceSnapshotId?: string;
+}
+
+interface Output {
+  snapshot?: Ec2Snapshot;
+  sourceSnapshotId?: string;
+  sourceRegion?: string;
+}
+
+export const snapshotMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsEc2Icon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution ? snapshotEventSections(context.nodes, lastExecution, componentName) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: snapshotMetadata(context.node),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const output = outputs?.default?.[0]?.data as Output | undefined;
+
+    if (!output) {
+      return {};
+    }
+
+    const details: Record<string, string> = {
+      "Snapshot ID": stringOrDash(output.snapshot?.snapshotId),
+      "Volume ID": stringOrDash(output.snapshot?.volumeId),
+      Region: stringOrDash(output.snapshot?.region),
+      State: stringOrDash(output.snapshot?.state),
+      Progress: stringOrDash(output.snapshot?.progress),
+      "Start Time": stringOrDash(output.snapshot?.startTime),
+      "Volume Size (GiB)": stringOrDash(output.snapshot?.volumeSize),
+      Encrypted: output.snapshot?.encrypted ? "Yes" : "No",
+      "KMS Key ID": stringOrDash(output.snapshot?.kmsKeyId),
+    };
+
+    if (output.sourceSnapshotId) {
+      details["Source Snapshot ID"] = output.sourceSnapshotId;
+      details["Source Region"] = stringOrDash(output.sourceRegion);
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function snapshotMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as Configuration | undefined;
+
+  if (configuration?.region) {
+    metadata.push({ icon: "globe", label: configuration.region });
+  }
+
+  const snapshot = configuration?.volumeId || configuration?.snapshotId || configuration?.sourceSnapshotId;
+  if (snapshot) {
+    metadata.push({ icon: "hard-drive", label: snapshot });
+  }
+
+  return metadata;
+}
+
+function snapshotEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((node) => node.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id!,
+    },
+  ];
+}
@@ -26,3 +26,18 @@ export interface Ec2Image {
   virtualizationType?: string;
   hypervisor?: string;
 }
+
+export interface Ec2Snapshot {
+  snapshotId?: string;
+  volumeId?: string;
+  state?: string;
+  stateMessage?: string;
+  progress?: string;
+  startTime?: string;
+  description?: string;
+  volumeSize?: number;
+  ownerId?: string;
+  encrypted?: boolean;
+  kmsKeyId?: string;
+  region?: string;
+}
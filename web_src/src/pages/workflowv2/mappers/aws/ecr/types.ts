@@ -13,6 +13,9 @@ export interface EcrRepositoryConfiguration {
   region?: string;
   imageDigest?: string;
   imageTag?: string;
+  image?: string;
+  tags?: string[];
+  severityThreshold?: string;
 }
 
 export type EcrTriggerMetadata = EcrRepositoryMetadata;
@@ -29,6 +32,14 @@ export interface EcrImageDetail {
   artifactMediaType?: string;
 }
 
+export interface EcrTaggedImage {
+  registryId?: string;
+  repositoryName?: string;
+  imageDigest?: string;
+  sourceImage?: string;
+  tags?: string[];
+}
+
 export interface EcrImageScanStatus {
   status?: string;
   description?: string;
@@ -0,0 +1,145 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  StateFunction,
+  SubtitleContext,
+} from "../../types";
+import {
+  ComponentBaseProps,
+  DEFAULT_EVENT_STATE_MAP,
+  EventSection,
+  EventState,
+  EventStateMap,
+} from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getTriggerRenderer } from "../..";
+import awsEcrIcon from "@/assets/icons/integrations/aws.ecr.svg";
+import { formatTimeAgo } from "@/utils/date";
+import { formatTimestampInUserTimezone } from "@/utils/timezone";
+import { MetadataItem } from "@/ui/metadataList";
+import { defaultStateFunction } from "../../stateRegistry";
+import { EcrImageScanFindingsResponse, EcrRepositoryConfiguration, EcrRepositoryMetadata } from "./types";
+import { getRepositoryLabel } from "./utils";
+import { numberOrZero, stringOrDash } from "../../utils";
+
+type WaitForScanFindingsOutputs = {
+  passed?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+export const WAIT_FOR_SCAN_FINDINGS_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  passed: DEFAULT_EVENT_STATE_MAP.success,
+  failed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-400",
+  },
+};
+
+export const waitForScanFindingsStateFunction: StateFunction = (execution: ExecutionInfo): EventState => {
+  if (!execution) return "neutral";
+
+  const outputs = execution.outputs as WaitForScanFindingsOutputs | undefined;
+  if (outputs?.failed && outputs.failed.length > 0) {
+    return "failed";
+  }
+
+  const state = defaultStateFunction(execution);
+  return state === "success" ? "passed" : state;
+};
+
+export const WAIT_FOR_SCAN_FINDINGS_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: WAIT_FOR_SCAN_FINDINGS_STATE_MAP,
+  getState: waitForScanFindingsStateFunction,
+};
+
+export const waitForScanFindingsMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsEcrIcon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution ? getWaitForScanFindingsEventSections(context.nodes, lastExecution) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: getWaitForScanFindingsMetadataList(context.node),
+      eventStateMap: WAIT_FOR_SCAN_FINDINGS_STATE_MAP,
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as WaitForScanFindingsOutputs | undefined;
+    const result = (outputs?.passed?.[0]?.data || outputs?.failed?.[0]?.data) as
+      | EcrImageScanFindingsResponse
+      | undefined;
+
+    if (!result) {
+      return {};
+    }
+
+    const counts = result.imageScanFindings?.findingSeverityCounts || {};
+
+    return {
+      Repository: stringOrDash(result.repositoryName),
+      "Image Digest": stringOrDash(result.imageId?.imageDigest),
+      "Scan Status": stringOrDash(result.imageScanStatus?.status),
+      "Scan Completed At": result.imageScanFindings?.imageScanCompletedAt
+        ? formatTimestampInUserTimezone(result.imageScanFindings.imageScanCompletedAt)
+        : "-",
+      Critical: numberOrZero(counts.CRITICAL).toString(),
+      High: numberOrZero(counts.HIGH).toString(),
+      Medium: numberOrZero(counts.MEDIUM).toString(),
+      Low: numberOrZero(counts.LOW).toString(),
+    };
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function getWaitForScanFindingsMetadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const nodeMetadata = node.metadata as EcrRepositoryMetadata | undefined;
+  const configuration = node.configuration as EcrRepositoryConfiguration | undefined;
+
+  const repositoryLabel = getRepositoryLabel(nodeMetadata, configuration);
+  if (repositoryLabel) {
+    metadata.push({ icon: "package", label: repositoryLabel });
+  }
+
+  if (configuration?.severityThreshold) {
+    metadata.push({ icon: "alert-triangle", label: `Fails on ${configuration.severityThreshold.toLowerCase()}` });
+  }
+
+  return metadata;
+}
+
+function getWaitForScanFindingsEventSections(nodes: NodeInfo[], execution: ExecutionInfo): EventSection[] {
+  const rootTriggerNode = nodes.find((n) => n.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName!);
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: waitForScanFindingsStateFunction(execution),
+      eventId: execution.rootEvent!.id!,
+    },
+  ];
+}
@@ -0,0 +1,118 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../../types";
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getState, getStateMap, getTriggerRenderer } from "../..";
+import { MetadataItem } from "@/ui/metadataList";
+import { formatTimeAgo } from "@/utils/date";
+import awsIcon from "@/assets/icons/integrations/aws.svg";
+import { stringOrDash } from "../../utils";
+
+interface Configuration {
+  region?: string;
+  cluster?: string;
+  namespace?: string;
+}
+
+interface Cluster {
+  name?: string;
+  arn?: string;
+  endpoint?: string;
+  status?: string;
+  version?: string;
+  platformVersion?: string;
+}
+
+// The token and the kubeconfig are credentials, so they are never shown in the execution details.
+interface Output {
+  cluster?: Cluster;
+  region?: string;
+  expiresAt?: string;
+}
+
+export const getClusterCredentialsMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsIcon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution ? clusterEventSections(context.nodes, lastExecution, componentName) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: clusterMetadata(context.node),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const output = outputs?.default?.[0]?.data as Output | undefined;
+
+    if (!output) {
+      return {};
+    }
+
+    return {
+      Cluster: stringOrDash(output.cluster?.name),
+      Region: stringOrDash(output.region),
+      Endpoint: stringOrDash(output.cluster?.endpoint),
+      "Kubernetes Version": stringOrDash(output.cluster?.version),
+      "Platform Version": stringOrDash(output.cluster?.platformVersion),
+      "Expires At": output.expiresAt ? new Date(output.expiresAt).toLocaleString() : "-",
+    };
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function clusterMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as Configuration | undefined;
+
+  if (configuration?.region) {
+    metadata.push({ icon: "globe", label: configuration.region });
+  }
+
+  if (configuration?.cluster) {
+    metadata.push({ icon: "boxes", label: configuration.cluster });
+  }
+
+  if (configuration?.namespace) {
+    metadata.push({ icon: "folder", label: configuration.namespace });
+  }
+
+  return metadata;
+}
+
+function clusterEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((node) => node.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id!,
+    },
+  ];
+}
@@ -0,0 +1,124 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../../types";
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getState, getStateMap, getTriggerRenderer } from "../..";
+import { MetadataItem } from "@/ui/metadataList";
+import { formatTimeAgo } from "@/utils/date";
+import awsIcon from "@/assets/icons/integrations/aws.svg";
+import { stringOrDash } from "../../utils";
+
+interface Target {
+  id?: string;
+  port?: number;
+  availabilityZone?: string;
+}
+
+interface TargetHealth extends Target {
+  state?: string;
+  reason?: string;
+}
+
+interface Configuration {
+  region?: string;
+  targetGroup?: string;
+  targets?: Target[];
+}
+
+interface Output {
+  requestId?: string;
+  targetGroupArn?: string;
+  targets?: TargetHealth[];
+}
+
+export const targetsMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsIcon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution ? targetsEventSections(context.nodes, lastExecution, componentName) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: targetsMetadata(context.node),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const output = outputs?.default?.[0]?.data as Output | undefined;
+
+    if (!output) {
+      return {};
+    }
+
+    const details: Record<string, string> = {
+      "Target Group": stringOrDash(output.targetGroupArn),
+      "Request ID": stringOrDash(output.requestId),
+    };
+
+    for (const target of output.targets || []) {
+      const name = target.port ? `${target.id}:${target.port}` : stringOrDash(target.id);
+      details[`Target ${name}`] = target.reason ? `${target.state} (${target.reason})` : stringOrDash(target.state);
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function targetsMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as Configuration | undefined;
+
+  if (configuration?.region) {
+    metadata.push({ icon: "globe", label: configuration.region });
+  }
+
+  const targetGroupName = configuration?.targetGroup?.split("/")[1];
+  if (targetGroupName) {
+    metadata.push({ icon: "network", label: targetGroupName });
+  }
+
+  const targets = configuration?.targets?.filter((target) => target.id) || [];
+  if (targets.length > 0) {
+    metadata.push({ icon: "server", label: targets.length === 1 ? targets[0].id! : `${targets.length} targets` });
+  }
+
+  return metadata;
+}
+
+function targetsEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((node) => node.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id!,
+    },
+  ];
+}
@@ -6,6 +6,8 @@ import { getImageMapper } from "./ecr/get_image";
 import { getImageScanFindingsMapper } from "./ecr/get_image_scan_findings";
 import { buildActionStateRegistry } from "../utils";
 import { scanImageMapper } from "./ecr/scan_image";
+import { tagImageMapper } from "./ecr/tag_image";
+import { WAIT_FOR_SCAN_FINDINGS_STATE_REGISTRY, waitForScanFindingsMapper } from "./ecr/wait_for_scan_findings";
 import { onPackageVersionTriggerRenderer } from "./codeartifact/on_package_version";
 import { getPackageVersionMapper } from "./codeartifact/get_package_version";
 import { createQueueMapper, deleteQueueMapper, getQueueMapper, purgeQueueMapper, sendMessageMapper } from "./sqs";
@@ -45,6 +47,13 @@ import { enableImageMapper } from "./ec2/enable_image";
 import { disableImageMapper } from "./ec2/disable_image";
 import { enableImageDeprecationMapper } from "./ec2/enable_image_deprecation";
 import { disableImageDeprecationMapper } from "./ec2/disable_image_deprecation";
+import { shareImageMapper } from "./ec2/share_image";
+import { snapshotMapper } from "./ec2/snapshot";
+import { targetsMapper } from "./elb/targets";
+import { getClusterCredentialsMapper } from "./eks/get_cluster_credentials";
+import { dbInstanceMapper } from "./rds/db_instance";
+import { dbSnapshotMapper } from "./rds/db_snapshot";
+import { secretValueMapper } from "./secretsmanager/secret_value";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   "codepipeline.getPipeline": getPipelineMapper,
@@ -61,6 +70,8 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
   "ecr.getImage": getImageMapper,
   "ecr.getImageScanFindings": getImageScanFindingsMapper,
   "ecr.scanImage": scanImageMapper,
+  "ecr.tagImage": tagImageMapper,
+  "ecr.waitForScanFindings": waitForScanFindingsMapper,
   "codeArtifact.copyPackageVersions": copyPackageVersionsMapper,
   "codeArtifact.createRepository": createRepositoryMapper,
   "codeArtifact.deletePackageVersions": deletePackageVersionsMapper,
@@ -82,13 +93,26 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
   "sns.deleteTopic": deleteTopicMapper,
   "sns.publishMessage": publishMessageMapper,
   "ec2.copyImage": copyImageMapper,
+  "ec2.copySnapshot": snapshotMapper,
   "ec2.createImage": createImageMapper,
+  "ec2.createSnapshot": snapshotMapper,
   "ec2.deregisterImage": deregisterImageMapper,
   "ec2.disableImage": disableImageMapper,
   "ec2.disableImageDeprecation": disableImageDeprecationMapper,
   "ec2.enableImage": enableImageMapper,
   "ec2.enableImageDeprecation": enableImageDeprecationMapper,
   "ec2.getImage": getEc2ImageMapper,
+  "ec2.getSnapshot": snapshotMapper,
+  "ec2.shareImage": shareImageMapper,
+  "ec2.waitForImage": getEc2ImageMapper,
+  "eks.getClusterCredentials": getClusterCredentialsMapper,
+  "elb.deregisterTargets": targetsMapper,
+  "elb.registerTargets": targetsMapper,
+  "rds.createDBSnapshot": dbSnapshotMapper,
+  "rds.modifyDBInstance": dbInstanceMapper,
+  "rds.restoreDBInstanceFromSnapshot": dbInstanceMapper,
+  "secretsmanager.getSecretValue": secretValueMapper,
+  "secretsmanager.putSecretValue": secretValueMapper,
 };
 
 export const triggerRenderers: Record<string, TriggerRenderer> = {
@@ -115,6 +139,8 @@ export const eventStateRegistry: Record<string, EventStateRegistry> = {
   "ecr.getImage": buildActionStateRegistry("retrieved"),
   "ecr.getImageScanFindings": buildActionStateRegistry("retrieved"),
   "ecr.scanImage": buildActionStateRegistry("scanned"),
+  "ecr.tagImage": buildActionStateRegistry("tagged"),
+  "ecr.waitForScanFindings": WAIT_FOR_SCAN_FINDINGS_STATE_REGISTRY,
   "codeArtifact.copyPackageVersions": buildActionStateRegistry("copied"),
   "codeArtifact.createRepository": buildActionStateRegistry("created"),
   "codeArtifact.deletePackageVersions": buildActionStateRegistry("deleted"),
@@ -136,11 +162,24 @@ export const eventStateRegistry: Record<string, EventStateRegistry> = {
   "sns.deleteTopic": buildActionStateRegistry("deleted"),
   "sns.publishMessage": buildActionStateRegistry("published"),
   "ec2.copyImage": buildActionStateRegistry("copied"),
+  "ec2.copySnapshot": buildActionStateRegistry("copied"),
   "ec2.createImage": buildActionStateRegistry("created"),
+  "ec2.createSnapshot": buildActionStateRegistry("created"),
   "ec2.deregisterImage": buildActionStateRegistry("deregistered"),
   "ec2.disableImage": buildActionStateRegistry("disabled"),
   "ec2.disableImageDeprecation": buildActionStateRegistry("disabled"),
   "ec2.enableImage": buildActionStateRegistry("enabled"),
   "ec2.enableImageDeprecation": buildActionStateRegistry("enabled"),
   "ec2.getImage": buildActionStateRegistry("retrieved"),
+  "ec2.getSnapshot": buildActionStateRegistry("retrieved"),
+  "ec2.shareImage": buildActionStateRegistry("shared"),
+  "ec2.waitForImage": buildActionStateRegistry("available"),
+  "eks.getClusterCredentials": buildActionStateRegistry("retrieved"),
+  "elb.deregisterTargets": buildActionStateRegistry("deregistered"),
+  "elb.registerTargets": buildActionStateRegistry("registered"),
+  "rds.createDBSnapshot": buildActionStateRegistry("created"),
+  "rds.modifyDBInstance": buildActionStateRegistry("modified"),
+  "rds.restoreDBInstanceFromSnapshot": buildActionStateRegistry("restored"),
+  "secretsmanager.getSecretValue": buildActionStateRegistry("retrieved"),
+  "secretsmanager.putSecretValue": buildActionStateRegistry("stored"),
 };
@@ -0,0 +1,141 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../../types";
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getState, getStateMap, getTriggerRenderer } from "../..";
+import { MetadataItem } from "@/ui/metadataList";
+import { formatTimeAgo } from "@/utils/date";
+import awsIcon from "@/assets/icons/integrations/aws.svg";
+import { stringOrDash } from "../../utils";
+
+interface Configuration {
+  region?: string;
+  dbInstance?: string;
+  snapshot?: string;
+  dbInstanceIdentifier?: string;
+  newDBInstanceIdentifier?: string;
+  dbInstanceClass?: string;
+}
+
+interface DBInstance {
+  dbInstanceIdentifier?: string;
+  dbInstanceArn?: string;
+  dbInstanceClass?: string;
+  status?: string;
+  engine?: string;
+  engineVersion?: string;
+  endpoint?: {
+    address?: string;
+    port?: number;
+  };
+  allocatedStorage?: number;
+  multiAZ?: boolean;
+  pendingModifiedValues?: Record<string, string>;
+  region?: string;
+}
+
+interface Output {
+  dbInstance?: DBInstance;
+}
+
+export const dbInstanceMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsIcon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution ? dbInstanceEventSections(context.nodes, lastExecution, componentName) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: dbInstanceMetadata(context.node),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const instance = (outputs?.default?.[0]?.data as Output | undefined)?.dbInstance;
+
+    if (!instance) {
+      return {};
+    }
+
+    const details: Record<string, string> = {
+      "DB Instance": stringOrDash(instance.dbInstanceIdentifier),
+      Region: stringOrDash(instance.region),
+      Status: stringOrDash(instance.status),
+      Class: stringOrDash(instance.dbInstanceClass),
+      Engine: instance.engine ? `${instance.engine} ${instance.engineVersion || ""}`.trim() : "-",
+      Endpoint: instance.endpoint?.address ? `${instance.endpoint.address}:${instance.endpoint.port}` : "-",
+      "Allocated Storage (GiB)": stringOrDash(instance.allocatedStorage),
+      "Multi-AZ": instance.multiAZ ? "Yes" : "No",
+    };
+
+    const pending = Object.entries(instance.pendingModifiedValues || {});
+    if (pending.length > 0) {
+      details["Pending Changes"] = pending.map(([name, value]) => `${name}: ${value}`).join(", ");
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function dbInstanceMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as Configuration | undefined;
+
+  if (configuration?.region) {
+    metadata.push({ icon: "globe", label: configuration.region });
+  }
+
+  if (configuration?.snapshot) {
+    metadata.push({ icon: "camera", label: configuration.snapshot });
+  }
+
+  const instance = configuration?.dbInstanceIdentifier || configuration?.dbInstance;
+  if (instance) {
+    const renamedTo = configuration?.newDBInstanceIdentifier;
+    metadata.push({ icon: "database", label: renamedTo ? `${instance} → ${renamedTo}` : instance });
+  }
+
+  if (configuration?.dbInstanceClass) {
+    metadata.push({ icon: "cpu", label: configuration.dbInstanceClass });
+  }
+
+  return metadata;
+}
+
+function dbInstanceEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((node) => node.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id!,
+    },
+  ];
+}
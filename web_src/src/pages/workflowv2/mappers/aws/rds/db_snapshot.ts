@@ -0,0 +1,122 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../../types";
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getState, getStateMap, getTriggerRenderer } from "../..";
+import { MetadataItem } from "@/ui/metadataList";
+import { formatTimeAgo } from "@/utils/date";
+import awsIcon from "@/assets/icons/integrations/aws.svg";
+import { stringOrDash } from "../../utils";
+
+interface Configuration {
+  region?: string;
+  dbInstance?: string;
+  snapshotIdentifier?: string;
+}
+
+interface DBSnapshot {
+  dbSnapshotIdentifier?: string;
+  dbSnapshotArn?: string;
+  dbInstanceIdentifier?: string;
+  status?: string;
+  engine?: string;
+  engineVersion?: string;
+  allocatedStorage?: number;
+  snapshotCreateTime?: string;
+  encrypted?: boolean;
+  region?: string;
+}
+
+interface Output {
+  dbSnapshot?: DBSnapshot;
+}
+
+export const dbSnapshotMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsIcon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution ? dbSnapshotEventSections(context.nodes, lastExecution, componentName) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: dbSnapshotMetadata(context.node),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const snapshot = (outputs?.default?.[0]?.data as Output | undefined)?.dbSnapshot;
+
+    if (!snapshot) {
+      return {};
+    }
+
+    return {
+      Snapshot: stringOrDash(snapshot.dbSnapshotIdentifier),
+      "DB Instance": stringOrDash(snapshot.dbInstanceIdentifier),
+      Region: stringOrDash(snapshot.region),
+      Status: stringOrDash(snapshot.status),
+      Engine: snapshot.engine ? `${snapshot.engine} ${snapshot.engineVersion || ""}`.trim() : "-",
+      "Allocated Storage (GiB)": stringOrDash(snapshot.allocatedStorage),
+      "Created At": stringOrDash(snapshot.snapshotCreateTime),
+      Encrypted: snapshot.encrypted ? "Yes" : "No",
+      ARN: stringOrDash(snapshot.dbSnapshotArn),
+    };
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function dbSnapshotMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as Configuration | undefined;
+
+  if (configuration?.region) {
+    metadata.push({ icon: "globe", label: configuration.region });
+  }
+
+  if (configuration?.dbInstance) {
+    metadata.push({ icon: "database", label: configuration.dbInstance });
+  }
+
+  if (configuration?.snapshotIdentifier) {
+    metadata.push({ icon: "camera", label: configuration.snapshotIdentifier });
+  }
+
+  return metadata;
+}
+
+function dbSnapshotEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((node) => node.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id!,
+    },
+  ];
+}
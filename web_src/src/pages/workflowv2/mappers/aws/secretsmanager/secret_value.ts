@@ -0,0 +1,119 @@
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../../types";
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/utils/colors";
+import { getState, getStateMap, getTriggerRenderer } from "../..";
+import { MetadataItem } from "@/ui/metadataList";
+import { formatTimeAgo } from "@/utils/date";
+import awsIcon from "@/assets/icons/integrations/aws.svg";
+import { stringOrDash } from "../../utils";
+
+interface Configuration {
+  region?: string;
+  secret?: string;
+  versionStage?: string;
+}
+
+// The value of the secret is part of the output of Get Secret Value,
+// but it is never shown in the execution details.
+interface Output {
+  arn?: string;
+  name?: string;
+  versionId?: string;
+  versionStages?: string[];
+  createdDate?: string;
+}
+
+export const secretValueMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      iconSrc: awsIcon,
+      iconColor: getColorClass(context.componentDefinition.color),
+      collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+      collapsed: context.node.isCollapsed,
+      eventSections: lastExecution ? secretEventSections(context.nodes, lastExecution, componentName) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: secretMetadata(context.node),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const output = outputs?.default?.[0]?.data as Output | undefined;
+
+    if (!output) {
+      return {};
+    }
+
+    const details: Record<string, string> = {
+      Secret: stringOrDash(output.name),
+      "Version ID": stringOrDash(output.versionId),
+      "Version Stages": output.versionStages?.length ? output.versionStages.join(", ") : "-",
+      ARN: stringOrDash(output.arn),
+    };
+
+    if (output.createdDate) {
+      details["Created At"] = new Date(output.createdDate).toLocaleString();
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) {
+      return "";
+    }
+
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function secretMetadata(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as Configuration | undefined;
+
+  if (configuration?.region) {
+    metadata.push({ icon: "globe", label: configuration.region });
+  }
+
+  // Secrets are selected by ARN, which ends with the name and a random suffix.
+  const secretName =
+    configuration?.secret?.split(":secret:")[1]?.replace(/-[A-Za-z0-9]{6}$/, "") || configuration?.secret;
+  if (secretName) {
+    metadata.push({ icon: "key-round", label: secretName });
+  }
+
+  if (configuration?.versionStage) {
+    metadata.push({ icon: "tag", label: configuration.versionStage });
+  }
+
+  return metadata;
+}
+
+function secretEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((node) => node.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt!),
+      eventTitle: title,
+      eventSubtitle: formatTimeAgo(new Date(execution.createdAt!)),
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id!,
+    },
+  ];
+}
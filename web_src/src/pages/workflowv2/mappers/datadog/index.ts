@@ -4,10 +4,12 @@ import { buildActionStateRegistry } from "../utils";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   createEvent: createEventMapper,
+  annotateDeployment: createEventMapper,
 };
 
 export const triggerRenderers: Record<string, TriggerRenderer> = {};
 
 export const eventStateRegistry: Record<string, EventStateRegistry> = {
   createEvent: buildActionStateRegistry("Event created"),
+  annotateDeployment: buildActionStateRegistry("annotated"),
 };
@@ -22,6 +22,16 @@ import { cloudDNSMapper } from "./clouddns";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   createVM: baseMapper,
+  addBackend: baseMapper,
+  addInstancesToInstanceGroup: baseMapper,
+  checkQuota: baseMapper,
+  checkVMCompliance: baseMapper,
+  cleanupVMs: baseMapper,
+  createInstanceGroup: baseMapper,
+  createNodeGroup: baseMapper,
+  createNodeTemplate: baseMapper,
+  getSerialPortOutput: baseMapper,
+  recommendMachineType: baseMapper,
   "cloudbuild.createBuild": cloudBuildBaseMapper,
   "cloudbuild.getBuild": cloudBuildBaseMapper,
   "cloudbuild.runTrigger": runTriggerMapper,
@@ -36,10 +46,15 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
   "clouddns.createRecord": cloudDNSMapper,
   "clouddns.deleteRecord": cloudDNSMapper,
   "clouddns.updateRecord": cloudDNSMapper,
+  "clouddns.upsertRecord": cloudDNSMapper,
 };
 
 export const triggerRenderers: Record<string, TriggerRenderer> = {
   onVMInstance: onVMInstanceTriggerRenderer,
+  "compute.onVMInstanceStarted": onVMInstanceTriggerRenderer,
+  "compute.onVMInstanceStopped": onVMInstanceTriggerRenderer,
+  "compute.onVMInstanceDeleted": onVMInstanceTriggerRenderer,
+  "compute.onSpotPreemption": onVMInstanceTriggerRenderer,
   "cloudbuild.onBuildComplete": onBuildCompleteTriggerRenderer,
   "artifactregistry.onArtifactPush": onArtifactPushTriggerRenderer,
   "artifactregistry.onArtifactAnalysis": onArtifactAnalysisTriggerRenderer,
@@ -48,6 +63,16 @@ export const triggerRenderers: Record<string, TriggerRenderer> = {
 
 export const eventStateRegistry: Record<string, EventStateRegistry> = {
   createVM: buildActionStateRegistry("completed"),
+  addBackend: buildActionStateRegistry("completed"),
+  addInstancesToInstanceGroup: buildActionStateRegistry("completed"),
+  checkQuota: buildActionStateRegistry("completed"),
+  checkVMCompliance: buildActionStateRegistry("completed"),
+  cleanupVMs: buildActionStateRegistry("completed"),
+  createInstanceGroup: buildActionStateRegistry("completed"),
+  createNodeGroup: buildActionStateRegistry("completed"),
+  createNodeTemplate: buildActionStateRegistry("completed"),
+  getSerialPortOutput: buildActionStateRegistry("completed"),
+  recommendMachineType: buildActionStateRegistry("completed"),
   "cloudbuild.createBuild": CLOUD_BUILD_EXECUTION_STATE_REGISTRY,
   "cloudbuild.getBuild": CLOUD_BUILD_EXECUTION_STATE_REGISTRY,
   "cloudbuild.runTrigger": CLOUD_BUILD_EXECUTION_STATE_REGISTRY,
@@ -62,6 +87,7 @@ export const eventStateRegistry: Record<string, EventStateRegistry> = {
   "clouddns.createRecord": buildActionStateRegistry("completed"),
   "clouddns.deleteRecord": buildActionStateRegistry("completed"),
   "clouddns.updateRecord": buildActionStateRegistry("completed"),
+  "clouddns.upsertRecord": buildActionStateRegistry("completed"),
 };
 
 export const customFieldRenderers: Record<string, CustomFieldRenderer> = {};
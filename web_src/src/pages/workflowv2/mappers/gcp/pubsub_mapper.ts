@@ -107,6 +107,9 @@ export const createSubscriptionMapper: ComponentBaseMapper = {
           topicId?: string;
           type?: string;
           name?: string;
+          deadLetterTopic?: string;
+          maxDeliveryAttempts?: number;
+          retryPolicy?: { minimumBackoffSeconds?: number; maximumBackoffSeconds?: number };
         }>
       | undefined;
     const item = payload?.default?.[0]?.data;
@@ -117,6 +120,13 @@ export const createSubscriptionMapper: ComponentBaseMapper = {
     if (subscription) details["Subscription"] = subscription;
     if (topic) details["Topic"] = topic;
     if (item?.type) details["Type"] = formatSubscriptionType(item.type);
+    if (item?.deadLetterTopic) {
+      details["Dead-letter Topic"] = `${item.deadLetterTopic} (after ${item.maxDeliveryAttempts} attempts)`;
+    }
+    if (item?.retryPolicy) {
+      const { minimumBackoffSeconds = 0, maximumBackoffSeconds = 0 } = item.retryPolicy;
+      details["Retry Backoff"] = `${minimumBackoffSeconds}s to ${maximumBackoffSeconds}s`;
+    }
     return details;
   },
 
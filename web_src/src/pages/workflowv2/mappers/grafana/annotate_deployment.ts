@@ -0,0 +1,106 @@
+import { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import { getState, getStateMap, getTriggerRenderer } from "..";
+import {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "../types";
+import { MetadataItem } from "@/ui/metadataList";
+import grafanaIcon from "@/assets/icons/integrations/grafana.svg";
+import { AnnotateDeploymentConfiguration, GrafanaAnnotation } from "./types";
+import { formatTimeAgo } from "@/utils/date";
+import { formatTimestamp } from "./utils";
+
+export const annotateDeploymentMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name || "unknown";
+
+    return {
+      iconSrc: grafanaIcon,
+      collapsedBackground: "bg-white",
+      collapsed: context.node.isCollapsed,
+      title: context.node.name || context.componentDefinition.label || "Unnamed component",
+      eventSections: lastExecution ? baseEventSections(context.nodes, lastExecution, componentName) : undefined,
+      metadata: metadataList(context.node),
+      includeEmptyState: !lastExecution,
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const outputs = context.execution.outputs as { default?: OutputPayload[] } | undefined;
+    const annotation = outputs?.default?.[0]?.data as GrafanaAnnotation | undefined;
+    if (!annotation) {
+      return {};
+    }
+
+    const details: Record<string, string> = {};
+    if (annotation.id) {
+      details["Annotation ID"] = String(annotation.id);
+    }
+
+    if (annotation.time) {
+      details["Time"] = formatTimestamp(new Date(annotation.time).toISOString());
+    }
+
+    if (annotation.dashboardUid) {
+      details["Dashboard"] = annotation.dashboardUid;
+    }
+
+    if (annotation.text) {
+      details["Text"] = annotation.text;
+    }
+
+    if (annotation.tags && annotation.tags.length > 0) {
+      details["Tags"] = annotation.tags.join(", ");
+    }
+
+    return details;
+  },
+
+  subtitle(context: SubtitleContext): string {
+    if (!context.execution.createdAt) return "-";
+    return formatTimeAgo(new Date(context.execution.createdAt));
+  },
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = node.configuration as AnnotateDeploymentConfiguration | undefined;
+
+  if (configuration?.service) {
+    metadata.push({ icon: "package", label: `Service: ${configuration.service}` });
+  }
+
+  if (configuration?.environment) {
+    metadata.push({ icon: "server", label: `Environment: ${configuration.environment}` });
+  }
+
+  if (configuration?.dashboardUid) {
+    metadata.push({ icon: "layout-dashboard", label: `Dashboard: ${configuration.dashboardUid}` });
+  }
+
+  return metadata;
+}
+
+function baseEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
+  const rootTriggerNode = nodes.find((n) => n.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+  const eventTitle = title || "Trigger event";
+
+  return [
+    {
+      receivedAt: execution.createdAt ? new Date(execution.createdAt) : undefined,
+      eventTitle: eventTitle,
+      eventSubtitle: execution.createdAt ? formatTimeAgo(new Date(execution.createdAt)) : "-",
+      eventState: getState(componentName)(execution),
+      eventId: execution.rootEvent?.id || "",
+    },
+  ];
+}
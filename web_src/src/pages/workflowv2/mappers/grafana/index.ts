@@ -2,9 +2,11 @@ import { ComponentBaseMapper, CustomFieldRenderer, TriggerRenderer, EventStateRe
 import { buildActionStateRegistry } from "../utils";
 import { onAlertFiringCustomFieldRenderer, onAlertFiringTriggerRenderer } from "./on_alert_firing";
 import { queryDataSourceMapper } from "./query_data_source";
+import { annotateDeploymentMapper } from "./annotate_deployment";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   queryDataSource: queryDataSourceMapper,
+  annotateDeployment: annotateDeploymentMapper,
 };
 
 export const triggerRenderers: Record<string, TriggerRenderer> = {
@@ -17,4 +19,5 @@ export const customFieldRenderers: Record<string, CustomFieldRenderer> = {
 
 export const eventStateRegistry: Record<string, EventStateRegistry> = {
   queryDataSource: buildActionStateRegistry("queried"),
+  annotateDeployment: buildActionStateRegistry("annotated"),
 };
@@ -24,3 +24,19 @@ export interface QueryDataSourceConfiguration {
   timeTo?: string;
   format?: string;
 }
+
+export interface AnnotateDeploymentConfiguration {
+  service?: string;
+  version?: string;
+  environment?: string;
+  status?: string;
+  dashboardUid?: string;
+}
+
+export interface GrafanaAnnotation {
+  id?: number;
+  time?: number;
+  dashboardUid?: string;
+  text?: string;
+  tags?: string[];
+}
@@ -6,6 +6,7 @@ import { buildActionStateRegistry } from "../utils";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   sendTextMessage: sendTextMessageMapper,
+  sendDeploymentMessage: sendTextMessageMapper,
   waitForButtonClick: waitForButtonClickMapper,
 };
 
@@ -15,5 +16,6 @@ export const triggerRenderers: Record<string, TriggerRenderer> = {
 
 export const eventStateRegistry: Record<string, EventStateRegistry> = {
   sendTextMessage: buildActionStateRegistry("sent"),
+  sendDeploymentMessage: buildActionStateRegistry("sent"),
   waitForButtonClick: WAIT_FOR_BUTTON_CLICK_STATE_REGISTRY,
 };
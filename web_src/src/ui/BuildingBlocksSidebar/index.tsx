@@ -1068,16 +1068,18 @@ function CategorySection({
         ? "ready"
         : matchingIntegrationStates.includes("error")
           ? "error"
-          : matchingIntegrationStates.includes("pending")
-            ? "pending"
-            : undefined;
+          : matchingIntegrationStates.includes("degraded")
+            ? "degraded"
+            : matchingIntegrationStates.includes("pending")
+              ? "pending"
+              : undefined;
 
   const integrationStatusColorClass =
     integrationState === "ready"
       ? "text-green-500"
       : integrationState === "error"
         ? "text-red-500"
-        : integrationState === "pending"
+        : integrationState === "pending" || integrationState === "degraded"
           ? "text-amber-600"
           : "text-gray-500";
 
@@ -372,7 +372,8 @@ export function SettingsTab({
                     <p className="py-2 text-xs text-gray-500">Connection</p>
                     {(() => {
                       const hasIntegrationError =
-                        selectedIntegrationFull.status?.state === "error" &&
+                        (selectedIntegrationFull.status?.state === "error" ||
+                          selectedIntegrationFull.status?.state === "degraded") &&
                         !!selectedIntegrationFull.status?.stateDescription;
 
                       const integrationStatusCard = (
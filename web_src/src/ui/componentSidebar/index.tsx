@@ -942,12 +942,13 @@ export const ComponentSidebar = ({
                   </div>
                 </div>
               </DialogHeader>
-              {configureIntegration.status?.state === "error" && configureIntegration.status?.stateDescription && (
-                <div className="flex items-start gap-2 text-sm text-red-700 dark:text-red-300">
-                  <TriangleAlert className="h-4 w-4 mt-0.5 flex-shrink-0" />
-                  <p>{configureIntegration.status.stateDescription}</p>
-                </div>
-              )}
+              {(configureIntegration.status?.state === "error" || configureIntegration.status?.state === "degraded") &&
+                configureIntegration.status?.stateDescription && (
+                  <div className="flex items-start gap-2 text-sm text-red-700 dark:text-red-300">
+                    <TriangleAlert className="h-4 w-4 mt-0.5 flex-shrink-0" />
+                    <p>{configureIntegration.status.stateDescription}</p>
+                  </div>
+                )}
               {configureIntegration?.status?.browserAction && (
                 <IntegrationInstructions
                   description={configureIntegration.status.browserAction.description}
@@ -37,6 +37,12 @@ export const CronFieldRenderer: React.FC<FieldRendererProps> = ({ field, value,
             <div>
               <code className="bg-gray-100 dark:bg-gray-800 px-1 rounded">/</code> step values
             </div>
+            <div>
+              <code className="bg-gray-100 dark:bg-gray-800 px-1 rounded">@hourly</code>,{" "}
+              <code className="bg-gray-100 dark:bg-gray-800 px-1 rounded">@daily</code>,{" "}
+              <code className="bg-gray-100 dark:bg-gray-800 px-1 rounded">@weekly</code>,{" "}
+              <code className="bg-gray-100 dark:bg-gray-800 px-1 rounded">@monthly</code> shortcuts
+            </div>
           </div>
           <p className="mt-2">
             Check{" "}
@@ -0,0 +1,48 @@
+import React from "react";
+import { Input } from "@/components/ui/input";
+import { FieldRendererProps } from "./types";
+import { toTestId } from "@/utils/testID";
+
+// Durations are stored as a number of seconds once the node is saved,
+// so they are displayed back in the same format users type them in.
+const formatSeconds = (seconds: number): string => {
+  if (seconds === 0) {
+    return "0s";
+  }
+
+  const units: Array<[string, number]> = [
+    ["d", 86400],
+    ["h", 3600],
+    ["m", 60],
+    ["s", 1],
+  ];
+
+  let remaining = seconds;
+  let result = "";
+  for (const [unit, size] of units) {
+    const count = Math.floor(remaining / size);
+    if (count > 0) {
+      result += `${count}${unit}`;
+      remaining -= count * size;
+    }
+  }
+
+  return result;
+};
+
+export const DurationFieldRenderer: React.FC<FieldRendererProps> = ({ field, value, onChange }) => {
+  const currentValue =
+    typeof value === "number" ? formatSeconds(value) : ((value as string) ?? (field.defaultValue as string) ?? "");
+
+  return (
+    <Input
+      type="text"
+      value={currentValue}
+      onChange={(e) => onChange(e.target.value || undefined)}
+      placeholder={field.placeholder || "e.g. 30s, 5m, 1h30m, 2d"}
+      className=""
+      spellCheck={false}
+      data-testid={toTestId(`duration-field-${field.name}`)}
+    />
+  );
+};
@@ -0,0 +1,72 @@
+import React, { useRef } from "react";
+import { Button } from "@/components/ui/button";
+import { FieldRendererProps } from "./types";
+import { toTestId } from "@/utils/testID";
+
+// Before the node is saved, the file is sent as a data URL.
+// After that, the value is a reference to the stored file.
+type FileReference = {
+  key?: string;
+  name?: string;
+  size?: number;
+};
+
+const fileName = (value: unknown): string | undefined => {
+  if (typeof value === "string") {
+    const match = value.match(/^data:[^,]*;name=([^;,]+)/);
+    return match ? decodeURIComponent(match[1]) : undefined;
+  }
+
+  if (value && typeof value === "object") {
+    return (value as FileReference).name;
+  }
+
+  return undefined;
+};
+
+const readAsDataURL = (file: File): Promise<string> =>
+  new Promise((resolve, reject) => {
+    const reader = new FileReader();
+    reader.onload = () => {
+      const result = String(reader.result);
+      const contentType = file.type || "application/octet-stream";
+      const [, content] = result.split(",", 2);
+      resolve(`data:${contentType};name=${encodeURIComponent(file.name)};base64,${content}`);
+    };
+    reader.onerror = () => reject(reader.error);
+    reader.readAsDataURL(file);
+  });
+
+export const FileFieldRenderer: React.FC<FieldRendererProps> = ({ field, value, onChange }) => {
+  const inputRef = useRef<HTMLInputElement>(null);
+  const name = fileName(value);
+
+  return (
+    <div className="flex items-center gap-2">
+      <input
+        ref={inputRef}
+        type="file"
+        className="hidden"
+        data-testid={toTestId(`file-field-${field.name}`)}
+        onChange={async (e) => {
+          const file = e.target.files?.[0];
+          if (!file) {
+            return;
+          }
+
+          onChange(await readAsDataURL(file));
+          e.target.value = "";
+        }}
+      />
+      <Button type="button" variant="outline" size="sm" onClick={() => inputRef.current?.click()}>
+        {name ? "Replace file" : "Choose file"}
+      </Button>
+      <span className="text-sm text-gray-500 dark:text-gray-400 truncate">{name || "No file selected"}</span>
+      {name && !field.required && (
+        <Button type="button" variant="ghost" size="sm" onClick={() => onChange(undefined)}>
+          Remove
+        </Button>
+      )}
+    </div>
+  );
+};
@@ -4,7 +4,7 @@ import { AutoCompleteInput } from "@/components/AutoCompleteInput/AutoCompleteIn
 import { MultiCombobox, MultiComboboxLabel } from "@/components/MultiCombobox/multi-combobox";
 import { Select, SelectTrigger, SelectValue } from "@/components/ui/select";
 import { Tabs, TabsContent, TabsList, TabsTrigger } from "@/components/ui/tabs";
-import { ConfigurationField } from "../../api-client";
+import { ConfigurationField, type OrganizationsIntegrationResourceRef } from "../../api-client";
 import { useIntegrationResources } from "@/hooks/useIntegrations";
 import { toTestId } from "@/utils/testID";
 import { type RefObject, useEffect, useMemo, useState } from "react";
@@ -28,6 +28,15 @@ type SelectOption = {
   value: string;
 };
 
+/**
+ * Label shown for a resource option, with its description, if there is one
+ * (e.g. "e2-medium (2 vCPU, 4 GB memory)").
+ */
+function resourceLabel(resource: OrganizationsIntegrationResourceRef): string {
+  const name = resource.name ?? resource.id ?? "Unnamed resource";
+  return resource.description ? `${name} (${resource.description})` : name;
+}
+
 /**
  * Detect if value looks like a wrapped expression (e.g. {{ $["node-name"].value }}).
  * Requires both {{ and }} so fixed IDs (e.g. channel IDs) are not misclassified.
@@ -115,13 +124,13 @@ export const IntegrationResourceFieldRenderer = ({
   const multiSelectOptions: SelectOption[] = useMemo(() => {
     if (!resources || resources.length === 0) return [];
     return resources
+      .filter((resource) => !resource.disabled)
       .map((resource) => {
         const optionValue = useNameAsValue
           ? (resource.name ?? resource.id ?? "")
           : (resource.id ?? resource.name ?? "");
-        const optionLabel = resource.name ?? resource.id ?? "Unnamed resource";
         if (!optionValue) return null;
-        return { id: optionValue, label: optionLabel, value: optionValue };
+        return { id: optionValue, label: resourceLabel(resource), value: optionValue };
       })
       .filter((option): option is SelectOption => option !== null);
   }, [resources, useNameAsValue]);
@@ -180,13 +189,13 @@ export const IntegrationResourceFieldRenderer = ({
   // Single select mode
   if (!isMulti) {
     const options: AutoCompleteOption[] = (resources ?? [])
+      .filter((resource) => !resource.disabled)
       .map((resource) => {
         const optionValue = useNameAsValue
           ? (resource.name ?? resource.id ?? "")
           : (resource.id ?? resource.name ?? "");
-        const optionLabel = resource.name ?? resource.id ?? "Unnamed resource";
         if (!optionValue) return null;
-        return { value: optionValue, label: optionLabel };
+        return { value: optionValue, label: resourceLabel(resource), group: resource.group || undefined };
       })
       .filter((option): option is AutoCompleteOption => option !== null);
 
@@ -28,6 +28,8 @@ import { SecretKeyFieldRenderer, type SecretKeyRefValue } from "./SecretKeyField
 import { AnyPredicateListFieldRenderer } from "./AnyPredicateListFieldRenderer";
 import { DaysOfWeekFieldRenderer } from "./DaysOfWeekFieldRenderer";
 import { TimeRangeFieldRenderer } from "./TimeRangeFieldRenderer";
+import { FileFieldRenderer } from "./FileFieldRenderer";
+import { DurationFieldRenderer } from "./DurationFieldRenderer";
 import {
   isFieldVisible,
   isFieldRequired,
@@ -289,6 +291,9 @@ export const ConfigurationFieldRenderer = ({
       case "cron":
         return <CronFieldRenderer {...commonProps} />;
 
+      case "duration":
+        return <DurationFieldRenderer {...commonProps} />;
+
       case "integration-resource":
         return (
           <IntegrationResourceFieldRenderer
@@ -308,6 +313,13 @@ export const ConfigurationFieldRenderer = ({
       case "git-ref":
         return <GitRefFieldRenderer {...commonProps} />;
 
+      case "secret":
+        // Secret values come back encrypted, so they are never shown or used in expressions.
+        return <StringFieldRenderer {...commonProps} field={{ ...field, sensitive: true, disallowExpression: true }} />;
+
+      case "file":
+        return <FileFieldRenderer {...commonProps} />;
+
       case "user":
         if (!domainId) {
           return <div className="text-sm text-red-500 dark:text-red-400">User field requires domainId prop</div>;